@@ -13,12 +13,14 @@ import (
 func agentCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "agent",
-		Short: "Manage agents — add, list, delete",
+		Short: "Manage agents — add, list, delete, status, files",
 	}
 	cmd.AddCommand(agentListCmd())
 	cmd.AddCommand(agentAddCmd())
 	cmd.AddCommand(agentDeleteCmd())
+	cmd.AddCommand(agentStatusCmd())
 	cmd.AddCommand(agentChatCmd())
+	cmd.AddCommand(agentFilesCmd())
 	return cmd
 }
 
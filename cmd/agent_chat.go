@@ -55,11 +55,7 @@ func runAgentChat(agentName, message, sessionKey string) {
 	}
 
 	// Try client mode first (connect to running gateway)
-	host := cfg.Gateway.Host
-	if host == "0.0.0.0" {
-		host = "127.0.0.1"
-	}
-	addr := fmt.Sprintf("%s:%d", host, cfg.Gateway.Port)
+	addr := gatewayLocalAddr(cfg)
 
 	if !isGatewayRunning(addr) {
 		fmt.Fprintln(os.Stderr, "Error: the gateway must be running for this command.")
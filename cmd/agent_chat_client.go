@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -47,7 +48,7 @@ func runClientMode(cfg *config.Config, addr, agentName, message, sessionKey stri
 	// Interactive REPL
 	fmt.Fprintf(os.Stderr, "\nGoClaw Interactive Chat (agent: %s, model: %s)\n", agentName, agentCfg.Model)
 	fmt.Fprintf(os.Stderr, "Session: %s\n", sessionKey)
-	fmt.Fprintf(os.Stderr, "Type \"exit\" to quit, \"/new\" for new session\n\n")
+	fmt.Fprintf(os.Stderr, "Type \"exit\" to quit, \"/new\" for new session, \"/rewind [id]\" to roll back\n\n")
 
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
@@ -68,6 +69,10 @@ func runClientMode(cfg *config.Config, addr, agentName, message, sessionKey stri
 			fmt.Fprintf(os.Stderr, "New session: %s\n\n", sessionKey)
 			continue
 		}
+		if arg, ok := strings.CutPrefix(input, "/rewind"); ok && (arg == "" || arg[0] == ' ') {
+			wsRewind(conn, sessionKey, strings.TrimSpace(arg))
+			continue
+		}
 
 		resp, err := wsChatSend(conn, agentName, sessionKey, input)
 		if err != nil {
@@ -114,6 +119,64 @@ func wsConnect(conn *websocket.Conn, token string) error {
 	return nil
 }
 
+// wsRewind lists the session's checkpoints (empty arg) or rewinds to one.
+func wsRewind(conn *websocket.Conn, sessionKey, arg string) {
+	method := protocol.MethodSessionsCheckpoints
+	params := map[string]any{"key": sessionKey}
+	if arg != "" {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid checkpoint %q\n\n", arg)
+			return
+		}
+		method = protocol.MethodSessionsRewind
+		params["checkpoint"] = id
+	}
+	resp, err := wsRequest(conn, method, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		return
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n\n", resp.Error.Message)
+		return
+	}
+	if arg == "" {
+		printCheckpoints(resp.Payload)
+	} else {
+		printRewindResult(resp.Payload)
+	}
+	fmt.Println()
+}
+
+// wsRequest sends an RPC and waits for its response, ignoring events.
+func wsRequest(conn *websocket.Conn, method string, params any) (*protocol.ResponseFrame, error) {
+	reqID := uuid.NewString()[:8]
+	paramsJSON, _ := json.Marshal(params)
+	if err := conn.WriteJSON(protocol.RequestFrame{
+		Type:   protocol.FrameTypeRequest,
+		ID:     reqID,
+		Method: method,
+		Params: paramsJSON,
+	}); err != nil {
+		return nil, fmt.Errorf("send %s: %w", method, err)
+	}
+	for {
+		_, rawMsg, err := conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		if frameType, _ := protocol.ParseFrameType(rawMsg); frameType != protocol.FrameTypeResponse {
+			continue
+		}
+		var resp protocol.ResponseFrame
+		if err := json.Unmarshal(rawMsg, &resp); err != nil || resp.ID != reqID {
+			continue
+		}
+		return &resp, nil
+	}
+}
+
 // wsChatSend sends a chat.send RPC and waits for the response,
 // displaying events (tool calls, chunks) in real-time.
 func wsChatSend(conn *websocket.Conn, agentID, sessionKey, message string) (string, error) {
@@ -199,6 +262,12 @@ func handleCLIEvent(evt protocol.EventFrame) {
 				}
 				fmt.Fprintf(os.Stderr, "  [tool] %s\n", name)
 			}
+		case protocol.AgentEventToolProgress:
+			if p, ok := payload["payload"].(map[string]any); ok {
+				name, _ := p["name"].(string)
+				message, _ := p["message"].(string)
+				fmt.Fprintf(os.Stderr, "  [tool] %s: %s\n", name, message)
+			}
 		case protocol.AgentEventToolResult:
 			if p, ok := payload["payload"].(map[string]any); ok {
 				isErr, _ := p["is_error"].(bool)
@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func agentFilesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "files",
+		Short: "View and edit agent context files (SOUL.md, IDENTITY.md, ...)",
+		Long: `View and edit agent context files.
+
+Uses the running gateway (agents.files.* RPCs) when it is reachable, so edits
+land in the database in managed mode. Without a gateway or database
+(standalone mode), files are read and written in the agent's workspace.
+
+Editable files: ` + strings.Join(bootstrap.EditableFiles, ", "),
+	}
+	cmd.AddCommand(agentFilesListCmd())
+	cmd.AddCommand(agentFilesGetCmd())
+	cmd.AddCommand(agentFilesSetCmd())
+	cmd.AddCommand(agentFilesEditCmd())
+	return cmd
+}
+
+func agentFilesListCmd() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "list <agent>",
+		Short: "List an agent's context files",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			fs := openAgentFiles()
+			defer fs.Close()
+			files, err := fs.List(args[0])
+			exitOnErr(err)
+			if jsonOutput {
+				data, _ := json.MarshalIndent(files, "", "  ")
+				fmt.Println(string(data))
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "FILE\tSIZE")
+			for _, f := range files {
+				size := "-"
+				if !f.Missing {
+					size = fmt.Sprintf("%d", f.Size)
+				}
+				fmt.Fprintf(w, "%s\t%s\n", f.Name, size)
+			}
+			w.Flush()
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func agentFilesGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <agent> <file>",
+		Short: "Print a context file to stdout",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			fs := openAgentFiles()
+			defer fs.Close()
+			f, err := fs.Get(args[0], args[1])
+			exitOnErr(err)
+			if f.Missing {
+				fmt.Fprintf(os.Stderr, "%s is not set for agent %s\n", args[1], args[0])
+				os.Exit(1)
+			}
+			fmt.Print(f.Content)
+		},
+	}
+}
+
+func agentFilesSetCmd() *cobra.Command {
+	var from string
+	var propagate bool
+	cmd := &cobra.Command{
+		Use:   "set <agent> <file>",
+		Short: "Replace a context file from --from or stdin",
+		Example: `  goclaw agent files set coder SOUL.md --from ./SOUL.md
+  echo "Name: Ada" | goclaw agent files set coder IDENTITY.md`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var data []byte
+			var err error
+			if from == "" || from == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(from)
+			}
+			exitOnErr(err)
+
+			fs := openAgentFiles()
+			defer fs.Close()
+			exitOnErr(fs.Set(args[0], args[1], string(data), propagate))
+			fmt.Fprintf(os.Stderr, "Updated %s for agent %s (%d bytes)\n", args[1], args[0], len(data))
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", "", "read content from this file (default: stdin)")
+	cmd.Flags().BoolVar(&propagate, "propagate", false, "also push the change to existing per-user instances (managed mode)")
+	return cmd
+}
+
+func agentFilesEditCmd() *cobra.Command {
+	var propagate bool
+	cmd := &cobra.Command{
+		Use:   "edit <agent> <file>",
+		Short: "Open a context file in $VISUAL / $EDITOR and save changes",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			agentID, name := args[0], args[1]
+			fs := openAgentFiles()
+			defer fs.Close()
+			f, err := fs.Get(agentID, name)
+			exitOnErr(err)
+
+			edited, err := editInEditor(name, f.Content)
+			exitOnErr(err)
+			if edited == f.Content {
+				fmt.Fprintln(os.Stderr, "No changes.")
+				return
+			}
+			exitOnErr(fs.Set(agentID, name, edited, propagate))
+			fmt.Fprintf(os.Stderr, "Updated %s for agent %s\n", name, agentID)
+		},
+	}
+	cmd.Flags().BoolVar(&propagate, "propagate", false, "also push the change to existing per-user instances (managed mode)")
+	return cmd
+}
+
+// editInEditor writes content to a temp file named after the context file,
+// opens it in the user's editor and returns the saved content.
+func editInEditor(name, content string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	dir, err := os.MkdirTemp("", "goclaw-agent-file-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return "", err
+	}
+
+	// The editor value may carry arguments (e.g. "code --wait").
+	parts := strings.Fields(editor)
+	c := exec.Command(parts[0], append(parts[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor %s: %w", parts[0], err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// agentFileInfo mirrors the file entries returned by agents.files.* RPCs.
+type agentFileInfo struct {
+	Name    string `json:"name"`
+	Missing bool   `json:"missing"`
+	Size    int    `json:"size,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// agentFiles reads and writes agent context files through the gateway when
+// conn is set, otherwise directly in the agent workspace (standalone mode).
+type agentFiles struct {
+	cfg  *config.Config
+	conn *websocket.Conn
+}
+
+// openAgentFiles connects to the running gateway, falling back to direct
+// workspace access when it is down and no database is configured. Exits when
+// the gateway is down in managed mode, since files live in the database.
+func openAgentFiles() *agentFiles {
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	addr := gatewayLocalAddr(cfg)
+	if !isGatewayRunning(addr) {
+		if cfg.Database.PostgresDSN != "" || cfg.Database.StorageBackend == "sqlite" {
+			fmt.Fprintln(os.Stderr, "Error: agent files are stored in the database; the gateway must be running.")
+			fmt.Fprintln(os.Stderr, "Start it first:  goclaw")
+			os.Exit(1)
+		}
+		return &agentFiles{cfg: cfg}
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.Dial("ws://"+addr+"/ws", nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WebSocket connect failed: %v\n", err)
+		os.Exit(1)
+	}
+	if err := wsConnect(conn, resolveGatewayToken()); err != nil {
+		conn.Close()
+		fmt.Fprintf(os.Stderr, "Gateway auth failed: %v\n", err)
+		os.Exit(1)
+	}
+	return &agentFiles{cfg: cfg, conn: conn}
+}
+
+func (a *agentFiles) Close() {
+	if a.conn != nil {
+		a.conn.Close()
+	}
+}
+
+func (a *agentFiles) List(agentID string) ([]agentFileInfo, error) {
+	if a.conn == nil {
+		ws := a.cfg.AgentWorkspacePath(agentID)
+		files := make([]agentFileInfo, 0, len(bootstrap.EditableFiles))
+		for _, name := range bootstrap.EditableFiles {
+			f, _ := bootstrap.ReadWorkspaceFile(ws, name)
+			files = append(files, agentFileInfo{Name: name, Missing: f.Missing, Size: len(f.Content)})
+		}
+		return files, nil
+	}
+	var payload struct {
+		Files []agentFileInfo `json:"files"`
+	}
+	err := a.call(protocol.MethodAgentsFileList, map[string]any{"agentId": agentID}, &payload)
+	return payload.Files, err
+}
+
+func (a *agentFiles) Get(agentID, name string) (agentFileInfo, error) {
+	if a.conn == nil {
+		f, err := bootstrap.ReadWorkspaceFile(a.cfg.AgentWorkspacePath(agentID), name)
+		return agentFileInfo{Name: name, Missing: f.Missing, Size: len(f.Content), Content: f.Content}, err
+	}
+	var payload struct {
+		File agentFileInfo `json:"file"`
+	}
+	err := a.call(protocol.MethodAgentsFileGet, map[string]any{"agentId": agentID, "name": name}, &payload)
+	return payload.File, err
+}
+
+func (a *agentFiles) Set(agentID, name, content string, propagate bool) error {
+	if a.conn == nil {
+		return bootstrap.WriteWorkspaceFile(a.cfg.AgentWorkspacePath(agentID), name, content)
+	}
+	return a.call(protocol.MethodAgentsFileSet, map[string]any{
+		"agentId":   agentID,
+		"name":      name,
+		"content":   content,
+		"propagate": propagate,
+	}, nil)
+}
+
+// call runs an RPC and decodes its payload into out (if non-nil).
+func (a *agentFiles) call(method string, params, out any) error {
+	resp, err := wsRequest(a.conn, method, params)
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		if resp.Error != nil {
+			return errors.New(resp.Error.Message)
+		}
+		return fmt.Errorf("%s failed", method)
+	}
+	if out == nil {
+		return nil
+	}
+	raw, err := json.Marshal(resp.Payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func exitOnErr(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
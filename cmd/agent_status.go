@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
+)
+
+func agentStatusCmd() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "status <agent>",
+		Short: "Show an agent's health, usage and schedules (requires running gateway)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			requireRunningGatewayHTTP()
+			runAgentStatus(args[0], jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func runAgentStatus(agent string, jsonOutput bool) {
+	card, err := gatewayHTTPGetTyped[httpapi.AgentStatusCard]("/v1/agents/" + url.PathEscape(agent) + "/status")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(card, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmtTime := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return t.Local().Format(time.DateTime)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Agent:\t%s (%s)\n", card.AgentKey, card.Status)
+	fmt.Fprintf(tw, "Model:\t%s / %s\n", card.Provider, card.Model)
+	if card.Queue != nil {
+		fmt.Fprintf(tw, "Queue:\t%d running, %d queued\n", card.Queue.Active, card.Queue.Queued)
+	}
+	if card.LastRun != nil {
+		fmt.Fprintf(tw, "Last run:\t%s %s\n", fmtTime(&card.LastRun.StartedAt), card.LastRun.Status)
+	} else {
+		fmt.Fprintf(tw, "Last run:\tnever\n")
+	}
+	if hb := card.Heartbeat; hb != nil {
+		line := "disabled"
+		if hb.Enabled {
+			line = "next " + fmtTime(hb.NextRunAt)
+		}
+		if hb.SnoozedUntil != nil {
+			line += ", alerts snoozed until " + fmtTime(hb.SnoozedUntil)
+		}
+		fmt.Fprintf(tw, "Heartbeat:\t%s\n", line)
+	}
+	if c := card.NextCron; c != nil {
+		fmt.Fprintf(tw, "Next cron:\t%s (%s)\n", fmtTime(&c.NextRunAt), c.Name)
+	}
+	if m := card.Memory; m != nil {
+		fmt.Fprintf(tw, "Memory:\t%d documents, %d chunks\n", m.Documents, m.Chunks)
+	}
+	if b := card.Budget; b != nil {
+		if b.MonthlyCents != nil && *b.MonthlyCents > 0 {
+			fmt.Fprintf(tw, "Budget:\t$%.2f of $%.2f (%.1f%%)\n", float64(b.SpentCents)/100, float64(*b.MonthlyCents)/100, b.UsedPercent)
+		} else {
+			fmt.Fprintf(tw, "Spend:\t$%.2f this month (no budget)\n", float64(b.SpentCents)/100)
+		}
+	}
+	tw.Flush()
+
+	if len(card.RecentErrors) > 0 {
+		fmt.Println("\nRecent errors:")
+		for _, e := range card.RecentErrors {
+			msg := e.Error
+			if len(msg) > 80 {
+				msg = msg[:77] + "..."
+			}
+			fmt.Printf("  %s  %s\n", e.StartedAt.Local().Format(time.DateTime), msg)
+		}
+	}
+}
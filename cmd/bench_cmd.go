@@ -0,0 +1,498 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// benchFakeAgent is the throwaway agent created by `bench --fake-provider`.
+const benchFakeAgent = "bench-fake"
+
+type benchOptions struct {
+	sessions     int
+	rps          float64
+	duration     time.Duration
+	agent        string
+	message      string
+	fakeProvider bool
+	jsonOutput   bool
+}
+
+func benchCmd() *cobra.Command {
+	var opts benchOptions
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Load-test a running gateway over the WebSocket protocol",
+		Long: `Drive the gateway with synthetic conversations and report end-to-end latency,
+scheduler lane saturation and memory usage.
+
+With --fake-provider the bench creates a temporary agent backed by the "fake"
+echo provider so no LLM calls are made. The gateway must be started with
+GOCLAW_FAKE_PROVIDER=1 (or a latency such as GOCLAW_FAKE_PROVIDER=500ms).`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runBench(opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.sessions, "sessions", 50, "number of concurrent WebSocket sessions")
+	cmd.Flags().Float64Var(&opts.rps, "rps", 10, "global request rate (messages per second)")
+	cmd.Flags().DurationVar(&opts.duration, "duration", time.Minute, "how long to generate load")
+	cmd.Flags().StringVar(&opts.agent, "agent", "default", "agent key to target")
+	cmd.Flags().StringVar(&opts.message, "message", "Hello, this is a load test message.", "message body sent on each turn")
+	cmd.Flags().BoolVar(&opts.fakeProvider, "fake-provider", false, "use a temporary agent backed by the fake echo provider")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "output report as JSON")
+	return cmd
+}
+
+// benchReport is the final summary printed by `goclaw bench`.
+type benchReport struct {
+	Sessions    int                `json:"sessions"`
+	TargetRPS   float64            `json:"targetRps"`
+	DurationSec float64            `json:"durationSec"`
+	Sent        int64              `json:"sent"`
+	Succeeded   int64              `json:"succeeded"`
+	Failed      int64              `json:"failed"`
+	Dropped     int64              `json:"dropped"` // ticks skipped because every session was busy
+	AchievedRPS float64            `json:"achievedRps"`
+	LatencyMs   benchLatency       `json:"latencyMs"`
+	Lanes       []benchLaneSummary `json:"lanes,omitempty"`
+	PeakHeapMB  float64            `json:"peakHeapMb"`
+	PeakSysMB   float64            `json:"peakSysMb"`
+	PeakGorout  int                `json:"peakGoroutines"`
+	Errors      map[string]int     `json:"errors,omitempty"`
+}
+
+type benchLatency struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+type benchLaneSummary struct {
+	Name           string  `json:"name"`
+	Concurrency    int     `json:"concurrency"`
+	PeakActive     int     `json:"peakActive"`
+	PeakPending    int     `json:"peakPending"`
+	PeakSaturation float64 `json:"peakSaturation"` // peakActive / concurrency
+	AvgWaitMs      float64 `json:"avgWaitMs"`
+	MaxWaitMs      float64 `json:"maxWaitMs"`
+}
+
+// benchCollector aggregates results from all session workers.
+type benchCollector struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	errors    map[string]int
+
+	sent, ok, failed, dropped atomic.Int64
+}
+
+func (c *benchCollector) record(d time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.failed.Add(1)
+		c.errors[err.Error()]++
+		return
+	}
+	c.ok.Add(1)
+	c.latencies = append(c.latencies, d)
+}
+
+func runBench(opts benchOptions) {
+	if opts.sessions <= 0 || opts.rps <= 0 {
+		fmt.Fprintln(os.Stderr, "Error: --sessions and --rps must be positive")
+		os.Exit(1)
+	}
+	requireGateway()
+
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	addr := gatewayLocalAddr(cfg)
+	token := resolveGatewayToken()
+
+	agentKey := opts.agent
+	if opts.fakeProvider {
+		if err := benchCreateFakeAgent(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating fake agent: %v\n", err)
+			fmt.Fprintln(os.Stderr, "Hint: start the gateway with GOCLAW_FAKE_PROVIDER=1")
+			os.Exit(1)
+		}
+		defer benchDeleteFakeAgent()
+		agentKey = benchFakeAgent
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Open all sessions up front so connect cost isn't counted as latency.
+	conns := make([]*websocket.Conn, 0, opts.sessions)
+	for i := 0; i < opts.sessions; i++ {
+		conn, err := benchDial(addr, token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening session %d: %v\n", i, err)
+			break
+		}
+		conns = append(conns, conn)
+	}
+	defer func() {
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+	if len(conns) == 0 {
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "bench: %d sessions, %.1f rps, %s against %s (agent %s)\n",
+		len(conns), opts.rps, opts.duration, addr, agentKey)
+
+	sampler := newBenchSampler()
+	samplerConn, err := benchDial(addr, token)
+	if err == nil {
+		defer samplerConn.Close()
+	}
+
+	col := &benchCollector{errors: make(map[string]int)}
+	loadCtx, stopLoad := context.WithTimeout(ctx, opts.duration)
+	defer stopLoad()
+
+	// Ticks are handed to whichever session is idle; a tick that finds every
+	// session busy is counted as dropped rather than queued, so the offered
+	// rate stays honest.
+	ticks := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, conn := range conns {
+		wg.Add(1)
+		go func(conn *websocket.Conn, idx int) {
+			defer wg.Done()
+			sessionKey := sessions.BuildSessionKey(agentKey, "bench", sessions.PeerDirect, fmt.Sprintf("%d-%s", idx, uuid.NewString()[:8]))
+			for {
+				select {
+				case <-loadCtx.Done():
+					return
+				case <-ticks:
+				}
+				col.sent.Add(1)
+				start := time.Now()
+				err := benchChatSend(conn, agentKey, sessionKey, opts.message)
+				col.record(time.Since(start), err)
+			}
+		}(conn, i)
+	}
+
+	startedAt := time.Now()
+	samplerDone := make(chan struct{})
+	go func() {
+		defer close(samplerDone)
+		sampler.run(loadCtx, samplerConn)
+	}()
+
+	interval := max(time.Duration(float64(time.Second)/opts.rps), time.Millisecond)
+	ticker := time.NewTicker(interval)
+loop:
+	for {
+		select {
+		case <-loadCtx.Done():
+			break loop
+		case <-ticker.C:
+			select {
+			case ticks <- struct{}{}:
+			default:
+				col.dropped.Add(1)
+			}
+		}
+	}
+	ticker.Stop()
+	wg.Wait()
+	elapsed := time.Since(startedAt)
+
+	// Final sample picks up lane wait stats accumulated during the run.
+	<-samplerDone
+	if samplerConn != nil {
+		sampler.sample(samplerConn)
+	}
+
+	report := col.report(opts, len(conns), elapsed)
+	sampler.fill(&report)
+	printBenchReport(report, opts.jsonOutput)
+}
+
+func (c *benchCollector) report(opts benchOptions, sessionCount int, elapsed time.Duration) benchReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r := benchReport{
+		Sessions:    sessionCount,
+		TargetRPS:   opts.rps,
+		DurationSec: elapsed.Seconds(),
+		Sent:        c.sent.Load(),
+		Succeeded:   c.ok.Load(),
+		Failed:      c.failed.Load(),
+		Dropped:     c.dropped.Load(),
+		Errors:      c.errors,
+	}
+	if elapsed > 0 {
+		r.AchievedRPS = float64(r.Succeeded) / elapsed.Seconds()
+	}
+	slices.Sort(c.latencies)
+	r.LatencyMs = benchLatency{
+		P50: benchPercentileMs(c.latencies, 0.50),
+		P95: benchPercentileMs(c.latencies, 0.95),
+		P99: benchPercentileMs(c.latencies, 0.99),
+	}
+	if n := len(c.latencies); n > 0 {
+		r.LatencyMs.Max = float64(c.latencies[n-1]) / float64(time.Millisecond)
+	}
+	return r
+}
+
+// benchPercentileMs returns the nearest-rank percentile of sorted durations in ms.
+func benchPercentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(float64(len(sorted))*p+0.5) - 1
+	idx = max(0, min(idx, len(sorted)-1))
+	return float64(sorted[idx]) / float64(time.Millisecond)
+}
+
+// --- gateway sampling (health RPC) ---
+
+type benchSampler struct {
+	mu        sync.Mutex
+	lanes     map[string]*benchLaneSummary
+	peakHeap  uint64
+	peakSys   uint64
+	peakGorou int
+}
+
+func newBenchSampler() *benchSampler {
+	return &benchSampler{lanes: make(map[string]*benchLaneSummary)}
+}
+
+func (s *benchSampler) run(ctx context.Context, conn *websocket.Conn) {
+	if conn == nil {
+		return
+	}
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.sample(conn)
+		}
+	}
+}
+
+func (s *benchSampler) sample(conn *websocket.Conn) {
+	resp, err := benchCall(conn, protocol.MethodHealth, nil, 5*time.Second)
+	if err != nil || !resp.OK {
+		return
+	}
+	raw, _ := json.Marshal(resp.Payload)
+	var health struct {
+		Lanes  []scheduler.LaneStats `json:"lanes"`
+		Memory struct {
+			HeapAllocBytes uint64 `json:"heapAllocBytes"`
+			SysBytes       uint64 `json:"sysBytes"`
+			Goroutines     int    `json:"goroutines"`
+		} `json:"memory"`
+	}
+	if json.Unmarshal(raw, &health) != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peakHeap = max(s.peakHeap, health.Memory.HeapAllocBytes)
+	s.peakSys = max(s.peakSys, health.Memory.SysBytes)
+	s.peakGorou = max(s.peakGorou, health.Memory.Goroutines)
+	for _, ls := range health.Lanes {
+		sum, ok := s.lanes[ls.Name]
+		if !ok {
+			sum = &benchLaneSummary{Name: ls.Name}
+			s.lanes[ls.Name] = sum
+		}
+		sum.Concurrency = ls.Concurrency
+		sum.PeakActive = max(sum.PeakActive, ls.Active)
+		sum.PeakPending = max(sum.PeakPending, ls.Pending)
+		if ls.Concurrency > 0 {
+			sum.PeakSaturation = float64(sum.PeakActive) / float64(ls.Concurrency)
+		}
+		sum.AvgWaitMs = ls.AvgWaitMs
+		sum.MaxWaitMs = ls.MaxWaitMs
+	}
+}
+
+func (s *benchSampler) fill(r *benchReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	const mb = 1 << 20
+	r.PeakHeapMB = float64(s.peakHeap) / mb
+	r.PeakSysMB = float64(s.peakSys) / mb
+	r.PeakGorout = s.peakGorou
+	for _, l := range s.lanes {
+		r.Lanes = append(r.Lanes, *l)
+	}
+	slices.SortFunc(r.Lanes, func(a, b benchLaneSummary) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+}
+
+// --- WS helpers ---
+
+// benchDial opens a WebSocket session and authenticates it.
+func benchDial(addr, token string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://%s/ws", addr), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := wsConnect(conn, token); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// benchCall sends one RPC and waits for its response, skipping events.
+func benchCall(conn *websocket.Conn, method string, params any, timeout time.Duration) (*protocol.ResponseFrame, error) {
+	var raw json.RawMessage
+	if params != nil {
+		raw, _ = json.Marshal(params)
+	}
+	reqID := uuid.NewString()[:8]
+	if err := conn.WriteJSON(protocol.RequestFrame{
+		Type:   protocol.FrameTypeRequest,
+		ID:     reqID,
+		Method: method,
+		Params: raw,
+	}); err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		if ft, _ := protocol.ParseFrameType(msg); ft != protocol.FrameTypeResponse {
+			continue
+		}
+		var resp protocol.ResponseFrame
+		if err := json.Unmarshal(msg, &resp); err != nil {
+			return nil, fmt.Errorf("parse response: %w", err)
+		}
+		if resp.ID == reqID {
+			return &resp, nil
+		}
+	}
+}
+
+// benchChatSend runs one non-streaming chat turn and returns when the final response arrives.
+func benchChatSend(conn *websocket.Conn, agentKey, sessionKey, message string) error {
+	resp, err := benchCall(conn, protocol.MethodChatSend, map[string]any{
+		"message":    message,
+		"agentId":    agentKey,
+		"sessionKey": sessionKey,
+	}, 5*time.Minute)
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		if resp.Error != nil {
+			return fmt.Errorf("%s", resp.Error.Message)
+		}
+		return fmt.Errorf("chat.send failed")
+	}
+	return nil
+}
+
+func benchCreateFakeAgent() error {
+	// Clear a leftover agent from an interrupted run so create starts clean.
+	delParams, _ := json.Marshal(map[string]any{"agentId": benchFakeAgent})
+	_, _ = gatewayRPC(protocol.MethodAgentsDelete, delParams)
+
+	params, _ := json.Marshal(map[string]any{
+		"name":     benchFakeAgent,
+		"provider": "fake",
+		"model":    "fake-echo",
+	})
+	resp, err := gatewayRPC(protocol.MethodAgentsCreate, params)
+	if err != nil {
+		return err
+	}
+	if !resp.OK {
+		if resp.Error != nil {
+			return fmt.Errorf("%s", resp.Error.Message)
+		}
+		return fmt.Errorf("agents.create failed")
+	}
+	return nil
+}
+
+func benchDeleteFakeAgent() {
+	params, _ := json.Marshal(map[string]any{"agentId": benchFakeAgent})
+	if resp, err := gatewayRPC(protocol.MethodAgentsDelete, params); err != nil || !resp.OK {
+		fmt.Fprintf(os.Stderr, "Warning: failed to delete temporary agent %q\n", benchFakeAgent)
+	}
+}
+
+func printBenchReport(r benchReport, jsonOutput bool) {
+	if jsonOutput {
+		data, _ := json.MarshalIndent(r, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("\nRequests:   sent=%d ok=%d failed=%d dropped=%d\n", r.Sent, r.Succeeded, r.Failed, r.Dropped)
+	fmt.Printf("Throughput: %.2f rps (target %.2f) over %.1fs, %d sessions\n", r.AchievedRPS, r.TargetRPS, r.DurationSec, r.Sessions)
+	fmt.Printf("Latency:    p50=%.0fms p95=%.0fms p99=%.0fms max=%.0fms\n", r.LatencyMs.P50, r.LatencyMs.P95, r.LatencyMs.P99, r.LatencyMs.Max)
+	fmt.Printf("Memory:     peak heap=%.1fMB sys=%.1fMB goroutines=%d\n", r.PeakHeapMB, r.PeakSysMB, r.PeakGorout)
+
+	if len(r.Lanes) > 0 {
+		fmt.Println("\nScheduler lanes:")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "LANE\tCONCURRENCY\tPEAK ACTIVE\tPEAK PENDING\tSATURATION\tAVG WAIT\tMAX WAIT")
+		for _, l := range r.Lanes {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.0f%%\t%.1fms\t%.1fms\n",
+				l.Name, l.Concurrency, l.PeakActive, l.PeakPending, l.PeakSaturation*100, l.AvgWaitMs, l.MaxWaitMs)
+		}
+		tw.Flush()
+	}
+
+	if len(r.Errors) > 0 {
+		fmt.Println("\nErrors:")
+		for msg, n := range r.Errors {
+			fmt.Printf("  %4d  %s\n", n, msg)
+		}
+	}
+}
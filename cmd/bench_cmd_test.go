@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBenchPercentileMs(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	cases := []struct {
+		p    float64
+		want float64
+	}{
+		{0.50, 50},
+		{0.95, 95},
+		{0.99, 99},
+		{1.00, 100},
+	}
+	for _, tc := range cases {
+		if got := benchPercentileMs(sorted, tc.p); got != tc.want {
+			t.Errorf("p%.0f = %.0f, want %.0f", tc.p*100, got, tc.want)
+		}
+	}
+
+	if got := benchPercentileMs(nil, 0.95); got != 0 {
+		t.Errorf("empty input = %.0f, want 0", got)
+	}
+}
+
+func TestBenchCollectorReport(t *testing.T) {
+	col := &benchCollector{errors: make(map[string]int)}
+	col.sent.Add(3)
+	col.record(30*time.Millisecond, nil)
+	col.record(10*time.Millisecond, nil)
+	col.record(0, errTestBench)
+
+	r := col.report(benchOptions{rps: 5}, 2, 2*time.Second)
+	if r.Succeeded != 2 || r.Failed != 1 {
+		t.Fatalf("succeeded/failed = %d/%d, want 2/1", r.Succeeded, r.Failed)
+	}
+	if r.LatencyMs.Max != 30 {
+		t.Errorf("max latency = %.0f, want 30", r.LatencyMs.Max)
+	}
+	if r.AchievedRPS != 1 {
+		t.Errorf("achieved rps = %.2f, want 1", r.AchievedRPS)
+	}
+	if r.Errors[errTestBench.Error()] != 1 {
+		t.Errorf("errors = %v, want one %q", r.Errors, errTestBench)
+	}
+}
+
+var errTestBench = benchTestError("agent timeout")
+
+type benchTestError string
+
+func (e benchTestError) Error() string { return string(e) }
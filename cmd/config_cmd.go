@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -18,6 +19,7 @@ func configCmd() *cobra.Command {
 	cmd.AddCommand(configShowCmd())
 	cmd.AddCommand(configPathCmd())
 	cmd.AddCommand(configValidateCmd())
+	cmd.AddCommand(configSchemaCmd())
 	return cmd
 }
 
@@ -52,21 +54,84 @@ func configPathCmd() *cobra.Command {
 }
 
 func configValidateCmd() *cobra.Command {
-	return &cobra.Command{
+	var jsonOut bool
+	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate configuration file",
+		Long: `Check the config file with env overrides applied: provider credentials,
+port conflicts, channel token formats, bindings, cron and heartbeat settings,
+and workspace writability. Exits 1 when any error is found; warnings alone
+do not fail. The gateway runs the same checks at startup.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfgPath := resolveConfigPath()
-			_, err := config.Load(cfgPath)
+			cfg, err := config.Load(cfgPath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Invalid config: %s\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("Config at %s is valid.\n", cfgPath)
+			issues := append(cfg.ValidateProviders(), cfg.Validate()...)
+			failed := config.HasValidationErrors(issues)
+
+			if jsonOut {
+				if issues == nil {
+					issues = []config.ValidationIssue{}
+				}
+				data, _ := json.MarshalIndent(map[string]any{
+					"path":   cfgPath,
+					"valid":  !failed,
+					"issues": issues,
+				}, "", "  ")
+				fmt.Println(string(data))
+			} else {
+				for _, issue := range issues {
+					fmt.Printf("  %-8s %s: %s\n", issue.Severity, issue.Path, issue.Message)
+				}
+				if failed {
+					fmt.Fprintf(os.Stderr, "Config at %s has errors.\n", cfgPath)
+				} else {
+					fmt.Printf("Config at %s is valid.\n", cfgPath)
+				}
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+	return cmd
+}
+
+func configSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the config JSON schema (for editor completion)",
+		Long: `Print the JSON schema for config.json. Point your editor at it with
+"$schema": "` + config.SchemaURL + `"
+or a local copy written by: goclaw config schema > config.schema.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			data, _ := json.MarshalIndent(config.JSONSchema(), "", "  ")
+			fmt.Println(string(data))
 		},
 	}
 }
 
+// checkConfigAtStartup logs validation issues and exits on errors, so a
+// broken config fails fast with the offending key instead of mid-startup.
+func checkConfigAtStartup(cfg *config.Config) {
+	issues := cfg.Validate()
+	for _, issue := range issues {
+		if issue.Severity == config.SeverityError {
+			slog.Error("config: "+issue.Message, "key", issue.Path)
+		} else {
+			slog.Warn("config: "+issue.Message, "key", issue.Path)
+		}
+	}
+	if config.HasValidationErrors(issues) {
+		slog.Error("config has errors; fix them or run `goclaw config validate` for details")
+		os.Exit(1)
+	}
+}
+
 // redactConfig returns a JSON-safe copy with secrets masked.
 func redactConfig(cfg *config.Config) any {
 	data, _ := json.Marshal(cfg)
@@ -81,6 +146,7 @@ func redactMap(m map[string]any) {
 		"apiKey": true, "api_key": true, "token": true,
 		"botToken": true, "bot_token": true, "secret": true,
 		"appSecret": true, "encryptKey": true, "verificationToken": true,
+		"cdp_token": true,
 	}
 	for k, v := range m {
 		if secretKeys[k] {
@@ -76,6 +76,7 @@ func runDoctor() {
 				fmt.Printf("    %-12s v%d (DIRTY — run: goclaw migrate force %d)\n", "Schema:", s.CurrentVersion, s.CurrentVersion-1)
 			} else if s.Compatible {
 				fmt.Printf("    %-12s v%d (up to date)\n", "Schema:", s.CurrentVersion)
+				printSchemaDrift(db, s.RequiredVersion)
 			} else if s.CurrentVersion > s.RequiredVersion {
 				fmt.Printf("    %-12s v%d (binary too old, requires v%d)\n", "Schema:", s.CurrentVersion, s.RequiredVersion)
 			} else {
@@ -130,6 +131,8 @@ func runDoctor() {
 	checkBinary("curl")
 	checkBinary("git")
 
+	checkOfflineMode(cfg, db)
+
 	// Workspace
 	fmt.Println()
 	ws := config.ExpandHome(cfg.Agents.Defaults.Workspace)
@@ -163,6 +166,30 @@ func checkChannel(name string, enabled, hasCredentials bool) {
 	fmt.Printf("    %-12s %s\n", name+":", status)
 }
 
+// printSchemaDrift lists tables/columns the migrations create but the database lacks.
+func printSchemaDrift(db *sql.DB, version uint) {
+	expected, err := upgrade.LoadExpectedSchema(resolveMigrationsDir(), version)
+	if err != nil {
+		fmt.Printf("    %-12s SKIPPED (migrations not found)\n", "Drift:")
+		return
+	}
+	r, err := upgrade.DetectDrift(context.Background(), db, expected)
+	switch {
+	case err != nil:
+		fmt.Printf("    %-12s CHECK FAILED (%s)\n", "Drift:", err)
+	case r.HasDrift():
+		fmt.Printf("    %-12s %d missing tables, %d missing columns\n", "Drift:", len(r.MissingTables), len(r.MissingColumns))
+		for _, t := range r.MissingTables {
+			fmt.Printf("    %-12s   table %s\n", "", t)
+		}
+		for _, c := range r.MissingColumns {
+			fmt.Printf("    %-12s   column %s\n", "", c)
+		}
+	default:
+		fmt.Printf("    %-12s none\n", "Drift:")
+	}
+}
+
 func checkDBChannels(db *sql.DB) {
 	rows, err := db.QueryContext(context.Background(),
 		"SELECT name, channel_type, enabled FROM channel_instances ORDER BY channel_type, name")
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// offlineReport collects endpoints that offline mode would block.
+// Violations are calls that will fail at runtime; warnings are components
+// whose traffic cannot be checked statically (channels, subprocess providers).
+type offlineReport struct {
+	allow      []string
+	violations int
+	warnings   int
+}
+
+// endpoint checks a configured URL against the offline allowlist.
+func (r *offlineReport) endpoint(label, rawURL string) {
+	u, err := url.Parse(config.DockerLocalhost(rawURL))
+	if err != nil || u.Host == "" {
+		r.violation(label, fmt.Sprintf("invalid endpoint %q", rawURL))
+		return
+	}
+	if !netproxy.OfflineAllowed(r.allow, u) {
+		r.violation(label, fmt.Sprintf("BLOCKED %s (add %s to offline_allow)", u.Host, u.Hostname()))
+		return
+	}
+	fmt.Printf("    %-24s OK (%s)\n", label+":", u.Host)
+}
+
+func (r *offlineReport) violation(label, msg string) {
+	r.violations++
+	fmt.Printf("    %-24s %s\n", label+":", msg)
+}
+
+func (r *offlineReport) warn(label, msg string) {
+	r.warnings++
+	fmt.Printf("    %-24s WARN %s\n", label+":", msg)
+}
+
+// checkOfflineMode validates the configuration against offline mode: every
+// provider, MCP server and browser endpoint must be loopback or allowlisted.
+func checkOfflineMode(cfg *config.Config, db *sql.DB) {
+	fmt.Println()
+	fmt.Println("  Offline Mode:")
+	if !cfg.Offline {
+		fmt.Printf("    %-12s disabled\n", "Status:")
+		return
+	}
+	fmt.Printf("    %-12s enabled\n", "Status:")
+	allowList := "(loopback only)"
+	if len(cfg.OfflineAllow) > 0 {
+		allowList = "loopback, " + strings.Join(cfg.OfflineAllow, ", ")
+	}
+	fmt.Printf("    %-12s %s\n", "Allowlist:", allowList)
+
+	r := &offlineReport{allow: cfg.OfflineAllow}
+
+	// Config providers: an API key without api_base means the cloud default.
+	for _, p := range []struct {
+		name string
+		pc   config.ProviderConfig
+	}{
+		{"anthropic", cfg.Providers.Anthropic}, {"openai", cfg.Providers.OpenAI},
+		{"openrouter", cfg.Providers.OpenRouter}, {"groq", cfg.Providers.Groq},
+		{"gemini", cfg.Providers.Gemini}, {"deepseek", cfg.Providers.DeepSeek},
+		{"mistral", cfg.Providers.Mistral}, {"xai", cfg.Providers.XAI},
+		{"minimax", cfg.Providers.MiniMax}, {"cohere", cfg.Providers.Cohere},
+		{"perplexity", cfg.Providers.Perplexity}, {"dashscope", cfg.Providers.DashScope},
+		{"bailian", cfg.Providers.Bailian}, {"zai", cfg.Providers.Zai},
+		{"zai_coding", cfg.Providers.ZaiCoding}, {"ollama_cloud", cfg.Providers.OllamaCloud},
+		{"novita", cfg.Providers.Novita}, {"byteplus", cfg.Providers.BytePlus},
+		{"byteplus_coding", cfg.Providers.BytePlusCoding},
+	} {
+		switch {
+		case p.pc.APIKey == "":
+		case p.pc.APIBase == "":
+			r.violation("provider "+p.name, "BLOCKED (cloud default endpoint; set api_base to a local server)")
+		default:
+			r.endpoint("provider "+p.name, p.pc.APIBase)
+		}
+	}
+	if cfg.Providers.Ollama.Host != "" {
+		r.endpoint("provider ollama", cfg.Providers.Ollama.Host)
+	}
+
+	for name, s := range cfg.Tools.McpServers {
+		if s.IsEnabled() && s.Transport != "stdio" {
+			r.endpoint("mcp "+name, s.URL)
+		}
+	}
+	if remote := cfg.Tools.Browser.RemoteEndpoint(); cfg.Tools.Browser.Enabled && remote != "" {
+		r.endpoint("browser remote", remote)
+	}
+	for _, ch := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"telegram", cfg.Channels.Telegram.Enabled}, {"discord", cfg.Channels.Discord.Enabled},
+		{"zalo", cfg.Channels.Zalo.Enabled}, {"feishu", cfg.Channels.Feishu.Enabled},
+		{"slack", cfg.Channels.Slack.Enabled}, {"whatsapp", cfg.Channels.WhatsApp.Enabled},
+	} {
+		if ch.enabled {
+			r.warn("channel "+ch.name, "platform API host must be allowlisted")
+		}
+	}
+	if cfg.Tts.Provider != "" {
+		r.warn("tts "+cfg.Tts.Provider, "cloud TTS API must be allowlisted")
+	}
+
+	if db != nil {
+		checkOfflineDBProviders(r, cfg, db)
+		checkOfflineDBMCPServers(r, db)
+		checkOfflineDBChannels(r, db)
+	}
+
+	fmt.Printf("    %-12s %d violation(s), %d warning(s)\n", "Result:", r.violations, r.warnings)
+}
+
+func checkOfflineDBProviders(r *offlineReport, cfg *config.Config, db *sql.DB) {
+	rows, err := db.QueryContext(context.Background(),
+		"SELECT name, provider_type, COALESCE(api_base, '') FROM llm_providers WHERE enabled ORDER BY name")
+	if err != nil {
+		fmt.Printf("    (could not query providers: %s)\n", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, providerType, apiBase string
+		if err := rows.Scan(&name, &providerType, &apiBase); err != nil {
+			continue
+		}
+		if apiBase == "" {
+			apiBase = cfg.Providers.APIBaseForType(providerType)
+		}
+		label := "provider " + name
+		switch {
+		case providerType == store.ProviderClaudeCLI || providerType == store.ProviderACP:
+			r.warn(label, "subprocess makes its own network calls (not enforced)")
+		case apiBase != "":
+			r.endpoint(label, apiBase)
+		case providerType == store.ProviderOllama:
+			r.endpoint(label, providers.NormalizeOllamaHost(cfg.Providers.Ollama.Host))
+		default:
+			r.violation(label, "BLOCKED (cloud default endpoint; set api_base to a local server)")
+		}
+	}
+}
+
+func checkOfflineDBMCPServers(r *offlineReport, db *sql.DB) {
+	rows, err := db.QueryContext(context.Background(),
+		"SELECT name, COALESCE(url, '') FROM mcp_servers WHERE enabled AND transport != 'stdio' ORDER BY name")
+	if err != nil {
+		fmt.Printf("    (could not query MCP servers: %s)\n", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, serverURL string
+		if err := rows.Scan(&name, &serverURL); err != nil {
+			continue
+		}
+		r.endpoint("mcp "+name, serverURL)
+	}
+}
+
+func checkOfflineDBChannels(r *offlineReport, db *sql.DB) {
+	rows, err := db.QueryContext(context.Background(),
+		"SELECT name, channel_type FROM channel_instances WHERE enabled ORDER BY channel_type, name")
+	if err != nil {
+		fmt.Printf("    (could not query channels: %s)\n", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, channelType string
+		if err := rows.Scan(&name, &channelType); err != nil {
+			continue
+		}
+		r.warn(fmt.Sprintf("channel %s/%s", channelType, name), "platform API host must be allowlisted")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
 	"github.com/nextlevelbuilder/goclaw/internal/bgalert"
 	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
@@ -18,6 +20,8 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/cache"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/consolidation"
+	"github.com/nextlevelbuilder/goclaw/internal/contextsync"
+	"github.com/nextlevelbuilder/goclaw/internal/dnd"
 	"github.com/nextlevelbuilder/goclaw/internal/eventbus"
 	kg "github.com/nextlevelbuilder/goclaw/internal/knowledgegraph"
 	"github.com/nextlevelbuilder/goclaw/internal/channels/discord"
@@ -37,7 +41,11 @@ import (
 	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
 	mcpbridge "github.com/nextlevelbuilder/goclaw/internal/mcp"
 	"github.com/nextlevelbuilder/goclaw/internal/media"
+	"github.com/nextlevelbuilder/goclaw/internal/memory"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/remotesync"
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -71,7 +79,7 @@ func runGateway() {
 		Level: logLevel,
 	})
 	logTee := gateway.NewLogTee(textHandler)
-	slog.SetDefault(slog.New(logTee))
+	slog.SetDefault(slog.New(requestid.NewLogHandler(logTee)))
 
 	// Load config
 	cfgPath := resolveConfigPath()
@@ -81,6 +89,7 @@ func runGateway() {
 		slog.Error("failed to load config", "error", err)
 		os.Exit(1)
 	}
+	checkConfigAtStartup(cfg)
 
 	// Edition override: explicit GOCLAW_EDITION takes precedence over auto-detection.
 	// Auto-detection happens later in setupStoresAndTracing (sqlite → lite).
@@ -97,6 +106,9 @@ func runGateway() {
 		}
 	}
 
+	// Resource profile sizes stores, caches and tools, so it precedes all of them.
+	configureRuntimeProfile(cfg)
+
 	// Create core components
 	msgBus := bus.New()
 
@@ -117,9 +129,16 @@ func runGateway() {
 	modelReg.RegisterResolver("anthropic", &providers.AnthropicForwardCompat{})
 	modelReg.RegisterResolver("openai", &providers.OpenAIForwardCompat{})
 
+	// Outbound proxy must be in place before any provider/channel client is built.
+	configureOutboundProxy(cfg)
+
+	// Crash reporting for recovered goroutine panics (no-op without a Sentry DSN).
+	defer configureCrashReporting(cfg)()
+
 	// Create provider registry
 	providerRegistry := providers.NewRegistry(store.TenantIDFromContext)
 	registerProviders(providerRegistry, cfg, modelReg)
+	configProviders := providerRegistry.ListForTenant(providers.MasterTenantID) // before DB providers; for config reload
 
 	// Resolve workspace (must be absolute for system prompt + file tool path resolution)
 	workspace := config.ExpandHome(cfg.Agents.Defaults.Workspace)
@@ -135,9 +154,17 @@ func runGateway() {
 	}
 
 	toolsReg, execApprovalMgr, mcpMgr, sandboxMgr, browserMgr, webFetchTool, ttsTool, audioMgr, permPE, toolPE, dataDir, agentCfg := setupToolRegistry(cfg, workspace, providerRegistry)
+	// Tool executions land in the audit log alongside admin/API mutations.
+	toolsReg.SetAuditPublisher(msgBus)
 	if browserMgr != nil {
+		browserMgr.SetDownloadHook(browserDownloadPublisher(msgBus))
 		defer browserMgr.Close()
 	}
+	if t, ok := toolsReg.Get("exec_background"); ok {
+		if bg, ok := t.(*tools.ExecBackgroundTool); ok {
+			defer bg.Processes().KillAll()
+		}
+	}
 	if mcpMgr != nil {
 		defer mcpMgr.Stop()
 	}
@@ -169,7 +196,7 @@ func runGateway() {
 
 	// Register providers from DB (overrides config providers).
 	if pgStores.Providers != nil {
-		dbGatewayAddr := loopbackAddr(cfg.Gateway.Host, cfg.Gateway.Port)
+		dbGatewayAddr := gatewayLocalAddr(cfg)
 		registerProvidersFromDB(providerRegistry, pgStores.Providers, pgStores.ConfigSecrets, dbGatewayAddr, cfg.Gateway.Token, pgStores.MCP, cfg, modelReg)
 	}
 	slog.Info("model registry initialized", "anthropic_models", len(modelReg.Catalog("anthropic")), "openai_models", len(modelReg.Catalog("openai")))
@@ -192,7 +219,7 @@ func runGateway() {
 			slog.Info("system_configs applied to in-memory config", "keys", len(sysConfigs))
 		}
 	}
-	setupMemoryEmbeddings(pgStores, providerRegistry)
+	embMigrator := setupMemoryEmbeddings(pgStores, providerRegistry)
 
 	// Resolve background provider for consolidation + vault enrichment.
 	// Fallback: background.provider → agent.default_provider → first registered provider.
@@ -299,10 +326,26 @@ func runGateway() {
 		server.SetAgentStore(pgStores.Agents)
 	}
 
+	// Budget checker: rejects runs over per-user/agent token and cost caps.
+	// Created before the resolver so every agent loop enforces it.
+	var budgetChecker *channels.BudgetChecker
+	var budgetGuard agent.BudgetGuard
+	if cfg.Gateway.Budget != nil && cfg.Gateway.Budget.Enabled {
+		budgetChecker = channels.NewBudgetChecker(pgStores.DB, *cfg.Gateway.Budget)
+		defer budgetChecker.Stop()
+		budgetGuard = budgetChecker
+		slog.Info("budget caps enabled",
+			"user_daily_tokens", cfg.Gateway.Budget.UserDefault.DailyTokens,
+			"user_monthly_cents", cfg.Gateway.Budget.UserDefault.MonthlyCents,
+			"agent_monthly_cents", cfg.Gateway.Budget.AgentDefault.MonthlyCents,
+		)
+	}
+
 	var mcpPool *mcpbridge.Pool
 	var mediaStore *media.Store
 	var postTurn tools.PostTurnProcessor
-	contextFileInterceptor, mcpPool, mediaStore, postTurn = wireExtras(pgStores, agentRouter, providerRegistry, modelReg, msgBus, pgStores.Sessions, toolsReg, toolPE, skillsLoader, hasMemory, traceCollector, workspace, cfg.Gateway.InjectionAction, cfg, sandboxMgr, redisClient, domainBus)
+	sessionLocker := setupSessionLocker(cfg, pgStores)
+	contextFileInterceptor, mcpPool, mediaStore, postTurn = wireExtras(pgStores, agentRouter, providerRegistry, modelReg, msgBus, pgStores.Sessions, toolsReg, toolPE, skillsLoader, hasMemory, traceCollector, workspace, cfg.Gateway.InjectionAction, cfg, sandboxMgr, redisClient, domainBus, budgetGuard, sessionLocker)
 	if mcpPool != nil {
 		defer mcpPool.Stop()
 	}
@@ -323,16 +366,26 @@ func runGateway() {
 		dataDir:          dataDir,
 		domainBus:        domainBus,
 		audioMgr:         audioMgr,
+		embMigrator:      embMigrator,
 	}
 
-	gatewayAddr := loopbackAddr(cfg.Gateway.Host, cfg.Gateway.Port)
+	gatewayAddr := gatewayLocalAddr(cfg)
 	var mcpToolLister httpapi.MCPToolLister
 	if mcpMgr != nil {
 		mcpToolLister = mcpMgr
 	}
 	httpapi.InitGatewayToken(cfg.Gateway.Token)
+	httpapi.InitScopedTokens(cfg.Gateway.ScopedTokens)
 	exportTokenStore := httpapi.InitExportTokenStore()
 	defer exportTokenStore.Stop()
+	uiSessions := httpapi.NewUISessionStore()
+	defer uiSessions.Stop()
+	httpapi.InitUISessions(uiSessions)
+	server.SetUISessionsHandler(httpapi.NewUISessionsHandler(uiSessions))
+	httpapi.InitOIDC(cfg.Gateway.OIDC)
+	if httpapi.OIDCEnabled() {
+		server.SetOIDCHandler(httpapi.NewOIDCHandler(uiSessions))
+	}
 	agentsH, skillsH, tracesH, mcpH, channelInstancesH, providersH, builtinToolsH, pendingMessagesH, teamEventsH, secureCLIH, secureCLIGrantH, mcpUserCredsH := wireHTTP(pgStores, cfg.Agents.Defaults.Workspace, dataDir, bundledSkillsDir, msgBus, toolsReg, providerRegistry, modelReg, permPE.IsOwner, gatewayAddr, mcpToolLister)
 
 	// Wire dependencies for system prompt preview parity.
@@ -382,6 +435,29 @@ func runGateway() {
 	// S3 backup integration — admin + owner only.
 	server.SetBackupS3Handler(httpapi.NewBackupS3Handler(cfg, cfg.Database.PostgresDSN, Version, pgStores.ConfigSecrets, permPE.IsOwner))
 
+	// Cron job management API — non-admins only see their own jobs.
+	if pgStores.Cron != nil {
+		server.SetCronHandler(httpapi.NewCronHandler(pgStores.Cron, msgBus, permPE.IsOwner))
+	}
+
+	// Agent status card — queue depth is wired once the scheduler exists.
+	var agentStatusHandler *httpapi.AgentStatusHandler
+	if pgStores.Agents != nil {
+		agentStatusHandler = httpapi.NewAgentStatusHandler(pgStores.Agents, pgStores.Heartbeats, pgStores.Cron, pgStores.Memory, pgStores.Tracing, permPE.IsOwner)
+		server.SetAgentStatusHandler(agentStatusHandler)
+	}
+
+	// Heartbeat run history and alert snooze API.
+	if pgStores.Heartbeats != nil && pgStores.Agents != nil {
+		server.SetHeartbeatHandler(httpapi.NewHeartbeatHandler(pgStores.Heartbeats, pgStores.Agents, msgBus, permPE.IsOwner))
+	}
+
+	// Remote sync: publish sealed state snapshots to linked standalone instances.
+	if cfg.Sync.Key != "" && cfg.Sync.Upstream == "" && pgStores.Agents != nil {
+		exporter := remotesync.NewExporter(pgStores.Agents, pgStores.Cron, pgStores.Memory, skillsLoader)
+		server.SetSyncHandler(httpapi.NewSyncHandler(exporter, cfg.Sync.Key))
+	}
+
 	// Tenant-scoped backup/restore — owner or tenant admin.
 	if pgStores.Tenants != nil {
 		server.SetTenantBackupHandler(httpapi.NewTenantBackupHandler(pgStores.DB, cfg, pgStores.Tenants, Version, permPE.IsOwner))
@@ -389,7 +465,7 @@ func runGateway() {
 
 	// Register all RPC methods
 	server.SetLogTee(logTee)
-	pairingMethods, heartbeatMethods, chatMethods, cfgPermsMethods := registerAllMethods(server, agentRouter, pgStores.Sessions, pgStores.Cron, pgStores.Pairing, cfg, cfgPath, workspace, dataDir, msgBus, execApprovalMgr, pgStores.Agents, pgStores.Skills, pgStores.ConfigSecrets, pgStores.Teams, contextFileInterceptor, logTee, pgStores.Heartbeats, pgStores.ConfigPermissions, pgStores.SystemConfigs, pgStores.Tenants, pgStores.SkillTenantCfgs, audioMgr)
+	pairingMethods, heartbeatMethods, chatMethods, cfgPermsMethods := registerAllMethods(server, agentRouter, pgStores.Sessions, pgStores.Cron, pgStores.Pairing, cfg, cfgPath, workspace, dataDir, msgBus, execApprovalMgr, pgStores.Agents, pgStores.Skills, pgStores.ConfigSecrets, pgStores.Teams, contextFileInterceptor, logTee, pgStores.Heartbeats, pgStores.ConfigPermissions, pgStores.SystemConfigs, pgStores.Tenants, pgStores.SkillTenantCfgs, audioMgr, pgStores.Episodic)
 
 	// Phase 3: Agent hooks RPC methods (hooks.list/create/update/delete/toggle/test/history).
 	if hs, ok := pgStores.Hooks.(hooks.HookStore); ok && hs != nil {
@@ -412,6 +488,9 @@ func runGateway() {
 
 	// Wire pairing event broadcasts to all WS clients.
 	pairingMethods.SetBroadcaster(server.BroadcastEvent)
+	pairingH := httpapi.NewPairingHandler(pgStores.Pairing, msgBus)
+	pairingH.SetBroadcaster(server.BroadcastEvent)
+	server.SetPairingHandler(pairingH)
 	// Wire pairing request callback — works for both PG and SQLite stores.
 	type pairingRequestNotifier interface {
 		SetOnRequest(func(code, senderID, channel, chatID string))
@@ -423,6 +502,13 @@ func runGateway() {
 			}))
 		})
 	}
+	// Pairing code and paired-sender lifetimes from channels.pairing.
+	type pairingTTLSetter interface {
+		SetTTLs(codeTTL, pairedTTL time.Duration)
+	}
+	if ps, ok := pgStores.Pairing.(pairingTTLSetter); ok {
+		ps.SetTTLs(cfg.Channels.Pairing.CodeTTL(), cfg.Channels.Pairing.PairedTTL())
+	}
 
 	// Channel manager
 	channelMgr := channels.NewManager(msgBus)
@@ -444,6 +530,39 @@ func runGateway() {
 			tc.SetChannelTenantChecker(channelMgr.ChannelTenantID)
 		}
 	}
+	// Tool approvals: WS events + audit records, and prompts sent to each
+	// owner's direct chat on the originating channel (Telegram inline
+	// buttons). Only owners may answer, so a sender cannot approve their own
+	// call. Without gateway.owner_ids, approvals are answered over WS only.
+	execApprovalMgr.SetEventPublisher(msgBus)
+	execApprovalMgr.SetNotifier(func(ctx context.Context, pa *tools.PendingApproval) {
+		owners := cfg.Gateway.OwnerIDs
+		if len(owners) == 0 {
+			slog.Debug("tool approval prompt not sent: no gateway.owner_ids configured", "id", pa.ID)
+			return
+		}
+		for _, owner := range owners {
+			err := channelMgr.SendApprovalPrompt(ctx, pa.Channel, channels.ApprovalPrompt{
+				ID:          pa.ID,
+				Tool:        pa.Tool,
+				Summary:     pa.Command,
+				ChatID:      owner,
+				Requester:   pa.SenderID,
+				ApproverIDs: owners,
+				ExpiresAt:   pa.ExpiresAt,
+			})
+			if err != nil {
+				slog.Debug("tool approval prompt not sent to owner", "id", pa.ID, "channel", pa.Channel, "owner", owner, "error", err)
+			}
+		}
+	})
+	channelMgr.SetApprovalResolver(func(id, decision, actorID string) error {
+		if tools.ApprovalDecision(decision) == tools.ApprovalAllowAlways {
+			return fmt.Errorf("allow-always is not accepted from channels")
+		}
+		return execApprovalMgr.ResolveBy(id, tools.ApprovalDecision(decision), actorID)
+	})
+
 	// Wire group member lister on list_group_members tool
 	if t, ok := toolsReg.Get("list_group_members"); ok {
 		if gl, ok := t.(tools.GroupMemberListerAware); ok {
@@ -478,7 +597,7 @@ func runGateway() {
 	wireChannelRPCMethods(server, pgStores, channelMgr, agentRouter, msgBus, workspace)
 
 	// Wire channel event subscribers (cache invalidation, pairing, cascade disable)
-	wireChannelEventSubscribers(msgBus, server, pgStores, channelMgr, instanceLoader, pairingMethods, cfg)
+	wireChannelEventSubscribers(msgBus, server, pgStores, channelMgr, instanceLoader, pairingMethods, pairingH, cfg)
 
 	// Audit log subscriber + team task event subscribers.
 	auditCh := deps.wireAuditSubscriber()
@@ -497,6 +616,12 @@ func runGateway() {
 	if skillsWatcher, err := skills.NewWatcher(skillsLoader); err != nil {
 		slog.Warn("skills watcher unavailable", "error", err)
 	} else {
+		skillsWatcher.SetOnChange(func(version int64) {
+			msgBus.Broadcast(bus.Event{
+				Name:    protocol.EventSkillsUpdated,
+				Payload: map[string]any{"version": version},
+			})
+		})
 		if err := skillsWatcher.Start(ctx); err != nil {
 			slog.Warn("skills watcher start failed", "error", err)
 		} else {
@@ -504,22 +629,129 @@ func runGateway() {
 		}
 	}
 
-	// Start channels
+	// Start channels (staggered in the background on constrained profiles)
+	if interval := runtimeprofile.Current().ChannelStartInterval; interval > 0 {
+		channelMgr.SetLazyStart(interval)
+	}
 	if err := channelMgr.StartAll(ctx); err != nil {
 		slog.Error("failed to start channels", "error", err)
 	}
+	server.SetChannelHealth(func() (bool, any) {
+		ok, detail := channelMgr.Readiness()
+		return ok, detail
+	})
+
+	// Config hot-reload: SIGHUP or an edit to the config file applies the new
+	// config without a restart; config.apply/patch go through it as well.
+	gatewayReloader = newConfigReloader(cfg, cfgPath, pgStores, msgBus, agentRouter, providerRegistry, modelReg, configProviders, channelMgr, audioMgr, instanceLoader == nil)
+	gatewayReloader.Start(ctx)
+	defer gatewayReloader.Stop()
 
 	// Create lane-based scheduler (matching TS CommandLane pattern).
 	// Must be created before cron setup so cron jobs route through the scheduler.
 	sched := scheduler.NewScheduler(
-		scheduler.DefaultLanes(),
-		scheduler.DefaultQueueConfig(),
+		gatewayLanes(cfg),
+		gatewayQueueConfig(cfg),
 		makeSchedulerRunFunc(agentRouter, cfg),
 	)
+	sched.SetSteerFunc(agentRouter.InjectMessage)
 	defer sched.Stop()
+	sched.Lanes().SetFairness(gatewayFairness(cfg))
+	server.SetLaneStats(sched.LaneStats)
+	server.SetBackpressure(laneBackpressure(sched, scheduler.LaneMain))
+	wakeH.SetBackpressure(laneBackpressure(sched, scheduler.LaneMain))
+	if agentStatusHandler != nil {
+		agentStatusHandler.SetQueueDepth(sched.AgentQueueDepth)
+	}
+	if subagentMgr != nil {
+		// Async subagents share the "subagent" lane's concurrency limit.
+		subagentMgr.SetLaneRunner(sched.Lanes().Get(scheduler.LaneSubagent).Submit)
+	}
+
+	// Do-not-disturb gate: holds cron/heartbeat deliveries during agents' quiet hours.
+	dndGate := dnd.NewGate(pgStores.Agents, msgBus, filepath.Join(dataDir, "dnd_queue.json"))
+	dndGate.Start()
+	defer dndGate.Stop()
+
+	// Workspace ↔ DB context file sync (opt-in): workspace edits to AGENTS.md,
+	// SOUL.md etc. reach the agent, dashboard edits land back in the workspace.
+	var ctxSync *contextsync.Service
+	if cs := cfg.Agents.Defaults.ContextFileSync; cs != nil && cs.Enabled {
+		ctxSync = contextsync.New(pgStores.Agents, filepath.Join(dataDir, "context_sync.json"))
+		ctxSync.SetOnChange(func(agentID uuid.UUID) {
+			msgBus.Broadcast(bus.Event{
+				Name:    protocol.EventCacheInvalidate,
+				Payload: bus.CacheInvalidatePayload{Kind: bus.CacheKindBootstrap, Key: agentID.String()},
+			})
+		})
+		ctxSync.Start(cs.IntervalDuration())
+		defer ctxSync.Stop()
+	}
+
+	// Workspace memory watcher: re-indexes MEMORY.md / memory/*.md as they change
+	// on disk and pushes bootstrap file edits through the context sync.
+	if wm := cfg.Agents.Defaults.WatchMemoryFiles; pgStores.Memory != nil && (wm == nil || *wm) {
+		if memWatcher, err := memory.NewWatcher(pgStores.Agents, pgStores.Memory); err != nil {
+			slog.Warn("memory watcher unavailable", "error", err)
+		} else {
+			if ctxSync != nil {
+				memWatcher.SetOnBootstrapChange(func(uuid.UUID) { ctxSync.SyncOnce(context.Background()) })
+			}
+			if err := memWatcher.Start(context.Background()); err == nil {
+				defer memWatcher.Stop()
+				msgBus.Subscribe("memory-watcher-agents", func(event bus.Event) {
+					if p, ok := event.Payload.(bus.CacheInvalidatePayload); ok && p.Kind == bus.CacheKindAgent {
+						memWatcher.Refresh(context.Background())
+					}
+				})
+			}
+		}
+	}
+
+	// Remote sync: pull agents, cron jobs, memory and skills from the upstream server.
+	if sc := cfg.Sync; sc.Upstream != "" && pgStores.Agents != nil {
+		if sc.Key == "" || sc.Token == "" {
+			slog.Warn("remote sync disabled: sync.upstream requires sync.key and sync.token")
+		} else {
+			ownerID := "system"
+			if len(cfg.Gateway.OwnerIDs) > 0 {
+				ownerID = cfg.Gateway.OwnerIDs[0]
+			}
+			applier := remotesync.NewApplier(pgStores.Agents, pgStores.Cron, pgStores.Memory, remotesync.ApplyOptions{
+				WorkspaceRoot: cfg.WorkspacePath(),
+				OwnerID:       ownerID,
+				SkillsDir:     globalSkillsDir,
+				RunCron:       sc.RunCron,
+			})
+			applier.SetOnAgentChange(func(agentID uuid.UUID, agentKey string) {
+				msgBus.Broadcast(bus.Event{
+					Name:    protocol.EventCacheInvalidate,
+					Payload: bus.CacheInvalidatePayload{Kind: bus.CacheKindAgent, Key: agentKey},
+				})
+				msgBus.Broadcast(bus.Event{
+					Name:    protocol.EventCacheInvalidate,
+					Payload: bus.CacheInvalidatePayload{Kind: bus.CacheKindBootstrap, Key: agentID.String()},
+				})
+			})
+			applier.SetOnSkillsChange(skillsLoader.BumpVersion)
+			puller := remotesync.NewPuller(sc.Upstream, sc.Token, sc.Key, applier, filepath.Join(dataDir, "remote_sync.json"))
+			puller.Start(sc.IntervalDuration())
+			defer puller.Stop()
+		}
+	}
+
+	// Skill registry: keep skills installed by `goclaw skills pull` up to date.
+	if rc := cfg.Skills.Registry; rc.URL != "" && rc.UpdateInterval() > 0 {
+		if reg, err := skills.NewRegistry(rc.URL, rc.Token, globalSkillsDir, nil); err != nil {
+			slog.Warn("skill registry auto-update disabled", "error", err)
+		} else {
+			stopRegistry := reg.StartAutoUpdate(rc.UpdateInterval(), skillsLoader.BumpVersion)
+			defer stopRegistry()
+		}
+	}
 
 	// Start cron + heartbeat ticker, wire wake functions and adaptive throttle.
-	heartbeatTicker := startCronAndHeartbeat(pgStores, server, sched, msgBus, providerRegistry, channelMgr, cfg, heartbeatTool, heartbeatMethods)
+	heartbeatTicker := startCronAndHeartbeat(pgStores, server, sched, msgBus, providerRegistry, channelMgr, cfg, heartbeatTool, heartbeatMethods, dndGate)
 
 	// Subscribe to agent events for channel streaming/reaction forwarding.
 	deps.wireChannelStreamingSubscriber()
@@ -548,7 +780,7 @@ func runGateway() {
 
 	// API key management RPC
 	if pgStores.APIKeys != nil {
-		methods.NewAPIKeysMethods(pgStores.APIKeys).Register(server.Router())
+		methods.NewAPIKeysMethods(pgStores.APIKeys, msgBus).Register(server.Router())
 	}
 
 	// Tenant management RPC + HTTP
@@ -575,6 +807,7 @@ func runGateway() {
 		sched:             sched,
 		heartbeatTicker:   heartbeatTicker,
 		quotaChecker:      quotaChecker,
+		budgetChecker:     budgetChecker,
 		webFetchTool:      webFetchTool,
 		ttsTool:           ttsTool,
 		sandboxMgr:        sandboxMgr,
@@ -3,13 +3,17 @@ package cmd
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/nextlevelbuilder/goclaw/internal/audio/elevenlabs"
 	geminiaudio "github.com/nextlevelbuilder/goclaw/internal/audio/gemini"
 	minimaxaudio "github.com/nextlevelbuilder/goclaw/internal/audio/minimax"
+	"github.com/nextlevelbuilder/goclaw/internal/audio/whisper"
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/memory"
+	"github.com/nextlevelbuilder/goclaw/internal/providerresolve"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -102,16 +106,17 @@ func resolveEmbeddingFromDB(
 }
 
 // buildEmbeddingProvider creates a memory.EmbeddingProvider from a DB provider record.
+// The API format comes from settings.embedding.format, else is detected from
+// the API base (Voyage, native Cohere) and defaults to OpenAI-compatible.
 func buildEmbeddingProvider(
 	dbp *store.LLMProviderData,
 	es *store.EmbeddingSettings,
 	memCfg *config.MemoryConfig,
 	providerReg *providers.Registry,
 ) memory.EmbeddingProvider {
-	// Resolve model: embedding settings → memCfg override → default
-	model := "text-embedding-3-small"
-	if es != nil && es.Model != "" {
-		model = es.Model
+	var format, model string
+	if es != nil {
+		format, model = es.Format, es.Model
 	}
 	if memCfg != nil && memCfg.EmbeddingModel != "" {
 		model = memCfg.EmbeddingModel
@@ -126,37 +131,121 @@ func buildEmbeddingProvider(
 		apiBase = memCfg.EmbeddingAPIBase
 	}
 
-	// Dimension truncation: default to RequiredMemoryEmbeddingDimensions to match pgvector schema.
-	// Models that natively output 1536 ignore the parameter; models with larger native dims get truncated.
-	dims := store.RequiredMemoryEmbeddingDimensions
-	if es != nil && es.Dimensions > 0 && es.Dimensions != store.RequiredMemoryEmbeddingDimensions {
-		slog.Warn("ignoring incompatible provider embedding dimensions for memory schema",
-			"provider", dbp.Name, "requested", es.Dimensions, "required", store.RequiredMemoryEmbeddingDimensions)
-	}
-
 	// Try registry first for the actual API key / base (handles runtime-registered providers)
+	apiKey, resolved := "", false
 	if providerReg != nil {
 		if regProv, regErr := providerReg.Get(context.Background(), dbp.Name); regErr == nil {
 			if op, ok := regProv.(*providers.OpenAIProvider); ok {
 				if apiBase == "" {
 					apiBase = op.APIBase()
 				}
-				ep := memory.NewOpenAIEmbeddingProvider(dbp.Name, op.APIKey(), apiBase, model)
-				ep.WithDimensions(dims)
-				return ep
+				apiKey, resolved = op.APIKey(), true
+			} else if olp, ok := regProv.(*providers.OllamaProvider); ok {
+				// Native Ollama chat provider: the OpenAI-compat /v1/embeddings
+				// endpoint accepts any non-empty Bearer value.
+				if apiBase == "" {
+					apiBase = olp.Host() + "/v1"
+				}
+				apiKey, resolved = "ollama", true
+			} else {
+				slog.Debug("embedding provider in registry is not OpenAI-compatible, using DB record", "name", dbp.Name)
 			}
-			slog.Debug("embedding provider in registry is not OpenAI-compatible, using DB record", "name", dbp.Name)
 		}
 	}
+	if format == "" {
+		format = memory.DetectEmbeddingFormat(apiBase)
+	}
+	// Fallback: build directly from DB record. Only local Ollama works keyless.
+	if !resolved {
+		if dbp.APIKey == "" && format != memory.EmbeddingFormatOllama {
+			return nil
+		}
+		apiKey = dbp.APIKey
+	}
 
-	// Fallback: build directly from DB record
-	if dbp.APIKey != "" {
-		ep := memory.NewOpenAIEmbeddingProvider(dbp.Name, dbp.APIKey, apiBase, model)
-		ep.WithDimensions(dims)
-		return ep
+	// Dimensions: the memory schema stores vector(MemoryEmbeddingDimensions()).
+	// Smaller requested sizes are zero-padded on the way in; OpenAI-compatible
+	// models default to a server-side truncation to the schema size.
+	colDims := store.MemoryEmbeddingDimensions()
+	dims := 0
+	if es != nil && es.Dimensions > 0 {
+		if es.Dimensions <= colDims {
+			dims = es.Dimensions
+		} else {
+			slog.Warn("ignoring incompatible provider embedding dimensions for memory schema",
+				"provider", dbp.Name, "requested", es.Dimensions, "max", colDims)
+		}
+	}
+	if dims == 0 && format == memory.EmbeddingFormatOpenAI {
+		dims = colDims
+	}
+	batchSize := 0
+	if es != nil {
+		batchSize = es.BatchSize
 	}
 
-	return nil
+	ep, err := memory.NewEmbeddingProvider(memory.EmbeddingOptions{
+		Format:     format,
+		Name:       dbp.Name,
+		APIKey:     apiKey,
+		APIBase:    apiBase,
+		Model:      model,
+		Dimensions: dims,
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		slog.Warn("embedding provider not built", "provider", dbp.Name, "error", err)
+		return nil
+	}
+	return memory.PadDimensions(ep, store.MemoryEmbeddingDimensions)
+}
+
+// buildRerankerResolver maps an agent's MemoryConfig.Rerank to a reranker.
+// "llm" uses the named chat provider, or the tenant's background provider
+// when none is named; "cross_encoder" calls <api_base>/rerank with the named
+// provider's API key.
+func buildRerankerResolver(providerReg *providers.Registry, sysConfigs store.SystemConfigStore) tools.RerankerResolver {
+	return func(ctx context.Context, rc *config.RerankConfig) store.Reranker {
+		switch rc.Mode {
+		case "", "llm":
+			if rc.Provider == "" {
+				p, model := providerresolve.ResolveBackgroundProvider(ctx, store.TenantIDFromContext(ctx), providerReg, sysConfigs)
+				if p == nil {
+					return nil
+				}
+				if rc.Model != "" {
+					model = rc.Model
+				}
+				return memory.NewLLMReranker(p, model)
+			}
+			p, err := providerReg.Get(ctx, rc.Provider)
+			if err != nil {
+				slog.Warn("memory rerank: provider not found", "provider", rc.Provider)
+				return nil
+			}
+			return memory.NewLLMReranker(p, rc.Model)
+		case "cross_encoder":
+			apiKey, apiBase := "", rc.APIBase
+			if rc.Provider != "" {
+				if p, err := providerReg.Get(ctx, rc.Provider); err == nil {
+					if op, ok := p.(*providers.OpenAIProvider); ok {
+						apiKey = op.APIKey()
+						if apiBase == "" {
+							apiBase = op.APIBase()
+						}
+					}
+				}
+			}
+			if apiBase == "" {
+				slog.Warn("memory rerank: cross_encoder needs api_base or an OpenAI-compatible provider", "provider", rc.Provider)
+				return nil
+			}
+			return memory.NewCrossEncoderReranker(apiKey, apiBase, rc.Model)
+		default:
+			slog.Warn("memory rerank: unknown mode", "mode", rc.Mode)
+			return nil
+		}
+	}
 }
 
 func setupSubagents(providerReg *providers.Registry, cfg *config.Config, msgBus *bus.MessageBus, toolsReg *tools.Registry, workspace string, sandboxMgr sandbox.Manager, secureCLIStore store.SecureCLIStore) *tools.SubagentManager {
@@ -227,12 +316,16 @@ func buildSubagentToolsRegistry(
 		reg.Register(tools.NewSandboxedReadFileTool(workspace, restrict, sandboxMgr))
 		reg.Register(tools.NewSandboxedWriteFileTool(workspace, restrict, sandboxMgr))
 		reg.Register(tools.NewSandboxedListFilesTool(workspace, restrict, sandboxMgr))
+		reg.Register(tools.NewSandboxedGrepTool(workspace, restrict, sandboxMgr))
+		reg.Register(tools.NewSandboxedGlobTool(workspace, restrict, sandboxMgr))
 		execTool = tools.NewSandboxedExecTool(workspace, restrict, sandboxMgr)
 		reg.Register(execTool)
 	} else {
 		reg.Register(tools.NewReadFileTool(workspace, restrict))
 		reg.Register(tools.NewWriteFileTool(workspace, restrict))
 		reg.Register(tools.NewListFilesTool(workspace, restrict))
+		reg.Register(tools.NewGrepTool(workspace, restrict))
+		reg.Register(tools.NewGlobTool(workspace, restrict))
 		execTool = tools.NewExecTool(workspace, restrict)
 		reg.Register(execTool)
 	}
@@ -318,7 +411,7 @@ func setupTTS(cfg *config.Config) *tts.Manager {
 // setupAudioExtras wires Music and SFX providers into the audio Manager.
 // ElevenLabs is registered for both SFX and Music when an API key is present.
 // MiniMax music is registered when cfg.Audio.Music is configured with a key.
-// Phase 4 will add STT providers here.
+// STT providers come from the ElevenLabs TTS key and cfg.Audio.Stt.
 func setupAudioExtras(cfg *config.Config, mgr *tts.Manager) {
 	ellKey := cfg.Tts.ElevenLabs.APIKey
 	ellBase := cfg.Tts.ElevenLabs.BaseURL
@@ -365,4 +458,82 @@ func setupAudioExtras(cfg *config.Config, mgr *tts.Manager) {
 		mgr.SetSTTChain([]string{"elevenlabs", "proxy"})
 		slog.Info("audio.stt: elevenlabs registered")
 	}
+
+	setupSTTFromConfig(cfg, mgr)
+}
+
+// setupSTTFromConfig registers the primary and fallback STT providers named in
+// cfg.Audio.Stt and places them ahead of the elevenlabs → proxy default chain.
+// Whisper backends without an explicit key reuse providers.openai / providers.groq.
+func setupSTTFromConfig(cfg *config.Config, mgr *tts.Manager) {
+	if cfg.Audio == nil || cfg.Audio.Stt == nil {
+		return
+	}
+	sc := cfg.Audio.Stt
+	mgr.SetSTTLanguage(sc.Language)
+
+	var chain []string
+	for i, name := range []string{sc.Provider, sc.Fallback} {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "scribe" {
+			name = "elevenlabs"
+		}
+		if name == "" || slices.Contains(chain, name) {
+			continue
+		}
+		// Only the primary provider takes the explicit key / base URL / model.
+		primary := i == 0
+		apiKey, baseURL, model := "", "", ""
+		if primary {
+			apiKey, baseURL, model = sc.APIKey, sc.BaseURL, sc.Model
+		}
+
+		switch {
+		case name == "elevenlabs":
+			if apiKey != "" {
+				mgr.RegisterSTT(elevenlabs.NewSTTProvider(elevenlabs.Config{APIKey: apiKey, BaseURL: baseURL, TimeoutMs: sc.TimeoutMs}))
+			} else if cfg.Tts.ElevenLabs.APIKey == "" {
+				slog.Warn("audio.stt: elevenlabs selected but no API key configured")
+				continue
+			}
+		case name == "proxy":
+			// Channel-scoped only (stt_proxy_url); kept in the chain for ordering.
+		case whisper.IsBackend(name):
+			if apiKey == "" {
+				switch name {
+				case whisper.BackendOpenAI:
+					apiKey = cfg.Providers.OpenAI.APIKey
+				case whisper.BackendGroq:
+					apiKey = cfg.Providers.Groq.APIKey
+				}
+			}
+			if apiKey == "" && name != whisper.BackendWhisperCpp {
+				slog.Warn("audio.stt: provider selected but no API key configured", "provider", name)
+				continue
+			}
+			mgr.RegisterSTT(whisper.NewSTTProvider(whisper.Config{
+				Backend:   name,
+				APIKey:    apiKey,
+				BaseURL:   baseURL,
+				Model:     model,
+				TimeoutMs: sc.TimeoutMs,
+			}))
+		default:
+			slog.Warn("audio.stt: unknown provider, ignoring", "provider", name)
+			continue
+		}
+		chain = append(chain, name)
+	}
+	if len(chain) == 0 {
+		return
+	}
+
+	if cfg.Tts.ElevenLabs.APIKey != "" && !slices.Contains(chain, "elevenlabs") {
+		chain = append(chain, "elevenlabs")
+	}
+	if !slices.Contains(chain, "proxy") {
+		chain = append(chain, "proxy")
+	}
+	mgr.SetSTTChain(chain)
+	slog.Info("audio.stt: chain configured", "chain", chain, "language", sc.Language)
 }
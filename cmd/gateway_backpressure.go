@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+// notifyLaneBackpressure tells the sender their message is queued when the
+// lane it is about to enter has no free slot. The message is still scheduled;
+// the notice only replaces silent waiting.
+func notifyLaneBackpressure(deps *ConsumerDeps, lane string, msg bus.InboundMessage, outMeta map[string]string, agentUUID uuid.UUID) {
+	if bus.IsInternalSender(msg.SenderID) || msg.Channel == tools.ChannelSystem || msg.Channel == tools.ChannelTeammate {
+		return
+	}
+	load := deps.Sched.LaneLoad(lane)
+	if !load.Saturated {
+		return
+	}
+	slog.Info("inbound: lane saturated, message queued",
+		"lane", load.Lane, "position", load.Position, "eta", load.EstimatedWait, "channel", msg.Channel)
+	deps.MsgBus.PublishOutbound(bus.OutboundMessage{
+		Channel:  msg.Channel,
+		ChatID:   msg.ChatID,
+		Content:  formatBackpressureNotice(load),
+		Metadata: outMeta,
+		TenantID: msg.TenantID,
+		AgentID:  agentUUID,
+	})
+}
+
+func formatBackpressureNotice(load scheduler.LaneLoad) string {
+	eta := "<1 min"
+	if load.EstimatedWait >= time.Minute {
+		eta = fmt.Sprintf("%d min", int((load.EstimatedWait+time.Minute-1)/time.Minute))
+	}
+	return fmt.Sprintf("⏳ I'm busy right now — you're #%d in line (~%s). I'll reply as soon as it's your turn.", load.Position, eta)
+}
+
+// laneBackpressure adapts a lane's load to the HTTP API's 429/Retry-After check.
+func laneBackpressure(sched *scheduler.Scheduler, lane string) httpapi.BackpressureFunc {
+	return func() (bool, time.Duration) {
+		load := sched.LaneLoad(lane)
+		return load.Saturated, load.EstimatedWait
+	}
+}
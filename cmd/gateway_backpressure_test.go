@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+)
+
+func TestFormatBackpressureNotice(t *testing.T) {
+	tests := []struct {
+		wait time.Duration
+		want string
+	}{
+		{20 * time.Second, "#3 in line (~<1 min)"},
+		{time.Minute, "#3 in line (~1 min)"},
+		{90 * time.Second, "#3 in line (~2 min)"},
+	}
+	for _, tt := range tests {
+		got := formatBackpressureNotice(scheduler.LaneLoad{Saturated: true, Position: 3, EstimatedWait: tt.wait})
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("wait %v: notice = %q, want it to contain %q", tt.wait, got, tt.want)
+		}
+	}
+}
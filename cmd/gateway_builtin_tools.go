@@ -18,12 +18,17 @@ func builtinToolSeedData() []store.BuiltinToolDef {
 		{Name: "read_file", DisplayName: "Read File", Description: "Read the contents of a file from the agent's workspace by path", Category: "filesystem", Enabled: true},
 		{Name: "write_file", DisplayName: "Write File", Description: "Write content to a file in the workspace, creating directories as needed", Category: "filesystem", Enabled: true},
 		{Name: "list_files", DisplayName: "List Files", Description: "List files and directories in a given path within the workspace", Category: "filesystem", Enabled: true},
-		{Name: "edit", DisplayName: "Edit File", Description: "Apply targeted search-and-replace edits to existing files without rewriting the entire file", Category: "filesystem", Enabled: true},
+		{Name: "grep", DisplayName: "Grep", Description: "Search file contents in the workspace with a regular expression, with context lines and a match limit; binary files are skipped", Category: "filesystem", Enabled: true},
+		{Name: "glob", DisplayName: "Glob", Description: "Find files in the workspace by glob pattern such as **/*.go", Category: "filesystem", Enabled: true},
+		{Name: "edit", DisplayName: "Edit File", Description: "Apply targeted search-and-replace edits or unified diff patches to existing files without rewriting the entire file", Category: "filesystem", Enabled: true},
 
 		// runtime
 		{Name: "exec", DisplayName: "Execute Command", Description: "Execute a shell command in the workspace and return stdout/stderr", Category: "runtime", Enabled: true,
 			Metadata: json.RawMessage(`{"config_hint":"Config → Tools → Exec Approval"}`),
 		},
+		{Name: "exec_background", DisplayName: "Background Process", Description: "Start a long-running shell command (dev server, watcher) detached from the tool-call timeout, with a rolling output buffer", Category: "runtime", Enabled: true},
+		{Name: "process_list", DisplayName: "List Processes", Description: "List the session's background processes or show one process's recent output", Category: "runtime", Enabled: true},
+		{Name: "process_kill", DisplayName: "Kill Process", Description: "Stop a background process started in this session", Category: "runtime", Enabled: true},
 
 		// web
 		{Name: "web_search", DisplayName: "Web Search", Description: "Search the web for information using a search engine (Brave or DuckDuckGo)", Category: "web", Enabled: true,
@@ -40,6 +45,9 @@ func builtinToolSeedData() []store.BuiltinToolDef {
 		{Name: "memory_get", DisplayName: "Memory Get", Description: "Retrieve a specific memory document by its file path", Category: "memory", Enabled: true,
 			Requires: []string{"memory"},
 		},
+		{Name: "memory_write", DisplayName: "Memory Write", Description: "Save facts to MEMORY.md or memory/<topic>.md, merging under a section heading and skipping duplicate bullets", Category: "memory", Enabled: true,
+			Requires: []string{"memory"},
+		},
 		{Name: "knowledge_graph_search", DisplayName: "Knowledge Graph Search", Description: "Search entities, relationships, and observations in the agent's knowledge graph", Category: "memory", Enabled: true,
 			Settings: json.RawMessage(`{"extract_on_memory_write":false,"extraction_provider":"","extraction_model":"","min_confidence":0.75}`),
 			Requires: []string{"knowledge_graph"},
@@ -71,6 +79,10 @@ func builtinToolSeedData() []store.BuiltinToolDef {
 			Requires: []string{"tts_provider"},
 			Metadata: json.RawMessage(`{"config_hint":"Config → TTS"}`),
 		},
+		{Name: "tts_speak", DisplayName: "Speak Reply", Description: "Reply to the current chat with voice notes, delivered chunk by chunk as they are generated", Category: "media", Enabled: true,
+			Requires: []string{"tts_provider"},
+			Metadata: json.RawMessage(`{"config_hint":"Config → TTS"}`),
+		},
 		{Name: "stt", DisplayName: "Speech-to-Text", Description: "Transcribe voice/audio messages to text using ElevenLabs Scribe or a proxy service", Category: "media", Enabled: true,
 			Requires: []string{"stt_provider"},
 			Metadata: json.RawMessage(`{"config_hint":"Config → Audio → STT"}`),
@@ -96,6 +108,9 @@ func builtinToolSeedData() []store.BuiltinToolDef {
 		{Name: "cron", DisplayName: "Cron Scheduler", Description: "Schedule or manage recurring tasks using cron expressions, at-times, or intervals", Category: "scheduling", Enabled: true,
 			Metadata: json.RawMessage(`{"config_hint":"Config → Cron"}`),
 		},
+		{Name: "cron_add", DisplayName: "Add Scheduled Job", Description: "Schedule a reminder or recurring task from a plain-language schedule (\"every monday at 9am\", \"in 20 minutes\")", Category: "scheduling", Enabled: true},
+		{Name: "cron_list", DisplayName: "List Scheduled Jobs", Description: "List the caller's scheduled jobs and reminders", Category: "scheduling", Enabled: true},
+		{Name: "cron_remove", DisplayName: "Remove Scheduled Job", Description: "Cancel one of the caller's scheduled jobs or reminders", Category: "scheduling", Enabled: true},
 
 		// subagents
 		{Name: "spawn", DisplayName: "Spawn", Description: "Spawn a subagent to handle a task in the background", Category: "subagents", Enabled: true,
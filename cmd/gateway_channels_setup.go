@@ -23,6 +23,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway/methods"
+	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
@@ -177,6 +178,7 @@ func wireChannelEventSubscribers(
 	channelMgr *channels.Manager,
 	instanceLoader *channels.InstanceLoader,
 	pairingMethods *methods.PairingMethods,
+	pairingH *httpapi.PairingHandler,
 	cfg *config.Config,
 ) {
 	// Cache invalidation: reload channel instances on changes.
@@ -195,7 +197,7 @@ func wireChannelEventSubscribers(
 
 	// Wire pairing approval notification → channel (matching TS notifyPairingApproved).
 	botName := cfg.ResolveDisplayName("default")
-	pairingMethods.SetOnApprove(func(ctx context.Context, channel, chatID, senderID string) {
+	notifyApproved := func(ctx context.Context, channel, chatID, senderID string) {
 		// Browser/internal channels use WebSocket — UI polls approval status directly.
 		if channels.IsInternalChannel(channel) {
 			slog.Debug("pairing approved for internal channel, skipping notification", "channel", channel)
@@ -213,7 +215,9 @@ func wireChannelEventSubscribers(
 		} else if err := channelMgr.SendToChannel(ctx, channel, chatID, msg); err != nil {
 			slog.Warn("failed to send pairing approval notification", "channel", channel, "chatID", chatID, "error", err)
 		}
-	})
+	}
+	pairingMethods.SetOnApprove(notifyApproved)
+	pairingH.SetOnApprove(notifyApproved)
 
 	// Wire pairing revocation → force disconnect active WebSocket sessions.
 	msgBus.Subscribe(bus.TopicPairingRevoked, func(event bus.Event) {
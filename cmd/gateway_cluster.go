@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/store/pg"
+)
+
+// setupSessionLocker returns the cross-replica session locker when
+// gateway.cluster is enabled on the Postgres backend, nil otherwise.
+func setupSessionLocker(cfg *config.Config, stores *store.Stores) agent.SessionLocker {
+	cc := cfg.Gateway.Cluster
+	if cc == nil || !cc.Enabled {
+		return nil
+	}
+	sessions, ok := stores.Sessions.(*pg.PGSessionStore)
+	if !ok || stores.DB == nil {
+		slog.Warn("gateway.cluster requires the Postgres backend; session locking disabled")
+		return nil
+	}
+	instanceID := cc.InstanceID
+	if instanceID == "" {
+		host, _ := os.Hostname()
+		instanceID = host + "-" + uuid.NewString()[:8]
+	}
+	slog.Info("cluster mode: cross-replica session locking enabled", "instance", instanceID)
+	return pg.NewSessionLocker(stores.DB, sessions, instanceID,
+		time.Duration(cc.LeaseTTLSec)*time.Second, time.Duration(cc.LockWaitSec)*time.Second)
+}
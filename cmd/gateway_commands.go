@@ -0,0 +1,379 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/commands"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	maxMuteDuration   = 7 * 24 * time.Hour
+	maxCommandResults = 10
+)
+
+var modelNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:/@-]{0,127}$`)
+
+// newCommandRegistry builds the registry of built-in slash commands handled
+// by the gateway before a message reaches the agent loop.
+func newCommandRegistry(deps *ConsumerDeps) *commands.Registry {
+	reg := commands.NewRegistry()
+	builtins := []commands.Command{
+		{
+			Name:        "help",
+			Description: "List available commands",
+			MaxArgs:     0,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandHelp(deps, inv), nil
+			},
+		},
+		{
+			Name:        "reset",
+			Aliases:     []string{"new"},
+			Description: "Clear the conversation history",
+			MaxArgs:     0,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				deps.SessStore.Reset(ctx, inv.SessionKey)
+				deps.SessStore.Save(ctx, inv.SessionKey)
+				providers.ResetCLISession("", inv.SessionKey)
+				deps.ModelOverrides.Delete(inv.SessionKey)
+				return commands.Reply{Text: "Conversation history has been reset."}, nil
+			},
+		},
+		{
+			Name:        "rewind",
+			Usage:       "[checkpoint]",
+			Description: "List checkpoints, or roll the conversation back to one",
+			MaxArgs:     1,
+			Validate: func(args []string) error {
+				if len(args) == 1 {
+					if n, err := strconv.Atoi(args[0]); err != nil || n <= 0 {
+						return fmt.Errorf("invalid checkpoint %q", args[0])
+					}
+				}
+				return nil
+			},
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandRewind(ctx, deps, inv), nil
+			},
+		},
+		{
+			Name:        "model",
+			Usage:       "[name|default]",
+			Description: "Show or switch the model for this conversation",
+			MaxArgs:     1,
+			Validate: func(args []string) error {
+				if len(args) == 1 && !modelNameRe.MatchString(args[0]) {
+					return fmt.Errorf("invalid model name %q", args[0])
+				}
+				return nil
+			},
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandModel(deps, inv), nil
+			},
+		},
+		{
+			Name:        "memory",
+			Usage:       "[query]",
+			Description: "Search the agent's memory, or list memory files",
+			MaxArgs:     -1,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandMemory(ctx, deps, inv)
+			},
+		},
+		{
+			Name:        "tasks",
+			Description: "List team tasks for this chat",
+			MaxArgs:     0,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandTasks(ctx, deps, inv)
+			},
+		},
+		{
+			Name:        "mute",
+			Usage:       "<duration|off>",
+			Description: "Stop replies in this conversation for a while, e.g. /mute 2h",
+			MinArgs:     1,
+			MaxArgs:     1,
+			Validate: func(args []string) error {
+				if strings.EqualFold(args[0], "off") {
+					return nil
+				}
+				d, err := time.ParseDuration(args[0])
+				if err != nil || d <= 0 {
+					return fmt.Errorf("invalid duration %q", args[0])
+				}
+				if d > maxMuteDuration {
+					return fmt.Errorf("duration must be at most %s", maxMuteDuration)
+				}
+				return nil
+			},
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				if strings.EqualFold(inv.Args[0], "off") {
+					deps.MutedUntil.Delete(inv.SessionKey)
+					return commands.Reply{Text: "Unmuted."}, nil
+				}
+				d, _ := time.ParseDuration(inv.Args[0])
+				until := time.Now().Add(d)
+				deps.MutedUntil.Store(inv.SessionKey, until)
+				return commands.Reply{Text: fmt.Sprintf("Muted until %s. Send /mute off to resume.", until.UTC().Format("2006-01-02 15:04 UTC"))}, nil
+			},
+		},
+		{
+			Name:        "confirm",
+			Description: "Run a request held for cost confirmation",
+			MaxArgs:     0,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandConfirm(ctx, deps, inv), nil
+			},
+		},
+		{
+			Name:        "plan",
+			Usage:       "<goal>|approve|pause|resume|cancel|status",
+			Description: "Draft a multi-step plan, then run it step by step once approved",
+			MaxArgs:     -1,
+			Run: func(ctx context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commandPlan(ctx, deps, inv)
+			},
+		},
+	}
+	for _, c := range builtins {
+		if err := reg.Register(c); err != nil {
+			slog.Warn("commands: register built-in failed", "command", c.Name, "error", err)
+		}
+	}
+	return reg
+}
+
+// agentCustomCommands converts the agent's configured custom commands into
+// prompt-forwarding commands.
+func agentCustomCommands(otherConfig []byte) []commands.Command {
+	custom := (&store.AgentData{OtherConfig: otherConfig}).ParseCustomCommands()
+	out := make([]commands.Command, 0, len(custom))
+	for _, c := range custom {
+		maxArgs := c.MaxArgs
+		if maxArgs == 0 {
+			maxArgs = -1
+		}
+		prompt := c.Prompt
+		out = append(out, commands.Command{
+			Name:        c.Name,
+			Usage:       c.Usage,
+			Description: c.Description,
+			MinArgs:     c.MinArgs,
+			MaxArgs:     maxArgs,
+			Run: func(_ context.Context, inv *commands.Invocation) (commands.Reply, error) {
+				return commands.Reply{Forward: strings.ReplaceAll(prompt, "{args}", inv.RawArgs)}, nil
+			},
+		})
+	}
+	return out
+}
+
+// handleSlashCommand runs a slash command carried by msg. It returns the text
+// to forward to the agent (custom prompt commands) and whether the message was
+// fully handled. Unknown commands are neither handled nor forwarded.
+func handleSlashCommand(ctx context.Context, msg bus.InboundMessage, deps *ConsumerDeps, agentLoop agent.Agent, agentID, sessionKey, userID string, outMeta map[string]string) (forward string, handled bool) {
+	if deps.Commands == nil || bus.IsInternalSender(msg.SenderID) {
+		return "", false
+	}
+	text := msg.Metadata[channels.MetaCommandText]
+	if text == "" {
+		text = msg.Content
+	}
+	if !strings.HasPrefix(strings.TrimSpace(text), "/") {
+		return "", false
+	}
+
+	inv := commands.Invocation{
+		AgentID:    agentID,
+		SessionKey: sessionKey,
+		UserID:     userID,
+		Msg:        msg,
+	}
+	reply, ok, err := deps.Commands.Dispatch(ctx, text, inv, agentCustomCommands(agentLoop.OtherConfig())...)
+	if !ok {
+		return "", false
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "inbound: slash command failed", "text", text, "session", sessionKey, "error", err)
+		reply = commands.Reply{Text: "Command failed: " + err.Error()}
+	}
+	if reply.Forward != "" {
+		return reply.Forward, false
+	}
+
+	slog.Info("inbound: slash command", "text", text, "session", sessionKey)
+	deps.MsgBus.PublishOutbound(bus.OutboundMessage{
+		Channel:  msg.Channel,
+		ChatID:   msg.ChatID,
+		Content:  commands.Render(reply, commands.StyleFor(resolveChannelType(deps.ChannelMgr, msg.Channel))),
+		Metadata: outMeta,
+		TenantID: msg.TenantID,
+		AgentID:  agentLoop.UUID(),
+	})
+	return "", true
+}
+
+// isSessionMuted reports whether /mute is active for sessionKey, clearing
+// expired entries.
+func isSessionMuted(deps *ConsumerDeps, sessionKey string) bool {
+	v, ok := deps.MutedUntil.Load(sessionKey)
+	if !ok {
+		return false
+	}
+	if time.Now().Before(v.(time.Time)) {
+		return true
+	}
+	deps.MutedUntil.Delete(sessionKey)
+	return false
+}
+
+// sessionModelOverride returns the model chosen via /model for sessionKey.
+func sessionModelOverride(deps *ConsumerDeps, sessionKey string) string {
+	if v, ok := deps.ModelOverrides.Load(sessionKey); ok {
+		return v.(string)
+	}
+	return ""
+}
+
+func commandHelp(deps *ConsumerDeps, inv *commands.Invocation) commands.Reply {
+	var custom []commands.Command
+	if loop, err := deps.Agents.Get(context.Background(), inv.AgentID); err == nil {
+		custom = agentCustomCommands(loop.OtherConfig())
+	}
+	var lines []string
+	for _, c := range deps.Commands.List(inv.AgentID, custom...) {
+		line := "/" + c.Name
+		if c.Usage != "" {
+			line += " " + c.Usage
+		}
+		if c.Description != "" {
+			line += " — " + c.Description
+		}
+		lines = append(lines, line)
+	}
+	return commands.Reply{Title: "Available commands", Lines: lines, Text: "Any other message is sent to the agent."}
+}
+
+func commandModel(deps *ConsumerDeps, inv *commands.Invocation) commands.Reply {
+	if len(inv.Args) == 0 {
+		current := sessionModelOverride(deps, inv.SessionKey)
+		if current == "" {
+			if loop, err := deps.Agents.Get(context.Background(), inv.AgentID); err == nil {
+				return commands.Reply{Text: fmt.Sprintf("Model: %s (agent default)", loop.Model())}
+			}
+			return commands.Reply{Text: "Model: agent default"}
+		}
+		return commands.Reply{Text: fmt.Sprintf("Model: %s (set for this conversation)", current)}
+	}
+	if strings.EqualFold(inv.Args[0], "default") {
+		deps.ModelOverrides.Delete(inv.SessionKey)
+		return commands.Reply{Text: "Model reset to the agent default."}
+	}
+	deps.ModelOverrides.Store(inv.SessionKey, inv.Args[0])
+	return commands.Reply{Text: fmt.Sprintf("Model set to %s for this conversation.", inv.Args[0])}
+}
+
+func commandMemory(ctx context.Context, deps *ConsumerDeps, inv *commands.Invocation) (commands.Reply, error) {
+	if deps.MemoryStore == nil {
+		return commands.Reply{Text: "Memory is not available."}, nil
+	}
+	agentUUID, err := resolveCommandAgentUUID(ctx, deps, inv.AgentID)
+	if err != nil {
+		return commands.Reply{}, err
+	}
+	if inv.RawArgs == "" {
+		docs, err := deps.MemoryStore.ListDocuments(ctx, agentUUID.String(), inv.UserID)
+		if err != nil {
+			return commands.Reply{}, fmt.Errorf("list memory: %w", err)
+		}
+		if len(docs) == 0 {
+			return commands.Reply{Text: "No memory files yet."}, nil
+		}
+		var lines []string
+		for i, d := range docs {
+			if i == maxCommandResults {
+				lines = append(lines, fmt.Sprintf("… and %d more", len(docs)-i))
+				break
+			}
+			lines = append(lines, d.Path)
+		}
+		return commands.Reply{Title: "Memory files", Lines: lines}, nil
+	}
+
+	results, err := deps.MemoryStore.Search(ctx, inv.RawArgs, agentUUID.String(), inv.UserID, store.MemorySearchOptions{MaxResults: 5})
+	if err != nil {
+		return commands.Reply{}, fmt.Errorf("search memory: %w", err)
+	}
+	if len(results) == 0 {
+		return commands.Reply{Text: fmt.Sprintf("No memory matches for %q.", inv.RawArgs)}, nil
+	}
+	var lines []string
+	for _, r := range results {
+		lines = append(lines, fmt.Sprintf("%s:%d — %s", r.Path, r.StartLine, channels.Truncate(strings.Join(strings.Fields(r.Snippet), " "), 120)))
+	}
+	return commands.Reply{Title: fmt.Sprintf("Memory matches for %q", inv.RawArgs), Lines: lines}, nil
+}
+
+func commandTasks(ctx context.Context, deps *ConsumerDeps, inv *commands.Invocation) (commands.Reply, error) {
+	if deps.TeamStore == nil {
+		return commands.Reply{Text: "Team features are not available."}, nil
+	}
+	agentUUID, err := resolveCommandAgentUUID(ctx, deps, inv.AgentID)
+	if err != nil {
+		return commands.Reply{}, err
+	}
+	team, err := deps.TeamStore.GetTeamForAgent(ctx, agentUUID)
+	if err != nil {
+		return commands.Reply{}, fmt.Errorf("look up team: %w", err)
+	}
+	if team == nil {
+		return commands.Reply{Text: "This agent is not part of any team."}, nil
+	}
+	tasks, err := deps.TeamStore.ListTasks(ctx, team.ID, "newest", store.TeamTaskFilterAll, inv.UserID, "", "", 0, 0)
+	if err != nil {
+		return commands.Reply{}, fmt.Errorf("list tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		return commands.Reply{Text: fmt.Sprintf("No tasks for team %q.", team.Name)}, nil
+	}
+	var lines []string
+	for i, t := range tasks {
+		if i == maxCommandResults {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(tasks)-i))
+			break
+		}
+		line := fmt.Sprintf("[%s] %s", t.Status, t.Subject)
+		if t.OwnerAgentKey != "" {
+			line += " — @" + t.OwnerAgentKey
+		}
+		lines = append(lines, line)
+	}
+	return commands.Reply{Title: fmt.Sprintf("Tasks for team %q", team.Name), Lines: lines}, nil
+}
+
+// resolveCommandAgentUUID maps an agent key (or UUID string) to its UUID.
+func resolveCommandAgentUUID(ctx context.Context, deps *ConsumerDeps, agentKey string) (uuid.UUID, error) {
+	loop, err := deps.Agents.Get(ctx, agentKey)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if id := loop.UUID(); id != uuid.Nil {
+		return id, nil
+	}
+	return uuid.Nil, errors.New("agent has no ID")
+}
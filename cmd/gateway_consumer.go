@@ -26,7 +26,7 @@ import (
 // and routes them through the scheduler/agent loop, then publishes the response back.
 // Also handles subagent announcements: routes them through the parent agent's session
 // (matching TS subagent-announce.ts pattern) so the agent can reformulate for the user.
-func consumeInboundMessages(ctx context.Context, msgBus *bus.MessageBus, agents *agent.Router, cfg *config.Config, sched *scheduler.Scheduler, channelMgr *channels.Manager, teamStore store.TeamStore, quotaChecker *channels.QuotaChecker, sessStore store.SessionStore, agentStore store.AgentStore, contactCollector *store.ContactCollector, postTurn tools.PostTurnProcessor, subagentMgr *tools.SubagentManager) {
+func consumeInboundMessages(ctx context.Context, msgBus *bus.MessageBus, agents *agent.Router, cfg *config.Config, sched *scheduler.Scheduler, channelMgr *channels.Manager, teamStore store.TeamStore, quotaChecker *channels.QuotaChecker, sessStore store.SessionStore, agentStore store.AgentStore, contactCollector *store.ContactCollector, postTurn tools.PostTurnProcessor, subagentMgr *tools.SubagentManager, sysConfigs store.SystemConfigStore, memStore store.MemoryStore) {
 	slog.Info("inbound message consumer started")
 
 	// Inbound message deduplication (matching TS src/infra/dedupe.ts + inbound-dedupe.ts).
@@ -54,12 +54,16 @@ func consumeInboundMessages(ctx context.Context, msgBus *bus.MessageBus, agents
 		TeamStore:        teamStore,
 		AgentStore:       agentStore,
 		SessStore:        sessStore,
+		SystemConfigs:    sysConfigs,
+		MemoryStore:      memStore,
 		PostTurn:         postTurn,
 		QuotaChecker:     quotaChecker,
 		ContactCollector: contactCollector,
 		SubagentMgr:      subagentMgr,
 		GetAnnounceMu:    getAnnounceMu,
 	}
+	deps.Commands = newCommandRegistry(deps)
+	deps.Plans = newPlanRunner(deps)
 
 	// Track running teammate tasks so they can be cancelled when the task is
 	// cancelled/failed externally (e.g. lead cancels via team_tasks tool).
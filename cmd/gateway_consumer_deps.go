@@ -6,7 +6,9 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/commands"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/plans"
 	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
@@ -23,6 +25,8 @@ type ConsumerDeps struct {
 	TeamStore        store.TeamStore
 	AgentStore       store.AgentStore
 	SessStore        store.SessionStore
+	SystemConfigs    store.SystemConfigStore // per-user accessibility prefs (nil = defaults)
+	MemoryStore      store.MemoryStore       // for /memory (nil = unavailable)
 	PostTurn         tools.PostTurnProcessor
 	QuotaChecker     *channels.QuotaChecker
 	ContactCollector *store.ContactCollector
@@ -30,4 +34,15 @@ type ConsumerDeps struct {
 	SubagentMgr      *tools.SubagentManager
 	BgWg             sync.WaitGroup
 	GetAnnounceMu    func(string) *sync.Mutex
+
+	// Slash commands and the per-session state they manage.
+	Commands       *commands.Registry
+	ModelOverrides sync.Map // sessionKey → model name set via /model
+	MutedUntil     sync.Map // sessionKey → time.Time set via /mute
+
+	// Runs held until the sender replies /confirm (agent cost_confirm).
+	PendingCostConfirms sync.Map // sessionKey → pendingCostConfirm
+
+	// Multi-step plan runs driven by /plan (nil = unavailable).
+	Plans *plans.Runner
 }
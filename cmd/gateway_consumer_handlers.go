@@ -393,22 +393,16 @@ func handleResetCommand(
 		return false
 	}
 
-	agentID := msg.AgentID
-	if agentID == "" {
-		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind)
-	}
 	peerKind := msg.PeerKind
 	if peerKind == "" {
 		peerKind = string(sessions.PeerDirect)
 	}
-	sessionKey := sessions.BuildScopedSessionKey(agentID, msg.Channel, sessions.PeerKind(peerKind), msg.ChatID)
-	if msg.Metadata[tools.MetaIsForum] == "true" && peerKind == string(sessions.PeerGroup) {
-		var topicID int
-		fmt.Sscanf(msg.Metadata[tools.MetaMessageThreadID], "%d", &topicID)
-		if topicID > 0 {
-			sessionKey = sessions.BuildGroupTopicSessionKey(agentID, msg.Channel, msg.ChatID, topicID)
-		}
+	thread := inboundThread(msg, peerKind)
+	agentID := msg.AgentID
+	if agentID == "" {
+		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind, thread)
 	}
+	sessionKey := inboundSessionKey(agentID, msg, peerKind, thread)
 	ctx := store.WithTenantID(context.Background(), msg.TenantID)
 	deps.SessStore.Reset(ctx, sessionKey)
 	deps.SessStore.Save(ctx, sessionKey)
@@ -429,29 +423,16 @@ func handleStopCommand(
 		return false
 	}
 
-	agentID := msg.AgentID
-	if agentID == "" {
-		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind)
-	}
 	peerKind := msg.PeerKind
 	if peerKind == "" {
 		peerKind = string(sessions.PeerDirect)
 	}
-	sessionKey := sessions.BuildScopedSessionKey(agentID, msg.Channel, sessions.PeerKind(peerKind), msg.ChatID)
-	if msg.Metadata[tools.MetaIsForum] == "true" && peerKind == string(sessions.PeerGroup) {
-		var topicID int
-		fmt.Sscanf(msg.Metadata[tools.MetaMessageThreadID], "%d", &topicID)
-		if topicID > 0 {
-			sessionKey = sessions.BuildGroupTopicSessionKey(agentID, msg.Channel, msg.ChatID, topicID)
-		}
-	}
-	if msg.Metadata[tools.MetaDMThreadID] != "" && peerKind == string(sessions.PeerDirect) {
-		var threadID int
-		fmt.Sscanf(msg.Metadata[tools.MetaDMThreadID], "%d", &threadID)
-		if threadID > 0 {
-			sessionKey = sessions.BuildDMThreadSessionKey(agentID, msg.Channel, msg.ChatID, threadID)
-		}
+	thread := inboundThread(msg, peerKind)
+	agentID := msg.AgentID
+	if agentID == "" {
+		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind, thread)
 	}
+	sessionKey := inboundSessionKey(agentID, msg, peerKind, thread)
 
 	// sessStore is referenced in the original code but not used in this branch beyond
 	// session key construction; kept as parameter for API consistency.
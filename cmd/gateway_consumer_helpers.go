@@ -1,23 +1,30 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"mime"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 )
 
 // resolveAgentRoute determines which agent should handle a message
 // based on config bindings. Priority: peer → channel → default.
 // Matching TS resolve-route.ts binding resolution.
-func resolveAgentRoute(cfg *config.Config, channel, chatID, peerKind string) string {
+// A peer binding with a thread only matches messages in that topic/thread.
+func resolveAgentRoute(cfg *config.Config, channel, chatID, peerKind string, thread sessions.Thread) string {
 	for _, binding := range cfg.Bindings {
 		match := binding.Match
 		if match.Channel != channel {
@@ -26,7 +33,8 @@ func resolveAgentRoute(cfg *config.Config, channel, chatID, peerKind string) str
 
 		// Peer-level match (most specific)
 		if match.Peer != nil {
-			if match.Peer.Kind == peerKind && match.Peer.ID == chatID {
+			if match.Peer.Kind == peerKind && match.Peer.ID == chatID &&
+				(match.Peer.Thread == "" || match.Peer.Thread == thread.ID) {
 				return config.NormalizeAgentID(binding.AgentID)
 			}
 			continue // has peer constraint but doesn't match — skip
@@ -39,6 +47,67 @@ func resolveAgentRoute(cfg *config.Config, channel, chatID, peerKind string) str
 	return cfg.ResolveDefaultAgentID()
 }
 
+// inboundThread returns the thread an inbound message belongs to: a Telegram
+// forum topic or DM thread, or a thread local_key (Slack threads, AI panel).
+// Returns the zero Thread for messages addressed to the whole chat.
+func inboundThread(msg bus.InboundMessage, peerKind string) sessions.Thread {
+	switch {
+	case peerKind == string(sessions.PeerDirect) && msg.Metadata[tools.MetaDMThreadID] != "":
+		var threadID int
+		fmt.Sscanf(msg.Metadata[tools.MetaDMThreadID], "%d", &threadID)
+		if threadID > 0 {
+			return sessions.Thread{Kind: sessions.ThreadReply, ID: strconv.Itoa(threadID)}
+		}
+	case peerKind == string(sessions.PeerGroup) && msg.Metadata[tools.MetaIsForum] == "true":
+		// TS ref: buildTelegramGroupPeerId() in src/telegram/bot/helpers.ts
+		var topicID int
+		fmt.Sscanf(msg.Metadata[tools.MetaMessageThreadID], "%d", &topicID)
+		if topicID > 0 {
+			return sessions.Thread{Kind: sessions.ThreadTopic, ID: strconv.Itoa(topicID)}
+		}
+	}
+	if _, threadID, ok := strings.Cut(msg.Metadata["local_key"], ":thread:"); ok && threadID != "" {
+		return sessions.Thread{Kind: sessions.ThreadReply, ID: threadID}
+	}
+	return sessions.Thread{}
+}
+
+// inboundSessionKey builds the session key for an inbound channel message,
+// isolating history per topic/thread when the message carries one.
+func inboundSessionKey(agentID string, msg bus.InboundMessage, peerKind string, thread sessions.Thread) string {
+	return sessions.BuildThreadedSessionKey(agentID, msg.Channel, sessions.PeerKind(peerKind), msg.ChatID, thread)
+}
+
+// inboundUserID returns the user scope for context files, memory and traces.
+//   - Discord guilds: "guild:{guildID}:user:{senderID}" — per-user per-server,
+//     shared across all channels within the same server. Session key stays per-channel.
+//   - Other groups: "group:{channel}:{chatID}" — shared by all users in the chat.
+//   - DMs: the sender's user ID.
+func inboundUserID(msg bus.InboundMessage, peerKind string) string {
+	if peerKind != string(sessions.PeerGroup) || msg.ChatID == "" {
+		return msg.UserID
+	}
+	if guildID := msg.Metadata["guild_id"]; guildID != "" && msg.SenderID != "" {
+		return fmt.Sprintf("guild:%s:user:%s", guildID, msg.SenderID)
+	}
+	return fmt.Sprintf("group:%s:%s", msg.Channel, msg.ChatID)
+}
+
+// fairShareKey returns the scheduler fair-share key for an inbound run: the
+// user scope from inboundUserID, prefixed by the tenant outside the master
+// tenant. Internal senders get "" so system work is never capped. Only
+// channel runs are scheduled with a key: WS chat.send and HTTP chat call the
+// agent loop directly and never enter a lane.
+func fairShareKey(msg bus.InboundMessage, userID string) string {
+	if userID == "" || bus.IsInternalSender(msg.SenderID) {
+		return ""
+	}
+	if msg.TenantID != uuid.Nil && msg.TenantID != store.MasterTenantID {
+		return msg.TenantID.String() + "/" + userID
+	}
+	return userID
+}
+
 // overrideSessionKeyFromLocalKey extracts topic/thread ID from the composite
 // local_key and returns the correct session key for forum topics or DM threads.
 // If localKey is empty or has no suffix, the original sessionKey is returned unchanged.
@@ -205,3 +274,15 @@ func resolveSenderName(msg bus.InboundMessage) string {
 	}
 	return ""
 }
+
+// loadAccessibilityPrefs resolves the recipient's accessibility preferences for
+// the outbound reply. Lookup failures are logged and treated as defaults so a
+// bad preference row never blocks delivery.
+func loadAccessibilityPrefs(ctx context.Context, sysConfigs store.SystemConfigStore, userID string) *bus.AccessibilityPrefs {
+	prefs, err := store.LoadAccessibilityPrefs(ctx, sysConfigs, userID)
+	if err != nil {
+		slog.Warn("accessibility: ignoring invalid prefs", "user_id", userID, "error", err)
+		return nil
+	}
+	return prefs
+}
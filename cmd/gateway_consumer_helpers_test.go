@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+func TestInboundThread(t *testing.T) {
+	cases := []struct {
+		name     string
+		peerKind string
+		meta     map[string]string
+		want     sessions.Thread
+	}{
+		{"plain group", "group", nil, sessions.Thread{}},
+		{"forum topic", "group", map[string]string{tools.MetaIsForum: "true", tools.MetaMessageThreadID: "99"}, sessions.Thread{Kind: sessions.ThreadTopic, ID: "99"}},
+		{"forum general topic", "group", map[string]string{tools.MetaIsForum: "true", tools.MetaMessageThreadID: "0"}, sessions.Thread{}},
+		{"dm thread", "direct", map[string]string{tools.MetaDMThreadID: "7"}, sessions.Thread{Kind: sessions.ThreadReply, ID: "7"}},
+		{"slack thread", "group", map[string]string{"local_key": "C123:thread:1712345678.000100"}, sessions.Thread{Kind: sessions.ThreadReply, ID: "1712345678.000100"}},
+	}
+	for _, tc := range cases {
+		got := inboundThread(bus.InboundMessage{Channel: "telegram", ChatID: "-100", Metadata: tc.meta}, tc.peerKind)
+		if got != tc.want {
+			t.Errorf("%s: inboundThread = %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestResolveAgentRoute_ThreadBinding(t *testing.T) {
+	cfg := &config.Config{Bindings: []config.AgentBinding{
+		{AgentID: "support", Match: config.BindingMatch{Channel: "telegram", Peer: &config.BindingPeer{Kind: "group", ID: "-100", Thread: "42"}}},
+		{AgentID: "general", Match: config.BindingMatch{Channel: "telegram", Peer: &config.BindingPeer{Kind: "group", ID: "-100"}}},
+	}}
+	topic := sessions.Thread{Kind: sessions.ThreadTopic, ID: "42"}
+	if got := resolveAgentRoute(cfg, "telegram", "-100", "group", topic); got != "support" {
+		t.Errorf("topic 42 routed to %q, want support", got)
+	}
+	if got := resolveAgentRoute(cfg, "telegram", "-100", "group", sessions.Thread{Kind: sessions.ThreadTopic, ID: "7"}); got != "general" {
+		t.Errorf("topic 7 routed to %q, want general", got)
+	}
+	if got := resolveAgentRoute(cfg, "telegram", "-100", "group", sessions.Thread{}); got != "general" {
+		t.Errorf("whole chat routed to %q, want general", got)
+	}
+}
+
+func TestFairShareKey(t *testing.T) {
+	tenant := uuid.MustParse("0193a5b0-7000-7000-8000-0000000000aa")
+	cases := []struct {
+		name string
+		msg  bus.InboundMessage
+		want string
+	}{
+		{"master tenant", bus.InboundMessage{SenderID: "42", TenantID: store.MasterTenantID}, "u-42"},
+		{"no tenant", bus.InboundMessage{SenderID: "42"}, "u-42"},
+		{"other tenant", bus.InboundMessage{SenderID: "42", TenantID: tenant}, tenant.String() + "/u-42"},
+		{"internal sender", bus.InboundMessage{SenderID: "system:cron"}, ""},
+	}
+	for _, tc := range cases {
+		if got := fairShareKey(tc.msg, "u-42"); got != tc.want {
+			t.Errorf("%s: fairShareKey = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
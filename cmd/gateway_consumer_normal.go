@@ -14,10 +14,12 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/channels/telegram/voiceguard"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
 	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/sessions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 // processNormalMessage handles routing, scheduling, and response delivery for a single
@@ -27,6 +29,8 @@ func processNormalMessage(
 	msg bus.InboundMessage,
 	deps *ConsumerDeps,
 ) {
+	ctx = requestid.With(ctx, msg.RequestID)
+
 	// Inject tenant from channel instance into context so all store operations
 	// (agent lookup, session creation, etc.) are tenant-scoped.
 	if msg.TenantID != uuid.Nil {
@@ -35,10 +39,17 @@ func processNormalMessage(
 		ctx = store.WithTenantID(ctx, store.MasterTenantID)
 	}
 
+	// Build session key based on scope config (matching TS buildAgentPeerSessionKey).
+	peerKind := msg.PeerKind
+	if peerKind == "" {
+		peerKind = string(sessions.PeerDirect) // default to DM
+	}
+	thread := inboundThread(msg, peerKind)
+
 	// Determine target agent via bindings or explicit AgentID
 	agentID := msg.AgentID
 	if agentID == "" {
-		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind)
+		agentID = resolveAgentRoute(deps.Cfg, msg.Channel, msg.ChatID, msg.PeerKind, thread)
 	}
 
 	agentLoop, err := deps.Agents.Get(ctx, agentID)
@@ -47,56 +58,12 @@ func processNormalMessage(
 		return
 	}
 
-	// Build session key based on scope config (matching TS buildAgentPeerSessionKey).
-	peerKind := msg.PeerKind
-	if peerKind == "" {
-		peerKind = string(sessions.PeerDirect) // default to DM
-	}
-	sessionKey := sessions.BuildScopedSessionKey(agentID, msg.Channel, sessions.PeerKind(peerKind), msg.ChatID)
-
-	// Thread-based isolation override (e.g. Slack DM threads, AI Panel)
-	if lk := msg.Metadata["local_key"]; lk != "" && strings.Contains(lk, ":thread:") {
-		parts := strings.SplitN(lk, ":thread:", 2)
-		if len(parts) == 2 {
-			sessionKey = sessions.BuildScopedThreadSessionKey(agentID, msg.Channel, sessions.PeerKind(peerKind), msg.ChatID, parts[1])
-		}
-	}
-
-	// Forum topic: override session key to isolate per-topic history.
-	// TS ref: buildTelegramGroupPeerId() in src/telegram/bot/helpers.ts
-	if msg.Metadata[tools.MetaIsForum] == "true" && peerKind == string(sessions.PeerGroup) {
-		var topicID int
-		fmt.Sscanf(msg.Metadata[tools.MetaMessageThreadID], "%d", &topicID)
-		if topicID > 0 {
-			sessionKey = sessions.BuildGroupTopicSessionKey(agentID, msg.Channel, msg.ChatID, topicID)
-		}
-	}
-
-	// DM thread: override session key to isolate per-thread history in private chats.
-	if msg.Metadata[tools.MetaDMThreadID] != "" && peerKind == string(sessions.PeerDirect) {
-		var threadID int
-		fmt.Sscanf(msg.Metadata[tools.MetaDMThreadID], "%d", &threadID)
-		if threadID > 0 {
-			sessionKey = sessions.BuildDMThreadSessionKey(agentID, msg.Channel, msg.ChatID, threadID)
-		}
-	}
+	// Forum topics and threads get their own history.
+	sessionKey := inboundSessionKey(agentID, msg, peerKind, thread)
 
 	// Group-scoped UserID: context files, memory, traces, and seeding scope.
-	// - Discord guilds: "guild:{guildID}:user:{senderID}" — per-user per-server,
-	//   shared across all channels within the same server. Session key stays per-channel.
-	// - Other platforms: "group:{channel}:{chatID}" — shared by all users in the chat.
 	// Individual senderID is preserved in InboundMessage for pairing/dedup/mention gate.
-	userID := msg.UserID
-	if peerKind == string(sessions.PeerGroup) && msg.ChatID != "" {
-		if guildID := msg.Metadata["guild_id"]; guildID != "" && msg.SenderID != "" {
-			// Discord guild: per-user scope so each member has own profile
-			// across all channels in the same server.
-			userID = fmt.Sprintf("guild:%s:user:%s", guildID, msg.SenderID)
-		} else {
-			groupID := msg.ChatID
-			userID = fmt.Sprintf("group:%s:%s", msg.Channel, groupID)
-		}
-	}
+	userID := inboundUserID(msg, peerKind)
 
 	// Persist friendly names from channel metadata into session + user profile.
 	sessionMeta := extractSessionMetadata(msg, peerKind)
@@ -157,7 +124,29 @@ func processNormalMessage(
 		}
 	}
 
+	// --- Slash commands ---
+	// Built-in and agent commands are answered without an LLM call; custom
+	// prompt commands replace the message text sent to the agent.
+	if forward, handled := handleSlashCommand(ctx, msg, deps, agentLoop, agentID, sessionKey, userID, channels.CopyFinalRoutingMeta(msg.Metadata)); handled {
+		return
+	} else if forward != "" {
+		msg.Content = forward
+	}
+	if isSessionMuted(deps, sessionKey) {
+		slog.Info("inbound: session muted, dropping message", "session", sessionKey, "channel", msg.Channel)
+		return
+	}
+
+	// --- Cost confirmation ---
+	// Runs predicted to exceed the agent's cost_confirm threshold wait for /confirm.
+	if requireCostConfirmation(ctx, deps, agentLoop, msg, sessionKey, userID, channels.CopyFinalRoutingMeta(msg.Metadata)) {
+		return
+	}
+
 	// --- Quota check ---
+	// Requests past a limit are rejected; requests nearing a limit (or an agent
+	// nearing its monthly budget) carry a notice in the reply and alert admins.
+	var quotaNotices []string
 	if deps.QuotaChecker != nil {
 		qResult := deps.QuotaChecker.Check(ctx, userID, msg.Channel, agentLoop.ProviderName())
 		if !qResult.Allowed {
@@ -177,7 +166,22 @@ func processNormalMessage(
 			return
 		}
 		deps.QuotaChecker.Increment(userID)
+		if qResult.Warning {
+			quotaNotices = append(quotaNotices, formatQuotaWarning(qResult))
+			publishQuotaWarning(ctx, deps, "user", userID, agentID, msg.Channel, qResult)
+		}
+		if loop, ok := agentLoop.(*agent.Loop); ok {
+			if bResult := deps.QuotaChecker.CheckAgentBudget(ctx, loop.UUID(), loop.BudgetMonthlyCents()); bResult.Warning {
+				quotaNotices = append(quotaNotices, formatQuotaWarning(bResult))
+				publishQuotaWarning(ctx, deps, "agent", userID, agentID, msg.Channel, bResult)
+			}
+		}
 	}
+	quotaNotice := strings.Join(quotaNotices, "\n")
+
+	// --- Backpressure ---
+	// A saturated lane still accepts the message; the sender learns their place in line.
+	notifyLaneBackpressure(deps, scheduler.LaneMain, msg, channels.CopyFinalRoutingMeta(msg.Metadata), agentLoop.UUID())
 
 	// Auto-clear followup reminders when user sends a message on a real channel.
 	// Fire-and-forget: don't block message processing.
@@ -397,13 +401,31 @@ func processNormalMessage(
 		Role:              effectiveRole,
 		SenderName:        resolveSenderName(msg),
 		RunID:             runID,
+		RequestID:         msg.RequestID,
 		Stream:            enableStream,
 		HistoryLimit:      msg.HistoryLimit,
 		ToolAllow:         msg.ToolAllow,
 		ExtraSystemPrompt: extraPrompt,
 		SkillFilter:       skillFilter,
+		QuotaNotice:       quotaNotice,
+		ModelOverride:     sessionModelOverride(deps, sessionKey),
 	}, scheduler.ScheduleOpts{
 		MaxConcurrent: maxConcurrent,
+		User:          fairShareKey(msg, userID),
+		OnSteered: func() {
+			locale := msg.Metadata["locale"]
+			if locale == "" {
+				locale = "en"
+			}
+			deps.MsgBus.PublishOutbound(bus.OutboundMessage{
+				Channel:  msg.Channel,
+				ChatID:   msg.ChatID,
+				Content:  i18n.T(locale, i18n.MsgInjectedAck),
+				Metadata: outMeta,
+				TenantID: msg.TenantID,
+				AgentID:  agentLoop.UUID(),
+			})
+		},
 	})
 
 	// Handle result asynchronously to not block the flush callback.
@@ -430,8 +452,9 @@ func processNormalMessage(
 		if outcome.Err != nil {
 			// Don't send error for cancelled runs (/stop command) —
 			// publish empty outbound to clean up thinking/typing indicators.
-			if errors.Is(outcome.Err, context.Canceled) {
-				slog.Info("inbound: run cancelled", "channel", channel, "session", session)
+			// A steered message was answered by the run it was injected into.
+			if errors.Is(outcome.Err, context.Canceled) || errors.Is(outcome.Err, scheduler.ErrMessageSteered) {
+				slog.Info("inbound: run cancelled or steered", "channel", channel, "session", session, "error", outcome.Err)
 				deps.MsgBus.PublishOutbound(bus.OutboundMessage{
 					Channel:  channel,
 					ChatID:   chatID,
@@ -442,16 +465,21 @@ func processNormalMessage(
 				})
 				return
 			}
-			slog.Error("inbound: agent run failed", "error", outcome.Err, "channel", channel)
+			slog.ErrorContext(ctx, "inbound: agent run failed", "error", outcome.Err, "channel", channel)
 			// Suppress technical error text on public-facing channels (FB, Telegram, etc.)
 			// Empty Content still triggers placeholder/typing cleanup downstream.
+			// Budget rejections are not technical: the user is told why.
 			errContent := formatAgentError(outcome.Err)
-			if deps.ChannelMgr != nil {
+			var budgetErr *channels.BudgetExceededError
+			if deps.ChannelMgr != nil && !errors.As(outcome.Err, &budgetErr) {
 				if ct := deps.ChannelMgr.ChannelTypeForName(channel); isExternalChannel(ct) {
-					slog.Info("inbound: suppressed error for external channel", "channel", channel, "type", ct)
+					slog.InfoContext(ctx, "inbound: suppressed error for external channel", "channel", channel, "type", ct)
 					errContent = ""
 				}
 			}
+			if !errors.As(outcome.Err, &budgetErr) {
+				errContent = withErrorRef(errContent, requestid.FromContext(ctx))
+			}
 			deps.MsgBus.PublishOutbound(bus.OutboundMessage{
 				Channel:  channel,
 				ChatID:   chatID,
@@ -485,13 +513,21 @@ func processNormalMessage(
 		// Dedup: if block replies were delivered and the final content matches the last
 		// block reply, suppress the final message to avoid duplicate delivery.
 		// Only applies when blockReply is enabled (otherwise nothing was delivered).
-		if blockReplyEnabled && outcome.Result.BlockReplies > 0 && outcome.Result.Content == outcome.Result.LastBlockReply && len(outcome.Result.Media) == 0 {
+		// A quota notice appended by the pipeline is ignored for the comparison and
+		// delivered on its own.
+		finalBody, dedupContent := outcome.Result.Content, ""
+		if quotaNotice != "" {
+			if body, ok := strings.CutSuffix(finalBody, "\n\n"+quotaNotice); ok {
+				finalBody, dedupContent = body, quotaNotice
+			}
+		}
+		if blockReplyEnabled && outcome.Result.BlockReplies > 0 && finalBody == outcome.Result.LastBlockReply && len(outcome.Result.Media) == 0 {
 			slog.Debug("inbound: dedup final message (matches last block reply)",
 				"channel", channel, "run_id", rID)
 			deps.MsgBus.PublishOutbound(bus.OutboundMessage{
 				Channel:  channel,
 				ChatID:   chatID,
-				Content:  "",
+				Content:  dedupContent,
 				Metadata: meta,
 				TenantID: tenantID,
 				AgentID:  agentUUID,
@@ -517,6 +553,7 @@ func processNormalMessage(
 			TenantID:         tenantID,
 			AgentID:          agentUUID,
 			AgentOtherConfig: agentOtherConfig,
+			Accessibility:    loadAccessibilityPrefs(store.WithTenantID(ctx, tenantID), deps.SystemConfigs, userID),
 		}
 
 		appendMediaToOutbound(&outMsg, outcome.Result.Media)
@@ -529,3 +566,26 @@ func processNormalMessage(
 		}
 	}(agentID, msg.Channel, msg.ChatID, sessionKey, runID, peerKind, msg.Content, outMeta, blockReply, ptd, msg.TenantID, agentLoop.UUID(), agentLoop.OtherConfig())
 }
+
+// publishQuotaWarning logs a quota/budget warning and alerts tenant admins via
+// a WS event. scope is "user" (request quota) or "agent" (monthly budget).
+func publishQuotaWarning(ctx context.Context, deps *ConsumerDeps, scope, userID, agentID, channel string, result channels.QuotaResult) {
+	slog.Warn("security.quota_warning",
+		"scope", scope,
+		"user_id", userID,
+		"agent", agentID,
+		"channel", channel,
+		"window", result.Window,
+		"used", result.Used,
+		"limit", result.Limit,
+	)
+	bus.BroadcastForTenant(deps.MsgBus, protocol.EventQuotaWarning, store.TenantIDFromContext(ctx), map[string]any{
+		"scope":   scope,
+		"userId":  userID,
+		"agentId": agentID,
+		"channel": channel,
+		"window":  result.Window,
+		"used":    result.Used,
+		"limit":   result.Limit,
+	})
+}
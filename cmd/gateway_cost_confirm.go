@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/commands"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/tracing"
+)
+
+const (
+	// costConfirmTTL is how long a run waits for /confirm before it is dropped.
+	costConfirmTTL = 10 * time.Minute
+
+	// imageTokenEstimate approximates the input tokens of one attached image.
+	imageTokenEstimate = 1600
+
+	// outputTokenEstimate is the output allowance priced into the estimate.
+	outputTokenEstimate = 1000
+
+	// metaCostConfirmed marks a message re-dispatched by /confirm.
+	metaCostConfirmed = "cost_confirmed"
+)
+
+// pendingCostConfirm is a message held back until its sender confirms it.
+type pendingCostConfirm struct {
+	msg       bus.InboundMessage
+	userID    string
+	expiresAt time.Time
+}
+
+// runCostEstimate is the predicted size of a run's first LLM call.
+type runCostEstimate struct {
+	InputTokens int
+	CostUSD     float64 // 0 when the model has no pricing configured
+}
+
+// estimateRunCost predicts the first LLM call of a run: session history,
+// the new message and attached images, priced with telemetry.model_pricing.
+func estimateRunCost(ctx context.Context, deps *ConsumerDeps, loop *agent.Loop, sessionKey, model string, msg bus.InboundMessage) runCostEstimate {
+	history := deps.SessStore.GetHistory(ctx, sessionKey)
+	lastPT, lastMC := deps.SessStore.GetLastPromptTokens(ctx, sessionKey)
+	tokens := agent.EstimateTokensWithCalibration(history, lastPT, lastMC)
+	tokens += agent.EstimateTokens([]providers.Message{{Role: "user", Content: msg.Content}})
+	for _, m := range msg.Media {
+		if strings.HasPrefix(m.MimeType, "image/") {
+			tokens += imageTokenEstimate
+		}
+	}
+
+	est := runCostEstimate{InputTokens: tokens}
+	if model == "" {
+		model = loop.Model()
+	}
+	if pricing := tracing.LookupPricing(deps.Cfg.Telemetry.ModelPricing, loop.ProviderName(), model); pricing != nil {
+		est.CostUSD = tracing.CalculateCost(pricing, &providers.Usage{PromptTokens: tokens, CompletionTokens: outputTokenEstimate})
+	}
+	return est
+}
+
+// requireCostConfirmation holds msg back when the agent's cost_confirm
+// threshold for the channel is exceeded, asking the sender to reply /confirm.
+// It returns true when the message was held.
+func requireCostConfirmation(ctx context.Context, deps *ConsumerDeps, agentLoop agent.Agent, msg bus.InboundMessage, sessionKey, userID string, outMeta map[string]string) bool {
+	if msg.Metadata[metaCostConfirmed] == "true" || bus.IsInternalSender(msg.SenderID) {
+		return false
+	}
+	loop, ok := agentLoop.(*agent.Loop)
+	if !ok {
+		return false
+	}
+	cfg := (&store.AgentData{OtherConfig: loop.OtherConfig()}).ParseCostConfirmConfig()
+	if cfg == nil {
+		return false
+	}
+	threshold := cfg.ThresholdFor(msg.Channel, resolveChannelType(deps.ChannelMgr, msg.Channel))
+	if !threshold.Enabled() {
+		return false
+	}
+
+	est := estimateRunCost(ctx, deps, loop, sessionKey, sessionModelOverride(deps, sessionKey), msg)
+	if !threshold.Exceeded(est.InputTokens, est.CostUSD) {
+		return false
+	}
+
+	deps.PendingCostConfirms.Store(sessionKey, pendingCostConfirm{
+		msg:       msg,
+		userID:    userID,
+		expiresAt: time.Now().Add(costConfirmTTL),
+	})
+	slog.Info("inbound: run held for cost confirmation",
+		"session", sessionKey, "tokens", est.InputTokens, "cost_usd", est.CostUSD)
+	deps.MsgBus.PublishOutbound(bus.OutboundMessage{
+		Channel:  msg.Channel,
+		ChatID:   msg.ChatID,
+		Content:  formatCostConfirmPrompt(est),
+		Metadata: outMeta,
+		TenantID: msg.TenantID,
+		AgentID:  loop.UUID(),
+	})
+	return true
+}
+
+func formatCostConfirmPrompt(est runCostEstimate) string {
+	size := fmt.Sprintf("~%dk input tokens", (est.InputTokens+500)/1000)
+	if est.CostUSD > 0 {
+		size += fmt.Sprintf(" (~$%.2f)", est.CostUSD)
+	}
+	return fmt.Sprintf("⚠️ This request is estimated at %s, above this agent's confirmation threshold. Reply /confirm within %d minutes to run it.",
+		size, int(costConfirmTTL.Minutes()))
+}
+
+// commandConfirm re-dispatches the caller's held message.
+func commandConfirm(ctx context.Context, deps *ConsumerDeps, inv *commands.Invocation) commands.Reply {
+	v, ok := deps.PendingCostConfirms.Load(inv.SessionKey)
+	if !ok {
+		return commands.Reply{Text: "Nothing is waiting for confirmation."}
+	}
+	pending := v.(pendingCostConfirm)
+	if time.Now().After(pending.expiresAt) {
+		deps.PendingCostConfirms.Delete(inv.SessionKey)
+		return commands.Reply{Text: "The request expired; please send it again."}
+	}
+	if pending.userID != inv.UserID {
+		return commands.Reply{Text: "Only the sender of the request can confirm it."}
+	}
+	deps.PendingCostConfirms.Delete(inv.SessionKey)
+
+	msg := pending.msg
+	meta := make(map[string]string, len(msg.Metadata)+1)
+	for k, v := range msg.Metadata {
+		meta[k] = v
+	}
+	meta[metaCostConfirmed] = "true"
+	msg.Metadata = meta
+	go processNormalMessage(context.WithoutCancel(ctx), msg, deps)
+	return commands.Reply{Text: "Confirmed, running it now."}
+}
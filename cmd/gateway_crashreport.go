@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/crashreport"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+)
+
+// configureCrashReporting installs a Sentry reporter for panics recovered by
+// safego when telemetry.sentry_dsn (or GOCLAW_SENTRY_DSN) is set. The returned
+// func flushes pending reports and should be deferred until shutdown.
+func configureCrashReporting(cfg *config.Config) func() {
+	dsn := cfg.Telemetry.SentryDSN
+	if dsn == "" {
+		return func() {}
+	}
+	s, err := crashreport.NewSentry(dsn, cfg.Telemetry.SentryEnv, Version)
+	if err != nil {
+		slog.Warn("crash reporting disabled", "error", err)
+		return func() {}
+	}
+	safego.SetReporter(s.Report)
+	slog.Info("crash reporting enabled", "provider", "sentry")
+	return func() {
+		safego.SetReporter(nil)
+		s.Close(5 * time.Second)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/dnd"
 	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/sessions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -24,14 +25,16 @@ import (
 // Safe because cron jobs only fire after Start(), well after this is set.
 var cronHeartbeatWakeFn func(agentID string)
 
-func makeCronJobHandler(sched *scheduler.Scheduler, msgBus *bus.MessageBus, cfg *config.Config, channelMgr *channels.Manager, sessionMgr store.SessionStore, agentStore store.AgentStore) func(job *store.CronJob) (*store.CronJobResult, error) {
+func makeCronJobHandler(sched *scheduler.Scheduler, msgBus *bus.MessageBus, cfg *config.Config, channelMgr *channels.Manager, sessionMgr store.SessionStore, agentStore store.AgentStore, dndGate *dnd.Gate) func(job *store.CronJob) (*store.CronJobResult, error) {
 	return func(job *store.CronJob) (*store.CronJobResult, error) {
 		agentID := job.AgentID
+		var agentUUID uuid.UUID // for the DND gate; Nil when unresolved
 		if agentID == "" && agentStore != nil {
 			// Resolve real default agent from DB instead of using literal "default" string.
 			tenantCtx := store.WithTenantID(context.Background(), job.TenantID)
 			if defaultAgent, err := agentStore.GetDefault(tenantCtx); err == nil {
 				agentID = defaultAgent.AgentKey
+				agentUUID = defaultAgent.ID
 			} else {
 				agentID = cfg.ResolveDefaultAgentID()
 			}
@@ -44,8 +47,14 @@ func makeCronJobHandler(sched *scheduler.Scheduler, msgBus *bus.MessageBus, cfg
 			if ag, err := agentStore.GetByID(cronCtx, id); err == nil {
 				agentID = ag.AgentKey
 			}
+			agentUUID = id
 		} else {
 			agentID = config.NormalizeAgentID(agentID)
+			if dndGate != nil && agentStore != nil {
+				if ag, err := agentStore.GetByKey(store.WithTenantID(context.Background(), job.TenantID), agentID); err == nil {
+					agentUUID = ag.ID
+				}
+			}
 		}
 
 		sessionKey := sessions.BuildCronSessionKey(agentID, job.ID)
@@ -126,15 +135,21 @@ func makeCronJobHandler(sched *scheduler.Scheduler, msgBus *bus.MessageBus, cfg
 		// If job wants delivery to a channel, send the agent response to the target chat.
 		if job.Deliver && job.DeliverChannel != "" && job.DeliverTo != "" {
 			outMsg := bus.OutboundMessage{
-				Channel: job.DeliverChannel,
-				ChatID:  job.DeliverTo,
-				Content: result.Content,
+				Channel:  job.DeliverChannel,
+				ChatID:   job.DeliverTo,
+				Content:  result.Content,
+				TenantID: job.TenantID,
+				AgentID:  agentUUID,
 			}
 			if peerKind == "group" {
 				outMsg.Metadata = map[string]string{"group_id": job.DeliverTo}
 			}
 			appendMediaToOutbound(&outMsg, result.Media)
-			msgBus.PublishOutbound(outMsg)
+			if dndGate != nil {
+				dndGate.Deliver(cronCtx, "cron", outMsg)
+			} else {
+				msgBus.PublishOutbound(outMsg)
+			}
 		} else if job.Deliver {
 			slog.Warn("cron: delivery configured but channel/chatID missing — output discarded",
 				"job_id", job.ID, "job_name", job.Name, "channel", job.DeliverChannel, "to", job.DeliverTo)
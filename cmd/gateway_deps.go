@@ -13,6 +13,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/store/pg"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 	"github.com/nextlevelbuilder/goclaw/internal/vault"
 )
@@ -28,13 +29,14 @@ type gatewayDeps struct {
 	channelMgr       *channels.Manager
 	agentRouter      *agent.Router
 	toolsReg         *tools.Registry
-	skillsLoader     *skills.Loader // optional: enables skill creation in evolution approval
+	skillsLoader     *skills.Loader         // optional: enables skill creation in evolution approval
 	permCache        *cache.PermissionCache // nil if no tenant store; closed on shutdown to stop sweep goroutines
-	enrichProgress *vault.EnrichProgress // nil if enrichment worker not registered
-	enrichWorker   *vault.EnrichWorker  // nil if enrichment worker not registered; for stop/enqueue
+	enrichProgress   *vault.EnrichProgress  // nil if enrichment worker not registered
+	enrichWorker     *vault.EnrichWorker    // nil if enrichment worker not registered; for stop/enqueue
 	workspace        string
 	dataDir          string
 	domainBus        eventbus.DomainEventBus
-	audioMgr         *audio.Manager      // nil if TTS not configured; used by TTSHandler
-	ttsHandler       *httpapi.TTSHandler // nil if TTS not configured; for hot-reload
+	audioMgr         *audio.Manager          // nil if TTS not configured; used by TTSHandler
+	ttsHandler       *httpapi.TTSHandler     // nil if TTS not configured; for hot-reload
+	embMigrator      *pg.PGEmbeddingMigrator // nil without an embedding provider; serves memory reembed
 }
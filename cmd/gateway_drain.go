@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	defaultDrainTimeout = 30 * time.Second
+	sessionFlushTimeout = 10 * time.Second
+)
+
+// drainTimeout returns gateway.drain_timeout_sec as a duration (default 30s).
+func drainTimeout(sec int) time.Duration {
+	if sec <= 0 {
+		return defaultDrainTimeout
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// drain puts the gateway into drain mode on SIGTERM: chat.send, HTTP and
+// channel runs are refused, the listener is handed to a replacement process
+// when gateway.reuse_port is set, in-flight runs get up to
+// gateway.drain_timeout_sec to finish, and cached sessions are flushed.
+func (d *gatewayDeps) drain(sched *scheduler.Scheduler) {
+	d.agentRouter.MarkDraining()
+	if sched != nil {
+		sched.MarkDraining()
+	}
+	if d.cfg.Gateway.ReusePort {
+		d.server.ReleaseListener()
+	}
+
+	if n := d.agentRouter.ActiveRunCount(); n > 0 {
+		timeout := drainTimeout(d.cfg.Gateway.DrainTimeoutSec)
+		slog.Info("gateway: draining in-flight runs", "runs", n, "timeout", timeout)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		left := d.agentRouter.WaitForActiveRuns(ctx)
+		cancel()
+		if left > 0 {
+			slog.Warn("gateway: drain deadline reached, remaining runs will be cancelled", "runs", left)
+		} else {
+			slog.Info("gateway: all in-flight runs finished")
+		}
+	}
+
+	if f, ok := d.pgStores.Sessions.(store.SessionFlusher); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), sessionFlushTimeout)
+		if err := f.FlushAll(ctx); err != nil {
+			slog.Warn("gateway: session flush failed", "error", err)
+		}
+		cancel()
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -8,9 +9,24 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 )
 
+// withErrorRef appends the request's correlation ID to a user-facing error so
+// it can be quoted in bug reports. Empty (suppressed) messages stay empty.
+func withErrorRef(msg, requestID string) string {
+	if msg == "" || requestID == "" {
+		return msg
+	}
+	return msg + "\n(ref: " + requestID + ")"
+}
+
 // Matching TS pi-embedded-helpers/errors.ts error classification.
 // Never expose raw JSON/API payloads to the user.
 func formatAgentError(err error) string {
+	// 0. Budget cap — our own rejection, safe and useful to show as-is.
+	var budgetErr *channels.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return budgetErr.UserMessage()
+	}
+
 	raw := err.Error()
 	lower := strings.ToLower(raw)
 
@@ -128,6 +144,18 @@ func containsAny(s string, substrs ...string) bool {
 	return false
 }
 
+// formatQuotaWarning formats the notice appended to a reply when a user nears
+// a request quota or the agent nears its monthly budget.
+func formatQuotaWarning(result channels.QuotaResult) string {
+	if result.Window == "month" {
+		return fmt.Sprintf("ℹ️ This assistant has used %d%% of its monthly budget.",
+			result.Used*100/result.Limit)
+	}
+	labels := map[string]string{"hour": "hourly", "day": "daily", "week": "weekly"}
+	return fmt.Sprintf("ℹ️ You have used %d of %d %s requests.",
+		result.Used, result.Limit, labels[result.Window])
+}
+
 // formatQuotaExceeded formats a user-friendly quota exceeded message.
 func formatQuotaExceeded(result channels.QuotaResult) string {
 	labels := map[string]string{"hour": "Hourly", "day": "Daily", "week": "Weekly"}
@@ -47,3 +47,18 @@ func TestIsExternalChannel(t *testing.T) {
 		})
 	}
 }
+
+func TestWithErrorRef(t *testing.T) {
+	t.Parallel()
+
+	if got := withErrorRef("⚠️ Request timed out.", "a1b2"); got != "⚠️ Request timed out.\n(ref: a1b2)" {
+		t.Errorf("withErrorRef = %q", got)
+	}
+	// Suppressed errors (external channels) must stay empty.
+	if got := withErrorRef("", "a1b2"); got != "" {
+		t.Errorf("withErrorRef on empty message = %q, want empty", got)
+	}
+	if got := withErrorRef("⚠️ Oops", ""); got != "⚠️ Oops" {
+		t.Errorf("withErrorRef without id = %q", got)
+	}
+}
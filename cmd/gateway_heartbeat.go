@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/dnd"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway/methods"
 	"github.com/nextlevelbuilder/goclaw/internal/heartbeat"
@@ -40,9 +42,10 @@ func startCronAndHeartbeat(
 	cfg *config.Config,
 	heartbeatTool *tools.HeartbeatTool,
 	heartbeatMethods *methods.HeartbeatMethods,
+	dndGate *dnd.Gate,
 ) *heartbeat.Ticker {
 	// Start cron service with job handler (routes through scheduler's cron lane)
-	pgStores.Cron.SetOnJob(makeCronJobHandler(sched, msgBus, cfg, channelMgr, pgStores.Sessions, pgStores.Agents))
+	pgStores.Cron.SetOnJob(makeCronJobHandler(sched, msgBus, cfg, channelMgr, pgStores.Sessions, pgStores.Agents, dndGate))
 	pgStores.Cron.SetOnEvent(func(event store.CronEvent) {
 		server.BroadcastEvent(*protocol.NewEvent(protocol.EventCron, event))
 	})
@@ -58,6 +61,7 @@ func startCronAndHeartbeat(
 		ProviderStore: pgStores.Providers,
 		ProviderReg:   providerRegistry,
 		MsgBus:        msgBus,
+		DND:           dndGate,
 		Sched:         sched,
 		RunAgent:      makeHeartbeatRunFn(sched),
 	})
@@ -66,6 +70,19 @@ func startCronAndHeartbeat(
 	})
 	heartbeatTicker.Start()
 
+	// Per-agent overrides from agents.list.<key>.heartbeat, re-applied on config reload.
+	applyConfigHeartbeats(pgStores.Agents, heartbeatTicker, cfg.Agents.List)
+	msgBus.Subscribe("heartbeat-config-reload", func(evt bus.Event) {
+		if evt.Name != bus.TopicConfigChanged {
+			return
+		}
+		updatedCfg, ok := evt.Payload.(*config.Config)
+		if !ok {
+			return
+		}
+		applyConfigHeartbeats(pgStores.Agents, heartbeatTicker, updatedCfg.Agents.List)
+	})
+
 	// Wire heartbeat wake function to tool + RPC + cron wakeMode
 	heartbeatTool.SetWakeFn(heartbeatTicker.Wake)
 	heartbeatMethods.SetWakeFn(heartbeatTicker.Wake)
@@ -92,3 +109,47 @@ func startCronAndHeartbeat(
 
 	return heartbeatTicker
 }
+
+// applyConfigHeartbeats reconciles heartbeat overrides declared in the config
+// agent list into the DB-backed heartbeats. Agents without a heartbeat block
+// are left untouched so UI/RPC edits survive.
+func applyConfigHeartbeats(agents store.AgentStore, ticker *heartbeat.Ticker, specs map[string]config.AgentSpec) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	for key, spec := range specs {
+		if spec.Heartbeat == nil {
+			continue
+		}
+		o, err := heartbeatOverride(spec.Heartbeat)
+		if err != nil {
+			slog.Warn("heartbeat: invalid config override", "agent", key, "error", err)
+			continue
+		}
+		ag, err := agents.GetByKey(ctx, key)
+		if err != nil {
+			slog.Warn("heartbeat: config agent not found", "agent", key, "error", err)
+			continue
+		}
+		if err := ticker.Reconfigure(ctx, ag.ID, o); err != nil {
+			slog.Warn("heartbeat: apply config override failed", "agent", key, "error", err)
+		}
+	}
+}
+
+// heartbeatOverride converts a config heartbeat spec to a ticker override.
+func heartbeatOverride(spec *config.HeartbeatSpec) (heartbeat.Override, error) {
+	o := heartbeat.Override{
+		Enabled:     spec.Enabled,
+		ActiveHours: spec.ActiveHours,
+		Timezone:    spec.Timezone,
+		Channel:     spec.Channel,
+		ChatID:      spec.ChatID,
+	}
+	if spec.Every != "" {
+		d, err := time.ParseDuration(spec.Every)
+		if err != nil {
+			return o, err
+		}
+		o.IntervalSec = int(d.Seconds())
+	}
+	return o, o.Validate()
+}
@@ -10,6 +10,7 @@ import (
 	hookhandlers "github.com/nextlevelbuilder/goclaw/internal/hooks/handlers"
 	"github.com/nextlevelbuilder/goclaw/internal/hooks/budget"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 	"github.com/nextlevelbuilder/goclaw/internal/security"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/store/pg"
@@ -41,13 +42,18 @@ func buildHookHandlers(stores *store.Stores, providerReg *providers.Registry, ho
 		DefaultModel: "haiku",
 	}
 
-	// ScriptHandler: bounded by cfg.Hooks caps; zero values fall back to
-	// handler defaults (10 / 3 / 500). Safe for concurrent reuse across
-	// dispatcher + hooks.test runner (each Execute allocates its own runtime).
+	// ScriptHandler: bounded by cfg.Hooks caps; zero values fall back to the
+	// runtime profile's cache size, then handler defaults (10 / 3 / 500). Safe
+	// for concurrent reuse across dispatcher + hooks.test runner (each Execute
+	// allocates its own runtime).
+	scriptCacheSize := hooksCfg.ScriptCacheSize
+	if scriptCacheSize == 0 {
+		scriptCacheSize = runtimeprofile.Current().ScriptCacheEntries
+	}
 	scriptHandler := hookhandlers.NewScriptHandler(
 		hooksCfg.ScriptConcurrency,
 		hooksCfg.ScriptPerTenantConcurrency,
-		hooksCfg.ScriptCacheSize,
+		scriptCacheSize,
 	)
 
 	return map[hooks.HandlerType]hooks.Handler{
@@ -33,15 +33,10 @@ func resolveGatewayBaseURL() string {
 	if err != nil {
 		return "http://127.0.0.1:18790"
 	}
-	host := cfg.Gateway.Host
-	if host == "" || host == "0.0.0.0" {
-		host = "127.0.0.1"
+	if cfg.Gateway.Port == 0 {
+		cfg.Gateway.Port = 18790
 	}
-	port := cfg.Gateway.Port
-	if port == 0 {
-		port = 18790
-	}
-	return fmt.Sprintf("http://%s:%d", host, port)
+	return "http://" + gatewayLocalAddr(cfg)
 }
 
 // resolveGatewayToken returns the gateway auth token.
@@ -103,6 +98,12 @@ func gatewayHTTPDelete(path string) error {
 // gatewayHTTPDoRaw executes an HTTP request and returns the raw response bytes.
 // Shared by both map-based and typed response functions.
 func gatewayHTTPDoRaw(method, path string, body any) ([]byte, int, error) {
+	return gatewayHTTPDoRawWith(httpClient, method, path, body)
+}
+
+// gatewayHTTPDoRawWith is gatewayHTTPDoRaw with a caller-supplied client
+// (e.g. a longer timeout for tool execution).
+func gatewayHTTPDoRawWith(client *http.Client, method, path string, body any) ([]byte, int, error) {
 	base := resolveGatewayBaseURL()
 
 	var bodyReader io.Reader
@@ -124,7 +125,7 @@ func gatewayHTTPDoRaw(method, path string, body any) ([]byte, int, error) {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
-	resp, err := httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, 0, fmt.Errorf("cannot reach gateway at %s: %w", base, err)
 	}
@@ -134,6 +135,36 @@ func gatewayHTTPDoRaw(method, path string, body any) ([]byte, int, error) {
 	return raw, resp.StatusCode, nil
 }
 
+// transferClient has a longer timeout for file-sized request/response bodies.
+var transferClient = &http.Client{Timeout: 5 * time.Minute}
+
+// gatewayHTTPStream sends body as-is and copies a successful response to out
+// without the 1 MB cap of gatewayHTTPDoRaw. Used for exports and imports.
+func gatewayHTTPStream(method, path string, body io.Reader, out io.Writer) error {
+	req, err := http.NewRequest(method, resolveGatewayBaseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GoClaw-User-Id", "system")
+	if token := resolveGatewayToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := transferClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cannot reach gateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return parseHTTPError(raw, resp.StatusCode)
+	}
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
 // parseHTTPError extracts an error message from a gateway error response.
 func parseHTTPError(raw []byte, statusCode int) error {
 	var errBody map[string]any
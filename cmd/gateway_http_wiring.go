@@ -58,6 +58,7 @@ func (d *gatewayDeps) wireHTTPHandlersOnServer(
 	if h.mcp != nil {
 		if mcpPool != nil {
 			h.mcp.SetPoolEvictor(mcpPool)
+			h.mcp.SetStatusReporter(mcpPool)
 		}
 		d.server.SetMCPHandler(h.mcp)
 	}
@@ -100,6 +101,11 @@ func (d *gatewayDeps) wireHTTPHandlersOnServer(
 		d.server.SetActivityHandler(httpapi.NewActivityHandler(d.pgStores.Activity))
 	}
 
+	// Session export/import API
+	if d.pgStores.Sessions != nil && d.pgStores.Agents != nil {
+		d.server.SetSessionsHandler(httpapi.NewSessionsHandler(d.pgStores.Sessions, d.pgStores.Agents, d.pgStores.Episodic, d.msgBus))
+	}
+
 	// System configs API
 	if d.pgStores.SystemConfigs != nil {
 		d.server.SetSystemConfigsHandler(httpapi.NewSystemConfigsHandler(d.pgStores.SystemConfigs, d.msgBus))
@@ -132,6 +138,9 @@ func (d *gatewayDeps) wireHTTPHandlersOnServer(
 	if d.pgStores.Snapshots != nil {
 		d.server.SetUsageHandler(httpapi.NewUsageHandler(d.pgStores.Snapshots, d.pgStores.DB))
 	}
+	if d.pgStores.Tracing != nil {
+		d.server.SetBillingHandler(httpapi.NewBillingHandler(d.pgStores.Tracing, d.pgStores.Agents, d.cfg))
+	}
 
 	// Runtime package management (install/uninstall system/pip/npm/github packages)
 	initGitHubInstaller()
@@ -156,7 +165,11 @@ func (d *gatewayDeps) wireHTTPHandlersOnServer(
 
 	// Memory management API
 	if d.pgStores != nil && d.pgStores.Memory != nil {
-		d.server.SetMemoryHandler(httpapi.NewMemoryHandler(d.pgStores.Memory))
+		memH := httpapi.NewMemoryHandler(d.pgStores.Memory)
+		if d.embMigrator != nil {
+			memH.SetEmbeddingMigrator(d.embMigrator)
+		}
+		d.server.SetMemoryHandler(memH)
 	}
 
 	// Knowledge graph API
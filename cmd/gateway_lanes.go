@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+)
+
+// gatewayLanes returns the default scheduler lanes with gateway.lanes
+// overrides applied. Lanes named only in the config are added.
+func gatewayLanes(cfg *config.Config) []scheduler.LaneConfig {
+	lanes := scheduler.DefaultLanes()
+	names := make([]string, 0, len(cfg.Gateway.Lanes))
+	for name := range cfg.Gateway.Lanes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		o := cfg.Gateway.Lanes[name]
+		i := slices.IndexFunc(lanes, func(l scheduler.LaneConfig) bool { return l.Name == name })
+		if i < 0 {
+			lanes = append(lanes, scheduler.LaneConfig{Name: name})
+			i = len(lanes) - 1
+		}
+		if o.Concurrency > 0 {
+			lanes[i].Concurrency = o.Concurrency
+		}
+		lanes[i].Priority = o.Priority
+		lanes[i].Preempt = o.Preempt
+	}
+	return lanes
+}
+
+// gatewayFairness converts gateway.fairness to the scheduler's config.
+func gatewayFairness(cfg *config.Config) scheduler.FairnessConfig {
+	f := cfg.Gateway.Fairness
+	if f == nil {
+		return scheduler.FairnessConfig{}
+	}
+	return scheduler.FairnessConfig{MaxInflightPerUser: f.MaxInflightPerUser, Weights: f.Weights}
+}
+
+// gatewayQueueConfig returns the default session queue config with
+// gateway.queue_mode applied. Unknown modes keep the default.
+func gatewayQueueConfig(cfg *config.Config) scheduler.QueueConfig {
+	qc := scheduler.DefaultQueueConfig()
+	switch mode := scheduler.QueueMode(cfg.Gateway.QueueMode); mode {
+	case "":
+	case scheduler.QueueModeQueue, scheduler.QueueModeFollowup, scheduler.QueueModeInterrupt, scheduler.QueueModeSteer:
+		qc.Mode = mode
+	default:
+		slog.Warn("gateway: unknown queue_mode, using default", "queue_mode", mode, "default", qc.Mode)
+	}
+	return qc
+}
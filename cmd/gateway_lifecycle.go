@@ -5,7 +5,6 @@ import (
 	"log/slog"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/cache"
@@ -13,6 +12,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/edition"
 	"github.com/nextlevelbuilder/goclaw/internal/heartbeat"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -26,6 +26,7 @@ type lifecycleDeps struct {
 	sched             *scheduler.Scheduler
 	heartbeatTicker   *heartbeat.Ticker
 	quotaChecker      *channels.QuotaChecker
+	budgetChecker     *channels.BudgetChecker
 	webFetchTool      *tools.WebFetchTool
 	ttsTool           *tools.TtsTool
 	sandboxMgr        sandbox.Manager
@@ -60,6 +61,21 @@ func (d *gatewayDeps) runLifecycle(
 		})
 	}
 
+	// Reload budget caps on config changes via pub/sub.
+	if deps.budgetChecker != nil {
+		d.msgBus.Subscribe("budget-config-reload", func(evt bus.Event) {
+			if evt.Name != bus.TopicConfigChanged {
+				return
+			}
+			updatedCfg, ok := evt.Payload.(*config.Config)
+			if !ok || updatedCfg.Gateway.Budget == nil {
+				return
+			}
+			deps.budgetChecker.UpdateConfig(*updatedCfg.Gateway.Budget)
+			slog.Info("budget config reloaded via pub/sub")
+		})
+	}
+
 	// Reload cron default timezone on config changes via pub/sub.
 	d.msgBus.Subscribe("cron-config-reload", func(evt bus.Event) {
 		if evt.Name != bus.TopicConfigChanged {
@@ -70,6 +86,7 @@ func (d *gatewayDeps) runLifecycle(
 			return
 		}
 		d.pgStores.Cron.SetDefaultTimezone(updatedCfg.Cron.DefaultTimezone)
+		d.pgStores.Cron.SetMaxConcurrentRuns(updatedCfg.Cron.MaxConcurrentRuns)
 	})
 
 	// Reload web_fetch domain policy on config changes via pub/sub.
@@ -82,6 +99,10 @@ func (d *gatewayDeps) runLifecycle(
 			return
 		}
 		deps.webFetchTool.UpdatePolicy(updatedCfg.Tools.WebFetch.Policy, updatedCfg.Tools.WebFetch.AllowedDomains, updatedCfg.Tools.WebFetch.BlockedDomains)
+		deps.webFetchTool.SetJSRenderEnabled(updatedCfg.Tools.WebFetch.RenderJS)
+		if err := tools.SetWebNetworkPolicy(updatedCfg.Tools.Web.AllowCIDRs, updatedCfg.Tools.Web.DenyCIDRs); err != nil {
+			slog.Warn("web tools: invalid network policy, keeping previous", "error", err)
+		}
 	})
 
 	// Reload global shell deny-group toggles on config changes via pub/sub
@@ -139,7 +160,9 @@ func (d *gatewayDeps) runLifecycle(
 		d.channelMgr.SetContactCollector(contactCollector)
 	}
 
-	go consumeInboundMessages(ctx, d.msgBus, d.agentRouter, d.cfg, deps.sched, d.channelMgr, deps.consumerTeamStore, deps.quotaChecker, d.pgStores.Sessions, d.pgStores.Agents, contactCollector, deps.postTurn, deps.subagentMgr)
+	go safego.Supervise(ctx, "inbound_consumer", func(ctx context.Context) {
+		consumeInboundMessages(ctx, d.msgBus, d.agentRouter, d.cfg, deps.sched, d.channelMgr, deps.consumerTeamStore, deps.quotaChecker, d.pgStores.Sessions, d.pgStores.Agents, contactCollector, deps.postTurn, deps.subagentMgr, d.pgStores.SystemConfigs, d.pgStores.Memory)
+	})
 
 	// Task recovery ticker: re-dispatches stale/pending team tasks on startup and periodically.
 	var taskTicker *tasks.TaskTicker
@@ -155,6 +178,10 @@ func (d *gatewayDeps) runLifecycle(
 		// Broadcast shutdown event
 		d.server.BroadcastEvent(*protocol.NewEvent(protocol.EventShutdown, nil))
 
+		// Refuse new runs and let in-flight ones finish while channels are
+		// still up to deliver their replies.
+		d.drain(deps.sched)
+
 		// Stop channels, cron, heartbeat, and task ticker
 		d.channelMgr.StopAll(context.Background())
 		d.pgStores.Cron.Stop()
@@ -184,9 +211,7 @@ func (d *gatewayDeps) runLifecycle(
 		}
 
 		if deps.sched != nil {
-			slog.Info("gateway: draining active runs", "timeout", "5s")
 			deps.sched.Stop() // MarkDraining + StopAll
-			time.Sleep(5 * time.Second)
 		}
 
 		cancel()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/store/pg"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
@@ -58,6 +60,8 @@ func wireExtras(
 	sandboxMgr sandbox.Manager,
 	redisClient any, // nil when built without -tags redis or when Redis is unconfigured
 	domainBus eventbus.DomainEventBus,
+	budgetGuard agent.BudgetGuard, // nil when gateway.budget is disabled
+	sessionLocker agent.SessionLocker, // nil unless gateway.cluster is enabled
 ) (*tools.ContextFileInterceptor, *mcpbridge.Pool, *media.Store, tools.PostTurnProcessor) {
 	// 1. Build cache instances (in-memory or Redis depending on build tags)
 	agentCtxCache, userCtxCache := makeCaches(redisClient)
@@ -190,6 +194,18 @@ func wireExtras(
 		slog.Info("agent hooks dispatcher wired", "handlers", "command,http,prompt")
 	}
 
+	// Workspace snapshots: files are saved before write_file/edit change them,
+	// so `goclaw workspace rollback --run <id>` can undo a run's edits.
+	var snapshotStore *snapshots.Store
+	if snapCfg := appCfg.Gateway.Snapshots; snapCfg.Enabled() {
+		snapshotStore = snapshots.NewStore(resolveSnapshotsDir(appCfg))
+		if n, err := snapshotStore.Prune(time.Now().Add(-snapCfg.Retention())); err != nil {
+			slog.Warn("snapshot prune failed", "dir", snapshotStore.Dir(), "err", err)
+		} else if n > 0 {
+			slog.Info("pruned workspace snapshots", "runs", n)
+		}
+	}
+
 	resolver := agent.NewManagedResolver(agent.ResolverDeps{
 		AgentStore:             stores.Agents,
 		ProviderStore:          stores.Providers,
@@ -240,6 +256,9 @@ func wireExtras(
 		EvolutionMetricsStore:  stores.EvolutionMetrics,
 		DomainBus:              domainBus,
 		HookDispatcher:         hookDispatcher,
+		BudgetGuard:            budgetGuard,
+		SessionLocker:          sessionLocker,
+		Snapshots:              snapshotStore,
 		OnTextUploaded: func(ctx context.Context, path, content string) {
 			if vaultIntc != nil {
 				vaultIntc.AfterWrite(ctx, path, content)
@@ -328,6 +347,21 @@ func wireExtras(
 			}
 		}
 	}
+	if writeMemIntc != nil {
+		if t, ok := toolsReg.Get("memory_write"); ok {
+			if mw, ok := t.(*tools.MemoryWriteTool); ok {
+				mw.SetMemoryInterceptor(writeMemIntc)
+			}
+		}
+	}
+	// exec: import memory files that shell commands write to disk (debounced, hash-based).
+	if stores.Memory != nil {
+		if t, ok := toolsReg.Get("exec"); ok {
+			if et, ok := t.(*tools.ExecTool); ok {
+				et.SetMemoryFileIndexer(tools.NewMemoryFileIndexer(stores.Memory, 2*time.Second))
+			}
+		}
+	}
 	if listTool, ok := toolsReg.Get("list_files"); ok {
 		if ia, ok := listTool.(tools.InterceptorAware); ok {
 			if stores.Memory != nil {
@@ -356,6 +390,9 @@ func wireExtras(
 			if ms, ok := searchTool.(tools.MemoryStoreAware); ok {
 				ms.SetMemoryStore(stores.Memory)
 			}
+			if mst, ok := searchTool.(*tools.MemorySearchTool); ok {
+				mst.SetRerankerResolver(buildRerankerResolver(providerReg, stores.SystemConfigs))
+			}
 		}
 		if getTool, ok := toolsReg.Get("memory_get"); ok {
 			if ms, ok := getTool.(tools.MemoryStoreAware); ok {
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/commands"
+	"github.com/nextlevelbuilder/goclaw/internal/plans"
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	// planDraftTimeout bounds the agent run that drafts a plan.
+	planDraftTimeout = 5 * time.Minute
+
+	// planStepTimeout bounds one plan step.
+	planStepTimeout = 30 * time.Minute
+
+	// maxPlanStepResult caps the step result kept in the checkpoint and reported.
+	maxPlanStepResult = 3000
+)
+
+// newPlanRunner opens the plan checkpoints under the data dir and pauses
+// plans interrupted by a restart. Returns nil when plans are unavailable.
+func newPlanRunner(deps *ConsumerDeps) *plans.Runner {
+	ps, err := plans.NewStore(filepath.Join(deps.Cfg.ResolvedDataDir(), "plans"))
+	if err != nil {
+		slog.Warn("plans: store unavailable, /plan disabled", "error", err)
+		return nil
+	}
+	runner := plans.NewRunner(ps, planStepFunc(deps), func(p *plans.Plan, text string) {
+		publishPlanMessage(deps, p, text)
+	})
+	if paused := runner.Recover(); len(paused) > 0 {
+		slog.Info("plans: paused plans interrupted by restart", "count", len(paused))
+	}
+	return runner
+}
+
+// planStepFunc runs one plan step as an agent turn in the plan's own session.
+// Steps use the cron lane: they are unattended background work and must not
+// take slots from interactive chats.
+func planStepFunc(deps *ConsumerDeps) plans.StepFunc {
+	return func(ctx context.Context, p *plans.Plan, idx int) (string, error) {
+		runCtx, cancel := context.WithTimeout(store.WithTenantID(ctx, p.TenantID), planStepTimeout)
+		defer cancel()
+
+		sessionKey := sessions.BuildPlanSessionKey(p.AgentKey, p.ID)
+		step := p.Steps[idx]
+		outCh := deps.Sched.Schedule(runCtx, scheduler.LaneCron, agent.RunRequest{
+			SessionKey:        sessionKey,
+			Message:           formatPlanStepMessage(p, idx),
+			Channel:           p.Channel,
+			ChannelType:       resolveChannelType(deps.ChannelMgr, p.Channel),
+			ChatID:            p.ChatID,
+			PeerKind:          p.PeerKind,
+			UserID:            p.UserID,
+			RunID:             fmt.Sprintf("plan:%s:%d", p.ID, idx+1),
+			ExtraSystemPrompt: fmt.Sprintf("[Plan Run]\nYou are executing step %d of %d of an approved plan. Goal: %s\nDo only this step; earlier steps and their results are in the conversation. End with a short summary of what you did and anything later steps need to know.", idx+1, len(p.Steps), p.Goal),
+			TraceName:         fmt.Sprintf("Plan [%s] step %d - %s", p.ID, idx+1, step.Title),
+			TraceTags:         []string{"plan"},
+		})
+
+		var outcome scheduler.RunOutcome
+		select {
+		case outcome = <-outCh:
+		case <-runCtx.Done():
+			deps.Sched.CancelSession(sessionKey)
+			if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+				return "", fmt.Errorf("step timed out after %s", planStepTimeout)
+			}
+			return "", runCtx.Err()
+		}
+		if outcome.Err != nil {
+			return "", outcome.Err
+		}
+		result := strings.TrimSpace(outcome.Result.Content)
+		if result == "" {
+			result = "(no output)"
+		}
+		return channels.Truncate(result, maxPlanStepResult), nil
+	}
+}
+
+func formatPlanStepMessage(p *plans.Plan, idx int) string {
+	step := p.Steps[idx]
+	msg := fmt.Sprintf("[Plan step %d/%d] %s\n\n%s", idx+1, len(p.Steps), step.Title, step.Instructions)
+	if len(step.Tools) > 0 {
+		msg += "\n\nSuggested tools: " + strings.Join(step.Tools, ", ")
+	}
+	return msg
+}
+
+func publishPlanMessage(deps *ConsumerDeps, p *plans.Plan, text string) {
+	deps.MsgBus.PublishOutbound(bus.OutboundMessage{
+		Channel:  p.Channel,
+		ChatID:   p.ChatID,
+		Content:  text,
+		Metadata: p.Meta,
+		TenantID: p.TenantID,
+		AgentID:  p.AgentID,
+	})
+}
+
+// commandPlan drafts a plan for a goal or controls the chat's current plan.
+func commandPlan(ctx context.Context, deps *ConsumerDeps, inv *commands.Invocation) (commands.Reply, error) {
+	if deps.Plans == nil {
+		return commands.Reply{Text: "Plans are not available."}, nil
+	}
+	sub := ""
+	if len(inv.Args) > 0 {
+		sub = strings.ToLower(inv.Args[0])
+	}
+
+	switch sub {
+	case "", "status":
+		list := deps.Plans.Store().List(inv.SessionKey)
+		if len(list) == 0 {
+			return commands.Reply{Text: "No plans yet. Send /plan <goal> to draft one."}, nil
+		}
+		return planStatusReply(list[0]), nil
+	case "approve", "pause", "resume", "cancel":
+		p := deps.Plans.Store().Active(inv.SessionKey)
+		if p == nil {
+			return commands.Reply{Text: "There is no active plan in this chat."}, nil
+		}
+		if p.UserID != inv.UserID {
+			return commands.Reply{Text: "Only the person who requested the plan can control it."}, nil
+		}
+		return controlPlan(deps, p, sub), nil
+	}
+
+	if p := deps.Plans.Store().Active(inv.SessionKey); p != nil {
+		return commands.Reply{Text: fmt.Sprintf("Plan %s is still %s. Finish it or send /plan cancel before drafting a new one.", p.ID, planStatusLabel(p.Status))}, nil
+	}
+	agentUUID, err := resolveCommandAgentUUID(ctx, deps, inv.AgentID)
+	if err != nil {
+		return commands.Reply{}, err
+	}
+
+	p := plans.New(inv.RawArgs, nil)
+	p.TenantID = inv.Msg.TenantID
+	p.AgentKey = inv.AgentID
+	p.AgentID = agentUUID
+	p.SessionKey = inv.SessionKey
+	p.UserID = inv.UserID
+	p.Channel = inv.Msg.Channel
+	p.ChatID = inv.Msg.ChatID
+	p.PeerKind = inv.Msg.PeerKind
+	p.Meta = channels.CopyRoutingMeta(inv.Msg.Metadata)
+	go draftPlan(deps, p)
+	return commands.Reply{Text: "📝 Drafting a plan…"}, nil
+}
+
+func controlPlan(deps *ConsumerDeps, p *plans.Plan, action string) commands.Reply {
+	var err error
+	var text string
+	switch action {
+	case "approve":
+		_, err = deps.Plans.Approve(p.ID)
+		text = fmt.Sprintf("▶️ Running plan %s (%d steps). I'll report after each step; /plan pause stops after the current one.", p.ID, len(p.Steps))
+	case "pause":
+		_, err = deps.Plans.Pause(p.ID)
+		text = "⏸ Pausing after the current step."
+	case "resume":
+		_, err = deps.Plans.Resume(p.ID)
+		text = fmt.Sprintf("▶️ Resuming plan %s at step %d/%d.", p.ID, p.Current+1, len(p.Steps))
+	case "cancel":
+		_, err = deps.Plans.Cancel(p.ID)
+		text = fmt.Sprintf("🛑 Plan %s cancelled.", p.ID)
+	}
+	if err != nil {
+		return commands.Reply{Text: fmt.Sprintf("Can't %s plan %s: %v.", action, p.ID, err)}
+	}
+	return commands.Reply{Text: text}
+}
+
+// draftPlan asks the agent for a plan in the plan's own session and posts
+// it for approval.
+func draftPlan(deps *ConsumerDeps, p *plans.Plan) {
+	ctx, cancel := context.WithTimeout(store.WithTenantID(context.Background(), p.TenantID), planDraftTimeout)
+	defer cancel()
+
+	sessionKey := sessions.BuildPlanSessionKey(p.AgentKey, p.ID)
+	outCh := deps.Sched.Schedule(ctx, scheduler.LaneMain, agent.RunRequest{
+		SessionKey:  sessionKey,
+		Message:     plans.PlannerPrompt(p.Goal),
+		Channel:     p.Channel,
+		ChannelType: resolveChannelType(deps.ChannelMgr, p.Channel),
+		ChatID:      p.ChatID,
+		PeerKind:    p.PeerKind,
+		UserID:      p.UserID,
+		RunID:       fmt.Sprintf("plan:%s:draft", p.ID),
+		TraceName:   fmt.Sprintf("Plan [%s] draft - %s", p.ID, p.AgentKey),
+		TraceTags:   []string{"plan"},
+	})
+
+	var outcome scheduler.RunOutcome
+	select {
+	case outcome = <-outCh:
+	case <-ctx.Done():
+		deps.Sched.CancelSession(sessionKey)
+		outcome.Err = fmt.Errorf("timed out after %s", planDraftTimeout)
+	}
+	var err error
+	if outcome.Err == nil {
+		p.Steps, err = plans.ParseSteps(outcome.Result.Content)
+	} else {
+		err = outcome.Err
+	}
+	if err == nil {
+		err = deps.Plans.Store().Create(p)
+	}
+	if err != nil {
+		slog.Warn("plans: draft failed", "plan", p.ID, "agent", p.AgentKey, "error", err)
+		publishPlanMessage(deps, p, fmt.Sprintf("Couldn't draft a plan: %v", err))
+		return
+	}
+
+	reply := planStatusReply(p)
+	reply.Text = "Reply /plan approve to run it, or /plan cancel to discard it."
+	publishPlanMessage(deps, p, commands.Render(reply, commands.StyleFor(resolveChannelType(deps.ChannelMgr, p.Channel))))
+}
+
+func planStatusReply(p *plans.Plan) commands.Reply {
+	lines := make([]string, 0, len(p.Steps))
+	for i, s := range p.Steps {
+		line := fmt.Sprintf("%s %d. %s", planStepIcon(s.Status), i+1, s.Title)
+		if len(s.Tools) > 0 && s.Status == plans.StepPending {
+			line += " (" + strings.Join(s.Tools, ", ") + ")"
+		}
+		if s.Status == plans.StepFailed && s.Error != "" {
+			line += " — " + channels.Truncate(s.Error, 120)
+		}
+		lines = append(lines, line)
+	}
+	return commands.Reply{
+		Title: fmt.Sprintf("Plan %s: %s — %s, %d/%d done", p.ID, channels.Truncate(p.Goal, 80), planStatusLabel(p.Status), p.Done(), len(p.Steps)),
+		Lines: lines,
+	}
+}
+
+func planStatusLabel(s plans.Status) string {
+	return strings.ReplaceAll(string(s), "_", " ")
+}
+
+func planStepIcon(s plans.StepStatus) string {
+	switch s {
+	case plans.StepDone:
+		return "✔️"
+	case plans.StepRunning:
+		return "▶️"
+	case plans.StepFailed:
+		return "❌"
+	default:
+		return "⏳"
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -28,6 +29,16 @@ func loopbackAddr(host string, port int) string {
 	return net.JoinHostPort(host, strconv.Itoa(port))
 }
 
+// gatewayLocalAddr returns the plain-HTTP address local clients (CLI commands,
+// Claude CLI MCP bridge) should dial. With gateway TLS enabled this is the
+// 127.0.0.1 loopback listener (gateway.tls.loopback_port) when configured.
+func gatewayLocalAddr(cfg *config.Config) string {
+	if tlsCfg := cfg.Gateway.TLS; tlsCfg.Enabled() && tlsCfg.LoopbackPort > 0 {
+		return loopbackAddr("127.0.0.1", tlsCfg.LoopbackPort)
+	}
+	return loopbackAddr(cfg.Gateway.Host, cfg.Gateway.Port)
+}
+
 func registerProviders(registry *providers.Registry, cfg *config.Config, modelReg providers.ModelRegistry) {
 	if cfg.Providers.Anthropic.APIKey != "" {
 		registry.Register(providers.NewAnthropicProvider(cfg.Providers.Anthropic.APIKey,
@@ -123,10 +134,14 @@ func registerProviders(registry *providers.Registry, cfg *config.Config, modelRe
 	}
 
 	// Local / self-hosted Ollama — gated on Host, no API key required.
-	// Ollama's OpenAI-compat endpoint accepts any non-empty Bearer value.
+	// Uses the native /api/chat endpoint (keep_alive, num_ctx, model metadata).
 	if cfg.Providers.Ollama.Host != "" {
-		host := cfg.Providers.Ollama.Host
-		registry.Register(providers.NewOpenAIProvider("ollama", "ollama", host+"/v1", "llama3.3"))
+		oc := cfg.Providers.Ollama
+		registry.Register(providers.NewOllamaProvider(oc.Host,
+			providers.WithOllamaModel(oc.Model),
+			providers.WithOllamaKeepAlive(oc.KeepAlive),
+			providers.WithOllamaNumCtx(oc.NumCtx),
+			providers.WithOllamaRegistry(modelReg)))
 		slog.Info("registered provider", "name", "ollama")
 	}
 
@@ -188,7 +203,7 @@ func registerProviders(registry *providers.Registry, cfg *config.Config, modelRe
 			opts = append(opts, providers.WithClaudeCLIPermMode(cfg.Providers.ClaudeCLI.PermMode))
 		}
 		// Build per-session MCP config: external MCP servers + GoClaw bridge
-		gatewayAddr := loopbackAddr(cfg.Gateway.Host, cfg.Gateway.Port)
+		gatewayAddr := gatewayLocalAddr(cfg)
 		mcpData := providers.BuildCLIMCPConfigData(cfg.Tools.McpServers, gatewayAddr, cfg.Gateway.Token)
 		opts = append(opts, providers.WithClaudeCLIMCPConfigData(mcpData))
 		// Enable GoClaw security hooks (shell deny patterns, path restrictions)
@@ -202,6 +217,14 @@ func registerProviders(registry *providers.Registry, cfg *config.Config, modelRe
 	if cfg.Providers.ACP.Binary != "" {
 		registerACPFromConfig(registry, cfg.Providers.ACP)
 	}
+
+	// Fake echo provider for `goclaw bench --fake-provider`. Opt-in via env only:
+	// GOCLAW_FAKE_PROVIDER=1 (default latency) or a duration like "500ms".
+	if v := os.Getenv("GOCLAW_FAKE_PROVIDER"); v != "" {
+		latency, _ := time.ParseDuration(v)
+		registry.Register(providers.NewFakeProvider(latency))
+		slog.Warn("registered fake provider (benchmark only)", "name", "fake", "latency", latency)
+	}
 }
 
 // buildMCPServerLookup creates an MCPServerLookup from an MCPServerStore.
@@ -313,13 +336,9 @@ func registerProvidersFromDB(registry *providers.Registry, provStore store.Provi
 			continue
 		}
 		// Local Ollama requires no API key — handle before the key guard (same pattern as ClaudeCLI).
-		// api_base is stored with /v1 (normalized at write time), so no suffix appending needed.
+		// api_base is stored with /v1 (OpenAI-compat); the native provider strips it.
 		if p.ProviderType == store.ProviderOllama {
-			host := p.APIBase
-			if host == "" {
-				host = "http://localhost:11434/v1"
-			}
-			registry.RegisterForTenant(p.TenantID, providers.NewOpenAIProvider(p.Name, "ollama", config.DockerLocalhost(host), "llama3.3"))
+			registry.RegisterForTenant(p.TenantID, newOllamaProviderFromDB(p, modelReg))
 			slog.Info("registered provider from DB", "name", p.Name)
 			continue
 		}
@@ -488,3 +507,21 @@ func registerACPFromDB(registry *providers.Registry, p store.LLMProviderData) {
 func defaultACPWorkDir() string {
 	return filepath.Join(config.ResolvedDataDirFromEnv(), "acp-workspaces")
 }
+
+// newOllamaProviderFromDB builds a native Ollama provider from a DB provider row.
+// In Docker, localhost is swapped for host.docker.internal so the container can
+// reach an Ollama server running on the host.
+func newOllamaProviderFromDB(p store.LLMProviderData, modelReg providers.ModelRegistry) *providers.OllamaProvider {
+	host := p.APIBase
+	if host == "" {
+		host = "http://localhost:11434"
+	}
+	settings := store.ParseOllamaProviderSettings(p.Settings)
+	return providers.NewOllamaProvider(config.DockerLocalhost(host),
+		providers.WithOllamaName(p.Name),
+		providers.WithOllamaAPIKey(p.APIKey),
+		providers.WithOllamaModel(settings.Model),
+		providers.WithOllamaKeepAlive(settings.KeepAlive),
+		providers.WithOllamaNumCtx(settings.NumCtx),
+		providers.WithOllamaRegistry(modelReg))
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// configureOutboundProxy installs the proxy settings from cfg.Proxy and points
+// the process-wide default HTTP transport and websocket dialer at them, so
+// clients built without an explicit transport also honor proxy.url/no_proxy.
+// Scoped clients (providers, web tools, channels, webhooks) pick up their
+// overrides via netproxy.For. With cfg.Offline set, the same Proxy funcs
+// reject every destination outside loopback and cfg.OfflineAllow.
+func configureOutboundProxy(cfg *config.Config) {
+	p := cfg.Proxy
+	overrides := make(map[string]string, len(p.Providers)+len(p.Tools)+len(p.Channels)+2)
+	for name, v := range p.Providers {
+		overrides[netproxy.Provider(name)] = v
+	}
+	for name, v := range p.Tools {
+		overrides[netproxy.Tool(name)] = v
+	}
+	for name, v := range p.Channels {
+		overrides[netproxy.Channel(name)] = v
+	}
+	if p.Webhooks != "" {
+		overrides[netproxy.ScopeWebhooks] = p.Webhooks
+	}
+	if p.Browser != "" {
+		overrides[netproxy.ScopeBrowser] = p.Browser
+	}
+	netproxy.Configure(netproxy.Settings{
+		URL:          p.URL,
+		NoProxy:      p.NoProxy,
+		Overrides:    overrides,
+		Offline:      cfg.Offline,
+		OfflineAllow: cfg.OfflineAllow,
+	})
+
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = netproxy.For(netproxy.ScopeDefault)
+	}
+	websocket.DefaultDialer.Proxy = netproxy.For(netproxy.ScopeDefault)
+
+	if p.URL != "" || len(overrides) > 0 {
+		slog.Info("outbound proxy configured", "default", netproxy.Redact(p.URL), "overrides", len(overrides))
+	}
+	if cfg.Offline {
+		slog.Warn("offline mode: outbound calls restricted to loopback and offline_allow", "allow", cfg.OfflineAllow)
+	}
+}
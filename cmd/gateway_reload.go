@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// gatewayReloader is set once the gateway is wired; config.apply/patch apply
+// through it (see registerAllMethods). Nil before that and in CLI commands.
+var gatewayReloader *configReloader
+
+// configChannelTypes maps channels.<key> config sections to channel names.
+var configChannelTypes = map[string]string{
+	"telegram":      channels.TypeTelegram,
+	"discord":       channels.TypeDiscord,
+	"slack":         channels.TypeSlack,
+	"whatsapp":      channels.TypeWhatsApp,
+	"zalo":          channels.TypeZaloOA,
+	"zalo_personal": channels.TypeZaloPersonal,
+	"feishu":        channels.TypeFeishu,
+}
+
+// configReloader applies a changed config.json to the running gateway on
+// SIGHUP, on file edits, and for config.apply/config.patch. The new config is
+// fully loaded before anything changes, then swapped in under the config
+// lock; config-based providers and channels are re-registered, cached agent
+// loops are dropped, and config:changed subscribers re-apply their settings.
+// Settings bound at startup are listed as restart_required in the report.
+type configReloader struct {
+	mu          sync.Mutex
+	cfg         *config.Config
+	cfgPath     string
+	stores      *store.Stores
+	msgBus      *bus.MessageBus
+	agentRouter *agent.Router
+	providerReg *providers.Registry
+	modelReg    providers.ModelRegistry
+	channelMgr  *channels.Manager
+	audioMgr    *audio.Manager
+
+	// configChannels is false when channels come from DB instances, so the
+	// channels.* config sections are not in use.
+	configChannels bool
+	// configProviders are the provider names registered from config.
+	configProviders []string
+
+	ctx     context.Context
+	watcher *config.Watcher
+	stop    func()
+}
+
+func newConfigReloader(cfg *config.Config, cfgPath string, stores *store.Stores, msgBus *bus.MessageBus, agentRouter *agent.Router, providerReg *providers.Registry, modelReg providers.ModelRegistry, configProviders []string, channelMgr *channels.Manager, audioMgr *audio.Manager, configChannels bool) *configReloader {
+	return &configReloader{
+		cfg:             cfg,
+		cfgPath:         cfgPath,
+		stores:          stores,
+		msgBus:          msgBus,
+		agentRouter:     agentRouter,
+		providerReg:     providerReg,
+		modelReg:        modelReg,
+		channelMgr:      channelMgr,
+		audioMgr:        audioMgr,
+		configChannels:  configChannels,
+		configProviders: configProviders,
+	}
+}
+
+// Start reloads on SIGHUP and when the config file changes. ctx bounds
+// channels started by a reload.
+func (r *configReloader) Start(ctx context.Context) {
+	r.ctx = ctx
+
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-hupCh:
+				r.Reload("sighup")
+			case <-done:
+				return
+			}
+		}
+	}()
+	r.stop = func() {
+		signal.Stop(hupCh)
+		close(done)
+	}
+
+	if _, err := os.Stat(r.cfgPath); err != nil {
+		slog.Info("config file not found, watching disabled (SIGHUP still reloads)", "path", r.cfgPath)
+		return
+	}
+	w, err := config.NewWatcher(r.cfgPath)
+	if err != nil {
+		slog.Warn("config watcher unavailable", "error", err)
+		return
+	}
+	w.OnChange(func(*config.Config) { r.Reload("file") })
+	if err := w.Start(); err != nil {
+		slog.Warn("config watcher start failed", "error", err)
+		return
+	}
+	r.watcher = w
+}
+
+// Stop ends signal handling and file watching.
+func (r *configReloader) Stop() {
+	if r.stop != nil {
+		r.stop()
+	}
+	if r.watcher != nil {
+		r.watcher.Stop()
+	}
+}
+
+// Reload re-reads the config file and applies it. On a load, parse or
+// validation error the running config is kept and the report carries the error.
+func (r *configReloader) Reload(trigger string) config.ReloadReport {
+	// Re-fetch secret:// references so rotated secrets are picked up.
+	secrets.ClearCache()
+	newCfg, err := r.load()
+	if err != nil {
+		report := config.ReloadReport{Trigger: trigger, Changed: []string{}, Applied: []string{}, RestartRequired: []string{}, Error: err.Error()}
+		slog.Error("config reload failed, keeping current config", "trigger", trigger, "error", err)
+		r.msgBus.Broadcast(bus.Event{Name: protocol.EventConfigReloaded, Payload: report})
+		return report
+	}
+	return r.Apply(newCfg, trigger)
+}
+
+// load reads the config file with DB secrets and env overrides layered on
+// top, in the same order as at startup.
+func (r *configReloader) load() (*config.Config, error) {
+	// Load falls back to defaults for a missing file; never apply those.
+	if _, err := os.Stat(r.cfgPath); err != nil {
+		return nil, err
+	}
+	cfg, err := config.Load(r.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	if r.stores != nil && r.stores.ConfigSecrets != nil {
+		masterCtx := store.WithTenantID(context.Background(), store.MasterTenantID)
+		if secrets, err := r.stores.ConfigSecrets.GetAll(masterCtx); err == nil && len(secrets) > 0 {
+			cfg.ApplyDBSecrets(secrets)
+		}
+	}
+	cfg.ApplyEnvOverrides()
+	return cfg, nil
+}
+
+// Apply swaps newCfg in as the live config and re-applies what changed.
+// A config that fails validation is refused as it would be at startup: the
+// running config is kept and the report lists the issues.
+func (r *configReloader) Apply(newCfg *config.Config, trigger string) config.ReloadReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	issues := newCfg.Validate()
+	if config.HasValidationErrors(issues) {
+		report := config.ReloadReport{Trigger: trigger, Changed: []string{}, Applied: []string{}, RestartRequired: []string{}, Error: "config has validation errors", Issues: issues}
+		for _, issue := range issues {
+			slog.Error("config reload: "+issue.Message, "key", issue.Path, "severity", issue.Severity)
+		}
+		slog.Error("config reload refused, keeping current config", "trigger", trigger)
+		r.msgBus.Broadcast(bus.Event{Name: protocol.EventConfigReloaded, Payload: report})
+		return report
+	}
+
+	// Startup merges per-group quotas into the live config; match it so the
+	// diff only shows real edits.
+	config.MergeChannelGroupQuotas(newCfg)
+	report := config.DiffReload(r.cfg, newCfg)
+	report.Trigger = trigger
+	report.Issues = issues
+	if len(report.Changed) == 0 {
+		// Writes by config.apply itself land here via the file watcher.
+		if trigger == "sighup" {
+			r.msgBus.Broadcast(bus.Event{Name: protocol.EventConfigReloaded, Payload: report})
+		}
+		slog.Debug("config reload: no changes", "trigger", trigger)
+		return report
+	}
+
+	r.cfg.ReplaceFrom(newCfg)
+
+	if changedUnder(report.Applied, "providers") {
+		r.reloadProviders()
+	}
+	if r.configChannels {
+		r.reloadChannels(changedSections(report.Applied, "channels"))
+	}
+	// Agent loops capture model, tools and workspace settings when resolved.
+	r.agentRouter.InvalidateAll()
+
+	r.msgBus.Broadcast(bus.Event{Name: bus.TopicConfigChanged, Payload: r.cfg})
+	r.msgBus.Broadcast(bus.Event{Name: protocol.EventConfigReloaded, Payload: report})
+	slog.Info("config reloaded",
+		"trigger", trigger,
+		"changed", len(report.Changed),
+		"restart_required", report.RestartRequired,
+	)
+	return report
+}
+
+// reloadProviders re-registers config-based providers, removes those no
+// longer configured, then re-applies DB providers, which override config
+// ones of the same name as they do at startup.
+func (r *configReloader) reloadProviders() {
+	tmp := providers.NewRegistry(nil)
+	registerProviders(tmp, r.cfg, r.modelReg)
+	names := tmp.ListForTenant(providers.MasterTenantID)
+	for _, name := range names {
+		if p, err := tmp.GetForTenant(providers.MasterTenantID, name); err == nil {
+			r.providerReg.Register(p)
+		}
+	}
+	for _, name := range r.configProviders {
+		if !slices.Contains(names, name) {
+			r.providerReg.Unregister(name)
+			slog.Info("unregistered provider", "name", name)
+		}
+	}
+	r.configProviders = names
+
+	if r.stores != nil && r.stores.Providers != nil {
+		registerProvidersFromDB(r.providerReg, r.stores.Providers, r.stores.ConfigSecrets, gatewayLocalAddr(r.cfg), r.cfg.Gateway.Token, r.stores.MCP, r.cfg, r.modelReg)
+	}
+}
+
+// reloadChannels restarts the config-based channels whose section changed.
+func (r *configReloader) reloadChannels(sections []string) {
+	if len(sections) == 0 {
+		return
+	}
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sub := &config.Config{}
+	var names []string
+	stopped := false
+	for _, section := range sections {
+		name, ok := configChannelTypes[section]
+		if !ok {
+			continue
+		}
+		names = append(names, name)
+		if ch, ok := r.channelMgr.GetChannel(name); ok {
+			if err := ch.Stop(ctx); err != nil {
+				slog.Warn("failed to stop channel on config reload", "channel", name, "error", err)
+			}
+			r.channelMgr.UnregisterChannel(name)
+			stopped = true
+		}
+		copyChannelConfig(&sub.Channels, r.cfg.Channels, section)
+	}
+	if stopped {
+		// Let external APIs (e.g. Telegram getUpdates) release polling locks,
+		// as InstanceLoader.Reload does.
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	registerConfigChannels(sub, r.channelMgr, r.msgBus, r.stores, nil, r.audioMgr)
+	for _, name := range names {
+		r.channelMgr.StartChannel(ctx, name)
+	}
+	slog.Info("config channels reloaded", "channels", names)
+}
+
+// copyChannelConfig copies one channels.<section> from src into dst.
+func copyChannelConfig(dst *config.ChannelsConfig, src config.ChannelsConfig, section string) {
+	switch section {
+	case "telegram":
+		dst.Telegram = src.Telegram
+	case "discord":
+		dst.Discord = src.Discord
+	case "slack":
+		dst.Slack = src.Slack
+	case "whatsapp":
+		dst.WhatsApp = src.WhatsApp
+	case "zalo":
+		dst.Zalo = src.Zalo
+	case "zalo_personal":
+		dst.ZaloPersonal = src.ZaloPersonal
+	case "feishu":
+		dst.Feishu = src.Feishu
+	}
+}
+
+// changedUnder reports whether any key lies under the top-level section.
+func changedUnder(keys []string, section string) bool {
+	return slices.ContainsFunc(keys, func(k string) bool {
+		return k == section || strings.HasPrefix(k, section+".")
+	})
+}
+
+// changedSections returns the distinct second-level names changed under
+// section, e.g. "telegram" for "channels.telegram.token".
+func changedSections(keys []string, section string) []string {
+	var out []string
+	for _, k := range keys {
+		rest, ok := strings.CutPrefix(k, section+".")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, ".")
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
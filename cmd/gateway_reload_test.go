@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+func TestChangedSections(t *testing.T) {
+	keys := []string{"channels.telegram.token", "channels.telegram.enabled", "channels.slack.bot_token", "providers.openai.api_key"}
+
+	if got := changedSections(keys, "channels"); !slices.Equal(got, []string{"telegram", "slack"}) {
+		t.Errorf("changedSections = %v", got)
+	}
+	if !changedUnder(keys, "providers") {
+		t.Error("expected providers change")
+	}
+	if changedUnder([]string{"providers_extra.x"}, "providers") {
+		t.Error("prefix match must be per segment")
+	}
+}
+
+func TestConfigReloaderApply_RefusesInvalidConfig(t *testing.T) {
+	cfg := config.Default()
+	r := &configReloader{cfg: cfg, msgBus: bus.New()}
+
+	bad := config.Default()
+	bad.Gateway.Port = 70000
+	report := r.Apply(bad, "file")
+
+	if report.Error == "" {
+		t.Fatal("expected reload error for invalid config")
+	}
+	if !slices.ContainsFunc(report.Issues, func(i config.ValidationIssue) bool { return i.Path == "gateway.port" }) {
+		t.Errorf("issues = %v, want gateway.port", report.Issues)
+	}
+	if len(report.Applied) != 0 || cfg.Gateway.Port == 70000 {
+		t.Error("invalid config must not be applied")
+	}
+}
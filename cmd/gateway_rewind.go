@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/commands"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// commandRewind lists the session's checkpoints, or rolls the conversation
+// back to one. Files written after the checkpoint are listed, not reverted.
+func commandRewind(ctx context.Context, deps *ConsumerDeps, inv *commands.Invocation) commands.Reply {
+	if len(inv.Args) == 0 {
+		cps := store.ListSessionCheckpoints(ctx, deps.SessStore, inv.SessionKey)
+		if len(cps) == 0 {
+			return commands.Reply{Text: "No checkpoints in this conversation yet."}
+		}
+		var lines []string
+		for _, cp := range slices.Backward(cps) {
+			if len(lines) == maxCommandResults {
+				lines = append(lines, fmt.Sprintf("… and %d older", len(cps)-maxCommandResults))
+				break
+			}
+			lines = append(lines, formatCheckpoint(cp))
+		}
+		return commands.Reply{Title: "Checkpoints", Lines: lines, Text: "Send /rewind <id> to roll back to one."}
+	}
+
+	if deps.Agents != nil && deps.Agents.IsSessionBusy(inv.SessionKey) {
+		return commands.Reply{Text: "A run is in progress. Send /stop first, then rewind."}
+	}
+	id, _ := strconv.Atoi(inv.Args[0])
+	res, err := store.RewindSession(ctx, deps.SessStore, inv.SessionKey, id)
+	switch {
+	case errors.Is(err, store.ErrCheckpointNotFound):
+		return commands.Reply{Text: fmt.Sprintf("No checkpoint #%d. Send /rewind to list them.", id)}
+	case errors.Is(err, store.ErrCheckpointStale):
+		return commands.Reply{Text: fmt.Sprintf("Checkpoint #%d no longer matches the conversation (it was compacted or reset since).", id)}
+	case err != nil:
+		return commands.Reply{Text: "Rewind failed: " + err.Error()}
+	}
+	// CLI-backed providers keep their own transcript; start it over.
+	providers.ResetCLISession("", inv.SessionKey)
+
+	text := fmt.Sprintf("Rewound to checkpoint #%d, removed %d messages.", id, res.Removed)
+	if len(res.Files) == 0 {
+		return commands.Reply{Text: text}
+	}
+	return commands.Reply{
+		Title: "Files written after this checkpoint (not reverted)",
+		Lines: res.Files,
+		Text:  text,
+	}
+}
+
+// formatCheckpoint renders one checkpoint for /rewind and the CLI.
+func formatCheckpoint(cp store.SessionCheckpoint) string {
+	where := "before message"
+	if cp.Iteration > 0 {
+		where = fmt.Sprintf("step %d", cp.Iteration)
+	}
+	line := fmt.Sprintf("#%d %s %s", cp.ID, cp.CreatedAt.Local().Format("Jan 2 15:04"), where)
+	if cp.Label != "" {
+		line += ": " + cp.Label
+	}
+	if len(cp.Files) > 0 {
+		line += " → writes " + strings.Join(cp.Files, ", ")
+	}
+	return line
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+	"runtime/debug"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
+)
+
+// configureRuntimeProfile installs the resource profile from cfg.Runtime,
+// applying any explicit overrides, and sets the Go soft memory limit. Must run
+// before stores, caches and tools are created — they size themselves from
+// runtimeprofile.Current(). A GOMEMLIMIT env var is left to the Go runtime.
+func configureRuntimeProfile(cfg *config.Config) {
+	rc := cfg.Runtime
+	p, ok := runtimeprofile.ByName(rc.Profile)
+	if !ok {
+		slog.Warn("unknown runtime.profile, using standard", "value", rc.Profile)
+		p = runtimeprofile.Standard
+	}
+	if rc.MemoryLimitMB > 0 {
+		p.MemoryLimitMB = rc.MemoryLimitMB
+	}
+	if rc.SQLiteCacheMB > 0 {
+		p.SQLiteCacheKB = rc.SQLiteCacheMB * 1024
+	}
+	if rc.SQLiteHeapLimitMB > 0 {
+		p.SQLiteHeapLimitMB = rc.SQLiteHeapLimitMB
+	}
+	runtimeprofile.SetCurrent(p)
+
+	if p.MemoryLimitMB > 0 && os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(int64(p.MemoryLimitMB) << 20)
+	}
+	if p.Name != runtimeprofile.Standard.Name || rc.MemoryLimitMB > 0 {
+		slog.Info("runtime profile", "profile", p.Name,
+			"browser", p.BrowserEnabled,
+			"memory_limit_mb", p.MemoryLimitMB,
+			"sqlite_cache_kb", p.SQLiteCacheKB,
+			"sqlite_heap_limit_mb", p.SQLiteHeapLimitMB)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +17,10 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	mcpbridge "github.com/nextlevelbuilder/goclaw/internal/mcp"
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/edition"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
@@ -55,48 +59,82 @@ func setupToolRegistry(
 	toolsReg = tools.NewRegistry()
 	agentCfg = cfg.ResolveAgent("default")
 
-	// Sandbox manager (optional — routes tools through Docker containers)
+	// Sandbox manager (optional — routes tools through Docker/Podman containers)
 	if sbCfg := cfg.Agents.Defaults.Sandbox; sbCfg != nil && sbCfg.Mode != "" && sbCfg.Mode != "off" {
-		if err := sandbox.CheckDockerAvailable(context.Background()); err != nil {
-			slog.Warn("sandbox disabled: Docker not available",
+		resolved := sbCfg.ToSandboxConfig()
+		err := sandbox.CheckRuntimeAvailable(context.Background(), resolved.Runtime)
+		if err != nil && sbCfg.Runtime == "" && sandbox.CheckRuntimeAvailable(context.Background(), sandbox.RuntimePodman) == nil {
+			// No runtime configured and Docker missing: fall back to Podman.
+			resolved.Runtime, err = sandbox.RuntimePodman, nil
+		}
+		if err != nil {
+			slog.Warn("sandbox disabled: container runtime not available",
 				"configured_mode", sbCfg.Mode,
+				"runtime", resolved.Binary(),
 				"error", err,
 			)
 		} else {
-			resolved := sbCfg.ToSandboxConfig()
 			sandboxMgr = sandbox.NewDockerManager(resolved)
-			slog.Info("sandbox enabled", "mode", string(resolved.Mode), "image", resolved.Image, "scope", string(resolved.Scope))
+			slog.Info("sandbox enabled", "mode", string(resolved.Mode), "runtime", resolved.Binary(), "image", resolved.Image, "scope", string(resolved.Scope))
 		}
 	}
 
+	// Host exec interpreter (cmd/PowerShell on Windows hosts); must be set
+	// before the exec tool's description is read.
+	if err := tools.SetHostShell(cfg.Tools.ExecShell); err != nil {
+		slog.Warn("invalid tools.exec_shell, using auto", "error", err)
+	} else if sandboxMgr == nil {
+		slog.Info("exec host shell", "shell", tools.HostShell())
+	}
+
 	// Register file tools + exec tool (with sandbox routing via FsBridge if enabled)
 	if sandboxMgr != nil {
 		toolsReg.Register(tools.NewSandboxedReadFileTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
 		toolsReg.Register(tools.NewSandboxedWriteFileTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
 		toolsReg.Register(tools.NewSandboxedListFilesTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
+		toolsReg.Register(tools.NewSandboxedGrepTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
+		toolsReg.Register(tools.NewSandboxedGlobTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
 		toolsReg.Register(tools.NewSandboxedEditTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
 		toolsReg.Register(tools.NewSandboxedExecTool(workspace, agentCfg.RestrictToWorkspace, sandboxMgr))
 	} else {
 		toolsReg.Register(tools.NewReadFileTool(workspace, agentCfg.RestrictToWorkspace))
 		toolsReg.Register(tools.NewWriteFileTool(workspace, agentCfg.RestrictToWorkspace))
 		toolsReg.Register(tools.NewListFilesTool(workspace, agentCfg.RestrictToWorkspace))
+		toolsReg.Register(tools.NewGrepTool(workspace, agentCfg.RestrictToWorkspace))
+		toolsReg.Register(tools.NewGlobTool(workspace, agentCfg.RestrictToWorkspace))
 		toolsReg.Register(tools.NewEditTool(workspace, agentCfg.RestrictToWorkspace))
 		toolsReg.Register(tools.NewExecTool(workspace, agentCfg.RestrictToWorkspace))
 	}
 
+	// Background processes share exec's safety checks and sandbox routing.
+	if et, ok := toolsReg.Get("exec"); ok {
+		if execTool, ok := et.(*tools.ExecTool); ok {
+			procs := tools.NewProcessManager()
+			toolsReg.Register(tools.NewExecBackgroundTool(execTool, procs))
+			toolsReg.Register(tools.NewProcessListTool(procs))
+			toolsReg.Register(tools.NewProcessKillTool(procs))
+		}
+	}
+
 	// Memory tools — PG-backed; always registered (PG memory is always available)
 	toolsReg.Register(tools.NewMemorySearchTool())
 	toolsReg.Register(tools.NewMemoryGetTool())
+	toolsReg.Register(tools.NewMemoryWriteTool())
 	toolsReg.Register(tools.NewMemoryExpandTool())
 	toolsReg.Register(tools.NewKnowledgeGraphSearchTool())
 	slog.Info("memory + knowledge graph tools registered (PG-backed)")
 
-	// Browser automation tool
-	if cfg.Tools.Browser.Enabled {
+	// Browser automation tool (never started on profiles without the browser subsystem)
+	if cfg.Tools.Browser.Enabled && !runtimeprofile.Current().BrowserEnabled {
+		slog.Warn("browser tool disabled by runtime profile", "profile", runtimeprofile.Current().Name)
+	} else if cfg.Tools.Browser.Enabled {
 		var opts []browser.Option
-		if cfg.Tools.Browser.RemoteURL != "" {
-			opts = append(opts, browser.WithRemoteURL(cfg.Tools.Browser.RemoteURL))
-			slog.Info("browser tool enabled", "remote", cfg.Tools.Browser.RemoteURL)
+		if remote := cfg.Tools.Browser.RemoteEndpoint(); remote != "" {
+			opts = append(opts, browser.WithRemoteURL(remote))
+			if h := browserCDPHeader(cfg.Tools.Browser); len(h) > 0 {
+				opts = append(opts, browser.WithCDPHeader(h))
+			}
+			slog.Info("browser tool enabled", "remote", browser.RedactURL(remote))
 		} else {
 			opts = append(opts, browser.WithHeadless(cfg.Tools.Browser.Headless))
 			slog.Info("browser tool enabled", "headless", cfg.Tools.Browser.Headless)
@@ -113,8 +151,33 @@ func setupToolRegistry(
 		if cfg.Tools.Browser.MaxPages > 0 {
 			opts = append(opts, browser.WithMaxPages(cfg.Tools.Browser.MaxPages))
 		}
+		if proxy := netproxy.Default(netproxy.ScopeBrowser); proxy != "" {
+			opts = append(opts, browser.WithProxy(proxy, netproxy.NoProxyList()))
+		}
+		if cfg.Tools.Browser.RemoteEndpoint() == "" {
+			profilesDir := cfg.Tools.Browser.ProfilesDir
+			if profilesDir == "" {
+				profilesDir = filepath.Join(cfg.ResolvedDataDir(), "browser-profiles")
+			}
+			opts = append(opts, browser.WithProfilesDir(config.ExpandHome(profilesDir)))
+		}
+		if bc := cfg.Tools.Browser.Block; bc != nil {
+			rules := browser.BlockRules{Domains: slices.Clone(bc.Domains), AllowDomains: bc.AllowDomains}
+			for _, name := range bc.Presets {
+				domains, ok := browser.BlockPreset(name)
+				if !ok {
+					slog.Warn("unknown browser block preset, ignored", "preset", name)
+					continue
+				}
+				rules.Domains = append(rules.Domains, domains...)
+			}
+			opts = append(opts, browser.WithBlockRules(rules))
+		}
 		browserMgr = browser.New(opts...)
-		toolsReg.Register(browser.NewBrowserTool(browserMgr))
+		browserTool := browser.NewBrowserTool(browserMgr)
+		browserTool.SetAgentProfiles(cfg.Tools.Browser.AgentProfiles)
+		toolsReg.Register(browserTool)
+		toolsReg.Register(browser.NewDownloadsTool(browserMgr))
 	}
 
 	// Web tools (web_fetch; web_search is registered in wireExtraTools after stores are ready)
@@ -124,7 +187,16 @@ func setupToolRegistry(
 		BlockedDomains: cfg.Tools.WebFetch.BlockedDomains,
 	})
 	toolsReg.Register(webFetchTool)
-	slog.Info("web_fetch tool enabled", "policy", cfg.Tools.WebFetch.Policy, "blocked", len(cfg.Tools.WebFetch.BlockedDomains))
+	if browserMgr != nil {
+		webFetchTool.SetJSRenderer(browserMgr)
+		webFetchTool.SetJSRenderEnabled(cfg.Tools.WebFetch.RenderJS)
+	} else if cfg.Tools.WebFetch.RenderJS {
+		slog.Warn("tools.web_fetch.render_js ignored: browser tool is not enabled")
+	}
+	if err := tools.SetWebNetworkPolicy(cfg.Tools.Web.AllowCIDRs, cfg.Tools.Web.DenyCIDRs); err != nil {
+		slog.Warn("web tools: invalid network policy, using built-in SSRF ranges only", "error", err)
+	}
+	slog.Info("web_fetch tool enabled", "policy", cfg.Tools.WebFetch.Policy, "blocked", len(cfg.Tools.WebFetch.BlockedDomains), "render_js", cfg.Tools.WebFetch.RenderJS && browserMgr != nil)
 
 	// Vision fallback tool (for non-vision providers like MiniMax)
 	toolsReg.Register(tools.NewReadImageTool(providerRegistry))
@@ -145,6 +217,7 @@ func setupToolRegistry(
 
 	ttsTool = tools.NewTtsTool(ttsMgr)
 	toolsReg.Register(ttsTool)
+	toolsReg.Register(tools.NewTtsSpeakTool(ttsTool))
 	if ttsMgr.HasProviders() {
 		slog.Info("tts enabled", "provider", ttsMgr.PrimaryProvider(), "auto", string(ttsMgr.AutoMode()))
 	}
@@ -183,7 +256,9 @@ func setupToolRegistry(
 		if len(cfg.Tools.ExecApproval.Allowlist) > 0 {
 			approvalCfg.Allowlist = cfg.Tools.ExecApproval.Allowlist
 		}
+		approvalCfg.Tools = cfg.Tools.ExecApproval.Tools
 		execApprovalMgr = tools.NewExecApprovalManager(approvalCfg)
+		toolsReg.SetApprovalGate(execApprovalMgr)
 
 		// Wire approval to exec tools in the registry
 		if execTool, ok := toolsReg.Get("exec"); ok {
@@ -191,7 +266,7 @@ func setupToolRegistry(
 				aa.SetApprovalManager(execApprovalMgr, "default")
 			}
 		}
-		slog.Info("exec approval enabled", "security", string(approvalCfg.Security), "ask", string(approvalCfg.Ask))
+		slog.Info("exec approval enabled", "security", string(approvalCfg.Security), "ask", string(approvalCfg.Ask), "tools", approvalCfg.Tools)
 	}
 
 	// --- Enforcement: Policy engines ---
@@ -218,8 +293,8 @@ func setupToolRegistry(
 			// Allow skills execution: master-tenant skills-store + all tenant-scoped skills-store dirs.
 			et.AllowPathExemptions(
 				".goclaw/skills-store/",
-				filepath.Join(dataDir, "skills-store")+"/",
-				filepath.Join(dataDir, "tenants")+"/",
+				filepath.Join(dataDir, "skills-store")+string(filepath.Separator),
+				filepath.Join(dataDir, "tenants")+string(filepath.Separator),
 			)
 			// Harden: block access to internal workspace files via shell commands.
 			// Prevents `cat ../config.json`, `cat memory.db` etc. from user workspaces.
@@ -276,6 +351,16 @@ func setupToolRegistry(
 			t.DenyPaths(internalDenyPaths...)
 		}
 	}
+	if gt, ok := toolsReg.Get("grep"); ok {
+		if t, ok := gt.(*tools.GrepTool); ok {
+			t.DenyPaths(internalDenyPaths...)
+		}
+	}
+	if gt, ok := toolsReg.Get("glob"); ok {
+		if t, ok := gt.(*tools.GlobTool); ok {
+			t.DenyPaths(internalDenyPaths...)
+		}
+	}
 	if sf, ok := toolsReg.Get("send_file"); ok {
 		if t, ok := sf.(*tools.SendFileTool); ok {
 			t.DenyPaths(internalDenyPaths...)
@@ -343,6 +428,7 @@ func wireTracingAndCron(
 		if cfg.Cron.DefaultTimezone != "" {
 			stores.Cron.SetDefaultTimezone(cfg.Cron.DefaultTimezone)
 		}
+		stores.Cron.SetMaxConcurrentRuns(cfg.Cron.MaxConcurrentRuns)
 	}
 
 	// Load secrets from config_secrets table before env overrides.
@@ -360,14 +446,41 @@ func wireTracingAndCron(
 
 // setupMemoryEmbeddings wires embedding provider to PGMemoryStore and triggers backfill.
 // Resolves embedding provider from DB providers with settings.embedding.enabled.
+// Returns the embedding migrator for `goclaw memory reembed` (nil without a
+// provider or outside PostgreSQL).
 func setupMemoryEmbeddings(
 	pgStores *store.Stores,
 	providerRegistry *providers.Registry,
-) {
+) *pg.PGEmbeddingMigrator {
+	var migrator *pg.PGEmbeddingMigrator
 	if pgStores.Memory != nil {
+		// Vector columns may have been resized by `goclaw memory reembed --dimensions`;
+		// read the live size before building the provider that pads to it.
+		_, isPG := pgStores.Memory.(*pg.PGMemoryStore)
+		if isPG && pgStores.DB != nil {
+			if dims, err := pg.VectorColumnDimensions(context.Background(), pgStores.DB); err != nil {
+				slog.Warn("vector column size unknown, assuming default", "error", err, "dimensions", store.RequiredMemoryEmbeddingDimensions)
+			} else {
+				store.SetMemoryEmbeddingDimensions(dims)
+			}
+		}
+
 		if embProvider := resolveEmbeddingProvider(pgStores.Providers, providerRegistry, pgStores.SystemConfigs); embProvider != nil {
 			pgStores.Memory.SetEmbeddingProvider(embProvider)
-			slog.Info("memory embeddings enabled", "provider", embProvider.Name(), "model", embProvider.Model())
+			slog.Info("memory embeddings enabled", "provider", embProvider.Name(), "model", embProvider.Model(),
+				"vector_dimensions", store.MemoryEmbeddingDimensions())
+
+			// Detect a provider switch since the stored vectors were written.
+			if isPG && pgStores.DB != nil {
+				migrator = pg.NewPGEmbeddingMigrator(pgStores.DB, pgStores.SystemConfigs, embProvider)
+				go func() {
+					ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+					defer cancel()
+					if err := migrator.CheckFingerprint(ctx); err != nil {
+						slog.Warn("embedding fingerprint check failed", "error", err)
+					}
+				}()
+			}
 
 			// Backfill embeddings for existing chunks that were stored without vectors.
 			type backfiller interface {
@@ -424,6 +537,7 @@ func setupMemoryEmbeddings(
 			slog.Warn("memory embeddings disabled (no API key), chunks stored without vectors")
 		}
 	}
+	return migrator
 }
 
 // seedSystemConfigs ensures system_configs has all expected keys for all tenants.
@@ -604,3 +718,34 @@ func setupSkillsSystem(
 	return skillsLoader, skillSearchTool, globalSkillsDir, bundledSkillsDir, builtinSkillsDir
 }
 
+// browserDownloadPublisher broadcasts finished browser downloads to the
+// tenant's WS clients of the user who ran the browser.
+func browserDownloadPublisher(msgBus *bus.MessageBus) func(browser.Download) {
+	return func(d browser.Download) {
+		tenantID, _ := uuid.Parse(d.TenantID)
+		bus.BroadcastForTenant(msgBus, protocol.EventBrowserDownloadFinished, tenantID, map[string]any{
+			"id":         d.ID,
+			"url":        d.URL,
+			"filename":   d.Filename,
+			"path":       d.Path,
+			"size":       d.Size,
+			"state":      d.State,
+			"error":      d.Error,
+			"userId":     d.UserID,
+			"agentKey":   d.AgentKey,
+			"sessionKey": d.SessionKey,
+		})
+	}
+}
+
+// browserCDPHeader builds the headers sent to a remote CDP endpoint.
+func browserCDPHeader(bc config.BrowserToolConfig) http.Header {
+	h := make(http.Header)
+	for k, v := range bc.CDPHeaders {
+		h.Set(k, v)
+	}
+	if bc.CDPToken != "" {
+		h.Set("Authorization", "Bearer "+bc.CDPToken)
+	}
+	return h
+}
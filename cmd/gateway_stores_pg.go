@@ -26,7 +26,7 @@ func setupStoresAndTracing(
 		os.Exit(1)
 	}
 
-	if err := checkSchemaOrAutoUpgrade(cfg.Database.PostgresDSN); err != nil {
+	if err := checkSchemaOrAutoUpgrade(cfg.Database.PostgresDSN, cfg.Database); err != nil {
 		slog.Error("schema compatibility check failed", "error", err)
 		os.Exit(1)
 	}
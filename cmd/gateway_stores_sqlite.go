@@ -61,7 +61,7 @@ func setupStoresAndTracing(
 			slog.Error("GOCLAW_POSTGRES_DSN is required. Set it in your environment or .env.local file.")
 			os.Exit(1)
 		}
-		if err := checkSchemaOrAutoUpgrade(cfg.Database.PostgresDSN); err != nil {
+		if err := checkSchemaOrAutoUpgrade(cfg.Database.PostgresDSN, cfg.Database); err != nil {
 			slog.Error("schema compatibility check failed", "error", err)
 			os.Exit(1)
 		}
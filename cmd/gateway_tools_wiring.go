@@ -31,9 +31,14 @@ func wireExtraTools(
 	// DateTime tool (precise time for cron scheduling, memory timestamps, etc.)
 	toolsReg.Register(tools.NewDateTimeTool())
 
-	// Cron tool (agent-facing)
-	toolsReg.Register(tools.NewCronTool(pgStores.Cron))
-	slog.Info("cron tool registered")
+	// Cron tools (agent-facing). cron_add/cron_list/cron_remove share the cron
+	// tool's store and group permission store.
+	cronTool := tools.NewCronTool(pgStores.Cron)
+	toolsReg.Register(cronTool)
+	toolsReg.Register(tools.NewCronAddTool(cronTool))
+	toolsReg.Register(tools.NewCronListTool(cronTool))
+	toolsReg.Register(tools.NewCronRemoveTool(cronTool))
+	slog.Info("cron tools registered")
 
 	// Heartbeat tool (agent-facing)
 	heartbeatTool = tools.NewHeartbeatTool(pgStores.Heartbeats, pgStores.ConfigPermissions)
@@ -65,6 +70,8 @@ func wireExtraTools(
 		"Read":       "read_file",
 		"Write":      "write_file",
 		"Edit":       "edit",
+		"Grep":       "grep",
+		"Glob":       "glob",
 		"Bash":       "exec",
 		"WebFetch":   "web_fetch",
 		"WebSearch":  "web_search",
@@ -76,7 +83,7 @@ func wireExtraTools(
 	}
 	slog.Info("tool aliases registered", "count", len(toolsReg.Aliases()))
 
-	// Allow read_file and list_files to access skills directories and CLI workspaces.
+	// Allow read_file, list_files, grep and glob to access skills directories and CLI workspaces.
 	homeDir, _ := os.UserHomeDir()
 	skillsAllowPaths := []string{globalSkillsDir, builtinSkillsDir, filepath.Join(dataDir, "tenants")}
 	if homeDir != "" {
@@ -102,10 +109,12 @@ func wireExtraTools(
 			pa.AllowPaths(userAllowPaths...)
 		}
 	}
-	if listTool, ok := toolsReg.Get("list_files"); ok {
-		if pa, ok := listTool.(tools.PathAllowable); ok {
-			pa.AllowPaths(skillsAllowPaths...)
-			pa.AllowPaths(userAllowPaths...)
+	for _, name := range []string{"list_files", "grep", "glob"} {
+		if listTool, ok := toolsReg.Get(name); ok {
+			if pa, ok := listTool.(tools.PathAllowable); ok {
+				pa.AllowPaths(skillsAllowPaths...)
+				pa.AllowPaths(userAllowPaths...)
+			}
 		}
 	}
 	// Write and edit tools also get user-configured allowed paths for cross-drive access.
@@ -140,10 +149,12 @@ func wireExtraTools(
 			}
 		}
 	}
-	// Wire BusAware on message tool
-	if t, ok := toolsReg.Get("message"); ok {
-		if ba, ok := t.(tools.BusAware); ok {
-			ba.SetMessageBus(msgBus)
+	// Wire BusAware on message and tts_speak tools
+	for _, name := range []string{"message", "tts_speak"} {
+		if t, ok := toolsReg.Get(name); ok {
+			if ba, ok := t.(tools.BusAware); ok {
+				ba.SetMessageBus(msgBus)
+			}
 		}
 	}
 
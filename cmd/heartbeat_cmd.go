@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func heartbeatCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "heartbeat",
+		Short: "Inspect heartbeat runs and snooze heartbeat alerts",
+	}
+	cmd.AddCommand(heartbeatHistoryCmd())
+	cmd.AddCommand(heartbeatSnoozeCmd())
+	cmd.AddCommand(heartbeatUnsnoozeCmd())
+	return cmd
+}
+
+func heartbeatHistoryCmd() *cobra.Command {
+	var jsonOutput bool
+	var limit int
+	cmd := &cobra.Command{
+		Use:   "history [agent]",
+		Short: "Show recent heartbeat outcomes for an agent",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			heartbeatHistoryRPC(args[0], limit, jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	cmd.Flags().IntVar(&limit, "limit", 20, "number of runs to show")
+	return cmd
+}
+
+func heartbeatSnoozeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snooze [agent] [hours]",
+		Short: "Withhold heartbeat alerts for N hours (runs still happen)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			hours, err := strconv.ParseFloat(args[1], 64)
+			if err != nil || hours <= 0 {
+				fmt.Fprintf(os.Stderr, "Error: hours must be a positive number\n")
+				os.Exit(1)
+			}
+			heartbeatSnoozeRPC(args[0], hours)
+		},
+	}
+}
+
+func heartbeatUnsnoozeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unsnooze [agent]",
+		Short: "Resume heartbeat alert delivery",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			heartbeatSnoozeRPC(args[0], 0)
+		},
+	}
+}
+
+// --- RPC implementations ---
+
+func heartbeatHistoryRPC(agent string, limit int, jsonOutput bool) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]any{"agentId": agent, "limit": limit})
+	resp, err := gatewayRPC(protocol.MethodHeartbeatLogs, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+
+	raw, _ := json.Marshal(resp.Payload)
+	var result struct {
+		Logs []store.HeartbeatHistoryEntry `json:"logs"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+		os.Exit(1)
+	}
+
+	printHeartbeatHistory(result.Logs, jsonOutput)
+}
+
+func heartbeatSnoozeRPC(agent string, hours float64) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]any{"agentId": agent, "hours": hours})
+	resp, err := gatewayRPC(protocol.MethodHeartbeatSnooze, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	if hours == 0 {
+		fmt.Printf("Heartbeat alerts resumed for %s\n", agent)
+		return
+	}
+	fmt.Printf("Heartbeat alerts snoozed for %s until %s\n", agent,
+		time.Now().Add(time.Duration(hours*float64(time.Hour))).Format(time.DateTime))
+}
+
+// --- Shared display ---
+
+func printHeartbeatHistory(entries []store.HeartbeatHistoryEntry, jsonOutput bool) {
+	if jsonOutput {
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No heartbeat runs recorded.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "RAN AT\tOUTCOME\tDETAIL\n")
+	for _, e := range entries {
+		detail := ""
+		switch {
+		case e.SkipReason != nil:
+			detail = *e.SkipReason
+		case e.Error != nil:
+			detail = *e.Error
+		case e.Summary != nil:
+			detail = *e.Summary
+		}
+		if len(detail) > 60 {
+			detail = detail[:57] + "..."
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.RanAt.Local().Format(time.DateTime), e.Outcome, detail)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func mcpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Model Context Protocol integration",
+	}
+	cmd.AddCommand(mcpServeCmd())
+	return cmd
+}
+
+func mcpServeCmd() *cobra.Command {
+	var agentKeys []string
+	var noChat bool
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Expose agents' tools and chat as an MCP server over stdio",
+		Long: "Run an MCP server on stdin/stdout that proxies to the running gateway.\n" +
+			"Every agent's effective tools (workspace files, memory, web, MCP tools...) are\n" +
+			"exposed as MCP tools and run as that agent; each agent also gets a chat_<agent>\n" +
+			"tool that sends a message through its full agent loop.\n\n" +
+			"With several agents, tool names are prefixed with \"<agent>__\".",
+		Example: `  goclaw mcp serve --agent coder
+
+  # Claude Desktop (claude_desktop_config.json):
+  "mcpServers": {
+    "goclaw": {"command": "goclaw", "args": ["mcp", "serve", "--agent", "coder"]}
+  }`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			requireGateway()
+			cfg, err := config.Load(resolveConfigPath())
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			b := &mcpServeBackend{
+				call: func(method, path string, body any) ([]byte, int, error) {
+					return gatewayHTTPDoRawWith(&http.Client{Timeout: timeout}, method, path, body)
+				},
+				chat: func(ctx context.Context, agentKey, sessionKey, message string) (string, error) {
+					return mcpChatSend(ctx, cfg, agentKey, sessionKey, message, timeout)
+				},
+			}
+			srv, err := newMCPServeServer(b, agentKeys, !noChat)
+			if err != nil {
+				return err
+			}
+			return mcpserver.ServeStdio(srv)
+		},
+	}
+	cmd.Flags().StringSliceVar(&agentKeys, "agent", nil, "agent key(s) to expose (default: all active agents)")
+	cmd.Flags().BoolVar(&noChat, "no-chat", false, "do not expose the chat_<agent> tools")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "maximum time for one tool call or chat turn")
+	return cmd
+}
+
+// mcpServeBackend is how the MCP server reaches the gateway: call for the HTTP
+// API, chat for agent turns over WebSocket.
+type mcpServeBackend struct {
+	call func(method, path string, body any) ([]byte, int, error)
+	chat func(ctx context.Context, agentKey, sessionKey, message string) (string, error)
+}
+
+type mcpServeAgent struct {
+	AgentKey    string `json:"agent_key"`
+	DisplayName string `json:"display_name"`
+	Frontmatter string `json:"frontmatter"`
+	Status      string `json:"status"`
+}
+
+// newMCPServeServer builds the MCP server: one proxy tool per (agent, tool)
+// plus a chat tool per agent.
+func newMCPServeServer(b *mcpServeBackend, agentKeys []string, withChat bool) (*mcpserver.MCPServer, error) {
+	agents, err := mcpServeAgents(b, agentKeys)
+	if err != nil {
+		return nil, err
+	}
+	srv := mcpserver.NewMCPServer("goclaw", Version, mcpserver.WithToolCapabilities(false))
+	prefixed := len(agents) > 1
+	var count int
+	for _, ag := range agents {
+		toolList, err := mcpServeGet[struct {
+			Tools []toolListEntry `json:"tools"`
+		}](b, "/v1/tools?agent="+url.QueryEscape(ag.AgentKey))
+		if err != nil {
+			return nil, fmt.Errorf("list tools of agent %s: %w", ag.AgentKey, err)
+		}
+		for _, t := range toolList.Tools {
+			schema, err := json.Marshal(t.Parameters)
+			if err != nil || t.Parameters == nil {
+				schema = []byte(`{"type":"object"}`)
+			}
+			name := mcpServeToolName(ag.AgentKey, t.Name, prefixed)
+			srv.AddTool(mcpgo.NewToolWithRawSchema(name, t.Description, schema), b.toolHandler(ag.AgentKey, t.Name))
+			count++
+		}
+		if withChat {
+			srv.AddTool(mcpServeChatTool(ag), b.chatHandler(ag.AgentKey))
+			count++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "goclaw mcp: serving %d tools for %d agent(s)\n", count, len(agents))
+	return srv, nil
+}
+
+// mcpServeAgents returns the requested agents (all active agents when none are
+// named), failing on unknown keys.
+func mcpServeAgents(b *mcpServeBackend, keys []string) ([]mcpServeAgent, error) {
+	resp, err := mcpServeGet[struct {
+		Agents []mcpServeAgent `json:"agents"`
+	}](b, "/v1/agents")
+	if err != nil {
+		return nil, fmt.Errorf("list agents: %w", err)
+	}
+	var out []mcpServeAgent
+	for _, ag := range resp.Agents {
+		if len(keys) == 0 && ag.Status != "" && ag.Status != "active" {
+			continue
+		}
+		if len(keys) == 0 || slices.Contains(keys, ag.AgentKey) {
+			out = append(out, ag)
+		}
+	}
+	for _, k := range keys {
+		if !slices.ContainsFunc(out, func(ag mcpServeAgent) bool { return ag.AgentKey == k }) {
+			return nil, fmt.Errorf("agent %q not found", k)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no active agents to serve")
+	}
+	return out, nil
+}
+
+func mcpServeGet[T any](b *mcpServeBackend, path string) (T, error) {
+	var out T
+	raw, status, err := b.call(http.MethodGet, path, nil)
+	if err != nil {
+		return out, err
+	}
+	if status >= 400 {
+		return out, parseHTTPError(raw, status)
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return out, fmt.Errorf("unmarshal response: %w", err)
+	}
+	return out, nil
+}
+
+var mcpToolNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// mcpServeToolName is the MCP-visible name of an agent tool. MCP clients only
+// accept [A-Za-z0-9_-] in tool names.
+func mcpServeToolName(agentKey, toolName string, prefixed bool) string {
+	if prefixed {
+		toolName = agentKey + "__" + toolName
+	}
+	return mcpToolNameUnsafe.ReplaceAllString(toolName, "_")
+}
+
+func mcpServeChatTool(ag mcpServeAgent) mcpgo.Tool {
+	name := ag.DisplayName
+	if name == "" {
+		name = ag.AgentKey
+	}
+	desc := fmt.Sprintf("Send a message to the GoClaw agent %q and return its reply. The agent runs its full loop (tools, memory, skills).", name)
+	if ag.Frontmatter != "" {
+		desc += " Expertise: " + ag.Frontmatter
+	}
+	return mcpgo.NewTool(mcpServeToolName(ag.AgentKey, "chat_"+ag.AgentKey, false),
+		mcpgo.WithDescription(desc),
+		mcpgo.WithString("message", mcpgo.Required(), mcpgo.Description("Message to send to the agent")),
+		mcpgo.WithString("session", mcpgo.Description("Conversation ID; reuse it to continue a conversation (default: a new conversation)")),
+	)
+}
+
+// toolHandler runs an agent tool through POST /v1/tools/invoke.
+func (b *mcpServeBackend) toolHandler(agentKey, toolName string) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		args := req.GetArguments()
+		if args == nil {
+			args = map[string]any{}
+		}
+		raw, status, err := b.call(http.MethodPost, "/v1/tools/invoke", map[string]any{
+			"tool":    toolName,
+			"args":    args,
+			"agentId": agentKey,
+		})
+		if err != nil {
+			return mcpgo.NewToolResultError(err.Error()), nil
+		}
+		if status >= 400 {
+			return mcpgo.NewToolResultError(parseHTTPError(raw, status).Error()), nil
+		}
+		var resp struct {
+			Result struct {
+				Output string `json:"output"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return mcpgo.NewToolResultError("invalid response from gateway"), nil
+		}
+		return mcpgo.NewToolResultText(resp.Result.Output), nil
+	}
+}
+
+// chatHandler sends one message to the agent. Conversations are keyed by the
+// optional session argument on the "mcp" channel.
+func (b *mcpServeBackend) chatHandler(agentKey string) mcpserver.ToolHandlerFunc {
+	return func(ctx context.Context, req mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		message, err := req.RequireString("message")
+		if err != nil || strings.TrimSpace(message) == "" {
+			return mcpgo.NewToolResultError("message is required"), nil
+		}
+		session := req.GetString("session", "")
+		if session == "" {
+			session = uuid.NewString()[:8]
+		}
+		sessionKey := sessions.BuildSessionKey(agentKey, "mcp", sessions.PeerDirect, session)
+		reply, err := b.chat(ctx, agentKey, sessionKey, message)
+		if err != nil {
+			return mcpgo.NewToolResultError(err.Error()), nil
+		}
+		return mcpgo.NewToolResultText(reply + "\n\n(session: " + session + ")"), nil
+	}
+}
+
+// mcpChatSend runs one non-streaming chat.send over a fresh WebSocket
+// connection. Unlike wsChatSend it writes nothing to stdout, which carries the
+// MCP protocol.
+func mcpChatSend(ctx context.Context, cfg *config.Config, agentKey, sessionKey, message string, timeout time.Duration) (string, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, "ws://"+gatewayLocalAddr(cfg)+"/ws", nil)
+	if err != nil {
+		return "", fmt.Errorf("connect to gateway: %w", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := wsConnect(conn, resolveGatewayToken()); err != nil {
+		return "", err
+	}
+	reqID := uuid.NewString()[:8]
+	params, _ := json.Marshal(map[string]any{
+		"message":    message,
+		"agentId":    agentKey,
+		"sessionKey": sessionKey,
+	})
+	if err := conn.WriteJSON(protocol.RequestFrame{
+		Type:   protocol.FrameTypeRequest,
+		ID:     reqID,
+		Method: protocol.MethodChatSend,
+		Params: params,
+	}); err != nil {
+		return "", fmt.Errorf("send chat: %w", err)
+	}
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return "", fmt.Errorf("read: %w", err)
+		}
+		if ft, _ := protocol.ParseFrameType(raw); ft != protocol.FrameTypeResponse {
+			continue
+		}
+		var resp protocol.ResponseFrame
+		if err := json.Unmarshal(raw, &resp); err != nil || resp.ID != reqID {
+			continue
+		}
+		if !resp.OK {
+			if resp.Error != nil {
+				return "", fmt.Errorf("agent error: %s", resp.Error.Message)
+			}
+			return "", fmt.Errorf("agent error (unknown)")
+		}
+		payload, _ := resp.Payload.(map[string]any)
+		content, _ := payload["content"].(string)
+		return content, nil
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+)
+
+func fakeMCPServeBackend(t *testing.T) (*mcpServeBackend, *[]map[string]any) {
+	t.Helper()
+	var invoked []map[string]any
+	b := &mcpServeBackend{
+		call: func(method, path string, body any) ([]byte, int, error) {
+			switch {
+			case path == "/v1/agents":
+				return []byte(`{"agents":[
+					{"agent_key":"coder","display_name":"Coder","status":"active"},
+					{"agent_key":"old","status":"inactive"},
+					{"agent_key":"researcher","status":"active"}]}`), http.StatusOK, nil
+			case strings.HasPrefix(path, "/v1/tools?agent="):
+				return []byte(`{"tools":[{"name":"read_file","description":"Read","parameters":{"type":"object"}},
+					{"name":"mcp_gh.list","description":"List"}]}`), http.StatusOK, nil
+			case path == "/v1/tools/invoke" && method == http.MethodPost:
+				invoked = append(invoked, body.(map[string]any))
+				return []byte(`{"result":{"output":"ok"}}`), http.StatusOK, nil
+			}
+			return []byte(`{"error":"not found"}`), http.StatusNotFound, nil
+		},
+		chat: func(_ context.Context, agentKey, sessionKey, message string) (string, error) {
+			return agentKey + "|" + sessionKey + "|" + message, nil
+		},
+	}
+	return b, &invoked
+}
+
+func callMCPTool(t *testing.T, h func(context.Context, mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error), args map[string]any) string {
+	t.Helper()
+	var req mcpgo.CallToolRequest
+	req.Params.Arguments = args
+	res, err := h(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError {
+		t.Fatalf("tool error: %+v", res.Content)
+	}
+	return res.Content[0].(mcpgo.TextContent).Text
+}
+
+func TestMCPServe_ExposesAgentToolsAndChat(t *testing.T) {
+	b, invoked := fakeMCPServeBackend(t)
+	srv, err := newMCPServeServer(b, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tools := srv.ListTools()
+	for _, name := range []string{"coder__read_file", "coder__mcp_gh_list", "chat_coder", "researcher__read_file", "chat_researcher"} {
+		if tools[name] == nil {
+			t.Errorf("tool %q not registered", name)
+		}
+	}
+	if len(tools) != 6 {
+		t.Errorf("registered %d tools, want 6 (inactive agents skipped)", len(tools))
+	}
+
+	out := callMCPTool(t, tools["coder__mcp_gh_list"].Handler, map[string]any{"repo": "a/b"})
+	if out != "ok" {
+		t.Errorf("output = %q", out)
+	}
+	got, _ := json.Marshal((*invoked)[0])
+	if want := `{"agentId":"coder","args":{"repo":"a/b"},"tool":"mcp_gh.list"}`; string(got) != want {
+		t.Errorf("invoke body = %s, want %s", got, want)
+	}
+
+	reply := callMCPTool(t, tools["chat_coder"].Handler, map[string]any{"message": "hi", "session": "s1"})
+	if !strings.HasPrefix(reply, "coder|agent:coder:mcp:direct:s1|hi") {
+		t.Errorf("chat reply = %q", reply)
+	}
+}
+
+func TestMCPServe_SingleAgentUnprefixed(t *testing.T) {
+	b, _ := fakeMCPServeBackend(t)
+	srv, err := newMCPServeServer(b, []string{"coder"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tools := srv.ListTools()
+	if tools["read_file"] == nil || tools["chat_coder"] != nil || len(tools) != 2 {
+		t.Errorf("unexpected tools: %v", tools)
+	}
+
+	if _, err := newMCPServeServer(b, []string{"missing"}, true); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func memoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memory",
+		Short: "Search, re-index and inspect agent memory",
+	}
+	cmd.PersistentFlags().String("agent", "default", "agent key or ID")
+	cmd.PersistentFlags().String("user", "", "user ID for per-user memory (default: shared memory)")
+	cmd.AddCommand(memorySearchCmd())
+	cmd.AddCommand(memoryIndexCmd())
+	cmd.AddCommand(memoryStatusCmd())
+	cmd.AddCommand(memoryReembedCmd())
+	return cmd
+}
+
+func memorySearchCmd() *cobra.Command {
+	var limit int
+	var minScore float64
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search memory and show matching chunks with scores",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			agentID, userID := memoryScopeFlags(cmd)
+			runMemorySearch(agentID, userID, strings.Join(args, " "), limit, minScore, jsonOutput)
+		},
+	}
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "max results (default: store setting)")
+	cmd.Flags().Float64Var(&minScore, "min-score", 0, "drop results scoring below this")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func memoryIndexCmd() *cobra.Command {
+	var path string
+	cmd := &cobra.Command{
+		Use:   "index",
+		Short: "Force re-index of memory documents (chunks + embeddings)",
+		Run: func(cmd *cobra.Command, args []string) {
+			agentID, userID := memoryScopeFlags(cmd)
+			runMemoryIndex(agentID, userID, path)
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "", "re-index a single document (default: all documents)")
+	return cmd
+}
+
+func memoryStatusCmd() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show document/chunk counts and embedding provider status",
+		Run: func(cmd *cobra.Command, args []string) {
+			agentID, _ := memoryScopeFlags(cmd)
+			runMemoryStatus(agentID, jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func memoryReembedCmd() *cobra.Command {
+	var dimensions int
+	cmd := &cobra.Command{
+		Use:   "reembed",
+		Short: "Re-generate all stored embeddings with the current embedding provider",
+		Long: `Re-generate every stored vector (memory chunks, knowledge graph entities,
+tasks, skills, agents, episodic summaries and vault documents) for all agents
+and tenants with the embedding provider the gateway is running with.
+
+Run this after switching embedding provider, model or dimensions. With
+--dimensions the pgvector columns are first resized to vector(N); existing
+vectors are dropped by the resize and regenerated by the same run.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runMemoryReembed(dimensions)
+		},
+	}
+	cmd.Flags().IntVar(&dimensions, "dimensions", 0, "resize vector columns to this size first (max 2000; default: keep current)")
+	return cmd
+}
+
+func memoryScopeFlags(cmd *cobra.Command) (agentID, userID string) {
+	agentID, _ = cmd.Flags().GetString("agent")
+	userID, _ = cmd.Flags().GetString("user")
+	return agentID, userID
+}
+
+// memoryAgentPath resolves an agent key to its ID (memory endpoints are keyed
+// by agent ID) and returns the agent's memory API prefix.
+func memoryAgentPath(agentKey string) string {
+	requireGateway()
+
+	ag, err := gatewayHTTPGetTyped[struct {
+		ID string `json:"id"`
+	}]("/v1/agents/" + url.PathEscape(agentKey))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return "/v1/agents/" + url.PathEscape(ag.ID) + "/memory"
+}
+
+func runMemorySearch(agentKey, userID, query string, limit int, minScore float64, jsonOutput bool) {
+	base := memoryAgentPath(agentKey)
+
+	resp, err := gatewayHTTPPostTyped[struct {
+		Results []struct {
+			Path      string  `json:"path"`
+			StartLine int     `json:"start_line"`
+			EndLine   int     `json:"end_line"`
+			Score     float64 `json:"score"`
+			Snippet   string  `json:"snippet"`
+			Scope     string  `json:"scope"`
+		} `json:"results"`
+	}](base+"/search", map[string]any{
+		"query":       query,
+		"user_id":     userID,
+		"max_results": limit,
+		"min_score":   minScore,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(resp.Results, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	if len(resp.Results) == 0 {
+		fmt.Println("No matches.")
+		return
+	}
+	for i, r := range resp.Results {
+		scope := ""
+		if r.Scope != "" {
+			scope = " [" + r.Scope + "]"
+		}
+		fmt.Printf("%d. %.3f  %s:%d-%d%s\n", i+1, r.Score, r.Path, r.StartLine, r.EndLine, scope)
+		snippet := strings.TrimSpace(r.Snippet)
+		if runes := []rune(snippet); len(runes) > 300 {
+			snippet = string(runes[:297]) + "..."
+		}
+		for line := range strings.SplitSeq(snippet, "\n") {
+			fmt.Printf("     %s\n", line)
+		}
+	}
+}
+
+func runMemoryIndex(agentKey, userID, path string) {
+	base := memoryAgentPath(agentKey)
+
+	endpoint, body := base+"/index-all", map[string]any{"user_id": userID}
+	if path != "" {
+		endpoint, body["path"] = base+"/index", path
+	}
+
+	// Re-embedding a large workspace can take a while; don't use the default 10s client.
+	start := time.Now()
+	raw, status, err := gatewayHTTPDoRawWith(&http.Client{Timeout: 30 * time.Minute}, http.MethodPost, endpoint, body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if status >= 400 {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", parseHTTPError(raw, status))
+		os.Exit(1)
+	}
+
+	target := "all documents"
+	if path != "" {
+		target = path
+	}
+	fmt.Printf("Re-indexed %s in %s.\n", target, time.Since(start).Round(time.Millisecond))
+}
+
+func runMemoryReembed(dimensions int) {
+	requireGateway()
+
+	start := time.Now()
+	raw, status, err := gatewayHTTPDoRawWith(&http.Client{Timeout: 2 * time.Hour}, http.MethodPost,
+		"/v1/memory/reembed", map[string]any{"dimensions": dimensions})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if status >= 400 {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", parseHTTPError(raw, status))
+		os.Exit(1)
+	}
+
+	var res struct {
+		Dimensions int            `json:"dimensions"`
+		Resized    bool           `json:"resized"`
+		Updated    map[string]int `json:"updated"`
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: decode response: %v\n", err)
+		os.Exit(1)
+	}
+
+	if res.Resized {
+		fmt.Printf("Resized vector columns to vector(%d).\n", res.Dimensions)
+	}
+	tables := make([]string, 0, len(res.Updated))
+	for t := range res.Updated {
+		tables = append(tables, t)
+	}
+	slices.Sort(tables)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, t := range tables {
+		fmt.Fprintf(tw, "%s:\t%d\n", t, res.Updated[t])
+	}
+	tw.Flush()
+	fmt.Printf("Re-embedded in %s.\n", time.Since(start).Round(time.Millisecond))
+}
+
+func runMemoryStatus(agentKey string, jsonOutput bool) {
+	base := memoryAgentPath(agentKey)
+
+	st, err := gatewayHTTPGetTyped[struct {
+		Documents         int    `json:"documents"`
+		Chunks            int    `json:"chunks"`
+		EmbeddedChunks    int    `json:"embedded_chunks"`
+		VectorSearch      bool   `json:"vector_search"`
+		EmbeddingProvider string `json:"embedding_provider"`
+		EmbeddingModel    string `json:"embedding_model"`
+		VectorDimensions  int    `json:"vector_dimensions,omitempty"`
+		ReembedRequired   string `json:"reembed_required,omitempty"`
+	}](base + "/status")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(st, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	embedding := "not configured (full-text search only)"
+	switch {
+	case !st.VectorSearch:
+		embedding = "unavailable in this edition (full-text search only)"
+	case st.EmbeddingProvider != "":
+		embedding = st.EmbeddingProvider
+		if st.EmbeddingModel != "" {
+			embedding += " / " + st.EmbeddingModel
+		}
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Agent:\t%s\n", agentKey)
+	fmt.Fprintf(tw, "Documents:\t%d\n", st.Documents)
+	fmt.Fprintf(tw, "Chunks:\t%d\n", st.Chunks)
+	if st.VectorSearch {
+		fmt.Fprintf(tw, "Embedded:\t%d/%d\n", st.EmbeddedChunks, st.Chunks)
+	}
+	fmt.Fprintf(tw, "Embeddings:\t%s\n", embedding)
+	if st.VectorDimensions > 0 {
+		fmt.Fprintf(tw, "Vector size:\t%d\n", st.VectorDimensions)
+	}
+	tw.Flush()
+
+	if st.ReembedRequired != "" {
+		fmt.Printf("\nWarning: %s.\n", st.ReembedRequired)
+	}
+
+	if st.VectorSearch && st.EmbeddingProvider != "" && st.EmbeddedChunks < st.Chunks {
+		fmt.Printf("\n%d chunks have no embedding; run `goclaw memory index --agent %s` to backfill.\n",
+			st.Chunks-st.EmbeddedChunks, agentKey)
+	}
+}
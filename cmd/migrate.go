@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -100,6 +101,7 @@ func migrateCmd() *cobra.Command {
 	cmd.AddCommand(migrateUpCmd())
 	cmd.AddCommand(migrateDownCmd())
 	cmd.AddCommand(migrateVersionCmd())
+	cmd.AddCommand(migrateStatusCmd())
 	cmd.AddCommand(migrateForceCmd())
 	cmd.AddCommand(migrateGotoCmd())
 	cmd.AddCommand(migrateDropCmd())
@@ -122,6 +124,7 @@ func migrateUpCmd() *cobra.Command {
 			}
 			defer m.Close()
 
+			from := migratorVersion(m)
 			if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 				return fmt.Errorf("migrate up: %w", err)
 			}
@@ -136,6 +139,7 @@ func migrateUpCmd() *cobra.Command {
 				return nil
 			}
 			defer db.Close()
+			recordMigrationHistory(db, from, v)
 
 			count, hookErr := upgrade.RunPendingHooks(context.Background(), db)
 			if hookErr != nil {
@@ -151,9 +155,13 @@ func migrateUpCmd() *cobra.Command {
 
 func migrateDownCmd() *cobra.Command {
 	var steps int
+	var to int
+	var force bool
 	cmd := &cobra.Command{
 		Use:   "down",
 		Short: "Roll back migrations (default: 1 step)",
+		Long: "Rolls back migrations by --steps or down to --to <version>. Refuses to run when a\n" +
+			"rollback would drop a non-empty table or a column holding data, unless --force is given.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			dsn, err := resolveDSN()
 			if err != nil {
@@ -165,11 +173,30 @@ func migrateDownCmd() *cobra.Command {
 			}
 			defer m.Close()
 
-			if steps <= 0 {
-				steps = 1
+			db, err := sql.Open("pgx", dsn)
+			if err != nil {
+				return fmt.Errorf("connect: %w", err)
+			}
+			defer db.Close()
+
+			current := migratorVersion(m)
+			var target uint
+			if cmd.Flags().Changed("to") {
+				if to < 0 || uint(to) >= current {
+					return fmt.Errorf("--to %d must be below the current version %d", to, current)
+				}
+				target = uint(to)
+			} else {
+				if steps <= 0 {
+					steps = 1
+				}
+				if target, err = versionStepsBack(current, steps); err != nil {
+					return err
+				}
 			}
-			if err := m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-				return fmt.Errorf("migrate down: %w", err)
+
+			if err := rollbackTo(m, db, current, target, force); err != nil {
+				return err
 			}
 
 			v, dirty, _ := m.Version()
@@ -178,9 +205,77 @@ func migrateDownCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().IntVarP(&steps, "steps", "n", 1, "number of steps to roll back")
+	cmd.Flags().IntVar(&to, "to", 0, "roll back to this version (later migrations are reverted)")
+	cmd.Flags().BoolVar(&force, "force", false, "roll back even if tables or columns holding data would be dropped")
+	cmd.MarkFlagsMutuallyExclusive("steps", "to")
 	return cmd
 }
 
+// rollbackTo migrates down from current to target after checking that no
+// non-empty table or populated column would be dropped (unless force).
+func rollbackTo(m *migrate.Migrate, db *sql.DB, current, target uint, force bool) error {
+	ctx := context.Background()
+	atRisk, err := upgrade.CheckRollback(ctx, db, resolveMigrationsDir(), current, target)
+	if err != nil {
+		return fmt.Errorf("rollback pre-check: %w", err)
+	}
+	if len(atRisk) > 0 {
+		if !force {
+			fmt.Printf("Rolling back v%d -> v%d would drop data in:\n", current, target)
+			for _, d := range atRisk {
+				fmt.Printf("  - %s\n", d)
+			}
+			fmt.Println("\nBack up the database first, then re-run with --force.")
+			return errors.New("rollback would drop data")
+		}
+		slog.Warn("rollback dropping data (--force)", "objects", len(atRisk))
+	}
+
+	if target == 0 {
+		err = m.Down()
+	} else {
+		err = m.Migrate(target)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate down: %w", err)
+	}
+	recordMigrationHistory(db, current, migratorVersion(m))
+	return nil
+}
+
+// versionStepsBack returns the migration version n files below current.
+func versionStepsBack(current uint, n int) (uint, error) {
+	files, err := upgrade.ListMigrations(resolveMigrationsDir(), "up")
+	if err != nil {
+		return 0, err
+	}
+	idx := slices.IndexFunc(files, func(f upgrade.MigrationFile) bool { return f.Version == current })
+	if idx < 0 {
+		return 0, fmt.Errorf("current version %d not found in migrations dir", current)
+	}
+	if idx-n < 0 {
+		return 0, nil
+	}
+	return files[idx-n].Version, nil
+}
+
+// migratorVersion returns the current schema version (0 when none applied).
+func migratorVersion(m *migrate.Migrate) uint {
+	v, _, err := m.Version()
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// recordMigrationHistory logs applied/rolled-back versions for `migrate status`.
+// Best-effort: the schema change itself already succeeded.
+func recordMigrationHistory(db *sql.DB, from, to uint) {
+	if err := upgrade.RecordMigrations(context.Background(), db, resolveMigrationsDir(), from, to); err != nil {
+		slog.Warn("could not record migration history", "error", err)
+	}
+}
+
 func migrateVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -206,6 +301,51 @@ func migrateVersionCmd() *cobra.Command {
 	}
 }
 
+func migrateStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "List migrations with their applied time",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dsn, err := resolveDSN()
+			if err != nil {
+				return err
+			}
+			m, err := newMigrator(dsn)
+			if err != nil {
+				return err
+			}
+			defer m.Close()
+
+			db, err := sql.Open("pgx", dsn)
+			if err != nil {
+				return fmt.Errorf("connect: %w", err)
+			}
+			defer db.Close()
+
+			current := migratorVersion(m)
+			_, dirty, _ := m.Version()
+			list, err := upgrade.ListMigrationStatus(context.Background(), db, resolveMigrationsDir(), current)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("version: %d, dirty: %v\n\n", current, dirty)
+			fmt.Printf("  %-8s %-20s %s\n", "VERSION", "APPLIED AT", "NAME")
+			for _, st := range list {
+				applied := "pending"
+				if st.Applied {
+					applied = "applied (unknown)"
+					if !st.AppliedAt.IsZero() {
+						applied = st.AppliedAt.Local().Format("2006-01-02 15:04:05")
+					}
+				}
+				fmt.Printf("  %06d   %-20s %s\n", st.Version, applied, strings.TrimSuffix(st.Name, ".up.sql"))
+			}
+			return nil
+		},
+	}
+}
+
 func migrateForceCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "force <version>",
@@ -236,7 +376,8 @@ func migrateForceCmd() *cobra.Command {
 }
 
 func migrateGotoCmd() *cobra.Command {
-	return &cobra.Command{
+	var force bool
+	cmd := &cobra.Command{
 		Use:   "goto <version>",
 		Short: "Migrate to a specific version",
 		Args:  cobra.ExactArgs(1),
@@ -255,13 +396,29 @@ func migrateGotoCmd() *cobra.Command {
 			}
 			defer m.Close()
 
-			if err := m.Migrate(uint(version)); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-				return fmt.Errorf("migrate goto: %w", err)
+			db, err := sql.Open("pgx", dsn)
+			if err != nil {
+				return fmt.Errorf("connect: %w", err)
+			}
+			defer db.Close()
+
+			current := migratorVersion(m)
+			if uint(version) < current {
+				if err := rollbackTo(m, db, current, uint(version), force); err != nil {
+					return err
+				}
+			} else {
+				if err := m.Migrate(uint(version)); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+					return fmt.Errorf("migrate goto: %w", err)
+				}
+				recordMigrationHistory(db, current, migratorVersion(m))
 			}
 			slog.Info("migrated to version", "version", version)
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&force, "force", false, "when going down, proceed even if tables or columns holding data would be dropped")
+	return cmd
 }
 
 func migrateDropCmd() *cobra.Command {
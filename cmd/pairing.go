@@ -5,22 +5,25 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"text/tabwriter"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/spf13/cobra"
 
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 func pairingCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pairing",
-		Short: "Manage device pairing (approve, list, revoke)",
+		Short: "Manage device pairing (approve, deny, list, revoke)",
 	}
 
 	cmd.AddCommand(pairingApproveCmd())
+	cmd.AddCommand(pairingDenyCmd())
 	cmd.AddCommand(pairingListCmd())
 	cmd.AddCommand(pairingRevokeCmd())
 
@@ -137,11 +140,93 @@ func pairingApproveByCode(code string) {
 }
 
 func pairingListCmd() *cobra.Command {
-	return &cobra.Command{
+	var jsonOutput bool
+	var channel string
+	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "List pending and paired devices",
+		Short: "List pending codes and paired senders",
+		Run: func(cmd *cobra.Command, args []string) {
+			var params json.RawMessage
+			if channel != "" {
+				params, _ = json.Marshal(map[string]string{"channel": channel})
+			}
+			resp, err := gatewayRPC(protocol.MethodPairingList, params)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if !resp.OK {
+				fmt.Printf("Failed: %s\n", resp.Error.Message)
+				os.Exit(1)
+			}
+
+			if jsonOutput {
+				data, _ := json.MarshalIndent(resp.Payload, "", "  ")
+				fmt.Println(string(data))
+				return
+			}
+
+			raw, _ := json.Marshal(resp.Payload)
+			var result struct {
+				Pending []store.PairingRequestData `json:"pending"`
+				Paired  []store.PairedDeviceData   `json:"paired"`
+			}
+			if err := json.Unmarshal(raw, &result); err != nil {
+				fmt.Printf("Error parsing pairing list: %v\n", err)
+				os.Exit(1)
+			}
+			printPairings(result.Pending, result.Paired)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	cmd.Flags().StringVar(&channel, "channel", "", "only show this channel (e.g. telegram)")
+	return cmd
+}
+
+func printPairings(pending []store.PairingRequestData, paired []store.PairedDeviceData) {
+	if len(pending) == 0 {
+		fmt.Println("No pending pairing requests.")
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "CODE\tCHANNEL\tSENDER\tEXPIRES\n")
+		for _, p := range pending {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Code, p.Channel, p.SenderID, pairingTime(p.ExpiresAt))
+		}
+		tw.Flush()
+	}
+	fmt.Println()
+
+	if len(paired) == 0 {
+		fmt.Println("No paired senders.")
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "CHANNEL\tSENDER\tPAIRED BY\tPAIRED AT\tEXPIRES\n")
+	for _, p := range paired {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			p.Channel, p.SenderID, p.PairedBy, pairingTime(p.PairedAt), pairingTime(p.ExpiresAt))
+	}
+	tw.Flush()
+}
+
+// pairingTime formats a Unix-ms timestamp; 0 means no expiry.
+func pairingTime(ms int64) string {
+	if ms == 0 {
+		return "never"
+	}
+	return time.UnixMilli(ms).Format(time.DateTime)
+}
+
+func pairingDenyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "deny <code>",
+		Short: "Reject a pending pairing code",
+		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			resp, err := gatewayRPC(protocol.MethodPairingList, nil)
+			params, _ := json.Marshal(map[string]string{"code": args[0]})
+
+			resp, err := gatewayRPC(protocol.MethodPairingDeny, params)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				os.Exit(1)
@@ -152,8 +237,7 @@ func pairingListCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
-			data, _ := json.MarshalIndent(resp.Payload, "", "  ")
-			fmt.Println(string(data))
+			fmt.Printf("Pairing denied. Code: %s\n", args[0])
 		},
 	}
 }
@@ -192,12 +276,7 @@ func gatewayRPC(method string, params json.RawMessage) (*protocol.ResponseFrame,
 		return nil, fmt.Errorf("load config: %w", err)
 	}
 
-	host := cfg.Gateway.Host
-	if host == "0.0.0.0" {
-		host = "127.0.0.1"
-	}
-
-	u := url.URL{Scheme: "ws", Host: fmt.Sprintf("%s:%d", host, cfg.Gateway.Port), Path: "/ws"}
+	u := url.URL{Scheme: "ws", Host: gatewayLocalAddr(cfg), Path: "/ws"}
 	conn, _, err := websocket.DefaultDialer.Dial(u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("connect to gateway at %s: %w", u.String(), err)
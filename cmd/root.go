@@ -39,9 +39,15 @@ func init() {
 	rootCmd.AddCommand(providersCmd())
 	rootCmd.AddCommand(channelsCmd())
 	rootCmd.AddCommand(cronCmd())
+	rootCmd.AddCommand(heartbeatCmd())
 	rootCmd.AddCommand(skillsCmd())
+	rootCmd.AddCommand(toolsCmd())
+	rootCmd.AddCommand(mcpCmd())
+	rootCmd.AddCommand(memoryCmd())
+	rootCmd.AddCommand(usageCmd())
 	rootCmd.AddCommand(sessionsCmd())
 	rootCmd.AddCommand(migrateCmd())
+	rootCmd.AddCommand(seedCmd())
 	rootCmd.AddCommand(upgradeCmd())
 	rootCmd.AddCommand(backupCmd())
 	rootCmd.AddCommand(restoreCmd())
@@ -49,6 +55,9 @@ func init() {
 	rootCmd.AddCommand(tenantRestoreCmd())
 	rootCmd.AddCommand(authCmd())
 	rootCmd.AddCommand(setupCmd())
+	rootCmd.AddCommand(benchCmd())
+	rootCmd.AddCommand(workspaceCmd())
+	rootCmd.AddCommand(secretsCmd())
 }
 
 func versionCmd() *cobra.Command {
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/titanous/json5"
+
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
+)
+
+func secretsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Check and store secret:// references",
+		Long: `Check and store secret:// references.
+
+Any config value, GOCLAW_* env var, config secret or provider API key can be
+a reference of the form secret://<backend>/<key>, resolved when the config
+is loaded:
+
+  secret://keyring/<name>                OS keyring, service "goclaw"
+  secret://vault/<mount>/<path>#<field>  HashiCorp Vault KV v2 (field default "value")
+  secret://aws/<name-or-arn>[#<field>]   AWS Secrets Manager`,
+	}
+	cmd.AddCommand(secretsCheckCmd())
+	cmd.AddCommand(secretsSetCmd())
+	return cmd
+}
+
+func secretsCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "Resolve every secret:// reference in the config file and env",
+		Run: func(cmd *cobra.Command, args []string) {
+			refs := map[string]string{}
+			cfgPath := resolveConfigPath()
+			if data, err := os.ReadFile(cfgPath); err == nil {
+				var raw map[string]any
+				if err := json5.Unmarshal(data, &raw); err != nil {
+					fmt.Fprintf(os.Stderr, "Invalid config: %s\n", err)
+					os.Exit(1)
+				}
+				refs = secrets.Refs(raw)
+			}
+			for _, kv := range os.Environ() {
+				name, val, _ := strings.Cut(kv, "=")
+				if secrets.IsRef(val) {
+					refs["$"+name] = val
+				}
+			}
+			if len(refs) == 0 {
+				fmt.Println("No secret references found.")
+				return
+			}
+
+			failed := false
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tREFERENCE\tSTATUS")
+			for _, key := range slices.Sorted(maps.Keys(refs)) {
+				status := "ok"
+				if _, err := secrets.Resolve(context.Background(), refs[key]); err != nil {
+					status = "error: " + err.Error()
+					failed = true
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", key, refs[key], status)
+			}
+			w.Flush()
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func secretsSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name>",
+		Short: "Store a secret in the OS keyring (value read from stdin)",
+		Long: `Store a secret in the OS keyring under service "goclaw". The value is read
+from the first line of stdin, e.g.:
+
+  printf %s "$ANTHROPIC_API_KEY" | goclaw secrets set anthropic
+
+then reference it as secret://keyring/anthropic.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+				fmt.Fprint(os.Stderr, "Value: ")
+			}
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			value := strings.TrimRight(line, "\r\n")
+			if value == "" {
+				if err != nil {
+					exitOnErr(fmt.Errorf("no value on stdin"))
+				}
+				exitOnErr(fmt.Errorf("empty value"))
+			}
+			exitOnErr(secrets.Keyring{Service: secrets.KeyringService}.Set(args[0], value))
+			fmt.Printf("Stored. Reference it as %skeyring/%s\n", secrets.Scheme, args[0])
+		},
+	}
+}
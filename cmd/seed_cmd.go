@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/store/pg"
+)
+
+func seedCmd() *cobra.Command {
+	var demo bool
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Seed managed-mode data (placeholder providers; --demo adds example agents)",
+		Long: "Seeds disabled placeholder providers. With --demo, also creates example agents\n" +
+			"(coder, researcher), sample skills, a cron job and a memory document.\n" +
+			"Safe to run repeatedly: existing items are left untouched.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(resolveConfigPath())
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			if cfg.Database.PostgresDSN == "" {
+				return fmt.Errorf("GOCLAW_POSTGRES_DSN environment variable is not set")
+			}
+			return seedManagedData(cfg, demo)
+		},
+	}
+	cmd.Flags().BoolVar(&demo, "demo", false, "also create demo agents, skills, a cron job and a memory document")
+	return cmd
+}
+
+// seedManagedData seeds placeholder providers and, when demo is set, the demo
+// data set. Every step checks for existing rows first, so re-running is a no-op.
+func seedManagedData(cfg *config.Config, demo bool) error {
+	if err := seedOnboardPlaceholders(cfg.Database.PostgresDSN); err != nil {
+		return err
+	}
+	if !demo {
+		return nil
+	}
+
+	dataDir := cfg.ResolvedDataDir()
+	stores, err := pg.NewPGStores(store.StoreConfig{
+		PostgresDSN:      cfg.Database.PostgresDSN,
+		EncryptionKey:    os.Getenv("GOCLAW_ENCRYPTION_KEY"),
+		SkillsStorageDir: filepath.Join(dataDir, "skills-store"),
+	})
+	if err != nil {
+		return fmt.Errorf("open PG stores: %w", err)
+	}
+	if stores.DB != nil {
+		defer stores.DB.Close()
+	}
+
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	s := &demoSeeder{cfg: cfg, stores: stores, dataDir: dataDir, owner: "system"}
+	if len(cfg.Gateway.OwnerIDs) > 0 {
+		s.owner = cfg.Gateway.OwnerIDs[0]
+	}
+	return s.run(ctx)
+}
+
+// --- Demo data ---
+
+type demoAgent struct {
+	Key, DisplayName, Emoji, Frontmatter string
+}
+
+var demoAgents = []demoAgent{
+	{Key: "coder", DisplayName: "Coder", Emoji: "🧑‍💻", Frontmatter: "Writes, reviews and debugs code; explains changes and trade-offs."},
+	{Key: "researcher", DisplayName: "Researcher", Emoji: "🔎", Frontmatter: "Searches the web, reads sources and writes cited summaries."},
+}
+
+type demoSkill struct {
+	Slug, AgentKey, Content string
+}
+
+var demoSkills = []demoSkill{
+	{Slug: "demo-code-review", AgentKey: "coder", Content: `---
+name: Code Review Checklist
+description: Review a diff for correctness, readability and tests before approving it.
+---
+
+# Code Review Checklist
+
+When asked to review code:
+
+1. Read the whole change first and restate its intent in one sentence.
+2. Check correctness: edge cases, error handling, concurrency, resource cleanup.
+3. Check readability: naming, function size, comments that explain "why".
+4. Check tests: new behavior is covered, failure paths included.
+5. Reply with a short verdict, then a numbered list of findings (most important first).
+`},
+	{Slug: "demo-research-brief", AgentKey: "researcher", Content: `---
+name: Research Brief
+description: Produce a concise, cited brief on a topic from several independent sources.
+---
+
+# Research Brief
+
+When asked to research a topic:
+
+1. Search for at least three independent sources; prefer primary sources.
+2. Note the publication date of each source and skip outdated ones.
+3. Write a brief: a 3-sentence summary, key facts as bullets, open questions.
+4. Cite every fact with its source URL.
+`},
+}
+
+const (
+	demoCronName    = "demo-daily-digest"
+	demoCronAgent   = "researcher"
+	demoCronExpr    = "0 9 * * 1-5"
+	demoCronMessage = "Write a short digest of notable news in AI tooling from the last 24 hours using the Research Brief skill."
+
+	demoMemoryAgent   = "coder"
+	demoMemoryPath    = "memory/getting-started.md"
+	demoMemoryContent = `# Getting started (demo)
+
+- This deployment was seeded with demo data by ` + "`goclaw seed --demo`" + `.
+- Agents: coder (code review skill) and researcher (research brief skill, weekday 09:00 digest cron job).
+- Delete the demo agents from the dashboard once you have created your own.
+`
+)
+
+type demoSeeder struct {
+	cfg     *config.Config
+	stores  *store.Stores
+	dataDir string
+	owner   string
+	agents  map[string]uuid.UUID
+}
+
+func (s *demoSeeder) run(ctx context.Context) error {
+	s.agents = make(map[string]uuid.UUID, len(demoAgents))
+	for _, a := range demoAgents {
+		id, err := s.ensureAgent(ctx, a)
+		if err != nil {
+			return fmt.Errorf("seed agent %s: %w", a.Key, err)
+		}
+		s.agents[a.Key] = id
+	}
+	for _, sk := range demoSkills {
+		if err := s.ensureSkill(ctx, sk); err != nil {
+			return fmt.Errorf("seed skill %s: %w", sk.Slug, err)
+		}
+	}
+	if err := s.ensureCronJob(ctx); err != nil {
+		return fmt.Errorf("seed cron job: %w", err)
+	}
+	if err := s.ensureMemoryDoc(ctx); err != nil {
+		return fmt.Errorf("seed memory document: %w", err)
+	}
+	fmt.Println("Demo data ready: agents coder, researcher; skills, cron job and memory document seeded.")
+	return nil
+}
+
+// ensureAgent creates a predefined demo agent with the same defaults as the
+// agents HTTP API, then seeds its context files.
+func (s *demoSeeder) ensureAgent(ctx context.Context, a demoAgent) (uuid.UUID, error) {
+	if existing, _ := s.stores.Agents.GetByKey(ctx, a.Key); existing != nil {
+		slog.Info("seed: agent exists, skipping", "agent", a.Key)
+		return existing.ID, nil
+	}
+	ag := &store.AgentData{
+		TenantID:            store.MasterTenantID,
+		AgentKey:            a.Key,
+		DisplayName:         a.DisplayName,
+		Emoji:               a.Emoji,
+		Frontmatter:         a.Frontmatter,
+		OwnerID:             s.owner,
+		Provider:            s.cfg.Agents.Defaults.Provider,
+		Model:               s.cfg.Agents.Defaults.Model,
+		ContextWindow:       config.DefaultContextWindow,
+		MaxToolIterations:   config.DefaultMaxIterations,
+		Workspace:           filepath.Join(s.cfg.WorkspacePath(), a.Key),
+		RestrictToWorkspace: true,
+		AgentType:           store.AgentTypePredefined,
+		Status:              store.AgentStatusActive,
+		CompactionConfig:    json.RawMessage(`{}`),
+		MemoryConfig:        json.RawMessage(`{"enabled":true}`),
+	}
+	if err := s.stores.Agents.Create(ctx, ag); err != nil {
+		return uuid.Nil, err
+	}
+	if _, err := bootstrap.SeedToStore(ctx, s.stores.Agents, ag.ID, ag.AgentType); err != nil {
+		slog.Warn("seed: context files not seeded", "agent", a.Key, "error", err)
+	}
+	slog.Info("seed: agent created", "agent", a.Key)
+	return ag.ID, nil
+}
+
+// ensureSkill writes SKILL.md into the skills store, registers it and grants
+// it to its demo agent — the same layout skill_manage produces.
+func (s *demoSeeder) ensureSkill(ctx context.Context, sk demoSkill) error {
+	ms, ok := s.stores.Skills.(store.SkillManageStore)
+	if !ok {
+		return nil
+	}
+	if _, exists := ms.GetSkillOwnerIDBySlug(ctx, sk.Slug); exists {
+		slog.Info("seed: skill exists, skipping", "skill", sk.Slug)
+		return nil
+	}
+	name, description, _, frontmatter := skills.ParseSkillFrontmatter(sk.Content)
+
+	version := ms.GetNextVersion(ctx, sk.Slug)
+	destDir := filepath.Join(config.TenantSkillsStoreDir(s.dataDir, store.MasterTenantID, ""), sk.Slug, strconv.Itoa(version))
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+	content := []byte(sk.Content)
+	if err := os.WriteFile(filepath.Join(destDir, "SKILL.md"), content, 0644); err != nil {
+		return err
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	id, err := ms.CreateSkillManaged(ctx, store.SkillCreateParams{
+		Name:        name,
+		Slug:        sk.Slug,
+		Description: &description,
+		OwnerID:     s.owner,
+		Visibility:  "internal",
+		Version:     version,
+		FilePath:    destDir,
+		FileSize:    int64(len(content)),
+		FileHash:    &hash,
+		Frontmatter: frontmatter,
+	})
+	if err != nil {
+		return err
+	}
+	if agentID, ok := s.agents[sk.AgentKey]; ok {
+		if err := ms.GrantToAgent(ctx, id, agentID, 0, s.owner); err != nil {
+			slog.Warn("seed: skill grant failed", "skill", sk.Slug, "agent", sk.AgentKey, "error", err)
+		}
+	}
+	slog.Info("seed: skill created", "skill", sk.Slug)
+	return nil
+}
+
+func (s *demoSeeder) ensureCronJob(ctx context.Context) error {
+	agentID := s.agents[demoCronAgent].String()
+	for _, j := range s.stores.Cron.ListJobs(ctx, true, agentID, "") {
+		if j.Name == demoCronName {
+			slog.Info("seed: cron job exists, skipping", "job", demoCronName)
+			return nil
+		}
+	}
+	schedule := store.CronSchedule{Kind: "cron", Expr: demoCronExpr}
+	if _, err := s.stores.Cron.AddJob(ctx, demoCronName, schedule, demoCronMessage, false, "", "", agentID, s.owner); err != nil {
+		return err
+	}
+	slog.Info("seed: cron job created", "job", demoCronName)
+	return nil
+}
+
+func (s *demoSeeder) ensureMemoryDoc(ctx context.Context) error {
+	agentID := s.agents[demoMemoryAgent].String()
+	if content, err := s.stores.Memory.GetDocument(ctx, agentID, "", demoMemoryPath); err == nil && content != "" {
+		slog.Info("seed: memory document exists, skipping", "path", demoMemoryPath)
+		return nil
+	}
+	if err := s.stores.Memory.PutDocument(ctx, agentID, "", demoMemoryPath, demoMemoryContent); err != nil {
+		return err
+	}
+	// Chunking for search; embeddings are filled in later by the gateway.
+	if err := s.stores.Memory.IndexDocument(ctx, agentID, "", demoMemoryPath); err != nil {
+		slog.Warn("seed: memory document not indexed", "path", demoMemoryPath, "error", err)
+	}
+	slog.Info("seed: memory document created", "path", demoMemoryPath)
+	return nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/skills"
+)
+
+func TestDemoSkillsAreValid(t *testing.T) {
+	agentKeys := map[string]bool{}
+	for _, a := range demoAgents {
+		agentKeys[a.Key] = true
+	}
+	for _, sk := range demoSkills {
+		name, description, _, _ := skills.ParseSkillFrontmatter(sk.Content)
+		if name == "" || description == "" {
+			t.Errorf("%s: frontmatter missing name/description", sk.Slug)
+		}
+		if !skills.SlugRegexp.MatchString(sk.Slug) {
+			t.Errorf("%s: invalid slug", sk.Slug)
+		}
+		if violations, safe := skills.GuardSkillContent(sk.Content); !safe {
+			t.Errorf("%s: rejected by skill guard: %v", sk.Slug, violations)
+		}
+		if !agentKeys[sk.AgentKey] {
+			t.Errorf("%s: granted to unknown demo agent %q", sk.Slug, sk.AgentKey)
+		}
+	}
+	if !agentKeys[demoCronAgent] || !agentKeys[demoMemoryAgent] {
+		t.Error("demo cron job / memory document must belong to a demo agent")
+	}
+}
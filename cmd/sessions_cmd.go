@@ -1,48 +1,111 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 func sessionsCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "sessions",
-		Short: "View and manage chat sessions",
+		Use:     "sessions",
+		Aliases: []string{"session"},
+		Short:   "View and manage chat sessions",
 	}
 	cmd.AddCommand(sessionsListCmd())
+	cmd.AddCommand(sessionsShowCmd())
+	cmd.AddCommand(sessionsRenameCmd())
+	cmd.AddCommand(sessionsArchiveCmd())
 	cmd.AddCommand(sessionsDeleteCmd())
 	cmd.AddCommand(sessionsResetCmd())
+	cmd.AddCommand(sessionsRewindCmd())
+	cmd.AddCommand(sessionsExportCmd())
+	cmd.AddCommand(sessionsImportCmd())
 	return cmd
 }
 
 func sessionsListCmd() *cobra.Command {
-	var jsonOutput bool
+	var jsonOutput, archived, all bool
 	var agentFilter string
+	var limit int
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all sessions",
 		Run: func(cmd *cobra.Command, args []string) {
-			sessionsListRPC(agentFilter, jsonOutput)
+			filter := store.SessionArchivedExclude
+			if all {
+				filter = store.SessionArchivedInclude
+			} else if archived {
+				filter = store.SessionArchivedOnly
+			}
+			sessionsListRPC(agentFilter, filter, limit, jsonOutput)
 		},
 	}
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
 	cmd.Flags().StringVar(&agentFilter, "agent", "", "filter by agent ID")
+	cmd.Flags().BoolVar(&archived, "archived", false, "list only archived sessions")
+	cmd.Flags().BoolVar(&all, "all", false, "include archived sessions")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum number of sessions")
+	return cmd
+}
+
+func sessionsShowCmd() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "show [key]",
+		Short: "Show a session transcript",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionsShowRPC(args[0], jsonOutput)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func sessionsRenameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename [key] [label]",
+		Short: "Set a session's display label (empty label clears it)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionsRenameRPC(args[0], args[1])
+		},
+	}
+}
+
+func sessionsArchiveCmd() *cobra.Command {
+	var undo bool
+	cmd := &cobra.Command{
+		Use:   "archive [key]",
+		Short: "Hide a session from listings (history is kept)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionsArchiveRPC(args[0], !undo)
+		},
+	}
+	cmd.Flags().BoolVar(&undo, "undo", false, "restore an archived session")
 	return cmd
 }
 
 func sessionsDeleteCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "delete [key]",
-		Short: "Delete a session",
+		Short: "Delete a session with its traces and episodic memory",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			sessionsDeleteRPC(args[0])
@@ -61,12 +124,62 @@ func sessionsResetCmd() *cobra.Command {
 	}
 }
 
+func sessionsRewindCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rewind [key] [checkpoint]",
+		Short: "List a session's checkpoints, or roll it back to one",
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				sessionsCheckpointsRPC(args[0])
+				return
+			}
+			id, err := strconv.Atoi(args[1])
+			if err != nil || id <= 0 {
+				fmt.Fprintf(os.Stderr, "Error: invalid checkpoint %q\n", args[1])
+				os.Exit(1)
+			}
+			sessionsRewindRPC(args[0], id)
+		},
+	}
+}
+
+func sessionsExportCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export [key]",
+		Short: "Export a session (messages, metadata, summaries) as a portable JSON bundle",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionsExportHTTP(args[0], output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+	return cmd
+}
+
+func sessionsImportCmd() *cobra.Command {
+	var key string
+	var overwrite bool
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import a session bundle created by 'sessions export' (use - for stdin)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sessionsImportHTTP(args[0], key, overwrite)
+		},
+	}
+	cmd.Flags().StringVar(&key, "key", "", "import under a different session key")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace an existing session with the same key")
+	return cmd
+}
+
 // --- RPC implementations ---
 
-func sessionsListRPC(agentFilter string, jsonOutput bool) {
+func sessionsListRPC(agentFilter, archived string, limit int, jsonOutput bool) {
 	requireGateway()
 
-	params, _ := json.Marshal(map[string]string{"agentId": agentFilter})
+	params, _ := json.Marshal(map[string]any{"agentId": agentFilter, "archived": archived, "limit": limit})
 	resp, err := gatewayRPC(protocol.MethodSessionsList, params)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -104,6 +217,93 @@ func sessionsDeleteRPC(key string) {
 		os.Exit(1)
 	}
 	fmt.Printf("Deleted session: %s\n", key)
+
+	raw, _ := json.Marshal(resp.Payload)
+	var result struct {
+		Purged *store.SessionPurgeResult `json:"purged"`
+	}
+	if json.Unmarshal(raw, &result) == nil && result.Purged != nil {
+		p := result.Purged
+		fmt.Printf("  removed %d traces (%d spans), %d episodic summaries, %d metrics, %d subagent tasks\n",
+			p.Traces, p.Spans, p.Episodic, p.Metrics, p.SubagentRuns)
+	}
+}
+
+func sessionsShowRPC(key string, jsonOutput bool) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]string{"key": key})
+	resp, err := gatewayRPC(protocol.MethodSessionsPreview, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+
+	raw, _ := json.Marshal(resp.Payload)
+	if jsonOutput {
+		var pretty any
+		json.Unmarshal(raw, &pretty)
+		data, _ := json.MarshalIndent(pretty, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	var result struct {
+		Messages []providers.Message `json:"messages"`
+		Summary  string              `json:"summary"`
+		Label    string              `json:"label"`
+		Digest   string              `json:"digest"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+		os.Exit(1)
+	}
+	if result.Label != "" {
+		fmt.Printf("[title]\n%s\n\n", result.Label)
+	}
+	if result.Digest != "" {
+		fmt.Printf("[digest]\n%s\n\n", result.Digest)
+	}
+	printTranscript(result.Messages, result.Summary)
+}
+
+func sessionsRenameRPC(key, label string) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]string{"key": key, "label": label})
+	resp, err := gatewayRPC(protocol.MethodSessionsPatch, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	fmt.Printf("Renamed session: %s → %q\n", key, label)
+}
+
+func sessionsArchiveRPC(key string, archived bool) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]any{"key": key, "archived": archived})
+	resp, err := gatewayRPC(protocol.MethodSessionsArchive, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	if archived {
+		fmt.Printf("Archived session: %s\n", key)
+	} else {
+		fmt.Printf("Restored session: %s\n", key)
+	}
 }
 
 func sessionsResetRPC(key string) {
@@ -122,6 +322,136 @@ func sessionsResetRPC(key string) {
 	fmt.Printf("Reset session: %s\n", key)
 }
 
+func sessionsCheckpointsRPC(key string) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]string{"key": key})
+	resp, err := gatewayRPC(protocol.MethodSessionsCheckpoints, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	printCheckpoints(resp.Payload)
+}
+
+func sessionsRewindRPC(key string, id int) {
+	requireGateway()
+
+	params, _ := json.Marshal(map[string]any{"key": key, "checkpoint": id})
+	resp, err := gatewayRPC(protocol.MethodSessionsRewind, params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !resp.OK {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", resp.Error.Message)
+		os.Exit(1)
+	}
+	printRewindResult(resp.Payload)
+}
+
+// printCheckpoints prints a sessions.checkpoints payload, newest first.
+func printCheckpoints(payload any) {
+	raw, _ := json.Marshal(payload)
+	var result struct {
+		Checkpoints []store.SessionCheckpoint `json:"checkpoints"`
+	}
+	json.Unmarshal(raw, &result)
+	if len(result.Checkpoints) == 0 {
+		fmt.Println("No checkpoints.")
+		return
+	}
+	for i := len(result.Checkpoints) - 1; i >= 0; i-- {
+		fmt.Println(formatCheckpoint(result.Checkpoints[i]))
+	}
+}
+
+// printRewindResult prints a sessions.rewind payload.
+func printRewindResult(payload any) {
+	raw, _ := json.Marshal(payload)
+	var result store.SessionRewindResult
+	json.Unmarshal(raw, &result)
+	fmt.Printf("Rewound to checkpoint #%d, removed %d messages.\n", result.Checkpoint.ID, result.Removed)
+	if len(result.Files) > 0 {
+		fmt.Println("Files written after this checkpoint (not reverted):")
+		for _, f := range result.Files {
+			fmt.Printf("  %s\n", f)
+		}
+	}
+}
+
+// Export/import go over HTTP rather than WS RPC: bundles of long sessions
+// exceed the WebSocket frame limit.
+
+func sessionsExportHTTP(key, output string) {
+	requireGateway()
+
+	out := os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := gatewayHTTPStream(http.MethodGet, "/v1/sessions/"+url.PathEscape(key)+"/export", nil, out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if output != "" {
+			os.Remove(output)
+		}
+		os.Exit(1)
+	}
+	if output != "" {
+		fmt.Fprintf(os.Stderr, "Exported session %s to %s\n", key, output)
+	}
+}
+
+func sessionsImportHTTP(path, key string, overwrite bool) {
+	requireGateway()
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	q := url.Values{}
+	if key != "" {
+		q.Set("key", key)
+	}
+	if overwrite {
+		q.Set("overwrite", "true")
+	}
+	endpoint := "/v1/sessions/import"
+	if len(q) > 0 {
+		endpoint += "?" + q.Encode()
+	}
+
+	var resp bytes.Buffer
+	if err := gatewayHTTPStream(http.MethodPost, endpoint, bytes.NewReader(data), &resp); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var res store.SessionImportResult
+	if err := json.Unmarshal(resp.Bytes(), &res); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Imported session: %s (%d messages, %d episodic summaries)\n", res.Key, res.Messages, res.Episodic)
+}
+
 // --- Shared display ---
 
 func printSessionInfos(infos []store.SessionInfo, jsonOutput bool) {
@@ -137,18 +467,49 @@ func printSessionInfos(infos []store.SessionInfo, jsonOutput bool) {
 	}
 
 	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(tw, "KEY\tMESSAGES\tCREATED\tUPDATED\n")
+	fmt.Fprintf(tw, "KEY\tLABEL\tMESSAGES\tCREATED\tUPDATED\tDIGEST\n")
 	for _, s := range infos {
-		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n",
+		label := s.Label
+		if s.Metadata[store.SessionMetaArchivedAt] != "" {
+			label = strings.TrimSpace(label + " [archived]")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
 			truncateStr(s.Key, 50),
+			truncateStr(label, 30),
 			s.MessageCount,
 			s.Created.Format(time.DateTime),
 			s.Updated.Format(time.DateTime),
+			truncateStr(s.Metadata[store.SessionMetaDigest], 60),
 		)
 	}
 	tw.Flush()
 }
 
+// printTranscript renders session history as role-prefixed blocks. Tool
+// results are shortened; tool calls are listed by name.
+func printTranscript(msgs []providers.Message, summary string) {
+	if summary != "" {
+		fmt.Printf("[summary]\n%s\n\n", summary)
+	}
+	if len(msgs) == 0 {
+		fmt.Println("No messages.")
+		return
+	}
+	for _, m := range msgs {
+		content := m.Content
+		if m.Role == "tool" {
+			content = truncateStr(content, 200)
+		}
+		if content != "" {
+			fmt.Printf("[%s]\n%s\n", m.Role, content)
+		}
+		for _, tc := range m.ToolCalls {
+			fmt.Printf("[%s → %s]\n", m.Role, tc.Name)
+		}
+		fmt.Println()
+	}
+}
+
 func truncateStr(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
 )
 
 // setupProviderStep guides the user through provider configuration.
@@ -50,6 +54,7 @@ func addProvider() {
 		{"OpenAI", "openai"},
 		{"OpenRouter", "openrouter"},
 		{"DashScope (Alibaba)", "dashscope"},
+		{"Ollama (local)", "ollama"},
 		{"OpenAI-compatible", "openai-compat"},
 	}
 	providerType, err := promptSelect("Provider type", typeOptions, 0)
@@ -62,6 +67,11 @@ func addProvider() {
 		return
 	}
 
+	if providerType == "ollama" {
+		addOllamaProvider(name)
+		return
+	}
+
 	apiKey, err := promptPassword("API key", "will be encrypted at rest")
 	if err != nil || apiKey == "" {
 		fmt.Println("  Skipped (no API key).")
@@ -86,6 +96,55 @@ func addProvider() {
 		body["base_url"] = baseURL
 	}
 
+	createAndVerifyProvider(name, body)
+}
+
+// addOllamaProvider configures a local Ollama server: no API key, a host URL,
+// and a default model picked from the models installed on that server.
+func addOllamaProvider(name string) {
+	host, err := promptString("Ollama host", "server running 'ollama serve'", "http://localhost:11434")
+	if err != nil {
+		return
+	}
+	host = providers.NormalizeOllamaHost(host)
+
+	body := map[string]any{
+		"name":          name,
+		"provider_type": "ollama",
+		"api_base":      host,
+		"enabled":       true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	models, err := providers.ListOllamaModels(ctx, nil, host, "")
+	switch {
+	case err != nil:
+		fmt.Printf("  Could not list models on %s (%v).\n", host, err)
+		fmt.Println("  Make sure Ollama is running; the gateway will retry when the provider is used.")
+	case len(models) == 0:
+		fmt.Println("  No models installed yet. Pull one with 'ollama pull <model>'.")
+	default:
+		options := make([]SelectOption[string], len(models))
+		for i, m := range models {
+			label := m.Name
+			if d := m.DisplayName(); d != m.Name {
+				label += " (" + d + ")"
+			}
+			options[i] = SelectOption[string]{label, m.Name}
+		}
+		model, err := promptSelect("Default model", options, 0)
+		if err != nil {
+			return
+		}
+		body["settings"] = map[string]any{"model": model}
+	}
+
+	createAndVerifyProvider(name, body)
+}
+
+// createAndVerifyProvider creates the provider on the gateway and runs its verify check.
+func createAndVerifyProvider(name string, body map[string]any) {
 	resp, err := gatewayHTTPPost("/v1/providers", body)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
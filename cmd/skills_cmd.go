@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -22,6 +24,10 @@ func skillsCmd() *cobra.Command {
 	}
 	cmd.AddCommand(skillsListCmd())
 	cmd.AddCommand(skillsShowCmd())
+	cmd.AddCommand(skillsPullCmd())
+	cmd.AddCommand(skillsUpdateCmd())
+	cmd.AddCommand(skillsLintCmd())
+	cmd.AddCommand(skillsPackageCmd())
 	return cmd
 }
 
@@ -142,13 +148,194 @@ func loadSkillsLoader() *skills.Loader {
 	cfgPath := resolveConfigPath()
 	cfg, _ := config.Load(cfgPath)
 	workspace := config.ExpandHome(cfg.Agents.Defaults.Workspace)
-	globalSkillsDir := os.Getenv("GOCLAW_SKILLS_DIR")
-	if globalSkillsDir == "" {
-		globalSkillsDir = filepath.Join(cfg.ResolvedDataDir(), "skills")
-	}
+	globalSkillsDir := resolveGlobalSkillsDir(cfg)
 	builtinSkillsDir := os.Getenv("GOCLAW_BUILTIN_SKILLS_DIR")
 	if builtinSkillsDir == "" {
 		builtinSkillsDir = "/app/bundled-skills"
 	}
 	return skills.NewLoader(workspace, globalSkillsDir, builtinSkillsDir)
 }
+
+// resolveGlobalSkillsDir returns the global skills directory the gateway loads
+// (GOCLAW_SKILLS_DIR, default dataDir/skills).
+func resolveGlobalSkillsDir(cfg *config.Config) string {
+	if dir := os.Getenv("GOCLAW_SKILLS_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(cfg.ResolvedDataDir(), "skills")
+}
+
+func skillsPullCmd() *cobra.Command {
+	var registryURL, token string
+	cmd := &cobra.Command{
+		Use:   "pull <name>[@version]",
+		Short: "Install a skill from the configured skill registry",
+		Long: `Download a skill from the skill registry (skills.registry.url), verify its
+SHA-256 checksum and install it into the global skills directory.
+
+Without @version the latest version is installed and the gateway keeps it up to
+date. With @version the skill is pinned to that version in skills.lock.json.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name, version := args[0], 0
+			if n, v, ok := strings.Cut(args[0], "@"); ok {
+				parsed, err := strconv.Atoi(strings.TrimPrefix(v, "v"))
+				if err != nil || parsed < 1 {
+					fmt.Fprintf(os.Stderr, "Error: invalid version %q\n", v)
+					os.Exit(1)
+				}
+				name, version = n, parsed
+			}
+			reg := newSkillRegistry(registryURL, token)
+			entry, err := reg.Pull(context.Background(), name, version, version > 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			pinned := ""
+			if entry.Pinned {
+				pinned = " (pinned)"
+			}
+			fmt.Printf("Installed %s v%d%s into %s\n", name, entry.Version, pinned, filepath.Join(reg.Dir(), name))
+		},
+	}
+	cmd.Flags().StringVar(&registryURL, "registry", "", "registry index URL (overrides skills.registry.url)")
+	cmd.Flags().StringVar(&token, "token", "", "registry bearer token (overrides skills.registry.token)")
+	return cmd
+}
+
+func skillsUpdateCmd() *cobra.Command {
+	var registryURL, token string
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update registry-installed skills to their latest or pinned versions",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			res, err := newSkillRegistry(registryURL, token).Update(context.Background())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, u := range res.Updated {
+				fmt.Println("Updated", u)
+			}
+			for _, f := range res.Failed {
+				fmt.Fprintln(os.Stderr, "Failed", f)
+			}
+			if len(res.Updated) == 0 && len(res.Failed) == 0 {
+				fmt.Println("All registry skills are up to date.")
+			}
+			if len(res.Failed) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&registryURL, "registry", "", "registry index URL (overrides skills.registry.url)")
+	cmd.Flags().StringVar(&token, "token", "", "registry bearer token (overrides skills.registry.token)")
+	return cmd
+}
+
+// newSkillRegistry builds a registry client from config, with flag overrides.
+func newSkillRegistry(registryURL, token string) *skills.Registry {
+	cfg, _ := config.Load(resolveConfigPath())
+	if registryURL == "" {
+		registryURL = cfg.Skills.Registry.URL
+	}
+	if token == "" {
+		token = cfg.Skills.Registry.Token
+	}
+	if registryURL == "" {
+		fmt.Fprintln(os.Stderr, "Error: no skill registry configured (set skills.registry.url, GOCLAW_SKILLS_REGISTRY_URL or --registry)")
+		os.Exit(1)
+	}
+	reg, err := skills.NewRegistry(registryURL, token, resolveGlobalSkillsDir(cfg), nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return reg
+}
+
+func skillsLintCmd() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "lint [dir...]",
+		Short: "Validate skill directories before packaging or upload",
+		Long: `Check SKILL.md frontmatter (name, description, slug, requires), the security
+scan, files referenced from SKILL.md, symlinks and size limits. Exits non-zero
+when any directory has errors. Defaults to the current directory.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+			failed := false
+			var reports []*skills.LintReport
+			for _, dir := range args {
+				rep, err := skills.LintSkillDir(dir)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				failed = failed || rep.HasErrors()
+				reports = append(reports, rep)
+			}
+			if jsonOutput {
+				data, _ := json.MarshalIndent(reports, "", "  ")
+				fmt.Println(string(data))
+			} else {
+				for _, rep := range reports {
+					printLintReport(rep)
+				}
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func skillsPackageCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "package <dir>",
+		Short: "Lint a skill directory and build the zip for /v1/skills/upload",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			out := output
+			if out == "" {
+				rep, err := skills.LintSkillDir(args[0])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				slug := rep.Slug
+				if slug == "" {
+					slug = "skill"
+				}
+				out = slug + ".zip"
+			}
+			rep, err := skills.PackageSkillDir(args[0], out)
+			if rep != nil {
+				printLintReport(rep)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Packaged %s (%d files) into %s\n", rep.Slug, len(rep.Files), out)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "zip path (default <slug>.zip)")
+	return cmd
+}
+
+func printLintReport(rep *skills.LintReport) {
+	for _, issue := range rep.Issues {
+		fmt.Printf("%s: %s\n", rep.Dir, issue)
+	}
+	if !rep.HasErrors() {
+		fmt.Printf("%s: ok (%s, %d files, %d bytes)\n", rep.Dir, rep.Slug, len(rep.Files), rep.Size)
+	}
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func toolsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "List and test agent tools",
+	}
+	cmd.AddCommand(toolsListCmd())
+	cmd.AddCommand(toolsTestCmd())
+	return cmd
+}
+
+func toolsListCmd() *cobra.Command {
+	var agentID string
+	var jsonOutput bool
+	var showSchema bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tools (builtin, skill, MCP) with their parameter schemas",
+		Long: "List the tools registered in the running gateway. With --agent, list the\n" +
+			"tools that agent's LLM is actually offered after tool policy filtering,\n" +
+			"including its MCP server tools.",
+		Run: func(cmd *cobra.Command, args []string) {
+			runToolsList(agentID, jsonOutput, showSchema)
+		},
+	}
+	cmd.Flags().StringVar(&agentID, "agent", "", "agent key (default: all registered tools)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	cmd.Flags().BoolVar(&showSchema, "schema", false, "print each tool's parameter schema")
+	return cmd
+}
+
+func toolsTestCmd() *cobra.Command {
+	var agentID string
+	var rawArgs string
+	var timeout time.Duration
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "test <name>",
+		Short: "Execute a single tool outside the agent loop",
+		Example: `  goclaw tools test datetime
+  goclaw tools test web_fetch --args '{"url":"https://example.com"}'
+  goclaw tools test mcp_github__list_issues --agent default --args '{"repo":"org/repo"}'`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runToolsTest(args[0], agentID, rawArgs, timeout, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVar(&agentID, "agent", "", "agent key to run the tool as (workspace, memory, MCP context)")
+	cmd.Flags().StringVar(&rawArgs, "args", "{}", "tool arguments as a JSON object")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "maximum time to wait for the tool")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output the raw JSON response")
+	return cmd
+}
+
+type toolListEntry struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters"`
+	Source      string         `json:"source"`
+	Server      string         `json:"server"`
+	AliasOf     string         `json:"aliasOf"`
+}
+
+func runToolsList(agentID string, jsonOutput, showSchema bool) {
+	requireGateway()
+
+	path := "/v1/tools"
+	if agentID != "" {
+		path += "?agent=" + url.QueryEscape(agentID)
+	}
+	resp, err := gatewayHTTPGetTyped[struct {
+		Agent string          `json:"agent"`
+		Tools []toolListEntry `json:"tools"`
+	}](path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(resp.Tools, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	if len(resp.Tools) == 0 {
+		fmt.Println("No tools found.")
+		return
+	}
+
+	if showSchema {
+		for _, t := range resp.Tools {
+			fmt.Printf("%s (%s)\n  %s\n", t.Name, toolSourceLabel(t), t.Description)
+			schema, _ := json.MarshalIndent(t.Parameters, "  ", "  ")
+			fmt.Printf("  %s\n\n", schema)
+		}
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "NAME\tSOURCE\tPARAMS\tDESCRIPTION\n")
+	for _, t := range resp.Tools {
+		desc := t.Description
+		if i := strings.IndexByte(desc, '\n'); i >= 0 {
+			desc = desc[:i]
+		}
+		if runes := []rune(desc); len(runes) > 60 {
+			desc = string(runes[:57]) + "..."
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", t.Name, toolSourceLabel(t), toolParamNames(t.Parameters), desc)
+	}
+	tw.Flush()
+	if resp.Agent != "" {
+		fmt.Printf("\n%d tools available to agent %s\n", len(resp.Tools), resp.Agent)
+	}
+}
+
+func toolSourceLabel(t toolListEntry) string {
+	switch {
+	case t.Server != "":
+		return t.Source + ":" + t.Server
+	case t.AliasOf != "":
+		return t.Source + " (alias of " + t.AliasOf + ")"
+	}
+	return t.Source
+}
+
+// toolParamNames renders the top-level schema properties, marking required ones with '*'.
+func toolParamNames(schema map[string]any) string {
+	props, _ := schema["properties"].(map[string]any)
+	if len(props) == 0 {
+		return "-"
+	}
+	required := map[string]bool{}
+	if req, ok := schema["required"].([]any); ok {
+		for _, r := range req {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		if required[name] {
+			name += "*"
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func runToolsTest(name, agentID, rawArgs string, timeout time.Duration, jsonOutput bool) {
+	requireGateway()
+
+	var args map[string]any
+	if err := json.Unmarshal([]byte(rawArgs), &args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --args must be a JSON object: %v\n", err)
+		os.Exit(1)
+	}
+
+	body := map[string]any{"tool": name, "args": args}
+	if agentID != "" {
+		body["agentId"] = agentID
+	}
+
+	start := time.Now()
+	raw, status, err := gatewayHTTPDoRawWith(&http.Client{Timeout: timeout}, http.MethodPost, "/v1/tools/invoke", body)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if jsonOutput {
+		fmt.Println(string(raw))
+		if status >= 400 {
+			os.Exit(1)
+		}
+		return
+	}
+	if status >= 400 {
+		fmt.Fprintf(os.Stderr, "Tool %s failed after %s: %v\n", name, elapsed, parseHTTPError(raw, status))
+		os.Exit(1)
+	}
+
+	var resp struct {
+		Result struct {
+			Output  string `json:"output"`
+			ForUser string `json:"forUser"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid response from gateway: %s\n", raw)
+		os.Exit(1)
+	}
+	fmt.Println(resp.Result.Output)
+	if resp.Result.ForUser != "" && resp.Result.ForUser != resp.Result.Output {
+		fmt.Printf("\n--- for user ---\n%s\n", resp.Result.ForUser)
+	}
+	fmt.Fprintf(os.Stderr, "\n(%s in %s)\n", name, elapsed)
+}
@@ -174,6 +174,7 @@ func runUpgrade(dryRun bool) error {
 		}
 		v, _, _ := m.Version()
 		fmt.Printf("OK (v%d -> v%d)\n", s.CurrentVersion, v)
+		recordMigrationHistory(db, s.CurrentVersion, v)
 	} else {
 		fmt.Println("  SQL schema is up to date.")
 	}
@@ -200,8 +201,10 @@ func runUpgrade(dryRun bool) error {
 var ErrUpgradeFailed = fmt.Errorf("upgrade cannot proceed")
 
 // checkSchemaOrAutoUpgrade is called from gateway startup to gate on schema compatibility.
-// If GOCLAW_AUTO_UPGRADE=true and schema is outdated, it runs the upgrade inline.
-func checkSchemaOrAutoUpgrade(dsn string) error {
+// If auto-migrate is enabled (database.auto_migrate or GOCLAW_AUTO_UPGRADE=true) and
+// schema is outdated, it runs the upgrade inline. A compatible schema is then
+// checked for drift (see checkSchemaDrift).
+func checkSchemaOrAutoUpgrade(dsn string, dbCfg config.DatabaseConfig) error {
 	db, err := sql.Open("pgx", dsn)
 	if err != nil {
 		return fmt.Errorf("schema check: connect: %w", err)
@@ -217,9 +220,16 @@ func checkSchemaOrAutoUpgrade(dsn string) error {
 		return fmt.Errorf("schema check: %w", err)
 	}
 
+	autoMigrate := dbCfg.AutoMigrate || os.Getenv("GOCLAW_AUTO_UPGRADE") == "true"
+
 	if s.Compatible {
 		slog.Info("schema check passed", "current", s.CurrentVersion, "required", s.RequiredVersion)
-		return nil
+		if autoMigrate {
+			if err := runStartupDataHooks(db); err != nil {
+				return err
+			}
+		}
+		return checkSchemaDrift(db, s.RequiredVersion, dbCfg.SchemaDriftCheck)
 	}
 
 	if s.Dirty {
@@ -231,7 +241,7 @@ func checkSchemaOrAutoUpgrade(dsn string) error {
 	}
 
 	// Schema is outdated — check if auto-upgrade is enabled.
-	if os.Getenv("GOCLAW_AUTO_UPGRADE") == "true" {
+	if autoMigrate {
 		slog.Info("auto-upgrade: applying migrations", "from", s.CurrentVersion, "to", s.RequiredVersion)
 
 		m, mErr := newMigrator(dsn)
@@ -246,19 +256,57 @@ func checkSchemaOrAutoUpgrade(dsn string) error {
 
 		v, _, _ := m.Version()
 		slog.Info("auto-upgrade: SQL migrations applied", "version", v)
+		recordMigrationHistory(db, s.CurrentVersion, v)
 
-		// Run data hooks.
-		count, hErr := upgrade.RunPendingHooks(context.Background(), db)
-		if hErr != nil {
-			return fmt.Errorf("auto-upgrade: data hooks: %w", hErr)
-		}
-		if count > 0 {
-			slog.Info("auto-upgrade: data hooks applied", "count", count)
+		if err := runStartupDataHooks(db); err != nil {
+			return err
 		}
 
 		slog.Info("auto-upgrade complete")
-		return nil
+		return checkSchemaDrift(db, s.RequiredVersion, dbCfg.SchemaDriftCheck)
 	}
 
 	return errors.New(upgrade.FormatError(s))
 }
+
+// runStartupDataHooks applies pending data hooks during auto-upgrade.
+func runStartupDataHooks(db *sql.DB) error {
+	count, err := upgrade.RunPendingHooks(context.Background(), db)
+	if err != nil {
+		return fmt.Errorf("auto-upgrade: data hooks: %w", err)
+	}
+	if count > 0 {
+		slog.Info("auto-upgrade: data hooks applied", "count", count)
+	}
+	return nil
+}
+
+// checkSchemaDrift verifies that the tables and columns created by the
+// migrations up to version actually exist. schema_migrations only records the
+// version number, so a database restored from an older dump or altered by hand
+// passes the version check and fails later at runtime. mode is
+// database.schema_drift_check: "error" (default) refuses to start, "warn" logs,
+// "off" skips the check.
+func checkSchemaDrift(db *sql.DB, version uint, mode string) error {
+	if mode == "off" {
+		return nil
+	}
+	dir := resolveMigrationsDir()
+	expected, err := upgrade.LoadExpectedSchema(dir, version)
+	if err != nil {
+		slog.Warn("schema drift check skipped: migrations not readable", "dir", dir, "error", err)
+		return nil
+	}
+	report, err := upgrade.DetectDrift(context.Background(), db, expected)
+	if err != nil {
+		return fmt.Errorf("schema drift check: %w", err)
+	}
+	if !report.HasDrift() {
+		return nil
+	}
+	if mode == "warn" {
+		slog.Warn("schema drift detected", "missing_tables", report.MissingTables, "missing_columns", report.MissingColumns)
+		return nil
+	}
+	return errors.New(upgrade.FormatDrift(report, version))
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func usageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report and export LLM token usage and cost",
+	}
+	cmd.AddCommand(usageReportCmd())
+	cmd.AddCommand(usageExportCmd())
+	return cmd
+}
+
+func usageReportCmd() *cobra.Command {
+	var groupBy, from, to, agent, user string
+	var limit int
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show tokens and estimated cost per run, session, agent or user",
+		Example: `  goclaw usage report
+  goclaw usage report --group-by user --from 2025-01-01 --to 2025-02-01
+  goclaw usage report --group-by session --agent support --limit 20`,
+		Run: func(cmd *cobra.Command, args []string) {
+			q := url.Values{}
+			q.Set("group_by", groupBy)
+			q.Set("limit", strconv.Itoa(limit))
+			for k, v := range map[string]string{"from": from, "to": to, "agent_id": agent, "user_id": user} {
+				if v != "" {
+					q.Set(k, v)
+				}
+			}
+			runUsageReport(q, jsonOutput)
+		},
+	}
+	cmd.Flags().StringVar(&groupBy, "group-by", "agent", "group rows by run, session, agent or user")
+	cmd.Flags().StringVar(&from, "from", "", "start date, YYYY-MM-DD or RFC3339 (default: 30 days before --to)")
+	cmd.Flags().StringVar(&to, "to", "", "end date, exclusive (default: now)")
+	cmd.Flags().StringVar(&agent, "agent", "", "only this agent (key or ID)")
+	cmd.Flags().StringVar(&user, "user", "", "only this user (admins only)")
+	cmd.Flags().IntVar(&limit, "limit", 50, "maximum rows, most expensive first")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	return cmd
+}
+
+func runUsageReport(q url.Values, jsonOutput bool) {
+	requireGateway()
+
+	raw, status, err := gatewayHTTPDoRaw(http.MethodGet, "/v1/usage?"+q.Encode(), nil)
+	if err == nil && status >= 400 {
+		err = parseHTTPError(raw, status)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, raw, "", "  ") == nil {
+			raw = append(pretty.Bytes(), '\n')
+		}
+		os.Stdout.Write(raw)
+		return
+	}
+
+	var report struct {
+		GroupBy   string                 `json:"group_by"`
+		Rows      []store.UsageReportRow `json:"rows"`
+		AgentKeys map[string]string      `json:"agent_keys"`
+	}
+	if err := json.Unmarshal(raw, &report); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing response: %v\n", err)
+		os.Exit(1)
+	}
+	if len(report.Rows) == 0 {
+		fmt.Println("No usage in this period.")
+		return
+	}
+
+	var in, out int64
+	var cost float64
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tRUNS\tINPUT\tOUTPUT\tCOST\tLAST RUN\n", strings.ToUpper(report.GroupBy))
+	for _, r := range report.Rows {
+		key := r.Key
+		if k := report.AgentKeys[key]; k != "" {
+			key = k
+		}
+		if key == "" {
+			key = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.4f\t%s\n",
+			key, r.Runs, r.InputTokens, r.OutputTokens, r.Cost, r.LastRunAt.Local().Format(time.DateTime))
+		in, out, cost = in+r.InputTokens, out+r.OutputTokens, cost+r.Cost
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%d\t%d\t%.4f\t\n", in, out, cost)
+	tw.Flush()
+}
+
+func usageExportCmd() *cobra.Command {
+	var month, format, groupBy, output string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a monthly billing report (per tenant/user/agent) as CSV or JSON",
+		Example: `  goclaw usage export --month 2025-01 --format csv
+  goclaw usage export --month 2025-01 --format csv --group-by tenant -o tenants.csv
+  goclaw usage export --format json > invoice.json`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if month == "" {
+				month = time.Now().UTC().AddDate(0, -1, 0).Format("2006-01")
+			}
+			runUsageExport(month, format, groupBy, output)
+		},
+	}
+	cmd.Flags().StringVar(&month, "month", "", "billing month as YYYY-MM (default: previous month)")
+	cmd.Flags().StringVar(&format, "format", "csv", "output format: csv or json")
+	cmd.Flags().StringVar(&groupBy, "group-by", "line", "CSV rows: line, tenant, user or agent")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+	return cmd
+}
+
+func runUsageExport(month, format, groupBy, output string) {
+	requireGateway()
+
+	q := url.Values{}
+	q.Set("month", month)
+	q.Set("format", format)
+	q.Set("group_by", groupBy)
+	raw, status, err := gatewayHTTPDoRaw(http.MethodGet, "/v1/usage/export?"+q.Encode(), nil)
+	if err == nil && status >= 400 {
+		err = parseHTTPError(raw, status)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format == "json" {
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, raw, "", "  ") == nil {
+			raw = append(pretty.Bytes(), '\n')
+		}
+	}
+
+	if output == "" {
+		os.Stdout.Write(raw)
+		return
+	}
+	if err := os.WriteFile(output, raw, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %s usage export for %s to %s\n", format, month, output)
+}
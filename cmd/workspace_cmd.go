@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
+)
+
+func workspaceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Inspect workspace snapshots and roll back agent file changes",
+		Long: `Inspect workspace snapshots and roll back agent file changes.
+
+Before each tool batch the gateway saves the files write_file and edit are
+about to change, keyed by run ID. Rolling back a run restores those files to
+their state before the run and deletes files it created. Changes made by
+shell commands are not tracked.`,
+	}
+	cmd.AddCommand(workspaceSnapshotsCmd())
+	cmd.AddCommand(workspaceRollbackCmd())
+	return cmd
+}
+
+func workspaceSnapshotsCmd() *cobra.Command {
+	var jsonOutput bool
+	var agentID string
+	cmd := &cobra.Command{
+		Use:   "snapshots",
+		Short: "List runs with workspace snapshots, newest first",
+		Run: func(cmd *cobra.Command, args []string) {
+			store := openSnapshotStore()
+			runs, err := store.List()
+			exitOnErr(err)
+			if agentID != "" {
+				filtered := runs[:0]
+				for _, r := range runs {
+					if r.AgentID == agentID {
+						filtered = append(filtered, r)
+					}
+				}
+				runs = filtered
+			}
+			if jsonOutput {
+				data, _ := json.MarshalIndent(runs, "", "  ")
+				fmt.Println(string(data))
+				return
+			}
+			if len(runs) == 0 {
+				fmt.Println("No snapshots found.")
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "RUN\tAGENT\tFILES\tSHELL\tUPDATED")
+			for _, r := range runs {
+				shell := "-"
+				if r.ShellUsed {
+					shell = "yes"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.RunID, r.AgentID, len(r.Files), shell, r.UpdatedAt.Local().Format(time.DateTime))
+			}
+			w.Flush()
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "output as JSON")
+	cmd.Flags().StringVar(&agentID, "agent", "", "only show runs of this agent")
+	return cmd
+}
+
+func workspaceRollbackCmd() *cobra.Command {
+	var runID string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "rollback --run <id>",
+		Short: "Restore the files a run changed to their state before the run",
+		Long: `Restore the files a run changed to their state before the run, and delete
+files it created. Only files written by write_file and edit are covered:
+changes made by shell commands (exec) are not in the snapshot and are left
+as they are.`,
+		Example: `  goclaw workspace rollback --run 3f2a9c1e-... --dry-run
+  goclaw workspace rollback --run cron:nightly-report`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if runID == "" {
+				fmt.Fprintln(os.Stderr, "Error: --run is required (see `goclaw workspace snapshots`)")
+				os.Exit(1)
+			}
+			store := openSnapshotStore()
+			res, err := store.Rollback(runID, dryRun)
+			if errors.Is(err, snapshots.ErrRunNotFound) {
+				fmt.Fprintf(os.Stderr, "Error: no snapshot for run %s in %s\n", runID, store.Dir())
+				os.Exit(1)
+			}
+			exitOnErr(err)
+
+			restore, remove := "Restored", "Removed"
+			if dryRun {
+				restore, remove = "Would restore", "Would remove"
+			}
+			for _, p := range res.Restored {
+				fmt.Printf("%s  %s\n", restore, p)
+			}
+			for _, p := range res.Removed {
+				fmt.Printf("%s  %s\n", remove, p)
+			}
+			fmt.Printf("%d restored, %d removed, %d unchanged\n", len(res.Restored), len(res.Removed), len(res.Unchanged))
+			if res.ShellUsed {
+				fmt.Println("Note: this run also ran shell commands. Files they changed are not in the snapshot and were not rolled back.")
+			}
+		},
+	}
+	cmd.Flags().StringVar(&runID, "run", "", "run ID to roll back")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would change without touching files")
+	return cmd
+}
+
+func openSnapshotStore() *snapshots.Store {
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	return snapshots.NewStore(resolveSnapshotsDir(cfg))
+}
+
+// resolveSnapshotsDir returns the workspace snapshot directory: the
+// GOCLAW_SNAPSHOTS_DIR env var, or {data_dir}/snapshots.
+func resolveSnapshotsDir(cfg *config.Config) string {
+	if dir := os.Getenv("GOCLAW_SNAPSHOTS_DIR"); dir != "" {
+		return config.ExpandHome(dir)
+	}
+	return filepath.Join(cfg.ResolvedDataDir(), "snapshots")
+}
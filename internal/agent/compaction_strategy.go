@@ -0,0 +1,244 @@
+package agent
+
+import (
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// Compaction strategies (CompactionConfig.Strategy).
+const (
+	CompactionStrategySummarizeOldest = "summarize-oldest"
+	CompactionStrategyDropToolResults = "drop-tool-results-first"
+	CompactionStrategySemanticDedupe  = "semantic-dedupe"
+)
+
+const (
+	droppedToolResultText = "[tool result dropped during compaction]"
+	duplicateMessageText  = "[duplicate of a later message, removed during compaction]"
+
+	// A cheap pass (drop/dedupe) replaces summarization only when it recovers
+	// at least this share of the tokens; otherwise the summary still runs.
+	cheapPassMinRecovery = 0.5
+
+	// Near-duplicate detection: word-set Jaccard similarity threshold and the
+	// minimum length below which only exact matches count.
+	dedupeSimilarity = 0.85
+	dedupeMinWords   = 5
+	dedupeWindow     = 200 // later messages compared against
+)
+
+// CompactionReport describes one compaction pass. It is logged and emitted as
+// a "compaction" agent event so clients can see what was reduced.
+type CompactionReport struct {
+	Strategy           string `json:"strategy"`
+	Trigger            string `json:"trigger"` // "mid_loop" or "post_run"
+	MessagesBefore     int    `json:"messagesBefore"`
+	MessagesAfter      int    `json:"messagesAfter"`
+	Summarized         int    `json:"summarized"` // messages folded into the summary (0 = no LLM call)
+	ToolResultsDropped int    `json:"toolResultsDropped,omitempty"`
+	Deduplicated       int    `json:"deduplicated,omitempty"`
+	Pinned             int    `json:"pinned,omitempty"` // messages kept verbatim by pin rules
+	TokensBefore       int    `json:"tokensBefore"`
+	TokensAfter        int    `json:"tokensAfter"`
+	TokensRecovered    int    `json:"tokensRecovered"`
+}
+
+// ResolveCompactionStrategy returns the configured strategy, defaulting to
+// summarize-oldest for nil config or unknown values.
+func ResolveCompactionStrategy(cfg *config.CompactionConfig) string {
+	if cfg != nil {
+		switch cfg.Strategy {
+		case CompactionStrategyDropToolResults, CompactionStrategySemanticDedupe:
+			return cfg.Strategy
+		}
+	}
+	return CompactionStrategySummarizeOldest
+}
+
+// newCompactionReport starts a report for messages about to be compacted.
+func (l *Loop) newCompactionReport(trigger string, messages []providers.Message) *CompactionReport {
+	return &CompactionReport{
+		Strategy:       ResolveCompactionStrategy(l.compactionCfg),
+		Trigger:        trigger,
+		MessagesBefore: len(messages),
+		TokensBefore:   l.estimateSummaryInputTokens(messages),
+	}
+}
+
+// finish fills the "after" side of the report from the resulting context.
+func (r *CompactionReport) finish(l *Loop, after []providers.Message) {
+	r.MessagesAfter = len(after)
+	r.TokensAfter = l.estimateSummaryInputTokens(after)
+	r.TokensRecovered = max(r.TokensBefore-r.TokensAfter, 0)
+}
+
+// emitCompaction logs the report and emits it as an agent event.
+func (l *Loop) emitCompaction(runID, sessionKey string, r *CompactionReport) {
+	l.emit(AgentEvent{
+		Type:       protocol.AgentEventCompaction,
+		AgentID:    l.id,
+		RunID:      runID,
+		SessionKey: sessionKey,
+		TenantID:   l.tenantID,
+		Payload:    r,
+	})
+}
+
+// reduceOlder runs the strategy's cheap pass over the messages about to be
+// compacted. later holds the messages that stay, used to spot repeats.
+// The input slice is not modified.
+func reduceOlder(strategy string, older, later []providers.Message, r *CompactionReport) []providers.Message {
+	switch strategy {
+	case CompactionStrategyDropToolResults:
+		return dropToolResults(older, r)
+	case CompactionStrategySemanticDedupe:
+		return dedupeMessages(older, later, r)
+	}
+	return older
+}
+
+// cheapPassEnough reports whether a drop/dedupe pass recovered enough tokens
+// to skip summarization.
+func (l *Loop) cheapPassEnough(r *CompactionReport, reduced []providers.Message) bool {
+	if r.Strategy == CompactionStrategySummarizeOldest || r.TokensBefore == 0 {
+		return false
+	}
+	after := l.estimateSummaryInputTokens(reduced)
+	return float64(r.TokensBefore-after) >= float64(r.TokensBefore)*cheapPassMinRecovery
+}
+
+// dropToolResults replaces tool result bodies with a placeholder. The tool
+// messages themselves stay so tool_use/tool_result pairs remain valid.
+func dropToolResults(msgs []providers.Message, r *CompactionReport) []providers.Message {
+	out := make([]providers.Message, len(msgs))
+	copy(out, msgs)
+	for i := range out {
+		if out[i].Role == "tool" && out[i].Content != "" && out[i].Content != droppedToolResultText {
+			out[i].Content = droppedToolResultText
+			r.ToolResultsDropped++
+		}
+	}
+	return out
+}
+
+// dedupeMessages blanks messages that repeat a later one: tool results with
+// the same body, and user/assistant text with near-identical wording (word
+// overlap, no embeddings). The later copy is kept since it is closer to the
+// current turn. Messages are replaced by a placeholder rather than removed so
+// roles and tool pairs stay intact.
+func dedupeMessages(older, later []providers.Message, r *CompactionReport) []providers.Message {
+	out := make([]providers.Message, len(older))
+	copy(out, older)
+
+	type seenMsg struct {
+		role  string
+		norm  string
+		words map[string]struct{}
+	}
+	var seen []seenMsg
+	remember := func(m providers.Message) {
+		if norm := normalizeForDedupe(m.Content); norm != "" {
+			seen = append(seen, seenMsg{role: m.Role, norm: norm, words: wordSet(norm)})
+		}
+	}
+	for i := len(later) - 1; i >= 0 && len(seen) < dedupeWindow; i-- {
+		remember(later[i])
+	}
+
+	for i := len(out) - 1; i >= 0; i-- {
+		m := out[i]
+		if m.Content == "" || len(m.ToolCalls) > 0 || m.Content == duplicateMessageText || m.Content == droppedToolResultText {
+			continue
+		}
+		norm := normalizeForDedupe(m.Content)
+		words := wordSet(norm)
+		dup := false
+		for j := len(seen) - 1; j >= 0 && len(seen)-j <= dedupeWindow; j-- {
+			s := seen[j]
+			if s.role != m.Role {
+				continue
+			}
+			if s.norm == norm || (m.Role != "tool" && len(words) >= dedupeMinWords && jaccard(words, s.words) >= dedupeSimilarity) {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			out[i].Content = duplicateMessageText
+			r.Deduplicated++
+			continue
+		}
+		remember(m)
+	}
+	return out
+}
+
+// splitPinned separates messages protected by pin rules. Only user messages
+// and assistant replies without tool calls can be pinned, so tool call/result
+// pairs are never split.
+func splitPinned(cfg *config.CompactionConfig, msgs []providers.Message) (rest, pinned []providers.Message) {
+	if cfg == nil || (!cfg.PinFirstUserMessage && len(cfg.PinPatterns) == 0) {
+		return msgs, nil
+	}
+	var patterns []string
+	for _, p := range cfg.PinPatterns {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	firstUser := -1
+	if cfg.PinFirstUserMessage {
+		for i, m := range msgs {
+			if m.Role == "user" {
+				firstUser = i
+				break
+			}
+		}
+	}
+	for i, m := range msgs {
+		pinnable := m.Role == "user" || (m.Role == "assistant" && len(m.ToolCalls) == 0)
+		if pinnable && (i == firstUser || containsAny(strings.ToLower(m.Content), patterns)) {
+			pinned = append(pinned, m)
+			continue
+		}
+		rest = append(rest, m)
+	}
+	return rest, pinned
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeForDedupe(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func wordSet(norm string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, w := range strings.Fields(norm) {
+		set[strings.Trim(w, ".,;:!?\"'()[]")] = struct{}{}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	inter := 0
+	for w := range a {
+		if _, ok := b[w]; ok {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
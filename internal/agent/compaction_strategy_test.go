@@ -0,0 +1,137 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// toolHeavyHistory builds turns where each tool result dwarfs the chat text.
+func toolHeavyHistory(turns int) []providers.Message {
+	big := strings.Repeat("row data ", 400)
+	var msgs []providers.Message
+	for range turns {
+		msgs = append(msgs,
+			providers.Message{Role: "user", Content: "look it up"},
+			providers.Message{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "c", Name: "search"}}},
+			providers.Message{Role: "tool", ToolCallID: "c", Content: big},
+			providers.Message{Role: "assistant", Content: "found it"})
+	}
+	return msgs
+}
+
+func TestCompactMessages_DropToolResultsSkipsSummary(t *testing.T) {
+	prov := &capturingProvider{response: "summary"}
+	loop := &Loop{
+		provider:      prov,
+		model:         "m",
+		compactionCfg: &config.CompactionConfig{Strategy: CompactionStrategyDropToolResults},
+	}
+	msgs := toolHeavyHistory(5)
+
+	out, report := loop.compactMessagesWithReport(context.Background(), msgs)
+	if out == nil || report == nil {
+		t.Fatal("expected compaction result")
+	}
+	if len(prov.captured) != 0 {
+		t.Fatalf("dropping tool results was enough; provider calls = %d", len(prov.captured))
+	}
+	if len(out) != len(msgs) || report.ToolResultsDropped == 0 || report.Summarized != 0 {
+		t.Errorf("len=%d report=%+v", len(out), report)
+	}
+	if report.TokensRecovered <= report.TokensBefore/2 {
+		t.Errorf("tokens recovered = %d of %d", report.TokensRecovered, report.TokensBefore)
+	}
+	if msgs[2].Content == droppedToolResultText {
+		t.Error("input slice must not be modified")
+	}
+}
+
+func TestCompactMessages_SummarizeKeepsPinned(t *testing.T) {
+	prov := &capturingProvider{response: "they talked"}
+	loop := &Loop{
+		provider: prov,
+		model:    "m",
+		compactionCfg: &config.CompactionConfig{
+			PinFirstUserMessage: true,
+			PinPatterns:         []string{"#keep"},
+		},
+	}
+	msgs := []providers.Message{
+		{Role: "user", Content: "Build me a CLI in Go"},
+		{Role: "assistant", Content: "ok"},
+		{Role: "user", Content: "use cobra #KEEP"},
+		{Role: "assistant", Content: "sure"},
+		{Role: "user", Content: "add tests"},
+		{Role: "assistant", Content: "done"},
+		{Role: "user", Content: "thanks"},
+		{Role: "assistant", Content: "welcome"},
+	}
+
+	out, report := loop.compactMessagesWithReport(context.Background(), msgs)
+	if out == nil {
+		t.Fatal("expected compaction result")
+	}
+	if report.Strategy != CompactionStrategySummarizeOldest || report.Pinned != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if !strings.Contains(out[0].Content, "user: Build me a CLI in Go") || !strings.Contains(out[0].Content, "use cobra #KEEP") {
+		t.Errorf("pinned messages missing from summary message: %q", out[0].Content)
+	}
+	if prompt := prov.captured[0].Messages[0].Content; strings.Contains(prompt, "Build me a CLI") {
+		t.Error("pinned messages should not be sent for summarization")
+	}
+}
+
+func TestDedupeMessages(t *testing.T) {
+	older := []providers.Message{
+		{Role: "user", Content: "Please summarize the quarterly sales report for the north region"},
+		{Role: "tool", Content: `{"rows": 12}`},
+		{Role: "assistant", Content: "Here is something unrelated"},
+	}
+	later := []providers.Message{
+		{Role: "user", Content: "please summarize the quarterly sales report for the north region."},
+		{Role: "tool", Content: `{"rows":  12}`},
+	}
+	var r CompactionReport
+	out := dedupeMessages(older, later, &r)
+	if r.Deduplicated != 2 {
+		t.Fatalf("deduplicated = %d, want 2", r.Deduplicated)
+	}
+	if out[0].Content != duplicateMessageText || out[1].Content != duplicateMessageText {
+		t.Errorf("repeats not replaced: %+v", out)
+	}
+	if out[2].Content != "Here is something unrelated" {
+		t.Errorf("distinct message changed: %q", out[2].Content)
+	}
+}
+
+func TestSplitPinned_NeverPinsToolPairs(t *testing.T) {
+	cfg := &config.CompactionConfig{PinPatterns: []string{"secret"}}
+	msgs := []providers.Message{
+		{Role: "assistant", Content: "secret call", ToolCalls: []providers.ToolCall{{ID: "1"}}},
+		{Role: "tool", Content: "secret result"},
+		{Role: "assistant", Content: "the secret is safe"},
+	}
+	rest, pinned := splitPinned(cfg, msgs)
+	if len(pinned) != 1 || pinned[0].Content != "the secret is safe" || len(rest) != 2 {
+		t.Errorf("rest=%+v pinned=%+v", rest, pinned)
+	}
+}
+
+func TestResolveCompactionStrategy(t *testing.T) {
+	cases := map[string]string{
+		"":                                CompactionStrategySummarizeOldest,
+		"bogus":                           CompactionStrategySummarizeOldest,
+		CompactionStrategyDropToolResults: CompactionStrategyDropToolResults,
+		CompactionStrategySemanticDedupe:  CompactionStrategySemanticDedupe,
+	}
+	for in, want := range cases {
+		if got := ResolveCompactionStrategy(&config.CompactionConfig{Strategy: in}); got != want {
+			t.Errorf("%q: got %q, want %q", in, got, want)
+		}
+	}
+}
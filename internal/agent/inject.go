@@ -13,6 +13,7 @@ import (
 // InjectedMessage represents a user message injected into a running agent loop
 // at the turn boundary (after tool results, before next LLM call).
 type InjectedMessage struct {
+	ID      string // caller's message ID (scheduler run ID), reported in RunResult.Injected
 	Content string
 	UserID  string
 }
@@ -100,6 +101,41 @@ func (l *Loop) drainInjectChannel(ch <-chan InjectedMessage, emitRun func(AgentE
 	}
 }
 
+// makeDrainInjected returns the pipeline hook that drains req.InjectCh before
+// the next LLM call. Each message is recorded in rs so the run result lists
+// it, and announced with a message.injected event.
+func (l *Loop) makeDrainInjected(req *RunRequest, rs *runState, emitRun func(AgentEvent)) func() []providers.Message {
+	return func() []providers.Message {
+		if req.InjectCh == nil {
+			return nil
+		}
+		var msgs []providers.Message
+		for {
+			select {
+			case injected := <-req.InjectCh:
+				msgs = append(msgs, providers.Message{
+					Role:    "user",
+					Content: injected.Content,
+				})
+				if injected.ID != "" {
+					rs.injected = append(rs.injected, injected.ID)
+				}
+				emitRun(AgentEvent{
+					Type:    protocol.AgentEventMessageInjected,
+					AgentID: l.id,
+					RunID:   req.RunID,
+					Payload: map[string]any{
+						"id":      injected.ID,
+						"content": truncateForLog(injected.Content, 200),
+					},
+				})
+			default:
+				return msgs
+			}
+		}
+	}
+}
+
 // truncateForLog truncates a string for log/event payloads.
 func truncateForLog(s string, maxLen int) string {
 	if len(s) <= maxLen {
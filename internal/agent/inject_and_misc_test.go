@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 // ─── truncateForLog ───────────────────────────────────────────────────────
@@ -143,6 +144,29 @@ func TestDrainInjectChannel_MultipleMessages(t *testing.T) {
 	}
 }
 
+func TestMakeDrainInjected_RecordsIDsAndEmitsEvent(t *testing.T) {
+	var events []AgentEvent
+	l := &Loop{id: "a"}
+	ch := make(chan InjectedMessage, 5)
+	ch <- InjectedMessage{ID: "run-2", Content: "also this", UserID: "u1"}
+	rs := &runState{}
+	drain := l.makeDrainInjected(&RunRequest{RunID: "run-1", InjectCh: ch}, rs, func(e AgentEvent) { events = append(events, e) })
+
+	msgs := drain()
+	if len(msgs) != 1 || msgs[0].Role != "user" || msgs[0].Content != "also this" {
+		t.Fatalf("msgs = %+v", msgs)
+	}
+	if len(rs.injected) != 1 || rs.injected[0] != "run-2" {
+		t.Errorf("injected IDs = %v, want [run-2]", rs.injected)
+	}
+	if len(events) != 1 || events[0].Type != protocol.AgentEventMessageInjected || events[0].RunID != "run-1" {
+		t.Errorf("events = %+v, want one message.injected for run-1", events)
+	}
+	if len(drain()) != 0 {
+		t.Error("second drain should be empty")
+	}
+}
+
 // ─── filterBootstrapTools ─────────────────────────────────────────────────
 
 func TestFilterBootstrapTools_AllowedNames(t *testing.T) {
@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// publishBudgetExceeded broadcasts a budget.exceeded event for a rejected run
+// so channels and dashboards can tell the user why nothing happened.
+func (l *Loop) publishBudgetExceeded(ctx context.Context, req RunRequest, err error) {
+	if l.eventPub == nil {
+		return
+	}
+	payload := map[string]any{
+		"agentId": l.id,
+		"userId":  req.UserID,
+		"channel": req.Channel,
+		"chatId":  req.ChatID,
+		"runId":   req.RunID,
+		"error":   err.Error(),
+	}
+	var be *channels.BudgetExceededError
+	if errors.As(err, &be) {
+		payload["scope"] = be.Scope
+		payload["window"] = be.Window
+		payload["metric"] = be.Metric
+		payload["used"] = be.Used
+		payload["limit"] = be.Limit
+		payload["message"] = be.UserMessage()
+	}
+	bus.BroadcastForTenant(l.eventPub, protocol.EventBudgetExceeded, store.TenantIDFromContext(ctx), payload)
+}
@@ -42,8 +42,18 @@ Conversation to summarize:
 // slice — no session state touched, no locks needed.
 // Returns nil on failure (caller keeps original messages).
 func (l *Loop) compactMessagesInPlace(ctx context.Context, messages []providers.Message) []providers.Message {
+	compacted, _ := l.compactMessagesWithReport(ctx, messages)
+	return compacted
+}
+
+// compactMessagesWithReport is compactMessagesInPlace applying the configured
+// compaction strategy; it also returns what was reduced. With the
+// drop-tool-results-first and semantic-dedupe strategies the older ~70% is
+// first thinned without an LLM call, and summarized only if that recovers too
+// little. Pinned messages are carried verbatim after the summary.
+func (l *Loop) compactMessagesWithReport(ctx context.Context, messages []providers.Message) ([]providers.Message, *CompactionReport) {
 	if len(messages) < 6 {
-		return nil
+		return nil, nil
 	}
 
 	// Resolve keepCount from compaction config (same defaults as maybeSummarize).
@@ -69,11 +79,28 @@ func (l *Loop) compactMessagesInPlace(ctx context.Context, messages []providers.
 		break
 	}
 	if splitIdx <= 1 {
-		return nil
+		return nil, nil
+	}
+
+	report := l.newCompactionReport("mid_loop", messages)
+	kept := messages[splitIdx:]
+	older := reduceOlder(report.Strategy, messages[:splitIdx], kept, report)
+	if reduced := append(older[:len(older):len(older)], kept...); l.cheapPassEnough(report, reduced) {
+		report.finish(l, reduced)
+		slog.Info("mid_loop_compacted", "agent", l.id, "strategy", report.Strategy,
+			"tool_results_dropped", report.ToolResultsDropped, "deduplicated", report.Deduplicated,
+			"tokens_recovered", report.TokensRecovered)
+		return reduced, report
+	}
+
+	toSummarize, pinned := splitPinned(l.compactionCfg, older)
+	if len(toSummarize) == 0 {
+		return nil, nil
 	}
+	report.Summarized = len(toSummarize)
+	report.Pinned = len(pinned)
 
 	// Build summary input (same pattern as maybeSummarize in loop_history.go).
-	toSummarize := messages[:splitIdx]
 	var sb strings.Builder
 	for _, m := range toSummarize {
 		switch m.Role {
@@ -99,7 +126,7 @@ func (l *Loop) compactMessagesInPlace(ctx context.Context, messages []providers.
 	})
 	if err != nil {
 		slog.Warn("mid_loop_compaction_failed", "agent", l.id, "error", err)
-		return nil
+		return nil, nil
 	}
 
 	// Collect MediaRefs from compacted messages (keep up to 30 most recent).
@@ -116,20 +143,38 @@ func (l *Loop) compactMessagesInPlace(ctx context.Context, messages []providers.
 
 	summary := providers.Message{
 		Role:      "user",
-		Content:   "[Summary of earlier conversation]\n" + SanitizeAssistantContent(resp.Content),
+		Content:   "[Summary of earlier conversation]\n" + SanitizeAssistantContent(resp.Content) + pinnedBlock(pinned),
 		MediaRefs: preservedRefs,
 	}
-	result := make([]providers.Message, 0, 1+keepCount)
+	result := make([]providers.Message, 0, 1+len(kept))
 	result = append(result, summary)
-	result = append(result, messages[splitIdx:]...)
+	result = append(result, kept...)
+	report.finish(l, result)
 
 	slog.Info("mid_loop_compacted",
 		"agent", l.id,
+		"strategy", report.Strategy,
 		"original_msgs", len(messages),
-		"summarized", splitIdx,
-		"kept", len(result))
+		"summarized", report.Summarized,
+		"pinned", report.Pinned,
+		"kept", len(result),
+		"tokens_recovered", report.TokensRecovered)
 
-	return result
+	return result, report
+}
+
+// pinnedBlock renders pinned messages verbatim for the mid-loop summary
+// message. Folding them into the summary keeps user/assistant alternation.
+func pinnedBlock(pinned []providers.Message) string {
+	if len(pinned) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n\n[Pinned messages, verbatim]\n")
+	for _, m := range pinned {
+		fmt.Fprintf(&sb, "%s: %s\n", m.Role, m.Content)
+	}
+	return sb.String()
 }
 
 // dynamicSummaryMax returns the output-token budget for a compaction or
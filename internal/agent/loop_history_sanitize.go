@@ -183,6 +183,10 @@ func sanitizeHistory(msgs []providers.Message) ([]providers.Message, int) {
 }
 
 func (l *Loop) maybeSummarize(ctx context.Context, sessionKey string) {
+	// Session digest (title + rolling summary for listings) has its own
+	// turn-based cadence, independent of the compaction threshold below.
+	l.maybeDigestSession(ctx, sessionKey)
+
 	history := l.sessions.GetHistory(ctx, sessionKey)
 
 	// Use calibrated token estimation, adjusted for overhead.
@@ -243,7 +247,22 @@ func (l *Loop) maybeSummarize(ctx context.Context, sessionKey string) {
 		}
 
 		summary := l.sessions.GetSummary(sctx, sessionKey)
-		toSummarize := history[:len(history)-keepLast]
+		report := l.newCompactionReport("post_run", withSummary(summary, history))
+		tail := history[len(history)-keepLast:]
+		older := reduceOlder(report.Strategy, history[:len(history)-keepLast], tail, report)
+		if reduced := append(older[:len(older):len(older)], tail...); l.cheapPassEnough(report, withSummary(summary, reduced)) {
+			l.sessions.SetHistory(sctx, sessionKey, reduced)
+			report.finish(l, withSummary(summary, reduced))
+			l.finishPostRunCompaction(sctx, sessionKey, report)
+			return
+		}
+
+		toSummarize, pinned := splitPinned(l.compactionCfg, older)
+		if len(toSummarize) == 0 {
+			return
+		}
+		report.Summarized = len(toSummarize)
+		report.Pinned = len(pinned)
 
 		var sb strings.Builder
 		var mediaKinds []string
@@ -306,8 +325,15 @@ func (l *Loop) maybeSummarize(ctx context.Context, sessionKey string) {
 			}
 		}
 
-		l.sessions.SetSummary(sctx, sessionKey, SanitizeAssistantContent(resp.Content))
-		l.sessions.TruncateHistory(sctx, sessionKey, keepLast)
+		newSummary := SanitizeAssistantContent(resp.Content)
+		l.sessions.SetSummary(sctx, sessionKey, newSummary)
+		if len(pinned) > 0 {
+			// Pinned messages lead the kept history so the next compaction
+			// sees (and pins) them again.
+			l.sessions.SetHistory(sctx, sessionKey, append(pinned[:len(pinned):len(pinned)], tail...))
+		} else {
+			l.sessions.TruncateHistory(sctx, sessionKey, keepLast)
+		}
 
 		// Inject preserved MediaRefs into the first kept message so they survive truncation.
 		if len(preservedRefs) > 0 {
@@ -321,16 +347,45 @@ func (l *Loop) maybeSummarize(ctx context.Context, sessionKey string) {
 				l.sessions.SetHistory(sctx, sessionKey, kept)
 			}
 		}
-		l.sessions.IncrementCompaction(sctx, sessionKey)
-		// Mirror SessionMetaKeyLastCompactionAt from the v3 prune/compact path
-		// so the legacy v2 post-turn summarizer also surfaces compaction cadence.
-		l.sessions.SetSessionMetadata(sctx, sessionKey, map[string]string{
-			SessionMetaKeyLastCompactionAt: time.Now().UTC().Format(time.RFC3339),
-		})
-		l.sessions.Save(sctx, sessionKey)
+		report.finish(l, withSummary(newSummary, l.sessions.GetHistory(sctx, sessionKey)))
+		l.finishPostRunCompaction(sctx, sessionKey, report)
 	}()
 }
 
+// finishPostRunCompaction records a post-run compaction on the session and
+// reports it.
+func (l *Loop) finishPostRunCompaction(ctx context.Context, sessionKey string, report *CompactionReport) {
+	l.sessions.IncrementCompaction(ctx, sessionKey)
+	// Mirror SessionMetaKeyLastCompactionAt from the v3 prune/compact path
+	// so the legacy v2 post-turn summarizer also surfaces compaction cadence.
+	l.sessions.SetSessionMetadata(ctx, sessionKey, map[string]string{
+		SessionMetaKeyLastCompactionAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	l.sessions.Save(ctx, sessionKey)
+
+	slog.Info("session_compacted",
+		"agent", l.id,
+		"session", sessionKey,
+		"strategy", report.Strategy,
+		"summarized", report.Summarized,
+		"tool_results_dropped", report.ToolResultsDropped,
+		"deduplicated", report.Deduplicated,
+		"pinned", report.Pinned,
+		"tokens_recovered", report.TokensRecovered)
+	l.emitCompaction("", sessionKey, report)
+}
+
+// withSummary prepends the compaction summary as a message so token
+// estimates cover what is actually sent to the model.
+func withSummary(summary string, history []providers.Message) []providers.Message {
+	if summary == "" {
+		return history
+	}
+	out := make([]providers.Message, 0, len(history)+1)
+	out = append(out, providers.Message{Role: "user", Content: summary})
+	return append(out, history...)
+}
+
 // estimateOverhead derives the non-history token overhead (system prompt + tool definitions +
 // context files) from calibration data. Used by maybeSummarize to compare history-only tokens
 // against the compaction threshold.
@@ -1,6 +1,11 @@
 package agent
 
-import "context"
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nextlevelbuilder/goclaw/internal/skills"
+)
 
 // Hybrid skill thresholds: when skill count and total token estimate are below
 // these limits, inline all skills as XML in the system prompt (like TS).
@@ -26,7 +31,10 @@ func (l *Loop) resolveSkillsSummary(ctx context.Context, skillFilter []string) s
 		allowList = skillFilter
 	}
 
-	filtered := l.skillsLoader.FilterSkills(ctx, allowList)
+	// Resolve pinned versions and frontmatter requirements (requires: x>=2).
+	res := l.skillsLoader.ResolveSkills(ctx, allowList, l.skillVersions)
+	l.logSkillConflicts(res.Conflicts)
+	filtered := res.Skills
 	if len(filtered) == 0 {
 		return ""
 	}
@@ -42,7 +50,7 @@ func (l *Loop) resolveSkillsSummary(ctx context.Context, skillFilter []string) s
 
 	if len(filtered) <= skillInlineMaxCount && estimatedTokens <= skillInlineMaxTokens {
 		// Inline mode: build full XML summary
-		return l.skillsLoader.BuildSummary(ctx, allowList)
+		return skills.FormatSummary(filtered)
 	}
 
 	// Search mode: no XML in prompt, agent uses skill_search tool
@@ -54,5 +62,21 @@ func (l *Loop) resolvePinnedSkillsSummary(ctx context.Context) string {
 	if l.skillsLoader == nil || len(l.pinnedSkills) == 0 {
 		return ""
 	}
-	return l.skillsLoader.BuildPinnedSummary(ctx, l.pinnedSkills)
+	// Conflicts are reported by resolveSkillsSummary, which sees every skill.
+	return skills.FormatSummary(l.skillsLoader.ResolveSkills(ctx, l.pinnedSkills, l.skillVersions).Skills)
+}
+
+// logSkillConflicts warns about unmet skill pins and requirements once per
+// skills snapshot version, not on every message.
+func (l *Loop) logSkillConflicts(conflicts []skills.Conflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+	v := l.skillsLoader.Version() + 1 // +1: zero value means nothing logged yet
+	if l.skillConflictsLogged.Swap(v) == v {
+		return
+	}
+	for _, c := range conflicts {
+		slog.Warn("skill resolution conflict", "agent", l.id, "conflict", c.String())
+	}
 }
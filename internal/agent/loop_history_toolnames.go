@@ -60,3 +60,16 @@ func (l *Loop) filteredToolNamesForChannel(channelType string) []string {
 	}
 	return filtered
 }
+
+// ToolInfos describes the tools this agent's LLM is offered after policy
+// filtering (builtin, skill and MCP tools), for the tools listing API.
+func (l *Loop) ToolInfos() []tools.ToolInfo {
+	names := l.filteredToolNames()
+	infos := make([]tools.ToolInfo, 0, len(names))
+	for _, name := range names {
+		if info, ok := tools.DescribeTool(l.tools, name); ok {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
@@ -54,6 +54,7 @@ func (l *Loop) enrichInputMedia(ctx context.Context, req *RunRequest, messages [
 	// to the main LLM — the agent calls read_image tool instead. This avoids sending
 	// images to providers that don't support vision or have strict content filters.
 	deferToReadImageTool := l.hasReadImageProvider()
+	ctx = tools.WithMainModelVision(ctx, l.providerAcceptsImages())
 
 	if !deferToReadImageTool {
 		// Inline mode: reload historical images directly into messages for main provider.
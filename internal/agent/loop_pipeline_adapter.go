@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"strings"
 
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/eventbus"
@@ -15,6 +16,13 @@ import (
 
 // runViaPipeline delegates a run to the v3 pipeline.
 func (l *Loop) runViaPipeline(ctx context.Context, req RunRequest) (*RunResult, error) {
+	if req.ResponseFormat != nil {
+		// Every provider sees the schema; native structured output only
+		// tightens decoding. Suffixes would break the JSON answer.
+		req.ExtraSystemPrompt = strings.TrimSpace(req.ExtraSystemPrompt + "\n\n" + req.ResponseFormat.Instructions())
+		req.ContentSuffix = ""
+		req.QuotaNotice = ""
+	}
 	input := convertRunInput(&req)
 	// Bridge runState shares loop detection state between pipeline and agent.
 	bridgeRS := &runState{}
@@ -31,12 +39,15 @@ func (l *Loop) runViaPipeline(ctx context.Context, req RunRequest) (*RunResult,
 
 	p := pipeline.NewDefaultPipeline(deps)
 	state := pipeline.NewRunState(input, nil, model, provider)
+	l.recordRunStartCheckpoint(ctx, &req)
 
 	pResult, err := p.Run(ctx, state)
 	if err != nil {
 		return nil, err
 	}
-	return convertRunResult(pResult), nil
+	result := convertRunResult(pResult)
+	result.Injected = bridgeRS.injected
+	return result, nil
 }
 
 // buildPipelineDeps maps Loop fields + methods to PipelineDeps callbacks.
@@ -60,6 +71,7 @@ func (l *Loop) buildPipelineDeps(req *RunRequest, bridgeRS *runState) pipeline.P
 			MaxTokens:          l.effectiveMaxTokens(),
 			ReserveTokens:      l.resolveReserveTokens(),
 			Compaction:         l.compactionCfg,
+			ResponseFormat:     req.ResponseFormat,
 			// V3 memory/retrieval flags removed — always true at runtime.
 		},
 		// Resolve per-model context window once per run. Falls back to
@@ -138,25 +150,11 @@ func (l *Loop) buildPipelineDeps(req *RunRequest, bridgeRS *runState) pipeline.P
 		ExecuteToolRaw:    cb.executeToolRaw,
 		ProcessToolResult: cb.processToolResult,
 		CheckReadOnly:     cb.checkReadOnly,
+		RecordCheckpoint:  cb.recordCheckpoint,
+		SnapshotFiles:     cb.snapshotFiles,
 
-		// Observe: drain InjectCh
-		DrainInjectCh: func() []providers.Message {
-			if req.InjectCh == nil {
-				return nil
-			}
-			var msgs []providers.Message
-			for {
-				select {
-				case injected := <-req.InjectCh:
-					msgs = append(msgs, providers.Message{
-						Role:    "user",
-						Content: injected.Content,
-					})
-				default:
-					return msgs
-				}
-			}
-		},
+		// Observe / final answer: drain InjectCh
+		DrainInjectCh: cb.drainInjected,
 
 		// Checkpoint + Finalize
 		FlushMessages:          cb.flushMessages,
@@ -227,6 +225,7 @@ func convertRunInput(req *RunRequest) *pipeline.RunInput {
 		ModelOverride:     req.ModelOverride,
 		HideInput:         req.HideInput,
 		ContentSuffix:     req.ContentSuffix,
+		QuotaNotice:       req.QuotaNotice,
 		LeaderAgentID:     req.LeaderAgentID,
 		WorkspaceChannel:  req.WorkspaceChannel,
 		WorkspaceChatID:   req.WorkspaceChatID,
@@ -8,7 +8,6 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
-	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
@@ -55,6 +54,9 @@ func (l *Loop) pipelineCallbacks(req *RunRequest, bridgeRS *runState) pipelineCa
 		executeToolRaw:     l.makeExecuteToolRaw(req),
 		processToolResult:  l.makeProcessToolResult(req, bridgeRS),
 		checkReadOnly:      l.makeCheckReadOnly(req, bridgeRS),
+		recordCheckpoint:   l.makeRecordCheckpoint(req),
+		snapshotFiles:      l.makeSnapshotFiles(req),
+		drainInjected:      l.makeDrainInjected(req, bridgeRS, emitRun),
 		sanitizeContent:    SanitizeAssistantContent,
 		flushMessages:      l.makeFlushMessages(req),
 		updateMetadata:     l.makeUpdateMetadata(req),
@@ -83,6 +85,9 @@ type pipelineCallbackSet struct {
 	executeToolRaw     func(ctx context.Context, tc providers.ToolCall) (providers.Message, any, error)
 	processToolResult  func(ctx context.Context, state *pipeline.RunState, tc providers.ToolCall, rawMsg providers.Message, rawData any) []providers.Message
 	checkReadOnly      func(state *pipeline.RunState) (*providers.Message, bool)
+	recordCheckpoint   func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall)
+	snapshotFiles      func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall)
+	drainInjected      func() []providers.Message
 	sanitizeContent    func(string) string
 	flushMessages      func(ctx context.Context, sessionKey string, msgs []providers.Message) error
 	updateMetadata     func(ctx context.Context, sessionKey string, usage providers.Usage) error
@@ -227,7 +232,16 @@ func (l *Loop) makeCallLLM(req *RunRequest, emitRun func(AgentEvent)) func(ctx c
 		if chatReq.Options == nil {
 			chatReq.Options = make(map[string]any)
 		}
-		chatReq.Options[providers.OptTemperature] = config.DefaultTemperature
+		// OpenAI routes requests with the same prompt_cache_key to the same cache
+		// shard; the stable prompt prefix is per agent, so key on the agent.
+		// Set before applySampling so reproducible runs still strip it.
+		if _, ok := chatReq.Options[providers.OptPromptCacheKey]; !ok {
+			chatReq.Options[providers.OptPromptCacheKey] = "goclaw-" + l.agentUUID.String()
+		}
+		samplingParams, err := l.applySampling(chatReq.Options, model)
+		if err != nil {
+			return nil, err
+		}
 		chatReq.Options[providers.OptSessionKey] = req.SessionKey
 		chatReq.Options[providers.OptAgentID] = l.agentUUID.String()
 		chatReq.Options[providers.OptUserID] = req.UserID
@@ -263,10 +277,10 @@ func (l *Loop) makeCallLLM(req *RunRequest, emitRun func(AgentEvent)) func(ctx c
 		if provider != nil {
 			opts = append(opts, withProvider(provider.Name()))
 		}
+		opts = append(opts, withModelParams(samplingParamsJSON(samplingParams), l.sampling.Reproducible))
 		spanID := l.emitLLMSpanStart(ctx, start, state.Iteration+1, chatReq.Messages, opts...)
 
 		var resp *providers.ChatResponse
-		var err error
 		if req.Stream {
 			resp, err = provider.ChatStream(ctx, chatReq, func(chunk providers.StreamChunk) {
 				if chunk.Thinking != "" {
@@ -289,6 +303,9 @@ func (l *Loop) makeCallLLM(req *RunRequest, emitRun func(AgentEvent)) func(ctx c
 		} else {
 			resp, err = provider.Chat(ctx, chatReq)
 		}
+		if err == nil && resp != nil && len(resp.ToolCalls) > 0 && req.BeginSideEffects != nil && !req.BeginSideEffects() {
+			resp, err = nil, context.Canceled // preempted: stop before acting
+		}
 
 		// Non-streaming: emit content events matching v2 behavior (channels need these).
 		if !req.Stream && err == nil && resp != nil {
@@ -325,10 +342,13 @@ func (l *Loop) makePruneMessages() func(msgs []providers.Message, budget int) ([
 
 func (l *Loop) makeCompactMessages(req *RunRequest) func(ctx context.Context, msgs []providers.Message, model string) ([]providers.Message, error) {
 	return func(ctx context.Context, msgs []providers.Message, model string) ([]providers.Message, error) {
-		compacted := l.compactMessagesInPlace(ctx, msgs)
+		compacted, report := l.compactMessagesWithReport(ctx, msgs)
 		if compacted == nil {
 			return msgs, nil // compaction failed, return original
 		}
+		if req != nil {
+			l.emitCompaction(req.RunID, req.SessionKey, report)
+		}
 		// Stamp session metadata with the compaction timestamp so operators
 		// can diagnose compaction cadence without a dedicated column. Stored
 		// as RFC3339 string in sessions.metadata JSONB (flushed on next save).
@@ -22,7 +22,7 @@ func (l *Loop) makeExecuteToolCall(req *RunRequest, bridgeRS *runState) func(ctx
 	return func(ctx context.Context, state *pipeline.RunState, tc providers.ToolCall) ([]providers.Message, error) {
 		registryName := l.resolveToolCallName(tc.Name)
 		argsJSON, _ := json.Marshal(tc.Arguments)
-		slog.Info("tool call", "agent", l.id, "tool", tc.Name, "args_len", len(argsJSON))
+		slog.InfoContext(ctx, "tool call", "agent", l.id, "tool", tc.Name, "args_len", len(argsJSON))
 
 		emitRun(AgentEvent{
 			Type:    protocol.AgentEventToolCall,
@@ -44,6 +44,8 @@ func (l *Loop) makeExecuteToolCall(req *RunRequest, bridgeRS *runState) func(ctx
 			})
 		}
 
+		ctx = tools.WithProgressReporter(ctx, newToolProgressReporter(emitRun, l.id, state.RunID, tc))
+
 		result := l.tools.ExecuteWithContext(ctx, registryName, tc.Arguments,
 			req.Channel, req.ChatID, req.PeerKind, req.SessionKey, nil)
 		toolDuration := time.Since(toolStart)
@@ -76,7 +78,7 @@ func (l *Loop) makeExecuteToolRaw(req *RunRequest) func(ctx context.Context, tc
 	return func(ctx context.Context, tc providers.ToolCall) (providers.Message, any, error) {
 		registryName := l.resolveToolCallName(tc.Name)
 		argsJSON, _ := json.Marshal(tc.Arguments)
-		slog.Info("tool call", "agent", l.id, "tool", tc.Name, "args_len", len(argsJSON))
+		slog.InfoContext(ctx, "tool call", "agent", l.id, "tool", tc.Name, "args_len", len(argsJSON))
 
 		// Emit tool.call event at I/O start — parity with sequential path (makeExecuteToolCall).
 		// Without this, parallel tool execution (2+ concurrent tools) never notifies UI of
@@ -101,6 +103,8 @@ func (l *Loop) makeExecuteToolRaw(req *RunRequest) func(ctx context.Context, tc
 			})
 		}
 
+		ctx = tools.WithProgressReporter(ctx, newToolProgressReporter(emitRun, l.id, req.RunID, tc))
+
 		result := l.tools.ExecuteWithContext(ctx, registryName, tc.Arguments,
 			req.Channel, req.ChatID, req.PeerKind, req.SessionKey, nil)
 		dur := time.Since(start)
@@ -2,11 +2,14 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 	"github.com/nextlevelbuilder/goclaw/internal/tracing"
@@ -19,6 +22,14 @@ func (l *Loop) Run(ctx context.Context, req RunRequest) (*RunResult, error) {
 	l.activeRuns.Add(1)
 	defer l.activeRuns.Add(-1)
 
+	// The scheduler may start a queued run under another message's context,
+	// so the request's own correlation ID takes precedence.
+	if req.RequestID != "" {
+		ctx = requestid.With(ctx, req.RequestID)
+	} else {
+		ctx, req.RequestID = requestid.Ensure(ctx)
+	}
+
 	// Per-run emit wrapper: enriches every AgentEvent with delegation + routing context.
 	emitRun := func(event AgentEvent) {
 		event.RunKind = req.RunKind
@@ -35,6 +46,27 @@ func (l *Loop) Run(ctx context.Context, req RunRequest) (*RunResult, error) {
 		l.emit(event)
 	}
 
+	// Budget caps: rejected before any trace or LLM call. Announce runs only
+	// deliver results of work that was already admitted.
+	if l.budgetGuard != nil && req.RunKind != "announce" {
+		if err := l.budgetGuard.CheckRun(ctx, req.UserID, l.id, l.agentUUID, l.budgetMonthlyCents); err != nil {
+			l.publishBudgetExceeded(ctx, req, err)
+			emitRun(AgentEvent{Type: protocol.AgentEventRunFailed, AgentID: l.id, RunID: req.RunID, Payload: map[string]string{"error": err.Error()}})
+			return nil, err
+		}
+	}
+
+	// Multi-instance: own the session before touching its history so two
+	// replicas never interleave turns or overwrite each other's saves.
+	if l.sessionLocker != nil && req.SessionKey != "" {
+		release, err := l.sessionLocker.LockSession(ctx, req.SessionKey)
+		if err != nil {
+			emitRun(AgentEvent{Type: protocol.AgentEventRunFailed, AgentID: l.id, RunID: req.RunID, Payload: map[string]string{"error": err.Error()}})
+			return nil, fmt.Errorf("session lock: %w", err)
+		}
+		defer release()
+	}
+
 	emitRun(AgentEvent{
 		Type:    protocol.AgentEventRunStarted,
 		AgentID: l.id,
@@ -81,6 +113,7 @@ func (l *Loop) Run(ctx context.Context, req RunRequest) (*RunResult, error) {
 			CreatedAt:    now,
 			Tags:         req.TraceTags,
 		}
+		trace.Metadata, _ = json.Marshal(map[string]string{"request_id": req.RequestID})
 		if l.agentUUID != uuid.Nil {
 			trace.AgentID = &l.agentUUID
 		}
@@ -97,7 +130,7 @@ func (l *Loop) Run(ctx context.Context, req RunRequest) (*RunResult, error) {
 			}
 		}
 		if err := l.traceCollector.CreateTrace(ctx, trace); err != nil {
-			slog.Warn("tracing: failed to create trace", "error", err)
+			slog.WarnContext(ctx, "tracing: failed to create trace", "error", err)
 		} else {
 			ctx = tracing.WithTraceID(ctx, traceID)
 			ctx = tracing.WithCollector(ctx, l.traceCollector)
@@ -208,7 +241,7 @@ func (l *Loop) Run(ctx context.Context, req RunRequest) (*RunResult, error) {
 		if result.Usage != nil {
 			logAttrs = append(logAttrs, "total_tokens", result.Usage.TotalTokens)
 		}
-		slog.Info("v3.run.completed", logAttrs...)
+		slog.InfoContext(ctx, "v3.run.completed", logAttrs...)
 
 		if agentSpanID != uuid.Nil {
 			l.emitAgentSpanEnd(ctx, agentSpanID, runStart, result, nil)
@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// reproducibleStripOptions are request options that route to different
+// deployments or cache tiers and therefore add run-to-run variance.
+var reproducibleStripOptions = []string{
+	providers.OptServiceTier,
+	providers.OptFastMode,
+	providers.OptPromptCacheKey,
+	providers.OptPromptCacheRetention,
+}
+
+// applySampling sets temperature/seed on the request options according to the
+// agent's sampling config and returns the effective params for span recording.
+// Reproducible runs reject floating model aliases: the provider may move the
+// alias to a new snapshot between runs, so results would not be comparable.
+func (l *Loop) applySampling(opts map[string]any, model string) (map[string]any, error) {
+	temp := config.DefaultTemperature
+	if l.sampling.Reproducible {
+		temp = 0
+	}
+	if l.sampling.Temperature != nil {
+		temp = *l.sampling.Temperature
+	}
+	opts[providers.OptTemperature] = temp
+
+	params := map[string]any{
+		"model":       model,
+		"temperature": temp,
+	}
+	if l.sampling.Seed != nil {
+		opts[providers.OptSeed] = *l.sampling.Seed
+		params["seed"] = *l.sampling.Seed
+	}
+	if !l.sampling.Reproducible {
+		return params, nil
+	}
+
+	if modelIsFloatingAlias(model) {
+		return nil, fmt.Errorf("reproducible run needs a pinned model version, got floating alias %q (e.g. use gpt-4o-2024-08-06 or claude-sonnet-4-5-20250929)", model)
+	}
+	for _, k := range reproducibleStripOptions {
+		delete(opts, k)
+	}
+	params["reproducible"] = true
+	return params, nil
+}
+
+// modelIsFloatingAlias reports whether a model ID points at a moving target
+// (e.g. "gpt-4o-latest", "claude-sonnet-4-5") rather than a pinned version.
+// Pinned IDs end with a date (20250929, 2024-08-06), a short version number
+// (gpt-4-0613, mistral-large-2407, gemini-1.5-pro-002), or are Ollama
+// name:tag / @sha256 references with a tag other than "latest".
+func modelIsFloatingAlias(model string) bool {
+	m := strings.ToLower(model)
+	if m == "" || strings.Contains(m, "latest") {
+		return true
+	}
+	if _, tag, ok := strings.Cut(m, ":"); ok {
+		return tag == "" // Ollama tag or @sha256: digest
+	}
+	// 8-digit (20250929) or ISO (2024-08-06) date.
+	digits := 0
+	for i := len(m) - 1; i >= 0 && (m[i] >= '0' && m[i] <= '9' || m[i] == '-'); i-- {
+		if m[i] != '-' {
+			digits++
+		}
+	}
+	if digits >= 8 {
+		return false
+	}
+	// -MMDD, -YYMM or -NNN version suffix.
+	i := strings.LastIndexByte(m, '-')
+	suffix := m[i+1:]
+	if i < 0 || len(suffix) < 3 || len(suffix) > 4 {
+		return true
+	}
+	for _, c := range suffix {
+		if c < '0' || c > '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// samplingParamsJSON marshals effective sampling params for SpanData.ModelParams.
+func samplingParamsJSON(params map[string]any) json.RawMessage {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestApplySamplingDefaults(t *testing.T) {
+	l := &Loop{}
+	opts := map[string]any{}
+	params, err := l.applySampling(opts, "gpt-4o")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts[providers.OptTemperature] != config.DefaultTemperature {
+		t.Errorf("temperature = %v, want %v", opts[providers.OptTemperature], config.DefaultTemperature)
+	}
+	if _, ok := opts[providers.OptSeed]; ok {
+		t.Error("seed should not be set by default")
+	}
+	if _, ok := params["reproducible"]; ok {
+		t.Error("reproducible should not be recorded by default")
+	}
+}
+
+func TestApplySamplingReproducible(t *testing.T) {
+	seed := int64(1234)
+	l := &Loop{sampling: store.SamplingConfig{Seed: &seed, Reproducible: true}}
+	opts := map[string]any{
+		providers.OptServiceTier:    "flex",
+		providers.OptPromptCacheKey: "k",
+	}
+	params, err := l.applySampling(opts, "gpt-4o-2024-08-06")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts[providers.OptTemperature] != 0.0 {
+		t.Errorf("temperature = %v, want 0", opts[providers.OptTemperature])
+	}
+	if opts[providers.OptSeed] != seed {
+		t.Errorf("seed = %v, want %d", opts[providers.OptSeed], seed)
+	}
+	for _, k := range reproducibleStripOptions {
+		if _, ok := opts[k]; ok {
+			t.Errorf("option %q should be stripped in reproducible mode", k)
+		}
+	}
+	if params["reproducible"] != true || params["seed"] != seed {
+		t.Errorf("params = %v, want reproducible + seed recorded", params)
+	}
+}
+
+func TestApplySamplingExplicitTemperatureWins(t *testing.T) {
+	temp := 0.4
+	l := &Loop{sampling: store.SamplingConfig{Temperature: &temp, Reproducible: true}}
+	opts := map[string]any{}
+	if _, err := l.applySampling(opts, "claude-sonnet-4-5-20250929"); err != nil {
+		t.Fatal(err)
+	}
+	if opts[providers.OptTemperature] != 0.4 {
+		t.Errorf("temperature = %v, want 0.4", opts[providers.OptTemperature])
+	}
+}
+
+func TestApplySamplingReproducibleRejectsFloatingAlias(t *testing.T) {
+	l := &Loop{sampling: store.SamplingConfig{Reproducible: true}}
+	for _, model := range []string{"gpt-4o", "chatgpt-4o-latest", ""} {
+		if _, err := l.applySampling(map[string]any{}, model); err == nil {
+			t.Errorf("applySampling(%q): expected error in reproducible mode", model)
+		}
+	}
+	// Outside reproducible mode aliases are fine.
+	if _, err := (&Loop{}).applySampling(map[string]any{}, "gpt-4o"); err != nil {
+		t.Errorf("non-reproducible alias: %v", err)
+	}
+}
+
+func TestModelIsFloatingAlias(t *testing.T) {
+	cases := map[string]bool{
+		"":                           true,
+		"gpt-4o":                     true,
+		"chatgpt-4o-latest":          true,
+		"claude-sonnet-4-5":          true,
+		"claude-sonnet-4-5-20250929": false,
+		"gpt-4o-2024-08-06":          false,
+		"gpt-4-0613":                 false,
+		"gpt-3.5-turbo-0125":         false,
+		"gemini-1.5-pro-002":         false,
+		"mistral-large-2407":         false,
+		"llama3.1:8b-instruct-q4_0":  false,
+		"llama3@sha256:4f2222927938": false,
+		"llama3:latest":              true,
+		"mistral-large":              true,
+		"gemini-1.5-pro":             true,
+		"qwen2.5-72b":                true,
+	}
+	for model, want := range cases {
+		if got := modelIsFloatingAlias(model); got != want {
+			t.Errorf("modelIsFloatingAlias(%q) = %v, want %v", model, got, want)
+		}
+	}
+}
+
+func TestCallLLM_SetsPromptCacheKey(t *testing.T) {
+	prov := &capturingProvider{response: "ok"}
+	l := &Loop{agentUUID: uuid.New()}
+	call := l.makeCallLLM(&RunRequest{}, func(AgentEvent) {})
+	state := &pipeline.RunState{Provider: prov, Model: "gpt-4o-2024-08-06"}
+
+	if _, err := call(context.Background(), state, providers.ChatRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := prov.captured[0].Options[providers.OptPromptCacheKey]; got != "goclaw-"+l.agentUUID.String() {
+		t.Errorf("prompt_cache_key = %v, want goclaw-%s", got, l.agentUUID)
+	}
+
+	// Reproducible runs strip it again.
+	l.sampling.Reproducible = true
+	if _, err := call(context.Background(), state, providers.ChatRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := prov.captured[1].Options[providers.OptPromptCacheKey]; ok {
+		t.Error("prompt_cache_key should be stripped in reproducible mode")
+	}
+}
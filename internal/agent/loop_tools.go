@@ -50,7 +50,7 @@ func (l *Loop) processToolResult(
 		if len(errMsg) > 200 {
 			errMsg = errMsg[:200] + "..."
 		}
-		slog.Warn("tool error", "agent", l.id, "tool", tc.Name, "error", errMsg)
+		slog.WarnContext(ctx, "tool error", "agent", l.id, "tool", tc.Name, "error", errMsg)
 	}
 
 	// Count successful spawn calls for orphan detection (post-execution).
@@ -119,6 +119,16 @@ func (l *Loop) processToolResult(
 
 	action = toolResultContinue
 
+	// read_image attach mode: the images reach the model as a user turn after
+	// the tool results (tool messages cannot carry images on most providers).
+	if len(result.Images) > 0 && !result.IsError {
+		warningMsgs = append(warningMsgs, providers.Message{
+			Role:    "user",
+			Content: "[Image(s) loaded by " + registryName + "]",
+			Images:  result.Images,
+		})
+	}
+
 	// Check for tool call loop after recording result.
 	if level, msg := rs.loopDetector.detect(registryName, argsHash); level != "" {
 		if level == "critical" {
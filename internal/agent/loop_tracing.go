@@ -36,6 +36,9 @@ func (l *Loop) UUID() uuid.UUID { return l.agentUUID }
 // Used for per-agent TTS voice override (tts_voice_id, tts_model_id).
 func (l *Loop) OtherConfig() json.RawMessage { return l.agentOtherConfig }
 
+// BudgetMonthlyCents returns the agent's monthly LLM budget in cents (0 = none).
+func (l *Loop) BudgetMonthlyCents() int { return l.budgetMonthlyCents }
+
 // Model returns the model identifier for this agent loop.
 func (l *Loop) Model() string { return l.model }
 
@@ -51,13 +54,22 @@ func (l *Loop) IsRunning() bool { return l.activeRuns.Load() > 0 }
 type spanOption func(*spanOverrides)
 
 type spanOverrides struct {
-	model    string
-	provider string
+	model       string
+	provider    string
+	modelParams json.RawMessage
+	fullInput   bool // reproducible runs: record untruncated input messages
 }
 
 func withModel(m string) spanOption    { return func(o *spanOverrides) { o.model = m } }
 func withProvider(p string) spanOption { return func(o *spanOverrides) { o.provider = p } }
 
+func withModelParams(params json.RawMessage, fullInput bool) spanOption {
+	return func(o *spanOverrides) {
+		o.modelParams = params
+		o.fullInput = fullInput
+	}
+}
+
 // resolveSpan returns (model, provider) applying any overrides on top of agent defaults.
 func (l *Loop) resolveSpan(opts []spanOption) (string, string) {
 	o := spanOverrides{model: l.model, provider: l.provider.Name()}
@@ -82,6 +94,10 @@ func (l *Loop) emitLLMSpanStart(ctx context.Context, start time.Time, iteration
 	}
 
 	model, providerName := l.resolveSpan(opts)
+	var o spanOverrides
+	for _, fn := range opts {
+		fn(&o)
+	}
 	spanID := store.GenNewID()
 	span := store.SpanData{
 		ID:        spanID,
@@ -95,6 +111,7 @@ func (l *Loop) emitLLMSpanStart(ctx context.Context, start time.Time, iteration
 		Provider:  providerName,
 		CreatedAt: start,
 	}
+	span.ModelParams = o.modelParams
 	if parentID := tracing.ParentSpanIDFromContext(ctx); parentID != uuid.Nil {
 		span.ParentSpanID = &parentID
 	}
@@ -122,7 +139,11 @@ func (l *Loop) emitLLMSpanStart(ctx context.Context, start time.Time, iteration
 			}
 		}
 		if b, err := json.Marshal(stripped); err == nil {
-			span.InputPreview = tracing.TruncateJSON(string(b), previewLimit)
+			if o.fullInput {
+				span.InputPreview = string(b)
+			} else {
+				span.InputPreview = tracing.TruncateJSON(string(b), previewLimit)
+			}
 		}
 	}
 
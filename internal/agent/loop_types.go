@@ -19,6 +19,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tokencount"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
@@ -108,7 +109,7 @@ type Loop struct {
 	// Memory flush runs if callback != nil; auto-inject runs if AutoInjector != nil.
 	autoInjector memory.AutoInjector // v3 L0 memory auto-inject (nil = disabled)
 
-	eventPub        bus.EventPublisher // currently unused by Loop; kept for future use
+	eventPub        bus.EventPublisher // budget.exceeded broadcasts
 	domainBus       eventbus.DomainEventBus // V3 domain event bus for consolidation pipeline
 	sessions        store.SessionStore
 	tools           tools.ToolExecutor
@@ -119,14 +120,19 @@ type Loop struct {
 
 	// Per-session summarization lock: prevents concurrent summarize goroutines for the same session.
 	summarizeMu sync.Map // sessionKey → *sync.Mutex
+	digestMu    sync.Map // sessionKey → *sync.Mutex (background session digest)
 
 	// Bootstrap/persona context (loaded at startup, injected into system prompt)
 	ownerIDs       []string
 	skillsLoader   *skills.Loader
-	skillAllowList []string // nil = all, [] = none, ["x","y"] = filter
+	skillAllowList []string       // nil = all, [] = none, ["x","y"] = filter
+	skillVersions  map[string]int // slug → pinned version from agent grants
 	hasMemory      bool
 	contextFiles   []bootstrap.ContextFile
 
+	// Loader version whose skill resolution conflicts were last logged.
+	skillConflictsLogged atomic.Int64
+
 	// Per-user profile + file seeding + dynamic context loading
 	ensureUserProfile EnsureUserProfileFunc // create/resolve user profile + workspace
 	seedUserFiles     SeedUserFilesFunc     // seed context files (BOOTSTRAP.md, USER.md)
@@ -191,6 +197,9 @@ type Loop struct {
 	// Requested reasoning config parsed from agent other_config.
 	reasoningConfig store.AgentReasoningConfig
 
+	// Sampling overrides (temperature, seed, reproducible mode) from agent other_config.
+	sampling store.SamplingConfig
+
 	// Prompt mode from agent other_config (empty = full).
 	promptMode PromptMode
 
@@ -265,6 +274,32 @@ type Loop struct {
 	// nil the pipeline fast-path skips all hook overhead. Populated from
 	// LoopConfig.HookDispatcher during startup wiring.
 	hookDispatcher hooks.Dispatcher
+
+	// budgetGuard rejects runs once the user or agent reaches a token/cost cap.
+	// Nil = budgets not enforced.
+	budgetGuard BudgetGuard
+
+	// sessionLocker serializes runs on a session across gateway replicas.
+	// Nil = single instance, no cross-process locking.
+	sessionLocker SessionLocker
+
+	// snapshots saves workspace files before tools write them, for
+	// `goclaw workspace rollback`. Nil = snapshots disabled.
+	snapshots *snapshots.Store
+}
+
+// BudgetGuard decides whether a run may start given the user's and the
+// agent's spend so far. A non-nil error rejects the run and is returned from
+// Run unchanged. Implemented by channels.BudgetChecker.
+type BudgetGuard interface {
+	CheckRun(ctx context.Context, userID, agentKey string, agentID uuid.UUID, agentBudgetCents int) error
+}
+
+// SessionLocker serializes runs on one session across gateway replicas.
+// LockSession blocks until this instance owns the session (or fails) and
+// returns a release func. Implemented by pg.SessionLocker.
+type SessionLocker interface {
+	LockSession(ctx context.Context, sessionKey string) (release func(), err error)
 }
 
 // AgentEvent is emitted during agent execution for WS broadcasting.
@@ -321,6 +356,9 @@ type LoopConfig struct {
 	Bus             bus.EventPublisher
 	DomainBus       eventbus.DomainEventBus // V3 domain event bus for consolidation pipeline
 	HookDispatcher  hooks.Dispatcher        // lifecycle hook dispatcher (nil = noop)
+	BudgetGuard     BudgetGuard             // per-user/agent token and cost caps (nil = not enforced)
+	SessionLocker   SessionLocker           // cross-replica session locks (nil = single instance)
+	Snapshots       *snapshots.Store        // pre-write workspace file snapshots (nil = disabled)
 	Sessions        store.SessionStore
 	Tools           *tools.Registry
 	ToolPolicy      *tools.PolicyEngine    // optional: filters tools sent to LLM
@@ -330,7 +368,8 @@ type LoopConfig struct {
 	// Bootstrap/persona context
 	OwnerIDs       []string
 	SkillsLoader   *skills.Loader
-	SkillAllowList []string // nil = all, [] = none, ["x","y"] = filter
+	SkillAllowList []string       // nil = all, [] = none, ["x","y"] = filter
+	SkillVersions  map[string]int // slug → pinned version (absent = active version)
 	HasMemory      bool
 	ContextFiles   []bootstrap.ContextFile
 
@@ -387,6 +426,7 @@ type LoopConfig struct {
 
 	// Requested reasoning config parsed from agent other_config.
 	ReasoningConfig store.AgentReasoningConfig
+	Sampling        store.SamplingConfig
 
 	// Prompt mode from agent other_config ("full", "task", "minimal", "none")
 	PromptMode PromptMode
@@ -518,6 +558,9 @@ func NewLoop(cfg LoopConfig) *Loop {
 		eventPub:               cfg.Bus,
 		domainBus:              cfg.DomainBus,
 		hookDispatcher:         cfg.HookDispatcher,
+		budgetGuard:            cfg.BudgetGuard,
+		sessionLocker:          cfg.SessionLocker,
+		snapshots:              cfg.Snapshots,
 		sessions:               cfg.Sessions,
 		tools:                  cfg.Tools,
 		registry:               cfg.Tools,
@@ -527,6 +570,7 @@ func NewLoop(cfg LoopConfig) *Loop {
 		ownerIDs:               cfg.OwnerIDs,
 		skillsLoader:           cfg.SkillsLoader,
 		skillAllowList:         cfg.SkillAllowList,
+		skillVersions:          cfg.SkillVersions,
 		hasMemory:              cfg.HasMemory,
 		contextFiles:           cfg.ContextFiles,
 		defaultTimezone:        cfg.DefaultTimezone,
@@ -552,6 +596,7 @@ func NewLoop(cfg LoopConfig) *Loop {
 		tenantAllowedPaths:     cfg.TenantAllowedPaths,
 		disabledTools:          cfg.DisabledTools,
 		reasoningConfig:        cfg.ReasoningConfig,
+		sampling:               cfg.Sampling,
 		promptMode:             cfg.PromptMode,
 		pinnedSkills:           cfg.PinnedSkills,
 		selfEvolve:             cfg.SelfEvolve,
@@ -592,6 +637,7 @@ type RunRequest struct {
 	ChatID            string             // source chat ID
 	PeerKind          string             // "direct" or "group" (for session key building and tool context)
 	RunID             string             // unique run identifier
+	RequestID         string             // correlation ID of the inbound message (falls back to the ctx request ID)
 	UserID            string             // external user ID (TEXT, free-form) for multi-tenant scoping
 	SenderID          string             // original individual sender ID (preserved in group chats for permission checks)
 	SenderName        string             // display name from channel metadata (for bootstrap auto-contact)
@@ -612,10 +658,15 @@ type RunRequest struct {
 	ProviderOverride  providers.Provider // per-request provider override (heartbeat uses different provider)
 	LightContext      bool               // skip loading context files (only inject ExtraSystemPrompt)
 
+	// ResponseFormat forces the final answer to be JSON matching a schema
+	// (nil = free text). Invalid answers are sent back to the model to fix.
+	ResponseFormat *providers.ResponseFormat
+
 	// Run classification
 	RunKind       string // "delegation", "announce" — empty for user-initiated runs
 	HideInput     bool   // don't persist input message in session history (announce runs)
 	ContentSuffix string // appended to assistant response before saving (e.g. image markdown for WS)
+	QuotaNotice   string // appended to the delivered reply only (not saved): quota/budget warning for the user
 
 	// Mid-run message injection channel (nil = disabled).
 	// When set, the loop drains this channel at turn boundaries to inject
@@ -627,6 +678,13 @@ type RunRequest struct {
 	// so force-abort can mark the correct trace as cancelled. Nil = no-op.
 	OnTraceCreated func(traceID uuid.UUID)
 
+	// BeginSideEffects is called when the model first asks for tool calls,
+	// before any of them (or the block reply) runs. It returns false when
+	// the scheduler has already preempted the run; the run then stops
+	// instead of acting, because a preempted run restarts from the
+	// beginning. Nil = no-op.
+	BeginSideEffects func() bool
+
 	// Delegation context (set when running as a delegate agent)
 	DelegationID  string // delegation ID for event correlation
 	TeamID        string // team ID (if delegation is team-scoped)
@@ -654,6 +712,7 @@ type RunResult struct {
 	BlockReplies   int              `json:"blockReplies,omitempty"`   // number of block.reply events emitted
 	LastBlockReply string           `json:"lastBlockReply,omitempty"` // last block reply content (for dedup)
 	LoopKilled     bool             `json:"loopKilled,omitempty"`     // true when run was terminated by loop detector
+	Injected       []string         `json:"injected,omitempty"`       // IDs of mid-run messages the run took in (InjectedMessage.ID)
 }
 
 // MediaResult represents a media file produced by a tool during the agent run.
@@ -683,6 +742,7 @@ type runState struct {
 	asyncToolCalls []string // async spawn tool names for fallback
 	mediaResults   []MediaResult
 	deliverables   []string // tool output content for team task results
+	injected       []string // IDs of mid-run messages drained into the loop
 	pendingMsgs    []providers.Message
 
 	// Event state
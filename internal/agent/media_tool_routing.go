@@ -50,6 +50,13 @@ func (l *Loop) hasReadImageProvider() bool {
 	return false
 }
 
+// providerAcceptsImages reports whether the agent's main provider declares
+// image input support (read_image attach mode).
+func (l *Loop) providerAcceptsImages() bool {
+	ca, ok := l.provider.(providers.CapabilitiesAware)
+	return ok && ca.Capabilities().Vision
+}
+
 // loadHistoricalImagesForTool collects image MediaRefs from historical messages
 // and loads them into context for the read_image tool. Merges with any images already
 // in context (from current turn). Limited to last maxMediaReloadMessages messages with image refs.
@@ -27,23 +27,42 @@ const (
 		"You may reply, but usually NO_REPLY is correct."
 )
 
+// Memory flush modes.
+const (
+	MemoryFlushModeAuto   = "auto"   // run only when the compacted turns look worth saving
+	MemoryFlushModeAlways = "always" // run on every compaction
+)
+
+// Auto-mode thresholds when not configured.
+const (
+	defaultFlushMinUserTurns = 2
+	defaultFlushMinChars     = 400
+)
+
 // MemoryFlushSettings holds resolved flush config with defaults applied.
 type MemoryFlushSettings struct {
 	Enabled      bool
 	Prompt       string
 	SystemPrompt string
+	Mode         string // MemoryFlushModeAuto or MemoryFlushModeAlways
+	MinUserTurns int
+	MinChars     int
 }
 
 // ResolveMemoryFlushSettings resolves flush settings from config, applying defaults.
 // Returns nil if disabled.
 func ResolveMemoryFlushSettings(compaction *config.CompactionConfig) *MemoryFlushSettings {
+	settings := &MemoryFlushSettings{
+		Enabled:      true,
+		Prompt:       DefaultMemoryFlushPrompt,
+		SystemPrompt: DefaultMemoryFlushSystemPrompt,
+		Mode:         MemoryFlushModeAuto,
+		MinUserTurns: defaultFlushMinUserTurns,
+		MinChars:     defaultFlushMinChars,
+	}
 	if compaction == nil || compaction.MemoryFlush == nil {
-		// Default: enabled
-		return &MemoryFlushSettings{
-			Enabled:      true,
-			Prompt:       DefaultMemoryFlushPrompt,
-			SystemPrompt: DefaultMemoryFlushSystemPrompt,
-		}
+		// Default: enabled, heuristics decide per compaction
+		return settings
 	}
 
 	mf := compaction.MemoryFlush
@@ -51,18 +70,21 @@ func ResolveMemoryFlushSettings(compaction *config.CompactionConfig) *MemoryFlus
 		return nil
 	}
 
-	settings := &MemoryFlushSettings{
-		Enabled:      true,
-		Prompt:       DefaultMemoryFlushPrompt,
-		SystemPrompt: DefaultMemoryFlushSystemPrompt,
-	}
-
 	if mf.Prompt != "" {
 		settings.Prompt = mf.Prompt
 	}
 	if mf.SystemPrompt != "" {
 		settings.SystemPrompt = mf.SystemPrompt
 	}
+	if mf.Mode == MemoryFlushModeAlways {
+		settings.Mode = MemoryFlushModeAlways
+	}
+	if mf.MinUserTurns > 0 {
+		settings.MinUserTurns = mf.MinUserTurns
+	}
+	if mf.MinChars > 0 {
+		settings.MinChars = mf.MinChars
+	}
 
 	return settings
 }
@@ -119,8 +141,16 @@ func (l *Loop) runMemoryFlush(ctx context.Context, sessionKey string, settings *
 	flushCtx, cancel := context.WithTimeout(ctx, 90*time.Second)
 	defer cancel()
 
-	// Build messages: system prompt + history summary + flush prompt
-	history := l.sessions.GetHistory(ctx, sessionKey)
+	// Build messages: system prompt + history summary + flush prompt.
+	// The flush only sees the turns compaction is about to summarize away —
+	// the kept tail survives compaction verbatim.
+	history := flushCandidates(l.sessions.GetHistory(ctx, sessionKey), l.keepLastMessages())
+	if ok, reason := assessMemoryFlush(history, settings); !ok {
+		slog.Info("memory flush: skipped", "session", sessionKey, "reason", reason, "messages", len(history))
+		l.sessions.SetMemoryFlushDone(ctx, sessionKey)
+		l.sessions.Save(ctx, sessionKey)
+		return
+	}
 	summary := l.sessions.GetSummary(ctx, sessionKey)
 
 	var messages []providers.Message
@@ -161,12 +191,8 @@ func (l *Loop) runMemoryFlush(ctx context.Context, sessionKey string, settings *
 		})
 	}
 
-	// Include recent history (last 10 messages for context)
-	recentHistory := history
-	if len(recentHistory) > 10 {
-		recentHistory = recentHistory[len(recentHistory)-10:]
-	}
-	sanitized, _ := sanitizeHistory(recentHistory)
+	// Include the turns about to be compacted
+	sanitized, _ := sanitizeHistory(history)
 	messages = append(messages, sanitized...)
 
 	// Flush prompt (with YYYY-MM-DD replaced by today's date)
@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// maxFlushInputMessages caps how many of the compacted turns the flush sees,
+// keeping the flush prompt bounded on very long sessions.
+const maxFlushInputMessages = 40
+
+// reIdentity catches personal facts the extractive patterns don't cover.
+var reIdentity = regexp.MustCompile(`(?i)\b(?:remember\s+that|my\s+name\s+is|call\s+me|I\s+work\s+(?:at|on|for)|I\s+live\s+in|my\s+\w+\s+is|deadline|birthday)\b`)
+
+// keepLastMessages returns how many trailing messages compaction keeps verbatim.
+func (l *Loop) keepLastMessages() int {
+	if l.compactionCfg != nil && l.compactionCfg.KeepLastMessages > 0 {
+		return l.compactionCfg.KeepLastMessages
+	}
+	return 4
+}
+
+// flushCandidates returns the messages compaction is about to summarize
+// (everything but the last keepLast), capped to the most recent
+// maxFlushInputMessages.
+func flushCandidates(history []providers.Message, keepLast int) []providers.Message {
+	if len(history) <= keepLast {
+		return nil
+	}
+	candidates := history[:len(history)-keepLast]
+	if len(candidates) > maxFlushInputMessages {
+		candidates = candidates[len(candidates)-maxFlushInputMessages:]
+	}
+	return candidates
+}
+
+// assessMemoryFlush decides whether the compacted turns are worth a flush
+// LLM call. In auto mode a flush needs MinUserTurns substantive user turns
+// and then either a durable signal (decision, preference, fact, URL, date,
+// personal detail) or a long span (10× MinChars) — the model may still find
+// facts the patterns miss. Returns the reason for logging.
+func assessMemoryFlush(msgs []providers.Message, s *MemoryFlushSettings) (bool, string) {
+	if s.Mode == MemoryFlushModeAlways {
+		return true, "always"
+	}
+
+	userTurns, chars := 0, 0
+	var texts []string
+	for _, m := range msgs {
+		if m.Role != "user" && m.Role != "assistant" {
+			continue
+		}
+		c := strings.TrimSpace(m.Content)
+		if c == "" {
+			continue
+		}
+		chars += len([]rune(c))
+		texts = append(texts, c)
+		if m.Role == "user" && len(strings.Fields(c)) >= 3 {
+			userTurns++
+		}
+	}
+
+	switch {
+	case userTurns < s.MinUserTurns:
+		return false, "too few substantive user turns"
+	case hasDurableSignal(strings.Join(texts, "\n")):
+		return true, "durable signals"
+	case chars < s.MinChars:
+		return false, "too little conversation"
+	case chars >= 10*s.MinChars:
+		return true, "long span"
+	}
+	return false, "no durable signals"
+}
+
+// hasDurableSignal reports whether text contains anything the extractive
+// fallback or a flush turn would typically save.
+func hasDurableSignal(text string) bool {
+	for _, re := range []*regexp.Regexp{reDecision, rePreference, reTechFact, reURL, reDate, reIdentity} {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
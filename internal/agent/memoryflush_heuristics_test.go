@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+func TestResolveMemoryFlushSettings_HeuristicDefaultsAndOverrides(t *testing.T) {
+	s := ResolveMemoryFlushSettings(nil)
+	if s.Mode != MemoryFlushModeAuto || s.MinUserTurns != defaultFlushMinUserTurns || s.MinChars != defaultFlushMinChars {
+		t.Errorf("defaults = %+v", s)
+	}
+
+	s = ResolveMemoryFlushSettings(&config.CompactionConfig{MemoryFlush: &config.MemoryFlushConfig{
+		Mode: "always", MinUserTurns: 5, MinChars: 1000,
+	}})
+	if s.Mode != MemoryFlushModeAlways || s.MinUserTurns != 5 || s.MinChars != 1000 {
+		t.Errorf("overrides = %+v", s)
+	}
+
+	off := false
+	if ResolveMemoryFlushSettings(&config.CompactionConfig{MemoryFlush: &config.MemoryFlushConfig{Enabled: &off}}) != nil {
+		t.Error("disabled flush should resolve to nil")
+	}
+}
+
+func TestFlushCandidates(t *testing.T) {
+	history := make([]providers.Message, 50)
+	for i := range history {
+		history[i] = providers.Message{Role: "user", Content: string(rune('a' + i%26))}
+	}
+	if got := flushCandidates(history[:3], 4); got != nil {
+		t.Errorf("nothing to compact, got %d messages", len(got))
+	}
+	got := flushCandidates(history, 4)
+	if len(got) != maxFlushInputMessages || got[len(got)-1].Content != history[45].Content {
+		t.Errorf("got %d messages ending at %q; want the %d before the kept tail", len(got), got[len(got)-1].Content, maxFlushInputMessages)
+	}
+}
+
+func TestAssessMemoryFlush(t *testing.T) {
+	auto := ResolveMemoryFlushSettings(nil)
+	turns := func(contents ...string) []providers.Message {
+		var msgs []providers.Message
+		for i, c := range contents {
+			role := "user"
+			if i%2 == 1 {
+				role = "assistant"
+			}
+			msgs = append(msgs, providers.Message{Role: role, Content: c})
+		}
+		return msgs
+	}
+	filler := strings.Repeat("some ordinary chit chat about the weather today ", 5)
+
+	cases := []struct {
+		name string
+		msgs []providers.Message
+		want bool
+	}{
+		{"greetings only", turns("hi", "Hello! How can I help?", "thanks", "You're welcome."), false},
+		{"short span", turns("what time is it", "It is noon.", "and the date today", "Monday."), false},
+		{"small talk", turns(filler, filler, filler, filler), false},
+		{"preference", turns(filler, "Noted.", "I prefer replies in Vietnamese from now on please", "Sure."), true},
+		{"decision", turns("which db should we use for the app", filler, "ok we decided to use Postgres for the main store", "Great."), true},
+		{"long span", turns(strings.Repeat(filler, 10), strings.Repeat(filler, 10), "more of the same text here", "ok"), true},
+	}
+	for _, c := range cases {
+		if got, reason := assessMemoryFlush(c.msgs, auto); got != c.want {
+			t.Errorf("%s: assessMemoryFlush = %v (%s), want %v", c.name, got, reason, c.want)
+		}
+	}
+
+	always := *auto
+	always.Mode = MemoryFlushModeAlways
+	if ok, _ := assessMemoryFlush(turns("hi"), &always); !ok {
+		t.Error("always mode must flush regardless of content")
+	}
+}
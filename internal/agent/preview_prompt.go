@@ -30,7 +30,7 @@ type PreviewDeps struct {
 	}
 	SkillsLoader interface {
 		BuildPinnedSummary(ctx context.Context, names []string) string
-		BuildSummary(ctx context.Context, allowList []string) string
+		BuildVersionedSummary(ctx context.Context, allowList []string, versions map[string]int) string
 	}
 	DataDir string // for team workspace path construction
 }
@@ -148,15 +148,19 @@ func BuildPreviewPrompt(ctx context.Context, ag *store.AgentData, mode PromptMod
 		pinnedSummary = deps.SkillsLoader.BuildPinnedSummary(ctx, pinnedSkills)
 	}
 
-	// --- Skills summary (BuildSummary + token count) ---
+	// --- Skills summary (resolved versions + token count) ---
 	var skillsSummary string
 	if deps.SkillsLoader != nil {
 		var skillAllowList []string
+		skillVersions := map[string]int{}
 		if deps.SkillAccessStore != nil {
 			if accessible, err := deps.SkillAccessStore.ListAccessible(ctx, ag.ID, userID); err == nil {
 				skillAllowList = make([]string, 0, len(accessible))
 				for _, sk := range accessible {
 					skillAllowList = append(skillAllowList, sk.Slug)
+					if sk.PinnedVersion > 0 {
+						skillVersions[sk.Slug] = sk.PinnedVersion
+					}
 				}
 			} else {
 				// On error: empty list (no skills). Preview is diagnostic; safer than showing all.
@@ -164,7 +168,7 @@ func BuildPreviewPrompt(ctx context.Context, ag *store.AgentData, mode PromptMod
 			}
 		}
 
-		summary := deps.SkillsLoader.BuildSummary(ctx, skillAllowList)
+		summary := deps.SkillsLoader.BuildVersionedSummary(ctx, skillAllowList, skillVersions)
 		if summary != "" {
 			tokens := tokencount.NewFallbackCounter().Count("claude-3", summary)
 			if tokens <= skillInlineMaxTokens {
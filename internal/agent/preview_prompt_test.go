@@ -77,14 +77,17 @@ func TestBuildPreviewPrompt_SkillAllowList(t *testing.T) {
 	r := BuildPreviewPrompt(context.Background(), ag, PromptFull, "user1", PreviewDeps{
 		SkillsLoader: loader,
 		SkillAccessStore: &mockSkillAccessStore{
-			accessible: []store.SkillInfo{{Slug: "allowed-skill"}},
+			accessible: []store.SkillInfo{{Slug: "allowed-skill"}, {Slug: "pinned-skill", PinnedVersion: 2}},
 		},
 	})
 	if !strings.Contains(r.Prompt, "<available_skills>") {
 		t.Error("expected filtered skills in prompt")
 	}
-	if len(loader.capturedAllow) != 1 || loader.capturedAllow[0] != "allowed-skill" {
-		t.Errorf("expected allow list [allowed-skill], got %v", loader.capturedAllow)
+	if len(loader.capturedAllow) != 2 || loader.capturedAllow[0] != "allowed-skill" {
+		t.Errorf("expected allow list [allowed-skill pinned-skill], got %v", loader.capturedAllow)
+	}
+	if len(loader.capturedVersions) != 1 || loader.capturedVersions["pinned-skill"] != 2 {
+		t.Errorf("expected versions {pinned-skill: 2}, got %v", loader.capturedVersions)
 	}
 }
 
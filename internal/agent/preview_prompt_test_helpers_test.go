@@ -55,15 +55,17 @@ func (t *mockTool) Execute(_ context.Context, _ map[string]any) *tools.Result {
 type mockSkillsLoader struct {
 	pinned        string   // pre-built pinned XML
 	summary       string   // pre-built full summary
-	capturedAllow []string // set by BuildSummary for test assertions
+	capturedAllow    []string       // set by BuildVersionedSummary for test assertions
+	capturedVersions map[string]int // set by BuildVersionedSummary for test assertions
 }
 
 func (m *mockSkillsLoader) BuildPinnedSummary(_ context.Context, _ []string) string {
 	return m.pinned
 }
 
-func (m *mockSkillsLoader) BuildSummary(_ context.Context, allowList []string) string {
+func (m *mockSkillsLoader) BuildVersionedSummary(_ context.Context, allowList []string, versions map[string]int) string {
 	m.capturedAllow = allowList
+	m.capturedVersions = versions
 	return m.summary
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 	"github.com/nextlevelbuilder/goclaw/internal/tracing"
@@ -132,6 +133,15 @@ type ResolverDeps struct {
 	// HookDispatcher fires lifecycle hook events (Issue #875). Nil = noop.
 	HookDispatcher hooks.Dispatcher
 
+	// BudgetGuard rejects runs over per-user/agent token and cost caps. Nil = not enforced.
+	BudgetGuard BudgetGuard
+
+	// SessionLocker serializes runs on a session across gateway replicas. Nil = single instance.
+	SessionLocker SessionLocker
+
+	// Snapshots saves workspace files before tools write them. Nil = disabled.
+	Snapshots *snapshots.Store
+
 	// Vault hook: called when a text file is uploaded by user (nil = no vault registration)
 	OnTextUploaded func(ctx context.Context, path, content string)
 }
@@ -409,12 +419,20 @@ func NewManagedResolver(deps ResolverDeps) ResolverFunc {
 
 		// Filter skills by visibility + agent grants.
 		// Only public skills and explicitly granted internal skills appear in the system prompt.
+		// Grants may pin a skill version; unpinned skills follow the active version.
 		var skillAllowList []string
+		var skillVersions map[string]int
 		if deps.SkillAccessStore != nil {
 			if accessible, err := deps.SkillAccessStore.ListAccessible(ctx, ag.ID, ""); err == nil {
 				skillAllowList = make([]string, 0, len(accessible))
 				for _, sk := range accessible {
 					skillAllowList = append(skillAllowList, sk.Slug)
+					if sk.PinnedVersion > 0 {
+						if skillVersions == nil {
+							skillVersions = make(map[string]int)
+						}
+						skillVersions[sk.Slug] = sk.PinnedVersion
+					}
 				}
 				slog.Debug("skill visibility filter", "agent", agentKey, "accessible", len(skillAllowList))
 			} else {
@@ -483,12 +501,16 @@ func NewManagedResolver(deps ResolverDeps) ResolverFunc {
 			Bus:                    deps.Bus,
 			DomainBus:              deps.DomainBus,
 			HookDispatcher:         deps.HookDispatcher,
+			BudgetGuard:            deps.BudgetGuard,
+			SessionLocker:          deps.SessionLocker,
+			Snapshots:              deps.Snapshots,
 			Sessions:               deps.Sessions,
 			Tools:                  toolsReg,
 			ToolPolicy:             deps.ToolPolicy,
 			AgentToolPolicy:        agentToolPolicyForTeam(agentToolPolicyWithWorkspace(agentToolPolicyWithMCP(ag.ParseToolsConfig(), hasMCPTools), hasTeam), isTeamLead),
 			SkillsLoader:           deps.Skills,
 			SkillAllowList:         skillAllowList,
+			SkillVersions:          skillVersions,
 			HasMemory:              hasMemory,
 			ContextFiles:           contextFiles,
 			EnsureUserProfile:      deps.EnsureUserProfile,
@@ -511,6 +533,7 @@ func NewManagedResolver(deps ResolverDeps) ResolverFunc {
 			TenantAllowedPaths:     tenantAllowedPaths,
 			DisabledTools:          disabledTools,
 			ReasoningConfig:        store.ResolveEffectiveReasoningConfig(providerReasoningDefaults, ag.ParseReasoningConfig()),
+			Sampling:               ag.ParseSamplingConfig(),
 			PromptMode:             PromptMode(ag.ParsePromptMode()),
 			PinnedSkills:           ag.ParsePinnedSkills(),
 			SelfEvolve:             ag.ParseSelfEvolve(),
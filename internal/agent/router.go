@@ -52,6 +52,7 @@ type Router struct {
 	resolver        ResolverFunc // optional: lazy creation from DB
 	ttl             time.Duration
 	traceCollector  TraceCollector // optional: for force-marking aborted traces
+	draining        atomic.Bool    // set on SIGTERM: chat.send/HTTP runs are rejected
 }
 
 func NewRouter() *Router {
@@ -326,6 +327,50 @@ func (r *Router) UnregisterRun(runID string) {
 	r.activeRuns.Delete(runID)
 }
 
+// MarkDraining makes the router refuse new chat.send and HTTP runs during
+// graceful shutdown. Runs already registered continue to completion.
+func (r *Router) MarkDraining() {
+	r.draining.Store(true)
+}
+
+// IsDraining reports whether the gateway is draining for shutdown.
+func (r *Router) IsDraining() bool {
+	return r.draining.Load()
+}
+
+// ActiveRunCount returns the number of registered (in-flight) runs.
+func (r *Router) ActiveRunCount() int {
+	n := 0
+	r.activeRuns.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// WaitForActiveRuns blocks until every registered run has finished or ctx is
+// done, and returns the number of runs still active. Runs registered while
+// waiting (queued channel messages) are waited for too.
+func (r *Router) WaitForActiveRuns(ctx context.Context) int {
+	for {
+		var pending []chan struct{}
+		r.activeRuns.Range(func(_, val any) bool {
+			pending = append(pending, val.(*ActiveRun).Done)
+			return true
+		})
+		if len(pending) == 0 {
+			return 0
+		}
+		for _, done := range pending {
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return r.ActiveRunCount()
+			}
+		}
+	}
+}
+
 // AbortRun cancels a single run by ID using a 2-phase verified abort.
 //
 // Phase 1: CAS state 0→1 (idempotent, prevents double-cancel).
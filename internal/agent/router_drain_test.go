@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitForActiveRuns_ReturnsWhenRunsFinish(t *testing.T) {
+	r := NewRouter()
+	r.MarkDraining()
+	if !r.IsDraining() {
+		t.Fatal("IsDraining should be true after MarkDraining")
+	}
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.RegisterRun(context.Background(), "run-1", "agent:a:ws:direct:u1", "a", cancel)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		r.UnregisterRun("run-1")
+	}()
+
+	ctx, stop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer stop()
+	if left := r.WaitForActiveRuns(ctx); left != 0 {
+		t.Errorf("WaitForActiveRuns left %d runs, want 0", left)
+	}
+}
+
+func TestWaitForActiveRuns_DeadlineReportsRemaining(t *testing.T) {
+	r := NewRouter()
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.RegisterRun(context.Background(), "run-1", "agent:a:ws:direct:u1", "a", cancel)
+
+	ctx, stop := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer stop()
+	if left := r.WaitForActiveRuns(ctx); left != 1 {
+		t.Errorf("WaitForActiveRuns left %d runs, want 1", left)
+	}
+	if n := r.ActiveRunCount(); n != 1 {
+		t.Errorf("ActiveRunCount = %d, want 1", n)
+	}
+}
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const maxCheckpointLabelRunes = 80
+
+// fileWriteTools are the tools whose "path" argument is listed on a
+// checkpoint, so a rewind can report which files were written after it.
+var fileWriteTools = map[string]bool{
+	"write_file": true,
+	"edit":       true,
+}
+
+// checkpointsEnabled reports whether runs in sessionKey record rewind
+// checkpoints. Subagent, cron and heartbeat sessions have no user to rewind.
+func (l *Loop) checkpointsEnabled(sessionKey string) bool {
+	return l.sessions != nil && sessionKey != "" && digestEligible(sessionKey)
+}
+
+// recordRunStartCheckpoint marks the history before the run's user message,
+// so /rewind can undo a whole exchange. New sessions are skipped: rewinding
+// to nothing is what /reset does.
+func (l *Loop) recordRunStartCheckpoint(ctx context.Context, req *RunRequest) {
+	if req.HideInput || !l.checkpointsEnabled(req.SessionKey) {
+		return
+	}
+	if l.tenantID != uuid.Nil {
+		ctx = store.WithTenantID(ctx, l.tenantID)
+	}
+	n := len(l.sessions.GetHistory(ctx, req.SessionKey))
+	if n == 0 {
+		return
+	}
+	store.AddSessionCheckpoint(ctx, l.sessions, req.SessionKey, store.SessionCheckpoint{
+		RunID:    req.RunID,
+		Messages: n,
+		Label:    truncateRunes(strings.Join(strings.Fields(req.Message), " "), maxCheckpointLabelRunes),
+	})
+}
+
+// makeRecordCheckpoint returns the ToolStage hook that records a checkpoint
+// before each tool iteration. ToolStage has already flushed the iteration's
+// messages, so the stored history ends with the assistant tool-call message;
+// the checkpoint keeps everything before it.
+func (l *Loop) makeRecordCheckpoint(req *RunRequest) func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall) {
+	if !l.checkpointsEnabled(req.SessionKey) {
+		return nil
+	}
+	return func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall) {
+		history := l.sessions.GetHistory(ctx, req.SessionKey)
+		last := len(history) - 1
+		if last < 0 || history[last].Role != "assistant" || len(history[last].ToolCalls) == 0 {
+			return
+		}
+		names := make([]string, 0, len(toolCalls))
+		for _, tc := range toolCalls {
+			names = append(names, tc.Name)
+		}
+		store.AddSessionCheckpoint(ctx, l.sessions, req.SessionKey, store.SessionCheckpoint{
+			RunID:     req.RunID,
+			Iteration: state.Iteration + 1,
+			Messages:  last,
+			Label:     truncateRunes(strings.Join(names, ", "), maxCheckpointLabelRunes),
+			Files:     writtenFiles(toolCalls),
+		})
+	}
+}
+
+// writtenFiles returns the paths the file-writing tool calls will touch.
+func writtenFiles(toolCalls []providers.ToolCall) []string {
+	var files []string
+	for _, tc := range toolCalls {
+		if !fileWriteTools[tc.Name] {
+			continue
+		}
+		if path, _ := tc.Arguments["path"].(string); path != "" && !slices.Contains(files, path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
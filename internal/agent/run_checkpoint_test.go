@@ -0,0 +1,83 @@
+package agent
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// checkpointSessionStore keeps metadata so checkpoints can be read back.
+type checkpointSessionStore struct {
+	nopSessionStore
+	meta map[string]string
+}
+
+func (c *checkpointSessionStore) Get(_ context.Context, key string) *store.SessionData {
+	return &store.SessionData{Key: key}
+}
+func (c *checkpointSessionStore) GetSessionMetadata(_ context.Context, _ string) map[string]string {
+	return c.meta
+}
+func (c *checkpointSessionStore) SetSessionMetadata(_ context.Context, _ string, m map[string]string) {
+	if c.meta == nil {
+		c.meta = map[string]string{}
+	}
+	for k, v := range m {
+		c.meta[k] = v
+	}
+}
+
+func TestRecordCheckpoint_BeforeToolIteration(t *testing.T) {
+	writeCall := providers.ToolCall{ID: "c1", Name: "write_file", Arguments: map[string]any{"path": "notes.md"}}
+	ss := &checkpointSessionStore{nopSessionStore: nopSessionStore{history: []providers.Message{
+		{Role: "user", Content: "hi"},
+		{Role: "assistant", Content: "hello"},
+		{Role: "user", Content: "save my notes"},
+		{Role: "assistant", ToolCalls: []providers.ToolCall{writeCall}},
+	}}}
+	loop := &Loop{sessions: ss}
+	req := &RunRequest{SessionKey: "agent:a:telegram:direct:1", RunID: "run-1", Message: "save my notes"}
+
+	record := loop.makeRecordCheckpoint(req)
+	if record == nil {
+		t.Fatal("expected checkpoint hook for a chat session")
+	}
+	record(context.Background(), &pipeline.RunState{Iteration: 0}, []providers.ToolCall{writeCall})
+
+	cps := store.ListSessionCheckpoints(context.Background(), ss, req.SessionKey)
+	if len(cps) != 1 {
+		t.Fatalf("checkpoints = %+v", cps)
+	}
+	cp := cps[0]
+	if cp.Messages != 3 || cp.Iteration != 1 || cp.RunID != "run-1" || cp.Label != "write_file" {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+	if !slices.Equal(cp.Files, []string{"notes.md"}) {
+		t.Errorf("files = %v", cp.Files)
+	}
+}
+
+func TestRecordCheckpoint_SkipsBackgroundSessions(t *testing.T) {
+	loop := &Loop{sessions: &checkpointSessionStore{}}
+	for _, key := range []string{"agent:a:subagent:research", "agent:a:cron:daily", ""} {
+		if loop.makeRecordCheckpoint(&RunRequest{SessionKey: key}) != nil {
+			t.Errorf("%q: expected no checkpoint hook", key)
+		}
+	}
+}
+
+func TestWrittenFiles(t *testing.T) {
+	calls := []providers.ToolCall{
+		{Name: "write_file", Arguments: map[string]any{"path": "a.go"}},
+		{Name: "read_file", Arguments: map[string]any{"path": "b.go"}},
+		{Name: "edit", Arguments: map[string]any{"path": "a.go"}},
+		{Name: "edit", Arguments: map[string]any{"path": "c.go"}},
+	}
+	if got := writtenFiles(calls); !slices.Equal(got, []string{"a.go", "c.go"}) {
+		t.Errorf("writtenFiles = %v", got)
+	}
+}
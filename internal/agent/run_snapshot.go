@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+// shellTools run commands whose file changes snapshots cannot see.
+var shellTools = map[string]bool{
+	"exec":            true,
+	"exec_background": true,
+}
+
+// makeSnapshotFiles returns the ToolStage hook that saves the files
+// write_file/edit are about to change, keyed by run ID, so
+// `goclaw workspace rollback --run <id>` can undo the run's edits. Unlike
+// checkpoints it covers every run kind (cron, heartbeat, subagents). Shell
+// commands are not captured; the run is only flagged so rollback can say so.
+func (l *Loop) makeSnapshotFiles(req *RunRequest) func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall) {
+	if l.snapshots == nil || req.RunID == "" {
+		return nil
+	}
+	// Recurring run IDs (cron:<job>, heartbeat:<agent>) are reused, so each
+	// execution gets its own token and replaces the previous snapshot.
+	exec := uuid.NewString()
+	return func(ctx context.Context, state *pipeline.RunState, toolCalls []providers.ToolCall) {
+		files := writtenFiles(toolCalls)
+		shell := slices.ContainsFunc(toolCalls, func(tc providers.ToolCall) bool { return shellTools[tc.Name] })
+		if len(files) == 0 && !shell {
+			return
+		}
+		ws := tools.ToolWorkspaceFromCtx(ctx)
+		if state.Workspace != nil && state.Workspace.ActivePath != "" {
+			ws = state.Workspace.ActivePath
+		}
+		if ws == "" {
+			ws = l.workspace
+		}
+		err := l.snapshots.Capture(snapshots.RunInfo{
+			RunID:      req.RunID,
+			Exec:       exec,
+			AgentID:    l.id,
+			SessionKey: req.SessionKey,
+			Workspace:  ws,
+			Shell:      shell,
+		}, files)
+		if err != nil {
+			// Non-fatal: the run proceeds without a rollback point for these files.
+			slog.Warn("workspace snapshot failed", "run", req.RunID, "err", err)
+		}
+	}
+}
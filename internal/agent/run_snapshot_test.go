@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/pipeline"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/snapshots"
+)
+
+func TestSnapshotFiles_CapturesBeforeWrite(t *testing.T) {
+	ws := t.TempDir()
+	os.WriteFile(filepath.Join(ws, "notes.md"), []byte("before"), 0644)
+	store := snapshots.NewStore(t.TempDir())
+	loop := &Loop{id: "coder", workspace: ws, snapshots: store}
+
+	snapshot := loop.makeSnapshotFiles(&RunRequest{RunID: "cron:daily"})
+	if snapshot == nil {
+		t.Fatal("expected snapshot hook")
+	}
+	snapshot(context.Background(), &pipeline.RunState{}, []providers.ToolCall{
+		{Name: "read_file", Arguments: map[string]any{"path": "other.md"}},
+		{Name: "write_file", Arguments: map[string]any{"path": "notes.md"}},
+	})
+
+	run, err := store.Get("cron:daily")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.AgentID != "coder" || len(run.Files) != 1 || run.Files[0].Path != filepath.Join(ws, "notes.md") {
+		t.Errorf("run = %+v", run)
+	}
+}
+
+func TestSnapshotFiles_FlagsShellCommands(t *testing.T) {
+	store := snapshots.NewStore(t.TempDir())
+	loop := &Loop{id: "coder", workspace: t.TempDir(), snapshots: store}
+
+	snapshot := loop.makeSnapshotFiles(&RunRequest{RunID: "run-1"})
+	snapshot(context.Background(), &pipeline.RunState{}, []providers.ToolCall{
+		{Name: "exec", Arguments: map[string]any{"command": "rm -rf build"}},
+	})
+
+	res, err := store.Rollback("run-1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.ShellUsed {
+		t.Error("rollback should report that the run ran shell commands")
+	}
+}
+
+func TestSnapshotFiles_Disabled(t *testing.T) {
+	if (&Loop{}).makeSnapshotFiles(&RunRequest{RunID: "r"}) != nil {
+		t.Error("expected no hook without a snapshot store")
+	}
+}
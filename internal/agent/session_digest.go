@@ -0,0 +1,218 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	defaultDigestEveryTurns  = 6
+	sessionDigestTimeout     = 90 * time.Second
+	maxDigestTranscriptChars = 12000
+	maxDigestSummaryRunes    = 600
+	maxDigestTitleRunes      = 100
+)
+
+const sessionDigestSystemPrompt = `You maintain a short digest of a chat conversation so it can be found again later in a session list.
+Given the previous digest (if any) and the newest messages, reply in exactly this format:
+Title: <short title, max 10 words>
+Summary: <2-4 sentences covering the whole conversation so far: topics, decisions, open questions>
+Write in the language of the conversation. No quotes, no markdown.`
+
+// ResolveSessionDigestEveryTurns returns the number of user turns between
+// session digests, or 0 when digests are disabled.
+func ResolveSessionDigestEveryTurns(compaction *config.CompactionConfig) int {
+	if compaction == nil || compaction.SessionDigest == nil {
+		return defaultDigestEveryTurns
+	}
+	sd := compaction.SessionDigest
+	if sd.Enabled != nil && !*sd.Enabled {
+		return 0
+	}
+	if sd.EveryTurns > 0 {
+		return sd.EveryTurns
+	}
+	return defaultDigestEveryTurns
+}
+
+// maybeDigestSession refreshes the session's title and rolling summary in the
+// background once enough user turns have passed since the last digest. The
+// title only fills an empty label, so user renames and first-message titles
+// are kept. Subagent, cron and heartbeat sessions are skipped.
+func (l *Loop) maybeDigestSession(ctx context.Context, sessionKey string) {
+	every := ResolveSessionDigestEveryTurns(l.compactionCfg)
+	if every <= 0 || l.provider == nil || !digestEligible(sessionKey) {
+		return
+	}
+
+	history := l.sessions.GetHistory(ctx, sessionKey)
+	meta := l.sessions.GetSessionMetadata(ctx, sessionKey)
+	compactions := l.sessions.GetCompactionCount(ctx, sessionKey)
+	start := digestStart(meta, len(history), compactions)
+	if countUserTurns(history[start:]) < every {
+		return
+	}
+
+	muI, _ := l.digestMu.LoadOrStore(sessionKey, &sync.Mutex{})
+	sessionMu := muI.(*sync.Mutex)
+	if !sessionMu.TryLock() {
+		return
+	}
+
+	prevDigest := meta[store.SessionMetaDigest]
+	newMsgs := history[start:]
+	msgCount := len(history)
+
+	go func() {
+		defer sessionMu.Unlock()
+		defer safego.Recover(nil, "session", sessionKey)
+
+		dctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sessionDigestTimeout)
+		defer cancel()
+
+		title, summary := l.generateSessionDigest(dctx, prevDigest, newMsgs)
+		if summary == "" {
+			return
+		}
+		// The session may have been deleted while the digest was running.
+		if l.sessions.Get(dctx, sessionKey) == nil {
+			return
+		}
+		l.sessions.SetSessionMetadata(dctx, sessionKey, map[string]string{
+			store.SessionMetaDigest:            summary,
+			store.SessionMetaDigestAt:          time.Now().UTC().Format(time.RFC3339),
+			store.SessionMetaDigestMessages:    strconv.Itoa(msgCount),
+			store.SessionMetaDigestCompactions: strconv.Itoa(compactions),
+		})
+		if title != "" && l.sessions.GetLabel(dctx, sessionKey) == "" {
+			l.sessions.SetLabel(dctx, sessionKey, title)
+		}
+		if err := l.sessions.Save(dctx, sessionKey); err != nil {
+			slog.Warn("session digest: save failed", "session", sessionKey, "error", err)
+		}
+	}()
+}
+
+// generateSessionDigest asks the agent's model for a title and summary.
+// Returns empty strings on error.
+func (l *Loop) generateSessionDigest(ctx context.Context, prevDigest string, msgs []providers.Message) (title, summary string) {
+	var transcript strings.Builder
+	for _, m := range msgs {
+		switch m.Role {
+		case "user":
+			fmt.Fprintf(&transcript, "user: %s\n", m.Content)
+		case "assistant":
+			if content := SanitizeAssistantContent(m.Content); content != "" {
+				fmt.Fprintf(&transcript, "assistant: %s\n", content)
+			}
+		}
+	}
+	text := transcript.String()
+	if len(text) > maxDigestTranscriptChars {
+		// Keep the newest part; earlier turns are covered by the previous digest.
+		start := len(text) - maxDigestTranscriptChars
+		// Don't cut in the middle of a multi-byte rune.
+		for start < len(text) && !utf8.RuneStart(text[start]) {
+			start++
+		}
+		text = text[start:]
+	}
+
+	var prompt strings.Builder
+	if prevDigest != "" {
+		prompt.WriteString("Previous digest: " + prevDigest + "\n\n")
+	}
+	prompt.WriteString("Newest messages:\n" + text)
+
+	resp, err := l.provider.Chat(ctx, providers.ChatRequest{
+		Messages: []providers.Message{
+			{Role: "system", Content: sessionDigestSystemPrompt},
+			{Role: "user", Content: prompt.String()},
+		},
+		Model: l.model,
+		Options: map[string]any{
+			providers.OptMaxTokens:     512,
+			providers.OptTemperature:   0.3,
+			providers.OptThinkingLevel: "off",
+		},
+	})
+	if err != nil {
+		slog.Warn("session digest failed", "agent", l.id, "error", err)
+		return "", ""
+	}
+	return parseSessionDigest(SanitizeAssistantContent(resp.Content))
+}
+
+// parseSessionDigest splits a "Title: …\nSummary: …" reply. A reply without
+// the markers is taken as the summary.
+func parseSessionDigest(content string) (title, summary string) {
+	content = strings.TrimSpace(content)
+	var rest []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case title == "" && hasPrefixFold(trimmed, "title:"):
+			title = strings.TrimSpace(trimmed[len("title:"):])
+		case hasPrefixFold(trimmed, "summary:"):
+			rest = append(rest, strings.TrimSpace(trimmed[len("summary:"):]))
+		case trimmed != "":
+			rest = append(rest, trimmed)
+		}
+	}
+	title = truncateRunes(strings.Trim(title, "\"'`*"), maxDigestTitleRunes)
+	summary = truncateRunes(strings.Join(rest, " "), maxDigestSummaryRunes)
+	return strings.TrimSpace(title), strings.TrimSpace(summary)
+}
+
+// digestStart returns the history index where turns since the last digest
+// begin. After a compaction the stored index is stale, so all kept history
+// counts as new.
+func digestStart(meta map[string]string, historyLen, compactions int) int {
+	if meta[store.SessionMetaDigestCompactions] != strconv.Itoa(compactions) {
+		return 0
+	}
+	n, err := strconv.Atoi(meta[store.SessionMetaDigestMessages])
+	if err != nil || n < 0 || n > historyLen {
+		return 0
+	}
+	return n
+}
+
+func countUserTurns(msgs []providers.Message) int {
+	n := 0
+	for _, m := range msgs {
+		if m.Role == "user" {
+			n++
+		}
+	}
+	return n
+}
+
+func digestEligible(sessionKey string) bool {
+	return !sessions.IsSubagentSession(sessionKey) &&
+		!sessions.IsCronSession(sessionKey) &&
+		!sessions.IsHeartbeatSession(sessionKey)
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
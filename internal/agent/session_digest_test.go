@@ -0,0 +1,173 @@
+package agent
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// digestSessionStore records the metadata and label written by the digest.
+type digestSessionStore struct {
+	nopSessionStore
+	meta  map[string]string
+	label string
+	saved chan struct{}
+}
+
+func (d *digestSessionStore) Get(_ context.Context, key string) *store.SessionData {
+	return &store.SessionData{Key: key}
+}
+func (d *digestSessionStore) GetSessionMetadata(_ context.Context, _ string) map[string]string {
+	return d.meta
+}
+func (d *digestSessionStore) SetSessionMetadata(_ context.Context, _ string, m map[string]string) {
+	if d.meta == nil {
+		d.meta = map[string]string{}
+	}
+	for k, v := range m {
+		d.meta[k] = v
+	}
+}
+func (d *digestSessionStore) GetLabel(_ context.Context, _ string) string { return d.label }
+func (d *digestSessionStore) SetLabel(_ context.Context, _, label string) { d.label = label }
+func (d *digestSessionStore) Save(_ context.Context, _ string) error {
+	d.saved <- struct{}{}
+	return nil
+}
+
+func digestHistory(turns int) []providers.Message {
+	var msgs []providers.Message
+	for i := range turns {
+		msgs = append(msgs,
+			providers.Message{Role: "user", Content: "question " + strconv.Itoa(i)},
+			providers.Message{Role: "assistant", Content: "answer " + strconv.Itoa(i)})
+	}
+	return msgs
+}
+
+func TestMaybeDigestSession_WritesDigestAndFillsEmptyLabel(t *testing.T) {
+	ss := &digestSessionStore{
+		nopSessionStore: nopSessionStore{history: digestHistory(3)},
+		saved:           make(chan struct{}, 1),
+	}
+	prov := &capturingProvider{response: "Title: Trip planning\nSummary: The user is planning a trip to Hanoi."}
+	loop := &Loop{
+		provider:      prov,
+		model:         "m",
+		sessions:      ss,
+		compactionCfg: &config.CompactionConfig{SessionDigest: &config.SessionDigestConfig{EveryTurns: 3}},
+	}
+
+	loop.maybeDigestSession(context.Background(), "agent:a:telegram:direct:1")
+	select {
+	case <-ss.saved:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for digest")
+	}
+
+	if ss.meta[store.SessionMetaDigest] != "The user is planning a trip to Hanoi." {
+		t.Errorf("digest = %q", ss.meta[store.SessionMetaDigest])
+	}
+	if ss.meta[store.SessionMetaDigestMessages] != "6" || ss.meta[store.SessionMetaDigestCompactions] != "0" {
+		t.Errorf("digest cursor = %v", ss.meta)
+	}
+	if ss.label != "Trip planning" {
+		t.Errorf("label = %q", ss.label)
+	}
+
+	// Nothing new since the digest: no further call.
+	loop.maybeDigestSession(context.Background(), "agent:a:telegram:direct:1")
+	time.Sleep(50 * time.Millisecond)
+	if len(prov.captured) != 1 {
+		t.Errorf("provider calls = %d, want 1", len(prov.captured))
+	}
+}
+
+func TestMaybeDigestSession_SkipsBelowCadenceAndSubagents(t *testing.T) {
+	ss := &digestSessionStore{
+		nopSessionStore: nopSessionStore{history: digestHistory(2)},
+		saved:           make(chan struct{}, 1),
+	}
+	prov := &capturingProvider{response: "Summary: x"}
+	loop := &Loop{provider: prov, sessions: ss}
+
+	loop.maybeDigestSession(context.Background(), "agent:a:ws:direct:u")
+	ss.history = digestHistory(10)
+	loop.maybeDigestSession(context.Background(), "agent:a:subagent:research")
+	time.Sleep(50 * time.Millisecond)
+	if len(prov.captured) != 0 {
+		t.Errorf("provider calls = %d, want 0", len(prov.captured))
+	}
+}
+
+func TestGenerateSessionDigest_TrimsOnRuneBoundary(t *testing.T) {
+	prov := &capturingProvider{response: "Summary: x"}
+	loop := &Loop{provider: prov, model: "m"}
+	// "user: " plus 2-byte runes puts the byte cut mid-rune.
+	msgs := []providers.Message{{Role: "user", Content: strings.Repeat("é", 7000)}}
+
+	loop.generateSessionDigest(context.Background(), "", msgs)
+	if len(prov.captured) != 1 {
+		t.Fatalf("provider calls = %d, want 1", len(prov.captured))
+	}
+	for _, m := range prov.captured[0].Messages {
+		if !utf8.ValidString(m.Content) {
+			t.Errorf("%s message is not valid UTF-8", m.Role)
+		}
+	}
+}
+
+func TestDigestStart(t *testing.T) {
+	meta := map[string]string{
+		store.SessionMetaDigestMessages:    "8",
+		store.SessionMetaDigestCompactions: "1",
+	}
+	if got := digestStart(meta, 12, 1); got != 8 {
+		t.Errorf("same compaction: start = %d, want 8", got)
+	}
+	if got := digestStart(meta, 12, 2); got != 0 {
+		t.Errorf("after compaction: start = %d, want 0", got)
+	}
+	if got := digestStart(meta, 4, 1); got != 0 {
+		t.Errorf("history shorter than cursor: start = %d, want 0", got)
+	}
+	if got := digestStart(nil, 4, 0); got != 0 {
+		t.Errorf("no digest yet: start = %d, want 0", got)
+	}
+}
+
+func TestParseSessionDigest(t *testing.T) {
+	title, summary := parseSessionDigest("title: \"Budget review\"\nSummary: Went over Q3 numbers.\nAgreed to cut ads.")
+	if title != "Budget review" || summary != "Went over Q3 numbers. Agreed to cut ads." {
+		t.Errorf("got title=%q summary=%q", title, summary)
+	}
+	title, summary = parseSessionDigest("Just a plain summary.")
+	if title != "" || summary != "Just a plain summary." {
+		t.Errorf("unmarked reply: title=%q summary=%q", title, summary)
+	}
+}
+
+func TestResolveSessionDigestEveryTurns(t *testing.T) {
+	off := false
+	cases := []struct {
+		cfg  *config.CompactionConfig
+		want int
+	}{
+		{nil, defaultDigestEveryTurns},
+		{&config.CompactionConfig{}, defaultDigestEveryTurns},
+		{&config.CompactionConfig{SessionDigest: &config.SessionDigestConfig{EveryTurns: 3}}, 3},
+		{&config.CompactionConfig{SessionDigest: &config.SessionDigestConfig{Enabled: &off, EveryTurns: 3}}, 0},
+	}
+	for i, c := range cases {
+		if got := ResolveSessionDigestEveryTurns(c.cfg); got != c.want {
+			t.Errorf("case %d: got %d, want %d", i, got, c.want)
+		}
+	}
+}
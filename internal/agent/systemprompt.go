@@ -178,14 +178,23 @@ var coreToolSummaries = map[string]string{
 	"write_file":             "Create or overwrite files (set deliver=true to also send as chat attachment)",
 	"send_file":              "Send an EXISTING workspace file as a chat attachment — use to resend/share files; does NOT create or modify the file (use write_file for that)",
 	"list_files":             "List directory contents",
+	"grep":                   "Search file contents by regex (path:line:text, with context lines) — use to locate code before read_file",
+	"glob":                   "Find files by name pattern (e.g. '**/*.go')",
 	"exec":                   "Run shell commands",
+	"exec_background":        "Start a long-running command (dev server, watcher) in the background; returns a process ID",
+	"process_list":           "List background processes or show one's recent output",
+	"process_kill":           "Stop a background process",
 	"memory_search":          "Search indexed memory files (MEMORY.md + memory/*.md)",
 	"memory_get":             "Read specific sections of memory files",
+	"memory_write":           "Save facts to MEMORY.md or memory/<topic>.md under a section heading (dedupes bullets)",
 	"spawn":                  "Spawn a self-clone subagent to handle a task in the background",
 	"web_search":             "Search the web",
 	"web_fetch":              "Fetch and extract content from a URL",
 	"datetime":               "Get current date/time with timezone — use before creating cron jobs",
 	"cron":                   "Manage scheduled jobs and reminders (e.g. 'remind me at 9am', 'check every morning')",
+	"cron_add":               "Schedule a reminder or recurring task from a plain phrase ('every monday at 9am', 'in 20 minutes')",
+	"cron_list":              "List your scheduled jobs and reminders",
+	"cron_remove":            "Cancel a scheduled job or reminder by id",
 	"heartbeat":              "Periodic background monitoring with HEARTBEAT.md. Unlike cron, auto-suppresses 'all OK' via HEARTBEAT_OK",
 	"skill_search":           "Search available skills by keyword (weather, translate, github, etc.)",
 	"skill_manage":           "Create, patch, or delete skills from conversation experience",
@@ -193,8 +202,10 @@ var coreToolSummaries = map[string]string{
 	"use_skill":              "Invoke a skill by name and follow its instructions",
 	"mcp_tool_search":        "Search for available MCP external integration tools by keyword",
 	"browser":                "Browse web pages interactively",
+	"browser_downloads":      "List files the browser downloaded in this conversation",
 	"tts":                    "Convert text to speech audio",
-	"edit":                   "Edit a file by replacing exact text matches",
+	"tts_speak":              "Reply to the current chat with voice notes (streamed chunk by chunk)",
+	"edit":                   "Edit a file by exact text replacement or a unified diff patch",
 	"message":                "Send a PROACTIVE message to another channel/chat — do NOT use this to reply to the user, just respond directly",
 	"sessions_list":          "List sessions for this agent",
 	"session_status":         "Show session status (model, tokens, compaction count)",
@@ -0,0 +1,45 @@
+package agent
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// toolProgressInterval is the minimum gap between tool.progress events of one
+// tool call. A chatty command (e.g. a build printing thousands of lines) would
+// otherwise flood the event bus and every subscribed channel.
+const toolProgressInterval = 250 * time.Millisecond
+
+// newToolProgressReporter returns the ProgressReporter injected into a tool
+// call's context. Messages arriving within toolProgressInterval of the last
+// emitted one are dropped; the next event carries how many were skipped.
+func newToolProgressReporter(emitRun func(AgentEvent), agentID, runID string, tc providers.ToolCall) tools.ProgressReporter {
+	var (
+		mu      sync.Mutex
+		last    time.Time
+		skipped int
+	)
+	return func(message string) {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < toolProgressInterval {
+			skipped++
+			mu.Unlock()
+			return
+		}
+		n := skipped
+		last, skipped = now, 0
+		mu.Unlock()
+
+		emitRun(AgentEvent{
+			Type:    protocol.AgentEventToolProgress,
+			AgentID: agentID,
+			RunID:   runID,
+			Payload: map[string]any{"name": tc.Name, "id": tc.ID, "message": message, "skipped": n},
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func TestToolProgressReporter_Throttles(t *testing.T) {
+	var events []AgentEvent
+	report := newToolProgressReporter(func(e AgentEvent) { events = append(events, e) },
+		"coder", "run-1", providers.ToolCall{ID: "call-1", Name: "exec"})
+
+	report("line 1")
+	report("line 2")
+	report("line 3")
+	if len(events) != 1 {
+		t.Fatalf("emitted %d events within the interval, want 1", len(events))
+	}
+
+	time.Sleep(toolProgressInterval + 20*time.Millisecond)
+	report("line 4")
+	if len(events) != 2 {
+		t.Fatalf("emitted %d events, want 2", len(events))
+	}
+	e := events[1]
+	p := e.Payload.(map[string]any)
+	if e.Type != protocol.AgentEventToolProgress || e.RunID != "run-1" || p["id"] != "call-1" ||
+		p["message"] != "line 4" || p["skipped"] != 2 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
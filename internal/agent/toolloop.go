@@ -40,8 +40,8 @@ var mutatingTools = map[string]bool{
 	"write_file": true, "edit": true, "edit_file": true,
 	"spawn": true, "message": true,
 	"create_image": true, "create_video": true, "create_audio": true,
-	"tts": true, "cron": true, "publish_skill": true,
-	"sessions_send": true,
+	"tts": true, "tts_speak": true, "cron": true, "cron_add": true, "cron_remove": true, "publish_skill": true,
+	"sessions_send": true, "exec_background": true, "process_kill": true,
 }
 
 // teamTasksReadOnlyActions are team_tasks actions that don't indicate real progress.
@@ -38,6 +38,10 @@ var reOutputFormat = regexp.MustCompile(`^[a-z0-9_]+$`)
 // Finding #4: prevents injection via user-controlled language code.
 var reLanguageCode = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
 
+// reVoiceID validates voice IDs before they are placed in the URL path.
+// Covers premade, library and instant/professional voice-clone IDs.
+var reVoiceID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
 // FormatMeta maps an ElevenLabs output_format string to the file extension and
 // MIME type to use in SynthResult. All pcm_* variants use audio/pcm (single MIME
 // per Finding #7 — no per-rate variants). Exported for white-box tests.
@@ -91,6 +95,9 @@ func (p *TTSProvider) Synthesize(ctx context.Context, text string, opts audio.TT
 	if err := ValidateModel(modelID); err != nil {
 		return nil, err
 	}
+	if !reVoiceID.MatchString(voiceID) {
+		return nil, fmt.Errorf("elevenlabs: invalid voice id %q", voiceID)
+	}
 
 	// Telegram opus contract (Finding #11): when opts.Format=="opus", force
 	// ogg/Opus MIME regardless of any user-set output_format param. This
@@ -118,20 +125,10 @@ func (p *TTSProvider) Synthesize(ctx context.Context, text string, opts audio.TT
 		}
 	}
 
-	// Build voice_settings from params, falling back to hardcoded defaults.
-	// Defaults MUST match characterization fixture (stability=0.5, etc.).
-	voiceSettings := map[string]any{
-		"stability":         resolveELFloat(opts.Params, "voice_settings.stability", 0.5),
-		"similarity_boost":  resolveELFloat(opts.Params, "voice_settings.similarity_boost", 0.75),
-		"style":             resolveELFloat(opts.Params, "voice_settings.style", 0.0),
-		"use_speaker_boost": resolveELBool(opts.Params, "voice_settings.use_speaker_boost", true),
-		"speed":             resolveELFloat(opts.Params, "voice_settings.speed", 1.0),
-	}
-
 	body := map[string]any{
 		"text":           text,
 		"model_id":       modelID,
-		"voice_settings": voiceSettings,
+		"voice_settings": voiceSettingsFromParams(opts.Params),
 	}
 
 	// Optional top-level params.
@@ -175,6 +172,19 @@ func (p *TTSProvider) Synthesize(ctx context.Context, text string, opts audio.TT
 	}, nil
 }
 
+// voiceSettingsFromParams builds voice_settings from params, falling back to
+// hardcoded defaults. Defaults MUST match characterization fixture
+// (stability=0.5, etc.). Voice clones usually want a higher similarity_boost.
+func voiceSettingsFromParams(params map[string]any) map[string]any {
+	return map[string]any{
+		"stability":         resolveELFloat(params, "voice_settings.stability", 0.5),
+		"similarity_boost":  resolveELFloat(params, "voice_settings.similarity_boost", 0.75),
+		"style":             resolveELFloat(params, "voice_settings.style", 0.0),
+		"use_speaker_boost": resolveELBool(params, "voice_settings.use_speaker_boost", true),
+		"speed":             resolveELFloat(params, "voice_settings.speed", 1.0),
+	}
+}
+
 // resolveELFloat reads a float param from params map via nested key, falling back to def.
 func resolveELFloat(params map[string]any, key string, def float64) float64 {
 	if params == nil {
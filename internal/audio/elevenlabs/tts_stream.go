@@ -17,7 +17,8 @@ import (
 // so bytes can be consumed as they arrive. Callers MUST Close the returned
 // Audio reader.
 //
-// Resolution order for voice/model: opts > configured defaults.
+// Resolution order for voice/model: opts > configured defaults. voice_settings.*
+// params apply as in Synthesize, so voice-clone tuning carries over.
 // Model IDs are validated against AllowedElevenLabsModels before dispatch —
 // unknown IDs surface an i18n-keyed error without hitting the network.
 func (p *TTSProvider) SynthesizeStream(ctx context.Context, text string, opts audio.TTSOptions) (*audio.StreamResult, error) {
@@ -32,6 +33,9 @@ func (p *TTSProvider) SynthesizeStream(ctx context.Context, text string, opts au
 	if err := ValidateModel(modelID); err != nil {
 		return nil, err
 	}
+	if !reVoiceID.MatchString(voiceID) {
+		return nil, fmt.Errorf("elevenlabs: invalid voice id %q", voiceID)
+	}
 
 	outputFormat, ext, mime := "mp3_44100_128", "mp3", "audio/mpeg"
 	if opts.Format == "opus" {
@@ -39,14 +43,9 @@ func (p *TTSProvider) SynthesizeStream(ctx context.Context, text string, opts au
 	}
 
 	body := map[string]any{
-		"text":     text,
-		"model_id": modelID,
-		"voice_settings": map[string]any{
-			"stability":         0.5,
-			"similarity_boost":  0.75,
-			"style":             0.0,
-			"use_speaker_boost": true,
-		},
+		"text":           text,
+		"model_id":       modelID,
+		"voice_settings": voiceSettingsFromParams(opts.Params),
 	}
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
@@ -192,3 +192,18 @@ func TestSynthesizeStream_RejectsUnknownModel(t *testing.T) {
 		t.Fatal("expected error for unknown model, got nil")
 	}
 }
+
+func TestSynthesizeStream_RejectsInvalidVoiceID(t *testing.T) {
+	t.Parallel()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { hits.Add(1) }))
+	defer srv.Close()
+
+	p := NewTTSProvider(Config{APIKey: "k", BaseURL: srv.URL, ModelID: "eleven_v3"})
+	if _, err := p.SynthesizeStream(context.Background(), "hi", audio.TTSOptions{Voice: "../v1/user"}); err == nil {
+		t.Fatal("expected error for path-like voice id")
+	}
+	if hits.Load() != 0 {
+		t.Error("invalid voice id must not reach the API")
+	}
+}
@@ -45,6 +45,7 @@ type Manager struct {
 	sttChain            []string          // STT fallback order (Phase 4)
 	musicChain          []string          // Music fallback order (Phase 3)
 	channelSTTOverrides map[string][]string // channel → provider key list (Phase 4)
+	sttLanguage         string              // default STT language hint when the caller passes none
 
 	auto      AutoMode
 	mode      Mode
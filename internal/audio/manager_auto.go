@@ -27,6 +27,10 @@ func (m *Manager) MaybeApply(ctx context.Context, text, channel string, isVoiceI
 	if hasTenant && tenantAuto != "" {
 		auto = tenantAuto
 	}
+	// Per-user accessibility: recipients who asked for read-aloud get every reply voiced.
+	if prefs, ok := store.AccessibilityFromCtx(ctx); ok && prefs.AlwaysTTS {
+		auto = AutoAlways
+	}
 
 	if auto == AutoOff {
 		return nil, false
@@ -15,7 +15,11 @@ var defaultSTTChain = []string{"elevenlabs", "proxy"}
 
 // Transcribe tries providers in chain order. Returns first success.
 // Wraps last error with ErrAllSTTProvidersFailed on total failure.
+// An empty opts.Language falls back to the manager default (SetSTTLanguage).
 func (m *Manager) Transcribe(ctx context.Context, in STTInput, opts STTOptions) (*TranscriptResult, error) {
+	if opts.Language == "" {
+		opts.Language = m.sttLanguage
+	}
 	chain := m.resolveSTTChain(ctx)
 	if len(chain) == 0 {
 		return nil, fmt.Errorf("%w: chain is empty", ErrAllSTTProvidersFailed)
@@ -48,6 +52,12 @@ func (m *Manager) SetSTTChain(chain []string) {
 	m.sttChain = chain
 }
 
+// SetSTTLanguage sets the default language hint (BCP-47 / ISO-639-1) used
+// when a caller does not pass one. Empty = let the provider auto-detect.
+func (m *Manager) SetSTTLanguage(lang string) {
+	m.sttLanguage = lang
+}
+
 // RegisterChannelSTT registers STT providers scoped to a specific channel name
 // (e.g. "telegram"). Channel-scoped providers take precedence over the manager
 // default chain when resolveSTTChain detects a matching channel in ctx.
@@ -144,3 +144,34 @@ func TestManager_Transcribe_ChannelOverrideWins(t *testing.T) {
 		t.Errorf("expected channel override result, got %q", res.Text)
 	}
 }
+
+// langSTT records the language hint it was called with.
+type langSTT struct{ got string }
+
+func (l *langSTT) Name() string { return "whisper" }
+func (l *langSTT) Transcribe(_ context.Context, _ STTInput, opts STTOptions) (*TranscriptResult, error) {
+	l.got = opts.Language
+	return &TranscriptResult{Text: "ok", Provider: "whisper"}, nil
+}
+
+// Default language applies only when the caller passes none.
+func TestManager_Transcribe_DefaultLanguage(t *testing.T) {
+	m := newTestManager()
+	p := &langSTT{}
+	m.RegisterSTT(p)
+	m.SetSTTChain([]string{"whisper"})
+	m.SetSTTLanguage("vi")
+
+	if _, err := m.Transcribe(context.Background(), STTInput{}, STTOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.got != "vi" {
+		t.Errorf("expected default language 'vi', got %q", p.got)
+	}
+	if _, err := m.Transcribe(context.Background(), STTInput{}, STTOptions{Language: "en"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.got != "en" {
+		t.Errorf("expected caller language 'en', got %q", p.got)
+	}
+}
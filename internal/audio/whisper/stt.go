@@ -0,0 +1,255 @@
+// Package whisper implements audio.STTProvider for Whisper-family
+// transcription backends: the OpenAI /audio/transcriptions API, Groq's
+// OpenAI-compatible endpoint, and a local whisper.cpp server (/inference).
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+)
+
+// Backend names double as provider names in the STT chain.
+const (
+	BackendOpenAI     = "openai"
+	BackendGroq       = "groq"
+	BackendWhisperCpp = "whisper_cpp"
+)
+
+const (
+	sttMaxBytes       = 25 << 20 // OpenAI and Groq reject uploads over 25 MB
+	sttDefaultTimeout = 60 * time.Second
+)
+
+// backendDefaults holds per-backend base URL and model defaults.
+var backendDefaults = map[string]struct{ baseURL, model string }{
+	BackendOpenAI:     {"https://api.openai.com/v1", "whisper-1"},
+	BackendGroq:       {"https://api.groq.com/openai/v1", "whisper-large-v3-turbo"},
+	BackendWhisperCpp: {"http://127.0.0.1:8080", ""},
+}
+
+// IsBackend reports whether name is a supported Whisper backend.
+func IsBackend(name string) bool {
+	_, ok := backendDefaults[name]
+	return ok
+}
+
+// Config configures a Whisper STT provider. Backend selects the wire format
+// and defaults; empty BaseURL/Model fall back to the backend defaults.
+type Config struct {
+	Backend   string // "openai", "groq" or "whisper_cpp"
+	APIKey    string // not required for whisper_cpp
+	BaseURL   string
+	Model     string // ignored by whisper.cpp (the server loads one model)
+	TimeoutMs int
+}
+
+// STTProvider transcribes audio via a Whisper-family backend.
+type STTProvider struct {
+	cfg Config
+}
+
+// NewSTTProvider returns a Whisper STT provider. Unknown backends are treated
+// as OpenAI-compatible.
+func NewSTTProvider(cfg Config) *STTProvider {
+	if cfg.Backend == "" {
+		cfg.Backend = BackendOpenAI
+	}
+	def, ok := backendDefaults[cfg.Backend]
+	if !ok {
+		def = backendDefaults[BackendOpenAI]
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = def.baseURL
+	}
+	if cfg.Model == "" {
+		cfg.Model = def.model
+	}
+	return &STTProvider{cfg: cfg}
+}
+
+// Name returns the backend name, which is the key used in the STT chain.
+func (p *STTProvider) Name() string { return p.cfg.Backend }
+
+// Transcribe uploads the audio as multipart/form-data and returns the text.
+// opts.Language is reduced to its ISO-639-1 primary subtag ("vi-VN" → "vi"),
+// which is what Whisper expects.
+func (p *STTProvider) Transcribe(ctx context.Context, in audio.STTInput, opts audio.STTOptions) (*audio.TranscriptResult, error) {
+	filePath, cleanup, err := resolveFilePath(in)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: resolve input: %w", p.cfg.Backend, err)
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: stat file: %w", p.cfg.Backend, err)
+	}
+	if info.Size() > sttMaxBytes {
+		return nil, fmt.Errorf("%s stt: file too large (%d bytes, max %d)", p.cfg.Backend, info.Size(), sttMaxBytes)
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fields := map[string]string{"response_format": "verbose_json"}
+	if p.cfg.Backend == BackendWhisperCpp {
+		fields["response_format"] = "json"
+	} else {
+		model := opts.ModelID
+		if model == "" {
+			model = p.cfg.Model
+		}
+		fields["model"] = model
+	}
+	if lang := languageHint(opts.Language); lang != "" {
+		fields["language"] = lang
+	}
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			return nil, fmt.Errorf("%s stt: write %s field: %w", p.cfg.Backend, k, err)
+		}
+	}
+
+	filename := in.Filename
+	if filename == "" {
+		filename = filepath.Base(filePath)
+	}
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: create form file: %w", p.cfg.Backend, err)
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: open file: %w", p.cfg.Backend, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(fw, f); err != nil {
+		return nil, fmt.Errorf("%s stt: write file bytes: %w", p.cfg.Backend, err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("%s stt: close multipart writer: %w", p.cfg.Backend, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint(), &buf)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: create request: %w", p.cfg.Backend, err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	timeout := sttDefaultTimeout
+	if opts.TimeoutMs > 0 {
+		timeout = time.Duration(opts.TimeoutMs) * time.Millisecond
+	} else if p.cfg.TimeoutMs > 0 {
+		timeout = time.Duration(p.cfg.TimeoutMs) * time.Millisecond
+	}
+	hc := &http.Client{Timeout: timeout}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s stt: http request: %w", p.cfg.Backend, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s stt: API error %d: %s", p.cfg.Backend, resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Text     string  `json:"text"`
+		Language string  `json:"language"`
+		Duration float64 `json:"duration"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%s stt: parse response: %w", p.cfg.Backend, err)
+	}
+	lang := result.Language
+	if lang == "" {
+		lang = opts.Language
+	}
+	return &audio.TranscriptResult{
+		Text:     strings.TrimSpace(result.Text),
+		Language: lang,
+		Duration: result.Duration,
+		Provider: p.cfg.Backend,
+	}, nil
+}
+
+// endpoint returns the transcription URL for the configured backend.
+func (p *STTProvider) endpoint() string {
+	base := strings.TrimRight(p.cfg.BaseURL, "/")
+	if p.cfg.Backend == BackendWhisperCpp {
+		return base + "/inference"
+	}
+	return base + "/audio/transcriptions"
+}
+
+// languageHint reduces a BCP-47 tag to the lowercase primary language subtag.
+func languageHint(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, "-_"); i > 0 {
+		tag = tag[:i]
+	}
+	return strings.ToLower(tag)
+}
+
+// resolveFilePath returns a usable file path. When only Bytes is set, writes a
+// temp file (0600) and returns a cleanup func to remove it.
+func resolveFilePath(in audio.STTInput) (path string, cleanup func(), err error) {
+	if in.FilePath != "" {
+		return in.FilePath, nil, nil
+	}
+	if len(in.Bytes) == 0 {
+		return "", nil, fmt.Errorf("neither FilePath nor Bytes provided")
+	}
+	f, err := os.CreateTemp("", "stt-whisper-*"+extFromMime(in.MimeType))
+	if err != nil {
+		return "", nil, fmt.Errorf("create temp file: %w", err)
+	}
+	if err := os.Chmod(f.Name(), 0600); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", nil, fmt.Errorf("chmod temp file: %w", err)
+	}
+	if _, err := f.Write(in.Bytes); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", nil, fmt.Errorf("write temp file: %w", err)
+	}
+	f.Close()
+	return f.Name(), func() { os.Remove(f.Name()) }, nil
+}
+
+// extFromMime returns a file extension for a MIME type. Whisper backends
+// sniff the container from the filename, so the extension matters.
+func extFromMime(mime string) string {
+	switch strings.TrimSpace(strings.Split(mime, ";")[0]) {
+	case "audio/ogg", "audio/opus":
+		return ".ogg"
+	case "audio/mpeg", "audio/mp3":
+		return ".mp3"
+	case "audio/wav", "audio/wave", "audio/x-wav":
+		return ".wav"
+	case "audio/mp4", "audio/m4a", "audio/x-m4a":
+		return ".m4a"
+	case "audio/webm":
+		return ".webm"
+	case "audio/flac":
+		return ".flac"
+	default:
+		return ".ogg"
+	}
+}
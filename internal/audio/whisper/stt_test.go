@@ -0,0 +1,94 @@
+package whisper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+)
+
+func TestSTTProvider_OpenAICompatible(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/openai/v1/audio/transcriptions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer gsk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if got := r.FormValue("model"); got != "whisper-large-v3-turbo" {
+			t.Errorf("model = %q", got)
+		}
+		if got := r.FormValue("language"); got != "vi" {
+			t.Errorf("language = %q, want primary subtag", got)
+		}
+		if _, fh, err := r.FormFile("file"); err != nil || fh.Filename != "voice.ogg" {
+			t.Errorf("file = %v, %v", fh, err)
+		}
+		w.Write([]byte(`{"text":" xin chào ","language":"vietnamese","duration":1.5}`))
+	}))
+	defer srv.Close()
+
+	p := NewSTTProvider(Config{Backend: BackendGroq, APIKey: "gsk-test", BaseURL: srv.URL + "/openai/v1"})
+	res, err := p.Transcribe(context.Background(),
+		audio.STTInput{Bytes: []byte("fake-ogg"), MimeType: "audio/ogg", Filename: "voice.ogg"},
+		audio.STTOptions{Language: "vi-VN"})
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	if res.Text != "xin chào" || res.Provider != "groq" || res.Duration != 1.5 {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestSTTProvider_WhisperCpp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inference" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("whisper.cpp request should not carry auth")
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if r.FormValue("model") != "" || r.FormValue("response_format") != "json" {
+			t.Errorf("unexpected form: %v", r.MultipartForm.Value)
+		}
+		w.Write([]byte(`{"text":"hello world"}`))
+	}))
+	defer srv.Close()
+
+	p := NewSTTProvider(Config{Backend: BackendWhisperCpp, BaseURL: srv.URL})
+	res, err := p.Transcribe(context.Background(), audio.STTInput{Bytes: []byte("x"), MimeType: "audio/wav"}, audio.STTOptions{Language: "en"})
+	if err != nil {
+		t.Fatalf("Transcribe: %v", err)
+	}
+	if res.Text != "hello world" || res.Language != "en" || res.Provider != "whisper_cpp" {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestSTTProvider_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":{"message":"invalid api key"}}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	p := NewSTTProvider(Config{Backend: BackendOpenAI, APIKey: "bad", BaseURL: srv.URL})
+	if _, err := p.Transcribe(context.Background(), audio.STTInput{Bytes: []byte("x")}, audio.STTOptions{}); err == nil {
+		t.Fatal("expected error on 401")
+	}
+}
+
+func TestLanguageHint(t *testing.T) {
+	for in, want := range map[string]string{"": "", "en": "en", "vi-VN": "vi", "zh_Hans": "zh", " PT-br ": "pt"} {
+		if got := languageHint(in); got != want {
+			t.Errorf("languageHint(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -52,7 +52,7 @@ func ValidateTableRegistry(ctx context.Context, db *sql.DB) []string {
 		"activity_logs": true, "embedding_cache": true,
 		"pairing_requests": true, "paired_devices": true,
 		"channel_pending_messages": true, "cron_run_logs": true,
-		"team_user_grants": true,
+		"team_user_grants": true, "session_leases": true,
 	}
 
 	var warnings []string
@@ -0,0 +1,55 @@
+package billing
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// WriteCSV writes the report as CSV: one row per invoice line for GroupByLine,
+// otherwise one row per tenant, user or agent subtotal. Every row carries the
+// month and currency so exports from several months can be concatenated.
+func (r *Report) WriteCSV(w io.Writer, groupBy string) error {
+	cw := csv.NewWriter(w)
+	if groupBy == GroupByLine || groupBy == "" {
+		if err := cw.Write([]string{"month", "tenant_id", "user_id", "agent_id", "agent_key", "provider", "model",
+			"calls", "input_tokens", "output_tokens", "recorded_cost", "cost", "currency"}); err != nil {
+			return err
+		}
+		for _, l := range r.Lines {
+			if err := cw.Write([]string{r.Month, l.TenantID.String(), l.UserID, uuidString(l.AgentID), l.AgentKey,
+				l.Provider, l.Model, strconv.Itoa(l.Calls), formatInt(l.InputTokens), formatInt(l.OutputTokens),
+				formatCost(l.RecordedCost), formatCost(l.Cost), r.Currency}); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := cw.Write([]string{"month", "tenant_id", "user_id", "agent_id", "agent_key",
+			"calls", "input_tokens", "output_tokens", "cost", "currency"}); err != nil {
+			return err
+		}
+		for _, g := range r.Groups(groupBy) {
+			if err := cw.Write([]string{r.Month, g.TenantID.String(), g.UserID, uuidString(g.AgentID), g.AgentKey,
+				strconv.Itoa(g.Calls), formatInt(g.InputTokens), formatInt(g.OutputTokens),
+				formatCost(g.Cost), r.Currency}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatInt(n int64) string { return strconv.FormatInt(n, 10) }
+
+// formatCost keeps 6 decimals: per-call LLM costs are often fractions of a cent.
+func formatCost(c float64) string { return strconv.FormatFloat(c, 'f', 6, 64) }
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
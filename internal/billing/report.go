@@ -0,0 +1,205 @@
+// Package billing turns aggregated LLM usage into monthly charge-back reports
+// (per tenant, user and agent) with optional per-model price overrides.
+package billing
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/internal/tracing"
+)
+
+// DefaultCurrency labels exports when billing.currency is unset.
+const DefaultCurrency = "USD"
+
+// Grouping dimensions accepted by Report.Groups.
+const (
+	GroupByLine   = "line"
+	GroupByTenant = "tenant"
+	GroupByUser   = "user"
+	GroupByAgent  = "agent"
+)
+
+// Line is one invoice line: usage of a single provider/model by a user through an agent.
+type Line struct {
+	store.BillingUsageRow
+	AgentKey     string  `json:"agent_key,omitempty"`
+	RecordedCost float64 `json:"recorded_cost"`
+	Repriced     bool    `json:"repriced,omitempty"` // Cost comes from a price override
+}
+
+// Group is a subtotal for one tenant, user or agent.
+type Group struct {
+	TenantID     uuid.UUID  `json:"tenant_id,omitzero"`
+	UserID       string     `json:"user_id,omitempty"`
+	AgentID      *uuid.UUID `json:"agent_id,omitempty"`
+	AgentKey     string     `json:"agent_key,omitempty"`
+	Calls        int        `json:"calls"`
+	InputTokens  int64      `json:"input_tokens"`
+	OutputTokens int64      `json:"output_tokens"`
+	Cost         float64    `json:"cost"`
+}
+
+// Report is a monthly billing export.
+type Report struct {
+	Month    string    `json:"month"` // "2025-01"
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Currency string    `json:"currency"`
+	Total    Group     `json:"total"`
+	Tenants  []Group   `json:"tenants"`
+	Users    []Group   `json:"users"`
+	Agents   []Group   `json:"agents"`
+	Lines    []Line    `json:"lines"`
+}
+
+// Options configures report building.
+type Options struct {
+	Currency       string
+	PriceOverrides map[string]*config.ModelPricing
+	AgentKeys      map[uuid.UUID]string // optional agent ID → key for readable exports
+}
+
+// ParseMonth parses "YYYY-MM" into the UTC [from, to) range of that month.
+func ParseMonth(month string) (from, to time.Time, err error) {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid month %q (want YYYY-MM)", month)
+	}
+	from = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// Build prices usage rows and computes per-tenant, per-user and per-agent subtotals.
+// Rows whose model has a price override are repriced from their token counts;
+// all others keep the cost recorded at call time.
+func Build(month string, rows []store.BillingUsageRow, opts Options) (*Report, error) {
+	from, to, err := ParseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+	r := &Report{
+		Month:    month,
+		From:     from,
+		To:       to,
+		Currency: opts.Currency,
+		Tenants:  []Group{},
+		Users:    []Group{},
+		Agents:   []Group{},
+		Lines:    make([]Line, 0, len(rows)),
+	}
+	if r.Currency == "" {
+		r.Currency = DefaultCurrency
+	}
+
+	tenants := map[uuid.UUID]*Group{}
+	users := map[string]*Group{}
+	agents := map[string]*Group{}
+
+	for _, row := range rows {
+		line := Line{BillingUsageRow: row, RecordedCost: row.Cost}
+		if row.AgentID != nil {
+			line.AgentKey = opts.AgentKeys[*row.AgentID]
+		}
+		if p := tracing.LookupPricing(opts.PriceOverrides, row.Provider, row.Model); p != nil {
+			line.Cost = tracing.CalculateCost(p, &providers.Usage{
+				PromptTokens:     int(row.InputTokens),
+				CompletionTokens: int(row.OutputTokens),
+			})
+			line.Repriced = true
+		}
+		r.Lines = append(r.Lines, line)
+
+		r.Total.add(line)
+		groupFor(tenants, row.TenantID, func() *Group {
+			return &Group{TenantID: row.TenantID}
+		}).add(line)
+		groupFor(users, row.TenantID.String()+"/"+row.UserID, func() *Group {
+			return &Group{TenantID: row.TenantID, UserID: row.UserID}
+		}).add(line)
+		agentKey := ""
+		if row.AgentID != nil {
+			agentKey = row.AgentID.String()
+		}
+		groupFor(agents, row.TenantID.String()+"/"+agentKey, func() *Group {
+			return &Group{TenantID: row.TenantID, AgentID: row.AgentID, AgentKey: line.AgentKey}
+		}).add(line)
+	}
+
+	r.Tenants = sortedGroups(tenants)
+	r.Users = sortedGroups(users)
+	r.Agents = sortedGroups(agents)
+	return r, nil
+}
+
+// ValidGroupBy reports whether groupBy is a supported export dimension.
+func ValidGroupBy(groupBy string) bool {
+	switch groupBy {
+	case GroupByLine, GroupByTenant, GroupByUser, GroupByAgent:
+		return true
+	}
+	return false
+}
+
+// Groups returns the subtotals for a grouping dimension (nil for GroupByLine).
+func (r *Report) Groups(groupBy string) []Group {
+	switch groupBy {
+	case GroupByTenant:
+		return r.Tenants
+	case GroupByUser:
+		return r.Users
+	case GroupByAgent:
+		return r.Agents
+	}
+	return nil
+}
+
+func (g *Group) add(l Line) {
+	g.Calls += l.Calls
+	g.InputTokens += l.InputTokens
+	g.OutputTokens += l.OutputTokens
+	g.Cost += l.Cost
+}
+
+func groupFor[K comparable](m map[K]*Group, key K, create func() *Group) *Group {
+	g, ok := m[key]
+	if !ok {
+		g = create()
+		m[key] = g
+	}
+	return g
+}
+
+// sortedGroups orders subtotals by cost (highest first) for stable, useful output.
+func sortedGroups[K comparable](m map[K]*Group) []Group {
+	out := make([]Group, 0, len(m))
+	for _, g := range m {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Cost != out[j].Cost {
+			return out[i].Cost > out[j].Cost
+		}
+		if out[i].TenantID != out[j].TenantID {
+			return out[i].TenantID.String() < out[j].TenantID.String()
+		}
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return agentSortKey(out[i]) < agentSortKey(out[j])
+	})
+	return out
+}
+
+func agentSortKey(g Group) string {
+	if g.AgentKey != "" || g.AgentID == nil {
+		return g.AgentKey
+	}
+	return g.AgentID.String()
+}
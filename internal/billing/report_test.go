@@ -0,0 +1,105 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestParseMonth(t *testing.T) {
+	from, to, err := ParseMonth("2025-12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !from.Equal(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("range = [%v, %v)", from, to)
+	}
+	for _, bad := range []string{"", "2025-13", "2025/01", "Jan 2025"} {
+		if _, _, err := ParseMonth(bad); err == nil {
+			t.Errorf("ParseMonth(%q) should fail", bad)
+		}
+	}
+}
+
+func TestBuild_OverridesAndSubtotals(t *testing.T) {
+	tenantA, tenantB := uuid.New(), uuid.New()
+	agent := uuid.New()
+	rows := []store.BillingUsageRow{
+		{TenantID: tenantA, UserID: "alice", AgentID: &agent, Provider: "openai", Model: "gpt-4o",
+			InputTokens: 1_000_000, OutputTokens: 500_000, Cost: 9.99, Calls: 10},
+		{TenantID: tenantA, UserID: "bob", AgentID: &agent, Provider: "anthropic", Model: "claude",
+			InputTokens: 100, OutputTokens: 100, Cost: 1.5, Calls: 2},
+		{TenantID: tenantB, UserID: "alice", Provider: "anthropic", Model: "claude",
+			InputTokens: 100, OutputTokens: 100, Cost: 0.5, Calls: 1},
+	}
+	r, err := Build("2025-01", rows, Options{
+		PriceOverrides: map[string]*config.ModelPricing{
+			"openai/gpt-4o": {InputPerMillion: 2, OutputPerMillion: 8},
+		},
+		AgentKeys: map[uuid.UUID]string{agent: "support"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Currency != DefaultCurrency {
+		t.Errorf("currency = %q, want %q", r.Currency, DefaultCurrency)
+	}
+	if l := r.Lines[0]; !l.Repriced || l.Cost != 6 || l.RecordedCost != 9.99 || l.AgentKey != "support" {
+		t.Errorf("overridden line = %+v", l)
+	}
+	if l := r.Lines[1]; l.Repriced || l.Cost != 1.5 {
+		t.Errorf("recorded-cost line = %+v", l)
+	}
+	if r.Total.Calls != 13 || math.Abs(r.Total.Cost-8) > 1e-9 {
+		t.Errorf("total = %+v", r.Total)
+	}
+	if len(r.Tenants) != 2 || r.Tenants[0].TenantID != tenantA || r.Tenants[0].Cost != 7.5 {
+		t.Errorf("tenants = %+v", r.Tenants)
+	}
+	// Users are tenant-scoped: alice in tenant A and tenant B are separate rows.
+	if len(r.Users) != 3 {
+		t.Errorf("users = %+v", r.Users)
+	}
+	if len(r.Agents) != 2 || r.Agents[0].AgentKey != "support" || r.Agents[0].Calls != 12 {
+		t.Errorf("agents = %+v", r.Agents)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	tenant := uuid.New()
+	r, err := Build("2025-01", []store.BillingUsageRow{
+		{TenantID: tenant, UserID: "alice", Provider: "openai", Model: "gpt-4o", InputTokens: 10, OutputTokens: 5, Cost: 0.25, Calls: 1},
+	}, Options{Currency: "EUR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for groupBy, wantCols := range map[string]int{GroupByLine: 13, GroupByTenant: 10} {
+		var buf bytes.Buffer
+		if err := r.WriteCSV(&buf, groupBy); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 || len(records[1]) != wantCols {
+			t.Fatalf("%s: records = %v", groupBy, records)
+		}
+		last := records[1][wantCols-1]
+		if last != "EUR" || records[1][1] != tenant.String() {
+			t.Errorf("%s: row = %v", groupBy, records[1])
+		}
+		if cost := records[1][wantCols-2]; cost != "0.250000" {
+			t.Errorf("%s: cost = %q", groupBy, cost)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// EditableFiles are the agent-level context files exposed for editing via the
+// agents.files.* RPCs and `goclaw agent files`. TOOLS.md excluded — not applicable.
+var EditableFiles = []string{
+	AgentsFile, SoulFile, IdentityFile,
+	UserFile, UserPredefinedFile, CapabilitiesFile,
+	BootstrapFile, MemoryJSONFile,
+	HeartbeatFile,
+}
+
+// IsEditableFile reports whether name is one of EditableFiles.
+func IsEditableFile(name string) bool {
+	return slices.Contains(EditableFiles, name)
+}
+
+// ReadWorkspaceFile reads an editable context file from a workspace directory
+// (standalone mode, where files live on disk instead of agent_context_files).
+// A missing file is returned with Missing=true.
+func ReadWorkspaceFile(workspaceDir, name string) (File, error) {
+	if !IsEditableFile(name) {
+		return File{}, fmt.Errorf("file not editable: %s", name)
+	}
+	return loadFile(workspaceDir, name), nil
+}
+
+// WriteWorkspaceFile atomically replaces an editable context file in a
+// workspace directory, creating the directory if needed.
+func WriteWorkspaceFile(workspaceDir, name, content string) error {
+	if !IsEditableFile(name) {
+		return fmt.Errorf("file not editable: %s", name)
+	}
+	if err := os.MkdirAll(workspaceDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(workspaceDir, "."+name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(workspaceDir, name))
+}
@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceFileRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "agents", "coder") // created on first write
+
+	f, err := ReadWorkspaceFile(dir, SoulFile)
+	if err != nil || !f.Missing {
+		t.Fatalf("ReadWorkspaceFile before write = %+v, %v", f, err)
+	}
+
+	if err := WriteWorkspaceFile(dir, SoulFile, "calm and precise"); err != nil {
+		t.Fatal(err)
+	}
+	f, err = ReadWorkspaceFile(dir, SoulFile)
+	if err != nil || f.Missing || f.Content != "calm and precise" {
+		t.Fatalf("ReadWorkspaceFile after write = %+v, %v", f, err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only %s in workspace, got %d entries", SoulFile, len(entries))
+	}
+}
+
+func TestWorkspaceFileRejectsNonEditable(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{ToolsFile, "../SOUL.md", "notes.txt"} {
+		if err := WriteWorkspaceFile(dir, name, "x"); err == nil {
+			t.Errorf("WriteWorkspaceFile(%q) should fail", name)
+		}
+		if _, err := ReadWorkspaceFile(dir, name); err == nil {
+			t.Errorf("ReadWorkspaceFile(%q) should fail", name)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
 )
 
 // MessageBus routes messages between channels and the agent runtime,
@@ -34,12 +36,18 @@ func New() *MessageBus {
 // PublishInbound queues an inbound message from a channel.
 // Blocks if the inbound buffer is full.
 func (mb *MessageBus) PublishInbound(msg InboundMessage) {
+	if msg.RequestID == "" {
+		msg.RequestID = requestid.New()
+	}
 	mb.inbound <- msg
 }
 
 // TryPublishInbound attempts to queue an inbound message without blocking.
 // Returns false if the inbound buffer is full (message dropped).
 func (mb *MessageBus) TryPublishInbound(msg InboundMessage) bool {
+	if msg.RequestID == "" {
+		msg.RequestID = requestid.New()
+	}
 	select {
 	case mb.inbound <- msg:
 		return true
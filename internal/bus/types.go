@@ -31,6 +31,7 @@ type InboundMessage struct {
 	HistoryLimit int               `json:"history_limit,omitempty"` // max turns to keep in context (0=unlimited, from channel config)
 	ToolAllow    []string          `json:"tool_allow,omitempty"`    // per-group tool allow list (nil = no restriction)
 	Metadata     map[string]string `json:"metadata,omitempty"`
+	RequestID    string            `json:"request_id,omitempty"` // correlation ID (assigned on publish when empty)
 }
 
 // OutboundMessage represents a message to be sent to a channel.
@@ -43,6 +44,21 @@ type OutboundMessage struct {
 	TenantID        uuid.UUID         `json:"tenant_id,omitempty"`          // tenant scope for per-tenant TTS
 	AgentID         uuid.UUID         `json:"agent_id,omitempty"`           // agent scope for per-agent TTS voice override
 	AgentOtherConfig []byte           `json:"agent_other_config,omitempty"` // agent's other_config for TTS voice/model
+	Accessibility    *AccessibilityPrefs `json:"accessibility,omitempty"`   // recipient's read-aloud/formatting preferences (nil = defaults)
+}
+
+// AccessibilityPrefs are per-user delivery preferences, applied by the channel
+// formatting layer (channels.ApplyAccessibility) and TTS auto-apply.
+// The zero value changes nothing.
+type AccessibilityPrefs struct {
+	AlwaysTTS        bool `json:"always_tts,omitempty"`         // voice every reply regardless of tts.auto
+	SimpleFormatting bool `json:"simple_formatting,omitempty"`  // flatten tables, drop emojis
+	MaxMessageLength int  `json:"max_message_length,omitempty"` // cap replies at N characters (0 = unlimited)
+}
+
+// IsZero reports whether the preferences leave delivery unchanged.
+func (p *AccessibilityPrefs) IsZero() bool {
+	return p == nil || (!p.AlwaysTTS && !p.SimpleFormatting && p.MaxMessageLength <= 0)
 }
 
 // MediaAttachment represents a media file to be sent with a message.
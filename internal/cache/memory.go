@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 )
 
 // entry wraps a cached value with expiration and creation metadata.
@@ -56,7 +58,7 @@ func NewInMemoryCache[V any](opts ...CacheOption[V]) *InMemoryCache[V] {
 	if c.sweepInterval > 0 {
 		ctx, cancel := context.WithCancel(context.Background())
 		c.cancel = cancel
-		go c.sweepLoop(ctx)
+		go safego.Supervise(ctx, "cache_sweep", c.sweepLoop)
 	}
 	return c
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 )
 
 // agentAccessEntry caches agent access check results.
@@ -35,20 +36,24 @@ const (
 
 // NewPermissionCache creates a new permission cache with periodic sweep
 // goroutines for all three inner caches. Call Close() on gateway shutdown to
-// stop the sweep goroutines.
+// stop the sweep goroutines. The runtime profile may lower the size cap.
 func NewPermissionCache() *PermissionCache {
+	maxSize := permissionCacheMaxSize
+	if n := runtimeprofile.Current().PermissionCacheEntries; n > 0 {
+		maxSize = n
+	}
 	return &PermissionCache{
 		tenantRole: NewInMemoryCache[string](
 			WithSweepInterval[string](permissionCacheSweepInterval),
-			WithMaxSize[string](permissionCacheMaxSize),
+			WithMaxSize[string](maxSize),
 		),
 		agentAccess: NewInMemoryCache[agentAccessEntry](
 			WithSweepInterval[agentAccessEntry](permissionCacheSweepInterval),
-			WithMaxSize[agentAccessEntry](permissionCacheMaxSize),
+			WithMaxSize[agentAccessEntry](maxSize),
 		),
 		teamAccess: NewInMemoryCache[bool](
 			WithSweepInterval[bool](permissionCacheSweepInterval),
-			WithMaxSize[bool](permissionCacheMaxSize),
+			WithMaxSize[bool](maxSize),
 		),
 	}
 }
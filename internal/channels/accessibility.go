@@ -0,0 +1,151 @@
+package channels
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+)
+
+// accessibilityEllipsis marks replies shortened to the user's max message length.
+const accessibilityEllipsis = "…"
+
+// mdTableSepRe matches a markdown table separator row, e.g. "| --- | :---: |".
+var mdTableSepRe = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+
+// ApplyAccessibility rewrites outbound text according to the recipient's
+// accessibility preferences. Simplified formatting flattens markdown tables
+// into "Header: value" lines and drops emojis, which screen readers announce
+// verbosely; a max message length shortens the reply at a sentence or word
+// boundary. A nil or zero prefs returns text unchanged.
+func ApplyAccessibility(text string, prefs *bus.AccessibilityPrefs) string {
+	if prefs.IsZero() || text == "" {
+		return text
+	}
+	if prefs.SimpleFormatting {
+		text = flattenMarkdownTables(text)
+		text = stripEmojis(text)
+	}
+	if prefs.MaxMessageLength > 0 {
+		text = truncateAtBoundary(text, prefs.MaxMessageLength)
+	}
+	return text
+}
+
+// flattenMarkdownTables converts each markdown table into one line per row:
+// "Header1: cell1; Header2: cell2". Lines outside tables are left alone.
+func flattenMarkdownTables(text string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && isTableRow(lines[i]) && mdTableSepRe.MatchString(lines[i+1]) {
+			headers := splitTableRow(lines[i])
+			i += 2
+			for ; i < len(lines) && isTableRow(lines[i]); i++ {
+				out = append(out, formatTableRow(headers, splitTableRow(lines[i])))
+			}
+			i--
+			continue
+		}
+		out = append(out, lines[i])
+	}
+	return strings.Join(out, "\n")
+}
+
+func isTableRow(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "|") && strings.Count(trimmed, "|") >= 2
+}
+
+func splitTableRow(line string) []string {
+	trimmed := strings.Trim(strings.TrimSpace(line), "|")
+	cells := strings.Split(trimmed, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+func formatTableRow(headers, cells []string) string {
+	parts := make([]string, 0, len(cells))
+	for i, cell := range cells {
+		if cell == "" {
+			continue
+		}
+		if i < len(headers) && headers[i] != "" {
+			parts = append(parts, headers[i]+": "+cell)
+		} else {
+			parts = append(parts, cell)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// stripEmojis removes pictographic symbols along with their joiners, variation
+// selectors and skin-tone modifiers, then tidies the whitespace left behind.
+func stripEmojis(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		if isEmojiRune(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(collapseSpaces(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func isEmojiRune(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // pictographs, emoticons, transport, supplemental symbols
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // misc symbols + dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // arrows/stars used as emoji (⭐, ⬆)
+		return true
+	case r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F): // ZWJ + variation selectors
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tag sequences (subdivision flags)
+		return true
+	}
+	return false
+}
+
+// collapseSpaces squeezes runs of spaces (left by removed emojis) while
+// preserving leading indentation.
+func collapseSpaces(line string) string {
+	indent := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace))
+	body := line[indent:]
+	for strings.Contains(body, "  ") {
+		body = strings.ReplaceAll(body, "  ", " ")
+	}
+	return line[:indent] + body
+}
+
+// truncateAtBoundary shortens text to at most maxChars runes (including the
+// ellipsis), preferring to cut after a sentence end in the second half of the
+// allowed length, then at a word boundary in its last third.
+func truncateAtBoundary(text string, maxChars int) string {
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+	// Reserve room for " …" so the result never exceeds maxChars.
+	limit := maxChars - 1 - utf8.RuneCountInString(accessibilityEllipsis)
+	if limit <= 0 {
+		return string([]rune(text)[:maxChars])
+	}
+	cut := string([]rune(text)[:limit])
+	if i := strings.LastIndexAny(cut, ".!?\n"); i >= len(cut)/2 {
+		return strings.TrimSpace(cut[:i+1]) + " " + accessibilityEllipsis
+	}
+	if i := strings.LastIndexFunc(cut, unicode.IsSpace); i >= len(cut)*2/3 {
+		return strings.TrimSpace(cut[:i]) + accessibilityEllipsis
+	}
+	return cut + accessibilityEllipsis
+}
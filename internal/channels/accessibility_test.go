@@ -0,0 +1,47 @@
+package channels
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+)
+
+func TestApplyAccessibility_NilPrefsUnchanged(t *testing.T) {
+	in := "| a | b |\n|---|---|\n| 1 | 2 | 🎉"
+	if got := ApplyAccessibility(in, nil); got != in {
+		t.Fatalf("nil prefs changed text: %q", got)
+	}
+}
+
+func TestApplyAccessibility_SimpleFormatting(t *testing.T) {
+	in := "Results 🎉:\n\n| Name | Score |\n| --- | ---: |\n| Ann | 9 |\n| Bob | 7 |\n\nDone ✅ 👍🏽"
+	got := ApplyAccessibility(in, &bus.AccessibilityPrefs{SimpleFormatting: true})
+	want := "Results :\n\nName: Ann; Score: 9\nName: Bob; Score: 7\n\nDone"
+	if got != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestApplyAccessibility_MaxLength(t *testing.T) {
+	in := "First sentence is here. Second sentence goes on for a while longer."
+	got := ApplyAccessibility(in, &bus.AccessibilityPrefs{MaxMessageLength: 40})
+	if got != "First sentence is here. …" {
+		t.Fatalf("got %q", got)
+	}
+
+	long := strings.Repeat("word ", 50)
+	got = ApplyAccessibility(long, &bus.AccessibilityPrefs{MaxMessageLength: 30})
+	if n := utf8.RuneCountInString(got); n > 30 {
+		t.Fatalf("result has %d runes, want <= 30: %q", n, got)
+	}
+	if !strings.HasSuffix(got, "…") {
+		t.Fatalf("missing ellipsis: %q", got)
+	}
+
+	short := "Short reply."
+	if got := ApplyAccessibility(short, &bus.AccessibilityPrefs{MaxMessageLength: 100}); got != short {
+		t.Fatalf("short reply changed: %q", got)
+	}
+}
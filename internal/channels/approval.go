@@ -0,0 +1,67 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ApprovalPrompt asks an owner to confirm a tool call. Prompts go to the
+// owners' direct chats, never to the chat that made the call, so the sender
+// who triggered a tool cannot approve it unless they are an owner.
+type ApprovalPrompt struct {
+	ID          string    // pending approval ID
+	Tool        string    // tool name, e.g. "exec", "write_file"
+	Summary     string    // command or argument summary
+	ChatID      string    // chat to prompt: an approver's direct chat
+	Requester   string    // sender whose message triggered the call (shown in the prompt)
+	ApproverIDs []string  // senders allowed to answer; empty = nobody
+	ExpiresAt   time.Time // the call is denied after this time
+}
+
+// CanAnswer reports whether senderID may answer the prompt.
+func (p ApprovalPrompt) CanAnswer(senderID string) bool {
+	return senderID != "" && slices.Contains(p.ApproverIDs, senderID)
+}
+
+// ApprovalResolver resolves a pending approval. decision is "allow-once" or
+// "deny" (channels never offer "allow-always", which would change the
+// allowlist for every session); actorID is recorded in the audit log.
+type ApprovalResolver func(id, decision, actorID string) error
+
+// ApprovalChannel is implemented by channels that can render approval
+// prompts with inline controls (Telegram inline buttons).
+type ApprovalChannel interface {
+	SendApprovalPrompt(ctx context.Context, prompt ApprovalPrompt) error
+}
+
+// SetApprovalResolver sets the approval resolver for all current and future channels.
+func (m *Manager) SetApprovalResolver(r ApprovalResolver) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.approvalResolver = r
+	for _, ch := range m.channels {
+		if ac, ok := ch.(interface{ SetApprovalResolver(ApprovalResolver) }); ok {
+			ac.SetApprovalResolver(r)
+		}
+	}
+}
+
+// SendApprovalPrompt shows an approval prompt in the given channel. Channels
+// without inline controls return an error; the request stays answerable
+// over WS (exec.approval.approve / exec.approval.deny).
+func (m *Manager) SendApprovalPrompt(ctx context.Context, channelName string, prompt ApprovalPrompt) error {
+	m.mu.RLock()
+	channel, exists := m.channels[channelName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("channel %s not found", channelName)
+	}
+	ac, ok := channel.(ApprovalChannel)
+	if !ok {
+		return fmt.Errorf("channel %s does not support approval prompts", channelName)
+	}
+	return ac.SendApprovalPrompt(ctx, prompt)
+}
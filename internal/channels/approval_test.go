@@ -0,0 +1,20 @@
+package channels
+
+import "testing"
+
+func TestApprovalPromptCanAnswer(t *testing.T) {
+	p := ApprovalPrompt{Requester: "42", ApproverIDs: []string{"1001", "1002"}}
+	for sender, want := range map[string]bool{
+		"1001": true,
+		"1002": true,
+		"42":   false, // the requester is not an owner
+		"":     false,
+	} {
+		if got := p.CanAnswer(sender); got != want {
+			t.Errorf("CanAnswer(%q) = %v, want %v", sender, got, want)
+		}
+	}
+	if (ApprovalPrompt{Requester: "42"}).CanAnswer("42") {
+		t.Error("a prompt without approvers must not be answerable")
+	}
+}
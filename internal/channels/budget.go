@@ -0,0 +1,248 @@
+package channels
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+// BudgetExceededError is returned when a run is rejected because a user or
+// agent has used up a token or cost cap.
+type BudgetExceededError struct {
+	Scope  string // "user" or "agent"
+	Window string // "day" or "month"
+	Metric string // "tokens" or "cost" (cents)
+	Used   int64
+	Limit  int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("budget exceeded: %s %s %s cap reached (%d/%d)", e.Scope, e.windowLabel(), e.Metric, e.Used, e.Limit)
+}
+
+func (e *BudgetExceededError) windowLabel() string {
+	if e.Window == "day" {
+		return "daily"
+	}
+	return "monthly"
+}
+
+// UserMessage is the user-facing explanation sent back on the channel.
+func (e *BudgetExceededError) UserMessage() string {
+	reset := "tomorrow"
+	if e.Window == "month" {
+		reset = "next month"
+	}
+	if e.Scope == "agent" {
+		return fmt.Sprintf("⚠️ This assistant has reached its %s usage budget. It will be available again %s.", e.windowLabel(), reset)
+	}
+	return fmt.Sprintf("⚠️ You have reached your %s usage budget. Please try again %s.", e.windowLabel(), reset)
+}
+
+// budgetUsage holds cached day/month-to-date usage for a user or agent.
+type budgetUsage struct {
+	dayTokens, monthTokens int64
+	dayCents, monthCents   int64
+	fetchedAt              time.Time
+}
+
+// BudgetChecker enforces per-user and per-agent token/cost caps by summing
+// trace totals for the current UTC day and month. Results are cached
+// in-memory for cacheTTL, so a cap may be overshot by the runs in flight
+// within that window. Nil-safe (nil means budgets not configured).
+type BudgetChecker struct {
+	db       *sql.DB
+	config   config.BudgetConfig
+	users    map[string]*budgetUsage
+	agents   map[uuid.UUID]*budgetUsage
+	cacheTTL time.Duration
+	mu       sync.RWMutex
+	stopCh   chan struct{}
+	now      func() time.Time
+}
+
+// NewBudgetChecker creates a budget checker backed by the traces table.
+// Starts a background goroutine to evict stale cache entries.
+func NewBudgetChecker(db *sql.DB, cfg config.BudgetConfig) *BudgetChecker {
+	bc := &BudgetChecker{
+		db:       db,
+		config:   cfg,
+		users:    make(map[string]*budgetUsage),
+		agents:   make(map[uuid.UUID]*budgetUsage),
+		cacheTTL: 30 * time.Second,
+		stopCh:   make(chan struct{}),
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+	go bc.cleanupLoop()
+	return bc
+}
+
+// Stop shuts down the background cleanup goroutine.
+func (bc *BudgetChecker) Stop() {
+	close(bc.stopCh)
+}
+
+// UpdateConfig replaces the budget configuration (e.g., after config reload).
+func (bc *BudgetChecker) UpdateConfig(cfg config.BudgetConfig) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.config = cfg
+}
+
+// CheckRun returns a *BudgetExceededError when the user or the agent has
+// reached a cap. agentBudgetCents is the agent's own monthly budget
+// (budget_monthly_cents, 0 = none). Nil receiver and disabled config allow.
+func (bc *BudgetChecker) CheckRun(ctx context.Context, userID, agentKey string, agentID uuid.UUID, agentBudgetCents int) error {
+	if bc == nil {
+		return nil
+	}
+	bc.mu.RLock()
+	cfg := bc.config
+	bc.mu.RUnlock()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if userID != "" {
+		limit := cfg.UserDefault
+		if l, ok := cfg.Users[userID]; ok {
+			limit = l
+		}
+		if !limit.IsZero() {
+			if err := exceeded("user", limit, bc.userUsage(ctx, userID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if agentID != uuid.Nil {
+		limit := cfg.AgentDefault
+		if l, ok := cfg.Agents[agentKey]; ok {
+			limit = l
+		}
+		if limit.MonthlyCents == 0 && agentBudgetCents > 0 {
+			limit.MonthlyCents = int64(agentBudgetCents)
+		}
+		if !limit.IsZero() {
+			return exceeded("agent", limit, bc.agentUsage(ctx, agentID))
+		}
+	}
+	return nil
+}
+
+// exceeded reports the first cap reached, checking daily before monthly.
+func exceeded(scope string, limit config.BudgetLimit, u budgetUsage) error {
+	checks := []struct {
+		window, metric string
+		used, limit    int64
+	}{
+		{"day", "tokens", u.dayTokens, limit.DailyTokens},
+		{"day", "cost", u.dayCents, limit.DailyCents},
+		{"month", "tokens", u.monthTokens, limit.MonthlyTokens},
+		{"month", "cost", u.monthCents, limit.MonthlyCents},
+	}
+	for _, c := range checks {
+		if c.limit > 0 && c.used >= c.limit {
+			return &BudgetExceededError{Scope: scope, Window: c.window, Metric: c.metric, Used: c.used, Limit: c.limit}
+		}
+	}
+	return nil
+}
+
+// userUsage returns cached or fresh usage for a user.
+func (bc *BudgetChecker) userUsage(ctx context.Context, userID string) budgetUsage {
+	bc.mu.RLock()
+	if u, ok := bc.users[userID]; ok && bc.now().Sub(u.fetchedAt) < bc.cacheTTL {
+		usage := *u
+		bc.mu.RUnlock()
+		return usage
+	}
+	bc.mu.RUnlock()
+
+	usage := bc.queryUsage(ctx, "user_id", userID)
+
+	bc.mu.Lock()
+	bc.users[userID] = &usage
+	bc.mu.Unlock()
+	return usage
+}
+
+// agentUsage returns cached or fresh usage for an agent.
+func (bc *BudgetChecker) agentUsage(ctx context.Context, agentID uuid.UUID) budgetUsage {
+	bc.mu.RLock()
+	if u, ok := bc.agents[agentID]; ok && bc.now().Sub(u.fetchedAt) < bc.cacheTTL {
+		usage := *u
+		bc.mu.RUnlock()
+		return usage
+	}
+	bc.mu.RUnlock()
+
+	usage := bc.queryUsage(ctx, "agent_id", agentID)
+
+	bc.mu.Lock()
+	bc.agents[agentID] = &usage
+	bc.mu.Unlock()
+	return usage
+}
+
+// queryUsage sums tokens and cost of all traces (including delegated child
+// traces, which carry their own spans) for the current UTC day and month.
+// column is a fixed identifier ("user_id" or "agent_id"), never user input.
+func (bc *BudgetChecker) queryUsage(ctx context.Context, column string, key any) budgetUsage {
+	now := bc.now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usage := budgetUsage{fetchedAt: now}
+	var dayCost, monthCost float64
+	err := bc.db.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT
+			COALESCE(SUM(total_input_tokens + total_output_tokens) FILTER (WHERE created_at >= $2), 0),
+			COALESCE(SUM(total_cost) FILTER (WHERE created_at >= $2), 0),
+			COALESCE(SUM(total_input_tokens + total_output_tokens), 0),
+			COALESCE(SUM(total_cost), 0)
+		FROM traces
+		WHERE %s = $1 AND created_at >= $3`, column),
+		key, dayStart, monthStart,
+	).Scan(&usage.dayTokens, &dayCost, &usage.monthTokens, &monthCost)
+	if err != nil {
+		slog.Warn("budget: failed to query usage", column, key, "error", err)
+	}
+	usage.dayCents = int64(math.Round(dayCost * 100))
+	usage.monthCents = int64(math.Round(monthCost * 100))
+	return usage
+}
+
+// cleanupLoop periodically evicts stale cache entries.
+func (bc *BudgetChecker) cleanupLoop() {
+	ticker := time.NewTicker(2 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bc.stopCh:
+			return
+		case <-ticker.C:
+			bc.mu.Lock()
+			staleThreshold := bc.now().Add(-5 * time.Minute)
+			for k, v := range bc.users {
+				if v.fetchedAt.Before(staleThreshold) {
+					delete(bc.users, k)
+				}
+			}
+			for k, v := range bc.agents {
+				if v.fetchedAt.Before(staleThreshold) {
+					delete(bc.agents, k)
+				}
+			}
+			bc.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package channels
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+func newTestBudgetChecker(cfg config.BudgetConfig) *BudgetChecker {
+	return &BudgetChecker{
+		config:   cfg,
+		users:    make(map[string]*budgetUsage),
+		agents:   make(map[uuid.UUID]*budgetUsage),
+		cacheTTL: time.Minute,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+func TestBudgetCheckerRejectsUserOverDailyTokens(t *testing.T) {
+	bc := newTestBudgetChecker(config.BudgetConfig{
+		Enabled:     true,
+		UserDefault: config.BudgetLimit{DailyTokens: 1000, MonthlyCents: 500},
+		Users:       map[string]config.BudgetLimit{"vip": {DailyTokens: 5000}},
+	})
+	now := bc.now()
+	bc.users["u1"] = &budgetUsage{dayTokens: 1000, monthCents: 10, fetchedAt: now}
+	bc.users["vip"] = &budgetUsage{dayTokens: 1000, fetchedAt: now}
+
+	err := bc.CheckRun(t.Context(), "u1", "assistant", uuid.Nil, 0)
+	var be *BudgetExceededError
+	if !errors.As(err, &be) || be.Scope != "user" || be.Window != "day" || be.Metric != "tokens" {
+		t.Fatalf("CheckRun(u1) = %v, want user daily tokens rejection", err)
+	}
+	if !strings.Contains(be.UserMessage(), "daily") {
+		t.Errorf("UserMessage() = %q, want it to name the daily budget", be.UserMessage())
+	}
+
+	if err := bc.CheckRun(t.Context(), "vip", "assistant", uuid.Nil, 0); err != nil {
+		t.Fatalf("CheckRun(vip) = %v, want per-user override to allow", err)
+	}
+}
+
+func TestBudgetCheckerAgentBudgetColumnFillsMonthlyCents(t *testing.T) {
+	agentID := uuid.New()
+	bc := newTestBudgetChecker(config.BudgetConfig{Enabled: true})
+	bc.agents[agentID] = &budgetUsage{monthCents: 2500, fetchedAt: bc.now()}
+
+	if err := bc.CheckRun(t.Context(), "u1", "assistant", agentID, 3000); err != nil {
+		t.Fatalf("CheckRun under budget = %v, want nil", err)
+	}
+	err := bc.CheckRun(t.Context(), "u1", "assistant", agentID, 2000)
+	var be *BudgetExceededError
+	if !errors.As(err, &be) || be.Scope != "agent" || be.Window != "month" || be.Metric != "cost" || be.Limit != 2000 {
+		t.Fatalf("CheckRun over budget = %v, want agent monthly cost rejection", err)
+	}
+
+	// A configured per-agent limit takes precedence over the DB column.
+	bc.config.Agents = map[string]config.BudgetLimit{"assistant": {MonthlyCents: 10_000}}
+	if err := bc.CheckRun(t.Context(), "u1", "assistant", agentID, 2000); err != nil {
+		t.Fatalf("CheckRun with config override = %v, want nil", err)
+	}
+}
+
+func TestBudgetCheckerDisabledOrNil(t *testing.T) {
+	var nilChecker *BudgetChecker
+	if err := nilChecker.CheckRun(t.Context(), "u1", "a", uuid.New(), 1); err != nil {
+		t.Fatalf("nil checker = %v, want nil", err)
+	}
+	bc := newTestBudgetChecker(config.BudgetConfig{UserDefault: config.BudgetLimit{DailyTokens: 1}})
+	bc.users["u1"] = &budgetUsage{dayTokens: 100, fetchedAt: bc.now()}
+	if err := bc.CheckRun(t.Context(), "u1", "a", uuid.Nil, 0); err != nil {
+		t.Fatalf("disabled checker = %v, want nil", err)
+	}
+}
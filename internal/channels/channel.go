@@ -179,6 +179,7 @@ type BaseChannel struct {
 	agentID          string                  // for DB instances: routes to specific agent (empty = use resolveAgentRoute)
 	tenantID         uuid.UUID               // for DB instances: tenant scope (zero = master tenant fallback)
 	contactCollector *store.ContactCollector // optional: auto-collect contacts from channel messages
+	approvalResolver ApprovalResolver        // optional: resolves tool approval prompts answered in the chat
 
 	// Shared policy + pairing fields (set via setters after construction).
 	pairingService  store.PairingStore
@@ -234,6 +235,12 @@ func (c *BaseChannel) SetContactCollector(cc *store.ContactCollector) { c.contac
 // ContactCollector returns the contact collector (may be nil).
 func (c *BaseChannel) ContactCollector() *store.ContactCollector { return c.contactCollector }
 
+// SetApprovalResolver sets the callback used to answer tool approval prompts.
+func (c *BaseChannel) SetApprovalResolver(r ApprovalResolver) { c.approvalResolver = r }
+
+// ApprovalResolver returns the tool approval resolver (may be nil).
+func (c *BaseChannel) ApprovalResolver() ApprovalResolver { return c.approvalResolver }
+
 // SetPairingService sets the pairing store used for policy checks and code generation.
 func (c *BaseChannel) SetPairingService(ps store.PairingStore) { c.pairingService = ps }
 
@@ -652,3 +659,15 @@ func Truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen] + "..."
 }
+
+// MetaCommandText carries the user's original text when it looks like a slash
+// command. Channels that annotate Content (sender labels, group history) set it
+// so the gateway can parse the command from the unmodified text.
+const MetaCommandText = "command_text"
+
+// SetCommandText records text under MetaCommandText when it starts with "/".
+func SetCommandText(metadata map[string]string, text string) {
+	if text = strings.TrimSpace(text); strings.HasPrefix(text, "/") {
+		metadata[MetaCommandText] = text
+	}
+}
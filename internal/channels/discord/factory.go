@@ -29,6 +29,7 @@ type discordInstanceConfig struct {
 	STTAPIKey         string   `json:"stt_api_key,omitempty"`
 	STTTenantID       string   `json:"stt_tenant_id,omitempty"`
 	STTTimeoutSeconds int      `json:"stt_timeout_seconds,omitempty"`
+	STTLanguage       string   `json:"stt_language,omitempty"`
 	VoiceAgentID      string   `json:"voice_agent_id,omitempty"`
 }
 
@@ -87,6 +88,7 @@ func buildChannel(name string, creds json.RawMessage, cfg json.RawMessage,
 		STTAPIKey:         ic.STTAPIKey,
 		STTTenantID:       ic.STTTenantID,
 		STTTimeoutSeconds: ic.STTTimeoutSeconds,
+		STTLanguage:       ic.STTLanguage,
 		VoiceAgentID:      ic.VoiceAgentID,
 	}
 
@@ -109,8 +109,8 @@ func (c *Channel) handleMessage(_ *discordgo.Session, m *discordgo.MessageCreate
 				var transcript string
 				var sttErr error
 				if c.audioMgr != nil {
-					sttCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: mi.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{})
+					sttCtx, cancel := context.WithTimeout(audio.WithChannel(ctx, channels.TypeDiscord), 10*time.Second)
+					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: mi.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{Language: c.config.STTLanguage})
 					cancel()
 					if err == nil && res != nil {
 						transcript = res.Text
@@ -278,6 +278,7 @@ func (c *Channel) handleMessage(_ *discordgo.Session, m *discordgo.MessageCreate
 		"placeholder_key": m.ID, // keyed by inbound message ID for placeholder lookup
 	}
 
+	channels.SetCommandText(metadata, content)
 	// Voice agent routing
 	targetAgentID := c.AgentID()
 	if c.config.VoiceAgentID != "" {
@@ -84,6 +84,13 @@ func (m *Manager) dispatchOutbound(ctx context.Context) {
 				})
 			}
 
+			// Apply the recipient's accessibility preferences: simplified
+			// formatting + max length here, always-TTS via context for audio auto-apply.
+			if !msg.Accessibility.IsZero() {
+				msg.Content = ApplyAccessibility(msg.Content, msg.Accessibility)
+				sendCtx = store.WithAccessibility(sendCtx, *msg.Accessibility)
+			}
+
 			if err := channel.Send(sendCtx, msg); err != nil {
 				slog.Error("error sending message to channel",
 					"channel", msg.Channel,
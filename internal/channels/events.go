@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,9 +14,13 @@ import (
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
+// toolProgressEditInterval is the minimum gap between tool-progress edits of a
+// run's placeholder message.
+const toolProgressEditInterval = 3 * time.Second
+
 // HandleAgentEvent routes agent lifecycle events to streaming/reaction channels.
 // Called from the bus event subscriber — must be non-blocking.
-// eventType: "run.started", "chunk", "tool.call", "tool.result", "run.completed", "run.failed", "run.cancelled"
+// eventType: "run.started", "chunk", "tool.call", "tool.progress", "tool.result", "run.completed", "run.failed", "run.cancelled"
 func (m *Manager) HandleAgentEvent(eventType, runID string, payload any) {
 	val, ok := m.runs.Load(runID)
 	if !ok {
@@ -327,6 +332,31 @@ func (m *Manager) HandleAgentEvent(eventType, runID string, payload any) {
 		})
 	}
 
+	// Handle tool progress: show the latest output line under the tool status
+	// placeholder (non-streaming only — streamed text must not be overwritten).
+	// Throttled per run: platforms rate-limit message edits.
+	if eventType == protocol.AgentEventToolProgress && rc.ToolStatusEnabled && !rc.Streaming {
+		toolName := extractPayloadString(payload, "name")
+		message := extractPayloadString(payload, "message")
+		rc.mu.Lock()
+		due := message != "" && time.Since(rc.lastProgressAt) >= toolProgressEditInterval
+		if due {
+			rc.lastProgressAt = time.Now()
+		}
+		rc.mu.Unlock()
+		if due {
+			outMeta := copyRoutingMeta(rc.Metadata)
+			outMeta["placeholder_update"] = "true"
+			m.bus.PublishOutbound(bus.OutboundMessage{
+				Channel:  rc.ChannelName,
+				ChatID:   rc.ChatID,
+				Content:  formatToolStatus(toolName) + "\n" + message,
+				Metadata: outMeta,
+				TenantID: rc.TenantID,
+			})
+		}
+	}
+
 	// Forward to ReactionChannel
 	if reactionCh, ok := ch.(ReactionChannel); ok {
 		status := ""
@@ -378,15 +408,21 @@ var toolStatusMap = map[string]string{
 	"read_file":  "📝 Reading file...",
 	"write_file": "📝 Writing file...",
 	"list_files": "📝 Listing files...",
+	"grep":       "🔎 Searching files...",
+	"glob":       "🔎 Finding files...",
 	"edit":       "📝 Editing file...",
 	// Runtime
-	"exec": "⚡ Running code...",
+	"exec":            "⚡ Running code...",
+	"exec_background": "⚡ Starting background process...",
+	"process_list":    "⚡ Checking processes...",
+	"process_kill":    "⚡ Stopping process...",
 	// Web
 	"web_search": "🔍 Searching the web...",
 	"web_fetch":  "🔍 Fetching web content...",
 	// Memory
 	"memory_search":          "🧠 Searching memory...",
 	"memory_get":             "🧠 Retrieving memory...",
+	"memory_write":           "🧠 Saving to memory...",
 	"knowledge_graph_search": "🧠 Querying knowledge graph...",
 	// Media
 	"read_image":    "👁 Analyzing image...",
@@ -397,6 +433,7 @@ var toolStatusMap = map[string]string{
 	"create_video":  "🎬 Creating video...",
 	"create_audio":  "🎵 Creating audio...",
 	"tts":           "🔊 Generating speech...",
+	"tts_speak":     "🎙️ Recording voice reply...",
 	// Browser
 	"browser": "🌐 Browsing...",
 	// Delegation & teams
@@ -410,6 +447,9 @@ var toolStatusMap = map[string]string{
 	// Other
 	"message":         "📤 Sending message...",
 	"cron":            "⏰ Managing schedule...",
+	"cron_add":        "⏰ Scheduling...",
+	"cron_list":       "⏰ Checking schedule...",
+	"cron_remove":     "⏰ Cancelling schedule...",
 	"skill_search":    "🔍 Searching skills...",
 	"use_skill":       "🧩 Using skill...",
 	"mcp_tool_search": "🔌 Searching MCP tools...",
@@ -12,6 +12,8 @@ import (
 	"regexp"
 	"strconv"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 )
 
 const (
@@ -189,89 +191,79 @@ var graphBackoffBase = 1 * time.Second
 func (g *GraphClient) doRequest(ctx context.Context, method, path string, body any) ([]byte, error) {
 	apiURL := fmt.Sprintf("%s/%s%s", graphAPIBase, graphAPIVersion, path)
 
-	for attempt := range maxRetries {
-		if attempt > 0 {
-			backoff := time.Duration(1<<uint(attempt-1)) * graphBackoffBase
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
-
-		var reqBody io.Reader
-		if body != nil {
-			b, err := json.Marshal(body)
-			if err != nil {
-				return nil, fmt.Errorf("facebook: marshal request: %w", err)
-			}
-			reqBody = bytes.NewReader(b)
-		}
-
-		req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("facebook: build request: %w", err)
-		}
-		// Pass token via header to avoid URL logging exposure.
-		req.Header.Set("Authorization", "Bearer "+g.pageAccessToken)
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
-		}
-
-		resp, err := g.httpClient.Do(req)
-		if err != nil {
-			if attempt < maxRetries-1 {
-				slog.Warn("facebook: api request error, retrying", "attempt", attempt+1, "err", err)
-				continue
-			}
-			return nil, fmt.Errorf("facebook: api request: %w", err)
+			return nil, fmt.Errorf("facebook: marshal request: %w", err)
 		}
+		payload = b
+	}
 
-		respBody, readErr := io.ReadAll(resp.Body)
-		resp.Body.Close()
-		if readErr != nil {
-			return nil, fmt.Errorf("facebook: read response: %w", readErr)
-		}
+	policy := retry.Policy{
+		Name:    "facebook.graph",
+		Backoff: retry.Backoff{Attempts: maxRetries, MinDelay: graphBackoffBase},
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			slog.Warn("facebook: api request failed, retrying", "attempt", attempt, "delay", delay, "err", err)
+		},
+	}
+	return retry.DoValue(ctx, policy, func(ctx context.Context) ([]byte, error) {
+		return g.doRequestOnce(ctx, method, apiURL, payload)
+	})
+}
 
-		// Proactive rate limit monitoring.
-		g.logRateLimit(resp)
+// doRequestOnce performs a single Graph API call. Errors carry their HTTP
+// status so retry.Classify retries 5xx/429 (honoring Retry-After) and
+// transport errors, and gives up on other 4xx.
+func (g *GraphClient) doRequestOnce(ctx context.Context, method, apiURL string, payload []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reqBody)
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("facebook: build request: %w", err))
+	}
+	// Pass token via header to avoid URL logging exposure.
+	req.Header.Set("Authorization", "Bearer "+g.pageAccessToken)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-		// Retry on 5xx.
-		if resp.StatusCode >= 500 && attempt < maxRetries-1 {
-			slog.Warn("facebook: server error, retrying", "status", resp.StatusCode, "attempt", attempt+1)
-			continue
-		}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("facebook: api request: %w", err)
+	}
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, retry.Permanent(fmt.Errorf("facebook: read response: %w", readErr))
+	}
 
-		// Parse Graph API error envelope.
-		if resp.StatusCode >= 400 {
-			var apiErr graphErrorBody
-			if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Code != 0 {
-				// 24h messaging window violation — not retryable.
-				if apiErr.Error.Code == 551 || apiErr.Error.Subcode == 2018109 {
-					slog.Warn("facebook: 24h messaging window expired", "code", apiErr.Error.Code)
-					return nil, &graphAPIError{code: apiErr.Error.Code, msg: apiErr.Error.Message}
-				}
-				// Rate limited: sleep and retry (capped).
-				if resp.StatusCode == 429 && attempt < maxRetries-1 {
-					retryAfter := parseRetryAfter(resp)
-					slog.Warn("facebook: rate limited", "retry_after", retryAfter)
-					select {
-					case <-ctx.Done():
-						return nil, ctx.Err()
-					case <-time.After(retryAfter):
-					}
-					continue
-				}
-				return nil, &graphAPIError{code: apiErr.Error.Code, msg: apiErr.Error.Message}
-			}
-			return nil, fmt.Errorf("facebook: http %d", resp.StatusCode)
-		}
+	// Proactive rate limit monitoring.
+	g.logRateLimit(resp)
 
+	if resp.StatusCode < 400 {
 		return respBody, nil
 	}
 
-	// All attempts exhausted (only reachable when every iteration took the continue path).
-	return nil, fmt.Errorf("facebook: max retries exceeded")
+	// Parse Graph API error envelope.
+	var apiErr graphErrorBody
+	if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Code != 0 {
+		gerr := &graphAPIError{code: apiErr.Error.Code, msg: apiErr.Error.Message}
+		// 24h messaging window violation — not retryable.
+		if apiErr.Error.Code == 551 || apiErr.Error.Subcode == 2018109 {
+			slog.Warn("facebook: 24h messaging window expired", "code", apiErr.Error.Code)
+			return nil, retry.Permanent(gerr)
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			retryAfter := parseRetryAfter(resp)
+			slog.Warn("facebook: rate limited", "retry_after", retryAfter)
+			return nil, retry.HTTPStatus(gerr, resp.StatusCode, retryAfter)
+		}
+		return nil, retry.HTTPStatus(gerr, resp.StatusCode, 0)
+	}
+	return nil, retry.HTTPStatus(fmt.Errorf("facebook: http %d", resp.StatusCode), resp.StatusCode, 0)
 }
 
 // logRateLimit parses the X-Business-Use-Case-Usage header and warns when approaching limits.
@@ -218,12 +218,17 @@ func (c *Channel) handleMessageEvent(ctx context.Context, event *MessageEvent) {
 	// replies.
 	if mc.ThreadID != "" {
 		metadata["feishu_reply_target_id"] = messageID
+		c.replyTargets.Store(chatID, messageID)
+	} else {
+		c.replyTargets.Delete(chatID)
 	}
 
 	if sender != nil {
 		metadata["sender_open_id"] = sender.SenderID.OpenID
 	}
 
+	channels.SetCommandText(metadata, content)
+
 	// Annotate content with sender identity so the agent knows who is messaging.
 	if senderName != "" {
 		if mc.ChatType == "group" {
@@ -270,8 +275,8 @@ func (c *Channel) handleMessageEvent(ctx context.Context, event *MessageEvent) {
 				var transcript string
 				var sttErr error
 				if c.audioMgr != nil {
-					sttCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: m.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{})
+					sttCtx, cancel := context.WithTimeout(audio.WithChannel(ctx, channels.TypeFeishu), 10*time.Second)
+					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: m.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{Language: c.cfg.STTLanguage})
 					cancel()
 					if err == nil && res != nil {
 						transcript = res.Text
@@ -35,6 +35,8 @@ type feishuInstanceConfig struct {
 	MediaMaxMB       int      `json:"media_max_mb,omitempty"`
 	RenderMode       string   `json:"render_mode,omitempty"`
 	Streaming        *bool    `json:"streaming,omitempty"`
+	StreamThrottleMs int      `json:"stream_throttle_ms,omitempty"`
+	StreamMinChars   int      `json:"stream_min_chars,omitempty"`
 	ReactionLevel    string   `json:"reaction_level,omitempty"`
 	HistoryLimit      int      `json:"history_limit,omitempty"`
 	BlockReply        *bool    `json:"block_reply,omitempty"`
@@ -42,6 +44,7 @@ type feishuInstanceConfig struct {
 	STTAPIKey         string   `json:"stt_api_key,omitempty"`
 	STTTenantID       string   `json:"stt_tenant_id,omitempty"`
 	STTTimeoutSeconds int      `json:"stt_timeout_seconds,omitempty"`
+	STTLanguage       string   `json:"stt_language,omitempty"`
 	VoiceAgentID      string   `json:"voice_agent_id,omitempty"`
 }
 
@@ -86,6 +89,8 @@ func Factory(name string, creds json.RawMessage, cfg json.RawMessage,
 		MediaMaxMB:        ic.MediaMaxMB,
 		RenderMode:        ic.RenderMode,
 		Streaming:         ic.Streaming,
+		StreamThrottleMs:  ic.StreamThrottleMs,
+		StreamMinChars:    ic.StreamMinChars,
 		ReactionLevel:     ic.ReactionLevel,
 		HistoryLimit:      ic.HistoryLimit,
 		BlockReply:        ic.BlockReply,
@@ -93,6 +98,7 @@ func Factory(name string, creds json.RawMessage, cfg json.RawMessage,
 		STTAPIKey:         ic.STTAPIKey,
 		STTTenantID:       ic.STTTenantID,
 		STTTimeoutSeconds: ic.STTTimeoutSeconds,
+		STTLanguage:       ic.STTLanguage,
 		VoiceAgentID:      ic.VoiceAgentID,
 	}
 
@@ -157,6 +163,8 @@ func FactoryWithPendingStoreAndAudio(pendingStore store.PendingMessageStore, aud
 			MediaMaxMB:        ic.MediaMaxMB,
 			RenderMode:        ic.RenderMode,
 			Streaming:         ic.Streaming,
+			StreamThrottleMs:  ic.StreamThrottleMs,
+			StreamMinChars:    ic.StreamMinChars,
 			ReactionLevel:     ic.ReactionLevel,
 			HistoryLimit:      ic.HistoryLimit,
 			BlockReply:        ic.BlockReply,
@@ -164,6 +172,7 @@ func FactoryWithPendingStoreAndAudio(pendingStore store.PendingMessageStore, aud
 			STTAPIKey:         ic.STTAPIKey,
 			STTTenantID:       ic.STTTenantID,
 			STTTimeoutSeconds: ic.STTTimeoutSeconds,
+			STTLanguage:       ic.STTLanguage,
 			VoiceAgentID:      ic.VoiceAgentID,
 		}
 
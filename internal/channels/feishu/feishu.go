@@ -43,6 +43,8 @@ type Channel struct {
 	senderCache     sync.Map  // open_id → *senderCacheEntry
 	dedup           sync.Map  // message_id → struct{}
 	reactions       sync.Map  // chatID → *reactionState
+	streams         sync.Map  // chatID → *cardStream (finalized, awaiting Send)
+	replyTargets    sync.Map  // chatID → thread reply target message ID (for streamed cards)
 	docCache        *docCache // LRU+TTL cache for Lark docx raw_content lookups
 	agentStore      store.AgentStore            // optional — agent key → UUID lookup for writer commands
 	configPermStore store.ConfigPermissionStore // optional — group file writer ACL for /addwriter et al.
@@ -178,7 +180,11 @@ func (c *Channel) SetPendingHistoryTenantID(id uuid.UUID) {
 func (c *Channel) Stop(_ context.Context) error {
 	c.GroupHistory().StopFlusher()
 	slog.Info("stopping feishu/lark bot")
-	close(c.stopCh)
+	select {
+	case <-c.stopCh: // already stopped (supervisor restart path)
+	default:
+		close(c.stopCh)
+	}
 
 	if c.wsClient != nil {
 		c.wsClient.Stop()
@@ -213,8 +219,12 @@ func (c *Channel) Send(ctx context.Context, msg bus.OutboundMessage) error {
 	// Absent on non-thread messages — Send falls back to the new-message path.
 	replyTargetID := msg.Metadata["feishu_reply_target_id"]
 
-	// Send text content
+	// Send text content. When the reply was streamed into a card, the final
+	// response is written into that card instead of a new message.
 	text := msg.Content
+	if text != "" && c.finishStream(ctx, chatID, text) {
+		text = ""
+	}
 	if text != "" {
 		// Resolve render mode
 		renderMode := c.cfg.RenderMode
@@ -282,9 +292,15 @@ func (c *Channel) startWebSocket(ctx context.Context) error {
 	c.wsClient = NewWSClient(c.cfg.AppID, c.cfg.AppSecret, domain, &wsEventAdapter{ch: c})
 
 	go func() {
-		defer safego.Recover(nil, "component", "feishu_ws", "channel", c.Name())
+		defer safego.Recover(func(any) {
+			c.MarkFailed("WebSocket crashed", "Feishu WebSocket loop panicked. Review server logs for the stack trace.", channels.ChannelFailureKindUnknown, true)
+		}, "component", "feishu_ws", "channel", c.Name())
 		if err := c.wsClient.Start(ctx); err != nil {
 			slog.Error("feishu websocket error", "error", err)
+			if ctx.Err() == nil {
+				info := channels.ClassifyChannelError(err)
+				c.MarkFailed("WebSocket disconnected", info.Detail, info.Kind, info.Retryable)
+			}
 		}
 	}()
 
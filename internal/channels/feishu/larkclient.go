@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 const (
@@ -37,7 +39,7 @@ func NewLarkClient(appID, appSecret, baseURL string) *LarkClient {
 		baseURL:    baseURL,
 		appID:      appID,
 		appSecret:  appSecret,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: netproxy.Transport(netproxy.Channel("feishu"))},
 	}
 }
 
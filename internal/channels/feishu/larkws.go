@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 const (
@@ -137,7 +139,9 @@ func (c *WSClient) connectAndRun(ctx context.Context) error {
 
 		slog.Info("lark ws: connecting", "url_len", len(wsURL))
 
-		conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+		dialer := *websocket.DefaultDialer
+		dialer.Proxy = netproxy.For(netproxy.Channel("feishu"))
+		conn, _, err := dialer.DialContext(ctx, wsURL, nil)
 		if err != nil {
 			slog.Error("lark ws: dial failed", "error", err)
 			c.waitReconnect()
@@ -194,7 +198,7 @@ func (c *WSClient) getWSEndpoint(ctx context.Context) (string, error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: netproxy.Transport(netproxy.Channel("feishu"))}
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("ws endpoint request: %w", err)
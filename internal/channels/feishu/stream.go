@@ -0,0 +1,267 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+)
+
+const (
+	// streamElementID is the element_id of the markdown block that streaming
+	// updates target inside the CardKit card.
+	streamElementID = "content"
+
+	// defaultStreamThrottleMs is the card edit throttle when stream_throttle_ms
+	// is unset. CardKit streaming tolerates far more frequent updates than IM
+	// message edits, so the default is tighter than channels.DefaultStreamThrottle.
+	defaultStreamThrottleMs = 100
+
+	// streamMaxChars caps the streamed preview; the final Send() still delivers
+	// the full response (chunked) when it does not fit into the card.
+	streamMaxChars = 30000
+)
+
+// cardStream streams a reply into a Lark CardKit card in streaming mode.
+// Ref: CardKit streaming updates — POST /cardkit/v1/cards creates the card,
+// PATCH .../elements/{element_id} replaces the markdown content with a
+// monotonically increasing sequence, PATCH /cards/{id} turns streaming off.
+//
+// State machine:
+//
+//	NOT_STARTED → first due Update() → create card + send interactive message → STREAMING
+//	STREAMING   → subsequent Update() → update card element (throttled, coalesced) → STREAMING
+//	STREAMING   → Stop() → final flush → STOPPED (card still in streaming mode)
+//	STOPPED     → finalize(text) → final element update + streaming_mode=false
+//
+// Any CardKit failure marks the stream failed; Send() then falls back to
+// regular message delivery so the reply is never lost.
+type cardStream struct {
+	client   *LarkClient
+	deliver  func(ctx context.Context, content string) error
+	pacing   channels.StreamPacing
+	mu       sync.Mutex
+	cardID   string // "" = not yet created
+	seq      int    // CardKit update sequence (must strictly increase)
+	lastText string
+	lastEdit time.Time
+	pending  string
+	stopped  bool
+	failed   bool
+	closed   bool // streaming_mode turned off
+}
+
+// Update pushes the latest accumulated text, throttled and coalesced.
+func (s *cardStream) Update(ctx context.Context, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped || s.failed {
+		return
+	}
+	if len(text) > streamMaxChars {
+		text = text[:streamMaxChars]
+	}
+	s.pending = text
+	if !s.pacing.Due(s.lastEdit, s.lastText, text) {
+		return
+	}
+	s.flush(ctx)
+}
+
+// Stop flushes any pending text. Streaming mode is left on so Send() can
+// write the final formatted response into the same card; mid-run cards that
+// are never finalized are closed by Lark's own streaming timeout.
+func (s *cardStream) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped = true
+	return s.flush(ctx)
+}
+
+// MessageID always returns 0: Lark message IDs are strings ("om_xxx"), so the
+// card is handed back to Send() through Channel.streams instead.
+func (s *cardStream) MessageID() int { return 0 }
+
+// flush creates the card on first use, then replaces its content (must hold mu).
+func (s *cardStream) flush(ctx context.Context) error {
+	if s.failed || s.pending == "" || s.pending == s.lastText {
+		return nil
+	}
+	text := s.pending
+	display := convertMentionsForCard(audio.StripTTSDirectives(text))
+
+	if s.cardID == "" {
+		if err := s.create(ctx, display); err != nil {
+			s.failed = true
+			slog.Debug("feishu stream: create card failed", "error", err)
+			return err
+		}
+	} else if err := s.updateContent(ctx, display); err != nil {
+		slog.Debug("feishu stream: update card failed", "card_id", s.cardID, "error", err)
+		return err
+	}
+
+	s.lastText = text
+	s.lastEdit = time.Now()
+	return nil
+}
+
+func (s *cardStream) create(ctx context.Context, display string) error {
+	data, err := json.Marshal(buildStreamingCard(display))
+	if err != nil {
+		return fmt.Errorf("marshal card: %w", err)
+	}
+	cardID, err := s.client.CreateCard(ctx, "card_json", string(data))
+	if err != nil {
+		return err
+	}
+	content, _ := json.Marshal(map[string]any{
+		"type": "card",
+		"data": map[string]string{"card_id": cardID},
+	})
+	if err := s.deliver(ctx, string(content)); err != nil {
+		return err
+	}
+	s.cardID = cardID
+	return nil
+}
+
+func (s *cardStream) updateContent(ctx context.Context, display string) error {
+	s.seq++
+	return s.client.UpdateCardElement(ctx, s.cardID, streamElementID, display, s.seq, uuid.NewString())
+}
+
+// finalize writes the final response into the card and turns streaming off.
+// Returns false when the card cannot hold the response (not created, failed,
+// or text too long) so the caller falls back to regular delivery.
+func (s *cardStream) finalize(ctx context.Context, text string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cardID == "" || s.failed || s.closed || len(text) > streamMaxChars {
+		return false
+	}
+	if err := s.updateContent(ctx, convertMentionsForCard(text)); err != nil {
+		slog.Warn("feishu stream: final card update failed", "card_id", s.cardID, "error", err)
+		return false
+	}
+	s.close(ctx)
+	return true
+}
+
+// close turns streaming_mode off so the card stops showing the typing cursor (must hold mu).
+func (s *cardStream) close(ctx context.Context) {
+	if s.cardID == "" || s.closed {
+		return
+	}
+	s.closed = true
+	s.seq++
+	settings := `{"config":{"streaming_mode":false}}`
+	if err := s.client.UpdateCardSettings(ctx, s.cardID, settings, s.seq, uuid.NewString()); err != nil {
+		slog.Debug("feishu stream: close streaming mode failed", "card_id", s.cardID, "error", err)
+	}
+}
+
+// buildStreamingCard builds a schema 2.0 card in streaming mode with a single
+// addressable markdown element.
+func buildStreamingCard(text string) map[string]any {
+	return map[string]any{
+		"schema": "2.0",
+		"config": map[string]any{
+			"wide_screen_mode": true,
+			"streaming_mode":   true,
+			"streaming_config": map[string]any{
+				"print_frequency_ms": map[string]int{"default": 50},
+				"print_step":         map[string]int{"default": 2},
+			},
+		},
+		"body": map[string]any{
+			"elements": []map[string]any{
+				{
+					"tag":        "markdown",
+					"content":    text,
+					"element_id": streamElementID,
+				},
+			},
+		},
+	}
+}
+
+// --- StreamingChannel implementation ---
+
+// StreamEnabled reports whether replies are streamed into a card.
+// Controlled by "streaming" (default true) for both DMs and groups.
+func (c *Channel) StreamEnabled(_ bool) bool {
+	return c.cfg.Streaming == nil || *c.cfg.Streaming
+}
+
+// ReasoningStreamEnabled returns false: a Lark chat gets a single streaming
+// card per answer rather than a separate reasoning message.
+func (c *Channel) ReasoningStreamEnabled() bool { return false }
+
+// CreateStream prepares a per-run streaming card for chatID.
+// The card itself is created lazily on the first due Update().
+func (c *Channel) CreateStream(_ context.Context, chatID string, _ bool) (channels.ChannelStream, error) {
+	receiveIDType := resolveReceiveIDType(chatID)
+	replyTargetID := ""
+	if v, ok := c.replyTargets.Load(chatID); ok {
+		replyTargetID = v.(string)
+	}
+	return &cardStream{
+		client: c.client,
+		pacing: c.streamPacing(),
+		deliver: func(ctx context.Context, content string) error {
+			return c.deliverMessage(ctx, chatID, receiveIDType, replyTargetID, "interactive", content)
+		},
+	}, nil
+}
+
+// streamPacing resolves the per-channel edit throttle and chunk coalescing.
+func (c *Channel) streamPacing() channels.StreamPacing {
+	throttleMs := c.cfg.StreamThrottleMs
+	if throttleMs <= 0 {
+		throttleMs = defaultStreamThrottleMs
+	}
+	return channels.NewStreamPacing(throttleMs, c.cfg.StreamMinChars)
+}
+
+// FinalizeStream hands the streamed card to Send() so the final response is
+// written into it instead of being posted as a second message.
+func (c *Channel) FinalizeStream(_ context.Context, chatID string, stream channels.ChannelStream) {
+	cs, ok := stream.(*cardStream)
+	if !ok {
+		return
+	}
+	cs.mu.Lock()
+	created := cs.cardID != "" && !cs.failed
+	cs.mu.Unlock()
+	if created {
+		c.streams.Store(chatID, cs)
+	}
+}
+
+// finishStream writes text into the card finalized for chatID, if any.
+// Returns true when the card now holds the response and Send() is done.
+func (c *Channel) finishStream(ctx context.Context, chatID, text string) bool {
+	v, ok := c.streams.LoadAndDelete(chatID)
+	if !ok {
+		return false
+	}
+	cs := v.(*cardStream)
+	if cs.finalize(ctx, text) {
+		return true
+	}
+	cs.mu.Lock()
+	cs.close(ctx)
+	cs.mu.Unlock()
+	return false
+}
@@ -0,0 +1,113 @@
+package feishu
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+// newCardKitServer returns a mock Lark server that records every non-token
+// request as "METHOD path" plus its decoded body.
+func newCardKitServer(t *testing.T) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var reqs []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == tokenEndpoint {
+			_, _ = w.Write([]byte(`{"code":0,"msg":"ok","tenant_access_token":"tok","expire":7200}`))
+			return
+		}
+		rec := recordedRequest{method: r.Method, path: r.URL.Path}
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &rec.body)
+		mu.Lock()
+		reqs = append(reqs, rec)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":0,"msg":"","data":{"card_id":"card_1","message_id":"om_out"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), reqs...)
+	}
+}
+
+func TestCardStream_CoalescesAndFinalizesIntoCard(t *testing.T) {
+	srv, requests := newCardKitServer(t)
+	ch := &Channel{
+		client: NewLarkClient("app", "secret", srv.URL),
+		cfg:    config.FeishuConfig{StreamThrottleMs: 60000},
+	}
+	ctx := context.Background()
+
+	stream, err := ch.CreateStream(ctx, "oc_chat", true)
+	if err != nil {
+		t.Fatalf("CreateStream: %v", err)
+	}
+	stream.Update(ctx, "Hel")         // first update: creates card + sends message
+	stream.Update(ctx, "Hello")       // throttled: coalesced into pending
+	stream.Update(ctx, "Hello world") // still throttled
+	if err := stream.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	ch.FinalizeStream(ctx, "oc_chat", stream)
+
+	if !ch.finishStream(ctx, "oc_chat", "Hello world, final") {
+		t.Fatal("finishStream should write the final text into the card")
+	}
+
+	got := requests()
+	want := []string{
+		"POST /open-apis/cardkit/v1/cards",
+		"POST /open-apis/im/v1/messages",
+		"PATCH /open-apis/cardkit/v1/cards/card_1/elements/content", // Stop flush
+		"PATCH /open-apis/cardkit/v1/cards/card_1/elements/content", // final text
+		"PATCH /open-apis/cardkit/v1/cards/card_1",                  // streaming off
+	}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %d, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if g := got[i].method + " " + got[i].path; g != w {
+			t.Errorf("request %d = %q, want %q", i, g, w)
+		}
+	}
+	if c, _ := got[2].body["content"].(string); c != "Hello world" {
+		t.Errorf("stop flush content = %q, want coalesced %q", c, "Hello world")
+	}
+	if seq, _ := got[4].body["sequence"].(float64); seq != 3 {
+		t.Errorf("settings sequence = %v, want 3", seq)
+	}
+
+	if ch.finishStream(ctx, "oc_chat", "next reply") {
+		t.Error("card must be handed off to Send() only once")
+	}
+}
+
+func TestCardStream_NotFinalizedWithoutCard(t *testing.T) {
+	ch := &Channel{cfg: config.FeishuConfig{}}
+	ctx := context.Background()
+	stream, _ := ch.CreateStream(ctx, "oc_chat", true)
+	ch.FinalizeStream(ctx, "oc_chat", stream)
+	if ch.finishStream(ctx, "oc_chat", "reply") {
+		t.Error("finishStream without a created card must fall back to regular send")
+	}
+}
+
+func TestStreamEnabled_DefaultsOn(t *testing.T) {
+	off := false
+	if !(&Channel{}).StreamEnabled(true) {
+		t.Error("streaming should default to enabled")
+	}
+	if (&Channel{cfg: config.FeishuConfig{Streaming: &off}}).StreamEnabled(false) {
+		t.Error("streaming=false should disable streaming")
+	}
+}
@@ -75,6 +75,8 @@ type ChannelHealth struct {
 	LastFailedAt        time.Time           `json:"last_failed_at"`
 	LastHealthyAt       time.Time           `json:"last_healthy_at"`
 	Remediation         *ChannelRemediation `json:"remediation,omitempty"`
+	Restarts            int                 `json:"restarts,omitempty"`        // supervisor restart attempts since last healthy
+	NextRestartAt       *time.Time          `json:"next_restart_at,omitempty"` // scheduled supervisor restart
 }
 
 // ChannelErrorInfo contains shared error classification output for operators.
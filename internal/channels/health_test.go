@@ -531,3 +531,33 @@ func TestMergeChannelHealthTracksFailureTimelineAndRecovery(t *testing.T) {
 		t.Fatalf("expected cumulative failure count 3, got %d", third.FailureCount)
 	}
 }
+
+func TestManagerLazyStartStartsChannelsInBackground(t *testing.T) {
+	mgr := NewManager(bus.New())
+	first := newFakeHealthChannel("discord-main")
+	second := newFakeHealthChannel("telegram-main")
+	mgr.RegisterChannel("discord-main", first)
+	mgr.RegisterChannel("telegram-main", second)
+	mgr.SetLazyStart(50 * time.Millisecond)
+
+	ctx := t.Context()
+	if err := mgr.StartAll(ctx); err != nil {
+		t.Fatalf("StartAll returned error: %v", err)
+	}
+	t.Cleanup(func() { mgr.StopAll(context.Background()) })
+
+	if second.IsRunning() {
+		t.Fatal("expected second channel to start after the interval, not synchronously")
+	}
+	if status, _ := mgr.GetStatus()["telegram-main"].(ChannelHealth); status.State != ChannelHealthStateStarting {
+		t.Fatalf("expected pending channel in starting state, got %q", status.State)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !(first.IsRunning() && second.IsRunning()) {
+		if time.Now().After(deadline) {
+			t.Fatal("channels were not started in the background")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
@@ -44,6 +47,7 @@ type RunContext struct {
 	hasThinking       bool          // true if any thinking events received this iteration
 	thinkingDone      bool          // true after first chunk arrives (reasoning→answer transition complete)
 	tagParseSkipped   bool          // true after first chunk with no <think> tags (skip re-parsing)
+	lastProgressAt    time.Time     // last tool.progress placeholder edit (throttle)
 }
 
 // Manager manages all registered channels, handling their lifecycle
@@ -54,8 +58,13 @@ type Manager struct {
 	bus              *bus.MessageBus
 	runs             sync.Map // runID string → *RunContext
 	dispatchTask     *asyncTask
+	superviseTask    *asyncTask
+	restarts         map[string]*restartState // supervisor backoff per failed channel
+	lazyStart        time.Duration            // >0 = StartAll starts channels in the background, one per interval
+	lazyStartTask    *asyncTask
 	mu               sync.RWMutex
 	contactCollector *store.ContactCollector
+	approvalResolver ApprovalResolver
 }
 
 type asyncTask struct {
@@ -68,6 +77,7 @@ func NewManager(msgBus *bus.MessageBus) *Manager {
 	return &Manager{
 		channels: make(map[string]Channel),
 		health:   make(map[string]ChannelHealth),
+		restarts: make(map[string]*restartState),
 		bus:      msgBus,
 	}
 }
@@ -84,31 +94,102 @@ func (m *Manager) StartAll(ctx context.Context) error {
 	m.dispatchTask = &asyncTask{cancel: cancel}
 	go m.dispatchOutbound(dispatchCtx)
 
+	// Supervisor restarts channels whose poller/socket died with a retryable failure.
+	superviseCtx, superviseCancel := context.WithCancel(ctx)
+	m.superviseTask = &asyncTask{cancel: superviseCancel}
+	go m.supervise(superviseCtx)
+
 	if len(m.channels) == 0 {
 		slog.Warn("no channels enabled")
 		return nil
 	}
 
+	if m.lazyStart > 0 {
+		names := make([]string, 0, len(m.channels))
+		for name, channel := range m.channels {
+			if hc, ok := channel.(interface{ MarkStarting(string) }); ok {
+				hc.MarkStarting("Starting")
+			}
+			m.syncChannelHealthLocked(name, channel)
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		waitCtx, lazyCancel := context.WithCancel(ctx)
+		m.lazyStartTask = &asyncTask{cancel: lazyCancel}
+		go m.startLazily(waitCtx, ctx, names, m.lazyStart)
+		slog.Info("starting channels in background", "count", len(names), "interval", m.lazyStart)
+		return nil
+	}
+
 	slog.Info("starting all channels")
 
 	for name, channel := range m.channels {
-		slog.Info("starting channel", "channel", name)
-		if hc, ok := channel.(interface{ MarkStarting(string) }); ok {
-			hc.MarkStarting("Starting")
-		}
-		m.syncChannelHealthLocked(name, channel)
-		if err := channel.Start(ctx); err != nil {
-			m.recordChannelStartFailureLocked(name, channel, "", err)
-			slog.Error("failed to start channel", "channel", name, "error", err)
-			continue
-		}
-		m.syncChannelHealthLocked(name, channel)
+		m.startChannelLocked(ctx, name, channel)
 	}
 
 	slog.Info("all channels started")
 	return nil
 }
 
+// SetLazyStart makes StartAll return after starting the dispatcher and bring
+// channels up one at a time in the background, interval apart, instead of
+// connecting every channel at once. Used by the lite runtime profile to keep
+// start-up memory and CPU spikes low on small devices. Call before StartAll.
+func (m *Manager) SetLazyStart(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lazyStart = interval
+}
+
+// startLazily starts the named channels in order, waiting interval between
+// each. waitCtx is cancelled by StopAll; channels themselves get ctx so their
+// lifetime is not tied to this goroutine. Channels removed or already started
+// (e.g. by a Reload) in the meantime are skipped.
+func (m *Manager) startLazily(waitCtx, ctx context.Context, names []string, interval time.Duration) {
+	defer safego.Recover(nil, "component", "channel_lazy_start")
+
+	for i, name := range names {
+		if i > 0 {
+			select {
+			case <-waitCtx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+		m.mu.Lock()
+		if channel, ok := m.channels[name]; ok && waitCtx.Err() == nil && !channel.IsRunning() {
+			m.startChannelLocked(ctx, name, channel)
+		}
+		m.mu.Unlock()
+	}
+	slog.Info("all channels started")
+}
+
+// startChannelLocked starts one channel and records its health. Caller holds m.mu.
+func (m *Manager) startChannelLocked(ctx context.Context, name string, channel Channel) {
+	slog.Info("starting channel", "channel", name)
+	if hc, ok := channel.(interface{ MarkStarting(string) }); ok {
+		hc.MarkStarting("Starting")
+	}
+	m.syncChannelHealthLocked(name, channel)
+	if err := channel.Start(ctx); err != nil {
+		m.recordChannelStartFailureLocked(name, channel, "", err)
+		slog.Error("failed to start channel", "channel", name, "error", err)
+		return
+	}
+	m.syncChannelHealthLocked(name, channel)
+}
+
+// StartChannel starts one registered channel that is not yet running, e.g.
+// after it was re-registered on a config reload.
+func (m *Manager) StartChannel(ctx context.Context, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if channel, ok := m.channels[name]; ok && !channel.IsRunning() {
+		m.startChannelLocked(ctx, name, channel)
+	}
+}
+
 // StopAll gracefully stops all channels and the outbound dispatch loop.
 func (m *Manager) StopAll(ctx context.Context) error {
 	m.mu.Lock()
@@ -120,6 +201,14 @@ func (m *Manager) StopAll(ctx context.Context) error {
 		m.dispatchTask.cancel()
 		m.dispatchTask = nil
 	}
+	if m.superviseTask != nil {
+		m.superviseTask.cancel()
+		m.superviseTask = nil
+	}
+	if m.lazyStartTask != nil {
+		m.lazyStartTask.cancel()
+		m.lazyStartTask = nil
+	}
 
 	for name, channel := range m.channels {
 		slog.Info("stopping channel", "channel", name)
@@ -156,7 +245,7 @@ func (m *Manager) GetStatus() map[string]any {
 		status[name] = snapshot
 	}
 	for name, channel := range m.channels {
-		status[name] = snapshotChannelHealth(channel)
+		status[name] = m.withRestartInfoLocked(name, snapshotChannelHealth(channel))
 	}
 	return status
 }
@@ -183,6 +272,11 @@ func (m *Manager) RegisterChannel(name string, channel Channel) {
 			bc.SetContactCollector(m.contactCollector)
 		}
 	}
+	if m.approvalResolver != nil {
+		if ac, ok := channel.(interface{ SetApprovalResolver(ApprovalResolver) }); ok {
+			ac.SetApprovalResolver(m.approvalResolver)
+		}
+	}
 	m.channels[name] = channel
 	if hc, ok := channel.(interface{ MarkRegistered(string) }); ok {
 		hc.MarkRegistered("Configured")
@@ -315,6 +409,7 @@ func (m *Manager) UnregisterChannel(name string) {
 	defer m.mu.Unlock()
 	delete(m.channels, name)
 	delete(m.health, name)
+	delete(m.restarts, name)
 }
 
 func (m *Manager) recordHealthLocked(name string, snapshot ChannelHealth) {
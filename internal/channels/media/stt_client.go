@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 const (
@@ -35,6 +37,7 @@ func getSTTClient() *http.Client {
 		sttClient = &http.Client{
 			Timeout: 60 * time.Second, // defensive cap in case caller context has no deadline
 			Transport: &http.Transport{
+				Proxy:               netproxy.For(netproxy.Tool("stt")),
 				MaxIdleConnsPerHost: 4,
 				IdleConnTimeout:     90 * time.Second,
 			},
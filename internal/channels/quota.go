@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"math"
 	"sync"
 	"time"
 
@@ -13,12 +14,13 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
-// QuotaResult is returned by QuotaChecker.Check.
+// QuotaResult is returned by QuotaChecker.Check and CheckAgentBudget.
 type QuotaResult struct {
 	Allowed bool   // whether the request is within quota
-	Window  string // which window was exceeded: "hour", "day", "week"
-	Used    int    // current usage in the exceeded window
-	Limit   int    // configured limit for the exceeded window
+	Warning bool   // allowed, but usage reached the warn threshold (reported once per window)
+	Window  string // exceeded or warned window: "hour", "day", "week" ("month" for agent budgets)
+	Used    int    // usage in that window (includes the current request when warning; cents for budgets)
+	Limit   int    // configured limit for that window (cents for budgets)
 }
 
 // quotaCounts holds cached request counts for a user.
@@ -27,13 +29,23 @@ type quotaCounts struct {
 	fetchedAt       time.Time
 }
 
+// agentSpend holds a cached month-to-date LLM spend for an agent.
+type agentSpend struct {
+	cents     int
+	fetchedAt time.Time
+}
+
 // QuotaChecker enforces per-user/group request quotas by counting top-level
 // traces in the database. Results are cached in-memory for cacheTTL.
+// It also warns ahead of limits: once usage crosses warn_percent of a quota
+// window (or of an agent's monthly budget) a single warning is reported per window.
 // Nil-safe (nil means quota not configured).
 type QuotaChecker struct {
 	db       *sql.DB
 	config   config.QuotaConfig
 	cache    map[string]*quotaCounts
+	spend    map[uuid.UUID]*agentSpend
+	warned   map[string]time.Time // warning key → suppressed until
 	cacheTTL time.Duration
 	mu       sync.RWMutex
 	stopCh   chan struct{}
@@ -46,6 +58,8 @@ func NewQuotaChecker(db *sql.DB, cfg config.QuotaConfig) *QuotaChecker {
 		db:       db,
 		config:   cfg,
 		cache:    make(map[string]*quotaCounts),
+		spend:    make(map[uuid.UUID]*agentSpend),
+		warned:   make(map[string]time.Time),
 		cacheTTL: 60 * time.Second,
 		stopCh:   make(chan struct{}),
 	}
@@ -86,7 +100,86 @@ func (qc *QuotaChecker) Check(ctx context.Context, userID, channel, provider str
 		return QuotaResult{Allowed: false, Window: "week", Used: counts.week, Limit: window.Week}
 	}
 
-	return QuotaResult{Allowed: true}
+	return qc.warning(userID, window, counts)
+}
+
+// warning reports the window closest to its limit once usage, counting the
+// current request, reaches the warn threshold. A user is warned at most once
+// per window length so the notice is not repeated on every reply.
+func (qc *QuotaChecker) warning(userID string, window config.QuotaWindow, counts quotaCounts) QuotaResult {
+	pct := qc.warnPercent()
+	if pct == 0 {
+		return QuotaResult{Allowed: true}
+	}
+
+	windows := []struct {
+		name        string
+		used, limit int
+		length      time.Duration
+	}{
+		{"hour", counts.hour + 1, window.Hour, time.Hour},
+		{"day", counts.day + 1, window.Day, 24 * time.Hour},
+		{"week", counts.week + 1, window.Week, 7 * 24 * time.Hour},
+	}
+	best := -1
+	var bestRatio float64
+	for i, w := range windows {
+		if w.limit <= 0 || w.used*100 < w.limit*pct {
+			continue
+		}
+		if ratio := float64(w.used) / float64(w.limit); ratio > bestRatio {
+			best, bestRatio = i, ratio
+		}
+	}
+	if best < 0 {
+		return QuotaResult{Allowed: true}
+	}
+	w := windows[best]
+	if !qc.claimWarning(userID+"/"+w.name, w.length) {
+		return QuotaResult{Allowed: true}
+	}
+	return QuotaResult{Allowed: true, Warning: true, Window: w.name, Used: w.used, Limit: w.limit}
+}
+
+// CheckAgentBudget warns once per calendar month (UTC) when an agent's
+// month-to-date LLM spend reaches the warn threshold of its monthly budget.
+// Budgets are advisory here: the result is always Allowed.
+func (qc *QuotaChecker) CheckAgentBudget(ctx context.Context, agentID uuid.UUID, budgetCents int) QuotaResult {
+	pct := qc.warnPercent()
+	if budgetCents <= 0 || agentID == uuid.Nil || pct == 0 {
+		return QuotaResult{Allowed: true}
+	}
+
+	spent := qc.getAgentSpend(ctx, agentID)
+	if spent*100 < budgetCents*pct {
+		return QuotaResult{Allowed: true}
+	}
+
+	now := time.Now().UTC()
+	nextMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	if !qc.claimWarning("agent:"+agentID.String()+"/month", nextMonth.Sub(now)) {
+		return QuotaResult{Allowed: true}
+	}
+	return QuotaResult{Allowed: true, Warning: true, Window: "month", Used: spent, Limit: budgetCents}
+}
+
+func (qc *QuotaChecker) warnPercent() int {
+	qc.mu.RLock()
+	defer qc.mu.RUnlock()
+	return qc.config.EffectiveWarnPercent()
+}
+
+// claimWarning returns true if no warning for key was reported within the
+// last ttl, and records this one.
+func (qc *QuotaChecker) claimWarning(key string, ttl time.Duration) bool {
+	now := time.Now()
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+	if until, ok := qc.warned[key]; ok && now.Before(until) {
+		return false
+	}
+	qc.warned[key] = now.Add(ttl)
+	return true
 }
 
 // Increment optimistically bumps cached counts after a request is accepted.
@@ -178,6 +271,36 @@ func (qc *QuotaChecker) queryDB(ctx context.Context, userID string) quotaCounts
 	return counts
 }
 
+// getAgentSpend returns cached or fresh month-to-date spend (cents) for an agent.
+func (qc *QuotaChecker) getAgentSpend(ctx context.Context, agentID uuid.UUID) int {
+	qc.mu.RLock()
+	if s, ok := qc.spend[agentID]; ok && time.Since(s.fetchedAt) < qc.cacheTTL {
+		cents := s.cents
+		qc.mu.RUnlock()
+		return cents
+	}
+	qc.mu.RUnlock()
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var cost float64
+	err := qc.db.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(total_cost), 0) FROM traces WHERE agent_id = $1 AND created_at >= $2`,
+		agentID, monthStart,
+	).Scan(&cost)
+	if err != nil {
+		slog.Warn("quota: failed to query agent spend", "agent_id", agentID, "error", err)
+	}
+	cents := int(math.Round(cost * 100))
+
+	qc.mu.Lock()
+	qc.spend[agentID] = &agentSpend{cents: cents, fetchedAt: now}
+	qc.mu.Unlock()
+
+	return cents
+}
+
 // QuotaUsage represents usage vs limit for a single time window.
 type QuotaUsage struct {
 	Used  int `json:"used"`
@@ -324,6 +447,17 @@ func (qc *QuotaChecker) cleanupLoop() {
 					delete(qc.cache, k)
 				}
 			}
+			for k, v := range qc.spend {
+				if v.fetchedAt.Before(staleThreshold) {
+					delete(qc.spend, k)
+				}
+			}
+			now := time.Now()
+			for k, until := range qc.warned {
+				if now.After(until) {
+					delete(qc.warned, k)
+				}
+			}
 			qc.mu.Unlock()
 		}
 	}
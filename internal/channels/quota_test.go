@@ -0,0 +1,106 @@
+package channels
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+func newTestQuotaChecker(cfg config.QuotaConfig) *QuotaChecker {
+	return &QuotaChecker{
+		config:   cfg,
+		cache:    make(map[string]*quotaCounts),
+		spend:    make(map[uuid.UUID]*agentSpend),
+		warned:   make(map[string]time.Time),
+		cacheTTL: time.Minute,
+	}
+}
+
+func TestQuotaCheckerWarnsOncePerWindow(t *testing.T) {
+	qc := newTestQuotaChecker(config.QuotaConfig{Enabled: true, Default: config.QuotaWindow{Hour: 10, Day: 50}})
+	qc.cache["u1"] = &quotaCounts{hour: 3, day: 39, week: 39, fetchedAt: time.Now()}
+
+	// 40/50 daily (80%) with the current request → warn about the day window.
+	got := qc.Check(t.Context(), "u1", "telegram", "")
+	if !got.Allowed || !got.Warning || got.Window != "day" || got.Used != 40 || got.Limit != 50 {
+		t.Fatalf("Check() = %+v, want day warning at 40/50", got)
+	}
+
+	qc.Increment("u1")
+	if got := qc.Check(t.Context(), "u1", "telegram", ""); !got.Allowed || got.Warning {
+		t.Fatalf("second Check() = %+v, want allowed without a repeated warning", got)
+	}
+}
+
+func TestQuotaCheckerWarnsForClosestWindow(t *testing.T) {
+	qc := newTestQuotaChecker(config.QuotaConfig{Enabled: true, Default: config.QuotaWindow{Hour: 10, Day: 50}})
+	qc.cache["u1"] = &quotaCounts{hour: 9, day: 41, week: 41, fetchedAt: time.Now()}
+
+	got := qc.Check(t.Context(), "u1", "", "")
+	if !got.Warning || got.Window != "hour" || got.Used != 10 {
+		t.Fatalf("Check() = %+v, want hour warning (10/10 beats 42/50)", got)
+	}
+}
+
+func TestQuotaCheckerWarnPercent(t *testing.T) {
+	tests := []struct {
+		name        string
+		warnPercent int
+		day         int
+		wantWarning bool
+	}{
+		{"below default threshold", 0, 38, false},
+		{"custom threshold", 50, 24, true},
+		{"disabled", -1, 48, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qc := newTestQuotaChecker(config.QuotaConfig{
+				Enabled:     true,
+				Default:     config.QuotaWindow{Day: 50},
+				WarnPercent: tt.warnPercent,
+			})
+			qc.cache["u1"] = &quotaCounts{day: tt.day, week: tt.day, fetchedAt: time.Now()}
+			got := qc.Check(t.Context(), "u1", "", "")
+			if !got.Allowed || got.Warning != tt.wantWarning {
+				t.Fatalf("Check() = %+v, want allowed with Warning=%v", got, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestQuotaCheckerExceededIsNotAWarning(t *testing.T) {
+	qc := newTestQuotaChecker(config.QuotaConfig{Enabled: true, Default: config.QuotaWindow{Day: 50}})
+	qc.cache["u1"] = &quotaCounts{day: 50, week: 50, fetchedAt: time.Now()}
+
+	got := qc.Check(t.Context(), "u1", "", "")
+	if got.Allowed || got.Warning || got.Window != "day" {
+		t.Fatalf("Check() = %+v, want day limit exceeded", got)
+	}
+}
+
+func TestQuotaCheckerAgentBudget(t *testing.T) {
+	qc := newTestQuotaChecker(config.QuotaConfig{Enabled: true})
+	agentID := uuid.New()
+
+	qc.spend[agentID] = &agentSpend{cents: 700, fetchedAt: time.Now()}
+	if got := qc.CheckAgentBudget(t.Context(), agentID, 1000); got.Warning {
+		t.Fatalf("CheckAgentBudget() at 70%% = %+v, want no warning", got)
+	}
+
+	qc.spend[agentID] = &agentSpend{cents: 850, fetchedAt: time.Now()}
+	got := qc.CheckAgentBudget(t.Context(), agentID, 1000)
+	if !got.Allowed || !got.Warning || got.Window != "month" || got.Used != 850 || got.Limit != 1000 {
+		t.Fatalf("CheckAgentBudget() at 85%% = %+v, want month warning", got)
+	}
+	if got := qc.CheckAgentBudget(t.Context(), agentID, 1000); got.Warning {
+		t.Fatalf("repeated CheckAgentBudget() = %+v, want warning only once per month", got)
+	}
+
+	if got := qc.CheckAgentBudget(t.Context(), uuid.New(), 0); !got.Allowed || got.Warning {
+		t.Fatalf("CheckAgentBudget() without budget = %+v, want allowed", got)
+	}
+}
@@ -43,3 +43,10 @@ func CopyFinalRoutingMeta(src map[string]string) map[string]string {
 func copyRoutingMeta(src map[string]string) map[string]string {
 	return copySelectedMeta(src, routingMetaKeys)
 }
+
+// CopyRoutingMeta copies the routing metadata for messages sent to a chat
+// outside the reply to an inbound message (they must not take over its
+// placeholder).
+func CopyRoutingMeta(src map[string]string) map[string]string {
+	return copyRoutingMeta(src)
+}
@@ -257,6 +257,7 @@ func (c *Channel) handleMessage(ev *slackevents.MessageEvent) {
 		"local_key":       localKey,
 		"placeholder_key": localKey,
 	}
+	channels.SetCommandText(metadata, content)
 	if replyThreadTS != "" {
 		metadata["message_thread_id"] = replyThreadTS
 	}
@@ -94,6 +94,7 @@ func (c *Channel) handleAppMention(ev *slackevents.AppMentionEvent) {
 		"local_key":       localKey,
 		"placeholder_key": localKey,
 	}
+	channels.SetCommandText(metadata, content)
 	if replyThreadTS != "" {
 		metadata["message_thread_id"] = replyThreadTS
 	}
@@ -0,0 +1,52 @@
+package channels
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultStreamThrottle is the minimum delay between streaming preview edits
+// when a channel does not configure stream_throttle_ms.
+const DefaultStreamThrottle = 1000 * time.Millisecond
+
+// StreamPacing decides when a streaming preview should be re-edited.
+// Chunks arriving between edits are coalesced: the stream keeps only the latest
+// accumulated text as pending and pushes it once the throttle has elapsed and
+// enough new text has accumulated. Stop() always flushes whatever is pending.
+type StreamPacing struct {
+	Throttle time.Duration // min delay between edits
+	MinChars int           // min appended chars before an edit (0 = any change)
+}
+
+// NewStreamPacing builds pacing from per-channel config values.
+// throttleMs <= 0 falls back to DefaultStreamThrottle; minChars < 0 is treated as 0.
+func NewStreamPacing(throttleMs, minChars int) StreamPacing {
+	p := StreamPacing{Throttle: DefaultStreamThrottle, MinChars: minChars}
+	if throttleMs > 0 {
+		p.Throttle = time.Duration(throttleMs) * time.Millisecond
+	}
+	if p.MinChars < 0 {
+		p.MinChars = 0
+	}
+	return p
+}
+
+// Due reports whether pending should be pushed now, given the last text that
+// was sent and when. The first edit (zero lastEdit) is always due so the user
+// sees the reply start immediately. Text that does not extend the sent text
+// (buffer reset, rewrite) bypasses the MinChars gate.
+func (p StreamPacing) Due(lastEdit time.Time, sent, pending string) bool {
+	if pending == "" || pending == sent {
+		return false
+	}
+	if lastEdit.IsZero() {
+		return true
+	}
+	if time.Since(lastEdit) < p.Throttle {
+		return false
+	}
+	if p.MinChars > 0 && strings.HasPrefix(pending, sent) && len(pending)-len(sent) < p.MinChars {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package channels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewStreamPacingDefaults(t *testing.T) {
+	p := NewStreamPacing(0, -5)
+	if p.Throttle != DefaultStreamThrottle {
+		t.Fatalf("throttle = %v, want %v", p.Throttle, DefaultStreamThrottle)
+	}
+	if p.MinChars != 0 {
+		t.Fatalf("minChars = %d, want 0", p.MinChars)
+	}
+	if got := NewStreamPacing(250, 40); got.Throttle != 250*time.Millisecond || got.MinChars != 40 {
+		t.Fatalf("unexpected pacing %+v", got)
+	}
+}
+
+func TestStreamPacingDue(t *testing.T) {
+	p := StreamPacing{Throttle: time.Second, MinChars: 10}
+	old := time.Now().Add(-2 * time.Second)
+	recent := time.Now()
+
+	tests := []struct {
+		name     string
+		lastEdit time.Time
+		sent     string
+		pending  string
+		want     bool
+	}{
+		{"first edit", time.Time{}, "", "Hi", true},
+		{"unchanged", old, "Hello", "Hello", false},
+		{"empty", old, "Hello", "", false},
+		{"throttled", recent, "Hello", "Hello, world and more", false},
+		{"small delta coalesced", old, "Hello", "Hello, w", false},
+		{"large delta", old, "Hello", "Hello, world and more", true},
+		{"rewrite bypasses min chars", old, "Hello", "Bye", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Due(tt.lastEdit, tt.sent, tt.pending); got != tt.want {
+				t.Fatalf("Due() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
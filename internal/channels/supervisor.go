@@ -0,0 +1,163 @@
+package channels
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+)
+
+// Supervisor timing. A channel whose poller or socket died with a retryable
+// failure is restarted after restartBackoffBase, doubling per attempt up to
+// restartBackoffMax. The backoff resets once the channel reports healthy.
+const (
+	superviseInterval  = 5 * time.Second
+	restartBackoffBase = 5 * time.Second
+	restartBackoffMax  = 5 * time.Minute
+	restartStopTimeout = 30 * time.Second
+)
+
+type restartState struct {
+	attempts int
+	nextAt   time.Time
+}
+
+// restartBackoff returns the delay before restart attempt n (0-based).
+func restartBackoff(n int) time.Duration {
+	d := restartBackoffBase
+	for i := 0; i < n && d < restartBackoffMax; i++ {
+		d *= 2
+	}
+	return min(d, restartBackoffMax)
+}
+
+// needsRestart reports whether a snapshot describes a dead channel the
+// supervisor should bring back. Auth/config failures are not retryable and
+// wait for an operator fix instead of hammering the upstream.
+func needsRestart(h ChannelHealth) bool {
+	return h.State == ChannelHealthStateFailed && h.Retryable && !h.Running
+}
+
+func (m *Manager) supervise(ctx context.Context) {
+	defer safego.Recover(nil, "component", "channel_supervisor")
+
+	ticker := time.NewTicker(superviseInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.superviseOnce(ctx, now)
+		}
+	}
+}
+
+// superviseOnce schedules restarts for newly failed channels and restarts
+// those whose backoff has elapsed. Restarts run outside the manager lock.
+func (m *Manager) superviseOnce(ctx context.Context, now time.Time) {
+	type dueRestart struct {
+		name    string
+		channel Channel
+		attempt int
+	}
+	var due []dueRestart
+
+	m.mu.Lock()
+	for name, ch := range m.channels {
+		h := snapshotChannelHealth(ch)
+		rs := m.restarts[name]
+		if !needsRestart(h) {
+			if rs != nil && h.State == ChannelHealthStateHealthy {
+				slog.Info("channel recovered", "channel", name, "restarts", rs.attempts)
+				delete(m.restarts, name)
+			}
+			continue
+		}
+		if rs == nil {
+			rs = &restartState{nextAt: now.Add(restartBackoff(0))}
+			m.restarts[name] = rs
+			slog.Warn("channel failed, restart scheduled", "channel", name, "summary", h.Summary, "in", restartBackoff(0))
+			continue
+		}
+		if now.Before(rs.nextAt) {
+			continue
+		}
+		rs.attempts++
+		rs.nextAt = now.Add(restartBackoff(rs.attempts))
+		due = append(due, dueRestart{name: name, channel: ch, attempt: rs.attempts})
+	}
+	m.mu.Unlock()
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		m.restartChannel(ctx, d.name, d.channel, d.attempt)
+	}
+}
+
+func (m *Manager) restartChannel(ctx context.Context, name string, ch Channel, attempt int) {
+	defer safego.Recover(func(any) {
+		m.RecordFailure(name, "Channel restart panicked", nil)
+	}, "component", "channel_restart", "channel", name)
+
+	// The channel may have been reloaded or removed since it was picked.
+	if current, ok := m.GetChannel(name); !ok || current != ch {
+		return
+	}
+
+	slog.Warn("restarting channel", "channel", name, "attempt", attempt)
+	stopCtx, cancel := context.WithTimeout(ctx, restartStopTimeout)
+	if err := ch.Stop(stopCtx); err != nil {
+		slog.Warn("channel stop before restart failed", "channel", name, "error", err)
+	}
+	cancel()
+
+	if hc, ok := ch.(interface{ MarkStarting(string) }); ok {
+		hc.MarkStarting("Restarting")
+	}
+	if err := ch.Start(ctx); err != nil {
+		m.recordChannelStartFailure(name, ch, "", err)
+		slog.Error("channel restart failed", "channel", name, "attempt", attempt, "error", err)
+		return
+	}
+
+	m.mu.Lock()
+	m.syncChannelHealthLocked(name, ch)
+	m.mu.Unlock()
+	slog.Info("channel restarted", "channel", name, "attempt", attempt)
+}
+
+// withRestartInfoLocked annotates a snapshot with supervisor restart state.
+func (m *Manager) withRestartInfoLocked(name string, h ChannelHealth) ChannelHealth {
+	if rs := m.restarts[name]; rs != nil {
+		h.Restarts = rs.attempts
+		if needsRestart(h) {
+			next := rs.nextAt
+			h.NextRestartAt = &next
+		}
+	}
+	return h
+}
+
+// Readiness reports each channel's state for /readyz. ok is false when any
+// registered channel is currently failed. /readyz needs no auth, so only the
+// state is exposed; summaries and error detail stay in GetStatus, which backs
+// the authenticated channels.status RPC.
+func (m *Manager) Readiness() (ok bool, states map[string]ChannelHealthState) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ok = true
+	states = make(map[string]ChannelHealthState, len(m.channels))
+	for name, ch := range m.channels {
+		state := snapshotChannelHealth(ch).State
+		if state == ChannelHealthStateFailed {
+			ok = false
+		}
+		states[name] = state
+	}
+	return ok, states
+}
@@ -0,0 +1,75 @@
+package channels
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+)
+
+func TestRestartBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		0:  5 * time.Second,
+		1:  10 * time.Second,
+		3:  40 * time.Second,
+		10: restartBackoffMax,
+	}
+	for n, want := range cases {
+		if got := restartBackoff(n); got != want {
+			t.Errorf("restartBackoff(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestSupervisor_RestartsRetryableFailure(t *testing.T) {
+	m := NewManager(bus.New())
+	ch := newFakeHealthChannel("telegram")
+	m.RegisterChannel("telegram", ch)
+	ch.MarkFailed("Polling stopped unexpectedly", "", ChannelFailureKindNetwork, true)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	m.superviseOnce(ctx, now)
+	if ch.IsRunning() {
+		t.Fatal("restart should wait for the first backoff")
+	}
+	if ok, states := m.Readiness(); ok || states["telegram"] != ChannelHealthStateFailed {
+		t.Fatalf("readiness = %v, states = %v; want not ready with telegram failed", ok, states)
+	}
+	if next := m.GetStatus()["telegram"].(ChannelHealth).NextRestartAt; next == nil {
+		t.Fatal("status should carry the scheduled restart")
+	}
+
+	m.superviseOnce(ctx, now.Add(restartBackoff(0)+time.Second))
+	if !ch.IsRunning() {
+		t.Fatal("channel should be restarted after backoff")
+	}
+	if got := m.GetStatus()["telegram"].(ChannelHealth).Restarts; got != 1 {
+		t.Errorf("restarts = %d, want 1", got)
+	}
+
+	m.superviseOnce(ctx, now.Add(time.Minute))
+	if _, pending := m.restarts["telegram"]; pending {
+		t.Error("backoff should reset once the channel is healthy")
+	}
+	if ok, _ := m.Readiness(); !ok {
+		t.Error("healthy channel should be ready")
+	}
+}
+
+func TestSupervisor_SkipsNonRetryableFailure(t *testing.T) {
+	m := NewManager(bus.New())
+	ch := newFakeHealthChannel("discord")
+	m.RegisterChannel("discord", ch)
+	ch.MarkFailed("Authentication failed", "", ChannelFailureKindAuth, false)
+
+	now := time.Now()
+	for i := range 3 {
+		m.superviseOnce(context.Background(), now.Add(time.Duration(i)*restartBackoffMax))
+	}
+	if ch.IsRunning() {
+		t.Error("auth failures must wait for an operator fix, not be restarted")
+	}
+}
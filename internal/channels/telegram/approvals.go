@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mymmrac/telego"
+	tu "github.com/mymmrac/telego/telegoutil"
+
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
+)
+
+// Callback data for approval buttons: "ap:<o|d>:<approval ID>". There is no
+// allow-always button: it would extend the shared allowlist for every
+// session, so it stays an admin action over WS.
+var approvalCallbackDecisions = map[string]string{
+	"o": "allow-once",
+	"d": "deny",
+}
+
+// SendApprovalPrompt posts a tool approval request with Allow / Deny inline
+// buttons. Implements channels.ApprovalChannel.
+func (c *Channel) SendApprovalPrompt(ctx context.Context, prompt channels.ApprovalPrompt) error {
+	chatID, err := parseRawChatID(prompt.ChatID)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID: %w", err)
+	}
+	var threadID int
+	if idx := strings.Index(prompt.ChatID, ":topic:"); idx > 0 {
+		fmt.Sscanf(prompt.ChatID[idx+7:], "%d", &threadID)
+	}
+
+	msg := tu.Message(tu.ID(chatID), formatApprovalPrompt(prompt))
+	msg.ParseMode = telego.ModeHTML
+	msg.MessageThreadID = resolveThreadIDForSend(threadID)
+	msg.ReplyMarkup = &telego.InlineKeyboardMarkup{InlineKeyboard: [][]telego.InlineKeyboardButton{{
+		{Text: "✅ Allow", CallbackData: "ap:o:" + prompt.ID},
+		{Text: "❌ Deny", CallbackData: "ap:d:" + prompt.ID},
+	}}}
+	if _, err := c.bot.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	c.approvalPrompts.Store(prompt.ID, prompt)
+	time.AfterFunc(time.Until(prompt.ExpiresAt)+time.Minute, func() {
+		c.approvalPrompts.Delete(prompt.ID)
+	})
+	return nil
+}
+
+// handleApprovalCallback handles "ap:" button presses on approval prompts.
+// Only the prompt's approvers (the configured owners) may answer.
+func (c *Channel) handleApprovalCallback(ctx context.Context, query *telego.CallbackQuery) {
+	code, id, ok := strings.Cut(strings.TrimPrefix(query.Data, "ap:"), ":")
+	decision := approvalCallbackDecisions[code]
+	if !ok || decision == "" {
+		return
+	}
+
+	v, found := c.approvalPrompts.Load(id)
+	if !found {
+		c.finishApprovalPrompt(ctx, query, "", "⌛ This request has expired.")
+		return
+	}
+	prompt := v.(channels.ApprovalPrompt)
+
+	actorID := fmt.Sprintf("%d", query.From.ID)
+	if !prompt.CanAnswer(actorID) {
+		slog.Info("telegram: approval answer from non-approver ignored", "id", id, "user_id", actorID)
+		return
+	}
+
+	resolve := c.ApprovalResolver()
+	if resolve == nil {
+		return
+	}
+	c.approvalPrompts.Delete(id)
+	if err := resolve(id, decision, actorID); err != nil {
+		slog.Info("telegram: approval resolve failed", "id", id, "error", err)
+		c.finishApprovalPrompt(ctx, query, formatApprovalPrompt(prompt), "⌛ This request has expired.")
+		return
+	}
+
+	who := query.From.FirstName
+	if query.From.Username != "" {
+		who = "@" + query.From.Username
+	}
+	status := "✅ Allowed once by " + escapeHTML(who)
+	if decision == "deny" {
+		status = "❌ Denied by " + escapeHTML(who)
+	}
+	c.finishApprovalPrompt(ctx, query, formatApprovalPrompt(prompt), status)
+}
+
+// finishApprovalPrompt replaces the prompt's buttons with the outcome.
+func (c *Channel) finishApprovalPrompt(ctx context.Context, query *telego.CallbackQuery, body, status string) {
+	msg := query.Message
+	if msg == nil {
+		return
+	}
+	text := status
+	if body != "" {
+		text = body + "\n\n" + status
+	}
+	if err := c.editMessage(ctx, msg.GetChat().ID, msg.GetMessageID(), text); err != nil {
+		slog.Debug("telegram: approval prompt edit failed", "error", err)
+	}
+}
+
+// formatApprovalPrompt renders the prompt body as Telegram HTML.
+func formatApprovalPrompt(p channels.ApprovalPrompt) string {
+	var sb strings.Builder
+	sb.WriteString("🔐 <b>Approval needed</b>\n")
+	sb.WriteString(fmt.Sprintf("Tool: <code>%s</code>\n", escapeHTML(p.Tool)))
+	if p.Requester != "" {
+		sb.WriteString(fmt.Sprintf("Requested by: <code>%s</code>\n", escapeHTML(p.Requester)))
+	}
+	if p.Summary != "" {
+		sb.WriteString(fmt.Sprintf("<pre>%s</pre>\n", escapeHTML(truncateStr(p.Summary, 500))))
+	}
+	if !p.ExpiresAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("Denied automatically in %s if unanswered.", time.Until(p.ExpiresAt).Round(time.Second)))
+	}
+	return sb.String()
+}
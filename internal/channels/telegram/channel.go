@@ -18,6 +18,9 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
@@ -45,6 +48,7 @@ type Channel struct {
 	handlerWg         sync.WaitGroup     // tracks in-flight handler goroutines for graceful shutdown
 	handlerSem        chan struct{}      // bounded semaphore for concurrent handler goroutines
 	pendingDraftID    sync.Map           // localKey string → int (draftID)
+	approvalPrompts   sync.Map           // approval ID → channels.ApprovalPrompt (for sender checks on callback)
 	audioMgr          *audio.Manager    // unified STT via audio.Manager (nil = no STT)
 	writerHealMu      sync.Mutex         // guards writerHealLastTry for /writers self-heal
 	writerHealLastTry map[string]time.Time // key "chatID|userID" → last attempt timestamp
@@ -110,6 +114,8 @@ func New(cfg config.TelegramConfig, msgBus *bus.MessageBus, pairingSvc store.Pai
 			return nil, fmt.Errorf("invalid proxy URL %q: %w", cfg.Proxy, parseErr)
 		}
 		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = netproxy.For(netproxy.Channel("telegram"))
 	}
 
 	httpClient := &http.Client{
@@ -226,31 +232,32 @@ func (c *Channel) Start(ctx context.Context) error {
 		commands := DefaultMenuCommands()
 		syncCtx, cancel := context.WithTimeout(pollCtx, probeOverallTimeout)
 		defer cancel()
-		var lastErr error
 
-		for attempt := 1; attempt <= 3; attempt++ {
-			if err := c.SyncMenuCommands(syncCtx, commands); err != nil {
-				lastErr = err
+		err := retry.Do(syncCtx, retry.Policy{
+			Name:     "telegram.menu_sync",
+			Backoff:  retry.Backoff{Attempts: 3, MinDelay: 5 * time.Second, Jitter: 0.1},
+			Classify: retry.Always,
+			OnRetry: func(attempt int, err error, _ time.Duration) {
 				slog.Warn("failed to sync telegram menu commands", "error", err, "attempt", attempt)
-				if attempt < 3 {
-					select {
-					case <-syncCtx.Done():
-						return
-					case <-time.After(time.Duration(attempt*5) * time.Second):
-					}
-				}
-			} else {
-				slog.Info("telegram menu commands synced")
-				return
-			}
-		}
-		if lastErr != nil {
-			slog.Warn("telegram menu commands remain unsynced", "error", lastErr)
+			},
+		}, func(ctx context.Context) error {
+			return c.SyncMenuCommands(ctx, commands)
+		})
+		switch {
+		case err == nil:
+			slog.Info("telegram menu commands synced")
+		case syncCtx.Err() == nil:
+			slog.Warn("telegram menu commands remain unsynced", "error", err)
 		}
 	}()
 
 	go func() {
 		defer close(c.pollDone)
+		// A panic here would otherwise leave the bot silently dead; mark it
+		// failed so the channel supervisor restarts polling.
+		defer safego.Recover(func(any) {
+			c.MarkFailed("Polling crashed", "Telegram polling loop panicked. Review server logs for the stack trace.", channels.ChannelFailureKindUnknown, true)
+		}, "component", "telegram_poll", "channel", c.Name())
 		for {
 			select {
 			case <-pollCtx.Done():
@@ -270,6 +277,7 @@ func (c *Channel) Start(ctx context.Context) error {
 						go func(u telego.Update) {
 							defer c.handlerWg.Done()
 							defer func() { <-c.handlerSem }()
+							defer safego.Recover(nil, "component", "telegram_handler", "channel", c.Name())
 							c.handleMessage(pollCtx, u)
 						}(update)
 					case <-pollCtx.Done():
@@ -282,6 +290,7 @@ func (c *Channel) Start(ctx context.Context) error {
 						go func(q *telego.CallbackQuery) {
 							defer c.handlerWg.Done()
 							defer func() { <-c.handlerSem }()
+							defer safego.Recover(nil, "component", "telegram_callback", "channel", c.Name())
 							c.handleCallbackQuery(pollCtx, q)
 						}(update.CallbackQuery)
 					case <-pollCtx.Done():
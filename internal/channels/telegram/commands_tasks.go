@@ -207,6 +207,10 @@ func (c *Channel) handleCallbackQuery(ctx context.Context, query *telego.Callbac
 		c.handleSubagentCallback(ctx, query)
 		return
 	}
+	if strings.HasPrefix(query.Data, "ap:") {
+		c.handleApprovalCallback(ctx, query)
+		return
+	}
 
 	if !strings.HasPrefix(query.Data, "td:") {
 		return
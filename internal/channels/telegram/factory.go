@@ -31,12 +31,15 @@ type telegramInstanceConfig struct {
 	GroupStream     *bool    `json:"group_stream,omitempty"`
 	DraftTransport  *bool    `json:"draft_transport,omitempty"`   // sendMessageDraft for DM streaming (default true)
 	ReasoningStream *bool    `json:"reasoning_stream,omitempty"` // show reasoning as separate message (default true)
+	StreamThrottleMs int     `json:"stream_throttle_ms,omitempty"` // min delay between streaming edits (default 1000ms)
+	StreamMinChars  int      `json:"stream_min_chars,omitempty"`   // coalesce chunks until this many new chars (default 0)
 	ReactionLevel   string   `json:"reaction_level,omitempty"`
 	MediaMaxMB      int64    `json:"media_max_mb,omitempty"`
 	MediaMaxBytes   int64    `json:"media_max_bytes,omitempty"` // deprecated: use media_max_mb
 	LinkPreview     *bool    `json:"link_preview,omitempty"`
 	BlockReply      *bool    `json:"block_reply,omitempty"`
 	ForceIPv4       bool     `json:"force_ipv4,omitempty"`
+	STTLanguage     string   `json:"stt_language,omitempty"`
 	AllowFrom       []string `json:"allow_from,omitempty"`
 }
 
@@ -110,11 +113,14 @@ func buildChannel(name string, creds json.RawMessage, cfg json.RawMessage,
 		GroupStream:     ic.GroupStream,
 		DraftTransport:  ic.DraftTransport,
 		ReasoningStream: ic.ReasoningStream,
+		StreamThrottleMs: ic.StreamThrottleMs,
+		StreamMinChars:  ic.StreamMinChars,
 		ReactionLevel:   ic.ReactionLevel,
 		MediaMaxBytes:  resolveMediaMaxBytes(ic),
 		LinkPreview:    ic.LinkPreview,
 		BlockReply:     ic.BlockReply,
 		ForceIPv4:      ic.ForceIPv4,
+		STTLanguage:    ic.STTLanguage,
 	}
 
 	// DB instances default to "pairing" for groups (secure by default).
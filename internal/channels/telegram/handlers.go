@@ -420,8 +420,8 @@ func (c *Channel) handleMessage(ctx context.Context, update telego.Update) {
 				var transcript string
 				var sttErr error
 				if c.audioMgr != nil {
-					sttCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: m.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{})
+					sttCtx, cancel := context.WithTimeout(audio.WithChannel(ctx, channels.TypeTelegram), 10*time.Second)
+					res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: m.FilePath, MimeType: "audio/ogg"}, audio.STTOptions{Language: c.config.STTLanguage})
 					cancel()
 					if err == nil && res != nil {
 						transcript = res.Text
@@ -584,6 +584,7 @@ func (c *Channel) handleMessage(ctx context.Context, update telego.Update) {
 		"is_group":   fmt.Sprintf("%t", isGroup),
 		"local_key":  localKey,
 	}
+	channels.SetCommandText(metadata, content)
 	if message.Chat.Title != "" {
 		metadata[tools.MetaChatTitle] = message.Chat.Title
 	}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/channels/media"
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 )
 
@@ -198,23 +199,16 @@ func (c *Channel) resolveMedia(ctx context.Context, msg *telego.Message) ([]Medi
 // points to that server instead of the official api.telegram.org, removing the
 // standard 20 MB file size limit. Downstream providers enforce their own limits.
 func (c *Channel) downloadMedia(ctx context.Context, fileID string, maxBytes int64) (string, error) {
-	var file *telego.File
-	var err error
-
 	// Retry up to downloadMaxRetries times with exponential backoff
-	for attempt := 1; attempt <= downloadMaxRetries; attempt++ {
-		file, err = c.bot.GetFile(ctx, &telego.GetFileParams{FileID: fileID})
-		if err == nil {
-			break
-		}
-		if attempt < downloadMaxRetries {
-			slog.Debug("retrying file download", "file_id", fileID, "attempt", attempt, "error", err)
-			select {
-			case <-ctx.Done():
-				return "", ctx.Err()
-			case <-time.After(time.Duration(attempt) * time.Second):
-			}
-		}
+	file, err := retry.DoValue(ctx, retry.Policy{
+		Name:     "telegram.get_file",
+		Backoff:  retry.Backoff{Attempts: downloadMaxRetries, MinDelay: time.Second, Jitter: 0.1},
+		Classify: retry.Always,
+	}, func(ctx context.Context) (*telego.File, error) {
+		return c.bot.GetFile(ctx, &telego.GetFileParams{FileID: fileID})
+	})
+	if ctx.Err() != nil {
+		return "", ctx.Err()
 	}
 	if err != nil {
 		return "", fmt.Errorf("get file info after %d attempts: %w", downloadMaxRetries, err)
@@ -18,9 +18,6 @@ import (
 )
 
 const (
-	// defaultStreamThrottle is the minimum delay between message edits (matching TS: 1000ms).
-	defaultStreamThrottle = 1000 * time.Millisecond
-
 	// streamMaxChars is the max message length for streaming (Telegram limit).
 	streamMaxChars = 4096
 
@@ -82,7 +79,7 @@ type DraftStream struct {
 	messageThreadID int           // forum topic thread ID (0 = no thread)
 	messageID       int           // 0 = not yet created (message transport only)
 	lastText        string        // last sent text (for dedup)
-	throttle        time.Duration // min delay between edits
+	pacing          channels.StreamPacing // edit throttle + chunk coalescing
 	lastEdit        time.Time
 	mu              sync.Mutex
 	stopped         bool
@@ -96,11 +93,7 @@ type DraftStream struct {
 // NewDraftStream creates a new streaming preview manager.
 // When useDraft is true, the stream will attempt to use sendMessageDraft (Bot API 9.3+)
 // and automatically fall back to sendMessage+editMessageText if the API rejects it.
-func NewDraftStream(bot *telego.Bot, chatID int64, throttleMs int, messageThreadID int, useDraft bool) *DraftStream {
-	throttle := defaultStreamThrottle
-	if throttleMs > 0 {
-		throttle = time.Duration(throttleMs) * time.Millisecond
-	}
+func NewDraftStream(bot *telego.Bot, chatID int64, pacing channels.StreamPacing, messageThreadID int, useDraft bool) *DraftStream {
 	var draftID int
 	if useDraft {
 		draftID = allocateDraftID()
@@ -109,14 +102,15 @@ func NewDraftStream(bot *telego.Bot, chatID int64, throttleMs int, messageThread
 		bot:             bot,
 		chatID:          chatID,
 		messageThreadID: messageThreadID,
-		throttle:        throttle,
+		pacing:          pacing,
 		useDraft:        useDraft,
 		draftID:         draftID,
 	}
 }
 
 // Update sends or edits the streaming message with the latest text.
-// Throttled to avoid hitting Telegram rate limits.
+// Throttled and coalesced (see channels.StreamPacing) to avoid hitting Telegram
+// rate limits; text held back here is sent by the next due Update or by Stop.
 func (ds *DraftStream) Update(ctx context.Context, text string) {
 	ds.mu.Lock()
 	defer ds.mu.Unlock()
@@ -137,8 +131,8 @@ func (ds *DraftStream) Update(ctx context.Context, text string) {
 
 	ds.pending = text
 
-	// Check throttle
-	if time.Since(ds.lastEdit) < ds.throttle {
+	// Check throttle + coalescing
+	if !ds.pacing.Due(ds.lastEdit, ds.lastText, text) {
 		return
 	}
 
@@ -308,7 +302,8 @@ func (c *Channel) CreateStream(ctx context.Context, chatID string, firstStream b
 	// reasoning lane — draft messages are ephemeral and would disappear
 	// when the answer stream starts.
 	useDraft := isDM && !firstStream && c.draftTransportEnabled()
-	ds := NewDraftStream(c.bot, id, 0, threadID, useDraft)
+	pacing := channels.NewStreamPacing(c.config.StreamThrottleMs, c.config.StreamMinChars)
+	ds := NewDraftStream(c.bot, id, pacing, threadID, useDraft)
 
 	// No placeholder seeding — DraftStream creates its own message on first flush().
 	// This avoids "reply to deleted/non-existent message" artifacts.
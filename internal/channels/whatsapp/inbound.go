@@ -66,7 +66,8 @@ func (c *Channel) handleIncomingMessage(evt *events.Message) {
 		return
 	}
 
-	content := extractTextContent(evt.Message)
+	text := extractTextContent(evt.Message)
+	content := text
 
 	var mediaList []media.MediaInfo
 	mediaList = c.downloadMedia(evt)
@@ -110,6 +111,7 @@ func (c *Channel) handleIncomingMessage(evt *events.Message) {
 	if evt.Info.PushName != "" {
 		metadata["user_name"] = evt.Info.PushName
 	}
+	channels.SetCommandText(metadata, text)
 
 	// STT: transcribe audio items (opt-in via builtin_tools[stt].settings.whatsapp_enabled,
 	// default false per Decision 6 — enabling breaks E2E encryption for voice messages).
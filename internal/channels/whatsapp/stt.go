@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/audio"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 )
 
@@ -53,9 +54,9 @@ func (c *Channel) transcribeVoice(ctx context.Context, filePath, mimeType, local
 	if c.audioMgr == nil {
 		return fallback
 	}
-	sttCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	sttCtx, cancel := context.WithTimeout(audio.WithChannel(ctx, channels.TypeWhatsApp), 10*time.Second)
 	defer cancel()
-	res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: filePath, MimeType: mimeType}, audio.STTOptions{})
+	res, err := c.audioMgr.Transcribe(sttCtx, audio.STTInput{FilePath: filePath, MimeType: mimeType}, audio.STTOptions{Language: c.config.STTLanguage})
 	if err != nil || res == nil {
 		slog.Warn("whatsapp: stt failed or timed out", "error", err, "file", filePath)
 		return fallback
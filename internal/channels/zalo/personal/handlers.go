@@ -48,6 +48,7 @@ func (c *Channel) handleDM(msg protocol.UserMessage) {
 	}
 
 	// Annotate with sender display name so the agent knows who is messaging.
+	text := content
 	senderName := msg.Data.DName
 	if senderName != "" {
 		content = fmt.Sprintf("[From: %s]\n%s", senderName, content)
@@ -72,6 +73,7 @@ func (c *Channel) handleDM(msg protocol.UserMessage) {
 		"platform":     channels.TypeZaloPersonal,
 		"display_name": channels.SanitizeDisplayName(senderName),
 	}
+	channels.SetCommandText(metadata, text)
 	c.HandleMessage(senderID, threadID, content, media, metadata, "direct")
 }
 
@@ -153,6 +155,7 @@ func (c *Channel) handleGroupMessage(msg protocol.GroupMessage) {
 		"group_id":     threadID,
 		"display_name": channels.SanitizeDisplayName(senderName),
 	}
+	channels.SetCommandText(metadata, content)
 	c.HandleMessage(senderID, threadID, finalContent, allMedia, metadata, "group")
 
 	// Clear pending history after sending to agent (matches Telegram/Discord/Slack/Feishu pattern).
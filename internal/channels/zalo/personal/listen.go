@@ -6,7 +6,9 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/channels/zalo/personal/protocol"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 )
 
 const (
@@ -17,6 +19,9 @@ const (
 
 func (c *Channel) listenLoop(ctx context.Context) {
 	defer c.SetRunning(false)
+	defer safego.Recover(func(any) {
+		c.MarkFailed("Listener crashed", "Zalo listener loop panicked. Review server logs for the stack trace.", channels.ChannelFailureKindUnknown, true)
+	}, "component", "zalo_listener", "channel", c.Name())
 	for {
 		if !c.runListenerLoop(ctx) {
 			return
@@ -11,6 +11,8 @@ import (
 	"sync"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // WSClient wraps gorilla/websocket with a thread-safe write method.
@@ -24,6 +26,7 @@ type WSClient struct {
 // Go's cookiejar domain-matching limitations with wss:// URLs and host-only cookies.
 func DialWS(ctx context.Context, wsURL string, headers http.Header, jar http.CookieJar) (*WSClient, error) {
 	dialer := websocket.Dialer{
+		Proxy:             netproxy.For(netproxy.Channel("zalo_personal")),
 		EnableCompression: true,
 	}
 	// Don't pass jar to gorilla — we inject cookies manually below.
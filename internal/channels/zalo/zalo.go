@@ -19,6 +19,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/channels"
+	"github.com/nextlevelbuilder/goclaw/internal/channels/media"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
@@ -244,13 +245,8 @@ func (c *Channel) handleImageMessage(msg *zaloMessage) {
 		return
 	}
 
-	content := msg.Caption
-	if content == "" {
-		content = "[image]"
-	}
-
 	// Download photo from Zalo CDN to local temp file (CDN URLs are auth-restricted/expiring)
-	var media []string
+	var localPath string
 	var photoURL string
 	switch {
 	case msg.PhotoURL != "":
@@ -260,21 +256,20 @@ func (c *Channel) handleImageMessage(msg *zaloMessage) {
 	}
 
 	if photoURL != "" {
-		localPath, err := c.downloadMedia(photoURL)
+		path, err := c.downloadMedia(photoURL)
 		if err != nil {
-			slog.Warn("zalo photo download failed, passing URL as fallback",
-				"photo_url", photoURL, "error", err)
-			media = []string{photoURL}
+			slog.Warn("zalo photo download failed", "photo_url", photoURL, "error", err)
 		} else {
-			media = []string{localPath}
+			localPath = path
 		}
 	}
+	content, mediaPaths := imageMessageContent(msg.Caption, localPath)
 
 	slog.Info("zalo image message received",
 		"sender_id", senderID,
 		"chat_id", chatID,
 		"photo_url", photoURL,
-		"has_media", len(media) > 0,
+		"has_media", len(mediaPaths) > 0,
 	)
 
 	metadata := map[string]string{
@@ -282,7 +277,25 @@ func (c *Channel) handleImageMessage(msg *zaloMessage) {
 		"platform":   "zalo",
 	}
 
-	c.HandleMessage(senderID, chatID, content, media, metadata, "direct")
+	c.HandleMessage(senderID, chatID, content, mediaPaths, metadata, "direct")
+}
+
+// imageMessageContent builds the inbound text for a photo: a <media:image>
+// tag (which the agent loop uses to attach or route the image) followed by
+// the caption. Without a downloaded file the image is reported as missing —
+// the CDN URL is auth-restricted, so the agent could not load it either.
+func imageMessageContent(caption, localPath string) (string, []string) {
+	if localPath == "" {
+		if caption == "" {
+			return "[image could not be downloaded]", nil
+		}
+		return "[image could not be downloaded]\n\n" + caption, nil
+	}
+	tags := media.BuildMediaTags([]media.MediaInfo{{Type: media.TypeImage, FilePath: localPath}})
+	if caption == "" {
+		return tags, []string{localPath}
+	}
+	return tags + "\n\n" + caption, []string{localPath}
 }
 
 // --- DM Policy ---
@@ -552,3 +552,20 @@ func TestZaloAPIResponse_Roundtrip(t *testing.T) {
 		t.Error("OK field lost in round-trip")
 	}
 }
+
+// TestImageMessageContent verifies photos carry a <media:image> tag so the
+// agent loop attaches them, and failed downloads are reported instead of
+// passing the auth-restricted CDN URL on.
+func TestImageMessageContent(t *testing.T) {
+	content, paths := imageMessageContent("what is this?", "/tmp/goclaw_zalo_1.jpg")
+	if content != "<media:image>\n\nwhat is this?" || len(paths) != 1 || paths[0] != "/tmp/goclaw_zalo_1.jpg" {
+		t.Errorf("got %q, %v", content, paths)
+	}
+	if content, _ := imageMessageContent("", "/tmp/x.jpg"); content != "<media:image>" {
+		t.Errorf("no caption: got %q", content)
+	}
+	content, paths = imageMessageContent("caption", "")
+	if paths != nil || !strings.HasPrefix(content, "[image could not be downloaded]") || !strings.HasSuffix(content, "caption") {
+		t.Errorf("failed download: got %q, %v", content, paths)
+	}
+}
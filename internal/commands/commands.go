@@ -0,0 +1,229 @@
+// Package commands is the channel-agnostic slash-command framework.
+//
+// Inbound messages starting with "/" are parsed before they reach the agent
+// loop. A recognised command has its arguments validated and its handler run;
+// the Reply is rendered for the originating channel and sent back directly,
+// without an LLM call. Unknown commands fall through to the agent as text.
+//
+// Commands are registered globally (available to every agent) or for a single
+// agent key. Agent commands cannot shadow global ones, so built-ins like
+// /reset behave the same everywhere.
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+)
+
+// Command is a slash command.
+type Command struct {
+	Name        string // without the leading "/"; lowercase
+	Aliases     []string
+	Usage       string // argument synopsis, e.g. "<duration|off>"
+	Description string
+	MinArgs     int
+	MaxArgs     int // < 0 = unlimited
+
+	// Validate checks arguments beyond their count. Optional.
+	Validate func(args []string) error
+	Run      func(ctx context.Context, inv *Invocation) (Reply, error)
+}
+
+// Invocation is a parsed command call.
+type Invocation struct {
+	Name    string   // canonical command name
+	Args    []string // whitespace-separated arguments
+	RawArgs string   // everything after the command name, trimmed
+
+	AgentID    string // agent key the message is routed to
+	SessionKey string
+	UserID     string // scoped user ID (group chats share one)
+	Msg        bus.InboundMessage
+}
+
+// Reply is a command's response. Title and Lines are rendered per channel;
+// Text is appended as-is.
+type Reply struct {
+	Title string
+	Lines []string
+	Text  string
+
+	// Forward, when set, is sent to the agent as the user's message instead
+	// of replying directly (custom prompt commands).
+	Forward string
+}
+
+// UsageError reports invalid arguments. Its message includes the usage line.
+type UsageError struct {
+	Command *Command
+	Reason  string
+}
+
+func (e *UsageError) Error() string {
+	usage := "Usage: /" + e.Command.Name
+	if e.Command.Usage != "" {
+		usage += " " + e.Command.Usage
+	}
+	if e.Reason == "" {
+		return usage
+	}
+	return e.Reason + "\n" + usage
+}
+
+// CheckArgs validates the argument count and runs Validate.
+func (c *Command) CheckArgs(args []string) error {
+	if len(args) < c.MinArgs || (c.MaxArgs >= 0 && len(args) > c.MaxArgs) {
+		return &UsageError{Command: c}
+	}
+	if c.Validate != nil {
+		if err := c.Validate(args); err != nil {
+			return &UsageError{Command: c, Reason: err.Error()}
+		}
+	}
+	return nil
+}
+
+// Parse splits "/name@bot arg1 arg2" into its parts. ok is false when text is
+// not a command. The name is lowercased and any "@bot" suffix is dropped.
+func Parse(text string) (name string, args []string, rawArgs string, ok bool) {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 || text[0] != '/' {
+		return "", nil, "", false
+	}
+	head, rest := text[1:], ""
+	if i := strings.IndexFunc(head, unicode.IsSpace); i >= 0 {
+		head, rest = head[:i], head[i:]
+	}
+	head, _, _ = strings.Cut(head, "@")
+	if head == "" || strings.ContainsRune(head, '/') {
+		return "", nil, "", false // a path like "/usr/bin", not a command
+	}
+	rawArgs = strings.TrimSpace(rest)
+	return strings.ToLower(head), strings.Fields(rawArgs), rawArgs, true
+}
+
+// Registry holds global and per-agent commands. Safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	global map[string]*Command
+	agents map[string]map[string]*Command // agent key → name/alias → command
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{global: map[string]*Command{}, agents: map[string]map[string]*Command{}}
+}
+
+// Register adds a command available to every agent.
+func (r *Registry) Register(cmd Command) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return register(r.global, nil, &cmd)
+}
+
+// RegisterForAgent adds a command available only to agentKey.
+func (r *Registry) RegisterForAgent(agentKey string, cmd Command) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.agents[agentKey] == nil {
+		r.agents[agentKey] = map[string]*Command{}
+	}
+	return register(r.agents[agentKey], r.global, &cmd)
+}
+
+// UnregisterAgent drops all commands registered for agentKey.
+func (r *Registry) UnregisterAgent(agentKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.agents, agentKey)
+}
+
+func register(into, reserved map[string]*Command, cmd *Command) error {
+	if cmd.Name == "" || cmd.Run == nil {
+		return errors.New("command needs a name and a Run func")
+	}
+	for _, n := range append([]string{cmd.Name}, cmd.Aliases...) {
+		if _, taken := into[n]; taken {
+			return fmt.Errorf("command /%s already registered", n)
+		}
+		if _, taken := reserved[n]; taken {
+			return fmt.Errorf("command /%s is built in", n)
+		}
+	}
+	for _, n := range append([]string{cmd.Name}, cmd.Aliases...) {
+		into[n] = cmd
+	}
+	return nil
+}
+
+// Lookup finds a command by name or alias for agentKey. Global commands win,
+// then commands registered for the agent, then extra (e.g. loaded from the
+// agent's config).
+func (r *Registry) Lookup(agentKey, name string, extra ...Command) (*Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if c, ok := r.global[name]; ok {
+		return c, true
+	}
+	if c, ok := r.agents[agentKey][name]; ok {
+		return c, true
+	}
+	for i := range extra {
+		if extra[i].Name == name || slices.Contains(extra[i].Aliases, name) {
+			return &extra[i], true
+		}
+	}
+	return nil, false
+}
+
+// List returns the commands available to agentKey, sorted by name. extra
+// entries that clash with a registered command are left out.
+func (r *Registry) List(agentKey string, extra ...Command) []Command {
+	r.mu.RLock()
+	seen := map[string]bool{}
+	var out []Command
+	for _, m := range []map[string]*Command{r.global, r.agents[agentKey]} {
+		for _, c := range m {
+			if !seen[c.Name] {
+				seen[c.Name] = true
+				out = append(out, *c)
+			}
+		}
+	}
+	r.mu.RUnlock()
+	for _, c := range extra {
+		if !seen[c.Name] {
+			seen[c.Name] = true
+			out = append(out, c)
+		}
+	}
+	slices.SortFunc(out, func(a, b Command) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// Dispatch parses text and runs the matching command. handled is false when
+// text is not a command or the command is unknown, so the caller can pass the
+// message on to the agent. Argument errors are returned as a usage Reply.
+func (r *Registry) Dispatch(ctx context.Context, text string, inv Invocation, extra ...Command) (reply Reply, handled bool, err error) {
+	name, args, raw, ok := Parse(text)
+	if !ok {
+		return Reply{}, false, nil
+	}
+	cmd, ok := r.Lookup(inv.AgentID, name, extra...)
+	if !ok {
+		return Reply{}, false, nil
+	}
+	if err := cmd.CheckArgs(args); err != nil {
+		return Reply{Text: err.Error()}, true, nil
+	}
+	inv.Name, inv.Args, inv.RawArgs = cmd.Name, args, raw
+	reply, err = cmd.Run(ctx, &inv)
+	return reply, true, err
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func echo(name string) Command {
+	return Command{
+		Name:    name,
+		MaxArgs: -1,
+		Run: func(_ context.Context, inv *Invocation) (Reply, error) {
+			return Reply{Text: inv.Name + ":" + inv.RawArgs}, nil
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		name    string
+		args    int
+		raw     string
+		wantCmd bool
+	}{
+		{"/help", "help", 0, "", true},
+		{"  /Model@my_bot  gpt-4o ", "model", 1, "gpt-4o", true},
+		{"/memory project  deadlines", "memory", 2, "project  deadlines", true},
+		{"/usr/bin/env", "", 0, "", false},
+		{"hello /help", "", 0, "", false},
+		{"/", "", 0, "", false},
+		{"/@bot", "", 0, "", false},
+	}
+	for _, tt := range tests {
+		name, args, raw, ok := Parse(tt.in)
+		if ok != tt.wantCmd || name != tt.name || len(args) != tt.args || raw != tt.raw {
+			t.Errorf("Parse(%q) = %q %v %q %v", tt.in, name, args, raw, ok)
+		}
+	}
+}
+
+func TestRegistryAgentCannotShadowGlobal(t *testing.T) {
+	r := NewRegistry()
+	if err := r.Register(echo("reset")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterForAgent("a", echo("reset")); err == nil {
+		t.Fatal("expected error registering agent command over a built-in")
+	}
+	if err := r.RegisterForAgent("a", echo("deploy")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Lookup("b", "deploy"); ok {
+		t.Fatal("agent command leaked to another agent")
+	}
+	if _, ok := r.Lookup("a", "deploy"); !ok {
+		t.Fatal("agent command not found")
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	r := NewRegistry()
+	mute := echo("mute")
+	mute.Usage, mute.MinArgs, mute.MaxArgs = "<duration|off>", 1, 1
+	_ = r.Register(mute)
+
+	if _, handled, _ := r.Dispatch(context.Background(), "/unknown", Invocation{}); handled {
+		t.Fatal("unknown command should fall through")
+	}
+	reply, handled, err := r.Dispatch(context.Background(), "/mute", Invocation{})
+	if !handled || err != nil || !strings.Contains(reply.Text, "Usage: /mute <duration|off>") {
+		t.Fatalf("missing args: %+v %v %v", reply, handled, err)
+	}
+	reply, _, _ = r.Dispatch(context.Background(), "/mute 1h", Invocation{})
+	if reply.Text != "mute:1h" {
+		t.Fatalf("reply = %q", reply.Text)
+	}
+
+	custom := echo("standup")
+	reply, handled, _ = r.Dispatch(context.Background(), "/standup today", Invocation{}, custom)
+	if !handled || reply.Text != "standup:today" {
+		t.Fatalf("extra command: %+v %v", reply, handled)
+	}
+}
+
+func TestRender(t *testing.T) {
+	r := Reply{Title: "Commands", Lines: []string{"/help"}, Text: "done"}
+	if got := Render(r, StyleMarkdown); got != "**Commands**\n- /help\n\ndone" {
+		t.Errorf("markdown = %q", got)
+	}
+	if got := Render(r, StylePlain); got != "Commands\n• /help\n\ndone" {
+		t.Errorf("plain = %q", got)
+	}
+}
@@ -0,0 +1,57 @@
+package commands
+
+import "strings"
+
+// Style is how a channel displays command replies.
+type Style int
+
+const (
+	// StyleMarkdown is for channels that convert Markdown to their own markup
+	// on send (Telegram, Discord, Slack, Feishu, WhatsApp, the web UI).
+	StyleMarkdown Style = iota
+	// StylePlain is for channels that show text as-is or strip Markdown.
+	StylePlain
+)
+
+// plainChannels are channel types rendered without Markdown.
+var plainChannels = map[string]bool{
+	"zalo":          true,
+	"zalo_personal": true,
+	"facebook":      true,
+	"pancake":       true,
+}
+
+// StyleFor returns the reply style for a channel type.
+func StyleFor(channelType string) Style {
+	if plainChannels[channelType] {
+		return StylePlain
+	}
+	return StyleMarkdown
+}
+
+// Render formats a reply in the given style.
+func Render(r Reply, style Style) string {
+	var sb strings.Builder
+	if r.Title != "" {
+		if style == StyleMarkdown {
+			sb.WriteString("**" + r.Title + "**")
+		} else {
+			sb.WriteString(r.Title)
+		}
+		sb.WriteString("\n")
+	}
+	bullet := "- "
+	if style == StylePlain {
+		bullet = "• "
+	}
+	for _, line := range r.Lines {
+		sb.WriteString(bullet + line + "\n")
+	}
+	if r.Text != "" {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(r.Text)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -53,10 +53,63 @@ type Config struct {
 	Audio     *AudioConfig    `json:"audio,omitempty"` // optional STT/Music defaults (Phase 3/4)
 	Cron      CronConfig      `json:"cron"`
 	Telemetry TelemetryConfig `json:"telemetry"`
+	Billing   BillingConfig   `json:"billing,omitempty"`
 	Tailscale TailscaleConfig `json:"tailscale"`
 	Bindings  []AgentBinding  `json:"bindings,omitempty"`
 	Hooks     HooksConfig     `json:"hooks"`
-	mu        sync.RWMutex
+	Proxy     ProxyConfig     `json:"proxy,omitempty"`
+	Runtime   RuntimeConfig   `json:"runtime,omitempty"`
+	Sync      SyncConfig      `json:"sync,omitempty"`
+	Skills    SkillsConfig    `json:"skills,omitempty"`
+	// Offline blocks every outbound call except loopback and OfflineAllow
+	// entries (NO_PROXY syntax: hosts, ".domain", CIDRs, host:port), for
+	// air-gapped deployments with local Ollama, embeddings and MCP servers.
+	Offline      bool     `json:"offline,omitempty"`
+	OfflineAllow []string `json:"offline_allow,omitempty"`
+	mu           sync.RWMutex
+	secretRefs   map[string]string // path → secret:// reference, recorded before resolution
+}
+
+// RuntimeConfig selects the resource profile. "lite" targets Raspberry
+// Pi-class devices: no browser, smaller caches, staggered channel start-up
+// and memory ceilings for the Go heap and the SQLite store. Non-zero
+// overrides take precedence over the profile's values.
+type RuntimeConfig struct {
+	Profile           string `json:"profile,omitempty"`              // "standard" (default) or "lite"
+	MemoryLimitMB     int    `json:"memory_limit_mb,omitempty"`      // Go soft memory limit; GOMEMLIMIT env wins
+	SQLiteCacheMB     int    `json:"sqlite_cache_mb,omitempty"`      // per-connection SQLite page cache
+	SQLiteHeapLimitMB int    `json:"sqlite_heap_limit_mb,omitempty"` // SQLite soft heap limit
+}
+
+// SyncConfig links a standalone instance to a server for one-way state sync
+// (agents, context files, cron jobs, agent memory, skills). Setting Key on
+// the server publishes GET /v1/sync/snapshot; setting Upstream makes the
+// instance pull from it instead. Both sides need the same Key.
+type SyncConfig struct {
+	Upstream    string `json:"upstream,omitempty"`     // server base URL, e.g. "https://vps.example.com"
+	Token       string `json:"token,omitempty"`        // admin API key or gateway token on the server
+	Key         string `json:"key,omitempty"`          // shared 32-byte payload encryption key (hex, base64 or raw)
+	IntervalSec int    `json:"interval_sec,omitempty"` // pull interval (default 300)
+	RunCron     bool   `json:"run_cron,omitempty"`     // keep synced cron jobs enabled locally (default false: they'd run twice)
+}
+
+// IntervalDuration returns the pull interval, or 0 for the default.
+func (s SyncConfig) IntervalDuration() time.Duration {
+	return time.Duration(s.IntervalSec) * time.Second
+}
+
+// ProxyConfig routes outbound traffic (providers, tools, channels, webhooks,
+// browser) through an HTTP or SOCKS5 proxy. Empty URL falls back to the
+// HTTPS_PROXY/HTTP_PROXY/ALL_PROXY env vars; NO_PROXY is always honored.
+// Override values take a proxy URL or "direct" to bypass the proxy.
+type ProxyConfig struct {
+	URL       string            `json:"url,omitempty"`       // default proxy, e.g. "http://proxy:3128" or "socks5://proxy:1080"
+	NoProxy   string            `json:"no_proxy,omitempty"`  // comma-separated, merged with NO_PROXY env
+	Providers map[string]string `json:"providers,omitempty"` // provider name → proxy URL or "direct"
+	Tools     map[string]string `json:"tools,omitempty"`     // tool name (web_fetch, web_search, stt, ...) → proxy URL or "direct"
+	Channels  map[string]string `json:"channels,omitempty"`  // channel type (feishu, zalo_personal, ...) → proxy URL or "direct"
+	Webhooks  string            `json:"webhooks,omitempty"`  // hook/webhook deliveries
+	Browser   string            `json:"browser,omitempty"`   // headless Chrome --proxy-server
 }
 
 // HooksConfig tunes the script-hook runtime caps. All zero-valued fields fall
@@ -93,11 +146,31 @@ type DatabaseConfig struct {
 	RedisDSN       string `json:"-"` // from env GOCLAW_REDIS_DSN only (optional, requires -tags redis)
 	StorageBackend string `json:"-"` // from env GOCLAW_STORAGE_BACKEND only ("postgres" or "sqlite", default "postgres")
 	SQLitePath     string `json:"-"` // from env GOCLAW_SQLITE_PATH only (default: {dataDir}/goclaw.db)
+
+	AutoMigrate      bool   `json:"auto_migrate,omitempty"`       // apply pending migrations + data hooks on startup (same as GOCLAW_AUTO_UPGRADE=true)
+	SchemaDriftCheck string `json:"schema_drift_check,omitempty"` // "error" (default), "warn" or "off"; env GOCLAW_SCHEMA_DRIFT_CHECK
 }
 
 // SkillsConfig configures the skills storage system.
 type SkillsConfig struct {
-	StorageDir string `json:"storage_dir,omitempty"` // directory for skill content (default: dataDir/skills-store/)
+	StorageDir string              `json:"storage_dir,omitempty"` // directory for skill content (default: dataDir/skills-store/)
+	Registry   SkillRegistryConfig `json:"registry,omitempty"`
+}
+
+// SkillRegistryConfig points `goclaw skills pull` at a remote skill index
+// (JSON listing versioned zip artifacts with SHA-256 checksums). Pulled
+// skills land in the global skills directory and are recorded in its
+// skills.lock.json; the gateway re-checks unpinned ones every
+// UpdateIntervalSec.
+type SkillRegistryConfig struct {
+	URL               string `json:"url,omitempty"`                 // index URL, e.g. "https://skills.example.com/index.json"
+	Token             string `json:"token,omitempty"`               // Bearer token for the index and artifacts
+	UpdateIntervalSec int    `json:"update_interval_sec,omitempty"` // auto-update period in the gateway (0 = off)
+}
+
+// UpdateInterval returns the auto-update period, or 0 when disabled.
+func (r SkillRegistryConfig) UpdateInterval() time.Duration {
+	return time.Duration(r.UpdateIntervalSec) * time.Second
 }
 
 // AgentBinding maps a channel/peer pattern to a specific agent.
@@ -117,8 +190,9 @@ type BindingMatch struct {
 
 // BindingPeer specifies a specific chat target.
 type BindingPeer struct {
-	Kind string `json:"kind"` // "direct" or "group"
-	ID   string `json:"id"`
+	Kind   string `json:"kind"` // "direct" or "group"
+	ID     string `json:"id"`
+	Thread string `json:"thread,omitempty"` // forum topic / thread ID within the chat; empty = whole chat
 }
 
 // AgentsConfig contains agent defaults and per-agent overrides.
@@ -148,15 +222,50 @@ type AgentDefaults struct {
 	// Bootstrap context truncation limits (matching TS bootstrapMaxChars / bootstrapTotalMaxChars)
 	BootstrapMaxChars      int `json:"bootstrapMaxChars,omitempty"`      // per-file max before truncation (default 20000)
 	BootstrapTotalMaxChars int `json:"bootstrapTotalMaxChars,omitempty"` // total budget across all files (default 24000)
+	// ContextFileSync mirrors agent-level context files between agent workspaces and the DB.
+	ContextFileSync *ContextFileSyncConfig `json:"contextFileSync,omitempty"`
+	// WatchMemoryFiles re-indexes workspace memory files as they change on disk (default true).
+	WatchMemoryFiles *bool `json:"watchMemoryFiles,omitempty"`
+}
+
+// ContextFileSyncConfig configures the workspace ↔ DB context file sync.
+type ContextFileSyncConfig struct {
+	Enabled  bool   `json:"enabled"`
+	Interval string `json:"interval,omitempty"` // poll interval, Go duration (default "30s")
+}
+
+// IntervalDuration returns the parsed poll interval, or 0 when unset/invalid.
+func (c *ContextFileSyncConfig) IntervalDuration() time.Duration {
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
 }
 
 // CompactionConfig configures session compaction behaviour.
 // Matching TS agents.defaults.compaction.
 type CompactionConfig struct {
-	ReserveTokensFloor int                `json:"reserveTokensFloor,omitempty"` // min reserve tokens (default 20000)
-	MaxHistoryShare    float64            `json:"maxHistoryShare,omitempty"`    // max share of context for history (default 0.85)
-	KeepLastMessages   int                `json:"keepLastMessages,omitempty"`   // messages to keep after compaction (default 4)
-	MemoryFlush        *MemoryFlushConfig `json:"memoryFlush,omitempty"`        // pre-compaction flush
+	ReserveTokensFloor int                  `json:"reserveTokensFloor,omitempty"` // min reserve tokens (default 20000)
+	MaxHistoryShare    float64              `json:"maxHistoryShare,omitempty"`    // max share of context for history (default 0.85)
+	KeepLastMessages   int                  `json:"keepLastMessages,omitempty"`   // messages to keep after compaction (default 4)
+	MemoryFlush        *MemoryFlushConfig   `json:"memoryFlush,omitempty"`        // pre-compaction flush
+	SessionDigest      *SessionDigestConfig `json:"sessionDigest,omitempty"`      // background session title + rolling summary
+
+	// Strategy picks how older history is reduced: "summarize-oldest"
+	// (default), "drop-tool-results-first" or "semantic-dedupe". The latter two
+	// only fall back to summarization when the cheaper pass is not enough.
+	Strategy            string   `json:"strategy,omitempty"`
+	PinFirstUserMessage bool     `json:"pinFirstUserMessage,omitempty"` // keep the first user message verbatim
+	PinPatterns         []string `json:"pinPatterns,omitempty"`         // keep messages containing any of these (case-insensitive)
+}
+
+// SessionDigestConfig configures the background session digest: a short
+// title and rolling summary refreshed every few user turns, shown in session
+// listings.
+type SessionDigestConfig struct {
+	Enabled    *bool `json:"enabled,omitempty"`    // default true (nil = enabled)
+	EveryTurns int   `json:"everyTurns,omitempty"` // user turns between digests (default 6)
 }
 
 // MemoryFlushConfig configures the pre-compaction memory flush.
@@ -166,6 +275,9 @@ type MemoryFlushConfig struct {
 	SoftThresholdTokens int    `json:"softThresholdTokens,omitempty"` // flush when within N tokens of compaction (default 4000)
 	Prompt              string `json:"prompt,omitempty"`              // user prompt for flush turn
 	SystemPrompt        string `json:"systemPrompt,omitempty"`        // system prompt for flush turn
+	Mode                string `json:"mode,omitempty"`                // "auto" (default: skip flush when the compacted turns carry nothing durable) or "always"
+	MinUserTurns        int    `json:"minUserTurns,omitempty"`        // auto mode: min substantive user turns in the compacted span (default 2)
+	MinChars            int    `json:"minChars,omitempty"`            // auto mode: spans without durable signals flush only past 10× this many chars (default 400)
 }
 
 // ContextPruningConfig configures in-memory context pruning of old tool results.
@@ -216,6 +328,27 @@ type MemoryConfig struct {
 	// Dreaming configures the episodic → long-term consolidation worker.
 	// nil = use hardcoded defaults (threshold=5, debounce=10min, enabled).
 	Dreaming *DreamingConfig `json:"dreaming,omitempty"`
+
+	// Transcripts configures ingestion of completed session summaries into
+	// memory documents so memory_search can recall past conversations.
+	// nil = disabled (opt-in).
+	Transcripts *TranscriptMemoryConfig `json:"transcripts,omitempty"`
+
+	// Rerank reorders memory_search candidates after the hybrid merge.
+	// nil = disabled.
+	Rerank *RerankConfig `json:"rerank,omitempty"`
+}
+
+// RerankConfig configures the memory search rerank stage. "llm" asks a chat
+// model to score each candidate; "cross_encoder" calls a /rerank endpoint
+// (Cohere, Jina, Voyage and compatible servers).
+type RerankConfig struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Mode     string `json:"mode,omitempty"`     // "llm" (default) or "cross_encoder"
+	Provider string `json:"provider,omitempty"` // provider name; "" = background provider (llm mode)
+	Model    string `json:"model,omitempty"`    // model name; "" = provider default
+	APIBase  string `json:"api_base,omitempty"` // cross_encoder endpoint override (default: provider API base)
+	TopK     int    `json:"top_k,omitempty"`    // candidates sent to the reranker (default 20)
 }
 
 // DreamingConfig controls per-agent behaviour of the consolidation dreaming
@@ -230,10 +363,21 @@ type DreamingConfig struct {
 	VerboseLog *bool `json:"verbose_log,omitempty"` // log debounce/below-threshold skips at info level (default false)
 }
 
-// SandboxConfig configures Docker-based sandbox execution.
+// TranscriptMemoryConfig controls per-agent session transcript ingestion:
+// each completed session's summary is written to a memory document scoped
+// to the agent and user, and pruned after the retention period.
+type TranscriptMemoryConfig struct {
+	Enabled       bool     `json:"enabled,omitempty"`        // default false (opt-in)
+	RetentionDays int      `json:"retention_days,omitempty"` // delete ingested transcripts older than N days (default 90)
+	ScrubPII      *bool    `json:"scrub_pii,omitempty"`      // redact emails, phone numbers, tokens and API keys before storing (default true)
+	ScrubPatterns []string `json:"scrub_patterns,omitempty"` // extra regular expressions to redact
+}
+
+// SandboxConfig configures container-based sandbox execution.
 // Matching TS agents.defaults.sandbox.
 type SandboxConfig struct {
 	Mode            string            `json:"mode,omitempty"`             // "off" (default), "non-main", "all"
+	Runtime         string            `json:"runtime,omitempty"`          // "docker" or "podman" (default: docker, podman if docker is missing)
 	Image           string            `json:"image,omitempty"`            // Docker image (default: "goclaw-sandbox:bookworm-slim")
 	WorkspaceAccess string            `json:"workspace_access,omitempty"` // "none", "ro", "rw" (default)
 	Scope           string            `json:"scope,omitempty"`            // "session" (default), "agent", "shared"
@@ -241,6 +385,7 @@ type SandboxConfig struct {
 	CPUs            float64           `json:"cpus,omitempty"`             // CPU limit (default 1.0)
 	TimeoutSec      int               `json:"timeout_sec,omitempty"`      // exec timeout in seconds (default 300)
 	NetworkEnabled  bool              `json:"network_enabled,omitempty"`  // enable network (default false)
+	Network         string            `json:"network,omitempty"`          // named network joined when enabled (default bridge)
 	ReadOnlyRoot    *bool             `json:"read_only_root,omitempty"`   // read-only root fs (default true)
 	SetupCommand    string            `json:"setup_command,omitempty"`    // run once after container creation
 	Env             map[string]string `json:"env,omitempty"`              // extra environment variables
@@ -273,6 +418,12 @@ func (sc *SandboxConfig) ToSandboxConfig() sandbox.Config {
 		cfg.Mode = sandbox.ModeOff
 	}
 
+	switch sc.Runtime {
+	case "podman":
+		cfg.Runtime = sandbox.RuntimePodman
+	case "docker":
+		cfg.Runtime = sandbox.RuntimeDocker
+	}
 	if sc.Image != "" {
 		cfg.Image = sc.Image
 	}
@@ -302,6 +453,7 @@ func (sc *SandboxConfig) ToSandboxConfig() sandbox.Config {
 		cfg.TimeoutSec = sc.TimeoutSec
 	}
 	cfg.NetworkEnabled = sc.NetworkEnabled
+	cfg.Network = sc.Network
 	if sc.ReadOnlyRoot != nil {
 		cfg.ReadOnlyRoot = *sc.ReadOnlyRoot
 	}
@@ -361,6 +513,18 @@ type TelemetryConfig struct {
 	ServiceName  string                   `json:"service_name,omitempty"`  // OTEL service name (default "goclaw-gateway")
 	Headers      map[string]string        `json:"headers,omitempty"`       // extra headers (e.g. auth tokens for cloud backends)
 	ModelPricing map[string]*ModelPricing `json:"model_pricing,omitempty"` // cost per model, key = "provider/model" or just "model"
+
+	// Crash reporting: recovered goroutine panics are sent to Sentry when a DSN is set.
+	SentryDSN string `json:"sentry_dsn,omitempty"` // e.g. "https://<key>@o0.ingest.sentry.io/<project>"
+	SentryEnv string `json:"sentry_env,omitempty"` // Sentry environment tag (default "production")
+}
+
+// BillingConfig configures monthly usage exports used to charge back LLM spend.
+type BillingConfig struct {
+	Currency string `json:"currency,omitempty"` // label on exports (default "USD"); prices are in this currency
+	// PriceOverrides reprices usage per model instead of the cost recorded at call time,
+	// key = "provider/model" or just "model" (same as telemetry.model_pricing).
+	PriceOverrides map[string]*ModelPricing `json:"price_overrides,omitempty"`
 }
 
 // CronConfig configures the cron job system.
@@ -370,6 +534,10 @@ type CronConfig struct {
 	RetryMaxDelay   string `json:"retry_max_delay,omitempty"`  // maximum backoff delay (default "30s", Go duration)
 	DefaultTimezone string `json:"default_timezone,omitempty"` // IANA timezone for cron expressions when not set per-job (e.g. "Asia/Ho_Chi_Minh")
 	JobTimeout      string `json:"job_timeout,omitempty"`      // max duration per cron job execution (default "10m", Go duration)
+
+	// MaxConcurrentRuns caps scheduled runs in flight at once; due jobs beyond
+	// it wait for a free slot. 0 = unlimited.
+	MaxConcurrentRuns int `json:"max_concurrent_runs,omitempty"`
 }
 
 // DefaultJobTimeout is the fallback timeout for cron job execution.
@@ -435,6 +603,18 @@ type AgentSpec struct {
 	Default           bool            `json:"default,omitempty"`
 	Sandbox           *SandboxConfig  `json:"sandbox,omitempty"`
 	Identity          *IdentityConfig `json:"identity,omitempty"`
+	Heartbeat         *HeartbeatSpec  `json:"heartbeat,omitempty"` // applied to the agent's DB heartbeat on startup and reload
+}
+
+// HeartbeatSpec is a per-agent heartbeat override. Empty fields keep the
+// value stored in the DB (set via the heartbeat RPCs or the UI).
+type HeartbeatSpec struct {
+	Enabled     bool   `json:"enabled"`
+	Every       string `json:"every,omitempty"`        // Go duration, min "5m" (default "30m" for new heartbeats)
+	ActiveHours string `json:"active_hours,omitempty"` // "HH:MM-HH:MM", wraps midnight when start > end
+	Timezone    string `json:"timezone,omitempty"`     // IANA zone for active_hours (default UTC)
+	Channel     string `json:"channel,omitempty"`      // delivery target channel
+	ChatID      string `json:"chat_id,omitempty"`      // delivery target chat
 }
 
 // ReplaceFrom copies all data fields from src into c, preserving c's mutex.
@@ -454,6 +634,11 @@ func (c *Config) ReplaceFrom(src *Config) {
 	c.Telemetry = src.Telemetry
 	c.Tailscale = src.Tailscale
 	c.Bindings = src.Bindings
+	c.Proxy = src.Proxy
+	c.Runtime = src.Runtime
+	c.Offline = src.Offline
+	c.OfflineAllow = src.OfflineAllow
+	c.secretRefs = src.secretRefs
 }
 
 // IdentityConfig defines agent persona / display identity.
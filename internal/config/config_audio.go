@@ -14,8 +14,12 @@ type AudioConfig struct {
 
 // AudioSTTConfig configures optional static STT defaults. Empty = "no global
 // default; rely on tenant builtin_tools[stt] or per-channel STTProxyURL".
+//
+// Provider names: "elevenlabs" (Scribe), "openai" (Whisper API), "groq"
+// (Groq-hosted Whisper) and "whisper_cpp" (local whisper.cpp server, no key).
+// The chosen providers are tried before the built-in elevenlabs → proxy chain.
 type AudioSTTConfig struct {
-	Provider   string `json:"provider,omitempty"`    // primary provider name (e.g. "scribe")
+	Provider   string `json:"provider,omitempty"`    // primary provider name (e.g. "groq")
 	APIKey     string `json:"api_key,omitempty"`     // may be overridden by env / secrets
 	BaseURL    string `json:"base_url,omitempty"`    // override for enterprise deploys
 	Model      string `json:"model,omitempty"`       // provider-specific
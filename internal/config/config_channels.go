@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // PendingCompactionConfig configures LLM-based compaction of pending group messages.
 // When a group accumulates more than Threshold pending messages, older messages are
 // summarized by an LLM and replaced with a compact summary, keeping KeepRecent raw messages.
@@ -11,6 +13,34 @@ type PendingCompactionConfig struct {
 	Model      string `json:"model,omitempty"`       // model for summarization; empty = use agent's model
 }
 
+// PairingConfig controls the "pairing" DM/group policy shared by all
+// channels: how long an approval code stays valid and how long an approved
+// sender stays paired.
+type PairingConfig struct {
+	CodeTTLMinutes int `json:"code_ttl_minutes,omitempty"` // pending code lifetime (default 60)
+	PairedTTLDays  int `json:"paired_ttl_days,omitempty"`  // approved pairing lifetime (default 30, -1 = never expires)
+}
+
+// CodeTTL returns the pairing code lifetime; 0 means the store default.
+func (p *PairingConfig) CodeTTL() time.Duration {
+	if p == nil || p.CodeTTLMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(p.CodeTTLMinutes) * time.Minute
+}
+
+// PairedTTL returns the approved pairing lifetime; 0 means the store
+// default and a negative value means approved pairings never expire.
+func (p *PairingConfig) PairedTTL() time.Duration {
+	switch {
+	case p == nil || p.PairedTTLDays == 0:
+		return 0
+	case p.PairedTTLDays < 0:
+		return -1
+	}
+	return time.Duration(p.PairedTTLDays) * 24 * time.Hour
+}
+
 // ChannelsConfig contains per-channel configuration.
 type ChannelsConfig struct {
 	Telegram          TelegramConfig           `json:"telegram"`
@@ -21,6 +51,7 @@ type ChannelsConfig struct {
 	ZaloPersonal      ZaloPersonalConfig       `json:"zalo_personal"`
 	Feishu            FeishuConfig             `json:"feishu"`
 	PendingCompaction *PendingCompactionConfig `json:"pending_compaction,omitempty"` // global pending message compaction settings
+	Pairing           *PairingConfig           `json:"pairing,omitempty"`            // pairing code and approval lifetimes (nil = 60 min / 30 days)
 }
 
 type TelegramConfig struct {
@@ -38,6 +69,8 @@ type TelegramConfig struct {
 	GroupStream      *bool               `json:"group_stream,omitempty"`      // enable streaming for groups (default false) — sends new message, edits progressively
 	DraftTransport   *bool               `json:"draft_transport,omitempty"`   // use sendMessageDraft for DM streaming (default true) — stealth preview, no notifications per edit
 	ReasoningStream  *bool               `json:"reasoning_stream,omitempty"`  // show reasoning as separate message when provider emits thinking events (default true)
+	StreamThrottleMs int                 `json:"stream_throttle_ms,omitempty"` // min delay between streaming edits in ms (default 1000)
+	StreamMinChars   int                 `json:"stream_min_chars,omitempty"`   // coalesce chunks: skip an edit until this many new chars arrived (default 0 = any change)
 	ReactionLevel    string              `json:"reaction_level,omitempty"`    // "off" (default), "minimal", "full" — status emoji reactions
 	MediaMaxBytes  int64               `json:"media_max_bytes,omitempty"` // max media download size in bytes (default 20MB)
 	LinkPreview    *bool               `json:"link_preview,omitempty"`    // enable URL previews in messages (default true)
@@ -50,6 +83,7 @@ type TelegramConfig struct {
 	STTAPIKey         string `json:"stt_api_key,omitempty"`         // Bearer token for the STT proxy
 	STTTenantID       string `json:"stt_tenant_id,omitempty"`       // optional tenant/org identifier forwarded to the STT proxy
 	STTTimeoutSeconds int    `json:"stt_timeout_seconds,omitempty"` // per-request timeout for STT calls (default 30s)
+	STTLanguage       string `json:"stt_language,omitempty"`        // language hint for voice transcription (e.g. "vi"); empty = audio.stt.language / auto-detect
 
 	// Optional audio-aware routing: when set, voice/audio inbound messages are routed to this
 	// agent instead of the default channel agent. Requires the named agent to exist in the config.
@@ -108,6 +142,7 @@ type DiscordConfig struct {
 	STTAPIKey         string              `json:"stt_api_key,omitempty"`
 	STTTenantID       string              `json:"stt_tenant_id,omitempty"`
 	STTTimeoutSeconds int                 `json:"stt_timeout_seconds,omitempty"`
+	STTLanguage       string              `json:"stt_language,omitempty"`
 	VoiceAgentID      string              `json:"voice_agent_id,omitempty"`
 }
 
@@ -140,6 +175,7 @@ type WhatsAppConfig struct {
 	RequireMention *bool               `json:"require_mention,omitempty"` // only respond in groups when bot is @mentioned (default false)
 	HistoryLimit   int                 `json:"history_limit,omitempty"`   // max pending group messages for context (default 200, 0=disabled)
 	BlockReply     *bool               `json:"block_reply,omitempty"`     // override gateway block_reply (nil = inherit)
+	STTLanguage    string              `json:"stt_language,omitempty"`    // language hint for voice transcription (e.g. "en")
 }
 
 type ZaloConfig struct {
@@ -184,6 +220,8 @@ type FeishuConfig struct {
 	MediaMaxMB        int                 `json:"media_max_mb,omitempty"`       // default 30
 	RenderMode        string              `json:"render_mode,omitempty"`        // "auto", "raw", "card"
 	Streaming         *bool               `json:"streaming,omitempty"`          // default true
+	StreamThrottleMs  int                 `json:"stream_throttle_ms,omitempty"` // min delay between streaming card edits in ms (default 1000)
+	StreamMinChars    int                 `json:"stream_min_chars,omitempty"`   // coalesce chunks: skip an edit until this many new chars arrived (default 0 = any change)
 	ReactionLevel     string              `json:"reaction_level,omitempty"`     // "off" (default), "minimal", "full" — typing emoji reactions
 	HistoryLimit      int                 `json:"history_limit,omitempty"`
 	BlockReply        *bool               `json:"block_reply,omitempty"` // override gateway block_reply (nil = inherit)
@@ -191,6 +229,7 @@ type FeishuConfig struct {
 	STTAPIKey         string              `json:"stt_api_key,omitempty"`
 	STTTenantID       string              `json:"stt_tenant_id,omitempty"`
 	STTTimeoutSeconds int                 `json:"stt_timeout_seconds,omitempty"`
+	STTLanguage       string              `json:"stt_language,omitempty"`
 	VoiceAgentID      string              `json:"voice_agent_id,omitempty"`
 }
 
@@ -223,7 +262,10 @@ type ProvidersConfig struct {
 // OllamaConfig configures a local (or self-hosted) Ollama instance.
 // No API key is required — Ollama accepts any Bearer token value.
 type OllamaConfig struct {
-	Host string `json:"host"` // Ollama server base URL, e.g. http://localhost:11434
+	Host      string `json:"host"`                 // Ollama server base URL, e.g. http://localhost:11434
+	Model     string `json:"model,omitempty"`      // default model (default "llama3.3")
+	KeepAlive string `json:"keep_alive,omitempty"` // how long models stay loaded: "10m", "-1" (forever), "0" (unload)
+	NumCtx    int    `json:"num_ctx,omitempty"`    // context length per request (default 32768, capped at the model's max)
 }
 
 // ClaudeCLIConfig configures the Claude CLI provider (uses subscription, not API key).
@@ -337,11 +379,54 @@ func (w QuotaWindow) IsZero() bool { return w.Hour == 0 && w.Day == 0 && w.Week
 // QuotaConfig configures per-user/group request quotas.
 // Config merge priority: Groups > Channels > Providers > Default.
 type QuotaConfig struct {
-	Enabled   bool                   `json:"enabled"`
-	Default   QuotaWindow            `json:"default"`
-	Providers map[string]QuotaWindow `json:"providers,omitempty"` // key = provider name (e.g. "anthropic")
-	Channels  map[string]QuotaWindow `json:"channels,omitempty"`  // key = channel name (e.g. "telegram")
-	Groups    map[string]QuotaWindow `json:"groups,omitempty"`    // key = userID (e.g. "group:telegram:-100123")
+	Enabled     bool                   `json:"enabled"`
+	Default     QuotaWindow            `json:"default"`
+	Providers   map[string]QuotaWindow `json:"providers,omitempty"`    // key = provider name (e.g. "anthropic")
+	Channels    map[string]QuotaWindow `json:"channels,omitempty"`     // key = channel name (e.g. "telegram")
+	Groups      map[string]QuotaWindow `json:"groups,omitempty"`       // key = userID (e.g. "group:telegram:-100123")
+	WarnPercent int                    `json:"warn_percent,omitempty"` // warn once per window at this % of a limit or agent budget (default 80, -1 = disabled)
+}
+
+// DefaultQuotaWarnPercent is the usage percentage at which quota warnings are sent.
+const DefaultQuotaWarnPercent = 80
+
+// EffectiveWarnPercent returns the configured warning threshold, or 0 when warnings are disabled.
+func (c QuotaConfig) EffectiveWarnPercent() int {
+	switch {
+	case c.WarnPercent < 0:
+		return 0
+	case c.WarnPercent == 0:
+		return DefaultQuotaWarnPercent
+	case c.WarnPercent > 100:
+		return 100
+	}
+	return c.WarnPercent
+}
+
+// BudgetLimit caps LLM usage per calendar day and month (UTC). Tokens count
+// input + output; cost caps are in cents. 0 = no cap for that window.
+type BudgetLimit struct {
+	DailyTokens   int64 `json:"daily_tokens,omitempty"`
+	MonthlyTokens int64 `json:"monthly_tokens,omitempty"`
+	DailyCents    int64 `json:"daily_cents,omitempty"`
+	MonthlyCents  int64 `json:"monthly_cents,omitempty"`
+}
+
+// IsZero returns true if no caps are set.
+func (b BudgetLimit) IsZero() bool {
+	return b.DailyTokens == 0 && b.MonthlyTokens == 0 && b.DailyCents == 0 && b.MonthlyCents == 0
+}
+
+// BudgetConfig enforces token/cost caps per user and per agent. Runs over a
+// cap are rejected. Users[userID] replaces UserDefault and Agents[agentKey]
+// replaces AgentDefault; an agent's budget_monthly_cents fills MonthlyCents
+// when the resolved agent limit leaves it unset.
+type BudgetConfig struct {
+	Enabled      bool                   `json:"enabled"`
+	UserDefault  BudgetLimit            `json:"user_default"`
+	Users        map[string]BudgetLimit `json:"users,omitempty"` // key = userID (e.g. "group:telegram:-100123")
+	AgentDefault BudgetLimit            `json:"agent_default"`
+	Agents       map[string]BudgetLimit `json:"agents,omitempty"` // key = agent key
 }
 
 // GatewayConfig controls the gateway server.
@@ -356,11 +441,206 @@ type GatewayConfig struct {
 	InjectionAction   string       `json:"injection_action,omitempty"`    // prompt injection action: "log", "warn" (default), "block", "off"
 	InboundDebounceMs int          `json:"inbound_debounce_ms,omitempty"` // merge rapid messages from same sender (default 1000ms, -1 = disabled)
 	Quota             *QuotaConfig `json:"quota,omitempty"`               // per-user/group request quotas
+	Budget            *BudgetConfig `json:"budget,omitempty"`             // per-user/agent token and cost caps
 	BlockReply              *bool        `json:"block_reply,omitempty"`                // deliver intermediate text during tool iterations (default false)
 	ToolStatus              *bool        `json:"tool_status,omitempty"`                // show tool name in streaming preview during tool execution (default true)
 	TaskRecoveryIntervalSec int          `json:"task_recovery_interval_sec,omitempty"` // team task recovery ticker interval in seconds (default 300 = 5min)
 	BackgroundProvider      string       `json:"background_provider,omitempty"`        // LLM provider for background workers (vault enrichment, consolidation)
 	BackgroundModel         string       `json:"background_model,omitempty"`           // LLM model for background workers
+	TLS                     *GatewayTLSConfig `json:"tls,omitempty"`                   // HTTPS/WSS termination + optional mTLS (nil = plain HTTP)
+	AllowedCIDRs            []string      `json:"allowed_cidrs,omitempty"`             // IP/CIDR allowlist for all routes (empty = allow all)
+	TrustedProxies          []string      `json:"trusted_proxies,omitempty"`           // CIDRs whose X-Forwarded-For/X-Real-IP is honored for allowlisting
+	RoutePolicies           []RoutePolicy `json:"route_policies,omitempty"`            // per-path-prefix auth level + IP allowlist (longest prefix wins)
+	WSCompression           *bool         `json:"ws_compression,omitempty"`            // negotiate permessage-deflate on /ws (default true; frames < 1KB sent uncompressed)
+	DrainTimeoutSec         int           `json:"drain_timeout_sec,omitempty"`         // on SIGTERM, max seconds to wait for in-flight runs (default 30)
+	ReusePort               bool          `json:"reuse_port,omitempty"`                // bind with SO_REUSEPORT so an upgraded process can take over the port while this one drains
+	Cluster                 *ClusterConfig `json:"cluster,omitempty"`                  // multi-replica session locking (nil = single instance)
+	ScopedTokens            []ScopedToken  `json:"scoped_tokens,omitempty"`            // static tokens limited to API key scopes (e.g. chat-only)
+	Lanes                   map[string]LaneConfig `json:"lanes,omitempty"`             // scheduler lane overrides keyed by lane name (main, subagent, team, cron)
+	Fairness                *FairnessConfig       `json:"fairness,omitempty"`          // fair-share scheduling between users (nil = round-robin, no caps)
+	QueueMode               string                `json:"queue_mode,omitempty"`        // message arriving while its session runs: "queue" (default), "followup", "interrupt", "steer"
+	Snapshots               *SnapshotsConfig      `json:"snapshots,omitempty"`         // pre-run workspace file snapshots for `goclaw workspace rollback` (nil = enabled, 14 days)
+	OIDC                    *OIDCConfig           `json:"oidc,omitempty"`              // OpenID Connect login (nil = disabled)
+}
+
+// OIDCConfig enables OpenID Connect login against an external identity
+// provider (Okta, Auth0, Keycloak, ...). After login the gateway issues its
+// own short-lived JWT whose subject is the caller's user ID, accepted as a
+// bearer token on /v1 and in the WS connect frame.
+type OIDCConfig struct {
+	Issuer          string   `json:"issuer"`                      // IdP issuer URL; discovery is read from {issuer}/.well-known/openid-configuration
+	ClientID        string   `json:"client_id"`
+	ClientSecret    string   `json:"client_secret,omitempty"`     // env GOCLAW_OIDC_CLIENT_SECRET; empty for public clients (PKCE only)
+	RedirectURL     string   `json:"redirect_url,omitempty"`      // default {request origin}/v1/auth/oidc/callback
+	Scopes          []string `json:"scopes,omitempty"`            // default openid, profile, email
+	UserClaim       string   `json:"user_claim,omitempty"`        // ID token claim used as the user ID (default "sub")
+	Role            string   `json:"role,omitempty"`              // role before the tenant_users cap: "admin", "operator" (default), "viewer", "member"
+	TokenTTLMinutes int      `json:"token_ttl_minutes,omitempty"` // lifetime of issued JWTs (default 60)
+	SigningKey      string   `json:"signing_key,omitempty"`       // env GOCLAW_OIDC_SIGNING_KEY; HMAC key for issued JWTs (default random per process; set it when running replicas)
+}
+
+// Enabled reports whether OIDC login is configured.
+func (o *OIDCConfig) Enabled() bool {
+	return o != nil && o.Issuer != "" && o.ClientID != ""
+}
+
+// TokenTTL returns the lifetime of gateway-issued JWTs.
+func (o *OIDCConfig) TokenTTL() time.Duration {
+	if o == nil || o.TokenTTLMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(o.TokenTTLMinutes) * time.Minute
+}
+
+// SnapshotsConfig controls workspace snapshots. Before each tool batch the
+// files write_file/edit will change are saved under {data_dir}/snapshots
+// (override with GOCLAW_SNAPSHOTS_DIR) so a run's edits can be rolled back.
+type SnapshotsConfig struct {
+	Disabled      bool `json:"disabled,omitempty"`
+	RetentionDays int  `json:"retention_days,omitempty"` // snapshots older than this are pruned at startup (default 14)
+}
+
+// Enabled reports whether workspace snapshots are taken (nil = enabled).
+func (s *SnapshotsConfig) Enabled() bool {
+	return s == nil || !s.Disabled
+}
+
+// Retention returns how long snapshots are kept.
+func (s *SnapshotsConfig) Retention() time.Duration {
+	days := 14
+	if s != nil && s.RetentionDays > 0 {
+		days = s.RetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// FairnessConfig shares scheduler slots between users so one user flooding
+// many sessions cannot starve others. Waiting channel runs of different users
+// take turns for lane slots. Keys are the run's user ID (the group scope in
+// group chats), prefixed with "<tenant-uuid>/" outside the master tenant.
+// WS chat.send and HTTP chat runs bypass the scheduler and are not covered.
+type FairnessConfig struct {
+	MaxInflightPerUser int            `json:"max_inflight_per_user,omitempty"` // runs one user may have running at once across lanes (0 = unlimited)
+	Weights            map[string]int `json:"weights,omitempty"`               // consecutive slots per turn by user key (default 1)
+}
+
+// LaneConfig overrides one scheduler lane. Zero Concurrency keeps the lane's
+// default (or its GOCLAW_LANE_* env value). A saturated lane borrows free
+// slots from lanes with a lower Priority; with Preempt it also stops a
+// lower-priority run when none is free, and that run restarts later.
+type LaneConfig struct {
+	Concurrency int  `json:"concurrency,omitempty"`
+	Priority    int  `json:"priority,omitempty"`
+	Preempt     bool `json:"preempt,omitempty"`
+}
+
+// ScopedToken is a config-defined bearer token that authenticates like an API
+// key with the listed scopes. Only the SHA-256 hex digest of the token is
+// stored, so the config file holds no secret. The token binds to the master
+// tenant; UserID, when set, is forced as the caller's user ID.
+type ScopedToken struct {
+	Name      string   `json:"name"`
+	TokenHash string   `json:"token_hash"` // hex SHA-256 of the raw token
+	Scopes    []string `json:"scopes"`
+	UserID    string   `json:"user_id,omitempty"`
+}
+
+// RoutePolicy applies gateway-level access rules to a path prefix.
+// Auth: "open" (no gateway check), "token" (gateway token only), "admin"
+// (gateway token or admin-role API key), or "" (handler decides).
+// For /ws the level is enforced on the connect frame instead of headers.
+// AllowedCIDRs replaces the global allowlist for the prefix; set it to [] to
+// exempt a route (e.g. health probes) from the global list.
+type RoutePolicy struct {
+	Prefix       string   `json:"prefix"`
+	Auth         string   `json:"auth,omitempty"`
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty"`
+}
+
+// GatewayTLSConfig enables TLS on the gateway listener, either from static
+// cert/key files or via ACME (Let's Encrypt) for the listed domains.
+//
+// ClientAuth controls mTLS: "off" (default), "require" (every connection must
+// present a cert signed by ClientCAFile), or "optional" (cert verified when
+// presented, and mandatory only for ClientCertPaths — default ["/v1/"], the
+// managed HTTP API).
+//
+// While TLS is on, LoopbackPort keeps a plain-HTTP listener on 127.0.0.1 for
+// the goclaw CLI and the Claude CLI MCP bridge, which dial the gateway locally.
+type GatewayTLSConfig struct {
+	CertFile        string   `json:"cert_file,omitempty"`
+	KeyFile         string   `json:"key_file,omitempty"`
+	ACMEDomains     []string `json:"acme_domains,omitempty"`      // hostnames for automatic Let's Encrypt certs
+	ACMEEmail       string   `json:"acme_email,omitempty"`        // contact for expiry notices
+	ACMECacheDir    string   `json:"acme_cache_dir,omitempty"`    // default {data_dir}/acme
+	ACMEHTTPAddr    string   `json:"acme_http_addr,omitempty"`    // optional HTTP-01 + redirect listener, e.g. ":80" (TLS-ALPN-01 is always on)
+	ClientCAFile    string   `json:"client_ca_file,omitempty"`    // PEM bundle of CAs trusted for client certs
+	ClientAuth      string   `json:"client_auth,omitempty"`       // "off" (default), "optional", "require"
+	ClientCertPaths []string `json:"client_cert_paths,omitempty"` // path prefixes that need a client cert in "optional" mode
+	MinVersion      string   `json:"min_version,omitempty"`       // "1.2" (default) or "1.3"
+	LoopbackPort    int      `json:"loopback_port,omitempty"`     // plain HTTP on 127.0.0.1 while TLS is on (0 = disabled)
+}
+
+// ClusterConfig lets several gateway replicas share one Postgres database.
+// Each run takes a lease on its session in session_leases, so replicas never
+// run turns on the same session at once, and the lease holder reloads the
+// session from the database instead of trusting its in-memory cache.
+type ClusterConfig struct {
+	Enabled     bool   `json:"enabled,omitempty"`
+	InstanceID  string `json:"instance_id,omitempty"`   // lease holder name (default hostname + random suffix)
+	LeaseTTLSec int    `json:"lease_ttl_sec,omitempty"` // lease lifetime, renewed while a run is active (default 60)
+	LockWaitSec int    `json:"lock_wait_sec,omitempty"` // max wait for a session busy on another replica (default 120)
+}
+
+// Enabled reports whether TLS termination is configured.
+func (t *GatewayTLSConfig) Enabled() bool {
+	return t != nil && ((t.CertFile != "" && t.KeyFile != "") || len(t.ACMEDomains) > 0)
+}
+
+// MissingKeyPairField returns the json name of the missing half when only one
+// of cert_file and key_file is set, or "" otherwise. Such a block is not
+// Enabled, so startup must reject it rather than fall back to plain HTTP.
+func (t *GatewayTLSConfig) MissingKeyPairField() string {
+	switch {
+	case t == nil || (t.CertFile == "") == (t.KeyFile == ""):
+		return ""
+	case t.CertFile == "":
+		return "cert_file"
+	default:
+		return "key_file"
+	}
+}
+
+// IgnoredFields returns the json names of the fields set on a block that is
+// not Enabled, e.g. client_auth alone or acme_email without acme_domains.
+// TLS stays off for such a block, so startup must reject it rather than
+// silently serve plain HTTP.
+func (t *GatewayTLSConfig) IgnoredFields() []string {
+	if t == nil || t.Enabled() {
+		return nil
+	}
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{"cert_file", t.CertFile != ""},
+		{"key_file", t.KeyFile != ""},
+		{"acme_email", t.ACMEEmail != ""},
+		{"acme_cache_dir", t.ACMECacheDir != ""},
+		{"acme_http_addr", t.ACMEHTTPAddr != ""},
+		{"client_ca_file", t.ClientCAFile != ""},
+		{"client_auth", t.ClientAuth != ""},
+		{"client_cert_paths", len(t.ClientCertPaths) > 0},
+		{"min_version", t.MinVersion != ""},
+		{"loopback_port", t.LoopbackPort != 0},
+	}
+	var out []string
+	for _, f := range fields {
+		if f.set {
+			out = append(out, f.name)
+		}
+	}
+	return out
 }
 
 // ToolsConfig controls tool availability, policy, and web search.
@@ -372,7 +652,9 @@ type ToolsConfig struct {
 	ByProvider       map[string]*ToolPolicySpec  `json:"byProvider,omitempty"`      // per-provider overrides
 	ShellDenyGroups  map[string]bool             `json:"shellDenyGroups,omitempty"` // global shell deny-group toggles (group name -> denied); per-agent overrides win per-key
 	ExecApproval     ExecApprovalCfg             `json:"execApproval"`              // exec command approval settings
+	ExecShell        string                      `json:"exec_shell,omitempty"`      // host exec interpreter: "auto" (default: cmd on Windows, sh elsewhere), "sh", "bash", "cmd", "powershell", "pwsh"
 	WebFetch         WebFetchPolicyConfig        `json:"web_fetch"`            // domain policy for URL fetching
+	Web              WebNetworkConfig            `json:"web,omitempty"`        // IP ranges web_fetch / web_search may or may not reach
 	Browser          BrowserToolConfig           `json:"browser"`
 	RateLimitPerHour int                         `json:"rate_limit_per_hour,omitempty"` // max tool executions per hour per session (0 = disabled)
 	ScrubCredentials *bool                       `json:"scrub_credentials,omitempty"`   // auto-redact API keys/tokens in tool output (default true)
@@ -390,6 +672,8 @@ type MCPServerConfig struct {
 	Enabled    *bool             `json:"enabled,omitempty"`     // default true
 	ToolPrefix string            `json:"tool_prefix,omitempty"` // prefix for tool names (avoids collisions)
 	TimeoutSec int               `json:"timeout_sec,omitempty"` // per-tool-call timeout in seconds (default 60)
+	ToolAllow  []string          `json:"tool_allow,omitempty"`  // only register these server tool names (empty = all)
+	ToolDeny   []string          `json:"tool_deny,omitempty"`   // never register these server tool names (wins over allow)
 }
 
 // IsEnabled returns whether this MCP server is enabled (default true).
@@ -402,6 +686,7 @@ type ExecApprovalCfg struct {
 	Security  string   `json:"security,omitempty"`  // "deny", "allowlist", "full" (default "full")
 	Ask       string   `json:"ask,omitempty"`       // "off", "on-miss", "always" (default "off")
 	Allowlist []string `json:"allowlist,omitempty"` // glob patterns for allowed commands
+	Tools     []string `json:"tools,omitempty"`     // tools that need owner approval on every call, e.g. ["write_file", "edit", "browser:act"]
 }
 
 // WebFetchPolicyConfig controls domain filtering for the web_fetch tool.
@@ -409,6 +694,14 @@ type WebFetchPolicyConfig struct {
 	Policy         string   `json:"policy,omitempty"`          // "allow_all" (default), "allowlist"
 	AllowedDomains []string `json:"allowed_domains,omitempty"` // e.g. ["github.com", "*.example.com"]
 	BlockedDomains []string `json:"blocked_domains,omitempty"` // always checked regardless of policy
+	RenderJS       bool     `json:"render_js,omitempty"`       // allow the headless-browser fallback for JavaScript pages (needs tools.browser.enabled)
+}
+
+// WebNetworkConfig sets IP rules for web_fetch and web_search on top of the
+// built-in SSRF block of private, loopback and link-local ranges.
+type WebNetworkConfig struct {
+	AllowCIDRs []string `json:"allow_cidrs,omitempty"` // private ranges web tools may reach, e.g. ["10.20.0.0/16"]
+	DenyCIDRs  []string `json:"deny_cidrs,omitempty"`  // extra ranges to block; wins over allow_cidrs
 }
 
 // BrowserToolConfig controls the browser automation tool.
@@ -419,6 +712,35 @@ type BrowserToolConfig struct {
 	ActionTimeoutMs int    `json:"action_timeout_ms,omitempty"` // per-action timeout in ms (default 30000)
 	IdleTimeoutMs   int    `json:"idle_timeout_ms,omitempty"`   // idle page auto-close in ms (default 600000, 0=disabled)
 	MaxPages        int    `json:"max_pages,omitempty"`         // max open pages per tenant (default 5)
+	// CDPURL attaches to an existing Chrome instead of launching one: a sidecar
+	// ("ws://chrome:9222"), a full WebSocket URL ("wss://host?token=...") or an
+	// HTTPS endpoint serving /json/version. Takes precedence over RemoteURL.
+	CDPURL     string            `json:"cdp_url,omitempty"`
+	CDPToken   string            `json:"cdp_token,omitempty"`   // sent as "Authorization: Bearer <token>" (secret)
+	CDPHeaders map[string]string `json:"cdp_headers,omitempty"` // extra headers for the CDP endpoint
+	// Named profiles keep cookies and localStorage in a persistent Chrome user-data dir
+	// per tenant and profile. Not available with RemoteURL.
+	ProfilesDir   string            `json:"profiles_dir,omitempty"`   // default <data_dir>/browser-profiles
+	AgentProfiles map[string]string `json:"agent_profiles,omitempty"` // agent key → default profile when the call passes none
+	// Block refuses matching requests in every browser page.
+	Block *BrowserBlockConfig `json:"block,omitempty"`
+}
+
+// RemoteEndpoint returns the remote Chrome endpoint to attach to, or "" to
+// launch a local Chrome.
+func (c BrowserToolConfig) RemoteEndpoint() string {
+	if c.CDPURL != "" {
+		return c.CDPURL
+	}
+	return c.RemoteURL
+}
+
+// BrowserBlockConfig lists domains the browser must not contact. Domains match
+// their subdomains too.
+type BrowserBlockConfig struct {
+	Presets      []string `json:"presets,omitempty"`       // "ads", "analytics"
+	Domains      []string `json:"domains,omitempty"`       // extra blocked domains
+	AllowDomains []string `json:"allow_domains,omitempty"` // when set, every other domain is blocked
 }
 
 // ToolPolicySpec defines a tool policy at any level (global, per-agent, per-provider).
@@ -400,6 +400,48 @@ func TestLoad_SandboxEnvOverrides(t *testing.T) {
 	}
 }
 
+// --- Browser CDP endpoint ---
+
+func TestLoad_BrowserCDPEnvOverrides(t *testing.T) {
+	t.Setenv("GOCLAW_BROWSER_CDP_URL", "wss://chrome.example.com")
+	t.Setenv("GOCLAW_BROWSER_CDP_TOKEN", "tok-123")
+
+	cfg, err := Load("/nonexistent/path")
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	if !cfg.Tools.Browser.Enabled {
+		t.Error("GOCLAW_BROWSER_CDP_URL should enable the browser tool")
+	}
+	if got := cfg.Tools.Browser.RemoteEndpoint(); got != "wss://chrome.example.com" {
+		t.Errorf("remote endpoint: got %q", got)
+	}
+
+	cfg.Tools.Browser.CDPHeaders = map[string]string{"X-API-Key": "k"}
+	masked := cfg.MaskedCopy()
+	if masked.Tools.Browser.CDPToken != secretMask || masked.Tools.Browser.CDPHeaders["X-API-Key"] != secretMask {
+		t.Errorf("CDP credentials not masked: %+v", masked.Tools.Browser)
+	}
+	if cfg.Tools.Browser.CDPHeaders["X-API-Key"] != "k" {
+		t.Error("MaskedCopy modified the original headers")
+	}
+	masked.StripMaskedSecrets()
+	if masked.Tools.Browser.CDPToken != "" || len(masked.Tools.Browser.CDPHeaders) != 0 {
+		t.Errorf("masked CDP credentials not stripped: %+v", masked.Tools.Browser)
+	}
+}
+
+func TestBrowserToolConfig_RemoteEndpoint(t *testing.T) {
+	bc := BrowserToolConfig{RemoteURL: "ws://chrome:9222"}
+	if got := bc.RemoteEndpoint(); got != "ws://chrome:9222" {
+		t.Errorf("remote_url only: got %q", got)
+	}
+	bc.CDPURL = "wss://chrome.example.com"
+	if got := bc.RemoteEndpoint(); got != "wss://chrome.example.com" {
+		t.Errorf("cdp_url should win: got %q", got)
+	}
+}
+
 // --- SandboxConfig.ToSandboxConfig ---
 
 func TestSandboxConfig_ToSandboxConfig_Nil(t *testing.T) {
@@ -470,3 +512,28 @@ func TestLoad_ChannelAutoEnable_Slack(t *testing.T) {
 		t.Error("Slack should be auto-enabled when both tokens are set")
 	}
 }
+
+func TestLoad_SkillRegistryEnvOverrides(t *testing.T) {
+	t.Setenv("GOCLAW_SKILLS_REGISTRY_URL", "https://skills.example.com/index.json")
+	t.Setenv("GOCLAW_SKILLS_REGISTRY_TOKEN", "reg-tok")
+
+	cfg, err := Load("/nonexistent/path")
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	if cfg.Skills.Registry.URL != "https://skills.example.com/index.json" || cfg.Skills.Registry.Token != "reg-tok" {
+		t.Errorf("registry: got %+v", cfg.Skills.Registry)
+	}
+	if cfg.Skills.Registry.UpdateInterval() != 0 {
+		t.Error("auto-update should default to off")
+	}
+
+	masked := cfg.MaskedCopy()
+	if masked.Skills.Registry.Token != secretMask {
+		t.Errorf("registry token not masked: %q", masked.Skills.Registry.Token)
+	}
+	masked.StripMaskedSecrets()
+	if masked.Skills.Registry.Token != "" {
+		t.Error("masked registry token not stripped")
+	}
+}
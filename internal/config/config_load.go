@@ -107,9 +107,14 @@ func (c *Config) applyEnvOverrides() {
 	envStr("GOCLAW_ZAI_API_KEY", &c.Providers.Zai.APIKey)
 	envStr("GOCLAW_ZAI_CODING_API_KEY", &c.Providers.ZaiCoding.APIKey)
 	envStr("GOCLAW_OLLAMA_HOST", &c.Providers.Ollama.Host)
+	envStr("GOCLAW_OLLAMA_KEEP_ALIVE", &c.Providers.Ollama.KeepAlive)
 	envStr("GOCLAW_OLLAMA_CLOUD_API_KEY", &c.Providers.OllamaCloud.APIKey)
 	envStr("GOCLAW_OLLAMA_CLOUD_API_BASE", &c.Providers.OllamaCloud.APIBase)
 	envStr("GOCLAW_GATEWAY_TOKEN", &c.Gateway.Token)
+	envStr("GOCLAW_SYNC_TOKEN", &c.Sync.Token)
+	envStr("GOCLAW_SYNC_KEY", &c.Sync.Key)
+	envStr("GOCLAW_SKILLS_REGISTRY_URL", &c.Skills.Registry.URL)
+	envStr("GOCLAW_SKILLS_REGISTRY_TOKEN", &c.Skills.Registry.Token)
 	envStr("GOCLAW_TELEGRAM_TOKEN", &c.Channels.Telegram.Token)
 	envStr("GOCLAW_DISCORD_TOKEN", &c.Channels.Discord.Token)
 	envStr("GOCLAW_ZALO_TOKEN", &c.Channels.Zalo.Token)
@@ -176,11 +181,29 @@ func (c *Config) applyEnvOverrides() {
 		}
 	}
 
+	// Multi-replica session locking
+	if v := os.Getenv("GOCLAW_CLUSTER"); v == "1" || v == "true" {
+		if c.Gateway.Cluster == nil {
+			c.Gateway.Cluster = &ClusterConfig{}
+		}
+		c.Gateway.Cluster.Enabled = true
+	}
+	if v := os.Getenv("GOCLAW_INSTANCE_ID"); v != "" && c.Gateway.Cluster != nil {
+		c.Gateway.Cluster.InstanceID = v
+	}
+
+	// OpenID Connect secrets (issuer and client ID come from config.json)
+	if c.Gateway.OIDC != nil {
+		envStr("GOCLAW_OIDC_CLIENT_SECRET", &c.Gateway.OIDC.ClientSecret)
+		envStr("GOCLAW_OIDC_SIGNING_KEY", &c.Gateway.OIDC.SigningKey)
+	}
+
 	// Database
 	envStr("GOCLAW_POSTGRES_DSN", &c.Database.PostgresDSN)
 	envStr("GOCLAW_REDIS_DSN", &c.Database.RedisDSN)
 	envStr("GOCLAW_STORAGE_BACKEND", &c.Database.StorageBackend)
 	envStr("GOCLAW_SQLITE_PATH", &c.Database.SQLitePath)
+	envStr("GOCLAW_SCHEMA_DRIFT_CHECK", &c.Database.SchemaDriftCheck)
 
 	// Deprecation warning for GOCLAW_MODE (removed — PostgreSQL is always active)
 	if v := os.Getenv("GOCLAW_MODE"); v != "" {
@@ -191,6 +214,8 @@ func (c *Config) applyEnvOverrides() {
 	envStr("GOCLAW_TELEMETRY_ENDPOINT", &c.Telemetry.Endpoint)
 	envStr("GOCLAW_TELEMETRY_PROTOCOL", &c.Telemetry.Protocol)
 	envStr("GOCLAW_TELEMETRY_SERVICE_NAME", &c.Telemetry.ServiceName)
+	envStr("GOCLAW_SENTRY_DSN", &c.Telemetry.SentryDSN)
+	envStr("GOCLAW_SENTRY_ENV", &c.Telemetry.SentryEnv)
 	if v := os.Getenv("GOCLAW_TELEMETRY_ENABLED"); v != "" {
 		c.Telemetry.Enabled = v == "true" || v == "1"
 	}
@@ -198,6 +223,23 @@ func (c *Config) applyEnvOverrides() {
 		c.Telemetry.Insecure = v == "true" || v == "1"
 	}
 
+	envStr("GOCLAW_EXEC_SHELL", &c.Tools.ExecShell)
+	envStr("GOCLAW_RUNTIME_PROFILE", &c.Runtime.Profile)
+
+	// Offline mode (air-gapped deployments)
+	if v := os.Getenv("GOCLAW_OFFLINE"); v != "" {
+		c.Offline = v == "true" || v == "1"
+	}
+	if v := os.Getenv("GOCLAW_OFFLINE_ALLOW"); v != "" {
+		var allow []string
+		for h := range strings.SplitSeq(v, ",") {
+			if trimmed := strings.TrimSpace(h); trimmed != "" {
+				allow = append(allow, trimmed)
+			}
+		}
+		c.OfflineAllow = allow
+	}
+
 	// Owner IDs from env (comma-separated, whitespace-trimmed)
 	if v := os.Getenv("GOCLAW_OWNER_IDS"); v != "" {
 		var ids []string
@@ -272,9 +314,14 @@ func (c *Config) applyEnvOverrides() {
 
 	// Browser (for Docker-compose browser sidecar overlay)
 	envStr("GOCLAW_BROWSER_REMOTE_URL", &c.Tools.Browser.RemoteURL)
-	if c.Tools.Browser.RemoteURL != "" {
+	envStr("GOCLAW_BROWSER_CDP_URL", &c.Tools.Browser.CDPURL)
+	envStr("GOCLAW_BROWSER_CDP_TOKEN", &c.Tools.Browser.CDPToken)
+	if c.Tools.Browser.RemoteEndpoint() != "" {
 		c.Tools.Browser.Enabled = true
 	}
+
+	// Last, so env values can be references too.
+	c.resolveSecretRefs()
 }
 
 
@@ -371,6 +418,25 @@ func (c *Config) ResolveAgent(agentID string) AgentDefaults {
 	return d
 }
 
+// AgentWorkspacePath returns the expanded workspace directory holding an
+// agent's context files when no agent store is configured: the per-agent
+// override if set, the default workspace for the default agent, otherwise
+// <workspace>/agents/<id> (where agents.create seeds files).
+func (c *Config) AgentWorkspacePath(agentID string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	spec, ok := c.Agents.List[agentID]
+	if ok && spec.Workspace != "" {
+		return ExpandHome(spec.Workspace)
+	}
+	ws := ExpandHome(c.Agents.Defaults.Workspace)
+	if agentID == DefaultAgentID || (ok && spec.Default) {
+		return ws
+	}
+	return filepath.Join(ws, "agents", agentID)
+}
+
 // ResolveDefaultAgentID returns the ID of the agent marked as default,
 // or "default" if none is explicitly marked.
 func (c *Config) ResolveDefaultAgentID() string {
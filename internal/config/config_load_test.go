@@ -1,10 +1,13 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
 )
 
 // --- Default ---
@@ -130,6 +133,33 @@ func TestLoad_EnvVarAPIKeys(t *testing.T) {
 	}
 }
 
+type staticSecrets map[string]string
+
+func (s staticSecrets) Get(_ context.Context, key string) (string, error) {
+	if v, ok := s[key]; ok {
+		return v, nil
+	}
+	return "", secrets.ErrNotFound
+}
+
+func TestLoad_SecretReferences(t *testing.T) {
+	secrets.Register("loadtest", staticSecrets{"anthropic": "sk-from-store"})
+	t.Setenv("GOCLAW_ANTHROPIC_API_KEY", "secret://loadtest/anthropic")
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{providers: {openai: {api_key: "secret://loadtest/missing"}}}`), 0600)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+	if cfg.Providers.Anthropic.APIKey != "sk-from-store" {
+		t.Errorf("anthropic key: got %q", cfg.Providers.Anthropic.APIKey)
+	}
+	if cfg.Providers.OpenAI.APIKey != "" {
+		t.Errorf("unresolved reference must be cleared, got %q", cfg.Providers.OpenAI.APIKey)
+	}
+}
+
 // --- Allowed origins from JSON5 ---
 
 func TestLoad_AllowedOrigins_JSON5(t *testing.T) {
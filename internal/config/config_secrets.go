@@ -1,6 +1,10 @@
 package config
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
+)
 
 const secretMask = "***"
 
@@ -40,6 +44,15 @@ func (c *Config) MaskedCopy() *Config {
 
 	// Mask gateway token
 	maskNonEmpty(&cp.Gateway.Token)
+	if cp.Gateway.OIDC != nil {
+		maskNonEmpty(&cp.Gateway.OIDC.ClientSecret)
+		maskNonEmpty(&cp.Gateway.OIDC.SigningKey)
+	}
+
+	// Mask remote sync and skill registry credentials
+	maskNonEmpty(&cp.Sync.Token)
+	maskNonEmpty(&cp.Sync.Key)
+	maskNonEmpty(&cp.Skills.Registry.Token)
 
 	// Mask channel secrets
 	maskNonEmpty(&cp.Channels.Telegram.Token)
@@ -61,12 +74,23 @@ func (c *Config) MaskedCopy() *Config {
 	// Mask Tailscale auth key
 	maskNonEmpty(&cp.Tailscale.AuthKey)
 
+	// Mask browser CDP credentials
+	maskNonEmpty(&cp.Tools.Browser.CDPToken)
+	for k, v := range cp.Tools.Browser.CDPHeaders {
+		maskNonEmpty(&v)
+		cp.Tools.Browser.CDPHeaders[k] = v
+	}
+
 	return cp
 }
 
 // StripSecrets zeros out all secret fields in the config.
 // Used before saving to disk to ensure secrets never persist in config.json.
+// secret:// references are not secrets and are kept.
 func (c *Config) StripSecrets() {
+	refs := secrets.Refs(c)
+	defer secrets.SetFields(c, refs)
+
 	// Provider API keys
 	c.Providers.Anthropic.APIKey = ""
 	c.Providers.OpenAI.APIKey = ""
@@ -87,6 +111,15 @@ func (c *Config) StripSecrets() {
 
 	// Gateway token
 	c.Gateway.Token = ""
+	if c.Gateway.OIDC != nil {
+		c.Gateway.OIDC.ClientSecret = ""
+		c.Gateway.OIDC.SigningKey = ""
+	}
+
+	// Remote sync and skill registry credentials
+	c.Sync.Token = ""
+	c.Sync.Key = ""
+	c.Skills.Registry.Token = ""
 
 	// Channel secrets
 	c.Channels.Telegram.Token = ""
@@ -107,6 +140,9 @@ func (c *Config) StripSecrets() {
 
 	// Tailscale auth key
 	c.Tailscale.AuthKey = ""
+
+	// Browser CDP token
+	c.Tools.Browser.CDPToken = ""
 }
 
 // StripMaskedSecrets strips only fields that still contain the mask value "***".
@@ -139,6 +175,15 @@ func (c *Config) StripMaskedSecrets() {
 
 	// Gateway token
 	stripIfMasked(&c.Gateway.Token)
+	if c.Gateway.OIDC != nil {
+		stripIfMasked(&c.Gateway.OIDC.ClientSecret)
+		stripIfMasked(&c.Gateway.OIDC.SigningKey)
+	}
+
+	// Remote sync and skill registry credentials
+	stripIfMasked(&c.Sync.Token)
+	stripIfMasked(&c.Sync.Key)
+	stripIfMasked(&c.Skills.Registry.Token)
 
 	// Channel secrets
 	stripIfMasked(&c.Channels.Telegram.Token)
@@ -159,6 +204,14 @@ func (c *Config) StripMaskedSecrets() {
 
 	// Tailscale auth key
 	stripIfMasked(&c.Tailscale.AuthKey)
+
+	// Browser CDP credentials
+	stripIfMasked(&c.Tools.Browser.CDPToken)
+	for k, v := range c.Tools.Browser.CDPHeaders {
+		if v == secretMask {
+			delete(c.Tools.Browser.CDPHeaders, k)
+		}
+	}
 }
 
 // ApplyDBSecrets overlays secrets from the config_secrets table onto the config.
@@ -172,11 +225,17 @@ func (c *Config) ApplyDBSecrets(secrets map[string]string) {
 	}
 
 	apply("gateway.token", &c.Gateway.Token)
+	if c.Gateway.OIDC != nil {
+		apply("gateway.oidc.client_secret", &c.Gateway.OIDC.ClientSecret)
+		apply("gateway.oidc.signing_key", &c.Gateway.OIDC.SigningKey)
+	}
 	apply("tts.openai.api_key", &c.Tts.OpenAI.APIKey)
 	apply("tts.elevenlabs.api_key", &c.Tts.ElevenLabs.APIKey)
 	apply("tts.minimax.api_key", &c.Tts.MiniMax.APIKey)
 	apply("tts.minimax.group_id", &c.Tts.MiniMax.GroupID)
 	apply("tailscale.auth_key", &c.Tailscale.AuthKey)
+	apply("tools.browser.cdp_token", &c.Tools.Browser.CDPToken)
+	c.resolveSecretRefs()
 }
 
 // ExtractDBSecrets returns the config_secrets key-value pairs from the config.
@@ -191,11 +250,16 @@ func (c *Config) ExtractDBSecrets() map[string]string {
 	}
 
 	collect("gateway.token", c.Gateway.Token)
+	if c.Gateway.OIDC != nil {
+		collect("gateway.oidc.client_secret", c.Gateway.OIDC.ClientSecret)
+		collect("gateway.oidc.signing_key", c.Gateway.OIDC.SigningKey)
+	}
 	collect("tts.openai.api_key", c.Tts.OpenAI.APIKey)
 	collect("tts.elevenlabs.api_key", c.Tts.ElevenLabs.APIKey)
 	collect("tts.minimax.api_key", c.Tts.MiniMax.APIKey)
 	collect("tts.minimax.group_id", c.Tts.MiniMax.GroupID)
 	collect("tailscale.auth_key", c.Tailscale.AuthKey)
+	collect("tools.browser.cdp_token", c.Tools.Browser.CDPToken)
 
 	return secrets
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"log/slog"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -44,9 +45,10 @@ func (cw *Watcher) OnChange(handler ChangeHandler) {
 	cw.handlers = append(cw.handlers, handler)
 }
 
-// Start begins watching the config file for changes.
+// Start begins watching the config file for changes. The parent directory is
+// watched so editors that save by renaming a temp file over it are noticed.
 func (cw *Watcher) Start() error {
-	if err := cw.watcher.Add(cw.path); err != nil {
+	if err := cw.watcher.Add(filepath.Dir(cw.path)); err != nil {
 		return err
 	}
 
@@ -82,6 +84,9 @@ func (cw *Watcher) watchLoop() {
 				return
 			}
 
+			if filepath.Clean(event.Name) != filepath.Clean(cw.path) {
+				continue
+			}
 			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
 				continue
 			}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ReloadReport describes a config reload: which keys changed, which of them
+// took effect in the running gateway and which only apply after a restart.
+// Keys are dotted JSON paths up to three levels deep (e.g. "gateway.port",
+// "providers.openai.api_key", "agents.list.coder").
+type ReloadReport struct {
+	Trigger         string   `json:"trigger"` // "sighup", "file", "config.apply", "config.patch"
+	Changed         []string `json:"changed"`
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
+	Error           string   `json:"error,omitempty"` // set when the new config could not be loaded or failed validation; nothing was applied
+
+	Issues []ValidationIssue `json:"issues,omitempty"` // Validate() findings for the new config
+}
+
+// restartKeys are settings read once at gateway startup (listeners, stores,
+// background managers). Changes to them are saved but only apply after a
+// restart. Everything else is read live or re-applied on reload.
+var restartKeys = []string{
+	"data_dir",
+	"database",
+	"runtime",
+	"proxy",
+	"offline",
+	"offline_allow",
+	"tailscale",
+	"telemetry",
+	"sync",
+	"hooks",
+	"audio",
+	"agents.defaults.workspace",
+	"agents.defaults.sandbox",
+	"channels.pairing",
+	"gateway.host",
+	"gateway.port",
+	"gateway.token",
+	"gateway.scoped_tokens",
+	"gateway.owner_ids",
+	"gateway.tls",
+	"gateway.reuse_port",
+	"gateway.allowed_origins",
+	"gateway.allowed_cidrs",
+	"gateway.trusted_proxies",
+	"gateway.route_policies",
+	"gateway.ws_compression",
+	"gateway.rate_limit_rpm",
+	"gateway.inbound_debounce_ms",
+	"gateway.injection_action",
+	"gateway.cluster",
+	"gateway.lanes",
+	"gateway.fairness",
+	"gateway.queue_mode",
+	"gateway.snapshots",
+	"tools.mcp_servers",
+	"tools.browser",
+}
+
+// RequiresRestart reports whether a changed key only applies after a restart.
+func RequiresRestart(key string) bool {
+	for _, k := range restartKeys {
+		// Exact match, a change below a restart key, or a coarser change
+		// (e.g. "gateway" replaced wholesale) that contains one.
+		if key == k || strings.HasPrefix(key, k+".") || strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffReload compares two configs and classifies the changed keys. Values are
+// not included in the report, so secrets never leak through it.
+func DiffReload(oldCfg, newCfg *Config) ReloadReport {
+	var a, b map[string]any
+	oldCfg.mu.RLock()
+	oldData, _ := json.Marshal(oldCfg)
+	oldCfg.mu.RUnlock()
+	newCfg.mu.RLock()
+	newData, _ := json.Marshal(newCfg)
+	newCfg.mu.RUnlock()
+	json.Unmarshal(oldData, &a)
+	json.Unmarshal(newData, &b)
+
+	r := ReloadReport{Changed: []string{}, Applied: []string{}, RestartRequired: []string{}}
+	diffKeys("", a, b, 3, &r.Changed)
+	slices.Sort(r.Changed)
+	for _, k := range r.Changed {
+		if RequiresRestart(k) {
+			r.RestartRequired = append(r.RestartRequired, k)
+		} else {
+			r.Applied = append(r.Applied, k)
+		}
+	}
+	return r
+}
+
+// diffKeys appends the paths where a and b differ, descending into objects
+// until depth runs out.
+func diffKeys(prefix string, a, b map[string]any, depth int, out *[]string) {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range keys {
+		va, vb := a[k], b[k]
+		if reflect.DeepEqual(va, vb) {
+			continue
+		}
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		ma, aok := va.(map[string]any)
+		mb, bok := vb.(map[string]any)
+		if depth > 1 && (aok || va == nil) && (bok || vb == nil) && (aok || bok) {
+			diffKeys(path, ma, mb, depth-1, out)
+			continue
+		}
+		*out = append(*out, path)
+	}
+}
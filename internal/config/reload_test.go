@@ -0,0 +1,51 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffReload(t *testing.T) {
+	oldCfg := Default()
+	newCfg := Default()
+	newCfg.Providers.OpenAI.APIKey = "sk-new"
+	newCfg.Gateway.Port = oldCfg.Gateway.Port + 1
+	newCfg.Channels.Telegram.Enabled = true
+	newCfg.Agents.List = map[string]AgentSpec{"coder": {Model: "gpt-4o"}}
+
+	r := DiffReload(oldCfg, newCfg)
+
+	wantChanged := []string{"agents.list.coder", "channels.telegram.enabled", "gateway.port", "providers.openai.api_key"}
+	if !slices.Equal(r.Changed, wantChanged) {
+		t.Errorf("Changed = %v, want %v", r.Changed, wantChanged)
+	}
+	if !slices.Equal(r.RestartRequired, []string{"gateway.port"}) {
+		t.Errorf("RestartRequired = %v", r.RestartRequired)
+	}
+	if len(r.Applied) != 3 || slices.Contains(r.Applied, "gateway.port") {
+		t.Errorf("Applied = %v", r.Applied)
+	}
+
+	if r := DiffReload(oldCfg, Default()); len(r.Changed) != 0 {
+		t.Errorf("identical configs: Changed = %v", r.Changed)
+	}
+}
+
+func TestRequiresRestart(t *testing.T) {
+	for key, want := range map[string]bool{
+		"gateway.port":             true,
+		"gateway.tls.cert_file":    true,
+		"gateway":                  true, // coarse change containing restart keys
+		"database.postgres_dsn":    true,
+		"agents.defaults.sandbox":  true,
+		"agents.defaults.model":    false,
+		"gateway.quota":            false,
+		"providers.anthropic":      false,
+		"tools.web_fetch.policy":   false,
+		"gateway.portal_something": false, // prefix match is per segment
+	} {
+		if got := RequiresRestart(key); got != want {
+			t.Errorf("RequiresRestart(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
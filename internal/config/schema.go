@@ -0,0 +1,112 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SchemaURL is where the published schema lives; reference it from
+// config.json as "$schema" for editor completion.
+const SchemaURL = "https://raw.githubusercontent.com/nextlevelbuilder/goclaw/main/docs/config.schema.json"
+
+// sectionDescriptions annotates the top-level config sections in the schema.
+var sectionDescriptions = map[string]string{
+	"agents":    "Agent configuration (defaults + per-agent overrides)",
+	"channels":  "Channel configuration (telegram, discord, slack, etc.)",
+	"providers": "AI provider API keys and settings",
+	"gateway":   "Gateway server settings (host, port, token)",
+	"tools":     "Tool configuration (browser, exec, web search)",
+	"sessions":  "Session storage configuration",
+	"bindings":  "Route channel messages to agents by channel, account and peer",
+	"cron":      "Cron job retries, default time zone and timeouts",
+	"database":  "Database settings (the Postgres DSN comes from GOCLAW_POSTGRES_DSN only)",
+	"data_dir":  "Persistent data directory (default ~/.goclaw/data)",
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) for config.json, derived
+// from the Config struct's json tags. Unknown keys are allowed, as Load
+// ignores them.
+func JSONSchema() map[string]any {
+	s := schemaFor(reflect.TypeFor[Config](), map[reflect.Type]bool{})
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	s["$id"] = SchemaURL
+	s["title"] = "GoClaw configuration"
+
+	props := s["properties"].(map[string]any)
+	for key, desc := range sectionDescriptions {
+		if p, ok := props[key].(map[string]any); ok {
+			p["description"] = desc
+		}
+	}
+	props["$schema"] = map[string]any{"type": "string", "description": "JSON schema reference for editors"}
+	return s
+}
+
+var flexibleStringSliceType = reflect.TypeFor[FlexibleStringSlice]()
+
+// schemaFor maps a Go type to a schema. seen guards against recursive types.
+func schemaFor(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == flexibleStringSliceType {
+		return map[string]any{"type": "array", "items": map[string]any{"type": []string{"string", "number"}}}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]any{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		props := map[string]any{}
+		addStructFields(t, props, seen)
+		return map[string]any{"type": "object", "properties": props}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), seen)}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), seen)}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// addStructFields adds t's JSON fields to props, flattening untagged
+// embedded structs the way encoding/json does.
+func addStructFields(t reflect.Type, props map[string]any, seen map[reflect.Type]bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructFields(ft, props, seen)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = schemaFor(f.Type, seen)
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
+)
+
+// resolveSecretRefs replaces secret://<backend>/<key> values (from the file,
+// DB secrets or env vars) with the referenced secret. References that cannot
+// be resolved are cleared and logged, so they are never sent as credentials.
+// The references are remembered so RestoreSecretRefs can put them back
+// before the config is persisted.
+func (c *Config) resolveSecretRefs() {
+	refs := secrets.Refs(c)
+	if len(refs) == 0 {
+		return
+	}
+	if c.secretRefs == nil {
+		c.secretRefs = make(map[string]string, len(refs))
+	}
+	maps.Copy(c.secretRefs, refs)
+	for _, e := range secrets.ResolveFields(context.Background(), c) {
+		slog.Error("config: secret reference not resolved", "key", e.Path, "error", e.Err)
+	}
+}
+
+// SecretRefs returns the secret references resolved into c, keyed by dotted
+// JSON path.
+func (c *Config) SecretRefs() map[string]string {
+	return maps.Clone(c.secretRefs)
+}
+
+// RestoreSecretRefs writes refs (from SecretRefs) back in place of their
+// resolved values, so saving c persists the references rather than the
+// secrets. Call it on a copy, never on the live config.
+func (c *Config) RestoreSecretRefs(refs map[string]string) {
+	secrets.SetFields(c, refs)
+}
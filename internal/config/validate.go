@@ -0,0 +1,334 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Validation severities. Errors stop the gateway at startup; warnings are
+// logged and the affected feature is skipped or falls back to a default.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ValidationIssue is one problem found by Validate.
+type ValidationIssue struct {
+	Severity string `json:"severity"` // "error" or "warning"
+	Path     string `json:"path"`     // dotted config key, e.g. "channels.telegram.token"
+	Message  string `json:"message"`  // what is wrong and how to fix it
+}
+
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Path, i.Message)
+}
+
+// HasValidationErrors reports whether any issue is an error.
+func HasValidationErrors(issues []ValidationIssue) bool {
+	return slices.ContainsFunc(issues, func(i ValidationIssue) bool { return i.Severity == SeverityError })
+}
+
+var (
+	telegramTokenRe = regexp.MustCompile(`^\d+:[A-Za-z0-9_-]{30,}$`)
+	discordTokenRe  = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
+	activeHoursRe   = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d-([01]\d|2[0-3]):[0-5]\d$`)
+)
+
+// Validate checks a loaded config (env overrides applied) for problems that
+// parsing does not catch: port conflicts, malformed channel tokens, bad
+// bindings, durations and time zones, and workspace directories the gateway
+// cannot write to. It touches the filesystem only to probe writability.
+func (c *Config) Validate() []ValidationIssue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var issues []ValidationIssue
+	add := issueCollector(&issues)
+	c.validatePorts(add)
+	c.validateChannels(add)
+	c.validateBindings(add)
+	c.validateSchedules(add)
+
+	checkWritableDir(add, "data_dir", c.DataDir)
+	checkWritableDir(add, "agents.defaults.workspace", c.Agents.Defaults.Workspace)
+	for _, id := range slices.Sorted(maps.Keys(c.Agents.List)) {
+		if ws := c.Agents.List[id].Workspace; ws != "" {
+			checkWritableDir(add, "agents.list."+id+".workspace", ws)
+		}
+	}
+	return issues
+}
+
+type addIssueFunc func(severity, path, format string, args ...any)
+
+func issueCollector(issues *[]ValidationIssue) addIssueFunc {
+	return func(severity, path, format string, args ...any) {
+		*issues = append(*issues, ValidationIssue{Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// ValidateProviders warns when no config provider has credentials, or when
+// an agent names a config provider without them. Providers added in the
+// dashboard live in the DB, so the gateway does not run this at startup.
+func (c *Config) ValidateProviders() []ValidationIssue {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var issues []ValidationIssue
+	c.validateProviders(issueCollector(&issues))
+	return issues
+}
+
+// providerSections maps each providers.<key> section to whether it carries
+// credentials (API key, Ollama host, CLI path or ACP binary).
+func (c *Config) providerSections() map[string]bool {
+	p := c.Providers
+	return map[string]bool{
+		"anthropic":       p.Anthropic.APIKey != "",
+		"openai":          p.OpenAI.APIKey != "",
+		"openrouter":      p.OpenRouter.APIKey != "",
+		"groq":            p.Groq.APIKey != "",
+		"gemini":          p.Gemini.APIKey != "",
+		"deepseek":        p.DeepSeek.APIKey != "",
+		"mistral":         p.Mistral.APIKey != "",
+		"xai":             p.XAI.APIKey != "",
+		"minimax":         p.MiniMax.APIKey != "",
+		"cohere":          p.Cohere.APIKey != "",
+		"perplexity":      p.Perplexity.APIKey != "",
+		"dashscope":       p.DashScope.APIKey != "",
+		"bailian":         p.Bailian.APIKey != "",
+		"zai":             p.Zai.APIKey != "",
+		"zai_coding":      p.ZaiCoding.APIKey != "",
+		"ollama":          p.Ollama.Host != "",
+		"ollama_cloud":    p.OllamaCloud.APIKey != "",
+		"claude_cli":      p.ClaudeCLI.CLIPath != "",
+		"acp":             p.ACP.Binary != "",
+		"novita":          p.Novita.APIKey != "",
+		"byteplus":        p.BytePlus.APIKey != "",
+		"byteplus_coding": p.BytePlusCoding.APIKey != "",
+	}
+}
+
+// validateProviders backs ValidateProviders. Names that are not config
+// sections may be DB providers and are not checked.
+func (c *Config) validateProviders(add addIssueFunc) {
+	sections := c.providerSections()
+	if !slices.Contains(slices.Collect(maps.Values(sections)), true) {
+		add(SeverityWarning, "providers", "no provider has credentials in config; set an API key (e.g. GOCLAW_ANTHROPIC_API_KEY) unless providers are added in the dashboard")
+		return
+	}
+	check := func(path, name string) {
+		key := strings.ReplaceAll(name, "-", "_")
+		if configured, known := sections[key]; known && !configured {
+			add(SeverityWarning, path, "provider %q has no credentials; set providers.%s or its env var", name, key)
+		}
+	}
+	if p := c.Agents.Defaults.Provider; p != "" {
+		check("agents.defaults.provider", p)
+	}
+	for _, id := range slices.Sorted(maps.Keys(c.Agents.List)) {
+		if p := c.Agents.List[id].Provider; p != "" {
+			check("agents.list."+id+".provider", p)
+		}
+	}
+}
+
+// validatePorts checks port ranges and that no two listeners share a port.
+func (c *Config) validatePorts(add addIssueFunc) {
+	type listener struct {
+		path string
+		port int
+	}
+	var ports []listener
+	ports = append(ports, listener{"gateway.port", c.Gateway.Port})
+	if missing := c.Gateway.TLS.MissingKeyPairField(); missing != "" {
+		add(SeverityError, "gateway.tls."+missing, "cert_file and key_file must be set together; the gateway will not fall back to plain HTTP")
+	} else {
+		for _, f := range c.Gateway.TLS.IgnoredFields() {
+			add(SeverityError, "gateway.tls."+f, "is set but TLS is off; set cert_file and key_file, or acme_domains (the gateway will not fall back to plain HTTP)")
+		}
+	}
+	if c.Gateway.TLS.Enabled() {
+		if p := c.Gateway.TLS.LoopbackPort; p != 0 {
+			ports = append(ports, listener{"gateway.tls.loopback_port", p})
+		}
+		if addr := c.Gateway.TLS.ACMEHTTPAddr; addr != "" {
+			_, portStr, err := net.SplitHostPort(addr)
+			port, convErr := strconv.Atoi(portStr)
+			if err != nil || convErr != nil {
+				add(SeverityError, "gateway.tls.acme_http_addr", "%q is not a host:port address (e.g. \":80\")", addr)
+			} else {
+				ports = append(ports, listener{"gateway.tls.acme_http_addr", port})
+			}
+		}
+	}
+	if f := c.Channels.Feishu; f.Enabled && f.ConnectionMode == "webhook" && f.WebhookPort > 0 {
+		ports = append(ports, listener{"channels.feishu.webhook_port", f.WebhookPort})
+	}
+
+	seen := map[int]string{}
+	for _, l := range ports {
+		if l.port < 1 || l.port > 65535 {
+			add(SeverityError, l.path, "port %d is out of range (1-65535)", l.port)
+			continue
+		}
+		if other, ok := seen[l.port]; ok {
+			add(SeverityError, l.path, "port %d is already used by %s", l.port, other)
+			continue
+		}
+		seen[l.port] = l.path
+	}
+}
+
+// validateChannels checks credentials of enabled config channels. A channel
+// with missing or malformed credentials fails to start but does not stop
+// the gateway, so these are warnings.
+func (c *Config) validateChannels(add addIssueFunc) {
+	ch := c.Channels
+	if ch.Telegram.Enabled {
+		switch {
+		case ch.Telegram.Token == "":
+			add(SeverityWarning, "channels.telegram.token", "telegram is enabled but has no token; set GOCLAW_TELEGRAM_TOKEN")
+		case !telegramTokenRe.MatchString(ch.Telegram.Token):
+			add(SeverityWarning, "channels.telegram.token", "does not look like a BotFather token (<bot id>:<secret>)")
+		}
+	}
+	if ch.Discord.Enabled {
+		switch {
+		case ch.Discord.Token == "":
+			add(SeverityWarning, "channels.discord.token", "discord is enabled but has no token; set GOCLAW_DISCORD_TOKEN")
+		case strings.HasPrefix(ch.Discord.Token, "Bot "):
+			add(SeverityWarning, "channels.discord.token", "remove the \"Bot \" prefix; it is added automatically")
+		case !discordTokenRe.MatchString(ch.Discord.Token):
+			add(SeverityWarning, "channels.discord.token", "does not look like a Discord bot token (three dot-separated parts)")
+		}
+	}
+	if ch.Slack.Enabled {
+		checkPrefixedToken(add, "channels.slack.bot_token", ch.Slack.BotToken, "xoxb-", "GOCLAW_SLACK_BOT_TOKEN")
+		checkPrefixedToken(add, "channels.slack.app_token", ch.Slack.AppToken, "xapp-", "GOCLAW_SLACK_APP_TOKEN")
+		checkPrefixedToken(add, "channels.slack.user_token", ch.Slack.UserToken, "xoxp-", "")
+	}
+	if ch.Zalo.Enabled && ch.Zalo.Token == "" {
+		add(SeverityWarning, "channels.zalo.token", "zalo is enabled but has no token; set GOCLAW_ZALO_TOKEN")
+	}
+	if ch.Feishu.Enabled {
+		if ch.Feishu.AppID == "" || ch.Feishu.AppSecret == "" {
+			add(SeverityWarning, "channels.feishu.app_id", "feishu is enabled but app_id or app_secret is empty")
+		} else if !strings.HasPrefix(ch.Feishu.AppID, "cli_") {
+			add(SeverityWarning, "channels.feishu.app_id", "does not look like a Feishu/Lark app ID (cli_...)")
+		}
+	}
+}
+
+// checkPrefixedToken checks a token's well-known prefix. An empty token is
+// reported only when envVar is set (the token is required).
+func checkPrefixedToken(add addIssueFunc, path, token, prefix, envVar string) {
+	switch {
+	case token == "":
+		if envVar != "" {
+			add(SeverityWarning, path, "empty; set %s (%s...)", envVar, prefix)
+		}
+	case !strings.HasPrefix(token, prefix):
+		add(SeverityWarning, path, "expected a token starting with %q", prefix)
+	}
+}
+
+// validateBindings checks that each binding names an agent and a channel.
+func (c *Config) validateBindings(add addIssueFunc) {
+	for i, b := range c.Bindings {
+		path := fmt.Sprintf("bindings[%d]", i)
+		if b.AgentID == "" {
+			add(SeverityWarning, path+".agentId", "binding has no agentId and is ignored")
+		} else if len(c.Agents.List) > 0 {
+			if _, ok := c.Agents.List[b.AgentID]; !ok {
+				add(SeverityWarning, path+".agentId", "agent %q is not in agents.list (fine if it is a DB agent)", b.AgentID)
+			}
+		}
+		if b.Match.Channel == "" {
+			add(SeverityWarning, path+".match.channel", "binding has no channel and never matches")
+		}
+		if p := b.Match.Peer; p != nil && p.Kind != "direct" && p.Kind != "group" {
+			add(SeverityWarning, path+".match.peer.kind", "kind %q must be \"direct\" or \"group\"", p.Kind)
+		}
+	}
+}
+
+// validateSchedules checks cron settings and per-agent heartbeat overrides.
+func (c *Config) validateSchedules(add addIssueFunc) {
+	if tz := c.Cron.DefaultTimezone; tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			add(SeverityWarning, "cron.default_timezone", "unknown IANA time zone %q; jobs fall back to UTC", tz)
+		}
+	}
+	if jt := c.Cron.JobTimeout; jt != "" {
+		if d, err := time.ParseDuration(jt); err != nil || d <= 0 {
+			add(SeverityWarning, "cron.job_timeout", "%q is not a positive Go duration (e.g. \"10m\"); using %s", jt, DefaultJobTimeout)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(c.Agents.List)) {
+		hb := c.Agents.List[id].Heartbeat
+		if hb == nil {
+			continue
+		}
+		path := "agents.list." + id + ".heartbeat"
+		if hb.Every != "" {
+			if d, err := time.ParseDuration(hb.Every); err != nil {
+				add(SeverityWarning, path+".every", "%q is not a Go duration (e.g. \"30m\")", hb.Every)
+			} else if d < 5*time.Minute {
+				add(SeverityWarning, path+".every", "%s is below the 5m minimum", hb.Every)
+			}
+		}
+		if hb.ActiveHours != "" && !activeHoursRe.MatchString(hb.ActiveHours) {
+			add(SeverityWarning, path+".active_hours", "%q must be HH:MM-HH:MM", hb.ActiveHours)
+		}
+		if hb.Timezone != "" {
+			if _, err := time.LoadLocation(hb.Timezone); err != nil {
+				add(SeverityWarning, path+".timezone", "unknown IANA time zone %q", hb.Timezone)
+			}
+		}
+	}
+}
+
+// checkWritableDir reports an error when dir (or, if it does not exist yet,
+// its nearest existing parent) is not a writable directory.
+func checkWritableDir(add addIssueFunc, path, dir string) {
+	if dir == "" {
+		return
+	}
+	dir = ExpandHome(dir)
+	probe := dir
+	for {
+		info, err := os.Stat(probe)
+		if err == nil {
+			if !info.IsDir() {
+				add(SeverityError, path, "%s is not a directory", probe)
+				return
+			}
+			break
+		}
+		parent := filepath.Dir(probe)
+		if parent == probe {
+			return
+		}
+		probe = parent
+	}
+	f, err := os.CreateTemp(probe, ".goclaw-write-check-*")
+	if err != nil {
+		if probe == dir {
+			add(SeverityError, path, "%s is not writable by this user", dir)
+		} else {
+			add(SeverityError, path, "%s does not exist and cannot be created: %s is not writable", dir, probe)
+		}
+		return
+	}
+	f.Close()
+	os.Remove(f.Name())
+}
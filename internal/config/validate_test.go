@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func issuePaths(issues []ValidationIssue, severity string) []string {
+	var paths []string
+	for _, i := range issues {
+		if i.Severity == severity {
+			paths = append(paths, i.Path)
+		}
+	}
+	return paths
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	notDir := filepath.Join(dir, "file")
+	os.WriteFile(notDir, []byte("x"), 0644)
+
+	cfg := Default()
+	cfg.DataDir = filepath.Join(dir, "data") // missing, parent writable: fine
+	cfg.Agents.Defaults.Workspace = notDir
+	cfg.Gateway.TLS = &GatewayTLSConfig{CertFile: "c.pem", KeyFile: "k.pem", LoopbackPort: cfg.Gateway.Port}
+	cfg.Channels.Telegram = TelegramConfig{Enabled: true, Token: "not-a-token"}
+	cfg.Channels.Slack = SlackConfig{Enabled: true, BotToken: "xoxb-1", AppToken: "xoxb-2"}
+	cfg.Bindings = []AgentBinding{{AgentID: "coder", Match: BindingMatch{Channel: "telegram", Peer: &BindingPeer{Kind: "dm"}}}}
+	cfg.Agents.List = map[string]AgentSpec{"coder": {Heartbeat: &HeartbeatSpec{Every: "1m", ActiveHours: "9-17"}}}
+
+	issues := cfg.Validate()
+	if got := issuePaths(issues, SeverityError); !slices.Equal(got, []string{"gateway.tls.loopback_port", "agents.defaults.workspace"}) {
+		t.Errorf("errors = %v", got)
+	}
+	wantWarnings := []string{
+		"channels.telegram.token",
+		"channels.slack.app_token",
+		"bindings[0].match.peer.kind",
+		"agents.list.coder.heartbeat.every",
+		"agents.list.coder.heartbeat.active_hours",
+	}
+	if got := issuePaths(issues, SeverityWarning); !slices.Equal(got, wantWarnings) {
+		t.Errorf("warnings = %v, want %v", got, wantWarnings)
+	}
+	if !HasValidationErrors(issues) {
+		t.Error("expected HasValidationErrors")
+	}
+
+	half := Default()
+	half.DataDir = filepath.Join(dir, "data")
+	half.Agents.Defaults.Workspace = filepath.Join(dir, "ws")
+	half.Gateway.TLS = &GatewayTLSConfig{CertFile: "c.pem"}
+	if got := issuePaths(half.Validate(), SeverityError); !slices.Equal(got, []string{"gateway.tls.key_file"}) {
+		t.Errorf("half-configured TLS errors = %v", got)
+	}
+	for _, tt := range []struct {
+		tls  *GatewayTLSConfig
+		want []string
+	}{
+		{&GatewayTLSConfig{ClientAuth: "require", ClientCAFile: "ca.pem"}, []string{"gateway.tls.client_ca_file", "gateway.tls.client_auth"}},
+		{&GatewayTLSConfig{ACMEEmail: "ops@example.com"}, []string{"gateway.tls.acme_email"}},
+	} {
+		half.Gateway.TLS = tt.tls
+		if got := issuePaths(half.Validate(), SeverityError); !slices.Equal(got, tt.want) {
+			t.Errorf("TLS %+v errors = %v, want %v", tt.tls, got, tt.want)
+		}
+	}
+
+	clean := Default()
+	clean.DataDir = filepath.Join(dir, "data")
+	clean.Agents.Defaults.Workspace = filepath.Join(dir, "ws")
+	if issues := clean.Validate(); len(issues) != 0 {
+		t.Errorf("default config: %v", issues)
+	}
+}
+
+func TestValidateProviders(t *testing.T) {
+	cfg := Default()
+	if got := issuePaths(cfg.ValidateProviders(), SeverityWarning); !slices.Equal(got, []string{"providers"}) {
+		t.Errorf("no providers: %v", got)
+	}
+
+	cfg.Providers.OpenAI.APIKey = "sk-test"
+	cfg.Agents.List = map[string]AgentSpec{"a": {Provider: "openai"}, "b": {Provider: "my-db-provider"}}
+	if got := issuePaths(cfg.ValidateProviders(), SeverityWarning); !slices.Equal(got, []string{"agents.defaults.provider"}) {
+		t.Errorf("default anthropic without key: %v", got)
+	}
+}
+
+// The published schema must match the Config struct; regenerate with
+// `go run . config schema > docs/config.schema.json`.
+func TestJSONSchemaPublished(t *testing.T) {
+	published, err := os.ReadFile("../../docs/config.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.MarshalIndent(JSONSchema(), "", "  ")
+	if string(published) != string(data)+"\n" {
+		t.Error("docs/config.schema.json is stale; run: go run . config schema > docs/config.schema.json")
+	}
+
+	props := JSONSchema()["properties"].(map[string]any)
+	port := props["gateway"].(map[string]any)["properties"].(map[string]any)["port"]
+	if port.(map[string]any)["type"] != "integer" {
+		t.Errorf("gateway.port = %v", port)
+	}
+}
@@ -67,18 +67,23 @@ func newAgentStoreResolver(agents store.AgentCRUDStore) DreamingConfigResolver {
 		return nil
 	}
 	return func(ctx context.Context, agentID string) *config.DreamingConfig {
-		id, err := uuid.Parse(agentID)
-		if err != nil {
-			return nil
+		if mc := agentMemoryConfig(ctx, agents, agentID); mc != nil {
+			return mc.Dreaming
 		}
-		ag, err := agents.GetByIDUnscoped(ctx, id)
-		if err != nil || ag == nil {
-			return nil
-		}
-		mc := ag.ParseMemoryConfig()
-		if mc == nil {
-			return nil
-		}
-		return mc.Dreaming
+		return nil
+	}
+}
+
+// agentMemoryConfig loads the agent's MemoryConfig, or nil when the agent
+// is unknown or has none.
+func agentMemoryConfig(ctx context.Context, agents store.AgentCRUDStore, agentID string) *config.MemoryConfig {
+	id, err := uuid.Parse(agentID)
+	if err != nil {
+		return nil
+	}
+	ag, err := agents.GetByIDUnscoped(ctx, id)
+	if err != nil || ag == nil {
+		return nil
 	}
+	return ag.ParseMemoryConfig()
 }
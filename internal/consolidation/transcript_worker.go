@@ -0,0 +1,189 @@
+package consolidation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/eventbus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	// transcriptDir holds ingested session summaries in the memory store.
+	transcriptDir = "_system/transcripts"
+
+	transcriptDefaultRetention = 90 * 24 * time.Hour
+	transcriptRedactedMarker   = "[REDACTED]"
+)
+
+// transcriptPIIPatterns are redacted from summaries before they are stored
+// when scrubbing is on. Card numbers go first so the phone pattern does not
+// leave partial digits behind.
+var transcriptPIIPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+`),
+	regexp.MustCompile(`(?i)Bearer\s+[A-Za-z0-9._\-]{8,}`),
+	regexp.MustCompile(`sk-[A-Za-z0-9_\-]{8,}`),
+	regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+	regexp.MustCompile(`\+\d[\d\s().-]{6,}\d`),
+	regexp.MustCompile(`\(?\b\d{3}\)?[\s.-]?\d{3}[\s.-]\d{4}\b`),
+}
+
+// TranscriptConfigResolver fetches per-agent transcript ingestion config at
+// event handling time.
+type TranscriptConfigResolver func(ctx context.Context, agentID string) *config.TranscriptMemoryConfig
+
+// newTranscriptConfigResolver reads MemoryConfig.Transcripts from the agent
+// store. Returns nil if the store is nil, which leaves ingestion disabled.
+func newTranscriptConfigResolver(agents store.AgentCRUDStore) TranscriptConfigResolver {
+	if agents == nil {
+		return nil
+	}
+	return func(ctx context.Context, agentID string) *config.TranscriptMemoryConfig {
+		if mc := agentMemoryConfig(ctx, agents, agentID); mc != nil {
+			return mc.Transcripts
+		}
+		return nil
+	}
+}
+
+// resolvedTranscriptConfig is the concrete view of TranscriptMemoryConfig.
+type resolvedTranscriptConfig struct {
+	Retention time.Duration
+	Scrub     []*regexp.Regexp // nil = no scrubbing
+}
+
+// resolveTranscriptConfig applies defaults to an enabled config. Invalid
+// extra patterns are logged and skipped rather than disabling ingestion.
+func resolveTranscriptConfig(cfg *config.TranscriptMemoryConfig, agentID string) resolvedTranscriptConfig {
+	out := resolvedTranscriptConfig{Retention: transcriptDefaultRetention}
+	if cfg.RetentionDays > 0 {
+		out.Retention = time.Duration(cfg.RetentionDays) * 24 * time.Hour
+	}
+	if cfg.ScrubPII != nil && !*cfg.ScrubPII {
+		return out
+	}
+	out.Scrub = append(out.Scrub, transcriptPIIPatterns...)
+	for _, p := range cfg.ScrubPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			slog.Warn("transcripts: invalid scrub pattern skipped", "agent", agentID, "pattern", p, "error", err)
+			continue
+		}
+		out.Scrub = append(out.Scrub, re)
+	}
+	return out
+}
+
+// scrubTranscript replaces every match of patterns with a fixed marker.
+func scrubTranscript(s string, patterns []*regexp.Regexp) string {
+	for _, p := range patterns {
+		s = p.ReplaceAllString(s, transcriptRedactedMarker)
+	}
+	return s
+}
+
+// transcriptWorker writes each episodic summary to a memory document so
+// memory_search can recall past conversations. Opt-in per agent via
+// MemoryConfig.Transcripts.
+type transcriptWorker struct {
+	memoryStore   store.MemoryStore
+	resolveConfig TranscriptConfigResolver
+	now           func() time.Time
+}
+
+// Handle processes an episodic.created event into a transcript memory document.
+func (w *transcriptWorker) Handle(ctx context.Context, event eventbus.DomainEvent) error {
+	if w.memoryStore == nil || w.resolveConfig == nil {
+		return nil
+	}
+	payload, ok := event.Payload.(*eventbus.EpisodicCreatedPayload)
+	if !ok {
+		return fmt.Errorf("transcripts: unexpected payload type %T", event.Payload)
+	}
+	if event.TenantID != "" {
+		if tid, err := uuid.Parse(event.TenantID); err == nil {
+			ctx = store.WithTenantID(ctx, tid)
+		}
+	}
+
+	raw := w.resolveConfig(ctx, event.AgentID)
+	if raw == nil || !raw.Enabled {
+		return nil
+	}
+	if strings.TrimSpace(payload.Summary) == "" {
+		return nil
+	}
+	cfg := resolveTranscriptConfig(raw, event.AgentID)
+	now := w.clock()
+
+	docPath := transcriptPath(now, payload.EpisodicID)
+	content := fmt.Sprintf("# Conversation on %s\n\nSession: %s\n\n%s\n",
+		now.Format("2006-01-02"),
+		scrubTranscript(payload.SessionKey, cfg.Scrub),
+		strings.TrimSpace(scrubTranscript(payload.Summary, cfg.Scrub)))
+
+	if err := w.memoryStore.PutDocument(ctx, event.AgentID, event.UserID, docPath, content); err != nil {
+		return fmt.Errorf("transcripts: store document: %w", err)
+	}
+	if err := w.memoryStore.IndexDocument(ctx, event.AgentID, event.UserID, docPath); err != nil {
+		slog.Warn("transcripts: index document failed", "err", err, "path", docPath, "agent", event.AgentID)
+	}
+
+	w.prune(ctx, event.AgentID, event.UserID, now.Add(-cfg.Retention))
+	slog.Debug("transcripts: ingested session", "session", payload.SessionKey, "path", docPath, "agent", event.AgentID)
+	return nil
+}
+
+// prune deletes the user's transcript documents dated before cutoff.
+func (w *transcriptWorker) prune(ctx context.Context, agentID, userID string, cutoff time.Time) {
+	docs, err := w.memoryStore.ListDocuments(ctx, agentID, userID)
+	if err != nil {
+		slog.Warn("transcripts: list documents failed", "err", err, "agent", agentID)
+		return
+	}
+	for _, d := range docs {
+		if d.UserID != userID || path.Dir(d.Path) != transcriptDir {
+			continue
+		}
+		day, ok := transcriptDate(d.Path)
+		if !ok || !day.Before(cutoff) {
+			continue
+		}
+		if err := w.memoryStore.DeleteDocument(ctx, agentID, userID, d.Path); err != nil {
+			slog.Warn("transcripts: delete expired document failed", "err", err, "path", d.Path, "agent", agentID)
+		}
+	}
+}
+
+func (w *transcriptWorker) clock() time.Time {
+	if w.now != nil {
+		return w.now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// transcriptPath names the document by day so retention can be applied
+// from the path alone.
+func transcriptPath(day time.Time, episodicID string) string {
+	return fmt.Sprintf("%s/%s-%s.md", transcriptDir, day.Format("20060102"), episodicID)
+}
+
+// transcriptDate parses the day prefix written by transcriptPath.
+func transcriptDate(p string) (time.Time, bool) {
+	base := path.Base(p)
+	if len(base) < 8 {
+		return time.Time{}, false
+	}
+	day, err := time.Parse("20060102", base[:8])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
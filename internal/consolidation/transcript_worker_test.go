@@ -0,0 +1,111 @@
+package consolidation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/eventbus"
+)
+
+func transcriptEvent(summary string) eventbus.DomainEvent {
+	return eventbus.DomainEvent{
+		Type:     eventbus.EventEpisodicCreated,
+		TenantID: "0193a5b0-7000-7000-8000-000000000001",
+		AgentID:  "0193a5b0-7000-7000-8000-000000000002",
+		UserID:   "user-1",
+		Payload: &eventbus.EpisodicCreatedPayload{
+			EpisodicID: "ep-1",
+			SessionKey: "agent:bot:telegram:direct:42",
+			Summary:    summary,
+		},
+	}
+}
+
+func newTestTranscriptWorker(cfg *config.TranscriptMemoryConfig, now time.Time) (*transcriptWorker, *mockMemoryStore) {
+	mem := newMockMemoryStore()
+	return &transcriptWorker{
+		memoryStore: mem,
+		resolveConfig: func(context.Context, string) *config.TranscriptMemoryConfig {
+			return cfg
+		},
+		now: func() time.Time { return now },
+	}, mem
+}
+
+func TestTranscriptWorker_DisabledByDefault(t *testing.T) {
+	for _, cfg := range []*config.TranscriptMemoryConfig{nil, {RetentionDays: 7}} {
+		w, mem := newTestTranscriptWorker(cfg, time.Now())
+		if err := w.Handle(context.Background(), transcriptEvent("talked about Go")); err != nil {
+			t.Fatal(err)
+		}
+		if len(mem.docs) != 0 {
+			t.Errorf("cfg %+v: wrote %d documents, want none", cfg, len(mem.docs))
+		}
+	}
+}
+
+func TestTranscriptWorker_IngestsScrubbedSummary(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	w, mem := newTestTranscriptWorker(&config.TranscriptMemoryConfig{
+		Enabled:       true,
+		ScrubPatterns: []string{`ACME-\d+`, `(`},
+	}, now)
+
+	summary := "User (jane@example.com, +1 415 555 0100) shared key sk-abcdef123456 and ticket ACME-991. Release is 2026-03-20."
+	if err := w.Handle(context.Background(), transcriptEvent(summary)); err != nil {
+		t.Fatal(err)
+	}
+
+	path := "_system/transcripts/20260314-ep-1.md"
+	doc, ok := mem.docs[path]
+	if !ok {
+		t.Fatalf("document %s not written; have %v", path, mem.docs)
+	}
+	if !mem.indexed[path] {
+		t.Error("document should be indexed for memory_search")
+	}
+	for _, leaked := range []string{"jane@example.com", "555 0100", "sk-abcdef", "ACME-991"} {
+		if strings.Contains(doc, leaked) {
+			t.Errorf("document leaks %q:\n%s", leaked, doc)
+		}
+	}
+	if !strings.Contains(doc, "2026-03-20") || !strings.Contains(doc, "# Conversation on 2026-03-14") {
+		t.Errorf("document lost non-PII content:\n%s", doc)
+	}
+}
+
+func TestTranscriptWorker_ScrubCanBeDisabled(t *testing.T) {
+	off := false
+	w, mem := newTestTranscriptWorker(&config.TranscriptMemoryConfig{Enabled: true, ScrubPII: &off}, time.Now())
+	if err := w.Handle(context.Background(), transcriptEvent("mail jane@example.com")); err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range mem.docs {
+		if !strings.Contains(doc, "jane@example.com") {
+			t.Errorf("scrub_pii=false should keep the summary as is:\n%s", doc)
+		}
+	}
+}
+
+func TestTranscriptWorker_PrunesExpired(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	w, mem := newTestTranscriptWorker(&config.TranscriptMemoryConfig{Enabled: true, RetentionDays: 30}, now)
+	mem.docs["_system/transcripts/20260101-old.md"] = "old"
+	mem.docs["_system/transcripts/20260301-recent.md"] = "recent"
+	mem.docs["MEMORY.md"] = "notes"
+
+	if err := w.Handle(context.Background(), transcriptEvent("summary")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := mem.docs["_system/transcripts/20260101-old.md"]; ok {
+		t.Error("transcript past retention should be deleted")
+	}
+	for _, keep := range []string{"_system/transcripts/20260301-recent.md", "MEMORY.md", "_system/transcripts/20260314-ep-1.md"} {
+		if _, ok := mem.docs[keep]; !ok {
+			t.Errorf("%s should be kept", keep)
+		}
+	}
+}
@@ -28,7 +28,8 @@ type ConsolidationDeps struct {
 	AlertDeps     bgalert.AlertDeps // for reporting non-retryable LLM errors
 	// AgentStore is optional: when present, the dreaming worker reads
 	// per-agent overrides from MemoryConfig.Dreaming. If nil, the worker
-	// uses its built-in defaults for every agent.
+	// uses its built-in defaults for every agent, and transcript ingestion
+	// (MemoryConfig.Transcripts) stays off.
 	AgentStore store.AgentCRUDStore
 }
 
@@ -63,11 +64,16 @@ func Register(deps ConsolidationDeps) func() {
 		debounce:      dreamingDefaultDebounce,
 		resolveConfig: newAgentStoreResolver(deps.AgentStore),
 	}
+	transcripts := &transcriptWorker{
+		memoryStore:   deps.MemoryStore,
+		resolveConfig: newTranscriptConfigResolver(deps.AgentStore),
+	}
 
 	unsub1 := deps.EventBus.Subscribe(eventbus.EventSessionCompleted, episodic.Handle)
 	unsub2 := deps.EventBus.Subscribe(eventbus.EventEpisodicCreated, semantic.Handle)
 	unsub3 := deps.EventBus.Subscribe(eventbus.EventEntityUpserted, dedup.Handle)
 	unsub4 := deps.EventBus.Subscribe(eventbus.EventEpisodicCreated, dreaming.Handle)
+	unsub5 := deps.EventBus.Subscribe(eventbus.EventEpisodicCreated, transcripts.Handle)
 
 	// Periodic pruning of expired episodic summaries (runs every 6 hours).
 	pruneStop := make(chan struct{})
@@ -89,7 +95,14 @@ func Register(deps ConsolidationDeps) func() {
 		}
 	}()
 
-	return func() { unsub1(); unsub2(); unsub3(); unsub4(); close(pruneStop) }
+	return func() {
+		unsub1()
+		unsub2()
+		unsub3()
+		unsub4()
+		unsub5()
+		close(pruneStop)
+	}
 }
 
 // summarizationPrompt for LLM session summarization.
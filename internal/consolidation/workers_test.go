@@ -203,9 +203,24 @@ func (m *mockMemoryStore) IndexDocument(_ context.Context, _, _, path string) er
 
 // Implement remaining store.MemoryStore methods
 func (m *mockMemoryStore) GetDocument(context.Context, string, string, string) (string, error) { return "", nil }
-func (m *mockMemoryStore) DeleteDocument(context.Context, string, string, string) error { return nil }
-func (m *mockMemoryStore) ListDocuments(context.Context, string, string) ([]store.DocumentInfo, error) { return nil, nil }
+func (m *mockMemoryStore) DeleteDocument(_ context.Context, _, _, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.docs, path)
+	return nil
+}
+
+func (m *mockMemoryStore) ListDocuments(_ context.Context, _, userID string) ([]store.DocumentInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []store.DocumentInfo
+	for path := range m.docs {
+		out = append(out, store.DocumentInfo{Path: path, UserID: userID})
+	}
+	return out, nil
+}
 func (m *mockMemoryStore) ListAllDocumentsGlobal(context.Context) ([]store.DocumentInfo, error) { return nil, nil }
+func (m *mockMemoryStore) Stats(context.Context, string) (*store.MemoryStats, error)              { return nil, nil }
 func (m *mockMemoryStore) ListAllDocuments(context.Context, string) ([]store.DocumentInfo, error) { return nil, nil }
 func (m *mockMemoryStore) GetDocumentDetail(context.Context, string, string, string) (*store.DocumentDetail, error) { return nil, nil }
 func (m *mockMemoryStore) ListChunks(context.Context, string, string, string) ([]store.ChunkInfo, error) { return nil, nil }
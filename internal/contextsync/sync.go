@@ -0,0 +1,330 @@
+// Package contextsync keeps agent-level context files (AGENTS.md, SOUL.md, ...)
+// in step between an agent's workspace directory and the managed store.
+//
+// In managed mode the store is what the agent reads, so edits made with
+// workspace tooling (an editor, git, the exec tool) used to be invisible, and
+// dashboard edits never reached the workspace. The service polls both sides
+// and copies whichever side changed since the last sync. When both changed,
+// the store wins and the workspace version is kept next to the file as
+// "<name>.conflict".
+//
+// Only master-tenant agents with their own workspace directory are synced.
+// Agents that share a directory (the global default workspace, tenant
+// workspaces) are skipped, since their files would overwrite each other.
+package contextsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// DefaultInterval is the polling interval when none is configured.
+const DefaultInterval = 30 * time.Second
+
+// ConflictSuffix is appended to the workspace copy kept on a conflict.
+const ConflictSuffix = ".conflict"
+
+// Files are the agent-level context files kept in sync. Per-user files
+// (USER.md, BOOTSTRAP.md) only exist in the store.
+var Files = []string{
+	bootstrap.AgentsFile,
+	bootstrap.SoulFile,
+	bootstrap.IdentityFile,
+	bootstrap.CapabilitiesFile,
+	bootstrap.HeartbeatFile,
+	bootstrap.UserPredefinedFile,
+}
+
+// AgentStore is the subset of store.AgentStore the service needs.
+type AgentStore interface {
+	List(ctx context.Context, ownerID string) ([]store.AgentData, error)
+	GetAgentContextFiles(ctx context.Context, agentID uuid.UUID) ([]store.AgentContextFileData, error)
+	SetAgentContextFile(ctx context.Context, agentID uuid.UUID, fileName, content string) error
+}
+
+// Conflict describes a file edited on both sides since the last sync.
+type Conflict struct {
+	AgentID  uuid.UUID
+	AgentKey string
+	File     string
+	SavedAs  string // path of the preserved workspace version
+}
+
+// Service syncs context files between workspaces and the store.
+type Service struct {
+	agents    AgentStore
+	statePath string // "" = in-memory only
+
+	onChange   func(agentID uuid.UUID) // store content changed (invalidate caches)
+	onConflict func(Conflict)
+
+	mu    sync.Mutex
+	state map[string]string // "agentID/file" → sha256 of the last synced content
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a sync service. statePath records what was last synced, so
+// the direction of a change can be told apart across restarts.
+func New(agents AgentStore, statePath string) *Service {
+	s := &Service{agents: agents, statePath: statePath, state: map[string]string{}, stopCh: make(chan struct{})}
+	s.load()
+	return s
+}
+
+// SetOnChange sets the callback run after a workspace edit is written to the store.
+func (s *Service) SetOnChange(fn func(agentID uuid.UUID)) { s.onChange = fn }
+
+// SetOnConflict sets the callback run when a conflict is resolved.
+func (s *Service) SetOnConflict(fn func(Conflict)) { s.onConflict = fn }
+
+// Start runs a sync pass immediately and then every interval.
+func (s *Service) Start(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.SyncOnce(context.Background())
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-t.C:
+				s.SyncOnce(context.Background())
+			}
+		}
+	}()
+}
+
+// Stop halts the polling loop.
+func (s *Service) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+}
+
+// SyncOnce runs one sync pass over all eligible agents.
+func (s *Service) SyncOnce(ctx context.Context) {
+	ctx = store.WithTenantID(ctx, store.MasterTenantID)
+	agents, err := s.agents.List(ctx, "")
+	if err != nil {
+		slog.Warn("contextsync: failed to list agents", "error", err)
+		return
+	}
+
+	// Count directory users first: shared directories are skipped.
+	dirs := make(map[uuid.UUID]string, len(agents))
+	users := map[string]int{}
+	for _, ag := range agents {
+		if dir := agentDir(ag); dir != "" {
+			dirs[ag.ID] = dir
+			users[dir]++
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dirty := false
+	for _, ag := range agents {
+		dir := dirs[ag.ID]
+		if dir == "" || users[dir] > 1 {
+			continue
+		}
+		if s.syncAgent(ctx, ag, dir) {
+			dirty = true
+		}
+	}
+	if dirty {
+		s.saveLocked()
+	}
+}
+
+// agentDir returns the agent's own workspace directory, or "" when it has none.
+func agentDir(ag store.AgentData) string {
+	if ag.Workspace == "" || (ag.TenantID != store.MasterTenantID && ag.TenantID != uuid.Nil) {
+		return ""
+	}
+	dir := config.ExpandHome(ag.Workspace)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// syncAgent syncs one agent's files. Returns true when the sync state changed.
+func (s *Service) syncAgent(ctx context.Context, ag store.AgentData, dir string) bool {
+	stored, err := s.agents.GetAgentContextFiles(ctx, ag.ID)
+	if err != nil {
+		slog.Warn("contextsync: failed to load context files", "agent", ag.AgentKey, "error", err)
+		return false
+	}
+	db := make(map[string]string, len(stored))
+	for _, f := range stored {
+		db[f.FileName] = f.Content
+	}
+
+	dirty, storeChanged := false, false
+	for _, name := range Files {
+		changed, wroteStore, err := s.syncFile(ctx, ag, filepath.Join(dir, name), name, db[name])
+		if err != nil {
+			slog.Warn("contextsync: sync failed", "agent", ag.AgentKey, "file", name, "error", err)
+			continue
+		}
+		dirty = dirty || changed
+		storeChanged = storeChanged || wroteStore
+	}
+	if storeChanged && s.onChange != nil {
+		s.onChange(ag.ID)
+	}
+	return dirty
+}
+
+// syncFile reconciles one file. An empty store row counts as absent, and a
+// file missing from the workspace is restored rather than deleted from the
+// store, so removing a workspace never wipes an agent's configuration.
+func (s *Service) syncFile(ctx context.Context, ag store.AgentData, path, name, dbContent string) (changed, wroteStore bool, err error) {
+	key := ag.ID.String() + "/" + name
+	base, known := s.state[key]
+
+	data, err := os.ReadFile(path)
+	fsExists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, false, err
+	}
+	fsContent := string(data)
+	fsHash, dbHash := contentHash(fsContent), contentHash(dbContent)
+
+	record := func(h string) bool {
+		if known && base == h {
+			return false
+		}
+		s.state[key] = h
+		return true
+	}
+
+	switch {
+	case !fsExists && dbContent == "":
+		if known {
+			delete(s.state, key)
+			return true, false, nil
+		}
+		return false, false, nil
+
+	case fsExists && fsContent == dbContent:
+		return record(fsHash), false, nil
+
+	case !fsExists:
+		// Missing in the workspace: restore it from the store.
+		if err := writeFileAtomic(path, dbContent); err != nil {
+			return false, false, err
+		}
+		slog.Info("contextsync: restored workspace file from store", "agent", ag.AgentKey, "file", name)
+		return record(dbHash), false, nil
+
+	case dbContent == "" || (known && dbHash == base):
+		// Only the workspace changed (or the store has nothing yet).
+		if err := s.agents.SetAgentContextFile(ctx, ag.ID, name, fsContent); err != nil {
+			return false, false, err
+		}
+		slog.Info("contextsync: workspace edit applied to store", "agent", ag.AgentKey, "file", name)
+		return record(fsHash), true, nil
+
+	case known && fsHash == base:
+		// Only the store changed.
+		if err := writeFileAtomic(path, dbContent); err != nil {
+			return false, false, err
+		}
+		slog.Info("contextsync: store edit written to workspace", "agent", ag.AgentKey, "file", name)
+		return record(dbHash), false, nil
+
+	default:
+		// Both sides changed since the last sync (or first sync with different
+		// content): keep the store version and preserve the workspace edit.
+		savedAs := path + ConflictSuffix
+		if err := writeFileAtomic(savedAs, fsContent); err != nil {
+			return false, false, err
+		}
+		if err := writeFileAtomic(path, dbContent); err != nil {
+			return false, false, err
+		}
+		slog.Warn("contextsync: conflicting edits, kept store version",
+			"agent", ag.AgentKey, "file", name, "workspace_copy", savedAs)
+		if s.onConflict != nil {
+			s.onConflict(Conflict{AgentID: ag.ID, AgentKey: ag.AgentKey, File: name, SavedAs: savedAs})
+		}
+		return record(dbHash), false, nil
+	}
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFileAtomic(path, content string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rename %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func (s *Service) load() {
+	if s.statePath == "" {
+		return
+	}
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("contextsync: failed to read state", "path", s.statePath, "error", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil || s.state == nil {
+		slog.Warn("contextsync: failed to parse state, starting fresh", "path", s.statePath, "error", err)
+		s.state = map[string]string{}
+	}
+}
+
+func (s *Service) saveLocked() {
+	if s.statePath == "" {
+		return
+	}
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0o755); err != nil {
+		slog.Warn("contextsync: failed to create state dir", "path", s.statePath, "error", err)
+		return
+	}
+	if err := writeFileAtomic(s.statePath, string(data)); err != nil {
+		slog.Warn("contextsync: failed to save state", "path", s.statePath, "error", err)
+	}
+}
@@ -0,0 +1,162 @@
+package contextsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type fakeStore struct {
+	agents []store.AgentData
+	files  map[uuid.UUID]map[string]string
+	writes int
+}
+
+func (f *fakeStore) List(context.Context, string) ([]store.AgentData, error) { return f.agents, nil }
+
+func (f *fakeStore) GetAgentContextFiles(_ context.Context, id uuid.UUID) ([]store.AgentContextFileData, error) {
+	var out []store.AgentContextFileData
+	for name, content := range f.files[id] {
+		out = append(out, store.AgentContextFileData{AgentID: id, FileName: name, Content: content})
+	}
+	return out, nil
+}
+
+func (f *fakeStore) SetAgentContextFile(_ context.Context, id uuid.UUID, name, content string) error {
+	if f.files[id] == nil {
+		f.files[id] = map[string]string{}
+	}
+	f.files[id][name] = content
+	f.writes++
+	return nil
+}
+
+func newAgent(dir string) store.AgentData {
+	return store.AgentData{
+		BaseModel: store.BaseModel{ID: uuid.New()},
+		AgentKey:  "a-" + filepath.Base(dir),
+		TenantID:  store.MasterTenantID,
+		Workspace: dir,
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSync_BothDirections(t *testing.T) {
+	dir := t.TempDir()
+	ag := newAgent(dir)
+	fs := &fakeStore{agents: []store.AgentData{ag}, files: map[uuid.UUID]map[string]string{
+		ag.ID: {"SOUL.md": "soul v1"},
+	}}
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte("agents v1"), 0o644)
+
+	var changed []uuid.UUID
+	s := New(fs, statePath)
+	s.SetOnChange(func(id uuid.UUID) { changed = append(changed, id) })
+	ctx := context.Background()
+
+	// First pass: workspace-only file is imported, store-only file is written out.
+	s.SyncOnce(ctx)
+	if fs.files[ag.ID]["AGENTS.md"] != "agents v1" {
+		t.Fatalf("AGENTS.md not imported: %q", fs.files[ag.ID]["AGENTS.md"])
+	}
+	if got := readFile(t, filepath.Join(dir, "SOUL.md")); got != "soul v1" {
+		t.Fatalf("SOUL.md not written to workspace: %q", got)
+	}
+	if len(changed) != 1 {
+		t.Fatalf("onChange calls = %d, want 1", len(changed))
+	}
+
+	// Workspace edit propagates to the store.
+	os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte("agents v2"), 0o644)
+	s.SyncOnce(ctx)
+	if fs.files[ag.ID]["AGENTS.md"] != "agents v2" {
+		t.Fatalf("workspace edit not applied: %q", fs.files[ag.ID]["AGENTS.md"])
+	}
+
+	// Store edit propagates to the workspace, also after a restart.
+	fs.files[ag.ID]["AGENTS.md"] = "agents v3"
+	s = New(fs, statePath)
+	s.SyncOnce(ctx)
+	if got := readFile(t, filepath.Join(dir, "AGENTS.md")); got != "agents v3" {
+		t.Fatalf("store edit not written: %q", got)
+	}
+
+	// Steady state: nothing to do.
+	writes := fs.writes
+	s.SyncOnce(ctx)
+	if fs.writes != writes {
+		t.Error("steady-state pass wrote to the store")
+	}
+
+	// A deleted workspace file is restored, not deleted from the store.
+	os.Remove(filepath.Join(dir, "SOUL.md"))
+	s.SyncOnce(ctx)
+	if got := readFile(t, filepath.Join(dir, "SOUL.md")); got != "soul v1" || fs.files[ag.ID]["SOUL.md"] != "soul v1" {
+		t.Fatalf("SOUL.md not restored: %q", got)
+	}
+}
+
+func TestSync_ConflictKeepsStoreVersion(t *testing.T) {
+	dir := t.TempDir()
+	ag := newAgent(dir)
+	fs := &fakeStore{agents: []store.AgentData{ag}, files: map[uuid.UUID]map[string]string{
+		ag.ID: {"AGENTS.md": "base"},
+	}}
+	s := New(fs, "")
+	var conflicts []Conflict
+	s.SetOnConflict(func(c Conflict) { conflicts = append(conflicts, c) })
+	s.SyncOnce(context.Background())
+
+	path := filepath.Join(dir, "AGENTS.md")
+	os.WriteFile(path, []byte("workspace edit"), 0o644)
+	fs.files[ag.ID]["AGENTS.md"] = "dashboard edit"
+	s.SyncOnce(context.Background())
+
+	if got := readFile(t, path); got != "dashboard edit" {
+		t.Errorf("workspace file = %q, want store version", got)
+	}
+	if got := readFile(t, path+ConflictSuffix); got != "workspace edit" {
+		t.Errorf("conflict copy = %q, want workspace edit", got)
+	}
+	if fs.files[ag.ID]["AGENTS.md"] != "dashboard edit" {
+		t.Errorf("store overwritten: %q", fs.files[ag.ID]["AGENTS.md"])
+	}
+	if len(conflicts) != 1 || conflicts[0].File != "AGENTS.md" {
+		t.Errorf("conflicts = %+v", conflicts)
+	}
+}
+
+func TestSync_SkipsSharedAndTenantWorkspaces(t *testing.T) {
+	shared := t.TempDir()
+	a, b := newAgent(shared), newAgent(shared)
+	tenant := newAgent(t.TempDir())
+	tenant.TenantID = uuid.New()
+	noWS := newAgent("")
+	noWS.Workspace = ""
+	fs := &fakeStore{agents: []store.AgentData{a, b, tenant, noWS}, files: map[uuid.UUID]map[string]string{
+		a.ID:      {"SOUL.md": "a"},
+		b.ID:      {"SOUL.md": "b"},
+		tenant.ID: {"SOUL.md": "t"},
+	}}
+	New(fs, "").SyncOnce(context.Background())
+
+	for _, dir := range []string{shared, tenant.Workspace} {
+		if _, err := os.Stat(filepath.Join(dir, "SOUL.md")); !os.IsNotExist(err) {
+			t.Errorf("%s: SOUL.md should not be synced", dir)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Package crashreport forwards recovered goroutine panics to Sentry using
+// the envelope HTTP API directly (no SDK dependency).
+package crashreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+)
+
+const (
+	queueSize   = 64
+	sendTimeout = 10 * time.Second
+)
+
+// Sentry sends crashes to a Sentry project. Report never blocks: events are
+// queued and delivered by a single background worker; when the queue is
+// full, new crashes are dropped (they are still logged and counted by safego).
+type Sentry struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+
+	queue     chan safego.Crash
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewSentry parses dsn ("https://<key>@<host>/<project>") and starts the
+// delivery worker. Call Close to flush pending events on shutdown.
+func NewSentry(dsn, environment, release string) (*Sentry, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse sentry dsn: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("sentry dsn: missing public key")
+	}
+	project := strings.Trim(u.Path, "/")
+	if u.Host == "" || project == "" {
+		return nil, fmt.Errorf("sentry dsn: missing host or project id")
+	}
+	prefix := ""
+	if i := strings.LastIndex(project, "/"); i >= 0 {
+		prefix, project = "/"+project[:i], project[i+1:]
+	}
+	if environment == "" {
+		environment = "production"
+	}
+	host, _ := os.Hostname()
+
+	s := &Sentry{
+		endpoint:    fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, project),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=goclaw/%s", u.User.Username(), release),
+		environment: environment,
+		release:     release,
+		serverName:  host,
+		client:      &http.Client{Timeout: sendTimeout},
+		queue:       make(chan safego.Crash, queueSize),
+		done:        make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Report queues c for delivery. Safe to use as a safego reporter.
+func (s *Sentry) Report(c safego.Crash) {
+	defer func() { recover() }() // queue closed during shutdown
+	select {
+	case s.queue <- c:
+	default:
+		slog.Warn("crashreport: queue full, dropping event", "component", c.Component)
+	}
+}
+
+// Close stops accepting events and waits up to timeout for pending ones.
+func (s *Sentry) Close(timeout time.Duration) {
+	s.closeOnce.Do(func() { close(s.queue) })
+	select {
+	case <-s.done:
+	case <-time.After(timeout):
+		slog.Warn("crashreport: flush timed out")
+	}
+}
+
+func (s *Sentry) run() {
+	defer close(s.done)
+	for c := range s.queue {
+		if err := s.send(c); err != nil {
+			slog.Warn("crashreport: send failed", "error", err)
+		}
+	}
+}
+
+func (s *Sentry) send(c safego.Crash) error {
+	body, err := s.envelope(c)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// envelope builds a single-event Sentry envelope: header, item header, payload.
+func (s *Sentry) envelope(c safego.Crash) ([]byte, error) {
+	id := eventID()
+	extra := map[string]any{"stack": c.Stack}
+	for i := 0; i+1 < len(c.Attrs); i += 2 {
+		if k, ok := c.Attrs[i].(string); ok {
+			extra[k] = fmt.Sprint(c.Attrs[i+1])
+		}
+	}
+	event := map[string]any{
+		"event_id":    id,
+		"timestamp":   c.Time.UTC().Format(time.RFC3339Nano),
+		"level":       "fatal",
+		"platform":    "go",
+		"logger":      "safego",
+		"environment": s.environment,
+		"release":     s.release,
+		"server_name": s.serverName,
+		"tags":        map[string]string{"component": c.Component},
+		"exception": map[string]any{
+			"values": []map[string]any{{
+				"type":      "panic",
+				"value":     c.Value,
+				"mechanism": map[string]any{"type": "recover", "handled": true},
+			}},
+		},
+		"extra": extra,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": id, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	item, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(item)
+	buf.WriteByte('\n')
+	buf.Write(payload)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func eventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
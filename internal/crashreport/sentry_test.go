@@ -0,0 +1,79 @@
+package crashreport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+)
+
+func TestNewSentry_InvalidDSN(t *testing.T) {
+	for _, dsn := range []string{"", "https://sentry.io/1", "https://key@sentry.io/", "::bad"} {
+		if _, err := NewSentry(dsn, "", "dev"); err == nil {
+			t.Errorf("NewSentry(%q) should fail", dsn)
+		}
+	}
+}
+
+func TestSentry_ReportSendsEnvelope(t *testing.T) {
+	type received struct {
+		path, auth string
+		body       []byte
+	}
+	got := make(chan received, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		got <- received{r.URL.Path, r.Header.Get("X-Sentry-Auth"), b}
+	}))
+	defer srv.Close()
+
+	dsn := strings.Replace(srv.URL, "http://", "http://pubkey@", 1) + "/sub/42"
+	s, err := NewSentry(dsn, "staging", "v1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Report(safego.Crash{Component: "cron", Value: "boom", Stack: "goroutine 1", Attrs: []any{"component", "cron", "job_id", "j1"}, Time: time.Now()})
+	s.Close(5 * time.Second)
+
+	var r received
+	select {
+	case r = <-got:
+	default:
+		t.Fatal("no event delivered")
+	}
+	if r.path != "/sub/api/42/envelope/" {
+		t.Errorf("path = %q", r.path)
+	}
+	if !strings.Contains(r.auth, "sentry_key=pubkey") {
+		t.Errorf("auth header = %q", r.auth)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(r.body))
+	sc.Buffer(nil, 1<<20)
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("envelope has %d lines, want 3", len(lines))
+	}
+	var event struct {
+		Environment string            `json:"environment"`
+		Release     string            `json:"release"`
+		Tags        map[string]string `json:"tags"`
+		Extra       map[string]string `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Environment != "staging" || event.Release != "v1.2.3" || event.Tags["component"] != "cron" || event.Extra["job_id"] != "j1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
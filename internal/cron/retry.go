@@ -1,8 +1,10 @@
 package cron
 
 import (
-	"math/rand/v2"
+	"context"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 )
 
 // RetryConfig controls exponential backoff retry for failed cron jobs.
@@ -21,37 +23,28 @@ func DefaultRetryConfig() RetryConfig {
 	}
 }
 
+func (c RetryConfig) backoff() retry.Backoff {
+	return retry.Backoff{Attempts: c.MaxRetries + 1, MinDelay: c.BaseDelay, MaxDelay: c.MaxDelay, Jitter: 0.25}
+}
+
 // ExecuteWithRetry runs fn, retrying on error with exponential backoff + jitter.
 // Returns the first successful result or the last error after all retries.
+// Every error is retried except those marked with retry.Permanent.
 func ExecuteWithRetry(fn func() (string, error), cfg RetryConfig) (result string, attempts int, err error) {
-	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
-		result, err = fn()
-		if err == nil {
-			return result, attempt + 1, nil
-		}
-
-		if attempt < cfg.MaxRetries {
-			delay := backoffWithJitter(cfg.BaseDelay, cfg.MaxDelay, attempt)
-			time.Sleep(delay)
-		}
-	}
-	return "", cfg.MaxRetries + 1, err
+	result, err = retry.DoValue(context.Background(), retry.Policy{
+		Name:     "cron",
+		Backoff:  cfg.backoff(),
+		Classify: retry.Always,
+	}, func(context.Context) (string, error) {
+		attempts++
+		return fn()
+	})
+	return result, attempts, err
 }
 
 // backoffWithJitter computes delay = min(base * 2^attempt, max) + jitter(±25%).
 func backoffWithJitter(base, max time.Duration, attempt int) time.Duration {
-	delay := min(
-		// base * 2^attempt
-		base<<uint(attempt), max)
-
-	// Jitter: ±25% of delay
-	quarter := delay / 4
-	if quarter > 0 {
-		jitter := time.Duration(rand.Int64N(int64(quarter*2))) - quarter
-		delay += jitter
-	}
-
-	return delay
+	return RetryConfig{BaseDelay: base, MaxDelay: max}.backoff().Delay(attempt + 1)
 }
 
 // maxOutputBytes is the truncation limit for cron job output (16KB).
@@ -1,9 +1,12 @@
 package cron
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"sync"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 )
 
 // Service manages cron jobs with persistence, scheduling, and execution.
@@ -16,6 +19,12 @@ type Service struct {
 	mu        sync.Mutex
 	runLog    []RunLogEntry // in-memory run history (last 200 entries)
 	retryCfg  RetryConfig   // retry config for failed jobs
+
+	// Concurrency tracking for scheduled runs (see checkJobs).
+	maxConcurrent int             // global cap on in-flight scheduled runs; 0 = unlimited
+	activeRuns    int             // in-flight scheduled runs across all jobs
+	jobRuns       map[string]int  // job ID → in-flight scheduled runs
+	queuedRuns    map[string]bool // job ID → rerun pending (OverlapQueue)
 }
 
 // NewService creates a new cron service.
@@ -23,10 +32,12 @@ type Service struct {
 // onJob is the callback invoked when a job fires (can be set later via SetOnJob).
 func NewService(storePath string, onJob JobHandler) *Service {
 	return &Service{
-		storePath: storePath,
-		store:     Store{Version: 1},
-		onJob:     onJob,
-		retryCfg:  DefaultRetryConfig(),
+		storePath:  storePath,
+		store:      Store{Version: storeVersion},
+		onJob:      onJob,
+		retryCfg:   DefaultRetryConfig(),
+		jobRuns:    make(map[string]int),
+		queuedRuns: make(map[string]bool),
 	}
 }
 
@@ -37,6 +48,15 @@ func (cs *Service) SetRetryConfig(cfg RetryConfig) {
 	cs.retryCfg = cfg
 }
 
+// SetMaxConcurrentRuns caps how many scheduled runs may be in flight at once.
+// Due jobs beyond the cap stay due and are dispatched as slots free up.
+// n <= 0 removes the cap.
+func (cs *Service) SetMaxConcurrentRuns(n int) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.maxConcurrent = max(n, 0)
+}
+
 // SetOnJob sets the job execution callback.
 func (cs *Service) SetOnJob(handler JobHandler) {
 	cs.mu.Lock()
@@ -55,7 +75,7 @@ func (cs *Service) Start() error {
 
 	if err := cs.loadUnsafe(); err != nil {
 		slog.Warn("cron: failed to load store, starting fresh", "error", err)
-		cs.store = Store{Version: 1}
+		cs.store = Store{Version: storeVersion}
 	}
 
 	// Compute next runs for all enabled jobs: fix NULL and past-due next_run_at.
@@ -89,7 +109,8 @@ func (cs *Service) Start() error {
 	// after a previous Stop() returned but the runLoop goroutine hasn't yet
 	// executed its ticker construction.
 	tick := runLoopTickInterval
-	go cs.runLoop(cs.stopChan, tick)
+	stop := cs.stopChan
+	go safego.Supervise(context.Background(), "cron", func(context.Context) { cs.runLoop(stop, tick) })
 
 	slog.Info("cron service started", "jobs", len(cs.store.Jobs))
 	return nil
@@ -268,6 +289,12 @@ func (cs *Service) UpdateJob(jobID string, patch JobPatch) (*Job, error) {
 		if patch.DeleteAfterRun != nil {
 			job.DeleteAfterRun = *patch.DeleteAfterRun
 		}
+		if patch.Overlap != nil {
+			if err := validateOverlap(*patch.Overlap); err != nil {
+				return nil, err
+			}
+			job.Overlap = *patch.Overlap
+		}
 
 		job.UpdatedAtMS = nowMS()
 
@@ -298,5 +325,6 @@ func (cs *Service) Status() map[string]any {
 		"enabled":      cs.running,
 		"jobs":         len(cs.store.Jobs),
 		"nextWakeAtMs": cs.getNextWakeMS(),
+		"activeRuns":   cs.activeRuns,
 	}
 }
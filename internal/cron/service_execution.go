@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/adhocore/gronx"
@@ -165,14 +166,19 @@ func (cs *Service) runLoop(stopChan chan struct{}, tick time.Duration) {
 // safeCheckJobs wraps checkJobs with panic recovery so a panic in any
 // check/claim logic doesn't kill the runLoop goroutine.
 func (cs *Service) safeCheckJobs() {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("cron: checkJobs panicked — runLoop continues", "panic", fmt.Sprint(r))
-		}
-	}()
+	defer safego.Recover(nil, "component", "cron")
 	cs.checkJobs()
 }
 
+// checkJobs dispatches due jobs, honouring each job's overlap policy and the
+// global concurrency cap.
+//
+// Skip-policy jobs have NextRunAtMS cleared while they run, and their next run
+// is computed when the run finishes, so missed occurrences are dropped. Queue
+// and parallel jobs are advanced at dispatch instead, so an occurrence that
+// falls inside a run is still noticed: queue records one rerun, parallel
+// starts it. Jobs held back by the cap keep their NextRunAtMS and are retried
+// on the next tick.
 func (cs *Service) checkJobs() {
 	cs.mu.Lock()
 
@@ -180,35 +186,61 @@ func (cs *Service) checkJobs() {
 
 	// Collect due jobs and preserve their original scheduled times.
 	// The scheduled time is used as anchor for "every" jobs to prevent drift.
+	// scheduledAtMS is 0 for a queued rerun, which leaves the schedule as is.
 	type dueJob struct {
 		id            string
 		scheduledAtMS int64
 	}
 	var dueJobs []dueJob
+	dirty := false
 
 	for i := range cs.store.Jobs {
 		job := &cs.store.Jobs[i]
-		if job.Enabled && job.State.NextRunAtMS != nil && *job.State.NextRunAtMS <= now {
-			dueJobs = append(dueJobs, dueJob{id: job.ID, scheduledAtMS: *job.State.NextRunAtMS})
+		if !job.Enabled {
+			continue
+		}
+		running := cs.jobRuns[job.ID] > 0
+		due := job.State.NextRunAtMS != nil && *job.State.NextRunAtMS <= now
+		rerun := cs.queuedRuns[job.ID] && !running
+		if !due && !rerun {
+			continue
 		}
-	}
 
-	if len(dueJobs) == 0 {
-		cs.mu.Unlock()
-		return
-	}
+		if due && running && job.Overlap != OverlapParallel {
+			if job.Overlap == OverlapQueue {
+				cs.queuedRuns[job.ID] = true
+			} else {
+				slog.Info("cron: skipping occurrence, previous run still in progress", "id", job.ID, "name", job.Name)
+			}
+			cs.advanceLocked(job, *job.State.NextRunAtMS, now)
+			dirty = true
+			continue
+		}
 
-	// Clear NextRunAtMS to prevent duplicate execution
-	dueMap := make(map[string]bool, len(dueJobs))
-	for _, dj := range dueJobs {
-		dueMap[dj.id] = true
-	}
-	for i := range cs.store.Jobs {
-		if dueMap[cs.store.Jobs[i].ID] {
-			cs.store.Jobs[i].State.NextRunAtMS = nil
+		if cs.maxConcurrent > 0 && cs.activeRuns >= cs.maxConcurrent {
+			continue
+		}
+
+		dj := dueJob{id: job.ID}
+		delete(cs.queuedRuns, job.ID) // a due occurrence also covers a queued rerun
+		if due {
+			dj.scheduledAtMS = *job.State.NextRunAtMS
+			if job.Overlap == OverlapQueue || job.Overlap == OverlapParallel {
+				cs.advanceLocked(job, dj.scheduledAtMS, now)
+			} else {
+				// Clear NextRunAtMS to prevent duplicate execution
+				job.State.NextRunAtMS = nil
+			}
+			dirty = true
 		}
+		cs.jobRuns[job.ID]++
+		cs.activeRuns++
+		dueJobs = append(dueJobs, dj)
+	}
+
+	if dirty {
+		cs.saveUnsafe()
 	}
-	cs.saveUnsafe()
 	cs.mu.Unlock()
 
 	// Execute jobs in parallel without blocking the runLoop.
@@ -217,12 +249,45 @@ func (cs *Service) checkJobs() {
 	// due jobs. Now each job runs independently with panic recovery.
 	for _, dj := range dueJobs {
 		go func(id string, scheduledAtMS int64) {
+			defer cs.releaseRun(id)
 			defer safego.Recover(nil, "job_id", id)
 			cs.executeJobByID(id, scheduledAtMS)
 		}(dj.id, dj.scheduledAtMS)
 	}
 }
 
+// releaseRun frees the concurrency slot taken by checkJobs for jobID.
+func (cs *Service) releaseRun(jobID string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.jobRuns[jobID]--; cs.jobRuns[jobID] <= 0 {
+		delete(cs.jobRuns, jobID)
+	}
+	cs.activeRuns--
+}
+
+// advanceLocked moves job's next run past now. "every" jobs stay anchored on
+// scheduledAtMS; a one-time job is left without a next run. Must be called
+// with cs.mu held.
+func (cs *Service) advanceLocked(job *Job, scheduledAtMS, now int64) {
+	job.State.NextRunAtMS = cs.nextRunAfter(&job.Schedule, scheduledAtMS, now)
+}
+
+// nextRunAfter computes the first run after now. For "every" jobs it advances
+// from the original scheduled time (anchor) to prevent drift and
+// synchronization.
+func (cs *Service) nextRunAfter(schedule *Schedule, scheduledAtMS, now int64) *int64 {
+	if schedule.Kind == "every" && schedule.EveryMS != nil && *schedule.EveryMS > 0 && scheduledAtMS > 0 {
+		interval := *schedule.EveryMS
+		// O(1) advance to the next future slot from anchor
+		elapsed := now - scheduledAtMS
+		periods := elapsed / interval
+		next := scheduledAtMS + (periods+1)*interval
+		return &next
+	}
+	return cs.computeNextRun(schedule, now)
+}
+
 func (cs *Service) executeJobByID(jobID string, scheduledAtMS int64) {
 	cs.mu.Lock()
 	var job *Job
@@ -271,26 +336,16 @@ func (cs *Service) executeJobByID(jobID string, scheduledAtMS int64) {
 			slog.Info("cron job completed", "id", jobID, "result", result)
 		}
 
-		// Schedule next run or handle one-time jobs
+		// Schedule next run or handle one-time jobs. Queue and parallel jobs
+		// were already advanced at dispatch.
 		if cs.store.Jobs[i].DeleteAfterRun {
 			cs.store.Jobs = append(cs.store.Jobs[:i], cs.store.Jobs[i+1:]...)
-		} else {
-			schedule := &cs.store.Jobs[i].Schedule
-			// For "every" (interval) jobs, compute next run from the original
-			// scheduled time (anchor) to prevent drift and synchronization.
-			if schedule.Kind == "every" && schedule.EveryMS != nil && *schedule.EveryMS > 0 && scheduledAtMS > 0 {
-				interval := *schedule.EveryMS
-				// O(1) advance to the next future slot from anchor
-				elapsed := now - scheduledAtMS
-				periods := elapsed / interval
-				next := scheduledAtMS + (periods+1)*interval
-				cs.store.Jobs[i].State.NextRunAtMS = &next
-			} else {
-				next := cs.computeNextRun(schedule, now)
-				cs.store.Jobs[i].State.NextRunAtMS = next
-				if next == nil {
-					cs.store.Jobs[i].Enabled = false
-				}
+			delete(cs.queuedRuns, jobID)
+		} else if cs.store.Jobs[i].State.NextRunAtMS == nil {
+			next := cs.nextRunAfter(&cs.store.Jobs[i].Schedule, scheduledAtMS, now)
+			cs.store.Jobs[i].State.NextRunAtMS = next
+			if next == nil && !cs.queuedRuns[jobID] {
+				cs.store.Jobs[i].Enabled = false
 			}
 		}
 		break
@@ -307,8 +362,8 @@ func (cs *Service) executeJobByID(jobID string, scheduledAtMS int64) {
 func (cs *Service) computeNextRun(schedule *Schedule, now int64) *int64 {
 	switch schedule.Kind {
 	case "at":
-		if schedule.AtMS != nil && *schedule.AtMS > now {
-			return schedule.AtMS
+		if at, err := schedule.atMS(); err == nil && at > now {
+			return &at
 		}
 		return nil
 
@@ -323,12 +378,7 @@ func (cs *Service) computeNextRun(schedule *Schedule, now int64) *int64 {
 		if schedule.Expr == "" {
 			return nil
 		}
-		nowTime := time.UnixMilli(now)
-		if schedule.TZ != "" {
-			if loc, err := time.LoadLocation(schedule.TZ); err == nil {
-				nowTime = nowTime.In(loc)
-			}
-		}
+		nowTime := time.UnixMilli(now).In(schedule.location())
 		nextTime, err := gronx.NextTickAfter(schedule.Expr, nowTime, false)
 		if err != nil {
 			slog.Error("cron: failed to compute next run", "expr", schedule.Expr, "error", err)
@@ -342,11 +392,24 @@ func (cs *Service) computeNextRun(schedule *Schedule, now int64) *int64 {
 	}
 }
 
+func validateOverlap(policy string) error {
+	switch policy {
+	case "", OverlapSkip, OverlapQueue, OverlapParallel:
+		return nil
+	}
+	return fmt.Errorf("invalid overlap policy %q (want %s, %s or %s)", policy, OverlapSkip, OverlapQueue, OverlapParallel)
+}
+
 func (cs *Service) validateSchedule(schedule *Schedule) error {
+	if schedule.TZ != "" {
+		if _, err := time.LoadLocation(schedule.TZ); err != nil {
+			return fmt.Errorf("invalid timezone: %s", schedule.TZ)
+		}
+	}
 	switch schedule.Kind {
 	case "at":
-		if schedule.AtMS == nil {
-			return fmt.Errorf("at schedule requires atMs")
+		if _, err := schedule.atMS(); err != nil {
+			return err
 		}
 	case "every":
 		if schedule.EveryMS == nil || *schedule.EveryMS <= 0 {
@@ -360,17 +423,43 @@ func (cs *Service) validateSchedule(schedule *Schedule) error {
 		if !gx.IsValid(schedule.Expr) {
 			return fmt.Errorf("invalid cron expression: %s", schedule.Expr)
 		}
-		if schedule.TZ != "" {
-			if _, err := time.LoadLocation(schedule.TZ); err != nil {
-				return fmt.Errorf("invalid timezone: %s", schedule.TZ)
-			}
-		}
 	default:
 		return fmt.Errorf("unknown schedule kind: %s", schedule.Kind)
 	}
 	return nil
 }
 
+// atLayouts are the accepted formats for Schedule.At. RFC 3339 times carry
+// their own offset; the others are read in the schedule's timezone.
+var atLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"}
+
+// location returns the schedule's timezone, falling back to server local.
+func (s *Schedule) location() *time.Location {
+	if s.TZ != "" {
+		if loc, err := time.LoadLocation(s.TZ); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// atMS resolves an "at" schedule to an absolute timestamp: AtMS when set,
+// otherwise the wall-clock At in the schedule's timezone.
+func (s *Schedule) atMS() (int64, error) {
+	if s.AtMS != nil {
+		return *s.AtMS, nil
+	}
+	if s.At == "" {
+		return 0, fmt.Errorf("at schedule requires atMs or at")
+	}
+	for _, layout := range atLayouts {
+		if t, err := time.ParseInLocation(layout, s.At, s.location()); err == nil {
+			return t.UnixMilli(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid at time %q (use 2006-01-02T15:04)", s.At)
+}
+
 func (cs *Service) getNextWakeMS() *int64 {
 	var earliest *int64
 	for _, job := range cs.store.Jobs {
@@ -393,7 +482,56 @@ func (cs *Service) loadUnsafe() error {
 		}
 		return err
 	}
-	return json.Unmarshal(data, &cs.store)
+	if err := json.Unmarshal(data, &cs.store); err != nil {
+		return err
+	}
+	cs.migrateUnsafe()
+	return nil
+}
+
+// migrateUnsafe upgrades a store loaded from an older file format.
+// v1 files evaluated cron expressions without a timezone in server local time;
+// pin those jobs to the server's zone so they keep firing at the same
+// wall-clock time if the process later runs with a different TZ.
+func (cs *Service) migrateUnsafe() {
+	if cs.store.Version >= storeVersion {
+		return
+	}
+	if tz := serverZoneName(); tz != "" {
+		for i := range cs.store.Jobs {
+			if s := &cs.store.Jobs[i].Schedule; s.Kind == "cron" && s.TZ == "" {
+				s.TZ = tz
+			}
+		}
+	}
+	slog.Info("cron: migrated store", "from_version", cs.store.Version, "to_version", storeVersion)
+	cs.store.Version = storeVersion
+}
+
+// serverZoneName is the zone v1 jobs are pinned to. Tests override it rather
+// than swapping time.Local, which running jobs read concurrently.
+var serverZoneName = localZoneName
+
+// localZoneName returns the IANA name of the server's local zone, or "" when
+// it cannot be determined (the job then keeps using server local time).
+func localZoneName() string {
+	if name := time.Local.String(); name != "Local" && name != "" {
+		if _, err := time.LoadLocation(name); err == nil {
+			return name
+		}
+	}
+	target, err := os.Readlink("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	_, name, ok := strings.Cut(target, "zoneinfo/")
+	if !ok {
+		return ""
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+	return name
 }
 
 func (cs *Service) saveUnsafe() error {
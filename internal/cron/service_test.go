@@ -30,6 +30,10 @@ func TestValidateSchedule(t *testing.T) {
 		{"cron_invalid_expr", Schedule{Kind: "cron", Expr: "bad cron"}, true},
 		{"cron_valid_with_tz", Schedule{Kind: "cron", Expr: "0 9 * * *", TZ: "Asia/Saigon"}, false},
 		{"cron_invalid_tz", Schedule{Kind: "cron", Expr: "0 9 * * *", TZ: "Invalid/Zone"}, true},
+		{"at_local_with_tz", Schedule{Kind: "at", At: "2030-01-02T09:00", TZ: "Asia/Saigon"}, false},
+		{"at_local_bad_format", Schedule{Kind: "at", At: "tomorrow 9am"}, true},
+		{"at_invalid_tz", Schedule{Kind: "at", At: "2030-01-02T09:00", TZ: "Invalid/Zone"}, true},
+		{"every_invalid_tz", Schedule{Kind: "every", EveryMS: new(int64(5000)), TZ: "Invalid/Zone"}, true},
 		{"unknown_kind", Schedule{Kind: "invalid"}, true},
 	}
 	for _, tt := range tests {
@@ -330,6 +334,69 @@ func TestService_Persistence_Roundtrip(t *testing.T) {
 	}
 }
 
+// --- Timezones ---
+
+func TestComputeNextRun_Timezone(t *testing.T) {
+	saigon, err := time.LoadLocation("Asia/Saigon")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	cs := NewService("", nil)
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli() // 19:00 in Saigon
+
+	cronSched := Schedule{Kind: "cron", Expr: "0 9 * * *", TZ: "Asia/Saigon"}
+	if next := cs.computeNextRun(&cronSched, now); next == nil || *next != time.Date(2030, 1, 2, 9, 0, 0, 0, saigon).UnixMilli() {
+		t.Fatalf("cron next = %v, want 09:00 Saigon on Jan 2", next)
+	}
+
+	atSched := Schedule{Kind: "at", At: "2030-01-02T09:00", TZ: "Asia/Saigon"}
+	if next := cs.computeNextRun(&atSched, now); next == nil || *next != time.Date(2030, 1, 2, 2, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Fatalf("at next = %v, want 02:00 UTC on Jan 2", next)
+	}
+
+	atSched.At = "2030-01-01T18:00" // 11:00 UTC, already past
+	if next := cs.computeNextRun(&atSched, now); next != nil {
+		t.Fatalf("past local at-schedule should return nil, got %d", *next)
+	}
+}
+
+func TestService_Load_MigratesV1Store(t *testing.T) {
+	orig := serverZoneName
+	serverZoneName = func() string { return "Asia/Tokyo" }
+	t.Cleanup(func() { serverZoneName = orig })
+
+	storePath := filepath.Join(t.TempDir(), "cron.json")
+	v1 := `{"version":1,"jobs":[
+		{"id":"a","name":"daily","enabled":true,"schedule":{"kind":"cron","expr":"0 9 * * *"},"payload":{"kind":"agent_turn","message":"m"}},
+		{"id":"b","name":"pinned","enabled":true,"schedule":{"kind":"cron","expr":"0 9 * * *","tz":"Europe/Paris"},"payload":{"kind":"agent_turn","message":"m"}},
+		{"id":"c","name":"tick","enabled":true,"schedule":{"kind":"every","everyMs":60000},"payload":{"kind":"agent_turn","message":"m"}}
+	]}`
+	if err := os.WriteFile(storePath, []byte(v1), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cs := NewService(storePath, nil)
+	if err := cs.Start(); err != nil {
+		t.Fatal(err)
+	}
+	cs.Stop()
+
+	want := map[string]string{"a": "Asia/Tokyo", "b": "Europe/Paris", "c": ""}
+	for _, job := range cs.ListJobs(true) {
+		if job.Schedule.TZ != want[job.ID] {
+			t.Errorf("job %s TZ = %q, want %q", job.ID, job.Schedule.TZ, want[job.ID])
+		}
+	}
+
+	reloaded := NewService(storePath, nil)
+	if err := reloaded.loadUnsafe(); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.store.Version != storeVersion {
+		t.Fatalf("persisted version = %d, want %d", reloaded.store.Version, storeVersion)
+	}
+}
+
 // --- Run log ---
 
 func TestService_RunLog_PopulatedByAutoExecution(t *testing.T) {
@@ -589,3 +656,142 @@ func TestService_Start_DisablesPastDueAtJobs(t *testing.T) {
 
 //go:fix inline
 func ptrInt64(v int64) *int64 { return new(v) }
+
+// --- Overlap policy & concurrency ---
+
+// blockingHandler returns a handler that counts starts and blocks until release is closed.
+func blockingHandler(started *atomic.Int32, release chan struct{}) JobHandler {
+	return func(job *Job) (string, error) {
+		started.Add(1)
+		<-release
+		return "ok", nil
+	}
+}
+
+// makeDue marks a job as due now, as if its next occurrence had arrived.
+func makeDue(cs *Service, id string) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for i := range cs.store.Jobs {
+		if cs.store.Jobs[i].ID == id {
+			cs.store.Jobs[i].State.NextRunAtMS = ptrInt64(nowMS() - 1)
+		}
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func activeRuns(cs *Service) int {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.activeRuns
+}
+
+func TestCheckJobs_OverlapPolicies(t *testing.T) {
+	cases := []struct {
+		policy       string
+		wantOverlap  int32 // starts after the second occurrence, while the first run is in progress
+		wantAfterRun int32 // starts after the first run finished and the scheduler ticked again
+	}{
+		{"", 1, 1},
+		{OverlapSkip, 1, 1},
+		{OverlapQueue, 1, 2},
+		{OverlapParallel, 2, 2},
+	}
+	for _, tc := range cases {
+		t.Run("policy="+tc.policy, func(t *testing.T) {
+			var started atomic.Int32
+			release := make(chan struct{})
+			cs := NewService(filepath.Join(t.TempDir(), "cron.json"), blockingHandler(&started, release))
+			cs.SetRetryConfig(RetryConfig{MaxRetries: 0})
+
+			interval := int64(60000)
+			job, err := cs.AddJob("slow", Schedule{Kind: "every", EveryMS: &interval}, "msg", false, "", "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			policy := tc.policy
+			if _, err := cs.UpdateJob(job.ID, JobPatch{Overlap: &policy}); err != nil {
+				t.Fatal(err)
+			}
+
+			makeDue(cs, job.ID)
+			cs.checkJobs()
+			waitFor(t, "first run", func() bool { return started.Load() == 1 })
+
+			makeDue(cs, job.ID)
+			cs.checkJobs()
+			if tc.wantOverlap > 1 {
+				waitFor(t, "parallel run", func() bool { return started.Load() == tc.wantOverlap })
+			}
+			if got := started.Load(); got != tc.wantOverlap {
+				t.Fatalf("starts during overlap = %d, want %d", got, tc.wantOverlap)
+			}
+			if found, _ := cs.GetJob(job.ID); found.State.NextRunAtMS != nil && *found.State.NextRunAtMS <= nowMS() {
+				t.Error("overlapping occurrence should advance the schedule")
+			}
+
+			close(release)
+			waitFor(t, "runs to finish", func() bool { return activeRuns(cs) == 0 })
+			cs.checkJobs()
+			if tc.wantAfterRun > tc.wantOverlap {
+				waitFor(t, "queued rerun", func() bool { return started.Load() == tc.wantAfterRun })
+			}
+			waitFor(t, "runs to finish", func() bool { return activeRuns(cs) == 0 })
+			if got := started.Load(); got != tc.wantAfterRun {
+				t.Fatalf("starts after run = %d, want %d", got, tc.wantAfterRun)
+			}
+		})
+	}
+}
+
+func TestCheckJobs_MaxConcurrentRuns(t *testing.T) {
+	var started atomic.Int32
+	release := make(chan struct{})
+	cs := NewService(filepath.Join(t.TempDir(), "cron.json"), blockingHandler(&started, release))
+	cs.SetRetryConfig(RetryConfig{MaxRetries: 0})
+	cs.SetMaxConcurrentRuns(1)
+
+	interval := int64(60000)
+	a, _ := cs.AddJob("a", Schedule{Kind: "every", EveryMS: &interval}, "msg", false, "", "", "")
+	b, _ := cs.AddJob("b", Schedule{Kind: "every", EveryMS: &interval}, "msg", false, "", "", "")
+	makeDue(cs, a.ID)
+	makeDue(cs, b.ID)
+
+	cs.checkJobs()
+	waitFor(t, "first run", func() bool { return started.Load() == 1 })
+	cs.checkJobs()
+	if got := started.Load(); got != 1 {
+		t.Fatalf("starts at cap = %d, want 1", got)
+	}
+
+	close(release)
+	waitFor(t, "first run to finish", func() bool { return activeRuns(cs) == 0 })
+	cs.checkJobs()
+	waitFor(t, "held-back job", func() bool { return started.Load() == 2 })
+	waitFor(t, "second run to finish", func() bool { return activeRuns(cs) == 0 })
+	for _, id := range []string{a.ID, b.ID} {
+		if found, _ := cs.GetJob(id); found.State.LastStatus != "ok" {
+			t.Errorf("job %s: LastStatus = %q, want ok", id, found.State.LastStatus)
+		}
+	}
+}
+
+func TestService_UpdateJob_InvalidOverlap(t *testing.T) {
+	cs := NewService(filepath.Join(t.TempDir(), "cron.json"), nil)
+	interval := int64(60000)
+	job, _ := cs.AddJob("j", Schedule{Kind: "every", EveryMS: &interval}, "msg", false, "", "", "")
+	bad := "sometimes"
+	if _, err := cs.UpdateJob(job.ID, JobPatch{Overlap: &bad}); err == nil {
+		t.Fatal("expected error for unknown overlap policy")
+	}
+}
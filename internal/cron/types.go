@@ -14,12 +14,16 @@ import (
 )
 
 // Schedule defines when a job should run.
+//
+// TZ is the job's IANA timezone. Cron expressions are evaluated in it, and a
+// wall-clock "at" time (At) is resolved in it. Empty means server local time.
 type Schedule struct {
 	Kind    string `json:"kind"`              // "at", "every", or "cron"
 	AtMS    *int64 `json:"atMs,omitempty"`    // absolute timestamp (for "at")
+	At      string `json:"at,omitempty"`      // local time "2006-01-02T15:04[:05]" in TZ (for "at", when atMs is unset)
 	EveryMS *int64 `json:"everyMs,omitempty"` // interval in milliseconds (for "every")
 	Expr    string `json:"expr,omitempty"`    // cron expression (for "cron")
-	TZ      string `json:"tz,omitempty"`      // timezone (reserved)
+	TZ      string `json:"tz,omitempty"`      // IANA timezone; empty = server local
 }
 
 // Payload describes what a job does when triggered.
@@ -54,8 +58,21 @@ type Job struct {
 	DeliverChannel string   `json:"deliverChannel"`
 	DeliverTo      string   `json:"deliverTo"`
 	WakeHeartbeat  bool     `json:"wakeHeartbeat"`
+	Overlap        string   `json:"overlap,omitempty"` // OverlapSkip (default), OverlapQueue, OverlapParallel
 }
 
+// Overlap policies decide what happens when a job comes due while a previous
+// run of the same job is still in progress.
+const (
+	OverlapSkip     = "skip"     // drop the occurrence
+	OverlapQueue    = "queue"    // run once more after the current run finishes
+	OverlapParallel = "parallel" // start another run alongside the current one
+)
+
+// storeVersion is the current store file format.
+// v2: cron jobs without a timezone are pinned to the server's zone on load.
+const storeVersion = 2
+
 // Store is the persistent store for all cron jobs.
 type Store struct {
 	Version int   `json:"version"`
@@ -76,6 +93,7 @@ type JobPatch struct {
 	DeliverChannel *string   `json:"deliverChannel,omitempty"`
 	DeliverTo      *string   `json:"deliverTo,omitempty"`
 	WakeHeartbeat  *bool     `json:"wakeHeartbeat,omitempty"`
+	Overlap        *string   `json:"overlap,omitempty"`
 }
 
 // RunLogEntry is an in-memory record of a job execution.
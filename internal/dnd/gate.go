@@ -0,0 +1,208 @@
+// Package dnd holds back proactive deliveries while the sending agent is in a
+// do-not-disturb window (store.DNDConfig) and releases them when it ends.
+//
+// Only background output goes through the gate — cron job results and
+// heartbeat alerts. Replies to interactive chats are published directly.
+package dnd
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// checkInterval is how often queued deliveries are checked for release.
+const checkInterval = 30 * time.Second
+
+// AgentGetter loads the agent whose DND config applies.
+type AgentGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*store.AgentData, error)
+}
+
+// Publisher sends a released message. Abstracts *bus.MessageBus.
+type Publisher interface {
+	PublishOutbound(msg bus.OutboundMessage)
+}
+
+// Deferred is a delivery held back by DND.
+type Deferred struct {
+	Source    string              `json:"source"` // "cron", "heartbeat"
+	Message   bus.OutboundMessage `json:"message"`
+	QueuedAt  time.Time           `json:"queued_at"`
+	ReleaseAt time.Time           `json:"release_at"`
+}
+
+// Gate queues proactive deliveries during DND windows. The queue is persisted
+// to a JSON file so deliveries survive a restart.
+type Gate struct {
+	agents  AgentGetter
+	publish Publisher
+	path    string // "" = in-memory only
+	now     func() time.Time
+
+	mu    sync.Mutex
+	queue []Deferred
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewGate creates a gate. path is the queue file ("" disables persistence).
+func NewGate(agents AgentGetter, publish Publisher, path string) *Gate {
+	g := &Gate{agents: agents, publish: publish, path: path, now: time.Now, stopCh: make(chan struct{})}
+	g.load()
+	return g
+}
+
+// Deliver publishes msg now, or queues it when msg.AgentID is in a DND window
+// and msg.Channel is not one of its urgent channels. Messages without an
+// agent are always published.
+func (g *Gate) Deliver(ctx context.Context, source string, msg bus.OutboundMessage) {
+	release, quiet := g.quietUntil(ctx, msg)
+	if !quiet {
+		g.publish.PublishOutbound(msg)
+		return
+	}
+	g.mu.Lock()
+	g.queue = append(g.queue, Deferred{Source: source, Message: msg, QueuedAt: g.now(), ReleaseAt: release})
+	g.saveLocked()
+	g.mu.Unlock()
+	slog.Info("dnd: delivery queued", "source", source, "agent_id", msg.AgentID,
+		"channel", msg.Channel, "release_at", release.UTC().Format(time.RFC3339))
+}
+
+// Pending returns a copy of the queued deliveries.
+func (g *Gate) Pending() []Deferred {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Deferred(nil), g.queue...)
+}
+
+// Start begins releasing queued deliveries as their windows end.
+func (g *Gate) Start() {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		t := time.NewTicker(checkInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-g.stopCh:
+				return
+			case <-t.C:
+				g.releaseDue()
+			}
+		}
+	}()
+}
+
+// Stop halts the release loop. Queued deliveries stay persisted.
+func (g *Gate) Stop() {
+	close(g.stopCh)
+	g.wg.Wait()
+}
+
+// quietUntil reports whether msg must wait and until when.
+func (g *Gate) quietUntil(ctx context.Context, msg bus.OutboundMessage) (time.Time, bool) {
+	if msg.AgentID == uuid.Nil || g.agents == nil {
+		return time.Time{}, false
+	}
+	if msg.TenantID != uuid.Nil {
+		ctx = store.WithTenantID(ctx, msg.TenantID)
+	}
+	ag, err := g.agents.GetByID(ctx, msg.AgentID)
+	if err != nil || ag == nil {
+		return time.Time{}, false // fail-open: never lose a delivery to a lookup error
+	}
+	cfg := ag.ParseDNDConfig()
+	if cfg == nil || cfg.IsUrgentChannel(msg.Channel) {
+		return time.Time{}, false
+	}
+	return cfg.ActiveUntil(g.now())
+}
+
+// releaseDue publishes deliveries whose window has ended. The agent's config
+// is re-read first, so an extended or disabled window takes effect.
+func (g *Gate) releaseDue() {
+	now := g.now()
+	g.mu.Lock()
+	var due []Deferred
+	kept := g.queue[:0]
+	for _, d := range g.queue {
+		if now.Before(d.ReleaseAt) {
+			kept = append(kept, d)
+		} else {
+			due = append(due, d)
+		}
+	}
+	g.queue = kept
+	g.mu.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	var requeue []Deferred
+	for _, d := range due {
+		if release, quiet := g.quietUntil(context.Background(), d.Message); quiet {
+			d.ReleaseAt = release
+			requeue = append(requeue, d)
+			continue
+		}
+		slog.Info("dnd: releasing queued delivery", "source", d.Source, "agent_id", d.Message.AgentID,
+			"channel", d.Message.Channel, "queued_at", d.QueuedAt.UTC().Format(time.RFC3339))
+		g.publish.PublishOutbound(d.Message)
+	}
+
+	g.mu.Lock()
+	g.queue = append(g.queue, requeue...)
+	g.saveLocked()
+	g.mu.Unlock()
+}
+
+func (g *Gate) load() {
+	if g.path == "" {
+		return
+	}
+	data, err := os.ReadFile(g.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("dnd: failed to read queue", "path", g.path, "error", err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &g.queue); err != nil {
+		slog.Warn("dnd: failed to parse queue, starting empty", "path", g.path, "error", err)
+		g.queue = nil
+	}
+}
+
+func (g *Gate) saveLocked() {
+	if g.path == "" {
+		return
+	}
+	data, err := json.Marshal(g.queue)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+		slog.Warn("dnd: failed to create queue dir", "path", g.path, "error", err)
+		return
+	}
+	tmp := g.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		slog.Warn("dnd: failed to write queue", "path", g.path, "error", err)
+		return
+	}
+	if err := os.Rename(tmp, g.path); err != nil {
+		slog.Warn("dnd: failed to save queue", "path", g.path, "error", err)
+	}
+}
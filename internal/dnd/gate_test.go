@@ -0,0 +1,116 @@
+package dnd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type fakeAgents map[uuid.UUID]*store.AgentData
+
+func (f fakeAgents) GetByID(_ context.Context, id uuid.UUID) (*store.AgentData, error) {
+	if ag, ok := f[id]; ok {
+		return ag, nil
+	}
+	return nil, context.Canceled
+}
+
+type fakePublisher struct {
+	mu   sync.Mutex
+	sent []bus.OutboundMessage
+}
+
+func (p *fakePublisher) PublishOutbound(msg bus.OutboundMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent = append(p.sent, msg)
+}
+
+func (p *fakePublisher) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sent)
+}
+
+func nightAgent(id uuid.UUID) *store.AgentData {
+	return &store.AgentData{
+		BaseModel:   store.BaseModel{ID: id},
+		OtherConfig: json.RawMessage(`{"do_not_disturb":{"windows":[{"start":"22:00","end":"07:00"}],"urgent_channels":["alerts"]}}`),
+	}
+}
+
+func TestGate_QueuesDuringDNDAndReleases(t *testing.T) {
+	id := uuid.New()
+	pub := &fakePublisher{}
+	path := filepath.Join(t.TempDir(), "dnd_queue.json")
+	g := NewGate(fakeAgents{id: nightAgent(id)}, pub, path)
+	now := time.Date(2026, 10, 14, 23, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	g.Deliver(context.Background(), "cron", bus.OutboundMessage{Channel: "telegram", ChatID: "1", Content: "report", AgentID: id})
+	if pub.count() != 0 || len(g.Pending()) != 1 {
+		t.Fatalf("expected message to be queued: sent=%d pending=%d", pub.count(), len(g.Pending()))
+	}
+
+	// Urgent channels and agent-less messages bypass the gate.
+	g.Deliver(context.Background(), "cron", bus.OutboundMessage{Channel: "alerts", ChatID: "1", AgentID: id})
+	g.Deliver(context.Background(), "cron", bus.OutboundMessage{Channel: "telegram", ChatID: "1"})
+	if pub.count() != 2 {
+		t.Fatalf("urgent/agent-less deliveries: sent=%d, want 2", pub.count())
+	}
+
+	// The queue survives a restart.
+	reloaded := NewGate(fakeAgents{id: nightAgent(id)}, pub, path)
+	if p := reloaded.Pending(); len(p) != 1 || p[0].Message.Content != "report" {
+		t.Fatalf("reloaded queue = %+v", p)
+	}
+
+	now = time.Date(2026, 10, 15, 6, 59, 0, 0, time.UTC)
+	g.releaseDue()
+	if pub.count() != 2 {
+		t.Fatal("released before the window ended")
+	}
+	now = time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	g.releaseDue()
+	if pub.count() != 3 || len(g.Pending()) != 0 {
+		t.Fatalf("after window: sent=%d pending=%d", pub.count(), len(g.Pending()))
+	}
+	if reloaded := NewGate(nil, pub, path); len(reloaded.Pending()) != 0 {
+		t.Error("released delivery still persisted")
+	}
+}
+
+func TestGate_RequeuesWhenWindowExtended(t *testing.T) {
+	id := uuid.New()
+	agents := fakeAgents{id: nightAgent(id)}
+	pub := &fakePublisher{}
+	g := NewGate(agents, pub, "")
+	now := time.Date(2026, 10, 14, 23, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+	g.Deliver(context.Background(), "heartbeat", bus.OutboundMessage{Channel: "telegram", ChatID: "1", AgentID: id})
+
+	agents[id].OtherConfig = json.RawMessage(`{"do_not_disturb":{"windows":[{"start":"22:00","end":"09:00"}]}}`)
+	now = time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	g.releaseDue()
+	p := g.Pending()
+	if pub.count() != 0 || len(p) != 1 || !p[0].ReleaseAt.Equal(time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected requeue until 09:00: sent=%d pending=%+v", pub.count(), p)
+	}
+}
+
+func TestGate_FailsOpen(t *testing.T) {
+	pub := &fakePublisher{}
+	g := NewGate(fakeAgents{}, pub, "")
+	g.Deliver(context.Background(), "cron", bus.OutboundMessage{Channel: "telegram", ChatID: "1", AgentID: uuid.New()})
+	if pub.count() != 1 {
+		t.Error("unknown agent should not block delivery")
+	}
+}
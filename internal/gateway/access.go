@@ -0,0 +1,227 @@
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+)
+
+// Route auth levels for gateway.route_policies.
+const (
+	routeAuthDefault = ""      // handler decides (token, API key, pairing)
+	routeAuthOpen    = "open"  // no gateway-level auth check
+	routeAuthToken   = "token" // gateway token only
+	routeAuthAdmin   = "admin" // gateway token or admin-role API key
+)
+
+type routeAuthKey struct{}
+
+// routeAuthFrom returns the auth level the access middleware attached to ctx.
+// Used by the WS connect handshake, where credentials arrive in the first frame.
+func routeAuthFrom(ctx context.Context) string {
+	v, _ := ctx.Value(routeAuthKey{}).(string)
+	return v
+}
+
+type routePolicy struct {
+	prefix string
+	auth   string
+	nets   []*net.IPNet // nil = inherit the global allowlist
+}
+
+// accessControl enforces IP allowlists and per-route auth levels in front of
+// the gateway mux. It is an outer gate that can only tighten access: handlers
+// still wrap routes in requireAuth (internal/http), which resolves the
+// caller's role, tenant and user into the request context and applies the
+// route's minimum role. Token-only routes outside the API handlers (the MCP
+// bridge) use require directly instead of their own token comparison.
+type accessControl struct {
+	token   string
+	global  []*net.IPNet
+	trusted []*net.IPNet
+	routes  []routePolicy // longest prefix first
+}
+
+// newAccessControl parses gateway access settings. Returns nil when nothing is
+// configured so the mux is served unwrapped.
+func newAccessControl(gw config.GatewayConfig) (*accessControl, error) {
+	if len(gw.AllowedCIDRs) == 0 && len(gw.RoutePolicies) == 0 {
+		return nil, nil
+	}
+	a := &accessControl{token: gw.Token}
+	var err error
+	if a.global, err = parseCIDRs(gw.AllowedCIDRs); err != nil {
+		return nil, fmt.Errorf("gateway.allowed_cidrs: %w", err)
+	}
+	if a.trusted, err = parseCIDRs(gw.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("gateway.trusted_proxies: %w", err)
+	}
+	for _, rp := range gw.RoutePolicies {
+		switch rp.Auth {
+		case routeAuthDefault, routeAuthOpen, routeAuthToken, routeAuthAdmin:
+		default:
+			return nil, fmt.Errorf("gateway.route_policies[%s]: unknown auth %q", rp.Prefix, rp.Auth)
+		}
+		p := routePolicy{prefix: rp.Prefix, auth: rp.Auth}
+		if rp.AllowedCIDRs != nil {
+			if p.nets, err = parseCIDRs(rp.AllowedCIDRs); err != nil {
+				return nil, fmt.Errorf("gateway.route_policies[%s]: %w", rp.Prefix, err)
+			}
+		}
+		if p.auth == routeAuthToken && gw.Token == "" {
+			slog.Warn("security.route_policy_unsatisfiable", "prefix", rp.Prefix, "reason", "no gateway token configured")
+		}
+		a.routes = append(a.routes, p)
+	}
+	sort.SliceStable(a.routes, func(i, j int) bool { return len(a.routes[i].prefix) > len(a.routes[j].prefix) })
+	return a, nil
+}
+
+// parseCIDRs accepts CIDRs and bare IPs ("10.0.0.0/8", "203.0.113.7").
+func parseCIDRs(list []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(list))
+	for _, s := range list {
+		s = strings.TrimSpace(s)
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP %q", s)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", s)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyFor returns the longest-prefix policy matching path (nil if none).
+func (a *accessControl) policyFor(path string) *routePolicy {
+	for i := range a.routes {
+		if strings.HasPrefix(path, a.routes[i].prefix) {
+			return &a.routes[i]
+		}
+	}
+	return nil
+}
+
+// peerIP resolves the caller IP for allowlisting. Forwarding headers are only
+// honored when the direct peer is a trusted proxy — unlike clientIP, which is
+// used for logging and rate-limit keys and trusts them unconditionally.
+func (a *accessControl) peerIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !containsIP(a.trusted, ip) {
+		return ip
+	}
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		hops := strings.Split(xff, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := net.ParseIP(strings.TrimSpace(hops[i]))
+			if hop == nil {
+				break
+			}
+			if !containsIP(a.trusted, hop) {
+				return hop
+			}
+		}
+	}
+	if real := net.ParseIP(r.Header.Get("X-Real-IP")); real != nil {
+		return real
+	}
+	return ip
+}
+
+// checkBearer reports whether the request's bearer token satisfies level.
+func (a *accessControl) checkBearer(r *http.Request, level string) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return false
+	}
+	return credentialSatisfies(r.Context(), a.token, bearer, level)
+}
+
+// credentialSatisfies checks a raw token (header or WS connect param) against level.
+func credentialSatisfies(ctx context.Context, gatewayToken, provided, level string) bool {
+	if gatewayToken != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(gatewayToken)) == 1 {
+		return true
+	}
+	if level != routeAuthAdmin {
+		return false
+	}
+	_, role := httpapi.ResolveAPIKey(ctx, provided)
+	return role != "" && permissions.HasMinRole(role, permissions.RoleAdmin)
+}
+
+func (a *accessControl) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := a.policyFor(r.URL.Path)
+
+		nets := a.global
+		if p != nil && p.nets != nil {
+			nets = p.nets
+		}
+		if len(nets) > 0 {
+			if ip := a.peerIP(r); ip == nil || !containsIP(nets, ip) {
+				slog.Warn("security.ip_not_allowed", "path", r.URL.Path, "ip", ip, "remote", r.RemoteAddr)
+				http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+				return
+			}
+		}
+
+		if p == nil || p.auth == routeAuthDefault || p.auth == routeAuthOpen {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// WebSocket clients authenticate in the connect frame; carry the
+		// required level through to handleConnect instead of checking headers.
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), routeAuthKey{}, p.auth)))
+			return
+		}
+
+		a.require(p.auth, next).ServeHTTP(w, r)
+	})
+}
+
+// require wraps next with a bearer-token check for the given auth level.
+func (a *accessControl) require(level string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.checkBearer(r, level) {
+			slog.Warn("security.route_auth_rejected", "path", r.URL.Path, "required", level, "ip", clientIP(r))
+			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
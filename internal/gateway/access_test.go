@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+func newTestAccess(t *testing.T, gw config.GatewayConfig) http.Handler {
+	t.Helper()
+	ac, err := newAccessControl(gw)
+	if err != nil {
+		t.Fatalf("newAccessControl: %v", err)
+	}
+	if ac == nil {
+		t.Fatal("expected access control to be configured")
+	}
+	return ac.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Route-Auth", routeAuthFrom(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func doAccess(h http.Handler, path, remote, bearer string, hdr map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remote
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	for k, v := range hdr {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewAccessControl_NilWhenUnconfigured(t *testing.T) {
+	ac, err := newAccessControl(config.GatewayConfig{Token: "t"})
+	if err != nil || ac != nil {
+		t.Fatalf("got (%v, %v), want (nil, nil)", ac, err)
+	}
+}
+
+func TestNewAccessControl_Invalid(t *testing.T) {
+	cases := map[string]config.GatewayConfig{
+		"bad cidr":    {AllowedCIDRs: []string{"10.0.0.0/99"}},
+		"bad ip":      {AllowedCIDRs: []string{"not-an-ip"}},
+		"bad auth":    {RoutePolicies: []config.RoutePolicy{{Prefix: "/v1/", Auth: "sometimes"}}},
+		"bad trusted": {AllowedCIDRs: []string{"10.0.0.1"}, TrustedProxies: []string{"x"}},
+	}
+	for name, gw := range cases {
+		if _, err := newAccessControl(gw); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestAccessControl_IPAllowlist(t *testing.T) {
+	h := newTestAccess(t, config.GatewayConfig{
+		AllowedCIDRs:   []string{"10.0.0.0/8", "203.0.113.7"},
+		TrustedProxies: []string{"10.0.0.1"},
+		RoutePolicies: []config.RoutePolicy{
+			{Prefix: "/health", Auth: "open", AllowedCIDRs: []string{}},
+		},
+	})
+
+	cases := []struct {
+		name   string
+		remote string
+		path   string
+		hdr    map[string]string
+		want   int
+	}{
+		{"allowed cidr", "10.1.2.3:5000", "/v1/agents", nil, http.StatusOK},
+		{"allowed single ip", "203.0.113.7:5000", "/v1/agents", nil, http.StatusOK},
+		{"denied", "198.51.100.1:5000", "/v1/agents", nil, http.StatusForbidden},
+		{"spoofed xff from untrusted peer", "198.51.100.1:5000", "/v1/agents", map[string]string{"X-Forwarded-For": "10.1.1.1"}, http.StatusForbidden},
+		{"xff via trusted proxy", "10.0.0.1:5000", "/v1/agents", map[string]string{"X-Forwarded-For": "198.51.100.9"}, http.StatusForbidden},
+		{"xff via trusted proxy allowed", "10.0.0.1:5000", "/v1/agents", map[string]string{"X-Forwarded-For": "203.0.113.7"}, http.StatusOK},
+		{"exempt route", "198.51.100.1:5000", "/health", nil, http.StatusOK},
+	}
+	for _, c := range cases {
+		if rec := doAccess(h, c.path, c.remote, "", c.hdr); rec.Code != c.want {
+			t.Errorf("%s: status %d, want %d", c.name, rec.Code, c.want)
+		}
+	}
+}
+
+func TestAccessControl_RouteAuth(t *testing.T) {
+	h := newTestAccess(t, config.GatewayConfig{
+		Token: "gw-token",
+		RoutePolicies: []config.RoutePolicy{
+			{Prefix: "/v1/", Auth: "admin"},
+			{Prefix: "/v1/public/", Auth: "open"},
+			{Prefix: "/mcp/", Auth: "token"},
+		},
+	})
+	const remote = "192.0.2.1:1234"
+
+	cases := []struct {
+		name, path, bearer string
+		want               int
+	}{
+		{"admin route without token", "/v1/agents", "", http.StatusUnauthorized},
+		{"admin route wrong token", "/v1/agents", "nope", http.StatusUnauthorized},
+		{"admin route gateway token", "/v1/agents", "gw-token", http.StatusOK},
+		{"longest prefix open", "/v1/public/info", "", http.StatusOK},
+		{"token route", "/mcp/bridge", "gw-token", http.StatusOK},
+		{"token route rejects other", "/mcp/bridge", "goclaw_sk_x", http.StatusUnauthorized},
+		{"no policy", "/other", "", http.StatusOK},
+	}
+	for _, c := range cases {
+		if rec := doAccess(h, c.path, remote, c.bearer, nil); rec.Code != c.want {
+			t.Errorf("%s: status %d, want %d", c.name, rec.Code, c.want)
+		}
+	}
+}
+
+func TestAccessControl_WebSocketDefersToConnect(t *testing.T) {
+	h := newTestAccess(t, config.GatewayConfig{
+		Token:         "gw-token",
+		RoutePolicies: []config.RoutePolicy{{Prefix: "/ws", Auth: "token"}},
+	})
+	rec := doAccess(h, "/ws", "192.0.2.1:1234", "", map[string]string{"Upgrade": "websocket"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200 (auth deferred to connect frame)", rec.Code)
+	}
+	if got := rec.Header().Get("X-Route-Auth"); got != routeAuthToken {
+		t.Errorf("route auth in ctx = %q, want %q", got, routeAuthToken)
+	}
+}
+
+func TestCredentialSatisfies(t *testing.T) {
+	ctx := context.Background()
+	if !credentialSatisfies(ctx, "gw", "gw", routeAuthToken) {
+		t.Error("gateway token should satisfy token level")
+	}
+	if credentialSatisfies(ctx, "gw", "other", routeAuthToken) {
+		t.Error("wrong token must not satisfy token level")
+	}
+	if credentialSatisfies(ctx, "", "", routeAuthToken) {
+		t.Error("empty token must never satisfy token level")
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"log/slog"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 
+	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
@@ -22,7 +24,7 @@ type Client struct {
 	authenticated bool
 	role          permissions.Role
 	userID        string // external user ID (TEXT, free-form), set during connect
-	send          chan []byte
+	send          chan wsMessage
 
 	connectedAt time.Time // when the client connected
 	remoteAddr  string    // peer IP (extracted from proxy headers or RemoteAddr)
@@ -35,6 +37,10 @@ type Client struct {
 	pairingPending bool   // true while waiting for admin approval
 	pairedSenderID string // senderID used for browser pairing auth (for revocation lookup)
 	pairedChannel  string // channel used for pairing auth (e.g., "browser")
+	routeAuth      string // gateway.route_policies level for /ws ("token", "admin"; "" = any)
+
+	// Admin UI cookie session presented on the upgrade request (nil = none).
+	uiSession *httpapi.UISessionAuth
 
 	// Team access cache for event filtering (lazily populated).
 	teamIDs map[string]bool
@@ -42,6 +48,20 @@ type Client struct {
 	tenantID   uuid.UUID // resolved tenant; always concrete after connect
 	tenantName string    // resolved tenant display name (set during connect)
 	tenantSlug string    // resolved tenant URL slug (set during connect)
+
+	// Correlation IDs of in-flight requests keyed by request frame ID, copied
+	// into error responses.
+	requestIDs sync.Map
+
+	// In-progress binary attachment uploads keyed by transfer ID.
+	// Only touched from the read pump goroutine.
+	uploads map[string]*wsUpload
+}
+
+// wsMessage is one outbound WebSocket message (text JSON frame or binary chunk).
+type wsMessage struct {
+	typ  int
+	data []byte
 }
 
 func NewClient(conn *websocket.Conn, server *Server, remoteIP string) *Client {
@@ -49,7 +69,7 @@ func NewClient(conn *websocket.Conn, server *Server, remoteIP string) *Client {
 		id:          uuid.NewString(),
 		conn:        conn,
 		server:      server,
-		send:        make(chan []byte, 256),
+		send:        make(chan wsMessage, 256),
 		connectedAt: time.Now(),
 		remoteAddr:  remoteIP,
 	}
@@ -65,9 +85,14 @@ func (c *Client) Run(ctx context.Context) {
 // Gorilla/websocket closes the connection with ErrReadLimit if exceeded.
 const maxWSMessageSize = 512 * 1024
 
+// wsCompressThreshold is the smallest text frame compressed with
+// permessage-deflate; below it the deflate overhead outweighs the savings.
+const wsCompressThreshold = 1024
+
 // readPump reads frames from the WebSocket connection.
 func (c *Client) readPump(ctx context.Context) {
 	defer c.conn.Close()
+	defer c.abortUploads()
 
 	c.conn.SetReadLimit(maxWSMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
@@ -77,7 +102,7 @@ func (c *Client) readPump(ctx context.Context) {
 	})
 
 	for {
-		_, data, err := c.conn.ReadMessage()
+		msgType, data, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
 				slog.Warn("websocket read error", "client", c.id, "error", err)
@@ -88,6 +113,10 @@ func (c *Client) readPump(ctx context.Context) {
 		// Reset read deadline on activity
 		c.conn.SetReadDeadline(time.Now().Add(60 * time.Second))
 
+		if msgType == websocket.BinaryMessage {
+			c.handleBinary(data)
+			continue
+		}
 		c.handleFrame(ctx, data)
 	}
 }
@@ -108,7 +137,9 @@ func (c *Client) writePump() {
 				return
 			}
 			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+			// Attachments (images, audio) are usually compressed already.
+			c.conn.EnableWriteCompression(msg.typ == websocket.TextMessage && len(msg.data) >= wsCompressThreshold)
+			if err := c.conn.WriteMessage(msg.typ, msg.data); err != nil {
 				return
 			}
 
@@ -153,8 +184,21 @@ func (c *Client) handleFrame(ctx context.Context, data []byte) {
 	}
 }
 
-// SendResponse sends a response frame to this client.
+// trackRequestID remembers the correlation ID of an in-flight request.
+func (c *Client) trackRequestID(frameID, requestID string) {
+	if frameID != "" {
+		c.requestIDs.Store(frameID, requestID)
+	}
+}
+
+// SendResponse sends a response frame to this client. Error responses carry
+// the request's correlation ID.
 func (c *Client) SendResponse(resp *protocol.ResponseFrame) {
+	if resp.ID != "" {
+		if v, ok := c.requestIDs.LoadAndDelete(resp.ID); ok && resp.Error != nil && resp.Error.RequestID == "" {
+			resp.Error.RequestID = v.(string)
+		}
+	}
 	data, err := json.Marshal(resp)
 	if err != nil {
 		slog.Error("marshal response failed", "error", err)
@@ -166,7 +210,7 @@ func (c *Client) SendResponse(resp *protocol.ResponseFrame) {
 		}
 	}()
 	select {
-	case c.send <- data:
+	case c.send <- wsMessage{typ: websocket.TextMessage, data: data}:
 	default:
 		slog.Warn("client send buffer full, dropping message", "client", c.id)
 	}
@@ -185,7 +229,7 @@ func (c *Client) SendEvent(event protocol.EventFrame) {
 		}
 	}()
 	select {
-	case c.send <- data:
+	case c.send <- wsMessage{typ: websocket.TextMessage, data: data}:
 	default:
 		slog.Warn("client send buffer full, dropping event", "client", c.id)
 	}
@@ -0,0 +1,224 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/nextlevelbuilder/goclaw/internal/channels/media"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+const (
+	// maxWSAttachmentSize caps one binary upload (same as POST /v1/media/upload).
+	maxWSAttachmentSize int64 = 50 * 1024 * 1024
+
+	// maxWSUploads caps concurrent in-progress uploads per connection.
+	maxWSUploads = 4
+
+	// attachmentSendTimeout is how long an outbound chunk may wait for room in
+	// the send buffer. Chunks are never dropped silently: a missing chunk would
+	// corrupt the transfer, so the transfer is aborted instead.
+	attachmentSendTimeout = 10 * time.Second
+)
+
+// wsUpload is a client → server attachment being written to a temp file.
+type wsUpload struct {
+	file     *os.File
+	filename string
+	mimeType string
+	size     int64 // declared total size (0 = unknown)
+	written  int64
+	next     int // expected next chunk seq
+}
+
+// handleBinary processes one binary chunk frame from the client. Chunks are
+// streamed to a temp file; when the final chunk arrives the client receives
+// attachment.uploaded with the file path, usable as a chat.send media item.
+func (c *Client) handleBinary(data []byte) {
+	if !c.authenticated {
+		c.sendError("", protocol.ErrUnauthorized, "first request must be 'connect'")
+		return
+	}
+	h, chunk, err := protocol.DecodeBinaryFrame(data)
+	if err != nil {
+		c.sendError("", protocol.ErrInvalidRequest, "invalid binary frame: "+err.Error())
+		return
+	}
+
+	up := c.uploads[h.ID]
+	switch {
+	case up == nil && h.Seq != 0:
+		// Remaining chunks of a transfer that already failed.
+		return
+	case up == nil:
+		if up, err = c.startUpload(h); err != nil {
+			c.failUpload(h.ID, err.Error())
+			return
+		}
+	case h.Seq != up.next:
+		c.failUpload(h.ID, fmt.Sprintf("chunk %d out of order (expected %d)", h.Seq, up.next))
+		return
+	}
+
+	up.written += int64(len(chunk))
+	if up.written > maxWSAttachmentSize || (up.size > 0 && up.written > up.size) {
+		c.failUpload(h.ID, "attachment exceeds its declared or maximum size")
+		return
+	}
+	if _, err := up.file.Write(chunk); err != nil {
+		c.failUpload(h.ID, "failed to save file")
+		return
+	}
+	up.next++
+	if !h.Final {
+		return
+	}
+
+	if up.size > 0 && up.written != up.size {
+		c.failUpload(h.ID, fmt.Sprintf("received %d of %d bytes", up.written, up.size))
+		return
+	}
+	if err := up.file.Close(); err != nil {
+		c.failUpload(h.ID, "failed to save file")
+		return
+	}
+	delete(c.uploads, h.ID)
+	c.SendEvent(*protocol.NewEvent(protocol.EventAttachmentUploaded, map[string]any{
+		"id":       h.ID,
+		"path":     up.file.Name(),
+		"filename": up.filename,
+		"mimeType": up.mimeType,
+		"size":     up.written,
+	}))
+}
+
+// startUpload validates chunk 0 of a transfer and creates its temp file.
+func (c *Client) startUpload(h protocol.BinaryHeader) (*wsUpload, error) {
+	if len(c.uploads) >= maxWSUploads {
+		return nil, fmt.Errorf("too many concurrent uploads (max %d)", maxWSUploads)
+	}
+	if h.Size > maxWSAttachmentSize {
+		return nil, fmt.Errorf("attachment too large (max %d bytes)", maxWSAttachmentSize)
+	}
+	// Sanitize filename: strip path, prevent traversal.
+	name := filepath.Base(h.Filename)
+	if h.Filename == "" {
+		name = "attachment"
+	}
+	if name == "." || name == "/" || strings.Contains(name, "..") {
+		return nil, errors.New("invalid filename")
+	}
+	ext := filepath.Ext(name)
+	if ext == "" {
+		ext = ".bin"
+	}
+	f, err := os.CreateTemp("", "ws_upload_*"+ext)
+	if err != nil {
+		return nil, errors.New("failed to create temp file")
+	}
+	mimeType := h.MimeType
+	if mimeType == "" {
+		mimeType = media.DetectMIMEType(name)
+	}
+	up := &wsUpload{file: f, filename: name, mimeType: mimeType, size: h.Size}
+	if c.uploads == nil {
+		c.uploads = make(map[string]*wsUpload)
+	}
+	c.uploads[h.ID] = up
+	return up, nil
+}
+
+// failUpload discards a transfer and tells the client why.
+func (c *Client) failUpload(id, reason string) {
+	if up, ok := c.uploads[id]; ok {
+		up.file.Close()
+		os.Remove(up.file.Name())
+		delete(c.uploads, id)
+	}
+	c.SendEvent(*protocol.NewEvent(protocol.EventAttachmentFailed, map[string]any{
+		"id":    id,
+		"error": reason,
+	}))
+}
+
+// abortUploads removes the temp files of transfers left unfinished when the
+// connection closes.
+func (c *Client) abortUploads() {
+	for id, up := range c.uploads {
+		up.file.Close()
+		os.Remove(up.file.Name())
+		delete(c.uploads, id)
+	}
+}
+
+// SendAttachment streams r to the client as binary chunk frames under the
+// given transfer ID. size is advisory (0 = unknown). Returns an error if the
+// client disconnects or stops reading; the client then never sees a final chunk.
+func (c *Client) SendAttachment(id, filename, mimeType string, size int64, r io.Reader) error {
+	buf := make([]byte, protocol.MaxBinaryChunkSize)
+	for seq := 0; ; seq++ {
+		n, err := io.ReadFull(r, buf)
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !final {
+			return err
+		}
+		h := protocol.BinaryHeader{ID: id, Seq: seq, Final: final}
+		if seq == 0 {
+			h.Filename, h.MimeType, h.Size = filename, mimeType, size
+		}
+		frame, err := protocol.EncodeBinaryFrame(h, buf[:n])
+		if err != nil {
+			return err
+		}
+		if err := c.sendBinary(frame); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// SendAttachmentFile streams a local file to the client (see SendAttachment).
+func (c *Client) SendAttachmentFile(id, path, mimeType string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var size int64
+	if st, err := f.Stat(); err == nil {
+		size = st.Size()
+	}
+	if mimeType == "" {
+		mimeType = media.DetectMIMEType(path)
+	}
+	return c.SendAttachment(id, filepath.Base(path), mimeType, size, f)
+}
+
+// sendBinary queues a binary frame, waiting up to attachmentSendTimeout for
+// buffer space.
+func (c *Client) sendBinary(frame []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Debug("client gone, dropping attachment", "client", c.id)
+			err = errors.New("client disconnected")
+		}
+	}()
+	timer := time.NewTimer(attachmentSendTimeout)
+	defer timer.Stop()
+	select {
+	case c.send <- wsMessage{typ: websocket.BinaryMessage, data: frame}:
+		return nil
+	case <-timer.C:
+		return errors.New("client send buffer full")
+	}
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func attachmentTestClient() *Client {
+	return &Client{id: "c1", authenticated: true, send: make(chan wsMessage, 512)}
+}
+
+// nextEvent returns the next queued text frame decoded as an event.
+func nextEvent(t *testing.T, c *Client) protocol.EventFrame {
+	t.Helper()
+	select {
+	case msg := <-c.send:
+		var ev protocol.EventFrame
+		if err := json.Unmarshal(msg.data, &ev); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		return ev
+	default:
+		t.Fatal("no frame queued")
+		return protocol.EventFrame{}
+	}
+}
+
+func chunkFrame(t *testing.T, h protocol.BinaryHeader, data []byte) []byte {
+	t.Helper()
+	frame, err := protocol.EncodeBinaryFrame(h, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frame
+}
+
+func TestHandleBinary_ReassemblesChunkedUpload(t *testing.T) {
+	c := attachmentTestClient()
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 0, Filename: "voice.ogg", Size: 6}, []byte("abc")))
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 1, Final: true}, []byte("def")))
+
+	ev := nextEvent(t, c)
+	if ev.Event != protocol.EventAttachmentUploaded {
+		t.Fatalf("event = %q, want %q (%v)", ev.Event, protocol.EventAttachmentUploaded, ev.Payload)
+	}
+	p := ev.Payload.(map[string]any)
+	path, _ := p["path"].(string)
+	defer os.Remove(path)
+	got, err := os.ReadFile(path)
+	if err != nil || string(got) != "abcdef" {
+		t.Fatalf("uploaded file = %q, %v", got, err)
+	}
+	if p["filename"] != "voice.ogg" || p["size"] != float64(6) {
+		t.Errorf("unexpected payload %v", p)
+	}
+	if len(c.uploads) != 0 {
+		t.Errorf("upload still tracked after final chunk")
+	}
+}
+
+func TestHandleBinary_OutOfOrderChunkFailsTransfer(t *testing.T) {
+	c := attachmentTestClient()
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 0, Filename: "a.png"}, []byte("abc")))
+	path := c.uploads["up1"].file.Name()
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 2}, []byte("ghi")))
+
+	if ev := nextEvent(t, c); ev.Event != protocol.EventAttachmentFailed {
+		t.Fatalf("event = %q, want %q", ev.Event, protocol.EventAttachmentFailed)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("temp file not removed: %v", err)
+	}
+	// Remaining chunks of the failed transfer are dropped silently.
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 3, Final: true}, nil))
+	if len(c.send) != 0 {
+		t.Errorf("expected no frame for chunks of a failed transfer")
+	}
+}
+
+func TestHandleBinary_RejectsSizeMismatchAndUnauthenticated(t *testing.T) {
+	c := attachmentTestClient()
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 0, Size: 2, Final: true}, []byte("abc")))
+	if ev := nextEvent(t, c); ev.Event != protocol.EventAttachmentFailed {
+		t.Errorf("oversize: event = %q, want %q", ev.Event, protocol.EventAttachmentFailed)
+	}
+
+	c = attachmentTestClient()
+	c.authenticated = false
+	c.handleBinary(chunkFrame(t, protocol.BinaryHeader{ID: "up1", Seq: 0, Final: true}, []byte("abc")))
+	msg := <-c.send
+	if !bytes.Contains(msg.data, []byte(protocol.ErrUnauthorized)) || len(c.uploads) != 0 {
+		t.Errorf("unauthenticated upload not rejected: %s", msg.data)
+	}
+}
+
+func TestSendAttachment_ChunksPayload(t *testing.T) {
+	c := attachmentTestClient()
+	payload := bytes.Repeat([]byte("x"), protocol.MaxBinaryChunkSize*2+10)
+	if err := c.SendAttachment("dl1", "shot.png", "image/png", int64(len(payload)), bytes.NewReader(payload)); err != nil {
+		t.Fatalf("SendAttachment: %v", err)
+	}
+	close(c.send)
+
+	var got []byte
+	seq := 0
+	for msg := range c.send {
+		if msg.typ != websocket.BinaryMessage {
+			t.Fatalf("frame %d is not binary", seq)
+		}
+		h, chunk, err := protocol.DecodeBinaryFrame(msg.data)
+		if err != nil {
+			t.Fatalf("decode frame %d: %v", seq, err)
+		}
+		if h.ID != "dl1" || h.Seq != seq {
+			t.Fatalf("frame %d: header %+v", seq, h)
+		}
+		if seq == 0 && (h.Filename != "shot.png" || h.Size != int64(len(payload))) {
+			t.Errorf("first chunk missing metadata: %+v", h)
+		}
+		if h.Final != (seq == 2) {
+			t.Errorf("frame %d: final = %v", seq, h.Final)
+		}
+		got = append(got, chunk...)
+		seq++
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("reassembled %d bytes, want %d", len(got), len(payload))
+	}
+}
@@ -82,6 +82,12 @@ func clientCanReceiveEvent(c *Client, event bus.Event) bool {
 		return true
 	}
 
+	// Budget rejections: only the affected user (admins receive all of them).
+	if event.Name == protocol.EventBudgetExceeded {
+		uid := extractMapField(event.Payload, "userId")
+		return uid != "" && uid == c.userID
+	}
+
 	// Immediate trace status events: broadcast to all tenant clients (no per-user routing).
 	if event.Name == protocol.EventTraceStatusChanged {
 		return true
@@ -108,6 +114,20 @@ func clientCanReceiveEvent(c *Client, event bus.Event) bool {
 		return false // non-admin clients don't receive these
 	}
 
+	// Subagent progress: scoped to the user whose run spawned the subagent.
+	if event.Name == protocol.EventSubagentProgress {
+		if uid := extractMapField(event.Payload, "userId"); uid != "" {
+			return uid == c.userID
+		}
+		return true
+	}
+
+	// Browser downloads: scoped to the user whose run downloaded the file.
+	if strings.HasPrefix(event.Name, "browser.download.") {
+		uid := extractMapField(event.Payload, "userId")
+		return uid != "" && uid == c.userID
+	}
+
 	// Exec approval events: scoped to the requesting user.
 	if strings.HasPrefix(event.Name, "exec.approval.") {
 		if uid := extractMapField(event.Payload, "userId"); uid != "" {
@@ -153,7 +173,7 @@ func isAdminOnlyEvent(name string) bool {
 		protocol.EventDevicePairReq, protocol.EventDevicePairRes,
 		protocol.EventAgentLinkCreated, protocol.EventAgentLinkUpdated, protocol.EventAgentLinkDeleted,
 		protocol.EventWorkspaceFileChanged,
-		protocol.EventBackgroundError:
+		protocol.EventBackgroundError, protocol.EventQuotaWarning:
 		return true
 	}
 	return false
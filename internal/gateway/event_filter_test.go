@@ -155,6 +155,33 @@ func TestClientCanReceiveEvent_AgentEvent_FilteredByUserID(t *testing.T) {
 	}
 }
 
+func TestClientCanReceiveEvent_BudgetExceeded_OnlyAffectedUser(t *testing.T) {
+	userA := makeClient(permissions.RoleOperator, "user-a", masterTenant)
+	userB := makeClient(permissions.RoleOperator, "user-b", masterTenant)
+	admin := makeClient(permissions.RoleAdmin, "admin", masterTenant)
+
+	evt := makeEvent(protocol.EventBudgetExceeded, masterTenant, map[string]any{"userId": "user-a", "scope": "user"})
+	if !clientCanReceiveEvent(userA, evt) || !clientCanReceiveEvent(admin, evt) {
+		t.Error("affected user and admin should receive budget.exceeded")
+	}
+	if clientCanReceiveEvent(userB, evt) {
+		t.Error("user-b should NOT receive user-a's budget.exceeded")
+	}
+}
+
+func TestClientCanReceiveEvent_SubagentProgress_OnlySpawningUser(t *testing.T) {
+	userA := makeClient(permissions.RoleOperator, "user-a", masterTenant)
+	userB := makeClient(permissions.RoleOperator, "user-b", masterTenant)
+
+	evt := makeEvent(protocol.EventSubagentProgress, masterTenant, map[string]any{"userId": "user-a", "id": "sub-1"})
+	if !clientCanReceiveEvent(userA, evt) {
+		t.Error("spawning user should receive subagent.progress")
+	}
+	if clientCanReceiveEvent(userB, evt) {
+		t.Error("user-b should NOT receive user-a's subagent.progress")
+	}
+}
+
 func TestClientCanReceiveEvent_AgentEvent_AdminSeesAll(t *testing.T) {
 	admin := makeClient(permissions.RoleAdmin, "admin", masterTenant)
 	evt := makeEvent(protocol.EventAgent, masterTenant, map[string]any{"userId": "user-x"})
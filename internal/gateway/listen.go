@@ -0,0 +1,17 @@
+package gateway
+
+import (
+	"context"
+	"net"
+)
+
+// listenGateway opens the main gateway listener. With reusePort the socket is
+// bound with SO_REUSEPORT, so an upgraded process can bind the same address
+// while this one drains (zero-downtime restart).
+func listenGateway(ctx context.Context, addr string, reusePort bool) (net.Listener, error) {
+	var lc net.ListenConfig
+	if reusePort {
+		lc.Control = reusePortControl
+	}
+	return lc.Listen(ctx, "tcp", addr)
+}
@@ -0,0 +1,13 @@
+//go:build !(linux || darwin || freebsd)
+
+package gateway
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePortControl rejects gateway.reuse_port on platforms without SO_REUSEPORT.
+func reusePortControl(_, _ string, _ syscall.RawConn) error {
+	return errors.New("gateway.reuse_port is not supported on this platform")
+}
@@ -0,0 +1,31 @@
+//go:build linux || darwin || freebsd
+
+package gateway
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestListenGateway_ReusePortAllowsSecondBind(t *testing.T) {
+	ctx := context.Background()
+	first, err := listenGateway(ctx, "127.0.0.1:0", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+
+	// A replacement process binds the same port while the first still listens.
+	second, err := listenGateway(ctx, first.Addr().String(), true)
+	if err != nil {
+		t.Fatalf("second bind with reuse_port: %v", err)
+	}
+	second.Close()
+
+	// Without SO_REUSEPORT the port stays exclusive.
+	if ln, err := net.Listen("tcp", first.Addr().String()); err == nil {
+		ln.Close()
+		t.Error("plain bind should fail while the port is held")
+	}
+}
@@ -14,13 +14,7 @@ import (
 )
 
 // allowedAgentFiles is the list of files exposed via agents.files.* RPCs.
-// TOOLS.md excluded — not applicable.
-var allowedAgentFiles = []string{
-	bootstrap.AgentsFile, bootstrap.SoulFile, bootstrap.IdentityFile,
-	bootstrap.UserFile, bootstrap.UserPredefinedFile, bootstrap.CapabilitiesFile,
-	bootstrap.BootstrapFile, bootstrap.MemoryJSONFile,
-	bootstrap.HeartbeatFile,
-}
+var allowedAgentFiles = bootstrap.EditableFiles
 
 // --- agents.files.list ---
 // Matching TS src/gateway/server-methods/agents.ts:399-422
@@ -78,6 +72,22 @@ func (m *AgentsMethods) handleFilesList(ctx context.Context, client *gateway.Cli
 		}))
 		return
 	}
+
+	// --- Standalone: list from the agent workspace ---
+	ws := m.cfg.AgentWorkspacePath(params.AgentID)
+	files := make([]map[string]any, 0, len(allowedAgentFiles))
+	for _, name := range allowedAgentFiles {
+		f, _ := bootstrap.ReadWorkspaceFile(ws, name)
+		entry := map[string]any{"name": name, "missing": f.Missing}
+		if !f.Missing {
+			entry["size"] = len(f.Content)
+		}
+		files = append(files, entry)
+	}
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"agentId": params.AgentID,
+		"files":   files,
+	}))
 }
 
 // --- agents.files.get ---
@@ -144,6 +154,22 @@ func (m *AgentsMethods) handleFilesGet(ctx context.Context, client *gateway.Clie
 		}))
 		return
 	}
+
+	// --- Standalone: read from the agent workspace ---
+	f, err := bootstrap.ReadWorkspaceFile(m.cfg.AgentWorkspacePath(params.AgentID), params.Name)
+	if err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, err.Error()))
+		return
+	}
+	file := map[string]any{"name": params.Name, "missing": f.Missing}
+	if !f.Missing {
+		file["size"] = len(f.Content)
+		file["content"] = f.Content
+	}
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"agentId": params.AgentID,
+		"file":    file,
+	}))
 }
 
 // --- agents.files.set ---
@@ -216,6 +242,24 @@ func (m *AgentsMethods) handleFilesSet(ctx context.Context, client *gateway.Clie
 		}))
 		return
 	}
+
+	// --- Standalone: write to the agent workspace (no user instances to propagate to) ---
+	if err := bootstrap.WriteWorkspaceFile(m.cfg.AgentWorkspacePath(params.AgentID), params.Name, params.Content); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, i18n.T(locale, i18n.MsgFailedToSave, "file", err.Error())))
+		return
+	}
+	m.agents.InvalidateAgent(params.AgentID)
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"agentId": params.AgentID,
+		"file": map[string]any{
+			"name":    params.Name,
+			"missing": false,
+			"size":    len(params.Content),
+			"content": params.Content,
+		},
+		"propagated": 0,
+	}))
 }
 
 // --- Helpers ---
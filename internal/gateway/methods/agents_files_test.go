@@ -0,0 +1,46 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// TestHandleFilesSet_Standalone verifies that without an agent store,
+// agents.files.set writes into the agent's workspace directory.
+func TestHandleFilesSet_Standalone(t *testing.T) {
+	ws := t.TempDir()
+	cfg := minimalConfig()
+	cfg.Agents.Defaults.Workspace = ws
+	cfg.Agents.List = map[string]config.AgentSpec{"pinned": {Workspace: filepath.Join(ws, "custom")}}
+	m := &AgentsMethods{agents: agent.NewRouter(), cfg: cfg, workspace: ws}
+
+	cases := map[string]string{
+		"default": filepath.Join(ws, bootstrap.SoulFile),
+		"coder":   filepath.Join(ws, "agents", "coder", bootstrap.SoulFile),
+		"pinned":  filepath.Join(ws, "custom", bootstrap.SoulFile),
+	}
+	for agentID, want := range cases {
+		raw, _ := json.Marshal(map[string]any{"agentId": agentID, "name": bootstrap.SoulFile, "content": "soul of " + agentID})
+		m.handleFilesSet(context.Background(), nullClient(), &protocol.RequestFrame{ID: "1", Method: protocol.MethodAgentsFileSet, Params: raw})
+
+		data, err := os.ReadFile(want)
+		if err != nil || string(data) != "soul of "+agentID {
+			t.Errorf("%s: %s = %q, %v", agentID, want, data, err)
+		}
+	}
+
+	// Files outside the allowlist are rejected.
+	raw, _ := json.Marshal(map[string]any{"agentId": "default", "name": bootstrap.ToolsFile, "content": "x"})
+	m.handleFilesSet(context.Background(), nullClient(), &protocol.RequestFrame{ID: "2", Method: protocol.MethodAgentsFileSet, Params: raw})
+	if _, err := os.Stat(filepath.Join(ws, bootstrap.ToolsFile)); !os.IsNotExist(err) {
+		t.Errorf("%s should not be written", bootstrap.ToolsFile)
+	}
+}
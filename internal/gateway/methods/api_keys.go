@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/crypto"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
@@ -16,14 +17,16 @@ import (
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
-// APIKeysMethods handles api_keys.list, api_keys.create, api_keys.revoke.
+// APIKeysMethods handles api_keys.list, api_keys.create, api_keys.revoke,
+// api_keys.rotate.
 type APIKeysMethods struct {
 	apiKeys store.APIKeyStore
+	msgBus  *bus.MessageBus // for cache invalidation events; nil in tests
 }
 
 // NewAPIKeysMethods creates a new API keys method handler.
-func NewAPIKeysMethods(apiKeys store.APIKeyStore) *APIKeysMethods {
-	return &APIKeysMethods{apiKeys: apiKeys}
+func NewAPIKeysMethods(apiKeys store.APIKeyStore, msgBus *bus.MessageBus) *APIKeysMethods {
+	return &APIKeysMethods{apiKeys: apiKeys, msgBus: msgBus}
 }
 
 // Register registers API key management RPC methods.
@@ -31,6 +34,7 @@ func (m *APIKeysMethods) Register(router *gateway.MethodRouter) {
 	router.Register(protocol.MethodAPIKeysList, m.handleList)
 	router.Register(protocol.MethodAPIKeysCreate, m.handleCreate)
 	router.Register(protocol.MethodAPIKeysRevoke, m.handleRevoke)
+	router.Register(protocol.MethodAPIKeysRotate, m.handleRotate)
 }
 
 func (m *APIKeysMethods) handleList(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
@@ -230,5 +234,96 @@ func (m *APIKeysMethods) handleRevoke(ctx context.Context, client *gateway.Clien
 		return
 	}
 
+	m.emitCacheInvalidate(params.ID)
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]string{"status": "revoked"}))
 }
+
+// handleRotate issues a replacement for an API key (same name, scopes, tenant
+// and owner) and revokes the old one. Non-owner callers may only rotate keys
+// of their own tenant, as in handleRevoke.
+func (m *APIKeysMethods) handleRotate(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	locale := store.LocaleFromContext(ctx)
+
+	var params struct {
+		ID        string `json:"id"`
+		ExpiresIn *int   `json:"expires_in"` // seconds; nil = keep the old key's lifetime
+	}
+	if req.Params != nil {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidJSON)))
+			return
+		}
+	}
+
+	id, err := uuid.Parse(params.ID)
+	if err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidID, "API key")))
+		return
+	}
+
+	old, err := m.apiKeys.Get(ctx, id)
+	if err != nil || old == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "API key", params.ID)))
+		return
+	}
+	if old.Revoked {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, "API key is revoked")))
+		return
+	}
+	if !client.IsOwner() {
+		callerTID := store.TenantIDFromContext(ctx)
+		if old.TenantID == uuid.Nil || old.TenantID != callerTID {
+			slog.Warn("security.api_key_rotate_forbidden",
+				"key_id", params.ID,
+				"caller_tenant", callerTID,
+				"key_tenant", old.TenantID,
+				"user_id", client.UserID(),
+			)
+			client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "API key")))
+			return
+		}
+	}
+
+	raw, hash, prefix, err := crypto.GenerateAPIKey()
+	if err != nil {
+		slog.Error("api_keys.generate failed", "error", err)
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "key generation")))
+		return
+	}
+	key := store.RotatedAPIKey(old, hash, prefix, params.ExpiresIn, store.UserIDFromContext(ctx), time.Now())
+	if err := m.apiKeys.Create(ctx, key); err != nil {
+		slog.Error("api_keys.rotate create failed", "error", err, "id", params.ID)
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, i18n.T(locale, i18n.MsgFailedToCreate, "API key", "internal error")))
+		return
+	}
+	if err := m.apiKeys.Revoke(ctx, id, ""); err != nil {
+		// The replacement exists; report the failure so the caller can retry the revoke.
+		slog.Error("api_keys.rotate revoke failed", "error", err, "id", params.ID, "replacement", key.ID)
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "revoke old API key")))
+		return
+	}
+
+	m.emitCacheInvalidate(params.ID)
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"id":         key.ID,
+		"name":       key.Name,
+		"prefix":     key.Prefix,
+		"key":        raw,
+		"scopes":     key.Scopes,
+		"expires_at": key.ExpiresAt,
+		"created_at": key.CreatedAt,
+		"replaces":   old.ID,
+	}))
+}
+
+// emitCacheInvalidate drops cached API key lookups on every instance so a
+// revoked key stops authenticating immediately.
+func (m *APIKeysMethods) emitCacheInvalidate(key string) {
+	if m.msgBus == nil {
+		return
+	}
+	m.msgBus.Broadcast(bus.Event{
+		Name:    protocol.EventCacheInvalidate,
+		Payload: bus.CacheInvalidatePayload{Kind: bus.CacheKindAPIKeys, Key: key},
+	})
+}
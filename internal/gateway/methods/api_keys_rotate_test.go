@@ -0,0 +1,110 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/gateway"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+func buildRotateRequest(t *testing.T, keyID uuid.UUID) *protocol.RequestFrame {
+	t.Helper()
+	raw, err := json.Marshal(map[string]string{"id": keyID.String()})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return &protocol.RequestFrame{
+		Type:   protocol.FrameTypeRequest,
+		ID:     "rotate-req-1",
+		Method: protocol.MethodAPIKeysRotate,
+		Params: raw,
+	}
+}
+
+// replacementFor returns the key created by a rotation of oldID, if any.
+func (s *stubAPIKeyStore) replacementFor(oldID uuid.UUID) *store.APIKeyData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, k := range s.byID {
+		if id != oldID {
+			return k
+		}
+	}
+	return nil
+}
+
+func TestWSRotate_OwnTenantKey_ReplacesAndRevokes(t *testing.T) {
+	tid := uuid.MustParse("66666666-6666-6666-6666-666666666666")
+	keyID := uuid.New()
+	created := time.Now().Add(-48 * time.Hour)
+	expires := created.Add(30 * 24 * time.Hour)
+
+	stub := newStubAPIKeyStore()
+	_ = stub.Create(context.Background(), &store.APIKeyData{
+		ID:        keyID,
+		Name:      "chat-widget",
+		TenantID:  tid,
+		Scopes:    []string{"operator.chat"},
+		OwnerID:   "widget-user",
+		CreatedAt: created,
+		ExpiresAt: &expires,
+	})
+
+	m := &APIKeysMethods{apiKeys: stub}
+	client := gateway.NewTestClient(permissions.RoleAdmin, tid, "admin-1")
+	m.handleRotate(wsCallCtx(client), client, buildRotateRequest(t, keyID))
+
+	if !stub.wasRevoked(keyID) {
+		t.Fatal("old key must be revoked after rotation")
+	}
+	next := stub.replacementFor(keyID)
+	if next == nil {
+		t.Fatal("no replacement key created")
+	}
+	if next.Name != "chat-widget" || next.TenantID != tid || next.OwnerID != "widget-user" ||
+		len(next.Scopes) != 1 || next.Scopes[0] != "operator.chat" {
+		t.Errorf("replacement = %+v", next)
+	}
+	if next.ExpiresAt == nil || next.ExpiresAt.Sub(next.CreatedAt) != 30*24*time.Hour {
+		t.Errorf("replacement should keep the 30-day lifetime, expires_at = %v", next.ExpiresAt)
+	}
+}
+
+func TestWSRotate_TenantAdmin_CrossTenantKey_Denied(t *testing.T) {
+	keyID := uuid.New()
+	stub := newStubAPIKeyStore()
+	_ = stub.Create(context.Background(), &store.APIKeyData{
+		ID:       keyID,
+		Name:     "other-tenant-key",
+		TenantID: uuid.MustParse("77777777-7777-7777-7777-777777777777"),
+	})
+
+	m := &APIKeysMethods{apiKeys: stub}
+	client := gateway.NewTestClient(permissions.RoleAdmin, uuid.MustParse("88888888-8888-8888-8888-888888888888"), "admin-1")
+	m.handleRotate(wsCallCtx(client), client, buildRotateRequest(t, keyID))
+
+	if stub.wasRevoked(keyID) || stub.replacementFor(keyID) != nil {
+		t.Fatal("cross-tenant key must not be rotated")
+	}
+}
+
+func TestWSRotate_RevokedKey_Rejected(t *testing.T) {
+	keyID := uuid.New()
+	stub := newStubAPIKeyStore()
+	_ = stub.Create(context.Background(), &store.APIKeyData{ID: keyID, Name: "old", Revoked: true})
+
+	m := &APIKeysMethods{apiKeys: stub}
+	client := gateway.NewTestClient(permissions.RoleOwner, store.MasterTenantID, "owner-1")
+	m.handleRotate(wsCallCtx(client), client, buildRotateRequest(t, keyID))
+
+	if stub.replacementFor(keyID) != nil {
+		t.Fatal("revoked key must not be rotated")
+	}
+}
@@ -107,6 +107,20 @@ type chatSendParams struct {
 	SessionKey string            `json:"sessionKey"`
 	Stream     bool              `json:"stream"`
 	Media      json.RawMessage   `json:"media,omitempty"` // []string (legacy) or []chatMediaItem
+	// BinaryMedia streams result media to this connection as binary chunk
+	// frames after the response, in addition to the signed URLs in "media".
+	BinaryMedia bool `json:"binaryMedia,omitempty"`
+	// ResponseFormat forces a JSON answer, in OpenAI response_format shape:
+	// {"type":"json_object"} or {"type":"json_schema","json_schema":{...}}.
+	ResponseFormat json.RawMessage `json:"responseFormat,omitempty"`
+}
+
+// chatMediaTransfer links a result media item to its binary transfer ID.
+type chatMediaTransfer struct {
+	ID         string `json:"transferId"`
+	MediaIndex int    `json:"mediaIndex"`
+	path       string
+	mimeType   string
 }
 
 // parseMedia handles both legacy string paths and new {path,filename} objects.
@@ -132,6 +146,11 @@ func (p *chatSendParams) parseMedia() []chatMediaItem {
 
 func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
 	locale := store.LocaleFromContext(ctx)
+	// Graceful shutdown: in-flight runs finish, new ones go to the next process.
+	if m.agents.IsDraining() {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnavailable, i18n.T(locale, i18n.MsgGatewayDraining)))
+		return
+	}
 	// Rate limit check per user/client
 	if m.rateLimiter != nil && m.rateLimiter.Enabled() {
 		key := client.UserID()
@@ -150,6 +169,12 @@ func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, re
 		return
 	}
 
+	responseFormat, err := providers.ParseResponseFormat(params.ResponseFormat)
+	if err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, err.Error()))
+		return
+	}
+
 	if params.AgentID == "" {
 		// Extract agent key from session key (format: "agent:{key}:{rest}")
 		// so resuming an existing session routes to the correct agent.
@@ -284,8 +309,9 @@ func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, re
 			WorkspaceChatID: userID, // mirror ChatID so vault chat_id isolation activates for WS direct flow
 			RunID:           runID,
 			UserID:          userID,
-			Stream:     params.Stream,
-			InjectCh:   injectCh,
+			Stream:          params.Stream,
+			InjectCh:        injectCh,
+			ResponseFormat:  responseFormat,
 			// Wire trace ID back to the active run so force-abort can mark the
 			// correct trace as cancelled if the goroutine does not exit within 3s.
 			OnTraceCreated: func(traceID uuid.UUID) {
@@ -332,6 +358,7 @@ func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, re
 		// TTS auto-apply: convert [[tts]] tagged responses to voice audio
 		content := result.Content
 		var ttsAudio *agent.MediaResult
+		var ttsAudioPath string
 		if m.audioMgr != nil && content != "" {
 			// For WS, we don't have voice inbound info - use "tagged" mode only
 			ttsResult, _ := m.audioMgr.AutoApplyToText(runCtx, content, "ws", false, "")
@@ -342,6 +369,7 @@ func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, re
 					ContentType: ttsResult.AudioMime,
 					AsVoice:     true,
 				}
+				ttsAudioPath = ttsResult.AudioPath
 				content = ttsResult.Text // Use stripped text
 			} else if ttsResult != nil {
 				content = ttsResult.Text // Strip directives even if TTS not applied
@@ -364,7 +392,25 @@ func (m *ChatMethods) handleSend(ctx context.Context, client *gateway.Client, re
 		if len(mediaResults) > 0 {
 			resp["media"] = mediaResults
 		}
+		var transfers []chatMediaTransfer
+		if params.BinaryMedia {
+			for i, mr := range mediaResults {
+				path := mr.Path
+				if i == 0 && ttsAudio != nil {
+					path = ttsAudioPath // mediaResults holds the signed URL
+				}
+				transfers = append(transfers, chatMediaTransfer{ID: uuid.NewString(), MediaIndex: i, path: path, mimeType: mr.ContentType})
+			}
+			if len(transfers) > 0 {
+				resp["transfers"] = transfers
+			}
+		}
 		client.SendResponse(protocol.NewOKResponse(req.ID, resp))
+		for _, t := range transfers {
+			if err := client.SendAttachmentFile(t.ID, t.path, t.mimeType); err != nil {
+				slog.Warn("chat.send: binary media transfer failed", "runId", result.RunID, "path", t.path, "error", err)
+			}
+		}
 	}()
 }
 
@@ -23,12 +23,20 @@ type ConfigMethods struct {
 	secretsStore store.ConfigSecretsStore
 	syncFn       func(ctx context.Context, cfg *config.Config) // nil-safe; syncs non-secret settings to system_configs
 	eventBus     bus.EventPublisher       // nil-safe; broadcasts config change events
+	reloadFn     func(cfg *config.Config, trigger string) *config.ReloadReport // nil-safe; applies a saved config to the running gateway
 }
 
 func NewConfigMethods(cfg *config.Config, cfgPath string, secretsStore store.ConfigSecretsStore, eventBus bus.EventPublisher) *ConfigMethods {
 	return &ConfigMethods{cfg: cfg, cfgPath: cfgPath, secretsStore: secretsStore, eventBus: eventBus}
 }
 
+// SetReloadFunc sets the function that makes a saved config live in the
+// running gateway (providers, channels, agents) and reports what changed.
+// Without it the config is swapped in and config:changed is broadcast.
+func (m *ConfigMethods) SetReloadFunc(fn func(cfg *config.Config, trigger string) *config.ReloadReport) {
+	m.reloadFn = fn
+}
+
 // SetSystemConfigSync sets a callback to sync config to system_configs after save.
 // The callback receives the final resolved config (with secrets + env applied).
 func (m *ConfigMethods) SetSystemConfigSync(fn func(ctx context.Context, cfg *config.Config)) {
@@ -134,16 +142,7 @@ func (m *ConfigMethods) handleApply(ctx context.Context, client *gateway.Client,
 		return
 	}
 
-	// Update in-memory config and restore secrets
-	m.cfg.ReplaceFrom(newCfg)
-	if m.secretsStore != nil {
-		if secrets, err := m.secretsStore.GetAll(ctx); err == nil {
-			m.cfg.ApplyDBSecrets(secrets)
-		}
-	}
-	m.cfg.ApplyEnvOverrides()
-	m.syncToSystemConfigs(ctx)
-	m.broadcastChanged()
+	report := m.commit(ctx, newCfg, req.Method)
 	emitAudit(m.eventBus, client, "config.applied", "config", "gateway")
 
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
@@ -151,7 +150,8 @@ func (m *ConfigMethods) handleApply(ctx context.Context, client *gateway.Client,
 		"path":    m.cfgPath,
 		"config":  m.cfg.MaskedCopy(),
 		"hash":    m.cfg.Hash(),
-		"restart": false,
+		"restart": report != nil && len(report.RestartRequired) > 0,
+		"reload":  report,
 	}))
 }
 
@@ -192,6 +192,9 @@ func (m *ConfigMethods) handlePatch(ctx context.Context, client *gateway.Client,
 		return
 	}
 
+	// The live config holds resolved secret:// values; persist the references.
+	merged.RestoreSecretRefs(m.cfg.SecretRefs())
+
 	// Apply patch on top
 	if err := json5.Unmarshal([]byte(params.Raw), merged); err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, err.Error())))
@@ -208,16 +211,7 @@ func (m *ConfigMethods) handlePatch(ctx context.Context, client *gateway.Client,
 		return
 	}
 
-	// Update in-memory config and restore secrets
-	m.cfg.ReplaceFrom(merged)
-	if m.secretsStore != nil {
-		if secrets, err := m.secretsStore.GetAll(ctx); err == nil {
-			m.cfg.ApplyDBSecrets(secrets)
-		}
-	}
-	m.cfg.ApplyEnvOverrides()
-	m.syncToSystemConfigs(ctx)
-	m.broadcastChanged()
+	report := m.commit(ctx, merged, req.Method)
 	emitAudit(m.eventBus, client, "config.patched", "config", "gateway")
 
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
@@ -225,10 +219,39 @@ func (m *ConfigMethods) handlePatch(ctx context.Context, client *gateway.Client,
 		"path":    m.cfgPath,
 		"config":  m.cfg.MaskedCopy(),
 		"hash":    m.cfg.Hash(),
-		"restart": false,
+		"restart": report != nil && len(report.RestartRequired) > 0,
+		"reload":  report,
 	}))
 }
 
+// commit makes a saved config (secrets stripped) the live config with DB
+// secrets and env overrides restored. Returns the reload report, or nil when
+// no reload function is set.
+func (m *ConfigMethods) commit(ctx context.Context, cfg *config.Config, trigger string) *config.ReloadReport {
+	if m.reloadFn == nil {
+		m.cfg.ReplaceFrom(cfg)
+		if m.secretsStore != nil {
+			if secrets, err := m.secretsStore.GetAll(ctx); err == nil {
+				m.cfg.ApplyDBSecrets(secrets)
+			}
+		}
+		m.cfg.ApplyEnvOverrides()
+		m.syncToSystemConfigs(ctx)
+		m.broadcastChanged()
+		return nil
+	}
+
+	if m.secretsStore != nil {
+		if secrets, err := m.secretsStore.GetAll(ctx); err == nil {
+			cfg.ApplyDBSecrets(secrets)
+		}
+	}
+	cfg.ApplyEnvOverrides()
+	report := m.reloadFn(cfg, trigger)
+	m.syncToSystemConfigs(ctx)
+	return report
+}
+
 // syncToSystemConfigs syncs the resolved config to system_configs table for the given tenant.
 func (m *ConfigMethods) syncToSystemConfigs(ctx context.Context) {
 	if m.syncFn != nil {
@@ -243,41 +266,11 @@ func (m *ConfigMethods) broadcastChanged() {
 	}
 }
 
-// handleSchema returns the config JSON schema for UI form generation.
-// Matching TS config.schema (src/gateway/server-methods/config.ts:276-289).
+// handleSchema returns the config JSON schema for UI form generation and
+// editor completion (same schema as `goclaw config schema`).
 func (m *ConfigMethods) handleSchema(_ context.Context, client *gateway.Client, req *protocol.RequestFrame) {
-	schema := map[string]any{
-		"type": "object",
-		"properties": map[string]any{
-			"agents": map[string]any{
-				"type":        "object",
-				"description": "Agent configuration (defaults + per-agent overrides)",
-			},
-			"channels": map[string]any{
-				"type":        "object",
-				"description": "Channel configuration (telegram, discord, slack, etc.)",
-			},
-			"providers": map[string]any{
-				"type":        "object",
-				"description": "AI provider API keys and settings",
-			},
-			"gateway": map[string]any{
-				"type":        "object",
-				"description": "Gateway server settings (host, port, token)",
-			},
-			"tools": map[string]any{
-				"type":        "object",
-				"description": "Tool configuration (browser, exec, web search)",
-			},
-			"sessions": map[string]any{
-				"type":        "object",
-				"description": "Session storage configuration",
-			},
-		},
-	}
-
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
-		"json": schema,
+		"json": config.JSONSchema(),
 	}))
 }
 
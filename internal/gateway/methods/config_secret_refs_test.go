@@ -0,0 +1,84 @@
+package methods
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/gateway"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+type staticSecrets map[string]string
+
+func (s staticSecrets) Get(_ context.Context, key string) (string, error) {
+	if v, ok := s[key]; ok {
+		return v, nil
+	}
+	return "", secrets.ErrNotFound
+}
+
+// memSecretsStore is an in-memory config_secrets table.
+type memSecretsStore map[string]string
+
+func (s memSecretsStore) Get(_ context.Context, key string) (string, error) { return s[key], nil }
+func (s memSecretsStore) Set(_ context.Context, key, value string) error {
+	s[key] = value
+	return nil
+}
+func (s memSecretsStore) Delete(_ context.Context, key string) error {
+	delete(s, key)
+	return nil
+}
+func (s memSecretsStore) GetAll(context.Context) (map[string]string, error) { return s, nil }
+
+var _ store.ConfigSecretsStore = memSecretsStore{}
+
+func TestConfigPatch_KeepsSecretRefs(t *testing.T) {
+	secrets.Register("cfgtest", staticSecrets{"openai": "sk-live"})
+	t.Cleanup(secrets.ClearCache)
+
+	cfg := config.Default()
+	cfg.Providers.OpenAI.APIKey = "secret://cfgtest/openai"
+	cfg.Tools.Browser.CDPToken = "secret://cfgtest/missing"
+	cfg.ApplyDBSecrets(nil)
+	if cfg.Providers.OpenAI.APIKey != "sk-live" || cfg.Tools.Browser.CDPToken != "" {
+		t.Fatalf("refs not resolved: %q %q", cfg.Providers.OpenAI.APIKey, cfg.Tools.Browser.CDPToken)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	db := memSecretsStore{}
+	m := NewConfigMethods(cfg, path, db, nil)
+	client := gateway.NewTestClient(permissions.RoleOwner, store.MasterTenantID, "owner-1")
+	params, _ := json.Marshal(map[string]string{"raw": `{"gateway": {"port": 18801}}`})
+	m.handlePatch(wsCallCtx(client), client, &protocol.RequestFrame{
+		Type: protocol.FrameTypeRequest, ID: "patch-1", Method: protocol.MethodConfigPatch, Params: params,
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("config not saved: %v", err)
+	}
+	saved := string(data)
+	if strings.Contains(saved, "sk-live") || !strings.Contains(saved, "secret://cfgtest/openai") {
+		t.Errorf("saved config should keep the reference, not the secret:\n%s", saved)
+	}
+	if got := db["tools.browser.cdp_token"]; got != "secret://cfgtest/missing" {
+		t.Errorf("unresolved reference lost: config_secrets cdp_token = %q", got)
+	}
+	for k, v := range db {
+		if strings.Contains(v, "sk-live") {
+			t.Errorf("config_secrets %s holds the resolved secret", k)
+		}
+	}
+	if cfg.Gateway.Port != 18801 || cfg.Providers.OpenAI.APIKey != "sk-live" {
+		t.Errorf("live config = port %d, openai key %q", cfg.Gateway.Port, cfg.Providers.OpenAI.APIKey)
+	}
+}
@@ -106,6 +106,7 @@ func (s *stubCronStore) RunJob(_ context.Context, _ string, _ bool) (bool, strin
 	return true, "", nil
 }
 func (s *stubCronStore) SetDefaultTimezone(_ string)                             {}
+func (s *stubCronStore) SetMaxConcurrentRuns(_ int)                             {}
 func (s *stubCronStore) GetDueJobs(_ time.Time) []store.CronJob                 { return nil }
 
 // ---- helpers ----
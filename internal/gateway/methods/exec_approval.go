@@ -39,18 +39,24 @@ func (m *ExecApprovalMethods) handleList(_ context.Context, client *gateway.Clie
 
 	type pendingInfo struct {
 		ID        string `json:"id"`
+		Tool      string `json:"tool"`
 		Command   string `json:"command"`
 		AgentID   string `json:"agentId"`
+		Channel   string `json:"channel,omitempty"`
 		CreatedAt int64  `json:"createdAt"`
+		ExpiresAt int64  `json:"expiresAt"`
 	}
 
 	items := make([]pendingInfo, 0, len(pending))
 	for _, pa := range pending {
 		items = append(items, pendingInfo{
 			ID:        pa.ID,
+			Tool:      pa.Tool,
 			Command:   pa.Command,
 			AgentID:   pa.AgentID,
+			Channel:   pa.Channel,
 			CreatedAt: pa.CreatedAt.UnixMilli(),
+			ExpiresAt: pa.ExpiresAt.UnixMilli(),
 		})
 	}
 
@@ -84,7 +90,8 @@ func (m *ExecApprovalMethods) handleApprove(ctx context.Context, client *gateway
 		decision = tools.ApprovalAllowAlways
 	}
 
-	if err := m.manager.Resolve(params.ID, decision); err != nil {
+	// The manager records the decision (audit log + exec.approval.resolved).
+	if err := m.manager.ResolveBy(params.ID, decision, client.UserID()); err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, err.Error()))
 		return
 	}
@@ -93,7 +100,6 @@ func (m *ExecApprovalMethods) handleApprove(ctx context.Context, client *gateway
 		"resolved": true,
 		"decision": string(decision),
 	}))
-	emitAudit(m.eventBus, client, "exec.approved", "exec", params.ID)
 }
 
 func (m *ExecApprovalMethods) handleDeny(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
@@ -115,7 +121,7 @@ func (m *ExecApprovalMethods) handleDeny(ctx context.Context, client *gateway.Cl
 		return
 	}
 
-	if err := m.manager.Resolve(params.ID, tools.ApprovalDeny); err != nil {
+	if err := m.manager.ResolveBy(params.ID, tools.ApprovalDeny, client.UserID()); err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, err.Error()))
 		return
 	}
@@ -124,5 +130,4 @@ func (m *ExecApprovalMethods) handleDeny(ctx context.Context, client *gateway.Cl
 		"resolved": true,
 		"decision": "deny",
 	}))
-	emitAudit(m.eventBus, client, "exec.denied", "exec", params.ID)
 }
@@ -63,6 +63,7 @@ func (m *HeartbeatMethods) Register(router *gateway.MethodRouter) {
 	router.Register(protocol.MethodHeartbeatChecklistGet, m.handleChecklistGet)
 	router.Register(protocol.MethodHeartbeatChecklistSet, m.handleChecklistSet)
 	router.Register(protocol.MethodHeartbeatTargets, m.handleTargets)
+	router.Register(protocol.MethodHeartbeatSnooze, m.handleSnooze)
 }
 
 func (m *HeartbeatMethods) handleGet(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
@@ -346,11 +347,66 @@ func (m *HeartbeatMethods) handleLogs(ctx context.Context, client *gateway.Clien
 	}
 
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
-		"logs":  logs,
+		"logs":  store.HeartbeatHistory(logs),
 		"total": total,
 	}))
 }
 
+// handleSnooze holds heartbeat alerts back for N hours (0 = clear the snooze).
+// Runs still happen and are logged with status "snoozed".
+func (m *HeartbeatMethods) handleSnooze(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	locale := store.LocaleFromContext(ctx)
+	var params struct {
+		AgentID string  `json:"agentId"`
+		Hours   float64 `json:"hours"`
+	}
+	if req.Params != nil {
+		json.Unmarshal(req.Params, &params)
+	}
+	if params.AgentID == "" {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "agentId")))
+		return
+	}
+	if params.Hours < 0 || params.Hours > store.MaxHeartbeatSnoozeHours {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, fmt.Sprintf("hours must be between 0 and %d", store.MaxHeartbeatSnoozeHours)))
+		return
+	}
+
+	agentUUID, err := resolveAgentUUIDCached(ctx, m.agentRouter, m.agentStore, params.AgentID)
+	if err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, "invalid agentId"))
+		return
+	}
+
+	hb, err := m.hbStore.Get(ctx, agentUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, "heartbeat not configured"))
+			return
+		}
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, heartbeatInternalErr("snooze.load", err)))
+		return
+	}
+
+	var until *time.Time
+	if params.Hours > 0 {
+		t := time.Now().Add(time.Duration(params.Hours * float64(time.Hour)))
+		until = &t
+	}
+	hb.SetSnoozedUntil(until)
+	if err := m.hbStore.Upsert(ctx, hb); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, heartbeatInternalErr("snooze.save", err)))
+		return
+	}
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"agentId":      params.AgentID,
+		"snoozedUntil": until,
+	}))
+	m.emitCacheInvalidate(agentUUID.String())
+	emitAudit(m.eventBus, client, "heartbeat.snoozed", "heartbeat", agentUUID.String())
+}
+
 func (m *HeartbeatMethods) handleChecklistGet(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
 	locale := store.LocaleFromContext(ctx)
 	var params struct {
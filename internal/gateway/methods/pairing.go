@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
-	"regexp"
+	"slices"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/gateway"
@@ -13,12 +13,9 @@ import (
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
-var validSenderIDRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._:@-]*$`)
-
 // isValidSenderID checks that a sender ID contains only safe characters.
-// Prevents log injection and bus event poisoning.
 func isValidSenderID(id string) bool {
-	return len(id) <= 128 && validSenderIDRe.MatchString(id)
+	return store.IsValidPairingSenderID(id)
 }
 
 // PairingApproveCallback is called after a pairing is approved.
@@ -113,6 +110,7 @@ func (m *PairingMethods) handleApprove(ctx context.Context, client *gateway.Clie
 		params.ApprovedBy = "operator"
 	}
 
+	params.Code = store.NormalizePairingCode(params.Code)
 	paired, err := m.service.ApprovePairing(ctx, params.Code, params.ApprovedBy)
 	if err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, err.Error()))
@@ -149,6 +147,7 @@ func (m *PairingMethods) handleDeny(ctx context.Context, client *gateway.Client,
 		return
 	}
 
+	params.Code = store.NormalizePairingCode(params.Code)
 	if err := m.service.DenyPairing(ctx, params.Code); err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, err.Error()))
 		return
@@ -165,8 +164,19 @@ func (m *PairingMethods) handleDeny(ctx context.Context, client *gateway.Client,
 }
 
 func (m *PairingMethods) handleList(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	var params struct {
+		Channel string `json:"channel"` // optional filter
+	}
+	if req.Params != nil {
+		json.Unmarshal(req.Params, &params)
+	}
+
 	pending := m.service.ListPending(ctx)
 	paired := m.service.ListPaired(ctx)
+	if params.Channel != "" {
+		pending = slices.DeleteFunc(pending, func(p store.PairingRequestData) bool { return p.Channel != params.Channel })
+		paired = slices.DeleteFunc(paired, func(p store.PairedDeviceData) bool { return p.Channel != params.Channel })
+	}
 
 	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
 		"pending": pending,
@@ -3,6 +3,8 @@ package methods
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
@@ -13,17 +15,27 @@ import (
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
-// SessionsMethods handles sessions.list, sessions.preview, sessions.patch, sessions.delete, sessions.reset.
+// SessionsMethods handles sessions.list, sessions.preview, sessions.patch, sessions.archive,
+// sessions.delete, sessions.reset, sessions.compact, sessions.export, sessions.import,
+// sessions.checkpoints, sessions.rewind.
 type SessionsMethods struct {
 	sessions store.SessionStore
 	eventBus bus.EventPublisher
 	cfg      *config.Config
+	agents   store.AgentStore    // resolves the agent on import; nil disables sessions.import
+	episodic store.EpisodicStore // optional: episodic summaries travel with export/import
 }
 
 func NewSessionsMethods(sess store.SessionStore, eventBus bus.EventPublisher, cfg *config.Config) *SessionsMethods {
 	return &SessionsMethods{sessions: sess, eventBus: eventBus, cfg: cfg}
 }
 
+// SetBundleStores wires the stores used by sessions.export/sessions.import.
+func (m *SessionsMethods) SetBundleStores(agents store.AgentStore, episodic store.EpisodicStore) {
+	m.agents = agents
+	m.episodic = episodic
+}
+
 func (m *SessionsMethods) Register(router *gateway.MethodRouter) {
 	router.Register(protocol.MethodSessionsList, m.handleList)
 	router.Register(protocol.MethodSessionsPreview, m.handlePreview)
@@ -31,13 +43,20 @@ func (m *SessionsMethods) Register(router *gateway.MethodRouter) {
 	router.Register(protocol.MethodSessionsDelete, m.handleDelete)
 	router.Register(protocol.MethodSessionsReset, m.handleReset)
 	router.Register(protocol.MethodSessionsCompact, m.handleCompact)
+	router.Register(protocol.MethodSessionsArchive, m.handleArchive)
+	router.Register(protocol.MethodSessionsExport, m.handleExport)
+	router.Register(protocol.MethodSessionsImport, m.handleImport)
+	router.Register(protocol.MethodSessionsCheckpoints, m.handleCheckpoints)
+	router.Register(protocol.MethodSessionsRewind, m.handleRewind)
 }
 
 type sessionsListParams struct {
 	AgentID string `json:"agentId"`
 	Channel string `json:"channel"` // optional: filter by channel prefix ("ws", "telegram")
-	Limit   int    `json:"limit"`
-	Offset  int    `json:"offset"`
+	// Archived: "" (default) hides archived sessions, "only" lists just them, "all" lists both.
+	Archived string `json:"archived"`
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
 }
 
 func (m *SessionsMethods) handleList(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
@@ -53,6 +72,7 @@ func (m *SessionsMethods) handleList(ctx context.Context, client *gateway.Client
 	opts := store.SessionListOpts{
 		AgentID:  params.AgentID,
 		Channel:  params.Channel,
+		Archived: params.Archived,
 		Limit:    params.Limit,
 		Offset:   params.Offset,
 		TenantID: store.TenantIDFromContext(ctx),
@@ -113,6 +133,8 @@ func (m *SessionsMethods) handlePreview(ctx context.Context, client *gateway.Cli
 		"key":      params.Key,
 		"messages": history,
 		"summary":  summary,
+		"label":    m.sessions.GetLabel(ctx, params.Key),
+		"digest":   m.sessions.GetSessionMetadata(ctx, params.Key)[store.SessionMetaDigest],
 	}))
 }
 
@@ -193,6 +215,22 @@ func (m *SessionsMethods) handleDelete(ctx context.Context, client *gateway.Clie
 		}
 	}
 
+	// Stores that can cascade also drop traces, episodic memory and other
+	// per-session records; otherwise only the session row is removed.
+	if purger, ok := m.sessions.(store.SessionPurger); ok {
+		purged, err := purger.Purge(ctx, params.Key)
+		if err != nil {
+			client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, err.Error()))
+			return
+		}
+		client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+			"ok":     true,
+			"purged": purged,
+		}))
+		emitAudit(m.eventBus, client, "session.deleted", "session", params.Key)
+		return
+	}
+
 	if err := m.sessions.Delete(ctx, params.Key); err != nil {
 		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, err.Error()))
 		return
@@ -293,3 +331,216 @@ func (m *SessionsMethods) handleCompact(ctx context.Context, client *gateway.Cli
 	}))
 	emitAudit(m.eventBus, client, "session.compacted", "session", params.Key)
 }
+
+// handleArchive hides a session from sessions.list (or restores it with
+// archived=false). History is kept; archiving only stamps the archived_at
+// metadata key, so the session can still be previewed, resumed or deleted.
+func (m *SessionsMethods) handleArchive(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	locale := store.LocaleFromContext(ctx)
+	var params struct {
+		Key      string `json:"key"`
+		Archived *bool  `json:"archived,omitempty"` // default true
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidJSON)))
+		return
+	}
+	if params.Key == "" {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "key")))
+		return
+	}
+
+	sess := m.sessions.Get(ctx, params.Key)
+	if sess == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "session", params.Key)))
+		return
+	}
+	if !canSeeAll(client.Role(), m.cfg.Gateway.OwnerIDs, client.UserID()) && sess.UserID != client.UserID() {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session")))
+		return
+	}
+
+	archived := params.Archived == nil || *params.Archived
+	archivedAt := ""
+	if archived {
+		archivedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	m.sessions.SetSessionMetadata(ctx, params.Key, map[string]string{store.SessionMetaArchivedAt: archivedAt})
+	m.sessions.Save(ctx, params.Key)
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"ok":         true,
+		"key":        params.Key,
+		"archived":   archived,
+		"archivedAt": archivedAt,
+	}))
+	action := "session.archived"
+	if !archived {
+		action = "session.unarchived"
+	}
+	emitAudit(m.eventBus, client, action, "session", params.Key)
+}
+
+// handleExport returns the session as a portable store.SessionBundle.
+func (m *SessionsMethods) handleExport(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	locale := store.LocaleFromContext(ctx)
+	var params sessionKeyParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidJSON)))
+		return
+	}
+	if params.Key == "" {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "key")))
+		return
+	}
+
+	bundle, err := store.ExportSessionBundle(ctx, m.sessions, m.episodic, params.Key)
+	if err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInternal, err.Error()))
+		return
+	}
+	if bundle == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "session", params.Key)))
+		return
+	}
+	if !canSeeAll(client.Role(), m.cfg.Gateway.OwnerIDs, client.UserID()) && bundle.Session.UserID != client.UserID() {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session")))
+		return
+	}
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, bundle))
+	emitAudit(m.eventBus, client, "session.exported", "session", params.Key)
+}
+
+// handleImport writes a bundle produced by sessions.export (on this or another
+// gateway). Non-admin callers import as themselves, need access to the agent,
+// may only use their own DM key (other bundles get a new web-chat key) and
+// cannot overwrite sessions they don't own.
+func (m *SessionsMethods) handleImport(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	locale := store.LocaleFromContext(ctx)
+	if m.agents == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnavailable, "session import is not available"))
+		return
+	}
+	var params struct {
+		Bundle    *store.SessionBundle `json:"bundle"`
+		Key       string               `json:"key,omitempty"`
+		Overwrite bool                 `json:"overwrite,omitempty"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidJSON)))
+		return
+	}
+	if params.Bundle == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "bundle")))
+		return
+	}
+
+	opts := store.SessionImportOpts{Key: params.Key, Overwrite: params.Overwrite}
+	if !canSeeAll(client.Role(), m.cfg.Gateway.OwnerIDs, client.UserID()) {
+		// Non-admins import as themselves; ImportSessionBundle enforces the rest.
+		opts.UserID = client.UserID()
+		if opts.UserID == "" {
+			client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session")))
+			return
+		}
+	}
+
+	res, err := store.ImportSessionBundle(ctx, m.sessions, m.agents, m.episodic, params.Bundle, opts)
+	if err != nil {
+		code := protocol.ErrInternal
+		switch {
+		case errors.Is(err, store.ErrSessionBundleInvalid):
+			code = protocol.ErrInvalidRequest
+		case errors.Is(err, store.ErrSessionExists):
+			code = protocol.ErrAlreadyExists
+		case errors.Is(err, store.ErrSessionAgentNotFound):
+			code = protocol.ErrNotFound
+		case errors.Is(err, store.ErrSessionImportDenied):
+			code = protocol.ErrUnauthorized
+		}
+		client.SendResponse(protocol.NewErrorResponse(req.ID, code, err.Error()))
+		return
+	}
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, res))
+	emitAudit(m.eventBus, client, "session.imported", "session", res.Key)
+}
+
+// authorizeSession loads the session and checks the caller may act on it.
+// Sends the error response and returns false otherwise.
+func (m *SessionsMethods) authorizeSession(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame, key string) bool {
+	locale := store.LocaleFromContext(ctx)
+	if key == "" {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "key")))
+		return false
+	}
+	sess := m.sessions.Get(ctx, key)
+	if sess == nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "session", key)))
+		return false
+	}
+	if !canSeeAll(client.Role(), m.cfg.Gateway.OwnerIDs, client.UserID()) && sess.UserID != client.UserID() {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session")))
+		return false
+	}
+	return true
+}
+
+// handleCheckpoints lists the session's rewind checkpoints, oldest first.
+func (m *SessionsMethods) handleCheckpoints(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	var params sessionKeyParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(store.LocaleFromContext(ctx), i18n.MsgInvalidJSON)))
+		return
+	}
+	if !m.authorizeSession(ctx, client, req, params.Key) {
+		return
+	}
+	cps := store.ListSessionCheckpoints(ctx, m.sessions, params.Key)
+	if cps == nil {
+		cps = []store.SessionCheckpoint{}
+	}
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"key":         params.Key,
+		"checkpoints": cps,
+	}))
+}
+
+// handleRewind rolls the session back to a checkpoint, dropping later
+// messages. Files written after it are reported but not reverted.
+func (m *SessionsMethods) handleRewind(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
+	var params struct {
+		Key        string `json:"key"`
+		Checkpoint int    `json:"checkpoint"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrInvalidRequest, i18n.T(store.LocaleFromContext(ctx), i18n.MsgInvalidJSON)))
+		return
+	}
+	if !m.authorizeSession(ctx, client, req, params.Key) {
+		return
+	}
+
+	res, err := store.RewindSession(ctx, m.sessions, params.Key, params.Checkpoint)
+	if err != nil {
+		code := protocol.ErrInternal
+		switch {
+		case errors.Is(err, store.ErrCheckpointNotFound):
+			code = protocol.ErrNotFound
+		case errors.Is(err, store.ErrCheckpointStale):
+			code = protocol.ErrInvalidRequest
+		}
+		client.SendResponse(protocol.NewErrorResponse(req.ID, code, err.Error()))
+		return
+	}
+
+	client.SendResponse(protocol.NewOKResponse(req.ID, map[string]any{
+		"ok":         true,
+		"key":        params.Key,
+		"checkpoint": res.Checkpoint,
+		"removed":    res.Removed,
+		"files":      res.Files,
+	}))
+	emitAudit(m.eventBus, client, "session.rewound", "session", params.Key)
+}
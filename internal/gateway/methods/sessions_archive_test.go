@@ -0,0 +1,93 @@
+package methods
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/gateway"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// metaSessionStore records SetSessionMetadata calls.
+type metaSessionStore struct {
+	*stubSessionStore
+	meta map[string]map[string]string
+}
+
+func (s *metaSessionStore) SetSessionMetadata(_ context.Context, key string, md map[string]string) {
+	if s.meta[key] == nil {
+		s.meta[key] = map[string]string{}
+	}
+	for k, v := range md {
+		s.meta[key][k] = v
+	}
+}
+
+// purgingSessionStore implements store.SessionPurger.
+type purgingSessionStore struct {
+	*stubSessionStore
+	purged []string
+}
+
+func (s *purgingSessionStore) Purge(_ context.Context, key string) (store.SessionPurgeResult, error) {
+	s.purged = append(s.purged, key)
+	delete(s.sessions, key)
+	return store.SessionPurgeResult{Traces: 2, Spans: 7}, nil
+}
+
+func newMetaSessionStore() *metaSessionStore {
+	return &metaSessionStore{stubSessionStore: newStubSessionStore(), meta: map[string]map[string]string{}}
+}
+
+func TestSessionsArchive_OwnerArchivesAndRestores(t *testing.T) {
+	sess := newMetaSessionStore()
+	sess.addSession("agent:a:ws:direct:u1", "u1")
+	m := NewSessionsMethods(sess, &stubEventPub{}, &config.Config{})
+	client := gateway.NewTestClient(permissions.RoleOperator, uuid.Nil, "u1")
+
+	m.handleArchive(wsCallCtx(client), client, sessionReqFrame(t, protocol.MethodSessionsArchive,
+		map[string]any{"key": "agent:a:ws:direct:u1"}))
+	if got := sess.meta["agent:a:ws:direct:u1"][store.SessionMetaArchivedAt]; got == "" {
+		t.Fatal("archive should stamp archived_at")
+	}
+
+	m.handleArchive(wsCallCtx(client), client, sessionReqFrame(t, protocol.MethodSessionsArchive,
+		map[string]any{"key": "agent:a:ws:direct:u1", "archived": false}))
+	if got, ok := sess.meta["agent:a:ws:direct:u1"][store.SessionMetaArchivedAt]; !ok || got != "" {
+		t.Fatalf("unarchive should clear archived_at, got %q (present=%v)", got, ok)
+	}
+}
+
+func TestSessionsArchive_OtherUsersSessionDenied(t *testing.T) {
+	sess := newMetaSessionStore()
+	sess.addSession("agent:a:ws:direct:u2", "u2")
+	m := NewSessionsMethods(sess, &stubEventPub{}, &config.Config{})
+	client := gateway.NewTestClient(permissions.RoleOperator, uuid.Nil, "u1")
+
+	m.handleArchive(wsCallCtx(client), client, sessionReqFrame(t, protocol.MethodSessionsArchive,
+		map[string]any{"key": "agent:a:ws:direct:u2"}))
+	if len(sess.meta) != 0 {
+		t.Fatalf("non-owner must not archive another user's session: %v", sess.meta)
+	}
+}
+
+func TestSessionsDelete_UsesPurgerWhenAvailable(t *testing.T) {
+	sess := &purgingSessionStore{stubSessionStore: newStubSessionStore()}
+	sess.addSession("agent:a:ws:direct:u1", "u1")
+	m := NewSessionsMethods(sess, &stubEventPub{}, &config.Config{})
+	client := gateway.NewTestClient(permissions.RoleAdmin, uuid.Nil, "admin")
+
+	m.handleDelete(wsCallCtx(client), client, sessionReqFrame(t, protocol.MethodSessionsDelete,
+		map[string]any{"key": "agent:a:ws:direct:u1"}))
+	if len(sess.purged) != 1 || sess.purged[0] != "agent:a:ws:direct:u1" {
+		t.Fatalf("expected Purge to be called once, got %v", sess.purged)
+	}
+	if len(sess.deleted) != 0 {
+		t.Fatalf("plain Delete should not run when the store can purge, got %v", sess.deleted)
+	}
+}
@@ -53,8 +53,9 @@ func (m *UsageMethods) handleGet(ctx context.Context, client *gateway.Client, re
 	// Use ListPagedRich: single query returns model, provider, tokens — no N+1 GetOrCreate loop.
 	// Fetch large batch to filter non-zero tokens, then paginate in-memory.
 	result := m.sessions.ListPagedRich(ctx, store.SessionListOpts{
-		AgentID: params.AgentID,
-		Limit:   10000,
+		AgentID:  params.AgentID,
+		Archived: store.SessionArchivedInclude,
+		Limit:    10000,
 	})
 
 	records := make([]UsageRecord, 0, len(result.Sessions))
@@ -97,7 +98,7 @@ func (m *UsageMethods) handleGet(ctx context.Context, client *gateway.Client, re
 
 func (m *UsageMethods) handleSummary(ctx context.Context, client *gateway.Client, req *protocol.RequestFrame) {
 	// Use ListPagedRich: single query returns all token data — no N+1 GetOrCreate loop.
-	result := m.sessions.ListPagedRich(ctx, store.SessionListOpts{Limit: 10000})
+	result := m.sessions.ListPagedRich(ctx, store.SessionListOpts{Archived: store.SessionArchivedInclude, Limit: 10000})
 
 	type agentSummary struct {
 		InputTokens  int64 `json:"inputTokens"`
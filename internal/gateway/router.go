@@ -5,7 +5,9 @@ import (
 	"crypto/subtle"
 	"encoding/json"
 	"log/slog"
+	"runtime"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +16,8 @@ import (
 	httpapi "github.com/nextlevelbuilder/goclaw/internal/http"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
@@ -51,6 +55,12 @@ func (r *MethodRouter) Register(method string, handler MethodHandler) {
 
 // Handle dispatches a request to the appropriate handler.
 func (r *MethodRouter) Handle(ctx context.Context, client *Client, req *protocol.RequestFrame) {
+	// Each WS request gets its own correlation ID (the upgrade request's ID
+	// would otherwise be shared by every request on the connection).
+	reqID := requestid.New()
+	ctx = requestid.With(ctx, reqID)
+	client.trackRequestID(req.ID, reqID)
+
 	handler, ok := r.handlers[req.Method]
 	if !ok {
 		slog.Warn("unknown method", "method", req.Method, "client", client.id)
@@ -89,6 +99,28 @@ func (r *MethodRouter) Handle(ctx context.Context, client *Client, req *protocol
 				))
 				return
 			}
+			// Scoped credentials (API keys, static scoped tokens) must also
+			// hold one of the method's scopes — the role alone is too coarse
+			// for e.g. a chat-only token.
+			if len(client.scopes) > 0 && !pe.CanAccessWithScopes(client.scopes, req.Method) {
+				required := permissions.MethodScopes(req.Method)
+				names := make([]string, len(required))
+				for i, s := range required {
+					names[i] = string(s)
+				}
+				slog.Warn("security.scope_denied",
+					"method", req.Method,
+					"scopes", client.scopes,
+					"client", client.id,
+				)
+				locale := i18n.Normalize(client.locale)
+				client.SendResponse(protocol.NewErrorResponse(
+					req.ID,
+					protocol.ErrUnauthorized,
+					i18n.T(locale, i18n.MsgPermissionDenied, req.Method+" requires one of scopes "+strings.Join(names, ", ")),
+				))
+				return
+			}
 		}
 	}
 
@@ -108,7 +140,7 @@ func (r *MethodRouter) Handle(ctx context.Context, client *Client, req *protocol
 		ctx = store.WithRole(ctx, string(role))
 	}
 
-	slog.Debug("handling method", "method", req.Method, "client", client.id, "req_id", req.ID)
+	slog.DebugContext(ctx, "handling method", "method", req.Method, "client", client.id, "req_id", req.ID)
 	handler(ctx, client, req)
 }
 
@@ -142,6 +174,23 @@ func (r *MethodRouter) handleConnect(ctx context.Context, client *Client, req *p
 
 	configToken := r.server.cfg.Gateway.Token
 
+	// Route policy for /ws may restrict connects to the gateway token (or an
+	// admin API key); pairing and lower-role keys are refused outright.
+	if client.routeAuth != "" && !credentialSatisfies(ctx, configToken, params.Token, client.routeAuth) {
+		slog.Warn("security.ws_connect_rejected",
+			"reason", "route_policy",
+			"required", client.routeAuth,
+			"client", client.id,
+		)
+		locale := i18n.Normalize(client.locale)
+		client.SendResponse(protocol.NewErrorResponse(
+			req.ID,
+			protocol.ErrUnauthorized,
+			i18n.T(locale, i18n.MsgPermissionDenied, client.routeAuth+" credentials required"),
+		))
+		return
+	}
+
 	// Path 1: Valid gateway token → admin (constant-time comparison)
 	if configToken != "" && subtle.ConstantTimeCompare([]byte(params.Token), []byte(configToken)) == 1 {
 		client.role = permissions.RoleAdmin
@@ -181,6 +230,24 @@ func (r *MethodRouter) handleConnect(ctx context.Context, client *Client, req *p
 		return
 	}
 
+	// Path 1a: Admin UI session cookie (HttpOnly, set by POST /v1/auth/login).
+	if params.Token == "" && client.uiSession != nil {
+		client.role = client.uiSession.Role
+		client.authenticated = true
+		client.userID = client.uiSession.UserID
+		client.tenantID = client.uiSession.TenantID
+		if client.tenantID == uuid.Nil {
+			client.tenantID = store.MasterTenantID
+		}
+		slog.Debug("security.ws_connect_resolved",
+			"client", client.id,
+			"role", string(client.role),
+			"session", client.uiSession.ID,
+		)
+		r.sendConnectResponse(ctx, client, req.ID)
+		return
+	}
+
 	// Path 1b: API key → role derived from scopes (uses shared cache)
 	if params.Token != "" {
 		if keyData, role := httpapi.ResolveAPIKey(ctx, params.Token); keyData != nil {
@@ -232,8 +299,36 @@ func (r *MethodRouter) handleConnect(ctx context.Context, client *Client, req *p
 		}
 	}
 
-	// Path 2: No token configured → operator (backward compat)
-	if configToken == "" {
+	// Path 1c: gateway-issued OIDC token (POST /v1/auth/oidc/exchange) → its
+	// role, capped by the user's tenant_users role.
+	if params.Token != "" {
+		hint := params.TenantID
+		if hint == "" {
+			hint = params.TenantHint
+		}
+		if id, ok := httpapi.ResolveOIDCToken(ctx, params.Token, hint); ok {
+			if id.Denied {
+				client.SendResponse(protocol.NewErrorResponse(req.ID, protocol.ErrTenantAccessRevoked, "tenant access revoked"))
+				return
+			}
+			client.role = id.Role
+			client.authenticated = true
+			client.userID = id.UserID
+			client.tenantID = id.TenantID
+			slog.Debug("security.ws_connect_resolved",
+				"client", client.id,
+				"role", string(client.role),
+				"user", client.userID,
+				"tenant_id", client.tenantID.String(),
+			)
+			r.sendConnectResponse(ctx, client, req.ID)
+			return
+		}
+	}
+
+	// Path 2: No token configured → operator (backward compat). With OIDC
+	// login enabled, callers must authenticate instead.
+	if configToken == "" && !httpapi.OIDCEnabled() {
 		client.role = permissions.RoleOperator
 		client.authenticated = true
 		client.userID = params.UserID
@@ -492,6 +587,13 @@ func (r *MethodRouter) handleHealth(ctx context.Context, client *Client, req *pr
 		"tools":     toolCount,
 		"clients":   clientList,
 		"currentId": client.ID(),
+		"memory":    memoryStats(),
+	}
+	if n := safego.PanicCount(); n > 0 {
+		resp["panics"] = map[string]any{"total": n, "byComponent": safego.Stats()}
+	}
+	if s.laneStats != nil {
+		resp["lanes"] = s.laneStats()
 	}
 	if s.updateChecker != nil {
 		if info := s.updateChecker.Info(); info != nil {
@@ -504,6 +606,18 @@ func (r *MethodRouter) handleHealth(ctx context.Context, client *Client, req *pr
 	client.SendResponse(protocol.NewOKResponse(req.ID, resp))
 }
 
+// memoryStats returns a snapshot of Go runtime memory usage for the health RPC.
+func memoryStats() map[string]any {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return map[string]any{
+		"heapAllocBytes": ms.HeapAlloc,
+		"sysBytes":       ms.Sys,
+		"numGC":          ms.NumGC,
+		"goroutines":     runtime.NumGoroutine(),
+	}
+}
+
 func (r *MethodRouter) handleStatus(ctx context.Context, client *Client, req *protocol.RequestFrame) {
 	agents := r.server.agents.ListInfo()
 
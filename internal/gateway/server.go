@@ -2,7 +2,7 @@ package gateway
 
 import (
 	"context"
-	"crypto/subtle"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,6 +25,8 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/webui"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
+	"github.com/nextlevelbuilder/goclaw/internal/scheduler"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
@@ -63,12 +66,19 @@ type Server struct {
 	version        string
 	db             interface{ PingContext(context.Context) error } // for health check DB ping
 	updateChecker  *UpdateChecker
+	laneStats      func() []scheduler.LaneStats // optional; scheduler lane utilization for health
+	backpressure   httpapi.BackpressureFunc     // optional; rejects HTTP agent runs with 429 when lanes are saturated
+	channelHealth  func() (bool, any)           // optional; per-channel readiness for /readyz
 
 	logTee   *LogTee                  // optional; auto-unsubscribes clients on disconnect
 	postTurn tools.PostTurnProcessor // optional; for team task dispatch in HTTP API paths
 
 	httpServer *http.Server
 	mux        *http.ServeMux
+
+	listener         net.Listener // main gateway listener (set by Start)
+	listenerMu       sync.Mutex
+	listenerReleased atomic.Bool // ReleaseListener called: Serve returning is expected
 }
 
 // SetPostTurnProcessor sets the post-turn processor for team task dispatch in HTTP API handlers.
@@ -91,6 +101,8 @@ func NewServer(cfg *config.Config, eventPub bus.EventPublisher, agents *agent.Ro
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 		CheckOrigin:     s.checkOrigin,
+		// permessage-deflate, used only when the client offers it (default on).
+		EnableCompression: cfg.Gateway.WSCompression == nil || *cfg.Gateway.WSCompression,
 	}
 
 	if len(toolsReg) > 0 && toolsReg[0] != nil {
@@ -145,6 +157,7 @@ func (s *Server) BuildMux() *http.ServeMux {
 
 	// HTTP API endpoints
 	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/readyz", s.handleReadyz)
 
 	// OpenAI-compatible chat completions
 	isManaged := s.agentStore != nil
@@ -155,6 +168,7 @@ func (s *Server) BuildMux() *http.ServeMux {
 	if s.postTurn != nil {
 		chatHandler.SetPostTurnProcessor(s.postTurn)
 	}
+	chatHandler.SetBackpressure(s.checkBackpressure)
 	mux.Handle("/v1/chat/completions", chatHandler)
 
 	// OpenResponses protocol
@@ -162,12 +176,14 @@ func (s *Server) BuildMux() *http.ServeMux {
 	if s.postTurn != nil {
 		responsesHandler.SetPostTurnProcessor(s.postTurn)
 	}
+	responsesHandler.SetBackpressure(s.checkBackpressure)
 	mux.Handle("/v1/responses", responsesHandler)
 
-	// Direct tool invocation
+	// Direct tool invocation and listing
 	if s.tools != nil {
 		toolsHandler := httpapi.NewToolsInvokeHandler(s.tools, s.agentStore)
 		mux.Handle("/v1/tools/invoke", toolsHandler)
+		mux.Handle("GET /v1/tools", httpapi.NewToolsListHandler(s.tools, s.agents))
 	}
 
 	// Register all HTTP API handlers (agents, skills, teams, storage, etc.)
@@ -302,27 +318,35 @@ func bridgeContextMiddleware(gatewayToken string, agentStore store.AgentStore, n
 	})
 }
 
-// tokenAuthMiddleware wraps an http.Handler with Bearer token authentication.
+// tokenAuthMiddleware wraps an http.Handler with gateway-token-only Bearer auth.
 func tokenAuthMiddleware(token string, next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		auth := r.Header.Get("Authorization")
-		provided := strings.TrimPrefix(auth, "Bearer ")
-		if !strings.HasPrefix(auth, "Bearer ") || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
-			http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+	return (&accessControl{token: token}).require(routeAuthToken, next)
 }
 
 // Start begins listening for WebSocket and HTTP connections.
 func (s *Server) Start(ctx context.Context) error {
+	if missing := s.cfg.Gateway.TLS.MissingKeyPairField(); missing != "" {
+		return fmt.Errorf("gateway server: gateway.tls.%s is not set; cert_file and key_file must be set together", missing)
+	}
+	if ignored := s.cfg.Gateway.TLS.IgnoredFields(); len(ignored) > 0 {
+		return fmt.Errorf("gateway server: gateway.tls.%s is set but TLS is off; set cert_file and key_file, or acme_domains", ignored[0])
+	}
+
 	mux := s.BuildMux()
 
-	// Wrap with CORS for desktop dev mode (Wails serves frontend on different port).
 	var handler http.Handler = mux
+	ac, err := newAccessControl(s.cfg.Gateway)
+	if err != nil {
+		return fmt.Errorf("gateway server: %w", err)
+	}
+	if ac != nil {
+		handler = ac.middleware(mux)
+	}
+	handler = requestIDMiddleware(handler)
+
+	// Wrap with CORS for desktop dev mode (Wails serves frontend on different port).
 	if os.Getenv("GOCLAW_DESKTOP") == "1" {
-		handler = desktopCORS(mux)
+		handler = desktopCORS(handler)
 	}
 
 	addr := fmt.Sprintf("%s:%d", s.cfg.Gateway.Host, s.cfg.Gateway.Port)
@@ -330,20 +354,93 @@ func (s *Server) Start(ctx context.Context) error {
 		Addr:    addr,
 		Handler: handler,
 	}
+	ln, err := listenGateway(ctx, addr, s.cfg.Gateway.ReusePort)
+	if err != nil {
+		return fmt.Errorf("gateway server: %w", err)
+	}
+	s.listenerMu.Lock()
+	s.listener = ln
+	s.listenerMu.Unlock()
 
-	slog.Info("gateway starting", "addr", addr)
+	tlsOpts := s.cfg.Gateway.TLS
+	if !tlsOpts.Enabled() {
+		slog.Info("gateway starting", "addr", addr, "reuse_port", s.cfg.Gateway.ReusePort)
+		go s.shutdownOnDone(ctx, s.httpServer)
 
-	go func() {
-		<-ctx.Done()
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		s.httpServer.Shutdown(shutdownCtx)
-	}()
+		return s.serveResult(ctx, s.httpServer.Serve(ln))
+	}
 
-	if err := s.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+	tlsCfg, acmeMgr, err := buildTLSConfig(tlsOpts, s.cfg.ResolvedDataDir())
+	if err != nil {
 		return fmt.Errorf("gateway server: %w", err)
 	}
-	return nil
+	s.httpServer.TLSConfig = tlsCfg
+	if tlsOpts.ClientAuth == "optional" {
+		s.httpServer.Handler = clientCertMiddleware(tlsOpts.ClientCertPaths, handler)
+	}
+	slog.Info("gateway starting", "addr", addr, "tls", true, "acme", acmeMgr != nil, "client_auth", tlsOpts.ClientAuth)
+	go s.shutdownOnDone(ctx, s.httpServer)
+
+	// ACME HTTP-01 challenges + redirect to HTTPS (TLS-ALPN-01 works without it).
+	if acmeMgr != nil && tlsOpts.ACMEHTTPAddr != "" {
+		s.serveAux(ctx, "acme", tlsOpts.ACMEHTTPAddr, acmeMgr.HTTPHandler(nil))
+	}
+	// Plain HTTP on loopback for local CLI and MCP bridge clients.
+	if tlsOpts.LoopbackPort > 0 {
+		s.serveAux(ctx, "loopback", fmt.Sprintf("127.0.0.1:%d", tlsOpts.LoopbackPort), handler)
+	}
+
+	return s.serveResult(ctx, s.httpServer.ServeTLS(ln, "", ""))
+}
+
+// serveResult maps the error returned by Serve. After ReleaseListener, Serve
+// returns early while clients are still being drained, so Start keeps
+// blocking until shutdown.
+func (s *Server) serveResult(ctx context.Context, err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	if s.listenerReleased.Load() {
+		<-ctx.Done()
+		return nil
+	}
+	return fmt.Errorf("gateway server: %w", err)
+}
+
+// ReleaseListener stops accepting connections on the main listener so a
+// replacement process bound to the same port (gateway.reuse_port) receives
+// all new traffic. Established connections stay open until shutdown.
+func (s *Server) ReleaseListener() {
+	s.listenerMu.Lock()
+	defer s.listenerMu.Unlock()
+	if s.listener == nil || !s.listenerReleased.CompareAndSwap(false, true) {
+		return
+	}
+	if err := s.listener.Close(); err != nil {
+		slog.Warn("gateway: release listener failed", "error", err)
+		return
+	}
+	slog.Info("gateway: listener released for replacement process")
+}
+
+// shutdownOnDone gracefully stops srv once ctx is cancelled.
+func (s *Server) shutdownOnDone(ctx context.Context, srv *http.Server) {
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.Shutdown(shutdownCtx)
+}
+
+// serveAux runs a secondary plain-HTTP listener alongside the TLS gateway.
+func (s *Server) serveAux(ctx context.Context, name, addr string, h http.Handler) {
+	srv := &http.Server{Addr: addr, Handler: h}
+	go s.shutdownOnDone(ctx, srv)
+	go func() {
+		slog.Info("gateway auxiliary listener starting", "name", name, "addr", addr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("gateway auxiliary listener failed", "name", name, "addr", addr, "error", err)
+		}
+	}()
 }
 
 // handleWebSocket upgrades HTTP to WebSocket and manages the connection.
@@ -355,6 +452,10 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 
 	client := NewClient(conn, s, clientIP(r))
+	client.routeAuth = routeAuthFrom(r.Context())
+	if sess, ok := httpapi.ResolveUISession(r); ok {
+		client.uiSession = sess
+	}
 	s.registerClient(client)
 
 	defer func() {
@@ -372,6 +473,41 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, `{"status":"ok","protocol":%d}`, protocol.ProtocolVersion)
 }
 
+// handleReadyz reports readiness with per-channel health. The database is the
+// only hard dependency (503 when unreachable); failed channels mark the
+// gateway "degraded" but keep it in rotation, since one bad bot token should
+// not take the whole gateway out of a load balancer.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	status, code := "ready", http.StatusOK
+	resp := map[string]any{"protocol": protocol.ProtocolVersion}
+
+	if s.db != nil {
+		pingCtx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+		if err := s.db.PingContext(pingCtx); err != nil {
+			resp["database"] = "error"
+			status, code = "not_ready", http.StatusServiceUnavailable
+		} else {
+			resp["database"] = "ok"
+		}
+	}
+	if s.agents != nil && s.agents.IsDraining() {
+		status, code = "draining", http.StatusServiceUnavailable
+	}
+	if s.channelHealth != nil {
+		ok, detail := s.channelHealth()
+		resp["channels"] = detail
+		if !ok && code == http.StatusOK {
+			status = "degraded"
+		}
+	}
+	resp["status"] = status
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
 // clientIP extracts the real client IP from the request, checking proxy headers first.
 func clientIP(r *http.Request) string {
 	if ip := r.Header.Get("X-Real-IP"); ip != "" {
@@ -472,6 +608,21 @@ func (s *Server) SetAPIKeysHandler(h *httpapi.APIKeysHandler) {
 	s.handlers = append(s.handlers, h)
 }
 
+// SetUISessionsHandler sets the admin UI cookie session handler.
+func (s *Server) SetUISessionsHandler(h *httpapi.UISessionsHandler) {
+	s.handlers = append(s.handlers, h)
+}
+
+// SetOIDCHandler sets the OpenID Connect login handler.
+func (s *Server) SetOIDCHandler(h *httpapi.OIDCHandler) {
+	s.handlers = append(s.handlers, h)
+}
+
+// SetPairingHandler sets the channel pairing management handler.
+func (s *Server) SetPairingHandler(h *httpapi.PairingHandler) {
+	s.handlers = append(s.handlers, h)
+}
+
 // SetTenantsHandler sets the tenant management handler.
 func (s *Server) SetTenantsHandler(h *httpapi.TenantsHandler) {
 	s.handlers = append(s.handlers, h)
@@ -496,6 +647,18 @@ func (s *Server) SetMediaServeHandler(h *httpapi.MediaServeHandler) {
 	s.handlers = append(s.handlers, h)
 }
 
+// SetCronHandler sets the cron job management handler.
+func (s *Server) SetCronHandler(h *httpapi.CronHandler) { s.handlers = append(s.handlers, h) }
+
+// SetAgentStatusHandler sets the agent status card handler.
+func (s *Server) SetAgentStatusHandler(h *httpapi.AgentStatusHandler) { s.handlers = append(s.handlers, h) }
+
+// SetHeartbeatHandler sets the heartbeat history/snooze handler.
+func (s *Server) SetHeartbeatHandler(h *httpapi.HeartbeatHandler) { s.handlers = append(s.handlers, h) }
+
+// SetSyncHandler sets the remote sync snapshot handler.
+func (s *Server) SetSyncHandler(h *httpapi.SyncHandler) { s.handlers = append(s.handlers, h) }
+
 // SetMemoryHandler sets the memory management handler.
 func (s *Server) SetMemoryHandler(h *httpapi.MemoryHandler) { s.handlers = append(s.handlers, h) }
 
@@ -542,6 +705,9 @@ func (s *Server) SetActivityHandler(h *httpapi.ActivityHandler) {
 	s.handlers = append(s.handlers, h)
 }
 
+// SetSessionsHandler sets the session export/import handler.
+func (s *Server) SetSessionsHandler(h *httpapi.SessionsHandler) { s.handlers = append(s.handlers, h) }
+
 // SetSystemConfigsHandler sets the system configs handler.
 func (s *Server) SetSystemConfigsHandler(h *httpapi.SystemConfigsHandler) {
 	s.handlers = append(s.handlers, h)
@@ -550,6 +716,9 @@ func (s *Server) SetSystemConfigsHandler(h *httpapi.SystemConfigsHandler) {
 // SetUsageHandler sets the usage analytics handler.
 func (s *Server) SetUsageHandler(h *httpapi.UsageHandler) { s.handlers = append(s.handlers, h) }
 
+// SetBillingHandler sets the monthly billing export handler.
+func (s *Server) SetBillingHandler(h *httpapi.BillingHandler) { s.handlers = append(s.handlers, h) }
+
 // SetBackupHandler sets the system backup handler.
 func (s *Server) SetBackupHandler(h *httpapi.BackupHandler) { s.handlers = append(s.handlers, h) }
 
@@ -582,6 +751,30 @@ func (s *Server) SetVersion(v string) { s.version = v }
 // SetDB sets the database connection for health check pings.
 func (s *Server) SetDB(db interface{ PingContext(context.Context) error }) { s.db = db }
 
+// SetLaneStats sets the scheduler lane stats source reported by the health RPC.
+func (s *Server) SetLaneStats(fn func() []scheduler.LaneStats) { s.laneStats = fn }
+
+// SetBackpressure sets the lane saturation check used by the OpenAI-compatible
+// endpoints. The scheduler is created after the mux, so handlers read it per request.
+func (s *Server) SetBackpressure(fn httpapi.BackpressureFunc) { s.backpressure = fn }
+
+// drainRetryAfter is the Retry-After hint for HTTP runs rejected while draining.
+const drainRetryAfter = 5 * time.Second
+
+func (s *Server) checkBackpressure() (bool, time.Duration) {
+	if s.agents != nil && s.agents.IsDraining() {
+		return true, drainRetryAfter
+	}
+	if s.backpressure == nil {
+		return false, 0
+	}
+	return s.backpressure()
+}
+
+// SetChannelHealth sets the per-channel readiness source reported by /readyz.
+// /readyz needs no auth, so the detail must not carry error text.
+func (s *Server) SetChannelHealth(fn func() (bool, any)) { s.channelHealth = fn }
+
 // StartedAt returns the server start time.
 func (s *Server) StartedAt() time.Time { return s.startedAt }
 
@@ -680,17 +873,20 @@ func StartTestServer(s *Server, ctx context.Context) (addr string, start func())
 	if s.postTurn != nil {
 		chatHandler.SetPostTurnProcessor(s.postTurn)
 	}
+	chatHandler.SetBackpressure(s.checkBackpressure)
 	mux.Handle("/v1/chat/completions", chatHandler)
 
 	responsesHandler := httpapi.NewResponsesHandler(s.agents, s.sessions)
 	if s.postTurn != nil {
 		responsesHandler.SetPostTurnProcessor(s.postTurn)
 	}
+	responsesHandler.SetBackpressure(s.checkBackpressure)
 	mux.Handle("/v1/responses", responsesHandler)
 
 	if s.tools != nil {
 		toolsHandler := httpapi.NewToolsInvokeHandler(s.tools, s.agentStore)
 		mux.Handle("/v1/tools/invoke", toolsHandler)
+		mux.Handle("GET /v1/tools", httpapi.NewToolsListHandler(s.tools, s.agents))
 	}
 
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
@@ -716,11 +912,26 @@ func StartTestServer(s *Server, ctx context.Context) (addr string, start func())
 
 // desktopCORS wraps a handler with permissive CORS headers for desktop dev mode.
 // Only active when GOCLAW_DESKTOP=1 (set by desktop app.go).
+// requestIDMiddleware attaches a correlation ID to every HTTP request: the
+// caller's X-Request-ID when well-formed, otherwise a new one. It is echoed in
+// the response header so users can quote it in bug reports.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.HTTPHeader)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.HTTPHeader, id)
+		next.ServeHTTP(w, r.WithContext(requestid.With(r.Context(), id)))
+	})
+}
+
 func desktopCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-GoClaw-Tenant-Id, X-GoClaw-User-Id")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-GoClaw-Tenant-Id, X-GoClaw-User-Id, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -1,11 +1,15 @@
 package gateway
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 // minimalServer builds a Server with only the fields needed for HTTP-level tests.
@@ -59,6 +63,38 @@ func TestHandleHealth_ContentTypeJSON(t *testing.T) {
 	}
 }
 
+// ---- handleReadyz ----
+
+func TestHandleReadyz_FailedChannelIsDegradedNotUnavailable(t *testing.T) {
+	s := minimalServer(t)
+	s.SetChannelHealth(func() (bool, any) {
+		return false, map[string]string{"telegram": "failed"}
+	})
+	w := httptest.NewRecorder()
+	s.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+	body := w.Body.String()
+	if !containsSubstr(body, `"degraded"`) || !containsSubstr(body, "telegram") {
+		t.Errorf("body %q should report degraded channel health", body)
+	}
+}
+
+func TestHandleReadyz_DrainingIsUnavailable(t *testing.T) {
+	s := minimalServer(t)
+	s.agents = agent.NewRouter()
+	s.agents.MarkDraining()
+	w := httptest.NewRecorder()
+	s.handleReadyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable || !containsSubstr(w.Body.String(), `"draining"`) {
+		t.Errorf("status = %d body %q, want 503 draining", w.Code, w.Body.String())
+	}
+	if saturated, _ := s.checkBackpressure(); !saturated {
+		t.Error("HTTP runs should be rejected while draining")
+	}
+}
+
 // ---- tokenAuthMiddleware ----
 
 func TestTokenAuthMiddleware_ValidToken_PassesThrough(t *testing.T) {
@@ -252,6 +288,58 @@ func TestDesktopCORS_OptionsRequest_Returns204(t *testing.T) {
 	}
 }
 
+// ---- requestIDMiddleware ----
+
+func TestRequestIDMiddleware_GeneratesAndPropagatesID(t *testing.T) {
+	var seen string
+	handler := requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/agents", nil))
+	if seen == "" || w.Header().Get(requestid.HTTPHeader) != seen {
+		t.Errorf("context id %q, header %q", seen, w.Header().Get(requestid.HTTPHeader))
+	}
+}
+
+func TestRequestIDMiddleware_HonorsValidCallerID(t *testing.T) {
+	var seen string
+	handler := requestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/agents", nil)
+	req.Header.Set(requestid.HTTPHeader, "client-abc.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if seen != "client-abc.1" {
+		t.Errorf("caller id not honored: %q", seen)
+	}
+
+	req.Header.Set(requestid.HTTPHeader, "bad id\r\n")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if seen == "bad id\r\n" || !requestid.Valid(seen) {
+		t.Errorf("malformed caller id propagated: %q", seen)
+	}
+}
+
+func TestSendResponse_ErrorCarriesRequestID(t *testing.T) {
+	c := &Client{id: "c1", send: make(chan wsMessage, 2)}
+	c.trackRequestID("r1", "corr1")
+	c.SendResponse(protocol.NewErrorResponse("r1", protocol.ErrInternal, "boom"))
+
+	var resp protocol.ResponseFrame
+	if err := json.Unmarshal((<-c.send).data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.RequestID != "corr1" {
+		t.Errorf("error response missing request id: %+v", resp.Error)
+	}
+	if _, ok := c.requestIDs.Load("r1"); ok {
+		t.Error("request id not released after response")
+	}
+}
+
 // ---- isOwnerID ----
 
 func TestIsOwnerID_MatchesConfiguredOwner(t *testing.T) {
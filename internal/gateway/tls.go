@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+// defaultClientCertPaths is the managed HTTP API, which requires a verified
+// client certificate when client_auth is "optional".
+var defaultClientCertPaths = []string{"/v1/"}
+
+// buildTLSConfig assembles the listener tls.Config from gateway TLS settings.
+// Returns the autocert manager when ACME is used so the caller can serve the
+// HTTP-01 challenge handler.
+func buildTLSConfig(c *config.GatewayTLSConfig, dataDir string) (*tls.Config, *autocert.Manager, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch c.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsCfg.MinVersion = tls.VersionTLS13
+	default:
+		return nil, nil, fmt.Errorf("tls: unsupported min_version %q (use 1.2 or 1.3)", c.MinVersion)
+	}
+
+	var mgr *autocert.Manager
+	switch {
+	case c.CertFile != "" && c.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tls: load cert/key: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case len(c.ACMEDomains) > 0:
+		cacheDir := c.ACMECacheDir
+		if cacheDir == "" {
+			cacheDir = filepath.Join(dataDir, "acme")
+		}
+		if err := os.MkdirAll(cacheDir, 0700); err != nil {
+			return nil, nil, fmt.Errorf("tls: create acme cache dir: %w", err)
+		}
+		mgr = &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(c.ACMEDomains...),
+			Cache:      autocert.DirCache(cacheDir),
+			Email:      c.ACMEEmail,
+		}
+		tlsCfg.GetCertificate = mgr.GetCertificate
+		tlsCfg.NextProtos = []string{"h2", "http/1.1", acme.ALPNProto}
+	default:
+		return nil, nil, errors.New("tls: set cert_file+key_file or acme_domains")
+	}
+
+	switch c.ClientAuth {
+	case "", "off":
+	case "optional", "require":
+		if c.ClientCAFile == "" {
+			return nil, nil, fmt.Errorf("tls: client_auth %q requires client_ca_file", c.ClientAuth)
+		}
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tls: read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, nil, errors.New("tls: client_ca_file contains no PEM certificates")
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if c.ClientAuth == "require" {
+			tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	default:
+		return nil, nil, fmt.Errorf("tls: unsupported client_auth %q (use off, optional or require)", c.ClientAuth)
+	}
+
+	return tlsCfg, mgr, nil
+}
+
+// clientCertMiddleware rejects requests under any of prefixes that did not
+// present a verified client certificate. Used with client_auth "optional",
+// where the handshake accepts cert-less clients (WS UI, health probes).
+func clientCertMiddleware(prefixes []string, next http.Handler) http.Handler {
+	if len(prefixes) == 0 {
+		prefixes = defaultClientCertPaths
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range prefixes {
+			if !strings.HasPrefix(r.URL.Path, p) {
+				continue
+			}
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				slog.Warn("security.mtls_rejected", "path", r.URL.Path, "ip", clientIP(r))
+				http.Error(w, `{"error":"client certificate required"}`, http.StatusUnauthorized)
+				return
+			}
+			break
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,175 @@
+package gateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+// writeSelfSigned writes a self-signed cert/key pair to dir and returns their paths.
+func writeSelfSigned(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile
+}
+
+func TestBuildTLSConfig_StaticCertWithClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSigned(t, dir)
+
+	cfg, mgr, err := buildTLSConfig(&config.GatewayTLSConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientCAFile: certFile,
+		ClientAuth:   "require",
+		MinVersion:   "1.3",
+	}, dir)
+	if err != nil {
+		t.Fatalf("buildTLSConfig: %v", err)
+	}
+	if mgr != nil {
+		t.Error("static cert should not create an ACME manager")
+	}
+	if len(cfg.Certificates) != 1 {
+		t.Errorf("certificates = %d, want 1", len(cfg.Certificates))
+	}
+	if cfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("clientAuth = %v, want RequireAndVerifyClientCert", cfg.ClientAuth)
+	}
+	if cfg.MinVersion != tls.VersionTLS13 {
+		t.Errorf("minVersion = %x, want TLS 1.3", cfg.MinVersion)
+	}
+}
+
+func TestBuildTLSConfig_ACME(t *testing.T) {
+	dir := t.TempDir()
+	cfg, mgr, err := buildTLSConfig(&config.GatewayTLSConfig{ACMEDomains: []string{"gw.example.com"}}, dir)
+	if err != nil {
+		t.Fatalf("buildTLSConfig: %v", err)
+	}
+	if mgr == nil || cfg.GetCertificate == nil {
+		t.Fatal("ACME config should wire autocert GetCertificate")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "acme")); err != nil {
+		t.Errorf("acme cache dir not created: %v", err)
+	}
+}
+
+func TestBuildTLSConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSigned(t, dir)
+
+	cases := map[string]*config.GatewayTLSConfig{
+		"no cert source":   {},
+		"missing CA":       {CertFile: certFile, KeyFile: keyFile, ClientAuth: "require"},
+		"bad client auth":  {CertFile: certFile, KeyFile: keyFile, ClientAuth: "sometimes"},
+		"bad min version":  {CertFile: certFile, KeyFile: keyFile, MinVersion: "1.0"},
+		"missing key file": {CertFile: certFile, KeyFile: filepath.Join(dir, "nope.pem")},
+	}
+	for name, c := range cases {
+		if _, _, err := buildTLSConfig(c, dir); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestClientCertMiddleware(t *testing.T) {
+	h := clientCertMiddleware(nil, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		path     string
+		verified bool
+		want     int
+	}{
+		{"/v1/chat/completions", false, http.StatusUnauthorized},
+		{"/v1/chat/completions", true, http.StatusOK},
+		{"/ws", false, http.StatusOK},
+		{"/health", false, http.StatusOK},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, c.path, nil)
+		if c.verified {
+			req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != c.want {
+			t.Errorf("%s verified=%v: status %d, want %d", c.path, c.verified, rec.Code, c.want)
+		}
+	}
+}
+
+func TestGatewayTLSConfig_Enabled(t *testing.T) {
+	var nilCfg *config.GatewayTLSConfig
+	if nilCfg.Enabled() {
+		t.Error("nil config should be disabled")
+	}
+	if (&config.GatewayTLSConfig{CertFile: "c"}).Enabled() {
+		t.Error("cert without key should be disabled")
+	}
+	if !(&config.GatewayTLSConfig{ACMEDomains: []string{"x"}}).Enabled() {
+		t.Error("acme domains should enable TLS")
+	}
+	for cfg, want := range map[*config.GatewayTLSConfig]string{
+		nil:                           "",
+		{CertFile: "c"}:               "key_file",
+		{KeyFile: "k"}:                "cert_file",
+		{CertFile: "c", KeyFile: "k"}: "",
+		{ACMEDomains: []string{"x"}}:  "",
+	} {
+		if got := cfg.MissingKeyPairField(); got != want {
+			t.Errorf("MissingKeyPairField(%+v) = %q, want %q", cfg, got, want)
+		}
+	}
+	for cfg, want := range map[*config.GatewayTLSConfig][]string{
+		nil:                          nil,
+		{}:                           nil,
+		{ClientAuth: "require"}:      {"client_auth"},
+		{ACMEEmail: "ops@x.example"}: {"acme_email"},
+		{ACMEDomains: []string{"x"}, ACMEEmail: "ops@x.example"}: nil,
+	} {
+		if got := cfg.IgnoredFields(); !slices.Equal(got, want) {
+			t.Errorf("IgnoredFields(%+v) = %v, want %v", cfg, got, want)
+		}
+	}
+}
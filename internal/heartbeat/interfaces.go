@@ -1,6 +1,8 @@
 package heartbeat
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
@@ -19,6 +21,12 @@ type EventPublisher interface {
 	PublishOutbound(msg bus.OutboundMessage)
 }
 
+// DeliveryGate may hold back a proactive delivery (agent do-not-disturb).
+// Abstracts *dnd.Gate for testability.
+type DeliveryGate interface {
+	Deliver(ctx context.Context, source string, msg bus.OutboundMessage)
+}
+
 // ActiveSessionChecker checks if a scheduler has active sessions for an agent.
 // Abstracts *scheduler.Scheduler for testability.
 type ActiveSessionChecker interface {
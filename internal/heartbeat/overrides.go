@@ -0,0 +1,146 @@
+package heartbeat
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// Defaults for heartbeats created from an Override (match heartbeat.set).
+const (
+	defaultIntervalSec = 1800
+	defaultAckMaxChars = 300
+	defaultMaxRetries  = 2
+)
+
+// Override is the per-agent heartbeat configuration declared outside the
+// heartbeat RPCs (e.g. agents.list.<key>.heartbeat in the config file).
+// Empty fields keep the stored value.
+type Override struct {
+	Enabled     bool
+	IntervalSec int    // 0 = keep (1800 for new heartbeats)
+	ActiveHours string // "HH:MM-HH:MM"; "" = keep
+	Timezone    string
+	Channel     string // delivery target
+	ChatID      string
+}
+
+// Validate checks the interval and active hours format.
+func (o Override) Validate() error {
+	if o.IntervalSec != 0 && o.IntervalSec < minIntervalSec {
+		return fmt.Errorf("minimum interval is %d seconds", minIntervalSec)
+	}
+	if o.ActiveHours != "" {
+		if _, _, err := splitActiveHours(o.ActiveHours); err != nil {
+			return err
+		}
+	}
+	if o.Timezone != "" {
+		if _, err := time.LoadLocation(o.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q", o.Timezone)
+		}
+	}
+	return nil
+}
+
+// applyOverride returns hb (or a new heartbeat for agentID when hb is nil)
+// with o applied. Runtime state is preserved; next_run_at is rescheduled when
+// the heartbeat is enabled without one or its interval changed.
+func applyOverride(hb *store.AgentHeartbeat, agentID uuid.UUID, o Override, now time.Time) *store.AgentHeartbeat {
+	if hb == nil {
+		hb = &store.AgentHeartbeat{
+			AgentID:         agentID,
+			IntervalSec:     defaultIntervalSec,
+			IsolatedSession: true,
+			AckMaxChars:     defaultAckMaxChars,
+			MaxRetries:      defaultMaxRetries,
+		}
+	} else {
+		cp := *hb
+		hb = &cp
+	}
+
+	reschedule := false
+	hb.Enabled = o.Enabled
+	if o.IntervalSec != 0 && o.IntervalSec != hb.IntervalSec {
+		hb.IntervalSec = o.IntervalSec
+		reschedule = true
+	}
+	if o.ActiveHours != "" {
+		start, end, _ := splitActiveHours(o.ActiveHours)
+		hb.ActiveHoursStart, hb.ActiveHoursEnd = &start, &end
+	}
+	if o.Timezone != "" {
+		hb.Timezone = &o.Timezone
+	}
+	if o.Channel != "" {
+		hb.Channel = &o.Channel
+	}
+	if o.ChatID != "" {
+		hb.ChatID = &o.ChatID
+	}
+
+	if !hb.Enabled {
+		hb.NextRunAt = nil
+	} else if hb.NextRunAt == nil || reschedule {
+		next := now.Add(time.Duration(hb.IntervalSec)*time.Second + store.StaggerOffset(hb.AgentID, hb.IntervalSec))
+		hb.NextRunAt = &next
+	}
+	return hb
+}
+
+// Reconfigure applies o to the agent's stored heartbeat, creating it when
+// missing. The ticker picks up the change on its next poll.
+func (t *Ticker) Reconfigure(ctx context.Context, agentID uuid.UUID, o Override) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	existing, err := t.store.Get(ctx, agentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("load heartbeat: %w", err)
+	}
+	hb := applyOverride(existing, agentID, o, time.Now())
+	if existing != nil && sameOverrideFields(existing, hb) {
+		return nil
+	}
+	if err := t.store.Upsert(ctx, hb); err != nil {
+		return fmt.Errorf("save heartbeat: %w", err)
+	}
+	slog.Info("heartbeat.reconfigured", "agent_id", agentID, "enabled", hb.Enabled, "interval_sec", hb.IntervalSec)
+	t.emitEvent(store.HeartbeatEvent{Action: "reconfigured", AgentID: agentID.String()})
+	return nil
+}
+
+func sameOverrideFields(a, b *store.AgentHeartbeat) bool {
+	return a.Enabled == b.Enabled &&
+		a.IntervalSec == b.IntervalSec &&
+		deref(a.ActiveHoursStart) == deref(b.ActiveHoursStart) &&
+		deref(a.ActiveHoursEnd) == deref(b.ActiveHoursEnd) &&
+		deref(a.Timezone) == deref(b.Timezone) &&
+		deref(a.Channel) == deref(b.Channel) &&
+		deref(a.ChatID) == deref(b.ChatID) &&
+		(a.NextRunAt == nil) == (b.NextRunAt == nil)
+}
+
+// splitActiveHours parses "HH:MM-HH:MM".
+func splitActiveHours(s string) (start, end string, err error) {
+	start, end, ok := strings.Cut(strings.TrimSpace(s), "-")
+	start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+	if !ok || !validHHMM(start) || !validHHMM(end) {
+		return "", "", fmt.Errorf("invalid active hours %q (want HH:MM-HH:MM)", s)
+	}
+	return start, end, nil
+}
+
+func validHHMM(s string) bool {
+	_, err := time.Parse("15:04", s)
+	return err == nil
+}
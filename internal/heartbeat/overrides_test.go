@@ -0,0 +1,86 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestOverrideValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Override
+		wantErr bool
+	}{
+		{"empty", Override{}, false},
+		{"valid", Override{IntervalSec: 600, ActiveHours: "08:00-22:30", Timezone: "Asia/Ho_Chi_Minh"}, false},
+		{"short_interval", Override{IntervalSec: 60}, true},
+		{"bad_hours", Override{ActiveHours: "8am-10pm"}, true},
+		{"missing_end", Override{ActiveHours: "08:00"}, true},
+		{"bad_timezone", Override{Timezone: "Mars/Olympus"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.o.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyOverrideNew(t *testing.T) {
+	now := time.Now()
+	id := uuid.New()
+	hb := applyOverride(nil, id, Override{Enabled: true, ActiveHours: "22:00-06:00", Channel: "telegram", ChatID: "42"}, now)
+
+	if hb.AgentID != id || !hb.Enabled || hb.IntervalSec != defaultIntervalSec {
+		t.Fatalf("unexpected heartbeat: %+v", hb)
+	}
+	if deref(hb.ActiveHoursStart) != "22:00" || deref(hb.ActiveHoursEnd) != "06:00" {
+		t.Errorf("active hours = %s-%s", deref(hb.ActiveHoursStart), deref(hb.ActiveHoursEnd))
+	}
+	if deref(hb.Channel) != "telegram" || deref(hb.ChatID) != "42" {
+		t.Errorf("target = %s/%s", deref(hb.Channel), deref(hb.ChatID))
+	}
+	if hb.NextRunAt == nil || hb.NextRunAt.Before(now.Add(defaultIntervalSec*time.Second)) {
+		t.Errorf("next run not scheduled one interval ahead: %v", hb.NextRunAt)
+	}
+}
+
+func TestApplyOverrideKeepsStateAndReschedules(t *testing.T) {
+	now := time.Now()
+	next := now.Add(time.Minute)
+	prompt := "check the queue"
+	existing := &store.AgentHeartbeat{
+		AgentID:     uuid.New(),
+		Enabled:     true,
+		IntervalSec: 1800,
+		Prompt:      &prompt,
+		NextRunAt:   &next,
+		RunCount:    7,
+	}
+
+	same := applyOverride(existing, existing.AgentID, Override{Enabled: true}, now)
+	if !same.NextRunAt.Equal(next) || same.RunCount != 7 || deref(same.Prompt) != prompt {
+		t.Errorf("unchanged interval should keep state: %+v", same)
+	}
+	if !sameOverrideFields(existing, same) {
+		t.Error("expected no-op override to compare equal")
+	}
+
+	faster := applyOverride(existing, existing.AgentID, Override{Enabled: true, IntervalSec: 600}, now)
+	if faster.IntervalSec != 600 || faster.NextRunAt.Equal(next) {
+		t.Errorf("interval change should reschedule: %+v", faster)
+	}
+	if existing.IntervalSec != 1800 {
+		t.Error("applyOverride mutated its input")
+	}
+
+	off := applyOverride(existing, existing.AgentID, Override{Enabled: false}, now)
+	if off.Enabled || off.NextRunAt != nil {
+		t.Errorf("disabled heartbeat should clear next run: %+v", off)
+	}
+}
@@ -35,6 +35,7 @@ type TickerConfig struct {
 	ProviderStore store.ProviderStore
 	ProviderReg   ProviderResolver
 	MsgBus        EventPublisher
+	DND           DeliveryGate // optional: queues deliveries during the agent's DND windows
 	Sched         ActiveSessionChecker
 	RunAgent      func(ctx context.Context, req agent.RunRequest) <-chan scheduler.RunOutcome
 }
@@ -47,6 +48,7 @@ type Ticker struct {
 	providerStore store.ProviderStore
 	providerReg   ProviderResolver
 	msgBus        EventPublisher
+	dnd           DeliveryGate
 	sched         ActiveSessionChecker
 	runAgent      func(ctx context.Context, req agent.RunRequest) <-chan scheduler.RunOutcome
 	onEvent       func(store.HeartbeatEvent)
@@ -65,6 +67,7 @@ func NewTicker(cfg TickerConfig) *Ticker {
 		providerStore: cfg.ProviderStore,
 		providerReg:   cfg.ProviderReg,
 		msgBus:        cfg.MsgBus,
+		dnd:           cfg.DND,
 		sched:         cfg.Sched,
 		runAgent:      cfg.RunAgent,
 		wakeCh:   make(chan uuid.UUID, 16),
@@ -177,6 +180,13 @@ func (t *Ticker) runOne(ctx context.Context, hb store.AgentHeartbeat) {
 		ctx = store.WithTenantID(ctx, store.MasterTenantID)
 	}
 
+	// [0] Inactive agents keep their heartbeat config but don't run.
+	if ag.Status != "" && ag.Status != store.AgentStatusActive {
+		t.logSkipped(ctx, hb, "agent_inactive", agentKey)
+		t.advanceNextRun(ctx, hb)
+		return
+	}
+
 	// [1] Active hours filter.
 	if !isWithinActiveHours(hb) {
 		t.logSkipped(ctx, hb, "active_hours", agentKey)
@@ -301,7 +311,7 @@ func (t *Ticker) runOne(ctx context.Context, hb store.AgentHeartbeat) {
 
 	// [6] Process result.
 	if lastErr != nil {
-		t.finishRun(ctx, hb, sessionKey, agentKey, "error", lastErr.Error(), "", durationMS, 0, 0)
+		t.finishRun(ctx, hb, sessionKey, agentKey, store.HeartbeatStatusError, lastErr.Error(), "", durationMS, 0, 0)
 		return
 	}
 
@@ -315,20 +325,33 @@ func (t *Ticker) runOne(ctx context.Context, hb store.AgentHeartbeat) {
 	}
 
 	if !deliver {
-		t.finishRun(ctx, hb, sessionKey, agentKey, "suppressed", "", truncate(result.Content, maxSummaryLen), durationMS, inputTokens, outputTokens)
+		t.finishRun(ctx, hb, sessionKey, agentKey, store.HeartbeatStatusSuppressed, "", truncate(result.Content, maxSummaryLen), durationMS, inputTokens, outputTokens)
 		return
 	}
 
-	// [8] Deliver to channel.
+	// [8] Snoozed: record what was found but hold the alert back.
+	if hb.IsSnoozed(time.Now()) {
+		t.finishRun(ctx, hb, sessionKey, agentKey, store.HeartbeatStatusSnoozed, "", truncate(cleaned, maxSummaryLen), durationMS, inputTokens, outputTokens)
+		return
+	}
+
+	// [9] Deliver to channel.
 	if hb.Channel != nil && *hb.Channel != "" && hb.ChatID != nil && *hb.ChatID != "" {
-		t.msgBus.PublishOutbound(bus.OutboundMessage{
-			Channel: *hb.Channel,
-			ChatID:  *hb.ChatID,
-			Content: cleaned,
-		})
+		msg := bus.OutboundMessage{
+			Channel:  *hb.Channel,
+			ChatID:   *hb.ChatID,
+			Content:  cleaned,
+			TenantID: store.TenantIDFromContext(ctx),
+			AgentID:  hb.AgentID,
+		}
+		if t.dnd != nil {
+			t.dnd.Deliver(ctx, "heartbeat", msg)
+		} else {
+			t.msgBus.PublishOutbound(msg)
+		}
 	}
 
-	t.finishRun(ctx, hb, sessionKey, agentKey, "ok", "", truncate(cleaned, maxSummaryLen), durationMS, inputTokens, outputTokens)
+	t.finishRun(ctx, hb, sessionKey, agentKey, store.HeartbeatStatusOK, "", truncate(cleaned, maxSummaryLen), durationMS, inputTokens, outputTokens)
 }
 
 func (t *Ticker) finishRun(ctx context.Context, hb store.AgentHeartbeat, sessionKey, agentKey, status, errMsg, summary string, durationMS, inputTokens, outputTokens int) {
@@ -398,7 +421,7 @@ func (t *Ticker) logSkipped(ctx context.Context, hb store.AgentHeartbeat, reason
 	logEntry := &store.HeartbeatRunLog{
 		HeartbeatID: hb.ID,
 		AgentID:     hb.AgentID,
-		Status:      "skipped",
+		Status:      store.HeartbeatStatusSkipped,
 		SkipReason:  &reason,
 		RanAt:       now,
 	}
@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 
 	"github.com/nextlevelbuilder/goclaw/internal/edition"
 	"github.com/nextlevelbuilder/goclaw/internal/hooks"
@@ -95,11 +97,15 @@ func (h *CommandHandler) Execute(ctx context.Context, cfg hooks.HookConfig, ev h
 	return hooks.DecisionAllow, nil
 }
 
-// findShell returns the path to sh, falling back to /bin/sh.
+// findShell returns the path to sh, falling back to /bin/sh. Hook commands
+// are POSIX shell; Windows hosts need sh on PATH (Git for Windows, MSYS2).
 func findShell() (string, error) {
 	if p, err := exec.LookPath("sh"); err == nil {
 		return p, nil
 	}
+	if runtime.GOOS == "windows" {
+		return "", errors.New("sh not found on PATH (install Git for Windows or MSYS2)")
+	}
 	return "/bin/sh", nil
 }
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/crypto"
 	"github.com/nextlevelbuilder/goclaw/internal/hooks"
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 	"github.com/nextlevelbuilder/goclaw/internal/security"
 )
 
@@ -68,22 +69,24 @@ func (h *HTTPHandler) Execute(ctx context.Context, cfg hooks.HookConfig, ev hook
 		client = &http.Client{Timeout: 10 * time.Second}
 	}
 
-	decision, err := h.doRequest(ctx, client, urlStr, cfg.Config, body)
+	// Retry once on 5xx / 429 / network error with 1s backoff; 4xx is final.
+	decision, err := retry.DoValue(ctx, httpHookRetry, func(ctx context.Context) (hooks.Decision, error) {
+		return h.doRequest(ctx, client, urlStr, cfg.Config, body)
+	})
 	if err != nil {
-		// Retry once on 5xx / network error with 1s backoff.
-		select {
-		case <-ctx.Done():
-			return hooks.DecisionError, ctx.Err()
-		case <-time.After(1 * time.Second):
-		}
-		decision, err = h.doRequest(ctx, client, urlStr, cfg.Config, body)
-		if err != nil {
-			return hooks.DecisionError, fmt.Errorf("hook: http handler: %w", err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return hooks.DecisionError, ctxErr
 		}
+		return hooks.DecisionError, fmt.Errorf("hook: http handler: %w", err)
 	}
 	return decision, nil
 }
 
+var httpHookRetry = retry.Policy{
+	Name:    "hook.http",
+	Backoff: retry.Backoff{Attempts: 2, MinDelay: time.Second},
+}
+
 func (h *HTTPHandler) doRequest(ctx context.Context, client *http.Client, urlStr string, cfgMap map[string]any, body []byte) (hooks.Decision, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, urlStr, bytes.NewReader(body))
 	if err != nil {
@@ -113,10 +116,10 @@ func (h *HTTPHandler) doRequest(ctx context.Context, client *http.Client, urlStr
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 500 {
-		return hooks.DecisionError, fmt.Errorf("server error %d", resp.StatusCode)
+		return hooks.DecisionError, retry.HTTPStatus(fmt.Errorf("server error %d", resp.StatusCode), resp.StatusCode, 0)
 	}
 	if resp.StatusCode >= 400 {
-		return hooks.DecisionError, fmt.Errorf("client error %d (no retry)", resp.StatusCode)
+		return hooks.DecisionError, retry.HTTPStatus(fmt.Errorf("client error %d", resp.StatusCode), resp.StatusCode, 0)
 	}
 
 	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20)) // 1 MiB cap
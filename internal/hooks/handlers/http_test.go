@@ -118,8 +118,7 @@ func TestHTTP_4xxReturnsError(t *testing.T) {
 	security.SetAllowLoopbackForTest(true)
 	defer security.SetAllowLoopbackForTest(false)
 
-	// The Execute method retries once on any doRequest error (including 4xx).
-	// Primary assertion: the final decision is DecisionError regardless of retry.
+	// 4xx is a permanent failure: DecisionError without a retry.
 	var calls atomic.Int32
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		calls.Add(1)
@@ -135,9 +134,8 @@ func TestHTTP_4xxReturnsError(t *testing.T) {
 	if dec != hooks.DecisionError {
 		t.Errorf("decision=%q, want error", dec)
 	}
-	// Execute retries once on any non-nil doRequest error, so server sees 2 calls.
-	if got := calls.Load(); got != 2 {
-		t.Errorf("server called %d times, want 2 (original + one retry)", got)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1 (4xx is not retried)", got)
 	}
 }
 
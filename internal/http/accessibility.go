@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// maxAccessibilityMessageLength bounds max_message_length so a typo cannot
+// silently disable the cap (values above every channel limit are pointless).
+const maxAccessibilityMessageLength = 100000
+
+// Per-user accessibility preferences live in system_configs under
+// store.AccessibilityConfigKey(userID), tenant-scoped like every other key.
+// userID is the same ID the agent loop sees (tenant user ID for merged
+// contacts, otherwise the channel user ID).
+
+// handleGetAccessibility returns a user's preferences (defaults when unset).
+func (h *SystemConfigsHandler) handleGetAccessibility(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	userID := r.PathValue("userID")
+	prefs, err := store.LoadAccessibilityPrefs(r.Context(), h.store, userID)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": i18n.T(locale, i18n.MsgInternalError, err.Error())})
+		return
+	}
+	if prefs == nil {
+		prefs = &bus.AccessibilityPrefs{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"user_id": userID, "accessibility": prefs})
+}
+
+// handleSetAccessibility replaces a user's preferences. All-default preferences delete the row.
+func (h *SystemConfigsHandler) handleSetAccessibility(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	userID := r.PathValue("userID")
+
+	var prefs bus.AccessibilityPrefs
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<12)).Decode(&prefs); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgInvalidJSON)})
+		return
+	}
+	if prefs.MaxMessageLength < 0 || prefs.MaxMessageLength > maxAccessibilityMessageLength {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "max_message_length must be between 0 and 100000"})
+		return
+	}
+
+	key := store.AccessibilityConfigKey(userID)
+	if prefs.IsZero() {
+		// Get → Delete keeps the store free of no-op rows; a missing row is fine.
+		if _, err := h.store.Get(r.Context(), key); err == nil {
+			if err := h.store.Delete(r.Context(), key); err != nil {
+				writeJSON(w, http.StatusInternalServerError, map[string]string{"error": i18n.T(locale, i18n.MsgInternalError, err.Error())})
+				return
+			}
+		}
+	} else {
+		raw, _ := json.Marshal(prefs)
+		if err := h.store.Set(r.Context(), key, string(raw)); err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": i18n.T(locale, i18n.MsgInternalError, err.Error())})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"user_id": userID, "accessibility": prefs})
+}
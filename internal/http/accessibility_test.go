@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func newAccessibilityMux(sc store.SystemConfigStore) *http.ServeMux {
+	h := NewSystemConfigsHandler(sc, nil)
+	mux := http.NewServeMux()
+	// Register without requireAuth — handlers are exercised directly.
+	mux.HandleFunc("GET /v1/users/{userID}/accessibility", h.handleGetAccessibility)
+	mux.HandleFunc("PUT /v1/users/{userID}/accessibility", h.handleSetAccessibility)
+	mux.HandleFunc("GET /v1/system-configs", h.handleList)
+	return mux
+}
+
+func TestAccessibility_SetGetAndReset(t *testing.T) {
+	sc := &validationSystemConfigStore{data: map[string]string{"tts.auto": "off"}}
+	mux := newAccessibilityMux(sc)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PUT", "/v1/users/u1/accessibility", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := put(`{"always_tts":true,"simple_formatting":true,"max_message_length":500}`); rr.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d: %s", rr.Code, rr.Body.String())
+	}
+	if _, ok := sc.data[store.AccessibilityConfigKey("u1")]; !ok {
+		t.Fatal("prefs not persisted under the per-user key")
+	}
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/users/u1/accessibility", nil))
+	var got struct {
+		Accessibility struct {
+			AlwaysTTS        bool `json:"always_tts"`
+			SimpleFormatting bool `json:"simple_formatting"`
+			MaxMessageLength int  `json:"max_message_length"`
+		} `json:"accessibility"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.Accessibility.AlwaysTTS || !got.Accessibility.SimpleFormatting || got.Accessibility.MaxMessageLength != 500 {
+		t.Fatalf("unexpected prefs: %+v", got.Accessibility)
+	}
+
+	// Per-user rows are hidden from the generic system config list.
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, httptest.NewRequest("GET", "/v1/system-configs", nil))
+	if strings.Contains(rr.Body.String(), store.AccessibilityKeyPrefix) {
+		t.Fatalf("system config list leaked accessibility rows: %s", rr.Body.String())
+	}
+
+	// All-default prefs remove the row.
+	if rr := put(`{}`); rr.Code != http.StatusOK {
+		t.Fatalf("reset status = %d", rr.Code)
+	}
+	if _, ok := sc.data[store.AccessibilityConfigKey("u1")]; ok {
+		t.Fatal("reset should delete the per-user row")
+	}
+}
+
+func TestAccessibility_RejectsInvalidLength(t *testing.T) {
+	mux := newAccessibilityMux(&validationSystemConfigStore{})
+	req := httptest.NewRequest("PUT", "/v1/users/u1/accessibility", strings.NewReader(`{"max_message_length":-1}`))
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rr.Code)
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -19,8 +20,12 @@ func NewActivityHandler(activity store.ActivityStore) *ActivityHandler {
 }
 
 // RegisterRoutes registers activity routes on the given mux.
+// /v1/audit is an alias, not a separate log: the activity_logs table is the
+// audit trail (admin/API mutations and tool executions), and both routes
+// serve it with the same filters.
 func (h *ActivityHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/activity", h.authMiddleware(h.handleList))
+	mux.HandleFunc("GET /v1/audit", h.authMiddleware(h.handleList))
 }
 
 func (h *ActivityHandler) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
@@ -49,6 +54,25 @@ func (h *ActivityHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	if v := r.URL.Query().Get("action"); v != "" {
 		opts.Action = v
 	}
+	if v := r.URL.Query().Get("action_prefix"); v != "" {
+		opts.ActionPrefix = v
+	}
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid from: want an RFC3339 timestamp"})
+			return
+		}
+		opts.Since = &t
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid to: want an RFC3339 timestamp"})
+			return
+		}
+		opts.Until = &t
+	}
 	if v := r.URL.Query().Get("entity_type"); v != "" {
 		opts.EntityType = v
 	}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// activityStubStore records the last list options it was queried with.
+type activityStubStore struct {
+	store.ActivityStore
+	opts *store.ActivityListOpts
+}
+
+func (s *activityStubStore) List(_ context.Context, opts store.ActivityListOpts) ([]store.ActivityLog, error) {
+	s.opts = &opts
+	return nil, nil
+}
+
+func (s *activityStubStore) Count(context.Context, store.ActivityListOpts) (int, error) {
+	return 0, nil
+}
+
+func TestActivityList_TimeRange(t *testing.T) {
+	setupTestToken(t, "gw-token")
+	as := &activityStubStore{}
+	mux := http.NewServeMux()
+	NewActivityHandler(as).RegisterRoutes(mux)
+
+	do := func(path string) int {
+		as.opts = nil
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Authorization", "Bearer gw-token")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for _, path := range []string{"/v1/activity?from=yesterday", "/v1/audit?to=2026-01-01"} {
+		if code := do(path); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", path, code)
+		}
+		if as.opts != nil {
+			t.Errorf("%s: store queried despite invalid range", path)
+		}
+	}
+
+	if code := do("/v1/audit?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z"); code != http.StatusOK {
+		t.Fatalf("valid range: status %d", code)
+	}
+	if as.opts.Since == nil || as.opts.Until == nil || !as.opts.Since.Before(*as.opts.Until) {
+		t.Errorf("range not applied: since %v until %v", as.opts.Since, as.opts.Until)
+	}
+}
@@ -96,7 +96,7 @@ type ToolPreviewLister interface {
 // SkillPreviewBuilder is satisfied by skills.Loader for system prompt preview.
 type SkillPreviewBuilder interface {
 	BuildPinnedSummary(ctx context.Context, names []string) string
-	BuildSummary(ctx context.Context, allowList []string) string
+	BuildVersionedSummary(ctx context.Context, allowList []string, versions map[string]int) string
 }
 
 // SetPreviewDeps attaches optional dependencies for system prompt preview.
@@ -112,6 +112,17 @@ func (h *AgentsHandler) SetPreviewStores(ts store.TeamStore, als store.AgentLink
 	h.skillAccessStore = sas
 }
 
+// canManageAgent reports whether the caller may modify ag: admins always may,
+// everyone else needs a caller ID that owns the agent or is a system owner.
+// An ownerless operator key (no user ID) may not modify any agent.
+func (h *AgentsHandler) canManageAgent(r *http.Request, ag *store.AgentData) bool {
+	if permissions.HasMinRole(permissions.Role(store.RoleFromContext(r.Context())), permissions.RoleAdmin) {
+		return true
+	}
+	userID := store.UserIDFromContext(r.Context())
+	return userID != "" && (ag.OwnerID == userID || h.isOwnerUser(userID))
+}
+
 // isOwnerUser checks if the given user ID is a system owner.
 func (h *AgentsHandler) isOwnerUser(userID string) bool {
 	return userID != "" && h.isOwner != nil && h.isOwner(userID)
@@ -130,27 +141,32 @@ func (h *AgentsHandler) emitCacheInvalidate(kind, key string) {
 
 // RegisterRoutes registers all agent management routes on the given mux.
 func (h *AgentsHandler) RegisterRoutes(mux *http.ServeMux) {
-	// Agent CRUD (reads: viewer+, writes: admin+)
-	mux.HandleFunc("GET /v1/agents", h.authMiddleware(h.handleList))
-	mux.HandleFunc("POST /v1/agents", h.adminMiddleware(h.handleCreate))
-	mux.HandleFunc("GET /v1/agents/{id}", h.authMiddleware(h.handleGet))
-	// Finding #15: PUT /v1/agents/{id} is gated by adminMiddleware (RoleAdmin required).
-	// Admin-only access significantly reduces abuse risk — rapid writes by a malicious admin
-	// are an insider threat with broader capabilities than tts_params mutation.
-	// No additional per-user rate limiter is added at this time (YAGNI). Re-evaluate
-	// if non-admin write paths are ever added or the endpoint is exposed via OAuth scopes.
-	mux.HandleFunc("PUT /v1/agents/{id}", h.adminMiddleware(h.handleUpdate))
-	mux.HandleFunc("DELETE /v1/agents/{id}", h.adminMiddleware(h.handleDelete))
+	// Agent CRUD (list/get: member+, writes: operator+; non-owners are limited
+	// to agents they own by the handlers)
+	mux.HandleFunc("GET /v1/agents", h.memberMiddleware(h.handleList))
+	mux.HandleFunc("POST /v1/agents", h.operatorMiddleware(h.handleCreate))
+	mux.HandleFunc("GET /v1/agents/{id}", h.memberMiddleware(h.handleGet))
+	// Finding #15: PUT /v1/agents/{id} is gated by operatorMiddleware and the
+	// handler only lets system owners edit agents they do not own, so rapid
+	// writes are limited to the caller's own agents. No additional per-user
+	// rate limiter is added at this time (YAGNI). Re-evaluate if the endpoint
+	// is exposed via OAuth scopes.
+	mux.HandleFunc("PUT /v1/agents/{id}", h.operatorMiddleware(h.handleUpdate))
+	mux.HandleFunc("DELETE /v1/agents/{id}", h.operatorMiddleware(h.handleDelete))
 	// Bulk operations (admin+)
 	mux.HandleFunc("POST /v1/agents/sync-workspace", h.adminMiddleware(h.handleSyncWorkspace))
-	// Sharing (admin+)
+	// Sharing (operator+, agent owner)
 	mux.HandleFunc("GET /v1/agents/{id}/shares", h.authMiddleware(h.handleListShares))
-	mux.HandleFunc("POST /v1/agents/{id}/shares", h.adminMiddleware(h.handleShare))
-	mux.HandleFunc("DELETE /v1/agents/{id}/shares/{userID}", h.adminMiddleware(h.handleRevokeShare))
-	// Agent operations (admin+)
-	mux.HandleFunc("POST /v1/agents/{id}/regenerate", h.adminMiddleware(h.handleRegenerate))
-	mux.HandleFunc("POST /v1/agents/{id}/resummon", h.adminMiddleware(h.handleResummon))
-	mux.HandleFunc("POST /v1/agents/{id}/cancel-summon", h.adminMiddleware(h.handleCancelSummon))
+	mux.HandleFunc("POST /v1/agents/{id}/shares", h.operatorMiddleware(h.handleShare))
+	mux.HandleFunc("DELETE /v1/agents/{id}/shares/{userID}", h.operatorMiddleware(h.handleRevokeShare))
+	// Agent operations (operator+, agent owner)
+	mux.HandleFunc("POST /v1/agents/{id}/regenerate", h.operatorMiddleware(h.handleRegenerate))
+	mux.HandleFunc("POST /v1/agents/{id}/resummon", h.operatorMiddleware(h.handleResummon))
+	mux.HandleFunc("POST /v1/agents/{id}/cancel-summon", h.operatorMiddleware(h.handleCancelSummon))
+	mux.HandleFunc("POST /v1/agents/{id}/summon/preview", h.operatorMiddleware(h.handleSummonPreview))
+	mux.HandleFunc("GET /v1/agents/{id}/summon/preview/{previewID}", h.operatorMiddleware(h.handleGetSummonPreview))
+	mux.HandleFunc("POST /v1/agents/{id}/summon/preview/{previewID}/apply", h.operatorMiddleware(h.handleApplySummonPreview))
+	mux.HandleFunc("DELETE /v1/agents/{id}/summon/preview/{previewID}", h.operatorMiddleware(h.handleDiscardSummonPreview))
 	// Export (agent owner or system owner)
 	mux.HandleFunc("GET /v1/agents/{id}/system-prompt-preview", h.adminMiddleware(h.handleSystemPromptPreview))
 	mux.HandleFunc("GET /v1/agents/{id}/export/preview", h.authMiddleware(h.handleExportPreview))
@@ -170,15 +186,23 @@ func (h *AgentsHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/agents/{id}/codex-pool-activity", h.authMiddleware(h.handleCodexPoolActivity))
 	mux.HandleFunc("GET /v1/agents/{id}/instances", h.authMiddleware(h.handleListInstances))
 	mux.HandleFunc("GET /v1/agents/{id}/instances/{userID}/files", h.authMiddleware(h.handleGetInstanceFiles))
-	// Instance writes (admin+)
-	mux.HandleFunc("PUT /v1/agents/{id}/instances/{userID}/files/{fileName}", h.adminMiddleware(h.handleSetInstanceFile))
-	mux.HandleFunc("PATCH /v1/agents/{id}/instances/{userID}/metadata", h.adminMiddleware(h.handleUpdateInstanceMetadata))
+	// Instance writes (operator+, agent owner)
+	mux.HandleFunc("PUT /v1/agents/{id}/instances/{userID}/files/{fileName}", h.operatorMiddleware(h.handleSetInstanceFile))
+	mux.HandleFunc("PATCH /v1/agents/{id}/instances/{userID}/metadata", h.operatorMiddleware(h.handleUpdateInstanceMetadata))
 }
 
 func (h *AgentsHandler) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return requireAuth("", next)
 }
 
+func (h *AgentsHandler) memberMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(permissions.RoleMember, next)
+}
+
+func (h *AgentsHandler) operatorMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(permissions.RoleOperator, next)
+}
+
 func (h *AgentsHandler) adminMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return requireAuth(permissions.RoleAdmin, next)
 }
@@ -368,8 +392,8 @@ func (h *AgentsHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Tenant admins can update any agent in their tenant (adminMiddleware already
-	// verified RoleAdmin). System owners can update any agent across tenants.
+	// Tenant admins can update any agent in their tenant; operators only the
+	// agents they own. System owners can update any agent across tenants.
 	// GetByID respects tenant scoping from context, so if the agent is returned
 	// it belongs to the caller's tenant.
 	ag, err := h.agents.GetByID(r.Context(), id)
@@ -385,6 +409,10 @@ func (h *AgentsHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "agent", id.String()))
 		return
 	}
+	if !h.canManageAgent(r, ag) {
+		writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgOwnerOnly, "update agent"))
+		return
+	}
 
 	var updates map[string]any
 	if !bindJSON(w, r, locale, &updates) {
@@ -537,7 +565,6 @@ func (h *AgentsHandler) syncIdentityName(ctx context.Context, ag *store.AgentDat
 }
 
 func (h *AgentsHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
-	userID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -551,7 +578,7 @@ func (h *AgentsHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "agent", id.String()))
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgOwnerOnly, "delete agent"))
 		return
 	}
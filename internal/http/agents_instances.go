@@ -77,7 +77,6 @@ func (h *AgentsHandler) handleGetInstanceFiles(w http.ResponseWriter, r *http.Re
 
 // handleSetInstanceFile updates a user context file for a specific instance.
 func (h *AgentsHandler) handleSetInstanceFile(w http.ResponseWriter, r *http.Request) {
-	callerID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -102,7 +101,7 @@ func (h *AgentsHandler) handleSetInstanceFile(w http.ResponseWriter, r *http.Req
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if callerID != "" && ag.OwnerID != callerID && !h.isOwnerUser(callerID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "edit instance files")})
 		return
 	}
@@ -140,7 +139,6 @@ func (h *AgentsHandler) handleSetInstanceFile(w http.ResponseWriter, r *http.Req
 
 // handleUpdateInstanceMetadata updates metadata for a user instance.
 func (h *AgentsHandler) handleUpdateInstanceMetadata(w http.ResponseWriter, r *http.Request) {
-	callerID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -158,7 +156,7 @@ func (h *AgentsHandler) handleUpdateInstanceMetadata(w http.ResponseWriter, r *h
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if callerID != "" && ag.OwnerID != callerID && !h.isOwnerUser(callerID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "edit instance metadata")})
 		return
 	}
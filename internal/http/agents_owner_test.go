@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// ownerStubStore serves a single agent owned by "alice".
+type ownerStubStore struct {
+	store.AgentStore // embed to satisfy interface; unused methods panic
+	ag               *store.AgentData
+	deleted          bool
+}
+
+func (s *ownerStubStore) GetByID(_ context.Context, id uuid.UUID) (*store.AgentData, error) {
+	if id != s.ag.ID {
+		return nil, fmt.Errorf("not found")
+	}
+	return s.ag, nil
+}
+
+func (s *ownerStubStore) Delete(context.Context, uuid.UUID) error {
+	s.deleted = true
+	return nil
+}
+
+func TestAgentMutations_OwnerlessOperatorKey(t *testing.T) {
+	setupTestCache(t, map[string]*store.APIKeyData{
+		crypto.HashAPIKey("op-key"): {ID: uuid.New(), Scopes: []string{"operator.write"}},
+	})
+	setupTestToken(t, "gw-token")
+	as := &ownerStubStore{ag: &store.AgentData{AgentKey: "support", OwnerID: "alice", TenantID: store.MasterTenantID}}
+	as.ag.ID = uuid.New()
+	mux := http.NewServeMux()
+	NewAgentsHandler(as, nil, nil, nil, nil, t.TempDir(), nil, nil, nil).RegisterRoutes(mux)
+
+	do := func(method, path, user string) int {
+		r := httptest.NewRequest(method, path, strings.NewReader(`{}`))
+		r.Header.Set("Authorization", "Bearer op-key")
+		if user != "" {
+			r.Header.Set("X-GoClaw-User-Id", user)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	base := "/v1/agents/" + as.ag.ID.String()
+	for _, rt := range []struct{ method, path string }{
+		{"DELETE", base},
+		{"POST", base + "/shares"},
+		{"POST", base + "/regenerate"},
+		{"POST", base + "/resummon"},
+		{"PUT", base + "/instances/bob/files/USER.md"},
+	} {
+		if code := do(rt.method, rt.path, ""); code != http.StatusForbidden {
+			t.Errorf("%s %s without user: status %d, want 403", rt.method, rt.path, code)
+		}
+		if code := do(rt.method, rt.path, "bob"); code != http.StatusForbidden {
+			t.Errorf("%s %s as non-owner: status %d, want 403", rt.method, rt.path, code)
+		}
+	}
+	if as.deleted {
+		t.Fatal("agent deleted by a non-owner")
+	}
+
+	if code := do("DELETE", base, "alice"); code != http.StatusOK || !as.deleted {
+		t.Errorf("owner delete: status %d, deleted %v", code, as.deleted)
+	}
+}
@@ -55,7 +55,7 @@ func (h *AgentsHandler) handleShare(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "share agent")})
 		return
 	}
@@ -89,7 +89,6 @@ func (h *AgentsHandler) handleShare(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *AgentsHandler) handleRevokeShare(w http.ResponseWriter, r *http.Request) {
-	userID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -103,7 +102,7 @@ func (h *AgentsHandler) handleRevokeShare(w http.ResponseWriter, r *http.Request
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "revoke shares")})
 		return
 	}
@@ -123,7 +122,6 @@ func (h *AgentsHandler) handleRevokeShare(w http.ResponseWriter, r *http.Request
 }
 
 func (h *AgentsHandler) handleRegenerate(w http.ResponseWriter, r *http.Request) {
-	userID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -137,7 +135,7 @@ func (h *AgentsHandler) handleRegenerate(w http.ResponseWriter, r *http.Request)
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "regenerate agent")})
 		return
 	}
@@ -172,7 +170,6 @@ func (h *AgentsHandler) handleRegenerate(w http.ResponseWriter, r *http.Request)
 // handleResummon re-runs SummonAgent from scratch using the original description.
 // Used when initial summoning failed (e.g. wrong model) and user wants to retry.
 func (h *AgentsHandler) handleResummon(w http.ResponseWriter, r *http.Request) {
-	userID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -185,7 +182,7 @@ func (h *AgentsHandler) handleResummon(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "resummon agent")})
 		return
 	}
@@ -214,7 +211,6 @@ func (h *AgentsHandler) handleResummon(w http.ResponseWriter, r *http.Request) {
 // handleCancelSummon force-transitions a stuck 'summoning' agent to 'summon_failed'.
 // Used when user wants to abort a hanging summon (UI Cancel button after 60s).
 func (h *AgentsHandler) handleCancelSummon(w http.ResponseWriter, r *http.Request) {
-	userID := store.UserIDFromContext(r.Context())
 	locale := store.LocaleFromContext(r.Context())
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -227,7 +223,7 @@ func (h *AgentsHandler) handleCancelSummon(w http.ResponseWriter, r *http.Reques
 		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
 		return
 	}
-	if userID != "" && ag.OwnerID != userID && !h.isOwnerUser(userID) {
+	if !h.canManageAgent(r, ag) {
 		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "cancel summon")})
 		return
 	}
@@ -0,0 +1,257 @@
+package http
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// recentErrorLimit caps the errored runs listed on a status card.
+const recentErrorLimit = 5
+
+// AgentStatusCard is the composite view served by GET /v1/agents/{id}/status.
+// Sections whose backing store is unavailable are omitted.
+type AgentStatusCard struct {
+	AgentID      string                `json:"agent_id"`
+	AgentKey     string                `json:"agent_key"`
+	DisplayName  string                `json:"display_name,omitempty"`
+	Status       string                `json:"status"`
+	Provider     string                `json:"provider"`
+	Model        string                `json:"model"`
+	Queue        *AgentQueueStatus     `json:"queue,omitempty"`
+	LastRun      *AgentRunSummary      `json:"last_run,omitempty"`
+	Heartbeat    *AgentHeartbeatStatus `json:"heartbeat,omitempty"`
+	NextCron     *AgentCronWake        `json:"next_cron,omitempty"`
+	Memory       *store.MemoryStats    `json:"memory,omitempty"`
+	Budget       *AgentBudgetStatus    `json:"budget,omitempty"`
+	RecentErrors []AgentRunSummary     `json:"recent_errors"`
+	GeneratedAt  time.Time             `json:"generated_at"`
+}
+
+// AgentQueueStatus is the agent's live scheduler load on this gateway instance.
+type AgentQueueStatus struct {
+	Active int `json:"active"`
+	Queued int `json:"queued"`
+}
+
+// AgentRunSummary describes one traced run.
+type AgentRunSummary struct {
+	TraceID    string    `json:"trace_id"`
+	Status     string    `json:"status"`
+	Channel    string    `json:"channel,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int       `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AgentHeartbeatStatus summarizes the heartbeat schedule.
+type AgentHeartbeatStatus struct {
+	Enabled      bool       `json:"enabled"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastStatus   string     `json:"last_status,omitempty"`
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+}
+
+// AgentCronWake is the agent's earliest upcoming cron run.
+type AgentCronWake struct {
+	JobID     string    `json:"job_id"`
+	Name      string    `json:"name"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+// AgentBudgetStatus is the current month's spend against the agent budget.
+type AgentBudgetStatus struct {
+	MonthlyCents *int    `json:"monthly_cents,omitempty"` // nil = unlimited
+	SpentCents   int     `json:"spent_cents"`
+	UsedPercent  float64 `json:"used_percent,omitempty"`
+}
+
+// AgentStatusHandler serves the agent status card. Every store except the
+// agent store is optional.
+type AgentStatusHandler struct {
+	agents     store.AgentStore
+	heartbeats store.HeartbeatStore
+	cron       store.CronStore
+	memory     store.MemoryStore
+	tracing    store.TracingStore
+	isOwner    func(string) bool
+	queueDepth func(agentKey string) (active, queued int)
+}
+
+// NewAgentStatusHandler creates a handler for the agent status card.
+func NewAgentStatusHandler(agents store.AgentStore, heartbeats store.HeartbeatStore, cron store.CronStore, memory store.MemoryStore, tracing store.TracingStore, isOwner func(string) bool) *AgentStatusHandler {
+	return &AgentStatusHandler{agents: agents, heartbeats: heartbeats, cron: cron, memory: memory, tracing: tracing, isOwner: isOwner}
+}
+
+// SetQueueDepth sets the scheduler lookup for per-agent queue depth.
+// The scheduler is created after HTTP handlers, so this is wired late.
+func (h *AgentStatusHandler) SetQueueDepth(fn func(agentKey string) (active, queued int)) {
+	h.queueDepth = fn
+}
+
+// RegisterRoutes registers the status route on the given mux.
+func (h *AgentStatusHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/agents/{id}/status", requireAuth("", h.handleStatus))
+}
+
+// GET /v1/agents/{id}/status — composite health/usage/schedule view
+func (h *AgentStatusHandler) handleStatus(w http.ResponseWriter, r *http.Request) {
+	ag, ok := resolveAccessibleAgent(w, r, h.agents, h.isOwner)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, h.buildCard(r.Context(), ag, time.Now()))
+}
+
+// buildCard assembles the status card. Section failures are logged and the
+// section left out so one slow or broken store doesn't hide the rest.
+func (h *AgentStatusHandler) buildCard(ctx context.Context, ag *store.AgentData, now time.Time) AgentStatusCard {
+	card := AgentStatusCard{
+		AgentID:      ag.ID.String(),
+		AgentKey:     ag.AgentKey,
+		DisplayName:  ag.DisplayName,
+		Status:       ag.Status,
+		Provider:     ag.Provider,
+		Model:        ag.Model,
+		RecentErrors: []AgentRunSummary{},
+		GeneratedAt:  now.UTC(),
+	}
+
+	if h.queueDepth != nil {
+		active, queued := h.queueDepth(ag.AgentKey)
+		card.Queue = &AgentQueueStatus{Active: active, Queued: queued}
+	}
+
+	if h.tracing != nil {
+		agentID := ag.ID
+		if traces, err := h.tracing.ListTraces(ctx, store.TraceListOpts{AgentID: &agentID, Limit: 1}); err != nil {
+			slog.Warn("agent.status: list traces failed", "agent", ag.AgentKey, "error", err)
+		} else if len(traces) > 0 {
+			run := runSummary(traces[0])
+			card.LastRun = &run
+		}
+		if traces, err := h.tracing.ListTraces(ctx, store.TraceListOpts{AgentID: &agentID, Status: store.TraceStatusError, Limit: recentErrorLimit}); err != nil {
+			slog.Warn("agent.status: list error traces failed", "agent", ag.AgentKey, "error", err)
+		} else {
+			for _, t := range traces {
+				card.RecentErrors = append(card.RecentErrors, runSummary(t))
+			}
+		}
+		spent, err := h.tracing.GetMonthlyAgentCost(ctx, ag.ID, now.UTC().Year(), now.UTC().Month())
+		if err != nil {
+			slog.Warn("agent.status: monthly cost failed", "agent", ag.AgentKey, "error", err)
+		} else {
+			card.Budget = budgetStatus(ag.BudgetMonthlyCents, spent)
+		}
+	}
+
+	if h.heartbeats != nil {
+		hb, err := h.heartbeats.Get(ctx, ag.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("agent.status: load heartbeat failed", "agent", ag.AgentKey, "error", err)
+		} else if hb != nil {
+			card.Heartbeat = &AgentHeartbeatStatus{
+				Enabled:   hb.Enabled,
+				NextRunAt: hb.NextRunAt,
+				LastRunAt: hb.LastRunAt,
+			}
+			if hb.LastStatus != nil {
+				card.Heartbeat.LastStatus = *hb.LastStatus
+			}
+			if hb.IsSnoozed(now) {
+				card.Heartbeat.SnoozedUntil = hb.SnoozedUntil()
+			}
+		}
+	}
+
+	if h.cron != nil {
+		card.NextCron = nextCronWake(h.cron.ListJobs(ctx, false, ag.ID.String(), ""))
+	}
+
+	if h.memory != nil {
+		if stats, err := h.memory.Stats(ctx, ag.ID.String()); err != nil {
+			slog.Warn("agent.status: memory stats failed", "agent", ag.AgentKey, "error", err)
+		} else {
+			card.Memory = stats
+		}
+	}
+
+	return card
+}
+
+func runSummary(t store.TraceData) AgentRunSummary {
+	return AgentRunSummary{
+		TraceID:    t.ID.String(),
+		Status:     t.Status,
+		Channel:    t.Channel,
+		StartedAt:  t.StartTime,
+		DurationMS: t.DurationMS,
+		Error:      t.Error,
+	}
+}
+
+// nextCronWake returns the enabled job with the earliest scheduled run, or nil.
+func nextCronWake(jobs []store.CronJob) *AgentCronWake {
+	var next *AgentCronWake
+	for _, j := range jobs {
+		if !j.Enabled || j.State.NextRunAtMS == nil {
+			continue
+		}
+		at := time.UnixMilli(*j.State.NextRunAtMS).UTC()
+		if next == nil || at.Before(next.NextRunAt) {
+			next = &AgentCronWake{JobID: j.ID, Name: j.Name, NextRunAt: at}
+		}
+	}
+	return next
+}
+
+// budgetStatus converts the month's spend (USD) into cents against the budget.
+func budgetStatus(budgetCents *int, spentUSD float64) *AgentBudgetStatus {
+	b := &AgentBudgetStatus{MonthlyCents: budgetCents, SpentCents: int(math.Round(spentUSD * 100))}
+	if budgetCents != nil && *budgetCents > 0 {
+		b.UsedPercent = math.Round(float64(b.SpentCents)*10000/float64(*budgetCents)) / 100
+	}
+	return b
+}
+
+// resolveAccessibleAgent loads the agent named by the {id} path value (UUID
+// or agent key) and checks the caller may access it, writing the error
+// response otherwise. Admins and system owners may access every agent.
+func resolveAccessibleAgent(w http.ResponseWriter, r *http.Request, agents store.AgentStore, isOwner func(string) bool) (*store.AgentData, bool) {
+	locale := store.LocaleFromContext(r.Context())
+	idStr := r.PathValue("id")
+
+	var ag *store.AgentData
+	var err error
+	if id, parseErr := uuid.Parse(idStr); parseErr == nil {
+		ag, err = agents.GetByID(r.Context(), id)
+	} else {
+		ag, err = agents.GetByKey(r.Context(), idStr)
+	}
+	if err != nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "agent", idStr))
+		return nil, false
+	}
+
+	userID := store.UserIDFromContext(r.Context())
+	if userID != "" && !(isOwner != nil && isOwner(userID)) &&
+		!permissions.HasMinRole(permissions.Role(store.RoleFromContext(r.Context())), permissions.RoleAdmin) {
+		if ok, _, _ := agents.CanAccess(r.Context(), ag.ID, userID); !ok {
+			writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgNoAccess, "agent"))
+			return nil, false
+		}
+	}
+	return ag, true
+}
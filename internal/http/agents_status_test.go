@@ -0,0 +1,38 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestNextCronWake(t *testing.T) {
+	ms := func(t time.Time) *int64 { v := t.UnixMilli(); return &v }
+	now := time.Now().Truncate(time.Millisecond)
+
+	jobs := []store.CronJob{
+		{ID: "late", Name: "weekly", Enabled: true, State: store.CronJobState{NextRunAtMS: ms(now.Add(48 * time.Hour))}},
+		{ID: "off", Name: "disabled", Enabled: false, State: store.CronJobState{NextRunAtMS: ms(now.Add(time.Minute))}},
+		{ID: "soon", Name: "hourly", Enabled: true, State: store.CronJobState{NextRunAtMS: ms(now.Add(time.Hour))}},
+		{ID: "done", Name: "one-shot", Enabled: true},
+	}
+	next := nextCronWake(jobs)
+	if next == nil || next.JobID != "soon" || !next.NextRunAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("nextCronWake() = %+v, want job soon", next)
+	}
+	if nextCronWake(nil) != nil {
+		t.Error("nextCronWake(nil) should be nil")
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	budget := 2000
+	b := budgetStatus(&budget, 5.004)
+	if b.SpentCents != 500 || b.UsedPercent != 25 {
+		t.Errorf("budgetStatus = %+v, want 500 cents / 25%%", b)
+	}
+	if b := budgetStatus(nil, 1.5); b.SpentCents != 150 || b.UsedPercent != 0 || b.MonthlyCents != nil {
+		t.Errorf("unlimited budgetStatus = %+v", b)
+	}
+}
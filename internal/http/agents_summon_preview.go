@@ -0,0 +1,176 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// summonPreviewAgent resolves the {id} agent for the summon preview endpoints,
+// enforcing the same owner check as regenerate. Writes the error response and
+// returns nil on failure.
+func (h *AgentsHandler) summonPreviewAgent(w http.ResponseWriter, r *http.Request) *store.AgentData {
+	locale := store.LocaleFromContext(r.Context())
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgInvalidID, "agent")})
+		return nil
+	}
+	ag, err := h.agents.GetByID(r.Context(), id)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "agent", id.String())})
+		return nil
+	}
+	if !h.canManageAgent(r, ag) {
+		writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgOwnerOnly, "regenerate agent")})
+		return nil
+	}
+	if h.summoner == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": i18n.T(locale, i18n.MsgSummoningUnavailable)})
+		return nil
+	}
+	return ag
+}
+
+// summonPreviewID parses {previewID}, writing a 400 on failure.
+func summonPreviewID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(r.PathValue("previewID"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(store.LocaleFromContext(r.Context()), i18n.MsgInvalidID, "preview")})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleSummonPreview starts a regeneration preview: the LLM proposes context
+// file edits, which are diffed but not written. Pass preview_id to refine an
+// earlier preview with a follow-up prompt.
+//
+//	POST /v1/agents/{id}/summon/preview  {"prompt": "...", "preview_id": "..."}
+func (h *AgentsHandler) handleSummonPreview(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	ag := h.summonPreviewAgent(w, r)
+	if ag == nil {
+		return
+	}
+
+	var req struct {
+		Prompt    string     `json:"prompt"`
+		PreviewID *uuid.UUID `json:"preview_id,omitempty"`
+	}
+	if !bindJSON(w, r, locale, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgRequired, "prompt")})
+		return
+	}
+	if ag.Status == store.AgentStatusSummoning {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": i18n.T(locale, i18n.MsgAlreadySummoning)})
+		return
+	}
+
+	p, err := h.summoner.StartPreview(ag.ID, store.TenantIDFromContext(r.Context()), ag.Provider, ag.Model, req.Prompt, req.PreviewID)
+	if !writeSummonPreviewError(w, locale, err, nil) {
+		return
+	}
+	emitAudit(h.msgBus, r, "agent.summon_previewed", "agent", ag.ID.String())
+	writeJSON(w, http.StatusAccepted, p)
+}
+
+// handleGetSummonPreview returns a preview with per-file diffs.
+//
+//	GET /v1/agents/{id}/summon/preview/{previewID}
+func (h *AgentsHandler) handleGetSummonPreview(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	ag := h.summonPreviewAgent(w, r)
+	if ag == nil {
+		return
+	}
+	previewID, ok := summonPreviewID(w, r)
+	if !ok {
+		return
+	}
+	p, ok := h.summoner.Preview(ag.ID, previewID)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "preview", previewID.String())})
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+// handleApplySummonPreview writes accepted changes from a preview.
+//
+//	POST /v1/agents/{id}/summon/preview/{previewID}/apply  {"files": ["SOUL.md"], "force": false}
+func (h *AgentsHandler) handleApplySummonPreview(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	ag := h.summonPreviewAgent(w, r)
+	if ag == nil {
+		return
+	}
+	previewID, ok := summonPreviewID(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Files []string `json:"files,omitempty"` // empty = all changed files
+		Force bool     `json:"force,omitempty"` // apply even if files changed since the preview
+	}
+	if r.ContentLength != 0 && !bindJSON(w, r, locale, &req) {
+		return
+	}
+
+	applied, err := h.summoner.ApplyPreview(r.Context(), ag.ID, store.TenantIDFromContext(r.Context()), previewID, req.Files, req.Force)
+	if !writeSummonPreviewError(w, locale, err, applied) {
+		return
+	}
+	emitAudit(h.msgBus, r, "agent.regenerated", "agent", ag.ID.String())
+	writeJSON(w, http.StatusOK, map[string]any{"applied": applied})
+}
+
+// handleDiscardSummonPreview drops a preview without applying it.
+//
+//	DELETE /v1/agents/{id}/summon/preview/{previewID}
+func (h *AgentsHandler) handleDiscardSummonPreview(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	ag := h.summonPreviewAgent(w, r)
+	if ag == nil {
+		return
+	}
+	previewID, ok := summonPreviewID(w, r)
+	if !ok {
+		return
+	}
+	if !h.summoner.DiscardPreview(ag.ID, previewID) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "preview", previewID.String())})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"ok": "true"})
+}
+
+// writeSummonPreviewError maps summoner preview errors to responses; stale
+// lists the drifted files for errSummonPreviewStale. Returns true when err is
+// nil and the caller should continue.
+func writeSummonPreviewError(w http.ResponseWriter, locale string, err error, stale []string) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, errSummonPreviewNotFound):
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "preview", "")})
+	case errors.Is(err, errSummonPreviewNotReady):
+		writeJSON(w, http.StatusConflict, map[string]string{"error": i18n.T(locale, i18n.MsgSummonPreviewNotReady, SummonPreviewGenerating+"/"+SummonPreviewFailed)})
+	case errors.Is(err, errSummonPreviewStale):
+		writeJSON(w, http.StatusConflict, map[string]any{
+			"error": i18n.T(locale, i18n.MsgSummonPreviewStale, strings.Join(stale, ", ")),
+			"stale": stale,
+		})
+	default:
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return false
+}
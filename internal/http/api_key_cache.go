@@ -73,11 +73,7 @@ func (c *apiKeyCache) getOrFetch(ctx context.Context, hash string) (*store.APIKe
 		return nil, ""
 	}
 
-	scopes := make([]permissions.Scope, len(keyData.Scopes))
-	for i, s := range keyData.Scopes {
-		scopes[i] = permissions.Scope(s)
-	}
-	role := permissions.RoleFromScopes(scopes)
+	role := permissions.RoleFromScopes(keyScopes(keyData))
 
 	c.mu.Lock()
 	c.entries[hash] = &cacheEntry{
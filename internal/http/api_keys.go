@@ -31,6 +31,7 @@ func (h *APIKeysHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/api-keys", h.adminAuth(h.handleList))
 	mux.HandleFunc("POST /v1/api-keys", h.adminAuth(h.handleCreate))
 	mux.HandleFunc("POST /v1/api-keys/{id}/revoke", h.adminAuth(h.handleRevoke))
+	mux.HandleFunc("POST /v1/api-keys/{id}/rotate", h.adminAuth(h.handleRotate))
 }
 
 // adminAuth ensures the caller has admin access (gateway token or API key with admin scope).
@@ -203,6 +204,80 @@ func (h *APIKeysHandler) handleRevoke(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "revoked"})
 }
 
+// handleRotate issues a replacement for an API key (same name, scopes, tenant
+// and owner) and revokes the old one. Ownership rules match handleRevoke.
+func (h *APIKeysHandler) handleRotate(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidID, "API key"))
+		return
+	}
+
+	var input struct {
+		ExpiresIn *int `json:"expires_in"` // seconds; nil = keep the old key's lifetime
+	}
+	if r.ContentLength != 0 && !bindJSON(w, r, locale, &input) {
+		return
+	}
+
+	ctx := r.Context()
+	old, err := h.apiKeys.Get(ctx, id)
+	if err != nil || old == nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "API key", idStr))
+		return
+	}
+	if old.Revoked {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, "API key is revoked"))
+		return
+	}
+	if !store.IsOwnerRole(ctx) {
+		callerTID := store.TenantIDFromContext(ctx)
+		if old.TenantID == uuid.Nil || old.TenantID != callerTID {
+			slog.Warn("security.api_key_rotate_forbidden",
+				"key_id", idStr,
+				"caller_tenant", callerTID,
+				"key_tenant", old.TenantID,
+				"user_id", store.UserIDFromContext(ctx),
+			)
+			writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "API key"))
+			return
+		}
+	}
+
+	raw, hash, prefix, err := crypto.GenerateAPIKey()
+	if err != nil {
+		slog.Error("api_keys.generate failed", "error", err)
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "key generation"))
+		return
+	}
+	key := store.RotatedAPIKey(old, hash, prefix, input.ExpiresIn, extractUserID(r), time.Now())
+	if err := h.apiKeys.Create(ctx, key); err != nil {
+		slog.Error("api_keys.rotate create failed", "error", err, "id", idStr)
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgFailedToCreate, "API key", "internal error"))
+		return
+	}
+	if err := h.apiKeys.Revoke(ctx, id, ""); err != nil {
+		// The replacement exists; report the failure so the caller can retry the revoke.
+		slog.Error("api_keys.rotate revoke failed", "error", err, "id", idStr, "replacement", key.ID)
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "revoke old API key"))
+		return
+	}
+
+	h.emitCacheInvalidate("api_keys", idStr)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":         key.ID,
+		"name":       key.Name,
+		"prefix":     key.Prefix,
+		"key":        raw, // shown only once!
+		"scopes":     key.Scopes,
+		"expires_at": key.ExpiresAt,
+		"created_at": key.CreatedAt,
+		"replaces":   old.ID,
+	})
+}
+
 func (h *APIKeysHandler) emitCacheInvalidate(kind, key string) {
 	if h.msgBus == nil {
 		return
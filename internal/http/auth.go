@@ -2,7 +2,9 @@ package http
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/hex"
 	"log/slog"
 	"net/http"
 	"slices"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/crypto"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
@@ -83,6 +86,7 @@ var pkgAPIKeyCache *apiKeyCache
 var pkgPairingStore store.PairingStore
 var pkgTenantCache *tenantCache
 var pkgOwnerIDs []string
+var pkgScopedTokens map[string]*store.APIKeyData // token SHA-256 → synthetic key
 
 // InitGatewayToken sets the gateway bearer token for HTTP auth.
 // Must be called once during server startup before handling requests.
@@ -90,6 +94,42 @@ func InitGatewayToken(token string) {
 	pkgGatewayToken = token
 }
 
+// InitScopedTokens registers the config-defined static scoped tokens
+// (gateway.scoped_tokens). Each authenticates like a system-level API key with
+// the listed scopes. Entries with a malformed hash or an unknown scope are
+// skipped with a warning.
+func InitScopedTokens(tokens []config.ScopedToken) {
+	m := make(map[string]*store.APIKeyData, len(tokens))
+	for _, t := range tokens {
+		hash := strings.ToLower(strings.TrimSpace(t.TokenHash))
+		if b, err := hex.DecodeString(hash); err != nil || len(b) != sha256.Size {
+			slog.Warn("security.scoped_token_invalid", "name", t.Name, "reason", "token_hash must be a hex SHA-256 digest")
+			continue
+		}
+		valid := len(t.Scopes) > 0
+		for _, sc := range t.Scopes {
+			if !permissions.ValidScope(sc) {
+				valid = false
+			}
+		}
+		if !valid {
+			slog.Warn("security.scoped_token_invalid", "name", t.Name, "reason", "missing or unknown scope", "scopes", t.Scopes)
+			continue
+		}
+		// Bound to the master tenant: a Nil tenant would make it a
+		// system-level key that can switch tenants per request.
+		m[hash] = &store.APIKeyData{
+			Name:      t.Name,
+			Prefix:    "static",
+			Scopes:    t.Scopes,
+			OwnerID:   t.UserID,
+			TenantID:  store.MasterTenantID,
+			CreatedBy: "config",
+		}
+	}
+	pkgScopedTokens = m
+}
+
 // InitAPIKeyCache initializes the shared API key cache with TTL and pubsub invalidation.
 // Must be called once during server startup before handling requests.
 func InitAPIKeyCache(s store.APIKeyStore, mb *bus.MessageBus) {
@@ -133,23 +173,50 @@ func InitTenantStore(ts store.TenantStore, mb *bus.MessageBus) {
 	pkgTenantCache = newTenantCache(ts, 5*time.Minute)
 	if mb != nil {
 		mb.Subscribe("http-tenant-cache", func(e bus.Event) {
-			if p, ok := e.Payload.(bus.CacheInvalidatePayload); ok && p.Kind == bus.CacheKindTenants {
+			p, ok := e.Payload.(bus.CacheInvalidatePayload)
+			if !ok {
+				return
+			}
+			switch p.Kind {
+			case bus.CacheKindTenants:
 				pkgTenantCache.invalidateAll()
+			case bus.CacheKindTenantUsers:
+				pkgTenantCache.invalidateRoles()
 			}
 		})
 	}
 }
 
-// ResolveAPIKey checks if the bearer token is a valid API key using the shared cache.
-// Returns the key data and derived role, or nil if not found/expired/revoked.
+// ResolveAPIKey checks if the bearer token is a static scoped token or a valid
+// API key (via the shared cache). Returns the key data and derived role, or nil
+// if not found/expired/revoked.
 func ResolveAPIKey(ctx context.Context, token string) (*store.APIKeyData, permissions.Role) {
-	if pkgAPIKeyCache == nil || token == "" {
+	if token == "" {
+		return nil, ""
+	}
+	return resolveAPIKeyHash(ctx, crypto.HashAPIKey(token))
+}
+
+// resolveAPIKeyHash is ResolveAPIKey for an already hashed token.
+func resolveAPIKeyHash(ctx context.Context, hash string) (*store.APIKeyData, permissions.Role) {
+	if key, ok := pkgScopedTokens[hash]; ok {
+		return key, permissions.RoleFromScopes(keyScopes(key))
+	}
+	if pkgAPIKeyCache == nil {
 		return nil, ""
 	}
-	hash := crypto.HashAPIKey(token)
 	return pkgAPIKeyCache.getOrFetch(ctx, hash)
 }
 
+// keyScopes converts the stored scope strings of an API key.
+func keyScopes(key *store.APIKeyData) []permissions.Scope {
+	scopes := make([]permissions.Scope, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = permissions.Scope(s)
+	}
+	return scopes
+}
+
 // authResult holds the resolved authentication state for an HTTP request.
 type authResult struct {
 	Role          permissions.Role
@@ -157,42 +224,144 @@ type authResult struct {
 	KeyData       *store.APIKeyData // non-nil when authenticated via API key
 	TenantID      uuid.UUID         // resolved tenant; always concrete after resolution
 	TenantSlug    string            // resolved tenant slug for filesystem paths
+	UserID        string            // session-bound user ID (cookie auth); overrides X-GoClaw-User-Id
+	SessionID     string            // admin UI session ID when authenticated via cookie
 }
 
 // resolveAuth determines the caller's role from the request.
-// Priority: gateway token → API key → no-auth fallback.
+// Priority: gateway token → OIDC token → API key → no-auth fallback.
 func resolveAuth(r *http.Request) authResult {
 	return resolveAuthWithBearer(r, extractBearerToken(r))
 }
 
 // resolveAuthWithBearer is like resolveAuth but accepts a pre-extracted bearer token.
 // Useful for handlers that also accept tokens from query params.
+// Without a bearer, an admin UI session cookie (plus CSRF header on writes) is accepted.
+// Keys holding only chat/observe scopes are refused: they are limited to
+// their WebSocket methods (and, for chat, the resolveChatAuth endpoints).
 func resolveAuthWithBearer(r *http.Request, bearer string) authResult {
-	// Gateway token → admin.
-	// Only configured owner IDs get unrestricted tenant scoping; other callers may
-	// only narrow to tenants where the supplied user already has membership.
-	if pkgGatewayToken != "" && tokenMatch(bearer, pkgGatewayToken) {
-		userID := extractUserID(r)
-		isOwner := isHTTPOwnerID(userID, pkgOwnerIDs)
-		role := permissions.RoleAdmin
-		if isOwner {
-			role = permissions.RoleOwner
+	res := resolveCredential(r, bearer)
+	if res.KeyData != nil && permissions.NarrowScopes(keyScopes(res.KeyData)) {
+		slog.Warn("security.http_narrow_scope_denied", "path", r.URL.Path, "key", res.KeyData.Name)
+		return authResult{}
+	}
+	return applyUserRole(r, res)
+}
+
+// resolveChatAuth is resolveAuth for the OpenAI-compatible chat endpoints,
+// which additionally accept keys scoped to operator.chat.
+func resolveChatAuth(r *http.Request) authResult {
+	res := resolveCredential(r, extractBearerToken(r))
+	if res.KeyData != nil {
+		scopes := keyScopes(res.KeyData)
+		if permissions.NarrowScopes(scopes) && !slices.Contains(scopes, permissions.ScopeChat) {
+			return authResult{}
 		}
-		res := authResult{Role: role, Authenticated: true}
-		tenantVal := r.Header.Get("X-GoClaw-Tenant-Id")
-		if isOwner {
-			res.TenantID = resolveScopedTenant(r.Context(), tenantVal)
-		} else {
-			tenantID, allowed := resolveTenantHint(r.Context(), tenantVal, userID)
-			if !allowed {
-				return authResult{}
-			}
-			res.TenantID = tenantID
+	}
+	return applyUserRole(r, res)
+}
+
+// applyUserRole caps the credential's role at the caller's role in the
+// tenant_users table, so a frontend holding the gateway token (or a key bound
+// to a user) acts with that user's permissions. It never raises a role.
+// Configured owners and anonymous callers keep the credential's role; a user
+// without a membership row is treated as a member.
+func applyUserRole(r *http.Request, res authResult) authResult {
+	return capUserRole(r.Context(), res, boundUserID(r, res))
+}
+
+// capUserRole is applyUserRole for a known user ID.
+func capUserRole(ctx context.Context, res authResult, userID string) authResult {
+	if isHTTPOwnerID(userID, pkgOwnerIDs) {
+		return res
+	}
+	return capTenantRole(ctx, res, userID)
+}
+
+// capTenantRole caps res at userID's tenant_users role, without exempting
+// configured owner IDs.
+func capTenantRole(ctx context.Context, res authResult, userID string) authResult {
+	if !res.Authenticated || res.Role == permissions.RoleOwner || pkgTenantCache == nil || userID == "" {
+		return res
+	}
+	tenantID := res.TenantID
+	if tenantID == uuid.Nil {
+		tenantID = store.MasterTenantID
+	}
+	tenantRole, err := pkgTenantCache.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		// Fail closed: a credential must not keep its full role when the
+		// user's own role cannot be checked.
+		slog.Warn("security.http_user_role_lookup_failed", "user", userID, "tenant_id", tenantID, "error", err)
+		return authResult{}
+	}
+	if tenantRole == "" {
+		// No membership row: naming an unknown user must not keep the
+		// credential's full role.
+		tenantRole = store.TenantRoleMember
+	}
+	role := roleFromTenantRole(tenantRole)
+	// Only step down. A read-only credential stays a viewer even for a
+	// member, who could otherwise chat.
+	if role == permissions.RoleMember && res.Role == permissions.RoleViewer {
+		return res
+	}
+	if role != res.Role && permissions.HasMinRole(res.Role, role) {
+		slog.Debug("security.http_role_capped", "user", userID, "from", string(res.Role), "to", string(role))
+		res.Role = role
+	}
+	return res
+}
+
+// boundUserID returns the user a request acts as: the session user, the
+// key's owner, or the X-GoClaw-User-Id header. The header is ignored in dev
+// mode (no gateway token), where enrichContext forces "system".
+func boundUserID(r *http.Request, res authResult) string {
+	switch {
+	case res.UserID != "":
+		return res.UserID
+	case res.KeyData != nil && res.KeyData.OwnerID != "":
+		return res.KeyData.OwnerID
+	case pkgGatewayToken != "" || res.KeyData != nil:
+		return extractUserID(r)
+	}
+	return ""
+}
+
+// roleFromTenantRole maps a tenant_users role to an API role. Tenant owners
+// administer their tenant but are not system owners. Unknown values map to
+// RoleNone, which denies everything.
+func roleFromTenantRole(role string) permissions.Role {
+	switch role {
+	case store.TenantRoleOwner, store.TenantRoleAdmin:
+		return permissions.RoleAdmin
+	case store.TenantRoleOperator:
+		return permissions.RoleOperator
+	case store.TenantRoleMember:
+		return permissions.RoleMember
+	case store.TenantRoleViewer:
+		return permissions.RoleViewer
+	}
+	return permissions.RoleNone
+}
+
+// resolveCredential maps the bearer (or UI session cookie) to an authResult.
+func resolveCredential(r *http.Request, bearer string) authResult {
+	if bearer == "" {
+		if res, _, ok := resolveUISession(r); ok {
+			return res
 		}
-		if res.TenantID == uuid.Nil {
-			res.TenantID = store.MasterTenantID
+	}
+	// Gateway token → admin.
+	if pkgGatewayToken != "" && tokenMatch(bearer, pkgGatewayToken) {
+		return userCredential(r.Context(), permissions.RoleAdmin, extractUserID(r), r.Header.Get("X-GoClaw-Tenant-Id"))
+	}
+	// Gateway-issued OIDC token → its role, bound to the token's user.
+	if userID, role, ok := verifyOIDCToken(bearer); ok {
+		res := oidcCredential(r.Context(), role, userID, r.Header.Get("X-GoClaw-Tenant-Id"))
+		if res.Authenticated {
+			res.UserID = userID
 		}
-		res.TenantSlug = resolveTenantSlug(r.Context(), res.TenantID)
 		return res
 	}
 	// API key → role from scopes
@@ -233,13 +402,54 @@ func resolveAuthWithBearer(r *http.Request, bearer string) authResult {
 			slog.Warn("security.http_pairing_auth_failed", "sender_id", senderID, "ip", r.RemoteAddr)
 		}
 	}
-	// No auth configured → admin (no token = dev/single-user mode, full access)
-	if pkgGatewayToken == "" {
+	// No auth configured → admin (no token = dev/single-user mode, full access).
+	// OIDC login counts as auth: without a token, callers must log in.
+	if pkgGatewayToken == "" && pkgOIDC == nil {
 		return authResult{Role: permissions.RoleAdmin, Authenticated: true, TenantID: store.MasterTenantID}
 	}
 	return authResult{}
 }
 
+// userCredential resolves the tenant for a credential acting as userID.
+// Only configured owner IDs become RoleOwner with unrestricted tenant
+// scoping; other callers may only narrow to tenants where the user already
+// has membership.
+func userCredential(ctx context.Context, role permissions.Role, userID, tenantVal string) authResult {
+	if !isHTTPOwnerID(userID, pkgOwnerIDs) {
+		return memberCredential(ctx, role, userID, tenantVal)
+	}
+	res := authResult{Role: permissions.RoleOwner, Authenticated: true}
+	res.TenantID = resolveScopedTenant(ctx, tenantVal)
+	if res.TenantID == uuid.Nil {
+		res.TenantID = store.MasterTenantID
+	}
+	res.TenantSlug = resolveTenantSlug(ctx, res.TenantID)
+	return res
+}
+
+// memberCredential resolves the tenant for a non-owner credential with role:
+// tenantVal may only narrow to a tenant where userID has membership.
+func memberCredential(ctx context.Context, role permissions.Role, userID, tenantVal string) authResult {
+	tenantID, allowed := resolveTenantHint(ctx, tenantVal, userID)
+	if !allowed {
+		return authResult{}
+	}
+	res := authResult{Role: role, Authenticated: true, TenantID: tenantID}
+	if res.TenantID == uuid.Nil {
+		res.TenantID = store.MasterTenantID
+	}
+	res.TenantSlug = resolveTenantSlug(ctx, res.TenantID)
+	return res
+}
+
+// oidcCredential resolves an OIDC login. The user ID is asserted by the
+// identity provider, so it never grants RoleOwner, even when it equals a
+// configured owner ID: the login gets the configured OIDC role, capped by the
+// user's tenant_users role.
+func oidcCredential(ctx context.Context, role permissions.Role, userID, tenantVal string) authResult {
+	return capTenantRole(ctx, memberCredential(ctx, role, userID, tenantVal), userID)
+}
+
 func resolveScopedTenant(ctx context.Context, tenantVal string) uuid.UUID {
 	if tenantVal == "" || pkgTenantCache == nil {
 		return uuid.Nil
@@ -310,6 +520,23 @@ func httpMinRole(method string) permissions.Role {
 	}
 }
 
+// allowsRole reports whether role may call an endpoint that requires minRole
+// ("" = httpMinRole). RoleMember marks endpoints open to members (chat and
+// their own data): members pass, everyone else needs the method's default
+// role, so opening an endpoint to members never lets viewers write.
+func allowsRole(role, minRole permissions.Role, method string) bool {
+	if minRole == permissions.RoleMember {
+		if role == permissions.RoleMember {
+			return true
+		}
+		minRole = ""
+	}
+	if minRole == "" {
+		minRole = httpMinRole(method)
+	}
+	return permissions.HasMinRole(role, minRole)
+}
+
 // enrichContext injects locale, role, userID, and tenantID from authResult into ctx.
 // Used by requireAuth middleware and ServeHTTP handlers that do their own auth checks.
 func enrichContext(ctx context.Context, r *http.Request, auth authResult) context.Context {
@@ -325,6 +552,10 @@ func enrichContext(ctx context.Context, r *http.Request, auth authResult) contex
 		)
 		userID = "system"
 	}
+	// Cookie sessions are bound to the user that logged in; ignore the header.
+	if auth.UserID != "" {
+		userID = auth.UserID
+	}
 	// If the API key has a bound owner, force user_id to owner regardless of header.
 	if auth.KeyData != nil && auth.KeyData.OwnerID != "" {
 		if userID != "" && userID != auth.KeyData.OwnerID {
@@ -355,7 +586,8 @@ func enrichContext(ctx context.Context, r *http.Request, auth authResult) contex
 }
 
 // requireAuth is a middleware that checks authentication and minimum role.
-// Pass "" for minRole to auto-detect from HTTP method (GET→Viewer, POST→Operator).
+// Pass "" for minRole to auto-detect from HTTP method (GET→Viewer, POST→Operator),
+// or RoleMember to also admit members (see allowsRole).
 // Injects locale, role, userID and tenantID into request context.
 func requireAuth(minRole permissions.Role, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -370,11 +602,11 @@ func requireAuth(minRole permissions.Role, next http.HandlerFunc) http.HandlerFu
 		}
 
 		required := minRole
-		if required == "" {
+		if required == "" || required == permissions.RoleMember {
 			required = httpMinRole(r.Method)
 		}
 
-		if !permissions.HasMinRole(auth.Role, required) {
+		if !allowsRole(auth.Role, minRole, r.Method) {
 			writeJSON(w, http.StatusForbidden, map[string]string{
 				"error": i18n.T(locale, i18n.MsgPermissionDenied, r.URL.Path+" requires "+string(required)+" role"),
 			})
@@ -386,34 +618,24 @@ func requireAuth(minRole permissions.Role, next http.HandlerFunc) http.HandlerFu
 	}
 }
 
-// requireAuthBearer is like requireAuth but accepts a pre-extracted bearer token.
-// Used by handlers that accept tokens from query params (files, media).
-// Returns the authenticated request with user context applied (owner_id enforcement included).
-func requireAuthBearer(minRole permissions.Role, bearer string, w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
-	locale := extractLocale(r)
-	auth := resolveAuthWithBearer(r, bearer)
-
-	if !auth.Authenticated {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{
-			"error": i18n.T(locale, i18n.MsgUnauthorized),
-		})
-		return r, false
-	}
-
-	required := minRole
-	if required == "" {
-		required = httpMinRole(r.Method)
-	}
-
-	if !permissions.HasMinRole(auth.Role, required) {
-		writeJSON(w, http.StatusForbidden, map[string]string{
-			"error": i18n.T(locale, i18n.MsgPermissionDenied, r.URL.Path+" requires "+string(required)+" role"),
-		})
-		return r, false
+// requireAuthOrFileToken is requireAuth for download routes that also accept
+// a short-lived signed file token (?ft=) bound to signedPath(r), so links can
+// be opened without a bearer header. A present but invalid token is rejected
+// without falling back to the bearer.
+func requireAuthOrFileToken(minRole permissions.Role, signedPath func(*http.Request) string, next http.HandlerFunc) http.HandlerFunc {
+	withAuth := requireAuth(minRole, next)
+	return func(w http.ResponseWriter, r *http.Request) {
+		ft := r.URL.Query().Get("ft")
+		if ft == "" {
+			withAuth(w, r)
+			return
+		}
+		if !VerifyFileToken(ft, signedPath(r), FileSigningKey()) {
+			http.Error(w, "invalid or expired file token", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
 	}
-
-	ctx := enrichContext(r.Context(), r, auth)
-	return r.WithContext(ctx), true
 }
 
 // extractLocale parses the Accept-Language header and returns a supported locale.
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func setupScopedTokens(t *testing.T, tokens []config.ScopedToken) {
+	t.Helper()
+	old := pkgScopedTokens
+	InitScopedTokens(tokens)
+	t.Cleanup(func() { pkgScopedTokens = old })
+}
+
+func TestInitScopedTokens_SkipsInvalidEntries(t *testing.T) {
+	setupScopedTokens(t, []config.ScopedToken{
+		{Name: "ok", TokenHash: crypto.HashAPIKey("a"), Scopes: []string{"operator.observe"}},
+		{Name: "bad-hash", TokenHash: "abc", Scopes: []string{"operator.read"}},
+		{Name: "bad-scope", TokenHash: crypto.HashAPIKey("b"), Scopes: []string{"operator.everything"}},
+		{Name: "no-scope", TokenHash: crypto.HashAPIKey("c")},
+	})
+	if len(pkgScopedTokens) != 1 {
+		t.Fatalf("registered %d tokens, want 1", len(pkgScopedTokens))
+	}
+}
+
+func TestResolveAPIKey_StaticScopedToken(t *testing.T) {
+	setupScopedTokens(t, []config.ScopedToken{
+		{Name: "grafana", TokenHash: crypto.HashAPIKey("observe-token"), Scopes: []string{"operator.observe"}},
+		{Name: "bot", TokenHash: crypto.HashAPIKey("chat-token"), Scopes: []string{"operator.chat"}, UserID: "bot-user"},
+	})
+
+	key, role := ResolveAPIKey(t.Context(), "chat-token")
+	if key == nil || key.Name != "bot" || key.OwnerID != "bot-user" || key.TenantID != store.MasterTenantID {
+		t.Fatalf("key = %+v", key)
+	}
+	if role != permissions.RoleOperator {
+		t.Errorf("chat token role = %v, want operator", role)
+	}
+	if key, _ := ResolveAPIKey(t.Context(), "unknown"); key != nil {
+		t.Errorf("unknown token resolved to %+v", key)
+	}
+}
+
+func TestResolveAuth_NarrowScopedTokenRefusedOnHTTP(t *testing.T) {
+	setupTestCache(t, nil)
+	setupTestToken(t, "gw-token")
+	setupScopedTokens(t, []config.ScopedToken{
+		{Name: "bot", TokenHash: crypto.HashAPIKey("chat-token"), Scopes: []string{"operator.chat"}},
+		{Name: "grafana", TokenHash: crypto.HashAPIKey("observe-token"), Scopes: []string{"operator.observe"}},
+		{Name: "ci", TokenHash: crypto.HashAPIKey("read-token"), Scopes: []string{"operator.read"}},
+	})
+
+	r := httptest.NewRequest("GET", "/v1/agents", nil)
+	r.Header.Set("Authorization", "Bearer chat-token")
+	if auth := resolveAuth(r); auth.Authenticated {
+		t.Error("chat-only token must not authenticate on the general HTTP API")
+	}
+
+	r = httptest.NewRequest("GET", "/v1/agents", nil)
+	r.Header.Set("Authorization", "Bearer read-token")
+	auth := resolveAuth(r)
+	if !auth.Authenticated || auth.Role != permissions.RoleViewer || auth.TenantID != store.MasterTenantID {
+		t.Errorf("read token auth = %+v", auth)
+	}
+
+	r = httptest.NewRequest("POST", "/v1/chat/completions", nil)
+	r.Header.Set("Authorization", "Bearer chat-token")
+	if auth := resolveChatAuth(r); !auth.Authenticated || auth.Role != permissions.RoleOperator {
+		t.Errorf("chat token on chat endpoint = %+v", auth)
+	}
+
+	r = httptest.NewRequest("POST", "/v1/chat/completions", nil)
+	r.Header.Set("Authorization", "Bearer observe-token")
+	if auth := resolveChatAuth(r); auth.Authenticated {
+		t.Error("observe-only token must not authenticate on the chat endpoint")
+	}
+}
+
+func TestResolveAuth_StaticScopedTokenCannotSwitchTenant(t *testing.T) {
+	setupTestCache(t, nil)
+	setupTestToken(t, "gw-token")
+	ts := newMockTenantStore()
+	other := uuid.New()
+	ts.addTenant(store.MasterTenantID, "master")
+	ts.addTenant(other, "acme")
+	setupTestTenantStore(t, ts)
+	setupScopedTokens(t, []config.ScopedToken{
+		{Name: "ci", TokenHash: crypto.HashAPIKey("read-token"), Scopes: []string{"operator.read"}},
+	})
+
+	for _, hint := range []string{other.String(), "acme"} {
+		r := httptest.NewRequest("GET", "/v1/agents", nil)
+		r.Header.Set("Authorization", "Bearer read-token")
+		r.Header.Set("X-GoClaw-Tenant-Id", hint)
+		if auth := resolveAuth(r); !auth.Authenticated || auth.TenantID != store.MasterTenantID {
+			t.Errorf("tenant hint %q: auth = %+v, want master tenant", hint, auth)
+		}
+	}
+}
@@ -206,6 +206,41 @@ func TestResolveAuth_GatewayTokenRejectsUnauthorizedTenantScope(t *testing.T) {
 	}
 }
 
+func TestResolveAuth_TenantRoleCapsCredential(t *testing.T) {
+	setupTestCache(t, map[string]*store.APIKeyData{
+		crypto.HashAPIKey("read-key"):  {Scopes: []string{"operator.read"}},
+		crypto.HashAPIKey("admin-key"): {Scopes: []string{"operator.admin"}, OwnerID: "dave"},
+	})
+	setupTestToken(t, "my-gateway-token")
+	ts := newMockTenantStore()
+	ts.setUserRole(store.MasterTenantID, "alice", store.TenantRoleMember)
+	ts.setUserRole(store.MasterTenantID, "bob", store.TenantRoleOperator)
+	ts.setUserRole(store.MasterTenantID, "system", store.TenantRoleMember)
+	setupTestTenantStore(t, ts)
+
+	tests := []struct {
+		bearer, userID string
+		want           permissions.Role
+	}{
+		{"my-gateway-token", "alice", permissions.RoleMember},
+		{"my-gateway-token", "bob", permissions.RoleOperator},
+		{"my-gateway-token", "carol", permissions.RoleMember}, // no membership row
+		{"my-gateway-token", "system", permissions.RoleOwner}, // configured owner
+		{"read-key", "alice", permissions.RoleViewer},         // never steps up to chat
+		{"read-key", "bob", permissions.RoleViewer},
+		{"read-key", "carol", permissions.RoleViewer},
+		{"admin-key", "", permissions.RoleMember}, // bound owner has no membership row
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/v1/agents", nil)
+		r.Header.Set("Authorization", "Bearer "+tt.bearer)
+		r.Header.Set("X-GoClaw-User-Id", tt.userID)
+		if got := resolveAuth(r).Role; got != tt.want {
+			t.Errorf("%s as %s: role = %v, want %v", tt.bearer, tt.userID, got, tt.want)
+		}
+	}
+}
+
 func TestResolveAuth_WrongToken(t *testing.T) {
 	setupTestCache(t, nil)
 	setupTestToken(t, "correct-token")
@@ -519,6 +554,51 @@ func TestRequireAuth_AutoDetectRole_GET(t *testing.T) {
 	}
 }
 
+func TestRequireAuth_MemberEndpoints(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	tests := []struct {
+		role    permissions.Role
+		minRole permissions.Role
+		method  string
+		want    bool
+	}{
+		{permissions.RoleMember, permissions.RoleMember, "GET", true},
+		{permissions.RoleMember, permissions.RoleMember, "POST", true},
+		{permissions.RoleMember, "", "GET", false},
+		{permissions.RoleViewer, permissions.RoleMember, "GET", true},
+		{permissions.RoleViewer, permissions.RoleMember, "POST", false},
+		{permissions.RoleOperator, permissions.RoleMember, "POST", true},
+	}
+	for _, tt := range tests {
+		if got := allowsRole(tt.role, tt.minRole, tt.method); got != tt.want {
+			t.Errorf("allowsRole(%s, %q, %s) = %v, want %v", tt.role, tt.minRole, tt.method, got, tt.want)
+		}
+	}
+
+	setupTestCache(t, nil)
+	setupTestToken(t, "my-gateway-token")
+	ts := newMockTenantStore()
+	ts.setUserRole(store.MasterTenantID, "alice", store.TenantRoleMember)
+	setupTestTenantStore(t, ts)
+	for path, handler := range map[string]http.HandlerFunc{
+		"/v1/agents/x":    requireAuth("", ok),
+		"/v1/chat/member": requireAuth(permissions.RoleMember, ok),
+	} {
+		r := httptest.NewRequest("GET", path, nil)
+		r.Header.Set("Authorization", "Bearer my-gateway-token")
+		r.Header.Set("X-GoClaw-User-Id", "alice")
+		w := httptest.NewRecorder()
+		handler(w, r)
+		want := http.StatusForbidden
+		if path == "/v1/chat/member" {
+			want = http.StatusOK
+		}
+		if w.Code != want {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, want)
+		}
+	}
+}
+
 func TestInitAPIKeyCache_PubsubInvalidation(t *testing.T) {
 	mb := bus.New()
 	ms := newMockAPIKeyStore()
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BackpressureFunc reports whether agent runs are saturated and, if so, how
+// long a caller should wait before retrying.
+type BackpressureFunc func() (saturated bool, retryAfter time.Duration)
+
+// checkBackpressure sets Retry-After and returns the delay in whole seconds
+// when runs are saturated, so the caller can answer 429 in its own error
+// format. It returns 0 when the request may proceed.
+func checkBackpressure(w http.ResponseWriter, fn BackpressureFunc) int {
+	if fn == nil {
+		return 0
+	}
+	saturated, retryAfter := fn()
+	if !saturated {
+		return 0
+	}
+	secs := max(int((retryAfter+time.Second-1)/time.Second), 1)
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	return secs
+}
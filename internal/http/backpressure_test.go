@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckBackpressure(t *testing.T) {
+	tests := []struct {
+		name      string
+		fn        BackpressureFunc
+		wantSecs  int
+		wantRetry string
+	}{
+		{"no check", nil, 0, ""},
+		{"free", func() (bool, time.Duration) { return false, 0 }, 0, ""},
+		{"saturated rounds up", func() (bool, time.Duration) { return true, 90500 * time.Millisecond }, 91, "91"},
+		{"saturated without estimate", func() (bool, time.Duration) { return true, 0 }, 1, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if got := checkBackpressure(w, tt.fn); got != tt.wantSecs {
+				t.Errorf("checkBackpressure = %d, want %d", got, tt.wantSecs)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.wantRetry {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetry)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/billing"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// BillingHandler serves monthly billing exports built from LLM call spans
+// and usage reports built from run traces.
+type BillingHandler struct {
+	tracing store.TracingStore
+	agents  store.AgentStore // optional: resolves agent keys in exports
+	cfg     *config.Config
+}
+
+// NewBillingHandler creates a handler for billing export endpoints.
+func NewBillingHandler(tracing store.TracingStore, agents store.AgentStore, cfg *config.Config) *BillingHandler {
+	return &BillingHandler{tracing: tracing, agents: agents, cfg: cfg}
+}
+
+// RegisterRoutes registers billing routes on the given mux.
+func (h *BillingHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/usage/export", requireAuth(permissions.RoleAdmin, h.handleExport))
+	mux.HandleFunc("GET /v1/usage", requireAuth("", h.handleReport))
+}
+
+// handleExport returns the billing report for ?month=YYYY-MM.
+// format=json (default) returns the full invoice with tenant/user/agent subtotals;
+// format=csv returns the rows selected by group_by (line, tenant, user or agent).
+func (h *BillingHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	q := r.URL.Query()
+
+	month := q.Get("month")
+	from, to, err := billing.ParseMonth(month)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "format must be json or csv"})
+		return
+	}
+	groupBy := q.Get("group_by")
+	if groupBy == "" {
+		groupBy = billing.GroupByLine
+	}
+	if !billing.ValidGroupBy(groupBy) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "group_by must be line, tenant, user or agent"})
+		return
+	}
+
+	rows, err := h.tracing.GetBillingUsage(r.Context(), store.BillingUsageOpts{From: from, To: to})
+	if err != nil {
+		slog.Error("billing.export query failed", "month", month, "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": i18n.T(locale, i18n.MsgInternalError, "billing export")})
+		return
+	}
+
+	report, err := billing.Build(month, rows, billing.Options{
+		Currency:       h.cfg.Billing.Currency,
+		PriceOverrides: h.cfg.Billing.PriceOverrides,
+		AgentKeys:      h.agentKeys(r, rows),
+	})
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if format == "json" {
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="usage-%s-%s.csv"`, month, groupBy))
+	if err := report.WriteCSV(w, groupBy); err != nil {
+		slog.Error("billing.export write csv failed", "month", month, "error", err)
+	}
+}
+
+// agentKeys resolves agent IDs in rows to agent keys. Deleted agents are
+// simply left without a key; the export still carries their ID.
+func (h *BillingHandler) agentKeys(r *http.Request, rows []store.BillingUsageRow) map[uuid.UUID]string {
+	if h.agents == nil {
+		return nil
+	}
+	seen := map[uuid.UUID]bool{}
+	var ids []uuid.UUID
+	for _, row := range rows {
+		if row.AgentID != nil && !seen[*row.AgentID] {
+			seen[*row.AgentID] = true
+			ids = append(ids, *row.AgentID)
+		}
+	}
+	return h.lookupAgentKeys(r, ids)
+}
+
+// lookupAgentKeys resolves agent IDs to agent keys; unknown IDs are omitted.
+func (h *BillingHandler) lookupAgentKeys(r *http.Request, ids []uuid.UUID) map[uuid.UUID]string {
+	agents, err := h.agents.GetByIDs(r.Context(), ids)
+	if err != nil {
+		slog.Warn("billing agent lookup failed", "error", err)
+		return nil
+	}
+	keys := make(map[uuid.UUID]string, len(agents))
+	for _, a := range agents {
+		keys[a.ID] = a.AgentKey
+	}
+	return keys
+}
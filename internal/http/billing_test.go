@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type billingTracingStore struct {
+	store.TracingStore
+	rows   []store.BillingUsageRow
+	opts   store.BillingUsageOpts
+	report store.UsageReportOpts
+}
+
+func (s *billingTracingStore) GetBillingUsage(_ context.Context, opts store.BillingUsageOpts) ([]store.BillingUsageRow, error) {
+	s.opts = opts
+	return s.rows, nil
+}
+
+func newBillingTestMux(ts *billingTracingStore) *http.ServeMux {
+	cfg := &config.Config{}
+	cfg.Billing.PriceOverrides = map[string]*config.ModelPricing{"gpt-4o": {InputPerMillion: 1_000_000}}
+	h := NewBillingHandler(ts, nil, cfg)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/usage/export", h.handleExport)
+	return mux
+}
+
+func TestBillingExport_JSON(t *testing.T) {
+	ts := &billingTracingStore{rows: []store.BillingUsageRow{
+		{TenantID: uuid.New(), UserID: "alice", Provider: "openai", Model: "gpt-4o", InputTokens: 2, Cost: 0.1, Calls: 1},
+	}}
+	w := httptest.NewRecorder()
+	newBillingTestMux(ts).ServeHTTP(w, httptest.NewRequest("GET", "/v1/usage/export?month=2025-02", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if !ts.opts.From.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) || !ts.opts.To.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("query range = %+v", ts.opts)
+	}
+	var resp struct {
+		Month    string `json:"month"`
+		Currency string `json:"currency"`
+		Total    struct {
+			Cost float64 `json:"cost"`
+		} `json:"total"`
+		Users []struct {
+			UserID string `json:"user_id"`
+		} `json:"users"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Month != "2025-02" || resp.Currency != "USD" || resp.Total.Cost != 2 || len(resp.Users) != 1 {
+		t.Errorf("unexpected report: %+v", resp)
+	}
+}
+
+func TestBillingExport_CSV(t *testing.T) {
+	ts := &billingTracingStore{rows: []store.BillingUsageRow{
+		{TenantID: uuid.New(), UserID: "alice", Provider: "anthropic", Model: "claude", Cost: 0.5, Calls: 3},
+	}}
+	w := httptest.NewRecorder()
+	newBillingTestMux(ts).ServeHTTP(w, httptest.NewRequest("GET", "/v1/usage/export?month=2025-02&format=csv&group_by=user", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("content type = %q", ct)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "month,tenant_id,user_id") || !strings.Contains(lines[1], ",alice,") {
+		t.Errorf("csv = %q", w.Body.String())
+	}
+}
+
+func TestBillingExport_Validation(t *testing.T) {
+	mux := newBillingTestMux(&billingTracingStore{})
+	for _, q := range []string{"", "?month=2025-13", "?month=2025-01&format=xml", "?month=2025-01&group_by=model"} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "/v1/usage/export"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: status = %d, want 400", q, w.Code)
+		}
+	}
+}
+
+func (s *billingTracingStore) GetUsageReport(_ context.Context, opts store.UsageReportOpts) ([]store.UsageReportRow, error) {
+	s.report = opts
+	return []store.UsageReportRow{{Key: "alice", InputTokens: 10, Cost: 0.25, Runs: 2}}, nil
+}
+
+func TestUsageReport(t *testing.T) {
+	ts := &billingTracingStore{}
+	h := NewBillingHandler(ts, nil, &config.Config{})
+	get := func(query, role, user string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/v1/usage"+query, nil)
+		r = r.WithContext(store.WithUserID(store.WithRole(r.Context(), role), user))
+		w := httptest.NewRecorder()
+		h.handleReport(w, r)
+		return w
+	}
+
+	w := get("?group_by=user&from=2025-02-01&to=2025-03-01&user_id=bob", "admin", "root")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	want := store.UsageReportOpts{GroupBy: "user", UserID: "bob", Limit: defaultUsageReportLimit,
+		From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}
+	if ts.report != want {
+		t.Errorf("opts = %+v, want %+v", ts.report, want)
+	}
+	var resp struct {
+		Rows []store.UsageReportRow `json:"rows"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Rows) != 1 || resp.Rows[0].Runs != 2 {
+		t.Errorf("body = %s", w.Body)
+	}
+
+	// Members are limited to their own usage whatever user_id they ask for.
+	if w := get("?user_id=bob", "member", "alice"); w.Code != http.StatusOK || ts.report.UserID != "alice" || ts.report.GroupBy != "agent" {
+		t.Errorf("member: %d, opts %+v", w.Code, ts.report)
+	}
+	if time.Since(ts.report.From) < 29*24*time.Hour {
+		t.Errorf("default window from = %v", ts.report.From)
+	}
+	if w := get("", "member", ""); w.Code != http.StatusForbidden {
+		t.Errorf("anonymous member: %d", w.Code)
+	}
+	for _, q := range []string{"?group_by=model", "?from=yesterday"} {
+		if w := get(q, "admin", "root"); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d", q, w.Code)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,8 +11,10 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/channels"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/sessions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
@@ -24,6 +27,7 @@ type ChatCompletionsHandler struct {
 	isManaged   bool
 	rateLimiter func(string) bool // rate limit check: key → allowed (nil = no limit)
 	postTurn    tools.PostTurnProcessor
+	pressure    BackpressureFunc // lane saturation check (nil = never reject)
 }
 
 // SetPostTurnProcessor sets the post-turn processor for team task dispatch.
@@ -45,11 +49,19 @@ func (h *ChatCompletionsHandler) SetRateLimiter(fn func(string) bool) {
 	h.rateLimiter = fn
 }
 
+// SetBackpressure sets the saturation check that rejects runs with 429.
+func (h *ChatCompletionsHandler) SetBackpressure(fn BackpressureFunc) {
+	h.pressure = fn
+}
+
 type chatCompletionsRequest struct {
 	Model    string        `json:"model"`
 	Messages []chatMessage `json:"messages"`
 	Stream   bool          `json:"stream"`
 	User     string        `json:"user,omitempty"`
+	// ResponseFormat forces a JSON answer: {"type":"json_object"} or
+	// {"type":"json_schema","json_schema":{"name","schema","strict"}}.
+	ResponseFormat json.RawMessage `json:"response_format,omitempty"`
 }
 
 type chatMessage struct {
@@ -75,9 +87,15 @@ type chatChoice struct {
 }
 
 type chatUsage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens        int                `json:"prompt_tokens"`
+	CompletionTokens    int                `json:"completion_tokens"`
+	TotalTokens         int                `json:"total_tokens"`
+	PromptTokensDetails *chatPromptDetails `json:"prompt_tokens_details,omitempty"`
+}
+
+// chatPromptDetails reports prompt-cache hits in the OpenAI response shape.
+type chatPromptDetails struct {
+	CachedTokens int `json:"cached_tokens"`
 }
 
 func (h *ChatCompletionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -88,13 +106,13 @@ func (h *ChatCompletionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Auth + RBAC check (gateway token or API key, operator required for POST)
-	auth := resolveAuth(r)
+	// Auth + RBAC check (gateway token or API key; operator or member required)
+	auth := resolveChatAuth(r)
 	if !auth.Authenticated {
 		http.Error(w, fmt.Sprintf(`{"error":{"message":"%s","type":"invalid_request_error"}}`, i18n.T(locale, i18n.MsgInvalidAuth)), http.StatusUnauthorized)
 		return
 	}
-	if !permissions.HasMinRole(auth.Role, permissions.RoleOperator) {
+	if !allowsRole(auth.Role, permissions.RoleMember, r.Method) {
 		http.Error(w, fmt.Sprintf(`{"error":{"message":"%s","type":"invalid_request_error"}}`, i18n.T(locale, i18n.MsgPermissionDenied, "/v1/chat/completions")), http.StatusForbidden)
 		return
 	}
@@ -115,6 +133,12 @@ func (h *ChatCompletionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	// Backpressure: batch callers back off instead of waiting on a full lane.
+	if secs := checkBackpressure(w, h.pressure); secs > 0 {
+		http.Error(w, fmt.Sprintf(`{"error":{"message":"%s","type":"rate_limit_error"}}`, i18n.T(locale, i18n.MsgAgentsBusy, secs)), http.StatusTooManyRequests)
+		return
+	}
+
 	// Limit request body size to prevent DoS
 	const maxRequestBodySize = 1 << 20 // 1MB
 	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
@@ -129,6 +153,12 @@ func (h *ChatCompletionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	responseFormat, err := providers.ParseResponseFormat(req.ResponseFormat)
+	if err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":{"message":%q,"type":"invalid_request_error"}}`, err.Error()), http.StatusBadRequest)
+		return
+	}
+
 	agentID := extractAgentID(r, req.Model)
 	userID := store.UserIDFromContext(r.Context()) // resolved by enrichContext (respects API key owner binding)
 	if h.isManaged && userID == "" {
@@ -166,26 +196,32 @@ func (h *ChatCompletionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	slog.Info("chat completions request", "agent", agentID, "stream", req.Stream, "user", userID)
 
 	if req.Stream {
-		h.handleStream(w, r, loop, runID, sessionKey, lastMessage, req.Model, userID)
+		h.handleStream(w, r, loop, runID, sessionKey, lastMessage, req.Model, userID, responseFormat)
 	} else {
-		h.handleNonStream(w, r, loop, runID, sessionKey, lastMessage, req.Model, userID)
+		h.handleNonStream(w, r, loop, runID, sessionKey, lastMessage, req.Model, userID, responseFormat)
 	}
 }
 
-func (h *ChatCompletionsHandler) handleNonStream(w http.ResponseWriter, r *http.Request, loop agent.Agent, runID, sessionKey, message, model, userID string) {
+func (h *ChatCompletionsHandler) handleNonStream(w http.ResponseWriter, r *http.Request, loop agent.Agent, runID, sessionKey, message, model, userID string, responseFormat *providers.ResponseFormat) {
 	ctx, drainTeamDispatch := tools.InjectTeamDispatch(r.Context(), h.postTurn)
 	defer drainTeamDispatch()
 
 	result, err := loop.Run(ctx, agent.RunRequest{
-		SessionKey: sessionKey,
-		Message:    message,
-		Channel:    "http",
-		ChatID:     "api",
-		RunID:      runID,
-		UserID:     userID,
-		Stream:     false,
+		SessionKey:     sessionKey,
+		Message:        message,
+		Channel:        "http",
+		ChatID:         "api",
+		RunID:          runID,
+		UserID:         userID,
+		Stream:         false,
+		ResponseFormat: responseFormat,
 	})
 
+	var budgetErr *channels.BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		http.Error(w, fmt.Sprintf(`{"error":{"message":"%s","type":"budget_exceeded"}}`, budgetErr.Error()), http.StatusTooManyRequests)
+		return
+	}
 	if err != nil {
 		locale := store.LocaleFromContext(r.Context())
 		http.Error(w, fmt.Sprintf(`{"error":{"message":"%s"}}`, i18n.T(locale, i18n.MsgInternalError, err.Error())), http.StatusInternalServerError)
@@ -210,13 +246,16 @@ func (h *ChatCompletionsHandler) handleNonStream(w http.ResponseWriter, r *http.
 			CompletionTokens: result.Usage.CompletionTokens,
 			TotalTokens:      result.Usage.TotalTokens,
 		}
+		if result.Usage.CacheReadTokens > 0 {
+			resp.Usage.PromptTokensDetails = &chatPromptDetails{CachedTokens: result.Usage.CacheReadTokens}
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
-func (h *ChatCompletionsHandler) handleStream(w http.ResponseWriter, r *http.Request, loop agent.Agent, runID, sessionKey, message, model, userID string) {
+func (h *ChatCompletionsHandler) handleStream(w http.ResponseWriter, r *http.Request, loop agent.Agent, runID, sessionKey, message, model, userID string, responseFormat *providers.ResponseFormat) {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		locale := store.LocaleFromContext(r.Context())
@@ -238,13 +277,14 @@ func (h *ChatCompletionsHandler) handleStream(w http.ResponseWriter, r *http.Req
 	defer drainTeamDispatch()
 
 	result, err := loop.Run(ctx, agent.RunRequest{
-		SessionKey: sessionKey,
-		Message:    message,
-		Channel:    "http",
-		ChatID:     "api",
-		RunID:      runID,
-		UserID:     userID,
-		Stream:     true,
+		SessionKey:     sessionKey,
+		Message:        message,
+		Channel:        "http",
+		ChatID:         "api",
+		RunID:          runID,
+		UserID:         userID,
+		Stream:         true,
+		ResponseFormat: responseFormat,
 	})
 
 	if err != nil {
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// CronHandler exposes cron job CRUD over HTTP. Jobs live in the managed
+// store, so every gateway instance and API client sees the same set.
+// Non-admin callers only see and manage the jobs they own.
+type CronHandler struct {
+	store   store.CronStore
+	msgBus  *bus.MessageBus
+	isOwner func(string) bool // checks if user ID is a system owner (nil = no owners configured)
+}
+
+// NewCronHandler creates a handler for cron job endpoints.
+func NewCronHandler(s store.CronStore, msgBus *bus.MessageBus, isOwner func(string) bool) *CronHandler {
+	return &CronHandler{store: s, msgBus: msgBus, isOwner: isOwner}
+}
+
+// RegisterRoutes registers all cron routes on the given mux.
+func (h *CronHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/cron", h.auth(h.handleList))
+	mux.HandleFunc("POST /v1/cron", h.auth(h.handleCreate))
+	mux.HandleFunc("GET /v1/cron/{id}", h.auth(h.handleGet))
+	mux.HandleFunc("PATCH /v1/cron/{id}", h.auth(h.handleUpdate))
+	mux.HandleFunc("DELETE /v1/cron/{id}", h.auth(h.handleDelete))
+	mux.HandleFunc("POST /v1/cron/{id}/run", h.auth(h.handleRun))
+	mux.HandleFunc("GET /v1/cron/{id}/runs", h.auth(h.handleRuns))
+}
+
+func (h *CronHandler) auth(next http.HandlerFunc) http.HandlerFunc {
+	return requireAuth("", next)
+}
+
+// canSeeAll reports whether the caller may see every job (admin role or system owner).
+func (h *CronHandler) canSeeAll(r *http.Request) bool {
+	if permissions.HasMinRole(permissions.Role(store.RoleFromContext(r.Context())), permissions.RoleAdmin) {
+		return true
+	}
+	userID := store.UserIDFromContext(r.Context())
+	return userID != "" && h.isOwner != nil && h.isOwner(userID)
+}
+
+// loadJob fetches a job the caller may access, writing the error response otherwise.
+func (h *CronHandler) loadJob(w http.ResponseWriter, r *http.Request) (*store.CronJob, bool) {
+	locale := store.LocaleFromContext(r.Context())
+	job, ok := h.store.GetJob(r.Context(), r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgJobNotFound))
+		return nil, false
+	}
+	if !h.canSeeAll(r) && job.UserID != store.UserIDFromContext(r.Context()) {
+		// Same response as a missing job: don't reveal other users' job IDs.
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgJobNotFound))
+		return nil, false
+	}
+	return job, true
+}
+
+// GET /v1/cron?include_disabled=true&agent_id=X — list jobs
+func (h *CronHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	includeDisabled, _ := strconv.ParseBool(r.URL.Query().Get("include_disabled"))
+	userID := ""
+	if !h.canSeeAll(r) {
+		userID = store.UserIDFromContext(r.Context())
+	}
+	jobs := h.store.ListJobs(r.Context(), includeDisabled, r.URL.Query().Get("agent_id"), userID)
+	writeJSON(w, http.StatusOK, map[string]any{"jobs": jobs, "status": h.store.Status()})
+}
+
+type cronCreateRequest struct {
+	Name           string             `json:"name"`
+	Schedule       store.CronSchedule `json:"schedule"`
+	Message        string             `json:"message"`
+	Deliver        bool               `json:"deliver"`
+	DeliverChannel string             `json:"deliverChannel"`
+	DeliverTo      string             `json:"deliverTo"`
+	WakeHeartbeat  bool               `json:"wakeHeartbeat"`
+	Stateless      *bool              `json:"stateless"` // default true for new crons
+	AgentID        string             `json:"agentId"`
+}
+
+// POST /v1/cron — create a job owned by the caller
+func (h *CronHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	var req cronCreateRequest
+	if !bindJSON(w, r, locale, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "name"))
+		return
+	}
+	if !isValidSlug(req.Name) {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidSlug, "name"))
+		return
+	}
+	if req.Message == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgMsgRequired))
+		return
+	}
+
+	job, err := h.store.AddJob(r.Context(), req.Name, req.Schedule, req.Message, req.Deliver, req.DeliverChannel, req.DeliverTo, req.AgentID, store.UserIDFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, err.Error())
+		return
+	}
+
+	// Fields outside the AddJob signature are applied as an immediate patch,
+	// matching the cron.create WebSocket method.
+	stateless := true
+	if req.Stateless != nil {
+		stateless = *req.Stateless
+	}
+	patch := store.CronJobPatch{Stateless: &stateless}
+	if req.WakeHeartbeat {
+		patch.WakeHeartbeat = &req.WakeHeartbeat
+	}
+	if updated, err := h.store.UpdateJob(r.Context(), job.ID, patch); err == nil {
+		job = updated
+	}
+
+	emitAudit(h.msgBus, r, "cron.created", "cron", job.ID)
+	writeJSON(w, http.StatusCreated, map[string]any{"job": job})
+}
+
+// GET /v1/cron/{id} — get one job
+func (h *CronHandler) handleGet(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.loadJob(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"job": job})
+}
+
+// PATCH /v1/cron/{id} — partial update (store.CronJobPatch)
+func (h *CronHandler) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	if _, ok := h.loadJob(w, r); !ok {
+		return
+	}
+	var patch store.CronJobPatch
+	if !bindJSON(w, r, locale, &patch) {
+		return
+	}
+	if patch.Name != "" && !isValidSlug(patch.Name) {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidSlug, "name"))
+		return
+	}
+
+	id := r.PathValue("id")
+	job, err := h.store.UpdateJob(r.Context(), id, patch)
+	if err != nil {
+		h.writeStoreError(w, err)
+		return
+	}
+	emitAudit(h.msgBus, r, "cron.updated", "cron", id)
+	writeJSON(w, http.StatusOK, map[string]any{"job": job})
+}
+
+// DELETE /v1/cron/{id} — delete a job
+func (h *CronHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.loadJob(w, r); !ok {
+		return
+	}
+	id := r.PathValue("id")
+	if err := h.store.RemoveJob(r.Context(), id); err != nil {
+		h.writeStoreError(w, err)
+		return
+	}
+	emitAudit(h.msgBus, r, "cron.deleted", "cron", id)
+	writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+}
+
+// POST /v1/cron/{id}/run?mode=force — trigger a run in the background
+func (h *CronHandler) handleRun(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.loadJob(w, r); !ok {
+		return
+	}
+	id := r.PathValue("id")
+	force := r.URL.Query().Get("mode") == "force"
+
+	// Preserve tenant scope for async execution.
+	tenantID := store.TenantIDFromContext(r.Context())
+	go func() {
+		bgCtx := store.WithTenantID(context.Background(), tenantID)
+		if _, _, err := h.store.RunJob(bgCtx, id, force); err != nil {
+			slog.Warn("cron run (http) background error", "jobId", id, "error", err)
+		}
+	}()
+
+	emitAudit(h.msgBus, r, "cron.run", "cron", id)
+	writeJSON(w, http.StatusAccepted, map[string]any{"ok": true, "ran": true})
+}
+
+// GET /v1/cron/{id}/runs?limit=N&offset=M — run history
+func (h *CronHandler) handleRuns(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.loadJob(w, r); !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	entries, total := h.store.GetRunLog(r.Context(), r.PathValue("id"), limit, offset)
+	writeJSON(w, http.StatusOK, map[string]any{"entries": entries, "total": total})
+}
+
+func (h *CronHandler) writeStoreError(w http.ResponseWriter, err error) {
+	if errors.Is(err, store.ErrCronJobNotFound) {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, err.Error())
+		return
+	}
+	writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, err.Error())
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// stubCronStore implements the CronStore methods the handler uses.
+type stubCronStore struct {
+	store.CronStore
+	jobs map[string]*store.CronJob
+}
+
+func (s *stubCronStore) AddJob(_ context.Context, name string, schedule store.CronSchedule, message string, _ bool, _, _, agentID, userID string) (*store.CronJob, error) {
+	job := &store.CronJob{ID: uuid.NewString(), Name: name, Schedule: schedule, AgentID: agentID, UserID: userID, Enabled: true}
+	job.Payload.Message = message
+	s.jobs[job.ID] = job
+	return job, nil
+}
+
+func (s *stubCronStore) GetJob(_ context.Context, id string) (*store.CronJob, bool) {
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+func (s *stubCronStore) ListJobs(_ context.Context, _ bool, _, userID string) []store.CronJob {
+	var out []store.CronJob
+	for _, job := range s.jobs {
+		if userID == "" || job.UserID == userID {
+			out = append(out, *job)
+		}
+	}
+	return out
+}
+
+func (s *stubCronStore) UpdateJob(_ context.Context, id string, patch store.CronJobPatch) (*store.CronJob, error) {
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, store.ErrCronJobNotFound
+	}
+	if patch.Stateless != nil {
+		job.Stateless = *patch.Stateless
+	}
+	return job, nil
+}
+
+func (s *stubCronStore) RemoveJob(_ context.Context, id string) error {
+	delete(s.jobs, id)
+	return nil
+}
+
+func (s *stubCronStore) Status() map[string]any { return map[string]any{} }
+
+func cronRequest(t *testing.T, h *CronHandler, method, path, body, bearer, userID string) *httptest.ResponseRecorder {
+	t.Helper()
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer "+bearer)
+	if userID != "" {
+		r.Header.Set("X-GoClaw-User-Id", userID)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, r)
+	return rec
+}
+
+func TestCronHandler_ScopesJobsToCaller(t *testing.T) {
+	setupTestToken(t, "secret")
+	const operatorKey = "operator-key"
+	setupTestCache(t, map[string]*store.APIKeyData{
+		crypto.HashAPIKey(operatorKey): {ID: uuid.New(), Scopes: []string{"operator.read", "operator.write"}},
+	})
+	cs := &stubCronStore{jobs: map[string]*store.CronJob{
+		"bob-job": {ID: "bob-job", Name: "bob", UserID: "bob"},
+	}}
+	h := NewCronHandler(cs, nil, nil)
+
+	rec := cronRequest(t, h, http.MethodPost, "/v1/cron",
+		`{"name":"daily-report","schedule":{"kind":"cron","expr":"0 9 * * *"},"message":"report"}`, operatorKey, "alice")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var created struct {
+		Job store.CronJob `json:"job"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	if created.Job.UserID != "alice" || !created.Job.Stateless {
+		t.Fatalf("created job = %+v, want owner alice and stateless", created.Job)
+	}
+
+	rec = cronRequest(t, h, http.MethodGet, "/v1/cron", "", operatorKey, "alice")
+	var list struct {
+		Jobs []store.CronJob `json:"jobs"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &list)
+	if len(list.Jobs) != 1 || list.Jobs[0].UserID != "alice" {
+		t.Fatalf("alice's list = %+v", list.Jobs)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		if rec := cronRequest(t, h, method, "/v1/cron/bob-job", "", operatorKey, "alice"); rec.Code != http.StatusNotFound {
+			t.Errorf("%s on another user's job: status = %d, want 404", method, rec.Code)
+		}
+	}
+	if _, ok := cs.jobs["bob-job"]; !ok {
+		t.Fatal("another user's job was deleted")
+	}
+
+	// Admins see every job.
+	rec = cronRequest(t, h, http.MethodGet, "/v1/cron", "", "secret", "")
+	json.Unmarshal(rec.Body.Bytes(), &list)
+	if len(list.Jobs) != 2 {
+		t.Errorf("admin list = %d jobs, want 2", len(list.Jobs))
+	}
+}
+
+func TestCronHandler_CreateValidation(t *testing.T) {
+	setupTestToken(t, "secret")
+	h := NewCronHandler(&stubCronStore{jobs: map[string]*store.CronJob{}}, nil, nil)
+	for _, body := range []string{
+		`{"schedule":{"kind":"every","everyMs":60000},"message":"m"}`,
+		`{"name":"Not A Slug","schedule":{"kind":"every","everyMs":60000},"message":"m"}`,
+		`{"name":"ok","schedule":{"kind":"every","everyMs":60000}}`,
+	} {
+		if rec := cronRequest(t, h, http.MethodPost, "/v1/cron", body, "secret", ""); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
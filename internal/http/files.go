@@ -35,21 +35,16 @@ func NewFilesHandler(workspace, dataDir string) *FilesHandler {
 // RegisterRoutes registers the file serving route.
 func (h *FilesHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/files/{path...}", h.auth(h.handleServe))
-	mux.HandleFunc("POST /v1/files/sign", h.handleSign)
+	mux.HandleFunc("POST /v1/files/sign", requireAuth("", h.handleSign))
 }
 
 // handleSign accepts a JSON body with a "path" field (absolute file path),
 // returns a signed /v1/files/ URL with ?ft= token. Requires Bearer auth.
 func (h *FilesHandler) handleSign(w http.ResponseWriter, r *http.Request) {
-	provided := extractBearerToken(r)
-	authedReq, ok := requireAuthBearer("", provided, w, r)
-	if !ok {
-		return
-	}
 	var body struct {
 		Path string `json:"path"`
 	}
-	if err := json.NewDecoder(authedReq.Body).Decode(&body); err != nil || body.Path == "" {
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Path == "" {
 		http.Error(w, `{"error":"path required"}`, http.StatusBadRequest)
 		return
 	}
@@ -76,8 +71,8 @@ func (h *FilesHandler) handleSign(w http.ResponseWriter, r *http.Request) {
 	// Multi-tenant (RBAC): additionally restrict to the requesting tenant's dirs.
 	// Prevents tenant A from signing a URL for tenant B's files.
 	if edition.Current().RBACEnabled {
-		tenantData := config.TenantDataDir(h.dataDir, store.TenantIDFromContext(authedReq.Context()), store.TenantSlugFromContext(authedReq.Context()))
-		tenantWs := config.TenantWorkspace(h.workspace, store.TenantIDFromContext(authedReq.Context()), store.TenantSlugFromContext(authedReq.Context()))
+		tenantData := config.TenantDataDir(h.dataDir, store.TenantIDFromContext(r.Context()), store.TenantSlugFromContext(r.Context()))
+		tenantWs := config.TenantWorkspace(h.workspace, store.TenantIDFromContext(r.Context()), store.TenantSlugFromContext(r.Context()))
 		if (!strings.HasPrefix(absPath, tenantData+sep) && absPath != tenantData) &&
 			(!strings.HasPrefix(absPath, tenantWs+sep) && absPath != tenantWs) {
 			slog.Warn("security.files_sign_tenant_denied", "path", absPath, "tenant_data", tenantData, "tenant_ws", tenantWs)
@@ -93,26 +88,11 @@ func (h *FilesHandler) handleSign(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// auth admits a signed file token (?ft=) for the path or a bearer credential.
 func (h *FilesHandler) auth(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Priority 1: short-lived signed file token (?ft=) — decoupled from gateway token.
-		if ft := r.URL.Query().Get("ft"); ft != "" {
-			path := "/v1/files/" + r.PathValue("path")
-			if VerifyFileToken(ft, path, FileSigningKey()) {
-				next(w, r)
-				return
-			}
-			http.Error(w, "invalid or expired file token", http.StatusUnauthorized)
-			return
-		}
-		// Priority 2: Bearer header (API clients only).
-		provided := extractBearerToken(r)
-		authedReq, ok := requireAuthBearer("", provided, w, r)
-		if !ok {
-			return
-		}
-		next(w, authedReq)
-	}
+	return requireAuthOrFileToken("", func(r *http.Request) string {
+		return "/v1/files/" + r.PathValue("path")
+	}, next)
 }
 
 // deniedFilePrefixes blocks access to sensitive system directories.
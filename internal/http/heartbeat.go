@@ -0,0 +1,120 @@
+package http
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// HeartbeatHandler exposes heartbeat run history and alert snoozing over HTTP.
+// Heartbeat configuration itself stays on the heartbeat.* WebSocket methods.
+type HeartbeatHandler struct {
+	store   store.HeartbeatStore
+	agents  store.AgentStore
+	msgBus  *bus.MessageBus
+	isOwner func(string) bool
+}
+
+// NewHeartbeatHandler creates a handler for heartbeat endpoints.
+func NewHeartbeatHandler(s store.HeartbeatStore, agents store.AgentStore, msgBus *bus.MessageBus, isOwner func(string) bool) *HeartbeatHandler {
+	return &HeartbeatHandler{store: s, agents: agents, msgBus: msgBus, isOwner: isOwner}
+}
+
+// RegisterRoutes registers all heartbeat routes on the given mux.
+func (h *HeartbeatHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/agents/{id}/heartbeat/history", requireAuth("", h.handleHistory))
+	mux.HandleFunc("POST /v1/agents/{id}/heartbeat/snooze", requireAuth(permissions.RoleAdmin, h.handleSnooze))
+	mux.HandleFunc("DELETE /v1/agents/{id}/heartbeat/snooze", requireAuth(permissions.RoleAdmin, h.handleUnsnooze))
+}
+
+// resolveAgent maps the {id} path value to an agent the caller may access.
+func (h *HeartbeatHandler) resolveAgent(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	ag, ok := resolveAccessibleAgent(w, r, h.agents, h.isOwner)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return ag.ID, true
+}
+
+// GET /v1/agents/{id}/heartbeat/history?limit=N&offset=M — run outcomes, newest first
+func (h *HeartbeatHandler) handleHistory(w http.ResponseWriter, r *http.Request) {
+	agentID, ok := h.resolveAgent(w, r)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	logs, total, err := h.store.ListLogs(r.Context(), agentID, limit, offset)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"entries": store.HeartbeatHistory(logs), "total": total})
+}
+
+// POST /v1/agents/{id}/heartbeat/snooze — body {"hours": N}; withhold alerts for N hours
+func (h *HeartbeatHandler) handleSnooze(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	var req struct {
+		Hours float64 `json:"hours"`
+	}
+	if !bindJSON(w, r, locale, &req) {
+		return
+	}
+	if req.Hours <= 0 || req.Hours > store.MaxHeartbeatSnoozeHours {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, fmt.Sprintf("hours must be greater than 0 and at most %d", store.MaxHeartbeatSnoozeHours))
+		return
+	}
+	until := time.Now().Add(time.Duration(req.Hours * float64(time.Hour)))
+	h.setSnooze(w, r, &until)
+}
+
+// DELETE /v1/agents/{id}/heartbeat/snooze — resume alert delivery
+func (h *HeartbeatHandler) handleUnsnooze(w http.ResponseWriter, r *http.Request) {
+	h.setSnooze(w, r, nil)
+}
+
+func (h *HeartbeatHandler) setSnooze(w http.ResponseWriter, r *http.Request, until *time.Time) {
+	agentID, ok := h.resolveAgent(w, r)
+	if !ok {
+		return
+	}
+	hb, err := h.store.Get(r.Context(), agentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, protocol.ErrNotFound, "heartbeat not configured")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, err.Error())
+		return
+	}
+	hb.SetSnoozedUntil(until)
+	if err := h.store.Upsert(r.Context(), hb); err != nil {
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, err.Error())
+		return
+	}
+
+	if h.msgBus != nil {
+		h.msgBus.Broadcast(bus.Event{
+			Name:    protocol.EventCacheInvalidate,
+			Payload: bus.CacheInvalidatePayload{Kind: bus.CacheKindHeartbeat, Key: agentID.String()},
+		})
+	}
+
+	action := "heartbeat.snoozed"
+	if until == nil {
+		action = "heartbeat.unsnoozed"
+	}
+	emitAudit(h.msgBus, r, action, "heartbeat", agentID.String())
+	writeJSON(w, http.StatusOK, map[string]any{"agentId": agentID.String(), "snoozedUntil": until})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 
@@ -26,13 +27,19 @@ type MCPPoolEvictor interface {
 	Evict(tenantID uuid.UUID, serverName string)
 }
 
+// MCPStatusReporter reports the live connection state of a tenant's pooled MCP server.
+type MCPStatusReporter interface {
+	ServerStatus(tenantID uuid.UUID, serverName string) (mcp.ServerStatus, bool)
+}
+
 // MCPHandler handles MCP server management HTTP endpoints.
 type MCPHandler struct {
-	store       store.MCPServerStore
-	msgBus      *bus.MessageBus
-	mgr         MCPToolLister  // optional, nil when Manager not available
-	poolEvictor MCPPoolEvictor // optional, nil when pool not available
-	db          *sql.DB        // for export/import direct queries
+	store          store.MCPServerStore
+	msgBus         *bus.MessageBus
+	mgr            MCPToolLister     // optional, nil when Manager not available
+	poolEvictor    MCPPoolEvictor    // optional, nil when pool not available
+	statusReporter MCPStatusReporter // optional, nil when pool not available
+	db             *sql.DB           // for export/import direct queries
 }
 
 // NewMCPHandler creates a handler for MCP server management endpoints.
@@ -43,6 +50,9 @@ func NewMCPHandler(s store.MCPServerStore, msgBus *bus.MessageBus, mgr MCPToolLi
 // SetPoolEvictor sets the pool evictor for credential rotation handling.
 func (h *MCPHandler) SetPoolEvictor(e MCPPoolEvictor) { h.poolEvictor = e }
 
+// SetStatusReporter sets the source of live connection state for the status endpoint.
+func (h *MCPHandler) SetStatusReporter(r MCPStatusReporter) { h.statusReporter = r }
+
 func (h *MCPHandler) emitCacheInvalidate() {
 	if h.msgBus == nil {
 		return
@@ -68,8 +78,9 @@ func (h *MCPHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Reconnect (admin+ — evict pooled connection)
 	mux.HandleFunc("POST /v1/mcp/servers/{id}/reconnect", h.adminAuth(h.handleReconnectServer))
 
-	// Server tools (read-only: viewer+)
+	// Server tools and live connection status (read-only: viewer+)
 	mux.HandleFunc("GET /v1/mcp/servers/{id}/tools", h.auth(h.handleListServerTools))
+	mux.HandleFunc("GET /v1/mcp/servers/{id}/status", h.auth(h.handleServerStatus))
 
 	// Agent grants (reads: viewer+, writes: admin+)
 	mux.HandleFunc("GET /v1/mcp/servers/{id}/grants", h.auth(h.handleListServerGrants))
@@ -101,6 +112,9 @@ func (h *MCPHandler) adminAuth(next http.HandlerFunc) http.HandlerFunc {
 
 // --- Server CRUD ---
 
+// mcpReconnectFields are the server fields whose update requires a new connection.
+var mcpReconnectFields = []string{"api_key", "headers", "env", "transport", "command", "args", "url", "timeout_sec"}
+
 // mcpServerWithCounts extends MCPServerData with agent grant count for list responses.
 type mcpServerWithCounts struct {
 	store.MCPServerData
@@ -273,15 +287,14 @@ func (h *MCPHandler) handleUpdateServer(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Evict pool connections when credentials change (force reconnect with new creds)
-	if h.poolEvictor != nil && serverName != "" {
-		_, hasKey := updates["api_key"]
-		_, hasHeaders := updates["headers"]
-		_, hasEnv := updates["env"]
-		if hasKey || hasHeaders || hasEnv {
-			tid := store.TenantIDFromContext(r.Context())
-			h.poolEvictor.Evict(tid, serverName)
-		}
+	// Evict pool connections when credentials or connection settings change, so
+	// agents reconnect (stdio: restart the process) with the new config.
+	if h.poolEvictor != nil && serverName != "" && slices.ContainsFunc(mcpReconnectFields, func(f string) bool {
+		_, ok := updates[f]
+		return ok
+	}) {
+		tid := store.TenantIDFromContext(r.Context())
+		h.poolEvictor.Evict(tid, serverName)
 	}
 
 	h.emitCacheInvalidate()
@@ -332,3 +345,46 @@ func (h *MCPHandler) handleReconnectServer(w http.ResponseWriter, r *http.Reques
 	slog.Info("mcp.server.reconnect_requested", "server", srv.Name, "id", id)
 	writeJSON(w, http.StatusOK, map[string]string{"status": "reconnected"})
 }
+
+// mcpServerStatusResponse is the live state of one MCP server.
+// State: connected, disconnected (health checks failing, reconnecting),
+// idle (no agent has connected yet, or evicted after inactivity) or disabled.
+type mcpServerStatusResponse struct {
+	ID      uuid.UUID `json:"id"`
+	Enabled bool      `json:"enabled"`
+	State   string    `json:"state"`
+	mcp.ServerStatus
+}
+
+func (h *MCPHandler) handleServerStatus(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgInvalidID, "server")})
+		return
+	}
+
+	srv, err := h.store.GetServer(r.Context(), id)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgNotFound, "server", id.String())})
+		return
+	}
+
+	resp := mcpServerStatusResponse{ID: id, Enabled: srv.Enabled, State: "idle"}
+	resp.Name, resp.Transport = srv.Name, srv.Transport
+	if h.statusReporter != nil {
+		st, ok := h.statusReporter.ServerStatus(store.TenantIDFromContext(r.Context()), srv.Name)
+		resp.UserConns = st.UserConns
+		if ok {
+			resp.ServerStatus = st
+			resp.State = "disconnected"
+			if st.Connected {
+				resp.State = "connected"
+			}
+		}
+	}
+	if !srv.Enabled {
+		resp.State = "disabled"
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
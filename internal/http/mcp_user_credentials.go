@@ -29,8 +29,10 @@ func (h *MCPUserCredentialsHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("DELETE /v1/mcp/servers/{id}/user-credentials", h.auth(h.handleDelete))
 }
 
+// auth admits members too: callers manage their own credentials, and only
+// admins may target other users (resolveTargetUserID).
 func (h *MCPUserCredentialsHandler) auth(next http.HandlerFunc) http.HandlerFunc {
-	return requireAuth("", next)
+	return requireAuth(permissions.RoleMember, next)
 }
 
 // resolveTargetUserID returns the effective user ID for credential operations.
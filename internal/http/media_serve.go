@@ -9,6 +9,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/media"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 )
 
 // validMediaID matches safe media identifiers: alphanumeric, hyphens, underscores, dots.
@@ -30,26 +31,11 @@ func (h *MediaServeHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/media/{id}", h.auth(h.handleServe))
 }
 
+// auth admits a signed file token (?ft=) for the media ID or a member credential.
 func (h *MediaServeHandler) auth(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Priority 1: short-lived signed file token (?ft=) — decoupled from gateway token.
-		if ft := r.URL.Query().Get("ft"); ft != "" {
-			mediaID := r.PathValue("id")
-			if VerifyFileToken(ft, "/v1/media/"+mediaID, FileSigningKey()) {
-				next(w, r)
-				return
-			}
-			http.Error(w, "invalid or expired file token", http.StatusUnauthorized)
-			return
-		}
-		// Priority 2: Bearer header (API clients only).
-		provided := extractBearerToken(r)
-		authedReq, ok := requireAuthBearer("", provided, w, r)
-		if !ok {
-			return
-		}
-		next(w, authedReq)
-	}
+	return requireAuthOrFileToken(permissions.RoleMember, func(r *http.Request) string {
+		return "/v1/media/" + r.PathValue("id")
+	}, next)
 }
 
 func (h *MediaServeHandler) handleServe(w http.ResponseWriter, r *http.Request) {
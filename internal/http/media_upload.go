@@ -11,6 +11,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/channels/media"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 )
 
 const (
@@ -31,8 +32,9 @@ func (h *MediaUploadHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /v1/media/upload", h.auth(h.handleUpload))
 }
 
+// auth admits members too: they attach files to their own chats.
 func (h *MediaUploadHandler) auth(next http.HandlerFunc) http.HandlerFunc {
-	return requireAuth("", next)
+	return requireAuth(permissions.RoleMember, next)
 }
 
 func (h *MediaUploadHandler) handleUpload(w http.ResponseWriter, r *http.Request) {
@@ -3,12 +3,14 @@ package http
 import (
 	"net/http"
 
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 // MemoryHandler handles memory document management endpoints.
 type MemoryHandler struct {
-	store store.MemoryStore
+	store    store.MemoryStore
+	migrator store.EmbeddingMigrator // nil = re-embed unavailable
 }
 
 // NewMemoryHandler creates a handler for memory management endpoints.
@@ -16,6 +18,12 @@ func NewMemoryHandler(s store.MemoryStore) *MemoryHandler {
 	return &MemoryHandler{store: s}
 }
 
+// SetEmbeddingMigrator enables POST /v1/memory/reembed and the vector
+// dimension / mismatch fields of the status endpoint.
+func (h *MemoryHandler) SetEmbeddingMigrator(m store.EmbeddingMigrator) {
+	h.migrator = m
+}
+
 // RegisterRoutes registers all memory routes on the given mux.
 func (h *MemoryHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/memory/documents", h.auth(h.handleListAllDocuments))
@@ -27,6 +35,8 @@ func (h *MemoryHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /v1/agents/{agentID}/memory/index", h.auth(h.handleIndexDocument))
 	mux.HandleFunc("POST /v1/agents/{agentID}/memory/index-all", h.auth(h.handleIndexAll))
 	mux.HandleFunc("POST /v1/agents/{agentID}/memory/search", h.auth(h.handleSearch))
+	mux.HandleFunc("GET /v1/agents/{agentID}/memory/status", h.auth(h.handleStatus))
+	mux.HandleFunc("POST /v1/memory/reembed", requireAuth(permissions.RoleAdmin, h.handleReembed))
 }
 
 func (h *MemoryHandler) auth(next http.HandlerFunc) http.HandlerFunc {
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -196,3 +197,58 @@ func (h *MemoryHandler) handleSearch(w http.ResponseWriter, r *http.Request) {
 		"count":   len(results),
 	})
 }
+
+func (h *MemoryHandler) handleStatus(w http.ResponseWriter, r *http.Request) {
+	agentID := r.PathValue("agentID")
+
+	stats, err := h.store.Stats(r.Context(), agentID)
+	if err != nil {
+		slog.Warn("memory.status failed", "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	if h.migrator != nil {
+		stats.VectorDimensions = store.MemoryEmbeddingDimensions()
+		stats.ReembedRequired = h.migrator.ReembedRequired()
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// handleReembed re-generates every stored embedding with the current
+// provider, optionally resizing the vector columns first. Affects all
+// tenants, so it is restricted to the master scope.
+func (h *MemoryHandler) handleReembed(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	if !requireMasterScope(w, r) {
+		return
+	}
+	if h.migrator == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "no embedding provider configured"})
+		return
+	}
+
+	var body struct {
+		Dimensions int `json:"dimensions"`
+	}
+	if !bindJSON(w, r, locale, &body) {
+		return
+	}
+	if body.Dimensions < 0 || body.Dimensions > store.MaxMemoryEmbeddingDimensions {
+		writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("dimensions must be between 1 and %d", store.MaxMemoryEmbeddingDimensions),
+		})
+		return
+	}
+
+	result, err := h.migrator.ReembedAll(r.Context(), body.Dimensions)
+	if err != nil {
+		slog.Warn("memory.reembed failed", "error", err)
+		resp := map[string]any{"error": err.Error()}
+		if result != nil {
+			resp["result"] = result
+		}
+		writeJSON(w, http.StatusInternalServerError, resp)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
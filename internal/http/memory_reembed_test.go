@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type fakeEmbeddingMigrator struct {
+	calledWith int
+	calls      int
+}
+
+func (f *fakeEmbeddingMigrator) VectorDimensions(context.Context) (int, error) { return 1536, nil }
+func (f *fakeEmbeddingMigrator) ReembedRequired() string                       { return "" }
+func (f *fakeEmbeddingMigrator) ReembedAll(_ context.Context, dims int) (*store.ReembedResult, error) {
+	f.calls++
+	f.calledWith = dims
+	return &store.ReembedResult{Dimensions: dims, Resized: dims > 0, Updated: map[string]int{"memory_chunks": 3}}, nil
+}
+
+func reembedRequest(ctx context.Context, body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/v1/memory/reembed", strings.NewReader(body)).WithContext(ctx)
+}
+
+func TestMemoryReembed_RunsMigrator(t *testing.T) {
+	m := &fakeEmbeddingMigrator{}
+	h := NewMemoryHandler(nil)
+	h.SetEmbeddingMigrator(m)
+
+	w := httptest.NewRecorder()
+	h.handleReembed(w, reembedRequest(context.Background(), `{"dimensions": 1024}`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	var res store.ReembedResult
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if m.calledWith != 1024 || !res.Resized || res.Updated["memory_chunks"] != 3 {
+		t.Errorf("migrator called with %d, result %+v", m.calledWith, res)
+	}
+}
+
+func TestMemoryReembed_RejectsBadRequests(t *testing.T) {
+	m := &fakeEmbeddingMigrator{}
+	h := NewMemoryHandler(nil)
+	h.SetEmbeddingMigrator(m)
+
+	w := httptest.NewRecorder()
+	h.handleReembed(w, reembedRequest(context.Background(), `{"dimensions": 4096}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("oversized dimensions: status = %d, want 400", w.Code)
+	}
+
+	tenantCtx := store.WithTenantID(context.Background(), uuid.New())
+	w = httptest.NewRecorder()
+	h.handleReembed(w, reembedRequest(tenantCtx, `{}`))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("non-master tenant: status = %d, want 403", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	NewMemoryHandler(nil).handleReembed(w, reembedRequest(context.Background(), `{}`))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("no migrator: status = %d, want 503", w.Code)
+	}
+	if m.calls != 0 {
+		t.Errorf("migrator ran %d times for rejected requests", m.calls)
+	}
+}
@@ -0,0 +1,386 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/oidc"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+const (
+	oidcCallbackPath = "/v1/auth/oidc/callback"
+	// oidcLoginTTL is how long a started login may take at the provider.
+	oidcLoginTTL = 10 * time.Minute
+	// oidcMaxPending caps logins waiting for their callback.
+	oidcMaxPending = 10000
+)
+
+// oidcAuth is the process-wide OIDC state shared by the handler and
+// resolveCredential.
+type oidcAuth struct {
+	provider *oidc.Provider
+	redirect string // configured redirect URL ("" = derive from request)
+	role     permissions.Role
+	ttl      time.Duration
+	key      []byte // HMAC key for gateway-issued tokens
+}
+
+var pkgOIDC *oidcAuth // nil = OIDC login disabled
+
+// InitOIDC enables OpenID Connect login from gateway.oidc. Without a
+// configured signing key a random one is generated, so issued tokens do not
+// survive a restart and are not accepted by other replicas.
+func InitOIDC(cfg *config.OIDCConfig) {
+	if !cfg.Enabled() {
+		pkgOIDC = nil
+		return
+	}
+	role := permissions.Role(cfg.Role)
+	switch role {
+	case permissions.RoleAdmin, permissions.RoleOperator, permissions.RoleViewer, permissions.RoleMember:
+	case "":
+		role = permissions.RoleOperator
+	default:
+		slog.Warn("security.oidc_role_invalid", "role", cfg.Role, "using", permissions.RoleOperator)
+		role = permissions.RoleOperator
+	}
+	key := []byte(cfg.SigningKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		rand.Read(key)
+		slog.Info("oidc: no signing_key configured; issued tokens are valid for this process only")
+	}
+	pkgOIDC = &oidcAuth{
+		provider: oidc.NewProvider(oidc.Config{
+			Issuer:       cfg.Issuer,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Scopes:       cfg.Scopes,
+			UserClaim:    cfg.UserClaim,
+		}),
+		redirect: cfg.RedirectURL,
+		role:     role,
+		ttl:      cfg.TokenTTL(),
+		key:      key,
+	}
+	slog.Info("oidc login enabled", "issuer", cfg.Issuer, "role", role)
+}
+
+// OIDCEnabled reports whether OIDC login is configured. When it is, callers
+// without a credential are no longer treated as dev-mode admins.
+func OIDCEnabled() bool {
+	return pkgOIDC != nil
+}
+
+// verifyOIDCToken checks a gateway-issued OIDC token and returns its user and
+// role.
+func verifyOIDCToken(bearer string) (string, permissions.Role, bool) {
+	if pkgOIDC == nil || !oidc.LooksLikeJWT(bearer) {
+		return "", "", false
+	}
+	claims, err := oidc.Verify(pkgOIDC.key, bearer)
+	if err != nil {
+		slog.Debug("security.oidc_token_rejected", "error", err)
+		return "", "", false
+	}
+	if err := store.ValidateUserID(claims.Subject); err != nil {
+		return "", "", false
+	}
+	role := permissions.Role(claims.Role)
+	switch role {
+	case permissions.RoleAdmin, permissions.RoleOperator, permissions.RoleViewer, permissions.RoleMember:
+		return claims.Subject, role, true
+	}
+	return "", "", false
+}
+
+// OIDCIdentity is a WebSocket caller authenticated by a gateway-issued OIDC
+// token.
+type OIDCIdentity struct {
+	UserID   string
+	Role     permissions.Role
+	TenantID uuid.UUID
+	Denied   bool // the token is valid but tenant access was refused
+}
+
+// ResolveOIDCToken verifies a gateway-issued OIDC token for the WS connect
+// handshake. tenantHint narrows the tenant like X-GoClaw-Tenant-Id does on
+// HTTP, and the role is capped by the user's tenant_users role. ok is false
+// when token is not a valid OIDC token.
+func ResolveOIDCToken(ctx context.Context, token, tenantHint string) (OIDCIdentity, bool) {
+	userID, role, ok := verifyOIDCToken(token)
+	if !ok {
+		return OIDCIdentity{}, false
+	}
+	res := oidcCredential(ctx, role, userID, tenantHint)
+	if !res.Authenticated {
+		return OIDCIdentity{UserID: userID, Denied: true}, true
+	}
+	return OIDCIdentity{UserID: userID, Role: res.Role, TenantID: res.TenantID}, true
+}
+
+// pendingOIDCLogin is a login started by /v1/auth/oidc/login, keyed by state.
+type pendingOIDCLogin struct {
+	nonce       string
+	verifier    string
+	redirectURL string // redirect_uri sent to the provider
+	returnTo    string // local path to land on after login
+	expires     time.Time
+}
+
+// OIDCHandler serves OpenID Connect login. Browsers use the redirect flow
+// and end up with an admin UI session cookie; API and WS clients that
+// already hold an ID token exchange it for a gateway-issued JWT.
+type OIDCHandler struct {
+	sessions     *UISessionStore
+	loginLimiter *perKeyRateLimiter
+
+	mu      sync.Mutex
+	pending map[string]pendingOIDCLogin
+}
+
+// NewOIDCHandler creates the OIDC login handler. Call InitOIDC first.
+func NewOIDCHandler(sessions *UISessionStore) *OIDCHandler {
+	return &OIDCHandler{
+		sessions:     sessions,
+		loginLimiter: newPerKeyRateLimiter(uiLoginRPM, uiLoginBurst),
+		pending:      make(map[string]pendingOIDCLogin),
+	}
+}
+
+// RegisterRoutes registers OIDC routes on the given mux.
+func (h *OIDCHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/auth/oidc/login", h.handleLogin)
+	mux.HandleFunc("GET "+oidcCallbackPath, h.handleCallback)
+	mux.HandleFunc("POST /v1/auth/oidc/exchange", h.handleExchange)
+}
+
+// handleLogin redirects the browser to the identity provider. The optional
+// redirect query parameter is a local path to return to after login.
+func (h *OIDCHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	if pkgOIDC == nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "auth provider", "oidc"))
+		return
+	}
+	ip := remoteIP(r)
+	if !h.loginLimiter.Allow(ip) {
+		slog.Warn("security.oidc_login_rate_limited", "ip", ip)
+		writeError(w, http.StatusTooManyRequests, protocol.ErrResourceExhausted, i18n.T(locale, i18n.MsgRateLimitExceeded))
+		return
+	}
+
+	login := pendingOIDCLogin{
+		nonce:       oidc.RandomString(),
+		verifier:    oidc.RandomString(),
+		redirectURL: oidcRedirectURL(r),
+		returnTo:    safeReturnPath(r.URL.Query().Get("redirect")),
+		expires:     time.Now().Add(oidcLoginTTL),
+	}
+	state := oidc.RandomString()
+	authURL, err := pkgOIDC.provider.AuthCodeURL(r.Context(), login.redirectURL, state, login.nonce, login.verifier)
+	if err != nil {
+		slog.Warn("security.oidc_discovery_failed", "error", err)
+		writeError(w, http.StatusBadGateway, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "identity provider unavailable"))
+		return
+	}
+	if !h.addPending(state, login) {
+		writeError(w, http.StatusTooManyRequests, protocol.ErrResourceExhausted, i18n.T(locale, i18n.MsgRateLimitExceeded))
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// handleCallback completes the code flow, creates an admin UI session bound
+// to the user and returns the browser to the UI.
+func (h *OIDCHandler) handleCallback(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	if pkgOIDC == nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "auth provider", "oidc"))
+		return
+	}
+	q := r.URL.Query()
+	login, ok := h.takePending(q.Get("state"))
+	if !ok {
+		slog.Warn("security.oidc_callback_bad_state", "ip", remoteIP(r))
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, "unknown or expired login state"))
+		return
+	}
+	if e := q.Get("error"); e != "" {
+		slog.Warn("security.oidc_login_denied", "error", e, "description", q.Get("error_description"))
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+
+	rawID, err := pkgOIDC.provider.Exchange(r.Context(), q.Get("code"), login.redirectURL, login.verifier)
+	if err != nil {
+		slog.Warn("security.oidc_exchange_failed", "error", err)
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	id, err := pkgOIDC.provider.VerifyIDToken(r.Context(), rawID, login.nonce)
+	if err != nil {
+		slog.Warn("security.oidc_id_token_invalid", "error", err)
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	if store.ValidateUserID(id.UserID) != nil {
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+
+	// The session gets the role the user has right now; applyUserRole
+	// re-checks tenant_users on every HTTP request anyway.
+	auth := oidcCredential(r.Context(), pkgOIDC.role, id.UserID, "")
+	if !auth.Authenticated {
+		writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	sess, secret := h.sessions.create(auth, id.UserID, remoteIP(r), r.UserAgent(), "")
+	http.SetCookie(w, &http.Cookie{
+		Name:     UISessionCookie,
+		Value:    secret,
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	slog.Info("security.oidc_login", "session", sess.ID, "role", string(sess.Role), "user", id.UserID, "ip", remoteIP(r))
+
+	// Navigate from a page on our own origin instead of a 302: the callback
+	// is reached cross-site from the provider, and browsers would withhold
+	// the SameSite=Strict cookie on a redirect continuing that navigation.
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	oidcReturnPage.Execute(w, login.returnTo)
+}
+
+// handleExchange trades an ID token from the provider (obtained by a CLI,
+// native app or backend) for a short-lived gateway JWT, usable as a bearer
+// token on /v1 and as the token in the WS connect frame.
+func (h *OIDCHandler) handleExchange(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	if pkgOIDC == nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "auth provider", "oidc"))
+		return
+	}
+	ip := remoteIP(r)
+	if !h.loginLimiter.Allow(ip) {
+		slog.Warn("security.oidc_login_rate_limited", "ip", ip)
+		writeError(w, http.StatusTooManyRequests, protocol.ErrResourceExhausted, i18n.T(locale, i18n.MsgRateLimitExceeded))
+		return
+	}
+	var input struct {
+		IDToken string `json:"id_token"`
+	}
+	if !bindJSON(w, r, locale, &input) {
+		return
+	}
+	if input.IDToken == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "id_token"))
+		return
+	}
+	id, err := pkgOIDC.provider.VerifyIDToken(r.Context(), input.IDToken, "")
+	if err != nil || store.ValidateUserID(id.UserID) != nil {
+		slog.Warn("security.oidc_exchange_rejected", "ip", ip, "error", err)
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	token, expires, err := oidc.Sign(pkgOIDC.key, id.UserID, string(pkgOIDC.role), id.Email, pkgOIDC.ttl)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, err.Error()))
+		return
+	}
+	slog.Info("security.oidc_token_issued", "user", id.UserID, "ip", ip)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"token":      token,
+		"token_type": "Bearer",
+		"expires_at": expires,
+		"user_id":    id.UserID,
+	})
+}
+
+func (h *OIDCHandler) addPending(state string, login pendingOIDCLogin) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	for k, p := range h.pending {
+		if now.After(p.expires) {
+			delete(h.pending, k)
+		}
+	}
+	if len(h.pending) >= oidcMaxPending {
+		return false
+	}
+	h.pending[state] = login
+	return true
+}
+
+// takePending returns and forgets the login for state, so a callback can
+// only be used once.
+func (h *OIDCHandler) takePending(state string) (pendingOIDCLogin, bool) {
+	if state == "" {
+		return pendingOIDCLogin{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	p, ok := h.pending[state]
+	delete(h.pending, state)
+	if !ok || time.Now().After(p.expires) {
+		return pendingOIDCLogin{}, false
+	}
+	return p, true
+}
+
+// oidcRedirectURL returns the configured redirect URL or derives it from the
+// request. The provider only accepts registered redirect URLs, so a forged
+// Host header cannot divert the code.
+func oidcRedirectURL(r *http.Request) string {
+	if pkgOIDC.redirect != "" {
+		return pkgOIDC.redirect
+	}
+	scheme := "http"
+	if isSecureRequest(r) {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + oidcCallbackPath
+}
+
+// safeReturnPath accepts only local absolute paths, so the login flow cannot
+// be used as an open redirect. Browsers drop tabs and newlines from URLs and
+// read a backslash as "/", so "/<tab>/evil.com" would resolve to
+// "//evil.com": any whitespace, control character or backslash is rejected,
+// raw or percent-encoded.
+func safeReturnPath(p string) string {
+	unsafe := func(r rune) bool { return r == '\\' || unicode.IsControl(r) || unicode.IsSpace(r) }
+	if !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.ContainsFunc(p, unsafe) {
+		return "/"
+	}
+	// u.Path is percent-decoded, which catches "/%09/evil.com" and "/%2F/evil.com".
+	u, err := url.Parse(p)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.User != nil ||
+		strings.HasPrefix(u.Path, "//") || strings.ContainsFunc(u.Path, unsafe) {
+		return "/"
+	}
+	return p
+}
+
+var oidcReturnPage = template.Must(template.New("oidc").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta http-equiv="refresh" content="0;url={{.}}"><title>Signed in</title></head>
+<body><a href="{{.}}">Continue</a></body></html>
+`))
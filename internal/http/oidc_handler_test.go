@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/oidc"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func setupTestOIDC(t *testing.T, key string) {
+	t.Helper()
+	old := pkgOIDC
+	pkgOIDC = &oidcAuth{role: permissions.RoleOperator, ttl: time.Hour, key: []byte(key)}
+	t.Cleanup(func() { pkgOIDC = old })
+}
+
+func TestResolveAuth_OIDCToken(t *testing.T) {
+	setupTestCache(t, nil)
+	setupTestOIDC(t, "test-signing-key")
+	ts := newMockTenantStore()
+	ts.setUserRole(store.MasterTenantID, "alice", store.TenantRoleMember)
+	ts.setUserRole(store.MasterTenantID, "system", store.TenantRoleAdmin)
+	setupTestTenantStore(t, ts)
+
+	sign := func(key, user string) string {
+		tok, _, err := oidc.Sign([]byte(key), user, "operator", "", time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	tests := []struct {
+		name, bearer string
+		want         permissions.Role
+		wantUser     string
+	}{
+		{"member capped", sign("test-signing-key", "alice"), permissions.RoleMember, "alice"},
+		{"no membership row", sign("test-signing-key", "carol"), permissions.RoleMember, "carol"},
+		{"owner ID from IdP", sign("test-signing-key", "system"), permissions.RoleOperator, "system"}, // no owner promotion
+		{"foreign key", sign("other-key", "carol"), permissions.RoleNone, ""},
+		{"no credential", "", permissions.RoleNone, ""}, // OIDC disables dev-mode admin
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/v1/agents", nil)
+		if tt.bearer != "" {
+			r.Header.Set("Authorization", "Bearer "+tt.bearer)
+		}
+		r.Header.Set("X-GoClaw-User-Id", "mallory")
+		auth := resolveAuth(r)
+		if auth.Role != tt.want || auth.UserID != tt.wantUser {
+			t.Errorf("%s: role = %q user = %q, want %q %q", tt.name, auth.Role, auth.UserID, tt.want, tt.wantUser)
+		}
+		if auth.Authenticated {
+			if got := store.UserIDFromContext(enrichContext(r.Context(), r, auth)); got != tt.wantUser {
+				t.Errorf("%s: context user = %q, want %q", tt.name, got, tt.wantUser)
+			}
+		}
+	}
+
+	id, ok := ResolveOIDCToken(context.Background(), sign("test-signing-key", "alice"), "")
+	if !ok || id.Denied || id.UserID != "alice" || id.Role != permissions.RoleMember || id.TenantID != store.MasterTenantID {
+		t.Errorf("ResolveOIDCToken = %+v, %v", id, ok)
+	}
+	if id, _ := ResolveOIDCToken(context.Background(), sign("test-signing-key", "system"), ""); id.Role != permissions.RoleOperator {
+		t.Errorf("ResolveOIDCToken for an owner ID: role = %q, want operator", id.Role)
+	}
+	if _, ok := ResolveOIDCToken(context.Background(), "not-a-jwt", ""); ok {
+		t.Error("ResolveOIDCToken accepted a non-JWT")
+	}
+}
+
+func TestOIDCPendingLoginSingleUse(t *testing.T) {
+	sessions := NewUISessionStore()
+	t.Cleanup(sessions.Stop)
+	h := NewOIDCHandler(sessions)
+	h.addPending("s1", pendingOIDCLogin{nonce: "n", expires: time.Now().Add(time.Minute)})
+	h.addPending("s2", pendingOIDCLogin{nonce: "n", expires: time.Now().Add(-time.Second)})
+
+	if p, ok := h.takePending("s1"); !ok || p.nonce != "n" {
+		t.Fatalf("takePending(s1) = %+v, %v", p, ok)
+	}
+	if _, ok := h.takePending("s1"); ok {
+		t.Error("state reused")
+	}
+	if _, ok := h.takePending("s2"); ok {
+		t.Error("expired state accepted")
+	}
+}
+
+func TestSafeReturnPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                      "/",
+		"/agents?tab=1":         "/agents?tab=1",
+		"//evil.example.com":    "/",
+		"/\\evil.example.com":   "/",
+		"https://evil.example":  "/",
+		"/\t/evil.example.com":  "/",
+		"/%09/evil.example.com": "/",
+		"/%2F/evil.example.com": "/",
+		"/ /evil.example.com":   "/",
+		"/\n/evil.example.com":  "/",
+		"//":                    "/",
+		"/search?q=a%20b":       "/search?q=a%20b",
+	} {
+		if got := safeReturnPath(in); got != want {
+			t.Errorf("safeReturnPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// PairingHandler manages channel pairing over HTTP: pending approval codes
+// and paired senders. It mirrors the device.pair.* WebSocket methods.
+type PairingHandler struct {
+	pairing     store.PairingStore
+	msgBus      *bus.MessageBus
+	onApprove   func(ctx context.Context, channel, chatID, senderID string)
+	broadcaster func(protocol.EventFrame)
+}
+
+// NewPairingHandler creates a handler for pairing management endpoints.
+func NewPairingHandler(pairing store.PairingStore, msgBus *bus.MessageBus) *PairingHandler {
+	return &PairingHandler{pairing: pairing, msgBus: msgBus}
+}
+
+// SetOnApprove sets a callback that notifies the sender after approval.
+func (h *PairingHandler) SetOnApprove(cb func(ctx context.Context, channel, chatID, senderID string)) {
+	h.onApprove = cb
+}
+
+// SetBroadcaster sets a function to broadcast pairing events to WS clients.
+func (h *PairingHandler) SetBroadcaster(fn func(protocol.EventFrame)) {
+	h.broadcaster = fn
+}
+
+// RegisterRoutes registers pairing routes on the given mux.
+func (h *PairingHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/pairing", h.adminAuth(h.handleList))
+	mux.HandleFunc("POST /v1/pairing/{code}/approve", h.adminAuth(h.handleApprove))
+	mux.HandleFunc("POST /v1/pairing/{code}/deny", h.adminAuth(h.handleDeny))
+	mux.HandleFunc("DELETE /v1/pairing/{channel}/{senderId}", h.adminAuth(h.handleRevoke))
+}
+
+func (h *PairingHandler) adminAuth(next http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(permissions.RoleAdmin, next)
+}
+
+// handleList returns pending codes and paired senders, optionally filtered
+// by ?channel=.
+func (h *PairingHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	pending := h.pairing.ListPending(r.Context())
+	paired := h.pairing.ListPaired(r.Context())
+	if ch := r.URL.Query().Get("channel"); ch != "" {
+		pending = slices.DeleteFunc(pending, func(p store.PairingRequestData) bool { return p.Channel != ch })
+		paired = slices.DeleteFunc(paired, func(p store.PairedDeviceData) bool { return p.Channel != ch })
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"pending": pending, "paired": paired})
+}
+
+func (h *PairingHandler) handleApprove(w http.ResponseWriter, r *http.Request) {
+	code := store.NormalizePairingCode(r.PathValue("code"))
+	approvedBy := store.UserIDFromContext(r.Context())
+	if approvedBy == "" {
+		approvedBy = "operator"
+	}
+	paired, err := h.pairing.ApprovePairing(r.Context(), code, approvedBy)
+	if err != nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, err.Error())
+		return
+	}
+	// Background context: the notification must not die with the request.
+	if h.onApprove != nil {
+		go h.onApprove(context.Background(), paired.Channel, paired.ChatID, paired.SenderID)
+	}
+	h.broadcast("approved")
+	emitAudit(h.msgBus, r, "pairing.approved", "pairing", code)
+	writeJSON(w, http.StatusOK, map[string]any{"paired": paired})
+}
+
+func (h *PairingHandler) handleDeny(w http.ResponseWriter, r *http.Request) {
+	code := store.NormalizePairingCode(r.PathValue("code"))
+	if err := h.pairing.DenyPairing(r.Context(), code); err != nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, err.Error())
+		return
+	}
+	h.broadcast("denied")
+	emitAudit(h.msgBus, r, "pairing.denied", "pairing", code)
+	writeJSON(w, http.StatusOK, map[string]any{"denied": true})
+}
+
+// handleRevoke unpairs a sender and disconnects its active sessions.
+func (h *PairingHandler) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	channel, senderID := r.PathValue("channel"), r.PathValue("senderId")
+	if !store.IsValidPairingSenderID(senderID) {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, "invalid sender_id format"))
+		return
+	}
+	if err := h.pairing.RevokePairing(r.Context(), senderID, channel); err != nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, err.Error())
+		return
+	}
+	h.broadcast("revoked")
+	if h.msgBus != nil {
+		h.msgBus.Broadcast(bus.Event{
+			Name:    bus.EventPairingRevoked,
+			Payload: bus.PairingRevokedPayload{SenderID: senderID, Channel: channel},
+		})
+	}
+	emitAudit(h.msgBus, r, "pairing.revoked", "pairing", senderID)
+	writeJSON(w, http.StatusOK, map[string]any{"revoked": true})
+}
+
+func (h *PairingHandler) broadcast(action string) {
+	if h.broadcaster != nil {
+		h.broadcaster(*protocol.NewEvent(protocol.EventDevicePairRes, map[string]any{"action": action}))
+	}
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type listPairingStore struct {
+	mockPairingStore
+	revoked []string
+}
+
+func (m *listPairingStore) ListPending(context.Context) []store.PairingRequestData {
+	return []store.PairingRequestData{{Code: "AAAA1111", Channel: "telegram"}, {Code: "BBBB2222", Channel: "discord"}}
+}
+
+func (m *listPairingStore) ListPaired(context.Context) []store.PairedDeviceData {
+	return []store.PairedDeviceData{{SenderID: "u1", Channel: "telegram"}, {SenderID: "u2", Channel: "zalo"}}
+}
+
+func (m *listPairingStore) RevokePairing(_ context.Context, senderID, channel string) error {
+	m.revoked = append(m.revoked, channel+"/"+senderID)
+	return nil
+}
+
+func TestPairingHandler(t *testing.T) {
+	setupTestCache(t, nil)
+	setupTestToken(t, "gw-token")
+	ps := &listPairingStore{}
+	mux := http.NewServeMux()
+	NewPairingHandler(ps, nil).RegisterRoutes(mux)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("Authorization", "Bearer gw-token")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := do("GET", "/v1/pairing?channel=telegram")
+	var list struct {
+		Pending []store.PairingRequestData `json:"pending"`
+		Paired  []store.PairedDeviceData   `json:"paired"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("list: %d %s", w.Code, w.Body)
+	}
+	if len(list.Pending) != 1 || list.Pending[0].Code != "AAAA1111" || len(list.Paired) != 1 || list.Paired[0].SenderID != "u1" {
+		t.Errorf("channel filter: %+v", list)
+	}
+
+	if w := do("DELETE", "/v1/pairing/telegram/u1"); w.Code != http.StatusOK {
+		t.Errorf("revoke: %d %s", w.Code, w.Body)
+	}
+	if w := do("DELETE", "/v1/pairing/telegram/-bad"); w.Code != http.StatusBadRequest {
+		t.Errorf("revoke bad sender: %d", w.Code)
+	}
+	if len(ps.revoked) != 1 || ps.revoked[0] != "telegram/u1" {
+		t.Errorf("revoked = %v", ps.revoked)
+	}
+
+	r := httptest.NewRequest("GET", "/v1/pairing", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated list: %d", w.Code)
+	}
+}
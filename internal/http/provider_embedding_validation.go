@@ -3,11 +3,14 @@ package http
 import (
 	"fmt"
 
+	"github.com/nextlevelbuilder/goclaw/internal/memory"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 // Provider-level embedding settings are used by the memory system, whose
-// PostgreSQL schema currently stores fixed vector(1536) embeddings.
+// PostgreSQL schema stores fixed-size vector(N) embeddings (1536 unless
+// resized with `goclaw memory reembed --dimensions`). Smaller requested
+// dimensions are zero-padded to fit; larger ones cannot be stored.
 func validateProviderEmbeddingSettings(p *store.LLMProviderData) error {
 	es := store.ParseEmbeddingSettings(p.Settings)
 	if es == nil || !es.Enabled {
@@ -16,12 +19,17 @@ func validateProviderEmbeddingSettings(p *store.LLMProviderData) error {
 	if es.Dimensions < 0 {
 		return fmt.Errorf("embedding.dimensions must be a positive integer or omitted")
 	}
-	if es.Dimensions > 0 && es.Dimensions != store.RequiredMemoryEmbeddingDimensions {
+	if cols := store.MemoryEmbeddingDimensions(); es.Dimensions > cols {
 		return fmt.Errorf(
-			"embedding.dimensions must be %d or omitted because GoClaw memory stores vector(%d)",
-			store.RequiredMemoryEmbeddingDimensions,
-			store.RequiredMemoryEmbeddingDimensions,
+			"embedding.dimensions must be at most %d or omitted because GoClaw memory stores vector(%d); run `goclaw memory reembed --dimensions %d` first to store larger vectors",
+			cols, cols, es.Dimensions,
 		)
 	}
+	if es.Format != "" && !memory.ValidEmbeddingFormats[es.Format] {
+		return fmt.Errorf("embedding.format must be one of openai, voyage, cohere, ollama or omitted")
+	}
+	if es.BatchSize < 0 {
+		return fmt.Errorf("embedding.batch_size must be a positive integer or omitted")
+	}
 	return nil
 }
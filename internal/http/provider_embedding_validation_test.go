@@ -34,8 +34,17 @@ func TestValidateProviderEmbeddingSettings(t *testing.T) {
 		}, false},
 		{"dimensions 1536", embeddingProvider(1536), false},
 		{"dimensions 2048", embeddingProvider(2048), true},
-		{"dimensions 1024", embeddingProvider(1024), true},
+		{"dimensions 1024 (zero-padded)", embeddingProvider(1024), false},
 		{"dimensions negative", embeddingProvider(-1), true},
+		{"cohere format", &store.LLMProviderData{
+			Settings: json.RawMessage(`{"embedding":{"enabled":true,"format":"cohere","batch_size":96}}`),
+		}, false},
+		{"unknown format", &store.LLMProviderData{
+			Settings: json.RawMessage(`{"embedding":{"enabled":true,"format":"bert"}}`),
+		}, true},
+		{"negative batch size", &store.LLMProviderData{
+			Settings: json.RawMessage(`{"embedding":{"enabled":true,"batch_size":-5}}`),
+		}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
 )
 
 // fetchAnthropicModels calls the Anthropic models API.
@@ -128,64 +129,18 @@ func fetchOpenAIModels(ctx context.Context, apiBase, apiKey string) ([]ModelInfo
 
 // fetchOllamaModels calls Ollama's native /api/tags endpoint to get model metadata
 // including parameter size, quantization level, and model family.
-// The api_base may include a /v1 suffix (from issue #654 normalization) — strip it
-// before appending /api/tags since /api/tags lives at the root, not under /v1.
+// The api_base may include a /v1 suffix (from issue #654 normalization);
+// NormalizeOllamaHost strips it since /api/tags lives at the root, not under /v1.
 func (h *ProvidersHandler) fetchOllamaModels(ctx context.Context, apiBase, apiKey string) ([]ModelInfo, error) {
-	// Strip /v1 suffix if present, then trim trailing slash.
-	base := strings.TrimRight(strings.TrimSuffix(strings.TrimRight(apiBase, "/"), "/v1"), "/")
-	url := config.DockerLocalhost(base + "/api/tags")
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	local, err := providers.ListOllamaModels(ctx, nil, config.DockerLocalhost(providers.NormalizeOllamaHost(apiBase)), apiKey)
 	if err != nil {
 		return nil, err
 	}
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
-	}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return nil, fmt.Errorf("ollama /api/tags returned %d: %s", resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Models []struct {
-			Name    string `json:"name"`
-			Details struct {
-				Family            string `json:"family"`
-				ParameterSize     string `json:"parameter_size"`
-				QuantizationLevel string `json:"quantization_level"`
-			} `json:"details"`
-		} `json:"models"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode ollama response: %w", err)
-	}
-
-	models := make([]ModelInfo, 0, len(result.Models))
-	for _, m := range result.Models {
-		// Build a human-readable display name: "family paramSize quantLevel" e.g. "gemma4 8.0B Q4_K_M"
-		var parts []string
-		if m.Details.Family != "" {
-			parts = append(parts, m.Details.Family)
-		}
-		if m.Details.ParameterSize != "" {
-			parts = append(parts, m.Details.ParameterSize)
-		}
-		if m.Details.QuantizationLevel != "" {
-			parts = append(parts, m.Details.QuantizationLevel)
-		}
-		name := m.Name
-		if len(parts) > 0 {
-			name = strings.Join(parts, " ")
-		}
-		models = append(models, ModelInfo{ID: m.Name, Name: name})
+	models := make([]ModelInfo, 0, len(local))
+	for _, m := range local {
+		// Human-readable display name: "family paramSize quantLevel" e.g. "gemma4 8.0B Q4_K_M"
+		models = append(models, ModelInfo{ID: m.Name, Name: m.DisplayName()})
 	}
 	return models, nil
 }
@@ -17,6 +17,7 @@ import (
 //	POST /v1/providers/{id}/verify-embedding
 //	Body: {"model": "text-embedding-3-small"}  (optional, falls back to settings.embedding.model)
 //	Response: {"valid": true, "dimensions": 1536} or {"valid": false, "error": "..."}
+//	          (+ "zero_padded": true below 1536 dims, "dimension_mismatch": true above)
 func (h *ProvidersHandler) handleVerifyEmbedding(w http.ResponseWriter, r *http.Request) {
 	locale := extractLocale(r)
 	id, err := uuid.Parse(r.PathValue("id"))
@@ -49,31 +50,49 @@ func (h *ProvidersHandler) handleVerifyEmbedding(w http.ResponseWriter, r *http.
 	// Parse embedding settings once for model/apiBase/dimensions resolution.
 	es := store.ParseEmbeddingSettings(p.Settings)
 
-	// Resolve model: request body → settings.embedding.model → default
-	model := req.Model
-	if model == "" && es != nil && es.Model != "" {
-		model = es.Model
-	}
-	if model == "" {
-		model = "text-embedding-3-small"
-	}
-
 	// Resolve API base: settings.embedding.api_base → provider api_base → resolved base
 	apiBase := h.resolveAPIBase(p)
 	if es != nil && es.APIBase != "" {
 		apiBase = es.APIBase
 	}
+	format := ""
+	if es != nil {
+		format = es.Format
+	}
+	if format == "" {
+		format = memory.DetectEmbeddingFormat(apiBase)
+	}
 
-	ep := memory.NewOpenAIEmbeddingProvider(p.Name, p.APIKey, apiBase, model)
+	// Resolve model: request body → settings.embedding.model → format default
+	model := req.Model
+	if model == "" && es != nil && es.Model != "" {
+		model = es.Model
+	}
+	if model == "" {
+		model = memory.DefaultEmbeddingModel(format)
+	}
 
-	// Apply dimension truncation: request body → provider settings → none.
+	// Apply dimensions: request body → provider settings → none.
 	// Clamp to reasonable range to avoid sending absurd values upstream.
 	truncDims := req.Dimensions
 	if truncDims <= 0 && es != nil && es.Dimensions > 0 {
 		truncDims = es.Dimensions
 	}
-	if truncDims > 0 && truncDims <= 8192 {
-		ep.WithDimensions(truncDims)
+	if truncDims < 0 || truncDims > 8192 {
+		truncDims = 0
+	}
+
+	ep, err := memory.NewEmbeddingProvider(memory.EmbeddingOptions{
+		Format:     format,
+		Name:       p.Name,
+		APIKey:     p.APIKey,
+		APIBase:    apiBase,
+		Model:      model,
+		Dimensions: truncDims,
+	})
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]any{"valid": false, "error": err.Error()})
+		return
 	}
 
 	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
@@ -90,8 +109,10 @@ func (h *ProvidersHandler) handleVerifyEmbedding(w http.ResponseWriter, r *http.
 		dims = len(vectors[0])
 	}
 	result := map[string]any{"valid": true, "dimensions": dims}
-	if dims > 0 && dims != 1536 {
+	if cols := store.MemoryEmbeddingDimensions(); dims > cols {
 		result["dimension_mismatch"] = true
+	} else if dims > 0 && dims < cols {
+		result["zero_padded"] = true
 	}
 	writeJSON(w, http.StatusOK, result)
 }
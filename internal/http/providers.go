@@ -195,13 +195,20 @@ func (h *ProvidersHandler) registerInMemory(p *store.LLMProviderData) {
 	}
 	// Ollama doesn't need an API key — handle before the key guard (same as startup).
 	// In Docker, swap localhost → host.docker.internal so the container can reach the host.
-	// api_base is stored with /v1 (normalized at write time), so no suffix appending needed.
+	// api_base is stored with /v1 (OpenAI-compat); the native provider strips it.
 	if p.ProviderType == store.ProviderOllama {
 		host := p.APIBase
 		if host == "" {
-			host = "http://localhost:11434/v1"
+			host = "http://localhost:11434"
 		}
-		h.providerReg.RegisterForTenant(p.TenantID, providers.NewOpenAIProvider(p.Name, "ollama", config.DockerLocalhost(host), "llama3.3"))
+		settings := store.ParseOllamaProviderSettings(p.Settings)
+		h.providerReg.RegisterForTenant(p.TenantID, providers.NewOllamaProvider(config.DockerLocalhost(host),
+			providers.WithOllamaName(p.Name),
+			providers.WithOllamaAPIKey(p.APIKey),
+			providers.WithOllamaModel(settings.Model),
+			providers.WithOllamaKeepAlive(settings.KeepAlive),
+			providers.WithOllamaNumCtx(settings.NumCtx),
+			providers.WithOllamaRegistry(h.modelReg)))
 		return
 	}
 	if p.APIKey == "" {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
@@ -222,17 +223,17 @@ func TestRegisterInMemoryOllamaURLNormalization(t *testing.T) {
 	tests := []struct {
 		name    string
 		apiBase string // value as stored in DB (post-normalization)
-		wantURL string // expected URLin the registered provider
+		wantURL string // expected host in the registered provider
 	}{
 		{
-			name:    "normalized /v1 is registered unchanged",
+			name:    "stored /v1 is stripped for the native API",
 			apiBase: "http://host:11434/v1",
-			wantURL: "http://host:11434/v1",
+			wantURL: "http://host:11434",
 		},
 		{
-			name:    "empty api_base falls back to default with /v1",
+			name:    "empty api_base falls back to default host",
 			apiBase: "",
-			wantURL: "http://localhost:11434/v1",
+			wantURL: "http://localhost:11434",
 		},
 	}
 
@@ -257,17 +258,13 @@ func TestRegisterInMemoryOllamaURLNormalization(t *testing.T) {
 				t.Fatalf("GetForTenant() error = %v", err)
 			}
 
-			type apiBaseProvider interface {
-				APIBase() string
-			}
-			abp, ok := runtimeProvider.(apiBaseProvider)
+			olp, ok := runtimeProvider.(*providers.OllamaProvider)
 			if !ok {
-				t.Fatalf("registered provider does not implement APIBase(), type = %T", runtimeProvider)
+				t.Fatalf("registered provider type = %T, want *providers.OllamaProvider", runtimeProvider)
 			}
 
-			got := abp.APIBase()
-			if got != tt.wantURL {
-				t.Fatalf("registered APIBase = %q, want %q", got, tt.wantURL)
+			if got := olp.Host(); got != config.DockerLocalhost(tt.wantURL) {
+				t.Fatalf("registered Host = %q, want %q", got, tt.wantURL)
 			}
 		})
 	}
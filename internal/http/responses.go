@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
 	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/sessions"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
@@ -21,6 +22,7 @@ type ResponsesHandler struct {
 	agents   *agent.Router
 	sessions store.SessionStore
 	postTurn tools.PostTurnProcessor
+	pressure BackpressureFunc // lane saturation check (nil = never reject)
 }
 
 // SetPostTurnProcessor sets the post-turn processor for team task dispatch.
@@ -28,6 +30,11 @@ func (h *ResponsesHandler) SetPostTurnProcessor(pt tools.PostTurnProcessor) {
 	h.postTurn = pt
 }
 
+// SetBackpressure sets the saturation check that rejects runs with 429.
+func (h *ResponsesHandler) SetBackpressure(fn BackpressureFunc) {
+	h.pressure = fn
+}
+
 // NewResponsesHandler creates a handler for the responses endpoint.
 func NewResponsesHandler(agents *agent.Router, sess store.SessionStore) *ResponsesHandler {
 	return &ResponsesHandler{
@@ -49,13 +56,13 @@ func (h *ResponsesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Auth + RBAC check (gateway token or API key, operator required for POST)
-	auth := resolveAuth(r)
+	// Auth + RBAC check (gateway token or API key; operator or member required)
+	auth := resolveChatAuth(r)
 	if !auth.Authenticated {
 		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
 		return
 	}
-	if !permissions.HasMinRole(auth.Role, permissions.RoleOperator) {
+	if !allowsRole(auth.Role, permissions.RoleMember, r.Method) {
 		http.Error(w, `{"error":"permission denied: insufficient role"}`, http.StatusForbidden)
 		return
 	}
@@ -64,6 +71,11 @@ func (h *ResponsesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	r = r.WithContext(enrichContext(r.Context(), r, auth))
 	locale := extractLocale(r)
 
+	if secs := checkBackpressure(w, h.pressure); secs > 0 {
+		http.Error(w, fmt.Sprintf(`{"error":%q}`, i18n.T(locale, i18n.MsgAgentsBusy, secs)), http.StatusTooManyRequests)
+		return
+	}
+
 	// Limit request body size to prevent DoS
 	const maxRequestBodySize = 1 << 20 // 1MB
 	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
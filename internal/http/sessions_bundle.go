@@ -0,0 +1,125 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// maxSessionBundleSize caps POST /v1/sessions/import bodies.
+const maxSessionBundleSize = 64 << 20
+
+// SessionsHandler serves session export/import over HTTP. The bundle format
+// is the same as the sessions.export / sessions.import RPCs.
+type SessionsHandler struct {
+	sessions store.SessionStore
+	agents   store.AgentStore
+	episodic store.EpisodicStore // optional
+	msgBus   *bus.MessageBus
+}
+
+// NewSessionsHandler creates a handler for session export/import endpoints.
+func NewSessionsHandler(sessions store.SessionStore, agents store.AgentStore, episodic store.EpisodicStore, msgBus *bus.MessageBus) *SessionsHandler {
+	return &SessionsHandler{sessions: sessions, agents: agents, episodic: episodic, msgBus: msgBus}
+}
+
+// RegisterRoutes registers session bundle routes on the given mux.
+func (h *SessionsHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Open to members: non-admins only reach their own sessions (canSeeAll).
+	mux.HandleFunc("GET /v1/sessions/{key}/export", requireAuth(permissions.RoleMember, h.handleExport))
+	mux.HandleFunc("POST /v1/sessions/import", requireAuth(permissions.RoleMember, h.handleImport))
+}
+
+func (h *SessionsHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	key := r.PathValue("key")
+
+	bundle, err := store.ExportSessionBundle(r.Context(), h.sessions, h.episodic, key)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, err.Error()))
+		return
+	}
+	if bundle == nil {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "session", key))
+		return
+	}
+	if !h.canSeeAll(r) && bundle.Session.UserID != store.UserIDFromContext(r.Context()) {
+		writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session"))
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.session.json"`, sessionBundleFileName(key)))
+	writeJSON(w, http.StatusOK, bundle)
+	emitAudit(h.msgBus, r, "session.exported", "session", key)
+}
+
+// handleImport accepts a bundle as the body. Query: key (import under a
+// different session key), overwrite=true (replace an existing session).
+// Non-admins need access to the agent and may only use their own DM key;
+// other bundles are imported under a new web-chat key.
+func (h *SessionsHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+	r.Body = http.MaxBytesReader(w, r.Body, maxSessionBundleSize)
+
+	var bundle store.SessionBundle
+	if !bindJSON(w, r, locale, &bundle) {
+		return
+	}
+
+	opts := store.SessionImportOpts{
+		Key:       r.URL.Query().Get("key"),
+		Overwrite: r.URL.Query().Get("overwrite") == "true",
+	}
+	if !h.canSeeAll(r) {
+		// Non-admins import as themselves; ImportSessionBundle enforces the rest.
+		opts.UserID = store.UserIDFromContext(r.Context())
+		if opts.UserID == "" {
+			writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "session"))
+			return
+		}
+	}
+
+	res, err := store.ImportSessionBundle(r.Context(), h.sessions, h.agents, h.episodic, &bundle, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrSessionBundleInvalid):
+			writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, err.Error())
+		case errors.Is(err, store.ErrSessionExists):
+			writeError(w, http.StatusConflict, protocol.ErrAlreadyExists, err.Error())
+		case errors.Is(err, store.ErrSessionAgentNotFound):
+			writeError(w, http.StatusNotFound, protocol.ErrNotFound, err.Error())
+		case errors.Is(err, store.ErrSessionImportDenied):
+			writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, err.Error()))
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+	emitAudit(h.msgBus, r, "session.imported", "session", res.Key)
+}
+
+// canSeeAll reports whether the caller may read and replace any session in
+// the tenant (admins); others are limited to their own sessions.
+func (h *SessionsHandler) canSeeAll(r *http.Request) bool {
+	return permissions.HasMinRole(resolveAuth(r).Role, permissions.RoleAdmin)
+}
+
+// sessionBundleFileName makes a session key safe for a download file name.
+func sessionBundleFileName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, key)
+}
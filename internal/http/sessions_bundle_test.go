@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// bundleSessionStore serves Get from a map; other methods panic.
+type bundleSessionStore struct {
+	store.SessionStore
+	sessions map[string]*store.SessionData
+}
+
+func (s *bundleSessionStore) Get(_ context.Context, key string) *store.SessionData {
+	return s.sessions[key]
+}
+
+func newSessionsBundleMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	setupTestCache(t, nil)
+	setupTestToken(t, "gw-token")
+	setupScopedTokens(t, []config.ScopedToken{
+		{Name: "alice", TokenHash: crypto.HashAPIKey("alice-token"), Scopes: []string{"operator.read", "operator.write"}, UserID: "alice"},
+	})
+	ss := &bundleSessionStore{sessions: map[string]*store.SessionData{
+		"agent:a:ws:direct:alice": {Key: "agent:a:ws:direct:alice", UserID: "alice"},
+		"agent:a:ws:direct:bob":   {Key: "agent:a:ws:direct:bob", UserID: "bob"},
+	}}
+	mux := http.NewServeMux()
+	NewSessionsHandler(ss, nil, nil, nil).RegisterRoutes(mux)
+	return mux
+}
+
+func TestSessionsExport_OwnSessionOnlyForNonAdmin(t *testing.T) {
+	mux := newSessionsBundleMux(t)
+	get := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/v1/sessions/"+key+"/export", nil)
+		req.Header.Set("Authorization", "Bearer alice-token")
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := get("agent:a:ws:direct:bob"); rr.Code != http.StatusForbidden {
+		t.Fatalf("export of another user's session: status = %d, want 403", rr.Code)
+	}
+
+	rr := get("agent:a:ws:direct:alice")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("export own session: status = %d: %s", rr.Code, rr.Body.String())
+	}
+	var b store.SessionBundle
+	if err := json.Unmarshal(rr.Body.Bytes(), &b); err != nil {
+		t.Fatalf("decode bundle: %v", err)
+	}
+	if b.Format != store.SessionBundleFormat || b.Session.Key != "agent:a:ws:direct:alice" || b.Session.AgentKey != "a" {
+		t.Fatalf("unexpected bundle header: %+v", b)
+	}
+	if !strings.Contains(rr.Header().Get("Content-Disposition"), "agent_a_ws_direct_alice.session.json") {
+		t.Errorf("Content-Disposition = %q", rr.Header().Get("Content-Disposition"))
+	}
+}
+
+func TestSessionsImport_RejectsForeignFormat(t *testing.T) {
+	mux := newSessionsBundleMux(t)
+	req := httptest.NewRequest("POST", "/v1/sessions/import",
+		strings.NewReader(`{"format":"other","version":1,"session":{"key":"agent:a:ws:direct:x"}}`))
+	req.Header.Set("Authorization", "Bearer gw-token")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestSessionsImport_NonAdminCannotTargetAnotherUsersKey(t *testing.T) {
+	mux := newSessionsBundleMux(t)
+	req := httptest.NewRequest("POST", "/v1/sessions/import?key=agent:a:telegram:direct:bob",
+		strings.NewReader(`{"format":"goclaw.session","version":1,"session":{"key":"agent:a:ws:direct:x"}}`))
+	req.Header.Set("Authorization", "Bearer alice-token")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403: %s", rr.Code, rr.Body.String())
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
-const maxSkillUploadSize = 20 << 20 // 20 MB
+const maxSkillUploadSize = skills.MaxSkillPackageSize // 20 MB
 
 var (
 	aggregateInstallDeps = skills.AggregateMissingDeps
@@ -87,15 +87,15 @@ func (h *SkillsHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/skills/{id}/versions", h.authMiddleware(h.handleListVersions))
 	mux.HandleFunc("GET /v1/skills/{id}/files/{path...}", h.authMiddleware(h.handleReadFile))
 	mux.HandleFunc("GET /v1/skills/{id}/files", h.authMiddleware(h.handleListFiles))
-	// Skill writes (admin+)
-	mux.HandleFunc("POST /v1/skills/upload", h.adminMiddleware(h.handleUpload))
-	mux.HandleFunc("PUT /v1/skills/{id}", h.adminMiddleware(h.handleUpdate))
-	mux.HandleFunc("DELETE /v1/skills/{id}", h.adminMiddleware(h.handleDelete))
-	// Skill grants (admin+)
-	mux.HandleFunc("POST /v1/skills/{id}/grants/agent", h.adminMiddleware(h.handleGrantAgent))
-	mux.HandleFunc("DELETE /v1/skills/{id}/grants/agent/{agentID}", h.adminMiddleware(h.handleRevokeAgent))
-	mux.HandleFunc("POST /v1/skills/{id}/grants/user", h.adminMiddleware(h.handleGrantUser))
-	mux.HandleFunc("DELETE /v1/skills/{id}/grants/user/{userID}", h.adminMiddleware(h.handleRevokeUser))
+	// Skill writes (operator+; non-admins only touch skills they own)
+	mux.HandleFunc("POST /v1/skills/upload", h.operatorMiddleware(h.handleUpload))
+	mux.HandleFunc("PUT /v1/skills/{id}", h.operatorMiddleware(h.handleUpdate))
+	mux.HandleFunc("DELETE /v1/skills/{id}", h.operatorMiddleware(h.handleDelete))
+	// Skill grants (operator+, skill owner)
+	mux.HandleFunc("POST /v1/skills/{id}/grants/agent", h.operatorMiddleware(h.handleGrantAgent))
+	mux.HandleFunc("DELETE /v1/skills/{id}/grants/agent/{agentID}", h.operatorMiddleware(h.handleRevokeAgent))
+	mux.HandleFunc("POST /v1/skills/{id}/grants/user", h.operatorMiddleware(h.handleGrantUser))
+	mux.HandleFunc("DELETE /v1/skills/{id}/grants/user/{userID}", h.operatorMiddleware(h.handleRevokeUser))
 	// System-level operations: admin + master tenant only.
 	// These execute shell commands (pip/npm install) and affect the entire server.
 	mux.HandleFunc("POST /v1/skills/rescan-deps", h.adminMiddleware(h.handleRescanDeps))
@@ -116,6 +116,12 @@ func (h *SkillsHandler) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return requireAuth("", next)
 }
 
+// operatorMiddleware requires operator role — used for managing skills; the
+// handlers limit non-admins to skills they own.
+func (h *SkillsHandler) operatorMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return requireAuth(permissions.RoleOperator, next)
+}
+
 // adminMiddleware requires admin role — used for system-level operations
 // (rescan deps, install packages, toggle skills) that affect the entire server.
 func (h *SkillsHandler) adminMiddleware(next http.HandlerFunc) http.HandlerFunc {
@@ -2,6 +2,7 @@ package http
 
 import (
 	"archive/zip"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -66,8 +67,16 @@ func (h *SkillsHandler) handleGrantAgent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if req.Version <= 0 {
-		req.Version = 1
+	// version pins the agent to that skill version; 0 follows the active version.
+	if req.Version < 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgInvalidRequest, "version must be >= 0")})
+		return
+	}
+	if req.Version > 0 {
+		if info, ok := h.skills.GetSkillByID(r.Context(), skillID); ok && req.Version > info.Version {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": i18n.T(locale, i18n.MsgInvalidRequest, fmt.Sprintf("version %d does not exist (latest is %d)", req.Version, info.Version))})
+			return
+		}
 	}
 
 	if err := h.skills.GrantToAgent(r.Context(), skillID, agentID, req.Version, userID); err != nil {
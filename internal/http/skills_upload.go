@@ -18,6 +18,7 @@ import (
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
 	"github.com/nextlevelbuilder/goclaw/internal/skills"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
@@ -149,6 +150,14 @@ func (h *SkillsHandler) handleUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A new version of an existing skill may only come from its owner (admins bypass)
+	if !permissions.HasMinRole(permissions.Role(store.RoleFromContext(r.Context())), permissions.RoleAdmin) {
+		if ownerID, found := h.skills.GetSkillOwnerIDBySlug(r.Context(), slug); found && ownerID != userID {
+			writeJSON(w, http.StatusForbidden, map[string]string{"error": "only the skill owner can perform this action"})
+			return
+		}
+	}
+
 	// Compute content hash of SKILL.md for idempotency check.
 	// Using SKILL.md content (not ZIP hash) so content-identical uploads are deduplicated
 	// even when packaged into different ZIP files (e.g. multi-skill split upload).
@@ -20,6 +20,8 @@ const (
 	SummonEventFailed        = "failed"
 	SummonEventCompleted     = "completed"
 	SummonEventFileGenerated = "file_generated"
+	SummonEventPreviewReady  = "preview_ready"
+	SummonEventPreviewFailed = "preview_failed"
 )
 
 // frontmatterKey is the special key used to store frontmatter in the parsed file map.
@@ -48,6 +50,7 @@ type AgentSummoner struct {
 	agents      store.AgentStore
 	providerReg *providers.Registry
 	msgBus      *bus.MessageBus
+	previews    summonPreviews // pending regeneration previews (see StartPreview)
 }
 
 // NewAgentSummoner creates a summoner backed by the given stores and provider registry.
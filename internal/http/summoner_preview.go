@@ -0,0 +1,313 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// summonPreviewTTL is how long an unapplied preview is kept in memory.
+const summonPreviewTTL = time.Hour
+
+// Summon preview statuses.
+const (
+	SummonPreviewGenerating = "generating"
+	SummonPreviewReady      = "ready"
+	SummonPreviewFailed     = "failed"
+)
+
+// Summon preview file change statuses.
+const (
+	SummonFileAdded     = "added"
+	SummonFileModified  = "modified"
+	SummonFileUnchanged = "unchanged"
+)
+
+var (
+	errSummonPreviewNotFound = errors.New("summon preview not found")
+	errSummonPreviewNotReady = errors.New("summon preview not ready")
+	errSummonPreviewStale    = errors.New("agent files changed since preview")
+)
+
+// SummonFileChange is one proposed context file edit in a preview.
+// The frontmatter summary is reported under the file name "frontmatter".
+type SummonFileChange struct {
+	File   string `json:"file"`
+	Status string `json:"status"` // SummonFile* constant
+	Before string `json:"before,omitempty"`
+	After  string `json:"after"`
+	Diff   string `json:"diff,omitempty"` // unified diff, empty when unchanged
+}
+
+// SummonPreview is a proposed regeneration held for review. Nothing is written
+// to the agent until ApplyPreview is called.
+type SummonPreview struct {
+	ID        uuid.UUID          `json:"id"`
+	AgentID   uuid.UUID          `json:"agent_id"`
+	ParentID  *uuid.UUID         `json:"parent_id,omitempty"` // preview this one refines
+	Prompt    string             `json:"prompt"`
+	Status    string             `json:"status"`
+	Error     string             `json:"error,omitempty"`
+	Files     []SummonFileChange `json:"files,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+
+	// proposed holds full proposed contents (including those inherited from
+	// the parent preview), keyed like generateFiles output.
+	proposed map[string]string
+}
+
+// summonPreviews is the in-memory preview registry of an AgentSummoner.
+type summonPreviews struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]*SummonPreview
+}
+
+// previewFrontmatterName is the SummonFileChange.File used for the frontmatter summary.
+const previewFrontmatterName = "frontmatter"
+
+// StartPreview begins generating a proposed regeneration of the agent's
+// context files from editPrompt without writing them. When parentID is set,
+// the edit is applied on top of that preview's proposals (refinement loop)
+// and the result accumulates both. Generation runs in the background; a
+// preview_ready or preview_failed summoning event reports completion.
+func (s *AgentSummoner) StartPreview(agentID, tenantID uuid.UUID, providerName, model, editPrompt string, parentID *uuid.UUID) (*SummonPreview, error) {
+	var base map[string]string
+	if parentID != nil {
+		parent, ok := s.getPreview(agentID, *parentID)
+		if !ok {
+			return nil, errSummonPreviewNotFound
+		}
+		if parent.Status != SummonPreviewReady {
+			return nil, errSummonPreviewNotReady
+		}
+		base = parent.proposed
+	}
+
+	p := &SummonPreview{
+		ID:        uuid.New(),
+		AgentID:   agentID,
+		ParentID:  parentID,
+		Prompt:    editPrompt,
+		Status:    SummonPreviewGenerating,
+		CreatedAt: time.Now().UTC(),
+	}
+	s.putPreview(p)
+	snapshot := *p
+
+	go s.generatePreview(p.ID, agentID, tenantID, providerName, model, editPrompt, base)
+	return &snapshot, nil
+}
+
+// generatePreview runs the LLM edit and records the diff against the stored files.
+func (s *AgentSummoner) generatePreview(previewID, agentID, tenantID uuid.UUID, providerName, model, editPrompt string, base map[string]string) {
+	ctx, cancel := context.WithTimeout(store.WithTenantID(context.Background(), tenantID), 300*time.Second)
+	defer cancel()
+
+	current := s.loadExistingFiles(ctx, agentID)
+
+	// The LLM sees the stored files overlaid with earlier proposals.
+	var existing []store.AgentContextFileData
+	for _, name := range summoningFiles {
+		content := current[name]
+		if v, ok := base[name]; ok {
+			content = v
+		}
+		existing = append(existing, store.AgentContextFileData{FileName: name, Content: content})
+	}
+
+	files, err := s.generateFiles(ctx, providerName, model, s.buildEditPrompt(existing, editPrompt))
+	if err != nil {
+		slog.Warn("summoning: preview generation failed", "agent", agentID, "preview", previewID, "error", err)
+		s.updatePreview(previewID, func(p *SummonPreview) {
+			p.Status = SummonPreviewFailed
+			p.Error = err.Error()
+		})
+		s.emitPreviewEvent(agentID, tenantID, SummonEventPreviewFailed, previewID, nil, err.Error())
+		return
+	}
+
+	proposed := make(map[string]string, len(base)+len(files))
+	for k, v := range base {
+		proposed[k] = v
+	}
+	for k, v := range files {
+		if v != "" {
+			proposed[k] = v
+		}
+	}
+
+	var frontmatter string
+	if ag, err := s.agents.GetByID(ctx, agentID); err == nil && ag != nil {
+		frontmatter = ag.Frontmatter
+	}
+	changes := buildSummonChanges(current, frontmatter, proposed)
+
+	changed := []string{}
+	for _, c := range changes {
+		if c.Status != SummonFileUnchanged {
+			changed = append(changed, c.File)
+		}
+	}
+	s.updatePreview(previewID, func(p *SummonPreview) {
+		p.Status = SummonPreviewReady
+		p.Files = changes
+		p.proposed = proposed
+	})
+	s.emitPreviewEvent(agentID, tenantID, SummonEventPreviewReady, previewID, changed, "")
+	slog.Info("summoning: preview ready", "agent", agentID, "preview", previewID, "changed", len(changed))
+}
+
+// buildSummonChanges diffs proposed contents against the stored files, in
+// summoningFiles order followed by the frontmatter summary.
+func buildSummonChanges(current map[string]string, frontmatter string, proposed map[string]string) []SummonFileChange {
+	var changes []SummonFileChange
+	add := func(name, before, after string) {
+		c := SummonFileChange{File: name, Before: before, After: after, Diff: unifiedDiff(name, before, after)}
+		switch {
+		case before == after:
+			c.Status = SummonFileUnchanged
+		case before == "":
+			c.Status = SummonFileAdded
+		default:
+			c.Status = SummonFileModified
+		}
+		changes = append(changes, c)
+	}
+	for _, name := range summoningFiles {
+		if after, ok := proposed[name]; ok {
+			add(name, current[name], after)
+		}
+	}
+	if fm, ok := proposed[frontmatterKey]; ok {
+		add(previewFrontmatterName, frontmatter, fm)
+	}
+	return changes
+}
+
+// Preview returns a snapshot of the agent's preview.
+func (s *AgentSummoner) Preview(agentID, previewID uuid.UUID) (*SummonPreview, bool) {
+	p, ok := s.getPreview(agentID, previewID)
+	if !ok {
+		return nil, false
+	}
+	return &p, true
+}
+
+// ApplyPreview writes the preview's proposals to the agent. files selects
+// which changes to accept by SummonFileChange.File (empty = all changed).
+// Unless force is set, it refuses with errSummonPreviewStale when a selected
+// file was edited after the preview was generated. Returns the applied files.
+func (s *AgentSummoner) ApplyPreview(ctx context.Context, agentID, tenantID, previewID uuid.UUID, files []string, force bool) ([]string, error) {
+	p, ok := s.getPreview(agentID, previewID)
+	if !ok {
+		return nil, errSummonPreviewNotFound
+	}
+	if p.Status != SummonPreviewReady {
+		return nil, errSummonPreviewNotReady
+	}
+
+	current := s.loadExistingFiles(ctx, agentID)
+	var frontmatter string
+	if ag, err := s.agents.GetByID(ctx, agentID); err == nil && ag != nil {
+		frontmatter = ag.Frontmatter
+	}
+
+	selected := map[string]string{}
+	var applied, stale []string
+	for _, c := range p.Files {
+		if c.Status == SummonFileUnchanged || (len(files) > 0 && !slices.Contains(files, c.File)) {
+			continue
+		}
+		now, key := current[c.File], c.File
+		if c.File == previewFrontmatterName {
+			now, key = frontmatter, frontmatterKey
+		}
+		if now != c.Before {
+			stale = append(stale, c.File)
+		}
+		selected[key] = c.After
+		applied = append(applied, c.File)
+	}
+	if len(stale) > 0 && !force {
+		return stale, errSummonPreviewStale
+	}
+
+	s.applyGeneratedFiles(ctx, agentID, tenantID, selected)
+	s.DiscardPreview(agentID, previewID)
+	s.emitEvent(agentID, tenantID, SummonEventCompleted, "", "")
+	slog.Info("summoning: preview applied", "agent", agentID, "preview", previewID, "files", applied)
+	return applied, nil
+}
+
+// DiscardPreview drops a preview. Returns false if it did not exist.
+func (s *AgentSummoner) DiscardPreview(agentID, previewID uuid.UUID) bool {
+	s.previews.mu.Lock()
+	defer s.previews.mu.Unlock()
+	p, ok := s.previews.items[previewID]
+	if !ok || p.AgentID != agentID {
+		return false
+	}
+	delete(s.previews.items, previewID)
+	return true
+}
+
+func (s *AgentSummoner) putPreview(p *SummonPreview) {
+	s.previews.mu.Lock()
+	defer s.previews.mu.Unlock()
+	if s.previews.items == nil {
+		s.previews.items = make(map[uuid.UUID]*SummonPreview)
+	}
+	cutoff := time.Now().Add(-summonPreviewTTL)
+	for id, old := range s.previews.items {
+		if old.CreatedAt.Before(cutoff) {
+			delete(s.previews.items, id)
+		}
+	}
+	s.previews.items[p.ID] = p
+}
+
+// getPreview returns a copy of the preview if it belongs to agentID and has not expired.
+func (s *AgentSummoner) getPreview(agentID, previewID uuid.UUID) (SummonPreview, bool) {
+	s.previews.mu.Lock()
+	defer s.previews.mu.Unlock()
+	p, ok := s.previews.items[previewID]
+	if !ok || p.AgentID != agentID || time.Since(p.CreatedAt) > summonPreviewTTL {
+		return SummonPreview{}, false
+	}
+	return *p, true
+}
+
+func (s *AgentSummoner) updatePreview(previewID uuid.UUID, fn func(*SummonPreview)) {
+	s.previews.mu.Lock()
+	defer s.previews.mu.Unlock()
+	if p, ok := s.previews.items[previewID]; ok {
+		fn(p)
+	}
+}
+
+func (s *AgentSummoner) emitPreviewEvent(agentID, tenantID uuid.UUID, eventType string, previewID uuid.UUID, changed []string, errMsg string) {
+	if s.msgBus == nil {
+		return
+	}
+	payload := map[string]any{
+		"type":       eventType,
+		"agent_id":   agentID.String(),
+		"preview_id": previewID.String(),
+	}
+	if changed != nil {
+		payload["files"] = changed // changed file names
+	}
+	if errMsg != "" {
+		payload["error"] = errMsg
+	}
+	bus.BroadcastForTenant(s.msgBus, protocol.EventAgentSummoning, tenantID, payload)
+}
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// previewStubStore is an in-memory AgentStore for summon preview tests.
+type previewStubStore struct {
+	store.AgentStore // embed to satisfy interface; unused methods panic
+	mu               sync.Mutex
+	files            map[string]string
+	updates          []map[string]any
+}
+
+func (s *previewStubStore) GetAgentContextFiles(_ context.Context, _ uuid.UUID) ([]store.AgentContextFileData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []store.AgentContextFileData
+	for name, content := range s.files {
+		out = append(out, store.AgentContextFileData{FileName: name, Content: content})
+	}
+	return out, nil
+}
+
+func (s *previewStubStore) SetAgentContextFile(_ context.Context, _ uuid.UUID, fileName, content string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[fileName] = content
+	return nil
+}
+
+func (s *previewStubStore) GetByID(_ context.Context, id uuid.UUID) (*store.AgentData, error) {
+	return &store.AgentData{}, nil
+}
+
+func (s *previewStubStore) Update(_ context.Context, _ uuid.UUID, updates map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.updates = append(s.updates, updates)
+	return nil
+}
+
+func (s *previewStubStore) file(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.files[name]
+}
+
+// scriptedProvider returns queued responses in order.
+type scriptedProvider struct {
+	mu        sync.Mutex
+	responses []string
+}
+
+func (p *scriptedProvider) Chat(_ context.Context, _ providers.ChatRequest) (*providers.ChatResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.responses) == 0 {
+		return nil, errors.New("no scripted response")
+	}
+	resp := p.responses[0]
+	p.responses = p.responses[1:]
+	return &providers.ChatResponse{Content: resp}, nil
+}
+
+func (p *scriptedProvider) ChatStream(ctx context.Context, req providers.ChatRequest, _ func(providers.StreamChunk)) (*providers.ChatResponse, error) {
+	return p.Chat(ctx, req)
+}
+
+func (p *scriptedProvider) DefaultModel() string { return "test-model" }
+func (p *scriptedProvider) Name() string         { return "scripted" }
+
+func newPreviewSummoner(agents store.AgentStore, responses ...string) *AgentSummoner {
+	reg := providers.NewRegistry(nil)
+	reg.Register(&scriptedProvider{responses: responses})
+	return &AgentSummoner{agents: agents, providerReg: reg}
+}
+
+func waitPreview(t *testing.T, s *AgentSummoner, agentID, previewID uuid.UUID) *SummonPreview {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		p, ok := s.Preview(agentID, previewID)
+		if !ok {
+			t.Fatal("preview disappeared")
+		}
+		if p.Status != SummonPreviewGenerating {
+			return p
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("preview did not finish")
+	return nil
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+	want := "--- a/SOUL.md\n+++ b/SOUL.md\n" +
+		"@@ -2,9 +2,10 @@\n b\n c\n d\n-e\n+E\n f\n g\n h\n i\n j\n+k\n"
+	if got := unifiedDiff("SOUL.md", before, after); got != want {
+		t.Errorf("diff mismatch:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := unifiedDiff("SOUL.md", "same\n", "same\n"); got != "" {
+		t.Errorf("identical contents should produce no diff, got %q", got)
+	}
+	if got := unifiedDiff("SOUL.md", "", "new\n"); !strings.HasPrefix(got, "--- /dev/null\n+++ b/SOUL.md\n@@ -0,0 +1,1 @@\n+new\n") {
+		t.Errorf("added file diff = %q", got)
+	}
+}
+
+func TestSummonPreview_RefineAndApply(t *testing.T) {
+	agentID := uuid.New()
+	agents := &previewStubStore{files: map[string]string{bootstrap.SoulFile: "soul v1"}}
+	s := newPreviewSummoner(agents,
+		`<file name="SOUL.md">soul v2</file>`,
+		`<file name="CAPABILITIES.md">caps v1</file>`,
+	)
+	ctx := context.Background()
+
+	first, err := s.StartPreview(agentID, uuid.Nil, "scripted", "", "be terser", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := waitPreview(t, s, agentID, first.ID)
+	if p.Status != SummonPreviewReady || len(p.Files) != 1 || p.Files[0].Status != SummonFileModified {
+		t.Fatalf("first preview = %+v", p)
+	}
+	if agents.file(bootstrap.SoulFile) != "soul v1" {
+		t.Fatal("preview must not write files")
+	}
+
+	// Refinement keeps the parent's proposals and adds the new ones.
+	second, err := s.StartPreview(agentID, uuid.Nil, "scripted", "", "add capabilities", &first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p = waitPreview(t, s, agentID, second.ID)
+	if len(p.Files) != 2 || p.Files[0].After != "soul v2" || p.Files[1].Status != SummonFileAdded {
+		t.Fatalf("refined preview files = %+v", p.Files)
+	}
+
+	// A concurrent edit makes the preview stale.
+	_ = agents.SetAgentContextFile(ctx, agentID, bootstrap.SoulFile, "edited by hand")
+	stale, err := s.ApplyPreview(ctx, agentID, uuid.Nil, second.ID, nil, false)
+	if !errors.Is(err, errSummonPreviewStale) || len(stale) != 1 || stale[0] != bootstrap.SoulFile {
+		t.Fatalf("ApplyPreview stale = %v, %v", stale, err)
+	}
+
+	// Applying only the untouched file succeeds.
+	applied, err := s.ApplyPreview(ctx, agentID, uuid.Nil, second.ID, []string{bootstrap.CapabilitiesFile}, false)
+	if err != nil || len(applied) != 1 {
+		t.Fatalf("ApplyPreview = %v, %v", applied, err)
+	}
+	if agents.file(bootstrap.CapabilitiesFile) != "caps v1" || agents.file(bootstrap.SoulFile) != "edited by hand" {
+		t.Errorf("files after apply = %v", agents.files)
+	}
+	if _, ok := s.Preview(agentID, second.ID); ok {
+		t.Error("applied preview should be discarded")
+	}
+}
+
+func TestSummonPreview_Failure(t *testing.T) {
+	agentID := uuid.New()
+	s := newPreviewSummoner(&previewStubStore{files: map[string]string{}}) // no responses → error
+
+	started, err := s.StartPreview(agentID, uuid.Nil, "scripted", "", "anything", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := waitPreview(t, s, agentID, started.ID)
+	if p.Status != SummonPreviewFailed || p.Error == "" {
+		t.Fatalf("preview = %+v", p)
+	}
+	if _, err := s.StartPreview(agentID, uuid.Nil, "scripted", "", "refine", &started.ID); !errors.Is(err, errSummonPreviewNotReady) {
+		t.Errorf("refining a failed preview: err = %v", err)
+	}
+	if _, err := s.ApplyPreview(context.Background(), agentID, uuid.Nil, started.ID, nil, false); !errors.Is(err, errSummonPreviewNotReady) {
+		t.Errorf("applying a failed preview: err = %v", err)
+	}
+	if s.DiscardPreview(uuid.New(), started.ID) {
+		t.Error("discard must be scoped to the owning agent")
+	}
+}
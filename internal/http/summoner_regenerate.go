@@ -48,6 +48,18 @@ func (s *AgentSummoner) RegenerateAgent(agentID uuid.UUID, tenantID uuid.UUID, p
 		return
 	}
 
+	s.applyGeneratedFiles(ctx, agentID, tenantID, files)
+
+	s.setAgentStatus(ctx, tenantID, agentID, store.AgentStatusActive)
+	s.emitEvent(agentID, tenantID, SummonEventCompleted, "", "")
+
+	slog.Info("summoning: regeneration completed", "agent", agentID, "files", len(files))
+}
+
+// applyGeneratedFiles stores regenerated context files and syncs agent
+// metadata: frontmatter, and display_name from IDENTITY.md unless the user
+// set a custom name (then IDENTITY.md is synced to that name instead).
+func (s *AgentSummoner) applyGeneratedFiles(ctx context.Context, agentID, tenantID uuid.UUID, files map[string]string) {
 	s.storeFiles(ctx, agentID, tenantID, files)
 
 	// Update frontmatter + display_name if IDENTITY.md was regenerated
@@ -75,11 +87,6 @@ func (s *AgentSummoner) RegenerateAgent(agentID uuid.UUID, tenantID uuid.UUID, p
 			slog.Warn("summoning: failed to save agent metadata", "agent", agentID, "error", err)
 		}
 	}
-
-	s.setAgentStatus(ctx, tenantID, agentID, store.AgentStatusActive)
-	s.emitEvent(agentID, tenantID, SummonEventCompleted, "", "")
-
-	slog.Info("summoning: regeneration completed", "agent", agentID, "files", len(files))
 }
 
 // isRetryableError returns true for timeout and context-cancellation errors
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/remotesync"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// SyncHandler publishes the encrypted state snapshot pulled by linked
+// standalone instances (see package remotesync).
+type SyncHandler struct {
+	exporter *remotesync.Exporter
+	key      string
+}
+
+// NewSyncHandler creates the snapshot handler. key is the shared sync key.
+func NewSyncHandler(exporter *remotesync.Exporter, key string) *SyncHandler {
+	return &SyncHandler{exporter: exporter, key: key}
+}
+
+// RegisterRoutes registers the sync route on the given mux.
+func (h *SyncHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+remotesync.SnapshotPath, requireAuth(permissions.RoleAdmin, h.handleSnapshot))
+}
+
+// GET /v1/sync/snapshot?since=<unix ms> — sealed snapshot; memory content
+// only for documents changed after since.
+func (h *SyncHandler) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	locale := store.LocaleFromContext(r.Context())
+
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 {
+			writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgInvalidRequest, "since must be a unix timestamp in milliseconds"))
+			return
+		}
+		since = time.UnixMilli(ms)
+	}
+
+	snap, err := h.exporter.Build(r.Context(), since)
+	if err != nil {
+		slog.Error("sync.snapshot: build failed", "error", err)
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, err.Error()))
+		return
+	}
+	sealed, err := remotesync.Seal(snap, h.key)
+	if err != nil {
+		slog.Error("sync.snapshot: seal failed", "error", err)
+		writeError(w, http.StatusInternalServerError, protocol.ErrInternal, i18n.T(locale, i18n.MsgInternalError, "seal snapshot"))
+		return
+	}
+	slog.Info("sync.snapshot: served", "agents", len(snap.Agents), "skills", len(snap.Skills), "since", since)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(sealed)
+}
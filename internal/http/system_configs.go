@@ -42,6 +42,8 @@ func (h *SystemConfigsHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/system-configs/{key}", requireAuth("", h.handleGet))
 	mux.HandleFunc("PUT /v1/system-configs/{key}", requireAuth("admin", h.handleSet))
 	mux.HandleFunc("DELETE /v1/system-configs/{key}", requireAuth("admin", h.handleDelete))
+	mux.HandleFunc("GET /v1/users/{userID}/accessibility", requireAuth("", h.handleGetAccessibility))
+	mux.HandleFunc("PUT /v1/users/{userID}/accessibility", requireAuth("", h.handleSetAccessibility))
 }
 
 func (h *SystemConfigsHandler) handleList(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +52,12 @@ func (h *SystemConfigsHandler) handleList(w http.ResponseWriter, r *http.Request
 		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
 		return
 	}
+	// Per-user accessibility rows have their own endpoints; keep the settings list clean.
+	for key := range configs {
+		if store.IsAccessibilityConfigKey(key) {
+			delete(configs, key)
+		}
+	}
 	writeJSON(w, http.StatusOK, configs)
 }
 
@@ -27,30 +27,10 @@ func (h *TeamAttachmentsHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /v1/teams/{teamId}/attachments/{attachmentId}/download", h.authMiddleware(h.handleDownload))
 }
 
+// authMiddleware admits a signed file token (?ft=) bound to the full request
+// path, or a bearer credential.
 func (h *TeamAttachmentsHandler) authMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		// Priority 1: HMAC-signed file token (?ft=) — no gateway token exposure.
-		if ft := r.URL.Query().Get("ft"); ft != "" {
-			path := r.URL.Path // full path for HMAC binding
-			if VerifyFileToken(ft, path, FileSigningKey()) {
-				next(w, r)
-				return
-			}
-			http.Error(w, "invalid or expired file token", http.StatusUnauthorized)
-			return
-		}
-		// Priority 2: Bearer header (API clients).
-		provided := extractBearerToken(r)
-		if provided == "" {
-			http.Error(w, "unauthorized", http.StatusUnauthorized)
-			return
-		}
-		authedReq, ok := requireAuthBearer("", provided, w, r)
-		if !ok {
-			return
-		}
-		next(w, authedReq)
-	}
+	return requireAuthOrFileToken("", func(r *http.Request) string { return r.URL.Path }, next)
 }
 
 func (h *TeamAttachmentsHandler) handleDownload(w http.ResponseWriter, r *http.Request) {
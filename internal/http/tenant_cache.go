@@ -16,12 +16,26 @@ type tenantCacheEntry struct {
 	fetchedAt time.Time
 }
 
-// tenantCache is a TTL cache for tenant lookups by UUID and slug.
-// Invalidated via bus CacheKindTenants events.
+// userRoleKey identifies a tenant_users membership.
+type userRoleKey struct {
+	tenantID uuid.UUID
+	userID   string
+}
+
+// userRoleEntry holds a cached tenant_users role ("" = not a member).
+type userRoleEntry struct {
+	role      string
+	fetchedAt time.Time
+}
+
+// tenantCache is a TTL cache for tenant lookups by UUID and slug, and for
+// users' tenant roles. Invalidated via bus CacheKindTenants and
+// CacheKindTenantUsers events.
 type tenantCache struct {
 	mu      sync.RWMutex
 	byID    map[uuid.UUID]*tenantCacheEntry
 	bySlug  map[string]*tenantCacheEntry
+	roles   map[userRoleKey]*userRoleEntry
 	ttl     time.Duration
 	store   store.TenantStore
 }
@@ -30,6 +44,7 @@ func newTenantCache(s store.TenantStore, ttl time.Duration) *tenantCache {
 	return &tenantCache{
 		byID:   make(map[uuid.UUID]*tenantCacheEntry),
 		bySlug: make(map[string]*tenantCacheEntry),
+		roles:  make(map[userRoleKey]*userRoleEntry),
 		ttl:    ttl,
 		store:  s,
 	}
@@ -77,6 +92,27 @@ func (c *tenantCache) GetTenantBySlug(ctx context.Context, slug string) (*store.
 	return t, nil
 }
 
+// GetUserRole returns the user's role in the tenant ("" when not a member),
+// using cache when available.
+func (c *tenantCache) GetUserRole(ctx context.Context, tenantID uuid.UUID, userID string) (string, error) {
+	key := userRoleKey{tenantID: tenantID, userID: userID}
+	c.mu.RLock()
+	if e, ok := c.roles[key]; ok && time.Since(e.fetchedAt) <= c.ttl {
+		c.mu.RUnlock()
+		return e.role, nil
+	}
+	c.mu.RUnlock()
+
+	role, err := c.store.GetUserRole(ctx, tenantID, userID)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.roles[key] = &userRoleEntry{role: role, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return role, nil
+}
+
 func (c *tenantCache) put(t *store.TenantData) {
 	entry := &tenantCacheEntry{tenant: t, fetchedAt: time.Now()}
 	c.mu.Lock()
@@ -92,4 +128,12 @@ func (c *tenantCache) invalidateAll() {
 	slog.Debug("tenant_cache.invalidated", "entries", len(c.byID))
 	c.byID = make(map[uuid.UUID]*tenantCacheEntry)
 	c.bySlug = make(map[string]*tenantCacheEntry)
+	c.roles = make(map[userRoleKey]*userRoleEntry)
+}
+
+// invalidateRoles clears cached user roles. Called on tenant_users changes.
+func (c *tenantCache) invalidateRoles() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roles = make(map[userRoleKey]*userRoleEntry)
 }
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+// ToolsListHandler handles GET /v1/tools — lists tools with their parameter
+// schemas. With ?agent=<key> it returns the agent's effective tool set
+// (policy-filtered builtin, skill and MCP tools) instead of the full registry.
+type ToolsListHandler struct {
+	registry *tools.Registry
+	agents   *agent.Router // nil if not configured
+}
+
+// NewToolsListHandler creates a handler for the tools listing endpoint.
+func NewToolsListHandler(registry *tools.Registry, agents *agent.Router) *ToolsListHandler {
+	return &ToolsListHandler{registry: registry, agents: agents}
+}
+
+// toolInfoLister is implemented by agents that can report their effective tools (*agent.Loop).
+type toolInfoLister interface {
+	ToolInfos() []tools.ToolInfo
+}
+
+func (h *ToolsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": i18n.T(locale, i18n.MsgMethodNotAllowed)})
+		return
+	}
+
+	auth := resolveAuth(r)
+	if !auth.Authenticated {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": i18n.T(locale, i18n.MsgUnauthorized)})
+		return
+	}
+	r = r.WithContext(enrichContext(r.Context(), r, auth))
+
+	agentKey := r.URL.Query().Get("agent")
+	if agentKey == "" {
+		writeJSON(w, http.StatusOK, map[string]any{"tools": h.registry.DescribeAll()})
+		return
+	}
+
+	if h.agents == nil {
+		writeToolError(w, http.StatusNotFound, "NOT_FOUND", i18n.T(locale, i18n.MsgNotFound, "agent", agentKey))
+		return
+	}
+	ag, err := h.agents.Get(r.Context(), agentKey)
+	if err != nil {
+		writeToolError(w, http.StatusNotFound, "NOT_FOUND", i18n.T(locale, i18n.MsgNotFound, "agent", agentKey))
+		return
+	}
+	lister, ok := ag.(toolInfoLister)
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]any{"agent": ag.ID(), "tools": h.registry.DescribeAll()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"agent": ag.ID(), "tools": lister.ToolInfos()})
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+type listTestTool struct{ name string }
+
+func (t *listTestTool) Name() string        { return t.name }
+func (t *listTestTool) Description() string { return "test tool" }
+func (t *listTestTool) Parameters() map[string]any {
+	return map[string]any{"type": "object", "properties": map[string]any{"q": map[string]any{"type": "string"}}}
+}
+func (t *listTestTool) Execute(context.Context, map[string]any) *tools.Result {
+	return tools.NewResult("ok")
+}
+
+func TestToolsList_AllRegistered(t *testing.T) {
+	setupTestToken(t, "secret")
+
+	reg := tools.NewRegistry()
+	reg.Register(&listTestTool{name: "datetime"})
+	reg.Register(&listTestTool{name: "use_skill"})
+	h := NewToolsListHandler(reg, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/tools", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated status = %d, want 401", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/tools", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		Tools []tools.ToolInfo `json:"tools"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Tools) != 2 || resp.Tools[0].Name != "datetime" || resp.Tools[1].Source != tools.ToolSourceSkill {
+		t.Errorf("unexpected tools: %+v", resp.Tools)
+	}
+	if resp.Tools[0].Parameters["properties"] == nil {
+		t.Error("parameter schema missing from listing")
+	}
+}
+
+func TestToolsList_UnknownAgent(t *testing.T) {
+	setupTestToken(t, "")
+
+	h := NewToolsListHandler(tools.NewRegistry(), nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/tools?agent=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
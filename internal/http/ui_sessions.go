@@ -0,0 +1,274 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	// UISessionCookie carries the opaque session secret. HttpOnly, so the
+	// admin UI never sees it; the CSRF token is returned in JSON instead.
+	UISessionCookie = "goclaw_session"
+	// UICSRFHeader must echo the session CSRF token on state-changing requests.
+	UICSRFHeader = "X-CSRF-Token"
+
+	uiSessionTTL     = 12 * time.Hour // absolute lifetime
+	uiSessionIdleTTL = 2 * time.Hour  // expires early when unused
+)
+
+// uiSession is a browser login. Only the SHA-256 of the cookie secret is kept.
+type uiSession struct {
+	ID         string            `json:"id"`
+	UserID     string            `json:"user_id,omitempty"`
+	Role       permissions.Role  `json:"role"`
+	TenantID   uuid.UUID         `json:"tenant_id"`
+	IP         string            `json:"ip,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	LastSeenAt time.Time         `json:"last_seen_at"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	tenantSlug string            // resolved at login
+	keyData    *store.APIKeyData // non-nil when the login used an API key
+	keyHash    string            // SHA-256 of that key, re-checked on every lookup
+	csrfToken  string
+}
+
+// UISessionStore keeps admin UI sessions in memory. Sessions do not survive a
+// gateway restart, which doubles as a global revocation.
+type UISessionStore struct {
+	mu       sync.Mutex
+	byHash   map[string]*uiSession // sha256(cookie secret) → session
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewUISessionStore creates a store and starts the background expiry sweep.
+func NewUISessionStore() *UISessionStore {
+	s := &UISessionStore{
+		byHash: make(map[string]*uiSession),
+		stop:   make(chan struct{}),
+	}
+	go s.sweep()
+	return s
+}
+
+// Stop terminates the sweep goroutine.
+func (s *UISessionStore) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// create issues a session for auth and returns a copy of it with the raw
+// cookie secret. keyHash is the hash of the API key used to log in, if any.
+func (s *UISessionStore) create(auth authResult, userID, ip, userAgent, keyHash string) (uiSession, string) {
+	secret := randomToken()
+	now := time.Now()
+	sess := &uiSession{
+		ID:         uuid.Must(uuid.NewV7()).String(),
+		UserID:     userID,
+		Role:       auth.Role,
+		TenantID:   auth.TenantID,
+		IP:         ip,
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(uiSessionTTL),
+		tenantSlug: auth.TenantSlug,
+		keyData:    auth.KeyData,
+		keyHash:    keyHash,
+		csrfToken:  randomToken(),
+	}
+	s.mu.Lock()
+	s.byHash[hashSecret(secret)] = sess
+	s.mu.Unlock()
+	return *sess, secret
+}
+
+// lookup returns a copy of the live session for a cookie secret and refreshes
+// LastSeenAt. The copy keeps callers off the shared struct outside the lock.
+func (s *UISessionStore) lookup(secret string) (uiSession, bool) {
+	if secret == "" {
+		return uiSession{}, false
+	}
+	h := hashSecret(secret)
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.byHash[h]
+	if !ok {
+		return uiSession{}, false
+	}
+	if sess.expired(now) {
+		delete(s.byHash, h)
+		return uiSession{}, false
+	}
+	sess.LastSeenAt = now
+	return *sess, true
+}
+
+// list returns a snapshot of live sessions, newest first.
+func (s *UISessionStore) list() []uiSession {
+	now := time.Now()
+	s.mu.Lock()
+	out := make([]uiSession, 0, len(s.byHash))
+	for _, sess := range s.byHash {
+		if !sess.expired(now) {
+			out = append(out, *sess)
+		}
+	}
+	s.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// revoke removes the session with the given public ID. Returns false if absent.
+func (s *UISessionStore) revoke(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for h, sess := range s.byHash {
+		if sess.ID == id {
+			delete(s.byHash, h)
+			return true
+		}
+	}
+	return false
+}
+
+// revokeAll removes every session and returns how many were dropped.
+func (s *UISessionStore) revokeAll() int {
+	s.mu.Lock()
+	n := len(s.byHash)
+	s.byHash = make(map[string]*uiSession)
+	s.mu.Unlock()
+	return n
+}
+
+func (s *UISessionStore) sweep() {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for h, sess := range s.byHash {
+				if sess.expired(now) {
+					delete(s.byHash, h)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (sess *uiSession) expired(now time.Time) bool {
+	return now.After(sess.ExpiresAt) || now.Sub(sess.LastSeenAt) > uiSessionIdleTTL
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic("crypto/rand: " + err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// --- Package-level wiring for resolveAuth ---
+
+var pkgUISessions *UISessionStore
+
+// InitUISessions enables cookie-based admin UI sessions in HTTP auth.
+// Must be called once during server startup before handling requests.
+func InitUISessions(s *UISessionStore) {
+	pkgUISessions = s
+}
+
+// isSafeMethod reports whether the method cannot change state (no CSRF check).
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// resolveUISession authenticates a request via the session cookie.
+// State-changing requests must also carry the session CSRF token in
+// X-CSRF-Token; a missing or wrong token fails authentication.
+func resolveUISession(r *http.Request) (authResult, uiSession, bool) {
+	if pkgUISessions == nil {
+		return authResult{}, uiSession{}, false
+	}
+	c, err := r.Cookie(UISessionCookie)
+	if err != nil || c.Value == "" {
+		return authResult{}, uiSession{}, false
+	}
+	sess, ok := pkgUISessions.lookup(c.Value)
+	if !ok {
+		return authResult{}, uiSession{}, false
+	}
+	// A session opened with an API key lives only as long as the key does:
+	// once it is revoked, expired or deleted the session is dropped too.
+	if sess.keyHash != "" {
+		keyData, _ := resolveAPIKeyHash(r.Context(), sess.keyHash)
+		if keyData == nil {
+			pkgUISessions.revoke(sess.ID)
+			slog.Warn("security.ui_session_key_revoked", "session", sess.ID, "ip", r.RemoteAddr)
+			return authResult{}, uiSession{}, false
+		}
+		sess.keyData = keyData
+	}
+	if !isSafeMethod(r.Method) {
+		provided := r.Header.Get(UICSRFHeader)
+		if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(sess.csrfToken)) != 1 {
+			slog.Warn("security.csrf_rejected", "path", r.URL.Path, "method", r.Method, "session", sess.ID, "ip", r.RemoteAddr)
+			return authResult{}, uiSession{}, false
+		}
+	}
+	return authResult{
+		Role:          sess.Role,
+		Authenticated: true,
+		KeyData:       sess.keyData,
+		TenantID:      sess.TenantID,
+		TenantSlug:    sess.tenantSlug,
+		UserID:        sess.UserID,
+		SessionID:     sess.ID,
+	}, sess, true
+}
+
+// UISessionAuth is the identity carried by an admin UI session cookie.
+type UISessionAuth struct {
+	ID       string
+	UserID   string
+	Role     permissions.Role
+	TenantID uuid.UUID
+}
+
+// ResolveUISession authenticates a WebSocket upgrade via the session cookie so
+// the admin UI can connect without holding a token. SameSite=Strict keeps the
+// cookie off cross-site upgrades.
+func ResolveUISession(r *http.Request) (*UISessionAuth, bool) {
+	res, sess, ok := resolveUISession(r)
+	if !ok {
+		return nil, false
+	}
+	return &UISessionAuth{ID: sess.ID, UserID: res.UserID, Role: res.Role, TenantID: res.TenantID}, true
+}
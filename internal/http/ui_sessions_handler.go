@@ -0,0 +1,202 @@
+package http
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// uiLoginRPM caps login attempts per client IP (burst uiLoginBurst).
+const (
+	uiLoginRPM   = 10
+	uiLoginBurst = 5
+)
+
+// UISessionsHandler serves cookie-based login, logout and session revocation
+// for the admin UI. Tokens never reach browser storage: the gateway token or
+// API key is exchanged once for an HttpOnly SameSite=Strict cookie, and
+// state-changing requests must echo the CSRF token returned at login.
+type UISessionsHandler struct {
+	sessions     *UISessionStore
+	loginLimiter *perKeyRateLimiter
+}
+
+// NewUISessionsHandler creates the admin UI session handler.
+func NewUISessionsHandler(sessions *UISessionStore) *UISessionsHandler {
+	return &UISessionsHandler{
+		sessions:     sessions,
+		loginLimiter: newPerKeyRateLimiter(uiLoginRPM, uiLoginBurst),
+	}
+}
+
+// RegisterRoutes registers session routes on the given mux.
+func (h *UISessionsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/auth/login", h.handleLogin)
+	mux.HandleFunc("POST /v1/auth/logout", h.handleLogout)
+	mux.HandleFunc("GET /v1/auth/session", h.handleCurrent)
+	mux.HandleFunc("GET /v1/auth/sessions", requireAuth(permissions.RoleAdmin, h.handleList))
+	mux.HandleFunc("DELETE /v1/auth/sessions", requireAuth(permissions.RoleAdmin, h.handleRevokeAll))
+	mux.HandleFunc("DELETE /v1/auth/sessions/{id}", requireAuth("", h.handleRevoke))
+}
+
+func (h *UISessionsHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	ip := remoteIP(r)
+	if !h.loginLimiter.Allow(ip) {
+		slog.Warn("security.ui_login_rate_limited", "ip", ip)
+		writeError(w, http.StatusTooManyRequests, protocol.ErrResourceExhausted, i18n.T(locale, i18n.MsgRateLimitExceeded))
+		return
+	}
+
+	var input struct {
+		Token string `json:"token"` // gateway token or API key
+	}
+	if !bindJSON(w, r, locale, &input) {
+		return
+	}
+	if input.Token == "" {
+		writeError(w, http.StatusBadRequest, protocol.ErrInvalidRequest, i18n.T(locale, i18n.MsgRequired, "token"))
+		return
+	}
+
+	// Credentials are checked exactly like a bearer request, so user/tenant
+	// headers on the login call follow the same owner and membership rules.
+	auth := resolveAuthWithBearer(r, input.Token)
+	if !auth.Authenticated || auth.SessionID != "" {
+		slog.Warn("security.ui_login_failed", "ip", ip)
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	userID := store.UserIDFromContext(enrichContext(r.Context(), r, auth))
+
+	var keyHash string
+	if auth.KeyData != nil {
+		keyHash = crypto.HashAPIKey(input.Token)
+	}
+	sess, secret := h.sessions.create(auth, userID, ip, r.UserAgent(), keyHash)
+	http.SetCookie(w, &http.Cookie{
+		Name:     UISessionCookie,
+		Value:    secret,
+		Path:     "/",
+		Expires:  sess.ExpiresAt,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+	slog.Info("security.ui_login", "session", sess.ID, "role", string(sess.Role), "user", userID, "ip", ip)
+	writeJSON(w, http.StatusOK, sessionView(&sess, true))
+}
+
+func (h *UISessionsHandler) handleLogout(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	_, sess, ok := resolveUISession(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	h.sessions.revoke(sess.ID)
+	clearSessionCookie(w, r)
+	writeJSON(w, http.StatusOK, map[string]string{"status": "logged_out"})
+}
+
+// handleCurrent returns the caller's session (including the CSRF token, so
+// the UI can recover it after a page reload).
+func (h *UISessionsHandler) handleCurrent(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	_, sess, ok := resolveUISession(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgUnauthorized))
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionView(&sess, true))
+}
+
+func (h *UISessionsHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	sessions := h.sessions.list()
+	out := make([]map[string]any, 0, len(sessions))
+	for i := range sessions {
+		out = append(out, sessionView(&sessions[i], false))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"sessions": out})
+}
+
+// handleRevoke revokes one session. Admins may revoke any session; other
+// callers only their own (identified by the session cookie).
+func (h *UISessionsHandler) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	id := r.PathValue("id")
+	role := permissions.Role(store.RoleFromContext(r.Context()))
+	if !permissions.HasMinRole(role, permissions.RoleAdmin) {
+		_, own, ok := resolveUISession(r)
+		if !ok || own.ID != id {
+			writeError(w, http.StatusForbidden, protocol.ErrUnauthorized, i18n.T(locale, i18n.MsgPermissionDenied, "can only revoke own session"))
+			return
+		}
+	}
+	if !h.sessions.revoke(id) {
+		writeError(w, http.StatusNotFound, protocol.ErrNotFound, i18n.T(locale, i18n.MsgNotFound, "session", id))
+		return
+	}
+	slog.Info("security.ui_session_revoked", "session", id, "by", store.UserIDFromContext(r.Context()))
+	writeJSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
+
+func (h *UISessionsHandler) handleRevokeAll(w http.ResponseWriter, r *http.Request) {
+	n := h.sessions.revokeAll()
+	slog.Info("security.ui_sessions_revoked_all", "count", n, "by", store.UserIDFromContext(r.Context()))
+	clearSessionCookie(w, r)
+	writeJSON(w, http.StatusOK, map[string]int{"revoked": n})
+}
+
+func sessionView(sess *uiSession, withCSRF bool) map[string]any {
+	v := map[string]any{
+		"id":           sess.ID,
+		"user_id":      sess.UserID,
+		"role":         sess.Role,
+		"tenant_id":    sess.TenantID,
+		"ip":           sess.IP,
+		"user_agent":   sess.UserAgent,
+		"created_at":   sess.CreatedAt,
+		"last_seen_at": sess.LastSeenAt,
+		"expires_at":   sess.ExpiresAt,
+	}
+	if withCSRF {
+		v["csrf_token"] = sess.csrfToken
+	}
+	return v
+}
+
+func clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     UISessionCookie,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// isSecureRequest reports whether the client connection is HTTPS (directly or
+// via a TLS-terminating proxy), so the session cookie gets the Secure flag.
+func isSecureRequest(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
+// remoteIP returns the host part of RemoteAddr (rate-limit key for logins;
+// deliberately ignores spoofable forwarding headers).
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// setupTestUISessions installs a fresh session store and returns a mux with
+// the session routes plus a probe route guarded by requireAuth.
+func setupTestUISessions(t *testing.T) (*UISessionStore, *http.ServeMux) {
+	t.Helper()
+	s := NewUISessionStore()
+	old := pkgUISessions
+	pkgUISessions = s
+	t.Cleanup(func() {
+		pkgUISessions = old
+		s.Stop()
+	})
+	mux := http.NewServeMux()
+	NewUISessionsHandler(s).RegisterRoutes(mux)
+	mux.HandleFunc("POST /v1/probe", requireAuth("", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return s, mux
+}
+
+func uiLogin(t *testing.T, mux *http.ServeMux, token string) (*http.Cookie, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"token":"`+token+`"}`))
+	req.RemoteAddr = "192.0.2.10:1234"
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		CSRFToken string `json:"csrf_token"`
+	}
+	json.NewDecoder(rec.Body).Decode(&body)
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == UISessionCookie {
+			if !c.HttpOnly || c.SameSite != http.SameSiteStrictMode {
+				t.Errorf("cookie flags: httpOnly=%v sameSite=%v", c.HttpOnly, c.SameSite)
+			}
+			if body.CSRFToken == "" {
+				t.Fatal("login response missing csrf_token")
+			}
+			return c, body.CSRFToken
+		}
+	}
+	t.Fatal("login did not set session cookie")
+	return nil, ""
+}
+
+func uiDo(mux *http.ServeMux, method, path string, cookie *http.Cookie, csrf string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	if csrf != "" {
+		req.Header.Set(UICSRFHeader, csrf)
+	}
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestUISession_LoginAndCSRF(t *testing.T) {
+	setupTestToken(t, "gw-token")
+	_, mux := setupTestUISessions(t)
+	cookie, csrf := uiLogin(t, mux, "gw-token")
+
+	if code := uiDo(mux, http.MethodGet, "/v1/auth/session", cookie, ""); code != http.StatusOK {
+		t.Errorf("GET session with cookie = %d, want 200", code)
+	}
+	if code := uiDo(mux, http.MethodPost, "/v1/probe", cookie, ""); code != http.StatusUnauthorized {
+		t.Errorf("POST without CSRF = %d, want 401", code)
+	}
+	if code := uiDo(mux, http.MethodPost, "/v1/probe", cookie, "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("POST with wrong CSRF = %d, want 401", code)
+	}
+	if code := uiDo(mux, http.MethodPost, "/v1/probe", cookie, csrf); code != http.StatusNoContent {
+		t.Errorf("POST with CSRF = %d, want 204", code)
+	}
+}
+
+func TestUISession_LoginRejectsBadToken(t *testing.T) {
+	setupTestToken(t, "gw-token")
+	_, mux := setupTestUISessions(t)
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"token":"nope"}`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	if len(rec.Result().Cookies()) != 0 {
+		t.Error("failed login must not set a cookie")
+	}
+}
+
+func TestUISession_LogoutAndRevoke(t *testing.T) {
+	setupTestToken(t, "gw-token")
+	s, mux := setupTestUISessions(t)
+
+	cookie, csrf := uiLogin(t, mux, "gw-token")
+	if code := uiDo(mux, http.MethodPost, "/v1/auth/logout", cookie, csrf); code != http.StatusOK {
+		t.Fatalf("logout = %d, want 200", code)
+	}
+	if code := uiDo(mux, http.MethodGet, "/v1/auth/session", cookie, ""); code != http.StatusUnauthorized {
+		t.Errorf("session after logout = %d, want 401", code)
+	}
+
+	cookie, _ = uiLogin(t, mux, "gw-token")
+	sessions := s.list()
+	if len(sessions) != 1 {
+		t.Fatalf("live sessions = %d, want 1", len(sessions))
+	}
+	req := httptest.NewRequest(http.MethodDelete, "/v1/auth/sessions/"+sessions[0].ID, nil)
+	req.Header.Set("Authorization", "Bearer gw-token")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("revoke = %d, want 200", rec.Code)
+	}
+	if code := uiDo(mux, http.MethodGet, "/v1/auth/session", cookie, ""); code != http.StatusUnauthorized {
+		t.Errorf("session after revoke = %d, want 401", code)
+	}
+}
+
+func TestUISession_EndsWhenAPIKeyRevoked(t *testing.T) {
+	ms := setupTestCache(t, map[string]*store.APIKeyData{
+		crypto.HashAPIKey("admin-key"): {ID: uuid.New(), Scopes: []string{"operator.admin"}},
+	})
+	s, mux := setupTestUISessions(t)
+
+	cookie, _ := uiLogin(t, mux, "admin-key")
+	if code := uiDo(mux, http.MethodGet, "/v1/auth/session", cookie, ""); code != http.StatusOK {
+		t.Fatalf("session before revoke = %d, want 200", code)
+	}
+
+	ms.mu.Lock()
+	delete(ms.keys, crypto.HashAPIKey("admin-key"))
+	ms.mu.Unlock()
+	pkgAPIKeyCache.invalidateAll()
+
+	if code := uiDo(mux, http.MethodGet, "/v1/auth/session", cookie, ""); code != http.StatusUnauthorized {
+		t.Errorf("session after key revoke = %d, want 401", code)
+	}
+	if n := len(s.list()); n != 0 {
+		t.Errorf("live sessions after key revoke = %d, want 0", n)
+	}
+}
+
+func TestUISession_LoginRateLimited(t *testing.T) {
+	setupTestToken(t, "gw-token")
+	_, mux := setupTestUISessions(t)
+	var last int
+	for range uiLoginBurst + 1 {
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", strings.NewReader(`{"token":"nope"}`))
+		req.RemoteAddr = "198.51.100.7:999"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		last = rec.Code
+	}
+	if last != http.StatusTooManyRequests {
+		t.Errorf("status after burst = %d, want 429", last)
+	}
+}
+
+func TestUISessionStore_Expiry(t *testing.T) {
+	s := NewUISessionStore()
+	defer s.Stop()
+
+	_, secret := s.create(authResult{Authenticated: true}, "", "", "", "")
+	if _, ok := s.lookup(secret); !ok {
+		t.Fatal("fresh session should be live")
+	}
+	s.byHash[hashSecret(secret)].LastSeenAt = time.Now().Add(-uiSessionIdleTTL - time.Minute)
+	if _, ok := s.lookup(secret); ok {
+		t.Error("idle session should expire")
+	}
+
+	_, secret = s.create(authResult{Authenticated: true}, "", "", "", "")
+	s.byHash[hashSecret(secret)].ExpiresAt = time.Now().Add(-time.Second)
+	if _, ok := s.lookup(secret); ok {
+		t.Error("session past absolute TTL should expire")
+	}
+}
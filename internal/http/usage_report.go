@@ -0,0 +1,138 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/i18n"
+	"github.com/nextlevelbuilder/goclaw/internal/permissions"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const (
+	defaultUsageReportDays  = 30
+	defaultUsageReportLimit = 100
+	maxUsageReportLimit     = 1000
+)
+
+// handleReport returns token usage and estimated cost per run, session, agent
+// or user (?group_by=, default agent) for ?from= to ?to= (RFC3339 or
+// YYYY-MM-DD, default the last 30 days). Non-admins only see their own usage.
+func (h *BillingHandler) handleReport(w http.ResponseWriter, r *http.Request) {
+	locale := extractLocale(r)
+	q := r.URL.Query()
+
+	opts := store.UsageReportOpts{GroupBy: q.Get("group_by"), UserID: q.Get("user_id"), Limit: defaultUsageReportLimit}
+	if opts.GroupBy == "" {
+		opts.GroupBy = store.UsageGroupAgent
+	}
+	switch opts.GroupBy {
+	case store.UsageGroupRun, store.UsageGroupSession, store.UsageGroupAgent, store.UsageGroupUser:
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "group_by must be run, session, agent or user"})
+		return
+	}
+
+	var err error
+	opts.To = time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		if opts.To, err = parseReportTime(v); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid to: " + err.Error()})
+			return
+		}
+	}
+	opts.From = opts.To.AddDate(0, 0, -defaultUsageReportDays)
+	if v := q.Get("from"); v != "" {
+		if opts.From, err = parseReportTime(v); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid from: " + err.Error()})
+			return
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			opts.Limit = min(n, maxUsageReportLimit)
+		}
+	}
+	if v := q.Get("agent_id"); v != "" {
+		id, ok := h.resolveAgentID(r, v)
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": i18n.T(locale, i18n.MsgAgentNotFound, v)})
+			return
+		}
+		opts.AgentID = &id
+	}
+
+	if !permissions.HasMinRole(permissions.Role(store.RoleFromContext(r.Context())), permissions.RoleAdmin) {
+		opts.UserID = store.UserIDFromContext(r.Context())
+		if opts.UserID == "" {
+			writeJSON(w, http.StatusForbidden, map[string]string{"error": i18n.T(locale, i18n.MsgPermissionDenied, "usage report")})
+			return
+		}
+	}
+
+	rows, err := h.tracing.GetUsageReport(r.Context(), opts)
+	if err != nil {
+		slog.Error("usage.report query failed", "group_by", opts.GroupBy, "error", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": i18n.T(locale, i18n.MsgInternalError, "usage report")})
+		return
+	}
+	if rows == nil {
+		rows = []store.UsageReportRow{}
+	}
+
+	resp := map[string]any{
+		"group_by": opts.GroupBy,
+		"from":     opts.From,
+		"to":       opts.To,
+		"rows":     rows,
+	}
+	if opts.GroupBy == store.UsageGroupAgent {
+		resp["agent_keys"] = h.reportAgentKeys(r, rows)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// parseReportTime accepts RFC3339 timestamps and plain dates (UTC midnight).
+func parseReportTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
+
+// resolveAgentID accepts an agent UUID or agent key.
+func (h *BillingHandler) resolveAgentID(r *http.Request, v string) (uuid.UUID, bool) {
+	if id, err := uuid.Parse(v); err == nil {
+		return id, true
+	}
+	if h.agents == nil {
+		return uuid.Nil, false
+	}
+	ag, err := h.agents.GetByKey(r.Context(), v)
+	if err != nil || ag == nil {
+		return uuid.Nil, false
+	}
+	return ag.ID, true
+}
+
+// reportAgentKeys maps agent IDs in an agent-grouped report to agent keys.
+func (h *BillingHandler) reportAgentKeys(r *http.Request, rows []store.UsageReportRow) map[string]string {
+	keys := map[string]string{}
+	if h.agents == nil {
+		return keys
+	}
+	var ids []uuid.UUID
+	for _, row := range rows {
+		if id, err := uuid.Parse(row.Key); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	for id, key := range h.lookupAgentKeys(r, ids) {
+		keys[id.String()] = key
+	}
+	return keys
+}
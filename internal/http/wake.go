@@ -20,6 +20,7 @@ import (
 type WakeHandler struct {
 	agents   *agent.Router
 	postTurn tools.PostTurnProcessor
+	pressure BackpressureFunc // lane saturation check (nil = never reject)
 }
 
 // SetPostTurnProcessor sets the post-turn processor for team task dispatch.
@@ -27,6 +28,11 @@ func (h *WakeHandler) SetPostTurnProcessor(pt tools.PostTurnProcessor) {
 	h.postTurn = pt
 }
 
+// SetBackpressure sets the saturation check that rejects runs with 429.
+func (h *WakeHandler) SetBackpressure(fn BackpressureFunc) {
+	h.pressure = fn
+}
+
 // NewWakeHandler creates a handler for the wake endpoint.
 func NewWakeHandler(agents *agent.Router) *WakeHandler {
 	return &WakeHandler{agents: agents}
@@ -79,6 +85,11 @@ func (h *WakeHandler) handleWake(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if secs := checkBackpressure(w, h.pressure); secs > 0 {
+		writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": i18n.T(locale, i18n.MsgAgentsBusy, secs)})
+		return
+	}
+
 	// Limit request body size
 	const maxBodySize = 1 << 20 // 1MB
 	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
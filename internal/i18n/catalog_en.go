@@ -27,9 +27,11 @@ func init() {
 
 		// Chat
 		MsgRateLimitExceeded: "rate limit exceeded — please wait",
+		MsgAgentsBusy:        "all agent workers are busy — retry in %d seconds",
 		MsgNoUserMessage:     "no user message found",
 		MsgUserIDRequired:    "user_id is required",
 		MsgMsgRequired:       "message is required",
+		MsgGatewayDraining:   "gateway is restarting — please retry shortly",
 
 		// Abort
 		MsgAbortStopped:         "run stopped",
@@ -75,6 +77,8 @@ func init() {
 		MsgNoDescription:         "agent has no description to resummon from",
 		MsgSummonCancelled:       "summon cancelled by user",
 		MsgCannotCancel:          "agent is not being summoned",
+		MsgSummonPreviewNotReady: "summon preview is not ready (status: %s)",
+		MsgSummonPreviewStale:    "agent files changed since the preview was generated: %s",
 		MsgInvalidPath:           "invalid path",
 
 		// Tenant backup / restore
@@ -27,9 +27,11 @@ func init() {
 
 		// Chat
 		MsgRateLimitExceeded: "vượt quá giới hạn tốc độ — vui lòng đợi",
+		MsgAgentsBusy:        "tất cả agent đang bận — vui lòng thử lại sau %d giây",
 		MsgNoUserMessage:     "không tìm thấy tin nhắn người dùng",
 		MsgUserIDRequired:    "user_id là bắt buộc",
 		MsgMsgRequired:       "tin nhắn là bắt buộc",
+		MsgGatewayDraining:   "gateway đang khởi động lại — vui lòng thử lại sau giây lát",
 
 		// Abort
 		MsgAbortStopped:         "đã dừng tác vụ",
@@ -75,6 +77,8 @@ func init() {
 		MsgNoDescription:         "agent không có mô tả để triệu hồi lại",
 		MsgSummonCancelled:       "đã huỷ triệu hồi",
 		MsgCannotCancel:          "agent không trong trạng thái đang triệu hồi",
+		MsgSummonPreviewNotReady: "bản xem trước triệu hồi chưa sẵn sàng (trạng thái: %s)",
+		MsgSummonPreviewStale:    "tệp của agent đã thay đổi kể từ khi tạo bản xem trước: %s",
 		MsgInvalidPath:           "đường dẫn không hợp lệ",
 
 		// Tenant backup / restore
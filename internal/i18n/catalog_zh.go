@@ -27,9 +27,11 @@ func init() {
 
 		// Chat
 		MsgRateLimitExceeded: "请求频率超限 — 请稍候",
+		MsgAgentsBusy:        "所有代理均繁忙 — 请在 %d 秒后重试",
 		MsgNoUserMessage:     "未找到用户消息",
 		MsgUserIDRequired:    "user_id 是必填项",
 		MsgMsgRequired:       "消息是必填项",
+		MsgGatewayDraining:   "网关正在重启 — 请稍后重试",
 
 		// Abort
 		MsgAbortStopped:         "已停止运行",
@@ -75,6 +77,8 @@ func init() {
 		MsgNoDescription:         "Agent没有可供重新召唤的描述",
 		MsgSummonCancelled:       "已取消召唤",
 		MsgCannotCancel:          "Agent 未处于召唤状态",
+		MsgSummonPreviewNotReady: "召唤预览尚未就绪（状态：%s）",
+		MsgSummonPreviewStale:    "生成预览后 Agent 文件已更改：%s",
 		MsgInvalidPath:           "路径无效",
 
 		// Tenant backup / restore
@@ -28,9 +28,11 @@ const (
 
 	// --- Chat ---
 	MsgRateLimitExceeded = "error.rate_limit"       // "rate limit exceeded — please wait"
+	MsgAgentsBusy        = "error.agents_busy"      // "all agent workers are busy — retry in %d seconds"
 	MsgNoUserMessage     = "error.no_user_message"  // "no user message found"
 	MsgUserIDRequired    = "error.user_id_required" // "user_id is required"
 	MsgMsgRequired       = "error.message_required" // "message is required"
+	MsgGatewayDraining   = "error.gateway_draining" // "gateway is restarting — please retry shortly"
 
 	// --- Abort ---
 	MsgAbortStopped         = "abort.stopped"          // "run stopped"
@@ -76,6 +78,8 @@ const (
 	MsgNoDescription         = "error.no_description"          // "agent has no description to resummon from"
 	MsgSummonCancelled       = "info.summon_cancelled"         // "summon cancelled by user"
 	MsgCannotCancel          = "error.cannot_cancel_summon"    // "agent is not being summoned"
+	MsgSummonPreviewNotReady = "error.summon_preview_pending"  // "summon preview is not ready (status: %s)"
+	MsgSummonPreviewStale    = "error.summon_preview_stale"    // "agent files changed since the preview was generated: %s"
 	MsgInvalidPath           = "error.invalid_path"            // "invalid path"
 
 	// --- Tenant backup / restore ---
@@ -22,6 +22,8 @@ var BridgeToolNames = map[string]bool{
 	"read_file":  true,
 	"write_file": true,
 	"list_files": true,
+	"grep":       true,
+	"glob":       true,
 	"edit":       true,
 	"exec":       true,
 	// Web
@@ -36,7 +38,8 @@ var BridgeToolNames = map[string]bool{
 	"create_image": true,
 	"tts":          true,
 	// Browser automation
-	"browser": true,
+	"browser":           true,
+	"browser_downloads": true,
 	// Scheduler
 	"cron": true,
 	// Messaging (send text/files to channels)
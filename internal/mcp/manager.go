@@ -20,6 +20,7 @@ import (
 const (
 	healthCheckInterval  = 30 * time.Second
 	healthFailThreshold  = 3 // consecutive ping failures before marking disconnected
+	healthPingTimeout    = 10 * time.Second
 	initialBackoff       = 2 * time.Second
 	maxBackoff           = 60 * time.Second
 	maxReconnectAttempts = 10
@@ -37,11 +38,18 @@ type ServerStatus struct {
 	Transport string `json:"transport"`
 	Connected bool   `json:"connected"`
 	ToolCount int    `json:"tool_count"`
-	Error     string `json:"error,omitempty"`
+	Error     string `json:"error,omitempty"` // current failure (cleared on recovery)
+
+	HealthFailures int        `json:"health_failures"`            // consecutive failed pings
+	Restarts       int        `json:"restarts"`                   // full reconnects (stdio: process restarts)
+	LastPingAt     *time.Time `json:"last_ping_at,omitempty"`     // last successful health ping
+	LastError      string     `json:"last_error,omitempty"`       // most recent failure, kept after recovery
+	LastErrorAt    *time.Time `json:"last_error_at,omitempty"`    // time of LastError
+	UserConns      int        `json:"user_connections,omitempty"` // pooled per-user connections
 }
 
 // connParams stores connection parameters needed to re-establish a dead connection.
-// Populated during initial connectAndDiscover and used by tryReconnect.
+// Populated during initial connectAndDiscover and used by fullReconnect.
 type connParams struct {
 	command string
 	args    []string
@@ -70,10 +78,14 @@ type serverState struct {
 	cancel     context.CancelFunc
 	conn       connParams // connection params for reconnect
 
-	mu              sync.Mutex
-	reconnAttempts  int
-	healthFailures  int // consecutive ping failures (resets on success)
-	lastErr         string
+	mu             sync.Mutex
+	reconnAttempts int
+	healthFailures int // consecutive ping failures (resets on success)
+	lastErr        string
+	lastPingAt     time.Time // last successful ping
+	lastFailure    string    // most recent failure (not cleared on recovery)
+	lastFailureAt  time.Time
+	restarts       int // successful full reconnects
 }
 
 // Manager orchestrates MCP server connections and tool registration.
@@ -186,6 +198,10 @@ func (m *Manager) Start(ctx context.Context) error {
 		if err := m.connectServer(ctx, name, cfg.Transport, cfg.Command, cfg.Args, cfg.Env, cfg.URL, headers, cfg.ToolPrefix, cfg.TimeoutSec, uuid.Nil); err != nil {
 			slog.Warn("mcp.server.connect_failed", "server", name, "error", err)
 			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if len(cfg.ToolAllow) > 0 || len(cfg.ToolDeny) > 0 {
+			m.filterTools(name, cfg.ToolAllow, cfg.ToolDeny)
 		}
 	}
 
@@ -559,13 +575,7 @@ func (m *Manager) ServerStatus() []ServerStatus {
 
 	statuses := make([]ServerStatus, 0, len(m.servers))
 	for _, ss := range m.servers {
-		statuses = append(statuses, ServerStatus{
-			Name:      ss.name,
-			Transport: ss.transport,
-			Connected: ss.connected.Load(),
-			ToolCount: len(ss.toolNames),
-			Error:     ss.lastErr,
-		})
+		statuses = append(statuses, ss.status(len(ss.toolNames)))
 	}
 	return statuses
 }
@@ -11,6 +11,9 @@ import (
 	mcpclient "github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 )
 
 // connectAndDiscover creates a client, initializes the MCP handshake, and
@@ -42,31 +45,28 @@ func connectAndDiscover(ctx context.Context, name, transportType, command string
 	// their stdin read loop. Without retries, Initialize sends JSON-RPC before the
 	// server is ready, gets EOF, and permanently fails. SSE/HTTP transports don't need
 	// this because the HTTP server rejects connections until ready (connection refused).
-	const maxInitAttempts = 4 // backoff: 2s + 4s + 8s = ~14s total before giving up
-	var initErr error
-	for attempt := range maxInitAttempts {
-		if attempt > 0 {
-			backoff := time.Duration(1<<attempt) * time.Second // 2s, 4s, 8s
-			slog.Debug("mcp.init.retry", "server", name, "attempt", attempt+1, "backoff", backoff)
-			select {
-			case <-time.After(backoff):
-			case <-ctx.Done():
-				_ = client.Close()
-				return nil, nil, fmt.Errorf("initialize: context cancelled during retry: %w", ctx.Err())
-			}
-		}
-		if _, err := client.Initialize(ctx, initReq); err == nil {
-			break
-		} else {
-			initErr = err
+	initErr := retry.Do(ctx, retry.Policy{
+		Name:    "mcp.init",
+		Backoff: retry.Backoff{Attempts: 4, MinDelay: 2 * time.Second}, // 2s + 4s + 8s = ~14s total before giving up
+		Classify: func(err error) retry.Class {
 			// Non-stdio transports: connection errors are definitive, don't retry.
 			if transportType != "stdio" {
-				break
+				return retry.ClassPermanent
 			}
-		}
-	}
+			return retry.Always(err)
+		},
+		OnRetry: func(attempt int, _ error, delay time.Duration) {
+			slog.Debug("mcp.init.retry", "server", name, "attempt", attempt+1, "backoff", delay)
+		},
+	}, func(ctx context.Context) error {
+		_, err := client.Initialize(ctx, initReq)
+		return err
+	})
 	if initErr != nil {
 		_ = client.Close()
+		if ctx.Err() != nil {
+			return nil, nil, fmt.Errorf("initialize: context cancelled during retry: %w", ctx.Err())
+		}
 		return nil, nil, fmt.Errorf("initialize: %w", initErr)
 	}
 
@@ -236,6 +236,9 @@ func createClient(transportType, command string, args []string, env map[string]s
 		return mcpclient.NewStdioMCPClient(command, envSlice, args...)
 
 	case "sse":
+		if err := netproxy.CheckOfflineURL(url); err != nil {
+			return nil, err
+		}
 		var opts []transport.ClientOption
 		if len(headers) > 0 {
 			opts = append(opts, mcpclient.WithHeaders(headers))
@@ -243,6 +246,9 @@ func createClient(transportType, command string, args []string, env map[string]s
 		return mcpclient.NewSSEMCPClient(url, opts...)
 
 	case "streamable-http":
+		if err := netproxy.CheckOfflineURL(url); err != nil {
+			return nil, err
+		}
 		var opts []transport.StreamableHTTPCOption
 		if len(headers) > 0 {
 			opts = append(opts, transport.WithHTTPHeaders(headers))
@@ -275,47 +281,34 @@ func (m *Manager) healthLoop(ctx context.Context, ss *serverState) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := ss.client.Ping(ctx); err != nil {
-				if isMethodNotFound(err) {
-					ss.connected.Store(true)
-					ss.mu.Lock()
-					ss.reconnAttempts = 0
-					ss.healthFailures = 0
-					ss.lastErr = ""
-					ss.mu.Unlock()
-					continue
-				}
-				ss.mu.Lock()
-				ss.healthFailures++
-				failures := ss.healthFailures
-				ss.lastErr = err.Error()
-				ss.mu.Unlock()
-
-				slog.Warn("mcp.server.health_failed", "server", ss.name, "error", err, "consecutive", failures)
-
-				// Only mark disconnected and attempt reconnect after consecutive failures
-				// to tolerate transient errors (e.g. 504 from upstream proxy).
-				if failures >= healthFailThreshold {
-					ss.connected.Store(false)
-					m.tryReconnect(ctx, ss)
-				}
-			} else {
-				ss.connected.Store(true)
-				ss.mu.Lock()
-				ss.reconnAttempts = 0
-				ss.healthFailures = 0
-				ss.lastErr = ""
-				ss.mu.Unlock()
-			}
+			checkHealth(ctx, ss, "mcp.server")
 		}
 	}
 }
 
-// tryReconnect attempts to reconnect with exponential backoff.
-func (m *Manager) tryReconnect(ctx context.Context, ss *serverState) {
-	reconnectWithBackoff(ctx, ss, "mcp.server")
+// checkHealth pings the server once. After failThreshold consecutive failures
+// it marks the server disconnected and reconnects (restarting stdio processes).
+// Shared by Manager.healthLoop and poolHealthLoop.
+func checkHealth(ctx context.Context, ss *serverState, logPrefix string) {
+	err := ss.ping(ctx)
+	if err == nil || isMethodNotFound(err) {
+		ss.markHealthy()
+		return
+	}
+	failures := ss.markFailed(err)
+	slog.Warn(logPrefix+".health_failed", "server", ss.name, "error", err, "consecutive", failures)
+
+	// Tolerate transient errors (e.g. 504 from upstream proxy) before reconnecting.
+	if failures >= ss.failThreshold() {
+		ss.connected.Store(false)
+		reconnectWithBackoff(ctx, ss, logPrefix)
+	}
 }
 
+// reconnectBackoff spaces reconnect attempts; jitter keeps servers that
+// dropped together (gateway network blip) from reconnecting in lockstep.
+var reconnectBackoff = retry.Backoff{MinDelay: initialBackoff, MaxDelay: maxBackoff, Jitter: 0.2}
+
 // reconnectWithBackoff implements the two-phase reconnect strategy shared by
 // Manager.healthLoop and poolHealthLoop. Handles cooldown after exhausting
 // max attempts, exponential backoff, fast-path ping (transient blips), and
@@ -341,24 +334,17 @@ func reconnectWithBackoff(ctx context.Context, ss *serverState, logPrefix string
 	attempt := ss.reconnAttempts
 	ss.mu.Unlock()
 
-	backoff := min(initialBackoff*time.Duration(1<<(attempt-1)), maxBackoff)
+	backoff := reconnectBackoff.Delay(attempt)
 	slog.Info(logPrefix+".reconnecting", "server", ss.name, "attempt", attempt, "backoff", backoff)
 
-	select {
-	case <-ctx.Done():
+	if retry.Sleep(ctx, backoff) != nil {
 		return
-	case <-time.After(backoff):
 	}
 
 	// Fast path: ping existing client — works for transient network blips
 	// where the server-side session is still alive.
-	if err := ss.client.Ping(ctx); err == nil {
-		ss.connected.Store(true)
-		ss.mu.Lock()
-		ss.reconnAttempts = 0
-		ss.healthFailures = 0
-		ss.lastErr = ""
-		ss.mu.Unlock()
+	if err := ss.ping(ctx); err == nil {
+		ss.markHealthy()
 		slog.Info(logPrefix+".reconnected", "server", ss.name)
 		return
 	}
@@ -411,12 +397,10 @@ func fullReconnect(ctx context.Context, ss *serverState) bool {
 	oldClient := ss.client
 	ss.client = newClient
 	ss.clientPtr.Store(newClient)
-	ss.connected.Store(true)
 	ss.mu.Lock()
-	ss.reconnAttempts = 0
-	ss.healthFailures = 0
-	ss.lastErr = ""
+	ss.restarts++
 	ss.mu.Unlock()
+	ss.markHealthy()
 
 	_ = oldClient.Close()
 	return true
@@ -0,0 +1,33 @@
+package mcp
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+func TestManager_FilterTools_DenyWinsOverAllow(t *testing.T) {
+	reg := tools.NewRegistry()
+	m := NewManager(reg)
+	ss := &serverState{name: "fs"}
+	for _, orig := range []string{"read_file", "write_file", "delete_file"} {
+		bt := makeBridgeTool("fs", orig)
+		reg.Register(bt)
+		ss.toolNames = append(ss.toolNames, bt.Name())
+	}
+	m.servers["fs"] = ss
+
+	m.filterTools("fs", []string{"read_file", "write_file"}, []string{"write_file"})
+
+	var kept []string
+	for _, name := range ss.toolNames {
+		if _, ok := reg.Get(name); ok {
+			kept = append(kept, name)
+		}
+	}
+	want := []string{makeBridgeTool("fs", "read_file").Name()}
+	if !slices.Equal(kept, want) {
+		t.Errorf("registered tools = %v, want %v", kept, want)
+	}
+}
@@ -600,7 +600,7 @@ func (e *poolEntry) MCPTools() []mcpgo.Tool { return e.tools }
 
 // poolHealthLoop is a standalone health loop for pool-managed connections.
 // After consecutive ping failures, it attempts a full reconnect by creating
-// a fresh client, mirroring the Manager.tryReconnect slow path.
+// a fresh client, mirroring the Manager.healthLoop slow path.
 func poolHealthLoop(ctx context.Context, ss *serverState) {
 	ticker := newHealthTicker()
 	defer ticker.Stop()
@@ -610,40 +610,7 @@ func poolHealthLoop(ctx context.Context, ss *serverState) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := ss.client.Ping(ctx); err != nil {
-				if isMethodNotFound(err) {
-					ss.connected.Store(true)
-					ss.mu.Lock()
-					ss.healthFailures = 0
-					ss.mu.Unlock()
-					continue
-				}
-				ss.mu.Lock()
-				ss.healthFailures++
-				failures := ss.healthFailures
-				ss.lastErr = err.Error()
-				ss.mu.Unlock()
-
-				slog.Warn("mcp.pool.health_failed", "server", ss.name, "error", err, "consecutive", failures)
-
-				if failures >= healthFailThreshold {
-					ss.connected.Store(false)
-					poolTryReconnect(ctx, ss)
-				}
-			} else {
-				ss.connected.Store(true)
-				ss.mu.Lock()
-				ss.reconnAttempts = 0
-				ss.healthFailures = 0
-				ss.lastErr = ""
-				ss.mu.Unlock()
-			}
+			checkHealth(ctx, ss, "mcp.pool")
 		}
 	}
 }
-
-// poolTryReconnect attempts reconnect for a pool-managed connection.
-// Delegates to the shared reconnectWithBackoff with pool-specific log prefix.
-func poolTryReconnect(ctx context.Context, ss *serverState) {
-	reconnectWithBackoff(ctx, ss, "mcp.pool")
-}
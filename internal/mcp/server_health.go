@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ping checks the server with a bounded wait: a hung stdio process would
+// otherwise block the health loop forever.
+func (ss *serverState) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthPingTimeout)
+	defer cancel()
+	return ss.client.Ping(ctx)
+}
+
+// markHealthy records a successful ping (or reconnect) and resets failure counters.
+func (ss *serverState) markHealthy() {
+	ss.connected.Store(true)
+	ss.mu.Lock()
+	ss.reconnAttempts = 0
+	ss.healthFailures = 0
+	ss.lastErr = ""
+	ss.lastPingAt = time.Now()
+	ss.mu.Unlock()
+}
+
+// markFailed records a failed ping and returns the consecutive failure count.
+func (ss *serverState) markFailed(err error) int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.healthFailures++
+	ss.lastErr = err.Error()
+	ss.lastFailure, ss.lastFailureAt = ss.lastErr, time.Now()
+	return ss.healthFailures
+}
+
+// failThreshold is the number of consecutive failed pings before reconnecting.
+// A stdio server has no network in between, so one failure means the process
+// crashed or hung and is restarted right away.
+func (ss *serverState) failThreshold() int {
+	if ss.transport == "stdio" {
+		return 1
+	}
+	return healthFailThreshold
+}
+
+// status snapshots the server's health for status endpoints.
+func (ss *serverState) status(toolCount int) ServerStatus {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	st := ServerStatus{
+		Name:           ss.name,
+		Transport:      ss.transport,
+		Connected:      ss.connected.Load(),
+		ToolCount:      toolCount,
+		Error:          ss.lastErr,
+		HealthFailures: ss.healthFailures,
+		Restarts:       ss.restarts,
+		LastError:      ss.lastFailure,
+	}
+	if !ss.lastPingAt.IsZero() {
+		t := ss.lastPingAt
+		st.LastPingAt = &t
+	}
+	if !ss.lastFailureAt.IsZero() {
+		t := ss.lastFailureAt
+		st.LastErrorAt = &t
+	}
+	return st
+}
+
+// ServerStatus reports the pooled shared connection of a tenant's server.
+// ok is false when no agent has connected to it yet (or it was evicted as idle).
+func (p *Pool) ServerStatus(tenantID uuid.UUID, name string) (st ServerStatus, ok bool) {
+	key := poolKey(tenantID, name)
+	p.mu.Lock()
+	entry := p.servers[key]
+	var userConns int
+	for k := range p.userServers {
+		if strings.HasPrefix(k, key+"/") {
+			userConns++
+		}
+	}
+	p.mu.Unlock()
+	if entry == nil {
+		return ServerStatus{Name: name, UserConns: userConns}, false
+	}
+	st = entry.state.status(len(entry.tools))
+	st.UserConns = userConns
+	return st, true
+}
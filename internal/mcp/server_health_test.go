@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestServerState_HealthBookkeeping(t *testing.T) {
+	ss := &serverState{name: "fs", transport: "stdio"}
+	if got := ss.failThreshold(); got != 1 {
+		t.Errorf("stdio failThreshold = %d, want 1", got)
+	}
+	if got := (&serverState{transport: "sse"}).failThreshold(); got != healthFailThreshold {
+		t.Errorf("sse failThreshold = %d, want %d", got, healthFailThreshold)
+	}
+
+	if n := ss.markFailed(errors.New("transport closed")); n != 1 {
+		t.Errorf("first failure count = %d", n)
+	}
+	st := ss.status(3)
+	if st.Connected || st.Error != "transport closed" || st.HealthFailures != 1 || st.LastErrorAt == nil || st.LastPingAt != nil {
+		t.Errorf("after failure: %+v", st)
+	}
+
+	ss.markHealthy()
+	st = ss.status(3)
+	if !st.Connected || st.Error != "" || st.HealthFailures != 0 || st.LastPingAt == nil {
+		t.Errorf("after recovery: %+v", st)
+	}
+	if st.LastError != "transport closed" || st.ToolCount != 3 {
+		t.Errorf("last error should survive recovery: %+v", st)
+	}
+}
+
+func TestPool_ServerStatus(t *testing.T) {
+	p := &Pool{servers: map[string]*poolEntry{}, userServers: map[string]*poolEntry{}}
+	tid := uuid.New()
+
+	if _, ok := p.ServerStatus(tid, "fs"); ok {
+		t.Error("unpooled server reported as pooled")
+	}
+
+	ss := &serverState{name: "fs", transport: "stdio"}
+	ss.markHealthy()
+	p.servers[poolKey(tid, "fs")] = &poolEntry{state: ss, tools: make([]mcpgo.Tool, 2)}
+	p.userServers[UserPoolKey(tid, "fs", "u1")] = &poolEntry{state: &serverState{}}
+	p.userServers[UserPoolKey(tid, "fs-other", "u1")] = &poolEntry{state: &serverState{}}
+	p.userServers[UserPoolKey(uuid.New(), "fs", "u1")] = &poolEntry{state: &serverState{}}
+
+	st, ok := p.ServerStatus(tid, "fs")
+	if !ok || !st.Connected || st.ToolCount != 2 || st.UserConns != 1 {
+		t.Errorf("status = %+v, ok = %v", st, ok)
+	}
+}
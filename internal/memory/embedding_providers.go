@@ -0,0 +1,313 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// Embedding API formats understood by NewEmbeddingProvider.
+const (
+	EmbeddingFormatOpenAI = "openai" // POST {base}/embeddings — OpenAI, OpenRouter, Gemini compat, most gateways
+	EmbeddingFormatVoyage = "voyage" // POST {base}/embeddings with output_dimension
+	EmbeddingFormatCohere = "cohere" // POST {base}/v2/embed (embed-v3 / v4)
+	EmbeddingFormatOllama = "ollama" // POST {host}/api/embed — local Ollama, no API key
+)
+
+// ValidEmbeddingFormats lists the accepted embedding formats.
+var ValidEmbeddingFormats = map[string]bool{
+	EmbeddingFormatOpenAI: true,
+	EmbeddingFormatVoyage: true,
+	EmbeddingFormatCohere: true,
+	EmbeddingFormatOllama: true,
+}
+
+// Default request batch sizes, set to each API's documented input limit
+// (Ollama has none; 64 keeps local requests short).
+var defaultEmbeddingBatchSizes = map[string]int{
+	EmbeddingFormatOpenAI: 2048,
+	EmbeddingFormatVoyage: 1000,
+	EmbeddingFormatCohere: 96,
+	EmbeddingFormatOllama: 64,
+}
+
+var defaultEmbeddingAPIBases = map[string]string{
+	EmbeddingFormatOpenAI: "https://api.openai.com/v1",
+	EmbeddingFormatVoyage: "https://api.voyageai.com/v1",
+	EmbeddingFormatCohere: "https://api.cohere.com",
+	EmbeddingFormatOllama: "http://localhost:11434",
+}
+
+var defaultEmbeddingModels = map[string]string{
+	EmbeddingFormatOpenAI: "text-embedding-3-small",
+	EmbeddingFormatVoyage: "voyage-3.5",
+	EmbeddingFormatCohere: "embed-v4.0",
+	EmbeddingFormatOllama: "nomic-embed-text",
+}
+
+// EmbeddingOptions configures NewEmbeddingProvider. Zero values take the
+// format's defaults.
+type EmbeddingOptions struct {
+	Format     string // "" = DetectEmbeddingFormat(APIBase)
+	Name       string
+	APIKey     string
+	APIBase    string
+	Model      string
+	Dimensions int // requested output size; 0 = model default
+	BatchSize  int // max texts per request; 0 = format default
+}
+
+// DetectEmbeddingFormat returns the format for an API base when none is
+// configured: Voyage and native Cohere endpoints are recognised by host,
+// everything else is treated as OpenAI-compatible.
+func DetectEmbeddingFormat(apiBase string) string {
+	base := strings.ToLower(apiBase)
+	switch {
+	case strings.Contains(base, "voyageai.com"):
+		return EmbeddingFormatVoyage
+	case strings.Contains(base, "cohere.") && !strings.Contains(base, "/compatibility"):
+		return EmbeddingFormatCohere
+	default:
+		return EmbeddingFormatOpenAI
+	}
+}
+
+// DefaultEmbeddingModel returns the model used for format when none is set.
+func DefaultEmbeddingModel(format string) string {
+	if m, ok := defaultEmbeddingModels[format]; ok {
+		return m
+	}
+	return defaultEmbeddingModels[EmbeddingFormatOpenAI]
+}
+
+// NewEmbeddingProvider builds the provider for opts.Format.
+func NewEmbeddingProvider(opts EmbeddingOptions) (EmbeddingProvider, error) {
+	format := opts.Format
+	if format == "" {
+		format = DetectEmbeddingFormat(opts.APIBase)
+	}
+	if !ValidEmbeddingFormats[format] {
+		return nil, fmt.Errorf("unknown embedding format %q", format)
+	}
+	if opts.APIBase == "" {
+		opts.APIBase = defaultEmbeddingAPIBases[format]
+	}
+	if opts.Model == "" {
+		opts.Model = defaultEmbeddingModels[format]
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultEmbeddingBatchSizes[format]
+	}
+	if opts.Name == "" {
+		opts.Name = format
+	}
+
+	switch format {
+	case EmbeddingFormatOpenAI:
+		return NewOpenAIEmbeddingProvider(opts.Name, opts.APIKey, opts.APIBase, opts.Model).
+			WithDimensions(opts.Dimensions).
+			WithBatchSize(opts.BatchSize), nil
+	case EmbeddingFormatOllama:
+		// Ollama providers store the OpenAI-compat base (".../v1"); the native
+		// API lives at the host root.
+		opts.APIBase = strings.TrimSuffix(strings.TrimRight(opts.APIBase, "/"), "/v1")
+	}
+	return &restEmbeddingProvider{
+		format:     format,
+		name:       opts.Name,
+		model:      opts.Model,
+		apiKey:     opts.APIKey,
+		apiURL:     strings.TrimRight(opts.APIBase, "/"),
+		dimensions: opts.Dimensions,
+		batchSize:  opts.BatchSize,
+	}, nil
+}
+
+// restEmbeddingProvider speaks the Voyage, Cohere and Ollama embedding APIs.
+type restEmbeddingProvider struct {
+	format     string
+	name       string
+	model      string
+	apiKey     string
+	apiURL     string
+	dimensions int
+	batchSize  int
+}
+
+func (p *restEmbeddingProvider) Name() string  { return p.name }
+func (p *restEmbeddingProvider) Model() string { return p.model }
+
+func (p *restEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, p.batchSize, p.embedBatch)
+}
+
+func (p *restEmbeddingProvider) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	var url string
+	body := map[string]any{"model": p.model}
+	switch p.format {
+	case EmbeddingFormatVoyage:
+		url = p.apiURL + "/embeddings"
+		body["input"] = texts
+		if p.dimensions > 0 {
+			body["output_dimension"] = p.dimensions
+		}
+	case EmbeddingFormatCohere:
+		url = p.apiURL + "/v2/embed"
+		body["texts"] = texts
+		body["input_type"] = "search_document"
+		body["embedding_types"] = []string{"float"}
+		if p.dimensions > 0 {
+			body["output_dimension"] = p.dimensions
+		}
+	case EmbeddingFormatOllama:
+		url = p.apiURL + "/api/embed"
+		body["input"] = texts
+		if p.dimensions > 0 {
+			body["dimensions"] = p.dimensions
+		}
+	}
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyJSON))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("embedding API error %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+			Index     int       `json:"index"`
+		} `json:"data"` // Voyage
+		Embeddings json.RawMessage `json:"embeddings"` // Cohere: {"float": [...]}, Ollama: [...]
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	var vectors [][]float32
+	switch p.format {
+	case EmbeddingFormatVoyage:
+		vectors = make([][]float32, len(texts))
+		for _, d := range result.Data {
+			if d.Index >= 0 && d.Index < len(vectors) {
+				vectors[d.Index] = d.Embedding
+			}
+		}
+	case EmbeddingFormatCohere:
+		var typed struct {
+			Float [][]float32 `json:"float"`
+		}
+		if err := json.Unmarshal(result.Embeddings, &typed); err != nil {
+			return nil, fmt.Errorf("decode cohere embeddings: %w", err)
+		}
+		vectors = typed.Float
+	case EmbeddingFormatOllama:
+		if err := json.Unmarshal(result.Embeddings, &vectors); err != nil {
+			return nil, fmt.Errorf("decode ollama embeddings: %w", err)
+		}
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embedding API returned %d vectors for %d texts", len(vectors), len(texts))
+	}
+	return vectors, nil
+}
+
+// embedInBatches splits texts into requests of at most size texts.
+func embedInBatches(ctx context.Context, texts []string, size int, embed func(context.Context, []string) ([][]float32, error)) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if size <= 0 || size >= len(texts) {
+		return embed(ctx, texts)
+	}
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += size {
+		end := min(start+size, len(texts))
+		vecs, err := embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("embedding batch [%d:%d]: %w", start, end, err)
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+// paddedEmbeddingProvider zero-pads vectors to a fixed size.
+type paddedEmbeddingProvider struct {
+	EmbeddingProvider
+	size func() int
+}
+
+// PadDimensions wraps p so every vector has exactly size() dimensions, for
+// storage in fixed-size vector columns. size is read on every call so a
+// column resize takes effect without rebuilding the provider. Shorter
+// vectors are zero-padded, which leaves cosine similarity between them
+// unchanged; longer vectors are an error because truncating them would
+// change their meaning.
+func PadDimensions(p EmbeddingProvider, size func() int) EmbeddingProvider {
+	return &paddedEmbeddingProvider{EmbeddingProvider: p, size: size}
+}
+
+func (p *paddedEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vecs, err := p.EmbeddingProvider.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	size := p.size()
+	for i, v := range vecs {
+		switch {
+		case len(v) > size:
+			return nil, fmt.Errorf("embedding model %s returned %d dimensions, more than the %d supported; set a smaller embedding dimension", p.Model(), len(v), size)
+		case len(v) < size && len(v) > 0:
+			padded := make([]float32, size)
+			copy(padded, v)
+			vecs[i] = padded
+		}
+	}
+	return vecs, nil
+}
+
+// embeddingProbeText is embedded once to learn a model's native vector size.
+const embeddingProbeText = "dimension probe"
+
+// Fingerprint identifies the model behind p, embedding a short probe text to
+// learn its native (unpadded) vector size.
+func Fingerprint(ctx context.Context, p EmbeddingProvider) (store.EmbeddingFingerprint, error) {
+	fp := store.EmbeddingFingerprint{Provider: p.Name(), Model: p.Model()}
+	if padded, ok := p.(*paddedEmbeddingProvider); ok {
+		p = padded.EmbeddingProvider
+	}
+	vecs, err := p.Embed(ctx, []string{embeddingProbeText})
+	if err != nil {
+		return fp, fmt.Errorf("embedding probe: %w", err)
+	}
+	if len(vecs) == 0 || len(vecs[0]) == 0 {
+		return fp, fmt.Errorf("embedding probe: provider returned no vector")
+	}
+	fp.Dimensions = len(vecs[0])
+	return fp, nil
+}
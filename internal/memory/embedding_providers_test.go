@@ -0,0 +1,173 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// embedServer answers one embedding request per call with 3-dim vectors
+// whose first component is the text's length, recording every request body.
+type embedServer struct {
+	mu     sync.Mutex
+	paths  []string
+	bodies []map[string]any
+}
+
+func (s *embedServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		s.mu.Lock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.bodies = append(s.bodies, body)
+		s.mu.Unlock()
+
+		var texts []any
+		for _, key := range []string{"input", "texts"} {
+			if v, ok := body[key].([]any); ok {
+				texts = v
+			}
+		}
+		vecs := make([][]float32, len(texts))
+		for i, tx := range texts {
+			vecs[i] = []float32{float32(len(tx.(string))), 0, 1}
+		}
+		switch r.URL.Path {
+		case "/v1/embeddings":
+			data := make([]map[string]any, len(vecs))
+			for i, v := range vecs {
+				data[i] = map[string]any{"index": i, "embedding": v}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+		case "/v2/embed":
+			_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": map[string]any{"float": vecs}})
+		case "/api/embed":
+			_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": vecs})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestRESTEmbeddingProviders(t *testing.T) {
+	tests := []struct {
+		format, base, path, dimKey string
+	}{
+		{EmbeddingFormatVoyage, "/v1", "/v1/embeddings", "output_dimension"},
+		{EmbeddingFormatCohere, "", "/v2/embed", "output_dimension"},
+		{EmbeddingFormatOllama, "/v1", "/api/embed", "dimensions"}, // /v1 is stripped for the native API
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			es := &embedServer{}
+			srv := httptest.NewServer(es.handler(t))
+			defer srv.Close()
+
+			ep, err := NewEmbeddingProvider(EmbeddingOptions{
+				Format: tt.format, APIBase: srv.URL + tt.base, Dimensions: 512, BatchSize: 2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ep.Model() != DefaultEmbeddingModel(tt.format) {
+				t.Errorf("model = %q", ep.Model())
+			}
+			vecs, err := ep.Embed(context.Background(), []string{"a", "bb", "ccc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(vecs) != 3 || vecs[0][0] != 1 || vecs[2][0] != 3 {
+				t.Errorf("vectors out of order: %v", vecs)
+			}
+			if len(es.paths) != 2 || es.paths[0] != tt.path {
+				t.Errorf("requests = %v, want 2 batches to %s", es.paths, tt.path)
+			}
+			if es.bodies[0][tt.dimKey] != float64(512) {
+				t.Errorf("body %v missing %s", es.bodies[0], tt.dimKey)
+			}
+		})
+	}
+}
+
+func TestOpenAIEmbeddingBatchSize(t *testing.T) {
+	es := &embedServer{}
+	srv := httptest.NewServer(es.handler(t))
+	defer srv.Close()
+
+	ep, err := NewEmbeddingProvider(EmbeddingOptions{Format: EmbeddingFormatOpenAI, APIBase: srv.URL + "/v1", BatchSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ep.Embed(context.Background(), []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(es.paths) != 2 {
+		t.Errorf("made %d requests, want one per text", len(es.paths))
+	}
+}
+
+func TestDetectEmbeddingFormat(t *testing.T) {
+	for base, want := range map[string]string{
+		"https://api.voyageai.com/v1":            EmbeddingFormatVoyage,
+		"https://api.cohere.com":                 EmbeddingFormatCohere,
+		"https://api.cohere.ai/compatibility/v1": EmbeddingFormatOpenAI,
+		"https://openrouter.ai/api/v1":           EmbeddingFormatOpenAI,
+		"":                                       EmbeddingFormatOpenAI,
+	} {
+		if got := DetectEmbeddingFormat(base); got != want {
+			t.Errorf("DetectEmbeddingFormat(%q) = %q, want %q", base, got, want)
+		}
+	}
+	if _, err := NewEmbeddingProvider(EmbeddingOptions{Format: "bert"}); err == nil {
+		t.Error("unknown format should fail")
+	}
+}
+
+type fixedEmbedder struct{ vecs [][]float32 }
+
+func (f fixedEmbedder) Name() string  { return "fixed" }
+func (f fixedEmbedder) Model() string { return "fixed-model" }
+func (f fixedEmbedder) Embed(context.Context, []string) ([][]float32, error) {
+	return f.vecs, nil
+}
+
+func TestPadDimensions(t *testing.T) {
+	a, b := []float32{1, 2}, []float32{2, 1}
+	p := PadDimensions(fixedEmbedder{vecs: [][]float32{a, b}}, func() int { return 4 })
+	vecs, err := p.Embed(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vecs[0]) != 4 || vecs[0][1] != 2 || vecs[0][3] != 0 {
+		t.Errorf("padded = %v", vecs[0])
+	}
+	if got, want := CosineSimilarity(vecs[0], vecs[1]), CosineSimilarity(a, b); got != want {
+		t.Errorf("padding changed similarity: %v != %v", got, want)
+	}
+	if p.Model() != "fixed-model" {
+		t.Errorf("Model() = %q", p.Model())
+	}
+
+	_, err = PadDimensions(fixedEmbedder{vecs: [][]float32{{1, 2, 3}}}, func() int { return 2 }).Embed(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "3 dimensions") {
+		t.Errorf("oversized vector error = %v", err)
+	}
+}
+
+func TestFingerprintUsesNativeDimensions(t *testing.T) {
+	p := PadDimensions(fixedEmbedder{vecs: [][]float32{{1, 2, 3}}}, func() int { return 8 })
+	fp, err := Fingerprint(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp.Provider != "fixed" || fp.Model != "fixed-model" || fp.Dimensions != 3 {
+		t.Errorf("fingerprint = %+v, want fixed/fixed-model with 3 dims", fp)
+	}
+}
@@ -144,6 +144,7 @@ type OpenAIEmbeddingProvider struct {
 	apiKey     string
 	apiURL     string
 	dimensions int // optional: truncate output to this many dimensions (0 = use model default)
+	batchSize  int // max texts per request (0 = no split)
 }
 
 // NewOpenAIEmbeddingProvider creates a provider for OpenAI-compatible embedding APIs.
@@ -169,10 +170,20 @@ func (p *OpenAIEmbeddingProvider) WithDimensions(d int) *OpenAIEmbeddingProvider
 	return p
 }
 
+// WithBatchSize caps the number of texts sent per request.
+func (p *OpenAIEmbeddingProvider) WithBatchSize(n int) *OpenAIEmbeddingProvider {
+	p.batchSize = n
+	return p
+}
+
 func (p *OpenAIEmbeddingProvider) Name() string  { return p.name }
 func (p *OpenAIEmbeddingProvider) Model() string { return p.model }
 
 func (p *OpenAIEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, p.batchSize, p.embedBatch)
+}
+
+func (p *OpenAIEmbeddingProvider) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	reqBody := map[string]any{
 		"input": texts,
 		"model": p.model,
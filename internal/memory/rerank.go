@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// rerankSnippetRunes caps each candidate sent to a reranker.
+const rerankSnippetRunes = 800
+
+// LLMReranker scores memory search candidates with a chat model.
+type LLMReranker struct {
+	provider providers.Provider
+	model    string
+}
+
+// NewLLMReranker creates a reranker that asks provider's model (default
+// model when empty) for a relevance score per candidate.
+func NewLLMReranker(provider providers.Provider, model string) *LLMReranker {
+	return &LLMReranker{provider: provider, model: model}
+}
+
+const llmRerankPrompt = `You rank search results. For each numbered passage, rate how well it answers or is relevant to the query, from 0 (unrelated) to 10 (directly answers it).
+Reply with ONLY a JSON array of numbers, one per passage, in passage order. Example for three passages: [7, 0, 3]`
+
+// Rerank returns one score in [0, 1] per doc.
+func (r *LLMReranker) Rerank(ctx context.Context, query string, docs []string) ([]float64, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Query: %s\n\n", query)
+	for i, d := range docs {
+		fmt.Fprintf(&sb, "[%d] %s\n\n", i+1, truncateRunes(d, rerankSnippetRunes))
+	}
+
+	resp, err := r.provider.Chat(ctx, providers.ChatRequest{
+		Messages: []providers.Message{
+			{Role: "system", Content: llmRerankPrompt},
+			{Role: "user", Content: sb.String()},
+		},
+		Model:   r.model,
+		Options: map[string]any{providers.OptMaxTokens: 16 + 6*len(docs), "temperature": 0.0},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rerank chat: %w", err)
+	}
+	scores, err := parseRerankScores(resp.Content)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) != len(docs) {
+		return nil, fmt.Errorf("rerank: got %d scores for %d passages", len(scores), len(docs))
+	}
+	for i, s := range scores {
+		scores[i] = min(max(s/10, 0), 1)
+	}
+	return scores, nil
+}
+
+// parseRerankScores extracts the first JSON number array from the reply.
+func parseRerankScores(text string) ([]float64, error) {
+	start := strings.Index(text, "[")
+	end := strings.LastIndex(text, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("rerank: no score array in reply")
+	}
+	var scores []float64
+	if err := json.Unmarshal([]byte(text[start:end+1]), &scores); err != nil {
+		return nil, fmt.Errorf("rerank: decode scores: %w", err)
+	}
+	return scores, nil
+}
+
+// CrossEncoderReranker calls a /rerank endpoint (Cohere, Jina, Voyage and
+// compatible self-hosted servers such as TEI or Infinity).
+type CrossEncoderReranker struct {
+	apiKey string
+	apiURL string
+	model  string
+}
+
+// NewCrossEncoderReranker creates a reranker for apiURL + "/rerank".
+func NewCrossEncoderReranker(apiKey, apiURL, model string) *CrossEncoderReranker {
+	return &CrossEncoderReranker{apiKey: apiKey, apiURL: strings.TrimRight(apiURL, "/"), model: model}
+}
+
+// Rerank returns the endpoint's relevance score per doc, in input order.
+func (r *CrossEncoderReranker) Rerank(ctx context.Context, query string, docs []string) ([]float64, error) {
+	trimmed := make([]string, len(docs))
+	for i, d := range docs {
+		trimmed[i] = truncateRunes(d, rerankSnippetRunes)
+	}
+	bodyJSON, err := json.Marshal(map[string]any{
+		"model":     r.model,
+		"query":     query,
+		"documents": trimmed,
+		"top_n":     len(docs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.apiURL+"/rerank", bytes.NewReader(bodyJSON))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("rerank API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Cohere/Jina return {"results": [...]}, Voyage returns {"data": [...]}.
+	type scored struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	}
+	var result struct {
+		Results []scored `json:"results"`
+		Data    []scored `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	items := result.Results
+	if len(items) == 0 {
+		items = result.Data
+	}
+
+	scores := make([]float64, len(docs))
+	seen := 0
+	for _, it := range items {
+		if it.Index < 0 || it.Index >= len(docs) {
+			return nil, fmt.Errorf("rerank: result index %d out of range", it.Index)
+		}
+		scores[it.Index] = it.RelevanceScore
+		seen++
+	}
+	if seen != len(docs) {
+		return nil, fmt.Errorf("rerank: got %d scores for %d documents", seen, len(docs))
+	}
+	return scores, nil
+}
+
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "..."
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+type rerankChatProvider struct {
+	reply string
+	got   providers.ChatRequest
+}
+
+func (p *rerankChatProvider) Chat(_ context.Context, req providers.ChatRequest) (*providers.ChatResponse, error) {
+	p.got = req
+	return &providers.ChatResponse{Content: p.reply}, nil
+}
+
+func (p *rerankChatProvider) ChatStream(ctx context.Context, req providers.ChatRequest, _ func(providers.StreamChunk)) (*providers.ChatResponse, error) {
+	return p.Chat(ctx, req)
+}
+
+func (p *rerankChatProvider) DefaultModel() string { return "mock" }
+func (p *rerankChatProvider) Name() string         { return "mock" }
+
+func TestLLMReranker(t *testing.T) {
+	p := &rerankChatProvider{reply: "Scores:\n[2, 10, 12.5]"}
+	scores, err := NewLLMReranker(p, "small").Rerank(context.Background(), "deploy target", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0.2, 1, 1}; scores[0] != want[0] || scores[1] != want[1] || scores[2] != want[2] {
+		t.Errorf("scores = %v, want %v", scores, want)
+	}
+	if p.got.Model != "small" || !strings.Contains(p.got.Messages[1].Content, "[3] c") {
+		t.Errorf("request = %+v", p.got)
+	}
+
+	p.reply = "[1, 2]"
+	if _, err := NewLLMReranker(p, "").Rerank(context.Background(), "q", []string{"a", "b", "c"}); err == nil {
+		t.Error("a score count mismatch should fail")
+	}
+}
+
+func TestCrossEncoderReranker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/rerank" || r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			Documents []string `json:"documents"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if len(body.Documents) != 2 {
+			http.Error(w, "want 2 documents", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [{"index": 1, "relevance_score": 0.9}, {"index": 0, "relevance_score": 0.1}]}`))
+	}))
+	defer srv.Close()
+
+	scores, err := NewCrossEncoderReranker("key", srv.URL+"/v1/", "rerank-v3").Rerank(context.Background(), "q", []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores[0] != 0.1 || scores[1] != 0.9 {
+		t.Errorf("scores = %v, want input order [0.1 0.9]", scores)
+	}
+}
@@ -0,0 +1,342 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// watchDebounce is the delay before processing workspace changes. Editors
+// and git write files in bursts; one flush per burst keeps re-embedding cheap.
+const watchDebounce = 1500 * time.Millisecond
+
+// maxWatchedFileBytes skips oversized files (logs, dumps) dropped into memory/.
+const maxWatchedFileBytes = 1 << 20
+
+// bootstrapFiles are the agent-level context files whose edits are reported
+// through the bootstrap callback instead of being indexed.
+var bootstrapFiles = []string{
+	bootstrap.AgentsFile,
+	bootstrap.SoulFile,
+	bootstrap.IdentityFile,
+	bootstrap.CapabilitiesFile,
+	bootstrap.HeartbeatFile,
+	bootstrap.UserPredefinedFile,
+}
+
+// AgentLister is the subset of store.AgentStore the watcher needs.
+type AgentLister interface {
+	List(ctx context.Context, ownerID string) ([]store.AgentData, error)
+}
+
+// Watcher monitors agent workspaces for memory file changes (MEMORY.md,
+// memory.md, memory/**/*.md) and re-indexes just the changed documents, so
+// edits made outside the agent are searchable without a full reindex.
+// Deleted files are removed from the index. Edits to workspace bootstrap
+// files are reported through the OnBootstrapChange callback.
+//
+// Files are indexed as agent-level (shared) memory. Only master-tenant agents
+// with a workspace directory of their own are watched.
+type Watcher struct {
+	agents   AgentLister
+	memStore store.MemoryStore
+	fsw      *fsnotify.Watcher
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	onBootstrapChange func(agentID uuid.UUID)
+
+	mu      sync.Mutex
+	roots   map[string]uuid.UUID // workspace root → agent
+	timer   *time.Timer
+	pending map[string]struct{} // changed absolute paths since last flush
+}
+
+// NewWatcher creates a workspace memory watcher.
+func NewWatcher(agents AgentLister, ms store.MemoryStore) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{
+		agents:   agents,
+		memStore: ms,
+		fsw:      fsw,
+		roots:    make(map[string]uuid.UUID),
+		pending:  make(map[string]struct{}),
+	}, nil
+}
+
+// SetOnBootstrapChange sets the callback run after a bootstrap file changed
+// in an agent's workspace.
+func (w *Watcher) SetOnBootstrapChange(fn func(agentID uuid.UUID)) { w.onBootstrapChange = fn }
+
+// Start watches the workspaces of all current agents.
+func (w *Watcher) Start(ctx context.Context) error {
+	w.Refresh(ctx)
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		safego.Supervise(ctx, "memory_watcher", w.loop)
+	}()
+	return nil
+}
+
+// Refresh starts watching workspaces of agents created since the last call.
+func (w *Watcher) Refresh(ctx context.Context) {
+	ctx = store.WithTenantID(ctx, store.MasterTenantID)
+	agents, err := w.agents.List(ctx, "")
+	if err != nil {
+		slog.Warn("memory watcher: failed to list agents", "error", err)
+		return
+	}
+
+	// Shared directories are skipped: their files can't be attributed to one agent.
+	dirs := make(map[uuid.UUID]string, len(agents))
+	users := map[string]int{}
+	for _, ag := range agents {
+		if dir := workspaceDir(ag); dir != "" {
+			dirs[ag.ID] = dir
+			users[dir]++
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	added := 0
+	for id, dir := range dirs {
+		if users[dir] > 1 {
+			continue
+		}
+		if _, ok := w.roots[dir]; ok {
+			continue
+		}
+		if err := w.fsw.Add(dir); err != nil {
+			slog.Warn("memory watcher: cannot watch workspace", "path", dir, "error", err)
+			continue
+		}
+		w.roots[dir] = id
+		w.addTree(filepath.Join(dir, "memory"))
+		added++
+	}
+	if added > 0 {
+		slog.Info("memory watcher: watching workspaces", "added", added, "total", len(w.roots))
+	}
+}
+
+// addTree watches dir and its subdirectories (fsnotify is not recursive).
+func (w *Watcher) addTree(dir string) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		_ = w.fsw.Add(path)
+		return nil
+	})
+}
+
+// Stop shuts down the watcher.
+func (w *Watcher) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	w.fsw.Close()
+
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+}
+
+func (w *Watcher) loop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(ctx, event)
+
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("memory watcher error", "error", err)
+		}
+	}
+}
+
+func (w *Watcher) handleEvent(ctx context.Context, event fsnotify.Event) {
+	path := event.Name
+
+	// New directory under memory/ → watch it and pick up files already in it.
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			if _, _, ok := w.classify(path + string(filepath.Separator) + "x.md"); ok {
+				w.mu.Lock()
+				w.addTree(path)
+				w.mu.Unlock()
+				_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+					if err == nil && !d.IsDir() {
+						w.schedule(ctx, p)
+					}
+					return nil
+				})
+			}
+			return
+		}
+	}
+	if event.Op == fsnotify.Chmod {
+		return
+	}
+	w.schedule(ctx, path)
+}
+
+// schedule queues path for the next debounced flush when it is a watched file.
+func (w *Watcher) schedule(ctx context.Context, path string) {
+	if _, _, ok := w.classify(path); !ok {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending[path] = struct{}{}
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchDebounce, func() { w.flush(ctx) })
+}
+
+// classify maps an absolute path to its agent and workspace-relative path.
+// ok is false for files the watcher ignores.
+func (w *Watcher) classify(path string) (agentID uuid.UUID, rel string, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for root, id := range w.roots {
+		r, err := filepath.Rel(root, path)
+		if err != nil || r == "." || strings.HasPrefix(r, "..") {
+			continue
+		}
+		r = filepath.ToSlash(r)
+		if IsMemoryFile(r) || slices.Contains(bootstrapFiles, r) {
+			return id, r, true
+		}
+		return uuid.Nil, "", false
+	}
+	return uuid.Nil, "", false
+}
+
+// IsMemoryFile reports whether a workspace-relative path is a memory file:
+// root MEMORY.md / memory.md or a Markdown file under memory/.
+func IsMemoryFile(rel string) bool {
+	if rel == bootstrap.MemoryFile || rel == bootstrap.MemoryAltFile {
+		return true
+	}
+	return strings.HasPrefix(rel, "memory/") && strings.HasSuffix(rel, ".md")
+}
+
+// flush re-indexes changed memory files and reports bootstrap edits.
+func (w *Watcher) flush(ctx context.Context) {
+	w.mu.Lock()
+	paths := make([]string, 0, len(w.pending))
+	for p := range w.pending {
+		paths = append(paths, p)
+	}
+	w.pending = make(map[string]struct{})
+	w.mu.Unlock()
+
+	ctx = store.WithTenantID(ctx, store.MasterTenantID)
+	bootstrapChanged := map[uuid.UUID]bool{}
+	indexed, removed := 0, 0
+	for _, path := range paths {
+		agentID, rel, ok := w.classify(path)
+		if !ok {
+			continue
+		}
+		if !IsMemoryFile(rel) {
+			bootstrapChanged[agentID] = true
+			continue
+		}
+		switch changed, deleted, err := w.syncFile(ctx, agentID.String(), path, rel); {
+		case err != nil:
+			slog.Warn("memory watcher: sync failed", "agent", agentID, "path", rel, "error", err)
+		case deleted:
+			removed++
+		case changed:
+			indexed++
+		}
+	}
+	if indexed+removed > 0 {
+		slog.Info("memory watcher: re-indexed workspace memory", "indexed", indexed, "removed", removed)
+	}
+	if w.onBootstrapChange != nil {
+		for id := range bootstrapChanged {
+			w.onBootstrapChange(id)
+		}
+	}
+}
+
+// syncFile brings one document in the store in line with the file on disk.
+func (w *Watcher) syncFile(ctx context.Context, agentID, path, rel string) (changed, deleted bool, err error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if _, getErr := w.memStore.GetDocument(ctx, agentID, "", rel); getErr != nil {
+			return false, false, nil // never indexed
+		}
+		return false, true, w.memStore.DeleteDocument(ctx, agentID, "", rel)
+	}
+	if err != nil {
+		return false, false, err
+	}
+	if !info.Mode().IsRegular() || info.Size() > maxWatchedFileBytes {
+		return false, false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, false, err
+	}
+	content := string(data)
+	if existing, err := w.memStore.GetDocument(ctx, agentID, "", rel); err == nil && existing == content {
+		return false, false, nil
+	}
+	if err := w.memStore.PutDocument(ctx, agentID, "", rel, content); err != nil {
+		return false, false, err
+	}
+	return true, false, w.memStore.IndexDocument(ctx, agentID, "", rel)
+}
+
+// workspaceDir returns the agent's own workspace directory, or "" when it has none.
+func workspaceDir(ag store.AgentData) string {
+	if ag.Workspace == "" || (ag.TenantID != store.MasterTenantID && ag.TenantID != uuid.Nil) {
+		return ""
+	}
+	dir := config.ExpandHome(ag.Workspace)
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+type stubAgentLister struct{ agents []store.AgentData }
+
+func (s stubAgentLister) List(context.Context, string) ([]store.AgentData, error) {
+	return s.agents, nil
+}
+
+// stubMemoryStore implements the MemoryStore methods the watcher uses.
+type stubMemoryStore struct {
+	store.MemoryStore
+	mu      sync.Mutex
+	docs    map[string]string
+	indexed []string
+}
+
+func (s *stubMemoryStore) GetDocument(_ context.Context, agentID, _, path string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.docs[agentID+"/"+path]; ok {
+		return c, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (s *stubMemoryStore) PutDocument(_ context.Context, agentID, _, path, content string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[agentID+"/"+path] = content
+	return nil
+}
+
+func (s *stubMemoryStore) DeleteDocument(_ context.Context, agentID, _, path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.docs, agentID+"/"+path)
+	return nil
+}
+
+func (s *stubMemoryStore) IndexDocument(_ context.Context, agentID, _, path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indexed = append(s.indexed, agentID+"/"+path)
+	return nil
+}
+
+func (s *stubMemoryStore) doc(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.docs[key]
+	return c, ok
+}
+
+func TestIsMemoryFile(t *testing.T) {
+	for rel, want := range map[string]bool{
+		"MEMORY.md":              true,
+		"memory.md":              true,
+		"memory/2026-10-16.md":   true,
+		"memory/notes/deep.md":   true,
+		"memory/raw.log":         false,
+		"README.md":              false,
+		"notes/memory/weekly.md": false,
+	} {
+		if got := IsMemoryFile(rel); got != want {
+			t.Errorf("IsMemoryFile(%q) = %v, want %v", rel, got, want)
+		}
+	}
+}
+
+func TestWatcherReindexesChangedFiles(t *testing.T) {
+	ws := t.TempDir()
+	agentID := uuid.New()
+	ms := &stubMemoryStore{docs: map[string]string{}}
+	w, err := NewWatcher(stubAgentLister{agents: []store.AgentData{{
+		BaseModel: store.BaseModel{ID: agentID},
+		TenantID:  store.MasterTenantID,
+		Workspace: ws,
+	}}}, ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bootstrapped := make(chan uuid.UUID, 1)
+	w.SetOnBootstrapChange(func(id uuid.UUID) { bootstrapped <- id })
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	key := agentID.String() + "/memory/notes/today.md"
+	if err := os.MkdirAll(filepath.Join(ws, "memory", "notes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ws, "memory", "notes", "today.md"), []byte("shipped"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(ws, "SOUL.md"), []byte("calm"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { c, ok := ms.doc(key); return ok && c == "shipped" })
+
+	select {
+	case id := <-bootstrapped:
+		if id != agentID {
+			t.Errorf("bootstrap change reported for %s, want %s", id, agentID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("bootstrap change not reported")
+	}
+
+	if err := os.Remove(filepath.Join(ws, "memory", "notes", "today.md")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { _, ok := ms.doc(key); return !ok })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,251 @@
+// Package netproxy resolves the outbound HTTP/SOCKS proxy for every network
+// call the gateway makes (LLM providers, tools, channel websockets, webhooks,
+// headless browser).
+//
+// Resolution order for a scope such as "provider:openai" or "tool:web_fetch":
+//  1. per-scope override from config (the literal "direct" disables proxying)
+//  2. config-level default URL
+//  3. HTTPS_PROXY / HTTP_PROXY / ALL_PROXY environment variables
+//
+// NO_PROXY from the environment and the config no_proxy list are merged and
+// always honored. Settings are read at request time, so Configure can be
+// called again on config hot-reload.
+package netproxy
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Direct is the override value that forces a scope to bypass any proxy.
+const Direct = "direct"
+
+// Well-known scopes. Providers, tools and channels use the Provider/Tool/Channel helpers.
+const (
+	ScopeDefault  = "" // no override; default URL or environment
+	ScopeWebhooks = "webhooks"
+	ScopeBrowser  = "browser"
+)
+
+// Provider returns the scope key for an LLM provider (registry name).
+func Provider(name string) string { return "provider:" + name }
+
+// Tool returns the scope key for a builtin tool.
+func Tool(name string) string { return "tool:" + name }
+
+// Channel returns the scope key for a channel type (e.g. "feishu", "zalo_personal").
+func Channel(name string) string { return "channel:" + name }
+
+// Settings is the resolved proxy configuration.
+type Settings struct {
+	URL       string            // default proxy; empty = fall back to environment
+	NoProxy   string            // comma-separated hosts/CIDRs/domains, merged with NO_PROXY
+	Overrides map[string]string // scope → proxy URL or Direct
+
+	Offline      bool     // block every outbound call not matched by OfflineAllow
+	OfflineAllow []string // NO_PROXY-style entries reachable in offline mode
+}
+
+type state struct {
+	settings Settings
+	funcs    map[string]func(*url.URL) (*url.URL, error) // scope → cached httpproxy func
+	offline  func(*url.URL) bool                         // offline allowlist matcher; nil unless settings.Offline
+}
+
+var (
+	mu  sync.RWMutex
+	cur = &state{funcs: map[string]func(*url.URL) (*url.URL, error){}}
+)
+
+// Configure replaces the active settings. Safe to call concurrently with requests.
+func Configure(s Settings) {
+	st := &state{settings: s, funcs: map[string]func(*url.URL) (*url.URL, error){}}
+	if s.Offline {
+		st.offline = offlineMatcher(s.OfflineAllow)
+	}
+	mu.Lock()
+	cur = st
+	mu.Unlock()
+}
+
+// For returns an http.Transport.Proxy func for the given scope.
+// Settings are looked up on every call so reconfiguration takes effect
+// without rebuilding transports. In offline mode requests to hosts outside
+// the allowlist fail here with an *OfflineError before any dial.
+func For(scope string) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if err := CheckOffline(req.URL); err != nil {
+			return nil, err
+		}
+		return resolve(scope)(req.URL)
+	}
+}
+
+// Transport returns a clone of http.DefaultTransport whose proxy is scoped.
+func Transport(scope string) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = For(scope)
+	return t
+}
+
+// URLFor returns the proxy URL to use for target within scope, or nil for direct.
+func URLFor(scope string, target *url.URL) (*url.URL, error) {
+	if err := CheckOffline(target); err != nil {
+		return nil, err
+	}
+	return resolve(scope)(target)
+}
+
+// Default returns the proxy URL configured for scope without evaluating
+// NO_PROXY (used for process-wide settings such as browser launch flags).
+// Returns "" when no proxy applies.
+func Default(scope string) string {
+	mu.RLock()
+	s := cur.settings
+	mu.RUnlock()
+	raw := proxyURLFor(s, scope)
+	if raw == Direct {
+		return ""
+	}
+	if raw == "" {
+		env := httpproxy.FromEnvironment()
+		raw = env.HTTPSProxy
+		if raw == "" {
+			raw = env.HTTPProxy
+		}
+		if raw == "" {
+			raw = os.Getenv("ALL_PROXY")
+		}
+	}
+	return raw
+}
+
+// NoProxyList returns the merged config + environment NO_PROXY entries.
+func NoProxyList() []string {
+	mu.RLock()
+	s := cur.settings
+	mu.RUnlock()
+	var out []string
+	for _, p := range strings.Split(mergeNoProxy(s.NoProxy), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// IsProxyAddr reports whether addr ("host:port") is the proxy endpoint that
+// scope would dial. Used by pinned-IP dialers (SSRF-safe webhook client) so
+// the connection to a trusted operator-configured proxy is not rewritten.
+func IsProxyAddr(scope, addr string) bool {
+	raw := Default(scope)
+	if raw == "" {
+		return false
+	}
+	u, err := parseProxyURL(raw)
+	if err != nil {
+		return false
+	}
+	return canonicalAddr(u) == addr
+}
+
+func resolve(scope string) func(*url.URL) (*url.URL, error) {
+	mu.RLock()
+	st := cur
+	fn, ok := st.funcs[scope]
+	mu.RUnlock()
+	if ok {
+		return fn
+	}
+
+	fn = build(st.settings, scope)
+	mu.Lock()
+	st.funcs[scope] = fn
+	mu.Unlock()
+	return fn
+}
+
+func build(s Settings, scope string) func(*url.URL) (*url.URL, error) {
+	raw := proxyURLFor(s, scope)
+	if raw == Direct {
+		return func(*url.URL) (*url.URL, error) { return nil, nil }
+	}
+
+	cfg := httpproxy.FromEnvironment()
+	if raw != "" {
+		cfg.HTTPProxy = raw
+		cfg.HTTPSProxy = raw
+	} else if cfg.HTTPSProxy == "" && cfg.HTTPProxy == "" {
+		if all := os.Getenv("ALL_PROXY"); all != "" {
+			cfg.HTTPProxy = all
+			cfg.HTTPSProxy = all
+		}
+	}
+	cfg.NoProxy = mergeNoProxy(s.NoProxy)
+	return cfg.ProxyFunc()
+}
+
+func proxyURLFor(s Settings, scope string) string {
+	if v := strings.TrimSpace(s.Overrides[scope]); v != "" {
+		return v
+	}
+	return strings.TrimSpace(s.URL)
+}
+
+func mergeNoProxy(cfgList string) string {
+	env := os.Getenv("NO_PROXY")
+	if env == "" {
+		env = os.Getenv("no_proxy")
+	}
+	switch {
+	case cfgList == "":
+		return env
+	case env == "":
+		return cfgList
+	default:
+		return cfgList + "," + env
+	}
+}
+
+// parseProxyURL mirrors httpproxy's leniency: a bare "host:port" means http.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return url.Parse("http://" + raw)
+	}
+	return u, nil
+}
+
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5", "socks5h":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// Redact strips credentials from a proxy URL for logging.
+func Redact(raw string) string {
+	if raw == "" || raw == Direct {
+		return raw
+	}
+	u, err := parseProxyURL(raw)
+	if err != nil {
+		return "[unparseable]"
+	}
+	u.User = nil
+	return u.String()
+}
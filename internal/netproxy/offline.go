@@ -0,0 +1,86 @@
+package netproxy
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// offlineImplicitAllow is always reachable in offline mode in addition to
+// localhost and loopback IPs: config.DockerLocalhost rewrites local endpoints
+// (Ollama, embeddings, MCP) to it when the gateway runs in a container.
+const offlineImplicitAllow = "host.docker.internal"
+
+// OfflineError is returned for an outbound call blocked by offline mode.
+type OfflineError struct {
+	Host string
+}
+
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("offline mode: outbound call to %s is blocked (add it to offline_allow to permit)", e.Host)
+}
+
+// Offline reports whether offline mode is active.
+func Offline() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return cur.settings.Offline
+}
+
+// CheckOffline returns an *OfflineError when offline mode is active and target
+// is not allowlisted. It is evaluated by every scoped transport, so callers only
+// need it to fail before work that does not go through one (browser, MCP connect).
+func CheckOffline(target *url.URL) error {
+	mu.RLock()
+	st := cur
+	mu.RUnlock()
+	if !st.settings.Offline || target == nil || st.offline(target) {
+		return nil
+	}
+	return &OfflineError{Host: target.Host}
+}
+
+// CheckOfflineURL is CheckOffline for a raw URL. Unparseable URLs are left to
+// the caller's own validation.
+func CheckOfflineURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil
+	}
+	return CheckOffline(u)
+}
+
+// OfflineAllowed reports whether target is reachable in offline mode with the
+// given allowlist. Entries use NO_PROXY syntax: hosts, ".domain" suffixes,
+// CIDRs and host:port. Localhost and loopback IPs are always allowed.
+func OfflineAllowed(allow []string, target *url.URL) bool {
+	return offlineMatcher(allow)(target)
+}
+
+// offlineMatcher reuses the NO_PROXY matcher: an allowlisted host is one the
+// sentinel proxy would not be used for.
+func offlineMatcher(allow []string) func(*url.URL) bool {
+	entries := append([]string{offlineImplicitAllow}, allow...)
+	cfg := httpproxy.Config{
+		HTTPProxy:  "http://offline.invalid",
+		HTTPSProxy: "http://offline.invalid",
+		NoProxy:    strings.Join(entries, ","),
+	}
+	fn := cfg.ProxyFunc()
+	return func(target *url.URL) bool {
+		if target.Host == "" {
+			return true // relative or non-network URL (file:, data:)
+		}
+		u := *target
+		switch u.Scheme {
+		case "https", "wss":
+			u.Scheme = "https"
+		default:
+			u.Scheme = "http"
+		}
+		p, err := fn(&u)
+		return err == nil && p == nil
+	}
+}
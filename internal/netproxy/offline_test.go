@@ -0,0 +1,82 @@
+package netproxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestOfflineAllowed(t *testing.T) {
+	allow := []string{"ollama.lan", ".corp.internal", "10.0.0.0/8", "mcp.example.com:8443"}
+	cases := []struct {
+		target string
+		want   bool
+	}{
+		{"http://localhost:11434/api/chat", true},
+		{"http://127.0.0.1:8080/embed", true},
+		{"http://[::1]:3000/mcp", true},
+		{"http://host.docker.internal:11434", true},
+		{"http://ollama.lan:11434", true},
+		{"https://mcp.tools.corp.internal/sse", true},
+		{"ws://10.1.2.3:9000/ws", true},
+		{"https://mcp.example.com:8443/mcp", true},
+		{"https://mcp.example.com/mcp", false}, // port-restricted entry
+		{"https://api.openai.com/v1/chat/completions", false},
+		{"wss://gateway.discord.gg", false},
+		{"http://192.168.1.10:11434", false},
+	}
+	for _, c := range cases {
+		u, _ := url.Parse(c.target)
+		if got := OfflineAllowed(allow, u); got != c.want {
+			t.Errorf("OfflineAllowed(%q) = %v, want %v", c.target, got, c.want)
+		}
+	}
+}
+
+func TestCheckOffline(t *testing.T) {
+	clearProxyEnv(t)
+
+	if err := CheckOfflineURL("https://api.openai.com/v1"); err != nil {
+		t.Fatalf("offline disabled: unexpected error %v", err)
+	}
+
+	Configure(Settings{Offline: true, OfflineAllow: []string{"ollama.lan"}})
+	if !Offline() {
+		t.Fatal("Offline() = false after Configure")
+	}
+	if err := CheckOfflineURL("http://ollama.lan:11434"); err != nil {
+		t.Errorf("allowlisted host blocked: %v", err)
+	}
+	err := CheckOfflineURL("https://api.openai.com/v1")
+	var oe *OfflineError
+	if !errors.As(err, &oe) || oe.Host != "api.openai.com" {
+		t.Fatalf("err = %v, want *OfflineError for api.openai.com", err)
+	}
+	if !strings.Contains(err.Error(), "offline_allow") {
+		t.Errorf("error %q should point at offline_allow", err)
+	}
+}
+
+func TestOffline_TransportFailsFast(t *testing.T) {
+	clearProxyEnv(t)
+	Configure(Settings{Offline: true})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Transport(Tool("web_fetch"))}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("loopback request blocked: %v", err)
+	}
+	resp.Body.Close()
+
+	_, err = client.Get("https://example.com/")
+	var oe *OfflineError
+	if !errors.As(err, &oe) {
+		t.Fatalf("err = %v, want *OfflineError", err)
+	}
+}
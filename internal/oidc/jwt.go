@@ -0,0 +1,237 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // SHA-384/512 for RS384/RS512/ES384
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// splitJWT decodes a compact JWS into its header and claims, and returns the
+// signed part and signature for verification.
+func splitJWT(raw string) (jwtHeader, map[string]any, string, []byte, error) {
+	var header jwtHeader
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return header, nil, "", nil, errors.New("malformed jwt")
+	}
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(hb, &header) != nil {
+		return header, nil, "", nil, errors.New("malformed jwt header")
+	}
+	pb, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return header, nil, "", nil, errors.New("malformed jwt payload")
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(pb, &claims); err != nil {
+		return header, nil, "", nil, errors.New("malformed jwt payload")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return header, nil, "", nil, errors.New("malformed jwt signature")
+	}
+	return header, claims, parts[0] + "." + parts[1], sig, nil
+}
+
+// verifySignature checks an asymmetric JWS signature. "none" and HMAC
+// algorithms are rejected: ID tokens must be signed with the provider's key.
+func verifySignature(alg string, key any, signed string, sig []byte) error {
+	var h crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		h = crypto.SHA256
+	case "RS384", "ES384":
+		h = crypto.SHA384
+	case "RS512":
+		h = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported id token algorithm %q", alg)
+	}
+	hasher := h.New()
+	hasher.Write([]byte(signed))
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %q does not match RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, h, digest, sig); err != nil {
+			return errors.New("invalid id token signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(sig) != 2*size {
+			return fmt.Errorf("algorithm %q does not match EC key", alg)
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid id token signature")
+		}
+	default:
+		return errors.New("unsupported signing key type")
+	}
+	return nil
+}
+
+// jwkSet is a JSON Web Key Set as served at the provider's jwks_uri.
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKeys parses the signing keys of the set, skipping encryption keys
+// and key types it does not support.
+func (s jwkSet) publicKeys() map[string]any {
+	keys := make(map[string]any, len(s.Keys))
+	for _, k := range s.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	return keys
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err1 := base64.RawURLEncoding.DecodeString(k.N)
+		e, err2 := base64.RawURLEncoding.DecodeString(k.E)
+		if err1 != nil || err2 != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err1 := base64.RawURLEncoding.DecodeString(k.X)
+		y, err2 := base64.RawURLEncoding.DecodeString(k.Y)
+		size := (curve.Params().BitSize + 7) / 8
+		if err1 != nil || err2 != nil || len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC key")
+		}
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// TokenIssuer is the iss claim of gateway-issued tokens.
+const TokenIssuer = "goclaw"
+
+// Claims are the claims of a gateway-issued token.
+type Claims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"` // user ID (store.WithUserID)
+	Role      string `json:"role"`
+	Email     string `json:"email,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Sign issues an HS256 token for userID with the given role, valid for ttl.
+func Sign(key []byte, userID, role, email string, ttl time.Duration) (string, time.Time, error) {
+	if len(key) == 0 {
+		return "", time.Time{}, errors.New("empty signing key")
+	}
+	now := time.Now()
+	exp := now.Add(ttl)
+	header, _ := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	payload, err := json.Marshal(Claims{
+		Issuer:    TokenIssuer,
+		Subject:   userID,
+		Role:      role,
+		Email:     email,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: exp.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(hmacSHA256(key, signed)), exp, nil
+}
+
+// Verify checks a gateway-issued token's signature, issuer and expiry.
+func Verify(key []byte, token string) (*Claims, error) {
+	if len(key) == 0 {
+		return nil, errors.New("empty signing key")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header jwtHeader
+	hb, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(hb, &header) != nil || header.Alg != "HS256" {
+		return nil, errors.New("unsupported token header")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, hmacSHA256(key, parts[0]+"."+parts[1])) {
+		return nil, errors.New("invalid token signature")
+	}
+	pb, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New("malformed token payload")
+	}
+	var c Claims
+	if err := json.Unmarshal(pb, &c); err != nil {
+		return nil, errors.New("malformed token payload")
+	}
+	if c.Issuer != TokenIssuer || c.Subject == "" {
+		return nil, errors.New("token not issued by this gateway")
+	}
+	if time.Now().Unix() >= c.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	return &c, nil
+}
+
+// LooksLikeJWT reports whether s has the shape of a compact JWS, so callers
+// can skip verification for other bearer formats.
+func LooksLikeJWT(s string) bool {
+	return strings.Count(s, ".") == 2 && strings.HasPrefix(s, "eyJ")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
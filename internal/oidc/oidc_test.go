@@ -0,0 +1,194 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var b64 = base64.RawURLEncoding.EncodeToString
+
+// testIdP is a minimal identity provider serving discovery, JWKS and a token
+// endpoint that returns idToken for the code "good-code".
+type testIdP struct {
+	srv      *httptest.Server
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	idToken  string
+	verifier string // code_verifier received by the token endpoint
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+	idp := &testIdP{}
+	idp.rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	idp.ecKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.srv.URL,
+			"authorization_endpoint": idp.srv.URL + "/authorize",
+			"token_endpoint":         idp.srv.URL + "/token",
+			"jwks_uri":               idp.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub := idp.rsaKey.PublicKey
+		ecPub := idp.ecKey.PublicKey
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa1", "use": "sig", "n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes())},
+			{"kty": "EC", "kid": "ec1", "crv": "P-256", "x": b64(ecPub.X.FillBytes(make([]byte, 32))), "y": b64(ecPub.Y.FillBytes(make([]byte, 32)))},
+		}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		idp.verifier = r.PostForm.Get("code_verifier")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "id_token": idp.idToken})
+	})
+	idp.srv = httptest.NewServer(mux)
+	t.Cleanup(idp.srv.Close)
+	return idp
+}
+
+func (idp *testIdP) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	switch alg {
+	case "RS256":
+		sig, _ = rsa.SignPKCS1v15(rand.Reader, idp.rsaKey, crypto.SHA256, digest[:])
+	case "ES256":
+		r, s, _ := ecdsa.Sign(rand.Reader, idp.ecKey, digest[:])
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(sig)
+}
+
+func (idp *testIdP) claims(nonce string) map[string]any {
+	return map[string]any{
+		"iss":   idp.srv.URL,
+		"aud":   "goclaw-client",
+		"sub":   "okta|alice",
+		"email": "alice@example.com",
+		"nonce": nonce,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(5 * time.Minute).Unix(),
+	}
+}
+
+func TestProviderCodeFlow(t *testing.T) {
+	idp := newTestIdP(t)
+	p := NewProvider(Config{Issuer: idp.srv.URL + "/", ClientID: "goclaw-client", ClientSecret: "s3cret"})
+	ctx := context.Background()
+
+	verifier := RandomString()
+	authURL, err := p.AuthCodeURL(ctx, "https://gw.example.com/v1/auth/oidc/callback", "st", "n1", verifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(authURL)
+	q := u.Query()
+	if u.Path != "/authorize" || q.Get("code_challenge") != Challenge(verifier) || q.Get("code_challenge_method") != "S256" ||
+		q.Get("scope") != "openid profile email" || q.Get("state") != "st" || q.Get("nonce") != "n1" {
+		t.Errorf("auth URL = %s", authURL)
+	}
+
+	idp.idToken = idp.sign(t, "RS256", "rsa1", idp.claims("n1"))
+	raw, err := p.Exchange(ctx, "good-code", "https://gw.example.com/v1/auth/oidc/callback", verifier)
+	if err != nil || raw != idp.idToken || idp.verifier != verifier {
+		t.Fatalf("Exchange = %q, %v (verifier %q)", raw, err, idp.verifier)
+	}
+	if _, err := p.Exchange(ctx, "bad-code", "x", verifier); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("bad code: %v", err)
+	}
+
+	id, err := p.VerifyIDToken(ctx, raw, "n1")
+	if err != nil || id.UserID != "okta|alice" || id.Email != "alice@example.com" {
+		t.Fatalf("VerifyIDToken = %+v, %v", id, err)
+	}
+	if _, err := p.VerifyIDToken(ctx, idp.sign(t, "ES256", "ec1", idp.claims("")), ""); err != nil {
+		t.Errorf("ES256: %v", err)
+	}
+}
+
+func TestVerifyIDTokenRejects(t *testing.T) {
+	idp := newTestIdP(t)
+	p := NewProvider(Config{Issuer: idp.srv.URL, ClientID: "goclaw-client", UserClaim: "email"})
+	ctx := context.Background()
+
+	if id, err := p.VerifyIDToken(ctx, idp.sign(t, "RS256", "rsa1", idp.claims("n")), "n"); err != nil || id.UserID != "alice@example.com" {
+		t.Fatalf("user claim: %+v, %v", id, err)
+	}
+
+	cases := map[string]func(c map[string]any){
+		"audience": func(c map[string]any) { c["aud"] = []any{"other"} },
+		"issuer":   func(c map[string]any) { c["iss"] = "https://evil.example.com" },
+		"expired":  func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"nonce":    func(c map[string]any) { c["nonce"] = "replayed" },
+		"no user":  func(c map[string]any) { delete(c, "email") },
+	}
+	for name, mutate := range cases {
+		c := idp.claims("n")
+		mutate(c)
+		if _, err := p.VerifyIDToken(ctx, idp.sign(t, "RS256", "rsa1", c), "n"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	good := idp.sign(t, "RS256", "rsa1", idp.claims("n"))
+	parts := strings.Split(good, ".")
+	tampered := parts[0] + "." + b64([]byte(`{"sub":"mallory"}`)) + "." + parts[2]
+	if _, err := p.VerifyIDToken(ctx, tampered, ""); err == nil {
+		t.Error("tampered payload accepted")
+	}
+	if _, err := p.VerifyIDToken(ctx, idp.sign(t, "RS256", "unknown", idp.claims("n")), "n"); err == nil {
+		t.Error("unknown kid accepted")
+	}
+	none := b64([]byte(`{"alg":"none","kid":"rsa1"}`)) + "." + parts[1] + "."
+	if _, err := p.VerifyIDToken(ctx, none, ""); err == nil {
+		t.Error("alg none accepted")
+	}
+}
+
+func TestGatewayToken(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	tok, exp, err := Sign(key, "alice", "operator", "alice@example.com", time.Hour)
+	if err != nil || !LooksLikeJWT(tok) || time.Until(exp) < 59*time.Minute {
+		t.Fatalf("Sign = %q, %v, %v", tok, exp, err)
+	}
+	c, err := Verify(key, tok)
+	if err != nil || c.Subject != "alice" || c.Role != "operator" || c.Issuer != TokenIssuer {
+		t.Fatalf("Verify = %+v, %v", c, err)
+	}
+	if _, err := Verify([]byte("other-key"), tok); err == nil {
+		t.Error("wrong key accepted")
+	}
+	expired, _, _ := Sign(key, "alice", "operator", "", -time.Second)
+	if _, err := Verify(key, expired); err == nil {
+		t.Error("expired token accepted")
+	}
+	if LooksLikeJWT("goclaw_abcdef") {
+		t.Error("API key looks like a JWT")
+	}
+}
@@ -2,7 +2,7 @@
 //
 // GoClaw uses a 5-layer permission system:
 //
-//  1. Gateway Auth (token/password, scopes: admin/read/write/approvals/pairing)
+//  1. Gateway Auth (token/password, scopes: admin/read/write/approvals/pairing/chat/observe)
 //  2. Global Tool Policy (tools.allow[], tools.deny[], tools.profile)
 //  3. Per-Agent Policy (agents.list[].tools.allow/deny)
 //  4. Per-Channel/Group Policy (channels.*.groups.*.tools.policy)
@@ -27,6 +27,7 @@ const (
 	RoleAdmin    Role = "admin"    // Full access to all methods
 	RoleOperator Role = "operator" // Read + write access (no admin operations)
 	RoleViewer   Role = "viewer"   // Read-only access
+	RoleMember   Role = "member"   // Chat and the caller's own data only (HTTP API)
 
 	// RoleNone is a sentinel returned by MethodRole for methods that have no
 	// explicit classification. The router treats it as deny-for-everyone so
@@ -44,6 +45,8 @@ const (
 	ScopeApprovals Scope = "operator.approvals"
 	ScopePairing   Scope = "operator.pairing"
 	ScopeProvision Scope = "operator.provision"
+	ScopeChat      Scope = "operator.chat"    // chat.* plus the agent lookups a chat client needs
+	ScopeObserve   Scope = "operator.observe" // usage, channel/cron status and run logs; no message content
 )
 
 // AllScopes is the set of all valid API key scopes.
@@ -54,6 +57,8 @@ var AllScopes = map[Scope]bool{
 	ScopeApprovals: true,
 	ScopePairing:   true,
 	ScopeProvision: true,
+	ScopeChat:      true,
+	ScopeObserve:   true,
 }
 
 // ValidScope reports whether s is a recognised API key scope.
@@ -131,7 +136,8 @@ func RoleFromScopes(scopes []Scope) Role {
 	}
 	if slices.Contains(scopes, ScopeWrite) ||
 		slices.Contains(scopes, ScopeApprovals) ||
-		slices.Contains(scopes, ScopePairing) {
+		slices.Contains(scopes, ScopePairing) ||
+		slices.Contains(scopes, ScopeChat) {
 		return RoleOperator
 	}
 	if slices.Contains(scopes, ScopeRead) {
@@ -185,8 +191,12 @@ func isPublicMethod(method string) bool {
 	return false
 }
 
-// MethodScopes returns the scopes required for a method.
+// MethodScopes returns the scopes that grant access to a method (any one
+// suffices). Public methods return nil.
 func MethodScopes(method string) []Scope {
+	if isPublicMethod(method) {
+		return nil
+	}
 	if isAdminMethod(method) {
 		return []Scope{ScopeAdmin}
 	}
@@ -196,10 +206,68 @@ func MethodScopes(method string) []Scope {
 	if strings.HasPrefix(method, "pairing.") || strings.HasPrefix(method, "device.pair") {
 		return []Scope{ScopePairing, ScopeAdmin}
 	}
+	scopes := []Scope{ScopeRead, ScopeWrite, ScopeAdmin}
 	if isWriteMethod(method) {
-		return []Scope{ScopeWrite, ScopeAdmin}
+		scopes = []Scope{ScopeWrite, ScopeAdmin}
+	}
+	if isChatMethod(method) {
+		scopes = append(scopes, ScopeChat)
+	}
+	if isObserveMethod(method) {
+		scopes = append(scopes, ScopeObserve)
+	}
+	return scopes
+}
+
+// NarrowScopes reports whether scopes only hold the method-specific scopes
+// (chat/observe). Such credentials are meant for the WebSocket RPC surface
+// and are refused by the general HTTP API.
+func NarrowScopes(scopes []Scope) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+	for _, s := range scopes {
+		if s != ScopeChat && s != ScopeObserve {
+			return false
+		}
 	}
-	return []Scope{ScopeRead, ScopeWrite, ScopeAdmin}
+	return true
+}
+
+// isChatMethod lists the methods granted by ScopeChat: sending and reading
+// chat plus picking an agent to talk to.
+func isChatMethod(method string) bool {
+	switch method {
+	case protocol.MethodChatSend,
+		protocol.MethodChatAbort,
+		protocol.MethodChatInject,
+		protocol.MethodChatHistory,
+		protocol.MethodChatSessionStatus,
+		protocol.MethodAgent,
+		protocol.MethodAgentWait,
+		protocol.MethodAgentIdentityGet,
+		protocol.MethodAgentsList:
+		return true
+	}
+	return false
+}
+
+// isObserveMethod lists the methods granted by ScopeObserve: read-only
+// operational data for dashboards, without session or message content.
+func isObserveMethod(method string) bool {
+	switch method {
+	case protocol.MethodUsageGet,
+		protocol.MethodUsageSummary,
+		protocol.MethodQuotaUsage,
+		protocol.MethodChannelsStatus,
+		protocol.MethodCronStatus,
+		protocol.MethodCronRuns,
+		protocol.MethodHeartbeatLogs,
+		protocol.MethodTTSStatus,
+		protocol.MethodAgentsList:
+		return true
+	}
+	return false
 }
 
 func isAdminMethod(method string) bool {
@@ -254,6 +322,7 @@ func isAdminMethod(method string) bool {
 		protocol.MethodAPIKeysList,
 		protocol.MethodAPIKeysCreate,
 		protocol.MethodAPIKeysRevoke,
+		protocol.MethodAPIKeysRotate,
 
 		// Skills (can rewrite agent behavior).
 		protocol.MethodSkillsUpdate,
@@ -263,6 +332,7 @@ func isAdminMethod(method string) bool {
 		protocol.MethodHeartbeatToggle,
 		protocol.MethodHeartbeatTest,
 		protocol.MethodHeartbeatChecklistSet,
+		protocol.MethodHeartbeatSnooze,
 
 		// Live server logs — data exfiltration risk (closes CVE #866 step 3).
 		protocol.MethodLogsTail,
@@ -293,6 +363,9 @@ func isWriteMethod(method string) bool {
 		protocol.MethodSessionsReset,
 		protocol.MethodSessionsPatch,
 		protocol.MethodSessionsCompact,
+		protocol.MethodSessionsArchive,
+		protocol.MethodSessionsImport,
+		protocol.MethodSessionsRewind,
 		protocol.MethodCronCreate,
 		protocol.MethodCronUpdate,
 		protocol.MethodCronDelete,
@@ -347,6 +420,8 @@ func isReadMethod(method string) bool {
 		// Sessions read
 		protocol.MethodSessionsList,
 		protocol.MethodSessionsPreview,
+		protocol.MethodSessionsExport,
+		protocol.MethodSessionsCheckpoints,
 
 		// Skills read
 		protocol.MethodSkillsList,
@@ -427,12 +502,14 @@ func HasMinRole(role, required Role) bool {
 func roleLevel(r Role) int {
 	switch r {
 	case RoleOwner:
-		return 4
+		return 5
 	case RoleAdmin:
-		return 3
+		return 4
 	case RoleOperator:
-		return 2
+		return 3
 	case RoleViewer:
+		return 2
+	case RoleMember:
 		return 1
 	default:
 		return 0
@@ -15,15 +15,16 @@ import (
 // --- Role hierarchy ---
 
 func TestRoleLevel_Ordering(t *testing.T) {
-	// Owner > Admin > Operator > Viewer > unknown
+	// Owner > Admin > Operator > Viewer > Member > unknown
 	levels := []struct {
 		role  Role
 		level int
 	}{
-		{RoleOwner, 4},
-		{RoleAdmin, 3},
-		{RoleOperator, 2},
-		{RoleViewer, 1},
+		{RoleOwner, 5},
+		{RoleAdmin, 4},
+		{RoleOperator, 3},
+		{RoleViewer, 2},
+		{RoleMember, 1},
 		{Role("unknown"), 0},
 		{Role(""), 0},
 	}
@@ -51,6 +52,7 @@ func TestHasMinRole(t *testing.T) {
 		{"operator_meets_viewer", RoleOperator, RoleViewer, true},
 		{"admin_meets_viewer", RoleAdmin, RoleViewer, true},
 		{"viewer_meets_viewer", RoleViewer, RoleViewer, true},
+		{"member_fails_viewer", RoleMember, RoleViewer, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,6 +82,8 @@ func TestRoleFromScopes(t *testing.T) {
 		{"nil_scopes", nil, RoleViewer},
 		{"provision_only_is_viewer", []Scope{ScopeProvision}, RoleViewer},
 		{"read_and_write", []Scope{ScopeRead, ScopeWrite}, RoleOperator},
+		{"chat_is_operator", []Scope{ScopeChat}, RoleOperator},
+		{"observe_is_viewer", []Scope{ScopeObserve}, RoleViewer},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -253,6 +257,22 @@ func TestCanAccessWithScopes(t *testing.T) {
 		{"write_scope_for_read_method", []Scope{ScopeWrite}, "sessions.list", true},
 		{"admin_scope_for_read_method", []Scope{ScopeAdmin}, "sessions.list", true},
 
+		// Chat scope: chat methods and agent lookup only
+		{"chat_scope_for_chat_send", []Scope{ScopeChat}, protocol.MethodChatSend, true},
+		{"chat_scope_for_chat_history", []Scope{ScopeChat}, protocol.MethodChatHistory, true},
+		{"chat_scope_for_agents_list", []Scope{ScopeChat}, protocol.MethodAgentsList, true},
+		{"chat_scope_for_sessions_delete", []Scope{ScopeChat}, protocol.MethodSessionsDelete, false},
+		{"chat_scope_for_cron_list", []Scope{ScopeChat}, protocol.MethodCronList, false},
+
+		// Observe scope: operational reads, no message content
+		{"observe_scope_for_usage", []Scope{ScopeObserve}, protocol.MethodUsageSummary, true},
+		{"observe_scope_for_channels_status", []Scope{ScopeObserve}, protocol.MethodChannelsStatus, true},
+		{"observe_scope_for_chat_history", []Scope{ScopeObserve}, protocol.MethodChatHistory, false},
+		{"observe_scope_for_chat_send", []Scope{ScopeObserve}, protocol.MethodChatSend, false},
+
+		// Public methods need no scope
+		{"chat_scope_for_status", []Scope{ScopeChat}, protocol.MethodStatus, true},
+
 		// Empty scopes
 		{"empty_scopes", []Scope{}, protocol.MethodAgentsCreate, false},
 	}
@@ -421,3 +441,21 @@ func TestMethodScopes_ApprovalMethod(t *testing.T) {
 		t.Fatalf("approvals method should require [approvals, admin], got %v", scopes)
 	}
 }
+
+func TestNarrowScopes(t *testing.T) {
+	tests := []struct {
+		scopes []Scope
+		want   bool
+	}{
+		{nil, false},
+		{[]Scope{ScopeChat}, true},
+		{[]Scope{ScopeChat, ScopeObserve}, true},
+		{[]Scope{ScopeChat, ScopeRead}, false},
+		{[]Scope{ScopeAdmin}, false},
+	}
+	for _, tt := range tests {
+		if got := NarrowScopes(tt.scopes); got != tt.want {
+			t.Errorf("NarrowScopes(%v) = %v, want %v", tt.scopes, got, tt.want)
+		}
+	}
+}
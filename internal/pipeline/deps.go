@@ -91,6 +91,15 @@ type PipelineDeps struct {
 	ProcessToolResult func(ctx context.Context, state *RunState, tc providers.ToolCall, rawMsg providers.Message, rawData any) []providers.Message
 	// CheckReadOnly checks read-only streak. Returns warning message (if any) and whether to break.
 	CheckReadOnly func(state *RunState) (*providers.Message, bool)
+	// RecordCheckpoint snapshots the session before the iteration's tools run
+	// so /rewind can return to it. ToolStage flushes pending messages first,
+	// so the session store holds the history up to the tool-call message.
+	// nil = checkpoints disabled.
+	RecordCheckpoint func(ctx context.Context, state *RunState, toolCalls []providers.ToolCall)
+	// SnapshotFiles saves the workspace files the iteration's tools are about
+	// to write so `goclaw workspace rollback` can restore them.
+	// nil = snapshots disabled.
+	SnapshotFiles func(ctx context.Context, state *RunState, toolCalls []providers.ToolCall)
 
 	// Observe callbacks (ObserveStage)
 	DrainInjectCh func() []providers.Message
@@ -143,6 +152,10 @@ type PipelineConfig struct {
 	// Recommended: 5-10% of contextWindow for reasoning-heavy models.
 	ReserveTokens int
 
+	// ResponseFormat, when set, requires the final answer to be JSON matching
+	// its schema. ThinkStage asks the model to retry invalid answers.
+	ResponseFormat *providers.ResponseFormat
+
 	// V3 memory/retrieval flags removed — always true at runtime.
 	// Memory flush runs if callback != nil; auto-inject runs if AutoInject != nil.
 }
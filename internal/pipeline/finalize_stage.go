@@ -34,7 +34,8 @@ func (s *FinalizeStage) Execute(ctx context.Context, state *RunState) error {
 	}
 
 	// 1b. Skill evolution postscript (matching v2 loop_finalize.go:52-57).
-	if s.deps.SkillPostscript != nil && state.Observe.FinalContent != "" {
+	// Skipped for structured output: anything appended would break the JSON.
+	if s.deps.SkillPostscript != nil && state.Observe.FinalContent != "" && s.deps.Config.ResponseFormat == nil {
 		state.Observe.FinalContent = s.deps.SkillPostscript(ctx, state.Observe.FinalContent, state.Tool.TotalToolCalls)
 	}
 
@@ -155,6 +156,12 @@ func (s *FinalizeStage) Execute(ctx context.Context, state *RunState) error {
 		state.Observe.FinalContent = ""
 	}
 
+	// 10b. Quota notice: appended after the session flush so the warning reaches
+	// the user without entering the model's history.
+	if state.Input.QuotaNotice != "" && state.Observe.FinalContent != "" {
+		state.Observe.FinalContent += "\n\n" + state.Input.QuotaNotice
+	}
+
 	// 11. Hook: async EventStop — fire and forget.
 	// run.completed event is emitted by loop_run.go after Pipeline.Run() returns,
 	// with full tracing context. No duplicate emission here.
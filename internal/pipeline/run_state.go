@@ -99,6 +99,7 @@ type RunInput struct {
 	ModelOverride     string
 	HideInput         bool
 	ContentSuffix     string
+	QuotaNotice       string
 	LeaderAgentID     string
 	WorkspaceChannel  string
 	WorkspaceChatID   string
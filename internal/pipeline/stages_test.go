@@ -78,6 +78,48 @@ func TestThinkStage_NoToolCalls_ReturnsBreakLoop(t *testing.T) {
 	}
 }
 
+func TestThinkStage_FinalAnswer_SteersWithInjectedMessages(t *testing.T) {
+	t.Parallel()
+	var blockReplies []string
+	injected := []providers.Message{{Role: "user", Content: "also add tests"}}
+	deps := &PipelineDeps{
+		Config: PipelineConfig{MaxIterations: 10, MaxTokens: 1000},
+		CallLLM: func(_ context.Context, _ *RunState, _ providers.ChatRequest) (*providers.ChatResponse, error) {
+			return &providers.ChatResponse{Content: "done", FinishReason: "stop"}, nil
+		},
+		DrainInjectCh: func() []providers.Message {
+			msgs := injected
+			injected = nil
+			return msgs
+		},
+		EmitBlockReply: func(content string) { blockReplies = append(blockReplies, content) },
+	}
+	stage := NewThinkStage(deps)
+	state := defaultState()
+
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if stage.Result() != Continue {
+		t.Fatalf("Result() = %v, want Continue while injected messages wait", stage.Result())
+	}
+	pending := state.Messages.Pending()
+	if len(pending) != 2 || pending[0].Role != "assistant" || pending[0].Content != "done" || pending[1].Content != "also add tests" {
+		t.Errorf("pending = %+v, want superseded answer then injected message", pending)
+	}
+	if len(blockReplies) != 1 || blockReplies[0] != "done" {
+		t.Errorf("block replies = %v, want superseded answer delivered", blockReplies)
+	}
+
+	// Nothing injected any more: the next final answer ends the run.
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if stage.Result() != BreakLoop {
+		t.Errorf("Result() = %v, want BreakLoop", stage.Result())
+	}
+}
+
 func TestThinkStage_WithToolCalls_ReturnsContinue(t *testing.T) {
 	t.Parallel()
 	deps := &PipelineDeps{
@@ -207,7 +249,7 @@ func TestThinkStage_UsageAccumulation(t *testing.T) {
 			return &providers.ChatResponse{
 				Content:      "hello",
 				FinishReason: "stop",
-				Usage:        &providers.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+				Usage:        &providers.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15, CacheCreationTokens: 3, CacheReadTokens: 7},
 			}, nil
 		},
 	}
@@ -227,6 +269,9 @@ func TestThinkStage_UsageAccumulation(t *testing.T) {
 	if state.Think.TotalUsage.TotalTokens != 30 {
 		t.Errorf("TotalTokens = %d, want 30", state.Think.TotalUsage.TotalTokens)
 	}
+	if u := state.Think.TotalUsage; u.CacheCreationTokens != 6 || u.CacheReadTokens != 14 {
+		t.Errorf("cache tokens = %d created / %d read, want 6 / 14", u.CacheCreationTokens, u.CacheReadTokens)
+	}
 }
 
 func TestThinkStage_Nudge70_FiresOnce(t *testing.T) {
@@ -906,6 +951,44 @@ func TestToolStage_MultipleTools_Sequential_MessagesInOrder(t *testing.T) {
 	}
 }
 
+// Images loaded by a tool (read_image attach mode) ride on a user message,
+// which must not split the tool results of one assistant turn.
+func TestToolStage_ImageMessagesFollowAllToolResults(t *testing.T) {
+	t.Parallel()
+	img := providers.Message{Role: "user", Content: "[images]", Images: []providers.ImageContent{{MimeType: "image/png", Data: "AA=="}}}
+	deps := &PipelineDeps{
+		ExecuteToolCall: func(_ context.Context, _ *RunState, _ providers.ToolCall) ([]providers.Message, error) {
+			return nil, nil
+		},
+		ExecuteToolRaw: func(_ context.Context, tc providers.ToolCall) (providers.Message, any, error) {
+			return providers.Message{Role: "tool", Content: tc.Name, ToolCallID: tc.ID}, nil, nil
+		},
+		ProcessToolResult: func(_ context.Context, _ *RunState, tc providers.ToolCall, rawMsg providers.Message, _ any) []providers.Message {
+			if tc.Name == "read_image" {
+				return []providers.Message{rawMsg, img}
+			}
+			return []providers.Message{rawMsg}
+		},
+	}
+	stage := NewToolStage(deps)
+	state := defaultState()
+	state.Think.LastResponse = &providers.ChatResponse{
+		ToolCalls: []providers.ToolCall{{ID: "1", Name: "read_image"}, {ID: "2", Name: "read_file"}},
+	}
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	pending := state.Messages.Pending()
+	var roles []string
+	for _, m := range pending {
+		roles = append(roles, m.Role)
+	}
+	if strings.Join(roles, ",") != "tool,tool,user" || len(pending[2].Images) != 1 {
+		t.Errorf("pending roles = %v", roles)
+	}
+}
+
 // Regression: v3 parallel path invokes ExecuteToolRaw + ProcessToolResult
 // for every tool call. If this breaks, the `tool.call` WS event emitted
 // inside makeExecuteToolRaw (loop_pipeline_tool_callbacks.go) stops firing
@@ -1082,6 +1165,42 @@ func TestToolStage_NilLastResponse_NoOp(t *testing.T) {
 	}
 }
 
+func TestToolStage_RecordCheckpoint_FlushesBeforeTools(t *testing.T) {
+	t.Parallel()
+	var flushed []providers.Message
+	var recordedAfter int
+	deps := &PipelineDeps{
+		FlushMessages: func(_ context.Context, _ string, msgs []providers.Message) error {
+			flushed = append(flushed, msgs...)
+			return nil
+		},
+		RecordCheckpoint: func(_ context.Context, _ *RunState, calls []providers.ToolCall) {
+			recordedAfter = len(flushed)
+			if len(calls) != 1 || calls[0].Name != "write_file" {
+				t.Errorf("calls = %+v", calls)
+			}
+		},
+		ExecuteToolCall: func(_ context.Context, _ *RunState, tc providers.ToolCall) ([]providers.Message, error) {
+			return []providers.Message{{Role: "tool", Content: "ok", ToolCallID: tc.ID}}, nil
+		},
+	}
+	stage := NewToolStage(deps)
+	state := defaultState()
+	call := providers.ToolCall{ID: "1", Name: "write_file"}
+	state.Messages.AppendPending(providers.Message{Role: "assistant", ToolCalls: []providers.ToolCall{call}})
+	state.Think.LastResponse = &providers.ChatResponse{ToolCalls: []providers.ToolCall{call}}
+
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if recordedAfter != 1 || state.Compact.CheckpointFlushedMsgs != 1 {
+		t.Errorf("flushed before checkpoint = %d, CheckpointFlushedMsgs = %d", recordedAfter, state.Compact.CheckpointFlushedMsgs)
+	}
+	if p := state.Messages.Pending(); len(p) != 1 || p[0].Role != "tool" {
+		t.Errorf("pending after tools = %+v", p)
+	}
+}
+
 // --- ObserveStage tests ---
 
 func TestObserveStage_DrainInjectCh_AddsToPending(t *testing.T) {
@@ -1538,6 +1657,43 @@ func TestFinalizeStage_SanitizesContent(t *testing.T) {
 	}
 }
 
+func TestFinalizeStage_QuotaNoticeNotPersisted(t *testing.T) {
+	t.Parallel()
+	var flushed []providers.Message
+	deps := &PipelineDeps{
+		IsSilentReply: func(content string) bool { return content == "NO_REPLY" },
+		FlushMessages: func(_ context.Context, _ string, msgs []providers.Message) error {
+			flushed = append(flushed, msgs...)
+			return nil
+		},
+	}
+	stage := NewFinalizeStage(deps)
+	state := defaultState()
+	state.Input.QuotaNotice = "You have used 40 of 50 daily requests."
+	state.Observe.FinalContent = "Here you go."
+
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if want := "Here you go.\n\nYou have used 40 of 50 daily requests."; state.Observe.FinalContent != want {
+		t.Errorf("FinalContent = %q, want %q", state.Observe.FinalContent, want)
+	}
+	if len(flushed) != 1 || flushed[0].Content != "Here you go." {
+		t.Errorf("flushed = %+v, want assistant message without the notice", flushed)
+	}
+
+	// Silent replies stay silent.
+	state = defaultState()
+	state.Input.QuotaNotice = "notice"
+	state.Observe.FinalContent = "NO_REPLY"
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if state.Observe.FinalContent != "" {
+		t.Errorf("FinalContent = %q, want empty for silent reply", state.Observe.FinalContent)
+	}
+}
+
 func TestFinalizeStage_DeduplicatesMediaByPath(t *testing.T) {
 	t.Parallel()
 	deps := &PipelineDeps{}
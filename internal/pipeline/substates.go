@@ -33,6 +33,7 @@ type ThinkState struct {
 	TotalUsage      providers.Usage
 	TruncRetries    int  // consecutive truncation retries (max 3)
 	OverflowRetries int  // context overflow compact+retry attempts (max 1)
+	FormatRetries   int  // final answers rejected by ResponseFormat (max 2)
 	StreamingActive bool // true during active stream
 
 	// Tools is populated by ContextStage (iteration=0) for overhead calculation.
@@ -11,6 +11,10 @@ import (
 
 const maxTruncRetries = 3
 
+// maxFormatRetries caps how often an answer that fails the run's
+// ResponseFormat is sent back to the model before the run errors.
+const maxFormatRetries = 2
+
 // ThinkStage runs per iteration. Calls LLM, handles truncation retries,
 // accumulates usage, returns BreakLoop when response has no tool calls.
 type ThinkStage struct {
@@ -52,6 +56,9 @@ func (s *ThinkStage) Execute(ctx context.Context, state *RunState) error {
 			providers.OptMaxTokens: s.deps.Config.MaxTokens,
 		},
 	}
+	if rf := s.deps.Config.ResponseFormat; rf != nil {
+		req.Options[providers.OptResponseFormat] = rf
+	}
 
 	// 4. Call LLM (stream or sync — delegated to callback)
 	if s.deps.CallLLM == nil {
@@ -85,12 +92,15 @@ func (s *ThinkStage) Execute(ctx context.Context, state *RunState) error {
 	}
 	state.Think.LastResponse = resp
 
-	// 5. Accumulate usage (including ThinkingTokens for reasoning models)
+	// 5. Accumulate usage (including ThinkingTokens for reasoning models and
+	// prompt-cache reads/writes, surfaced in the run result)
 	if resp.Usage != nil {
 		state.Think.TotalUsage.PromptTokens += resp.Usage.PromptTokens
 		state.Think.TotalUsage.CompletionTokens += resp.Usage.CompletionTokens
 		state.Think.TotalUsage.TotalTokens += resp.Usage.TotalTokens
 		state.Think.TotalUsage.ThinkingTokens += resp.Usage.ThinkingTokens
+		state.Think.TotalUsage.CacheCreationTokens += resp.Usage.CacheCreationTokens
+		state.Think.TotalUsage.CacheReadTokens += resp.Usage.CacheReadTokens
 	}
 
 	// 6. Handle truncation: retry when tool call args are truncated or malformed.
@@ -130,6 +140,12 @@ func (s *ThinkStage) Execute(ctx context.Context, state *RunState) error {
 	// message with sanitization + MediaRefs, so skip AppendPending here to avoid
 	// a duplicate. Matches v2 behavior where loop breaks before appending.
 	if len(resp.ToolCalls) == 0 {
+		if retry, err := s.checkResponseFormat(state, resp); err != nil || retry {
+			return err
+		}
+		if s.steerFinalAnswer(state, resp) {
+			return nil
+		}
 		s.result = BreakLoop
 		return nil
 	}
@@ -154,6 +170,61 @@ func (s *ThinkStage) Execute(ctx context.Context, state *RunState) error {
 	return nil
 }
 
+// steerFinalAnswer keeps the run going when user messages were injected
+// while the final answer was generated: the answer stays in the history as an
+// intermediate reply and the model answers again with the new messages. Not
+// on the last iteration: messages left in InjectCh are not taken in, so the
+// caller can run them on their own.
+func (s *ThinkStage) steerFinalAnswer(state *RunState, resp *providers.ChatResponse) bool {
+	if s.deps.DrainInjectCh == nil {
+		return false
+	}
+	if s.deps.Config.MaxIterations > 0 && state.Iteration+1 >= s.deps.Config.MaxIterations {
+		return false
+	}
+	injected := s.deps.DrainInjectCh()
+	if len(injected) == 0 {
+		return false
+	}
+	slog.Info("steering: continuing run with injected messages", "run_id", state.RunID, "messages", len(injected))
+	state.Messages.AppendPending(providers.Message{Role: "assistant", Content: resp.Content, Thinking: resp.Thinking})
+	for _, msg := range injected {
+		state.Messages.AppendPending(msg)
+	}
+	if resp.Content != "" && s.deps.EmitBlockReply != nil {
+		s.deps.EmitBlockReply(resp.Content)
+	}
+	return true
+}
+
+// checkResponseFormat validates a final answer against the run's
+// ResponseFormat. A valid answer is normalized in place (code fences
+// stripped); an invalid one is sent back with the violation so the model can
+// correct it (retry=true). Fails the run once retries or iterations run out.
+func (s *ThinkStage) checkResponseFormat(state *RunState, resp *providers.ChatResponse) (retry bool, err error) {
+	rf := s.deps.Config.ResponseFormat
+	if rf == nil {
+		return false, nil
+	}
+	content, checkErr := rf.Check(resp.Content)
+	if checkErr == nil {
+		resp.Content = content
+		return false, nil
+	}
+	lastIteration := s.deps.Config.MaxIterations > 0 && state.Iteration+1 >= s.deps.Config.MaxIterations
+	if state.Think.FormatRetries >= maxFormatRetries || lastIteration {
+		return false, fmt.Errorf("structured output: final answer does not match response format: %w", checkErr)
+	}
+	state.Think.FormatRetries++
+	slog.Info("structured output invalid, retrying", "run_id", state.RunID, "attempt", state.Think.FormatRetries, "error", checkErr)
+	state.Messages.AppendPending(providers.Message{Role: "assistant", Content: resp.Content})
+	state.Messages.AppendPending(providers.Message{
+		Role:    "user",
+		Content: "[System] Your answer does not match the required response format: " + checkErr.Error() + ". Reply again with only the corrected JSON.",
+	})
+	return true, nil
+}
+
 // maybeInjectNudge injects iteration budget warnings at 70% and 90%.
 func (s *ThinkStage) maybeInjectNudge(state *RunState) {
 	maxIter := s.deps.Config.MaxIterations
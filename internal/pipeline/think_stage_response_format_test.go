@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+func responseFormatDeps(answers ...string) (*PipelineDeps, *[]providers.ChatRequest) {
+	var reqs []providers.ChatRequest
+	deps := &PipelineDeps{
+		Config: PipelineConfig{
+			MaxIterations: 10,
+			MaxTokens:     1000,
+			ResponseFormat: &providers.ResponseFormat{
+				Name:   "answer",
+				Schema: json.RawMessage(`{"type":"object","properties":{"n":{"type":"integer"}},"required":["n"]}`),
+			},
+		},
+		CallLLM: func(_ context.Context, _ *RunState, req providers.ChatRequest) (*providers.ChatResponse, error) {
+			content := answers[len(reqs)]
+			reqs = append(reqs, req)
+			return &providers.ChatResponse{Content: content, FinishReason: "stop"}, nil
+		},
+	}
+	return deps, &reqs
+}
+
+func TestThinkStage_ResponseFormat_ValidAnswerBreaksLoop(t *testing.T) {
+	deps, reqs := responseFormatDeps("```json\n{\"n\": 3}\n```")
+	stage := NewThinkStage(deps)
+	state := defaultState()
+
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if stage.Result() != BreakLoop {
+		t.Errorf("Result() = %v, want BreakLoop", stage.Result())
+	}
+	if got := state.Think.LastResponse.Content; got != `{"n": 3}` {
+		t.Errorf("content = %q, want fence stripped", got)
+	}
+	if (*reqs)[0].Options[providers.OptResponseFormat] != deps.Config.ResponseFormat {
+		t.Error("response format not passed to the provider")
+	}
+}
+
+func TestThinkStage_ResponseFormat_InvalidAnswerRetries(t *testing.T) {
+	deps, _ := responseFormatDeps(`{"n": "three"}`, `{"n": 3}`)
+	stage := NewThinkStage(deps)
+	state := defaultState()
+
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+	if stage.Result() != Continue || state.Think.FormatRetries != 1 {
+		t.Fatalf("Result() = %v, FormatRetries = %d; want Continue, 1", stage.Result(), state.Think.FormatRetries)
+	}
+	pending := state.Messages.Pending()
+	if len(pending) != 2 || pending[1].Role != "user" || !strings.Contains(pending[1].Content, "$.n: expected integer, got string") {
+		t.Fatalf("pending = %+v, want assistant answer + correction hint", pending)
+	}
+
+	state.Iteration++
+	if err := stage.Execute(context.Background(), state); err != nil {
+		t.Fatalf("second Execute() error: %v", err)
+	}
+	if stage.Result() != BreakLoop {
+		t.Errorf("Result() = %v after corrected answer, want BreakLoop", stage.Result())
+	}
+}
+
+func TestThinkStage_ResponseFormat_FailsAfterRetries(t *testing.T) {
+	deps, _ := responseFormatDeps("nope", "still nope", "no")
+	stage := NewThinkStage(deps)
+	state := defaultState()
+
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		state.Iteration = i
+		err = stage.Execute(context.Background(), state)
+	}
+	if err == nil || !strings.Contains(err.Error(), "structured output") {
+		t.Fatalf("err = %v, want structured output failure after %d retries", err, maxFormatRetries)
+	}
+}
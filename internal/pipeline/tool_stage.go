@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/google/uuid"
@@ -26,6 +27,23 @@ func NewToolStage(deps *PipelineDeps) *ToolStage {
 func (s *ToolStage) Name() string        { return "tool" }
 func (s *ToolStage) Result() StageResult { return s.result }
 
+// recordCheckpoint flushes pending messages (the assistant tool-call message
+// included) to the session store and records a rewind checkpoint.
+func (s *ToolStage) recordCheckpoint(ctx context.Context, state *RunState, toolCalls []providers.ToolCall) {
+	if s.deps.RecordCheckpoint == nil || s.deps.FlushMessages == nil {
+		return
+	}
+	if pending := state.Messages.FlushPending(); len(pending) > 0 {
+		if err := s.deps.FlushMessages(ctx, state.Input.SessionKey, pending); err != nil {
+			// Non-fatal, as in CheckpointStage. The store is behind, so no checkpoint.
+			slog.Warn("checkpoint flush failed", "err", err, "iteration", state.Iteration)
+			return
+		}
+		state.Compact.CheckpointFlushedMsgs += len(pending)
+	}
+	s.deps.RecordCheckpoint(ctx, state, toolCalls)
+}
+
 // Execute extracts tool calls, dispatches them, checks exit conditions.
 func (s *ToolStage) Execute(ctx context.Context, state *RunState) error {
 	s.result = Continue
@@ -39,6 +57,10 @@ func (s *ToolStage) Execute(ctx context.Context, state *RunState) error {
 	if s.deps.ExecuteToolCall == nil {
 		return fmt.Errorf("ExecuteToolCall callback not configured")
 	}
+	s.recordCheckpoint(ctx, state, toolCalls)
+	if s.deps.SnapshotFiles != nil {
+		s.deps.SnapshotFiles(ctx, state, toolCalls)
+	}
 
 	// Parallel path: separate I/O (parallel) from state mutation (sequential).
 	// Requires both ExecuteToolRaw and ProcessToolResult callbacks.
@@ -47,6 +69,8 @@ func (s *ToolStage) Execute(ctx context.Context, state *RunState) error {
 	}
 
 	// Sequential fallback: ExecuteToolCall handles both I/O and state mutation.
+	var images imageMessages
+	defer images.flush(state)
 	for _, tc := range toolCalls {
 		// Hook: sync PreToolUse — block if hook denies. Builtin-source hooks may
 		// rewrite tc.Arguments via UpdatedToolInput (e.g. path-sanitizer); apply
@@ -77,7 +101,7 @@ func (s *ToolStage) Execute(ctx context.Context, state *RunState) error {
 			return fmt.Errorf("execute tool %s: %w", tc.Name, err)
 		}
 		for _, msg := range msgs {
-			state.Messages.AppendPending(msg)
+			images.appendPending(state, msg)
 		}
 		state.Tool.TotalToolCalls++
 
@@ -128,13 +152,15 @@ func (s *ToolStage) executeParallel(ctx context.Context, state *RunState, toolCa
 	wg.Wait()
 
 	// Phase 2: sequential state mutation (safe, deterministic order)
+	var images imageMessages
+	defer images.flush(state)
 	for _, r := range results {
 		if r.err != nil {
 			return fmt.Errorf("execute tool %s: %w", r.tc.Name, r.err)
 		}
 		processed := s.deps.ProcessToolResult(ctx, state, r.tc, r.msg, r.rawData)
 		for _, msg := range processed {
-			state.Messages.AppendPending(msg)
+			images.appendPending(state, msg)
 		}
 		state.Tool.TotalToolCalls++
 
@@ -163,6 +189,26 @@ func (s *ToolStage) executeParallel(ctx context.Context, state *RunState, toolCa
 	return nil
 }
 
+// imageMessages holds user messages carrying tool-loaded images (read_image
+// attach mode) until every tool result of the iteration is appended: OpenAI
+// rejects a user message between the tool results of one assistant turn.
+type imageMessages []providers.Message
+
+func (m *imageMessages) appendPending(state *RunState, msg providers.Message) {
+	if msg.Role == "user" && len(msg.Images) > 0 {
+		*m = append(*m, msg)
+		return
+	}
+	state.Messages.AppendPending(msg)
+}
+
+func (m *imageMessages) flush(state *RunState) {
+	for _, msg := range *m {
+		state.Messages.AppendPending(msg)
+	}
+	*m = nil
+}
+
 // checkExitConditions checks read-only streak and tool budget.
 func (s *ToolStage) checkExitConditions(state *RunState) {
 	if state.Tool.LoopKilled {
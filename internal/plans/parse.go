@@ -0,0 +1,128 @@
+package plans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxSteps caps the steps of one plan.
+const MaxSteps = 20
+
+// PlannerPrompt is the message that asks the agent to draft a plan for goal.
+func PlannerPrompt(goal string) string {
+	return fmt.Sprintf(`Draft an execution plan for the goal below. Do not carry out any of it yet.
+
+Goal: %s
+
+Reply with ONLY a JSON object, no other text:
+{"steps": [{"title": "short step title", "instructions": "what to do in this step and what counts as done", "tools": ["tool names this step will likely use"]}]}
+
+Rules:
+- At most %d steps, in execution order; each step must be completable in one working session.
+- Each step runs separately and only sees the results of earlier steps, so make instructions self-contained.
+- Put checks (verify, test, confirm) in their own steps after the work they check.`, goal, MaxSteps)
+}
+
+// ParseSteps extracts the plan steps from the planner's reply. The JSON may
+// be wrapped in a code fence or surrounded by prose, and may be a bare
+// array of steps.
+func ParseSteps(text string) ([]Step, error) {
+	raw := extractJSON(text)
+	if raw == "" {
+		return nil, errors.New("no JSON plan found in the reply")
+	}
+
+	var steps []Step
+	if strings.HasPrefix(raw, "[") {
+		if err := json.Unmarshal([]byte(raw), &steps); err != nil {
+			return nil, fmt.Errorf("decode plan: %w", err)
+		}
+	} else {
+		var wrapper struct {
+			Steps []Step `json:"steps"`
+		}
+		if err := json.Unmarshal([]byte(raw), &wrapper); err != nil {
+			return nil, fmt.Errorf("decode plan: %w", err)
+		}
+		steps = wrapper.Steps
+	}
+
+	out := steps[:0]
+	for _, s := range steps {
+		s.Title = strings.TrimSpace(s.Title)
+		s.Instructions = strings.TrimSpace(s.Instructions)
+		if s.Title == "" && s.Instructions == "" {
+			continue
+		}
+		if s.Title == "" {
+			s.Title = firstLine(s.Instructions, 60)
+		}
+		if s.Instructions == "" {
+			s.Instructions = s.Title
+		}
+		s.Status, s.Result, s.Error, s.StartedAt, s.FinishedAt = StepPending, "", "", nil, nil
+		out = append(out, s)
+	}
+	switch {
+	case len(out) == 0:
+		return nil, errors.New("the plan has no steps")
+	case len(out) > MaxSteps:
+		return nil, fmt.Errorf("the plan has %d steps (max %d)", len(out), MaxSteps)
+	}
+	return out, nil
+}
+
+// extractJSON returns the first balanced JSON object or array in text.
+func extractJSON(text string) string {
+	start := strings.IndexAny(text, "{[")
+	for start >= 0 {
+		if end := matchBracket(text, start); end > start {
+			return text[start : end+1]
+		}
+		next := strings.IndexAny(text[start+1:], "{[")
+		if next < 0 {
+			break
+		}
+		start += 1 + next
+	}
+	return ""
+}
+
+// matchBracket returns the index closing the bracket at start, or -1.
+func matchBracket(text string, start int) int {
+	depth := 0
+	inString, escaped := false, false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func firstLine(s string, max int) string {
+	s, _, _ = strings.Cut(s, "\n")
+	if r := []rune(s); len(r) > max {
+		return string(r[:max]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+// Package plans implements notebook-style plan runs: the agent drafts a
+// structured multi-step plan, its requester approves it, and the gateway
+// executes the steps one agent run at a time.
+//
+// Every state change is checkpointed to disk, so a plan survives restarts:
+// a plan that was running when the gateway stopped comes back paused at the
+// step that was interrupted. Plans can be paused (after the current step),
+// resumed (a failed step is retried) and cancelled at any time.
+package plans
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is a plan's lifecycle state.
+type Status string
+
+const (
+	StatusAwaitingApproval Status = "awaiting_approval"
+	StatusRunning          Status = "running"
+	StatusPaused           Status = "paused"
+	StatusCompleted        Status = "completed"
+	StatusFailed           Status = "failed"
+	StatusCancelled        Status = "cancelled"
+)
+
+// Finished reports whether the plan can no longer run.
+func (s Status) Finished() bool {
+	return s == StatusCompleted || s == StatusCancelled
+}
+
+// StepStatus is a step's execution state.
+type StepStatus string
+
+const (
+	StepPending StepStatus = "pending"
+	StepRunning StepStatus = "running"
+	StepDone    StepStatus = "done"
+	StepFailed  StepStatus = "failed"
+)
+
+// Step is one unit of work, executed as a single agent run.
+type Step struct {
+	Title        string     `json:"title"`
+	Instructions string     `json:"instructions"`
+	Tools        []string   `json:"tools,omitempty"` // tools the planner expects the step to use
+	Status       StepStatus `json:"status"`
+	Result       string     `json:"result,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+}
+
+// Plan is a multi-step run and its checkpointed progress.
+type Plan struct {
+	ID       string    `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	AgentKey string    `json:"agent_key"`
+	AgentID  uuid.UUID `json:"agent_id"`
+
+	// Where the plan was requested; step results are reported there.
+	SessionKey string            `json:"session_key"`
+	UserID     string            `json:"user_id"` // requester; only they can approve and control the plan
+	Channel    string            `json:"channel"`
+	ChatID     string            `json:"chat_id"`
+	PeerKind   string            `json:"peer_kind,omitempty"`
+	Meta       map[string]string `json:"meta,omitempty"` // outbound routing metadata
+
+	Goal      string    `json:"goal"`
+	Steps     []Step    `json:"steps"`
+	Status    Status    `json:"status"`
+	Current   int       `json:"current"` // index of the next step to run
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// New creates a plan awaiting approval.
+func New(goal string, steps []Step) *Plan {
+	now := time.Now().UTC()
+	for i := range steps {
+		steps[i].Status = StepPending
+	}
+	return &Plan{
+		ID:        newID(),
+		Goal:      goal,
+		Steps:     steps,
+		Status:    StatusAwaitingApproval,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Clone returns a deep copy.
+func (p *Plan) Clone() *Plan {
+	c := *p
+	c.Steps = make([]Step, len(p.Steps))
+	for i, s := range p.Steps {
+		s.Tools = append([]string(nil), s.Tools...)
+		c.Steps[i] = s
+	}
+	if p.Meta != nil {
+		c.Meta = make(map[string]string, len(p.Meta))
+		for k, v := range p.Meta {
+			c.Meta[k] = v
+		}
+	}
+	return &c
+}
+
+// Done counts the completed steps.
+func (p *Plan) Done() int {
+	n := 0
+	for _, s := range p.Steps {
+		if s.Status == StepDone {
+			n++
+		}
+	}
+	return n
+}
+
+func newID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
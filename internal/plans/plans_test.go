@@ -0,0 +1,222 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseSteps(t *testing.T) {
+	reply := "Here is the plan:\n```json\n{\"steps\": [\n" +
+		"{\"title\": \"Back up DB\", \"instructions\": \"Dump the database to /backups\", \"tools\": [\"exec\"]},\n" +
+		"{\"title\": \"\", \"instructions\": \"Vacuum {all} tables\"},\n" +
+		"{\"title\": \"\", \"instructions\": \"\"}\n]}\n```"
+	steps, err := ParseSteps(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("got %d steps, want 2", len(steps))
+	}
+	if steps[0].Title != "Back up DB" || steps[0].Tools[0] != "exec" || steps[0].Status != StepPending {
+		t.Errorf("step 1 = %+v", steps[0])
+	}
+	if steps[1].Title != "Vacuum {all} tables" {
+		t.Errorf("untitled step should take its instructions as title, got %q", steps[1].Title)
+	}
+
+	if steps, err := ParseSteps(`[{"title":"only"}]`); err != nil || steps[0].Instructions != "only" {
+		t.Errorf("bare array: %+v, %v", steps, err)
+	}
+	for _, bad := range []string{"no plan here", `{"steps": []}`, `{"steps": [` + strings.Repeat(`{"title":"x"},`, MaxSteps) + `{"title":"x"}]}`} {
+		if _, err := ParseSteps(bad); err == nil {
+			t.Errorf("ParseSteps(%.30q) should fail", bad)
+		}
+	}
+}
+
+func TestStore_CheckpointsSurviveReopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := New("tidy logs", []Step{{Title: "a"}, {Title: "b"}})
+	p.SessionKey = "sess"
+	if err := s.Create(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(p.ID, func(p *Plan) error {
+		p.Status, p.Current = StatusRunning, 1
+		p.Steps[0].Status, p.Steps[0].Result = StepDone, "ok"
+		p.Steps[1].Status = StepRunning
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s2, err := NewStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewRunner(s2, nil, nil)
+	if paused := r.Recover(); len(paused) != 1 {
+		t.Fatalf("recovered %d plans, want 1", len(paused))
+	}
+	got := s2.Active("sess")
+	if got == nil || got.Status != StatusPaused || got.Current != 1 || got.Steps[0].Result != "ok" || got.Steps[1].Status != StepPending {
+		t.Errorf("reopened plan = %+v", got)
+	}
+}
+
+// recorder collects notifications and signals each one.
+type recorder struct {
+	mu   sync.Mutex
+	msgs []string
+	ch   chan string
+}
+
+func newRecorder() *recorder { return &recorder{ch: make(chan string, 32)} }
+
+func (r *recorder) notify(_ *Plan, text string) {
+	r.mu.Lock()
+	r.msgs = append(r.msgs, text)
+	r.mu.Unlock()
+	r.ch <- text
+}
+
+func (r *recorder) wait(t *testing.T, prefix string) string {
+	t.Helper()
+	for {
+		select {
+		case m := <-r.ch:
+			if strings.HasPrefix(m, prefix) {
+				return m
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", prefix)
+			return ""
+		}
+	}
+}
+
+func newTestRunner(t *testing.T, exec StepFunc) (*Runner, *recorder, *Plan) {
+	t.Helper()
+	s, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := newRecorder()
+	p := New("maintain", []Step{{Title: "one"}, {Title: "two"}, {Title: "three"}})
+	if err := s.Create(p); err != nil {
+		t.Fatal(err)
+	}
+	return NewRunner(s, exec, rec.notify), rec, p
+}
+
+func TestRunner_RunsStepsInOrder(t *testing.T) {
+	var seen []string
+	r, rec, p := newTestRunner(t, func(_ context.Context, p *Plan, idx int) (string, error) {
+		seen = append(seen, p.Steps[idx].Title)
+		return "did " + p.Steps[idx].Title, nil
+	})
+
+	if _, err := r.Pause(p.ID); err == nil {
+		t.Error("pausing a plan awaiting approval should fail")
+	}
+	if _, err := r.Approve(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	rec.wait(t, "✅")
+
+	got, _ := r.Store().Get(p.ID)
+	if got.Status != StatusCompleted || got.Done() != 3 || got.Steps[2].Result != "did three" {
+		t.Errorf("plan = %+v", got)
+	}
+	if strings.Join(seen, ",") != "one,two,three" {
+		t.Errorf("steps ran as %v", seen)
+	}
+}
+
+func TestRunner_FailureThenResumeRetriesStep(t *testing.T) {
+	attempts := 0
+	r, rec, p := newTestRunner(t, func(_ context.Context, p *Plan, idx int) (string, error) {
+		if idx == 1 {
+			attempts++
+			if attempts == 1 {
+				return "", errors.New("disk full")
+			}
+		}
+		return "ok", nil
+	})
+
+	if _, err := r.Approve(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if msg := rec.wait(t, "❌"); !strings.Contains(msg, "disk full") {
+		t.Errorf("failure notice = %q", msg)
+	}
+	got, _ := r.Store().Get(p.ID)
+	if got.Status != StatusFailed || got.Current != 1 || got.Steps[1].Status != StepFailed {
+		t.Fatalf("after failure: %+v", got)
+	}
+
+	if _, err := r.Resume(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	rec.wait(t, "✅")
+	if attempts != 2 {
+		t.Errorf("step 2 ran %d times, want 2", attempts)
+	}
+}
+
+func TestRunner_PauseAndCancel(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan int, 4)
+	r, rec, p := newTestRunner(t, func(ctx context.Context, _ *Plan, idx int) (string, error) {
+		started <- idx
+		select {
+		case <-release:
+			return "ok", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	})
+
+	if _, err := r.Approve(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if _, err := r.Pause(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	rec.wait(t, "⏸")
+	got, _ := r.Store().Get(p.ID)
+	if got.Status != StatusPaused || got.Current != 1 {
+		t.Fatalf("after pause: %+v", got)
+	}
+
+	release = make(chan struct{})
+	if _, err := r.Resume(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if _, err := r.Cancel(p.ID); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, _ = r.Store().Get(p.ID)
+		if got.Steps[1].Status == StepFailed || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got.Status != StatusCancelled || got.Steps[1].Error != "cancelled" || got.Done() != 1 {
+		t.Errorf("after cancel: %+v", got)
+	}
+}
@@ -0,0 +1,212 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// StepFunc executes step idx of p and returns its result text.
+type StepFunc func(ctx context.Context, p *Plan, idx int) (string, error)
+
+// NotifyFunc reports progress on p to its requester.
+type NotifyFunc func(p *Plan, text string)
+
+// Runner executes approved plans, one goroutine per running plan.
+type Runner struct {
+	store  *Store
+	exec   StepFunc
+	notify NotifyFunc
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc // plan ID → cancels the in-flight step
+}
+
+// NewRunner creates a runner. notify may be nil.
+func NewRunner(store *Store, exec StepFunc, notify NotifyFunc) *Runner {
+	if notify == nil {
+		notify = func(*Plan, string) {}
+	}
+	return &Runner{store: store, exec: exec, notify: notify, running: map[string]context.CancelFunc{}}
+}
+
+// Store returns the runner's plan store.
+func (r *Runner) Store() *Store { return r.store }
+
+// Recover pauses plans that were running when the process stopped; the
+// interrupted step runs again on resume. It returns the paused plans.
+func (r *Runner) Recover() []*Plan {
+	var paused []*Plan
+	for _, p := range r.store.List("") {
+		if p.Status != StatusRunning {
+			continue
+		}
+		updated, err := r.store.Update(p.ID, func(p *Plan) error {
+			p.Status = StatusPaused
+			if p.Current < len(p.Steps) && p.Steps[p.Current].Status == StepRunning {
+				p.Steps[p.Current].Status = StepPending
+				p.Steps[p.Current].StartedAt = nil
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Warn("plans: recover failed", "plan", p.ID, "error", err)
+			continue
+		}
+		paused = append(paused, updated)
+	}
+	return paused
+}
+
+// Approve starts a plan awaiting approval.
+func (r *Runner) Approve(id string) (*Plan, error) {
+	return r.transition(id, StatusRunning, StatusAwaitingApproval)
+}
+
+// Resume restarts a paused or failed plan at its current step.
+func (r *Runner) Resume(id string) (*Plan, error) {
+	return r.transition(id, StatusRunning, StatusPaused, StatusFailed)
+}
+
+// Pause stops a running plan once its current step finishes.
+func (r *Runner) Pause(id string) (*Plan, error) {
+	return r.transition(id, StatusPaused, StatusRunning)
+}
+
+// Cancel stops a plan for good, aborting the step in flight.
+func (r *Runner) Cancel(id string) (*Plan, error) {
+	p, err := r.transition(id, StatusCancelled, StatusAwaitingApproval, StatusRunning, StatusPaused, StatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	if cancel, ok := r.running[id]; ok {
+		cancel()
+	}
+	r.mu.Unlock()
+	return p, nil
+}
+
+func (r *Runner) transition(id string, to Status, from ...Status) (*Plan, error) {
+	p, err := r.store.Update(id, func(p *Plan) error {
+		for _, f := range from {
+			if p.Status == f {
+				p.Status = to
+				return nil
+			}
+		}
+		return fmt.Errorf("plan is %s", p.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if to == StatusRunning {
+		r.start(id)
+	}
+	return p, nil
+}
+
+// start launches the plan loop unless it is already running (e.g. resumed
+// while its last step is still in flight).
+func (r *Runner) start(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.running[id]; ok {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.running[id] = cancel
+	go func() {
+		stopped := r.loop(ctx, id)
+		r.mu.Lock()
+		delete(r.running, id)
+		r.mu.Unlock()
+		cancel()
+		// Resumed between the loop seeing the pause and leaving: go again.
+		if p, err := r.store.Get(id); stopped && err == nil && p.Status == StatusRunning {
+			r.start(id)
+		}
+	}()
+}
+
+// loop runs steps until the plan finishes, fails or leaves the running
+// state. It returns true in the last case.
+func (r *Runner) loop(ctx context.Context, id string) bool {
+	for {
+		p, err := r.store.Update(id, func(p *Plan) error {
+			if p.Status != StatusRunning {
+				return errStop
+			}
+			if p.Current >= len(p.Steps) {
+				p.Status = StatusCompleted
+				return nil
+			}
+			now := time.Now().UTC()
+			step := &p.Steps[p.Current]
+			step.Status, step.Error, step.StartedAt, step.FinishedAt = StepRunning, "", &now, nil
+			return nil
+		})
+		if errors.Is(err, errStop) {
+			r.reportStop(id)
+			return true
+		}
+		if err != nil {
+			slog.Warn("plans: checkpoint failed", "plan", id, "error", err)
+			return false
+		}
+		if p.Status == StatusCompleted {
+			r.notify(p, fmt.Sprintf("✅ Plan complete: %s (%d steps).", p.Goal, len(p.Steps)))
+			return false
+		}
+
+		idx := p.Current
+		result, runErr := r.exec(ctx, p, idx)
+
+		p, err = r.store.Update(id, func(p *Plan) error {
+			now := time.Now().UTC()
+			step := &p.Steps[idx]
+			step.FinishedAt = &now
+			switch {
+			case p.Status == StatusCancelled:
+				step.Status, step.Error = StepFailed, "cancelled"
+			case runErr != nil:
+				step.Status, step.Error = StepFailed, runErr.Error()
+				p.Status = StatusFailed
+			default:
+				step.Status, step.Result = StepDone, result
+				p.Current = idx + 1
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Warn("plans: checkpoint failed", "plan", id, "error", err)
+			return false
+		}
+		step := p.Steps[idx]
+		switch {
+		case p.Status == StatusCancelled:
+			return false // Cancel already answered the requester
+		case step.Status == StepFailed:
+			slog.Warn("plans: step failed", "plan", id, "step", idx+1, "error", runErr)
+			r.notify(p, fmt.Sprintf("❌ Step %d/%d failed: %s\n%s\n\nReply /plan resume to retry it or /plan cancel to stop.",
+				idx+1, len(p.Steps), step.Title, step.Error))
+			return false
+		default:
+			r.notify(p, fmt.Sprintf("✔️ Step %d/%d: %s\n\n%s", idx+1, len(p.Steps), step.Title, step.Result))
+		}
+	}
+}
+
+// errStop ends the loop when the plan left the running state.
+var errStop = errors.New("plan not running")
+
+func (r *Runner) reportStop(id string) {
+	p, err := r.store.Get(id)
+	if err != nil || p.Status != StatusPaused {
+		return
+	}
+	r.notify(p, fmt.Sprintf("⏸ Plan paused after step %d/%d. Reply /plan resume to continue.", p.Current, len(p.Steps)))
+}
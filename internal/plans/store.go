@@ -0,0 +1,152 @@
+package plans
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// finishedRetention is how long completed and cancelled plans are kept.
+const finishedRetention = 30 * 24 * time.Hour
+
+// ErrNotFound is returned for an unknown plan ID.
+var ErrNotFound = errors.New("plan not found")
+
+// Store keeps plans in memory and checkpoints each one to <dir>/<id>.json
+// on every change.
+type Store struct {
+	dir string
+
+	mu    sync.Mutex
+	plans map[string]*Plan
+}
+
+// NewStore opens the plan directory, loading existing plans and dropping
+// finished ones past retention.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	s := &Store{dir: dir, plans: map[string]*Plan{}}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			slog.Warn("plans: read checkpoint failed", "path", path, "error", err)
+			continue
+		}
+		var p Plan
+		if err := json.Unmarshal(data, &p); err != nil || p.ID == "" {
+			slog.Warn("plans: corrupt checkpoint skipped", "path", path, "error", err)
+			continue
+		}
+		if p.Status.Finished() && time.Since(p.UpdatedAt) > finishedRetention {
+			_ = os.Remove(path)
+			continue
+		}
+		s.plans[p.ID] = &p
+	}
+	return s, nil
+}
+
+// Create stores a new plan.
+func (s *Store) Create(p *Plan) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.plans[p.ID]; ok {
+		return fmt.Errorf("plan %s already exists", p.ID)
+	}
+	c := p.Clone()
+	if err := s.write(c); err != nil {
+		return err
+	}
+	s.plans[c.ID] = c
+	return nil
+}
+
+// Get returns a copy of the plan.
+func (s *Store) Get(id string) (*Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.plans[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return p.Clone(), nil
+}
+
+// Update applies fn to the plan and checkpoints it. The change is dropped
+// when fn returns an error.
+func (s *Store) Update(id string, fn func(p *Plan) error) (*Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur, ok := s.plans[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	next := cur.Clone()
+	if err := fn(next); err != nil {
+		return nil, err
+	}
+	next.UpdatedAt = time.Now().UTC()
+	if err := s.write(next); err != nil {
+		return nil, err
+	}
+	s.plans[id] = next
+	return next.Clone(), nil
+}
+
+// List returns the plans requested from sessionKey ("" = all), newest first.
+func (s *Store) List(sessionKey string) []*Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*Plan
+	for _, p := range s.plans {
+		if sessionKey == "" || p.SessionKey == sessionKey {
+			out = append(out, p.Clone())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// Active returns the newest unfinished plan of sessionKey, or nil.
+func (s *Store) Active(sessionKey string) *Plan {
+	for _, p := range s.List(sessionKey) {
+		if !p.Status.Finished() {
+			return p
+		}
+	}
+	return nil
+}
+
+// write checkpoints p atomically. Must be called with s.mu held.
+func (s *Store) write(p *Plan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, p.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("checkpoint plan: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("checkpoint plan: %w", err)
+	}
+	return nil
+}
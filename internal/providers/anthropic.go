@@ -106,6 +106,9 @@ func (p *AnthropicProvider) Name() string           { return p.name }
 func (p *AnthropicProvider) DefaultModel() string   { return p.defaultModel }
 func (p *AnthropicProvider) SupportsThinking() bool { return true }
 
+// httpClient exposes the client so the registry can scope its proxy.
+func (p *AnthropicProvider) httpClient() *http.Client { return p.client }
+
 // Capabilities implements CapabilitiesAware for pipeline code-path selection.
 func (p *AnthropicProvider) Capabilities() ProviderCapabilities {
 	return ProviderCapabilities{
@@ -177,6 +180,7 @@ func (p *AnthropicProvider) doRequest(ctx context.Context, body any) (io.ReadClo
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("x-api-key", p.apiKey)
 	httpReq.Header.Set("anthropic-version", anthropicAPIVersion)
+	setRequestIDHeader(httpReq)
 
 	// Add beta header for interleaved thinking when thinking is enabled
 	if bodyMap, ok := body.(map[string]any); ok {
@@ -68,6 +68,9 @@ func (p *CodexProvider) Name() string           { return p.name }
 func (p *CodexProvider) DefaultModel() string   { return p.defaultModel }
 func (p *CodexProvider) SupportsThinking() bool { return true }
 
+// httpClient exposes the client so the registry can scope its proxy.
+func (p *CodexProvider) httpClient() *http.Client { return p.client }
+
 // Capabilities implements CapabilitiesAware for pipeline code-path selection.
 func (p *CodexProvider) Capabilities() ProviderCapabilities {
 	return ProviderCapabilities{
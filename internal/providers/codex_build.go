@@ -162,6 +162,7 @@ func (p *CodexProvider) doRequest(ctx context.Context, body any) (io.ReadCloser,
 	}
 	httpReq.Header.Set("Authorization", "Bearer "+token)
 	httpReq.Header.Set("OpenAI-Beta", "responses=v1")
+	setRequestIDHeader(httpReq)
 
 	resp, err := p.client.Do(httpReq)
 	if err != nil {
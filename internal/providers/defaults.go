@@ -3,6 +3,8 @@ package providers
 import (
 	"net/http"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // Provider-level defaults for HTTP clients and stream parsing.
@@ -26,7 +28,7 @@ const (
 // still terminates the request promptly via CtxBody.
 func NewDefaultTransport() *http.Transport {
 	return &http.Transport{
-		Proxy:                 http.ProxyFromEnvironment,
+		Proxy:                 netproxy.For(netproxy.ScopeDefault),
 		ResponseHeaderTimeout: 180 * time.Second, // wait for first byte of response (3min for slow providers)
 		IdleConnTimeout:       90 * time.Second, // close idle keep-alive connections
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -41,3 +43,25 @@ func NewDefaultTransport() *http.Transport {
 func NewDefaultHTTPClient() *http.Client {
 	return &http.Client{Transport: NewDefaultTransport()}
 }
+
+// httpClientProvider is implemented by providers that own an *http.Client,
+// letting the registry scope the transport proxy to the provider's name.
+type httpClientProvider interface {
+	httpClient() *http.Client
+}
+
+// applyProviderProxy points the provider's transport at its per-provider
+// proxy override (config proxy.providers[name]), falling back to the default.
+func applyProviderProxy(p Provider) {
+	hp, ok := p.(httpClientProvider)
+	if !ok {
+		return
+	}
+	c := hp.httpClient()
+	if c == nil {
+		return
+	}
+	if t, ok := c.Transport.(*http.Transport); ok {
+		t.Proxy = netproxy.For(netproxy.Provider(p.Name()))
+	}
+}
@@ -0,0 +1,86 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	fakeProviderName   = "fake"
+	fakeDefaultModel   = "fake-echo"
+	fakeDefaultLatency = 200 * time.Millisecond
+)
+
+// FakeProvider is a zero-cost provider that echoes the last user message after
+// a fixed latency. Used by `goclaw bench --fake-provider` to load-test the
+// gateway (scheduler, sessions, WS fan-out) without calling a real LLM.
+// Never registered unless GOCLAW_FAKE_PROVIDER is set.
+type FakeProvider struct {
+	latency time.Duration
+}
+
+// NewFakeProvider creates a fake provider. latency <= 0 uses the 200ms default.
+func NewFakeProvider(latency time.Duration) *FakeProvider {
+	if latency <= 0 {
+		latency = fakeDefaultLatency
+	}
+	return &FakeProvider{latency: latency}
+}
+
+func (p *FakeProvider) Name() string         { return fakeProviderName }
+func (p *FakeProvider) DefaultModel() string { return fakeDefaultModel }
+
+// Chat waits for the configured latency and echoes the last user message.
+func (p *FakeProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(p.latency):
+	}
+	return p.reply(req), nil
+}
+
+// ChatStream splits the echo reply into word chunks spread across the latency window.
+func (p *FakeProvider) ChatStream(ctx context.Context, req ChatRequest, onChunk func(StreamChunk)) (*ChatResponse, error) {
+	resp := p.reply(req)
+	words := strings.SplitAfter(resp.Content, " ")
+	step := p.latency / time.Duration(len(words))
+	for _, w := range words {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(step):
+		}
+		if onChunk != nil {
+			onChunk(StreamChunk{Content: w})
+		}
+	}
+	if onChunk != nil {
+		onChunk(StreamChunk{Done: true})
+	}
+	return resp, nil
+}
+
+func (p *FakeProvider) reply(req ChatRequest) *ChatResponse {
+	var last string
+	promptChars := 0
+	for _, m := range req.Messages {
+		promptChars += len(m.Content)
+		if m.Role == "user" {
+			last = m.Content
+		}
+	}
+	content := "echo: " + last
+	// Rough 4-chars-per-token estimate keeps usage accounting non-zero.
+	prompt, completion := promptChars/4+1, len(content)/4+1
+	return &ChatResponse{
+		Content:      content,
+		FinishReason: "stop",
+		Usage: &Usage{
+			PromptTokens:     prompt,
+			CompletionTokens: completion,
+			TotalTokens:      prompt + completion,
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakeProviderEchoesLastUserMessage(t *testing.T) {
+	p := NewFakeProvider(time.Millisecond)
+	resp, err := p.Chat(context.Background(), ChatRequest{Messages: []Message{
+		{Role: "system", Content: "you are a bench"},
+		{Role: "user", Content: "first"},
+		{Role: "assistant", Content: "echo: first"},
+		{Role: "user", Content: "second"},
+	}})
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	if resp.Content != "echo: second" {
+		t.Errorf("content = %q, want %q", resp.Content, "echo: second")
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens == 0 {
+		t.Errorf("usage = %+v, want non-zero tokens", resp.Usage)
+	}
+}
+
+func TestFakeProviderStreamChunks(t *testing.T) {
+	p := NewFakeProvider(5 * time.Millisecond)
+	var sb strings.Builder
+	var done bool
+	resp, err := p.ChatStream(context.Background(), ChatRequest{Messages: []Message{
+		{Role: "user", Content: "hello there world"},
+	}}, func(c StreamChunk) {
+		sb.WriteString(c.Content)
+		done = done || c.Done
+	})
+	if err != nil {
+		t.Fatalf("ChatStream: %v", err)
+	}
+	if sb.String() != resp.Content {
+		t.Errorf("streamed %q, final %q", sb.String(), resp.Content)
+	}
+	if !done {
+		t.Error("missing Done chunk")
+	}
+}
+
+func TestFakeProviderHonorsCancel(t *testing.T) {
+	p := NewFakeProvider(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Chat(ctx, ChatRequest{}); err == nil {
+		t.Error("expected context error")
+	}
+}
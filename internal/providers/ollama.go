@@ -0,0 +1,449 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ollamaDefaultHost  = "http://localhost:11434"
+	ollamaDefaultModel = "llama3.3"
+
+	// ollamaDefaultNumCtx is the num_ctx sent when none is configured. Ollama's
+	// own default (2k–8k depending on version) silently truncates agent prompts,
+	// so a larger window is requested, capped at the model's trained context length.
+	ollamaDefaultNumCtx = 32_768
+)
+
+// OllamaProvider implements Provider against Ollama's native API (/api/chat)
+// rather than its OpenAI-compatible shim. The native API exposes keep_alive,
+// per-request num_ctx and model metadata (/api/show) used for context window
+// detection, and /api/tags for listing locally installed models.
+type OllamaProvider struct {
+	name         string
+	host         string // server root, e.g. http://localhost:11434 (no /v1)
+	apiKey       string // optional Bearer token (reverse proxies, Ollama Cloud)
+	defaultModel string
+	keepAlive    string // how long the model stays loaded, e.g. "10m", "-1" (forever), "0" (unload)
+	numCtx       int    // context length requested per call (0 = ollamaDefaultNumCtx capped at model max)
+	client       *http.Client
+	retryConfig  RetryConfig
+	registry     ModelRegistry // receives detected context windows (nil = skip)
+
+	mu     sync.Mutex
+	models map[string]*OllamaModelInfo // model → /api/show metadata
+}
+
+// OllamaOption configures an OllamaProvider.
+type OllamaOption func(*OllamaProvider)
+
+// NewOllamaProvider creates a provider for the Ollama server at host.
+// A trailing /v1 (stored for the OpenAI-compatible endpoint) is stripped.
+func NewOllamaProvider(host string, opts ...OllamaOption) *OllamaProvider {
+	p := &OllamaProvider{
+		name:         "ollama",
+		host:         NormalizeOllamaHost(host),
+		defaultModel: ollamaDefaultModel,
+		client:       NewDefaultHTTPClient(),
+		retryConfig:  DefaultRetryConfig(),
+		models:       make(map[string]*OllamaModelInfo),
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	p.registerResolver()
+	return p
+}
+
+// WithOllamaName overrides the provider name (default: "ollama").
+func WithOllamaName(name string) OllamaOption {
+	return func(p *OllamaProvider) {
+		if name != "" {
+			p.name = name
+		}
+	}
+}
+
+func WithOllamaModel(model string) OllamaOption {
+	return func(p *OllamaProvider) {
+		if model != "" {
+			p.defaultModel = model
+		}
+	}
+}
+
+// WithOllamaAPIKey sends the key as a Bearer token (Ollama itself ignores it).
+func WithOllamaAPIKey(apiKey string) OllamaOption {
+	return func(p *OllamaProvider) { p.apiKey = apiKey }
+}
+
+// WithOllamaKeepAlive sets keep_alive on every request. Accepts a Go duration
+// ("10m", "1h"), a number of seconds, or "-1" to keep the model loaded.
+func WithOllamaKeepAlive(keepAlive string) OllamaOption {
+	return func(p *OllamaProvider) { p.keepAlive = strings.TrimSpace(keepAlive) }
+}
+
+// WithOllamaNumCtx fixes the context length requested per call.
+func WithOllamaNumCtx(numCtx int) OllamaOption {
+	return func(p *OllamaProvider) {
+		if numCtx > 0 {
+			p.numCtx = numCtx
+		}
+	}
+}
+
+// WithOllamaRegistry publishes detected context windows to the model registry,
+// so per-run context budgeting uses the window Ollama actually serves.
+func WithOllamaRegistry(r ModelRegistry) OllamaOption {
+	return func(p *OllamaProvider) { p.registry = r }
+}
+
+// NormalizeOllamaHost returns the server root for host: trailing slashes and an
+// OpenAI-compat /v1 suffix are removed; empty falls back to localhost:11434.
+func NormalizeOllamaHost(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	host = strings.TrimRight(strings.TrimSuffix(host, "/v1"), "/")
+	if host == "" {
+		return ollamaDefaultHost
+	}
+	return host
+}
+
+func (p *OllamaProvider) Name() string           { return p.name }
+func (p *OllamaProvider) DefaultModel() string   { return p.defaultModel }
+func (p *OllamaProvider) SupportsThinking() bool { return true }
+func (p *OllamaProvider) Host() string           { return p.host }
+
+// httpClient exposes the client so the registry can scope its proxy.
+func (p *OllamaProvider) httpClient() *http.Client { return p.client }
+
+// Capabilities implements CapabilitiesAware for pipeline code-path selection.
+// Per-model limits come from /api/show via ContextWindow.
+func (p *OllamaProvider) Capabilities() ProviderCapabilities {
+	window := p.numCtx
+	if window == 0 {
+		window = ollamaDefaultNumCtx
+	}
+	return ProviderCapabilities{
+		Streaming:        true,
+		ToolCalling:      true,
+		StreamWithTools:  true,
+		Thinking:         true,
+		Vision:           true,
+		CacheControl:     false,
+		MaxContextWindow: window,
+		TokenizerID:      "cl100k_base",
+	}
+}
+
+// ModelSupportsThinking implements ModelThinkingCapable using the model's
+// advertised capabilities, so "think" is never sent to models that reject it.
+func (p *OllamaProvider) ModelSupportsThinking(model string) bool {
+	info := p.modelInfo(context.Background(), p.resolveModel(model))
+	return info != nil && info.HasCapability("thinking")
+}
+
+func (p *OllamaProvider) resolveModel(model string) string {
+	if model == "" {
+		return p.defaultModel
+	}
+	return model
+}
+
+func (p *OllamaProvider) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
+	model := p.resolveModel(req.Model)
+	body := p.buildRequestBody(ctx, model, req, false)
+
+	resp, err := RetryDo(ctx, p.retryConfig, func() (*ChatResponse, error) {
+		respBody, err := p.doRequest(ctx, "/api/chat", body)
+		if err != nil {
+			return nil, err
+		}
+		defer respBody.Close()
+
+		var chunk ollamaChatChunk
+		if err := json.NewDecoder(respBody).Decode(&chunk); err != nil {
+			return nil, fmt.Errorf("%s: decode response: %w", p.name, err)
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("%s: %s", p.name, chunk.Error)
+		}
+		result := &ChatResponse{
+			Content:  chunk.Message.Content,
+			Thinking: chunk.Message.Thinking,
+		}
+		result.ToolCalls = appendOllamaToolCalls(nil, chunk.Message.ToolCalls)
+		p.finish(result, &chunk)
+		return result, nil
+	})
+	if resp != nil {
+		if strip, _ := req.Options[OptStripThinking].(bool); strip {
+			resp.Thinking = ""
+		}
+	}
+	return resp, err
+}
+
+// ChatStream reads Ollama's newline-delimited JSON stream. Tool calls arrive
+// whole (not as argument deltas) and are collected as they appear.
+func (p *OllamaProvider) ChatStream(ctx context.Context, req ChatRequest, onChunk func(StreamChunk)) (*ChatResponse, error) {
+	model := p.resolveModel(req.Model)
+	stripThinking, _ := req.Options[OptStripThinking].(bool)
+	body := p.buildRequestBody(ctx, model, req, true)
+
+	// Retry only the connection phase; once streaming starts, no retry.
+	respBody, err := RetryDo(ctx, p.retryConfig, func() (io.ReadCloser, error) {
+		return p.doRequest(ctx, "/api/chat", body)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cb := NewCtxBody(ctx, respBody)
+	defer cb.Close()
+
+	result := &ChatResponse{FinishReason: "stop"}
+	dec := json.NewDecoder(cb)
+	for {
+		var chunk ollamaChatChunk
+		if err := dec.Decode(&chunk); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("%s: stream read error: %w", p.name, err)
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("%s: %s", p.name, chunk.Error)
+		}
+		if t := chunk.Message.Thinking; t != "" && !stripThinking {
+			result.Thinking += t
+			if onChunk != nil {
+				onChunk(StreamChunk{Thinking: t})
+			}
+		}
+		if c := chunk.Message.Content; c != "" {
+			result.Content += c
+			if onChunk != nil {
+				onChunk(StreamChunk{Content: c})
+			}
+		}
+		result.ToolCalls = appendOllamaToolCalls(result.ToolCalls, chunk.Message.ToolCalls)
+		if chunk.Done {
+			p.finish(result, &chunk)
+			break
+		}
+	}
+
+	if onChunk != nil {
+		onChunk(StreamChunk{Done: true})
+	}
+	return result, nil
+}
+
+// finish sets finish reason and usage from the final (done) chunk.
+func (p *OllamaProvider) finish(result *ChatResponse, chunk *ollamaChatChunk) {
+	result.FinishReason = "stop"
+	if chunk.DoneReason == "length" {
+		result.FinishReason = "length"
+	} else if len(result.ToolCalls) > 0 {
+		result.FinishReason = "tool_calls"
+	}
+	if chunk.PromptEvalCount > 0 || chunk.EvalCount > 0 {
+		result.Usage = &Usage{
+			PromptTokens:     chunk.PromptEvalCount,
+			CompletionTokens: chunk.EvalCount,
+			TotalTokens:      chunk.PromptEvalCount + chunk.EvalCount,
+		}
+	}
+}
+
+func (p *OllamaProvider) buildRequestBody(ctx context.Context, model string, req ChatRequest, stream bool) map[string]any {
+	toolNameByID := buildToolNameIndex(req.Messages)
+
+	msgs := make([]map[string]any, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		msg := map[string]any{"role": m.Role, "content": m.Content}
+		if len(m.Images) > 0 {
+			images := make([]string, len(m.Images))
+			for i, img := range m.Images {
+				images[i] = img.Data
+			}
+			msg["images"] = images
+		}
+		if m.Role == "assistant" && m.Thinking != "" {
+			msg["thinking"] = m.Thinking
+		}
+		if len(m.ToolCalls) > 0 {
+			calls := make([]map[string]any, len(m.ToolCalls))
+			for i, tc := range m.ToolCalls {
+				args := tc.Arguments
+				if args == nil {
+					args = map[string]any{}
+				}
+				calls[i] = map[string]any{
+					"function": map[string]any{"name": tc.Name, "arguments": args},
+				}
+			}
+			msg["tool_calls"] = calls
+		}
+		if m.Role == "tool" {
+			if name := toolNameByID[m.ToolCallID]; name != "" {
+				msg["tool_name"] = name
+			}
+		}
+		msgs = append(msgs, msg)
+	}
+
+	body := map[string]any{
+		"model":    model,
+		"messages": msgs,
+		"stream":   stream,
+	}
+
+	if len(req.Tools) > 0 {
+		var tools []map[string]any
+		for _, t := range CleanToolSchemas("ollama", req.Tools) {
+			// Native provider tools (image_generation, ...) have no Ollama equivalent.
+			if t.Type != "function" || t.Function == nil {
+				continue
+			}
+			tools = append(tools, map[string]any{
+				"type": "function",
+				"function": map[string]any{
+					"name":        t.Function.Name,
+					"description": t.Function.Description,
+					"parameters":  t.Function.Parameters,
+				},
+			})
+		}
+		if len(tools) > 0 {
+			body["tools"] = tools
+		}
+	}
+
+	if p.keepAlive != "" {
+		// Ollama reads a bare number as seconds and a string as a Go duration.
+		if secs, err := strconv.Atoi(p.keepAlive); err == nil {
+			body["keep_alive"] = secs
+		} else {
+			body["keep_alive"] = p.keepAlive
+		}
+	}
+
+	options := map[string]any{}
+	if n := p.ContextWindow(ctx, model); n > 0 {
+		options["num_ctx"] = n
+	}
+	if v, ok := req.Options[OptMaxTokens]; ok {
+		options["num_predict"] = v
+	}
+	if v, ok := req.Options[OptTemperature]; ok {
+		options["temperature"] = v
+	}
+	if v, ok := req.Options[OptSeed]; ok {
+		options["seed"] = v
+	}
+	body["options"] = options
+
+	// Ollama constrains decoding to a JSON schema, or to any JSON with "json".
+	if rf, ok := req.Options[OptResponseFormat].(*ResponseFormat); ok && rf != nil {
+		if len(rf.Schema) > 0 {
+			body["format"] = rf.Schema
+		} else {
+			body["format"] = "json"
+		}
+	}
+
+	// Only thinking-capable models accept "think"; others return HTTP 400.
+	if level, ok := req.Options[OptThinkingLevel].(string); ok && level != "" {
+		if info := p.modelInfo(ctx, model); info != nil && info.HasCapability("thinking") {
+			body["think"] = level != "off"
+		}
+	}
+	return body
+}
+
+func (p *OllamaProvider) doRequest(ctx context.Context, path string, body any) (io.ReadCloser, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: marshal request: %w", p.name, err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.host+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: create request: %w", p.name, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	setRequestIDHeader(httpReq)
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: request failed: %w", p.name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, &HTTPError{
+			Status:     resp.StatusCode,
+			Body:       fmt.Sprintf("%s: %s", p.name, string(respBody)),
+			RetryAfter: ParseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	return resp.Body, nil
+}
+
+// appendOllamaToolCalls converts native tool calls (which carry no IDs) into
+// ToolCalls with generated IDs so tool results can be matched back.
+func appendOllamaToolCalls(dst []ToolCall, calls []ollamaToolCall) []ToolCall {
+	for _, tc := range calls {
+		args := tc.Function.Arguments
+		if args == nil {
+			args = map[string]any{}
+		}
+		id := tc.ID
+		if id == "" {
+			id = "call_" + uuid.NewString()
+		}
+		dst = append(dst, ToolCall{
+			ID:        id,
+			Name:      strings.TrimSpace(tc.Function.Name),
+			Arguments: args,
+		})
+	}
+	return dst
+}
+
+// --- wire types ---
+
+type ollamaChatChunk struct {
+	Model   string `json:"model"`
+	Message struct {
+		Role      string           `json:"role"`
+		Content   string           `json:"content"`
+		Thinking  string           `json:"thinking"`
+		ToolCalls []ollamaToolCall `json:"tool_calls"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	DoneReason      string `json:"done_reason"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error"`
+}
+
+type ollamaToolCall struct {
+	ID       string `json:"id,omitempty"`
+	Function struct {
+		Name      string         `json:"name"`
+		Arguments map[string]any `json:"arguments"`
+	} `json:"function"`
+}
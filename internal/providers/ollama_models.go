@@ -0,0 +1,229 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ollamaShowTimeout bounds /api/show lookups made on the request path.
+const ollamaShowTimeout = 5 * time.Second
+
+// OllamaModel is a locally installed model as reported by /api/tags.
+type OllamaModel struct {
+	Name              string    `json:"name"`
+	Size              int64     `json:"size"` // bytes on disk
+	ModifiedAt        time.Time `json:"modified_at"`
+	Family            string    `json:"family,omitempty"`
+	ParameterSize     string    `json:"parameter_size,omitempty"`
+	QuantizationLevel string    `json:"quantization_level,omitempty"`
+}
+
+// DisplayName returns "family paramSize quantLevel" (e.g. "gemma3 8.0B Q4_K_M"),
+// or the model name when Ollama reports no details.
+func (m OllamaModel) DisplayName() string {
+	var parts []string
+	for _, s := range []string{m.Family, m.ParameterSize, m.QuantizationLevel} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return m.Name
+	}
+	return strings.Join(parts, " ")
+}
+
+// OllamaModelInfo is the subset of /api/show used by the provider.
+type OllamaModelInfo struct {
+	ContextLength int      // trained context length (model_info "<arch>.context_length"), 0 = unknown
+	Capabilities  []string // e.g. "completion", "tools", "thinking", "vision"
+}
+
+// HasCapability reports whether the model advertises capability c.
+func (i *OllamaModelInfo) HasCapability(c string) bool {
+	return i != nil && slices.Contains(i.Capabilities, c)
+}
+
+// ListOllamaModels lists the models installed on the Ollama server at host
+// via GET /api/tags. client may be nil (http.DefaultClient); apiKey is optional.
+func ListOllamaModels(ctx context.Context, client *http.Client, host, apiKey string) ([]OllamaModel, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, NormalizeOllamaHost(host)+"/api/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("ollama /api/tags returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Models []struct {
+			Name       string    `json:"name"`
+			Size       int64     `json:"size"`
+			ModifiedAt time.Time `json:"modified_at"`
+			Details    struct {
+				Family            string `json:"family"`
+				ParameterSize     string `json:"parameter_size"`
+				QuantizationLevel string `json:"quantization_level"`
+			} `json:"details"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode ollama response: %w", err)
+	}
+
+	models := make([]OllamaModel, 0, len(result.Models))
+	for _, m := range result.Models {
+		models = append(models, OllamaModel{
+			Name:              m.Name,
+			Size:              m.Size,
+			ModifiedAt:        m.ModifiedAt,
+			Family:            m.Details.Family,
+			ParameterSize:     m.Details.ParameterSize,
+			QuantizationLevel: m.Details.QuantizationLevel,
+		})
+	}
+	return models, nil
+}
+
+// ListModels returns the models installed on this provider's Ollama server.
+func (p *OllamaProvider) ListModels(ctx context.Context) ([]OllamaModel, error) {
+	return ListOllamaModels(ctx, p.client, p.host, p.apiKey)
+}
+
+// ShowModel fetches model metadata via POST /api/show.
+func (p *OllamaProvider) ShowModel(ctx context.Context, model string) (*OllamaModelInfo, error) {
+	respBody, err := p.doRequest(ctx, "/api/show", map[string]any{"model": model})
+	if err != nil {
+		return nil, err
+	}
+	defer respBody.Close()
+
+	var show struct {
+		ModelInfo    map[string]any `json:"model_info"`
+		Capabilities []string       `json:"capabilities"`
+	}
+	if err := json.NewDecoder(respBody).Decode(&show); err != nil {
+		return nil, fmt.Errorf("%s: decode /api/show: %w", p.name, err)
+	}
+	return &OllamaModelInfo{
+		ContextLength: ollamaContextLength(show.ModelInfo),
+		Capabilities:  show.Capabilities,
+	}, nil
+}
+
+// ollamaContextLength extracts "<architecture>.context_length" from model_info.
+func ollamaContextLength(info map[string]any) int {
+	arch, _ := info["general.architecture"].(string)
+	if v, ok := info[arch+".context_length"].(float64); ok && arch != "" {
+		return int(v)
+	}
+	// Some GGUF imports omit general.architecture; take any *.context_length key.
+	for k, v := range info {
+		if n, ok := v.(float64); ok && strings.HasSuffix(k, ".context_length") {
+			return int(n)
+		}
+	}
+	return 0
+}
+
+// modelInfo returns cached /api/show metadata for model, fetching it on first
+// use. Failures are not cached so a model pulled later is picked up; nil means
+// the metadata is unavailable and callers fall back to defaults.
+func (p *OllamaProvider) modelInfo(ctx context.Context, model string) *OllamaModelInfo {
+	p.mu.Lock()
+	info, ok := p.models[model]
+	p.mu.Unlock()
+	if ok {
+		return info
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ollamaShowTimeout)
+	defer cancel()
+	info, err := p.ShowModel(ctx, model)
+	if err != nil {
+		slog.Debug("ollama: model metadata unavailable", "provider", p.name, "model", model, "error", err)
+		return nil
+	}
+
+	p.mu.Lock()
+	p.models[model] = info
+	p.mu.Unlock()
+
+	if p.registry != nil {
+		p.registry.Register(*p.modelSpec(model, info))
+	}
+	return info
+}
+
+// ContextWindow returns the num_ctx used for model: the configured num_ctx, or
+// ollamaDefaultNumCtx, never exceeding the model's trained context length.
+func (p *OllamaProvider) ContextWindow(ctx context.Context, model string) int {
+	return p.effectiveContextWindow(p.modelInfo(ctx, p.resolveModel(model)))
+}
+
+func (p *OllamaProvider) effectiveContextWindow(info *OllamaModelInfo) int {
+	window := p.numCtx
+	if window == 0 {
+		window = ollamaDefaultNumCtx
+	}
+	if info != nil && info.ContextLength > 0 && info.ContextLength < window {
+		window = info.ContextLength
+	}
+	return window
+}
+
+// ResolveForwardCompat implements ForwardCompatResolver: the model registry asks
+// the provider for unknown Ollama models, which are resolved via /api/show.
+func (p *OllamaProvider) ResolveForwardCompat(modelID string, _ ModelRegistry) *ModelSpec {
+	info := p.modelInfo(context.Background(), modelID)
+	if info == nil {
+		return nil
+	}
+	return p.modelSpec(modelID, info)
+}
+
+func (p *OllamaProvider) modelSpec(modelID string, info *OllamaModelInfo) *ModelSpec {
+	return &ModelSpec{
+		ID:            modelID,
+		Provider:      p.name,
+		ContextWindow: p.effectiveContextWindow(info),
+		Vision:        info.HasCapability("vision"),
+		Reasoning:     info.HasCapability("thinking"),
+		TokenizerID:   "cl100k_base",
+	}
+}
+
+// ollamaResolverRegistry is implemented by registries that accept per-provider
+// forward-compat resolvers (InMemoryRegistry).
+type ollamaResolverRegistry interface {
+	RegisterResolver(provider string, resolver ForwardCompatResolver)
+}
+
+// registerResolver lets the model registry resolve this provider's models
+// on demand (per-run context window lookup happens before the first call).
+func (p *OllamaProvider) registerResolver() {
+	if r, ok := p.registry.(ollamaResolverRegistry); ok {
+		r.RegisterResolver(p.name, p)
+	}
+}
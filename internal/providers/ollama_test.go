@@ -0,0 +1,262 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newOllamaTestServer serves /api/show for a model with the given trained
+// context length and capabilities; chat handles /api/chat.
+func newOllamaTestServer(t *testing.T, contextLength int, capabilities []string, chat http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var shows atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/show", func(w http.ResponseWriter, r *http.Request) {
+		shows.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{
+			"model_info": map[string]any{
+				"general.architecture": "llama",
+				"llama.context_length": contextLength,
+			},
+			"capabilities": capabilities,
+		})
+	})
+	if chat != nil {
+		mux.HandleFunc("POST /api/chat", chat)
+	}
+	mux.HandleFunc("GET /api/tags", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"models":[{"name":"qwen3:8b","size":5200000000,"modified_at":"2025-05-01T10:00:00Z",
+			"details":{"family":"qwen3","parameter_size":"8.2B","quantization_level":"Q4_K_M"}},
+			{"name":"custom:latest","size":100}]}`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &shows
+}
+
+func decodeOllamaRequest(t *testing.T, r *http.Request) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		t.Errorf("decode request: %v", err)
+	}
+	return body
+}
+
+func TestNormalizeOllamaHost(t *testing.T) {
+	cases := map[string]string{
+		"":                            "http://localhost:11434",
+		"http://localhost:11434/":     "http://localhost:11434",
+		"http://localhost:11434/v1":   "http://localhost:11434",
+		"http://gpu-box:11434/v1/":    "http://gpu-box:11434",
+		" https://ollama.example.com": "https://ollama.example.com",
+	}
+	for in, want := range cases {
+		if got := NormalizeOllamaHost(in); got != want {
+			t.Errorf("NormalizeOllamaHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOllamaChat_RequestBody(t *testing.T) {
+	var got map[string]any
+	srv, _ := newOllamaTestServer(t, 131072, []string{"completion", "tools", "thinking"}, func(w http.ResponseWriter, r *http.Request) {
+		got = decodeOllamaRequest(t, r)
+		io.WriteString(w, `{"model":"qwen3:8b","message":{"role":"assistant","content":"hi","thinking":"hmm"},
+			"done":true,"done_reason":"stop","prompt_eval_count":12,"eval_count":3}`)
+	})
+
+	p := NewOllamaProvider(srv.URL+"/v1", WithOllamaModel("qwen3:8b"), WithOllamaKeepAlive("10m"))
+	resp, err := p.Chat(context.Background(), ChatRequest{
+		Messages: []Message{
+			{Role: "user", Content: "hello"},
+			{Role: "assistant", ToolCalls: []ToolCall{{ID: "call_1", Name: "read_file", Arguments: map[string]any{"path": "a.txt"}}}},
+			{Role: "tool", ToolCallID: "call_1", Content: "contents"},
+		},
+		Tools: []ToolDefinition{{Type: "function", Function: &ToolFunctionSchema{
+			Name: "read_file", Parameters: map[string]any{"type": "object"},
+		}}},
+		Options: map[string]any{OptMaxTokens: 256, OptThinkingLevel: "medium"},
+	})
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+
+	if resp.Content != "hi" || resp.Thinking != "hmm" || resp.FinishReason != "stop" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if resp.Usage == nil || resp.Usage.PromptTokens != 12 || resp.Usage.CompletionTokens != 3 || resp.Usage.TotalTokens != 15 {
+		t.Errorf("unexpected usage: %+v", resp.Usage)
+	}
+
+	if got["model"] != "qwen3:8b" || got["stream"] != false {
+		t.Errorf("model/stream = %v/%v", got["model"], got["stream"])
+	}
+	if got["keep_alive"] != "10m" {
+		t.Errorf("keep_alive = %v, want 10m", got["keep_alive"])
+	}
+	if got["think"] != true {
+		t.Errorf("think = %v, want true for thinking-capable model", got["think"])
+	}
+	opts, _ := got["options"].(map[string]any)
+	if opts["num_ctx"] != float64(ollamaDefaultNumCtx) {
+		t.Errorf("num_ctx = %v, want %d", opts["num_ctx"], ollamaDefaultNumCtx)
+	}
+	if opts["num_predict"] != float64(256) {
+		t.Errorf("num_predict = %v, want 256", opts["num_predict"])
+	}
+	msgs, _ := got["messages"].([]any)
+	if len(msgs) != 3 {
+		t.Fatalf("messages = %d, want 3", len(msgs))
+	}
+	if tool, _ := msgs[2].(map[string]any); tool["tool_name"] != "read_file" {
+		t.Errorf("tool message tool_name = %v, want read_file", tool["tool_name"])
+	}
+	if tools, _ := got["tools"].([]any); len(tools) != 1 {
+		t.Errorf("tools = %v, want 1 function tool", got["tools"])
+	}
+}
+
+func TestOllamaChat_NumericKeepAliveAndNoThinkForPlainModels(t *testing.T) {
+	var got map[string]any
+	srv, _ := newOllamaTestServer(t, 8192, []string{"completion"}, func(w http.ResponseWriter, r *http.Request) {
+		got = decodeOllamaRequest(t, r)
+		io.WriteString(w, `{"message":{"role":"assistant","content":"ok"},"done":true}`)
+	})
+
+	p := NewOllamaProvider(srv.URL, WithOllamaKeepAlive("-1"))
+	if _, err := p.Chat(context.Background(), ChatRequest{
+		Model:    "llama3.2",
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Options:  map[string]any{OptThinkingLevel: "high"},
+	}); err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	if got["keep_alive"] != float64(-1) {
+		t.Errorf("keep_alive = %v (%T), want numeric -1", got["keep_alive"], got["keep_alive"])
+	}
+	if _, ok := got["think"]; ok {
+		t.Error("think must not be sent to models without the thinking capability")
+	}
+	// The model's trained context (8k) caps the default num_ctx.
+	if opts, _ := got["options"].(map[string]any); opts["num_ctx"] != float64(8192) {
+		t.Errorf("num_ctx = %v, want 8192", opts["num_ctx"])
+	}
+}
+
+func TestOllamaChatStream(t *testing.T) {
+	srv, _ := newOllamaTestServer(t, 32768, []string{"completion", "tools"}, func(w http.ResponseWriter, r *http.Request) {
+		if body := decodeOllamaRequest(t, r); body["stream"] != true {
+			t.Errorf("stream = %v, want true", body["stream"])
+		}
+		for _, line := range []string{
+			`{"message":{"role":"assistant","content":"Let me "},"done":false}`,
+			`{"message":{"role":"assistant","content":"check."},"done":false}`,
+			`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"web_search","arguments":{"query":"go"}}}]},"done":false}`,
+			`{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":40,"eval_count":9}`,
+		} {
+			io.WriteString(w, line+"\n")
+		}
+	})
+
+	p := NewOllamaProvider(srv.URL)
+	var content strings.Builder
+	var done bool
+	resp, err := p.ChatStream(context.Background(), ChatRequest{
+		Model:    "qwen3:8b",
+		Messages: []Message{{Role: "user", Content: "search go"}},
+	}, func(c StreamChunk) {
+		content.WriteString(c.Content)
+		if c.Done {
+			done = true
+		}
+	})
+	if err != nil {
+		t.Fatalf("ChatStream: %v", err)
+	}
+	if content.String() != "Let me check." || resp.Content != "Let me check." {
+		t.Errorf("content = %q / %q", content.String(), resp.Content)
+	}
+	if !done {
+		t.Error("expected a final Done chunk")
+	}
+	if len(resp.ToolCalls) != 1 || resp.ToolCalls[0].Name != "web_search" || resp.ToolCalls[0].Arguments["query"] != "go" {
+		t.Fatalf("tool calls = %+v", resp.ToolCalls)
+	}
+	if !strings.HasPrefix(resp.ToolCalls[0].ID, "call_") {
+		t.Errorf("tool call ID = %q, want generated call_ ID", resp.ToolCalls[0].ID)
+	}
+	if resp.FinishReason != "tool_calls" {
+		t.Errorf("finish reason = %q, want tool_calls", resp.FinishReason)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 49 {
+		t.Errorf("usage = %+v", resp.Usage)
+	}
+}
+
+func TestOllamaChat_ErrorStatus(t *testing.T) {
+	srv, _ := newOllamaTestServer(t, 8192, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error":"model \"missing\" not found, try pulling it first"}`)
+	})
+
+	p := NewOllamaProvider(srv.URL)
+	_, err := p.Chat(context.Background(), ChatRequest{Model: "missing", Messages: []Message{{Role: "user", Content: "hi"}}})
+	if err == nil || !strings.Contains(err.Error(), "try pulling it first") {
+		t.Fatalf("err = %v, want Ollama error message", err)
+	}
+}
+
+func TestOllamaContextWindowDetection(t *testing.T) {
+	srv, shows := newOllamaTestServer(t, 131072, []string{"completion", "vision"}, nil)
+	reg := NewInMemoryRegistry()
+
+	p := NewOllamaProvider(srv.URL, WithOllamaName("local"), WithOllamaNumCtx(65536), WithOllamaRegistry(reg))
+	spec := reg.Resolve("local", "gemma3:12b")
+	if spec == nil {
+		t.Fatal("registry did not resolve the Ollama model")
+	}
+	if spec.ContextWindow != 65536 || !spec.Vision {
+		t.Errorf("spec = %+v, want 65536 context window with vision", spec)
+	}
+	if got := p.ContextWindow(context.Background(), "gemma3:12b"); got != 65536 {
+		t.Errorf("ContextWindow = %d, want 65536", got)
+	}
+	if n := shows.Load(); n != 1 {
+		t.Errorf("/api/show called %d times, want 1 (cached)", n)
+	}
+}
+
+func TestOllamaContextWindow_ServerUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	p := NewOllamaProvider(srv.URL)
+	if got := p.ContextWindow(context.Background(), "llama3.3"); got != ollamaDefaultNumCtx {
+		t.Errorf("ContextWindow = %d, want default %d", got, ollamaDefaultNumCtx)
+	}
+}
+
+func TestListOllamaModels(t *testing.T) {
+	srv, _ := newOllamaTestServer(t, 0, nil, nil)
+
+	models, err := NewOllamaProvider(srv.URL + "/v1").ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want 2", len(models))
+	}
+	if models[0].Name != "qwen3:8b" || models[0].DisplayName() != "qwen3 8.2B Q4_K_M" {
+		t.Errorf("models[0] = %+v (%q)", models[0], models[0].DisplayName())
+	}
+	if models[1].DisplayName() != "custom:latest" {
+		t.Errorf("models[1].DisplayName() = %q, want name fallback", models[1].DisplayName())
+	}
+}
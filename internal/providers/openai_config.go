@@ -88,6 +88,9 @@ func (p *OpenAIProvider) APIBase() string        { return p.apiBase }
 func (p *OpenAIProvider) AuthPrefix() string     { return p.authPrefix }
 func (p *OpenAIProvider) ProviderType() string   { return p.providerType }
 
+// httpClient exposes the client so the registry can scope its proxy.
+func (p *OpenAIProvider) httpClient() *http.Client { return p.client }
+
 // Capabilities implements CapabilitiesAware for pipeline code-path selection.
 func (p *OpenAIProvider) Capabilities() ProviderCapabilities {
 	return ProviderCapabilities{
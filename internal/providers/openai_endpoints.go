@@ -35,6 +35,19 @@ func (p *OpenAIProvider) isTogetherEndpoint() bool {
 	return false
 }
 
+// supportsStructuredOutput returns true for hosts that accept
+// response_format json_schema. Other OpenAI-compatible APIs reject or ignore
+// it, so the agent loop validates their answers instead.
+func (p *OpenAIProvider) supportsStructuredOutput() bool {
+	b := strings.ToLower(p.apiBase)
+	for _, host := range []string{"api.openai.com", "openai.azure.com", "openrouter.ai", "generativelanguage.googleapis.com"} {
+		if strings.Contains(b, host) {
+			return true
+		}
+	}
+	return false
+}
+
 // isDashScopeAPIBase returns true for Alibaba DashScope OpenAI-compatible endpoints.
 func isDashScopeAPIBase(apiBase string) bool {
 	return strings.Contains(strings.ToLower(apiBase), "dashscope")
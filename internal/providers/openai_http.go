@@ -43,6 +43,7 @@ func (p *OpenAIProvider) doRequest(ctx context.Context, body any) (io.ReadCloser
 	if p.siteTitle != "" {
 		httpReq.Header.Set("X-Title", p.siteTitle)
 	}
+	setRequestIDHeader(httpReq)
 
 	resp, err := p.client.Do(httpReq)
 	if err != nil {
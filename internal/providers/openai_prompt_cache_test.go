@@ -0,0 +1,48 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestChat_SendsPromptCacheKey checks that prompt_cache_key from the request
+// options reaches the body sent to a native OpenAI endpoint.
+func TestChat_SendsPromptCacheKey(t *testing.T) {
+	var sent map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+
+	p := newTestOpenAIProvider("https://api.openai.com/v1")
+	// Keep the api.openai.com base (CacheMiddleware only forwards the key to
+	// native endpoints) but deliver the request to the test server.
+	p.client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.URL.Scheme, r.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	_, err := p.Chat(context.Background(), ChatRequest{
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Options:  map[string]any{OptPromptCacheKey: "goclaw-agent-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent["prompt_cache_key"] != "goclaw-agent-1" {
+		t.Errorf("prompt_cache_key = %v, want goclaw-agent-1", sent["prompt_cache_key"])
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
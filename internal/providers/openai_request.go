@@ -187,6 +187,14 @@ func (p *OpenAIProvider) buildRequestBody(model string, req ChatRequest, stream
 		}
 	}
 
+	if v, ok := req.Options[OptSeed]; ok {
+		body["seed"] = v
+	}
+
+	if rf, ok := req.Options[OptResponseFormat].(*ResponseFormat); ok && rf != nil && p.supportsStructuredOutput() {
+		body["response_format"] = rf.openAIPayload()
+	}
+
 	// reasoning_effort is OpenAI-specific; do not send to third-party OpenAI-compatible APIs.
 	if level, ok := req.Options[OptThinkingLevel].(string); ok && level != "" && level != "off" {
 		if openAIModelSupportsReasoningEffort(model) {
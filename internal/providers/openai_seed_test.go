@@ -0,0 +1,20 @@
+package providers
+
+import "testing"
+
+func TestBuildRequestBody_ForwardsSeed(t *testing.T) {
+	p := NewOpenAIProvider("openai", "key", "https://api.openai.com/v1", "gpt-4o")
+
+	body := p.buildRequestBody("gpt-4o-2024-08-06", ChatRequest{
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Options:  map[string]any{OptSeed: int64(7)},
+	}, false)
+	if got, ok := body["seed"]; !ok || got != int64(7) {
+		t.Fatalf("seed = %v (present=%v), want 7", got, ok)
+	}
+
+	body = p.buildRequestBody("gpt-4o", ChatRequest{Messages: []Message{{Role: "user", Content: "hi"}}}, false)
+	if _, ok := body["seed"]; ok {
+		t.Fatal("seed should be omitted when not requested")
+	}
+}
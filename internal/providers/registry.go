@@ -95,6 +95,7 @@ func (r *Registry) RegisterForTenant(tenantID uuid.UUID, provider Provider) {
 			c.Close()
 		}
 	}
+	applyProviderProxy(provider)
 	r.providers[key] = provider
 }
 
@@ -0,0 +1,19 @@
+package providers
+
+import (
+	"net/http"
+
+	"github.com/nextlevelbuilder/goclaw/internal/requestid"
+)
+
+// clientRequestIDHeader is OpenAI's client-supplied request ID header. Other
+// providers ignore it; it still shows up in proxy and gateway logs.
+const clientRequestIDHeader = "X-Client-Request-Id"
+
+// setRequestIDHeader forwards the correlation ID carried by the request
+// context so provider-side logs can be matched to a goclaw request.
+func setRequestIDHeader(req *http.Request) {
+	if id := requestid.FromContext(req.Context()); id != "" {
+		req.Header.Set(clientRequestIDHeader, id)
+	}
+}
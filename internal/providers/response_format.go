@@ -0,0 +1,403 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ResponseFormat requires the final assistant message to be JSON, optionally
+// validating against a JSON schema. Passed to providers as OptResponseFormat;
+// providers with native structured output constrain decoding with it, the
+// agent loop validates the answer either way.
+type ResponseFormat struct {
+	Name   string          `json:"name,omitempty"`
+	Schema json.RawMessage `json:"schema,omitempty"` // nil = any JSON object
+	Strict bool            `json:"strict,omitempty"`
+}
+
+// ParseResponseFormat reads an OpenAI-style response_format value:
+// {"type":"json_object"} or {"type":"json_schema","json_schema":{"name","schema","strict"}}.
+// Returns nil for an empty value or {"type":"text"}.
+func ParseResponseFormat(raw json.RawMessage) (*ResponseFormat, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var v struct {
+		Type       string `json:"type"`
+		JSONSchema *struct {
+			Name   string          `json:"name"`
+			Schema json.RawMessage `json:"schema"`
+			Strict bool            `json:"strict"`
+		} `json:"json_schema"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("invalid response_format: %w", err)
+	}
+	switch v.Type {
+	case "", "text":
+		return nil, nil
+	case "json_object":
+		return &ResponseFormat{}, nil
+	case "json_schema":
+		if v.JSONSchema == nil || len(v.JSONSchema.Schema) == 0 {
+			return nil, fmt.Errorf("response_format json_schema requires json_schema.schema")
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(v.JSONSchema.Schema, &schema); err != nil {
+			return nil, fmt.Errorf("response_format schema must be a JSON object: %w", err)
+		}
+		name := v.JSONSchema.Name
+		if name == "" {
+			name = "response"
+		}
+		return &ResponseFormat{Name: name, Schema: v.JSONSchema.Schema, Strict: v.JSONSchema.Strict}, nil
+	default:
+		return nil, fmt.Errorf("unsupported response_format type %q", v.Type)
+	}
+}
+
+// openAIPayload returns the response_format body field for OpenAI-compatible APIs.
+func (f *ResponseFormat) openAIPayload() map[string]any {
+	if len(f.Schema) == 0 {
+		return map[string]any{"type": "json_object"}
+	}
+	return map[string]any{
+		"type": "json_schema",
+		"json_schema": map[string]any{
+			"name":   f.Name,
+			"schema": f.Schema,
+			"strict": f.Strict,
+		},
+	}
+}
+
+// Instructions describes the required format for the system prompt, so
+// providers without native structured output still know the schema.
+func (f *ResponseFormat) Instructions() string {
+	if len(f.Schema) == 0 {
+		return "Your final answer must be a single JSON object and nothing else: no prose, no code fences."
+	}
+	return "Your final answer must be a single JSON value that validates against the JSON schema below, and nothing else: no prose, no code fences.\n\n" + string(f.Schema)
+}
+
+// Check validates content as the final answer and returns the bare JSON text
+// (code fences stripped). The error describes the first violation found, in a
+// form the model can act on.
+func (f *ResponseFormat) Check(content string) (string, error) {
+	text := stripJSONFence(content)
+	var value any
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return "", fmt.Errorf("not valid JSON: %v", err)
+	}
+	if dec.More() {
+		return "", fmt.Errorf("not valid JSON: unexpected content after the JSON value")
+	}
+	if len(f.Schema) == 0 {
+		if _, ok := value.(map[string]any); !ok {
+			return "", fmt.Errorf("expected a JSON object")
+		}
+		return text, nil
+	}
+	var schema any
+	if err := json.Unmarshal(f.Schema, &schema); err != nil {
+		return "", fmt.Errorf("invalid schema: %w", err)
+	}
+	v := schemaValidator{root: schema}
+	if err := v.validate(schema, value, "$"); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// stripJSONFence removes a surrounding ```json ... ``` block.
+func stripJSONFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") || len(s) < 6 {
+		return s
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "```"), "```")
+	if i := strings.IndexByte(s, '\n'); i >= 0 && !strings.ContainsAny(s[:i], "{[\"") {
+		s = s[i+1:] // drop the language tag
+	}
+	return strings.TrimSpace(s)
+}
+
+// schemaValidator checks values against the JSON schema subset used for
+// structured outputs: type, enum, const, properties, required,
+// additionalProperties, items, anyOf/oneOf/allOf, local $ref, and the
+// length/range bounds. Other keywords are ignored.
+type schemaValidator struct {
+	root any
+}
+
+func (sv schemaValidator) validate(schema, value any, path string) error {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return nil // true/false schemas and malformed nodes accept anything
+	}
+	if ref, ok := s["$ref"].(string); ok {
+		target, err := sv.resolve(ref)
+		if err != nil {
+			return err
+		}
+		return sv.validate(target, value, path)
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		return fmt.Errorf("%s: expected %s, got %s", path, typeNames(t), jsonTypeOf(value))
+	}
+	if c, ok := s["const"]; ok && !jsonEqual(c, value) {
+		return fmt.Errorf("%s: must equal %s", path, compactJSON(c))
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if jsonEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: must be one of %s", path, compactJSON(enum))
+		}
+	}
+
+	for _, sub := range asSlice(s["allOf"]) {
+		if err := sv.validate(sub, value, path); err != nil {
+			return err
+		}
+	}
+	if anyOf := asSlice(s["anyOf"]); len(anyOf) > 0 && sv.countMatches(anyOf, value, path) == 0 {
+		return fmt.Errorf("%s: does not match any allowed schema", path)
+	}
+	if oneOf := asSlice(s["oneOf"]); len(oneOf) > 0 && sv.countMatches(oneOf, value, path) != 1 {
+		return fmt.Errorf("%s: must match exactly one allowed schema", path)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return sv.validateObject(s, v, path)
+	case []any:
+		return sv.validateArray(s, v, path)
+	case string:
+		n := len([]rune(v))
+		if lo, ok := schemaNumber(s["minLength"]); ok && float64(n) < lo {
+			return fmt.Errorf("%s: shorter than %v characters", path, lo)
+		}
+		if hi, ok := schemaNumber(s["maxLength"]); ok && float64(n) > hi {
+			return fmt.Errorf("%s: longer than %v characters", path, hi)
+		}
+		if p, ok := s["pattern"].(string); ok {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(v) {
+				return fmt.Errorf("%s: does not match pattern %q", path, p)
+			}
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		if lo, ok := schemaNumber(s["minimum"]); ok && f < lo {
+			return fmt.Errorf("%s: less than minimum %v", path, lo)
+		}
+		if hi, ok := schemaNumber(s["maximum"]); ok && f > hi {
+			return fmt.Errorf("%s: greater than maximum %v", path, hi)
+		}
+	}
+	return nil
+}
+
+func (sv schemaValidator) validateObject(s map[string]any, obj map[string]any, path string) error {
+	for _, r := range asSlice(s["required"]) {
+		name, _ := r.(string)
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%s: missing required property %q", path, name)
+		}
+	}
+	props, _ := s["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // deterministic first error
+	for _, k := range keys {
+		child := path + "." + k
+		if ps, ok := props[k]; ok {
+			if err := sv.validate(ps, obj[k], child); err != nil {
+				return err
+			}
+			continue
+		}
+		switch ap := s["additionalProperties"].(type) {
+		case bool:
+			if !ap {
+				return fmt.Errorf("%s: unexpected property %q", path, k)
+			}
+		case map[string]any:
+			if err := sv.validate(ap, obj[k], child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sv schemaValidator) validateArray(s map[string]any, arr []any, path string) error {
+	if lo, ok := schemaNumber(s["minItems"]); ok && float64(len(arr)) < lo {
+		return fmt.Errorf("%s: fewer than %v items", path, lo)
+	}
+	if hi, ok := schemaNumber(s["maxItems"]); ok && float64(len(arr)) > hi {
+		return fmt.Errorf("%s: more than %v items", path, hi)
+	}
+	if items, ok := s["items"].(map[string]any); ok {
+		for i, item := range arr {
+			if err := sv.validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sv schemaValidator) countMatches(schemas []any, value any, path string) int {
+	n := 0
+	for _, sub := range schemas {
+		if sv.validate(sub, value, path) == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// resolve follows a local JSON pointer such as "#/$defs/item".
+func (sv schemaValidator) resolve(ref string) (any, error) {
+	if ref == "#" {
+		return sv.root, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q: only local references are allowed", ref)
+	}
+	node := sv.root
+	for _, part := range strings.Split(ref[2:], "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		if node, ok = m[part]; !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+	}
+	return node, nil
+}
+
+func matchesType(t, value any) bool {
+	switch tt := t.(type) {
+	case string:
+		return matchesTypeName(tt, value)
+	case []any:
+		for _, name := range tt {
+			if s, ok := name.(string); ok && matchesTypeName(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesTypeName(name string, value any) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	default:
+		return jsonTypeOf(value) == name
+	}
+}
+
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, n := range list {
+			names = append(names, fmt.Sprint(n))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func schemaNumber(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+// jsonEqual compares a schema literal (decoded with float64 numbers) with a
+// value decoded with json.Number.
+func jsonEqual(a, b any) bool {
+	return compactJSON(a) == compactJSON(b)
+}
+
+func compactJSON(v any) string {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		if err == nil {
+			v = f
+		}
+	}
+	if arr, ok := v.([]any); ok {
+		parts := make([]string, len(arr))
+		for i, e := range arr {
+			parts[i] = compactJSON(e)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+	if obj, ok := v.(map[string]any); ok {
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			kb, _ := json.Marshal(k)
+			parts[i] = string(kb) + ":" + compactJSON(obj[k])
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
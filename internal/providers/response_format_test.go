@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseResponseFormat(t *testing.T) {
+	rf, err := ParseResponseFormat(json.RawMessage(`{"type":"json_schema","json_schema":{"schema":{"type":"object"},"strict":true}}`))
+	if err != nil || rf == nil || rf.Name != "response" || !rf.Strict {
+		t.Fatalf("json_schema: rf = %+v, err = %v", rf, err)
+	}
+	if rf, err := ParseResponseFormat(json.RawMessage(`{"type":"text"}`)); rf != nil || err != nil {
+		t.Errorf("text: rf = %+v, err = %v; want nil, nil", rf, err)
+	}
+	if rf, err := ParseResponseFormat(json.RawMessage(`{"type":"json_object"}`)); err != nil || rf == nil || len(rf.Schema) != 0 {
+		t.Errorf("json_object: rf = %+v, err = %v", rf, err)
+	}
+	for _, bad := range []string{`{"type":"json_schema"}`, `{"type":"xml"}`, `{"type":"json_schema","json_schema":{"schema":[1]}}`} {
+		if _, err := ParseResponseFormat(json.RawMessage(bad)); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+}
+
+func TestResponseFormatCheck(t *testing.T) {
+	rf := &ResponseFormat{Schema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"title": {"type": "string", "minLength": 1},
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}, "maxItems": 2},
+			"score": {"type": ["number", "null"], "minimum": 0}
+		},
+		"required": ["title", "tags"],
+		"additionalProperties": false,
+		"$defs": {"tag": {"type": "string", "enum": ["go", "db"]}}
+	}`)}
+
+	cases := []struct {
+		content string
+		wantErr string
+	}{
+		{`{"title":"x","tags":["go"],"score":1.5}`, ""},
+		{"```json\n{\"title\":\"x\",\"tags\":[],\"score\":null}\n```", ""},
+		{`{"title":"x"}`, `$: missing required property "tags"`},
+		{`{"title":"x","tags":["rust"]}`, `$.tags[0]: must be one of ["go","db"]`},
+		{`{"title":"x","tags":["go","db","go"]}`, `$.tags: more than 2 items`},
+		{`{"title":"x","tags":[],"extra":1}`, `$: unexpected property "extra"`},
+		{`{"title":"x","tags":[],"score":-1}`, `$.score: less than minimum 0`},
+		{`{"title":"","tags":[]}`, `$.title: shorter than 1 characters`},
+		{`Here you go: {"title":"x"}`, `not valid JSON`},
+		{`{"title":"x","tags":[]} {}`, `unexpected content after the JSON value`},
+	}
+	for _, c := range cases {
+		_, err := rf.Check(c.content)
+		switch {
+		case c.wantErr == "" && err != nil:
+			t.Errorf("Check(%s) error: %v", c.content, err)
+		case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+			t.Errorf("Check(%s) = %v, want error containing %q", c.content, err, c.wantErr)
+		}
+	}
+
+	if _, err := (&ResponseFormat{}).Check(`[1,2]`); err == nil {
+		t.Error("json_object format accepted an array")
+	}
+}
+
+func TestBuildRequestBody_ResponseFormat(t *testing.T) {
+	rf := &ResponseFormat{Name: "answer", Schema: json.RawMessage(`{"type":"object"}`), Strict: true}
+	req := ChatRequest{
+		Messages: []Message{{Role: "user", Content: "hi"}},
+		Options:  map[string]any{OptResponseFormat: rf},
+	}
+
+	body := NewOpenAIProvider("openai", "key", "https://api.openai.com/v1", "gpt-4o").buildRequestBody("gpt-4o", req, false)
+	got, ok := body["response_format"].(map[string]any)
+	if !ok || got["type"] != "json_schema" {
+		t.Fatalf("response_format = %v, want json_schema", body["response_format"])
+	}
+
+	body = NewOpenAIProvider("deepseek", "key", "https://api.deepseek.com/v1", "deepseek-chat").buildRequestBody("deepseek-chat", req, false)
+	if _, ok := body["response_format"]; ok {
+		t.Error("response_format sent to a host without json_schema support")
+	}
+}
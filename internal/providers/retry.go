@@ -4,13 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log/slog"
-	"math"
-	"math/rand"
 	"net"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 )
 
 // RetryConfig configures retry behavior for provider requests.
@@ -19,6 +18,7 @@ type RetryConfig struct {
 	MinDelay time.Duration // initial delay (default 300ms)
 	MaxDelay time.Duration // delay cap (default 30s)
 	Jitter   float64       // jitter factor ±N (default 0.1 = ±10%)
+	Budget   *retry.Budget // caps retries across calls; nil = uncapped
 }
 
 // RetryHookFunc is called before each retry attempt.
@@ -40,12 +40,16 @@ func retryHookFromContext(ctx context.Context) RetryHookFunc {
 }
 
 // DefaultRetryConfig returns sensible defaults matching TS provider retry behavior.
+// Each call gets its own retry budget, so every provider instance (one per
+// provider and tenant) is throttled on its own: an outage at one upstream
+// does not use up the retries of the others.
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
 		Attempts: 3,
 		MinDelay: 300 * time.Millisecond,
 		MaxDelay: 30 * time.Second,
 		Jitter:   0.1,
+		Budget:   retry.NewBudget(100, 0.1),
 	}
 }
 
@@ -60,6 +64,12 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.Status, e.Body)
 }
 
+// HTTPStatus implements retry.StatusCoder.
+func (e *HTTPError) HTTPStatus() int { return e.Status }
+
+// RetryAfterDelay implements retry.RetryAfterer.
+func (e *HTTPError) RetryAfterDelay() time.Duration { return e.RetryAfter }
+
 // IsRetryableError checks if an error is retryable.
 // Retryable: 429 (rate limit), 500, 502, 503, 504, connection errors, timeouts.
 func IsRetryableError(err error) bool {
@@ -95,81 +105,37 @@ func IsRetryableError(err error) bool {
 	return false
 }
 
-// RetryDo executes fn with retry logic using exponential backoff and jitter.
-func RetryDo[T any](ctx context.Context, cfg RetryConfig, fn func() (T, error)) (T, error) {
-	if cfg.Attempts <= 0 {
-		cfg.Attempts = 1
-	}
-
-	var lastErr error
-	var zero T
-
-	for attempt := 1; attempt <= cfg.Attempts; attempt++ {
-		result, err := fn()
-		if err == nil {
-			return result, nil
-		}
-
-		lastErr = err
-
-		// Don't retry if not retryable or last attempt
-		if !IsRetryableError(err) || attempt == cfg.Attempts {
-			return zero, err
-		}
-
-		// Compute delay
-		delay := computeDelay(cfg, attempt, err)
-
-		slog.Debug("provider retry",
-			"attempt", attempt,
-			"maxAttempts", cfg.Attempts,
-			"delay", delay,
-			"error", err.Error(),
-		)
-
-		// Notify retry hook (for placeholder updates, etc.)
-		if hook := retryHookFromContext(ctx); hook != nil {
-			hook(attempt, cfg.Attempts, err)
-		}
-
-		// Wait with context cancellation support
-		select {
-		case <-ctx.Done():
-			return zero, ctx.Err()
-		case <-time.After(delay):
-		}
-	}
-
-	return zero, lastErr
+func (c RetryConfig) backoff() retry.Backoff {
+	return retry.Backoff{Attempts: c.Attempts, MinDelay: c.MinDelay, MaxDelay: c.MaxDelay, Jitter: c.Jitter}
 }
 
-// computeDelay calculates the retry delay with exponential backoff, jitter, and Retry-After support.
-func computeDelay(cfg RetryConfig, attempt int, err error) time.Duration {
-	// Check for Retry-After header
-	var httpErr *HTTPError
-	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
-		return httpErr.RetryAfter
-	}
-
-	// Exponential backoff: minDelay * 2^(attempt-1)
-	delay := float64(cfg.MinDelay) * math.Pow(2, float64(attempt-1))
-
-	// Cap at maxDelay
-	if time.Duration(delay) > cfg.MaxDelay {
-		delay = float64(cfg.MaxDelay)
+// classifyProviderError maps IsRetryableError onto retry classes.
+func classifyProviderError(err error) retry.Class {
+	if !IsRetryableError(err) {
+		return retry.ClassPermanent
 	}
-
-	// Apply jitter: ±jitter%
-	if cfg.Jitter > 0 {
-		jitterRange := delay * cfg.Jitter
-		delay += (rand.Float64()*2 - 1) * jitterRange
-	}
-
-	if delay < 0 {
-		delay = float64(cfg.MinDelay)
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.Status == 429 {
+		return retry.ClassRateLimited
 	}
+	return retry.ClassTransient
+}
 
-	return time.Duration(delay)
+// RetryDo executes fn with retry logic using exponential backoff and jitter.
+func RetryDo[T any](ctx context.Context, cfg RetryConfig, fn func() (T, error)) (T, error) {
+	hook := retryHookFromContext(ctx)
+	return retry.DoValue(ctx, retry.Policy{
+		Name:     "provider",
+		Backoff:  cfg.backoff(),
+		Classify: classifyProviderError,
+		Budget:   cfg.Budget,
+		OnRetry: func(attempt int, err error, _ time.Duration) {
+			// Notify retry hook (for placeholder updates, etc.)
+			if hook != nil {
+				hook(attempt, cfg.Attempts, err)
+			}
+		},
+	}, func(context.Context) (T, error) { return fn() })
 }
 
 // ParseRetryAfter parses a Retry-After header value (seconds or HTTP-date).
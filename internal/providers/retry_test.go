@@ -7,6 +7,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
 )
 
 // --- IsRetryableError ---
@@ -45,102 +47,93 @@ func TestIsRetryableError(t *testing.T) {
 	}
 }
 
-// --- computeDelay ---
+// --- backoff ---
 
-func TestComputeDelay_ExponentialBackoff(t *testing.T) {
+func TestRetryBackoff_ExponentialBackoff(t *testing.T) {
 	cfg := RetryConfig{
 		MinDelay: 100 * time.Millisecond,
 		MaxDelay: 10 * time.Second,
 		Jitter:   0, // no jitter for deterministic test
 	}
-	err := &HTTPError{Status: 500}
 
 	// attempt 1: 100ms * 2^0 = 100ms
-	d1 := computeDelay(cfg, 1, err)
+	d1 := cfg.backoff().Delay(1)
 	if d1 != 100*time.Millisecond {
 		t.Fatalf("attempt 1: got %v, want 100ms", d1)
 	}
 
 	// attempt 2: 100ms * 2^1 = 200ms
-	d2 := computeDelay(cfg, 2, err)
+	d2 := cfg.backoff().Delay(2)
 	if d2 != 200*time.Millisecond {
 		t.Fatalf("attempt 2: got %v, want 200ms", d2)
 	}
 
 	// attempt 3: 100ms * 2^2 = 400ms
-	d3 := computeDelay(cfg, 3, err)
+	d3 := cfg.backoff().Delay(3)
 	if d3 != 400*time.Millisecond {
 		t.Fatalf("attempt 3: got %v, want 400ms", d3)
 	}
 
 	// attempt 4: 100ms * 2^3 = 800ms
-	d4 := computeDelay(cfg, 4, err)
+	d4 := cfg.backoff().Delay(4)
 	if d4 != 800*time.Millisecond {
 		t.Fatalf("attempt 4: got %v, want 800ms", d4)
 	}
 }
 
-func TestComputeDelay_CappedAtMaxDelay(t *testing.T) {
+func TestRetryBackoff_CappedAtMaxDelay(t *testing.T) {
 	cfg := RetryConfig{
 		MinDelay: 1 * time.Second,
 		MaxDelay: 5 * time.Second,
 		Jitter:   0,
 	}
-	err := &HTTPError{Status: 500}
 
 	// attempt 10: 1s * 2^9 = 512s → capped at 5s
-	d := computeDelay(cfg, 10, err)
+	d := cfg.backoff().Delay(10)
 	if d != 5*time.Second {
 		t.Fatalf("attempt 10: got %v, want 5s (capped)", d)
 	}
 }
 
-func TestComputeDelay_JitterRange(t *testing.T) {
+func TestRetryBackoff_JitterRange(t *testing.T) {
 	cfg := RetryConfig{
 		MinDelay: 1 * time.Second,
 		MaxDelay: 30 * time.Second,
 		Jitter:   0.25, // ±25%
 	}
-	err := &HTTPError{Status: 500}
 
 	// attempt 1: base = 1s, jitter ±25% → [750ms, 1250ms]
 	min := 750 * time.Millisecond
 	max := 1250 * time.Millisecond
 
 	for range 100 {
-		d := computeDelay(cfg, 1, err)
+		d := cfg.backoff().Delay(1)
 		if d < min || d > max {
 			t.Fatalf("jitter out of range: got %v, want [%v, %v]", d, min, max)
 		}
 	}
 }
 
-func TestComputeDelay_NeverNegative(t *testing.T) {
+func TestRetryBackoff_NeverNegative(t *testing.T) {
 	cfg := RetryConfig{
 		MinDelay: 10 * time.Millisecond,
 		MaxDelay: 100 * time.Millisecond,
 		Jitter:   0.9, // extreme jitter
 	}
-	err := &HTTPError{Status: 500}
 
 	for range 200 {
-		d := computeDelay(cfg, 1, err)
+		d := cfg.backoff().Delay(1)
 		if d < 0 {
 			t.Fatalf("negative delay: %v", d)
 		}
 	}
 }
 
-func TestComputeDelay_RetryAfterOverride(t *testing.T) {
-	cfg := RetryConfig{
-		MinDelay: 100 * time.Millisecond,
-		MaxDelay: 30 * time.Second,
-		Jitter:   0.1,
-	}
-	// HTTPError with RetryAfter should override computed delay
-	err := &HTTPError{Status: 429, RetryAfter: 42 * time.Second}
+func TestRetryAfter_FromHTTPError(t *testing.T) {
+	// retry.DoValue waits the Retry-After hint instead of the backoff delay.
+	err := fmt.Errorf("provider: %w", &HTTPError{Status: 429, RetryAfter: 42 * time.Second})
 
-	d := computeDelay(cfg, 1, err)
+	d := retry.RetryAfter(err)
 	if d != 42*time.Second {
 		t.Fatalf("expected Retry-After override: got %v, want 42s", d)
 	}
@@ -328,3 +321,21 @@ func TestHTTPError_ErrorString(t *testing.T) {
 		t.Fatalf("got %q, want %q", got, want)
 	}
 }
+
+func TestRetryDo_BudgetPerProvider(t *testing.T) {
+	a, b := DefaultRetryConfig(), DefaultRetryConfig()
+	a.MinDelay, a.MaxDelay = time.Millisecond, time.Millisecond
+
+	_, err := RetryDo(context.Background(), a, func() (string, error) {
+		return "", &HTTPError{Status: 503}
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got := a.Budget.Available(); got != 98 {
+		t.Errorf("failing provider budget = %v, want 98", got)
+	}
+	if got := b.Budget.Available(); got != 100 {
+		t.Errorf("other provider budget = %v, want 100 (untouched)", got)
+	}
+}
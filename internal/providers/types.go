@@ -10,6 +10,7 @@ import (
 const (
 	OptMaxTokens       = "max_tokens"
 	OptTemperature     = "temperature"
+	OptSeed            = "seed" // int64; sent only by providers with seeded sampling (OpenAI-compat)
 	OptThinkingLevel   = "thinking_level"
 	OptReasoningEffort = "reasoning_effort"
 	OptEnableThinking  = "enable_thinking"
@@ -18,6 +19,9 @@ const (
 	// from ChatResponse.Thinking and onChunk callbacks. Usage.ThinkingTokens
 	// and RawAssistantContent are preserved (billing + tool passback safety).
 	OptStripThinking = "strip_thinking"
+	// OptResponseFormat (*ResponseFormat) asks for a JSON final answer. Sent
+	// only by providers with native structured output; ignored by the rest.
+	OptResponseFormat = "response_format"
 
 	// Middleware-related options (Phase 2 will use these)
 	OptServiceTier          = "service_tier"
@@ -0,0 +1,430 @@
+package remotesync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// syncedMarker marks a skill directory written by the sync, so skills the
+// server drops are removed without touching the user's own skills.
+const syncedMarker = ".remote-sync"
+
+// ApplyAgentStore is the subset of store.AgentStore the applier needs.
+type ApplyAgentStore interface {
+	List(ctx context.Context, ownerID string) ([]store.AgentData, error)
+	Create(ctx context.Context, agent *store.AgentData) error
+	Update(ctx context.Context, id uuid.UUID, updates map[string]any) error
+	GetAgentContextFiles(ctx context.Context, agentID uuid.UUID) ([]store.AgentContextFileData, error)
+	SetAgentContextFile(ctx context.Context, agentID uuid.UUID, fileName, content string) error
+}
+
+// ApplyOptions configures how snapshots land on the local instance.
+type ApplyOptions struct {
+	WorkspaceRoot string // new agents get <root>/<agent_key>
+	OwnerID       string // owner of agents created by the sync
+	SkillsDir     string // global skills directory; "" skips skills
+	RunCron       bool   // keep synced cron jobs enabled (default: disabled so jobs don't run twice)
+}
+
+// ApplyResult counts the changes one Apply made.
+type ApplyResult struct {
+	AgentsCreated int `json:"agents_created"`
+	AgentsUpdated int `json:"agents_updated"`
+	FilesWritten  int `json:"context_files_written"`
+	MemoryWritten int `json:"memory_written"`
+	MemoryDeleted int `json:"memory_deleted"`
+	CronChanged   int `json:"cron_changed"`
+	SkillsWritten int `json:"skills_written"`
+	SkillsRemoved int `json:"skills_removed"`
+
+	// Incomplete is set when a changed memory document arrived without
+	// content (the local copy diverged before the cursor); the next pull
+	// should ask for everything.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// Changed reports whether anything was written.
+func (r ApplyResult) Changed() bool {
+	return r.AgentsCreated+r.AgentsUpdated+r.FilesWritten+r.MemoryWritten+r.MemoryDeleted+
+		r.CronChanged+r.SkillsWritten+r.SkillsRemoved > 0
+}
+
+// Applier writes snapshots into the local stores. Cron and memory are optional.
+type Applier struct {
+	agents ApplyAgentStore
+	cron   store.CronStore
+	memory store.MemoryStore
+	opts   ApplyOptions
+
+	onAgentChange  func(agentID uuid.UUID, agentKey string)
+	onSkillsChange func()
+}
+
+// NewApplier creates a snapshot applier.
+func NewApplier(agents ApplyAgentStore, cron store.CronStore, memory store.MemoryStore, opts ApplyOptions) *Applier {
+	return &Applier{agents: agents, cron: cron, memory: memory, opts: opts}
+}
+
+// SetOnAgentChange sets the callback run after an agent or its context files changed.
+func (a *Applier) SetOnAgentChange(fn func(agentID uuid.UUID, agentKey string)) {
+	a.onAgentChange = fn
+}
+
+// SetOnSkillsChange sets the callback run after skill files changed.
+func (a *Applier) SetOnSkillsChange(fn func()) { a.onSkillsChange = fn }
+
+// Apply brings the local instance in line with snap. Errors on one agent
+// are logged and the rest still applied; the first error is returned. A
+// snapshot with an agent key that is not a safe path name is rejected
+// before anything is written, since new agents get <root>/<agent_key>.
+func (a *Applier) Apply(ctx context.Context, snap *Snapshot) (ApplyResult, error) {
+	var res ApplyResult
+	for _, st := range snap.Agents {
+		if !safePathName(st.AgentKey) {
+			slog.Warn("security.remotesync.agent_key_rejected", "agent", st.AgentKey)
+			return res, fmt.Errorf("invalid agent key %q", st.AgentKey)
+		}
+	}
+	local, err := a.agents.List(ctx, "")
+	if err != nil {
+		return res, fmt.Errorf("list agents: %w", err)
+	}
+	byKey := make(map[string]*store.AgentData, len(local))
+	for i := range local {
+		byKey[local[i].AgentKey] = &local[i]
+	}
+
+	var errs []error
+	for _, st := range snap.Agents {
+		if err := a.applyAgent(ctx, byKey[st.AgentKey], st, &res); err != nil {
+			slog.Warn("remotesync: apply agent failed", "agent", st.AgentKey, "error", err)
+			errs = append(errs, fmt.Errorf("agent %s: %w", st.AgentKey, err))
+		}
+	}
+	if a.opts.SkillsDir != "" {
+		if err := a.applySkills(snap.Skills, &res); err != nil {
+			errs = append(errs, fmt.Errorf("skills: %w", err))
+		}
+	}
+	return res, errors.Join(errs...)
+}
+
+func (a *Applier) applyAgent(ctx context.Context, ag *store.AgentData, st AgentState, res *ApplyResult) error {
+	changed := false
+	if ag == nil {
+		ag = &store.AgentData{
+			TenantID:            store.TenantIDFromContext(ctx),
+			AgentKey:            st.AgentKey,
+			OwnerID:             a.opts.OwnerID,
+			Workspace:           filepath.Join(a.opts.WorkspaceRoot, st.AgentKey),
+			RestrictToWorkspace: true,
+			Status:              store.AgentStatusActive,
+		}
+		copyAgentState(ag, st)
+		if err := a.agents.Create(ctx, ag); err != nil {
+			return fmt.Errorf("create: %w", err)
+		}
+		res.AgentsCreated++
+		changed = true
+	} else if updates := agentUpdates(ag, st); len(updates) > 0 {
+		if err := a.agents.Update(ctx, ag.ID, updates); err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+		res.AgentsUpdated++
+		changed = true
+	}
+
+	n, err := a.applyContextFiles(ctx, ag.ID, st.ContextFiles)
+	res.FilesWritten += n
+	changed = changed || n > 0
+	if changed && a.onAgentChange != nil {
+		a.onAgentChange(ag.ID, ag.AgentKey)
+	}
+	if err != nil {
+		return err
+	}
+	if a.memory != nil {
+		if err := a.applyMemory(ctx, ag.ID.String(), st.Memory, res); err != nil {
+			return err
+		}
+	}
+	if a.cron != nil {
+		if err := a.applyCron(ctx, ag.ID.String(), st.CronJobs, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyAgentState(ag *store.AgentData, st AgentState) {
+	ag.DisplayName = st.DisplayName
+	ag.Provider = st.Provider
+	ag.Model = st.Model
+	ag.AgentType = st.AgentType
+	ag.ContextWindow = st.ContextWindow
+	ag.MaxToolIterations = st.MaxToolIterations
+	ag.Emoji = st.Emoji
+	ag.AgentDescription = st.AgentDescription
+	ag.ThinkingLevel = st.ThinkingLevel
+	ag.MaxTokens = st.MaxTokens
+	ag.ToolsConfig = st.ToolsConfig
+	ag.MemoryConfig = st.MemoryConfig
+	ag.CompactionConfig = st.CompactionConfig
+	ag.OtherConfig = st.OtherConfig
+	if ag.AgentType == "" {
+		ag.AgentType = store.AgentTypeOpen
+	}
+	if len(ag.CompactionConfig) == 0 {
+		ag.CompactionConfig = json.RawMessage(`{}`)
+	}
+	if len(ag.MemoryConfig) == 0 {
+		ag.MemoryConfig = json.RawMessage(`{"enabled":true}`)
+	}
+}
+
+// agentUpdates returns the columns that differ between the local agent and st.
+func agentUpdates(ag *store.AgentData, st AgentState) map[string]any {
+	updates := map[string]any{}
+	setStr := func(col, local, remote string) {
+		if local != remote {
+			updates[col] = remote
+		}
+	}
+	setInt := func(col string, local, remote int) {
+		if local != remote {
+			updates[col] = remote
+		}
+	}
+	setJSON := func(col string, local, remote json.RawMessage) {
+		if len(remote) > 0 && !bytes.Equal(local, remote) {
+			updates[col] = []byte(remote)
+		}
+	}
+	setStr("display_name", ag.DisplayName, st.DisplayName)
+	setStr("provider", ag.Provider, st.Provider)
+	setStr("model", ag.Model, st.Model)
+	if st.AgentType != "" {
+		setStr("agent_type", ag.AgentType, st.AgentType)
+	}
+	setInt("context_window", ag.ContextWindow, st.ContextWindow)
+	setInt("max_tool_iterations", ag.MaxToolIterations, st.MaxToolIterations)
+	setStr("emoji", ag.Emoji, st.Emoji)
+	setStr("agent_description", ag.AgentDescription, st.AgentDescription)
+	setStr("thinking_level", ag.ThinkingLevel, st.ThinkingLevel)
+	setInt("max_tokens", ag.MaxTokens, st.MaxTokens)
+	setJSON("tools_config", ag.ToolsConfig, st.ToolsConfig)
+	setJSON("memory_config", ag.MemoryConfig, st.MemoryConfig)
+	setJSON("compaction_config", ag.CompactionConfig, st.CompactionConfig)
+	setJSON("other_config", ag.OtherConfig, st.OtherConfig)
+	return updates
+}
+
+func (a *Applier) applyContextFiles(ctx context.Context, agentID uuid.UUID, files map[string]string) (int, error) {
+	if len(files) == 0 {
+		return 0, nil
+	}
+	existing, err := a.agents.GetAgentContextFiles(ctx, agentID)
+	if err != nil {
+		return 0, fmt.Errorf("context files: %w", err)
+	}
+	current := make(map[string]string, len(existing))
+	for _, f := range existing {
+		current[f.FileName] = f.Content
+	}
+	written := 0
+	for name, content := range files {
+		if c, ok := current[name]; ok && c == content {
+			continue
+		}
+		if err := a.agents.SetAgentContextFile(ctx, agentID, name, content); err != nil {
+			return written, fmt.Errorf("context file %s: %w", name, err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// applyMemory mirrors the agent-level memory documents of one agent.
+func (a *Applier) applyMemory(ctx context.Context, agentID string, docs []MemoryDoc, res *ApplyResult) error {
+	existing, err := a.memory.ListAllDocuments(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("memory documents: %w", err)
+	}
+	local := map[string]string{}
+	for _, d := range existing {
+		if d.UserID == "" {
+			local[d.Path] = d.Hash
+		}
+	}
+
+	upstream := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		upstream[d.Path] = true
+		if h, ok := local[d.Path]; ok && h == d.Hash {
+			continue
+		}
+		if d.Content == nil {
+			res.Incomplete = true
+			continue
+		}
+		if err := a.memory.PutDocument(ctx, agentID, "", d.Path, *d.Content); err != nil {
+			return fmt.Errorf("memory %s: %w", d.Path, err)
+		}
+		if err := a.memory.IndexDocument(ctx, agentID, "", d.Path); err != nil {
+			slog.Warn("remotesync: index memory failed", "agent", agentID, "path", d.Path, "error", err)
+		}
+		res.MemoryWritten++
+	}
+	for path := range local {
+		if upstream[path] {
+			continue
+		}
+		if err := a.memory.DeleteDocument(ctx, agentID, "", path); err != nil {
+			return fmt.Errorf("memory %s: %w", path, err)
+		}
+		res.MemoryDeleted++
+	}
+	return nil
+}
+
+// applyCron mirrors one agent's cron jobs, matched by name.
+func (a *Applier) applyCron(ctx context.Context, agentID string, jobs []CronJobState, res *ApplyResult) error {
+	local := map[string]store.CronJob{}
+	for _, j := range a.cron.ListJobs(ctx, true, agentID, "") {
+		local[j.Name] = j
+	}
+
+	upstream := make(map[string]bool, len(jobs))
+	for _, up := range jobs {
+		upstream[up.Name] = true
+		enabled := up.Enabled && a.opts.RunCron
+		j, ok := local[up.Name]
+		if !ok {
+			job, err := a.cron.AddJob(ctx, up.Name, up.Schedule, up.Message, up.Deliver, up.DeliverChannel, up.DeliverTo, agentID, up.UserID)
+			if err != nil {
+				return fmt.Errorf("cron %s: %w", up.Name, err)
+			}
+			if job.Enabled != enabled {
+				if err := a.cron.EnableJob(ctx, job.ID, enabled); err != nil {
+					return fmt.Errorf("cron %s: %w", up.Name, err)
+				}
+			}
+			res.CronChanged++
+			continue
+		}
+		if !cronChanged(j, up, enabled) {
+			continue
+		}
+		schedule := up.Schedule
+		if _, err := a.cron.UpdateJob(ctx, j.ID, store.CronJobPatch{
+			Enabled:        &enabled,
+			Schedule:       &schedule,
+			Message:        up.Message,
+			Deliver:        &up.Deliver,
+			DeliverChannel: &up.DeliverChannel,
+			DeliverTo:      &up.DeliverTo,
+		}); err != nil {
+			return fmt.Errorf("cron %s: %w", up.Name, err)
+		}
+		res.CronChanged++
+	}
+	for name, j := range local {
+		if upstream[name] {
+			continue
+		}
+		if err := a.cron.RemoveJob(ctx, j.ID); err != nil {
+			return fmt.Errorf("cron %s: %w", name, err)
+		}
+		res.CronChanged++
+	}
+	return nil
+}
+
+func cronChanged(j store.CronJob, up CronJobState, enabled bool) bool {
+	if j.Enabled != enabled || j.Payload.Message != up.Message || j.Deliver != up.Deliver ||
+		j.DeliverChannel != up.DeliverChannel || j.DeliverTo != up.DeliverTo {
+		return true
+	}
+	a, _ := json.Marshal(j.Schedule)
+	b, _ := json.Marshal(up.Schedule)
+	return !bytes.Equal(a, b)
+}
+
+// applySkills writes synced skills into the global skills directory and
+// removes the ones the server dropped. A local skill with the same slug
+// that the sync didn't create is never overwritten.
+func (a *Applier) applySkills(list []SkillState, res *ApplyResult) error {
+	dir := a.opts.SkillsDir
+	upstream := make(map[string]bool, len(list))
+	for _, sk := range list {
+		if !safePathName(sk.Slug) {
+			slog.Warn("remotesync: skipping skill with invalid slug", "slug", sk.Slug)
+			continue
+		}
+		upstream[sk.Slug] = true
+		skillDir := filepath.Join(dir, sk.Slug)
+		skillFile := filepath.Join(skillDir, "SKILL.md")
+		if _, err := os.Stat(skillDir); err == nil && !isSynced(skillDir) {
+			continue
+		}
+		if data, err := os.ReadFile(skillFile); err == nil && string(data) == sk.Content {
+			continue
+		}
+		if violations, safe := skills.GuardSkillContent(sk.Content); !safe {
+			slog.Warn("security.remotesync.skill_rejected", "slug", sk.Slug, "first_rule", violations[0].Reason)
+			continue
+		}
+		if err := os.MkdirAll(skillDir, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(skillDir, syncedMarker), nil, 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(skillFile, []byte(sk.Content), 0o644); err != nil {
+			return err
+		}
+		res.SkillsWritten++
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || upstream[e.Name()] || !isSynced(filepath.Join(dir, e.Name())) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+		res.SkillsRemoved++
+	}
+
+	if res.SkillsWritten+res.SkillsRemoved > 0 && a.onSkillsChange != nil {
+		a.onSkillsChange()
+	}
+	return nil
+}
+
+func isSynced(skillDir string) bool {
+	_, err := os.Stat(filepath.Join(skillDir, syncedMarker))
+	return err == nil
+}
+
+// safePathName reports whether s is usable as a single path element under a
+// sync-managed directory: non-empty, no separator and no leading dot.
+func safePathName(s string) bool {
+	return s != "" && s == filepath.Base(s) && !strings.HasPrefix(s, ".")
+}
@@ -0,0 +1,144 @@
+package remotesync
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/skills"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// ExportAgentStore is the subset of store.AgentStore the exporter needs.
+type ExportAgentStore interface {
+	List(ctx context.Context, ownerID string) ([]store.AgentData, error)
+	GetAgentContextFiles(ctx context.Context, agentID uuid.UUID) ([]store.AgentContextFileData, error)
+}
+
+// SkillLister lists the skills visible to agents (implemented by *skills.Loader).
+type SkillLister interface {
+	ListSkills(ctx context.Context) []skills.Info
+}
+
+// Exporter builds snapshots on the server. Every source but agents is optional.
+type Exporter struct {
+	agents ExportAgentStore
+	cron   store.CronStore
+	memory store.MemoryStore
+	skills SkillLister
+}
+
+// NewExporter creates a snapshot builder.
+func NewExporter(agents ExportAgentStore, cron store.CronStore, memory store.MemoryStore, skills SkillLister) *Exporter {
+	return &Exporter{agents: agents, cron: cron, memory: memory, skills: skills}
+}
+
+// Build snapshots the active agents of the tenant in ctx. Memory documents
+// updated after since carry their content; older ones are listed by hash.
+func (e *Exporter) Build(ctx context.Context, since time.Time) (*Snapshot, error) {
+	agents, err := e.agents.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("list agents: %w", err)
+	}
+
+	snap := &Snapshot{GeneratedAt: time.Now().UTC(), Agents: []AgentState{}, Skills: []SkillState{}}
+	for _, ag := range agents {
+		if ag.Status != store.AgentStatusActive {
+			continue
+		}
+		st, err := e.agentState(ctx, ag, since)
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: %w", ag.AgentKey, err)
+		}
+		snap.Agents = append(snap.Agents, st)
+	}
+
+	if e.skills != nil {
+		for _, sk := range e.skills.ListSkills(ctx) {
+			if sk.Source == "builtin" {
+				continue // every instance ships its own bundled skills
+			}
+			data, err := os.ReadFile(sk.Path)
+			if err != nil {
+				slog.Warn("remotesync: read skill failed", "slug", sk.Slug, "error", err)
+				continue
+			}
+			snap.Skills = append(snap.Skills, SkillState{Slug: sk.Slug, Content: string(data)})
+		}
+	}
+	return snap, nil
+}
+
+func (e *Exporter) agentState(ctx context.Context, ag store.AgentData, since time.Time) (AgentState, error) {
+	st := AgentState{
+		AgentKey:          ag.AgentKey,
+		DisplayName:       ag.DisplayName,
+		Provider:          ag.Provider,
+		Model:             ag.Model,
+		AgentType:         ag.AgentType,
+		ContextWindow:     ag.ContextWindow,
+		MaxToolIterations: ag.MaxToolIterations,
+		Emoji:             ag.Emoji,
+		AgentDescription:  ag.AgentDescription,
+		ThinkingLevel:     ag.ThinkingLevel,
+		MaxTokens:         ag.MaxTokens,
+		ToolsConfig:       ag.ToolsConfig,
+		MemoryConfig:      ag.MemoryConfig,
+		CompactionConfig:  ag.CompactionConfig,
+		OtherConfig:       ag.OtherConfig,
+		Memory:            []MemoryDoc{},
+		CronJobs:          []CronJobState{},
+	}
+
+	files, err := e.agents.GetAgentContextFiles(ctx, ag.ID)
+	if err != nil {
+		return st, fmt.Errorf("context files: %w", err)
+	}
+	if len(files) > 0 {
+		st.ContextFiles = make(map[string]string, len(files))
+		for _, f := range files {
+			st.ContextFiles[f.FileName] = f.Content
+		}
+	}
+
+	if e.memory != nil {
+		docs, err := e.memory.ListAllDocuments(ctx, ag.ID.String())
+		if err != nil {
+			return st, fmt.Errorf("memory documents: %w", err)
+		}
+		for _, d := range docs {
+			if d.UserID != "" {
+				continue // per-user memory stays on the server
+			}
+			doc := MemoryDoc{Path: d.Path, Hash: d.Hash}
+			if d.UpdatedAt > since.UnixMilli() {
+				content, err := e.memory.GetDocument(ctx, ag.ID.String(), "", d.Path)
+				if err != nil {
+					return st, fmt.Errorf("memory document %s: %w", d.Path, err)
+				}
+				doc.Content = &content
+			}
+			st.Memory = append(st.Memory, doc)
+		}
+	}
+
+	if e.cron != nil {
+		for _, j := range e.cron.ListJobs(ctx, true, ag.ID.String(), "") {
+			st.CronJobs = append(st.CronJobs, CronJobState{
+				Name:           j.Name,
+				UserID:         j.UserID,
+				Enabled:        j.Enabled,
+				Schedule:       j.Schedule,
+				Message:        j.Payload.Message,
+				Deliver:        j.Deliver,
+				DeliverChannel: j.DeliverChannel,
+				DeliverTo:      j.DeliverTo,
+			})
+		}
+	}
+	return st, nil
+}
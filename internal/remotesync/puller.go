@@ -0,0 +1,193 @@
+package remotesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// DefaultInterval is the pull interval when none is configured.
+const DefaultInterval = 5 * time.Minute
+
+// maxSnapshotBytes caps the sealed snapshot read from the server.
+const maxSnapshotBytes = 64 << 20
+
+// SnapshotPath is the server route that publishes snapshots.
+const SnapshotPath = "/v1/sync/snapshot"
+
+// Puller periodically fetches the server's snapshot and applies it.
+type Puller struct {
+	upstream  string
+	token     string
+	key       string
+	applier   *Applier
+	client    *http.Client
+	statePath string // "" = in-memory only
+
+	mu     sync.Mutex
+	cursor time.Time // server GeneratedAt of the last complete pull
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewPuller creates a puller for the server at upstream (base URL). token
+// authenticates as an admin on the server; key decrypts the snapshot.
+// statePath records the delta cursor across restarts.
+func NewPuller(upstream, token, key string, applier *Applier, statePath string) *Puller {
+	p := &Puller{
+		upstream:  strings.TrimRight(upstream, "/"),
+		token:     token,
+		key:       key,
+		applier:   applier,
+		client:    &http.Client{Timeout: 2 * time.Minute, Transport: netproxy.Transport(netproxy.ScopeDefault)},
+		statePath: statePath,
+		stopCh:    make(chan struct{}),
+	}
+	p.load()
+	return p
+}
+
+// Start pulls immediately and then every interval.
+func (p *Puller) Start(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.pullLogged()
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-p.stopCh:
+				return
+			case <-t.C:
+				p.pullLogged()
+			}
+		}
+	}()
+}
+
+// Stop halts the polling loop.
+func (p *Puller) Stop() {
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+func (p *Puller) pullLogged() {
+	res, err := p.PullOnce(context.Background())
+	if err != nil {
+		slog.Warn("remotesync: pull failed", "upstream", p.upstream, "error", err)
+		return
+	}
+	if res.Changed() {
+		slog.Info("remotesync: applied server state",
+			"agents_created", res.AgentsCreated, "agents_updated", res.AgentsUpdated,
+			"files", res.FilesWritten, "memory_written", res.MemoryWritten, "memory_deleted", res.MemoryDeleted,
+			"cron", res.CronChanged, "skills_written", res.SkillsWritten, "skills_removed", res.SkillsRemoved)
+	}
+}
+
+// PullOnce fetches one snapshot and applies it. The cursor only advances
+// after a complete apply, so failed or partial pulls are retried in full.
+func (p *Puller) PullOnce(ctx context.Context) (ApplyResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	snap, err := p.fetch(ctx, p.cursor)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	res, err := p.applier.Apply(store.WithTenantID(ctx, store.MasterTenantID), snap)
+	switch {
+	case err != nil:
+		return res, err
+	case res.Incomplete:
+		p.cursor = time.Time{}
+	default:
+		p.cursor = snap.GeneratedAt
+	}
+	p.save()
+	return res, nil
+}
+
+func (p *Puller) fetch(ctx context.Context, since time.Time) (*Snapshot, error) {
+	u := p.upstream + SnapshotPath
+	if !since.IsZero() {
+		u += "?since=" + url.QueryEscape(strconv.FormatInt(since.UnixMilli(), 10))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSnapshotBytes))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return Open(body, p.key)
+}
+
+type pullerState struct {
+	CursorMS int64 `json:"cursor_ms"`
+}
+
+func (p *Puller) load() {
+	if p.statePath == "" {
+		return
+	}
+	data, err := os.ReadFile(p.statePath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("remotesync: failed to read state", "path", p.statePath, "error", err)
+		}
+		return
+	}
+	var st pullerState
+	if err := json.Unmarshal(data, &st); err != nil {
+		slog.Warn("remotesync: corrupt state file, starting fresh", "path", p.statePath, "error", err)
+		return
+	}
+	if st.CursorMS > 0 {
+		p.cursor = time.UnixMilli(st.CursorMS).UTC()
+	}
+}
+
+// save persists the cursor. Must be called with p.mu held.
+func (p *Puller) save() {
+	if p.statePath == "" {
+		return
+	}
+	st := pullerState{}
+	if !p.cursor.IsZero() {
+		st.CursorMS = p.cursor.UnixMilli()
+	}
+	data, _ := json.Marshal(st)
+	if err := os.WriteFile(p.statePath, data, 0o600); err != nil {
+		slog.Warn("remotesync: failed to write state", "path", p.statePath, "error", err)
+	}
+}
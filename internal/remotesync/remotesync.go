@@ -0,0 +1,120 @@
+// Package remotesync replicates agents, their context files, cron jobs and
+// agent-level memory, plus filesystem skills, from a server instance to a
+// linked standalone instance (e.g. a VPS and a laptop).
+//
+// Sync is one-way: the server is the source of truth. It publishes a
+// snapshot at GET /v1/sync/snapshot, sealed with AES-256-GCM under a key
+// shared by both instances, behind admin authentication. The standalone
+// instance polls that endpoint and applies the snapshot to its own stores.
+// Memory contents are sent as deltas: documents unchanged since the
+// caller's cursor are listed by hash only.
+//
+// Agents are matched by agent key, cron jobs by name within an agent and
+// skills by slug. Local agents the server doesn't have are left alone;
+// within a synced agent, memory documents and cron jobs mirror the server.
+package remotesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/crypto"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// ErrNotSealed is returned by Open for a payload that is not encrypted.
+var ErrNotSealed = errors.New("sync payload is not encrypted")
+
+// Snapshot is the state a server publishes to linked instances.
+type Snapshot struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Agents      []AgentState `json:"agents"`
+	Skills      []SkillState `json:"skills"`
+}
+
+// AgentState is one agent's configuration and the state hanging off it.
+type AgentState struct {
+	AgentKey          string          `json:"agent_key"`
+	DisplayName       string          `json:"display_name,omitempty"`
+	Provider          string          `json:"provider"`
+	Model             string          `json:"model"`
+	AgentType         string          `json:"agent_type"`
+	ContextWindow     int             `json:"context_window"`
+	MaxToolIterations int             `json:"max_tool_iterations"`
+	Emoji             string          `json:"emoji,omitempty"`
+	AgentDescription  string          `json:"agent_description,omitempty"`
+	ThinkingLevel     string          `json:"thinking_level,omitempty"`
+	MaxTokens         int             `json:"max_tokens,omitempty"`
+	ToolsConfig       json.RawMessage `json:"tools_config,omitempty"`
+	MemoryConfig      json.RawMessage `json:"memory_config,omitempty"`
+	CompactionConfig  json.RawMessage `json:"compaction_config,omitempty"`
+	OtherConfig       json.RawMessage `json:"other_config,omitempty"`
+
+	ContextFiles map[string]string `json:"context_files,omitempty"` // agent-level files (AGENTS.md, SOUL.md, ...)
+	Memory       []MemoryDoc       `json:"memory"`
+	CronJobs     []CronJobState    `json:"cron_jobs"`
+}
+
+// MemoryDoc is an agent-level memory document. Content is nil when the
+// document has not changed since the requested cursor.
+type MemoryDoc struct {
+	Path    string  `json:"path"`
+	Hash    string  `json:"hash"`
+	Content *string `json:"content,omitempty"`
+}
+
+// CronJobState is a cron job's definition, without runtime state.
+type CronJobState struct {
+	Name           string             `json:"name"`
+	UserID         string             `json:"user_id,omitempty"`
+	Enabled        bool               `json:"enabled"`
+	Schedule       store.CronSchedule `json:"schedule"`
+	Message        string             `json:"message"`
+	Deliver        bool               `json:"deliver"`
+	DeliverChannel string             `json:"deliver_channel,omitempty"`
+	DeliverTo      string             `json:"deliver_to,omitempty"`
+}
+
+// SkillState is a filesystem skill: its slug and raw SKILL.md.
+type SkillState struct {
+	Slug    string `json:"slug"`
+	Content string `json:"content"`
+}
+
+// Seal encrypts a snapshot with the shared sync key.
+func Seal(snap *Snapshot, key string) ([]byte, error) {
+	if _, err := crypto.DeriveKey(key); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := crypto.Encrypt(string(data), key)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(sealed), nil
+}
+
+// Open decrypts a payload produced by Seal. Unencrypted payloads are
+// rejected so a misconfigured server can't downgrade the channel.
+func Open(payload []byte, key string) (*Snapshot, error) {
+	if _, err := crypto.DeriveKey(key); err != nil {
+		return nil, err
+	}
+	if !crypto.IsEncrypted(string(payload)) {
+		return nil, ErrNotSealed
+	}
+	plain, err := crypto.Decrypt(string(payload), key)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal([]byte(plain), &snap); err != nil {
+		return nil, fmt.Errorf("decode snapshot: %w", err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,336 @@
+package remotesync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+type fakeAgents struct {
+	agents  []store.AgentData
+	files   map[uuid.UUID]map[string]string
+	updates map[uuid.UUID]map[string]any
+}
+
+func newFakeAgents(agents ...store.AgentData) *fakeAgents {
+	return &fakeAgents{agents: agents, files: map[uuid.UUID]map[string]string{}, updates: map[uuid.UUID]map[string]any{}}
+}
+
+func (f *fakeAgents) List(context.Context, string) ([]store.AgentData, error) { return f.agents, nil }
+
+func (f *fakeAgents) Create(_ context.Context, ag *store.AgentData) error {
+	ag.ID = uuid.New()
+	f.agents = append(f.agents, *ag)
+	return nil
+}
+
+func (f *fakeAgents) Update(_ context.Context, id uuid.UUID, updates map[string]any) error {
+	f.updates[id] = updates
+	return nil
+}
+
+func (f *fakeAgents) GetAgentContextFiles(_ context.Context, id uuid.UUID) ([]store.AgentContextFileData, error) {
+	var out []store.AgentContextFileData
+	for name, content := range f.files[id] {
+		out = append(out, store.AgentContextFileData{AgentID: id, FileName: name, Content: content})
+	}
+	return out, nil
+}
+
+func (f *fakeAgents) SetAgentContextFile(_ context.Context, id uuid.UUID, name, content string) error {
+	if f.files[id] == nil {
+		f.files[id] = map[string]string{}
+	}
+	f.files[id][name] = content
+	return nil
+}
+
+// fakeMemory keeps agent-level documents keyed by agent ID and path.
+type fakeMemory struct {
+	store.MemoryStore
+	docs map[string]map[string]string
+}
+
+func (f *fakeMemory) ListAllDocuments(_ context.Context, agentID string) ([]store.DocumentInfo, error) {
+	var out []store.DocumentInfo
+	for path, content := range f.docs[agentID] {
+		out = append(out, store.DocumentInfo{Path: path, Hash: "h-" + content, AgentID: agentID, UpdatedAt: 1})
+	}
+	return out, nil
+}
+
+func (f *fakeMemory) PutDocument(_ context.Context, agentID, _, path, content string) error {
+	if f.docs[agentID] == nil {
+		f.docs[agentID] = map[string]string{}
+	}
+	f.docs[agentID][path] = content
+	return nil
+}
+
+func (f *fakeMemory) DeleteDocument(_ context.Context, agentID, _, path string) error {
+	delete(f.docs[agentID], path)
+	return nil
+}
+
+func (f *fakeMemory) IndexDocument(context.Context, string, string, string) error { return nil }
+
+type fakeCron struct {
+	store.CronStore
+	jobs []store.CronJob
+}
+
+func (f *fakeCron) ListJobs(_ context.Context, _ bool, agentID, _ string) []store.CronJob {
+	var out []store.CronJob
+	for _, j := range f.jobs {
+		if j.AgentID == agentID {
+			out = append(out, j)
+		}
+	}
+	return out
+}
+
+func (f *fakeCron) AddJob(_ context.Context, name string, schedule store.CronSchedule, message string, deliver bool, channel, to, agentID, userID string) (*store.CronJob, error) {
+	j := store.CronJob{ID: uuid.NewString(), Name: name, AgentID: agentID, UserID: userID, Enabled: true, Schedule: schedule,
+		Payload: store.CronPayload{Message: message}, Deliver: deliver, DeliverChannel: channel, DeliverTo: to}
+	f.jobs = append(f.jobs, j)
+	return &j, nil
+}
+
+func (f *fakeCron) EnableJob(_ context.Context, id string, enabled bool) error {
+	for i := range f.jobs {
+		if f.jobs[i].ID == id {
+			f.jobs[i].Enabled = enabled
+		}
+	}
+	return nil
+}
+
+func (f *fakeCron) RemoveJob(_ context.Context, id string) error {
+	for i := range f.jobs {
+		if f.jobs[i].ID == id {
+			f.jobs = append(f.jobs[:i], f.jobs[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestSealOpen(t *testing.T) {
+	snap := &Snapshot{GeneratedAt: time.UnixMilli(1700000000000).UTC(), Agents: []AgentState{{AgentKey: "ops"}}}
+	sealed, err := Seal(snap, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(sealed), "ops") {
+		t.Fatal("sealed payload leaks plaintext")
+	}
+
+	got, err := Open(sealed, testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Agents) != 1 || got.Agents[0].AgentKey != "ops" || !got.GeneratedAt.Equal(snap.GeneratedAt) {
+		t.Fatalf("round trip mismatch: %+v", got)
+	}
+
+	if _, err := Open(sealed, strings.Repeat("x", 32)); err == nil {
+		t.Error("expected error opening with the wrong key")
+	}
+	if _, err := Open([]byte(`{"agents":[]}`), testKey); !errors.Is(err, ErrNotSealed) {
+		t.Errorf("plaintext payload: err = %v, want ErrNotSealed", err)
+	}
+}
+
+func TestApply_AgentsMemoryCron(t *testing.T) {
+	existing := store.AgentData{BaseModel: store.BaseModel{ID: uuid.New()}, AgentKey: "ops", Model: "old-model", AgentType: store.AgentTypeOpen}
+	agents := newFakeAgents(existing)
+	mem := &fakeMemory{docs: map[string]map[string]string{
+		existing.ID.String(): {"MEMORY.md": "same", "memory/stale.md": "gone"},
+	}}
+	cron := &fakeCron{}
+
+	a := NewApplier(agents, cron, mem, ApplyOptions{WorkspaceRoot: "/ws", OwnerID: "owner"})
+	var changed []string
+	a.SetOnAgentChange(func(_ uuid.UUID, key string) { changed = append(changed, key) })
+
+	snap := &Snapshot{Agents: []AgentState{
+		{
+			AgentKey: "ops", Model: "new-model", AgentType: store.AgentTypeOpen,
+			ContextFiles: map[string]string{"SOUL.md": "calm"},
+			Memory: []MemoryDoc{
+				{Path: "MEMORY.md", Hash: "h-same"},
+				{Path: "memory/new.md", Hash: "h-fresh", Content: strPtr("fresh")},
+			},
+			CronJobs: []CronJobState{{Name: "daily", Enabled: true, Schedule: store.CronSchedule{Kind: "cron", Expr: "0 9 * * *"}, Message: "report"}},
+		},
+		{AgentKey: "research", Model: "m"},
+	}}
+
+	res, err := a.Apply(context.Background(), snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AgentsCreated != 1 || res.AgentsUpdated != 1 || res.FilesWritten != 1 {
+		t.Errorf("agents: %+v", res)
+	}
+	if got := agents.updates[existing.ID]; len(got) != 1 || got["model"] != "new-model" {
+		t.Errorf("updates = %v, want only model", got)
+	}
+	created := agents.agents[1]
+	if created.AgentKey != "research" || created.OwnerID != "owner" || created.Workspace != filepath.Join("/ws", "research") {
+		t.Errorf("created agent = %+v", created)
+	}
+	if len(changed) != 2 {
+		t.Errorf("agent change callbacks = %v", changed)
+	}
+
+	docs := mem.docs[existing.ID.String()]
+	if res.MemoryWritten != 1 || res.MemoryDeleted != 1 || docs["memory/new.md"] != "fresh" || docs["memory/stale.md"] != "" {
+		t.Errorf("memory: res=%+v docs=%v", res, docs)
+	}
+	if res.Incomplete {
+		t.Error("apply should be complete")
+	}
+
+	if len(cron.jobs) != 1 || cron.jobs[0].Name != "daily" || cron.jobs[0].Enabled {
+		t.Errorf("cron jobs = %+v, want one disabled job", cron.jobs)
+	}
+
+	// A second apply of the same snapshot changes nothing.
+	agents.updates = map[uuid.UUID]map[string]any{}
+	agents.agents[0].Model = "new-model"
+	res, err = a.Apply(context.Background(), snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed() {
+		t.Errorf("second apply changed state: %+v", res)
+	}
+}
+
+func TestApply_MissingMemoryContentIsIncomplete(t *testing.T) {
+	ag := store.AgentData{BaseModel: store.BaseModel{ID: uuid.New()}, AgentKey: "ops"}
+	mem := &fakeMemory{docs: map[string]map[string]string{}}
+	a := NewApplier(newFakeAgents(ag), nil, mem, ApplyOptions{})
+
+	res, err := a.Apply(context.Background(), &Snapshot{Agents: []AgentState{
+		{AgentKey: "ops", Memory: []MemoryDoc{{Path: "MEMORY.md", Hash: "h-x"}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Incomplete || res.MemoryWritten != 0 {
+		t.Errorf("res = %+v, want incomplete with nothing written", res)
+	}
+}
+
+func TestApply_RejectsUnsafeAgentKey(t *testing.T) {
+	for _, key := range []string{"../escape", "a/b", ".hidden", ""} {
+		agents := newFakeAgents()
+		a := NewApplier(agents, nil, nil, ApplyOptions{WorkspaceRoot: t.TempDir()})
+		res, err := a.Apply(context.Background(), &Snapshot{Agents: []AgentState{
+			{AgentKey: "ops"},
+			{AgentKey: key},
+		}})
+		if err == nil {
+			t.Errorf("key %q: expected snapshot to be rejected", key)
+		}
+		if res.AgentsCreated != 0 {
+			t.Errorf("key %q: created %d agents, want none", key, res.AgentsCreated)
+		}
+	}
+}
+
+func TestApply_Skills(t *testing.T) {
+	dir := t.TempDir()
+	local := filepath.Join(dir, "mine")
+	if err := os.MkdirAll(local, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(local, "SKILL.md"), []byte("local"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewApplier(newFakeAgents(), nil, nil, ApplyOptions{SkillsDir: dir})
+	bumped := 0
+	a.SetOnSkillsChange(func() { bumped++ })
+
+	res, err := a.Apply(context.Background(), &Snapshot{Skills: []SkillState{
+		{Slug: "mine", Content: "remote"},
+		{Slug: "shared", Content: "# shared"},
+		{Slug: "../escape", Content: "x"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SkillsWritten != 1 || bumped != 1 {
+		t.Errorf("res = %+v, bumped = %d", res, bumped)
+	}
+	if data, _ := os.ReadFile(filepath.Join(local, "SKILL.md")); string(data) != "local" {
+		t.Errorf("local skill overwritten: %q", data)
+	}
+
+	res, err = a.Apply(context.Background(), &Snapshot{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SkillsRemoved != 1 {
+		t.Errorf("res = %+v, want the synced skill removed", res)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "shared")); !os.IsNotExist(err) {
+		t.Error("synced skill still present")
+	}
+	if _, err := os.Stat(local); err != nil {
+		t.Error("local skill removed")
+	}
+}
+
+func TestPuller_PullOnce(t *testing.T) {
+	var gotSince, gotAuth string
+	generated := time.UnixMilli(1700000000000).UTC()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != SnapshotPath {
+			http.NotFound(w, r)
+			return
+		}
+		gotSince, gotAuth = r.URL.Query().Get("since"), r.Header.Get("Authorization")
+		sealed, _ := Seal(&Snapshot{GeneratedAt: generated, Agents: []AgentState{{AgentKey: "ops"}}}, testKey)
+		_, _ = w.Write(sealed)
+	}))
+	defer srv.Close()
+
+	agents := newFakeAgents()
+	statePath := filepath.Join(t.TempDir(), "remote_sync.json")
+	p := NewPuller(srv.URL+"/", "tok", testKey, NewApplier(agents, nil, nil, ApplyOptions{}), statePath)
+
+	res, err := p.PullOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AgentsCreated != 1 || gotAuth != "Bearer tok" || gotSince != "" {
+		t.Errorf("first pull: res=%+v auth=%q since=%q", res, gotAuth, gotSince)
+	}
+
+	// The cursor survives a restart and is sent on the next pull.
+	p = NewPuller(srv.URL, "tok", testKey, NewApplier(agents, nil, nil, ApplyOptions{}), statePath)
+	if _, err := p.PullOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if gotSince != "1700000000000" {
+		t.Errorf("since = %q, want the previous GeneratedAt", gotSince)
+	}
+}
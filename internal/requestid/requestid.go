@@ -0,0 +1,102 @@
+// Package requestid carries a per-inbound-message correlation ID (channel
+// message, WS request or HTTP call) through context so log lines, traces,
+// provider calls and error replies can be tied back to one request.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// HTTPHeader is the header used to accept and return request IDs over HTTP.
+const HTTPHeader = "X-Request-ID"
+
+// LogKey is the slog attribute key added to records logged with a request context.
+const LogKey = "request_id"
+
+// maxLen bounds caller-supplied IDs (HTTP header) before they are trusted.
+const maxLen = 64
+
+type contextKey struct{}
+
+// New returns a fresh 16-character hex request ID.
+func New() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Valid reports whether a caller-supplied ID is safe to propagate into logs
+// and headers: 1–64 characters of [A-Za-z0-9._-].
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// With returns a context carrying id. An empty id leaves ctx unchanged.
+func With(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID in ctx, or "".
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Ensure returns ctx and its request ID, attaching a new one if none is set.
+func Ensure(ctx context.Context) (context.Context, string) {
+	if id := FromContext(ctx); id != "" {
+		return ctx, id
+	}
+	id := New()
+	return With(ctx, id), id
+}
+
+// LogHandler adds a request_id attribute to records logged with a context
+// that carries one (slog.InfoContext and friends).
+type LogHandler struct {
+	inner slog.Handler
+}
+
+// NewLogHandler wraps inner with request ID enrichment.
+func NewLogHandler(inner slog.Handler) *LogHandler {
+	return &LogHandler{inner: inner}
+}
+
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := FromContext(ctx); id != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{inner: h.inner.WithAttrs(attrs)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{inner: h.inner.WithGroup(name)}
+}
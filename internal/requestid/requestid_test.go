@@ -0,0 +1,56 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestEnsureKeepsExistingID(t *testing.T) {
+	ctx, id := Ensure(context.Background())
+	if !Valid(id) || len(id) != 16 {
+		t.Fatalf("generated id %q is not a 16-char valid id", id)
+	}
+	if _, again := Ensure(ctx); again != id {
+		t.Errorf("Ensure replaced existing id %q with %q", id, again)
+	}
+	if FromContext(With(context.Background(), "")) != "" {
+		t.Error("empty id should not be attached")
+	}
+}
+
+func TestValid(t *testing.T) {
+	for id, want := range map[string]bool{
+		"abc-123_x.y":           true,
+		"":                      false,
+		"has space":             false,
+		"new\nline":             false,
+		strings.Repeat("a", 64): true,
+		strings.Repeat("a", 65): false,
+	} {
+		if got := Valid(id); got != want {
+			t.Errorf("Valid(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestLogHandlerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil))).With("agent", "coder")
+
+	log.InfoContext(With(context.Background(), "req42"), "tool call")
+	log.Info("no context")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "request_id=req42") || !strings.Contains(lines[0], "agent=coder") {
+		t.Errorf("first line missing attrs: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("second line should not carry request_id: %s", lines[1])
+	}
+}
@@ -0,0 +1,54 @@
+package retry
+
+import "sync"
+
+// Budget caps retries across many calls so a failing dependency is not hit
+// with attempts × callers requests. Every retry spends one token; every
+// success refunds Ratio tokens, up to Max. Once the bucket drops to half of
+// Max, retries are refused until successes refill it (gRPC retry throttling).
+//
+// A nil *Budget allows every retry.
+type Budget struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	ratio  float64
+}
+
+// NewBudget creates a full budget of maxTokens that refills ratio tokens per
+// success (e.g. NewBudget(20, 0.1) allows ~1 retry per 10 successes at steady state).
+func NewBudget(maxTokens int, ratio float64) *Budget {
+	return &Budget{tokens: float64(maxTokens), max: float64(maxTokens), ratio: ratio}
+}
+
+// Available returns the current token count (for metrics and tests).
+func (b *Budget) Available() float64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens
+}
+
+func (b *Budget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens <= b.max/2 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *Budget) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	b.tokens = min(b.tokens+b.ratio, b.max)
+	b.mu.Unlock()
+}
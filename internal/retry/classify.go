@@ -0,0 +1,141 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// Class groups errors that share a retry policy.
+type Class int
+
+const (
+	ClassPermanent   Class = iota // not retried (4xx, auth, validation, cancellation)
+	ClassTransient                // network blips, timeouts, 5xx
+	ClassRateLimited              // 429 / quota; waits honor Retry-After
+)
+
+func (c Class) String() string {
+	switch c {
+	case ClassTransient:
+		return "transient"
+	case ClassRateLimited:
+		return "rate_limited"
+	default:
+		return "permanent"
+	}
+}
+
+// RetryAfterer is implemented by errors that carry a server-provided wait
+// (e.g. a parsed Retry-After header).
+type RetryAfterer interface {
+	RetryAfterDelay() time.Duration
+}
+
+// StatusCoder is implemented by errors that carry an HTTP status code.
+type StatusCoder interface {
+	HTTPStatus() int
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not retryable regardless of its content.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type statusError struct {
+	err    error
+	status int
+	after  time.Duration
+}
+
+func (e *statusError) Error() string                  { return e.err.Error() }
+func (e *statusError) Unwrap() error                  { return e.err }
+func (e *statusError) HTTPStatus() int                { return e.status }
+func (e *statusError) RetryAfterDelay() time.Duration { return e.after }
+
+// HTTPStatus attaches an HTTP status (and optional Retry-After) to err so
+// Classify can pick the right class.
+func HTTPStatus(err error, status int, retryAfter time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &statusError{err: err, status: status, after: retryAfter}
+}
+
+// ClassifyStatus maps an HTTP status code to a retry class.
+func ClassifyStatus(status int) Class {
+	switch status {
+	case 429:
+		return ClassRateLimited
+	case 408, 500, 502, 503, 504:
+		return ClassTransient
+	}
+	return ClassPermanent
+}
+
+// RetryAfter returns the server-provided wait carried by err, or 0.
+func RetryAfter(err error) time.Duration {
+	var ra RetryAfterer
+	if errors.As(err, &ra) {
+		return max(ra.RetryAfterDelay(), 0)
+	}
+	return 0
+}
+
+// Classify is the default classifier: explicit markers and HTTP status codes
+// first, then network errors, then well-known transient error strings.
+// Anything unrecognized is permanent.
+func Classify(err error) Class {
+	if err == nil {
+		return ClassPermanent
+	}
+	var pe *permanentError
+	if errors.As(err, &pe) || errors.Is(err, context.Canceled) {
+		return ClassPermanent
+	}
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return ClassifyStatus(sc.HTTPStatus())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTransient
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ClassTransient
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "connection reset"),
+		strings.Contains(msg, "connection refused"),
+		strings.Contains(msg, "broken pipe"),
+		strings.Contains(msg, "EOF"),
+		strings.Contains(msg, "timeout"):
+		return ClassTransient
+	}
+	return ClassPermanent
+}
+
+// Always retries every error except explicit Permanent markers and
+// cancellation. For jobs where any failure is worth another try (cron).
+func Always(err error) Class {
+	var pe *permanentError
+	if errors.As(err, &pe) || errors.Is(err, context.Canceled) {
+		return ClassPermanent
+	}
+	if RetryAfter(err) > 0 {
+		return ClassRateLimited
+	}
+	return ClassTransient
+}
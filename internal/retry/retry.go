@@ -0,0 +1,142 @@
+// Package retry provides context-aware exponential backoff with jitter,
+// per-error-class policies and shared retry budgets for outbound calls
+// (LLM providers, channel APIs, webhooks, MCP connections).
+//
+//	err := retry.Do(ctx, retry.Policy{Name: "webhook", Backoff: retry.Backoff{Attempts: 3, MinDelay: time.Second}},
+//		func(ctx context.Context) error { return send(ctx) })
+package retry
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff is an exponential schedule: MinDelay * 2^(attempt-1), capped at
+// MaxDelay, with ±Jitter applied as a fraction of the delay.
+type Backoff struct {
+	Attempts int           // max attempts including the first (<= 0 means 1)
+	MinDelay time.Duration // delay before the first retry
+	MaxDelay time.Duration // delay cap (0 = uncapped)
+	Jitter   float64       // ±fraction, e.g. 0.1 = ±10%
+}
+
+// Delay returns the wait after failed attempt n (1-based). Never negative.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := float64(b.MinDelay) * math.Pow(2, float64(attempt-1))
+	if b.MaxDelay > 0 && d > float64(b.MaxDelay) {
+		d = float64(b.MaxDelay)
+	}
+	if b.Jitter > 0 {
+		d += (rand.Float64()*2 - 1) * d * b.Jitter
+	}
+	if d < 0 {
+		d = float64(b.MinDelay)
+	}
+	return time.Duration(d)
+}
+
+func (b Backoff) attempts() int {
+	return max(b.Attempts, 1)
+}
+
+// Policy configures one kind of retried operation.
+type Policy struct {
+	Name string // log label, e.g. "provider.anthropic", "mcp.init"
+	Backoff
+	// RateLimit overrides Backoff for ClassRateLimited errors (nil = Backoff).
+	// Retry-After hints still take precedence over the computed delay.
+	RateLimit *Backoff
+	// Classify decides how an error is retried (nil = Classify).
+	Classify func(error) Class
+	// Budget, when set, is shared across calls and caps the overall retry rate.
+	Budget *Budget
+	// OnRetry is called before each wait (attempt is the failed attempt, 1-based).
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+func (p Policy) backoffFor(c Class) Backoff {
+	if c == ClassRateLimited && p.RateLimit != nil {
+		return *p.RateLimit
+	}
+	return p.Backoff
+}
+
+// Do runs fn until it succeeds, returns a non-retryable error, exhausts the
+// attempts for its error class, runs out of budget, or ctx is done.
+// The last error from fn is returned (ctx.Err() if cancelled while waiting).
+func Do(ctx context.Context, p Policy, fn func(context.Context) error) error {
+	_, err := DoValue(ctx, p, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoValue is Do for functions that return a value.
+func DoValue[T any](ctx context.Context, p Policy, fn func(context.Context) (T, error)) (T, error) {
+	classify := p.Classify
+	if classify == nil {
+		classify = Classify
+	}
+
+	var zero T
+	for attempt := 1; ; attempt++ {
+		v, err := fn(ctx)
+		if err == nil {
+			p.Budget.success()
+			return v, nil
+		}
+		if ctx.Err() != nil {
+			return zero, err
+		}
+
+		class := classify(err)
+		b := p.backoffFor(class)
+		if class == ClassPermanent || attempt >= b.attempts() {
+			return zero, err
+		}
+		if !p.Budget.withdraw() {
+			slog.Warn("retry.budget_exhausted", "op", p.Name, "attempt", attempt, "error", err.Error())
+			return zero, err
+		}
+
+		delay := b.Delay(attempt)
+		if after := RetryAfter(err); after > 0 {
+			delay = after
+		}
+		slog.Debug("retry", "op", p.Name, "attempt", attempt, "max_attempts", b.attempts(),
+			"class", class.String(), "delay", delay, "error", err.Error())
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, delay)
+		}
+
+		if err := Sleep(ctx, delay); err != nil {
+			return zero, err
+		}
+	}
+}
+
+// Sleep waits for d or until ctx is done, returning ctx.Err() in the latter case.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// IsRetryable reports whether Classify would retry err.
+func IsRetryable(err error) bool {
+	return err != nil && Classify(err) != ClassPermanent
+}
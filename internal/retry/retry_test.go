@@ -0,0 +1,161 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+var fast = Backoff{Attempts: 4, MinDelay: time.Millisecond, MaxDelay: 4 * time.Millisecond}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{MinDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}
+	for i, w := range want {
+		if got := b.Delay(i + 1); got != w {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	b.Jitter = 0.25
+	for range 100 {
+		if d := b.Delay(1); d < 75*time.Millisecond || d > 125*time.Millisecond {
+			t.Fatalf("jittered delay %v outside ±25%%", d)
+		}
+	}
+}
+
+func TestDo_RetriesTransientUntilSuccess(t *testing.T) {
+	calls := 0
+	v, err := DoValue(context.Background(), Policy{Backoff: fast}, func(context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "ok", nil
+	})
+	if err != nil || v != "ok" || calls != 3 {
+		t.Fatalf("got (%q, %v) after %d calls, want (ok, nil) after 3", v, err, calls)
+	}
+}
+
+func TestDo_PermanentNotRetried(t *testing.T) {
+	cases := map[string]error{
+		"unknown":   errors.New("validation failed"),
+		"marked":    Permanent(io.EOF),
+		"http 400":  HTTPStatus(errors.New("bad request"), 400, 0),
+		"cancelled": fmt.Errorf("op: %w", context.Canceled),
+	}
+	for name, failure := range cases {
+		calls := 0
+		err := Do(context.Background(), Policy{Backoff: fast}, func(context.Context) error {
+			calls++
+			return failure
+		})
+		if err == nil || calls != 1 {
+			t.Errorf("%s: %d calls, err=%v; want 1 call with error", name, calls, err)
+		}
+	}
+}
+
+func TestDo_ExhaustsAttempts(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{Backoff: fast}, func(context.Context) error {
+		calls++
+		return HTTPStatus(errors.New("unavailable"), 503, 0)
+	})
+	if err == nil || calls != fast.Attempts {
+		t.Fatalf("%d calls, err=%v; want %d calls with error", calls, err, fast.Attempts)
+	}
+}
+
+func TestDo_RateLimitPolicyAndRetryAfter(t *testing.T) {
+	var delays []time.Duration
+	rl := Backoff{Attempts: 2, MinDelay: time.Millisecond}
+	calls := 0
+	err := Do(context.Background(), Policy{
+		Backoff:   Backoff{Attempts: 1},
+		RateLimit: &rl,
+		OnRetry:   func(_ int, _ error, d time.Duration) { delays = append(delays, d) },
+	}, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return HTTPStatus(errors.New("slow down"), 429, 3*time.Millisecond)
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("%d calls, err=%v; rate-limit override should allow one retry", calls, err)
+	}
+	if len(delays) != 1 || delays[0] != 3*time.Millisecond {
+		t.Errorf("delays = %v, want Retry-After 3ms", delays)
+	}
+}
+
+func TestDo_ContextCancelStopsWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Do(ctx, Policy{Backoff: Backoff{Attempts: 5, MinDelay: time.Hour}}, func(context.Context) error {
+		calls++
+		cancel()
+		return io.EOF
+	})
+	if calls != 1 || err == nil {
+		t.Fatalf("%d calls, err=%v; want 1 call and an error", calls, err)
+	}
+}
+
+func TestBudget_CapsRetriesAcrossCalls(t *testing.T) {
+	budget := NewBudget(4, 1)
+	p := Policy{Backoff: Backoff{Attempts: 10, MinDelay: time.Microsecond}, Budget: budget}
+
+	calls := 0
+	_ = Do(context.Background(), p, func(context.Context) error {
+		calls++
+		return io.EOF
+	})
+	// 4 tokens, retries refused at half (2): 2 retries → 3 calls.
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3 (budget allows 2 retries)", calls)
+	}
+
+	calls = 0
+	_ = Do(context.Background(), p, func(context.Context) error {
+		calls++
+		return io.EOF
+	})
+	if calls != 1 {
+		t.Errorf("exhausted budget should refuse retries, got %d calls", calls)
+	}
+
+	_ = Do(context.Background(), p, func(context.Context) error { return nil })
+	if got := budget.Available(); got != 3 {
+		t.Errorf("tokens after success = %v, want 3", got)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		err  error
+		want Class
+	}{
+		{io.EOF, ClassTransient},
+		{errors.New("read: connection reset by peer"), ClassTransient},
+		{context.DeadlineExceeded, ClassTransient},
+		{HTTPStatus(errors.New("x"), 502, 0), ClassTransient},
+		{HTTPStatus(errors.New("x"), 429, 0), ClassRateLimited},
+		{HTTPStatus(errors.New("x"), 401, 0), ClassPermanent},
+		{errors.New("invalid argument"), ClassPermanent},
+	}
+	for _, c := range cases {
+		if got := Classify(c.err); got != c.want {
+			t.Errorf("Classify(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+	if Always(errors.New("anything")) != ClassTransient {
+		t.Error("Always should retry unknown errors")
+	}
+}
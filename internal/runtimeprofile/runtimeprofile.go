@@ -0,0 +1,82 @@
+// Package runtimeprofile defines resource footprints for GoClaw.
+// Independent of the edition (feature tiers): a Standard-edition gateway can
+// run with the Lite profile on a Raspberry Pi and vice versa.
+// Set once at startup via SetCurrent(), read everywhere via Current().
+package runtimeprofile
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Profile sizes caches, pools and optional subsystems. Zero values mean
+// "use the consumer's built-in default".
+type Profile struct {
+	Name                   string        `json:"name"`                     // "standard" or "lite"
+	BrowserEnabled         bool          `json:"browser_enabled"`          // false = browser tool never starts Chrome
+	WebCacheEntries        int           `json:"web_cache_entries"`        // web_search / web_fetch result cache
+	PermissionCacheEntries int           `json:"permission_cache_entries"` // per inner permission cache
+	ScriptCacheEntries     int           `json:"script_cache_entries"`     // compiled hook scripts
+	ChannelStartInterval   time.Duration `json:"channel_start_interval"`   // >0 = start channels one at a time in the background
+	SQLiteCacheKB          int           `json:"sqlite_cache_kb"`          // per-connection page cache
+	SQLiteHeapLimitMB      int           `json:"sqlite_heap_limit_mb"`     // soft_heap_limit; 0 = unlimited
+	SQLiteMaxConns         int           `json:"sqlite_max_conns"`         // pool size
+	MemoryLimitMB          int           `json:"memory_limit_mb"`          // Go soft memory limit; 0 = unlimited
+}
+
+// --- Presets ---
+
+// Standard is the default profile: current server defaults, nothing disabled.
+var Standard = Profile{
+	Name:           "standard",
+	BrowserEnabled: true,
+	SQLiteCacheKB:  8000,
+	SQLiteMaxConns: 4,
+}
+
+// Lite targets Raspberry Pi-class devices (1–2 GB RAM, SD-card storage).
+var Lite = Profile{
+	Name:                   "lite",
+	BrowserEnabled:         false,
+	WebCacheEntries:        20,
+	PermissionCacheEntries: 1000,
+	ScriptCacheEntries:     32,
+	ChannelStartInterval:   2 * time.Second,
+	SQLiteCacheKB:          2000,
+	SQLiteHeapLimitMB:      32,
+	SQLiteMaxConns:         2,
+	MemoryLimitMB:          256,
+}
+
+// ByName returns the preset for name ("" = standard).
+func ByName(name string) (Profile, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", Standard.Name:
+		return Standard, true
+	case Lite.Name:
+		return Lite, true
+	}
+	return Profile{}, false
+}
+
+// --- Global state ---
+
+// current holds the active profile. Atomic pointer for safe concurrent reads.
+var current atomic.Pointer[Profile]
+
+func init() {
+	std := Standard
+	current.Store(&std)
+}
+
+// Current returns the active profile. Safe for concurrent use.
+func Current() Profile {
+	return *current.Load()
+}
+
+// SetCurrent sets the active profile. Call once at startup, before stores and
+// tools are created.
+func SetCurrent(p Profile) {
+	current.Store(&p)
+}
@@ -0,0 +1,34 @@
+package runtimeprofile
+
+import "testing"
+
+func TestByName(t *testing.T) {
+	for in, want := range map[string]string{"": "standard", "standard": "standard", " Lite ": "lite"} {
+		p, ok := ByName(in)
+		if !ok || p.Name != want {
+			t.Errorf("ByName(%q) = %q, %v; want %q", in, p.Name, ok, want)
+		}
+	}
+	if _, ok := ByName("tiny"); ok {
+		t.Error("ByName(\"tiny\") should not resolve")
+	}
+}
+
+// TestStandardMatchesServerDefaults guards against the default profile
+// silently shrinking a regular deployment.
+func TestStandardMatchesServerDefaults(t *testing.T) {
+	p := Current()
+	if p.Name != "standard" || !p.BrowserEnabled || p.ChannelStartInterval != 0 || p.MemoryLimitMB != 0 || p.SQLiteHeapLimitMB != 0 {
+		t.Errorf("default profile = %+v; want unrestricted standard", p)
+	}
+}
+
+func TestSetCurrent(t *testing.T) {
+	original := Current()
+	defer SetCurrent(original)
+
+	SetCurrent(Lite)
+	if p := Current(); p.Name != "lite" || p.BrowserEnabled {
+		t.Errorf("after SetCurrent(Lite), Current() = %+v", p)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"log/slog"
 	"runtime"
+	"time"
 )
 
-// Recover catches panics, logs an error with stack trace, and optionally
+// Recover catches panics, logs an error with stack trace, records the panic
+// in Stats, forwards it to the crash reporter (if any), and optionally
 // invokes onPanic. Must be called via defer:
 //
 //	defer safego.Recover(nil, "job_id", id)              // log-only
@@ -19,10 +21,28 @@ func Recover(onPanic func(v any), attrs ...any) {
 	}
 	buf := make([]byte, 8192)
 	n := runtime.Stack(buf, false)
+	stack := string(buf[:n])
 	slog.Error("goroutine panicked",
-		append(attrs, "panic", fmt.Sprint(r), "stack", string(buf[:n]))...,
+		append(attrs, "panic", fmt.Sprint(r), "stack", stack)...,
 	)
+	record(Crash{
+		Component: componentOf(attrs),
+		Value:     fmt.Sprint(r),
+		Stack:     stack,
+		Attrs:     attrs,
+		Time:      time.Now(),
+	})
 	if onPanic != nil {
 		onPanic(r)
 	}
 }
+
+// componentOf returns the value of the "component" attr, or "unknown".
+func componentOf(attrs []any) string {
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if k, ok := attrs[i].(string); ok && k == "component" {
+			return fmt.Sprint(attrs[i+1])
+		}
+	}
+	return "unknown"
+}
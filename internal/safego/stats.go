@@ -0,0 +1,67 @@
+package safego
+
+import (
+	"log/slog"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Crash describes one recovered panic, as passed to the crash reporter.
+type Crash struct {
+	Component string
+	Value     string
+	Stack     string
+	Attrs     []any
+	Time      time.Time
+}
+
+var (
+	panicTotal atomic.Int64
+
+	statsMu     sync.Mutex
+	panicCounts = map[string]int64{}
+
+	reporter atomic.Pointer[func(Crash)]
+)
+
+// SetReporter installs fn to receive every recovered panic (e.g. a Sentry
+// client). fn is called on the panicking goroutine after logging and must not
+// block; pass nil to remove the reporter.
+func SetReporter(fn func(Crash)) {
+	if fn == nil {
+		reporter.Store(nil)
+		return
+	}
+	reporter.Store(&fn)
+}
+
+// PanicCount returns the number of panics recovered since startup.
+func PanicCount() int64 {
+	return panicTotal.Load()
+}
+
+// Stats returns recovered panic counts keyed by component ("unknown" when
+// the Recover call site did not pass a "component" attr).
+func Stats() map[string]int64 {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	return maps.Clone(panicCounts)
+}
+
+func record(c Crash) {
+	panicTotal.Add(1)
+	statsMu.Lock()
+	panicCounts[c.Component]++
+	statsMu.Unlock()
+
+	if fn := reporter.Load(); fn != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Warn("crash reporter panicked", "panic", r)
+			}
+		}()
+		(*fn)(c)
+	}
+}
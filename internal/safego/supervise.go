@@ -0,0 +1,59 @@
+package safego
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/retry"
+)
+
+// superviseBackoff spaces out restarts of a loop that keeps panicking.
+// The attempt counter resets once a run survives superviseResetAfter.
+var superviseBackoff = retry.Backoff{MinDelay: time.Second, MaxDelay: time.Minute, Jitter: 0.2}
+
+const superviseResetAfter = time.Minute
+
+// Go runs fn in a new goroutine with panic recovery tagged as component.
+// A panic is logged and counted; the goroutine is not restarted.
+func Go(component string, fn func()) {
+	go func() {
+		defer Recover(nil, "component", component)
+		fn()
+	}()
+}
+
+// Supervise runs a long-lived loop and restarts it with backoff whenever it
+// panics. It returns when fn returns normally or ctx is done. Call it in its
+// own goroutine:
+//
+//	go safego.Supervise(ctx, "cron", cs.runLoop)
+func Supervise(ctx context.Context, component string, fn func(ctx context.Context)) {
+	restarts := 0
+	for {
+		started := time.Now()
+		if !runOnce(ctx, component, fn) {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) >= superviseResetAfter {
+			restarts = 0
+		}
+		restarts++
+		delay := superviseBackoff.Delay(restarts)
+		slog.Warn("supervised loop restarting after panic",
+			"component", component, "restarts", restarts, "delay", delay)
+		if retry.Sleep(ctx, delay) != nil {
+			return
+		}
+	}
+}
+
+// runOnce runs fn and reports whether it panicked.
+func runOnce(ctx context.Context, component string, fn func(ctx context.Context)) (panicked bool) {
+	defer Recover(func(any) { panicked = true }, "component", component)
+	fn(ctx)
+	return false
+}
@@ -0,0 +1,78 @@
+package safego
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSupervise_RestartsAfterPanic(t *testing.T) {
+	old := superviseBackoff
+	superviseBackoff.MinDelay, superviseBackoff.MaxDelay = time.Millisecond, time.Millisecond
+	defer func() { superviseBackoff = old }()
+
+	before := Stats()["supervise_test"]
+	var runs atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Supervise(context.Background(), "supervise_test", func(context.Context) {
+			if runs.Add(1) < 3 {
+				panic("boom")
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Supervise did not return after fn returned normally")
+	}
+	if runs.Load() != 3 {
+		t.Errorf("runs = %d, want 3", runs.Load())
+	}
+	if got := Stats()["supervise_test"] - before; got != 2 {
+		t.Errorf("recorded panics = %d, want 2", got)
+	}
+}
+
+func TestSupervise_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Supervise(ctx, "supervise_cancel", func(context.Context) {
+			cancel()
+			panic("boom")
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Supervise should not restart once ctx is done")
+	}
+}
+
+func TestRecover_Reporter(t *testing.T) {
+	got := make(chan Crash, 1)
+	SetReporter(func(c Crash) { got <- c })
+	defer SetReporter(nil)
+
+	total := PanicCount()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer Recover(nil, "component", "reporter_test")
+		panic("reported")
+	}()
+	<-done
+
+	c := <-got
+	if c.Component != "reporter_test" || c.Value != "reported" || c.Stack == "" {
+		t.Errorf("unexpected crash: %+v", c)
+	}
+	if PanicCount() != total+1 {
+		t.Errorf("PanicCount = %d, want %d", PanicCount(), total+1)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"maps"
 	"os/exec"
@@ -15,17 +16,29 @@ import (
 // CheckDockerAvailable verifies that the Docker CLI and daemon are accessible.
 // Returns nil if Docker is ready, or an error describing the failure.
 func CheckDockerAvailable(ctx context.Context) error {
+	return CheckRuntimeAvailable(ctx, RuntimeDocker)
+}
+
+// CheckRuntimeAvailable verifies that the container engine CLI works (and,
+// for Docker, that the daemon is reachable).
+func CheckRuntimeAvailable(ctx context.Context, runtime Runtime) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	out, err := exec.CommandContext(ctx, "docker", "info", "--format", "{{.ServerVersion}}").CombinedOutput()
+	cfg := Config{Runtime: runtime}
+	format := "{{.ServerVersion}}"
+	if runtime == RuntimePodman {
+		format = "{{.Version.Version}}"
+	}
+	out, err := exec.CommandContext(ctx, cfg.Binary(), "info", "--format", format).CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("docker not available: %w (output: %s)", err, strings.TrimSpace(string(out)))
+		return fmt.Errorf("%s not available: %w (output: %s)", cfg.Binary(), err, strings.TrimSpace(string(out)))
 	}
 	return nil
 }
 
-// DockerSandbox is a sandbox backed by a Docker container.
+// DockerSandbox is a sandbox backed by a Docker or Podman container (both
+// engines take the same CLI arguments).
 type DockerSandbox struct {
 	containerID string
 	config      Config
@@ -35,9 +48,58 @@ type DockerSandbox struct {
 	mu          sync.Mutex // protects lastUsed
 }
 
-// newDockerSandbox creates and starts a Docker container for sandboxed execution.
-// Matching TS buildSandboxCreateArgs() + createSandboxContainer().
+// newDockerSandbox creates and starts a container for sandboxed execution.
+// Matching TS createSandboxContainer().
 func newDockerSandbox(ctx context.Context, name string, cfg Config, workspace string) (*DockerSandbox, error) {
+	// Workspace mount — resolve host path for DooD (Docker-out-of-Docker) setups.
+	hostPath := ""
+	if workspace != "" && cfg.WorkspaceAccess != AccessNone {
+		hostPath = resolveHostWorkspacePath(ctx, cfg.Binary(), workspace)
+	}
+	args := buildCreateArgs(name, cfg, hostPath)
+
+	slog.Debug("creating sandbox container", "name", name, "runtime", cfg.Binary(), "args", args)
+
+	cmd := exec.CommandContext(ctx, cfg.Binary(), args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s run failed: %w\nstderr: %s", cfg.Binary(), err, stderr.String())
+	}
+
+	containerID := strings.TrimSpace(stdout.String())
+	if len(containerID) > 12 {
+		containerID = containerID[:12]
+	}
+
+	slog.Info("sandbox container created", "id", containerID, "name", name, "image", cfg.Image, "runtime", cfg.Binary())
+
+	// Run optional setup command (matching TS setupCommand)
+	if cfg.SetupCommand != "" {
+		setupCmd := exec.CommandContext(ctx, cfg.Binary(), "exec", "-i", containerID, "sh", "-lc", cfg.SetupCommand)
+		if out, err := setupCmd.CombinedOutput(); err != nil {
+			slog.Warn("sandbox setup command failed", "id", containerID, "error", err, "output", string(out))
+		} else {
+			slog.Info("sandbox setup command completed", "id", containerID)
+		}
+	}
+
+	now := time.Now()
+	return &DockerSandbox{
+		containerID: containerID,
+		config:      cfg,
+		workspace:   workspace,
+		createdAt:   now,
+		lastUsed:    now,
+	}, nil
+}
+
+// buildCreateArgs returns the "run" arguments for a sandbox container.
+// hostWorkspace is the host-side workspace path to mount ("" = no mount).
+// Matching TS buildSandboxCreateArgs().
+func buildCreateArgs(name string, cfg Config, hostWorkspace string) []string {
 	args := []string{
 		"run", "-d",
 		"--name", name,
@@ -83,20 +145,22 @@ func newDockerSandbox(ctx context.Context, name string, cfg Config, workspace st
 		args = append(args, "--pids-limit", fmt.Sprintf("%d", cfg.PidsLimit))
 	}
 
-	// Network
-	if !cfg.NetworkEnabled {
+	// Network policy: none unless enabled; an enabled sandbox joins the named
+	// network if one is set (e.g. an egress-filtered network), else the default.
+	switch {
+	case !cfg.NetworkEnabled:
 		args = append(args, "--network", "none")
+	case cfg.Network != "":
+		args = append(args, "--network", cfg.Network)
 	}
 
-	// Workspace mount — resolve host path for DooD (Docker-out-of-Docker) setups.
 	containerWorkdir := cfg.ContainerWorkdir()
-	if workspace != "" && cfg.WorkspaceAccess != AccessNone {
+	if hostWorkspace != "" {
 		mountOpt := "rw"
 		if cfg.WorkspaceAccess == AccessRO {
 			mountOpt = "ro"
 		}
-		hostPath := resolveHostWorkspacePath(ctx, workspace)
-		args = append(args, "-v", fmt.Sprintf("%s:%s:%s", hostPath, containerWorkdir, mountOpt))
+		args = append(args, "-v", fmt.Sprintf("%s:%s:%s", hostWorkspace, containerWorkdir, mountOpt))
 	}
 	args = append(args, "-w", containerWorkdir)
 
@@ -106,44 +170,7 @@ func newDockerSandbox(ctx context.Context, name string, cfg Config, workspace st
 	}
 
 	// Image + keep-alive command
-	args = append(args, cfg.Image, "sleep", "infinity")
-
-	slog.Debug("creating sandbox container", "name", name, "args", args)
-
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("docker run failed: %w\nstderr: %s", err, stderr.String())
-	}
-
-	containerID := strings.TrimSpace(stdout.String())
-	if len(containerID) > 12 {
-		containerID = containerID[:12]
-	}
-
-	slog.Info("sandbox container created", "id", containerID, "name", name, "image", cfg.Image)
-
-	// Run optional setup command (matching TS setupCommand)
-	if cfg.SetupCommand != "" {
-		setupCmd := exec.CommandContext(ctx, "docker", "exec", "-i", containerID, "sh", "-lc", cfg.SetupCommand)
-		if out, err := setupCmd.CombinedOutput(); err != nil {
-			slog.Warn("sandbox setup command failed", "id", containerID, "error", err, "output", string(out))
-		} else {
-			slog.Info("sandbox setup command completed", "id", containerID)
-		}
-	}
-
-	now := time.Now()
-	return &DockerSandbox{
-		containerID: containerID,
-		config:      cfg,
-		workspace:   workspace,
-		createdAt:   now,
-		lastUsed:    now,
-	}, nil
+	return append(args, cfg.Image, "sleep", "infinity")
 }
 
 // Exec runs a command inside the container.
@@ -174,7 +201,7 @@ func (s *DockerSandbox) Exec(ctx context.Context, command []string, workDir stri
 	args = append(args, s.containerID)
 	args = append(args, command...)
 
-	cmd := exec.CommandContext(execCtx, "docker", args...)
+	cmd := exec.CommandContext(execCtx, s.config.Binary(), args...)
 
 	// Limit output capture to prevent OOM from large command output
 	maxOut := s.config.MaxOutputBytes
@@ -185,6 +212,12 @@ func (s *DockerSandbox) Exec(ctx context.Context, command []string, workDir stri
 	stderr := &limitedBuffer{max: maxOut}
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
+	if o.Stdout != nil {
+		cmd.Stdout = io.MultiWriter(stdout, o.Stdout)
+	}
+	if o.Stderr != nil {
+		cmd.Stderr = io.MultiWriter(stderr, o.Stderr)
+	}
 
 	err := cmd.Run()
 	exitCode := 0
@@ -192,7 +225,7 @@ func (s *DockerSandbox) Exec(ctx context.Context, command []string, workDir stri
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			exitCode = exitErr.ExitCode()
 		} else {
-			return nil, fmt.Errorf("docker exec: %w", err)
+			return nil, fmt.Errorf("%s exec: %w", s.config.Binary(), err)
 		}
 	}
 
@@ -212,7 +245,7 @@ func (s *DockerSandbox) Exec(ctx context.Context, command []string, workDir stri
 
 // Destroy removes the container.
 func (s *DockerSandbox) Destroy(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "docker", "rm", "-f", s.containerID)
+	cmd := exec.CommandContext(ctx, s.config.Binary(), "rm", "-f", s.containerID)
 	if err := cmd.Run(); err != nil {
 		slog.Warn("failed to remove sandbox container", "id", s.containerID, "error", err)
 		return err
@@ -224,6 +257,9 @@ func (s *DockerSandbox) Destroy(ctx context.Context) error {
 // ID returns the container ID.
 func (s *DockerSandbox) ID() string { return s.containerID }
 
+// Runtime returns the container engine running this sandbox.
+func (s *DockerSandbox) Runtime() Runtime { return Runtime(s.config.Binary()) }
+
 // DockerManager manages Docker sandbox containers based on scope.
 type DockerManager struct {
 	config    Config
@@ -250,6 +286,9 @@ func (m *DockerManager) Get(ctx context.Context, key string, workspace string, c
 	cfg := m.config
 	if cfgOverride != nil {
 		cfg = *cfgOverride
+		if cfg.Runtime == "" {
+			cfg.Runtime = m.config.Runtime // per-agent configs inherit the detected engine
+		}
 	}
 	if cfg.Mode == ModeOff {
 		return nil, ErrSandboxDisabled
@@ -327,6 +366,7 @@ func (m *DockerManager) Stats() map[string]any {
 
 	return map[string]any{
 		"mode":       m.config.Mode,
+		"runtime":    m.config.Binary(),
 		"image":      m.config.Image,
 		"active":     len(m.sandboxes),
 		"containers": containers,
@@ -18,10 +18,11 @@ import (
 // container — the sandbox needs the corresponding host path or volume name
 // to mount it correctly.
 //
-// If not running in Docker (no /.dockerenv), returns localPath as-is.
-func resolveHostWorkspacePath(ctx context.Context, localPath string) string {
+// If not running in a container (no /.dockerenv or /run/.containerenv),
+// returns localPath as-is. binary is the engine CLI used to inspect mounts.
+func resolveHostWorkspacePath(ctx context.Context, binary, localPath string) string {
 	// Not in a container — local path is the host path.
-	if _, err := os.Stat("/.dockerenv"); err != nil {
+	if !inContainer() {
 		return localPath
 	}
 
@@ -35,9 +36,9 @@ func resolveHostWorkspacePath(ctx context.Context, localPath string) string {
 	inspectCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	out, err := exec.CommandContext(inspectCtx, "docker", "inspect", "--format", "{{json .Mounts}}", containerID).Output()
+	out, err := exec.CommandContext(inspectCtx, binary, "inspect", "--format", "{{json .Mounts}}", containerID).Output()
 	if err != nil {
-		slog.Warn("sandbox.resolve: docker inspect failed", "container", containerID, "error", err)
+		slog.Warn("sandbox.resolve: inspect failed", "runtime", binary, "container", containerID, "error", err)
 		return localPath
 	}
 
@@ -115,6 +116,17 @@ func resolveHostWorkspacePath(ctx context.Context, localPath string) string {
 	return localPath
 }
 
+// inContainer reports whether GoClaw itself runs in a Docker (/.dockerenv)
+// or Podman (/run/.containerenv) container.
+func inContainer() bool {
+	for _, marker := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // detectContainerID returns the current Docker container ID using multiple
 // strategies for reliability:
 //  1. /proc/self/mountinfo — parse the container ID from cgroup mount paths
@@ -126,3 +126,35 @@ func TestResolveScopeKey(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildCreateArgs_NetworkPolicy(t *testing.T) {
+	cfg := DefaultConfig()
+	args := strings.Join(buildCreateArgs("sbx", cfg, "/srv/ws"), " ")
+	if !strings.Contains(args, "--network none") {
+		t.Errorf("network disabled: args %q lack --network none", args)
+	}
+	if !strings.Contains(args, "-v /srv/ws:/workspace:rw") || !strings.Contains(args, "--memory 512m") || !strings.Contains(args, "--pids-limit 256") {
+		t.Errorf("args %q missing workspace mount or resource limits", args)
+	}
+
+	cfg.NetworkEnabled = true
+	cfg.Network = "sandbox-egress"
+	args = strings.Join(buildCreateArgs("sbx", cfg, ""), " ")
+	if !strings.Contains(args, "--network sandbox-egress") || strings.Contains(args, "-v ") {
+		t.Errorf("named network without workspace: args %q", args)
+	}
+
+	cfg.Network = ""
+	if args := strings.Join(buildCreateArgs("sbx", cfg, ""), " "); strings.Contains(args, "--network") {
+		t.Errorf("default network should not pass --network: %q", args)
+	}
+}
+
+func TestConfigBinary(t *testing.T) {
+	if got := (Config{}).Binary(); got != "docker" {
+		t.Errorf("default binary = %q, want docker", got)
+	}
+	if got := (Config{Runtime: RuntimePodman}).Binary(); got != "podman" {
+		t.Errorf("podman binary = %q", got)
+	}
+}
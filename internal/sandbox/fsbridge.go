@@ -1,7 +1,7 @@
 // Package sandbox — fsbridge.go provides sandboxed file operations via Docker exec.
 // Matching TS src/agents/sandbox/fs-bridge.ts.
 //
-// When sandbox is enabled, file tools (read_file, write_file, list_files)
+// When sandbox is enabled, file tools (read_file, write_file, list_files, grep, glob)
 // route through FsBridge instead of direct host filesystem access.
 // All operations execute inside the Docker container via "docker exec".
 package sandbox
@@ -20,16 +20,18 @@ import (
 type FsBridge struct {
 	containerID string
 	workdir     string // container-side working directory (e.g. "/workspace")
+	binary      string // container engine CLI ("docker" or "podman")
 }
 
 // NewFsBridge creates a bridge to a running sandbox container.
-func NewFsBridge(containerID, workdir string) *FsBridge {
+func NewFsBridge(sb Sandbox, workdir string) *FsBridge {
 	if workdir == "" {
 		workdir = "/workspace"
 	}
 	return &FsBridge{
-		containerID: containerID,
+		containerID: sb.ID(),
 		workdir:     workdir,
+		binary:      Config{Runtime: sb.Runtime()}.Binary(),
 	}
 }
 
@@ -109,6 +111,83 @@ func (b *FsBridge) Stat(ctx context.Context, path string) (string, error) {
 	return stdout, nil
 }
 
+// FindFiles lists regular files under root, relative to root, skipping the
+// named directories. Symlinks are not followed.
+func (b *FsBridge) FindFiles(ctx context.Context, root string, skipDirs []string) ([]string, error) {
+	resolved := b.resolvePath(root)
+
+	args := []string{"find", resolved}
+	if len(skipDirs) > 0 {
+		args = append(args, "(")
+		for i, d := range skipDirs {
+			if i > 0 {
+				args = append(args, "-o")
+			}
+			args = append(args, "-name", d)
+		}
+		args = append(args, ")", "-type", "d", "-prune", "-o")
+	}
+	args = append(args, "-type", "f", "-print")
+
+	stdout, stderr, exitCode, err := b.dockerExec(ctx, nil, args...)
+	if err != nil {
+		return nil, fmt.Errorf("fsbridge find: %w", err)
+	}
+	if exitCode != 0 && stdout == "" {
+		return nil, fmt.Errorf("find failed: %s", strings.TrimSpace(stderr))
+	}
+
+	var files []string
+	for _, line := range strings.Split(stdout, "\n") {
+		rel := strings.TrimPrefix(strings.TrimPrefix(line, resolved), "/")
+		if rel != "" {
+			files = append(files, rel)
+		}
+	}
+	return files, nil
+}
+
+// GrepOptions configures FsBridge.Grep.
+type GrepOptions struct {
+	Pattern    string // extended regular expression
+	IgnoreCase bool
+	Fixed      bool // literal string match
+	Context    int  // lines of context around each match
+}
+
+// Grep searches files (relative to root) with grep -nH, skipping binary
+// files. Output is grep's "path:N:text" format; no matches is not an error.
+func (b *FsBridge) Grep(ctx context.Context, root string, files []string, opts GrepOptions) (string, error) {
+	resolved := b.resolvePath(root)
+
+	grepArgs := []string{"grep", "-nHI"}
+	if opts.Fixed {
+		grepArgs = append(grepArgs, "-F")
+	} else {
+		grepArgs = append(grepArgs, "-E")
+	}
+	if opts.IgnoreCase {
+		grepArgs = append(grepArgs, "-i")
+	}
+	if opts.Context > 0 {
+		grepArgs = append(grepArgs, "-C", fmt.Sprintf("%d", opts.Context))
+	}
+	grepArgs = append(grepArgs, "-e", opts.Pattern, "--")
+
+	// File list goes over stdin (NUL-separated) to avoid argv limits.
+	stdin := []byte(strings.Join(files, "\x00"))
+	args := append([]string{"sh", "-c", `cd "$0" && xargs -0 "$@"`, resolved}, grepArgs...)
+	stdout, stderr, exitCode, err := b.dockerExec(ctx, stdin, args...)
+	if err != nil {
+		return "", fmt.Errorf("fsbridge grep: %w", err)
+	}
+	// grep exits 1 when nothing matched; xargs reports that as 123.
+	if exitCode != 0 && exitCode != 1 && exitCode != 123 {
+		return "", fmt.Errorf("grep failed: %s", strings.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
 // resolvePath resolves a path relative to the container workdir.
 // Validates that absolute paths stay within the workdir (defense in depth).
 func (b *FsBridge) resolvePath(path string) string {
@@ -137,7 +216,7 @@ func (b *FsBridge) dockerExec(ctx context.Context, stdin []byte, args ...string)
 	dockerArgs = append(dockerArgs, b.containerID)
 	dockerArgs = append(dockerArgs, args...)
 
-	cmd := exec.CommandContext(ctx, "docker", dockerArgs...)
+	cmd := exec.CommandContext(ctx, b.binary, dockerArgs...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -1,7 +1,7 @@
-// Package sandbox provides Docker-based code execution isolation.
+// Package sandbox provides container-based code execution isolation.
 //
-// Agents can run tool commands (exec, shell) inside Docker containers
-// instead of the host system. Sandbox modes:
+// Agents can run tool commands (exec, shell) inside Docker or Podman
+// containers instead of the host system. Sandbox modes:
 //   - off: no sandboxing, execute directly on host
 //   - non-main: all agents except "main" run in sandbox
 //   - all: every agent runs in sandbox
@@ -20,6 +20,7 @@ package sandbox
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -50,10 +51,19 @@ const (
 	ScopeShared  Scope = "shared"  // one container for all
 )
 
+// Runtime is the container engine CLI used to run sandboxes.
+type Runtime string
+
+const (
+	RuntimeDocker Runtime = "docker" // default
+	RuntimePodman Runtime = "podman" // daemonless, rootless-friendly; CLI-compatible with docker
+)
+
 // Config configures the sandbox system.
 // Matches TS SandboxDockerSettings + SandboxConfig.
 type Config struct {
 	Mode              Mode              `json:"mode"`
+	Runtime           Runtime           `json:"runtime,omitempty"` // container engine (default docker)
 	Image             string            `json:"image"`
 	WorkspaceAccess   Access            `json:"workspace_access"`
 	Scope             Scope             `json:"scope"`
@@ -61,6 +71,7 @@ type Config struct {
 	CPUs              float64           `json:"cpus"`
 	TimeoutSec        int               `json:"timeout_sec"`
 	NetworkEnabled    bool              `json:"network_enabled"`
+	Network           string            `json:"network,omitempty"` // named network to join when enabled (default bridge)
 	RestrictedDomains []string          `json:"restricted_domains,omitempty"`
 	Env               map[string]string `json:"env,omitempty"`
 
@@ -118,6 +129,14 @@ func (c Config) ShouldSandbox(agentID string) bool {
 	}
 }
 
+// Binary returns the container engine CLI for this config.
+func (c Config) Binary() string {
+	if c.Runtime == "" {
+		return string(RuntimeDocker)
+	}
+	return string(c.Runtime)
+}
+
 // DefaultContainerWorkdir is the default container-side working directory
 // used when no custom Workdir is configured.
 const DefaultContainerWorkdir = "/workspace"
@@ -163,7 +182,9 @@ type ExecOption func(*ExecOpts)
 
 // ExecOpts holds optional settings applied via ExecOption.
 type ExecOpts struct {
-	Env map[string]string // extra env vars injected into the container exec
+	Env    map[string]string // extra env vars injected into the container exec
+	Stdout io.Writer         // optional live copy of stdout (progress streaming)
+	Stderr io.Writer         // optional live copy of stderr
 }
 
 // WithEnv injects additional environment variables into the sandbox exec call.
@@ -172,6 +193,12 @@ func WithEnv(env map[string]string) ExecOption {
 	return func(o *ExecOpts) { o.Env = env }
 }
 
+// WithOutputTee copies the command's output to stdout/stderr as it is
+// produced, in addition to the captured ExecResult. Either may be nil.
+func WithOutputTee(stdout, stderr io.Writer) ExecOption {
+	return func(o *ExecOpts) { o.Stdout, o.Stderr = stdout, stderr }
+}
+
 // ApplyExecOpts resolves variadic ExecOption into ExecOpts.
 func ApplyExecOpts(opts []ExecOption) ExecOpts {
 	var o ExecOpts
@@ -192,6 +219,9 @@ type Sandbox interface {
 
 	// ID returns the sandbox's unique identifier (container ID).
 	ID() string
+
+	// Runtime returns the container engine that runs the sandbox.
+	Runtime() Runtime
 }
 
 // Manager manages sandbox lifecycle based on scope.
@@ -0,0 +1,54 @@
+package scheduler
+
+import "time"
+
+// defaultRunEstimate is the assumed run time before a lane has finished any run.
+const defaultRunEstimate = 30 * time.Second
+
+// LaneLoad is a lane's backpressure signal: whether a new request would wait
+// for a worker slot and, if so, roughly how long.
+type LaneLoad struct {
+	Lane      string `json:"lane"`
+	Saturated bool   `json:"saturated"` // no own or borrowable slot is free; new requests queue
+
+	// Position is the 1-based place a new request would take in the lane's
+	// wait queue (0 when a slot is free).
+	Position int `json:"position,omitempty"`
+
+	// EstimatedWait is the expected time until a new request starts, from
+	// the lane's average run time (0 when a slot is free).
+	EstimatedWait time.Duration `json:"estimatedWait,omitempty"`
+}
+
+// Load returns the lane's current backpressure signal. The wait estimate
+// assumes requests ahead finish in waves of `concurrency` runs, each taking
+// the lane's average run time.
+func (l *Lane) Load() LaneLoad {
+	load := LaneLoad{Lane: l.name}
+	l.pool.mu.Lock()
+	free := l.freeSlotLocked() != nil
+	l.pool.mu.Unlock()
+	if free {
+		return load
+	}
+
+	load.Saturated = true
+	load.Position = int(l.pending.Load()) + 1
+
+	avgRun := defaultRunEstimate
+	if n := l.runCount.Load(); n > 0 {
+		avgRun = time.Duration(l.runTotalNs.Load() / n)
+	}
+	waves := (load.Position + l.concurrency - 1) / l.concurrency
+	load.EstimatedWait = time.Duration(waves) * avgRun
+	return load
+}
+
+// LaneLoad returns the backpressure signal of the named lane (falling back
+// to the main lane like Get). A zero LaneLoad means no lane is configured.
+func (s *Scheduler) LaneLoad(lane string) LaneLoad {
+	if l := s.lanes.Get(lane); l != nil {
+		return l.Load()
+	}
+	return LaneLoad{Lane: lane}
+}
@@ -13,6 +13,10 @@ var (
 	// enqueued before an abort (/stopall) and is no longer relevant.
 	ErrMessageStale = errors.New("message stale: enqueued before abort")
 
+	// ErrMessageSteered is returned when a message was injected into the
+	// session's running run (QueueModeSteer); that run's reply answers it.
+	ErrMessageSteered = errors.New("message steered into running run")
+
 	// ErrGatewayDraining is returned when the gateway is shutting down and
 	// new requests cannot be accepted.
 	ErrGatewayDraining = errors.New("gateway is shutting down, please retry shortly")
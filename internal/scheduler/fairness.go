@@ -0,0 +1,229 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	// maxTrackedUsers bounds the per-lane wait metrics; the least recently
+	// seen user is dropped first.
+	maxTrackedUsers = 1000
+
+	// maxStatsUsers is how many users LaneStats lists.
+	maxStatsUsers = 20
+)
+
+// FairnessConfig shares lane slots fairly between users. Requests carry a
+// fair-share key (SubmitOpts.User); waiting requests of different keys take
+// turns for slots, so one user flooding many sessions cannot starve others.
+type FairnessConfig struct {
+	// MaxInflightPerUser caps how many requests of one key run at once
+	// across all lanes (0 = unlimited). Further requests wait even when a
+	// slot is free.
+	MaxInflightPerUser int
+
+	// Weights gives a key more consecutive slots per turn (default 1).
+	Weights map[string]int
+}
+
+// UserWaitStats is one user's queue wait in a lane.
+type UserWaitStats struct {
+	User      string  `json:"user"`
+	Waits     int64   `json:"waits"` // requests that acquired a slot
+	AvgWaitMs float64 `json:"avgWaitMs"`
+	MaxWaitMs float64 `json:"maxWaitMs"`
+	Queued    int     `json:"queued"` // requests waiting now
+}
+
+type userWait struct {
+	count    int64
+	totalNs  int64
+	maxNs    int64
+	lastSeen time.Time
+}
+
+func (p *lanePool) weight(user string) int {
+	if w := p.fair.Weights[user]; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// cappedLocked reports whether user already runs its maximum.
+func (p *lanePool) cappedLocked(user string) bool {
+	return user != "" && p.fair.MaxInflightPerUser > 0 && p.users[user] >= p.fair.MaxInflightPerUser
+}
+
+func (p *lanePool) startLocked(user string) {
+	if user == "" {
+		return
+	}
+	if p.users == nil {
+		p.users = make(map[string]int)
+	}
+	p.users[user]++
+}
+
+func (p *lanePool) finishLocked(user string) {
+	if user == "" {
+		return
+	}
+	if p.users[user]--; p.users[user] <= 0 {
+		delete(p.users, user)
+	}
+}
+
+// enqueueLocked adds w to the lane's waiters, giving its user a turn.
+func (l *Lane) enqueueLocked(w *laneWaiter) {
+	l.waiters = append(l.waiters, w)
+	if !slices.Contains(l.turns, w.user) {
+		l.turns = append(l.turns, w.user)
+	}
+}
+
+// dequeueLocked removes a waiter that gave up. Returns false if it was
+// already granted a slot.
+func (l *Lane) dequeueLocked(w *laneWaiter) bool {
+	i := slices.Index(l.waiters, w)
+	if i < 0 {
+		return false
+	}
+	l.waiters = slices.Delete(l.waiters, i, i+1)
+	l.dropTurnIfIdleLocked(w.user)
+	return true
+}
+
+// nextWaiterLocked returns the waiter to serve next: the oldest request of
+// the user whose turn it is. Users at their in-flight cap lose their turn.
+// Returns nil when no waiter may start.
+func (l *Lane) nextWaiterLocked() *laneWaiter {
+	for range len(l.turns) {
+		user := l.turns[0]
+		if !l.pool.cappedLocked(user) {
+			i := slices.IndexFunc(l.waiters, func(w *laneWaiter) bool { return w.user == user })
+			return l.waiters[i]
+		}
+		l.rotateTurnLocked()
+	}
+	return nil
+}
+
+// grantLocked removes w, the waiter returned by nextWaiterLocked, and
+// moves the turn on once its user has used its weight.
+func (l *Lane) grantLocked(w *laneWaiter) {
+	l.waiters = slices.DeleteFunc(l.waiters, func(o *laneWaiter) bool { return o == w })
+	if l.dropTurnIfIdleLocked(w.user) {
+		return
+	}
+	if l.turnUsed++; l.turnUsed >= l.pool.weight(w.user) {
+		l.rotateTurnLocked()
+	}
+}
+
+func (l *Lane) rotateTurnLocked() {
+	l.turns = append(l.turns[1:], l.turns[0])
+	l.turnUsed = 0
+}
+
+// dropTurnIfIdleLocked removes user from the turn order once it has no
+// waiters left. Reports whether it did.
+func (l *Lane) dropTurnIfIdleLocked(user string) bool {
+	if slices.ContainsFunc(l.waiters, func(w *laneWaiter) bool { return w.user == user }) {
+		return false
+	}
+	i := slices.Index(l.turns, user)
+	if i < 0 {
+		return true
+	}
+	if i == 0 {
+		l.turnUsed = 0
+	}
+	l.turns = slices.Delete(l.turns, i, i+1)
+	return true
+}
+
+// recordUserWaitLocked accumulates queue wait for keyed requests.
+func (l *Lane) recordUserWaitLocked(user string, d time.Duration) {
+	if user == "" {
+		return
+	}
+	uw := l.userWaits[user]
+	if uw == nil {
+		if l.userWaits == nil {
+			l.userWaits = make(map[string]*userWait)
+		}
+		if len(l.userWaits) >= maxTrackedUsers {
+			l.evictUserWaitLocked()
+		}
+		uw = &userWait{}
+		l.userWaits[user] = uw
+	}
+	ns := d.Nanoseconds()
+	uw.count++
+	uw.totalNs += ns
+	uw.maxNs = max(uw.maxNs, ns)
+	uw.lastSeen = time.Now()
+}
+
+func (l *Lane) evictUserWaitLocked() {
+	var oldest string
+	var oldestSeen time.Time
+	for user, uw := range l.userWaits {
+		if oldest == "" || uw.lastSeen.Before(oldestSeen) {
+			oldest, oldestSeen = user, uw.lastSeen
+		}
+	}
+	delete(l.userWaits, oldest)
+}
+
+// userStatsLocked returns the users with the longest total wait.
+func (l *Lane) userStatsLocked() []UserWaitStats {
+	if len(l.userWaits) == 0 && len(l.waiters) == 0 {
+		return nil
+	}
+	queued := make(map[string]int)
+	for _, w := range l.waiters {
+		if w.user != "" {
+			queued[w.user]++
+		}
+	}
+	type entry struct {
+		stats   UserWaitStats
+		totalNs int64
+	}
+	var entries []entry
+	for user, uw := range l.userWaits {
+		entries = append(entries, entry{
+			stats: UserWaitStats{
+				User:      user,
+				Waits:     uw.count,
+				AvgWaitMs: float64(uw.totalNs) / float64(uw.count) / float64(time.Millisecond),
+				MaxWaitMs: float64(uw.maxNs) / float64(time.Millisecond),
+				Queued:    queued[user],
+			},
+			totalNs: uw.totalNs,
+		})
+		delete(queued, user)
+	}
+	for user, n := range queued { // waiting for their first slot
+		entries = append(entries, entry{stats: UserWaitStats{User: user, Queued: n}})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		if a.stats.Queued != b.stats.Queued {
+			return b.stats.Queued - a.stats.Queued
+		}
+		switch {
+		case a.totalNs > b.totalNs:
+			return -1
+		case a.totalNs < b.totalNs:
+			return 1
+		}
+		return 0
+	})
+	stats := make([]UserWaitStats, 0, min(len(entries), maxStatsUsers))
+	for _, e := range entries[:min(len(entries), maxStatsUsers)] {
+		stats = append(stats, e.stats)
+	}
+	return stats
+}
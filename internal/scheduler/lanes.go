@@ -13,9 +13,11 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Lane name constants.
@@ -27,27 +29,100 @@ const (
 )
 
 // LaneConfig configures a single lane.
+//
+// Priority only matters relative to other lanes. When a lane is saturated,
+// its requests take free slots from lower-priority lanes, and a freed slot
+// goes to the waiting request of the highest-priority lane that may use it.
+// Preempt additionally lets a saturated lane stop a run in a lower-priority
+// lane when no slot is free; the stopped run is restarted from its session
+// queue later. Runs that have started acting (tool calls) refuse, since a
+// restart would repeat those effects. All lanes default to priority 0 (no
+// borrowing).
 type LaneConfig struct {
 	Name        string `json:"name"`
 	Concurrency int    `json:"concurrency"`
+	Priority    int    `json:"priority,omitempty"`
+	Preempt     bool   `json:"preempt,omitempty"`
+}
+
+// lanePool is the slot accounting shared by the lanes of one LaneManager, so
+// slots can move between lanes. lanes is kept sorted by priority, highest
+// first.
+type lanePool struct {
+	mu    sync.Mutex
+	lanes []*Lane
+	fair  FairnessConfig
+	users map[string]int // running requests per fair-share key (keyed requests only)
+}
+
+// laneWaiter is a request waiting for a slot. ready receives the lane whose
+// slot it was given.
+type laneWaiter struct {
+	user  string
+	ready chan *Lane
+}
+
+// laneRun is a running request that may be preempted.
+type laneRun struct {
+	lane      *Lane
+	preempt   func() bool
+	preempted bool // asked once, whether or not it agreed
+}
+
+// SubmitOpts are per-request options for Lane.SubmitWithOpts.
+type SubmitOpts struct {
+	// User is the fair-share key. Waiting requests of different users take
+	// turns for slots, and FairnessConfig.MaxInflightPerUser caps how many
+	// requests of one user run at once. "" marks system work: it takes its
+	// turn like one more user and is never capped.
+	User string
+
+	// Preempt, when set, lets a higher-priority lane stop the run. It is
+	// called at most once, from another goroutine, to ask fn to return
+	// early, and must not block. It returns false when the run can no
+	// longer be stopped safely; the lane then tries the next candidate.
+	Preempt func() bool
 }
 
 // Lane is a named worker pool with bounded concurrency.
 // Requests submitted to a lane execute concurrently up to the
-// configured limit; excess requests wait in a buffered channel.
+// configured limit; excess requests wait, taking turns by user.
 type Lane struct {
 	name        string
 	concurrency int
-	sem         chan struct{} // semaphore tokens
-	pending     atomic.Int64  // pending requests count
-	active      atomic.Int64  // active (running) requests count
+	priority    int
+	preempt     bool
+	pool        *lanePool
+
+	// Guarded by pool.mu.
+	free      int                  // own slots not in use
+	waiters   []*laneWaiter        // requests waiting for a slot, oldest first
+	turns     []string             // users with waiters, in round-robin order
+	turnUsed  int                  // slots granted to turns[0] in its current turn
+	runs      []*laneRun           // preemptible runs, oldest first
+	userWaits map[string]*userWait // queue wait per fair-share key
+
+	pending     atomic.Int64 // pending requests count
+	active      atomic.Int64 // active (running) requests count
+	waitCount   atomic.Int64 // requests that acquired a slot
+	waitTotalNs atomic.Int64 // cumulative time spent waiting for a slot
+	waitMaxNs   atomic.Int64 // longest observed wait for a slot
+	runCount    atomic.Int64 // requests that finished running
+	runTotalNs  atomic.Int64 // cumulative run time of finished requests
+	borrowed    atomic.Int64 // requests that ran on another lane's slot
+	preempted   atomic.Int64 // runs of this lane stopped for a higher-priority lane
 	ctx         context.Context
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
 }
 
-// NewLane creates a lane with the given concurrency limit.
+// NewLane creates a standalone lane with the given concurrency limit.
 func NewLane(name string, concurrency int) *Lane {
+	return newLane(LaneConfig{Name: name, Concurrency: concurrency}, &lanePool{})
+}
+
+func newLane(cfg LaneConfig, pool *lanePool) *Lane {
+	concurrency := cfg.Concurrency
 	if concurrency <= 0 {
 		concurrency = 2
 	}
@@ -55,51 +130,211 @@ func NewLane(name string, concurrency int) *Lane {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	l := &Lane{
-		name:        name,
+		name:        cfg.Name,
 		concurrency: concurrency,
-		sem:         make(chan struct{}, concurrency),
+		priority:    cfg.Priority,
+		preempt:     cfg.Preempt,
+		pool:        pool,
+		free:        concurrency,
 		ctx:         ctx,
 		cancel:      cancel,
 	}
-
-	// Pre-fill semaphore tokens
-	for i := 0; i < concurrency; i++ {
-		l.sem <- struct{}{}
-	}
-
+	pool.mu.Lock()
+	pool.lanes = append(pool.lanes, l)
+	slices.SortStableFunc(pool.lanes, func(a, b *Lane) int { return b.priority - a.priority })
+	pool.mu.Unlock()
 	return l
 }
 
 // Submit runs fn in the lane, blocking until a worker slot is available
 // or ctx is cancelled. Returns immediately if the lane is shut down.
 func (l *Lane) Submit(ctx context.Context, fn func()) error {
+	return l.SubmitWithOpts(ctx, fn, SubmitOpts{})
+}
+
+// SubmitWithOpts is Submit with a fair-share key and an optional preempt
+// hook (see SubmitOpts).
+func (l *Lane) SubmitWithOpts(ctx context.Context, fn func(), opts SubmitOpts) error {
 	l.pending.Add(1)
 	defer l.pending.Add(-1)
+	enqueuedAt := time.Now()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if l.ctx.Err() != nil {
+		return context.Canceled
+	}
+	lender, err := l.acquire(ctx, opts.User)
+	if err != nil {
+		return err
+	}
+
+	wait := time.Since(enqueuedAt)
+	l.recordWait(wait)
+	if lender != l {
+		l.borrowed.Add(1)
+	}
+	var run *laneRun
+	l.pool.mu.Lock()
+	l.recordUserWaitLocked(opts.User, wait)
+	if opts.Preempt != nil {
+		run = &laneRun{lane: l, preempt: opts.Preempt}
+		l.runs = append(l.runs, run)
+	}
+	l.pool.mu.Unlock()
+	l.active.Add(1)
+	l.wg.Add(1)
+
+	go func() {
+		startedAt := time.Now()
+		defer func() {
+			l.runCount.Add(1)
+			l.runTotalNs.Add(time.Since(startedAt).Nanoseconds())
+			l.pool.mu.Lock()
+			if run != nil {
+				l.runs = slices.DeleteFunc(l.runs, func(r *laneRun) bool { return r == run })
+			}
+			l.pool.releaseLocked(lender, opts.User)
+			l.pool.mu.Unlock()
+			l.active.Add(-1)
+			l.wg.Done()
+		}()
+		fn()
+	}()
+
+	return nil
+}
+
+// acquire takes a slot for user: a free one of this lane, else a free one
+// of a lower-priority lane, else one handed over by dispatchLocked. Returns
+// the lane that owns the slot.
+func (l *Lane) acquire(ctx context.Context, user string) (*Lane, error) {
+	l.pool.mu.Lock()
+	capped := l.pool.cappedLocked(user)
+	if !capped {
+		if lender := l.freeSlotLocked(); lender != nil {
+			lender.free--
+			l.pool.startLocked(user)
+			l.pool.mu.Unlock()
+			return lender, nil
+		}
+	}
+	w := &laneWaiter{user: user, ready: make(chan *Lane, 1)}
+	l.enqueueLocked(w)
+	var victim *laneRun
+	if l.preempt && !capped {
+		victim = l.preemptVictimLocked()
+	}
+	l.pool.mu.Unlock()
+
+	if victim != nil {
+		victim.lane.preempted.Add(1)
+		slog.Info("lane: preempted run", "lane", victim.lane.name, "for", l.name)
+	}
 
-	// Wait for a semaphore token or cancellation
 	select {
+	case lender := <-w.ready:
+		return lender, nil
 	case <-ctx.Done():
-		return ctx.Err()
+		l.abandon(w)
+		return nil, ctx.Err()
 	case <-l.ctx.Done():
-		return context.Canceled
-	case token, ok := <-l.sem:
-		if !ok {
-			return context.Canceled
+		l.abandon(w)
+		return nil, context.Canceled
+	}
+}
+
+// abandon removes a waiter that gave up. A slot handed over in the
+// meantime is released again.
+func (l *Lane) abandon(w *laneWaiter) {
+	l.pool.mu.Lock()
+	defer l.pool.mu.Unlock()
+	if l.dequeueLocked(w) {
+		return
+	}
+	l.pool.releaseLocked(<-w.ready, w.user)
+}
+
+// freeSlotLocked returns the lane whose free slot l may use: l itself, or
+// the lowest-priority lane below l with a free slot.
+// Must be called with pool.mu held.
+func (l *Lane) freeSlotLocked() *Lane {
+	if l.free > 0 {
+		return l
+	}
+	for _, o := range slices.Backward(l.pool.lanes) {
+		if o.priority >= l.priority {
+			break
+		}
+		if o.free > 0 {
+			return o
 		}
+	}
+	return nil
+}
 
-		l.active.Add(1)
-		l.wg.Add(1)
+// releaseLocked returns a slot of lender held by user and hands free slots
+// to waiting requests. Must be called with pool.mu held.
+func (p *lanePool) releaseLocked(lender *Lane, user string) {
+	p.finishLocked(user)
+	lender.free++
+	p.dispatchLocked()
+}
 
-		go func() {
-			defer func() {
-				l.active.Add(-1)
-				l.wg.Done()
-				l.sem <- token // return token
-			}()
-			fn()
-		}()
+// dispatchLocked gives free slots to waiting requests, highest-priority
+// lanes first, each lane using its own slots before borrowing. Within a
+// lane, users take turns (see nextWaiterLocked). Must be called with
+// pool.mu held.
+func (p *lanePool) dispatchLocked() {
+	for _, l := range p.lanes {
+		for {
+			w := l.nextWaiterLocked()
+			if w == nil {
+				break
+			}
+			lender := l.freeSlotLocked()
+			if lender == nil {
+				break
+			}
+			lender.free--
+			l.grantLocked(w)
+			p.startLocked(w.user)
+			w.ready <- lender
+		}
+	}
+}
 
-		return nil
+// preemptVictimLocked stops the newest not-yet-asked run of the
+// lowest-priority lane below l that agrees to stop, and returns it. Must be
+// called with pool.mu held.
+func (l *Lane) preemptVictimLocked() *laneRun {
+	for _, o := range slices.Backward(l.pool.lanes) {
+		if o.priority >= l.priority {
+			break
+		}
+		for _, r := range slices.Backward(o.runs) {
+			if !r.preempted {
+				r.preempted = true
+				if r.preempt() {
+					return r
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// recordWait accumulates queue wait time for Stats.
+func (l *Lane) recordWait(d time.Duration) {
+	ns := d.Nanoseconds()
+	l.waitCount.Add(1)
+	l.waitTotalNs.Add(ns)
+	for {
+		old := l.waitMaxNs.Load()
+		if ns <= old || l.waitMaxNs.CompareAndSwap(old, ns) {
+			return
+		}
 	}
 }
 
@@ -111,25 +346,62 @@ func (l *Lane) Stop() {
 
 // Stats returns lane utilization metrics.
 func (l *Lane) Stats() LaneStats {
-	return LaneStats{
+	stats := LaneStats{
 		Name:        l.name,
 		Concurrency: l.concurrency,
+		Priority:    l.priority,
+		Preempt:     l.preempt,
 		Active:      int(l.active.Load()),
 		Pending:     int(l.pending.Load()),
+		Completed:   l.waitCount.Load(),
+		MaxWaitMs:   float64(l.waitMaxNs.Load()) / float64(time.Millisecond),
+		Borrowed:    l.borrowed.Load(),
+		Preempted:   l.preempted.Load(),
+	}
+	l.pool.mu.Lock()
+	stats.Users = l.userStatsLocked()
+	l.pool.mu.Unlock()
+	if stats.Completed > 0 {
+		stats.AvgWaitMs = float64(l.waitTotalNs.Load()) / float64(stats.Completed) / float64(time.Millisecond)
 	}
+	if n := l.runCount.Load(); n > 0 {
+		stats.AvgRunMs = float64(l.runTotalNs.Load()) / float64(n) / float64(time.Millisecond)
+	}
+	return stats
 }
 
 // LaneStats is a snapshot of lane utilization.
 type LaneStats struct {
 	Name        string `json:"name"`
 	Concurrency int    `json:"concurrency"`
-	Active      int    `json:"active"`
+	Priority    int    `json:"priority"`
+	Preempt     bool   `json:"preempt,omitempty"`
+	Active      int    `json:"active"` // may exceed Concurrency while borrowing slots
 	Pending     int    `json:"pending"`
+
+	// Queue wait metrics since lane creation. Completed counts requests
+	// that acquired a slot (not necessarily finished running).
+	Completed int64   `json:"completed"`
+	AvgWaitMs float64 `json:"avgWaitMs"`
+	MaxWaitMs float64 `json:"maxWaitMs"`
+
+	// AvgRunMs is the mean run time of requests that finished running.
+	AvgRunMs float64 `json:"avgRunMs"`
+
+	// Borrowed counts requests that ran on a lower-priority lane's slot;
+	// Preempted counts runs of this lane stopped to free a slot.
+	Borrowed  int64 `json:"borrowed"`
+	Preempted int64 `json:"preempted"`
+
+	// Users lists queue wait by fair-share key: users with queued requests
+	// first, then by total wait.
+	Users []UserWaitStats `json:"users,omitempty"`
 }
 
 // LaneManager manages named lanes.
 type LaneManager struct {
 	lanes map[string]*Lane
+	pool  *lanePool
 	mu    sync.RWMutex
 }
 
@@ -137,11 +409,12 @@ type LaneManager struct {
 func NewLaneManager(configs []LaneConfig) *LaneManager {
 	lm := &LaneManager{
 		lanes: make(map[string]*Lane),
+		pool:  &lanePool{},
 	}
 
 	for _, cfg := range configs {
-		lm.lanes[cfg.Name] = NewLane(cfg.Name, cfg.Concurrency)
-		slog.Info("lane created", "name", cfg.Name, "concurrency", cfg.Concurrency)
+		lm.lanes[cfg.Name] = newLane(cfg, lm.pool)
+		slog.Info("lane created", "name", cfg.Name, "concurrency", cfg.Concurrency, "priority", cfg.Priority, "preempt", cfg.Preempt)
 	}
 
 	return lm
@@ -209,7 +482,7 @@ func (lm *LaneManager) GetOrCreate(name string, concurrency int) *Lane {
 		return lane
 	}
 
-	lane := NewLane(name, concurrency)
+	lane := newLane(LaneConfig{Name: name, Concurrency: concurrency}, lm.pool)
 	lm.lanes[name] = lane
 	slog.Info("lane created on demand", "name", name, "concurrency", concurrency)
 	return lane
@@ -226,6 +499,14 @@ func (lm *LaneManager) StopAll() {
 	}
 }
 
+// SetFairness sets the fair-share limits shared by all lanes.
+func (lm *LaneManager) SetFairness(cfg FairnessConfig) {
+	lm.pool.mu.Lock()
+	defer lm.pool.mu.Unlock()
+	lm.pool.fair = cfg
+	lm.pool.dispatchLocked() // a raised cap may unblock waiters
+}
+
 // AllStats returns utilization for all lanes.
 func (lm *LaneManager) AllStats() []LaneStats {
 	lm.mu.RLock()
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/agent"
@@ -23,6 +25,11 @@ const (
 
 	// QueueModeInterrupt cancels the current run and starts the new message.
 	QueueModeInterrupt QueueMode = "interrupt"
+
+	// QueueModeSteer injects the new message into the session's running agent
+	// loop before its next LLM call. Falls back to queueing when nothing is
+	// running or the message cannot be injected.
+	QueueModeSteer QueueMode = "steer"
 )
 
 // DropPolicy determines which messages to drop when the queue is full.
@@ -57,6 +64,10 @@ func DefaultQueueConfig() QueueConfig {
 // The scheduler calls this when it's the request's turn.
 type RunFunc func(ctx context.Context, req agent.RunRequest) (*agent.RunResult, error)
 
+// SteerFunc injects a message into the running agent loop of a session.
+// Returns false when no run accepts it (see agent.Router.InjectMessage).
+type SteerFunc func(sessionKey string, msg agent.InjectedMessage) bool
+
 // TokenEstimateFunc returns token estimate and context window for a session.
 // Used by adaptive throttle to reduce concurrency near the summary threshold.
 type TokenEstimateFunc func(sessionKey string) (tokens int, contextWindow int)
@@ -66,6 +77,10 @@ type PendingRequest struct {
 	Req        agent.RunRequest
 	ResultCh   chan RunOutcome
 	EnqueuedAt time.Time // timestamp when enqueued, used for stale message detection
+
+	user      string // fair-share key passed to the lane (see SubmitOpts)
+	onSteered func() // called once the message is injected into a running run
+	preempted bool   // already preempted once; not preemptible again
 }
 
 // RunOutcome is the result of a scheduled agent run.
@@ -98,8 +113,10 @@ type SessionQueue struct {
 	parentCtx       context.Context           // stored from first Enqueue call
 	abortCutoffTime time.Time                 // messages enqueued before this are stale
 	generation      uint64                    // bumped on Reset() to ignore stale completions
+	steered         []*PendingRequest         // injected into a running run, settled when it finishes
 
 	tokenEstimateFn TokenEstimateFunc // optional: for adaptive throttle
+	steerFn         SteerFunc         // optional: required by QueueModeSteer
 }
 
 // NewSessionQueue creates a queue for a specific session.
@@ -158,8 +175,20 @@ func (sq *SessionQueue) hasCapacity() bool {
 // If capacity is available, it starts immediately (after debounce).
 // Returns a channel that receives the result when the run completes.
 func (sq *SessionQueue) Enqueue(ctx context.Context, req agent.RunRequest) <-chan RunOutcome {
+	return sq.enqueue(ctx, &PendingRequest{Req: req})
+}
+
+func (sq *SessionQueue) enqueue(ctx context.Context, pending *PendingRequest) <-chan RunOutcome {
 	outcome := make(chan RunOutcome, 1)
-	pending := &PendingRequest{Req: req, ResultCh: outcome, EnqueuedAt: time.Now()}
+	pending.ResultCh = outcome
+	pending.EnqueuedAt = time.Now()
+
+	if sq.config.Mode == QueueModeSteer && sq.steer(pending) {
+		if pending.onSteered != nil {
+			pending.onSteered()
+		}
+		return outcome
+	}
 
 	sq.mu.Lock()
 	defer sq.mu.Unlock()
@@ -199,6 +228,51 @@ func (sq *SessionQueue) Enqueue(ctx context.Context, req agent.RunRequest) <-cha
 	return outcome
 }
 
+// steer injects pending into a running run of the session. Only user
+// messages (with a fair-share key) steer; system runs queue as usual. The
+// request is settled when that run finishes (see settleSteered).
+func (sq *SessionQueue) steer(pending *PendingRequest) bool {
+	req := pending.Req
+	if sq.steerFn == nil || pending.user == "" || req.Message == "" || len(req.Media) > 0 || req.RunKind != "" || req.HideInput {
+		return false
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	if len(sq.activeRuns) == 0 {
+		return false
+	}
+	if !sq.steerFn(sq.key, agent.InjectedMessage{ID: req.RunID, Content: req.Message, UserID: req.UserID}) {
+		return false
+	}
+	sq.steered = append(sq.steered, pending)
+	slog.Info("scheduler: steered message into running run", "session", sq.key, "run_id", req.RunID)
+	return true
+}
+
+// settleSteered resolves steered messages after a run finished: those it took
+// in complete with ErrMessageSteered. Once no run is left, the rest go back to
+// the head of the queue as regular runs. Must be called with sq.mu held.
+func (sq *SessionQueue) settleSteered(result *agent.RunResult) {
+	if len(sq.steered) == 0 {
+		return
+	}
+	var left []*PendingRequest
+	for _, p := range sq.steered {
+		if result != nil && slices.Contains(result.Injected, p.Req.RunID) {
+			p.ResultCh <- RunOutcome{Err: ErrMessageSteered}
+			close(p.ResultCh)
+			continue
+		}
+		left = append(left, p)
+	}
+	sq.steered = left
+	if len(sq.activeRuns) == 0 && len(left) > 0 {
+		slog.Info("scheduler: steered messages not taken in, queueing", "session", sq.key, "count", len(left))
+		sq.queue = append(left, sq.queue...)
+		sq.steered = nil
+	}
+}
+
 // scheduleNext starts the next queued request(s), applying debounce.
 // Must be called with sq.mu held.
 func (sq *SessionQueue) scheduleNext(ctx context.Context) {
@@ -277,13 +351,27 @@ func (sq *SessionQueue) startOne(ctx context.Context) {
 
 	if lane == nil {
 		// No lane available — run directly
-		go sq.executeRun(runCtx, runID, gen, pending)
+		go sq.executeRun(runCtx, runID, gen, pending, nil)
 		return
 	}
 
-	err := lane.Submit(ctx, func() {
-		sq.executeRun(runCtx, runID, gen, pending)
-	})
+	// A higher-priority lane may stop the run to take its slot; executeRun
+	// then puts it back at the head of the queue. A run is preempted at most
+	// once so it cannot starve, and only before it starts acting.
+	var state atomic.Int32
+	var preempt func() bool
+	if !pending.preempted {
+		preempt = func() bool {
+			if !state.CompareAndSwap(runPreemptible, runPreempted) {
+				return false
+			}
+			cancel()
+			return true
+		}
+	}
+	err := lane.SubmitWithOpts(ctx, func() {
+		sq.executeRun(runCtx, runID, gen, pending, &state)
+	}, SubmitOpts{User: pending.user, Preempt: preempt})
 	if err != nil {
 		pending.ResultCh <- RunOutcome{Err: err}
 		close(pending.ResultCh)
@@ -293,8 +381,16 @@ func (sq *SessionQueue) startOne(ctx context.Context) {
 	}
 }
 
+// Preemption states of a scheduled run.
+const (
+	runPreemptible int32 = iota // nothing done outside the run yet
+	runActing                   // started tool calls; runs to completion
+	runPreempted                // stopped for a higher-priority lane; requeued
+)
+
 // executeRun runs the agent and then starts the next queued message(s) if capacity allows.
-func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGeneration uint64, pending *PendingRequest) {
+// A run stopped by lane preemption is requeued instead of completing.
+func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGeneration uint64, pending *PendingRequest, state *atomic.Int32) {
 	// Defense-in-depth: if runFn panics despite agent-level recovery,
 	// ensure cleanup still runs so the session queue doesn't orphan this run.
 	defer func() {
@@ -305,6 +401,7 @@ func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGenerat
 			sq.mu.Lock()
 			delete(sq.activeRuns, runID)
 			sq.removeFromOrder(runID)
+			sq.settleSteered(nil)
 			if sq.hasCapacity() && len(sq.queue) > 0 {
 				sq.scheduleNext(sq.parentCtx)
 			}
@@ -312,7 +409,19 @@ func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGenerat
 		}
 	}()
 
-	result, err := sq.runFn(ctx, pending.Req)
+	req := pending.Req
+	if state != nil {
+		// A requeued run starts from the original request, so it must not be
+		// preempted once it has acted: exec commands, messages and file
+		// writes would happen twice.
+		req.BeginSideEffects = func() bool {
+			return state.CompareAndSwap(runPreemptible, runActing) || state.Load() == runActing
+		}
+	}
+	result, err := sq.runFn(ctx, req)
+	if state != nil && state.Load() == runPreempted && sq.requeuePreempted(runID, runGeneration, pending) {
+		return
+	}
 	pending.ResultCh <- RunOutcome{Result: result, Err: err}
 	close(pending.ResultCh)
 
@@ -326,6 +435,7 @@ func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGenerat
 		sq.mu.Unlock()
 		return
 	}
+	sq.settleSteered(result)
 
 	if sq.hasCapacity() && len(sq.queue) > 0 {
 		// Use parentCtx (not the per-run ctx which may be cancelled)
@@ -334,6 +444,33 @@ func (sq *SessionQueue) executeRun(ctx context.Context, runID string, runGenerat
 	sq.mu.Unlock()
 }
 
+// requeuePreempted puts a preempted run back at the head of the queue. Returns
+// false when the run was stopped or reset meanwhile, so its outcome is
+// delivered as usual.
+func (sq *SessionQueue) requeuePreempted(runID string, runGeneration uint64, pending *PendingRequest) bool {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	entry, ok := sq.activeRuns[runID]
+	if !ok || entry.generation != runGeneration || runGeneration != sq.generation {
+		return false
+	}
+	delete(sq.activeRuns, runID)
+	sq.removeFromOrder(runID)
+	pending.preempted = true
+	sq.queue = append([]*PendingRequest{pending}, sq.queue...)
+	slog.Info("scheduler: requeued preempted run", "session", sq.key, "run_id", runID)
+	// Restart once this run has returned its slot: scheduling inline would
+	// wait for a slot while still holding the one the preempting lane needs.
+	go func() {
+		sq.mu.Lock()
+		defer sq.mu.Unlock()
+		if sq.hasCapacity() && len(sq.queue) > 0 {
+			sq.scheduleNext(sq.parentCtx)
+		}
+	}()
+	return true
+}
+
 // removeFromOrder removes a runID from the activeOrder slice.
 // Must be called with sq.mu held.
 func (sq *SessionQueue) removeFromOrder(runID string) {
@@ -384,6 +521,11 @@ func (sq *SessionQueue) drainQueue(outcome RunOutcome) {
 		close(p.ResultCh)
 	}
 	sq.queue = nil
+	for _, p := range sq.steered {
+		p.ResultCh <- outcome
+		close(p.ResultCh)
+	}
+	sq.steered = nil
 }
 
 // CancelOne stops the oldest active run (FIFO).
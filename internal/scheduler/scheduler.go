@@ -14,7 +14,9 @@ import (
 
 // ScheduleOpts provides per-request overrides for the scheduler.
 type ScheduleOpts struct {
-	MaxConcurrent int // per-session override (0 = use config default)
+	MaxConcurrent int    // per-session override (0 = use config default)
+	User          string // fair-share key across sessions (see SubmitOpts); "" for system runs
+	OnSteered     func() // QueueModeSteer: called when the message was injected into a running run
 }
 
 // Scheduler is the top-level coordinator that manages lanes and session queues.
@@ -26,6 +28,7 @@ type Scheduler struct {
 	mu              sync.RWMutex
 	draining        atomic.Bool       // set during graceful shutdown to reject new requests
 	tokenEstimateFn TokenEstimateFunc // optional: for adaptive throttle
+	steerFn         SteerFunc         // optional: for QueueModeSteer
 }
 
 // NewScheduler creates a scheduler with the given lane and queue config.
@@ -42,6 +45,12 @@ func NewScheduler(laneConfigs []LaneConfig, queueCfg QueueConfig, runFn RunFunc)
 	}
 }
 
+// SetSteerFunc sets the callback QueueModeSteer uses to inject messages.
+// Must be called before any Schedule calls.
+func (s *Scheduler) SetSteerFunc(fn SteerFunc) {
+	s.steerFn = fn
+}
+
 // SetTokenEstimateFunc sets the callback used by adaptive throttle.
 // Must be called before any Schedule calls.
 func (s *Scheduler) SetTokenEstimateFunc(fn TokenEstimateFunc) {
@@ -81,7 +90,7 @@ func (s *Scheduler) ScheduleWithOpts(ctx context.Context, lane string, req agent
 	if opts.MaxConcurrent > 0 {
 		sq.SetMaxConcurrent(opts.MaxConcurrent)
 	}
-	return sq.Enqueue(ctx, req)
+	return sq.enqueue(ctx, &PendingRequest{Req: req, user: opts.User, onSteered: opts.OnSteered})
 }
 
 // getOrCreateSession returns or creates a session queue for the given key.
@@ -106,6 +115,7 @@ func (s *Scheduler) getOrCreateSession(sessionKey, lane string) *SessionQueue {
 	if s.tokenEstimateFn != nil {
 		sq.tokenEstimateFn = s.tokenEstimateFn
 	}
+	sq.steerFn = s.steerFn
 	s.sessions[sessionKey] = sq
 
 	slog.Debug("session queue created", "session", sessionKey, "lane", lane)
@@ -158,6 +168,21 @@ func (s *Scheduler) HasActiveSessionsForAgent(agentID string) bool {
 	return false
 }
 
+// AgentQueueDepth returns the number of running and queued requests across
+// all session queues of the given agent.
+func (s *Scheduler) AgentQueueDepth(agentKey string) (active, queued int) {
+	prefix := "agent:" + agentKey + ":"
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, sq := range s.sessions {
+		if strings.HasPrefix(key, prefix) {
+			active += sq.ActiveCount()
+			queued += sq.QueueLen()
+		}
+	}
+	return active, queued
+}
+
 // LaneStats returns utilization metrics for all lanes.
 func (s *Scheduler) LaneStats() []LaneStats {
 	return s.lanes.AllStats()
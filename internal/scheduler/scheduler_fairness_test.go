@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// queueUsers fills a one-slot lane: a blocker holds the slot while the given
+// users' requests queue in order. Returns the order they ran in.
+func queueUsers(t *testing.T, lm *LaneManager, users ...string) []string {
+	t.Helper()
+	lane := lm.Get(LaneMain)
+	release := make(chan struct{})
+	if err := lane.Submit(context.Background(), func() { <-release }); err != nil {
+		t.Fatalf("submit blocker: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func() {
+			_ = lane.SubmitWithOpts(context.Background(), func() {
+				defer wg.Done()
+				mu.Lock()
+				order = append(order, user)
+				mu.Unlock()
+			}, SubmitOpts{User: user})
+		}()
+		waitFor(t, "request to queue", func() bool { return queued(lane) == i+1 })
+	}
+	close(release)
+	wg.Wait()
+	return order
+}
+
+func queued(l *Lane) int {
+	l.pool.mu.Lock()
+	defer l.pool.mu.Unlock()
+	return len(l.waiters)
+}
+
+func TestLane_RoundRobinBetweenUsers(t *testing.T) {
+	lm := NewLaneManager([]LaneConfig{{Name: LaneMain, Concurrency: 1}})
+	defer lm.StopAll()
+
+	got := queueUsers(t, lm, "flood", "flood", "flood", "alice", "bob")
+	if want := []string{"flood", "alice", "bob", "flood", "flood"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestLane_WeightedTurns(t *testing.T) {
+	lm := NewLaneManager([]LaneConfig{{Name: LaneMain, Concurrency: 1}})
+	defer lm.StopAll()
+	lm.SetFairness(FairnessConfig{Weights: map[string]int{"vip": 2}})
+
+	got := queueUsers(t, lm, "vip", "vip", "vip", "alice", "alice")
+	if want := []string{"vip", "vip", "alice", "vip", "alice"}; !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestLane_MaxInflightPerUser(t *testing.T) {
+	lm := NewLaneManager([]LaneConfig{{Name: LaneMain, Concurrency: 3}})
+	defer lm.StopAll()
+	lm.SetFairness(FairnessConfig{MaxInflightPerUser: 1})
+	lane := lm.Get(LaneMain)
+
+	release := make(chan struct{})
+	block := func() { <-release }
+	if err := lane.SubmitWithOpts(context.Background(), block, SubmitOpts{User: "flood"}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = lane.SubmitWithOpts(context.Background(), func() { close(done) }, SubmitOpts{User: "flood"})
+	}()
+	waitFor(t, "capped request to queue", func() bool { return queued(lane) == 1 })
+
+	// Other users and system work still get the free slots.
+	if err := lane.SubmitWithOpts(context.Background(), block, SubmitOpts{User: "alice"}); err != nil {
+		t.Fatalf("submit alice: %v", err)
+	}
+	if err := lane.Submit(context.Background(), block); err != nil {
+		t.Fatalf("submit system: %v", err)
+	}
+	stats := lane.Stats()
+	if stats.Active != 3 || stats.Pending != 1 {
+		t.Fatalf("stats = %+v, want 3 active, 1 pending", stats)
+	}
+	if len(stats.Users) == 0 || stats.Users[0].User != "flood" || stats.Users[0].Queued != 1 {
+		t.Errorf("user stats = %+v, want flood first with 1 queued", stats.Users)
+	}
+
+	close(release)
+	<-done
+	if users := lane.Stats().Users; len(users) != 2 {
+		t.Errorf("user stats = %+v, want flood and alice", users)
+	}
+}
@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/agent"
+)
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLaneManager_BorrowsFromLowerPriority(t *testing.T) {
+	lm := NewLaneManager([]LaneConfig{
+		{Name: LaneMain, Concurrency: 1, Priority: 10},
+		{Name: LaneCron, Concurrency: 1},
+	})
+	defer lm.StopAll()
+	mainLane, cronLane := lm.Get(LaneMain), lm.Get(LaneCron)
+
+	release := make(chan struct{})
+	block := func() { <-release }
+	for range 2 {
+		if err := mainLane.Submit(context.Background(), block); err != nil {
+			t.Fatalf("submit main: %v", err)
+		}
+	}
+	if s := mainLane.Stats(); s.Active != 2 || s.Borrowed != 1 {
+		t.Fatalf("main stats = %+v, want 2 active, 1 borrowed", s)
+	}
+	if !mainLane.Load().Saturated || !cronLane.Load().Saturated {
+		t.Fatal("both lanes should be saturated")
+	}
+
+	// The lower-priority lane never borrows upward.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := cronLane.Submit(ctx, func() {}); err == nil {
+		t.Fatal("cron submit should wait while its slot is lent out")
+	}
+
+	close(release)
+	waitFor(t, "lanes to drain", func() bool { return mainLane.Stats().Active == 0 })
+	if mainLane.Load().Saturated || cronLane.Load().Saturated {
+		t.Fatal("drained lanes should not be saturated")
+	}
+}
+
+func TestScheduler_PreemptRequeuesLowerPriorityRun(t *testing.T) {
+	release := make(chan struct{})
+	var cronRuns atomic.Int32
+	runFn := func(ctx context.Context, req agent.RunRequest) (*agent.RunResult, error) {
+		switch req.RunID {
+		case "main-1":
+			<-release
+		case "cron-1":
+			if cronRuns.Add(1) == 1 {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+		}
+		return &agent.RunResult{Content: "ok", RunID: req.RunID}, nil
+	}
+
+	sched := NewScheduler([]LaneConfig{
+		{Name: LaneMain, Concurrency: 1, Priority: 10, Preempt: true},
+		{Name: LaneCron, Concurrency: 1},
+	}, QueueConfig{Mode: QueueModeQueue, Cap: 10, DebounceMs: 0, MaxConcurrent: 1}, runFn)
+	defer sched.Stop()
+	ctx := context.Background()
+
+	busy := sched.Schedule(ctx, LaneMain, agent.RunRequest{SessionKey: "agent:a:s1", RunID: "main-1"})
+	cronCh := sched.Schedule(ctx, LaneCron, agent.RunRequest{SessionKey: "agent:a:cron:job", RunID: "cron-1"})
+	waitFor(t, "cron run to start", func() bool { return cronRuns.Load() == 1 })
+
+	// main is saturated and cron holds the only other slot: preempt it.
+	mainCh := sched.Schedule(ctx, LaneMain, agent.RunRequest{SessionKey: "agent:a:s2", RunID: "main-2"})
+	select {
+	case out := <-mainCh:
+		if out.Err != nil {
+			t.Fatalf("main-2: %v", out.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("main-2 did not preempt the cron run")
+	}
+
+	close(release)
+	<-busy
+	select {
+	case out := <-cronCh:
+		if out.Err != nil || out.Result == nil {
+			t.Fatalf("requeued cron run outcome = %+v", out)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("preempted cron run was not restarted")
+	}
+	if n := cronRuns.Load(); n != 2 {
+		t.Errorf("cron runs = %d, want 2 (preempted once, then restarted)", n)
+	}
+	if s := sched.Lanes().Get(LaneCron).Stats(); s.Preempted != 1 {
+		t.Errorf("cron stats = %+v, want 1 preempted", s)
+	}
+}
+
+func TestScheduler_DoesNotPreemptRunThatStartedActing(t *testing.T) {
+	release, cronRelease := make(chan struct{}), make(chan struct{})
+	var cronRuns, cronActing atomic.Int32
+	runFn := func(ctx context.Context, req agent.RunRequest) (*agent.RunResult, error) {
+		switch req.RunID {
+		case "main-1":
+			<-release
+		case "cron-1":
+			cronRuns.Add(1)
+			if !req.BeginSideEffects() {
+				return nil, context.Canceled
+			}
+			cronActing.Add(1)
+			select {
+			case <-cronRelease:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		return &agent.RunResult{Content: "ok", RunID: req.RunID}, nil
+	}
+
+	sched := NewScheduler([]LaneConfig{
+		{Name: LaneMain, Concurrency: 1, Priority: 10, Preempt: true},
+		{Name: LaneCron, Concurrency: 1},
+	}, QueueConfig{Mode: QueueModeQueue, Cap: 10, DebounceMs: 0, MaxConcurrent: 1}, runFn)
+	defer sched.Stop()
+	ctx := context.Background()
+
+	busy := sched.Schedule(ctx, LaneMain, agent.RunRequest{SessionKey: "agent:a:s1", RunID: "main-1"})
+	cronCh := sched.Schedule(ctx, LaneCron, agent.RunRequest{SessionKey: "agent:a:cron:job", RunID: "cron-1"})
+	waitFor(t, "cron run to act", func() bool { return cronActing.Load() == 1 })
+
+	// The cron run already acted, so main-2 waits instead of restarting it
+	// (Schedule blocks until main-2 gets a slot).
+	mainCh := make(chan RunOutcome, 1)
+	go func() {
+		mainCh <- <-sched.Schedule(ctx, LaneMain, agent.RunRequest{SessionKey: "agent:a:s2", RunID: "main-2"})
+	}()
+	select {
+	case <-mainCh:
+		t.Fatal("main-2 ran by preempting a run that had started acting")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(cronRelease)
+	if out := <-cronCh; out.Err != nil || out.Result == nil {
+		t.Fatalf("cron outcome = %+v", out)
+	}
+	if out := <-mainCh; out.Err != nil {
+		t.Fatalf("main-2: %v", out.Err)
+	}
+	close(release)
+	<-busy
+	if n := cronRuns.Load(); n != 1 {
+		t.Errorf("cron runs = %d, want 1", n)
+	}
+	if s := sched.Lanes().Get(LaneCron).Stats(); s.Preempted != 0 {
+		t.Errorf("cron stats = %+v, want 0 preempted", s)
+	}
+}
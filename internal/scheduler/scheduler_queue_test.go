@@ -464,3 +464,66 @@ func TestSessionQueue_InterruptMode(t *testing.T) {
 		t.Fatalf("r2 should complete successfully: %v", outcome.Err)
 	}
 }
+
+// --- Steer mode: messages go into the running run ---
+
+func TestScheduler_SteerMode(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		takesIn bool // the running run drains the injected message
+	}{
+		{"taken in", true},
+		{"left over", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			injected := make(chan agent.InjectedMessage, 1)
+			var ran sync.Map
+			runFn := func(ctx context.Context, req agent.RunRequest) (*agent.RunResult, error) {
+				ran.Store(req.RunID, true)
+				res := &agent.RunResult{Content: "ok", RunID: req.RunID}
+				if req.RunID == "r1" {
+					msg := <-injected
+					if tc.takesIn {
+						res.Injected = []string{msg.ID}
+					}
+				}
+				return res, nil
+			}
+			sched := NewScheduler([]LaneConfig{{Name: LaneMain, Concurrency: 2}},
+				QueueConfig{Mode: QueueModeSteer, Cap: 10, DebounceMs: 0, MaxConcurrent: 1}, runFn)
+			defer sched.Stop()
+			sched.SetSteerFunc(func(_ string, msg agent.InjectedMessage) bool {
+				injected <- msg
+				return true
+			})
+			ctx := context.Background()
+			opts := ScheduleOpts{User: "alice"}
+
+			ch1 := sched.ScheduleWithOpts(ctx, LaneMain, agent.RunRequest{SessionKey: "s", RunID: "r1", Message: "start"}, opts)
+			waitFor(t, "r1 to start", func() bool { _, ok := ran.Load("r1"); return ok })
+			var acked atomic.Bool
+			opts.OnSteered = func() { acked.Store(true) }
+			ch2 := sched.ScheduleWithOpts(ctx, LaneMain, agent.RunRequest{SessionKey: "s", RunID: "r2", Message: "also this"}, opts)
+			if !acked.Load() {
+				t.Error("OnSteered not called")
+			}
+
+			if out := <-ch1; out.Err != nil {
+				t.Fatalf("r1: %v", out.Err)
+			}
+			out := <-ch2
+			if tc.takesIn {
+				if !errors.Is(out.Err, ErrMessageSteered) {
+					t.Errorf("r2 outcome = %+v, want ErrMessageSteered", out)
+				}
+				if _, ok := ran.Load("r2"); ok {
+					t.Error("steered message should not run on its own")
+				}
+				return
+			}
+			if out.Err != nil || out.Result == nil || out.Result.RunID != "r2" {
+				t.Errorf("r2 outcome = %+v, want its own run", out)
+			}
+		})
+	}
+}
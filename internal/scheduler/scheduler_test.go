@@ -66,6 +66,91 @@ func TestLane_Stats(t *testing.T) {
 	}
 }
 
+func TestLane_StatsWaitTime(t *testing.T) {
+	lane := NewLane("test", 1)
+	defer lane.Stop()
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	if err := lane.Submit(context.Background(), func() {
+		defer wg.Done()
+		<-release
+	}); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	// Second submit blocks until the first slot is released.
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		close(release)
+	}()
+	if err := lane.Submit(context.Background(), func() { wg.Done() }); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	wg.Wait()
+
+	stats := lane.Stats()
+	if stats.Completed != 2 {
+		t.Errorf("completed = %d, want 2", stats.Completed)
+	}
+	if stats.MaxWaitMs < 20 {
+		t.Errorf("maxWaitMs = %.1f, want >= 20", stats.MaxWaitMs)
+	}
+	if stats.AvgWaitMs <= 0 || stats.AvgWaitMs > stats.MaxWaitMs {
+		t.Errorf("avgWaitMs = %.1f, want in (0, %.1f]", stats.AvgWaitMs, stats.MaxWaitMs)
+	}
+}
+
+func TestLane_Load(t *testing.T) {
+	lane := NewLane("test", 1)
+	defer lane.Stop()
+
+	if load := lane.Load(); load.Saturated || load.Position != 0 {
+		t.Fatalf("idle lane load = %+v, want unsaturated", load)
+	}
+
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	if err := lane.Submit(context.Background(), func() {
+		defer wg.Done()
+		<-release
+	}); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	load := lane.Load()
+	if !load.Saturated || load.Position != 1 || load.EstimatedWait != defaultRunEstimate {
+		t.Errorf("busy lane load = %+v, want position 1 wait %v", load, defaultRunEstimate)
+	}
+
+	// A waiting request pushes new arrivals one place back.
+	go func() {
+		_ = lane.Submit(context.Background(), func() { wg.Done() })
+	}()
+	deadline := time.Now().Add(time.Second)
+	for lane.Stats().Pending != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if load := lane.Load(); load.Position != 2 || load.EstimatedWait != 2*defaultRunEstimate {
+		t.Errorf("queued lane load = %+v, want position 2 wait %v", load, 2*defaultRunEstimate)
+	}
+
+	close(release)
+	wg.Wait()
+	deadline = time.Now().Add(time.Second)
+	for lane.Stats().Active != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if load := lane.Load(); load.Saturated {
+		t.Errorf("drained lane load = %+v, want unsaturated", load)
+	}
+	if stats := lane.Stats(); stats.AvgRunMs <= 0 {
+		t.Errorf("avgRunMs = %.1f, want > 0", stats.AvgRunMs)
+	}
+}
+
 func TestLaneManager_GetFallback(t *testing.T) {
 	lm := NewLaneManager([]LaneConfig{
 		{Name: "main", Concurrency: 2},
@@ -372,3 +457,45 @@ func TestScheduler_InterruptMode(t *testing.T) {
 		t.Error("second run timed out")
 	}
 }
+
+func TestScheduler_AgentQueueDepth(t *testing.T) {
+	release := make(chan struct{})
+	runFn := func(_ context.Context, req agent.RunRequest) (*agent.RunResult, error) {
+		<-release
+		return &agent.RunResult{Content: "ok", RunID: req.RunID}, nil
+	}
+
+	sched := NewScheduler(DefaultLanes(), QueueConfig{
+		Mode: QueueModeQueue,
+		Cap:  10,
+		Drop: DropOld,
+	}, runFn)
+	defer sched.Stop()
+
+	ctx := context.Background()
+	var outcomes []<-chan RunOutcome
+	for i, key := range []string{"agent:alpha:s1", "agent:alpha:s1", "agent:alphabet:s1"} {
+		outcomes = append(outcomes, sched.Schedule(ctx, "main", agent.RunRequest{
+			SessionKey: key,
+			Message:    "hello",
+			RunID:      "run-" + string(rune('a'+i)),
+		}))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		active, queued := sched.AgentQueueDepth("alpha")
+		if active == 1 && queued == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("AgentQueueDepth(alpha) = %d active, %d queued; want 1, 1", active, queued)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	close(release)
+	for _, ch := range outcomes {
+		<-ch
+	}
+}
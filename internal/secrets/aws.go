@@ -0,0 +1,138 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// AWSSecretsManager reads secrets from AWS Secrets Manager. Keys are a
+// secret name or ARN, optionally with "#field" to pick one key of a JSON
+// secret. Credentials and region come from the standard AWS chain (env,
+// shared config, instance role); an ARN's region wins over the default.
+// AWS_ENDPOINT_URL_SECRETS_MANAGER or AWS_ENDPOINT_URL override the endpoint.
+type AWSSecretsManager struct {
+	Client *http.Client
+
+	mu  sync.Mutex
+	cfg *aws.Config
+}
+
+func (a *AWSSecretsManager) awsConfig(ctx context.Context) (aws.Config, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cfg != nil {
+		return *a.cfg, nil
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	a.cfg = &cfg
+	return cfg, nil
+}
+
+// Get calls GetSecretValue and returns the secret string (or one field of it).
+func (a *AWSSecretsManager) Get(ctx context.Context, key string) (string, error) {
+	secretID, field := splitField(key)
+	cfg, err := a.awsConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("aws: load config: %w", err)
+	}
+	region := cfg.Region
+	if parts := strings.Split(secretID, ":"); len(parts) > 3 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	if region == "" {
+		return "", fmt.Errorf("aws: no region; set AWS_REGION or use a secret ARN")
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("aws: credentials: %w", err)
+	}
+
+	endpoint := firstNonEmpty(os.Getenv("AWS_ENDPOINT_URL_SECRETS_MANAGER"), aws.ToString(cfg.BaseEndpoint))
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + region + ".amazonaws.com"
+		if strings.HasPrefix(region, "cn-") {
+			endpoint += ".cn"
+		}
+	}
+	payload, _ := json.Marshal(map[string]string{"SecretId": secretID})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	sum := sha256.Sum256(payload)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), "secretsmanager", region, time.Now()); err != nil {
+		return "", fmt.Errorf("aws: sign request: %w", err)
+	}
+
+	client := a.Client
+	if client == nil {
+		client = &http.Client{Timeout: lookupTimeout, Transport: netproxy.Transport(netproxy.ScopeDefault)}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("aws: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(body, &apiErr)
+		if strings.HasSuffix(apiErr.Type, "ResourceNotFoundException") {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("aws: %s: %s %s", resp.Status, apiErr.Type, apiErr.Message)
+	}
+
+	var out struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary string `json:"SecretBinary"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("aws: decode response: %w", err)
+	}
+	val := out.SecretString
+	if val == "" && out.SecretBinary != "" {
+		raw, err := base64.StdEncoding.DecodeString(out.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("aws: decode binary secret: %w", err)
+		}
+		val = string(raw)
+	}
+	if field == "" {
+		return val, nil
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(val), &fields); err != nil {
+		return "", fmt.Errorf("aws: secret is not a JSON object, cannot select field %q", field)
+	}
+	fv, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("aws: field %q: %w", field, ErrNotFound)
+	}
+	return stringValue(fv), nil
+}
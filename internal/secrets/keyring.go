@@ -0,0 +1,34 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// KeyringService is the OS keyring service name secret://keyring/<name>
+// entries are stored under.
+const KeyringService = "goclaw"
+
+// Keyring reads secrets from the OS keyring.
+type Keyring struct {
+	Service string
+}
+
+// Get returns the keyring entry named key.
+func (k Keyring) Get(_ context.Context, key string) (string, error) {
+	val, err := keyring.Get(k.Service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
+// Set stores a keyring entry (used by `goclaw secrets set`).
+func (k Keyring) Set(key, value string) error {
+	return keyring.Set(k.Service, key, value)
+}
@@ -0,0 +1,261 @@
+// Package secrets resolves secret references of the form
+// secret://<backend>/<key> against external secret stores, so credentials
+// can stay out of config.json, .env.local and the database.
+//
+// Built-in backends:
+//
+//	keyring  OS keyring (macOS Keychain, Secret Service, Windows Credential Manager)
+//	vault    HashiCorp Vault KV v2 (VAULT_ADDR, VAULT_TOKEN, VAULT_NAMESPACE)
+//	aws      AWS Secrets Manager (standard AWS credential chain and region)
+//
+// A "#field" suffix selects one field of a structured secret, e.g.
+// secret://vault/secret/goclaw#anthropic_api_key.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Scheme prefixes secret references.
+const Scheme = "secret://"
+
+// lookupTimeout bounds a single backend lookup.
+const lookupTimeout = 10 * time.Second
+
+// cacheTTL is how long a resolved value is reused, so stores that resolve on
+// every read do not hit the backend each time. Rotated secrets are picked up
+// after it expires.
+const cacheTTL = 5 * time.Minute
+
+// ErrNotFound is returned when a backend has no secret under the key.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider fetches secrets from one backend. key is everything after
+// secret://<backend>/, including any "#field" suffix.
+type Provider interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+type cachedValue struct {
+	value   string
+	expires time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]cachedValue{}
+
+	mu        sync.RWMutex
+	providers = map[string]Provider{
+		"keyring": Keyring{Service: KeyringService},
+		"vault":   &Vault{},
+		"aws":     &AWSSecretsManager{},
+	}
+)
+
+// Register adds or replaces a backend and drops cached values.
+func Register(name string, p Provider) {
+	mu.Lock()
+	providers[name] = p
+	mu.Unlock()
+	ClearCache()
+}
+
+// ClearCache forgets resolved values, e.g. before a config reload.
+func ClearCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	clear(cache)
+}
+
+// Backends returns the registered backend names.
+func Backends() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsRef reports whether s is a secret reference.
+func IsRef(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// ParseRef splits a reference into backend and key.
+func ParseRef(ref string) (backend, key string, err error) {
+	rest, ok := strings.CutPrefix(ref, Scheme)
+	if !ok {
+		return "", "", fmt.Errorf("not a secret reference: missing %s prefix", Scheme)
+	}
+	backend, key, _ = strings.Cut(rest, "/")
+	if backend == "" || key == "" {
+		return "", "", fmt.Errorf("invalid secret reference %q: want %s<backend>/<key>", ref, Scheme)
+	}
+	return backend, key, nil
+}
+
+// Resolve fetches the value behind a reference. Values are cached for
+// cacheTTL; errors are not cached.
+func Resolve(ctx context.Context, ref string) (string, error) {
+	backend, key, err := ParseRef(ref)
+	if err != nil {
+		return "", err
+	}
+	cacheMu.Lock()
+	c, ok := cache[ref]
+	cacheMu.Unlock()
+	if ok && time.Now().Before(c.expires) {
+		return c.value, nil
+	}
+
+	mu.RLock()
+	p, ok := providers[backend]
+	mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret backend %q in %s", backend, ref)
+	}
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	val, err := p.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+	cacheMu.Lock()
+	cache[ref] = cachedValue{value: val, expires: time.Now().Add(cacheTTL)}
+	cacheMu.Unlock()
+	return val, nil
+}
+
+// RefError is a reference found by ResolveFields that could not be resolved.
+type RefError struct {
+	Path string // dotted JSON path of the field, e.g. "providers.openai.api_key"
+	Ref  string
+	Err  error
+}
+
+func (e RefError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// ResolveFields replaces every string field, slice element and map value
+// under v (a pointer to a struct) that holds a secret reference with the
+// secret's value. Fields that fail to resolve are cleared so the reference
+// is never used as a credential, and reported in the returned errors.
+// Paths use json tag names.
+func ResolveFields(ctx context.Context, v any) []RefError {
+	var errs []RefError
+	walkStrings(reflect.ValueOf(v), "", func(path, s string) (string, bool) {
+		if !IsRef(s) {
+			return "", false
+		}
+		val, err := Resolve(ctx, s)
+		if err != nil {
+			errs = append(errs, RefError{Path: path, Ref: s, Err: err})
+			return "", true
+		}
+		return val, true
+	})
+	return errs
+}
+
+// Refs returns the dotted paths and references found under v without
+// resolving them.
+func Refs(v any) map[string]string {
+	refs := map[string]string{}
+	walkStrings(reflect.ValueOf(v), "", func(path, s string) (string, bool) {
+		if IsRef(s) {
+			refs[path] = s
+		}
+		return "", false
+	})
+	return refs
+}
+
+// SetFields writes vals (dotted path → value, as returned by Refs) back into
+// v, e.g. to put references in place of their resolved values before a
+// config is persisted. Paths that no longer exist under v are skipped.
+func SetFields(v any, vals map[string]string) {
+	if len(vals) == 0 {
+		return
+	}
+	walkStrings(reflect.ValueOf(v), "", func(path, _ string) (string, bool) {
+		val, ok := vals[path]
+		return val, ok
+	})
+}
+
+// walkStrings calls fn for every string reachable from v; when fn returns
+// true the string is replaced. Reports whether anything was replaced, so map
+// entries are only written back when they changed.
+func walkStrings(v reflect.Value, path string, fn func(path, s string) (string, bool)) bool {
+	changed := false
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			changed = walkStrings(v.Elem(), path, fn)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				name = f.Name
+			}
+			if walkStrings(v.Field(i), joinPath(path, name), fn) {
+				changed = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if walkStrings(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn) {
+				changed = true
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable; walk a copy and store it back.
+			cp := reflect.New(iter.Value().Type()).Elem()
+			cp.Set(iter.Value())
+			if walkStrings(cp, joinPath(path, iter.Key().String()), fn) {
+				v.SetMapIndex(iter.Key(), cp)
+				changed = true
+			}
+		}
+	case reflect.String:
+		if val, ok := fn(path, v.String()); ok && v.CanSet() {
+			v.SetString(val)
+			changed = true
+		}
+	}
+	return changed
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// splitField splits "key#field" into key and field.
+func splitField(key string) (string, string) {
+	k, field, _ := strings.Cut(key, "#")
+	return k, field
+}
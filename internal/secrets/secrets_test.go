@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type mapProvider map[string]string
+
+func (m mapProvider) Get(_ context.Context, key string) (string, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+func TestParseRef(t *testing.T) {
+	backend, key, err := ParseRef("secret://vault/secret/goclaw#api_key")
+	if err != nil || backend != "vault" || key != "secret/goclaw#api_key" {
+		t.Errorf("ParseRef = %q, %q, %v", backend, key, err)
+	}
+	for _, bad := range []string{"vault/x", "secret://", "secret://vault", "secret:///x"} {
+		if _, _, err := ParseRef(bad); err == nil {
+			t.Errorf("ParseRef(%q): expected error", bad)
+		}
+	}
+}
+
+func TestResolveFields(t *testing.T) {
+	Register("test", mapProvider{"a": "value-a", "b": "value-b"})
+
+	type inner struct {
+		Token string `json:"token"`
+	}
+	type cfg struct {
+		Key     string            `json:"api_key"`
+		Plain   string            `json:"plain"`
+		Inner   *inner            `json:"inner"`
+		Headers map[string]string `json:"headers"`
+		Servers map[string]inner  `json:"servers"`
+		Missing string            `json:"missing"`
+	}
+	c := &cfg{
+		Key:     "secret://test/a",
+		Plain:   "keep",
+		Inner:   &inner{Token: "secret://test/b"},
+		Headers: map[string]string{"Authorization": "secret://test/a"},
+		Servers: map[string]inner{"s1": {Token: "secret://test/b"}},
+		Missing: "secret://test/nope",
+	}
+
+	refs := Refs(c)
+	errs := ResolveFields(context.Background(), c)
+	if c.Key != "value-a" || c.Plain != "keep" || c.Inner.Token != "value-b" ||
+		c.Headers["Authorization"] != "value-a" || c.Servers["s1"].Token != "value-b" {
+		t.Errorf("resolved = %+v", c)
+	}
+	if c.Missing != "" {
+		t.Errorf("unresolved reference kept: %q", c.Missing)
+	}
+	if len(errs) != 1 || errs[0].Path != "missing" || !errors.Is(errs[0].Err, ErrNotFound) {
+		t.Errorf("errs = %v", errs)
+	}
+
+	SetFields(c, refs)
+	if c.Key != "secret://test/a" || c.Plain != "keep" || c.Inner.Token != "secret://test/b" ||
+		c.Headers["Authorization"] != "secret://test/a" || c.Servers["s1"].Token != "secret://test/b" ||
+		c.Missing != "secret://test/nope" {
+		t.Errorf("restored = %+v", c)
+	}
+	if refs := Refs(map[string]any{"x": map[string]any{"y": "secret://test/a"}}); refs["x.y"] != "secret://test/a" {
+		t.Errorf("Refs = %v", refs)
+	}
+}
+
+func TestVaultGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "tok" || r.URL.Path != "/v1/secret/data/goclaw/prod" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": map[string]any{"value": "v1", "api_key": "k1"}}})
+	}))
+	defer srv.Close()
+
+	v := &Vault{Addr: srv.URL, Token: "tok"}
+	for key, want := range map[string]string{"secret/goclaw/prod": "v1", "secret/goclaw/prod#api_key": "k1"} {
+		if got, err := v.Get(context.Background(), key); err != nil || got != want {
+			t.Errorf("Get(%q) = %q, %v", key, got, err)
+		}
+	}
+	if _, err := v.Get(context.Background(), "secret/other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing secret: %v", err)
+	}
+}
+
+func TestAWSSecretsManagerGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&in)
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if in.SecretId != "prod/goclaw" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"__type": "ResourceNotFoundException", "message": "no"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"anthropic":"sk-ant"}`})
+	}))
+	defer srv.Close()
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_CONFIG_FILE", "/nonexistent")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/nonexistent")
+	t.Setenv("AWS_ENDPOINT_URL_SECRETS_MANAGER", srv.URL)
+
+	a := &AWSSecretsManager{}
+	if got, err := a.Get(context.Background(), "prod/goclaw"); err != nil || got != `{"anthropic":"sk-ant"}` {
+		t.Errorf("Get = %q, %v", got, err)
+	}
+	if got, err := a.Get(context.Background(), "prod/goclaw#anthropic"); err != nil || got != "sk-ant" {
+		t.Errorf("Get field = %q, %v", got, err)
+	}
+	if _, err := a.Get(context.Background(), "other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing secret: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// Vault reads secrets from a HashiCorp Vault KV v2 engine. Keys are
+// "<mount>/<path>#<field>"; field defaults to "value". Empty fields fall back
+// to VAULT_ADDR, VAULT_TOKEN (then ~/.vault-token) and VAULT_NAMESPACE at
+// lookup time.
+type Vault struct {
+	Addr      string
+	Token     string
+	Namespace string
+	Client    *http.Client
+}
+
+// Get reads one field of a KV v2 secret.
+func (v *Vault) Get(ctx context.Context, key string) (string, error) {
+	addr := firstNonEmpty(v.Addr, os.Getenv("VAULT_ADDR"))
+	if addr == "" {
+		return "", fmt.Errorf("vault: VAULT_ADDR is not set")
+	}
+	token := firstNonEmpty(v.Token, os.Getenv("VAULT_TOKEN"), vaultTokenFile())
+	if token == "" {
+		return "", fmt.Errorf("vault: VAULT_TOKEN is not set")
+	}
+
+	key, field := splitField(key)
+	if field == "" {
+		field = "value"
+	}
+	mount, path, ok := strings.Cut(key, "/")
+	if !ok || path == "" {
+		return "", fmt.Errorf("vault: key %q must be <mount>/<path>", key)
+	}
+	u := strings.TrimRight(addr, "/") + "/v1/" + url.PathEscape(mount) + "/data/" + escapePath(path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := firstNonEmpty(v.Namespace, os.Getenv("VAULT_NAMESPACE")); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: lookupTimeout, Transport: netproxy.Transport(netproxy.ScopeDefault)}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var out struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return "", fmt.Errorf("vault: decode response: %w", err)
+	}
+	val, ok := out.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("vault: field %q: %w", field, ErrNotFound)
+	}
+	return stringValue(val), nil
+}
+
+// vaultTokenFile returns the token the vault CLI caches after `vault login`.
+func vaultTokenFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func escapePath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, s := range parts {
+		parts[i] = url.PathEscape(s)
+	}
+	return strings.Join(parts, "/")
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// stringValue renders a JSON secret field; non-strings are re-encoded.
+func stringValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -13,6 +13,8 @@ import (
 	"net/url"
 	"sync/atomic"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // pinnedIPKey is a context key used to pass the pre-resolved IP from
@@ -176,11 +178,21 @@ func pinnedIPFrom(ctx context.Context) net.IP {
 //  2. ctx = WithPinnedIP(ctx, pinnedIP)
 //  3. http.NewRequestWithContext(ctx, ...)
 //  4. client.Do(req)
+//
+// When an outbound proxy is configured for webhooks (proxy.webhooks, proxy.url
+// or HTTPS_PROXY), the request is tunneled through it: the dial to the
+// operator-configured proxy endpoint is not pinned, while the destination
+// itself was still validated by Validate before the request was built.
 func NewSafeClient(timeout time.Duration) *http.Client {
 	dialer := &net.Dialer{Timeout: timeout}
 
 	transport := &http.Transport{
+		Proxy: netproxy.For(netproxy.ScopeWebhooks),
 		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if netproxy.IsProxyAddr(netproxy.ScopeWebhooks, addr) {
+				return dialer.DialContext(ctx, network, addr)
+			}
+
 			pinnedIP := pinnedIPFrom(ctx)
 			if pinnedIP == nil {
 				// No pinned IP in context — reject for safety.
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // ---- Validate reject tests ----
@@ -178,3 +180,35 @@ func TestNewSafeClient_RejectsNoPinnedIP(t *testing.T) {
 		t.Fatal("expected error when no pinned IP in context, got nil")
 	}
 }
+
+func TestNewSafeClient_TunnelsThroughWebhookProxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String() // absolute-form request line when proxied
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	netproxy.Configure(netproxy.Settings{Overrides: map[string]string{netproxy.ScopeWebhooks: proxy.URL}})
+	defer netproxy.Configure(netproxy.Settings{})
+
+	target := "http://93.184.216.34/hook"
+	_, pinnedIP, err := validate(target, false)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodPost, target, nil)
+	req = req.WithContext(WithPinnedIP(req.Context(), pinnedIP))
+
+	resp, err := NewSafeClient(5e9).Do(req)
+	if err != nil {
+		t.Fatalf("do request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status=%d, want 204 from proxy", resp.StatusCode)
+	}
+	if gotURL != target {
+		t.Errorf("proxy saw %q, want %q", gotURL, target)
+	}
+}
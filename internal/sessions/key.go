@@ -15,8 +15,10 @@
 //	DM:          {channel}:direct:{peerId}
 //	Group:       {channel}:group:{groupId}
 //	Forum topic: {channel}:group:{groupId}:topic:{topicId}
+//	Thread:      {channel}:{kind}:{chatId}:thread:{threadId}
 //	Subagent:    subagent:{label}
 //	Cron:        cron:{jobId}
+//	Plan:        plan:{planId}
 //
 // Examples:
 //
@@ -29,6 +31,7 @@ package sessions
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -41,6 +44,24 @@ const (
 	PeerGroup  PeerKind = "group"
 )
 
+// ThreadKind names the thread dimension of a session key.
+type ThreadKind string
+
+const (
+	ThreadTopic ThreadKind = "topic"  // forum topic (Telegram forum groups)
+	ThreadReply ThreadKind = "thread" // reply thread (Telegram DM threads, Slack threads)
+)
+
+// Thread identifies a sub-conversation inside a chat, so parallel discussions
+// in one group keep separate histories. The zero value means the whole chat.
+type Thread struct {
+	Kind ThreadKind
+	ID   string
+}
+
+// IsZero reports whether t refers to the whole chat.
+func (t Thread) IsZero() bool { return t.ID == "" }
+
 // BuildSessionKey builds the canonical agent session key for a channel conversation.
 //
 //	DM:    agent:{agentId}:{channel}:direct:{peerID}
@@ -49,12 +70,40 @@ func BuildSessionKey(agentID, channel string, kind PeerKind, chatID string) stri
 	return fmt.Sprintf("agent:%s:%s:%s:%s", agentID, channel, kind, chatID)
 }
 
+// BuildThreadedSessionKey is BuildSessionKey with an optional thread dimension.
+// A zero thread yields the plain chat key.
+//
+//	agent:{agentId}:{channel}:{kind}:{chatID}:{threadKind}:{threadID}
+func BuildThreadedSessionKey(agentID, channel string, kind PeerKind, chatID string, thread Thread) string {
+	key := BuildSessionKey(agentID, channel, kind, chatID)
+	if thread.IsZero() {
+		return key
+	}
+	return fmt.Sprintf("%s:%s:%s", key, thread.Kind, thread.ID)
+}
+
+// ThreadFromSessionKey returns the thread dimension of a channel session key,
+// or the zero Thread when the key covers a whole chat.
+func ThreadFromSessionKey(key string) Thread {
+	_, rest := ParseSessionKey(key)
+	// rest = {channel}:{kind}:{chatID}[:{threadKind}:{threadID}]
+	for _, kind := range []ThreadKind{ThreadTopic, ThreadReply} {
+		sep := ":" + string(kind) + ":"
+		if idx := strings.LastIndex(rest, sep); idx > 0 && strings.Count(rest[:idx], ":") >= 2 {
+			if id := rest[idx+len(sep):]; id != "" && !strings.Contains(id, ":") {
+				return Thread{Kind: kind, ID: id}
+			}
+		}
+	}
+	return Thread{}
+}
+
 // BuildGroupTopicSessionKey builds the session key for a forum group topic.
 // TS ref: buildTelegramGroupPeerId() in src/telegram/bot/helpers.ts
 //
 //	agent:{agentId}:{channel}:group:{chatID}:topic:{topicID}
 func BuildGroupTopicSessionKey(agentID, channel, chatID string, topicID int) string {
-	return fmt.Sprintf("agent:%s:%s:group:%s:topic:%d", agentID, channel, chatID, topicID)
+	return BuildThreadedSessionKey(agentID, channel, PeerGroup, chatID, Thread{Kind: ThreadTopic, ID: strconv.Itoa(topicID)})
 }
 
 // BuildDMThreadSessionKey builds the session key for a DM thread (topic in private chat).
@@ -62,7 +111,7 @@ func BuildGroupTopicSessionKey(agentID, channel, chatID string, topicID int) str
 //
 //	agent:{agentId}:{channel}:direct:{peerID}:thread:{threadID}
 func BuildDMThreadSessionKey(agentID, channel, peerID string, threadID int) string {
-	return fmt.Sprintf("agent:%s:%s:direct:%s:thread:%d", agentID, channel, peerID, threadID)
+	return BuildThreadedSessionKey(agentID, channel, PeerDirect, peerID, Thread{Kind: ThreadReply, ID: strconv.Itoa(threadID)})
 }
 
 // BuildScopedThreadSessionKey builds a session key that includes a thread/topic ID.
@@ -70,7 +119,7 @@ func BuildDMThreadSessionKey(agentID, channel, peerID string, threadID int) stri
 //
 //	agent:{agentId}:{channel}:{kind}:{chatID}:thread:{threadID}
 func BuildScopedThreadSessionKey(agentID, channel string, kind PeerKind, chatID, threadID string) string {
-	return fmt.Sprintf("agent:%s:%s:%s:%s:thread:%s", agentID, channel, kind, chatID, threadID)
+	return BuildThreadedSessionKey(agentID, channel, kind, chatID, Thread{Kind: ThreadReply, ID: threadID})
 }
 
 // BuildSubagentSessionKey builds the session key for a subagent.
@@ -109,6 +158,14 @@ func BuildCronSessionKey(agentID, jobID string) string {
 	return fmt.Sprintf("agent:%s:cron:%s", agentID, jobID)
 }
 
+// BuildPlanSessionKey builds the session key for a plan run. All steps of
+// the plan share it, so each step sees the results of the earlier ones.
+//
+//	agent:{agentId}:plan:{planID}
+func BuildPlanSessionKey(agentID, planID string) string {
+	return fmt.Sprintf("agent:%s:plan:%s", agentID, planID)
+}
+
 // BuildAgentMainSessionKey builds the shared "main" session key for an agent.
 // Used when dm_scope="main" — all DMs share one session per agent.
 // Matching TS buildAgentMainSessionKey().
@@ -138,6 +195,18 @@ func ParseSessionKey(key string) (agentID, rest string) {
 	return parts[1], parts[2]
 }
 
+// DirectPeerFromSessionKey returns the peer ID of a DM session key
+// (agent:{agentId}:{channel}:direct:{peerId}[:thread:{id}]), or "" for any
+// other key.
+func DirectPeerFromSessionKey(key string) string {
+	_, rest := ParseSessionKey(key)
+	parts := strings.SplitN(rest, ":", 4)
+	if len(parts) < 3 || parts[1] != string(PeerDirect) {
+		return ""
+	}
+	return parts[2]
+}
+
 // IsSubagentSession checks if a session key indicates a subagent session.
 func IsSubagentSession(key string) bool {
 	_, rest := ParseSessionKey(key)
@@ -69,6 +69,45 @@ func TestBuildScopedThreadSessionKey(t *testing.T) {
 	}
 }
 
+// TestBuildThreadedSessionKey covers the optional thread dimension.
+func TestBuildThreadedSessionKey(t *testing.T) {
+	cases := []struct {
+		thread Thread
+		want   string
+	}{
+		{Thread{}, "agent:bot:telegram:group:-100123"},
+		{Thread{Kind: ThreadTopic, ID: "42"}, "agent:bot:telegram:group:-100123:topic:42"},
+		{Thread{Kind: ThreadReply, ID: "1712345678.000100"}, "agent:bot:telegram:group:-100123:thread:1712345678.000100"},
+	}
+	for _, tc := range cases {
+		got := BuildThreadedSessionKey("bot", "telegram", PeerGroup, "-100123", tc.thread)
+		if got != tc.want {
+			t.Errorf("BuildThreadedSessionKey(%+v) = %q, want %q", tc.thread, got, tc.want)
+		}
+		if back := ThreadFromSessionKey(got); back != tc.thread {
+			t.Errorf("ThreadFromSessionKey(%q) = %+v, want %+v", got, back, tc.thread)
+		}
+	}
+}
+
+// TestThreadFromSessionKey_NonChannelKeys ensures non-thread keys parse to the zero Thread.
+func TestThreadFromSessionKey_NonChannelKeys(t *testing.T) {
+	for _, key := range []string{
+		"agent:bot:cron:job-1",
+		"agent:bot:subagent:thread:x",
+		"agent:bot:telegram:direct:386246614",
+		"not-a-session-key",
+	} {
+		if got := ThreadFromSessionKey(key); !got.IsZero() {
+			t.Errorf("ThreadFromSessionKey(%q) = %+v, want zero", key, got)
+		}
+	}
+	// Feishu topic sessions embed the topic in the chat ID.
+	if got := ThreadFromSessionKey("agent:bot:feishu:group:oc_xyz:topic:om_1"); got != (Thread{Kind: ThreadTopic, ID: "om_1"}) {
+		t.Errorf("feishu topic key parsed as %+v", got)
+	}
+}
+
 // TestBuildSubagentSessionKey covers the subagent key format.
 func TestBuildSubagentSessionKey(t *testing.T) {
 	got := BuildSubagentSessionKey("default", "my-task")
@@ -27,18 +27,21 @@ import (
 
 // Metadata holds parsed SKILL.md frontmatter.
 type Metadata struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Requires    []string `json:"requires,omitempty"` // skill dependencies, e.g. "pdf-tools>=2"
 }
 
 // Info describes a discovered skill.
 type Info struct {
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`    // directory name (unique identifier)
-	Path        string `json:"path"`    // absolute path to SKILL.md
-	BaseDir     string `json:"baseDir"` // skill directory (parent of SKILL.md)
-	Source      string `json:"source"`  // "workspace", "global", "builtin"
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Slug        string   `json:"slug"`    // directory name (unique identifier)
+	Path        string   `json:"path"`    // absolute path to SKILL.md
+	BaseDir     string   `json:"baseDir"` // skill directory (parent of SKILL.md)
+	Source      string   `json:"source"`  // "workspace", "global", "builtin"
+	Description string   `json:"description"`
+	Version     int      `json:"version,omitempty"`  // managed skills only
+	Requires    []string `json:"requires,omitempty"` // raw frontmatter requirements
 }
 
 // Loader discovers and loads SKILL.md files from multiple directories.
@@ -106,6 +109,8 @@ func (l *Loader) ListSkills(_ context.Context) []Info {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	// Rebuild the name index on every scan so removed skills drop out of GetSkill.
+	l.cache = make(map[string]*Info, len(l.cache))
 	seen := make(map[string]bool)
 	var skills []Info
 
@@ -146,6 +151,7 @@ func (l *Loader) ListSkills(_ context.Context) []Info {
 			}
 			if meta := parseMetadata(skillFile); meta != nil {
 				info.Description = meta.Description
+				info.Requires = meta.Requires
 				if meta.Name != "" {
 					info.Name = meta.Name
 				}
@@ -189,6 +195,7 @@ func (l *Loader) ListSkills(_ context.Context) []Info {
 				}
 				if meta := parseMetadata(skillFile); meta != nil {
 					info.Description = meta.Description
+					info.Requires = meta.Requires
 					if meta.Name != "" {
 						info.Name = meta.Name
 					}
@@ -220,42 +227,68 @@ func (l *Loader) listManagedSkills() []Info {
 		slug := d.Name()
 
 		// Find the latest version subdirectory
-		latestVersion, latestDir := l.findLatestVersion(slug)
+		latestVersion, _ := l.findLatestVersion(slug)
 		if latestVersion < 0 {
 			continue
 		}
-
-		skillFile := filepath.Join(latestDir, "SKILL.md")
-		if _, err := os.Stat(skillFile); err != nil {
-			continue
-		}
-
-		info := Info{
-			Name:    slug,
-			Slug:    slug,
-			Path:    skillFile,
-			BaseDir: latestDir,
-			Source:  "managed",
-		}
-		if meta := parseMetadata(skillFile); meta != nil {
-			info.Description = meta.Description
-			if meta.Name != "" {
-				info.Name = meta.Name
-			}
+		if info, ok := l.managedInfo(slug, latestVersion); ok {
+			skills = append(skills, info)
 		}
-		skills = append(skills, info)
 	}
 	return skills
 }
 
+// managedInfo describes one version of a managed skill.
+func (l *Loader) managedInfo(slug string, version int) (Info, bool) {
+	dir := filepath.Join(l.managedSkillsDir, slug, strconv.Itoa(version))
+	skillFile := filepath.Join(dir, "SKILL.md")
+	if _, err := os.Stat(skillFile); err != nil {
+		return Info{}, false
+	}
+	info := Info{
+		Name:    slug,
+		Slug:    slug,
+		Path:    skillFile,
+		BaseDir: dir,
+		Source:  "managed",
+		Version: version,
+	}
+	if meta := parseMetadata(skillFile); meta != nil {
+		info.Description = meta.Description
+		info.Requires = meta.Requires
+		if meta.Name != "" {
+			info.Name = meta.Name
+		}
+	}
+	return info, true
+}
+
+// ManagedSkillPath returns the SKILL.md path of a specific managed skill version.
+func (l *Loader) ManagedSkillPath(slug string, version int) (string, bool) {
+	if l.managedSkillsDir == "" || version < 1 {
+		return "", false
+	}
+	info, ok := l.managedInfo(slug, version)
+	return info.Path, ok
+}
+
 // findLatestVersion finds the highest-numbered version subdirectory for a skill slug.
 // Returns (version, path) or (-1, "") if no valid version found.
 func (l *Loader) findLatestVersion(slug string) (int, string) {
-	slugDir := filepath.Join(l.managedSkillsDir, slug)
-	entries, err := os.ReadDir(slugDir)
-	if err != nil {
+	versions := l.managedVersions(slug)
+	if len(versions) == 0 {
 		return -1, ""
 	}
+	latestVer := versions[0]
+	return latestVer, filepath.Join(l.managedSkillsDir, slug, strconv.Itoa(latestVer))
+}
+
+// managedVersions lists the version subdirectories of a managed skill, highest first.
+func (l *Loader) managedVersions(slug string) []int {
+	entries, err := os.ReadDir(filepath.Join(l.managedSkillsDir, slug))
+	if err != nil {
+		return nil
+	}
 
 	var versions []int
 	for _, e := range entries {
@@ -268,13 +301,8 @@ func (l *Loader) findLatestVersion(slug string) (int, string) {
 		}
 		versions = append(versions, v)
 	}
-	if len(versions) == 0 {
-		return -1, ""
-	}
-
 	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
-	latestVer := versions[0]
-	return latestVer, filepath.Join(slugDir, strconv.Itoa(latestVer))
+	return versions
 }
 
 // LoadSkill reads and returns the content of a skill by name (frontmatter stripped).
@@ -388,13 +416,18 @@ func (l *Loader) BuildSummary(ctx context.Context, allowList []string) string {
 		}
 	}
 
-	if len(filtered) == 0 {
+	return FormatSummary(filtered)
+}
+
+// FormatSummary renders skills as the <available_skills> XML used in system prompts.
+func FormatSummary(skills []Info) string {
+	if len(skills) == 0 {
 		return ""
 	}
 
 	var lines []string
 	lines = append(lines, "<available_skills>")
-	for _, s := range filtered {
+	for _, s := range skills {
 		lines = append(lines, "  <skill>")
 		lines = append(lines, fmt.Sprintf("    <name>%s</name>", escapeXML(s.Name)))
 		desc := s.Description
@@ -410,6 +443,12 @@ func (l *Loader) BuildSummary(ctx context.Context, allowList []string) string {
 	return strings.Join(lines, "\n")
 }
 
+// BuildVersionedSummary is BuildSummary with per-skill version pins and
+// frontmatter requirements resolved (see ResolveSkills).
+func (l *Loader) BuildVersionedSummary(ctx context.Context, allowList []string, versions map[string]int) string {
+	return FormatSummary(l.ResolveSkills(ctx, allowList, versions).Skills)
+}
+
 // BuildPinnedSummary generates XML summary for only the pinned skill names.
 // Delegates to BuildSummary with pinned names as allowlist.
 // Returns empty string if none match.
@@ -499,9 +538,19 @@ func parseMetadata(path string) *Metadata {
 
 	// Fall back to simple YAML key: value
 	kv := parseSimpleYAML(fm)
+	requires := parseSimpleYAMLLists(fm)["requires"]
+	if len(requires) == 0 && kv["requires"] != "" {
+		// Inline form: "requires: pdf-tools>=2, brand-voice"
+		for _, r := range strings.Split(kv["requires"], ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				requires = append(requires, r)
+			}
+		}
+	}
 	return &Metadata{
 		Name:        kv["name"],
 		Description: kv["description"],
+		Requires:    requires,
 	}
 }
 
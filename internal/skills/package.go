@@ -0,0 +1,307 @@
+package skills
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxSkillPackageSize caps a skill directory and its upload zip (the
+// /v1/skills/upload body limit and the publish_skill directory limit).
+const MaxSkillPackageSize = 20 << 20
+
+// Lint severities.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is one problem found by LintSkillDir.
+type LintIssue struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	loc := i.File
+	if loc != "" && i.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", loc, i.Line)
+	}
+	if loc == "" {
+		return i.Severity + ": " + i.Message
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, loc, i.Message)
+}
+
+// LintReport is the result of LintSkillDir.
+type LintReport struct {
+	Dir    string      `json:"dir"`
+	Name   string      `json:"name,omitempty"`
+	Slug   string      `json:"slug,omitempty"`
+	Files  []string    `json:"files"` // files that would be packaged, slash-separated
+	Size   int64       `json:"size"`  // total uncompressed bytes of Files
+	Issues []LintIssue `json:"issues,omitempty"`
+}
+
+// HasErrors reports whether any issue blocks packaging.
+func (r *LintReport) HasErrors() bool {
+	for _, i := range r.Issues {
+		if i.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *LintReport) add(severity, file string, line int, format string, args ...any) {
+	r.Issues = append(r.Issues, LintIssue{Severity: severity, File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+}
+
+var (
+	// Relative markdown link or image targets: [text](scripts/run.py).
+	mdLinkRe = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+	// Inline code naming a file under a conventional skill subdirectory.
+	codePathRe = regexp.MustCompile("`((?:scripts|references|assets|templates)/[^`\\s]+)`")
+)
+
+// LintSkillDir validates a skill directory the way /v1/skills/upload and
+// publish_skill do, plus checks they skip: SKILL.md frontmatter (name
+// required, description recommended), slug rules, requires syntax, the
+// content guard, files referenced from SKILL.md, symlinks, and size limits.
+func LintSkillDir(dir string) (*LintReport, error) {
+	st, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !st.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	rep := &LintReport{Dir: dir}
+
+	files := map[string]bool{}
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if IsSystemArtifact(rel) || d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			rep.add(LintWarning, rel, 0, "symlink is not packaged")
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rep.Files = append(rep.Files, rel)
+		rep.Size += info.Size()
+		files[rel] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rep.Files)
+
+	if len(rep.Files) > maxArchiveEntries {
+		rep.add(LintError, "", 0, "%d files exceeds the %d file limit", len(rep.Files), maxArchiveEntries)
+	}
+	if rep.Size > MaxSkillPackageSize {
+		rep.add(LintError, "", 0, "skill is %d bytes, exceeds the %d MB limit", rep.Size, MaxSkillPackageSize>>20)
+	}
+
+	if !files["SKILL.md"] {
+		rep.add(LintError, "SKILL.md", 0, "missing SKILL.md")
+		return rep, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+	if strings.TrimSpace(content) == "" {
+		rep.add(LintError, "SKILL.md", 0, "SKILL.md is empty")
+		return rep, nil
+	}
+
+	if violations, safe := GuardSkillContent(content); !safe {
+		for _, v := range violations {
+			rep.add(LintError, "SKILL.md", v.Line, "security scan: %s", v.Reason)
+		}
+	}
+
+	name, description, slug, _ := ParseSkillFrontmatter(content)
+	switch {
+	case !strings.HasPrefix(content, "---"):
+		rep.add(LintError, "SKILL.md", 1, "missing YAML frontmatter (--- name: ... ---)")
+	case name == "":
+		rep.add(LintError, "SKILL.md", 1, "frontmatter is missing name")
+	}
+	if name != "" && description == "" {
+		rep.add(LintWarning, "SKILL.md", 1, "frontmatter is missing description; agents use it to decide when to load the skill")
+	}
+	rep.Name = name
+	if slug == "" && name != "" {
+		slug = Slugify(name)
+	}
+	rep.Slug = slug
+	if slug != "" && !SlugRegexp.MatchString(slug) {
+		rep.add(LintError, "SKILL.md", 1, "invalid slug %q: must be lowercase alphanumeric with hyphens", slug)
+	}
+	if base := filepath.Base(filepath.Clean(dir)); slug != "" && base != "." && base != slug {
+		rep.add(LintWarning, "", 0, "directory name %q differs from slug %q", base, slug)
+	}
+	if meta := parseMetadata(filepath.Join(dir, "SKILL.md")); meta != nil {
+		for _, raw := range meta.Requires {
+			if _, err := ParseRequirement(raw); err != nil {
+				rep.add(LintError, "SKILL.md", 1, "requires: %v", err)
+			}
+		}
+	}
+
+	for lineNum, line := range strings.Split(content, "\n") {
+		for _, ref := range referencedPaths(line) {
+			target := path.Clean(ref)
+			switch {
+			case strings.HasPrefix(target, "../") || target == "..":
+				rep.add(LintError, "SKILL.md", lineNum+1, "reference %q points outside the skill directory", ref)
+			case !files[target] && !hasFilePrefix(files, target+"/"):
+				rep.add(LintError, "SKILL.md", lineNum+1, "referenced file %q does not exist", ref)
+			}
+		}
+	}
+	return rep, nil
+}
+
+// referencedPaths returns the relative file paths a SKILL.md line links to.
+func referencedPaths(line string) []string {
+	var out []string
+	for _, m := range mdLinkRe.FindAllStringSubmatch(line, -1) {
+		ref := m[1]
+		if i := strings.IndexAny(ref, "#?"); i >= 0 {
+			ref = ref[:i]
+		}
+		if ref == "" || strings.HasPrefix(ref, "/") || strings.Contains(ref, ":") {
+			continue // anchors, absolute paths, URLs, mailto:
+		}
+		out = append(out, strings.TrimPrefix(ref, "./"))
+	}
+	for _, m := range codePathRe.FindAllStringSubmatch(line, -1) {
+		if !strings.ContainsAny(m[1], "*{}<>$") { // skip globs and placeholders
+			out = append(out, m[1])
+		}
+	}
+	return out
+}
+
+func hasFilePrefix(files map[string]bool, prefix string) bool {
+	for f := range files {
+		if strings.HasPrefix(f, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageSkillDir lints dir and, when it has no errors, writes the
+// upload-ready zip (SKILL.md at the archive root) to out. It returns the
+// lint report either way; out is only written when err is nil.
+func PackageSkillDir(dir, out string) (*LintReport, error) {
+	rep, err := LintSkillDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if rep.HasErrors() {
+		return rep, fmt.Errorf("%s has lint errors", dir)
+	}
+
+	if absOut, err := filepath.Abs(out); err == nil {
+		if absDir, err := filepath.Abs(dir); err == nil && strings.HasPrefix(absOut, absDir+string(filepath.Separator)) {
+			rel, _ := filepath.Rel(absDir, absOut)
+			rep.Files = removeString(rep.Files, filepath.ToSlash(rel)) // don't zip a previous package into itself
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(out), ".skill-package-*.zip")
+	if err != nil {
+		return rep, err
+	}
+	defer os.Remove(tmp.Name())
+	if err := writeSkillZip(tmp, dir, rep.Files); err != nil {
+		tmp.Close()
+		return rep, err
+	}
+	if err := tmp.Close(); err != nil {
+		return rep, err
+	}
+	st, err := os.Stat(tmp.Name())
+	if err != nil {
+		return rep, err
+	}
+	if st.Size() > MaxSkillPackageSize {
+		return rep, fmt.Errorf("package is %d bytes, exceeds the %d MB upload limit", st.Size(), MaxSkillPackageSize>>20)
+	}
+	return rep, os.Rename(tmp.Name(), out)
+}
+
+func writeSkillZip(w io.Writer, dir string, files []string) error {
+	zw := zip.NewWriter(w)
+	for _, rel := range files {
+		src := filepath.Join(dir, filepath.FromSlash(rel))
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		hdr.Method = zip.Deflate
+		dst, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(dst, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func removeString(list []string, s string) []string {
+	out := list[:0]
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package skills
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func lintMessages(rep *LintReport, severity string) string {
+	var out []string
+	for _, i := range rep.Issues {
+		if i.Severity == severity {
+			out = append(out, i.String())
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func TestLintSkillDir_Valid(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pdf-tools")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: PDF Tools\ndescription: Work with PDFs\nrequires: charts>=2\n---\n"+
+		"Run `scripts/extract.py`, see [the guide](references/guide.md#usage) and [docs](https://example.com).\n"+
+		"Outputs go to `assets/*.png`.\n")
+	writeFile(t, filepath.Join(dir, "scripts", "extract.py"), "print(1)\n")
+	writeFile(t, filepath.Join(dir, "references", "guide.md"), "# guide\n")
+	writeFile(t, filepath.Join(dir, ".DS_Store"), "junk")
+	writeFile(t, filepath.Join(dir, ".git", "HEAD"), "ref")
+
+	rep, err := LintSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Issues) != 0 {
+		t.Errorf("issues = %v", rep.Issues)
+	}
+	if rep.Slug != "pdf-tools" || strings.Join(rep.Files, ",") != "SKILL.md,references/guide.md,scripts/extract.py" {
+		t.Errorf("slug = %q, files = %v", rep.Slug, rep.Files)
+	}
+}
+
+func TestLintSkillDir_Problems(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "notes")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: notes\nslug: Bad_Slug\nrequires: other~=2\n---\n"+
+		"See [missing](references/missing.md) and [escape](../secret.md).\n")
+
+	rep, err := LintSkillDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := lintMessages(rep, LintError)
+	for _, want := range []string{`invalid slug "Bad_Slug"`, `invalid requirement "other~=2"`, `"references/missing.md" does not exist`, `"../secret.md" points outside`} {
+		if !strings.Contains(errs, want) {
+			t.Errorf("errors missing %q:\n%s", want, errs)
+		}
+	}
+	if warns := lintMessages(rep, LintWarning); !strings.Contains(warns, "missing description") {
+		t.Errorf("warnings = %s", warns)
+	}
+
+	empty := t.TempDir()
+	rep, _ = LintSkillDir(empty)
+	if !rep.HasErrors() || !strings.Contains(lintMessages(rep, LintError), "missing SKILL.md") {
+		t.Errorf("empty dir issues = %v", rep.Issues)
+	}
+
+	writeFile(t, filepath.Join(empty, "SKILL.md"), "# no frontmatter\n")
+	writeFile(t, filepath.Join(empty, "big.bin"), strings.Repeat("x", MaxSkillPackageSize+1))
+	rep, _ = LintSkillDir(empty)
+	errs = lintMessages(rep, LintError)
+	if !strings.Contains(errs, "missing YAML frontmatter") || !strings.Contains(errs, "MB limit") {
+		t.Errorf("errors = %s", errs)
+	}
+}
+
+func TestPackageSkillDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "charts")
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: charts\ndescription: Charts\n---\nUse `scripts/plot.py`.\n")
+	writeFile(t, filepath.Join(dir, "scripts", "plot.py"), "print(1)\n")
+	writeFile(t, filepath.Join(dir, "__MACOSX", "._plot.py"), "junk")
+
+	// Packaging into the skill directory must not include the zip itself.
+	out := filepath.Join(dir, "charts.zip")
+	for range 2 {
+		if _, err := PackageSkillDir(dir, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zr, err := zip.OpenReader(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if strings.Join(names, ",") != "SKILL.md,scripts/plot.py" {
+		t.Errorf("zip entries = %v", names)
+	}
+
+	// The package round-trips through the registry/upload extraction rules.
+	files, err := ExtractArchive(out, MaxSkillPackageSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files, err = skillRootFiles(files); err != nil || len(files) != 2 {
+		t.Errorf("skillRootFiles = %v, %v", files, err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad")
+	writeFile(t, filepath.Join(bad, "SKILL.md"), "---\ndescription: no name\n---\n")
+	badOut := filepath.Join(t.TempDir(), "bad.zip")
+	if _, err := PackageSkillDir(bad, badOut); err == nil {
+		t.Error("expected lint errors to block packaging")
+	}
+	if _, err := os.Stat(badOut); !os.IsNotExist(err) {
+		t.Error("no zip should be written on lint errors")
+	}
+}
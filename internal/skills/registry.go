@@ -0,0 +1,510 @@
+package skills
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// Registry limits: the index is small JSON; artifacts are zipped skill dirs.
+const (
+	maxRegistryIndexBytes    = 4 << 20
+	maxRegistryArtifactBytes = 50 << 20
+	maxRegistryUnpackedBytes = 200 << 20
+)
+
+// LockfileName is the lock file kept in the skills directory for
+// registry-installed skills.
+const LockfileName = "skills.lock.json"
+
+// ErrNotRegistrySkill is returned when pulling over a skill directory the
+// registry did not install.
+var ErrNotRegistrySkill = errors.New("registry: skill directory exists and was not installed from the registry")
+
+// RegistryIndex is the JSON document a skill registry serves:
+//
+//	{"skills": [{"name": "pdf-tools", "description": "...",
+//	  "versions": [{"version": 3, "url": "pdf-tools-3.zip", "sha256": "..."}]}]}
+//
+// Artifact URLs may be relative to the index URL. Every version must carry
+// the SHA-256 of its artifact.
+type RegistryIndex struct {
+	Skills []RegistrySkill `json:"skills"`
+}
+
+// RegistrySkill is one skill in a registry index.
+type RegistrySkill struct {
+	Name        string            `json:"name"` // slug; also the install directory name
+	Description string            `json:"description,omitempty"`
+	Versions    []RegistryVersion `json:"versions"`
+}
+
+// RegistryVersion is one published artifact of a skill.
+type RegistryVersion struct {
+	Version int    `json:"version"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"`
+}
+
+// Find returns the named skill.
+func (idx *RegistryIndex) Find(name string) (RegistrySkill, bool) {
+	for _, s := range idx.Skills {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return RegistrySkill{}, false
+}
+
+// Version returns version v of the skill, or the highest version when v is 0.
+func (s RegistrySkill) Version(v int) (RegistryVersion, bool) {
+	var best RegistryVersion
+	found := false
+	for _, rv := range s.Versions {
+		if v > 0 && rv.Version == v {
+			return rv, true
+		}
+		if v == 0 && rv.Version > best.Version {
+			best, found = rv, true
+		}
+	}
+	return best, found
+}
+
+// Lockfile records registry-installed skills so installs are reproducible
+// and auto-update knows what it owns.
+type Lockfile struct {
+	Skills map[string]LockEntry `json:"skills"`
+}
+
+// LockEntry is one installed skill.
+type LockEntry struct {
+	Version     int       `json:"version"`
+	SHA256      string    `json:"sha256"`
+	URL         string    `json:"url"`
+	Pinned      bool      `json:"pinned,omitempty"` // auto-update leaves pinned skills alone
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// LoadLockfile reads the lock file in skillsDir. A missing file is an empty lock.
+func LoadLockfile(skillsDir string) (*Lockfile, error) {
+	lf := &Lockfile{Skills: map[string]LockEntry{}}
+	data, err := os.ReadFile(filepath.Join(skillsDir, LockfileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return lf, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, lf); err != nil {
+		return nil, fmt.Errorf("registry: parse %s: %w", LockfileName, err)
+	}
+	if lf.Skills == nil {
+		lf.Skills = map[string]LockEntry{}
+	}
+	return lf, nil
+}
+
+// Save writes the lock file into skillsDir atomically.
+func (lf *Lockfile) Save(skillsDir string) error {
+	data, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(skillsDir, "."+LockfileName+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(skillsDir, LockfileName))
+}
+
+// Registry pulls skills from a remote index into a skills directory
+// (normally the global skills dir, which the loader watches).
+type Registry struct {
+	indexURL *url.URL
+	token    string
+	client   *http.Client
+	dir      string
+
+	mu sync.Mutex // serializes installs and lock file writes
+}
+
+// NewRegistry creates a registry client for the index at indexURL that
+// installs into dir. The index must be served over HTTPS (plain HTTP is
+// accepted for loopback hosts only). A nil client uses the default proxy
+// settings with a 5 minute timeout.
+func NewRegistry(indexURL, token, dir string, client *http.Client) (*Registry, error) {
+	u, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("registry: parse url: %w", err)
+	}
+	if err := checkRegistryURL(u); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Minute, Transport: netproxy.Transport(netproxy.ScopeDefault)}
+	}
+	return &Registry{indexURL: u, token: token, client: client, dir: dir}, nil
+}
+
+func checkRegistryURL(u *url.URL) error {
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("registry: %s must use https", u.Redacted())
+}
+
+// Dir returns the directory skills are installed into.
+func (r *Registry) Dir() string { return r.dir }
+
+// Index fetches the registry index.
+func (r *Registry) Index(ctx context.Context) (*RegistryIndex, error) {
+	resp, err := r.get(ctx, r.indexURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryIndexBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("registry: read index: %w", err)
+	}
+	if len(data) > maxRegistryIndexBytes {
+		return nil, fmt.Errorf("registry: index exceeds %d bytes", maxRegistryIndexBytes)
+	}
+	var idx RegistryIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("registry: parse index: %w", err)
+	}
+	return &idx, nil
+}
+
+// Pull installs version (0 = latest) of the named skill and records it in
+// the lock file. pin keeps auto-update from moving it to newer versions;
+// pulling without pin releases an earlier pin.
+func (r *Registry) Pull(ctx context.Context, name string, version int, pin bool) (LockEntry, error) {
+	idx, err := r.Index(ctx)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lf, err := LoadLockfile(r.dir)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	entry, err := r.install(ctx, idx, lf, name, version)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	entry.Pinned = pin
+	lf.Skills[name] = entry
+	return entry, lf.Save(r.dir)
+}
+
+// UpdateResult lists what an Update changed.
+type UpdateResult struct {
+	Updated []string // "name v1 -> v2"
+	Failed  []string // "name: error"
+}
+
+// Update brings every locked skill to its target version: the pinned
+// version for pinned skills, otherwise the registry's latest. Skills whose
+// directory went missing are reinstalled. Failures are collected per skill.
+func (r *Registry) Update(ctx context.Context) (UpdateResult, error) {
+	var res UpdateResult
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lf, err := LoadLockfile(r.dir)
+	if err != nil || len(lf.Skills) == 0 {
+		return res, err
+	}
+	idx, err := r.Index(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	names := make([]string, 0, len(lf.Skills))
+	for name := range lf.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cur := lf.Skills[name]
+		want := cur.Version
+		if !cur.Pinned {
+			s, ok := idx.Find(name)
+			if !ok {
+				res.Failed = append(res.Failed, name+": no longer in registry")
+				continue
+			}
+			latest, _ := s.Version(0)
+			want = max(latest.Version, cur.Version)
+		}
+		if _, err := os.Stat(filepath.Join(r.dir, name, "SKILL.md")); want == cur.Version && err == nil {
+			continue
+		}
+		entry, err := r.install(ctx, idx, lf, name, want)
+		if err != nil {
+			res.Failed = append(res.Failed, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		entry.Pinned = cur.Pinned
+		lf.Skills[name] = entry
+		res.Updated = append(res.Updated, fmt.Sprintf("%s v%d -> v%d", name, cur.Version, entry.Version))
+	}
+	if len(res.Updated) == 0 {
+		return res, nil
+	}
+	return res, lf.Save(r.dir)
+}
+
+// StartAutoUpdate runs Update every interval until the returned stop func is
+// called. onChange runs after updates that installed something.
+func (r *Registry) StartAutoUpdate(interval time.Duration, onChange func()) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			res, err := r.Update(ctx)
+			if err != nil {
+				slog.Warn("skills.registry: update failed", "registry", r.indexURL.Redacted(), "error", err)
+				continue
+			}
+			for _, f := range res.Failed {
+				slog.Warn("skills.registry: skill update failed", "detail", f)
+			}
+			if len(res.Updated) > 0 {
+				slog.Info("skills.registry: updated skills", "skills", res.Updated)
+				if onChange != nil {
+					onChange()
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// install downloads, verifies and unpacks one skill version into r.dir.
+// Must be called with r.mu held.
+func (r *Registry) install(ctx context.Context, idx *RegistryIndex, lf *Lockfile, name string, version int) (LockEntry, error) {
+	if !SlugRegexp.MatchString(name) {
+		return LockEntry{}, fmt.Errorf("registry: invalid skill name %q", name)
+	}
+	s, ok := idx.Find(name)
+	if !ok {
+		return LockEntry{}, fmt.Errorf("registry: skill %q not found", name)
+	}
+	rv, ok := s.Version(version)
+	if !ok {
+		return LockEntry{}, fmt.Errorf("registry: skill %q has no version %d", name, version)
+	}
+	if len(rv.SHA256) != sha256.Size*2 {
+		return LockEntry{}, fmt.Errorf("registry: %s v%d has no valid sha256", name, rv.Version)
+	}
+	target := filepath.Join(r.dir, name)
+	if _, err := os.Stat(target); err == nil {
+		if _, locked := lf.Skills[name]; !locked {
+			return LockEntry{}, fmt.Errorf("%w: %s", ErrNotRegistrySkill, target)
+		}
+	}
+
+	artifactURL, err := r.indexURL.Parse(rv.URL)
+	if err != nil {
+		return LockEntry{}, fmt.Errorf("registry: artifact url: %w", err)
+	}
+	if err := checkRegistryURL(artifactURL); err != nil {
+		return LockEntry{}, err
+	}
+	tmpFile, sum, err := r.download(ctx, artifactURL)
+	if err != nil {
+		return LockEntry{}, err
+	}
+	defer os.Remove(tmpFile)
+	if err := VerifyChecksum(rv.SHA256, sum); err != nil {
+		return LockEntry{}, fmt.Errorf("registry: %s v%d: %w", name, rv.Version, err)
+	}
+
+	files, err := ExtractArchive(tmpFile, maxRegistryUnpackedBytes)
+	if err != nil {
+		return LockEntry{}, fmt.Errorf("registry: %s v%d: %w", name, rv.Version, err)
+	}
+	files, err = skillRootFiles(files)
+	if err != nil {
+		return LockEntry{}, fmt.Errorf("registry: %s v%d: %w", name, rv.Version, err)
+	}
+	if err := writeSkillDir(r.dir, name, files); err != nil {
+		return LockEntry{}, err
+	}
+	return LockEntry{
+		Version:     rv.Version,
+		SHA256:      strings.ToLower(rv.SHA256),
+		URL:         artifactURL.Redacted(),
+		InstalledAt: time.Now().UTC(),
+	}, nil
+}
+
+func (r *Registry) get(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("registry: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("registry: GET %s: status %d: %s", u.Redacted(), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// download streams an artifact to a temp file, returning its path and SHA-256.
+func (r *Registry) download(ctx context.Context, u *url.URL) (string, string, error) {
+	resp, err := r.get(ctx, u)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp("", "goclaw-skill-*.zip")
+	if err != nil {
+		return "", "", err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(resp.Body, maxRegistryArtifactBytes+1))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > maxRegistryArtifactBytes {
+		err = fmt.Errorf("registry: artifact exceeds %d bytes", maxRegistryArtifactBytes)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", "", err
+	}
+	return tmp.Name(), hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// skillRootFiles accepts SKILL.md at the archive root or inside a single
+// top-level directory (as zip tools often wrap contents) and returns the
+// files relative to the skill root. SKILL.md must pass the content guard.
+func skillRootFiles(files []ArchiveFile) ([]ArchiveFile, error) {
+	prefix := ""
+	found := false
+	for _, f := range files {
+		if f.Name == "SKILL.md" {
+			prefix, found = "", true
+			break
+		}
+		if dir, base, ok := strings.Cut(f.Name, "/"); ok && base == "SKILL.md" {
+			prefix, found = dir+"/", true
+		}
+	}
+	if !found {
+		return nil, errors.New("artifact must contain SKILL.md at its root or inside a single top-level directory")
+	}
+	out := make([]ArchiveFile, 0, len(files))
+	for _, f := range files {
+		if !strings.HasPrefix(f.Name, prefix) || IsSystemArtifact(f.Name) {
+			continue
+		}
+		f.Name = strings.TrimPrefix(f.Name, prefix)
+		if f.Name == "SKILL.md" {
+			if violations, safe := GuardSkillContent(string(f.Content)); !safe {
+				return nil, fmt.Errorf("SKILL.md failed security scan: %s", violations[0].Reason)
+			}
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+// writeSkillDir writes files into a staging directory next to dir/name and
+// swaps it in, so the loader never sees a half-written skill.
+func writeSkillDir(dir, name string, files []ArchiveFile) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp(dir, ".pull-"+name+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+	for _, f := range files {
+		dst := filepath.Join(staging, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		mode := f.Mode.Perm() & 0o755
+		if mode == 0 {
+			mode = 0o644
+		}
+		if err := os.WriteFile(dst, f.Content, mode|0o600); err != nil {
+			return err
+		}
+	}
+
+	target := filepath.Join(dir, name)
+	old := ""
+	if _, err := os.Stat(target); err == nil {
+		old = staging + ".old"
+		if err := os.Rename(target, old); err != nil {
+			return err
+		}
+		defer os.RemoveAll(old)
+	}
+	if err := os.Rename(staging, target); err != nil {
+		if old != "" {
+			_ = os.Rename(old, target)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package skills
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeRegistry serves an index plus zip artifacts built from SKILL.md bodies.
+type fakeRegistry struct {
+	index     RegistryIndex
+	artifacts map[string][]byte
+}
+
+func (f *fakeRegistry) publish(t *testing.T, name string, version int, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for n, body := range files {
+		w, err := zw.Create(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file := name + "-" + strconv.Itoa(version) + ".zip"
+	f.artifacts["/"+file] = buf.Bytes()
+	sum := sha256.Sum256(buf.Bytes())
+	rv := RegistryVersion{Version: version, URL: file, SHA256: hex.EncodeToString(sum[:])}
+	for i := range f.index.Skills {
+		if f.index.Skills[i].Name == name {
+			f.index.Skills[i].Versions = append(f.index.Skills[i].Versions, rv)
+			return
+		}
+	}
+	f.index.Skills = append(f.index.Skills, RegistrySkill{Name: name, Versions: []RegistryVersion{rv}})
+}
+
+func newFakeRegistry(t *testing.T) (*fakeRegistry, *httptest.Server) {
+	f := &fakeRegistry{artifacts: map[string][]byte{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/index.json" {
+			json.NewEncoder(w).Encode(f.index)
+			return
+		}
+		data, ok := f.artifacts[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func skillFiles(name, desc string) map[string]string {
+	return map[string]string{
+		name + "/SKILL.md":          "---\nname: " + name + "\ndescription: " + desc + "\n---\n# " + name + "\n",
+		name + "/scripts/run.sh":    "echo hi\n",
+		"__MACOSX/" + name + "/._x": "junk",
+	}
+}
+
+func readSkillMD(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestNewRegistry_RequiresHTTPS(t *testing.T) {
+	if _, err := NewRegistry("http://skills.example.com/index.json", "", t.TempDir(), nil); err == nil {
+		t.Error("plain http to a remote host should be rejected")
+	}
+	for _, u := range []string{"https://skills.example.com/index.json", "http://127.0.0.1:8080/index.json", "http://localhost/index.json"} {
+		if _, err := NewRegistry(u, "", t.TempDir(), nil); err != nil {
+			t.Errorf("NewRegistry(%q): %v", u, err)
+		}
+	}
+}
+
+func TestRegistry_PullAndUpdate(t *testing.T) {
+	f, srv := newFakeRegistry(t)
+	f.publish(t, "pdf-tools", 1, skillFiles("pdf-tools", "v1"))
+	f.publish(t, "charts", 1, skillFiles("charts", "v1"))
+
+	dir := t.TempDir()
+	reg, err := NewRegistry(srv.URL+"/index.json", "tok", dir, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := reg.Pull(ctx, "pdf-tools", 0, false); err != nil {
+		t.Fatalf("pull pdf-tools: %v", err)
+	}
+	if _, err := reg.Pull(ctx, "charts", 1, true); err != nil {
+		t.Fatalf("pull charts: %v", err)
+	}
+	if !strings.Contains(readSkillMD(t, dir, "pdf-tools"), "description: v1") {
+		t.Error("pdf-tools not installed at v1")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pdf-tools", "scripts", "run.sh")); err != nil {
+		t.Errorf("supporting file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pdf-tools", "__MACOSX")); err == nil {
+		t.Error("system artifacts should be skipped")
+	}
+
+	lf, err := LoadLockfile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := lf.Skills["charts"]; e.Version != 1 || !e.Pinned || len(e.SHA256) != 64 {
+		t.Errorf("charts lock entry = %+v", e)
+	}
+
+	// New versions: unpinned skill follows, pinned stays.
+	f.publish(t, "pdf-tools", 2, skillFiles("pdf-tools", "v2"))
+	f.publish(t, "charts", 2, skillFiles("charts", "v2"))
+	res, err := reg.Update(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Updated) != 1 || res.Updated[0] != "pdf-tools v1 -> v2" || len(res.Failed) != 0 {
+		t.Errorf("update = %+v", res)
+	}
+	if !strings.Contains(readSkillMD(t, dir, "pdf-tools"), "description: v2") {
+		t.Error("pdf-tools not updated to v2")
+	}
+	if !strings.Contains(readSkillMD(t, dir, "charts"), "description: v1") {
+		t.Error("pinned charts should stay at v1")
+	}
+
+	// A deleted skill directory is restored at its locked version.
+	os.RemoveAll(filepath.Join(dir, "charts"))
+	if res, err := reg.Update(ctx); err != nil || len(res.Updated) != 1 {
+		t.Errorf("restore = %+v, %v", res, err)
+	}
+	if !strings.Contains(readSkillMD(t, dir, "charts"), "description: v1") {
+		t.Error("charts not restored at pinned v1")
+	}
+
+	// Nothing left to do.
+	if res, err := reg.Update(ctx); err != nil || len(res.Updated) != 0 {
+		t.Errorf("idle update = %+v, %v", res, err)
+	}
+}
+
+func TestRegistry_PullChecksumMismatch(t *testing.T) {
+	f, srv := newFakeRegistry(t)
+	f.publish(t, "pdf-tools", 1, skillFiles("pdf-tools", "v1"))
+	f.index.Skills[0].Versions[0].SHA256 = strings.Repeat("0", 64)
+
+	dir := t.TempDir()
+	reg, _ := NewRegistry(srv.URL+"/index.json", "tok", dir, srv.Client())
+	_, err := reg.Pull(context.Background(), "pdf-tools", 0, false)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("err = %v, want checksum mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pdf-tools")); !os.IsNotExist(err) {
+		t.Error("nothing should be installed on checksum mismatch")
+	}
+}
+
+func TestRegistry_PullRejects(t *testing.T) {
+	f, srv := newFakeRegistry(t)
+	f.publish(t, "local", 1, skillFiles("local", "v1"))
+	f.publish(t, "no-skill", 1, map[string]string{"README.md": "hi"})
+	f.publish(t, "unsigned", 1, skillFiles("unsigned", "v1"))
+	f.index.Skills[2].Versions[0].SHA256 = ""
+
+	dir := t.TempDir()
+	makeSkillDir(t, dir, "local", "---\nname: local\n---\nhand-written\n")
+	reg, _ := NewRegistry(srv.URL+"/index.json", "tok", dir, srv.Client())
+	ctx := context.Background()
+
+	if _, err := reg.Pull(ctx, "local", 0, false); !errors.Is(err, ErrNotRegistrySkill) {
+		t.Errorf("overwrite local skill: err = %v", err)
+	}
+	if !strings.Contains(readSkillMD(t, dir, "local"), "hand-written") {
+		t.Error("local skill was overwritten")
+	}
+	if _, err := reg.Pull(ctx, "no-skill", 0, false); err == nil || !strings.Contains(err.Error(), "SKILL.md") {
+		t.Errorf("missing SKILL.md: err = %v", err)
+	}
+	if _, err := reg.Pull(ctx, "unsigned", 0, false); err == nil || !strings.Contains(err.Error(), "sha256") {
+		t.Errorf("missing sha256: err = %v", err)
+	}
+	if _, err := reg.Pull(ctx, "pdf-tools", 0, false); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("unknown skill: err = %v", err)
+	}
+	if _, err := reg.Pull(ctx, "local", 9, false); err == nil || !strings.Contains(err.Error(), "no version 9") {
+		t.Errorf("unknown version: err = %v", err)
+	}
+
+	bad, _ := NewRegistry(srv.URL+"/index.json", "wrong", dir, srv.Client())
+	if _, err := bad.Index(ctx); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("bad token: err = %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package skills
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Requirement is a skill dependency declared in SKILL.md frontmatter:
+//
+//	requires:
+//	  - pdf-tools>=2
+//	  - brand-voice
+//
+// A bare slug accepts any version. Version constraints only apply to managed
+// (versioned) skills; workspace, global and builtin skills are unversioned and
+// only satisfy bare requirements.
+type Requirement struct {
+	Slug    string `json:"slug"`
+	Op      string `json:"op,omitempty"` // "", "=", ">=", ">", "<=", "<"
+	Version int    `json:"version,omitempty"`
+}
+
+var requirementRe = regexp.MustCompile(`^([a-z0-9][a-z0-9._-]*)\s*(?:(>=|<=|==|=|>|<)\s*v?([0-9]+))?$`)
+
+// ParseRequirement parses "slug", "slug>=2", "slug=3", "slug<4", etc.
+func ParseRequirement(s string) (Requirement, error) {
+	m := requirementRe.FindStringSubmatch(s)
+	if m == nil {
+		return Requirement{}, fmt.Errorf("invalid requirement %q", s)
+	}
+	r := Requirement{Slug: m[1], Op: m[2]}
+	if r.Op == "==" {
+		r.Op = "="
+	}
+	if m[3] != "" {
+		v, err := strconv.Atoi(m[3])
+		if err != nil || v < 1 {
+			return Requirement{}, fmt.Errorf("invalid requirement %q: version must be >= 1", s)
+		}
+		r.Version = v
+	}
+	return r, nil
+}
+
+// Allows reports whether version satisfies the requirement (0 = unversioned).
+func (r Requirement) Allows(version int) bool {
+	if r.Op == "" {
+		return true
+	}
+	if version < 1 {
+		return false
+	}
+	switch r.Op {
+	case "=":
+		return version == r.Version
+	case ">=":
+		return version >= r.Version
+	case ">":
+		return version > r.Version
+	case "<=":
+		return version <= r.Version
+	case "<":
+		return version < r.Version
+	}
+	return false
+}
+
+func (r Requirement) String() string {
+	if r.Op == "" {
+		return r.Slug
+	}
+	return r.Slug + r.Op + strconv.Itoa(r.Version)
+}
+
+// Conflict explains why a skill's version pin or requirement cannot be met.
+type Conflict struct {
+	Skill       string `json:"skill"`
+	Requirement string `json:"requirement,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+func (c Conflict) String() string {
+	if c.Requirement == "" {
+		return c.Skill + ": " + c.Reason
+	}
+	return fmt.Sprintf("%s requires %s: %s", c.Skill, c.Requirement, c.Reason)
+}
+
+// Resolution is the result of ResolveSkills.
+type Resolution struct {
+	Skills    []Info     // selected skills at their resolved versions
+	Conflicts []Conflict // unmet pins and requirements (skills are still listed)
+}
+
+// ResolveSkills selects the skills in allowList (nil = all) at the versions to
+// load: a pinned version when pins names one (0 or absent = active version),
+// otherwise the newest version that satisfies every requirement declared by
+// the other selected skills. Requirements are never used to widen allowList;
+// a dependency the agent cannot access is reported as a conflict, as is a
+// pinned version that does not exist or does not satisfy a requirement.
+func (l *Loader) ResolveSkills(ctx context.Context, allowList []string, pins map[string]int) Resolution {
+	var res Resolution
+	selected := l.FilterSkills(ctx, allowList)
+	if len(selected) == 0 {
+		return res
+	}
+
+	chosen := make(map[string]*Info, len(selected))
+	pinned := make(map[string]bool)
+	for i := range selected {
+		s := &selected[i]
+		chosen[s.Slug] = s
+		pin := pins[s.Slug]
+		if pin <= 0 {
+			continue
+		}
+		switch {
+		case s.Source != "managed":
+			res.Conflicts = append(res.Conflicts, Conflict{Skill: s.Slug,
+				Reason: fmt.Sprintf("pinned version %d ignored: overridden by %s skill", pin, s.Source)})
+		case pin == s.Version:
+			pinned[s.Slug] = true
+		default:
+			info, ok := l.managedInfo(s.Slug, pin)
+			if !ok {
+				res.Conflicts = append(res.Conflicts, Conflict{Skill: s.Slug,
+					Reason: fmt.Sprintf("pinned version %d not found, using version %d", pin, s.Version)})
+				continue
+			}
+			*s = info
+			pinned[s.Slug] = true
+		}
+	}
+
+	// Move unpinned managed dependencies to the newest version that meets every
+	// requirement on them. A version change alters that skill's own
+	// requirements, so repeat until stable (bounded by the number of skills).
+	for range len(selected) {
+		changed := false
+		for dep, reqs := range collectRequirements(selected) {
+			s, ok := chosen[dep]
+			if !ok || pinned[dep] || s.Source != "managed" || allowAll(reqs, s.Version) {
+				continue
+			}
+			for _, v := range l.managedVersions(dep) {
+				if v == s.Version || !allowAll(reqs, v) {
+					continue
+				}
+				if info, ok := l.managedInfo(dep, v); ok {
+					*s = info
+					changed = true
+					break
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	var available map[string]bool
+	for _, s := range selected {
+		for _, raw := range s.Requires {
+			req, err := ParseRequirement(raw)
+			if err != nil {
+				res.Conflicts = append(res.Conflicts, Conflict{Skill: s.Slug, Requirement: raw, Reason: "invalid requirement"})
+				continue
+			}
+			if req.Slug == s.Slug {
+				continue
+			}
+			dep, ok := chosen[req.Slug]
+			switch {
+			case !ok:
+				if available == nil {
+					available = make(map[string]bool)
+					for _, a := range l.ListSkills(ctx) {
+						available[a.Slug] = true
+					}
+				}
+				reason := "not installed"
+				if available[req.Slug] {
+					reason = "not granted to this agent"
+				}
+				res.Conflicts = append(res.Conflicts, Conflict{Skill: s.Slug, Requirement: raw, Reason: reason})
+			case !req.Allows(dep.Version):
+				reason := fmt.Sprintf("no matching version (resolved %s)", versionLabel(*dep))
+				if pinned[dep.Slug] {
+					reason = fmt.Sprintf("%s is pinned to version %d", dep.Slug, dep.Version)
+				}
+				res.Conflicts = append(res.Conflicts, Conflict{Skill: s.Slug, Requirement: raw, Reason: reason})
+			}
+		}
+	}
+	res.Skills = selected
+	return res
+}
+
+// collectRequirements groups the valid requirements of skills by dependency slug.
+func collectRequirements(skills []Info) map[string][]Requirement {
+	out := make(map[string][]Requirement)
+	for _, s := range skills {
+		for _, raw := range s.Requires {
+			if req, err := ParseRequirement(raw); err == nil && req.Slug != s.Slug {
+				out[req.Slug] = append(out[req.Slug], req)
+			}
+		}
+	}
+	return out
+}
+
+func allowAll(reqs []Requirement, version int) bool {
+	for _, r := range reqs {
+		if !r.Allows(version) {
+			return false
+		}
+	}
+	return true
+}
+
+func versionLabel(s Info) string {
+	if s.Version < 1 {
+		return "unversioned " + s.Source + " skill"
+	}
+	return "version " + strconv.Itoa(s.Version)
+}
@@ -0,0 +1,156 @@
+package skills
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// makeManagedVersion creates <managed>/<slug>/<version>/SKILL.md.
+func makeManagedVersion(t *testing.T, managed, slug string, version int, frontmatter string) {
+	t.Helper()
+	makeSkillDir(t, filepath.Join(managed, slug), strconv.Itoa(version),
+		"---\nname: "+slug+"\n"+frontmatter+"---\n# "+slug+"\n")
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Requirement
+		allows  []int
+		rejects []int
+	}{
+		{"pdf-tools", Requirement{Slug: "pdf-tools"}, []int{0, 1, 9}, nil},
+		{"pdf-tools>=2", Requirement{Slug: "pdf-tools", Op: ">=", Version: 2}, []int{2, 3}, []int{0, 1}},
+		{"pdf-tools == 3", Requirement{Slug: "pdf-tools", Op: "=", Version: 3}, []int{3}, []int{2, 4}},
+		{"pdf-tools<v4", Requirement{Slug: "pdf-tools", Op: "<", Version: 4}, []int{1, 3}, []int{0, 4}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRequirement(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRequirement(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+			continue
+		}
+		for _, v := range tt.allows {
+			if !got.Allows(v) {
+				t.Errorf("%s should allow %d", got, v)
+			}
+		}
+		for _, v := range tt.rejects {
+			if got.Allows(v) {
+				t.Errorf("%s should reject %d", got, v)
+			}
+		}
+	}
+	for _, bad := range []string{"", ">=2", "Pdf-Tools", "pdf-tools>=0", "pdf-tools~=2"} {
+		if _, err := ParseRequirement(bad); err == nil {
+			t.Errorf("ParseRequirement(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseMetadata_Requires(t *testing.T) {
+	dir := t.TempDir()
+	list := makeSkillDir(t, dir, "a", "---\nname: a\nrequires:\n  - b>=2\n  - c\n---\n")
+	inline := makeSkillDir(t, dir, "b", "---\nname: b\nrequires: c=1, d\n---\n")
+
+	if got := parseMetadata(filepath.Join(list, "SKILL.md")).Requires; strings.Join(got, ",") != "b>=2,c" {
+		t.Errorf("list requires = %v", got)
+	}
+	if got := parseMetadata(filepath.Join(inline, "SKILL.md")).Requires; strings.Join(got, ",") != "c=1,d" {
+		t.Errorf("inline requires = %v", got)
+	}
+}
+
+func TestLoader_ResolveSkills_PinnedVersion(t *testing.T) {
+	managed := t.TempDir()
+	makeManagedVersion(t, managed, "report", 1, "description: v1\n")
+	makeManagedVersion(t, managed, "report", 2, "description: v2\n")
+
+	l := NewLoader("", "", "")
+	l.SetManagedDir(managed)
+
+	res := l.ResolveSkills(context.Background(), []string{"report"}, map[string]int{"report": 1})
+	if len(res.Skills) != 1 || res.Skills[0].Version != 1 || res.Skills[0].Description != "v1" {
+		t.Fatalf("skills = %+v", res.Skills)
+	}
+	if want := filepath.Join(managed, "report", "1", "SKILL.md"); res.Skills[0].Path != want {
+		t.Errorf("path = %q, want %q", res.Skills[0].Path, want)
+	}
+
+	res = l.ResolveSkills(context.Background(), []string{"report"}, map[string]int{"report": 7})
+	if res.Skills[0].Version != 2 || len(res.Conflicts) != 1 || !strings.Contains(res.Conflicts[0].Reason, "not found") {
+		t.Errorf("missing pin: skills = %+v, conflicts = %v", res.Skills, res.Conflicts)
+	}
+}
+
+func TestLoader_ResolveSkills_Requirements(t *testing.T) {
+	managed := t.TempDir()
+	makeManagedVersion(t, managed, "charts", 1, "")
+	makeManagedVersion(t, managed, "charts", 2, "")
+	makeManagedVersion(t, managed, "charts", 3, "")
+	makeManagedVersion(t, managed, "report", 1, "requires:\n  - charts>=2\n  - charts<3\n  - brand-voice\n")
+	makeManagedVersion(t, managed, "brand-voice", 1, "")
+
+	l := NewLoader("", "", "")
+	l.SetManagedDir(managed)
+	ctx := context.Background()
+
+	// Unpinned dependency resolves to the newest version meeting every constraint.
+	res := l.ResolveSkills(ctx, []string{"charts", "report", "brand-voice"}, nil)
+	versions := map[string]int{}
+	for _, s := range res.Skills {
+		versions[s.Slug] = s.Version
+	}
+	if versions["charts"] != 2 || versions["report"] != 1 || len(res.Conflicts) != 0 {
+		t.Fatalf("versions = %v, conflicts = %v", versions, res.Conflicts)
+	}
+
+	// A pin that violates a requirement is kept and reported.
+	res = l.ResolveSkills(ctx, []string{"charts", "report", "brand-voice"}, map[string]int{"charts": 1})
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Requirement != "charts>=2" ||
+		!strings.Contains(res.Conflicts[0].Reason, "pinned to version 1") {
+		t.Errorf("pin conflict = %v", res.Conflicts)
+	}
+
+	// Dependencies outside the allow list are reported, not added.
+	res = l.ResolveSkills(ctx, []string{"report"}, nil)
+	if len(res.Skills) != 1 {
+		t.Fatalf("skills = %+v", res.Skills)
+	}
+	reasons := map[string]string{}
+	for _, c := range res.Conflicts {
+		reasons[c.Requirement] = c.Reason
+	}
+	if reasons["charts>=2"] != "not granted to this agent" || reasons["brand-voice"] != "not granted to this agent" {
+		t.Errorf("conflicts = %v", res.Conflicts)
+	}
+}
+
+func TestLoader_ResolveSkills_UnversionedDependency(t *testing.T) {
+	ws := t.TempDir()
+	makeSkillDir(t, filepath.Join(ws, "skills"), "notes", "---\nname: notes\nrequires: glossary>=2, missing\n---\n")
+	makeSkillDir(t, filepath.Join(ws, "skills"), "glossary", "---\nname: glossary\n---\n")
+
+	l := NewLoader(ws, "", "")
+	res := l.ResolveSkills(context.Background(), nil, nil)
+	if len(res.Conflicts) != 2 {
+		t.Fatalf("conflicts = %v", res.Conflicts)
+	}
+	for _, c := range res.Conflicts {
+		switch c.Requirement {
+		case "glossary>=2":
+			if !strings.Contains(c.Reason, "unversioned workspace skill") {
+				t.Errorf("glossary conflict = %v", c)
+			}
+		case "missing":
+			if c.Reason != "not installed" {
+				t.Errorf("missing conflict = %v", c)
+			}
+		default:
+			t.Errorf("unexpected conflict %v", c)
+		}
+	}
+}
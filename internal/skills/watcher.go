@@ -10,19 +10,31 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 )
 
 // watchDebounce is the delay before processing skill directory changes.
 // Shorter than memory watcher (500ms vs 1500ms) because skill changes are lightweight.
 const watchDebounce = 500 * time.Millisecond
 
+// watchDepth is how many directory levels below a skill root are watched:
+// <root>/<slug>/SKILL.md needs 1, managed <root>/<slug>/<version>/SKILL.md needs 2.
+const watchDepth = 2
+
 // Watcher monitors skill directories for SKILL.md changes and bumps the loader version.
 // Reuses the same fsnotify + debounce pattern as memory.Watcher.
 type Watcher struct {
-	loader *Loader
-	fsw    *fsnotify.Watcher
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	loader   *Loader
+	fsw      *fsnotify.Watcher
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	onChange func(version int64)
+
+	// roots maps each skill root to itself; missing roots are tracked through
+	// their parent directory and start being watched once created.
+	roots   map[string]bool
+	missing map[string]bool
 
 	// debounce state
 	mu      sync.Mutex
@@ -37,51 +49,94 @@ func NewWatcher(loader *Loader) (*Watcher, error) {
 		return nil, err
 	}
 	return &Watcher{
-		loader: loader,
-		fsw:    fsw,
+		loader:  loader,
+		fsw:     fsw,
+		roots:   make(map[string]bool),
+		missing: make(map[string]bool),
 	}, nil
 }
 
-// Start begins watching all skill directories for changes.
+// SetOnChange registers a callback run after each debounced version bump
+// (e.g. to broadcast a skills-updated event). Must be called before Start.
+func (w *Watcher) SetOnChange(fn func(version int64)) {
+	w.onChange = fn
+}
+
+// Start begins watching all skill directories for changes. Roots that do
+// not exist yet are picked up when they are created, provided their parent
+// directory exists.
 func (w *Watcher) Start(ctx context.Context) error {
 	dirs := w.loader.Dirs()
 	watched := 0
 
+	w.mu.Lock()
 	for _, dir := range dirs {
-		// Watch the skill root dir (detects new skill folders)
-		if err := w.fsw.Add(dir); err != nil {
-			// Directory may not exist yet — that's fine
-			if !os.IsNotExist(err) {
-				slog.Warn("skills watcher: cannot watch dir", "path", dir, "error", err)
+		dir = filepath.Clean(dir)
+		w.roots[dir] = true
+		if _, err := os.Stat(dir); err != nil {
+			// Directory may not exist yet — watch its parent for its creation.
+			if err := w.fsw.Add(filepath.Dir(dir)); err == nil {
+				w.missing[dir] = true
 			}
 			continue
 		}
-		watched++
-
-		// Watch each existing skill subdirectory (detects SKILL.md changes)
-		entries, err := os.ReadDir(dir)
-		if err != nil {
-			continue
-		}
-		for _, e := range entries {
-			if !e.IsDir() {
-				continue
-			}
-			subDir := filepath.Join(dir, e.Name())
-			if err := w.fsw.Add(subDir); err == nil {
-				watched++
-			}
-		}
+		watched += w.watchTree(dir, watchDepth)
 	}
+	w.mu.Unlock()
 
 	ctx, w.cancel = context.WithCancel(ctx)
 	w.wg.Add(1)
-	go w.loop(ctx)
+	go func() {
+		defer w.wg.Done()
+		safego.Supervise(ctx, "skills_watcher", w.loop)
+	}()
 
-	slog.Info("skills watcher started", "dirs", len(dirs), "watched", watched)
+	slog.Info("skills watcher started", "dirs", len(dirs), "watched", watched, "pending", len(w.missing))
 	return nil
 }
 
+// watchTree watches dir and its subdirectories down to depth levels,
+// skipping hidden directories (.git, staging dirs). Returns the number of
+// directories added.
+func (w *Watcher) watchTree(dir string, depth int) int {
+	if err := w.fsw.Add(dir); err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("skills watcher: cannot watch dir", "path", dir, "error", err)
+		}
+		return 0
+	}
+	added := 1
+	if depth == 0 {
+		return added
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return added
+	}
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			added += w.watchTree(filepath.Join(dir, e.Name()), depth-1)
+		}
+	}
+	return added
+}
+
+// depthBelowRoot returns how many levels path sits below its skill root, or
+// -1 when it is not inside a root.
+func (w *Watcher) depthBelowRoot(path string) int {
+	for d, dir := 0, path; d <= watchDepth+1; d++ {
+		if w.roots[dir] {
+			return d
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return -1
+}
+
 // Stop shuts down the watcher.
 func (w *Watcher) Stop() {
 	if w.cancel != nil {
@@ -98,8 +153,6 @@ func (w *Watcher) Stop() {
 }
 
 func (w *Watcher) loop(ctx context.Context) {
-	defer w.wg.Done()
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -121,27 +174,50 @@ func (w *Watcher) loop(ctx context.Context) {
 }
 
 func (w *Watcher) handleEvent(event fsnotify.Event) {
-	path := event.Name
+	path := filepath.Clean(event.Name)
 
-	// New directory created inside a skill root → start watching it
-	// (e.g. user creates ~/.goclaw/skills/new-skill/)
+	w.mu.Lock()
+	// A missing skill root was created → watch it and everything under it.
+	if w.missing[path] && event.Has(fsnotify.Create) {
+		delete(w.missing, path)
+		w.watchTree(path, watchDepth)
+		w.mu.Unlock()
+		slog.Debug("skills watcher: watching new root", "path", path)
+		w.scheduleBump()
+		return
+	}
+	// A skill root was deleted → wait for it to be recreated.
+	if w.roots[path] && (event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename)) {
+		if err := w.fsw.Add(filepath.Dir(path)); err == nil {
+			w.missing[path] = true
+		}
+	}
+	depth := w.depthBelowRoot(path)
+	if depth < 0 {
+		// Sibling activity in the parent of a missing root.
+		w.mu.Unlock()
+		return
+	}
+	// New directory inside a skill root → start watching it
+	// (e.g. user creates ~/.goclaw/skills/new-skill/ or a new managed version).
+	isDir := false
 	if event.Has(fsnotify.Create) {
 		if info, err := os.Stat(path); err == nil && info.IsDir() {
-			_ = w.fsw.Add(path)
-			slog.Debug("skills watcher: watching new dir", "path", path)
+			isDir = true
+			if depth <= watchDepth && !strings.HasPrefix(filepath.Base(path), ".") {
+				w.watchTree(path, watchDepth-depth)
+				slog.Debug("skills watcher: watching new dir", "path", path)
+			}
 		}
 	}
+	w.mu.Unlock()
 
-	// Only care about SKILL.md file events
-	base := filepath.Base(path)
-	if !strings.EqualFold(base, "SKILL.md") && !event.Has(fsnotify.Create) {
-		// Also trigger on directory delete (skill folder removed)
-		if !event.Has(fsnotify.Remove) && !event.Has(fsnotify.Rename) {
-			return
-		}
+	// Only SKILL.md changes and skill directories appearing or disappearing
+	// affect the skill set; other files (scripts, references) do not.
+	if strings.EqualFold(filepath.Base(path), "SKILL.md") || isDir ||
+		event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		w.scheduleBump()
 	}
-
-	w.scheduleBump()
 }
 
 // scheduleBump debounces version bumps.
@@ -169,5 +245,9 @@ func (w *Watcher) flush() {
 	w.mu.Unlock()
 
 	w.loader.BumpVersion()
-	slog.Info("skills changed, version bumped", "version", w.loader.Version())
+	version := w.loader.Version()
+	slog.Info("skills changed, version bumped", "version", version)
+	if w.onChange != nil {
+		w.onChange(version)
+	}
 }
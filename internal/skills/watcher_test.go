@@ -0,0 +1,98 @@
+package skills
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startWatcher starts a watcher on l and returns a channel of bumped versions.
+func startWatcher(t *testing.T, l *Loader) <-chan int64 {
+	t.Helper()
+	w, err := NewWatcher(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bumps := make(chan int64, 16)
+	w.SetOnChange(func(v int64) { bumps <- v })
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(w.Stop)
+	return bumps
+}
+
+// waitBump waits for a version bump, then drains any trailing ones so the
+// next step starts clean.
+func waitBump(t *testing.T, bumps <-chan int64, what string) {
+	t.Helper()
+	select {
+	case <-bumps:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no skills update after %s", what)
+	}
+	for {
+		select {
+		case <-bumps:
+		case <-time.After(2 * watchDebounce):
+			return
+		}
+	}
+}
+
+func TestWatcher_DetectsSkillChanges(t *testing.T) {
+	global := filepath.Join(t.TempDir(), "skills") // created after Start
+	managed := t.TempDir()
+	makeManagedVersion(t, managed, "report", 1, "")
+
+	l := NewLoader("", global, "")
+	l.SetManagedDir(managed)
+	bumps := startWatcher(t, l)
+	ctx := context.Background()
+
+	// Root created after startup, then a skill inside it.
+	makeSkillDir(t, global, "notes", "---\nname: notes\n---\n")
+	waitBump(t, bumps, "creating the global skills dir")
+	if _, ok := l.GetSkill(ctx, "notes"); !ok {
+		t.Fatal("notes skill not visible")
+	}
+
+	// New managed version (two levels below the root).
+	makeManagedVersion(t, managed, "report", 2, "description: v2\n")
+	waitBump(t, bumps, "adding a managed version")
+
+	// Editing SKILL.md.
+	if err := os.WriteFile(filepath.Join(global, "notes", "SKILL.md"), []byte("---\nname: notes\ndescription: edited\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitBump(t, bumps, "editing SKILL.md")
+	if info, _ := l.GetSkill(ctx, "notes"); info.Description != "edited" {
+		t.Errorf("description = %q", info.Description)
+	}
+
+	// Removing the skill drops it from the loader.
+	if err := os.RemoveAll(filepath.Join(global, "notes")); err != nil {
+		t.Fatal(err)
+	}
+	waitBump(t, bumps, "removing a skill")
+	if _, ok := l.GetSkill(ctx, "notes"); ok {
+		t.Error("removed skill still returned by GetSkill")
+	}
+}
+
+func TestWatcher_IgnoresSupportingFiles(t *testing.T) {
+	global := t.TempDir()
+	dir := makeSkillDir(t, global, "notes", "---\nname: notes\n---\n")
+	bumps := startWatcher(t, NewLoader("", global, ""))
+
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-bumps:
+		t.Error("writing a non-SKILL.md file should not bump the version")
+	case <-time.After(2 * watchDebounce):
+	}
+}
@@ -0,0 +1,412 @@
+// Package snapshots keeps the pre-run state of workspace files so an agent
+// run's file changes can be rolled back.
+//
+// Before each tool batch the agent loop captures the files that write_file
+// and edit are about to change. Contents are stored once by SHA-256; each run
+// has a manifest recording, per file, whether it existed and which content it
+// had when the run first touched it:
+//
+//	<dir>/objects/<sha256[:2]>/<sha256>   file contents (deduplicated)
+//	<dir>/runs/<run-id>.json              manifest (Run)
+//
+// Only regular files inside the run's workspace are captured. Changes made by
+// other means (shell commands, sandboxed absolute paths) are not tracked.
+package snapshots
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxFileSize is the largest file captured; bigger files are skipped.
+const MaxFileSize = 20 << 20
+
+// ErrRunNotFound is returned when no snapshot exists for a run ID.
+var ErrRunNotFound = errors.New("no snapshot for run")
+
+// RunInfo identifies the run being captured.
+type RunInfo struct {
+	RunID      string
+	Exec       string // unique per execution; recurring run IDs (cron, heartbeat) start over when it changes
+	AgentID    string
+	SessionKey string
+	Workspace  string // absolute workspace root; relative paths resolve against it
+	Shell      bool   // the batch runs shell commands, whose file changes are not captured
+}
+
+// FileState is a file's state before the run first changed it.
+type FileState struct {
+	Path    string      `json:"path"` // absolute
+	Existed bool        `json:"existed"`
+	Hash    string      `json:"hash,omitempty"` // SHA-256 of the prior content
+	Mode    os.FileMode `json:"mode,omitempty"`
+}
+
+// Run is the manifest of one run's snapshot.
+type Run struct {
+	RunID      string      `json:"run_id"`
+	Exec       string      `json:"exec,omitempty"`
+	AgentID    string      `json:"agent_id,omitempty"`
+	SessionKey string      `json:"session_key,omitempty"`
+	Workspace  string      `json:"workspace"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	Files      []FileState `json:"files"`
+	ShellUsed  bool        `json:"shell_used,omitempty"` // ran shell commands; files they changed are not in Files
+}
+
+// RollbackResult lists what a rollback changed (or would change, for a dry run).
+type RollbackResult struct {
+	Restored  []string `json:"restored,omitempty"`   // rewritten with their prior content
+	Removed   []string `json:"removed,omitempty"`    // created by the run, deleted
+	Unchanged []string `json:"unchanged,omitempty"`  // already in their prior state
+	ShellUsed bool     `json:"shell_used,omitempty"` // the run also ran shell commands, which rollback cannot undo
+}
+
+// Store is a snapshot directory. Safe for concurrent use.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewStore returns a store rooted at dir (created on first capture).
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store's root directory.
+func (s *Store) Dir() string { return s.dir }
+
+// Capture records the current state of paths for the run, unless the run
+// already captured them (the first capture is the pre-run state). Paths
+// outside info.Workspace, directories and oversized files are skipped.
+// info.Shell only flags the run: shell commands can touch any file, so
+// their changes are not captured.
+func (s *Store) Capture(info RunInfo, paths []string) error {
+	if info.RunID == "" || info.Workspace == "" || (len(paths) == 0 && !info.Shell) {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run, err := s.load(info.RunID)
+	if err != nil && !errors.Is(err, ErrRunNotFound) {
+		return err
+	}
+	now := time.Now().UTC()
+	if run == nil || run.Exec != info.Exec {
+		run = &Run{
+			RunID:      info.RunID,
+			Exec:       info.Exec,
+			AgentID:    info.AgentID,
+			SessionKey: info.SessionKey,
+			Workspace:  info.Workspace,
+			CreatedAt:  now,
+		}
+	}
+
+	added := false
+	if info.Shell && !run.ShellUsed {
+		run.ShellUsed = true
+		added = true
+	}
+	for _, p := range paths {
+		abs, ok := resolveInside(info.Workspace, p)
+		if !ok || slices.ContainsFunc(run.Files, func(f FileState) bool { return f.Path == abs }) {
+			continue
+		}
+		st, ok, err := s.capture(abs)
+		if err != nil {
+			return err
+		}
+		if ok {
+			run.Files = append(run.Files, st)
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	run.UpdatedAt = now
+	return s.save(run)
+}
+
+// capture stores abs's content and returns its state. ok is false for
+// files that cannot be restored (directories, symlinks, oversized).
+func (s *Store) capture(abs string) (st FileState, ok bool, err error) {
+	fi, err := os.Lstat(abs)
+	if errors.Is(err, os.ErrNotExist) {
+		return FileState{Path: abs}, true, nil
+	}
+	if err != nil {
+		return FileState{}, false, err
+	}
+	if !fi.Mode().IsRegular() || fi.Size() > MaxFileSize {
+		return FileState{}, false, nil
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return FileState{}, false, err
+	}
+	hash, err := s.putObject(data)
+	if err != nil {
+		return FileState{}, false, err
+	}
+	return FileState{Path: abs, Existed: true, Hash: hash, Mode: fi.Mode().Perm()}, true, nil
+}
+
+// Get returns the run's manifest.
+func (s *Store) Get(runID string) (*Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(runID)
+}
+
+// List returns all manifests, newest first.
+func (s *Store) List() ([]Run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := os.ReadDir(filepath.Join(s.dir, "runs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Run
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		run, err := readManifest(filepath.Join(s.dir, "runs", e.Name()))
+		if err != nil {
+			continue
+		}
+		runs = append(runs, *run)
+	}
+	slices.SortFunc(runs, func(a, b Run) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return runs, nil
+}
+
+// Rollback restores every file of the run to its pre-run state: prior content
+// is written back and files the run created are removed. With dryRun nothing
+// is changed. The manifest is kept, so a rollback can be repeated.
+func (s *Store) Rollback(runID string, dryRun bool) (RollbackResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run, err := s.load(runID)
+	if err != nil {
+		return RollbackResult{}, err
+	}
+
+	res := RollbackResult{ShellUsed: run.ShellUsed}
+	for _, f := range run.Files {
+		current, err := fileHash(f.Path)
+		if err != nil {
+			return res, err
+		}
+		switch {
+		case !f.Existed && current == "":
+			res.Unchanged = append(res.Unchanged, f.Path)
+		case !f.Existed:
+			if !dryRun {
+				if err := os.Remove(f.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return res, err
+				}
+			}
+			res.Removed = append(res.Removed, f.Path)
+		case current == f.Hash:
+			res.Unchanged = append(res.Unchanged, f.Path)
+		default:
+			if !dryRun {
+				if err := s.restore(f); err != nil {
+					return res, fmt.Errorf("restore %s: %w", f.Path, err)
+				}
+			}
+			res.Restored = append(res.Restored, f.Path)
+		}
+	}
+	return res, nil
+}
+
+func (s *Store) restore(f FileState) error {
+	data, err := os.ReadFile(s.objectPath(f.Hash))
+	if err != nil {
+		return err
+	}
+	mode := f.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+		return err
+	}
+	return writeAtomic(f.Path, data, mode)
+}
+
+// Prune deletes manifests last updated before cutoff and the contents no
+// remaining manifest references. Returns the number of runs removed.
+func (s *Store) Prune(cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runsDir := filepath.Join(s.dir, "runs")
+	entries, err := os.ReadDir(runsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	live := map[string]bool{}
+	for _, e := range entries {
+		path := filepath.Join(runsDir, e.Name())
+		run, err := readManifest(path)
+		if err != nil || run.UpdatedAt.Before(cutoff) {
+			if os.Remove(path) == nil {
+				removed++
+			}
+			continue
+		}
+		for _, f := range run.Files {
+			live[f.Hash] = true
+		}
+	}
+
+	objects := filepath.Join(s.dir, "objects")
+	err = filepath.WalkDir(objects, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if !live[d.Name()] {
+			os.Remove(path)
+		}
+		return nil
+	})
+	return removed, err
+}
+
+func (s *Store) load(runID string) (*Run, error) {
+	run, err := readManifest(s.manifestPath(runID))
+	if errors.Is(err, os.ErrNotExist) || (err == nil && run.RunID != runID) {
+		return nil, ErrRunNotFound
+	}
+	return run, err
+}
+
+func (s *Store) save(run *Run) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := s.manifestPath(run.RunID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return writeAtomic(path, data, 0600)
+}
+
+func (s *Store) putObject(data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	path := s.objectPath(hash)
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	return hash, writeAtomic(path, data, 0600)
+}
+
+func (s *Store) objectPath(hash string) string {
+	return filepath.Join(s.dir, "objects", hash[:2], hash)
+}
+
+// manifestPath maps a run ID to a file name; IDs like "cron:<id>" contain
+// characters that are replaced. The manifest stores the exact ID.
+func (s *Store) manifestPath(runID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, runID)
+	return filepath.Join(s.dir, "runs", strings.TrimLeft(name, ".")+".json")
+}
+
+func readManifest(path string) (*Run, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &run, nil
+}
+
+// resolveInside resolves p against workspace and reports whether the result
+// lies inside it.
+func resolveInside(workspace, p string) (string, bool) {
+	ws := filepath.Clean(workspace)
+	abs := p
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(ws, abs)
+	}
+	abs = filepath.Clean(abs)
+	rel, err := filepath.Rel(ws, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return abs, true
+}
+
+// fileHash returns the SHA-256 of a regular file, or "" if it does not exist.
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,122 @@
+package snapshots
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCaptureAndRollback(t *testing.T) {
+	ws := t.TempDir()
+	store := NewStore(t.TempDir())
+	existing := filepath.Join(ws, "notes.md")
+	os.WriteFile(existing, []byte("original"), 0600)
+
+	info := RunInfo{RunID: "cron:job-1", Exec: "a", AgentID: "coder", Workspace: ws}
+	if err := store.Capture(info, []string{"notes.md", "new/file.txt", "../outside.txt"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run changes both files; a later capture must not overwrite the
+	// pre-run state.
+	os.WriteFile(existing, []byte("changed"), 0600)
+	os.MkdirAll(filepath.Join(ws, "new"), 0755)
+	os.WriteFile(filepath.Join(ws, "new", "file.txt"), []byte("created"), 0644)
+	if err := store.Capture(info, []string{existing}); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := store.Get("cron:job-1")
+	if err != nil || len(run.Files) != 2 {
+		t.Fatalf("Get = %+v, %v; want 2 files", run, err)
+	}
+
+	res, err := store.Rollback("cron:job-1", true)
+	if err != nil || len(res.Restored) != 1 || len(res.Removed) != 1 {
+		t.Fatalf("dry run = %+v, %v", res, err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "changed" {
+		t.Fatal("dry run must not modify files")
+	}
+
+	if _, err := store.Rollback("cron:job-1", false); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "original" {
+		t.Errorf("notes.md = %q, want original", data)
+	}
+	if fi, _ := os.Stat(existing); fi.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", fi.Mode().Perm())
+	}
+	if _, err := os.Stat(filepath.Join(ws, "new", "file.txt")); !os.IsNotExist(err) {
+		t.Error("file created by the run should be removed")
+	}
+
+	res, _ = store.Rollback("cron:job-1", false)
+	if len(res.Unchanged) != 2 {
+		t.Errorf("second rollback = %+v, want all unchanged", res)
+	}
+}
+
+func TestCaptureNewExecStartsOver(t *testing.T) {
+	ws := t.TempDir()
+	store := NewStore(t.TempDir())
+	path := filepath.Join(ws, "a.txt")
+
+	os.WriteFile(path, []byte("v1"), 0644)
+	store.Capture(RunInfo{RunID: "heartbeat:coder", Exec: "1", Workspace: ws}, []string{path})
+	os.WriteFile(path, []byte("v2"), 0644)
+	store.Capture(RunInfo{RunID: "heartbeat:coder", Exec: "2", Workspace: ws}, []string{path})
+	os.WriteFile(path, []byte("v3"), 0644)
+
+	store.Rollback("heartbeat:coder", false)
+	if data, _ := os.ReadFile(path); string(data) != "v2" {
+		t.Errorf("a.txt = %q, want state before the latest execution (v2)", data)
+	}
+}
+
+func TestGetUnknownRun(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if _, err := store.Get("missing"); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Get = %v, want ErrRunNotFound", err)
+	}
+	// Sanitized names must not alias a different run ID.
+	ws := t.TempDir()
+	os.WriteFile(filepath.Join(ws, "x"), nil, 0644)
+	store.Capture(RunInfo{RunID: "a:b", Workspace: ws}, []string{"x"})
+	if _, err := store.Get("a_b"); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("Get(a_b) = %v, want ErrRunNotFound", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ws := t.TempDir()
+	store := NewStore(t.TempDir())
+	os.WriteFile(filepath.Join(ws, "a"), []byte("A"), 0644)
+	os.WriteFile(filepath.Join(ws, "b"), []byte("B"), 0644)
+	store.Capture(RunInfo{RunID: "old", Workspace: ws}, []string{"a"})
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now()
+	store.Capture(RunInfo{RunID: "new", Workspace: ws}, []string{"b"})
+
+	n, err := store.Prune(cutoff)
+	if err != nil || n != 1 {
+		t.Fatalf("Prune = %d, %v; want 1", n, err)
+	}
+	runs, _ := store.List()
+	if len(runs) != 1 || runs[0].RunID != "new" {
+		t.Errorf("List = %+v, want only new", runs)
+	}
+	var objects int
+	filepath.WalkDir(filepath.Join(store.Dir(), "objects"), func(_ string, d os.DirEntry, _ error) error {
+		if d != nil && !d.IsDir() {
+			objects++
+		}
+		return nil
+	})
+	if objects != 1 {
+		t.Errorf("objects after prune = %d, want 1", objects)
+	}
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+)
+
+// AccessibilityKeyPrefix namespaces per-user accessibility preferences inside
+// the tenant-scoped system_configs table (value = JSON bus.AccessibilityPrefs).
+const AccessibilityKeyPrefix = "accessibility.user."
+
+// AccessibilityConfigKey returns the system config key holding userID's preferences.
+func AccessibilityConfigKey(userID string) string {
+	return AccessibilityKeyPrefix + userID
+}
+
+// IsAccessibilityConfigKey reports whether key stores per-user accessibility preferences.
+func IsAccessibilityConfigKey(key string) bool {
+	return strings.HasPrefix(key, AccessibilityKeyPrefix)
+}
+
+// LoadAccessibilityPrefs reads userID's preferences for the tenant in ctx.
+// Returns (nil, nil) when nothing is stored or the stored preferences are all defaults.
+func LoadAccessibilityPrefs(ctx context.Context, s SystemConfigStore, userID string) (*bus.AccessibilityPrefs, error) {
+	if s == nil || userID == "" {
+		return nil, nil
+	}
+	raw, err := s.Get(ctx, AccessibilityConfigKey(userID))
+	if err != nil || raw == "" {
+		// Get reports a missing key as an error; absence means defaults.
+		return nil, nil
+	}
+	var prefs bus.AccessibilityPrefs
+	if err := json.Unmarshal([]byte(raw), &prefs); err != nil {
+		return nil, fmt.Errorf("decode accessibility prefs for %s: %w", userID, err)
+	}
+	if prefs.IsZero() {
+		return nil, nil
+	}
+	return &prefs, nil
+}
@@ -28,8 +28,12 @@ type ActivityListOpts struct {
 	Action     string
 	EntityType string
 	EntityID   string
-	Limit      int
-	Offset     int
+	// ActionPrefix matches actions starting with the prefix (e.g. "tool." or "agent.").
+	ActionPrefix string
+	Since        *time.Time // created_at >= Since
+	Until        *time.Time // created_at < Until
+	Limit        int
+	Offset       int
 }
 
 // ActivityStore manages activity audit logs.
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// CustomCommand is an agent-defined slash command, stored in
+// other_config.commands. Invoking it sends Prompt to the agent as the user's
+// message, with "{args}" replaced by the command arguments.
+type CustomCommand struct {
+	Name        string `json:"name"` // without the leading "/"
+	Description string `json:"description,omitempty"`
+	Usage       string `json:"usage,omitempty"` // argument synopsis shown in /help, e.g. "<topic>"
+	Prompt      string `json:"prompt"`
+	MinArgs     int    `json:"min_args,omitempty"`
+	MaxArgs     int    `json:"max_args,omitempty"` // 0 = unlimited
+}
+
+var customCommandNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// ParseCustomCommands returns the agent's custom slash commands from
+// OtherConfig JSONB. Entries without a valid name or a prompt are dropped.
+func (a *AgentData) ParseCustomCommands() []CustomCommand {
+	if len(a.OtherConfig) == 0 {
+		return nil
+	}
+	var bag struct {
+		Commands []CustomCommand `json:"commands"`
+	}
+	if json.Unmarshal(a.OtherConfig, &bag) != nil {
+		return nil
+	}
+	var out []CustomCommand
+	for _, c := range bag.Commands {
+		if customCommandNameRe.MatchString(c.Name) && c.Prompt != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package store
+
+import "encoding/json"
+
+// CostConfirmConfig asks the user to confirm runs predicted to be expensive
+// before they start, stored in other_config.cost_confirm. Channels overrides
+// the default threshold per channel instance name or channel type; an
+// override with no limits set disables confirmation on that channel.
+type CostConfirmConfig struct {
+	CostThreshold
+	Channels map[string]CostThreshold `json:"channels,omitempty"`
+}
+
+// CostThreshold triggers confirmation when either limit is exceeded.
+// Zero means no limit.
+type CostThreshold struct {
+	MaxTokens  int     `json:"max_tokens,omitempty"`   // estimated input tokens for the first LLM call
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty"` // estimated USD cost; needs telemetry.model_pricing
+}
+
+// Enabled reports whether the threshold sets any limit.
+func (t CostThreshold) Enabled() bool {
+	return t.MaxTokens > 0 || t.MaxCostUSD > 0
+}
+
+// Exceeded reports whether an estimate is over either limit.
+func (t CostThreshold) Exceeded(tokens int, costUSD float64) bool {
+	return (t.MaxTokens > 0 && tokens > t.MaxTokens) ||
+		(t.MaxCostUSD > 0 && costUSD > t.MaxCostUSD)
+}
+
+// ThresholdFor returns the threshold for a channel: the override for the
+// channel instance name, then the channel type, then the default.
+func (c *CostConfirmConfig) ThresholdFor(channel, channelType string) CostThreshold {
+	if t, ok := c.Channels[channel]; ok {
+		return t
+	}
+	if t, ok := c.Channels[channelType]; ok {
+		return t
+	}
+	return c.CostThreshold
+}
+
+// ParseCostConfirmConfig returns the agent's cost confirmation config from
+// OtherConfig JSONB, or nil when none is set.
+func (a *AgentData) ParseCostConfirmConfig() *CostConfirmConfig {
+	if len(a.OtherConfig) == 0 {
+		return nil
+	}
+	var bag struct {
+		CostConfirm *CostConfirmConfig `json:"cost_confirm"`
+	}
+	if json.Unmarshal(a.OtherConfig, &bag) != nil || bag.CostConfirm == nil {
+		return nil
+	}
+	return bag.CostConfirm
+}
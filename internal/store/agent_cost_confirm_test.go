@@ -0,0 +1,34 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCostConfirmConfig(t *testing.T) {
+	ag := &AgentData{OtherConfig: json.RawMessage(`{"cost_confirm":{"max_tokens":100000,"max_cost_usd":0.5,"channels":{"telegram":{"max_tokens":20000},"ops-slack":{}}}}`)}
+	cfg := ag.ParseCostConfirmConfig()
+	if cfg == nil {
+		t.Fatal("ParseCostConfirmConfig() = nil")
+	}
+
+	if th := cfg.ThresholdFor("my-telegram", "telegram"); th.MaxTokens != 20000 || th.MaxCostUSD != 0 {
+		t.Errorf("channel type override = %+v", th)
+	}
+	if th := cfg.ThresholdFor("ops-slack", "slack"); th.Enabled() {
+		t.Errorf("empty override should disable confirmation, got %+v", th)
+	}
+	th := cfg.ThresholdFor("discord", "discord")
+	if th.MaxTokens != 100000 {
+		t.Errorf("default threshold = %+v", th)
+	}
+	if th.Exceeded(50000, 0.1) || !th.Exceeded(150000, 0) || !th.Exceeded(1000, 0.75) {
+		t.Error("Exceeded mismatch")
+	}
+
+	for _, raw := range []string{``, `{}`, `{"cost_confirm":null}`} {
+		if cfg := (&AgentData{OtherConfig: json.RawMessage(raw)}).ParseCostConfirmConfig(); cfg != nil {
+			t.Errorf("%q: expected nil, got %+v", raw, cfg)
+		}
+	}
+}
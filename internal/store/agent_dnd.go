@@ -0,0 +1,139 @@
+package store
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNDConfig is an agent's do-not-disturb schedule, stored in
+// other_config.do_not_disturb. While a window is active, proactive deliveries
+// (cron results, heartbeat alerts) are queued until it ends; interactive chats
+// are unaffected.
+type DNDConfig struct {
+	Windows  []DNDWindow `json:"windows"`
+	Timezone string      `json:"timezone,omitempty"` // IANA name; empty = UTC
+	// UrgentChannels lists channel instance names whose deliveries bypass DND
+	// (e.g. an on-call alerts chat).
+	UrgentChannels []string `json:"urgent_channels,omitempty"`
+}
+
+// DNDWindow is a daily quiet period. End may be earlier than Start to wrap
+// midnight ("22:00"-"07:00"); "00:00"-"24:00" is the whole day. Days, when
+// set, restricts the window to the weekdays it starts on ("sat", "sun", ...).
+type DNDWindow struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+}
+
+// ParseDNDConfig returns the agent's do-not-disturb config from OtherConfig
+// JSONB, or nil when none is set or it has no valid window.
+func (a *AgentData) ParseDNDConfig() *DNDConfig {
+	if len(a.OtherConfig) == 0 {
+		return nil
+	}
+	var bag struct {
+		DND *DNDConfig `json:"do_not_disturb"`
+	}
+	if json.Unmarshal(a.OtherConfig, &bag) != nil || bag.DND == nil {
+		return nil
+	}
+	if !slices.ContainsFunc(bag.DND.Windows, func(w DNDWindow) bool { return w.valid() }) {
+		return nil
+	}
+	return bag.DND
+}
+
+// IsUrgentChannel reports whether deliveries to channel bypass DND.
+func (c *DNDConfig) IsUrgentChannel(channel string) bool {
+	return slices.Contains(c.UrgentChannels, channel)
+}
+
+// ActiveUntil reports whether now falls in a DND window and, if so, when the
+// quiet period ends (back-to-back windows are merged).
+func (c *DNDConfig) ActiveUntil(now time.Time) (time.Time, bool) {
+	loc := time.UTC
+	if c.Timezone != "" {
+		if l, err := time.LoadLocation(c.Timezone); err == nil {
+			loc = l
+		}
+	}
+	end, ok := c.windowEnd(now.In(loc))
+	if !ok {
+		return time.Time{}, false
+	}
+	// Follow chained windows (e.g. a weekday night window running into an
+	// all-day weekend window); bounded so a 24/7 config still terminates.
+	for range 8 {
+		next, ok := c.windowEnd(end)
+		if !ok || !next.After(end) {
+			break
+		}
+		end = next
+	}
+	return end, true
+}
+
+// windowEnd returns the latest end among windows covering t.
+func (c *DNDConfig) windowEnd(t time.Time) (time.Time, bool) {
+	var end time.Time
+	found := false
+	for _, w := range c.Windows {
+		if !w.valid() {
+			continue
+		}
+		start, _ := parseDNDClock(w.Start)
+		stop, _ := parseDNDClock(w.End)
+		length := stop - start
+		if length <= 0 {
+			length += 24 * 60
+		}
+		// A window covering t started today or (wrapping midnight) yesterday.
+		for _, offset := range []int{-1, 0} {
+			day := t.AddDate(0, 0, offset)
+			if !w.onDay(day.Weekday()) {
+				continue
+			}
+			from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location()).Add(time.Duration(start) * time.Minute)
+			to := from.Add(time.Duration(length) * time.Minute)
+			if !t.Before(from) && t.Before(to) && to.After(end) {
+				end, found = to, true
+			}
+		}
+	}
+	return end, found
+}
+
+func (w DNDWindow) valid() bool {
+	start, ok1 := parseDNDClock(w.Start)
+	end, ok2 := parseDNDClock(w.End)
+	return ok1 && ok2 && start != end && start < 24*60
+}
+
+func (w DNDWindow) onDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	name := strings.ToLower(d.String()[:3])
+	return slices.ContainsFunc(w.Days, func(s string) bool {
+		s = strings.ToLower(strings.TrimSpace(s))
+		return len(s) >= 3 && s[:3] == name
+	})
+}
+
+// parseDNDClock parses "HH:MM" (00:00-24:00) into minutes since midnight.
+func parseDNDClock(s string) (int, bool) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, false
+	}
+	hh, err1 := strconv.Atoi(h)
+	mm, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || hh < 0 || mm < 0 || mm > 59 || hh > 24 || (hh == 24 && mm != 0) {
+		return 0, false
+	}
+	return hh*60 + mm, true
+}
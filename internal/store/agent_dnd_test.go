@@ -0,0 +1,66 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDNDConfig(t *testing.T) {
+	ag := &AgentData{OtherConfig: json.RawMessage(`{"do_not_disturb":{"windows":[{"start":"22:00","end":"07:00"}],"urgent_channels":["alerts"]}}`)}
+	cfg := ag.ParseDNDConfig()
+	if cfg == nil || len(cfg.Windows) != 1 {
+		t.Fatalf("ParseDNDConfig() = %+v", cfg)
+	}
+	if !cfg.IsUrgentChannel("alerts") || cfg.IsUrgentChannel("telegram") {
+		t.Error("urgent channel mismatch")
+	}
+
+	for _, raw := range []string{``, `{}`, `{"do_not_disturb":{"windows":[]}}`, `{"do_not_disturb":{"windows":[{"start":"25:00","end":"07:00"}]}}`, `{"do_not_disturb":{"windows":[{"start":"09:00","end":"09:00"}]}}`} {
+		if cfg := (&AgentData{OtherConfig: json.RawMessage(raw)}).ParseDNDConfig(); cfg != nil {
+			t.Errorf("%q: expected nil, got %+v", raw, cfg)
+		}
+	}
+}
+
+func TestDNDConfig_ActiveUntil(t *testing.T) {
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC) }
+	night := DNDWindow{Start: "22:00", End: "07:00"}
+	weekend := DNDWindow{Start: "00:00", End: "24:00", Days: []string{"sat", "sunday"}}
+
+	cases := []struct {
+		name    string
+		cfg     DNDConfig
+		now     time.Time
+		want    time.Time
+		wantDND bool
+	}{
+		{"before night window", DNDConfig{Windows: []DNDWindow{night}}, at(14, 21, 59), time.Time{}, false},
+		{"night window evening", DNDConfig{Windows: []DNDWindow{night}}, at(14, 23, 0), at(15, 7, 0), true},
+		{"night window after midnight", DNDConfig{Windows: []DNDWindow{night}}, at(15, 3, 0), at(15, 7, 0), true},
+		{"night window ended", DNDConfig{Windows: []DNDWindow{night}}, at(15, 7, 0), time.Time{}, false},
+		{"weekend only on weekdays", DNDConfig{Windows: []DNDWindow{weekend}}, at(16, 12, 0), time.Time{}, false}, // Friday
+		{"weekend saturday", DNDConfig{Windows: []DNDWindow{weekend}}, at(17, 12, 0), at(19, 0, 0), true},
+		// Friday night runs into the weekend, which runs into Sunday night.
+		{"chained windows", DNDConfig{Windows: []DNDWindow{night, weekend}}, at(16, 23, 0), at(19, 7, 0), true},
+	}
+	for _, tc := range cases {
+		got, ok := tc.cfg.ActiveUntil(tc.now)
+		if ok != tc.wantDND || !got.Equal(tc.want) {
+			t.Errorf("%s: ActiveUntil = (%s, %v), want (%s, %v)", tc.name, got, ok, tc.want, tc.wantDND)
+		}
+	}
+}
+
+func TestDNDConfig_Timezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	cfg := DNDConfig{Windows: []DNDWindow{{Start: "22:00", End: "07:00"}}, Timezone: "Asia/Tokyo"}
+	// 14:00 UTC = 23:00 in Tokyo.
+	got, ok := cfg.ActiveUntil(time.Date(2026, 10, 14, 14, 0, 0, 0, time.UTC))
+	if want := time.Date(2026, 10, 15, 7, 0, 0, 0, tokyo); !ok || !got.Equal(want) {
+		t.Errorf("ActiveUntil = (%s, %v), want %s", got, ok, want)
+	}
+}
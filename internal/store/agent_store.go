@@ -281,6 +281,37 @@ func (a *AgentData) ParsePromptMode() string {
 	return mode
 }
 
+// SamplingConfig controls LLM sampling for reproducible runs (eval harnesses).
+// Stored in other_config.sampling; all fields optional.
+type SamplingConfig struct {
+	Temperature *float64 `json:"temperature,omitempty"` // nil = provider default (0.7)
+	Seed        *int64   `json:"seed,omitempty"`        // forwarded where the provider supports it
+	// Reproducible forces temperature 0 (unless set), strips latency/cache
+	// tier options, requires a dated model version, and records full
+	// untruncated inputs on LLM spans.
+	Reproducible bool `json:"reproducible,omitempty"`
+}
+
+// ParseSamplingConfig returns the sampling config from OtherConfig JSONB.
+// Returns a zero value if not set or malformed.
+func (a *AgentData) ParseSamplingConfig() SamplingConfig {
+	var cfg SamplingConfig
+	if len(a.OtherConfig) == 0 {
+		return cfg
+	}
+	var bag struct {
+		Sampling *SamplingConfig `json:"sampling"`
+	}
+	if json.Unmarshal(a.OtherConfig, &bag) != nil || bag.Sampling == nil {
+		return cfg
+	}
+	cfg = *bag.Sampling
+	if cfg.Temperature != nil && (*cfg.Temperature < 0 || *cfg.Temperature > 2) {
+		cfg.Temperature = nil // out of range for every provider → default
+	}
+	return cfg
+}
+
 // ParsePinnedSkills returns per-agent pinned skill names from OtherConfig JSONB.
 // Max 10 enforced. Returns nil if not set.
 func (a *AgentData) ParsePinnedSkills() []string {
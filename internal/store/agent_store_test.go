@@ -439,3 +439,29 @@ func TestParseAllowImageGeneration_UnrelatedKeys_DefaultsTrue(t *testing.T) {
 		t.Error("other_config without allow_image_generation key must default to true")
 	}
 }
+
+func TestParseSamplingConfig(t *testing.T) {
+	agent := &AgentData{OtherConfig: json.RawMessage(`{"sampling":{"temperature":0.2,"seed":42,"reproducible":true}}`)}
+
+	got := agent.ParseSamplingConfig()
+	if got.Temperature == nil || *got.Temperature != 0.2 {
+		t.Fatalf("Temperature = %v, want 0.2", got.Temperature)
+	}
+	if got.Seed == nil || *got.Seed != 42 {
+		t.Fatalf("Seed = %v, want 42", got.Seed)
+	}
+	if !got.Reproducible {
+		t.Fatal("Reproducible = false, want true")
+	}
+}
+
+func TestParseSamplingConfigRejectsOutOfRangeTemperature(t *testing.T) {
+	agent := &AgentData{OtherConfig: json.RawMessage(`{"sampling":{"temperature":5}}`)}
+
+	if got := agent.ParseSamplingConfig(); got.Temperature != nil {
+		t.Fatalf("Temperature = %v, want nil", *got.Temperature)
+	}
+	if got := (&AgentData{}).ParseSamplingConfig(); got.Reproducible || got.Seed != nil {
+		t.Fatalf("empty other_config = %+v, want zero value", got)
+	}
+}
@@ -48,3 +48,35 @@ type APIKeyStore interface {
 	// TouchLastUsed updates the last_used_at timestamp.
 	TouchLastUsed(ctx context.Context, id uuid.UUID) error
 }
+
+// RotatedAPIKey builds the successor of old for key rotation: same name,
+// scopes, tenant and owner, with the given fresh hash/prefix. expiresIn
+// (seconds) sets the new expiry; nil carries over the old key's lifetime
+// (created → expires), and a non-positive value means never.
+func RotatedAPIKey(old *APIKeyData, hash, prefix string, expiresIn *int, createdBy string, now time.Time) *APIKeyData {
+	key := &APIKeyData{
+		ID:        GenNewID(),
+		TenantID:  old.TenantID,
+		Name:      old.Name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    old.Scopes,
+		OwnerID:   old.OwnerID,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	switch {
+	case expiresIn != nil:
+		if *expiresIn > 0 {
+			exp := now.Add(time.Duration(*expiresIn) * time.Second)
+			key.ExpiresAt = &exp
+		}
+	case old.ExpiresAt != nil:
+		if lifetime := old.ExpiresAt.Sub(old.CreatedAt); lifetime > 0 {
+			exp := now.Add(lifetime)
+			key.ExpiresAt = &exp
+		}
+	}
+	return key
+}
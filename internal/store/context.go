@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
 )
 
 type contextKey string
@@ -50,6 +52,8 @@ const (
 	SenderNameKey contextKey = "goclaw_sender_name"
 	// AgentAudioKey carries the immutable agent audio snapshot for TTS tool dispatch.
 	AgentAudioKey contextKey = "goclaw_agent_audio"
+	// AccessibilityKey carries the recipient's accessibility preferences for outbound delivery.
+	AccessibilityKey contextKey = "goclaw_accessibility"
 )
 
 // AgentAudioSnapshot is an immutable snapshot of agent audio config carried through
@@ -77,6 +81,17 @@ func AgentAudioFromCtx(ctx context.Context) (AgentAudioSnapshot, bool) {
 	return snap, true
 }
 
+// WithAccessibility returns a new context with the recipient's accessibility preferences.
+func WithAccessibility(ctx context.Context, prefs bus.AccessibilityPrefs) context.Context {
+	return context.WithValue(ctx, AccessibilityKey, prefs)
+}
+
+// AccessibilityFromCtx extracts accessibility preferences from context.
+func AccessibilityFromCtx(ctx context.Context) (bus.AccessibilityPrefs, bool) {
+	prefs, ok := ctx.Value(AccessibilityKey).(bus.AccessibilityPrefs)
+	return prefs, ok
+}
+
 // WithShellDenyGroups returns a new context with shell deny group overrides.
 func WithShellDenyGroups(ctx context.Context, groups map[string]bool) context.Context {
 	return context.WithValue(ctx, ShellDenyGroupsKey, groups)
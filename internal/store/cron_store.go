@@ -126,6 +126,7 @@ type CronStore interface {
 	SetOnEvent(handler func(event CronEvent))
 	RunJob(ctx context.Context, jobID string, force bool) (ran bool, reason string, err error)
 	SetDefaultTimezone(tz string)
+	SetMaxConcurrentRuns(n int) // cap on in-flight scheduled runs; 0 = unlimited
 
 	// Due job detection (for scheduler)
 	GetDueJobs(now time.Time) []CronJob
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// MaxMemoryEmbeddingDimensions is the largest vector size pgvector can build
+// an HNSW index for.
+const MaxMemoryEmbeddingDimensions = 2000
+
+// EmbeddingFingerprintKey is the system config key recording which model
+// produced the stored vectors (JSON-encoded EmbeddingFingerprint).
+const EmbeddingFingerprintKey = "embedding.fingerprint"
+
+var memoryEmbeddingDims atomic.Int64
+
+// MemoryEmbeddingDimensions returns the live size of the pgvector columns.
+// Defaults to RequiredMemoryEmbeddingDimensions until the gateway reads the
+// schema at startup.
+func MemoryEmbeddingDimensions() int {
+	if n := memoryEmbeddingDims.Load(); n > 0 {
+		return int(n)
+	}
+	return RequiredMemoryEmbeddingDimensions
+}
+
+// SetMemoryEmbeddingDimensions records the live pgvector column size.
+func SetMemoryEmbeddingDimensions(n int) {
+	memoryEmbeddingDims.Store(int64(n))
+}
+
+// EmbeddingFingerprint identifies the model behind a set of vectors. Vectors
+// with different fingerprints live in different spaces and must not be
+// compared, even when zero-padding gives them the same length.
+type EmbeddingFingerprint struct {
+	Provider   string `json:"provider"`
+	Model      string `json:"model"`
+	Dimensions int    `json:"dimensions"` // native model output, before padding
+}
+
+func (f EmbeddingFingerprint) String() string {
+	return fmt.Sprintf("%s/%s (%d dims)", f.Provider, f.Model, f.Dimensions)
+}
+
+// ReembedResult reports what a re-embed run changed.
+type ReembedResult struct {
+	Dimensions int            `json:"dimensions"`        // vector column size after the run
+	Resized    bool           `json:"resized,omitempty"` // columns were migrated to a new size
+	Updated    map[string]int `json:"updated"`           // rows re-embedded per table
+}
+
+// EmbeddingMigrator regenerates stored vectors after the embedding provider
+// changes. Implemented by the PostgreSQL store only.
+type EmbeddingMigrator interface {
+	// VectorDimensions returns the current size of the vector columns.
+	VectorDimensions(ctx context.Context) (int, error)
+	// ReembedRequired explains why stored vectors do not match the current
+	// provider, or returns "" when they do.
+	ReembedRequired() string
+	// ReembedAll re-generates every stored embedding with the current provider
+	// across all tenants. When dims > 0 and differs from the column size, the
+	// vector columns are first migrated to vector(dims).
+	ReembedAll(ctx context.Context, dims int) (*ReembedResult, error)
+}
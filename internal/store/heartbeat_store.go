@@ -86,6 +86,85 @@ func StaggerOffset(agentID uuid.UUID, intervalSec int) time.Duration {
 	return time.Duration(offset) * time.Second
 }
 
+// Heartbeat run log statuses.
+const (
+	HeartbeatStatusOK         = "ok"         // alert delivered (or nothing configured to deliver to)
+	HeartbeatStatusSuppressed = "suppressed" // agent replied HEARTBEAT_OK
+	HeartbeatStatusSnoozed    = "snoozed"    // alert found but withheld while snoozed
+	HeartbeatStatusSkipped    = "skipped"    // not run; see SkipReason
+	HeartbeatStatusError      = "error"
+)
+
+// HeartbeatOutcome maps a run log status to the user-facing outcome:
+// "ok" (nothing to report), "alert", "snoozed", "skipped" or "error".
+func HeartbeatOutcome(status string) string {
+	switch status {
+	case HeartbeatStatusSuppressed:
+		return "ok"
+	case HeartbeatStatusOK:
+		return "alert"
+	default:
+		return status
+	}
+}
+
+// HeartbeatHistoryEntry is a run log annotated with its outcome.
+type HeartbeatHistoryEntry struct {
+	HeartbeatRunLog
+	Outcome string `json:"outcome"`
+}
+
+// HeartbeatHistory annotates run logs with their outcomes.
+func HeartbeatHistory(logs []HeartbeatRunLog) []HeartbeatHistoryEntry {
+	out := make([]HeartbeatHistoryEntry, len(logs))
+	for i, l := range logs {
+		out[i] = HeartbeatHistoryEntry{HeartbeatRunLog: l, Outcome: HeartbeatOutcome(l.Status)}
+	}
+	return out
+}
+
+const heartbeatSnoozeKey = "snoozed_until"
+
+// MaxHeartbeatSnoozeHours caps a single snooze.
+const MaxHeartbeatSnoozeHours = 720
+
+// SnoozedUntil returns the end of the alert snooze stored in Metadata, or nil.
+func (hb *AgentHeartbeat) SnoozedUntil() *time.Time {
+	if len(hb.Metadata) == 0 {
+		return nil
+	}
+	var meta map[string]any
+	if json.Unmarshal(hb.Metadata, &meta) != nil {
+		return nil
+	}
+	s, _ := meta[heartbeatSnoozeKey].(string)
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// IsSnoozed reports whether alerts are snoozed at now.
+func (hb *AgentHeartbeat) IsSnoozed(now time.Time) bool {
+	until := hb.SnoozedUntil()
+	return until != nil && now.Before(*until)
+}
+
+// SetSnoozedUntil stores (or, with nil, clears) the alert snooze in Metadata.
+func (hb *AgentHeartbeat) SetSnoozedUntil(until *time.Time) {
+	meta := map[string]any{}
+	if len(hb.Metadata) > 0 {
+		_ = json.Unmarshal(hb.Metadata, &meta)
+	}
+	if until == nil {
+		delete(meta, heartbeatSnoozeKey)
+	} else {
+		meta[heartbeatSnoozeKey] = until.UTC().Format(time.RFC3339)
+	}
+	hb.Metadata, _ = json.Marshal(meta)
+}
+
 // HeartbeatEvent represents a heartbeat lifecycle event sent to subscribers.
 type HeartbeatEvent struct {
 	Action   string `json:"action" db:"-"` // "running", "completed", "suppressed", "error", "skipped"
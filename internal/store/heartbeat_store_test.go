@@ -0,0 +1,45 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAgentHeartbeat_Snooze(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	hb := &AgentHeartbeat{Metadata: json.RawMessage(`{"owner":"ops"}`)}
+	if hb.IsSnoozed(now) {
+		t.Fatal("fresh heartbeat should not be snoozed")
+	}
+
+	until := now.Add(3 * time.Hour)
+	hb.SetSnoozedUntil(&until)
+	if !hb.IsSnoozed(now) || hb.IsSnoozed(until.Add(time.Second)) {
+		t.Errorf("snoozed window mismatch, metadata = %s", hb.Metadata)
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(hb.Metadata, &meta); err != nil || meta["owner"] != "ops" {
+		t.Errorf("other metadata keys lost: %s", hb.Metadata)
+	}
+
+	hb.SetSnoozedUntil(nil)
+	if hb.SnoozedUntil() != nil {
+		t.Errorf("snooze not cleared: %s", hb.Metadata)
+	}
+}
+
+func TestHeartbeatOutcome(t *testing.T) {
+	tests := map[string]string{
+		HeartbeatStatusOK:         "alert",
+		HeartbeatStatusSuppressed: "ok",
+		HeartbeatStatusSnoozed:    "snoozed",
+		HeartbeatStatusSkipped:    "skipped",
+		HeartbeatStatusError:      "error",
+	}
+	for status, want := range tests {
+		if got := HeartbeatOutcome(status); got != want {
+			t.Errorf("HeartbeatOutcome(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
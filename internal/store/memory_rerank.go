@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// DefaultRerankTopK is the number of candidates reranked when
+// MemorySearchOptions.RerankTopK is unset.
+const DefaultRerankTopK = 20
+
+// Reranker scores memory search candidates against a query. Scores are
+// returned in input order; higher is more relevant.
+type Reranker interface {
+	Rerank(ctx context.Context, query string, docs []string) ([]float64, error)
+}
+
+// CandidateLimit returns how many filtered candidates a search should
+// collect before RerankResults: maxResults, raised to the rerank top-K
+// when a reranker is set.
+func (o MemorySearchOptions) CandidateLimit(maxResults int) int {
+	if o.Reranker == nil {
+		return maxResults
+	}
+	topK := o.RerankTopK
+	if topK <= 0 {
+		topK = DefaultRerankTopK
+	}
+	return max(maxResults, topK)
+}
+
+// RerankResults rescores candidates with opts.Reranker, orders them by the
+// new score and caps them at maxResults. Without a reranker, or when it
+// fails, the hybrid order is kept.
+func RerankResults(ctx context.Context, opts MemorySearchOptions, query string, results []MemorySearchResult, maxResults int) []MemorySearchResult {
+	if opts.Reranker != nil && len(results) > 1 {
+		docs := make([]string, len(results))
+		for i, r := range results {
+			docs[i] = r.Snippet
+		}
+		scores, err := opts.Reranker.Rerank(ctx, query, docs)
+		switch {
+		case err != nil:
+			slog.Warn("memory rerank failed, keeping hybrid order", "error", err)
+		case len(scores) != len(results):
+			slog.Warn("memory rerank returned wrong score count, keeping hybrid order", "want", len(results), "got", len(scores))
+		default:
+			reranked := make([]MemorySearchResult, len(results))
+			copy(reranked, results)
+			for i := range reranked {
+				reranked[i].Score = scores[i]
+			}
+			sort.SliceStable(reranked, func(i, j int) bool { return reranked[i].Score > reranked[j].Score })
+			results = reranked
+		}
+	}
+	if maxResults > 0 && len(results) > maxResults {
+		results = results[:maxResults]
+	}
+	return results
+}
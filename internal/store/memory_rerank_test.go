@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fixedReranker struct {
+	scores []float64
+	err    error
+}
+
+func (r fixedReranker) Rerank(context.Context, string, []string) ([]float64, error) {
+	return r.scores, r.err
+}
+
+func TestRerankResults(t *testing.T) {
+	results := []MemorySearchResult{
+		{Path: "a.md", Score: 1.2}, {Path: "b.md", Score: 1.2}, {Path: "c.md", Score: 1.0},
+	}
+
+	opts := MemorySearchOptions{Reranker: fixedReranker{scores: []float64{0.1, 0.8, 0.9}}}
+	got := RerankResults(context.Background(), opts, "q", results, 2)
+	if len(got) != 2 || got[0].Path != "c.md" || got[1].Path != "b.md" || got[0].Score != 0.9 {
+		t.Errorf("reranked = %+v", got)
+	}
+	if results[0].Path != "a.md" || results[0].Score != 1.2 {
+		t.Error("input slice must not be modified")
+	}
+
+	opts.Reranker = fixedReranker{err: errors.New("timeout")}
+	if got := RerankResults(context.Background(), opts, "q", results, 2); got[0].Path != "a.md" || len(got) != 2 {
+		t.Errorf("failed rerank should keep hybrid order, got %+v", got)
+	}
+}
+
+func TestCandidateLimit(t *testing.T) {
+	if n := (MemorySearchOptions{}).CandidateLimit(6); n != 6 {
+		t.Errorf("no reranker: %d, want 6", n)
+	}
+	if n := (MemorySearchOptions{Reranker: fixedReranker{}}).CandidateLimit(6); n != DefaultRerankTopK {
+		t.Errorf("default top-k: %d, want %d", n, DefaultRerankTopK)
+	}
+	if n := (MemorySearchOptions{Reranker: fixedReranker{}, RerankTopK: 3}).CandidateLimit(6); n != 6 {
+		t.Errorf("top-k below max results: %d, want 6", n)
+	}
+}
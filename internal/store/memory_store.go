@@ -30,6 +30,11 @@ type MemorySearchOptions struct {
 	PathPrefix   string
 	VectorWeight float64 // per-agent override (0 = use store default)
 	TextWeight   float64 // per-agent override (0 = use store default)
+
+	// Reranker, when set, rescores the top RerankTopK candidates after the
+	// hybrid merge (see RerankResults).
+	Reranker   Reranker
+	RerankTopK int // 0 = DefaultRerankTopK
 }
 
 // EmbeddingProvider generates vector embeddings for text.
@@ -60,6 +65,18 @@ type ChunkInfo struct {
 	HasEmbedding bool   `json:"has_embedding" db:"has_embedding"`
 }
 
+// MemoryStats summarizes an agent's memory index and embedding setup.
+type MemoryStats struct {
+	Documents         int    `json:"documents"`
+	Chunks            int    `json:"chunks"`
+	EmbeddedChunks    int    `json:"embedded_chunks"`
+	VectorSearch      bool   `json:"vector_search"`                // store supports vector similarity search
+	EmbeddingProvider string `json:"embedding_provider,omitempty"` // empty = no provider configured (FTS only)
+	EmbeddingModel    string `json:"embedding_model,omitempty"`
+	VectorDimensions  int    `json:"vector_dimensions,omitempty"` // pgvector column size
+	ReembedRequired   string `json:"reembed_required,omitempty"`  // why stored vectors don't match the current provider
+}
+
 // MemoryStore manages memory documents and search.
 type MemoryStore interface {
 	// Document CRUD
@@ -73,6 +90,7 @@ type MemoryStore interface {
 	ListAllDocuments(ctx context.Context, agentID string) ([]DocumentInfo, error)
 	GetDocumentDetail(ctx context.Context, agentID, userID, path string) (*DocumentDetail, error)
 	ListChunks(ctx context.Context, agentID, userID, path string) ([]ChunkInfo, error)
+	Stats(ctx context.Context, agentID string) (*MemoryStats, error)
 
 	// Search
 	Search(ctx context.Context, query string, agentID, userID string, opts MemorySearchOptions) ([]MemorySearchResult, error)
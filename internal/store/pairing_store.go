@@ -1,6 +1,10 @@
 package store
 
-import "context"
+import (
+	"context"
+	"regexp"
+	"strings"
+)
 
 // PairingRequest represents a pending pairing code.
 type PairingRequestData struct {
@@ -16,12 +20,13 @@ type PairingRequestData struct {
 
 // PairedDeviceData represents an approved pairing.
 type PairedDeviceData struct {
-	SenderID string            `json:"sender_id" db:"sender_id"`
-	Channel  string            `json:"channel" db:"channel"`
-	ChatID   string            `json:"chat_id" db:"chat_id"`
-	PairedAt int64             `json:"paired_at" db:"paired_at"`
-	PairedBy string            `json:"paired_by" db:"paired_by"`
-	Metadata map[string]string `json:"metadata,omitempty" db:"metadata"`
+	SenderID  string            `json:"sender_id" db:"sender_id"`
+	Channel   string            `json:"channel" db:"channel"`
+	ChatID    string            `json:"chat_id" db:"chat_id"`
+	PairedAt  int64             `json:"paired_at" db:"paired_at"`
+	PairedBy  string            `json:"paired_by" db:"paired_by"`
+	ExpiresAt int64             `json:"expires_at,omitempty" db:"expires_at"` // Unix ms; 0 = never expires
+	Metadata  map[string]string `json:"metadata,omitempty" db:"metadata"`
 }
 
 // PairingStore manages device pairing.
@@ -38,3 +43,22 @@ type PairingStore interface {
 	// Scoped by tenant_id and channel. Idempotent (safe to call multiple times).
 	MigrateGroupChatID(ctx context.Context, channel, oldChatID, newChatID string) error
 }
+
+// NormalizePairingCode upper-cases a user-typed pairing code and drops
+// spaces and dashes, so "abcd-efgh" matches the issued code ABCDEFGH.
+func NormalizePairingCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+var validPairingSenderIDRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._:@-]*$`)
+
+// IsValidPairingSenderID checks that a sender ID contains only safe characters.
+// Prevents log injection and bus event poisoning.
+func IsValidPairingSenderID(id string) bool {
+	return len(id) <= 128 && validPairingSenderIDRe.MatchString(id)
+}
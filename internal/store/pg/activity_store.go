@@ -104,6 +104,21 @@ func buildActivityWhere(ctx context.Context, opts store.ActivityListOpts) (strin
 		args = append(args, opts.Action)
 		idx++
 	}
+	if opts.ActionPrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("action LIKE $%d ESCAPE '\\'", idx))
+		args = append(args, strings.NewReplacer("%", "\\%", "_", "\\_").Replace(opts.ActionPrefix)+"%")
+		idx++
+	}
+	if opts.Since != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", idx))
+		args = append(args, *opts.Since)
+		idx++
+	}
+	if opts.Until != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", idx))
+		args = append(args, *opts.Until)
+		idx++
+	}
 	if opts.EntityType != "" {
 		conditions = append(conditions, fmt.Sprintf("entity_type = $%d", idx))
 		args = append(args, opts.EntityType)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/cron"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
@@ -33,6 +34,9 @@ type PGCronStore struct {
 
 	retryCfg  cron.RetryConfig
 	defaultTZ string // fallback IANA timezone for cron jobs without explicit TZ
+
+	maxConcurrent int // cap on in-flight scheduled runs; 0 = unlimited
+	activeRuns    int // in-flight scheduled runs
 }
 
 func NewPGCronStore(db *sql.DB) *PGCronStore {
@@ -46,6 +50,15 @@ func (s *PGCronStore) SetRetryConfig(cfg cron.RetryConfig) {
 	s.retryCfg = cfg
 }
 
+// SetMaxConcurrentRuns caps how many scheduled runs may be in flight at once.
+// Due jobs beyond the cap are left unclaimed and picked up on a later tick.
+// n <= 0 removes the cap.
+func (s *PGCronStore) SetMaxConcurrentRuns(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxConcurrent = max(n, 0)
+}
+
 // SetDefaultTimezone sets the fallback IANA timezone for cron expressions
 // when a job does not specify its own timezone.
 func (s *PGCronStore) SetDefaultTimezone(tz string) {
@@ -70,7 +83,8 @@ func (s *PGCronStore) Start() error {
 	s.stop = make(chan struct{})
 	s.running = true
 	s.recomputeStaleJobs()
-	go s.runLoop()
+	stop := s.stop
+	go safego.Supervise(s.baseCtx, "cron", func(context.Context) { s.runLoop(stop) })
 	slog.Info("pg cron service started")
 	return nil
 }
@@ -3,7 +3,6 @@ package pg
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"log/slog"
 	"time"
 
@@ -148,12 +147,12 @@ func (s *PGCronStore) recomputeStaleJobs() {
 	}
 }
 
-func (s *PGCronStore) runLoop() {
+func (s *PGCronStore) runLoop(stop chan struct{}) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-s.stop:
+		case <-stop:
 			return
 		case <-ticker.C:
 			s.safeCheckAndRunDueJobs()
@@ -164,11 +163,7 @@ func (s *PGCronStore) runLoop() {
 // safeCheckAndRunDueJobs wraps checkAndRunDueJobs with panic recovery
 // so a panic in any check/claim logic doesn't kill the runLoop goroutine.
 func (s *PGCronStore) safeCheckAndRunDueJobs() {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("cron: checkAndRunDueJobs panicked — runLoop continues", "panic", fmt.Sprint(r))
-		}
-	}()
+	defer safego.Recover(nil, "component", "cron")
 	s.checkAndRunDueJobs()
 }
 
@@ -180,15 +175,24 @@ func (s *PGCronStore) checkAndRunDueJobs() {
 
 	s.mu.Lock()
 	handler := s.onJob
+	slots := len(dueJobs)
+	if s.maxConcurrent > 0 {
+		slots = min(slots, s.maxConcurrent-s.activeRuns)
+	}
 	s.mu.Unlock()
 
-	if handler == nil {
+	if handler == nil || slots <= 0 {
 		return
 	}
 
+	// Claim no more jobs than there are free slots. Unclaimed jobs keep their
+	// next_run_at and are picked up once running jobs finish.
 	now := time.Now()
 	var claimedJobs []store.CronJob
 	for _, job := range dueJobs {
+		if len(claimedJobs) == slots {
+			break
+		}
 		if id, parseErr := uuid.Parse(job.ID); parseErr == nil && s.claimDueJob(id, now) {
 			claimedJobs = append(claimedJobs, job)
 		}
@@ -196,6 +200,9 @@ func (s *PGCronStore) checkAndRunDueJobs() {
 	if len(claimedJobs) == 0 {
 		return
 	}
+	s.mu.Lock()
+	s.activeRuns += len(claimedJobs)
+	s.mu.Unlock()
 
 	// Execute jobs in parallel without blocking the runLoop.
 	// Previously wg.Wait() blocked here — if any job hung (e.g. LLM timeout,
@@ -203,6 +210,7 @@ func (s *PGCronStore) checkAndRunDueJobs() {
 	// due jobs. Now each job runs independently; cache is invalidated per-job.
 	for _, job := range claimedJobs {
 		go func(job store.CronJob) {
+			defer s.releaseRun()
 			defer safego.Recover(nil, "component", "cron_job", "job_id", job.ID, "job_name", job.Name)
 			defer s.InvalidateCache()
 			s.executeOneJob(job, handler, true)
@@ -210,6 +218,13 @@ func (s *PGCronStore) checkAndRunDueJobs() {
 	}
 }
 
+// releaseRun frees a slot taken by checkAndRunDueJobs.
+func (s *PGCronStore) releaseRun() {
+	s.mu.Lock()
+	s.activeRuns--
+	s.mu.Unlock()
+}
+
 // executeOneJob runs a single cron job with retry, logs the result, and updates next_run_at.
 // executeOneJob runs a claimed job. When reloadClaimed is true (scheduler path),
 // it re-reads the job from DB to verify claim invariants (enabled + next_run_at IS NULL).
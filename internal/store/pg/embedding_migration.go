@@ -0,0 +1,330 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nextlevelbuilder/goclaw/internal/memory"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// vectorTable describes a table whose embedding column is re-generated by
+// ReembedAll. text is the SQL expression that produced the embedded text at
+// write time; rows outside where carry no embedding.
+type vectorTable struct {
+	name  string
+	text  string
+	where string
+}
+
+// vectorTables lists every vector(N) column except embedding_cache, which is
+// cleared instead of re-embedded.
+var vectorTables = []vectorTable{
+	{name: "memory_chunks", text: "text", where: "TRUE"},
+	{name: "kg_entities", text: "name || ' ' || COALESCE(description, '')", where: "TRUE"},
+	{name: "team_tasks", text: "subject", where: "status NOT IN ('cancelled')"},
+	{name: "skills", text: "name || CASE WHEN COALESCE(description, '') <> '' THEN ': ' || description ELSE '' END", where: "status = 'active' AND enabled = true"},
+	{name: "agents", text: "COALESCE(display_name, '') || ': ' || frontmatter", where: "deleted_at IS NULL AND frontmatter IS NOT NULL AND frontmatter <> ''"},
+	{name: "episodic_summaries", text: "summary", where: "summary <> ''"},
+	{name: "vault_documents", text: "COALESCE(title, '') || ' ' || path || ' ' || summary", where: "summary IS NOT NULL AND summary <> ''"},
+}
+
+const reembedBatchSize = 50
+
+// PGEmbeddingMigrator re-generates stored vectors and resizes the pgvector
+// columns when the embedding provider changes.
+type PGEmbeddingMigrator struct {
+	db       *sql.DB
+	configs  store.SystemConfigStore
+	provider store.EmbeddingProvider
+
+	running sync.Mutex // one re-embed at a time
+
+	mu     sync.RWMutex
+	reason string // why a re-embed is required; "" = vectors match the provider
+}
+
+// NewPGEmbeddingMigrator creates a migrator. configs persists the embedding
+// fingerprint and may be nil.
+func NewPGEmbeddingMigrator(db *sql.DB, configs store.SystemConfigStore, provider store.EmbeddingProvider) *PGEmbeddingMigrator {
+	return &PGEmbeddingMigrator{db: db, configs: configs, provider: provider}
+}
+
+var _ store.EmbeddingMigrator = (*PGEmbeddingMigrator)(nil)
+
+// VectorDimensions returns the current vector column size.
+func (m *PGEmbeddingMigrator) VectorDimensions(ctx context.Context) (int, error) {
+	return VectorColumnDimensions(ctx, m.db)
+}
+
+// VectorColumnDimensions reads the size of memory_chunks.embedding from the
+// schema. pgvector stores the declared dimension as the type modifier.
+func VectorColumnDimensions(ctx context.Context, db *sql.DB) (int, error) {
+	var dims int
+	err := db.QueryRowContext(ctx,
+		`SELECT atttypmod FROM pg_attribute
+		 WHERE attrelid = 'memory_chunks'::regclass AND attname = 'embedding' AND NOT attisdropped`,
+	).Scan(&dims)
+	if err != nil {
+		return 0, fmt.Errorf("read vector column size: %w", err)
+	}
+	if dims <= 0 {
+		return 0, fmt.Errorf("memory_chunks.embedding has no declared dimension")
+	}
+	return dims, nil
+}
+
+// ReembedRequired explains why stored vectors don't match the provider.
+func (m *PGEmbeddingMigrator) ReembedRequired() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.reason
+}
+
+func (m *PGEmbeddingMigrator) setReembedRequired(reason string) {
+	m.mu.Lock()
+	m.reason = reason
+	m.mu.Unlock()
+}
+
+// CheckFingerprint compares the provider with the fingerprint recorded for
+// the stored vectors and with the column size. A first run records the
+// current fingerprint. Mismatches are logged and reported by ReembedRequired.
+func (m *PGEmbeddingMigrator) CheckFingerprint(ctx context.Context) error {
+	current, err := memory.Fingerprint(ctx, m.provider)
+	if err != nil {
+		return err
+	}
+	cols := store.MemoryEmbeddingDimensions()
+
+	var reason string
+	stored, ok := m.loadFingerprint(ctx)
+	switch {
+	case current.Dimensions > cols && current.Dimensions <= store.MaxMemoryEmbeddingDimensions:
+		reason = fmt.Sprintf("%s returns %d dimensions but vector columns hold %d; run `goclaw memory reembed --dimensions %d`",
+			current, current.Dimensions, cols, current.Dimensions)
+	case current.Dimensions > cols:
+		reason = fmt.Sprintf("%s returns %d dimensions, more than the %d pgvector can index; set a smaller embedding dimension",
+			current, current.Dimensions, store.MaxMemoryEmbeddingDimensions)
+	case !ok:
+		m.saveFingerprint(ctx, current)
+	case stored != current:
+		reason = fmt.Sprintf("stored vectors were produced by %s but the provider is now %s; run `goclaw memory reembed`",
+			stored, current)
+	}
+	m.setReembedRequired(reason)
+	if reason != "" {
+		slog.Warn("embedding provider mismatch: vector search results will be wrong until re-embedded", "reason", reason)
+	}
+	return nil
+}
+
+func (m *PGEmbeddingMigrator) loadFingerprint(ctx context.Context) (store.EmbeddingFingerprint, bool) {
+	var fp store.EmbeddingFingerprint
+	if m.configs == nil {
+		return fp, false
+	}
+	raw, err := m.configs.Get(store.WithTenantID(ctx, store.MasterTenantID), store.EmbeddingFingerprintKey)
+	if err != nil || raw == "" {
+		return fp, false
+	}
+	if err := json.Unmarshal([]byte(raw), &fp); err != nil {
+		slog.Warn("embedding fingerprint unreadable, ignoring", "error", err)
+		return fp, false
+	}
+	return fp, true
+}
+
+func (m *PGEmbeddingMigrator) saveFingerprint(ctx context.Context, fp store.EmbeddingFingerprint) {
+	if m.configs == nil {
+		return
+	}
+	data, _ := json.Marshal(fp)
+	if err := m.configs.Set(store.WithTenantID(ctx, store.MasterTenantID), store.EmbeddingFingerprintKey, string(data)); err != nil {
+		slog.Warn("embedding fingerprint save failed", "error", err)
+	}
+}
+
+// ReembedAll re-generates every stored embedding with the current provider,
+// across all tenants. Rows are updated in place, so vector search keeps
+// working (with mixed results) while it runs; a failed run can be repeated.
+func (m *PGEmbeddingMigrator) ReembedAll(ctx context.Context, dims int) (*store.ReembedResult, error) {
+	if m.provider == nil {
+		return nil, fmt.Errorf("no embedding provider configured")
+	}
+	if !m.running.TryLock() {
+		return nil, fmt.Errorf("a re-embed is already running")
+	}
+	defer m.running.Unlock()
+
+	current, err := memory.Fingerprint(ctx, m.provider)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := m.VectorDimensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &store.ReembedResult{Dimensions: cols, Updated: make(map[string]int)}
+	if dims > 0 && dims != cols {
+		if dims > store.MaxMemoryEmbeddingDimensions {
+			return nil, fmt.Errorf("dimensions %d exceed the pgvector index limit of %d", dims, store.MaxMemoryEmbeddingDimensions)
+		}
+		if err := m.resizeVectorColumns(ctx, dims); err != nil {
+			return nil, err
+		}
+		store.SetMemoryEmbeddingDimensions(dims)
+		result.Dimensions, result.Resized = dims, true
+		slog.Info("vector columns resized", "from", cols, "to", dims)
+	}
+	if current.Dimensions > result.Dimensions {
+		return result, fmt.Errorf("%s returns %d dimensions but vector columns hold %d; pass --dimensions %d",
+			current, current.Dimensions, result.Dimensions, current.Dimensions)
+	}
+
+	// The cache is keyed by provider and model only, so entries written with
+	// other dimension settings would be served back as-is.
+	if _, err := m.db.ExecContext(ctx, `TRUNCATE embedding_cache`); err != nil {
+		return result, fmt.Errorf("clear embedding cache: %w", err)
+	}
+
+	for _, t := range vectorTables {
+		n, err := m.reembedTable(ctx, t)
+		result.Updated[t.name] = n
+		if err != nil {
+			return result, fmt.Errorf("re-embed %s: %w", t.name, err)
+		}
+		slog.Info("re-embedded table", "table", t.name, "rows", n)
+	}
+
+	m.saveFingerprint(ctx, current)
+	m.setReembedRequired("")
+	return result, nil
+}
+
+// reembedTable walks t in id order and overwrites each row's embedding.
+// Rows that should not carry one have it cleared.
+func (m *PGEmbeddingMigrator) reembedTable(ctx context.Context, t vectorTable) (int, error) {
+	if t.where != "TRUE" {
+		if _, err := m.db.ExecContext(ctx,
+			fmt.Sprintf(`UPDATE %s SET embedding = NULL WHERE embedding IS NOT NULL AND NOT (%s)`, t.name, t.where),
+		); err != nil {
+			return 0, fmt.Errorf("clear stale embeddings: %w", err)
+		}
+	}
+
+	q := fmt.Sprintf(`SELECT id, %s FROM %s WHERE id > $1 AND (%s) ORDER BY id LIMIT $2`, t.text, t.name, t.where)
+	total := 0
+	after := uuid.Nil
+	for {
+		rows, err := m.db.QueryContext(ctx, q, after, reembedBatchSize)
+		if err != nil {
+			return total, err
+		}
+		var ids []uuid.UUID
+		var texts []string
+		for rows.Next() {
+			var id uuid.UUID
+			var text string
+			if err := rows.Scan(&id, &text); err != nil {
+				rows.Close()
+				return total, err
+			}
+			ids = append(ids, id)
+			texts = append(texts, text)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		vecs, err := m.provider.Embed(ctx, texts)
+		if err != nil {
+			return total, fmt.Errorf("generate embeddings: %w", err)
+		}
+		if len(vecs) != len(ids) {
+			return total, fmt.Errorf("provider returned %d vectors for %d texts", len(vecs), len(ids))
+		}
+		for i, id := range ids {
+			if _, err := m.db.ExecContext(ctx,
+				fmt.Sprintf(`UPDATE %s SET embedding = $1::vector WHERE id = $2`, t.name),
+				vectorToString(vecs[i]), id,
+			); err != nil {
+				return total, fmt.Errorf("update id=%s: %w", id, err)
+			}
+			total++
+		}
+		after = ids[len(ids)-1]
+		if len(ids) < reembedBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// resizeVectorColumns migrates every vector column to vector(dims) in one
+// transaction. Existing vectors cannot be converted and are cleared; their
+// HNSW indexes are dropped and recreated with the same definitions.
+func (m *PGEmbeddingMigrator) resizeVectorColumns(ctx context.Context, dims int) error {
+	tables := make([]string, 0, len(vectorTables)+1)
+	for _, t := range vectorTables {
+		tables = append(tables, t.name)
+	}
+	tables = append(tables, "embedding_cache")
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var indexes []struct{ name, def string }
+	rows, err := tx.QueryContext(ctx,
+		`SELECT indexname, indexdef FROM pg_indexes
+		 WHERE schemaname = current_schema() AND tablename = ANY($1) AND indexdef ILIKE '%(embedding %'`,
+		pq.Array(tables))
+	if err != nil {
+		return fmt.Errorf("list vector indexes: %w", err)
+	}
+	for rows.Next() {
+		var name, def string
+		if err := rows.Scan(&name, &def); err != nil {
+			rows.Close()
+			return err
+		}
+		indexes = append(indexes, struct{ name, def string }{name, def})
+	}
+	rows.Close()
+
+	for _, idx := range indexes {
+		if _, err := tx.ExecContext(ctx, `DROP INDEX IF EXISTS `+pq.QuoteIdentifier(idx.name)); err != nil {
+			return fmt.Errorf("drop index %s: %w", idx.name, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `TRUNCATE embedding_cache`); err != nil {
+		return fmt.Errorf("clear embedding cache: %w", err)
+	}
+	for _, t := range tables {
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf(`ALTER TABLE %s ALTER COLUMN embedding TYPE vector(%d) USING NULL`, t, dims),
+		); err != nil {
+			return fmt.Errorf("resize %s.embedding: %w", t, err)
+		}
+	}
+	for _, idx := range indexes {
+		if _, err := tx.ExecContext(ctx, idx.def); err != nil {
+			return fmt.Errorf("recreate index %s: %w", idx.name, err)
+		}
+	}
+	return tx.Commit()
+}
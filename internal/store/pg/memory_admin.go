@@ -59,6 +59,36 @@ func (s *PGMemoryStore) ListAllDocuments(ctx context.Context, agentID string) ([
 	return result, nil
 }
 
+// Stats returns document/chunk/embedding counts for an agent across all users.
+func (s *PGMemoryStore) Stats(ctx context.Context, agentID string) (*store.MemoryStats, error) {
+	aid, err := parseUUID(agentID)
+	if err != nil {
+		return nil, fmt.Errorf("memory stats: %w", err)
+	}
+	tc, tcArgs, _, err := scopeClause(ctx, 2)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]any{aid}, tcArgs...)
+
+	stats := &store.MemoryStats{VectorSearch: true}
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM memory_documents WHERE agent_id = $1`+tc, args...,
+	).Scan(&stats.Documents); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*), COUNT(embedding) FROM memory_chunks WHERE agent_id = $1`+tc, args...,
+	).Scan(&stats.Chunks, &stats.EmbeddedChunks); err != nil {
+		return nil, err
+	}
+	if s.provider != nil {
+		stats.EmbeddingProvider = s.provider.Name()
+		stats.EmbeddingModel = s.provider.Model()
+	}
+	return stats, nil
+}
+
 // GetDocumentDetail returns full document info with chunk and embedding counts.
 func (s *PGMemoryStore) GetDocumentDetail(ctx context.Context, agentID, userID, path string) (*store.DocumentDetail, error) {
 	aid, err := parseUUID(agentID)
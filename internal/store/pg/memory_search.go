@@ -16,13 +16,16 @@ func (s *PGMemoryStore) Search(ctx context.Context, query string, agentID, userI
 		maxResults = s.cfg.MaxResults
 	}
 
+	candidates := opts.CandidateLimit(maxResults)
+	fetch := max(maxResults*2, candidates)
+
 	aid, err := parseUUID(agentID)
 	if err != nil {
 		return nil, fmt.Errorf("memory search: %w", err)
 	}
 
 	// FTS search using tsvector
-	ftsResults, err := s.ftsSearch(ctx, query, aid, userID, maxResults*2)
+	ftsResults, err := s.ftsSearch(ctx, query, aid, userID, fetch)
 	if err != nil {
 		return nil, err
 	}
@@ -32,7 +35,7 @@ func (s *PGMemoryStore) Search(ctx context.Context, query string, agentID, userI
 	if s.provider != nil {
 		embeddings, err := s.provider.Embed(ctx, []string{query})
 		if err == nil && len(embeddings) > 0 {
-			vecResults, err = s.vectorSearch(ctx, embeddings[0], aid, userID, maxResults*2)
+			vecResults, err = s.vectorSearch(ctx, embeddings[0], aid, userID, fetch)
 			if err != nil {
 				vecResults = nil
 			}
@@ -64,12 +67,13 @@ func (s *PGMemoryStore) Search(ctx context.Context, query string, agentID, userI
 			continue
 		}
 		filtered = append(filtered, m)
-		if len(filtered) >= maxResults {
+		if len(filtered) >= candidates {
 			break
 		}
 	}
 
-	return filtered, nil
+	// Optional rerank stage; also caps at maxResults.
+	return store.RerankResults(ctx, opts, query, filtered, maxResults), nil
 }
 
 type scoredChunk struct {
@@ -14,21 +14,35 @@ import (
 )
 
 const (
-	codeAlphabet         = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
-	codeLength           = 8
-	codeTTL              = 60 * time.Minute
-	pairedDeviceTTL      = 30 * 24 * time.Hour // 30 days
-	maxPendingPerAccount = 3
+	codeAlphabet           = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength             = 8
+	defaultCodeTTL         = 60 * time.Minute
+	defaultPairedDeviceTTL = 30 * 24 * time.Hour // 30 days
+	maxPendingPerAccount   = 3
 )
 
 // PGPairingStore implements store.PairingStore backed by Postgres.
 type PGPairingStore struct {
 	db        *sql.DB
 	onRequest func(code, senderID, channel, chatID string)
+	codeTTL   time.Duration
+	pairedTTL time.Duration // <= 0: paired devices never expire
 }
 
 func NewPGPairingStore(db *sql.DB) *PGPairingStore {
-	return &PGPairingStore{db: db}
+	return &PGPairingStore{db: db, codeTTL: defaultCodeTTL, pairedTTL: defaultPairedDeviceTTL}
+}
+
+// SetTTLs overrides how long pairing codes and approved pairings stay valid.
+// A zero codeTTL keeps the default; a negative pairedTTL never expires
+// approved pairings.
+func (s *PGPairingStore) SetTTLs(codeTTL, pairedTTL time.Duration) {
+	if codeTTL > 0 {
+		s.codeTTL = codeTTL
+	}
+	if pairedTTL != 0 {
+		s.pairedTTL = pairedTTL
+	}
 }
 
 // SetOnRequest sets a callback fired after a new pairing request is created.
@@ -66,7 +80,7 @@ func (s *PGPairingStore) RequestPairing(ctx context.Context, senderID, channel,
 	_, err = s.db.ExecContext(ctx,
 		`INSERT INTO pairing_requests (id, code, sender_id, channel, chat_id, account_id, expires_at, created_at, metadata, tenant_id)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
-		uuid.Must(uuid.NewV7()), code, senderID, channel, chatID, accountID, now.Add(codeTTL), now, metaJSON, tid,
+		uuid.Must(uuid.NewV7()), code, senderID, channel, chatID, accountID, now.Add(s.codeTTL), now, metaJSON, tid,
 	)
 	if err != nil {
 		return "", fmt.Errorf("create pairing request: %w", err)
@@ -96,12 +110,23 @@ func (s *PGPairingStore) ApprovePairing(ctx context.Context, code, approvedBy st
 		return nil, fmt.Errorf("pairing code %s not found or expired", code)
 	}
 
-	// Remove from pending
-	s.db.ExecContext(ctx, "DELETE FROM pairing_requests WHERE id = $1", reqID)
+	// Remove from pending. Only the caller that deletes the row may approve,
+	// so a code cannot be used twice by concurrent approvals.
+	res, err := s.db.ExecContext(ctx, "DELETE FROM pairing_requests WHERE id = $1", reqID)
+	if err != nil {
+		return nil, fmt.Errorf("consume pairing code: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, fmt.Errorf("pairing code %s not found or expired", code)
+	}
 
 	// Add to paired — use the request's tenant (the channel that initiated pairing)
 	now := time.Now()
-	expiresAt := now.Add(pairedDeviceTTL)
+	var expiresAt *time.Time
+	if s.pairedTTL > 0 {
+		t := now.Add(s.pairedTTL)
+		expiresAt = &t
+	}
 	_, err = s.db.ExecContext(ctx,
 		`INSERT INTO paired_devices (id, sender_id, channel, chat_id, paired_by, paired_at, metadata, expires_at, tenant_id)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
@@ -117,15 +142,23 @@ func (s *PGPairingStore) ApprovePairing(ctx context.Context, code, approvedBy st
 	}
 
 	return &store.PairedDeviceData{
-		SenderID: senderID,
-		Channel:  channel,
-		ChatID:   chatID,
-		PairedAt: now.UnixMilli(),
-		PairedBy: approvedBy,
-		Metadata: meta,
+		SenderID:  senderID,
+		Channel:   channel,
+		ChatID:    chatID,
+		PairedAt:  now.UnixMilli(),
+		PairedBy:  approvedBy,
+		ExpiresAt: unixMilliOrZero(expiresAt),
+		Metadata:  meta,
 	}, nil
 }
 
+func unixMilliOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
 func (s *PGPairingStore) DenyPairing(ctx context.Context, code string) error {
 	// Code lookup is cross-tenant (random token)
 	result, err := s.db.ExecContext(ctx, "DELETE FROM pairing_requests WHERE code = $1", code)
@@ -180,12 +213,13 @@ type pairingRequestRow struct {
 
 // pairedDeviceRow is an sqlx scan struct for paired_devices.
 type pairedDeviceRow struct {
-	SenderID string    `json:"sender_id" db:"sender_id"`
-	Channel  string    `json:"channel" db:"channel"`
-	ChatID   string    `json:"chat_id" db:"chat_id"`
-	PairedBy string    `json:"paired_by" db:"paired_by"`
-	PairedAt time.Time `json:"paired_at" db:"paired_at"`
-	Metadata []byte    `json:"metadata" db:"metadata"`
+	SenderID  string     `json:"sender_id" db:"sender_id"`
+	Channel   string     `json:"channel" db:"channel"`
+	ChatID    string     `json:"chat_id" db:"chat_id"`
+	PairedBy  string     `json:"paired_by" db:"paired_by"`
+	PairedAt  time.Time  `json:"paired_at" db:"paired_at"`
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	Metadata  []byte     `json:"metadata" db:"metadata"`
 }
 
 func (s *PGPairingStore) ListPending(ctx context.Context) []store.PairingRequestData {
@@ -224,7 +258,7 @@ func (s *PGPairingStore) ListPaired(ctx context.Context) []store.PairedDeviceDat
 
 	var rows []pairedDeviceRow
 	err := pkgSqlxDB.SelectContext(ctx, &rows,
-		`SELECT sender_id, channel, chat_id, paired_by, paired_at, COALESCE(metadata, '{}') AS metadata
+		`SELECT sender_id, channel, chat_id, paired_by, paired_at, expires_at, COALESCE(metadata, '{}') AS metadata
 		 FROM paired_devices WHERE tenant_id = $1 ORDER BY paired_at DESC`, tid)
 	if err != nil {
 		return []store.PairedDeviceData{}
@@ -235,6 +269,7 @@ func (s *PGPairingStore) ListPaired(ctx context.Context) []store.PairedDeviceDat
 		result[i] = store.PairedDeviceData{
 			SenderID: r.SenderID, Channel: r.Channel, ChatID: r.ChatID,
 			PairedBy: r.PairedBy, PairedAt: r.PairedAt.UnixMilli(),
+			ExpiresAt: unixMilliOrZero(r.ExpiresAt),
 		}
 		if len(r.Metadata) > 0 {
 			json.Unmarshal(r.Metadata, &result[i].Metadata)
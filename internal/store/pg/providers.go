@@ -179,7 +179,7 @@ func (s *PGProviderStore) decryptKey(apiKey, providerName string) string {
 			slog.Warn("failed to decrypt provider API key", "provider", providerName, "error", err)
 			return apiKey
 		}
-		return decrypted
+		apiKey = decrypted
 	}
-	return apiKey
+	return store.ResolveProviderAPIKey(apiKey, providerName)
 }
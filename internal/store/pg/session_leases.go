@@ -0,0 +1,234 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSessionLeaseTTL   = 60 * time.Second
+	defaultSessionLockWait   = 2 * time.Minute
+	sessionLeasePollInterval = 500 * time.Millisecond
+)
+
+// ErrSessionLocked is returned when another gateway replica keeps a session
+// busy for longer than the configured lock wait.
+var ErrSessionLocked = errors.New("session is busy on another gateway instance")
+
+// sessionLeaseBackend persists lease rows. Split out so the reference counting
+// in SessionLocker can be tested without a database.
+type sessionLeaseBackend interface {
+	acquire(ctx context.Context, tenantID uuid.UUID, sessionKey string) (bool, error)
+	renew(ctx context.Context, tenantID uuid.UUID, sessionKey string) (bool, error)
+	release(ctx context.Context, tenantID uuid.UUID, sessionKey string) error
+}
+
+// SessionLocker serializes agent runs on a session across gateway replicas
+// using lease rows in session_leases. Leases (not advisory locks) are used
+// because runs last minutes and an advisory lock would pin a pool connection
+// per active session. Within one process, runs on the same session share the
+// lease (the in-process scheduler already orders them); the lease is renewed
+// while held and deleted when the last run finishes.
+//
+// Implements agent.SessionLocker.
+type SessionLocker struct {
+	backend  sessionLeaseBackend
+	sessions *PGSessionStore // cached copies are dropped on acquire; nil in tests
+	ttl      time.Duration
+	wait     time.Duration
+
+	mu   sync.Mutex
+	held map[string]*sessionLease // tenant:session → lease
+}
+
+type sessionLease struct {
+	refs  int
+	ready chan struct{} // closed once the acquire attempt completes
+	err   error
+	stop  chan struct{} // stops the renew loop
+}
+
+// NewSessionLocker creates a locker for the given gateway instance ID.
+// Zero ttl/wait use the defaults (60s lease, 2 min wait).
+func NewSessionLocker(db *sql.DB, sessions *PGSessionStore, instanceID string, ttl, wait time.Duration) *SessionLocker {
+	if ttl <= 0 {
+		ttl = defaultSessionLeaseTTL
+	}
+	if wait <= 0 {
+		wait = defaultSessionLockWait
+	}
+	return &SessionLocker{
+		backend:  &pgSessionLeases{db: db, holder: instanceID, ttl: ttl},
+		sessions: sessions,
+		ttl:      ttl,
+		wait:     wait,
+		held:     make(map[string]*sessionLease),
+	}
+}
+
+// LockSession blocks until this instance owns the session lease (or the lock
+// wait elapses) and returns a release func. The first acquisition drops the
+// locally cached session so the run starts from what other replicas saved.
+func (l *SessionLocker) LockSession(ctx context.Context, sessionKey string) (func(), error) {
+	tid := tenantIDForInsert(ctx)
+	k := tid.String() + ":" + sessionKey
+
+	l.mu.Lock()
+	if lease, ok := l.held[k]; ok {
+		lease.refs++
+		l.mu.Unlock()
+		<-lease.ready
+		if lease.err != nil {
+			l.unref(k, tid, sessionKey)
+			return nil, lease.err
+		}
+		return l.releaseFunc(k, tid, sessionKey), nil
+	}
+	lease := &sessionLease{refs: 1, ready: make(chan struct{}), stop: make(chan struct{})}
+	l.held[k] = lease
+	l.mu.Unlock()
+
+	lease.err = l.acquire(ctx, tid, sessionKey)
+	if lease.err == nil {
+		if l.sessions != nil {
+			l.sessions.dropCached(ctx, sessionKey)
+		}
+		go l.renewLoop(tid, sessionKey, lease.stop)
+	}
+	close(lease.ready)
+	if lease.err != nil {
+		l.unref(k, tid, sessionKey)
+		return nil, lease.err
+	}
+	return l.releaseFunc(k, tid, sessionKey), nil
+}
+
+// acquire polls until the lease is ours, ctx is done, or the wait elapses.
+func (l *SessionLocker) acquire(ctx context.Context, tid uuid.UUID, sessionKey string) error {
+	deadline := time.NewTimer(l.wait)
+	defer deadline.Stop()
+	logged := false
+	for {
+		ok, err := l.backend.acquire(ctx, tid, sessionKey)
+		if err != nil {
+			return fmt.Errorf("acquire session lease: %w", err)
+		}
+		if ok {
+			return nil
+		}
+		if !logged {
+			slog.Info("sessions.lease_wait", "session", sessionKey)
+			logged = true
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return ErrSessionLocked
+		case <-time.After(sessionLeasePollInterval):
+		}
+	}
+}
+
+func (l *SessionLocker) releaseFunc(k string, tid uuid.UUID, sessionKey string) func() {
+	var once sync.Once
+	return func() { once.Do(func() { l.unref(k, tid, sessionKey) }) }
+}
+
+// unref drops one reference; the last one stops renewal and deletes the row.
+func (l *SessionLocker) unref(k string, tid uuid.UUID, sessionKey string) {
+	l.mu.Lock()
+	lease, ok := l.held[k]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+	lease.refs--
+	if lease.refs > 0 {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.held, k)
+	l.mu.Unlock()
+
+	if lease.err != nil {
+		return
+	}
+	close(lease.stop)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := l.backend.release(ctx, tid, sessionKey); err != nil {
+		slog.Warn("sessions.lease_release_failed", "session", sessionKey, "error", err)
+	}
+}
+
+// renewLoop extends the lease every ttl/3 until stop is closed.
+func (l *SessionLocker) renewLoop(tid uuid.UUID, sessionKey string, stop chan struct{}) {
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+			ok, err := l.backend.renew(ctx, tid, sessionKey)
+			cancel()
+			if err != nil {
+				slog.Warn("sessions.lease_renew_failed", "session", sessionKey, "error", err)
+			} else if !ok {
+				slog.Warn("sessions.lease_lost", "session", sessionKey)
+			}
+		}
+	}
+}
+
+// pgSessionLeases is the session_leases table backend.
+type pgSessionLeases struct {
+	db     *sql.DB
+	holder string
+	ttl    time.Duration
+}
+
+// acquire inserts the lease, or takes it over when it is already ours or
+// has expired. Zero affected rows means another live holder owns it.
+func (p *pgSessionLeases) acquire(ctx context.Context, tid uuid.UUID, sessionKey string) (bool, error) {
+	res, err := p.db.ExecContext(ctx,
+		`INSERT INTO session_leases (tenant_id, session_key, holder, acquired_at, expires_at)
+		 VALUES ($1, $2, $3, NOW(), NOW() + $4 * INTERVAL '1 millisecond')
+		 ON CONFLICT (tenant_id, session_key) DO UPDATE
+		 SET holder = EXCLUDED.holder, acquired_at = EXCLUDED.acquired_at, expires_at = EXCLUDED.expires_at
+		 WHERE session_leases.holder = EXCLUDED.holder OR session_leases.expires_at < NOW()`,
+		tid, sessionKey, p.holder, p.ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (p *pgSessionLeases) renew(ctx context.Context, tid uuid.UUID, sessionKey string) (bool, error) {
+	res, err := p.db.ExecContext(ctx,
+		`UPDATE session_leases SET expires_at = NOW() + $4 * INTERVAL '1 millisecond'
+		 WHERE tenant_id = $1 AND session_key = $2 AND holder = $3`,
+		tid, sessionKey, p.holder, p.ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (p *pgSessionLeases) release(ctx context.Context, tid uuid.UUID, sessionKey string) error {
+	_, err := p.db.ExecContext(ctx,
+		`DELETE FROM session_leases WHERE tenant_id = $1 AND session_key = $2 AND holder = $3`,
+		tid, sessionKey, p.holder)
+	return err
+}
@@ -0,0 +1,121 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeLeases is an in-memory session_leases table shared by several lockers.
+type fakeLeases struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+type fakeLeaseHolder struct {
+	table    *fakeLeases
+	holder   string
+	releases int
+}
+
+func (f *fakeLeaseHolder) acquire(_ context.Context, tid uuid.UUID, key string) (bool, error) {
+	f.table.mu.Lock()
+	defer f.table.mu.Unlock()
+	k := tid.String() + ":" + key
+	if owner, ok := f.table.owners[k]; ok && owner != f.holder {
+		return false, nil
+	}
+	f.table.owners[k] = f.holder
+	return true, nil
+}
+
+func (f *fakeLeaseHolder) renew(_ context.Context, tid uuid.UUID, key string) (bool, error) {
+	f.table.mu.Lock()
+	defer f.table.mu.Unlock()
+	return f.table.owners[tid.String()+":"+key] == f.holder, nil
+}
+
+func (f *fakeLeaseHolder) release(_ context.Context, tid uuid.UUID, key string) error {
+	f.table.mu.Lock()
+	defer f.table.mu.Unlock()
+	f.releases++
+	delete(f.table.owners, tid.String()+":"+key)
+	return nil
+}
+
+func newTestLocker(table *fakeLeases, holder string, wait time.Duration) (*SessionLocker, *fakeLeaseHolder) {
+	b := &fakeLeaseHolder{table: table, holder: holder}
+	return &SessionLocker{backend: b, ttl: time.Minute, wait: wait, held: make(map[string]*sessionLease)}, b
+}
+
+func TestSessionLocker_SharedWithinInstance(t *testing.T) {
+	table := &fakeLeases{owners: map[string]string{}}
+	l, b := newTestLocker(table, "a", time.Second)
+	ctx := context.Background()
+
+	r1, err := l.LockSession(ctx, "agent:x:ws:direct:u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := l.LockSession(ctx, "agent:x:ws:direct:u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1()
+	r1() // idempotent
+	if b.releases != 0 {
+		t.Fatal("lease released while a run still holds it")
+	}
+	r2()
+	if b.releases != 1 || len(table.owners) != 0 {
+		t.Errorf("releases = %d owners = %v, want lease deleted once", b.releases, table.owners)
+	}
+}
+
+func TestSessionLocker_OtherInstanceWaitsThenAcquires(t *testing.T) {
+	table := &fakeLeases{owners: map[string]string{}}
+	a, _ := newTestLocker(table, "a", time.Second)
+	b, _ := newTestLocker(table, "b", 5*time.Second)
+	ctx := context.Background()
+
+	release, err := a.LockSession(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		release()
+	}()
+	start := time.Now()
+	releaseB, err := b.LockSession(ctx, "s1")
+	if err != nil {
+		t.Fatalf("replica b: %v", err)
+	}
+	defer releaseB()
+	if time.Since(start) < 50*time.Millisecond {
+		t.Error("replica b acquired the session while replica a still held it")
+	}
+}
+
+func TestSessionLocker_WaitTimeout(t *testing.T) {
+	table := &fakeLeases{owners: map[string]string{}}
+	a, _ := newTestLocker(table, "a", time.Second)
+	b, _ := newTestLocker(table, "b", 10*time.Millisecond)
+	ctx := context.Background()
+
+	release, err := a.LockSession(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if _, err := b.LockSession(ctx, "s1"); !errors.Is(err, ErrSessionLocked) {
+		t.Errorf("err = %v, want ErrSessionLocked", err)
+	}
+	if len(b.held) != 0 {
+		t.Errorf("failed acquire left %d held entries", len(b.held))
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"maps"
 	"strings"
@@ -107,6 +109,38 @@ func sessionCacheKey(ctx context.Context, key string) string {
 	return tid.String() + ":" + key
 }
 
+// dropCached forgets the in-memory copy of a session so the next read loads
+// it from the database (another replica may have changed it).
+func (s *PGSessionStore) dropCached(ctx context.Context, key string) {
+	s.mu.Lock()
+	delete(s.cache, sessionCacheKey(ctx, key))
+	s.mu.Unlock()
+}
+
+// FlushAll saves every cached session. Cache keys carry the tenant prefix,
+// so each Save runs under that tenant's context.
+func (s *PGSessionStore) FlushAll(ctx context.Context) error {
+	s.mu.RLock()
+	cacheKeys := make([]string, 0, len(s.cache))
+	for ck := range s.cache {
+		cacheKeys = append(cacheKeys, ck)
+	}
+	s.mu.RUnlock()
+
+	var errs []error
+	for _, ck := range cacheKeys {
+		tidStr, key, ok := strings.Cut(ck, ":")
+		tid, err := uuid.Parse(tidStr)
+		if !ok || err != nil {
+			continue
+		}
+		if err := s.Save(store.WithTenantID(ctx, tid), key); err != nil {
+			errs = append(errs, fmt.Errorf("save session %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (s *PGSessionStore) GetOrCreate(ctx context.Context, key string) *store.SessionData {
 	s.mu.Lock()
 	defer s.mu.Unlock()
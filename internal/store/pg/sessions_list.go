@@ -49,6 +49,13 @@ func buildSessionFilter(ctx context.Context, opts store.SessionListOpts, tableAl
 		args = append(args, opts.UserID)
 		idx++
 	}
+	switch opts.Archived {
+	case store.SessionArchivedInclude:
+	case store.SessionArchivedOnly:
+		conditions = append(conditions, fmt.Sprintf("COALESCE(%smetadata->>'%s', '') <> ''", prefix, store.SessionMetaArchivedAt))
+	default:
+		conditions = append(conditions, fmt.Sprintf("COALESCE(%smetadata->>'%s', '') = ''", prefix, store.SessionMetaArchivedAt))
+	}
 
 	// Resolve tenant filter — opts override beats ctx.
 	tenantID := opts.TenantID
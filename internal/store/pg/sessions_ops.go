@@ -2,10 +2,12 @@ package pg
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 func (s *PGSessionStore) TruncateHistory(ctx context.Context, key string, keepLast int) {
@@ -67,3 +69,50 @@ func (s *PGSessionStore) Delete(ctx context.Context, key string) error {
 	_, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE session_key = $1 AND tenant_id = $2", key, tid)
 	return err
 }
+
+// Purge deletes the session and everything keyed by it (traces and their
+// spans, episodic summaries, evolution metrics, subagent tasks) in one
+// transaction. Implements store.SessionPurger.
+func (s *PGSessionStore) Purge(ctx context.Context, key string) (store.SessionPurgeResult, error) {
+	var res store.SessionPurgeResult
+	tid := tenantIDForInsert(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	steps := []struct {
+		query string
+		count *int64
+	}{
+		{`DELETE FROM spans WHERE tenant_id = $2 AND trace_id IN
+			(SELECT id FROM traces WHERE session_key = $1 AND tenant_id = $2)`, &res.Spans},
+		{"DELETE FROM traces WHERE session_key = $1 AND tenant_id = $2", &res.Traces},
+		{"DELETE FROM episodic_summaries WHERE session_key = $1 AND tenant_id = $2", &res.Episodic},
+		{"DELETE FROM agent_evolution_metrics WHERE session_key = $1 AND tenant_id = $2", &res.Metrics},
+		{"DELETE FROM subagent_tasks WHERE session_key = $1 AND tenant_id = $2", &res.SubagentRuns},
+		{"DELETE FROM sessions WHERE session_key = $1 AND tenant_id = $2", nil},
+	}
+	for _, st := range steps {
+		r, err := tx.ExecContext(ctx, st.query, key, tid)
+		if err != nil {
+			return store.SessionPurgeResult{}, fmt.Errorf("purge session: %w", err)
+		}
+		if st.count != nil {
+			*st.count, _ = r.RowsAffected()
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return store.SessionPurgeResult{}, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, sessionCacheKey(ctx, key))
+	s.mu.Unlock()
+	if s.OnDelete != nil {
+		s.OnDelete(key)
+	}
+	return res, nil
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
-// GrantToAgent grants a skill to an agent with version pinning.
+// GrantToAgent grants a skill to an agent, pinned to version (0 = follow the active version).
 // Auto-promotes visibility from 'private' to 'internal' so the skill
 // becomes accessible via ListAccessible for granted agents.
 // Validates the agent belongs to the requesting tenant (prevents cross-tenant grant injection).
@@ -166,7 +166,7 @@ func (s *PGSkillStore) ListAccessible(ctx context.Context, agentID uuid.UUID, us
 		stcFilter = " AND (stc.enabled IS NULL OR stc.enabled = true)"
 	}
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT DISTINCT s.name, s.slug, s.description, s.version, s.file_path FROM skills s
+		`SELECT DISTINCT s.name, s.slug, s.description, s.version, s.file_path, COALESCE(sag.pinned_version, 0) FROM skills s
 		LEFT JOIN skill_agent_grants sag ON s.id = sag.skill_id AND sag.agent_id = $1
 		LEFT JOIN skill_user_grants sug ON s.id = sug.skill_id AND (sug.user_id = $2 OR sug.user_id = $3)`+stcJoin+`
 		WHERE s.status = 'active'`+tenantCond+stcFilter+` AND (
@@ -187,11 +187,14 @@ func (s *PGSkillStore) ListAccessible(ctx context.Context, agentID uuid.UUID, us
 		var desc *string
 		var version int
 		var filePath *string
-		if err := rows.Scan(&name, &slug, &desc, &version, &filePath); err != nil {
+		var pinned int
+		if err := rows.Scan(&name, &slug, &desc, &version, &filePath, &pinned); err != nil {
 			slog.Warn("skill_grants: scan error in ListAccessible", "error", err)
 			continue
 		}
-		result = append(result, buildSkillInfo("", name, slug, desc, version, s.baseDir, filePath))
+		info := buildSkillInfo("", name, slug, desc, version, s.baseDir, filePath)
+		info.PinnedVersion = pinned
+		result = append(result, info)
 	}
 	return result, rows.Err()
 }
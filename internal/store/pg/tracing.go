@@ -121,6 +121,11 @@ func buildTraceWhere(ctx context.Context, opts store.TraceListOpts) (string, []a
 		args = append(args, opts.Channel)
 		argIdx++
 	}
+	if opts.RequestID != "" {
+		conditions = append(conditions, fmt.Sprintf("metadata->>'request_id' = $%d", argIdx))
+		args = append(args, opts.RequestID)
+		argIdx++
+	}
 
 	where := ""
 	if len(conditions) > 0 {
@@ -297,7 +302,7 @@ func (s *PGTracingStore) BatchUpdateTraceAggregates(ctx context.Context, traceID
 			total_input_tokens = COALESCE((SELECT SUM(input_tokens) FROM spans WHERE trace_id = $1 AND span_type = 'llm_call' AND input_tokens IS NOT NULL), 0),
 			total_output_tokens = COALESCE((SELECT SUM(output_tokens) FROM spans WHERE trace_id = $1 AND span_type = 'llm_call' AND output_tokens IS NOT NULL), 0),
 			total_cost = COALESCE((SELECT SUM(total_cost) FROM spans WHERE trace_id = $1 AND total_cost IS NOT NULL), 0),
-			metadata = (
+			metadata = COALESCE(traces.metadata, '{}'::jsonb) || (
 				SELECT jsonb_build_object(
 					'total_cache_read_tokens', COALESCE(SUM((metadata->>'cache_read_tokens')::int), 0),
 					'total_cache_creation_tokens', COALESCE(SUM((metadata->>'cache_creation_tokens')::int), 0)
@@ -377,6 +382,90 @@ func (s *PGTracingStore) GetCostSummary(ctx context.Context, opts store.CostSumm
 	return result, nil
 }
 
+func (s *PGTracingStore) GetBillingUsage(ctx context.Context, opts store.BillingUsageOpts) ([]store.BillingUsageRow, error) {
+	conditions := []string{"s.span_type = 'llm_call'", "s.start_time >= $1", "s.start_time < $2"}
+	args := []any{opts.From, opts.To}
+
+	if !store.IsCrossTenant(ctx) {
+		tenantID := store.TenantIDFromContext(ctx)
+		if tenantID != uuid.Nil {
+			conditions = append(conditions, "s.tenant_id = $3")
+			args = append(args, tenantID)
+		}
+	}
+
+	q := `SELECT s.tenant_id, COALESCE(t.user_id, '') AS user_id, COALESCE(s.agent_id, t.agent_id) AS agent_id,
+		  COALESCE(s.provider, '') AS provider, COALESCE(s.model, '') AS model,
+		  COALESCE(SUM(s.input_tokens), 0) AS input_tokens,
+		  COALESCE(SUM(s.output_tokens), 0) AS output_tokens,
+		  COALESCE(SUM(s.total_cost), 0) AS cost,
+		  COUNT(*) AS calls
+		  FROM spans s JOIN traces t ON t.id = s.trace_id
+		  WHERE ` + strings.Join(conditions, " AND ") + `
+		  GROUP BY 1, 2, 3, 4, 5 ORDER BY 1, 2, 3, 4, 5`
+
+	var result []store.BillingUsageRow
+	if err := pkgSqlxDB.SelectContext(ctx, &result, q, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// usageGroupKeys maps a usage report grouping to its traces column.
+var usageGroupKeys = map[string]string{
+	store.UsageGroupRun:     "id::text",
+	store.UsageGroupSession: "COALESCE(session_key, '')",
+	store.UsageGroupAgent:   "COALESCE(agent_id::text, '')",
+	store.UsageGroupUser:    "COALESCE(user_id, '')",
+}
+
+func (s *PGTracingStore) GetUsageReport(ctx context.Context, opts store.UsageReportOpts) ([]store.UsageReportRow, error) {
+	key, ok := usageGroupKeys[opts.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown usage grouping %q", opts.GroupBy)
+	}
+	conditions := []string{"created_at >= $1", "created_at < $2"}
+	args := []any{opts.From, opts.To}
+	argIdx := 3
+
+	if !store.IsCrossTenant(ctx) {
+		tenantID := store.TenantIDFromContext(ctx)
+		if tenantID != uuid.Nil {
+			conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", argIdx))
+			args = append(args, tenantID)
+			argIdx++
+		}
+	}
+	if opts.AgentID != nil {
+		conditions = append(conditions, fmt.Sprintf("agent_id = $%d", argIdx))
+		args = append(args, *opts.AgentID)
+		argIdx++
+	}
+	if opts.UserID != "" {
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", argIdx))
+		args = append(args, opts.UserID)
+		argIdx++
+	}
+
+	q := `SELECT ` + key + ` AS key,
+		  COALESCE(SUM(total_input_tokens), 0) AS input_tokens,
+		  COALESCE(SUM(total_output_tokens), 0) AS output_tokens,
+		  COALESCE(SUM(total_cost), 0) AS cost,
+		  COUNT(*) AS runs, MAX(created_at) AS last_run_at
+		  FROM traces WHERE ` + strings.Join(conditions, " AND ") + `
+		  GROUP BY 1 ORDER BY cost DESC, 1`
+	if opts.Limit > 0 {
+		q += fmt.Sprintf(" LIMIT $%d", argIdx)
+		args = append(args, opts.Limit)
+	}
+
+	var result []store.UsageReportRow
+	if err := pkgSqlxDB.SelectContext(ctx, &result, q, args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // DeleteTracesOlderThan deletes traces and their spans older than cutoff.
 // Spans are deleted first (FK), then traces. Returns total traces deleted.
 func (s *PGTracingStore) DeleteTracesOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
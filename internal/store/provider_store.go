@@ -3,8 +3,11 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 
 	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/secrets"
 )
 
 // Provider type constants.
@@ -85,8 +88,9 @@ type LLMProviderData struct {
 	Settings     json.RawMessage `json:"settings,omitempty" db:"settings"`
 }
 
-// RequiredMemoryEmbeddingDimensions is the fixed vector size used by the pgvector memory schema.
-// All memory embeddings must match this dimensionality until the schema supports variable sizes.
+// RequiredMemoryEmbeddingDimensions is the vector size created by the pgvector migrations.
+// `goclaw memory reembed --dimensions` can resize the columns; MemoryEmbeddingDimensions
+// reports the live size.
 const RequiredMemoryEmbeddingDimensions = 1536
 
 // EmbeddingSettings holds embedding-specific configuration stored in provider settings JSONB.
@@ -94,7 +98,9 @@ type EmbeddingSettings struct {
 	Enabled    bool   `json:"enabled" db:"-"`
 	Model      string `json:"model,omitempty" db:"-"`      // e.g. "text-embedding-3-small"
 	APIBase    string `json:"api_base,omitempty" db:"-"`   // override if embedding endpoint differs from chat
-	Dimensions int    `json:"dimensions,omitempty" db:"-"` // truncate output to N dims (e.g. 1536); 0 = model default
+	Dimensions int    `json:"dimensions,omitempty" db:"-"` // requested output dims (≤ vector column size, shorter vectors are zero-padded); 0 = model default
+	Format     string `json:"format,omitempty" db:"-"`     // "openai", "voyage", "cohere", "ollama"; "" = detect from API base
+	BatchSize  int    `json:"batch_size,omitempty" db:"-"` // max texts per embedding request; 0 = format default
 }
 
 // ProviderReasoningConfig holds provider-owned default reasoning settings.
@@ -109,6 +115,23 @@ type ChatGPTOAuthProviderSettings struct {
 	CodexPool *ChatGPTOAuthRoutingConfig `json:"codex_pool,omitempty" db:"-"`
 }
 
+// OllamaProviderSettings holds native Ollama options stored in provider settings JSONB.
+type OllamaProviderSettings struct {
+	Model     string `json:"model,omitempty" db:"-"`      // default model
+	KeepAlive string `json:"keep_alive,omitempty" db:"-"` // e.g. "10m", "-1" (keep loaded), "0" (unload after call)
+	NumCtx    int    `json:"num_ctx,omitempty" db:"-"`    // context length per request; 0 = provider default
+}
+
+// ParseOllamaProviderSettings extracts Ollama options from a provider's settings JSONB.
+// Returns a zero value when unset or malformed.
+func ParseOllamaProviderSettings(settings json.RawMessage) OllamaProviderSettings {
+	var s OllamaProviderSettings
+	if len(settings) > 0 {
+		_ = json.Unmarshal(settings, &s)
+	}
+	return s
+}
+
 // ParseEmbeddingSettings extracts embedding config from a provider's settings JSONB.
 // Returns nil if not configured.
 func ParseEmbeddingSettings(settings json.RawMessage) *EmbeddingSettings {
@@ -191,3 +214,18 @@ type ProviderStore interface {
 	UpdateProvider(ctx context.Context, id uuid.UUID, updates map[string]any) error
 	DeleteProvider(ctx context.Context, id uuid.UUID) error
 }
+
+// ResolveProviderAPIKey returns the secret behind an api_key stored as a
+// secret://<backend>/<key> reference, or apiKey unchanged. An unresolvable
+// reference yields "" so it is never sent to the provider.
+func ResolveProviderAPIKey(apiKey, providerName string) string {
+	if !secrets.IsRef(apiKey) {
+		return apiKey
+	}
+	val, err := secrets.Resolve(context.Background(), apiKey)
+	if err != nil {
+		slog.Error("provider api key reference not resolved", "provider", providerName, "error", err)
+		return ""
+	}
+	return val
+}
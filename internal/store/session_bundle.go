@@ -0,0 +1,296 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/sessions"
+)
+
+// SessionBundleFormat identifies a session export file; SessionBundleVersion
+// is bumped on incompatible changes.
+const (
+	SessionBundleFormat  = "goclaw.session"
+	SessionBundleVersion = 1
+)
+
+const (
+	maxBundleEpisodes    = 1000  // episodic summaries an export scans for
+	maxBundleCompactions = 10000 // compaction count accepted on import
+)
+
+var (
+	ErrSessionBundleInvalid = errors.New("invalid session bundle")
+	ErrSessionExists        = errors.New("session already exists")
+	ErrSessionAgentNotFound = errors.New("agent for session not found")
+	ErrSessionImportDenied  = errors.New("session import not permitted")
+)
+
+// SessionBundle is the portable JSON form of one session, used to move a
+// conversation between gateways (desktop/SQLite ↔ PostgreSQL, or machine to
+// machine). Instance-specific IDs (agent/team UUIDs) are left out: the agent
+// is re-resolved from the agent key embedded in the session key on import.
+// Media files referenced by messages are not included.
+type SessionBundle struct {
+	Format     string                 `json:"format"`
+	Version    int                    `json:"version"`
+	ExportedAt time.Time              `json:"exportedAt"`
+	Session    SessionBundleSession   `json:"session"`
+	Episodic   []SessionBundleEpisode `json:"episodic,omitempty"`
+}
+
+// SessionBundleSession carries the session row: transcript, compaction
+// summary, label and metadata.
+type SessionBundleSession struct {
+	Key             string              `json:"key"`
+	AgentKey        string              `json:"agentKey"`
+	UserID          string              `json:"userId,omitempty"`
+	Label           string              `json:"label,omitempty"`
+	Channel         string              `json:"channel,omitempty"`
+	Model           string              `json:"model,omitempty"`
+	Provider        string              `json:"provider,omitempty"`
+	Summary         string              `json:"summary,omitempty"`
+	Messages        []providers.Message `json:"messages"`
+	Metadata        map[string]string   `json:"metadata,omitempty"`
+	InputTokens     int64               `json:"inputTokens,omitempty"`
+	OutputTokens    int64               `json:"outputTokens,omitempty"`
+	CompactionCount int                 `json:"compactionCount,omitempty"`
+	Created         time.Time           `json:"created"`
+	Updated         time.Time           `json:"updated"`
+}
+
+// SessionBundleEpisode is an episodic memory summary produced from the session.
+type SessionBundleEpisode struct {
+	Summary    string    `json:"summary"`
+	KeyTopics  []string  `json:"keyTopics,omitempty"`
+	L0Abstract string    `json:"l0Abstract,omitempty"`
+	SourceType string    `json:"sourceType,omitempty"`
+	SourceID   string    `json:"sourceId,omitempty"`
+	TurnCount  int       `json:"turnCount,omitempty"`
+	TokenCount int       `json:"tokenCount,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// SessionImportOpts controls ImportSessionBundle.
+type SessionImportOpts struct {
+	Key       string // import under this key instead of the bundle's (must use the same key format)
+	UserID    string // when set, a non-admin import: see ImportSessionBundle
+	Overwrite bool   // replace an existing session with the same key
+}
+
+// SessionImportResult reports what ImportSessionBundle wrote.
+type SessionImportResult struct {
+	Key      string `json:"key"`
+	Messages int    `json:"messages"`
+	Episodic int    `json:"episodic"`
+}
+
+// ExportSessionBundle builds a bundle for key. episodic may be nil. Returns
+// (nil, nil) when the session does not exist.
+func ExportSessionBundle(ctx context.Context, ss SessionStore, episodic EpisodicStore, key string) (*SessionBundle, error) {
+	sess := ss.Get(ctx, key)
+	if sess == nil {
+		return nil, nil
+	}
+	agentKey, _ := sessions.ParseSessionKey(key)
+	b := &SessionBundle{
+		Format:     SessionBundleFormat,
+		Version:    SessionBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Session: SessionBundleSession{
+			Key:             key,
+			AgentKey:        agentKey,
+			UserID:          sess.UserID,
+			Label:           sess.Label,
+			Channel:         sess.Channel,
+			Model:           sess.Model,
+			Provider:        sess.Provider,
+			Summary:         sess.Summary,
+			Messages:        sess.Messages,
+			Metadata:        sess.Metadata,
+			InputTokens:     sess.InputTokens,
+			OutputTokens:    sess.OutputTokens,
+			CompactionCount: sess.CompactionCount,
+			Created:         sess.Created,
+			Updated:         sess.Updated,
+		},
+	}
+	if b.Session.Messages == nil {
+		b.Session.Messages = []providers.Message{}
+	}
+
+	if episodic != nil && sess.AgentUUID != uuid.Nil {
+		eps, err := episodic.List(ctx, sess.AgentUUID.String(), sess.UserID, maxBundleEpisodes, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list episodic summaries: %w", err)
+		}
+		for _, ep := range eps {
+			if ep.SessionKey != key {
+				continue
+			}
+			b.Episodic = append(b.Episodic, SessionBundleEpisode{
+				Summary:    ep.Summary,
+				KeyTopics:  ep.KeyTopics,
+				L0Abstract: ep.L0Abstract,
+				SourceType: ep.SourceType,
+				SourceID:   ep.SourceID,
+				TurnCount:  ep.TurnCount,
+				TokenCount: ep.TokenCount,
+				CreatedAt:  ep.CreatedAt,
+			})
+		}
+	}
+	return b, nil
+}
+
+// Validate checks the bundle header and that the session key names an agent.
+func (b *SessionBundle) Validate() error {
+	if b.Format != SessionBundleFormat {
+		return fmt.Errorf("%w: format %q, want %q", ErrSessionBundleInvalid, b.Format, SessionBundleFormat)
+	}
+	if b.Version < 1 || b.Version > SessionBundleVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrSessionBundleInvalid, b.Version)
+	}
+	if agentKey, _ := sessions.ParseSessionKey(b.Session.Key); agentKey == "" {
+		return fmt.Errorf("%w: session key %q is not an agent session key", ErrSessionBundleInvalid, b.Session.Key)
+	}
+	if b.Session.InputTokens < 0 || b.Session.OutputTokens < 0 {
+		return fmt.Errorf("%w: negative token counts", ErrSessionBundleInvalid)
+	}
+	if b.Session.CompactionCount < 0 || b.Session.CompactionCount > maxBundleCompactions {
+		return fmt.Errorf("%w: compaction count %d out of range", ErrSessionBundleInvalid, b.Session.CompactionCount)
+	}
+	return nil
+}
+
+// memberImportKey picks the key a non-admin import by userID is written
+// under. A requested key, or else the bundle's, is kept when it is a DM key
+// whose peer is userID. An explicitly requested key for anyone else is
+// rejected; a bundle key for anyone else is replaced by a new web-chat key
+// for the same agent.
+func (b *SessionBundle) memberImportKey(requested, userID string) (string, error) {
+	if requested != "" {
+		if sessions.DirectPeerFromSessionKey(requested) != userID {
+			return "", fmt.Errorf("%w: session key %q belongs to another user", ErrSessionImportDenied, requested)
+		}
+		return requested, nil
+	}
+	if sessions.DirectPeerFromSessionKey(b.Session.Key) == userID {
+		return b.Session.Key, nil
+	}
+	agentKey, _ := sessions.ParseSessionKey(b.Session.Key)
+	return sessions.BuildWSSessionKey(agentKey, uuid.NewString()), nil
+}
+
+// ImportSessionBundle writes the bundle into ss under the current tenant.
+// The agent named by the session key must exist on this gateway. An existing
+// session is only replaced with opts.Overwrite. Episodic summaries are
+// imported when episodic is non-nil; duplicates (same source ID) are skipped
+// by the store. Created/updated timestamps are not preserved.
+//
+// With opts.UserID set (a non-admin caller) the session is imported as that
+// user, the user must have access to the agent, the key must be the user's
+// own DM key or is newly generated, and only the user's own sessions can be
+// overwritten; violations return ErrSessionImportDenied.
+func ImportSessionBundle(ctx context.Context, ss SessionStore, agents AgentStore, episodic EpisodicStore, b *SessionBundle, opts SessionImportOpts) (SessionImportResult, error) {
+	if err := b.Validate(); err != nil {
+		return SessionImportResult{}, err
+	}
+	key := b.Session.Key
+	if opts.Key != "" {
+		key = opts.Key
+	}
+	if opts.UserID != "" {
+		var err error
+		if key, err = b.memberImportKey(opts.Key, opts.UserID); err != nil {
+			return SessionImportResult{}, err
+		}
+	}
+	agentKey, _ := sessions.ParseSessionKey(key)
+	if agentKey == "" {
+		return SessionImportResult{}, fmt.Errorf("%w: session key %q is not an agent session key", ErrSessionBundleInvalid, key)
+	}
+	agent, err := agents.GetByKey(ctx, agentKey)
+	if err != nil || agent == nil {
+		return SessionImportResult{}, fmt.Errorf("%w: %s", ErrSessionAgentNotFound, agentKey)
+	}
+	userID := b.Session.UserID
+	if opts.UserID != "" {
+		userID = opts.UserID
+		if ok, _, _ := agents.CanAccess(ctx, agent.ID, userID); !ok {
+			return SessionImportResult{}, fmt.Errorf("%w: no access to agent %s", ErrSessionImportDenied, agentKey)
+		}
+	}
+
+	if existing := ss.Get(ctx, key); existing != nil {
+		if opts.UserID != "" && existing.UserID != opts.UserID {
+			return SessionImportResult{}, fmt.Errorf("%w: session %s belongs to another user", ErrSessionImportDenied, key)
+		}
+		if !opts.Overwrite {
+			return SessionImportResult{}, fmt.Errorf("%w: %s", ErrSessionExists, key)
+		}
+		if err := ss.Delete(ctx, key); err != nil {
+			return SessionImportResult{}, fmt.Errorf("replace session: %w", err)
+		}
+	}
+
+	s := b.Session
+	ss.GetOrCreate(ctx, key)
+	ss.SetAgentInfo(ctx, key, agent.ID, userID)
+	ss.SetHistory(ctx, key, s.Messages)
+	if s.Summary != "" {
+		ss.SetSummary(ctx, key, s.Summary)
+	}
+	if s.Label != "" {
+		ss.SetLabel(ctx, key, s.Label)
+	}
+	ss.UpdateMetadata(ctx, key, s.Model, s.Provider, s.Channel)
+	if len(s.Metadata) > 0 {
+		ss.SetSessionMetadata(ctx, key, s.Metadata)
+	}
+	if s.InputTokens > 0 || s.OutputTokens > 0 {
+		ss.AccumulateTokens(ctx, key, s.InputTokens, s.OutputTokens)
+	}
+	for range s.CompactionCount { // bounded by Validate
+		ss.IncrementCompaction(ctx, key)
+	}
+	if err := ss.Save(ctx, key); err != nil {
+		return SessionImportResult{}, fmt.Errorf("save session: %w", err)
+	}
+
+	res := SessionImportResult{Key: key, Messages: len(s.Messages)}
+	if episodic == nil {
+		return res, nil
+	}
+	tenantID := TenantIDFromContext(ctx)
+	if tenantID == uuid.Nil {
+		tenantID = MasterTenantID
+	}
+	for _, ep := range b.Episodic {
+		if ep.Summary == "" {
+			continue
+		}
+		if err := episodic.Create(ctx, &EpisodicSummary{
+			TenantID:   tenantID,
+			AgentID:    agent.ID,
+			UserID:     userID,
+			SessionKey: key,
+			Summary:    ep.Summary,
+			KeyTopics:  ep.KeyTopics,
+			L0Abstract: ep.L0Abstract,
+			SourceType: ep.SourceType,
+			SourceID:   ep.SourceID,
+			TurnCount:  ep.TurnCount,
+			TokenCount: ep.TokenCount,
+		}); err != nil {
+			return res, fmt.Errorf("import episodic summary: %w", err)
+		}
+		res.Episodic++
+	}
+	return res, nil
+}
@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// SessionMetaCheckpoints is the session metadata key holding the run
+// checkpoints (JSON array of SessionCheckpoint, oldest first).
+const SessionMetaCheckpoints = "checkpoints"
+
+// MaxSessionCheckpoints caps how many checkpoints a session keeps; older ones
+// are dropped first.
+const MaxSessionCheckpoints = 20
+
+var (
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrCheckpointStale means the history no longer matches the checkpoint
+	// (compacted, reset or edited since), so rewinding would cut the wrong
+	// messages.
+	ErrCheckpointStale = errors.New("checkpoint no longer matches session history")
+)
+
+// SessionCheckpoint marks a point in a session's history that /rewind can
+// return to. One is taken when a run starts (before the user's message) and
+// one before each tool iteration.
+type SessionCheckpoint struct {
+	ID          int       `json:"id"`
+	RunID       string    `json:"runId,omitempty"`
+	Iteration   int       `json:"iteration"`   // 0 = run start
+	Messages    int       `json:"messages"`    // history length kept on rewind
+	Compactions int       `json:"compactions"` // compaction count when taken
+	Anchor      string    `json:"anchor,omitempty"`
+	Label       string    `json:"label,omitempty"` // user message preview or tool names
+	Files       []string  `json:"files,omitempty"` // paths written by the tools that ran after it
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// SessionRewindResult reports what a rewind discarded.
+type SessionRewindResult struct {
+	Checkpoint SessionCheckpoint `json:"checkpoint"`
+	Removed    int               `json:"removed"`         // messages dropped
+	Files      []string          `json:"files,omitempty"` // files written after the checkpoint (not reverted)
+}
+
+// ListSessionCheckpoints returns the session's checkpoints, oldest first.
+func ListSessionCheckpoints(ctx context.Context, ss SessionStore, key string) []SessionCheckpoint {
+	if ss.Get(ctx, key) == nil { // also loads the session into the cache
+		return nil
+	}
+	return parseCheckpoints(ss.GetSessionMetadata(ctx, key))
+}
+
+// AddSessionCheckpoint stamps cp with the next ID, the current compaction
+// count and an anchor for its last kept message, then appends it. Checkpoints
+// that no longer match the history are dropped. The caller saves the session.
+func AddSessionCheckpoint(ctx context.Context, ss SessionStore, key string, cp SessionCheckpoint) SessionCheckpoint {
+	history := ss.GetHistory(ctx, key)
+	compactions := ss.GetCompactionCount(ctx, key)
+	if cp.Messages < 0 || cp.Messages > len(history) {
+		cp.Messages = len(history)
+	}
+	cp.Compactions = compactions
+	cp.Anchor = historyAnchor(history, cp.Messages)
+	if cp.CreatedAt.IsZero() {
+		cp.CreatedAt = time.Now().UTC()
+	}
+
+	var kept []SessionCheckpoint
+	for _, c := range parseCheckpoints(ss.GetSessionMetadata(ctx, key)) {
+		if c.ID >= cp.ID {
+			cp.ID = c.ID
+		}
+		if checkpointValid(c, history, compactions) {
+			kept = append(kept, c)
+		}
+	}
+	cp.ID++
+	kept = append(kept, cp)
+	if len(kept) > MaxSessionCheckpoints {
+		kept = kept[len(kept)-MaxSessionCheckpoints:]
+	}
+	setCheckpoints(ctx, ss, key, kept)
+	return cp
+}
+
+// RewindSession truncates the history back to checkpoint id and drops the
+// checkpoints taken after it. Files written after the checkpoint are listed in
+// the result but left on disk. The session is saved.
+func RewindSession(ctx context.Context, ss SessionStore, key string, id int) (SessionRewindResult, error) {
+	if ss.Get(ctx, key) == nil {
+		return SessionRewindResult{}, ErrCheckpointNotFound
+	}
+	cps := parseCheckpoints(ss.GetSessionMetadata(ctx, key))
+	idx := slices.IndexFunc(cps, func(c SessionCheckpoint) bool { return c.ID == id })
+	if idx < 0 {
+		return SessionRewindResult{}, ErrCheckpointNotFound
+	}
+	cp := cps[idx]
+	history := ss.GetHistory(ctx, key)
+	if !checkpointValid(cp, history, ss.GetCompactionCount(ctx, key)) {
+		return SessionRewindResult{}, ErrCheckpointStale
+	}
+
+	res := SessionRewindResult{Checkpoint: cp, Removed: len(history) - cp.Messages}
+	for _, c := range cps[idx:] {
+		for _, f := range c.Files {
+			if !slices.Contains(res.Files, f) {
+				res.Files = append(res.Files, f)
+			}
+		}
+	}
+
+	ss.SetHistory(ctx, key, slices.Clone(history[:cp.Messages]))
+	cps = cps[:idx+1]
+	cps[idx].Files = nil
+	setCheckpoints(ctx, ss, key, cps)
+	if err := ss.Save(ctx, key); err != nil {
+		return SessionRewindResult{}, err
+	}
+	return res, nil
+}
+
+// checkpointValid reports whether the history still starts with the messages
+// the checkpoint kept.
+func checkpointValid(cp SessionCheckpoint, history []providers.Message, compactions int) bool {
+	return cp.Compactions == compactions &&
+		cp.Messages <= len(history) &&
+		cp.Anchor == historyAnchor(history, cp.Messages)
+}
+
+// historyAnchor fingerprints the last message of history[:n].
+func historyAnchor(history []providers.Message, n int) string {
+	if n <= 0 || n > len(history) {
+		return ""
+	}
+	m := history[n-1]
+	sum := sha256.Sum256([]byte(m.Role + "\x00" + m.ToolCallID + "\x00" + m.Content + "\x00" + strconv.Itoa(len(m.ToolCalls))))
+	return hex.EncodeToString(sum[:8])
+}
+
+func parseCheckpoints(meta map[string]string) []SessionCheckpoint {
+	raw := meta[SessionMetaCheckpoints]
+	if raw == "" {
+		return nil
+	}
+	var cps []SessionCheckpoint
+	if err := json.Unmarshal([]byte(raw), &cps); err != nil {
+		return nil
+	}
+	return cps
+}
+
+func setCheckpoints(ctx context.Context, ss SessionStore, key string, cps []SessionCheckpoint) {
+	value := ""
+	if len(cps) > 0 {
+		b, _ := json.Marshal(cps)
+		value = string(b)
+	}
+	ss.SetSessionMetadata(ctx, key, map[string]string{SessionMetaCheckpoints: value})
+}
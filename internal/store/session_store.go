@@ -51,12 +51,39 @@ type SessionInfo struct {
 	Metadata     map[string]string `json:"metadata,omitempty" db:"metadata"`
 }
 
+// SessionMetaArchivedAt is the session metadata key holding the archive time
+// (RFC 3339). Archived sessions keep their history but are hidden from
+// listings unless SessionListOpts.Archived asks for them. An empty value
+// means not archived.
+const SessionMetaArchivedAt = "archived_at"
+
+// Session metadata keys written by the background session digest. digest is
+// a rolling summary of the whole conversation for listings (unlike
+// SessionData.Summary, which only covers compacted history and is fed back to
+// the model). digest_messages and digest_compactions record the history
+// length and compaction count at the last digest, so the next one knows how
+// many turns have passed.
+const (
+	SessionMetaDigest            = "digest"
+	SessionMetaDigestAt          = "digest_at"
+	SessionMetaDigestMessages    = "digest_messages"
+	SessionMetaDigestCompactions = "digest_compactions"
+)
+
+// Values for SessionListOpts.Archived.
+const (
+	SessionArchivedExclude = ""     // active sessions only (default)
+	SessionArchivedOnly    = "only" // archived sessions only
+	SessionArchivedInclude = "all"  // both
+)
+
 // SessionListOpts holds pagination options for ListPaged.
 type SessionListOpts struct {
 	AgentID  string    `db:"-"`
 	Channel  string    `db:"-"` // optional: filter by channel prefix ("ws", "telegram", etc.)
 	UserID   string    `db:"-"` // optional: filter by user_id
 	TenantID uuid.UUID `db:"-"` // optional: filter by tenant (uuid.Nil = no filter)
+	Archived string    `db:"-"` // SessionArchived* (default: exclude archived)
 	Limit    int       `db:"-"`
 	Offset   int       `db:"-"`
 }
@@ -130,6 +157,30 @@ type SessionListingStore interface {
 	LastUsedChannel(ctx context.Context, agentID string) (channel, chatID string)
 }
 
+// SessionFlusher is an optional interface for session stores that keep hot
+// sessions in memory. FlushAll persists every cached session; the gateway
+// calls it during graceful shutdown after in-flight runs have finished.
+type SessionFlusher interface {
+	FlushAll(ctx context.Context) error
+}
+
+// SessionPurger is an optional interface for session stores that can delete a
+// session together with the data derived from it: traces and their spans,
+// episodic memory summaries, evolution metrics and subagent task records.
+// Used by sessions.delete; plain Delete only removes the session row.
+type SessionPurger interface {
+	Purge(ctx context.Context, key string) (SessionPurgeResult, error)
+}
+
+// SessionPurgeResult counts the rows removed by SessionPurger.Purge.
+type SessionPurgeResult struct {
+	Traces       int64 `json:"traces"`
+	Spans        int64 `json:"spans"`
+	Episodic     int64 `json:"episodic"`
+	Metrics      int64 `json:"metrics"`
+	SubagentRuns int64 `json:"subagentTasks"`
+}
+
 // SessionStore composes all session sub-interfaces for backward compatibility.
 // New code should depend on the specific sub-interface it needs.
 type SessionStore interface {
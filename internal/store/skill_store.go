@@ -23,6 +23,9 @@ type SkillInfo struct {
 	Enabled     bool     `json:"enabled" db:"enabled"`
 	Author      string   `json:"author,omitempty" db:"author"`
 	MissingDeps []string `json:"missing_deps,omitempty" db:"missing_deps"`
+	// PinnedVersion is the version an agent grant pins (ListAccessible only).
+	// 0 means the agent follows the active version.
+	PinnedVersion int `json:"pinned_version,omitempty" db:"pinned_version"`
 }
 
 // SkillSearchResult is a scored skill returned from embedding search.
@@ -117,7 +120,7 @@ type SkillManageStore interface {
 	ListAllSystemSkills(ctx context.Context) []SkillInfo
 	ListSystemSkillDirs(ctx context.Context) map[string]string
 	StoreMissingDeps(ctx context.Context, id uuid.UUID, missing []string) error
-	// Grants
+	// Grants. GrantToAgent pins the agent to version; 0 follows the active version.
 	GrantToAgent(ctx context.Context, skillID, agentID uuid.UUID, version int, grantedBy string) error
 	RevokeFromAgent(ctx context.Context, skillID, agentID uuid.UUID) error
 	GrantToUser(ctx context.Context, skillID uuid.UUID, userID, grantedBy string) error
@@ -13,6 +13,10 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
+// activityTimeLayout matches the created_at default format so that range
+// filters compare correctly as strings.
+const activityTimeLayout = "2006-01-02T15:04:05.000Z"
+
 // SQLiteActivityStore implements store.ActivityStore backed by SQLite.
 type SQLiteActivityStore struct {
 	db *sql.DB
@@ -104,6 +108,18 @@ func buildActivityWhere(ctx context.Context, opts store.ActivityListOpts) (strin
 		conditions = append(conditions, "action = ?")
 		args = append(args, opts.Action)
 	}
+	if opts.ActionPrefix != "" {
+		conditions = append(conditions, "action LIKE ? ESCAPE '\\'")
+		args = append(args, escapeLike(opts.ActionPrefix)+"%")
+	}
+	if opts.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, opts.Since.UTC().Format(activityTimeLayout))
+	}
+	if opts.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, opts.Until.UTC().Format(activityTimeLayout))
+	}
 	if opts.EntityType != "" {
 		conditions = append(conditions, "entity_type = ?")
 		args = append(args, opts.EntityType)
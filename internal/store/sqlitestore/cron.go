@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/cron"
+	"github.com/nextlevelbuilder/goclaw/internal/safego"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
@@ -38,6 +39,9 @@ type SQLiteCronStore struct {
 
 	retryCfg  cron.RetryConfig
 	defaultTZ string
+
+	maxConcurrent int // cap on in-flight scheduled runs; 0 = unlimited
+	activeRuns    int // in-flight scheduled runs
 }
 
 func NewSQLiteCronStore(db *sql.DB) *SQLiteCronStore {
@@ -50,6 +54,12 @@ func (s *SQLiteCronStore) SetRetryConfig(cfg cron.RetryConfig) {
 	s.retryCfg = cfg
 }
 
+func (s *SQLiteCronStore) SetMaxConcurrentRuns(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxConcurrent = max(n, 0)
+}
+
 func (s *SQLiteCronStore) SetDefaultTimezone(tz string) {
 	if tz != "" {
 		if _, err := time.LoadLocation(tz); err != nil {
@@ -72,7 +82,8 @@ func (s *SQLiteCronStore) Start() error {
 	s.stop = make(chan struct{})
 	s.running = true
 	s.recomputeStaleJobs()
-	go s.runLoop()
+	stop := s.stop
+	go safego.Supervise(s.baseCtx, "cron", func(context.Context) { s.runLoop(stop) })
 	slog.Info("sqlite cron service started")
 	return nil
 }
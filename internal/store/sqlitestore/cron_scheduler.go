@@ -5,7 +5,6 @@ package sqlitestore
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"log/slog"
 	"time"
 
@@ -151,12 +150,12 @@ func (s *SQLiteCronStore) recomputeStaleJobs() {
 	}
 }
 
-func (s *SQLiteCronStore) runLoop() {
+func (s *SQLiteCronStore) runLoop(stop chan struct{}) {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 	for {
 		select {
-		case <-s.stop:
+		case <-stop:
 			return
 		case <-ticker.C:
 			s.safeCheckAndRunDueJobs()
@@ -167,11 +166,7 @@ func (s *SQLiteCronStore) runLoop() {
 // safeCheckAndRunDueJobs wraps checkAndRunDueJobs with panic recovery
 // so a panic in any check/claim logic doesn't kill the runLoop goroutine.
 func (s *SQLiteCronStore) safeCheckAndRunDueJobs() {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("cron: checkAndRunDueJobs panicked — runLoop continues", "panic", fmt.Sprint(r))
-		}
-	}()
+	defer safego.Recover(nil, "component", "cron")
 	s.checkAndRunDueJobs()
 }
 
@@ -183,15 +178,24 @@ func (s *SQLiteCronStore) checkAndRunDueJobs() {
 
 	s.mu.Lock()
 	handler := s.onJob
+	slots := len(dueJobs)
+	if s.maxConcurrent > 0 {
+		slots = min(slots, s.maxConcurrent-s.activeRuns)
+	}
 	s.mu.Unlock()
 
-	if handler == nil {
+	if handler == nil || slots <= 0 {
 		return
 	}
 
+	// Claim no more jobs than there are free slots. Unclaimed jobs keep their
+	// next_run_at and are picked up once running jobs finish.
 	now := time.Now()
 	var claimedJobs []store.CronJob
 	for _, job := range dueJobs {
+		if len(claimedJobs) == slots {
+			break
+		}
 		if id, parseErr := uuid.Parse(job.ID); parseErr == nil && s.claimDueJob(id, now) {
 			claimedJobs = append(claimedJobs, job)
 		}
@@ -199,6 +203,9 @@ func (s *SQLiteCronStore) checkAndRunDueJobs() {
 	if len(claimedJobs) == 0 {
 		return
 	}
+	s.mu.Lock()
+	s.activeRuns += len(claimedJobs)
+	s.mu.Unlock()
 
 	// Execute jobs in parallel without blocking the runLoop.
 	// Previously wg.Wait() blocked here — if any job hung (e.g. LLM timeout,
@@ -206,6 +213,7 @@ func (s *SQLiteCronStore) checkAndRunDueJobs() {
 	// due jobs. Now each job runs independently; cache is invalidated per-job.
 	for _, job := range claimedJobs {
 		go func(job store.CronJob) {
+			defer s.releaseRun()
 			defer safego.Recover(nil, "component", "cron_job", "job_id", job.ID, "job_name", job.Name)
 			defer s.InvalidateCache()
 			s.executeOneJob(job, handler, true)
@@ -213,6 +221,13 @@ func (s *SQLiteCronStore) checkAndRunDueJobs() {
 	}
 }
 
+// releaseRun frees a slot taken by checkAndRunDueJobs.
+func (s *SQLiteCronStore) releaseRun() {
+	s.mu.Lock()
+	s.activeRuns--
+	s.mu.Unlock()
+}
+
 // executeOneJob runs a claimed job. When reloadClaimed is true (scheduler path),
 // it re-reads the job from DB to verify claim invariants (enabled + next_run_at IS NULL).
 // When false (manual RunJob path), it uses the already-loaded job directly.
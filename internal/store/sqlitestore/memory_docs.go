@@ -299,6 +299,33 @@ func (s *SQLiteMemoryStore) ListAllDocuments(ctx context.Context, agentID string
 	return result, nil
 }
 
+// Stats returns document/chunk counts for an agent across all users.
+// EmbeddedChunks is always 0 — no embedding column in SQLite.
+func (s *SQLiteMemoryStore) Stats(ctx context.Context, agentID string) (*store.MemoryStats, error) {
+	tc, tcArgs, err := scopeClause(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]any{agentID}, tcArgs...)
+
+	stats := &store.MemoryStats{}
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM memory_documents WHERE agent_id = ?`+tc, args...,
+	).Scan(&stats.Documents); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM memory_chunks WHERE agent_id = ?`+tc, args...,
+	).Scan(&stats.Chunks); err != nil {
+		return nil, err
+	}
+	if s.provider != nil {
+		stats.EmbeddingProvider = s.provider.Name()
+		stats.EmbeddingModel = s.provider.Model()
+	}
+	return stats, nil
+}
+
 // GetDocumentDetail returns full document info with chunk count.
 // EmbeddedCount is always 0 (no embedding column in SQLite).
 func (s *SQLiteMemoryStore) GetDocumentDetail(ctx context.Context, agentID, userID, path string) (*store.DocumentDetail, error) {
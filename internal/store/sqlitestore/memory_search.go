@@ -18,7 +18,8 @@ func (s *SQLiteMemoryStore) Search(ctx context.Context, query string, agentID, u
 		maxResults = s.cfg.MaxResults
 	}
 
-	results, err := s.likeSearch(ctx, query, agentID, userID, maxResults*2)
+	candidates := opts.CandidateLimit(maxResults)
+	results, err := s.likeSearch(ctx, query, agentID, userID, max(maxResults*2, candidates))
 	if err != nil {
 		return nil, err
 	}
@@ -33,11 +34,13 @@ func (s *SQLiteMemoryStore) Search(ctx context.Context, query string, agentID, u
 			continue
 		}
 		filtered = append(filtered, r)
-		if len(filtered) >= maxResults {
+		if len(filtered) >= candidates {
 			break
 		}
 	}
-	return filtered, nil
+	// Optional rerank stage; also caps at maxResults. LIKE scores are flat
+	// (1.0 / 1.2), so this is where ties get broken.
+	return store.RerankResults(ctx, opts, query, filtered, maxResults), nil
 }
 
 // likeSearch performs a case-insensitive LIKE search across chunk text.
@@ -18,21 +18,35 @@ import (
 )
 
 const (
-	codeAlphabet         = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
-	codeLength           = 8
-	codeTTL              = 60 * time.Minute
-	pairedDeviceTTL      = 30 * 24 * time.Hour
-	maxPendingPerAccount = 3
+	codeAlphabet           = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength             = 8
+	defaultCodeTTL         = 60 * time.Minute
+	defaultPairedDeviceTTL = 30 * 24 * time.Hour
+	maxPendingPerAccount   = 3
 )
 
 // SQLitePairingStore implements store.PairingStore backed by SQLite.
 type SQLitePairingStore struct {
 	db        *sql.DB
 	onRequest func(code, senderID, channel, chatID string)
+	codeTTL   time.Duration
+	pairedTTL time.Duration // <= 0: paired devices never expire
 }
 
 func NewSQLitePairingStore(db *sql.DB) *SQLitePairingStore {
-	return &SQLitePairingStore{db: db}
+	return &SQLitePairingStore{db: db, codeTTL: defaultCodeTTL, pairedTTL: defaultPairedDeviceTTL}
+}
+
+// SetTTLs overrides how long pairing codes and approved pairings stay valid.
+// A zero codeTTL keeps the default; a negative pairedTTL never expires
+// approved pairings.
+func (s *SQLitePairingStore) SetTTLs(codeTTL, pairedTTL time.Duration) {
+	if codeTTL > 0 {
+		s.codeTTL = codeTTL
+	}
+	if pairedTTL != 0 {
+		s.pairedTTL = pairedTTL
+	}
 }
 
 func (s *SQLitePairingStore) SetOnRequest(cb func(code, senderID, channel, chatID string)) {
@@ -66,7 +80,7 @@ func (s *SQLitePairingStore) RequestPairing(ctx context.Context, senderID, chann
 	_, err = s.db.ExecContext(ctx,
 		`INSERT INTO pairing_requests (id, code, sender_id, channel, chat_id, account_id, expires_at, created_at, metadata, tenant_id)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		uuid.Must(uuid.NewV7()), code, senderID, channel, chatID, accountID, now.Add(codeTTL).Round(0), now, metaJSON, tid,
+		uuid.Must(uuid.NewV7()), code, senderID, channel, chatID, accountID, now.Add(s.codeTTL).Round(0), now, metaJSON, tid,
 	)
 	if err != nil {
 		return "", fmt.Errorf("create pairing request: %w", err)
@@ -93,9 +107,22 @@ func (s *SQLitePairingStore) ApprovePairing(ctx context.Context, code, approvedB
 		return nil, fmt.Errorf("pairing code %s not found or expired", code)
 	}
 
-	s.db.ExecContext(ctx, "DELETE FROM pairing_requests WHERE id = ?", reqID)
+	// Only the caller that deletes the row may approve, so a code cannot be
+	// used twice by concurrent approvals.
+	res, err := s.db.ExecContext(ctx, "DELETE FROM pairing_requests WHERE id = ?", reqID)
+	if err != nil {
+		return nil, fmt.Errorf("consume pairing code: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, fmt.Errorf("pairing code %s not found or expired", code)
+	}
 
-	expiresAt := now.Add(pairedDeviceTTL)
+	var expiresAt *time.Time
+	var expiresAtMillis int64
+	if s.pairedTTL > 0 {
+		t := now.Add(s.pairedTTL)
+		expiresAt, expiresAtMillis = &t, t.UnixMilli()
+	}
 	_, err = s.db.ExecContext(ctx,
 		`INSERT INTO paired_devices (id, sender_id, channel, chat_id, paired_by, paired_at, metadata, expires_at, tenant_id)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
@@ -111,12 +138,13 @@ func (s *SQLitePairingStore) ApprovePairing(ctx context.Context, code, approvedB
 	}
 
 	return &store.PairedDeviceData{
-		SenderID: senderID,
-		Channel:  channel,
-		ChatID:   chatID,
-		PairedAt: now.UnixMilli(),
-		PairedBy: approvedBy,
-		Metadata: meta,
+		SenderID:  senderID,
+		Channel:   channel,
+		ChatID:    chatID,
+		PairedAt:  now.UnixMilli(),
+		PairedBy:  approvedBy,
+		ExpiresAt: expiresAtMillis,
+		Metadata:  meta,
 	}, nil
 }
 
@@ -204,7 +232,7 @@ func (s *SQLitePairingStore) ListPaired(ctx context.Context) []store.PairedDevic
 	s.db.ExecContext(ctx, "DELETE FROM paired_devices WHERE expires_at IS NOT NULL AND expires_at < ?", now)
 
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT sender_id, channel, chat_id, paired_by, paired_at, COALESCE(metadata, '{}')
+		`SELECT sender_id, channel, chat_id, paired_by, paired_at, expires_at, COALESCE(metadata, '{}')
 		 FROM paired_devices WHERE tenant_id = ? ORDER BY paired_at DESC`, tid)
 	if err != nil {
 		return nil
@@ -215,12 +243,16 @@ func (s *SQLitePairingStore) ListPaired(ctx context.Context) []store.PairedDevic
 	for rows.Next() {
 		var d store.PairedDeviceData
 		var pairedAtStr string
+		var expiresAtStr sql.NullString
 		var metaJSON []byte
-		if err := rows.Scan(&d.SenderID, &d.Channel, &d.ChatID, &d.PairedBy, &pairedAtStr, &metaJSON); err != nil {
+		if err := rows.Scan(&d.SenderID, &d.Channel, &d.ChatID, &d.PairedBy, &pairedAtStr, &expiresAtStr, &metaJSON); err != nil {
 			slog.Warn("pairing: scan paired error", "error", err)
 			continue
 		}
 		d.PairedAt = parseTimeToMillis(pairedAtStr)
+		if expiresAtStr.Valid {
+			d.ExpiresAt = parseTimeToMillis(expiresAtStr.String)
+		}
 		if len(metaJSON) > 0 {
 			json.Unmarshal(metaJSON, &d.Metadata)
 		}
@@ -0,0 +1,72 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func newTestPairingStore(t *testing.T) (*SQLitePairingStore, context.Context) {
+	t.Helper()
+	db := openTestDB(t)
+	if err := EnsureSchema(db); err != nil {
+		t.Fatalf("EnsureSchema error: %v", err)
+	}
+	return NewSQLitePairingStore(db), store.WithTenantID(context.Background(), store.MasterTenantID)
+}
+
+func TestSQLitePairingStore_ApproveOnceAndExpiry(t *testing.T) {
+	ps, ctx := newTestPairingStore(t)
+	ps.SetTTLs(5*time.Minute, 24*time.Hour)
+
+	code, err := ps.RequestPairing(ctx, "u1", "telegram", "c1", "default", nil)
+	if err != nil {
+		t.Fatalf("RequestPairing: %v", err)
+	}
+	pending := ps.ListPending(ctx)
+	if len(pending) != 1 || time.Until(time.UnixMilli(pending[0].ExpiresAt)) > 5*time.Minute {
+		t.Fatalf("pending = %+v", pending)
+	}
+
+	paired, err := ps.ApprovePairing(ctx, code, "admin")
+	if err != nil {
+		t.Fatalf("ApprovePairing: %v", err)
+	}
+	if left := time.Until(time.UnixMilli(paired.ExpiresAt)); left < 23*time.Hour || left > 24*time.Hour {
+		t.Errorf("paired ExpiresAt in %v, want ~24h", left)
+	}
+	if _, err := ps.ApprovePairing(ctx, code, "admin"); err == nil {
+		t.Error("code approved twice")
+	}
+
+	list := ps.ListPaired(ctx)
+	if len(list) != 1 || list[0].ExpiresAt == 0 || list[0].PairedBy != "admin" {
+		t.Errorf("ListPaired = %+v", list)
+	}
+	if ok, err := ps.IsPaired(ctx, "u1", "telegram"); err != nil || !ok {
+		t.Errorf("IsPaired = %v, %v", ok, err)
+	}
+}
+
+func TestSQLitePairingStore_NoDeviceExpiry(t *testing.T) {
+	ps, ctx := newTestPairingStore(t)
+	ps.SetTTLs(0, -1)
+
+	code, err := ps.RequestPairing(ctx, "u2", "discord", "c2", "default", nil)
+	if err != nil {
+		t.Fatalf("RequestPairing: %v", err)
+	}
+	if _, err := ps.ApprovePairing(ctx, code, "admin"); err != nil {
+		t.Fatalf("ApprovePairing: %v", err)
+	}
+	if list := ps.ListPaired(ctx); len(list) != 1 || list[0].ExpiresAt != 0 {
+		t.Errorf("ListPaired = %+v, want no expiry", list)
+	}
+	if ok, _ := ps.IsPaired(ctx, "u2", "discord"); !ok {
+		t.Error("not paired")
+	}
+}
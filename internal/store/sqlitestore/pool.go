@@ -8,8 +8,11 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	_ "modernc.org/sqlite" // pure-Go SQLite driver
+
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 )
 
 // connectionPragmas are applied to EVERY new SQLite connection.
@@ -20,10 +23,25 @@ var connectionPragmas = []string{
 	"PRAGMA journal_mode = WAL",
 	"PRAGMA busy_timeout = 15000",
 	"PRAGMA synchronous = NORMAL",
-	"PRAGMA cache_size = -8000", // 8MB cache
 	"PRAGMA foreign_keys = ON",
 }
 
+// profilePragmas returns the memory-related PRAGMAs for the active runtime
+// profile: page cache size and, on constrained profiles, a soft heap limit
+// so SQLite releases cache memory instead of growing past the ceiling.
+func profilePragmas(p runtimeprofile.Profile) []string {
+	pragmas := slices.Clone(connectionPragmas)
+	cacheKB := p.SQLiteCacheKB
+	if cacheKB <= 0 {
+		cacheKB = 8000 // 8MB cache
+	}
+	pragmas = append(pragmas, fmt.Sprintf("PRAGMA cache_size = -%d", cacheKB))
+	if p.SQLiteHeapLimitMB > 0 {
+		pragmas = append(pragmas, fmt.Sprintf("PRAGMA soft_heap_limit = %d", int64(p.SQLiteHeapLimitMB)<<20))
+	}
+	return pragmas
+}
+
 // pragmaConnector wraps a sql.Driver to apply PRAGMAs on every new connection.
 // This ensures ALL connections in the pool have busy_timeout, WAL mode, etc.
 type pragmaConnector struct {
@@ -65,8 +83,10 @@ func (c *pragmaConnector) Driver() driver.Driver { return c.driver }
 //
 // PRAGMAs are applied per-connection via pragmaConnector, ensuring every
 // connection in the pool has consistent settings (busy_timeout, WAL, etc.).
+// Cache size, heap limit and pool size come from runtimeprofile.Current().
 func OpenDB(path string) (*sql.DB, error) {
 	dsn := fmt.Sprintf("file:%s?_txlock=immediate", path)
+	profile := runtimeprofile.Current()
 
 	// Get the registered driver to wrap with pragmaConnector.
 	drv, err := getSQLiteDriver()
@@ -77,13 +97,17 @@ func OpenDB(path string) (*sql.DB, error) {
 	db := sql.OpenDB(&pragmaConnector{
 		driver:  drv,
 		dsn:     dsn,
-		pragmas: connectionPragmas,
+		pragmas: profilePragmas(profile),
 	})
 
 	// SQLite is single-writer; WAL allows concurrent readers.
-	// 4 connections: up to 3 readers + 1 writer can proceed in parallel,
-	// reducing connection pool starvation during concurrent operations.
-	db.SetMaxOpenConns(4)
+	// 4 connections (standard profile): up to 3 readers + 1 writer can proceed
+	// in parallel, reducing connection pool starvation during concurrent operations.
+	maxConns := profile.SQLiteMaxConns
+	if maxConns <= 0 {
+		maxConns = 4
+	}
+	db.SetMaxOpenConns(maxConns)
 
 	// Verify connection works (also triggers first pragma application).
 	if err := db.Ping(); err != nil {
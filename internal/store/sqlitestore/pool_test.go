@@ -0,0 +1,41 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
+)
+
+// TestOpenDB_AppliesRuntimeProfile verifies the lite profile shrinks the page
+// cache, sets a soft heap limit and narrows the pool on every connection.
+func TestOpenDB_AppliesRuntimeProfile(t *testing.T) {
+	original := runtimeprofile.Current()
+	t.Cleanup(func() { runtimeprofile.SetCurrent(original) })
+	runtimeprofile.SetCurrent(runtimeprofile.Lite)
+
+	db, err := OpenDB(filepath.Join(t.TempDir(), "lite.db"))
+	if err != nil {
+		t.Fatalf("OpenDB: %v", err)
+	}
+	defer db.Close()
+
+	var cacheSize, heapLimit int64
+	if err := db.QueryRow("PRAGMA cache_size").Scan(&cacheSize); err != nil {
+		t.Fatalf("cache_size: %v", err)
+	}
+	if cacheSize != -int64(runtimeprofile.Lite.SQLiteCacheKB) {
+		t.Errorf("cache_size = %d, want -%d", cacheSize, runtimeprofile.Lite.SQLiteCacheKB)
+	}
+	if err := db.QueryRow("PRAGMA soft_heap_limit").Scan(&heapLimit); err != nil {
+		t.Fatalf("soft_heap_limit: %v", err)
+	}
+	if want := int64(runtimeprofile.Lite.SQLiteHeapLimitMB) << 20; heapLimit != want {
+		t.Errorf("soft_heap_limit = %d, want %d", heapLimit, want)
+	}
+	if got := db.Stats().MaxOpenConnections; got != runtimeprofile.Lite.SQLiteMaxConns {
+		t.Errorf("max open conns = %d, want %d", got, runtimeprofile.Lite.SQLiteMaxConns)
+	}
+}
@@ -183,9 +183,9 @@ func (s *SQLiteProviderStore) decryptKey(apiKey, providerName string) string {
 			slog.Warn("failed to decrypt provider API key", "provider", providerName, "error", err)
 			return apiKey
 		}
-		return decrypted
+		apiKey = decrypted
 	}
-	return apiKey
+	return store.ResolveProviderAPIKey(apiKey, providerName)
 }
 
 func (s *SQLiteProviderStore) convertAndDecryptProviders(rows []providerRow) []store.LLMProviderData {
@@ -16,7 +16,7 @@ var schemaSQL string
 
 // SchemaVersion is the current SQLite schema version.
 // Bump this when adding new migration steps below.
-const SchemaVersion = 25
+const SchemaVersion = 26
 
 // migrations maps version → SQL to apply when upgrading FROM that version.
 // schema.sql always represents the LATEST full schema (for fresh DBs).
@@ -501,6 +501,9 @@ CREATE TRIGGER IF NOT EXISTS trg_vault_docs_scope_consistency_upd
 	// SQLite lacks regex by default — skip backfill (desktop is single-user; cross-chat risk minimal).
 	24: `ALTER TABLE vault_documents ADD COLUMN chat_id TEXT;
 CREATE INDEX IF NOT EXISTS idx_vault_docs_team_chat ON vault_documents(team_id, chat_id) WHERE team_id IS NOT NULL;`,
+	// Version 25 → 26: pinned_version 0 = follow the active version (mirrors PG migration 000058).
+	// Existing grants only recorded the version at grant time, so they follow the active version.
+	25: `UPDATE skill_agent_grants SET pinned_version = 0;`,
 }
 
 // addHooksTables is the SQLite incremental migration for schema v19 → v20.
@@ -405,7 +405,7 @@ CREATE TABLE IF NOT EXISTS skill_agent_grants (
     id             TEXT NOT NULL PRIMARY KEY,
     skill_id       TEXT NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
     agent_id       TEXT NOT NULL REFERENCES agents(id) ON DELETE CASCADE,
-    pinned_version INT NOT NULL,
+    pinned_version INT NOT NULL DEFAULT 0, -- 0 = follow the active version
     granted_by     VARCHAR(255) NOT NULL,
     tenant_id      TEXT NOT NULL REFERENCES tenants(id),
     created_at     TEXT DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
@@ -6,6 +6,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"maps"
 	"strconv"
 	"strings"
@@ -41,6 +43,30 @@ func sessionCacheKey(ctx context.Context, key string) string {
 	return tid.String() + ":" + key
 }
 
+// FlushAll saves every cached session. Cache keys carry the tenant prefix,
+// so each Save runs under that tenant's context.
+func (s *SQLiteSessionStore) FlushAll(ctx context.Context) error {
+	s.mu.RLock()
+	cacheKeys := make([]string, 0, len(s.cache))
+	for ck := range s.cache {
+		cacheKeys = append(cacheKeys, ck)
+	}
+	s.mu.RUnlock()
+
+	var errs []error
+	for _, ck := range cacheKeys {
+		tidStr, key, ok := strings.Cut(ck, ":")
+		tid, err := uuid.Parse(tidStr)
+		if !ok || err != nil {
+			continue
+		}
+		if err := s.Save(store.WithTenantID(ctx, tid), key); err != nil {
+			errs = append(errs, fmt.Errorf("save session %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (s *SQLiteSessionStore) GetOrCreate(ctx context.Context, key string) *store.SessionData {
 	s.mu.Lock()
 	defer s.mu.Unlock()
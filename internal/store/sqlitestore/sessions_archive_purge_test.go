@@ -0,0 +1,78 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestSessionListArchivedFilter_SQLite(t *testing.T) {
+	db := openTestDB(t)
+	if err := EnsureSchema(db); err != nil {
+		t.Fatalf("EnsureSchema: %v", err)
+	}
+	ss := NewSQLiteSessionStore(db)
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+
+	ss.GetOrCreate(ctx, "agent:a:ws:direct:active")
+	ss.Save(ctx, "agent:a:ws:direct:active")
+	ss.GetOrCreate(ctx, "agent:a:ws:direct:old")
+	ss.SetSessionMetadata(ctx, "agent:a:ws:direct:old", map[string]string{store.SessionMetaArchivedAt: "2026-01-02T03:04:05Z"})
+	ss.Save(ctx, "agent:a:ws:direct:old")
+
+	cases := []struct {
+		archived string
+		want     int
+	}{
+		{store.SessionArchivedExclude, 1},
+		{store.SessionArchivedOnly, 1},
+		{store.SessionArchivedInclude, 2},
+	}
+	for _, c := range cases {
+		res := ss.ListPaged(ctx, store.SessionListOpts{AgentID: "a", Archived: c.archived, Limit: 10, TenantID: store.MasterTenantID})
+		if res.Total != c.want {
+			t.Errorf("Archived=%q: total = %d, want %d", c.archived, res.Total, c.want)
+		}
+	}
+}
+
+func TestSessionPurge_SQLite(t *testing.T) {
+	db := openTestDB(t)
+	if err := EnsureSchema(db); err != nil {
+		t.Fatalf("EnsureSchema: %v", err)
+	}
+	ss := NewSQLiteSessionStore(db)
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	const key = "agent:a:ws:direct:purge-me"
+
+	ss.GetOrCreate(ctx, key)
+	ss.Save(ctx, key)
+	mustExec := func(q string, args ...any) {
+		t.Helper()
+		if _, err := db.Exec(q, args...); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+	}
+	mustExec(`INSERT INTO traces (id, session_key, tenant_id) VALUES ('t1', ?, ?), ('t2', 'agent:a:ws:direct:keep', ?)`,
+		key, store.MasterTenantID, store.MasterTenantID)
+	mustExec(`INSERT INTO spans (id, trace_id, span_type, tenant_id) VALUES ('s1', 't1', 'llm_call', ?), ('s2', 't1', 'tool_call', ?), ('s3', 't2', 'llm_call', ?)`,
+		store.MasterTenantID, store.MasterTenantID, store.MasterTenantID)
+
+	res, err := ss.Purge(ctx, key)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if res.Traces != 1 || res.Spans != 2 {
+		t.Fatalf("purge result = %+v, want 1 trace and 2 spans", res)
+	}
+	if ss.Get(ctx, key) != nil {
+		t.Fatal("session should be gone after purge")
+	}
+	var left int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM spans`).Scan(&left); err != nil || left != 1 {
+		t.Fatalf("spans of other sessions must survive: count=%d err=%v", left, err)
+	}
+}
@@ -0,0 +1,188 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// bundleTestDB opens a fresh database with one agent keyed "assistant".
+func bundleTestDB(t *testing.T) (*sql.DB, uuid.UUID) {
+	t.Helper()
+	db := openTestDB(t)
+	if err := EnsureSchema(db); err != nil {
+		t.Fatalf("EnsureSchema: %v", err)
+	}
+	agentID := uuid.New()
+	if _, err := db.Exec(
+		`INSERT INTO agents (id, tenant_id, agent_key, display_name, agent_type, status, provider, model, owner_id)
+		 VALUES (?,?,'assistant','Assistant','predefined','active','test','test-model','owner')`,
+		agentID.String(), store.MasterTenantID.String()); err != nil {
+		t.Fatalf("seed agent: %v", err)
+	}
+	return db, agentID
+}
+
+func TestSessionBundle_RoundTripBetweenInstances(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	const key = "agent:assistant:telegram:direct:42"
+
+	// Source instance.
+	srcDB, srcAgent := bundleTestDB(t)
+	src := NewSQLiteSessionStore(srcDB)
+	srcEpisodic := NewSQLiteEpisodicStore(srcDB)
+	src.GetOrCreate(ctx, key)
+	src.SetAgentInfo(ctx, key, srcAgent, "42")
+	src.AddMessage(ctx, key, providers.Message{Role: "user", Content: "hello"})
+	src.AddMessage(ctx, key, providers.Message{Role: "assistant", Content: "hi there"})
+	src.SetSummary(ctx, key, "greeting exchange")
+	src.SetLabel(ctx, key, "First chat")
+	src.SetSessionMetadata(ctx, key, map[string]string{"topic": "greetings"})
+	if err := src.Save(ctx, key); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := srcEpisodic.Create(ctx, &store.EpisodicSummary{
+		TenantID: store.MasterTenantID, AgentID: srcAgent, UserID: "42", SessionKey: key,
+		Summary: "User said hello.", SourceType: "session", SourceID: "src-1",
+	}); err != nil {
+		t.Fatalf("seed episodic: %v", err)
+	}
+
+	bundle, err := store.ExportSessionBundle(ctx, src, srcEpisodic, key)
+	if err != nil || bundle == nil {
+		t.Fatalf("ExportSessionBundle: bundle=%v err=%v", bundle, err)
+	}
+	raw, _ := json.Marshal(bundle)
+	var decoded store.SessionBundle
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal bundle: %v", err)
+	}
+
+	// Destination instance: same agent key, different agent UUID.
+	dstDB, dstAgent := bundleTestDB(t)
+	dst := NewSQLiteSessionStore(dstDB)
+	dstEpisodic := NewSQLiteEpisodicStore(dstDB)
+	res, err := store.ImportSessionBundle(ctx, dst, NewSQLiteAgentStore(dstDB), dstEpisodic, &decoded, store.SessionImportOpts{})
+	if err != nil {
+		t.Fatalf("ImportSessionBundle: %v", err)
+	}
+	if res.Messages != 2 || res.Episodic != 1 {
+		t.Fatalf("import result = %+v", res)
+	}
+
+	// Read back from a fresh store so nothing comes from the write cache.
+	got := NewSQLiteSessionStore(dstDB).Get(ctx, key)
+	if got == nil {
+		t.Fatal("imported session not found")
+	}
+	if len(got.Messages) != 2 || got.Messages[1].Content != "hi there" {
+		t.Errorf("messages = %+v", got.Messages)
+	}
+	if got.Summary != "greeting exchange" || got.Label != "First chat" || got.Metadata["topic"] != "greetings" {
+		t.Errorf("summary=%q label=%q metadata=%v", got.Summary, got.Label, got.Metadata)
+	}
+	if got.AgentUUID != dstAgent || got.UserID != "42" {
+		t.Errorf("agent=%s user=%q, want agent %s user 42", got.AgentUUID, got.UserID, dstAgent)
+	}
+	eps, err := dstEpisodic.List(ctx, dstAgent.String(), "42", 10, 0)
+	if err != nil || len(eps) != 1 || eps[0].SessionKey != key {
+		t.Errorf("episodic after import = %+v (err %v)", eps, err)
+	}
+
+	// A second import needs overwrite.
+	if _, err := store.ImportSessionBundle(ctx, dst, NewSQLiteAgentStore(dstDB), nil, &decoded, store.SessionImportOpts{}); !errors.Is(err, store.ErrSessionExists) {
+		t.Fatalf("re-import without overwrite: err = %v, want ErrSessionExists", err)
+	}
+	if _, err := store.ImportSessionBundle(ctx, dst, NewSQLiteAgentStore(dstDB), nil, &decoded, store.SessionImportOpts{Overwrite: true}); err != nil {
+		t.Fatalf("re-import with overwrite: %v", err)
+	}
+}
+
+func TestSessionBundle_RejectsUnknownAgentAndFormat(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	db, _ := bundleTestDB(t)
+	ss := NewSQLiteSessionStore(db)
+	agents := NewSQLiteAgentStore(db)
+
+	b := &store.SessionBundle{
+		Format:  store.SessionBundleFormat,
+		Version: store.SessionBundleVersion,
+		Session: store.SessionBundleSession{Key: "agent:missing:ws:direct:u1"},
+	}
+	if _, err := store.ImportSessionBundle(ctx, ss, agents, nil, b, store.SessionImportOpts{}); !errors.Is(err, store.ErrSessionAgentNotFound) {
+		t.Errorf("unknown agent: err = %v", err)
+	}
+
+	b.Format = "something-else"
+	if _, err := store.ImportSessionBundle(ctx, ss, agents, nil, b, store.SessionImportOpts{}); !errors.Is(err, store.ErrSessionBundleInvalid) {
+		t.Errorf("bad format: err = %v", err)
+	}
+}
+
+func TestSessionBundle_RejectsBadCounts(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	db, _ := bundleTestDB(t)
+	ss := NewSQLiteSessionStore(db)
+	agents := NewSQLiteAgentStore(db)
+
+	for _, s := range []store.SessionBundleSession{
+		{InputTokens: -1},
+		{OutputTokens: -5},
+		{CompactionCount: -1},
+		{CompactionCount: 1 << 30},
+	} {
+		s.Key = "agent:assistant:ws:direct:u1"
+		b := &store.SessionBundle{Format: store.SessionBundleFormat, Version: store.SessionBundleVersion, Session: s}
+		if _, err := store.ImportSessionBundle(ctx, ss, agents, nil, b, store.SessionImportOpts{}); !errors.Is(err, store.ErrSessionBundleInvalid) {
+			t.Errorf("%+v: err = %v, want ErrSessionBundleInvalid", s, err)
+		}
+	}
+}
+
+func TestSessionBundle_MemberImport(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	db, _ := bundleTestDB(t)
+	ss := NewSQLiteSessionStore(db)
+	agents := NewSQLiteAgentStore(db)
+	b := &store.SessionBundle{
+		Format:  store.SessionBundleFormat,
+		Version: store.SessionBundleVersion,
+		Session: store.SessionBundleSession{Key: "agent:assistant:telegram:direct:42", UserID: "42"},
+	}
+	imp := func(userID, key string) (store.SessionImportResult, error) {
+		return store.ImportSessionBundle(ctx, ss, agents, nil, b, store.SessionImportOpts{UserID: userID, Key: key})
+	}
+
+	if _, err := imp("mallory", ""); !errors.Is(err, store.ErrSessionImportDenied) {
+		t.Errorf("no agent access: err = %v, want ErrSessionImportDenied", err)
+	}
+	if _, err := imp("owner", "agent:assistant:telegram:direct:42"); !errors.Is(err, store.ErrSessionImportDenied) {
+		t.Errorf("another user's key: err = %v, want ErrSessionImportDenied", err)
+	}
+
+	res, err := imp("owner", "")
+	if err != nil {
+		t.Fatalf("import under new key: %v", err)
+	}
+	if res.Key == b.Session.Key || !strings.HasPrefix(res.Key, "agent:assistant:ws:direct:") {
+		t.Errorf("key = %q, want a new web-chat key", res.Key)
+	}
+	if got := ss.Get(ctx, res.Key); got == nil || got.UserID != "owner" {
+		t.Errorf("imported session = %+v", got)
+	}
+
+	const own = "agent:assistant:telegram:direct:owner"
+	if res, err := imp("owner", own); err != nil || res.Key != own {
+		t.Errorf("own DM key: key = %q, err = %v", res.Key, err)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestRewindSession_TruncatesAndListsFiles(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	const key = "agent:assistant:telegram:direct:42"
+	db, _ := bundleTestDB(t)
+	ss := NewSQLiteSessionStore(db)
+	ss.GetOrCreate(ctx, key)
+
+	ss.AddMessage(ctx, key, providers.Message{Role: "user", Content: "hello"})
+	ss.AddMessage(ctx, key, providers.Message{Role: "assistant", Content: "hi"})
+	start := store.AddSessionCheckpoint(ctx, ss, key, store.SessionCheckpoint{RunID: "r1", Messages: 2, Label: "write notes"})
+
+	ss.AddMessage(ctx, key, providers.Message{Role: "user", Content: "write notes"})
+	ss.AddMessage(ctx, key, providers.Message{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "c1", Name: "write_file"}}})
+	tool := store.AddSessionCheckpoint(ctx, ss, key, store.SessionCheckpoint{RunID: "r1", Iteration: 1, Messages: 3, Files: []string{"notes.md"}})
+	ss.AddMessage(ctx, key, providers.Message{Role: "tool", ToolCallID: "c1", Content: "ok"})
+	ss.AddMessage(ctx, key, providers.Message{Role: "assistant", Content: "done"})
+	if err := ss.Save(ctx, key); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if start.ID != 1 || tool.ID != 2 {
+		t.Fatalf("ids = %d, %d", start.ID, tool.ID)
+	}
+
+	res, err := store.RewindSession(ctx, ss, key, start.ID)
+	if err != nil {
+		t.Fatalf("RewindSession: %v", err)
+	}
+	if res.Removed != 4 || !slices.Equal(res.Files, []string{"notes.md"}) {
+		t.Errorf("result = %+v", res)
+	}
+	if h := ss.GetHistory(ctx, key); len(h) != 2 || h[1].Content != "hi" {
+		t.Errorf("history after rewind = %+v", h)
+	}
+	cps := store.ListSessionCheckpoints(ctx, ss, key)
+	if len(cps) != 1 || cps[0].ID != start.ID || len(cps[0].Files) != 0 {
+		t.Errorf("checkpoints after rewind = %+v", cps)
+	}
+
+	if _, err := store.RewindSession(ctx, ss, key, tool.ID); !errors.Is(err, store.ErrCheckpointNotFound) {
+		t.Errorf("rewind to dropped checkpoint: err = %v", err)
+	}
+}
+
+func TestRewindSession_RejectsStaleCheckpoint(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+	const key = "agent:assistant:ws:direct:u1"
+	db, _ := bundleTestDB(t)
+	ss := NewSQLiteSessionStore(db)
+	ss.GetOrCreate(ctx, key)
+
+	ss.AddMessage(ctx, key, providers.Message{Role: "user", Content: "one"})
+	ss.AddMessage(ctx, key, providers.Message{Role: "assistant", Content: "two"})
+	cp := store.AddSessionCheckpoint(ctx, ss, key, store.SessionCheckpoint{Messages: 2})
+
+	ss.SetHistory(ctx, key, []providers.Message{
+		{Role: "user", Content: "other"},
+		{Role: "assistant", Content: "history"},
+		{Role: "user", Content: "entirely"},
+	})
+	if _, err := store.RewindSession(ctx, ss, key, cp.ID); !errors.Is(err, store.ErrCheckpointStale) {
+		t.Fatalf("err = %v, want ErrCheckpointStale", err)
+	}
+
+	// Adding a new checkpoint prunes the stale one.
+	store.AddSessionCheckpoint(ctx, ss, key, store.SessionCheckpoint{Messages: 3})
+	if cps := store.ListSessionCheckpoints(ctx, ss, key); len(cps) != 1 || cps[0].ID != cp.ID+1 {
+		t.Errorf("checkpoints = %+v", cps)
+	}
+}
@@ -35,6 +35,13 @@ func buildSessionFilter(opts store.SessionListOpts, tableAlias string) (string,
 		conditions = append(conditions, prefix+"user_id = ?")
 		args = append(args, opts.UserID)
 	}
+	switch opts.Archived {
+	case store.SessionArchivedInclude:
+	case store.SessionArchivedOnly:
+		conditions = append(conditions, "COALESCE(json_extract("+prefix+"metadata, '$."+store.SessionMetaArchivedAt+"'), '') <> ''")
+	default:
+		conditions = append(conditions, "COALESCE(json_extract("+prefix+"metadata, '$."+store.SessionMetaArchivedAt+"'), '') = ''")
+	}
 	if opts.TenantID != uuid.Nil {
 		conditions = append(conditions, prefix+"tenant_id = ?")
 		args = append(args, opts.TenantID)
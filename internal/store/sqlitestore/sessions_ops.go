@@ -5,6 +5,7 @@ package sqlitestore
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 func (s *SQLiteSessionStore) Save(ctx context.Context, key string) error {
@@ -156,6 +158,54 @@ func (s *SQLiteSessionStore) Delete(ctx context.Context, key string) error {
 	return err
 }
 
+// Purge deletes the session and everything keyed by it (traces and their
+// spans, episodic summaries, evolution metrics, subagent tasks) in one
+// transaction. Implements store.SessionPurger.
+func (s *SQLiteSessionStore) Purge(ctx context.Context, key string) (store.SessionPurgeResult, error) {
+	var res store.SessionPurgeResult
+	tid := tenantIDForInsert(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	steps := []struct {
+		query string
+		args  []any
+		count *int64
+	}{
+		{`DELETE FROM spans WHERE tenant_id = ? AND trace_id IN
+			(SELECT id FROM traces WHERE session_key = ? AND tenant_id = ?)`, []any{tid, key, tid}, &res.Spans},
+		{"DELETE FROM traces WHERE session_key = ? AND tenant_id = ?", []any{key, tid}, &res.Traces},
+		{"DELETE FROM episodic_summaries WHERE session_key = ? AND tenant_id = ?", []any{key, tid}, &res.Episodic},
+		{"DELETE FROM agent_evolution_metrics WHERE session_key = ? AND tenant_id = ?", []any{key, tid}, &res.Metrics},
+		{"DELETE FROM subagent_tasks WHERE session_key = ? AND tenant_id = ?", []any{key, tid}, &res.SubagentRuns},
+		{"DELETE FROM sessions WHERE session_key = ? AND tenant_id = ?", []any{key, tid}, nil},
+	}
+	for _, st := range steps {
+		r, err := tx.ExecContext(ctx, st.query, st.args...)
+		if err != nil {
+			return store.SessionPurgeResult{}, fmt.Errorf("purge session: %w", err)
+		}
+		if st.count != nil {
+			*st.count, _ = r.RowsAffected()
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return store.SessionPurgeResult{}, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, sessionCacheKey(ctx, key))
+	s.mu.Unlock()
+	if s.OnDelete != nil {
+		s.OnDelete(key)
+	}
+	return res, nil
+}
+
 func (s *SQLiteSessionStore) LastUsedChannel(ctx context.Context, agentID string) (string, string) {
 	prefix := "agent:" + agentID + ":%"
 	tid := tenantIDForInsert(ctx)
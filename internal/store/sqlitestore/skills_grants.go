@@ -19,7 +19,7 @@ type SkillGrantInfo struct {
 	GrantedBy     string    `json:"granted_by" db:"granted_by"`
 }
 
-// GrantToAgent grants a skill to an agent with version pinning.
+// GrantToAgent grants a skill to an agent, pinned to version (0 = follow the active version).
 func (s *SQLiteSkillStore) GrantToAgent(ctx context.Context, skillID, agentID uuid.UUID, version int, grantedBy string) error {
 	if err := store.ValidateUserID(grantedBy); err != nil {
 		return err
@@ -162,7 +162,7 @@ func (s *SQLiteSkillStore) ListAccessible(ctx context.Context, agentID uuid.UUID
 	_ = tClause
 
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT DISTINCT s.name, s.slug, s.description, s.version, s.file_path FROM skills s
+		`SELECT DISTINCT s.name, s.slug, s.description, s.version, s.file_path, COALESCE(sag.pinned_version, 0) FROM skills s
 		LEFT JOIN skill_agent_grants sag ON s.id = sag.skill_id AND sag.agent_id = ?
 		LEFT JOIN skill_user_grants sug ON s.id = sug.skill_id AND (sug.user_id = ? OR sug.user_id = ?)`+stcJoin+`
 		WHERE s.status = 'active'`+tenantCond+stcFilter+` AND (
@@ -185,11 +185,14 @@ func (s *SQLiteSkillStore) ListAccessible(ctx context.Context, agentID uuid.UUID
 		var desc *string
 		var version int
 		var filePath *string
-		if err := rows.Scan(&name, &slug, &desc, &version, &filePath); err != nil {
+		var pinned int
+		if err := rows.Scan(&name, &slug, &desc, &version, &filePath, &pinned); err != nil {
 			slog.Warn("skill_grants: scan error in ListAccessible", "error", err)
 			continue
 		}
-		result = append(result, buildSkillInfo("", name, slug, desc, version, s.baseDir, filePath))
+		info := buildSkillInfo("", name, slug, desc, version, s.baseDir, filePath)
+		info.PinnedVersion = pinned
+		result = append(result, info)
 	}
 	return result, rows.Err()
 }
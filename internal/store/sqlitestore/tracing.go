@@ -108,6 +108,10 @@ func buildTraceWhere(ctx context.Context, opts store.TraceListOpts) (string, []a
 		conditions = append(conditions, "channel = ?")
 		args = append(args, opts.Channel)
 	}
+	if opts.RequestID != "" {
+		conditions = append(conditions, "json_extract(metadata, '$.request_id') = ?")
+		args = append(args, opts.RequestID)
+	}
 
 	if len(conditions) == 0 {
 		return "", nil
@@ -235,6 +239,106 @@ func (s *SQLiteTracingStore) GetCostSummary(ctx context.Context, opts store.Cost
 	return result, rows.Err()
 }
 
+func (s *SQLiteTracingStore) GetBillingUsage(ctx context.Context, opts store.BillingUsageOpts) ([]store.BillingUsageRow, error) {
+	conditions := []string{"s.span_type = 'llm_call'", "s.start_time >= ?", "s.start_time < ?"}
+	args := []any{opts.From, opts.To}
+
+	if !store.IsCrossTenant(ctx) {
+		tenantID := store.TenantIDFromContext(ctx)
+		if tenantID != uuid.Nil {
+			conditions = append(conditions, "s.tenant_id = ?")
+			args = append(args, tenantID)
+		}
+	}
+
+	q := `SELECT s.tenant_id, COALESCE(t.user_id, ''), COALESCE(s.agent_id, t.agent_id),
+		  COALESCE(s.provider, ''), COALESCE(s.model, ''),
+		  COALESCE(SUM(s.input_tokens), 0), COALESCE(SUM(s.output_tokens), 0),
+		  COALESCE(SUM(s.total_cost), 0), COUNT(*)
+		  FROM spans s JOIN traces t ON t.id = s.trace_id
+		  WHERE ` + strings.Join(conditions, " AND ") + `
+		  GROUP BY 1, 2, 3, 4, 5 ORDER BY 1, 2, 3, 4, 5`
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []store.BillingUsageRow
+	for rows.Next() {
+		var r store.BillingUsageRow
+		var agentID *uuid.UUID
+		if err := rows.Scan(&r.TenantID, &r.UserID, &agentID, &r.Provider, &r.Model,
+			&r.InputTokens, &r.OutputTokens, &r.Cost, &r.Calls); err != nil {
+			return nil, err
+		}
+		r.AgentID = agentID
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
+// usageGroupKeys maps a usage report grouping to its traces column.
+var usageGroupKeys = map[string]string{
+	store.UsageGroupRun:     "id",
+	store.UsageGroupSession: "COALESCE(session_key, '')",
+	store.UsageGroupAgent:   "COALESCE(agent_id, '')",
+	store.UsageGroupUser:    "COALESCE(user_id, '')",
+}
+
+func (s *SQLiteTracingStore) GetUsageReport(ctx context.Context, opts store.UsageReportOpts) ([]store.UsageReportRow, error) {
+	key, ok := usageGroupKeys[opts.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown usage grouping %q", opts.GroupBy)
+	}
+	conditions := []string{"created_at >= ?", "created_at < ?"}
+	args := []any{opts.From, opts.To}
+
+	if !store.IsCrossTenant(ctx) {
+		tenantID := store.TenantIDFromContext(ctx)
+		if tenantID != uuid.Nil {
+			conditions = append(conditions, "tenant_id = ?")
+			args = append(args, tenantID)
+		}
+	}
+	if opts.AgentID != nil {
+		conditions = append(conditions, "agent_id = ?")
+		args = append(args, *opts.AgentID)
+	}
+	if opts.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, opts.UserID)
+	}
+
+	q := `SELECT ` + key + `, COALESCE(SUM(total_input_tokens), 0), COALESCE(SUM(total_output_tokens), 0),
+		  COALESCE(SUM(total_cost), 0) AS cost, COUNT(*), MAX(created_at)
+		  FROM traces WHERE ` + strings.Join(conditions, " AND ") + `
+		  GROUP BY 1 ORDER BY cost DESC, 1`
+	if opts.Limit > 0 {
+		q += " LIMIT ?"
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []store.UsageReportRow
+	for rows.Next() {
+		var r store.UsageReportRow
+		var lastRun sqliteTime
+		if err := rows.Scan(&r.Key, &r.InputTokens, &r.OutputTokens, &r.Cost, &r.Runs, &lastRun); err != nil {
+			return nil, err
+		}
+		r.LastRunAt = lastRun.Time
+		result = append(result, r)
+	}
+	return result, rows.Err()
+}
+
 // DeleteTracesOlderThan deletes traces and their spans older than cutoff.
 func (s *SQLiteTracingStore) DeleteTracesOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
 	// Delete spans belonging to old traces.
@@ -0,0 +1,57 @@
+//go:build sqlite || sqliteonly
+
+package sqlitestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+func TestSQLiteTracingStore_GetUsageReport(t *testing.T) {
+	db := openTestDB(t)
+	if err := EnsureSchema(db); err != nil {
+		t.Fatalf("EnsureSchema error: %v", err)
+	}
+	ts := NewSQLiteTracingStore(db)
+	ctx := store.WithTenantID(context.Background(), store.MasterTenantID)
+
+	now := time.Now().UTC()
+	for _, tr := range []store.TraceData{
+		{UserID: "alice", SessionKey: "s1", TotalInputTokens: 100, TotalOutputTokens: 10, TotalCost: 0.5},
+		{UserID: "alice", SessionKey: "s1", TotalInputTokens: 200, TotalOutputTokens: 20, TotalCost: 1.0},
+		{UserID: "bob", SessionKey: "s2", TotalInputTokens: 50, TotalOutputTokens: 5, TotalCost: 2.0},
+	} {
+		tr.StartTime, tr.CreatedAt, tr.Status = now, now, store.TraceStatusCompleted
+		if err := ts.CreateTrace(ctx, &tr); err != nil {
+			t.Fatalf("CreateTrace: %v", err)
+		}
+	}
+	opts := store.UsageReportOpts{GroupBy: store.UsageGroupUser, From: now.Add(-time.Hour), To: now.Add(time.Hour)}
+
+	rows, err := ts.GetUsageReport(ctx, opts)
+	if err != nil {
+		t.Fatalf("GetUsageReport: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Key != "bob" || rows[1].Key != "alice" {
+		t.Fatalf("rows = %+v, want bob then alice", rows)
+	}
+	if a := rows[1]; a.InputTokens != 300 || a.OutputTokens != 30 || a.Cost != 1.5 || a.Runs != 2 || a.LastRunAt.IsZero() {
+		t.Errorf("alice = %+v", a)
+	}
+
+	opts.GroupBy, opts.UserID = store.UsageGroupRun, "alice"
+	if rows, err := ts.GetUsageReport(ctx, opts); err != nil || len(rows) != 2 {
+		t.Errorf("runs for alice = %+v, %v", rows, err)
+	}
+
+	opts.From = now.Add(time.Minute)
+	if rows, _ := ts.GetUsageReport(ctx, opts); len(rows) != 0 {
+		t.Errorf("rows outside window = %+v", rows)
+	}
+	if _, err := ts.GetUsageReport(ctx, store.UsageReportOpts{GroupBy: "model"}); err == nil {
+		t.Error("unknown grouping accepted")
+	}
+}
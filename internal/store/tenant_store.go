@@ -19,7 +19,9 @@ const (
 	TenantStatusArchived  = "archived"
 )
 
-// Tenant role constants (hierarchy: owner > admin > operator > member > viewer).
+// Tenant role constants (hierarchy: owner > admin > operator > viewer > member).
+// On the HTTP API a user's tenant role caps the role of the credential used;
+// members may chat and read their own data only.
 const (
 	TenantRoleOwner    = "owner"
 	TenantRoleAdmin    = "admin"
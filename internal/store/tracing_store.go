@@ -104,6 +104,7 @@ type TraceListOpts struct {
 	SessionKey string
 	Status     string
 	Channel    string
+	RequestID  string // correlation ID stored in trace metadata
 	Limit      int
 	Offset     int
 }
@@ -124,6 +125,57 @@ type CostSummaryRow struct {
 	TraceCount        int        `json:"trace_count" db:"trace_count"`
 }
 
+// BillingUsageOpts configures the billing usage aggregation.
+// From is inclusive, To is exclusive.
+type BillingUsageOpts struct {
+	From time.Time
+	To   time.Time
+}
+
+// BillingUsageRow is LLM usage aggregated per tenant, user, agent, provider and model.
+// Cost is the cost recorded on the spans at call time.
+type BillingUsageRow struct {
+	TenantID     uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	UserID       string     `json:"user_id" db:"user_id"`
+	AgentID      *uuid.UUID `json:"agent_id,omitempty" db:"agent_id"`
+	Provider     string     `json:"provider" db:"provider"`
+	Model        string     `json:"model" db:"model"`
+	InputTokens  int64      `json:"input_tokens" db:"input_tokens"`
+	OutputTokens int64      `json:"output_tokens" db:"output_tokens"`
+	Cost         float64    `json:"cost" db:"cost"`
+	Calls        int        `json:"calls" db:"calls"`
+}
+
+// Usage report groupings.
+const (
+	UsageGroupRun     = "run"
+	UsageGroupSession = "session"
+	UsageGroupAgent   = "agent"
+	UsageGroupUser    = "user"
+)
+
+// UsageReportOpts configures the usage report. Each trace is one run; delegated
+// runs are counted under their own agent. From is inclusive, To is exclusive.
+type UsageReportOpts struct {
+	GroupBy string // UsageGroupRun, UsageGroupSession, UsageGroupAgent or UsageGroupUser
+	From    time.Time
+	To      time.Time
+	AgentID *uuid.UUID
+	UserID  string
+	Limit   int // 0 = no limit
+}
+
+// UsageReportRow is token usage and estimated cost for one group.
+// Key is the trace ID, session key, agent ID or user ID, depending on GroupBy.
+type UsageReportRow struct {
+	Key          string    `json:"key" db:"key"`
+	InputTokens  int64     `json:"input_tokens" db:"input_tokens"`
+	OutputTokens int64     `json:"output_tokens" db:"output_tokens"`
+	Cost         float64   `json:"cost" db:"cost"`
+	Runs         int       `json:"runs" db:"runs"`
+	LastRunAt    time.Time `json:"last_run_at" db:"last_run_at"`
+}
+
 // CodexPoolSpan holds the fields from a single LLM span for Codex pool activity analysis.
 type CodexPoolSpan struct {
 	SpanID     uuid.UUID
@@ -156,6 +208,12 @@ type TracingStore interface {
 	// Cost aggregation
 	GetMonthlyAgentCost(ctx context.Context, agentID uuid.UUID, year int, month time.Month) (float64, error)
 	GetCostSummary(ctx context.Context, opts CostSummaryOpts) ([]CostSummaryRow, error)
+	// GetBillingUsage aggregates llm_call spans for billing exports.
+	// Scoped to the context tenant unless the context is cross-tenant.
+	GetBillingUsage(ctx context.Context, opts BillingUsageOpts) ([]BillingUsageRow, error)
+	// GetUsageReport aggregates run tokens and cost per run, session, agent or user,
+	// most expensive first. Scoped to the context tenant unless cross-tenant.
+	GetUsageReport(ctx context.Context, opts UsageReportOpts) ([]UsageReportRow, error)
 
 	// Maintenance
 	DeleteTracesOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
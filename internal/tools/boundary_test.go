@@ -331,6 +331,7 @@ func TestIsPathInside(t *testing.T) {
 		{"/a/bc", "/a/b", false}, // not a child, just prefix match
 		{"/a", "/a/b", false},
 		{"/x/y", "/a/b", false},
+		{"/etc/passwd", "/", true}, // root parent already ends in a separator
 	}
 	for _, tt := range tests {
 		got := isPathInside(tt.child, tt.parent)
@@ -353,6 +354,7 @@ func TestIsPathInside_WindowsCaseInsensitive(t *testing.T) {
 		{`C:\WORKSPACE\SUB\FILE`, `c:\workspace`, true},       // all caps child
 		{`D:\other`, `C:\workspace`, false},                   // different drive
 		{`C:\workspaceX\file.txt`, `C:\workspace`, false},     // prefix but not child
+		{`C:\data\file.txt`, `C:\`, true},                      // volume root
 	}
 	for _, tt := range tests {
 		got := isPathInside(tt.child, tt.parent)
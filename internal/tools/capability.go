@@ -41,7 +41,7 @@ func (m ToolMetadata) IsReadOnly() bool {
 func inferMetadata(name string) ToolMetadata {
 	meta := ToolMetadata{Name: name}
 	switch {
-	case name == "read_file" || name == "list_files" || name == "read_image" ||
+	case name == "read_file" || name == "list_files" || name == "grep" || name == "glob" || name == "process_list" || name == "read_image" ||
 		name == "read_audio" || name == "read_video" || name == "read_document" ||
 		name == "memory_search" || name == "memory_get" || name == "memory_expand" ||
 		name == "skill_search" || name == "knowledge_graph_search" ||
@@ -52,7 +52,7 @@ func isContextFile(path, workspace string) (fileName string, ok bool) {
 
 	// Relative root-level: "SOUL.md", "./SOUL.md"
 	dir := filepath.Dir(path)
-	if dir == "." || dir == "/" || dir == "" {
+	if isRootLevelDir(dir) {
 		return base, true
 	}
 
@@ -61,7 +61,7 @@ func isContextFile(path, workspace string) (fileName string, ok bool) {
 	if workspace != "" && filepath.IsAbs(path) {
 		cleanPath := filepath.Clean(path)
 		cleanWS := filepath.Clean(workspace)
-		if strings.HasPrefix(filepath.Dir(cleanPath), cleanWS) {
+		if isPathInside(filepath.Dir(cleanPath), cleanWS) {
 			return base, true
 		}
 	}
@@ -25,7 +25,7 @@ type credentialProvider interface {
 }
 
 // imageGenProviderPriority is the default order for image generation providers.
-var imageGenProviderPriority = []string{"openrouter", "gemini", "openai", "minimax", "dashscope", "byteplus"}
+var imageGenProviderPriority = []string{"openrouter", "gemini", "openai", "minimax", "dashscope", "byteplus", "stability"}
 
 // imageGenModelDefaults maps provider names to default image generation models.
 var imageGenModelDefaults = map[string]string{
@@ -35,6 +35,7 @@ var imageGenModelDefaults = map[string]string{
 	"minimax":    "image-01",
 	"dashscope":  "wan2.6-image",
 	"byteplus":   "seedream-5-0-260128",
+	"stability":  "stable-image-core",
 }
 
 // CreateImageTool generates images using an image generation API.
@@ -207,6 +208,8 @@ func (t *CreateImageTool) callProvider(ctx context.Context, cp credentialProvide
 		return callDashScopeImageGen(ctx, cp.APIKey(), cp.APIBase(), model, prompt, params)
 	case "byteplus":
 		return callBytePlusImageGen(ctx, cp.APIKey(), cp.APIBase(), model, prompt, params)
+	case "stability":
+		return callStabilityImageGen(ctx, cp.APIKey(), cp.APIBase(), model, prompt, params)
 	default:
 		return t.callStandardImageGenAPI(ctx, cp.APIKey(), cp.APIBase(), model, prompt, params)
 	}
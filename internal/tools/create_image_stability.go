@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+// stabilityDefaultAPIBase is used when the provider row has no api_base.
+const stabilityDefaultAPIBase = "https://api.stability.ai"
+
+// stabilityImageEndpoint maps a model name to the Stable Image v2beta route.
+// "core"/"ultra" (with or without the "stable-image-" prefix) select those
+// services; any sd3* model goes to /sd3 with the model passed as a form field.
+func stabilityImageEndpoint(apiBase, model string) (endpoint, sd3Model string) {
+	base := strings.TrimRight(apiBase, "/")
+	if base == "" {
+		base = stabilityDefaultAPIBase
+	}
+	for _, suffix := range []string{"/v2beta", "/v1"} {
+		base = strings.TrimSuffix(base, suffix)
+	}
+	m := strings.TrimPrefix(strings.ToLower(model), "stable-image-")
+	switch {
+	case m == "ultra":
+		return base + "/v2beta/stable-image/generate/ultra", ""
+	case strings.HasPrefix(m, "sd3"):
+		return base + "/v2beta/stable-image/generate/sd3", model
+	default:
+		return base + "/v2beta/stable-image/generate/core", ""
+	}
+}
+
+// stabilityAspectRatio maps create_image ratios to ones Stability accepts
+// (16:9 1:1 21:9 2:3 3:2 4:5 5:4 9:16 9:21); 4:3 and 3:4 take the nearest.
+func stabilityAspectRatio(params map[string]any) string {
+	switch ar := GetParamString(params, "aspect_ratio", "1:1"); ar {
+	case "4:3":
+		return "5:4"
+	case "3:4":
+		return "4:5"
+	case "1:1", "16:9", "9:16", "21:9", "9:21", "2:3", "3:2", "4:5", "5:4":
+		return ar
+	default:
+		return "1:1"
+	}
+}
+
+// callStabilityImageGen calls the Stability AI Stable Image API.
+// Endpoint: POST /v2beta/stable-image/generate/{core,ultra,sd3} (multipart)
+// Response: raw PNG bytes (Accept: image/*).
+func callStabilityImageGen(ctx context.Context, apiKey, apiBase, model, prompt string, params map[string]any) ([]byte, *providers.Usage, error) {
+	endpoint, sd3Model := stabilityImageEndpoint(apiBase, model)
+	aspectRatio := stabilityAspectRatio(params)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"prompt":        prompt,
+		"aspect_ratio":  aspectRatio,
+		"output_format": "png",
+	}
+	if sd3Model != "" {
+		fields["model"] = sd3Model
+	}
+	if neg := GetParamString(params, "negative_prompt", ""); neg != "" {
+		fields["negative_prompt"] = neg
+	}
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			return nil, nil, fmt.Errorf("build form: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("build form: %w", err)
+	}
+
+	slog.Info("create_image: calling Stability API", "endpoint", endpoint, "aspect_ratio", aspectRatio)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, &body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Accept", "image/*")
+
+	client := &http.Client{} // timeout governed by chain context
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("API error %d: %s", resp.StatusCode, truncateBytes(respBody, 500))
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "image/") {
+		return nil, nil, fmt.Errorf("unexpected Stability response (%s): %s", resp.Header.Get("Content-Type"), truncateBytes(respBody, 300))
+	}
+	if reason := resp.Header.Get("Finish-Reason"); reason == "CONTENT_FILTERED" {
+		return nil, nil, fmt.Errorf("image blocked by Stability content filter")
+	}
+	return respBody, nil, nil
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStabilityImageEndpoint(t *testing.T) {
+	tests := []struct {
+		apiBase, model, wantEndpoint, wantSD3 string
+	}{
+		{"", "stable-image-core", "https://api.stability.ai/v2beta/stable-image/generate/core", ""},
+		{"https://api.stability.ai/v1/", "ultra", "https://api.stability.ai/v2beta/stable-image/generate/ultra", ""},
+		{"https://proxy.test/v2beta", "sd3.5-large", "https://proxy.test/v2beta/stable-image/generate/sd3", "sd3.5-large"},
+	}
+	for _, tt := range tests {
+		endpoint, sd3 := stabilityImageEndpoint(tt.apiBase, tt.model)
+		if endpoint != tt.wantEndpoint || sd3 != tt.wantSD3 {
+			t.Errorf("stabilityImageEndpoint(%q, %q) = %q, %q", tt.apiBase, tt.model, endpoint, sd3)
+		}
+	}
+}
+
+func TestStabilityAspectRatio(t *testing.T) {
+	for in, want := range map[string]string{"": "1:1", "16:9": "16:9", "4:3": "5:4", "3:4": "4:5", "7:3": "1:1"} {
+		params := map[string]any{}
+		if in != "" {
+			params["aspect_ratio"] = in
+		}
+		if got := stabilityAspectRatio(params); got != want {
+			t.Errorf("stabilityAspectRatio(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCallStabilityImageGen(t *testing.T) {
+	wantPNG := []byte{0x89, 0x50, 0x4e, 0x47}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2beta/stable-image/generate/sd3" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("prompt") != "a red fox" || r.FormValue("model") != "sd3.5-medium" || r.FormValue("aspect_ratio") != "16:9" {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(wantPNG)
+	}))
+	defer srv.Close()
+
+	got, _, err := callStabilityImageGen(context.Background(), "sk-test", srv.URL, "sd3.5-medium", "a red fox", map[string]any{"aspect_ratio": "16:9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, wantPNG) {
+		t.Errorf("image = %v", got)
+	}
+}
+
+func TestCallStabilityImageGen_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPaymentRequired)
+		w.Write([]byte(`{"errors":["insufficient credits"]}`))
+	}))
+	defer srv.Close()
+
+	_, _, err := callStabilityImageGen(context.Background(), "k", srv.URL, "core", "x", nil)
+	if err == nil || !strings.Contains(err.Error(), "402") {
+		t.Errorf("err = %v", err)
+	}
+}
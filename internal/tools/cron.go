@@ -143,9 +143,9 @@ func (t *CronTool) Execute(ctx context.Context, args map[string]any) *Result {
 	}
 
 	// Group cron permission check for mutation actions
-	if t.permStore != nil && (action == "add" || action == "update" || action == "remove") {
-		if err := store.CheckCronPermission(ctx, t.permStore); err != nil {
-			return ErrorResult("permission denied: only users with cron or file_writer permission can manage cron jobs in group chats")
+	if action == "add" || action == "update" || action == "remove" {
+		if res := t.checkMutationPermission(ctx); res != nil {
+			return res
 		}
 	}
 
@@ -176,6 +176,18 @@ func (t *CronTool) Execute(ctx context.Context, args map[string]any) *Result {
 	}
 }
 
+// checkMutationPermission gates job creation/update/removal in group chats to
+// senders holding the cron or file_writer grant.
+func (t *CronTool) checkMutationPermission(ctx context.Context) *Result {
+	if t.permStore == nil {
+		return nil
+	}
+	if err := store.CheckCronPermission(ctx, t.permStore); err != nil {
+		return ErrorResult("permission denied: only users with cron or file_writer permission can manage cron jobs in group chats")
+	}
+	return nil
+}
+
 func (t *CronTool) handleStatus() *Result {
 	status := t.cronStore.Status()
 	data, _ := json.MarshalIndent(status, "", "  ")
@@ -256,6 +268,12 @@ func (t *CronTool) handleAdd(ctx context.Context, args map[string]any, agentID,
 		return ErrorResult(fmt.Sprintf("invalid schedule kind: %s (must be at, every, or cron)", schedule.Kind))
 	}
 
+	return t.addJob(ctx, jobObj, name, schedule, message, agentID, userID)
+}
+
+// addJob creates a job with a validated schedule. jobObj supplies the optional
+// fields (deliver, channel, to, agentId, wake_heartbeat).
+func (t *CronTool) addJob(ctx context.Context, jobObj map[string]any, name string, schedule store.CronSchedule, message, agentID, userID string) *Result {
 	// Optional fields
 	deliver, _ := jobObj["deliver"].(bool)
 	channel, _ := jobObj["channel"].(string)
@@ -0,0 +1,374 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adhocore/gronx"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// Schedule phrases accepted by cron_add, e.g.:
+//
+//	every monday at 9am           → cron "0 9 * * 1"
+//	every weekday at 8:30         → cron "30 8 * * 1-5"
+//	every month on the 1st at 9am → cron "0 9 1 * *"
+//	every 2 hours                 → every 7200000ms
+//	in 20 minutes                 → at now+20m
+//	tomorrow at 8pm, friday 17:00 → at (next occurrence)
+//	2026-11-03 14:00              → at
+//	0 9 * * 1-5                   → cron (passed through)
+//
+// Recurring phrases without a time run at defaultScheduleHour.
+
+const defaultScheduleHour = 9
+
+// minScheduleInterval is the shortest "every N ..." interval accepted.
+const minScheduleInterval = time.Minute
+
+var (
+	scheduleWeekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "sunday": time.Sunday,
+		"mon": time.Monday, "monday": time.Monday,
+		"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+		"wed": time.Wednesday, "wednesday": time.Wednesday,
+		"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+		"fri": time.Friday, "friday": time.Friday,
+		"sat": time.Saturday, "saturday": time.Saturday,
+	}
+
+	// schedulePeriods maps parts of the day to the hour they stand for.
+	schedulePeriods = map[string]int{
+		"midnight": 0, "morning": 9, "noon": 12, "afternoon": 14, "evening": 18, "night": 21, "tonight": 21,
+	}
+
+	scheduleUnits = map[string]time.Duration{
+		"minute": time.Minute, "min": time.Minute,
+		"hour": time.Hour, "hr": time.Hour,
+		"day":  24 * time.Hour,
+		"week": 7 * 24 * time.Hour,
+	}
+
+	scheduleClockRe   = regexp.MustCompile(`^(\d{1,2})(?:[:h](\d{2}))?(am|pm)?$`)
+	scheduleOrdinalRe = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+
+	scheduleDateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+	// scheduleFillers carry no meaning in a schedule phrase.
+	scheduleFillers = map[string]bool{"on": true, "the": true, "and": true, "of": true, "each": true}
+)
+
+// parseSchedulePhrase turns a natural-language schedule into a CronSchedule.
+// One-shot and relative times resolve against now in loc; recurring schedules
+// carry tz (empty = gateway default timezone).
+func parseSchedulePhrase(phrase string, now time.Time, loc *time.Location, tz string) (store.CronSchedule, error) {
+	raw := strings.ToLower(strings.TrimSpace(phrase))
+	if raw == "" {
+		return store.CronSchedule{}, fmt.Errorf("schedule is empty")
+	}
+	if len(strings.Fields(raw)) == 5 && gronx.New().IsValid(raw) {
+		return store.CronSchedule{Kind: "cron", Expr: raw, TZ: tz}, nil
+	}
+	for _, layout := range scheduleDateLayouts {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(raw), loc); err == nil {
+			if layout == "2006-01-02" {
+				t = t.Add(defaultScheduleHour * time.Hour)
+			}
+			return atSchedule(t, now)
+		}
+	}
+
+	words := scheduleWords(raw)
+	if len(words) == 0 {
+		return store.CronSchedule{}, fmt.Errorf("schedule is empty")
+	}
+	switch words[0] {
+	case "every":
+		return parseRecurring(words[1:], tz)
+	case "in":
+		d, err := parseScheduleDuration(words[1:])
+		if err != nil {
+			return store.CronSchedule{}, err
+		}
+		return atSchedule(now.Add(d), now)
+	case "hourly":
+		return parseRecurring(append([]string{"hour"}, words[1:]...), tz)
+	case "daily":
+		return parseRecurring(append([]string{"day"}, words[1:]...), tz)
+	case "weekly":
+		if len(words) == 1 {
+			return parseRecurring([]string{"week"}, tz)
+		}
+		return parseRecurring(words[1:], tz)
+	case "monthly":
+		return parseRecurring(append([]string{"month"}, words[1:]...), tz)
+	}
+	return parseOneShot(words, now.In(loc))
+}
+
+// scheduleWords splits a phrase into words, dropping fillers and joining a
+// detached "am"/"pm" onto the preceding number.
+func scheduleWords(s string) []string {
+	s = strings.NewReplacer(",", " ", "a.m.", "am", "p.m.", "pm").Replace(s)
+	var out []string
+	for _, w := range strings.Fields(s) {
+		if scheduleFillers[w] {
+			continue
+		}
+		if (w == "am" || w == "pm") && len(out) > 0 {
+			out[len(out)-1] += w
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// parseRecurring handles the words after "every".
+func parseRecurring(words []string, tz string) (store.CronSchedule, error) {
+	if len(words) == 0 {
+		return store.CronSchedule{}, fmt.Errorf("\"every\" needs an interval or a day, e.g. \"every 2 hours\" or \"every monday at 9am\"")
+	}
+
+	// Plain intervals: "every 15 minutes", "every hour", "every 3 days".
+	if d, err := parseScheduleDuration(words); err == nil {
+		if d < minScheduleInterval {
+			return store.CronSchedule{}, fmt.Errorf("interval must be at least %s", minScheduleInterval)
+		}
+		ms := d.Milliseconds()
+		return store.CronSchedule{Kind: "every", EveryMS: &ms}, nil
+	}
+
+	var (
+		dow, dom     string
+		monthly      bool
+		hour, minute = -1, 0
+	)
+	addDow := func(d string) {
+		if dow == "" || dow == "*" {
+			dow = d
+		} else {
+			dow += "," + d
+		}
+	}
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		switch {
+		case w == "day" || w == "days":
+			if dow == "" {
+				dow = "*"
+			}
+		case w == "weekday" || w == "weekdays":
+			addDow("1-5")
+		case w == "weekend" || w == "weekends":
+			addDow("0,6")
+		case w == "month":
+			monthly = true
+		case w == "at":
+			if i+1 == len(words) {
+				return store.CronSchedule{}, fmt.Errorf("missing time after \"at\"")
+			}
+			i++
+			h, m, ok := parseScheduleClock(words[i], true)
+			if !ok {
+				return store.CronSchedule{}, fmt.Errorf("unrecognized time %q", words[i])
+			}
+			hour, minute = h, m
+		default:
+			if wd, ok := scheduleWeekdays[strings.TrimSuffix(w, "s")]; ok {
+				addDow(strconv.Itoa(int(wd)))
+				continue
+			}
+			if wd, ok := scheduleWeekdays[w]; ok {
+				addDow(strconv.Itoa(int(wd)))
+				continue
+			}
+			if monthly && dom == "" {
+				if m := scheduleOrdinalRe.FindStringSubmatch(w); m != nil {
+					if n, _ := strconv.Atoi(m[1]); n >= 1 && n <= 31 {
+						dom = m[1]
+						continue
+					}
+				}
+			}
+			h, m, ok := parseScheduleClock(w, false)
+			if !ok {
+				return store.CronSchedule{}, fmt.Errorf("unrecognized schedule word %q", w)
+			}
+			hour, minute = h, m
+		}
+	}
+
+	switch {
+	case monthly && dow != "":
+		return store.CronSchedule{}, fmt.Errorf("combine either a day of the month or days of the week, not both")
+	case monthly:
+		if dom == "" {
+			dom = "1"
+		}
+		dow = "*"
+	case dow == "" && hour < 0:
+		return store.CronSchedule{}, fmt.Errorf("unrecognized recurring schedule %q", "every "+strings.Join(words, " "))
+	case dow == "":
+		dow, dom = "*", "*" // "every morning", "every evening at 7pm"
+	default:
+		dom = "*"
+	}
+	if hour < 0 {
+		hour = defaultScheduleHour
+	}
+	expr := fmt.Sprintf("%d %d %s * %s", minute, hour, dom, dow)
+	if !gronx.New().IsValid(expr) {
+		return store.CronSchedule{}, fmt.Errorf("could not build a valid cron expression (%s)", expr)
+	}
+	return store.CronSchedule{Kind: "cron", Expr: expr, TZ: tz}, nil
+}
+
+// parseScheduleDuration parses "20 minutes", "an hour", "1 hour 30 minutes".
+func parseScheduleDuration(words []string) (time.Duration, error) {
+	if len(words) == 0 {
+		return 0, fmt.Errorf("missing duration")
+	}
+	var total time.Duration
+	for i := 0; i < len(words); i++ {
+		n := 1
+		if v, err := strconv.Atoi(words[i]); err == nil && v > 0 {
+			n = v
+			i++
+		} else if words[i] == "a" || words[i] == "an" {
+			i++
+		}
+		if i == len(words) {
+			return 0, fmt.Errorf("missing unit after %q", words[i-1])
+		}
+		unit, ok := scheduleUnits[strings.TrimSuffix(words[i], "s")]
+		if !ok {
+			return 0, fmt.Errorf("unrecognized duration unit %q", words[i])
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
+
+// parseOneShot handles "tomorrow at 8pm", "friday 17:00", "at noon", "tonight".
+// A time alone means its next occurrence.
+func parseOneShot(words []string, now time.Time) (store.CronSchedule, error) {
+	var (
+		date         time.Time
+		hasDate      bool
+		onWeekday    bool
+		hour, minute = -1, 0
+	)
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		switch {
+		case w == "today" || w == "tonight":
+			date, hasDate = now, true
+			if w == "tonight" && hour < 0 {
+				hour = schedulePeriods["tonight"]
+			}
+		case w == "tomorrow":
+			date, hasDate = now.AddDate(0, 0, 1), true
+		case w == "next":
+			// "next friday" — same as "friday"
+		case w == "at":
+			if i+1 == len(words) {
+				return store.CronSchedule{}, fmt.Errorf("missing time after \"at\"")
+			}
+			i++
+			h, m, ok := parseScheduleClock(words[i], true)
+			if !ok {
+				return store.CronSchedule{}, fmt.Errorf("unrecognized time %q", words[i])
+			}
+			hour, minute = h, m
+		default:
+			if wd, ok := scheduleWeekdays[w]; ok {
+				days := (int(wd) - int(now.Weekday()) + 7) % 7
+				date, hasDate, onWeekday = now.AddDate(0, 0, days), true, true
+				continue
+			}
+			h, m, ok := parseScheduleClock(w, false)
+			if !ok {
+				return store.CronSchedule{}, fmt.Errorf("unrecognized schedule %q (try \"in 20 minutes\", \"tomorrow at 9am\", \"every monday at 9am\" or a cron expression)", strings.Join(words, " "))
+			}
+			hour, minute = h, m
+		}
+	}
+	if !hasDate && hour < 0 {
+		return store.CronSchedule{}, fmt.Errorf("unrecognized schedule %q", strings.Join(words, " "))
+	}
+	if hour < 0 {
+		hour = defaultScheduleHour
+	}
+	if !hasDate {
+		date = now
+	}
+	t := time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, now.Location())
+	if !t.After(now) {
+		switch {
+		case !hasDate:
+			t = t.AddDate(0, 0, 1) // time already passed today → tomorrow
+		case onWeekday:
+			t = t.AddDate(0, 0, 7) // "monday 9am" said on Monday after 9am → next week
+		}
+	}
+	return atSchedule(t, now)
+}
+
+// parseScheduleClock parses "9am", "9:30pm", "21:00", "21h30", "noon". A bare
+// number ("9") is only a time when bare is true (it followed "at").
+func parseScheduleClock(w string, bare bool) (hour, minute int, ok bool) {
+	if h, found := schedulePeriods[w]; found {
+		return h, 0, true
+	}
+	m := scheduleClockRe.FindStringSubmatch(w)
+	if m == nil || (!bare && m[2] == "" && m[3] == "") {
+		return 0, 0, false
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if hour > 23 || minute > 59 || (m[3] != "" && (hour == 0 || hour > 12)) {
+		return 0, 0, false
+	}
+	switch {
+	case m[3] == "am" && hour == 12:
+		hour = 0
+	case m[3] == "pm" && hour < 12:
+		hour += 12
+	}
+	return hour, minute, true
+}
+
+func atSchedule(t, now time.Time) (store.CronSchedule, error) {
+	if !t.After(now) {
+		return store.CronSchedule{}, fmt.Errorf("%s is in the past", t.Format("2006-01-02 15:04 MST"))
+	}
+	ms := t.UnixMilli()
+	return store.CronSchedule{Kind: "at", AtMS: &ms}, nil
+}
+
+// describeSchedule renders a schedule for humans ("cron 0 9 * * 1 (Asia/Tokyo)").
+func describeSchedule(s store.CronSchedule) string {
+	switch s.Kind {
+	case "at":
+		if s.AtMS != nil {
+			return "once at " + time.UnixMilli(*s.AtMS).UTC().Format(time.RFC3339)
+		}
+	case "every":
+		if s.EveryMS != nil {
+			return "every " + (time.Duration(*s.EveryMS) * time.Millisecond).String()
+		}
+	case "cron":
+		if s.TZ != "" {
+			return fmt.Sprintf("cron %s (%s)", s.Expr, s.TZ)
+		}
+		return "cron " + s.Expr
+	}
+	return s.Kind
+}
@@ -0,0 +1,191 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// Wednesday 2026-10-14 10:00 UTC.
+var scheduleTestNow = time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+
+func TestParseSchedulePhrase_Recurring(t *testing.T) {
+	cases := map[string]string{
+		"every monday at 9am":                  "0 9 * * 1",
+		"Every Mon, Wed and Fri at 6:30 PM":    "30 18 * * 1,3,5",
+		"every weekday at 8:30":                "30 8 * * 1-5",
+		"every weekend at 10am":                "0 10 * * 0,6",
+		"every day at noon":                    "0 12 * * *",
+		"daily at 21:15":                       "15 21 * * *",
+		"every morning":                        "0 9 * * *",
+		"every sunday":                         "0 9 * * 0",
+		"weekly on fridays at 17h00":           "0 17 * * 5",
+		"every month on the 15th at 8am":       "0 8 15 * *",
+		"monthly":                              "0 9 1 * *",
+		"0 9 * * 1-5":                          "0 9 * * 1-5",
+		"every tuesday and thursday at 7 p.m.": "0 19 * * 2,4",
+	}
+	for phrase, want := range cases {
+		got, err := parseSchedulePhrase(phrase, scheduleTestNow, time.UTC, "Asia/Tokyo")
+		if err != nil {
+			t.Errorf("%q: %v", phrase, err)
+			continue
+		}
+		if got.Kind != "cron" || got.Expr != want || got.TZ != "Asia/Tokyo" {
+			t.Errorf("%q = %+v, want cron %q", phrase, got, want)
+		}
+	}
+}
+
+func TestParseSchedulePhrase_Intervals(t *testing.T) {
+	cases := map[string]time.Duration{
+		"every 15 minutes": 15 * time.Minute,
+		"every hour":       time.Hour,
+		"hourly":           time.Hour,
+		"every 3 days":     72 * time.Hour,
+		"every week":       7 * 24 * time.Hour,
+	}
+	for phrase, want := range cases {
+		got, err := parseSchedulePhrase(phrase, scheduleTestNow, time.UTC, "")
+		if err != nil {
+			t.Errorf("%q: %v", phrase, err)
+			continue
+		}
+		if got.Kind != "every" || got.EveryMS == nil || *got.EveryMS != want.Milliseconds() {
+			t.Errorf("%q = %+v, want every %s", phrase, got, want)
+		}
+	}
+}
+
+func TestParseSchedulePhrase_OneShot(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	cases := map[string]time.Time{
+		"in 20 minutes":        scheduleTestNow.Add(20 * time.Minute),
+		"in an hour":           scheduleTestNow.Add(time.Hour),
+		"in 1 hour 30 minutes": scheduleTestNow.Add(90 * time.Minute),
+		"tomorrow at 7pm":      time.Date(2026, 10, 15, 19, 0, 0, 0, berlin),
+		"at 9am":               time.Date(2026, 10, 15, 9, 0, 0, 0, berlin), // 9am already passed in Berlin → tomorrow
+		"today at 23:30":       time.Date(2026, 10, 14, 23, 30, 0, 0, berlin),
+		"friday 17:00":         time.Date(2026, 10, 16, 17, 0, 0, 0, berlin),
+		"next monday":          time.Date(2026, 10, 19, 9, 0, 0, 0, berlin),
+		"wednesday at 8am":     time.Date(2026, 10, 21, 8, 0, 0, 0, berlin), // today's 8am passed → next week
+		"tonight":              time.Date(2026, 10, 14, 21, 0, 0, 0, berlin),
+		"2026-11-03 14:00":     time.Date(2026, 11, 3, 14, 0, 0, 0, berlin),
+		"2026-11-03":           time.Date(2026, 11, 3, 9, 0, 0, 0, berlin),
+		"2026-11-03T14:00:00Z": time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC),
+	}
+	for phrase, want := range cases {
+		got, err := parseSchedulePhrase(phrase, scheduleTestNow, berlin, "Europe/Berlin")
+		if err != nil {
+			t.Errorf("%q: %v", phrase, err)
+			continue
+		}
+		if got.Kind != "at" || got.AtMS == nil || *got.AtMS != want.UnixMilli() {
+			t.Errorf("%q = %s, want %s", phrase, describeSchedule(got), want.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseSchedulePhrase_Errors(t *testing.T) {
+	for _, phrase := range []string{
+		"", "whenever", "every", "every 30 seconds", "every month on monday",
+		"today at 9am", "2020-01-01 10:00", "at 25:00", "every day at 13pm", "in 5 fortnights",
+	} {
+		if got, err := parseSchedulePhrase(phrase, scheduleTestNow, time.UTC, ""); err == nil {
+			t.Errorf("%q: expected error, got %+v", phrase, got)
+		}
+	}
+}
+
+// fakeCronStore keeps jobs in memory for the cron_* tools.
+type fakeCronStore struct {
+	store.CronStore
+	jobs map[string]*store.CronJob
+}
+
+func (s *fakeCronStore) AddJob(_ context.Context, name string, schedule store.CronSchedule, message string, deliver bool, channel, to, agentID, userID string) (*store.CronJob, error) {
+	job := &store.CronJob{
+		ID: uuid.NewString(), Name: name, AgentID: agentID, UserID: userID, Enabled: true,
+		Schedule: schedule, Payload: store.CronPayload{Kind: "agent_turn", Message: message},
+		Deliver: deliver, DeliverChannel: channel, DeliverTo: to,
+	}
+	s.jobs[job.ID] = job
+	return job, nil
+}
+
+func (s *fakeCronStore) GetJob(_ context.Context, id string) (*store.CronJob, bool) {
+	j, ok := s.jobs[id]
+	return j, ok
+}
+
+func (s *fakeCronStore) ListJobs(_ context.Context, _ bool, agentID, userID string) []store.CronJob {
+	var out []store.CronJob
+	for _, j := range s.jobs {
+		if j.AgentID == agentID && j.UserID == userID {
+			out = append(out, *j)
+		}
+	}
+	return out
+}
+
+func (s *fakeCronStore) RemoveJob(_ context.Context, id string) error {
+	delete(s.jobs, id)
+	return nil
+}
+
+func TestCronSimpleTools_AddListRemove(t *testing.T) {
+	cs := &fakeCronStore{jobs: map[string]*store.CronJob{}}
+	cron := NewCronTool(cs)
+	add := NewCronAddTool(cron)
+	add.now = func() time.Time { return scheduleTestNow }
+
+	agentID := uuid.New()
+	ctx := store.WithAgentID(context.Background(), agentID)
+	ctx = store.WithUserID(ctx, "u1")
+	ctx = WithToolChannel(ctx, "telegram")
+	ctx = WithToolChatID(ctx, "chat-42")
+
+	res := add.Execute(ctx, map[string]any{"schedule": "every monday at 9am", "message": "Remind me: weekly report!"})
+	if res.IsError {
+		t.Fatal(res.ForLLM)
+	}
+	if len(cs.jobs) != 1 {
+		t.Fatalf("jobs = %d, want 1", len(cs.jobs))
+	}
+	var job *store.CronJob
+	for _, j := range cs.jobs {
+		job = j
+	}
+	if job.Name != "remind-me-weekly-report" || job.Schedule.Expr != "0 9 * * 1" || job.UserID != "u1" || job.AgentID != agentID.String() {
+		t.Errorf("unexpected job: %+v", job)
+	}
+	if !job.Deliver || job.DeliverChannel != "telegram" || job.DeliverTo != "chat-42" {
+		t.Errorf("job should deliver to the current chat: %+v", job)
+	}
+
+	if res := add.Execute(ctx, map[string]any{"schedule": "sometime", "message": "x"}); !res.IsError {
+		t.Error("expected error for unparseable schedule")
+	}
+
+	list := NewCronListTool(cron).Execute(ctx, map[string]any{})
+	if !strings.Contains(list.ForLLM, job.ID) || !strings.Contains(list.ForLLM, "cron 0 9 * * 1") {
+		t.Errorf("list output = %s", list.ForLLM)
+	}
+
+	remove := NewCronRemoveTool(cron)
+	otherUser := store.WithUserID(ctx, "u2")
+	if res := remove.Execute(otherUser, map[string]any{"id": job.ID}); !res.IsError || len(cs.jobs) != 1 {
+		t.Error("another user must not remove the job")
+	}
+	if res := remove.Execute(ctx, map[string]any{"id": job.ID}); res.IsError || len(cs.jobs) != 0 {
+		t.Errorf("owner remove failed: %v", res.ForLLM)
+	}
+}
@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// cron_add / cron_list / cron_remove are conversational front-ends to the cron
+// tool: schedules are plain phrases ("every monday at 9am", "in 20 minutes")
+// instead of schedule objects. They share the cron tool's store and group
+// permission store, and only ever see the caller's own jobs (agent + user scope).
+
+// CronAddTool schedules a job from a natural-language schedule phrase.
+type CronAddTool struct {
+	cron *CronTool
+	now  func() time.Time // test hook
+}
+
+func NewCronAddTool(cron *CronTool) *CronAddTool {
+	return &CronAddTool{cron: cron, now: time.Now}
+}
+
+func (t *CronAddTool) Name() string { return "cron_add" }
+
+func (t *CronAddTool) Description() string {
+	return `Schedule a reminder or recurring task from a plain-language schedule.
+Examples of "schedule": "every monday at 9am", "every weekday at 8:30", "every 2 hours",
+"every month on the 1st at 10am", "in 20 minutes", "tomorrow at 7pm", "friday 17:00",
+"2026-11-03 14:00", or a 5-field cron expression like "0 9 * * 1-5".
+Recurring schedules without a time run at 9:00. Pass "tz" (IANA name) when you know the user's timezone.
+When the job fires, "message" is run as a new agent turn and the reply is delivered to the current chat.`
+}
+
+func (t *CronAddTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"schedule": map[string]any{
+				"type":        "string",
+				"description": "When to run, e.g. \"every monday at 9am\", \"in 20 minutes\", \"tomorrow at 7pm\"",
+			},
+			"message": map[string]any{
+				"type":        "string",
+				"description": "What to do when the job fires (an instruction to yourself, e.g. \"Remind the user to submit the weekly report\")",
+			},
+			"name": map[string]any{
+				"type":        "string",
+				"description": "Short job name (lowercase letters, numbers, hyphens); derived from message when omitted",
+			},
+			"tz": map[string]any{
+				"type":        "string",
+				"description": "IANA timezone the schedule is in, e.g. \"Europe/Berlin\" (default: gateway timezone)",
+			},
+		},
+		"required": []string{"schedule", "message"},
+	}
+}
+
+func (t *CronAddTool) Execute(ctx context.Context, args map[string]any) *Result {
+	if res := t.cron.checkMutationPermission(ctx); res != nil {
+		return res
+	}
+	phrase, _ := args["schedule"].(string)
+	message, _ := args["message"].(string)
+	if strings.TrimSpace(phrase) == "" {
+		return ErrorResult("schedule is required")
+	}
+	if strings.TrimSpace(message) == "" {
+		return ErrorResult("message is required")
+	}
+
+	tz, _ := args["tz"].(string)
+	loc := time.Local
+	if tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return ErrorResult(fmt.Sprintf("invalid timezone '%s': use IANA names like 'Asia/Ho_Chi_Minh', 'America/New_York'", tz))
+		}
+		loc = l
+	}
+	schedule, err := parseSchedulePhrase(phrase, t.now(), loc, tz)
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("could not understand schedule %q: %v", phrase, err))
+	}
+
+	name, _ := args["name"].(string)
+	name = cronJobSlug(name)
+	if name == "" {
+		name = cronJobSlug(message)
+	}
+	if name == "" {
+		name = "reminder"
+	}
+
+	// Jobs are always created for the calling agent; deliver/channel/to follow
+	// the cron tool's defaults (reply into the current chat).
+	return t.cron.addJob(ctx, map[string]any{}, name, schedule, message, resolveAgentIDString(ctx), store.UserIDFromContext(ctx))
+}
+
+var cronSlugUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// cronJobSlug turns free text into a job name: lowercase words joined by
+// hyphens, at most 40 characters.
+func cronJobSlug(s string) string {
+	s = strings.Trim(cronSlugUnsafe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(s) > 40 {
+		s = strings.TrimRight(s[:40], "-")
+	}
+	return s
+}
+
+// CronListTool lists the caller's scheduled jobs.
+type CronListTool struct {
+	cron *CronTool
+}
+
+func NewCronListTool(cron *CronTool) *CronListTool { return &CronListTool{cron: cron} }
+
+func (t *CronListTool) Name() string { return "cron_list" }
+
+func (t *CronListTool) Description() string {
+	return "List your scheduled jobs and reminders in this conversation's scope, with their schedule and next run time. Use the returned id with cron_remove."
+}
+
+func (t *CronListTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"includeDisabled": map[string]any{
+				"type":        "boolean",
+				"description": "Include disabled jobs (default false)",
+			},
+		},
+	}
+}
+
+type cronListEntry struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Schedule   string `json:"schedule"`
+	Message    string `json:"message"`
+	Enabled    bool   `json:"enabled"`
+	NextRun    string `json:"nextRun,omitempty"`
+	LastStatus string `json:"lastStatus,omitempty"`
+}
+
+func (t *CronListTool) Execute(ctx context.Context, args map[string]any) *Result {
+	includeDisabled, _ := args["includeDisabled"].(bool)
+	jobs := t.cron.cronStore.ListJobs(ctx, includeDisabled, resolveAgentIDString(ctx), store.UserIDFromContext(ctx))
+
+	entries := make([]cronListEntry, 0, len(jobs))
+	for _, j := range jobs {
+		e := cronListEntry{
+			ID:         j.ID,
+			Name:       j.Name,
+			Schedule:   describeSchedule(j.Schedule),
+			Message:    j.Payload.Message,
+			Enabled:    j.Enabled,
+			LastStatus: j.State.LastStatus,
+		}
+		if j.State.NextRunAtMS != nil {
+			e.NextRun = time.UnixMilli(*j.State.NextRunAtMS).UTC().Format(time.RFC3339)
+		}
+		entries = append(entries, e)
+	}
+	data, _ := json.MarshalIndent(map[string]any{"jobs": entries, "count": len(entries)}, "", "  ")
+	return NewResult(string(data))
+}
+
+// CronRemoveTool deletes one of the caller's jobs.
+type CronRemoveTool struct {
+	cron *CronTool
+}
+
+func NewCronRemoveTool(cron *CronTool) *CronRemoveTool { return &CronRemoveTool{cron: cron} }
+
+func (t *CronRemoveTool) Name() string { return "cron_remove" }
+
+func (t *CronRemoveTool) Description() string {
+	return "Cancel a scheduled job or reminder by id (from cron_list). Only jobs created in this conversation's scope can be removed."
+}
+
+func (t *CronRemoveTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{
+				"type":        "string",
+				"description": "Job id from cron_list",
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+func (t *CronRemoveTool) Execute(ctx context.Context, args map[string]any) *Result {
+	if res := t.cron.checkMutationPermission(ctx); res != nil {
+		return res
+	}
+	jobID := resolveJobID(args)
+	if jobID == "" {
+		return ErrorResult("id is required")
+	}
+	agentID, userID := resolveAgentIDString(ctx), store.UserIDFromContext(ctx)
+	job, errResult := t.cron.checkJobOwnership(ctx, jobID, agentID, userID)
+	if errResult != nil {
+		return errResult
+	}
+	if err := t.cron.cronStore.RemoveJob(ctx, jobID); err != nil {
+		return ErrorResult(fmt.Sprintf("failed to remove cron job: %v", err))
+	}
+	data, _ := json.MarshalIndent(map[string]any{"deleted": true, "id": jobID, "name": job.Name}, "", "  ")
+	return NewResult(string(data))
+}
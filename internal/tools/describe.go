@@ -0,0 +1,72 @@
+package tools
+
+// Tool sources reported by DescribeTool.
+const (
+	ToolSourceBuiltin = "builtin"
+	ToolSourceSkill   = "skill"
+	ToolSourceMCP     = "mcp"
+)
+
+// skillToolNames are the builtin tools that expose the skills system to the agent.
+var skillToolNames = map[string]bool{
+	"skill_search":  true,
+	"use_skill":     true,
+	"skill_manage":  true,
+	"publish_skill": true,
+}
+
+// mcpServerTool is implemented by tools bridged from an MCP server.
+type mcpServerTool interface {
+	ServerName() string
+}
+
+// ToolInfo describes a tool for listings (CLI, HTTP API).
+type ToolInfo struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+	Source      string         `json:"source"`           // builtin, skill or mcp
+	Server      string         `json:"server,omitempty"` // MCP server name
+	AliasOf     string         `json:"aliasOf,omitempty"`
+}
+
+// DescribeTool returns listing info for a tool or alias in exec.
+// Disabled tools are not found.
+func DescribeTool(exec ToolExecutor, name string) (ToolInfo, bool) {
+	tool, ok := exec.Get(name)
+	if !ok {
+		return ToolInfo{}, false
+	}
+
+	info := ToolInfo{
+		Name:        name,
+		Description: tool.Description(),
+		Parameters:  tool.Parameters(),
+		Source:      ToolSourceBuiltin,
+	}
+	if canonical := tool.Name(); canonical != name {
+		info.AliasOf = canonical
+	}
+	switch {
+	case skillToolNames[tool.Name()]:
+		info.Source = ToolSourceSkill
+	default:
+		if m, ok := tool.(mcpServerTool); ok {
+			info.Source = ToolSourceMCP
+			info.Server = m.ServerName()
+		}
+	}
+	return info, true
+}
+
+// DescribeAll returns listing info for every enabled tool, sorted by name.
+func (r *Registry) DescribeAll() []ToolInfo {
+	names := r.List()
+	infos := make([]ToolInfo, 0, len(names))
+	for _, name := range names {
+		if info, ok := DescribeTool(r, name); ok {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
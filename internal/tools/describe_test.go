@@ -0,0 +1,39 @@
+package tools
+
+import "testing"
+
+type mockMCPTool struct{ mockTool }
+
+func (m *mockMCPTool) ServerName() string { return "github" }
+
+func TestDescribeTool(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(&mockTool{name: "read_file"})
+	reg.Register(&mockTool{name: "skill_search"})
+	reg.Register(&mockMCPTool{mockTool{name: "mcp_github__list_issues"}})
+	reg.RegisterAlias("Read", "read_file")
+	reg.Register(&mockTool{name: "exec"})
+	reg.Disable("exec")
+
+	cases := map[string]struct{ source, server, aliasOf string }{
+		"read_file":               {ToolSourceBuiltin, "", ""},
+		"Read":                    {ToolSourceBuiltin, "", "read_file"},
+		"skill_search":            {ToolSourceSkill, "", ""},
+		"mcp_github__list_issues": {ToolSourceMCP, "github", ""},
+	}
+	for name, want := range cases {
+		info, ok := DescribeTool(reg, name)
+		if !ok {
+			t.Fatalf("Describe(%q) not found", name)
+		}
+		if info.Name != name || info.Source != want.source || info.Server != want.server || info.AliasOf != want.aliasOf {
+			t.Errorf("Describe(%q) = %+v, want %+v", name, info, want)
+		}
+	}
+	if _, ok := DescribeTool(reg, "exec"); ok {
+		t.Error("disabled tool should not be described")
+	}
+	if got := len(reg.DescribeAll()); got != 3 {
+		t.Errorf("DescribeAll returned %d tools, want 3 (aliases and disabled excluded)", got)
+	}
+}
@@ -11,7 +11,8 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
-// EditTool performs search-and-replace edits on files.
+// EditTool performs targeted edits on files: a single search-and-replace,
+// a batch of search/replace blocks, or a unified diff (see edit_patch.go).
 // Supports context file interceptor and sandbox routing.
 type EditTool struct {
 	workspace       string
@@ -63,7 +64,9 @@ func (t *EditTool) SetSandboxKey(key string) {}
 
 func (t *EditTool) Name() string { return "edit" }
 func (t *EditTool) Description() string {
-	return "Edit a file by replacing exact text matches. Use old_string/new_string for precise edits without rewriting the entire file."
+	return "Edit a file without rewriting it. Use old_string/new_string for one replacement, edits for several search/replace blocks, " +
+		"or patch for a unified diff (@@ hunks with context lines). All changes apply atomically; on a conflict nothing is written. " +
+		"Set dry_run to check that the edit applies without writing."
 }
 
 func (t *EditTool) Parameters() map[string]any {
@@ -86,25 +89,40 @@ func (t *EditTool) Parameters() map[string]any {
 				"type":        "boolean",
 				"description": "Replace all occurrences (default: false, requires unique match)",
 			},
+			"edits": map[string]any{
+				"type":        "array",
+				"description": "Search/replace blocks applied in order, instead of old_string/new_string",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"old_string":  map[string]any{"type": "string"},
+						"new_string":  map[string]any{"type": "string"},
+						"replace_all": map[string]any{"type": "boolean"},
+					},
+					"required": []string{"old_string", "new_string"},
+				},
+			},
+			"patch": map[string]any{
+				"type":        "string",
+				"description": "Unified diff for this file (---/+++ headers optional). Hunks are located by their context lines, so line numbers may be approximate",
+			},
+			"dry_run": map[string]any{
+				"type":        "boolean",
+				"description": "Validate the edit and report what would change without writing (default: false)",
+			},
 		},
-		"required": []string{"path", "old_string", "new_string"},
+		"required": []string{"path"},
 	}
 }
 
 func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 	path, _ := args["path"].(string)
-	oldStr, _ := args["old_string"].(string)
-	newStr, _ := args["new_string"].(string)
-	replaceAll, _ := args["replace_all"].(bool)
-
 	if path == "" {
 		return ErrorResult("path is required")
 	}
-	if oldStr == "" {
-		return ErrorResult("old_string is required")
-	}
-	if oldStr == newStr {
-		return ErrorResult("old_string and new_string are identical")
+	ed, err := parseFileEdit(args)
+	if err != nil {
+		return ErrorResult(err.Error())
 	}
 
 	// Group write permission check
@@ -123,10 +141,13 @@ func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 			if content == "" {
 				return ErrorResult(fmt.Sprintf("context file not found: %s", path))
 			}
-			newContent, result := applyEdit(content, oldStr, newStr, replaceAll)
+			newContent, summary, result := ed.apply(content)
 			if result != nil {
 				return result
 			}
+			if ed.dryRun {
+				return dryRunResult(path, summary)
+			}
 			if _, err := t.contextFileIntc.WriteFile(ctx, path, newContent); err != nil {
 				return ErrorResult(fmt.Sprintf("failed to write context file: %v", err))
 			}
@@ -143,10 +164,13 @@ func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 			if content == "" {
 				return ErrorResult(fmt.Sprintf("memory file not found: %s", path))
 			}
-			newContent, result := applyEdit(content, oldStr, newStr, replaceAll)
+			newContent, summary, result := ed.apply(content)
 			if result != nil {
 				return result
 			}
+			if ed.dryRun {
+				return dryRunResult(path, summary)
+			}
 			mwr, err := t.memIntc.WriteFile(ctx, path, newContent, false)
 			if err != nil {
 				return ErrorResult(fmt.Sprintf("failed to write memory file: %v", err))
@@ -162,7 +186,7 @@ func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 	// Sandbox routing
 	sandboxKey := ToolSandboxKeyFromCtx(ctx)
 	if t.sandboxMgr != nil && sandboxKey != "" {
-		return t.executeInSandbox(ctx, path, oldStr, newStr, replaceAll, sandboxKey)
+		return t.executeInSandbox(ctx, path, ed, sandboxKey)
 	}
 
 	// Host execution — use per-user workspace from context if available
@@ -179,16 +203,21 @@ func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 		return ErrorResult(err.Error())
 	}
 
+	if info, err := os.Stat(resolved); err == nil && info.Size() > maxEditFileBytes {
+		return ErrorResult(fmt.Sprintf("file is %d bytes, edit limit is %d — use write_file or exec for large files", info.Size(), maxEditFileBytes))
+	}
 	data, err := os.ReadFile(resolved)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("failed to read file: %v", err))
 	}
 
-	content := string(data)
-	newContent, result := applyEdit(content, oldStr, newStr, replaceAll)
+	newContent, summary, result := ed.apply(string(data))
 	if result != nil {
 		return result
 	}
+	if ed.dryRun {
+		return dryRunResult(path, summary)
+	}
 
 	if err := os.MkdirAll(filepath.Dir(resolved), 0755); err != nil {
 		return ErrorResult(fmt.Sprintf("failed to create directory: %v", err))
@@ -202,11 +231,10 @@ func (t *EditTool) Execute(ctx context.Context, args map[string]any) *Result {
 		go t.vaultIntc.AfterWrite(context.WithoutCancel(ctx), resolved, newContent)
 	}
 
-	count := strings.Count(content, oldStr)
-	return SilentResult(fmt.Sprintf("File edited: %s (%d replacement(s))", path, count))
+	return SilentResult(fmt.Sprintf("File edited: %s (%s)", path, summary))
 }
 
-func (t *EditTool) executeInSandbox(ctx context.Context, path, oldStr, newStr string, replaceAll bool, sandboxKey string) *Result {
+func (t *EditTool) executeInSandbox(ctx context.Context, path string, ed *fileEdit, sandboxKey string) *Result {
 	sb, err := t.sandboxMgr.Get(ctx, sandboxKey, t.workspace, SandboxConfigFromCtx(ctx))
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("sandbox error: %v", err))
@@ -218,23 +246,30 @@ func (t *EditTool) executeInSandbox(ctx context.Context, path, oldStr, newStr st
 	}
 	containerPath := ResolveSandboxPath(path, containerCwd)
 
-	bridge := sandbox.NewFsBridge(sb.ID(), sandbox.DefaultContainerWorkdir)
+	bridge := sandbox.NewFsBridge(sb, sandbox.DefaultContainerWorkdir)
 	content, err := bridge.ReadFile(ctx, containerPath)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("failed to read file: %v", err) + MaybeFsBridgeHint(err))
 	}
 
-	newContent, result := applyEdit(content, oldStr, newStr, replaceAll)
+	newContent, summary, result := ed.apply(content)
 	if result != nil {
 		return result
 	}
+	if ed.dryRun {
+		return dryRunResult(path, summary)
+	}
 
 	if err := bridge.WriteFile(ctx, containerPath, newContent, false); err != nil {
 		return ErrorResult(fmt.Sprintf("failed to write file: %v", err) + MaybeFsBridgeHint(err))
 	}
 
-	count := strings.Count(content, oldStr)
-	return SilentResult(fmt.Sprintf("File edited: %s (%d replacement(s))", path, count))
+	return SilentResult(fmt.Sprintf("File edited: %s (%s)", path, summary))
+}
+
+// dryRunResult reports a validated edit that was not written.
+func dryRunResult(path, summary string) *Result {
+	return SilentResult(fmt.Sprintf("Dry run: edit applies cleanly to %s (%s). No changes written.", path, summary))
 }
 
 // applyEdit performs the search-and-replace. Returns (newContent, nil) on success
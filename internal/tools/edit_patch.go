@@ -0,0 +1,340 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Size limits for the edit tool. Edits are meant for targeted changes —
+// anything larger should be a write_file rewrite.
+const (
+	maxEditFileBytes  = 5 << 20   // files larger than this are not edited in place
+	maxEditPatchBytes = 256 << 10 // unified diff / edits payload
+	maxEditBlocks     = 50        // search/replace blocks per call
+)
+
+// fileEdit is one edit call: a single old/new replacement, a list of
+// search/replace blocks, or a unified diff. Exactly one mode is set.
+type fileEdit struct {
+	blocks []editBlock
+	patch  []diffHunk
+	dryRun bool
+}
+
+// editBlock is a single search/replace operation.
+type editBlock struct {
+	oldStr     string
+	newStr     string
+	replaceAll bool
+}
+
+// diffHunk is one "@@ -a,b +c,d @@" section of a unified diff.
+type diffHunk struct {
+	header   string
+	oldStart int      // 1-based line number in the original file
+	oldLines []string // context + removed lines, in order
+	newLines []string // context + added lines, in order
+	added    int
+	removed  int
+}
+
+// parseFileEdit reads the edit mode from tool arguments.
+func parseFileEdit(args map[string]any) (*fileEdit, error) {
+	ed := &fileEdit{}
+	ed.dryRun, _ = args["dry_run"].(bool)
+
+	oldStr, hasOld := args["old_string"].(string)
+	patch, _ := args["patch"].(string)
+	rawBlocks, _ := args["edits"].([]any)
+
+	modes := 0
+	for _, set := range []bool{hasOld && oldStr != "", patch != "", len(rawBlocks) > 0} {
+		if set {
+			modes++
+		}
+	}
+	switch {
+	case modes == 0:
+		return nil, fmt.Errorf("provide old_string/new_string, edits, or patch")
+	case modes > 1:
+		return nil, fmt.Errorf("old_string, edits and patch are mutually exclusive — use one per call")
+	}
+
+	switch {
+	case patch != "":
+		if len(patch) > maxEditPatchBytes {
+			return nil, fmt.Errorf("patch is %d bytes, limit is %d — split it into several calls or use write_file", len(patch), maxEditPatchBytes)
+		}
+		hunks, err := parseUnifiedDiff(patch)
+		if err != nil {
+			return nil, err
+		}
+		ed.patch = hunks
+
+	case len(rawBlocks) > 0:
+		if len(rawBlocks) > maxEditBlocks {
+			return nil, fmt.Errorf("%d edits in one call, limit is %d", len(rawBlocks), maxEditBlocks)
+		}
+		size := 0
+		for i, raw := range rawBlocks {
+			m, ok := raw.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("edits[%d] must be an object with old_string and new_string", i)
+			}
+			b := editBlock{}
+			b.oldStr, _ = m["old_string"].(string)
+			b.newStr, _ = m["new_string"].(string)
+			b.replaceAll, _ = m["replace_all"].(bool)
+			if b.oldStr == "" {
+				return nil, fmt.Errorf("edits[%d].old_string is required", i)
+			}
+			if b.oldStr == b.newStr {
+				return nil, fmt.Errorf("edits[%d]: old_string and new_string are identical", i)
+			}
+			size += len(b.oldStr) + len(b.newStr)
+			ed.blocks = append(ed.blocks, b)
+		}
+		if size > maxEditPatchBytes {
+			return nil, fmt.Errorf("edits total %d bytes, limit is %d — split them into several calls or use write_file", size, maxEditPatchBytes)
+		}
+
+	default:
+		newStr, _ := args["new_string"].(string)
+		if oldStr == newStr {
+			return nil, fmt.Errorf("old_string and new_string are identical")
+		}
+		replaceAll, _ := args["replace_all"].(bool)
+		ed.blocks = []editBlock{{oldStr: oldStr, newStr: newStr, replaceAll: replaceAll}}
+	}
+	return ed, nil
+}
+
+// apply runs the edit against content. It returns the new content and a short
+// summary of what changed, or an error result when any block or hunk does not
+// apply — in which case nothing is changed.
+func (ed *fileEdit) apply(content string) (string, string, *Result) {
+	if len(content) > maxEditFileBytes {
+		return "", "", ErrorResult(fmt.Sprintf("file is %d bytes, edit limit is %d — use write_file or exec for large files", len(content), maxEditFileBytes))
+	}
+
+	if ed.patch != nil {
+		out, err := applyUnifiedDiff(content, ed.patch)
+		if err != nil {
+			return "", "", ErrorResult(err.Error())
+		}
+		added, removed := 0, 0
+		for _, h := range ed.patch {
+			added += h.added
+			removed += h.removed
+		}
+		return out, fmt.Sprintf("%d hunk(s), +%d -%d lines", len(ed.patch), added, removed), nil
+	}
+
+	if len(ed.blocks) == 1 {
+		b := ed.blocks[0]
+		out, result := applyEdit(content, b.oldStr, b.newStr, b.replaceAll)
+		if result != nil {
+			return "", "", result
+		}
+		return out, fmt.Sprintf("%d replacement(s)", strings.Count(content, b.oldStr)), nil
+	}
+
+	total := 0
+	for i, b := range ed.blocks {
+		n := strings.Count(content, b.oldStr)
+		out, result := applyEdit(content, b.oldStr, b.newStr, b.replaceAll)
+		if result != nil {
+			return "", "", ErrorResult(fmt.Sprintf("edits[%d]: %s (no changes applied)", i, result.ForLLM))
+		}
+		if !b.replaceAll {
+			n = 1
+		}
+		total += n
+		content = out
+	}
+	return content, fmt.Sprintf("%d edit(s), %d replacement(s)", len(ed.blocks), total), nil
+}
+
+// parseUnifiedDiff parses the hunks of a single-file unified diff. File
+// headers (---/+++, diff, index) are optional.
+func parseUnifiedDiff(patch string) ([]diffHunk, error) {
+	lines := strings.Split(strings.ReplaceAll(patch, "\r\n", "\n"), "\n")
+	var hunks []*diffHunk
+	var cur *diffHunk
+	files := 0
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ "):
+			files++
+			if files > 1 {
+				return nil, fmt.Errorf("patch touches more than one file — call edit once per file")
+			}
+			cur = nil
+			i++ // skip "+++"
+
+		case strings.HasPrefix(line, "@@"):
+			start, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("patch line %d: %v", i+1, err)
+			}
+			cur = &diffHunk{header: line, oldStart: start}
+			hunks = append(hunks, cur)
+
+		case cur == nil:
+			// Header lines (diff --git, index) before the first hunk.
+			continue
+
+		case strings.HasPrefix(line, "+"):
+			cur.newLines = append(cur.newLines, line[1:])
+			cur.added++
+
+		case strings.HasPrefix(line, "-"):
+			cur.oldLines = append(cur.oldLines, line[1:])
+			cur.removed++
+
+		case strings.HasPrefix(line, " "):
+			cur.oldLines = append(cur.oldLines, line[1:])
+			cur.newLines = append(cur.newLines, line[1:])
+
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+			continue
+
+		case line == "":
+			// Blank context line whose leading space was stripped. A trailing
+			// empty line is just the end of the patch.
+			if i == len(lines)-1 {
+				continue
+			}
+			cur.oldLines = append(cur.oldLines, "")
+			cur.newLines = append(cur.newLines, "")
+
+		default:
+			return nil, fmt.Errorf("patch line %d: unexpected %q — hunk lines must start with ' ', '+' or '-'", i+1, truncateCmd(line, 60))
+		}
+	}
+
+	if len(hunks) == 0 {
+		return nil, fmt.Errorf("patch has no hunks (expected \"@@ -start,count +start,count @@\" headers)")
+	}
+	out := make([]diffHunk, 0, len(hunks))
+	for _, h := range hunks {
+		if h.added == 0 && h.removed == 0 {
+			return nil, fmt.Errorf("hunk %q changes nothing", h.header)
+		}
+		out = append(out, *h)
+	}
+	return out, nil
+}
+
+// parseHunkHeader returns the old-file start line of "@@ -a[,b] +c[,d] @@".
+func parseHunkHeader(line string) (int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "@@" || !strings.HasPrefix(fields[1], "-") {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	startStr, _, _ := strings.Cut(fields[1][1:], ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil || start < 0 {
+		return 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	return start, nil
+}
+
+// applyUnifiedDiff applies hunks in order. Each hunk is matched against its
+// context and removed lines, first at the stated line (shifted by earlier
+// hunks) and then at the nearest position after the previous hunk, so stale
+// line numbers still apply. A hunk whose lines are not in the file is a
+// conflict and fails the whole patch.
+func applyUnifiedDiff(content string, hunks []diffHunk) (string, error) {
+	trailingNewline := strings.HasSuffix(content, "\n")
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	}
+
+	minPos, shift := 0, 0
+	for i, h := range hunks {
+		want := h.oldStart - 1 + shift
+		if len(h.oldLines) == 0 {
+			want = h.oldStart + shift // pure insertion goes after line oldStart
+		}
+		pos := findHunk(lines, h.oldLines, want, minPos)
+		if pos < 0 {
+			return "", hunkConflict(i, h, lines, want)
+		}
+
+		next := make([]string, 0, len(lines)-len(h.oldLines)+len(h.newLines))
+		next = append(next, lines[:pos]...)
+		next = append(next, h.newLines...)
+		next = append(next, lines[pos+len(h.oldLines):]...)
+		lines = next
+
+		minPos = pos + len(h.newLines)
+		shift += len(h.newLines) - len(h.oldLines)
+	}
+
+	out := strings.Join(lines, "\n")
+	if trailingNewline && len(lines) > 0 {
+		out += "\n"
+	}
+	return out, nil
+}
+
+// findHunk returns the index where old appears in lines at or after minPos,
+// preferring the position closest to want. Returns -1 when there is no match.
+func findHunk(lines, old []string, want, minPos int) int {
+	maxPos := len(lines) - len(old)
+	if want < minPos {
+		want = minPos
+	}
+	if want > maxPos {
+		want = maxPos
+	}
+	for d := 0; ; d++ {
+		lo, hi := want-d, want+d
+		if lo < minPos && hi > maxPos {
+			return -1
+		}
+		if lo >= minPos && matchLines(lines, old, lo) {
+			return lo
+		}
+		if d > 0 && hi <= maxPos && matchLines(lines, old, hi) {
+			return hi
+		}
+	}
+}
+
+// matchLines reports whether old appears in lines at pos. Trailing
+// whitespace is ignored, since it is often lost when diffs are copied.
+func matchLines(lines, old []string, pos int) bool {
+	if pos < 0 || pos+len(old) > len(lines) {
+		return false
+	}
+	for i, l := range old {
+		if strings.TrimRight(lines[pos+i], " \t\r") != strings.TrimRight(l, " \t\r") {
+			return false
+		}
+	}
+	return true
+}
+
+// hunkConflict describes the first line where the hunk differs from the file
+// at its stated position, so the model can re-read and regenerate the hunk.
+func hunkConflict(i int, h diffHunk, lines []string, want int) error {
+	for j, l := range h.oldLines {
+		n := want + j
+		if n < 0 || n >= len(lines) {
+			return fmt.Errorf("conflict: hunk %d (%s) does not apply — file has %d lines, expected %q at line %d (no changes applied)",
+				i+1, h.header, len(lines), truncateCmd(l, 80), n+1)
+		}
+		if strings.TrimRight(lines[n], " \t\r") != strings.TrimRight(l, " \t\r") {
+			return fmt.Errorf("conflict: hunk %d (%s) does not apply — expected %q at line %d, found %q. Re-read the file and regenerate the patch (no changes applied)",
+				i+1, h.header, truncateCmd(l, 80), n+1, truncateCmd(lines[n], 80))
+		}
+	}
+	return fmt.Errorf("conflict: hunk %d (%s) does not apply after the previous hunk (no changes applied)", i+1, h.header)
+}
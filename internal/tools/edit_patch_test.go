@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const patchSample = "package main\n\nfunc a() int {\n\treturn 1\n}\n\nfunc b() int {\n\treturn 2\n}\n"
+
+func TestApplyUnifiedDiff(t *testing.T) {
+	patch := `--- a/main.go
++++ b/main.go
+@@ -3,3 +3,3 @@
+ func a() int {
+-	return 1
++	return 10
+ }
+@@ -7,3 +7,4 @@
+ func b() int {
+-	return 2
++	x := 2
++	return x
+ }
+`
+	hunks, err := parseUnifiedDiff(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := applyUnifiedDiff(patchSample, hunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package main\n\nfunc a() int {\n\treturn 10\n}\n\nfunc b() int {\n\tx := 2\n\treturn x\n}\n"
+	if got != want {
+		t.Errorf("patched =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyUnifiedDiff_StaleLineNumbers(t *testing.T) {
+	// Header says line 40; the hunk is found by its context instead.
+	hunks, err := parseUnifiedDiff("@@ -40,2 +40,2 @@\n func b() int {\n-\treturn 2\n+\treturn 3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := applyUnifiedDiff(patchSample, hunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "return 3") || !strings.Contains(got, "return 1") {
+		t.Errorf("patched = %q", got)
+	}
+}
+
+func TestApplyUnifiedDiff_Conflict(t *testing.T) {
+	hunks, err := parseUnifiedDiff("@@ -3,2 +3,2 @@\n func a() int {\n-\treturn 99\n+\treturn 100\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = applyUnifiedDiff(patchSample, hunks)
+	if err == nil || !strings.Contains(err.Error(), "conflict: hunk 1") || !strings.Contains(err.Error(), `found "\treturn 1"`) {
+		t.Errorf("err = %v, want conflict naming the mismatching line", err)
+	}
+}
+
+func TestParseUnifiedDiff_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"no hunks":   "just some text\n",
+		"multi file": "--- a/x\n+++ b/x\n@@ -1 +1 @@\n-a\n+b\n--- a/y\n+++ b/y\n@@ -1 +1 @@\n-a\n+b\n",
+		"bad line":   "@@ -1,1 +1,1 @@\n-a\n+b\n*c\n",
+		"no-op hunk": "@@ -1,1 +1,1 @@\n a\n",
+		"bad header": "@@ one two @@\n-a\n",
+	}
+	for name, patch := range cases {
+		if _, err := parseUnifiedDiff(patch); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEditTool_BlocksAreAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte(patchSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tool := NewEditTool(dir, true)
+
+	res := tool.Execute(context.Background(), map[string]any{
+		"path": "main.go",
+		"edits": []any{
+			map[string]any{"old_string": "return 1", "new_string": "return 10"},
+			map[string]any{"old_string": "return 42", "new_string": "return 43"},
+		},
+	})
+	if !res.IsError || !strings.Contains(res.ForLLM, "edits[1]") {
+		t.Fatalf("result = %+v, want error for second block", res)
+	}
+	if data, _ := os.ReadFile(path); string(data) != patchSample {
+		t.Error("file changed although one block failed")
+	}
+
+	res = tool.Execute(context.Background(), map[string]any{
+		"path": "main.go",
+		"edits": []any{
+			map[string]any{"old_string": "return 1", "new_string": "return 10"},
+			map[string]any{"old_string": "return 2", "new_string": "return 20"},
+		},
+	})
+	if res.IsError {
+		t.Fatal(res.ForLLM)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "return 10") || !strings.Contains(string(data), "return 20") {
+		t.Errorf("file = %q", data)
+	}
+}
+
+func TestEditTool_PatchDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte(patchSample), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tool := NewEditTool(dir, true)
+
+	res := tool.Execute(context.Background(), map[string]any{
+		"path":    "main.go",
+		"patch":   "@@ -4 +4 @@\n-\treturn 1\n+\treturn 5\n",
+		"dry_run": true,
+	})
+	if res.IsError || !strings.Contains(res.ForLLM, "Dry run") || !strings.Contains(res.ForLLM, "+1 -1") {
+		t.Fatalf("result = %+v", res)
+	}
+	if data, _ := os.ReadFile(path); string(data) != patchSample {
+		t.Error("dry run wrote the file")
+	}
+
+	res = tool.Execute(context.Background(), map[string]any{
+		"path":       "main.go",
+		"patch":      "@@ -4 +4 @@\n-\treturn 1\n+\treturn 5\n",
+		"old_string": "x",
+	})
+	if !res.IsError || !strings.Contains(res.ForLLM, "mutually exclusive") {
+		t.Errorf("mixed modes: result = %+v", res)
+	}
+}
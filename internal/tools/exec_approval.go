@@ -1,12 +1,18 @@
 package tools
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"path/filepath"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 // ExecSecurity determines the overall security mode for command execution.
@@ -42,6 +48,7 @@ type ExecApprovalConfig struct {
 	Security  ExecSecurity `json:"security"`  // "deny", "allowlist", "full" (default "full")
 	Ask       ExecAskMode  `json:"ask"`       // "off", "on-miss", "always" (default "off")
 	Allowlist []string     `json:"allowlist"` // glob patterns for allowed commands
+	Tools     []string     `json:"tools"`     // tools that need approval on every call: "write_file", "browser", "browser:act"
 }
 
 // DefaultExecApprovalConfig returns the default (permissive) config.
@@ -88,19 +95,39 @@ const (
 // PendingApproval is an in-flight approval request.
 type PendingApproval struct {
 	ID        string    `json:"id"`
-	Command   string    `json:"command"`
+	Tool      string    `json:"tool"`
+	Command   string    `json:"command"` // exec command, or a one-line summary of the tool arguments
 	AgentID   string    `json:"agentId"`
+	UserID    string    `json:"userId,omitempty"`   // requesting user, for WS event filtering
+	SenderID  string    `json:"senderId,omitempty"` // channel sender whose message triggered the call
+	Channel   string    `json:"channel,omitempty"`  // originating channel instance
+	ChatID    string    `json:"chatId,omitempty"`   // originating chat (local key incl. topic suffix)
 	CreatedAt time.Time `json:"createdAt"`
-	resultCh  chan ApprovalDecision
+	ExpiresAt time.Time `json:"expiresAt"`
+	TenantID  uuid.UUID `json:"-"`
+	resultCh  chan approvalResult
 }
 
+// approvalResult carries a decision and who made it.
+type approvalResult struct {
+	decision ApprovalDecision
+	actor    string
+}
+
+// ApprovalNotifier delivers a new approval request to the requesting chat
+// (e.g. a Telegram message with inline buttons).
+type ApprovalNotifier func(ctx context.Context, pa *PendingApproval)
+
 // ExecApprovalManager manages pending approval requests and the dynamic allowlist.
 type ExecApprovalManager struct {
-	config       ExecApprovalConfig
-	pending      map[string]*PendingApproval
-	alwaysAllow  map[string]bool // patterns added via "allow-always" decisions
-	mu           sync.Mutex
-	nextID       int
+	config      ExecApprovalConfig
+	pending     map[string]*PendingApproval
+	alwaysAllow map[string]bool // patterns added via "allow-always" decisions
+	mu          sync.Mutex
+	nextID      int
+
+	eventPub bus.EventPublisher // nil = no WS events / audit records
+	notifier ApprovalNotifier   // nil = approvals only via WS
 }
 
 // NewExecApprovalManager creates an approval manager with the given config.
@@ -112,6 +139,17 @@ func NewExecApprovalManager(cfg ExecApprovalConfig) *ExecApprovalManager {
 	}
 }
 
+// SetEventPublisher enables exec.approval.requested/resolved events and
+// audit records for every decision.
+func (m *ExecApprovalManager) SetEventPublisher(pub bus.EventPublisher) {
+	m.eventPub = pub
+}
+
+// SetNotifier sets the callback that forwards new requests to the requesting channel.
+func (m *ExecApprovalManager) SetNotifier(fn ApprovalNotifier) {
+	m.notifier = fn
+}
+
 // CheckCommand evaluates whether a command should be executed, blocked, or needs approval.
 // Returns: "allow", "deny", or "ask".
 func (m *ExecApprovalManager) CheckCommand(command string) string {
@@ -148,53 +186,104 @@ func (m *ExecApprovalManager) CheckCommand(command string) string {
 	return "allow"
 }
 
-// RequestApproval creates a pending approval and blocks until resolved or timeout.
+// RequestApproval creates a pending exec approval and blocks until resolved or timeout.
 func (m *ExecApprovalManager) RequestApproval(command, agentID string, timeout time.Duration) (ApprovalDecision, error) {
+	return m.RequestToolApproval(context.Background(), "exec", command, agentID, timeout)
+}
+
+// RequestToolApproval creates a pending approval for a tool call and blocks
+// until it is resolved, the timeout expires, or ctx is cancelled. The
+// requesting user and chat are read from ctx so the request can be shown to
+// them. Timeouts and cancellation count as a deny.
+func (m *ExecApprovalManager) RequestToolApproval(ctx context.Context, tool, command, agentID string, timeout time.Duration) (ApprovalDecision, error) {
+	chatID := ToolLocalKeyFromCtx(ctx)
+	if chatID == "" {
+		chatID = ToolChatIDFromCtx(ctx)
+	}
+	now := time.Now()
+
 	m.mu.Lock()
 	m.nextID++
-	id := fmt.Sprintf("exec-%d", m.nextID)
+	prefix := "exec"
+	if tool != "exec" {
+		prefix = "tool"
+	}
+	id := fmt.Sprintf("%s-%d", prefix, m.nextID)
 	pa := &PendingApproval{
 		ID:        id,
+		Tool:      tool,
 		Command:   command,
 		AgentID:   agentID,
-		CreatedAt: time.Now(),
-		resultCh:  make(chan ApprovalDecision, 1),
+		UserID:    store.UserIDFromContext(ctx),
+		SenderID:  store.SenderIDFromContext(ctx),
+		Channel:   ToolChannelFromCtx(ctx),
+		ChatID:    chatID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(timeout),
+		TenantID:  store.TenantIDFromContext(ctx),
+		resultCh:  make(chan approvalResult, 1),
 	}
 	m.pending[id] = pa
 	m.mu.Unlock()
 
-	slog.Info("exec approval requested", "id", id, "command", truncateCmd(command, 100))
+	slog.Info("exec approval requested", "id", id, "tool", tool, "command", truncateCmd(command, 100))
+	m.publishRequested(pa)
+	if m.notifier != nil && pa.Channel != "" && pa.ChatID != "" {
+		m.notifier(ctx, pa)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 
-	// Wait for resolution or timeout
+	// Wait for resolution, timeout or cancellation
+	var res approvalResult
+	var err error
 	select {
-	case decision := <-pa.resultCh:
-		m.mu.Lock()
-		delete(m.pending, id)
-		m.mu.Unlock()
-
-		// If allow-always, add the command's base binary to the dynamic allowlist
-		if decision == ApprovalAllowAlways {
-			bin := extractBin(command)
-			if bin != "" {
-				m.mu.Lock()
-				m.alwaysAllow[bin] = true
-				m.mu.Unlock()
-				slog.Info("exec approval: added to always-allow", "bin", bin)
-			}
-		}
+	case res = <-pa.resultCh:
+	case <-timer.C:
+		res = approvalResult{decision: ApprovalDeny}
+		err = fmt.Errorf("approval timed out after %s", timeout)
+	case <-ctx.Done():
+		res = approvalResult{decision: ApprovalDeny}
+		err = fmt.Errorf("approval cancelled: %w", ctx.Err())
+	}
 
-		return decision, nil
+	m.mu.Lock()
+	delete(m.pending, id)
+	m.mu.Unlock()
 
-	case <-time.After(timeout):
-		m.mu.Lock()
-		delete(m.pending, id)
-		m.mu.Unlock()
-		return ApprovalDeny, fmt.Errorf("approval timed out after %s", timeout)
+	// If allow-always, add the command's base binary (or the tool) to the dynamic allowlist
+	if res.decision == ApprovalAllowAlways {
+		key := toolAllowKey(tool)
+		if tool == "exec" {
+			key = extractBin(command)
+		}
+		if key != "" {
+			m.mu.Lock()
+			m.alwaysAllow[key] = true
+			m.mu.Unlock()
+			slog.Info("exec approval: added to always-allow", "key", key)
+		}
 	}
+
+	m.recordDecision(pa, res, err)
+	return res.decision, err
 }
 
 // Resolve resolves a pending approval request.
 func (m *ExecApprovalManager) Resolve(id string, decision ApprovalDecision) error {
+	return m.ResolveBy(id, decision, "")
+}
+
+// ResolveBy resolves a pending approval request on behalf of actor (recorded
+// in the audit log).
+func (m *ExecApprovalManager) ResolveBy(id string, decision ApprovalDecision, actor string) error {
+	switch decision {
+	case ApprovalAllowOnce, ApprovalAllowAlways, ApprovalDeny:
+	default:
+		return fmt.Errorf("invalid approval decision %q", decision)
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -203,10 +292,22 @@ func (m *ExecApprovalManager) Resolve(id string, decision ApprovalDecision) erro
 		return fmt.Errorf("approval %q not found or already resolved", id)
 	}
 
-	pa.resultCh <- decision
+	select {
+	case pa.resultCh <- approvalResult{decision: decision, actor: actor}:
+	default:
+		return fmt.Errorf("approval %q not found or already resolved", id)
+	}
 	return nil
 }
 
+// Get returns a pending approval request by ID.
+func (m *ExecApprovalManager) Get(id string) (*PendingApproval, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pa, ok := m.pending[id]
+	return pa, ok
+}
+
 // ListPending returns all pending approval requests.
 func (m *ExecApprovalManager) ListPending() []*PendingApproval {
 	m.mu.Lock()
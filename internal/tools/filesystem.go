@@ -6,7 +6,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"runtime"
 	"strings"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
@@ -220,7 +219,7 @@ func (t *ReadFileTool) getFsBridge(ctx context.Context, sandboxKey string) (*san
 	if err != nil {
 		return nil, err
 	}
-	return sandbox.NewFsBridge(sb.ID(), sandbox.DefaultContainerWorkdir), nil
+	return sandbox.NewFsBridge(sb, sandbox.DefaultContainerWorkdir), nil
 }
 
 // readFileMaxChars is the output cap for read_file. Large files require offset/limit pagination.
@@ -538,15 +537,22 @@ func resolvePath(path, workspace string, restrict bool) (string, error) {
 // isPathInside checks whether child is inside or equal to parent directory.
 // On Windows, comparison is case-insensitive since NTFS paths are case-insensitive.
 func isPathInside(child, parent string) bool {
-	// Windows paths are case-insensitive; normalize to lowercase for comparison.
-	if runtime.GOOS == "windows" {
-		child = strings.ToLower(child)
-		parent = strings.ToLower(parent)
-	}
+	child, parent = foldPathCase(child), foldPathCase(parent)
 	if child == parent {
 		return true
 	}
-	return strings.HasPrefix(child, parent+string(filepath.Separator))
+	// Volume or filesystem roots ("/", `C:\`) already end in a separator.
+	if !strings.HasSuffix(parent, string(filepath.Separator)) {
+		parent += string(filepath.Separator)
+	}
+	return strings.HasPrefix(child, parent)
+}
+
+// isRootLevelDir reports whether dir (from filepath.Dir) places a file at the
+// workspace root: "." for bare names, or a lone separator for "/SOUL.md"
+// (either separator on Windows).
+func isRootLevelDir(dir string) bool {
+	return dir == "." || dir == "" || dir == "/" || dir == string(filepath.Separator)
 }
 
 // resolveThroughExistingAncestors resolves a path by finding the deepest
@@ -0,0 +1,660 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
+)
+
+// Search limits. Results are meant to point the agent at the right lines —
+// it should read_file the hits it cares about instead of paging through output.
+const (
+	defaultGrepMatches = 100
+	maxGrepMatches     = 500
+	maxGrepContext     = 10
+	maxGrepLineLen     = 300
+	maxGrepFileBytes   = 2 << 20 // larger files are skipped
+	binarySniffBytes   = 8 << 10 // a NUL byte in this prefix marks a file as binary
+
+	defaultGlobResults = 200
+	maxGlobResults     = 1000
+)
+
+// searchSkipDirs are directories never descended into by grep and glob.
+var searchSkipDirs = map[string]bool{
+	".git": true, "node_modules": true, ".hg": true, ".svn": true,
+}
+
+// searchBase holds the path policy shared by grep and glob.
+type searchBase struct {
+	workspace       string
+	restrict        bool
+	allowedPrefixes []string // extra allowed path prefixes (e.g. skills dirs)
+	deniedPrefixes  []string // path prefixes to deny access to (e.g. .goclaw)
+	sandboxMgr      sandbox.Manager
+}
+
+// AllowPaths adds extra path prefixes the search tools may access
+// even when restrict_to_workspace is true (e.g. skills directories).
+func (b *searchBase) AllowPaths(prefixes ...string) {
+	b.allowedPrefixes = append(b.allowedPrefixes, prefixes...)
+}
+
+// DenyPaths adds path prefixes that are rejected as roots and skipped while walking.
+func (b *searchBase) DenyPaths(prefixes ...string) {
+	b.deniedPrefixes = append(b.deniedPrefixes, prefixes...)
+}
+
+// SetSandboxKey is a no-op; sandbox key is now read from ctx (thread-safe).
+func (b *searchBase) SetSandboxKey(key string) {}
+
+// resolveRoot resolves the search root on the host and checks it against the
+// allow/deny lists.
+func (b *searchBase) resolveRoot(ctx context.Context, path string) (string, error) {
+	workspace := ToolWorkspaceFromCtx(ctx)
+	if workspace == "" {
+		workspace = b.workspace
+	}
+	allowed := allowedWithTeamWorkspace(ctx, b.allowedPrefixes)
+	resolved, err := resolvePathWithAllowed(path, workspace, effectiveRestrict(ctx, b.restrict), allowed)
+	if err != nil {
+		return "", err
+	}
+	if err := checkDeniedPath(resolved, b.workspace, b.deniedPrefixes); err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
+
+// deniedDirs returns the absolute denied directories, resolved once per walk
+// instead of once per file.
+func (b *searchBase) deniedDirs() []string {
+	if len(b.deniedPrefixes) == 0 {
+		return nil
+	}
+	absWorkspace, _ := filepath.Abs(b.workspace)
+	wsReal, err := filepath.EvalSymlinks(absWorkspace)
+	if err != nil {
+		wsReal = absWorkspace
+	}
+	dirs := make([]string, 0, len(b.deniedPrefixes))
+	for _, prefix := range b.deniedPrefixes {
+		dirs = append(dirs, filepath.Join(wsReal, prefix))
+	}
+	return dirs
+}
+
+// walkFiles calls fn for every regular file under root, skipping VCS and
+// dependency directories, symlinks and denied paths. fn returns false to stop.
+// Paths passed to fn are slash-separated and relative to root.
+func (b *searchBase) walkFiles(ctx context.Context, root string, fn func(abs, rel string) bool) error {
+	denied := b.deniedDirs()
+	stopped := false
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root {
+				return err
+			}
+			return nil // unreadable entry: skip
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		for _, dir := range denied {
+			if isPathInside(p, dir) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		if d.IsDir() {
+			if p != root && searchSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil // symlinks, sockets, devices
+		}
+		rel, relErr := filepath.Rel(root, p)
+		if relErr != nil {
+			return nil
+		}
+		if !fn(p, filepath.ToSlash(rel)) {
+			stopped = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if stopped {
+		return nil
+	}
+	return err
+}
+
+func (b *searchBase) getFsBridge(ctx context.Context, sandboxKey string) (*sandbox.FsBridge, string, error) {
+	sb, err := b.sandboxMgr.Get(ctx, sandboxKey, b.workspace, SandboxConfigFromCtx(ctx))
+	if err != nil {
+		return nil, "", fmt.Errorf("sandbox error: %v", err)
+	}
+	containerCwd, err := SandboxCwd(ctx, b.workspace, sandbox.DefaultContainerWorkdir)
+	if err != nil {
+		return nil, "", fmt.Errorf("sandbox path mapping: %v", err)
+	}
+	return sandbox.NewFsBridge(sb, sandbox.DefaultContainerWorkdir), containerCwd, nil
+}
+
+// clampedIntArg reads an integer argument, falling back to def and clamping to [lo, hi].
+func clampedIntArg(args map[string]any, key string, def, lo, hi int) int {
+	return min(max(intArg(args, key, def), lo), hi)
+}
+
+// ---- grep ----
+
+// GrepTool searches file contents with a regular expression, ripgrep style.
+type GrepTool struct {
+	searchBase
+}
+
+func NewGrepTool(workspace string, restrict bool) *GrepTool {
+	return &GrepTool{searchBase{workspace: workspace, restrict: restrict}}
+}
+
+func NewSandboxedGrepTool(workspace string, restrict bool, mgr sandbox.Manager) *GrepTool {
+	return &GrepTool{searchBase{workspace: workspace, restrict: restrict, sandboxMgr: mgr}}
+}
+
+func (t *GrepTool) Name() string { return "grep" }
+func (t *GrepTool) Description() string {
+	return "Search file contents with a regular expression (ripgrep style). Returns path:line:text for each match, " +
+		"with optional context lines. Binary files, .git and node_modules are skipped. " +
+		"Use this to locate code before read_file instead of listing and reading whole directories."
+}
+func (t *GrepTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"pattern": map[string]any{
+				"type":        "string",
+				"description": "Regular expression (RE2 syntax) to search for",
+			},
+			"path": map[string]any{
+				"type":        "string",
+				"description": "File or directory to search (relative to workspace; omit for workspace root)",
+			},
+			"glob": map[string]any{
+				"type":        "string",
+				"description": "Only search files matching this glob, e.g. \"*.go\" or \"src/**/*.ts\"",
+			},
+			"ignore_case": map[string]any{
+				"type":        "boolean",
+				"description": "Case-insensitive match",
+			},
+			"fixed_strings": map[string]any{
+				"type":        "boolean",
+				"description": "Treat pattern as a literal string, not a regex",
+			},
+			"context": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Lines of context to show before and after each match (max %d)", maxGrepContext),
+			},
+			"max_matches": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Stop after this many matching lines (default %d, max %d)", defaultGrepMatches, maxGrepMatches),
+			},
+		},
+		"required": []string{"pattern"},
+	}
+}
+
+// grepQuery is a parsed grep call.
+type grepQuery struct {
+	re         *regexp.Regexp
+	glob       *globMatcher
+	context    int
+	maxMatches int
+}
+
+func parseGrepQuery(args map[string]any) (*grepQuery, error) {
+	pattern, _ := args["pattern"].(string)
+	if pattern == "" {
+		return nil, fmt.Errorf("pattern is required")
+	}
+	expr := pattern
+	if fixed, _ := args["fixed_strings"].(bool); fixed {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if ic, _ := args["ignore_case"].(bool); ic {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %v", err)
+	}
+
+	q := &grepQuery{
+		re:         re,
+		context:    clampedIntArg(args, "context", 0, 0, maxGrepContext),
+		maxMatches: clampedIntArg(args, "max_matches", defaultGrepMatches, 1, maxGrepMatches),
+	}
+	if g, _ := args["glob"].(string); g != "" {
+		if q.glob, err = compileGlob(g); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func (t *GrepTool) Execute(ctx context.Context, args map[string]any) *Result {
+	q, err := parseGrepQuery(args)
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	path, _ := args["path"].(string)
+	if path == "" {
+		path = "."
+	}
+
+	sandboxKey := ToolSandboxKeyFromCtx(ctx)
+	if t.sandboxMgr != nil && sandboxKey != "" {
+		return t.executeInSandbox(ctx, path, sandboxKey, q, args)
+	}
+
+	root, err := t.resolveRoot(ctx, path)
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SilentResult(fmt.Sprintf("Path does not exist: %s", path))
+		}
+		return ErrorResult(fmt.Sprintf("failed to search: %v", err))
+	}
+
+	var out grepOutput
+	out.max = q.maxMatches
+
+	if !info.IsDir() {
+		q.searchFile(root, filepath.Base(root), &out)
+	} else {
+		err = t.walkFiles(ctx, root, func(abs, rel string) bool {
+			if q.glob != nil && !q.glob.match(rel) {
+				return true
+			}
+			q.searchFile(abs, rel, &out)
+			return !out.full()
+		})
+		if err != nil {
+			return ErrorResult(fmt.Sprintf("failed to search: %v", err))
+		}
+	}
+	return SilentResult(out.String())
+}
+
+// grepOutput accumulates ripgrep-style output: "path:N:text" for matches,
+// "path-N-text" for context and "--" between non-adjacent groups.
+type grepOutput struct {
+	sb      strings.Builder
+	matches int
+	files   int
+	skipped int // binary or oversized files
+	max     int
+}
+
+func (o *grepOutput) full() bool { return o.matches >= o.max }
+
+func (o *grepOutput) String() string {
+	if o.matches == 0 {
+		return "No matches found."
+	}
+	s := strings.TrimSuffix(strings.TrimSuffix(o.sb.String(), "--\n"), "\n")
+	if o.full() {
+		s += fmt.Sprintf("\n\n[Stopped at %d matches — narrow the pattern, path or glob, or raise max_matches]", o.max)
+	} else {
+		s += fmt.Sprintf("\n\n[%d match(es) in %d file(s)]", o.matches, o.files)
+	}
+	return s
+}
+
+// searchFile greps one file. Binary files (NUL byte in the first 8KB, or a
+// known binary extension) and files over maxGrepFileBytes are skipped.
+func (q *grepQuery) searchFile(abs, rel string, out *grepOutput) {
+	if isBinaryFileExt(abs) {
+		return
+	}
+	info, err := os.Stat(abs)
+	if err != nil || info.Size() > maxGrepFileBytes {
+		out.skipped++
+		return
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return
+	}
+	if bytes.IndexByte(data[:min(len(data), binarySniffBytes)], 0) >= 0 {
+		out.skipped++
+		return
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64<<10), maxGrepFileBytes)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+
+	lastPrinted := -1
+	found := false
+	for i, line := range lines {
+		if out.full() {
+			break
+		}
+		if !q.re.MatchString(line) {
+			continue
+		}
+		found = true
+		start := max(i-q.context, lastPrinted+1)
+		if lastPrinted >= 0 && start > lastPrinted+1 && q.context > 0 {
+			out.sb.WriteString("--\n")
+		}
+		for j := start; j < i; j++ {
+			writeGrepLine(&out.sb, rel, j, '-', lines[j])
+		}
+		writeGrepLine(&out.sb, rel, i, ':', line)
+		out.matches++
+		lastPrinted = i
+
+		// Trailing context; further matches inside it are printed as matches.
+		end := min(i+q.context, len(lines)-1)
+		for j := i + 1; j <= end && !out.full(); j++ {
+			if q.re.MatchString(lines[j]) {
+				break
+			}
+			writeGrepLine(&out.sb, rel, j, '-', lines[j])
+			lastPrinted = j
+		}
+	}
+	if found {
+		out.files++
+		if q.context > 0 {
+			out.sb.WriteString("--\n")
+		}
+	}
+}
+
+func writeGrepLine(sb *strings.Builder, path string, idx int, sep byte, text string) {
+	if len(text) > maxGrepLineLen {
+		text = text[:maxGrepLineLen] + "..."
+	}
+	fmt.Fprintf(sb, "%s%c%d%c%s\n", path, sep, idx+1, sep, text)
+}
+
+func (t *GrepTool) executeInSandbox(ctx context.Context, path, sandboxKey string, q *grepQuery, args map[string]any) *Result {
+	bridge, containerCwd, err := t.getFsBridge(ctx, sandboxKey)
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	containerPath := ResolveSandboxPath(path, containerCwd)
+
+	files, err := bridge.FindFiles(ctx, containerPath, sortedKeys(searchSkipDirs))
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("failed to search: %v", err) + MaybeFsBridgeHint(err))
+	}
+	if q.glob != nil {
+		filtered := files[:0]
+		for _, f := range files {
+			if q.glob.match(f) {
+				filtered = append(filtered, f)
+			}
+		}
+		files = filtered
+	}
+	if len(files) == 0 {
+		return SilentResult("No matches found.")
+	}
+
+	pattern, _ := args["pattern"].(string)
+	output, err := bridge.Grep(ctx, containerPath, files, sandbox.GrepOptions{
+		Pattern:    pattern,
+		IgnoreCase: boolArg(args, "ignore_case"),
+		Fixed:      boolArg(args, "fixed_strings"),
+		Context:    q.context,
+	})
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("failed to search: %v", err) + MaybeFsBridgeHint(err))
+	}
+
+	// grep -m is per file, so the global match cap is applied here.
+	out := grepOutput{max: q.maxMatches}
+	seen := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		if m := grepMatchLine.FindStringSubmatch(line); m != nil {
+			if out.full() {
+				break
+			}
+			out.matches++
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				out.files++
+			}
+		}
+		if len(line) > maxGrepLineLen {
+			line = line[:maxGrepLineLen] + "..."
+		}
+		out.sb.WriteString(line + "\n")
+	}
+	return SilentResult(out.String())
+}
+
+// grepMatchLine matches a "path:N:text" match line in grep -nH output.
+var grepMatchLine = regexp.MustCompile(`^(.+?):(\d+):`)
+
+func boolArg(args map[string]any, key string) bool {
+	v, _ := args[key].(bool)
+	return v
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ---- glob ----
+
+// GlobTool finds files by name pattern.
+type GlobTool struct {
+	searchBase
+}
+
+func NewGlobTool(workspace string, restrict bool) *GlobTool {
+	return &GlobTool{searchBase{workspace: workspace, restrict: restrict}}
+}
+
+func NewSandboxedGlobTool(workspace string, restrict bool, mgr sandbox.Manager) *GlobTool {
+	return &GlobTool{searchBase{workspace: workspace, restrict: restrict, sandboxMgr: mgr}}
+}
+
+func (t *GlobTool) Name() string { return "glob" }
+func (t *GlobTool) Description() string {
+	return "Find files by glob pattern, e.g. \"**/*.go\" or \"src/**/handler_*.ts\". " +
+		"A pattern without \"/\" matches file names at any depth. Returns sorted paths relative to the search path."
+}
+func (t *GlobTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"pattern": map[string]any{
+				"type":        "string",
+				"description": "Glob pattern: * and ? match within a path segment, ** matches any number of directories, {a,b} matches alternatives",
+			},
+			"path": map[string]any{
+				"type":        "string",
+				"description": "Directory to search (relative to workspace; omit for workspace root)",
+			},
+			"max_results": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Maximum paths to return (default %d, max %d)", defaultGlobResults, maxGlobResults),
+			},
+		},
+		"required": []string{"pattern"},
+	}
+}
+
+func (t *GlobTool) Execute(ctx context.Context, args map[string]any) *Result {
+	pattern, _ := args["pattern"].(string)
+	if pattern == "" {
+		return ErrorResult("pattern is required")
+	}
+	g, err := compileGlob(pattern)
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	limit := clampedIntArg(args, "max_results", defaultGlobResults, 1, maxGlobResults)
+	path, _ := args["path"].(string)
+	if path == "" {
+		path = "."
+	}
+
+	var paths []string
+	sandboxKey := ToolSandboxKeyFromCtx(ctx)
+	if t.sandboxMgr != nil && sandboxKey != "" {
+		bridge, containerCwd, err := t.getFsBridge(ctx, sandboxKey)
+		if err != nil {
+			return ErrorResult(err.Error())
+		}
+		files, err := bridge.FindFiles(ctx, ResolveSandboxPath(path, containerCwd), sortedKeys(searchSkipDirs))
+		if err != nil {
+			return ErrorResult(fmt.Sprintf("failed to search: %v", err) + MaybeFsBridgeHint(err))
+		}
+		for _, f := range files {
+			if g.match(f) {
+				paths = append(paths, f)
+			}
+		}
+	} else {
+		root, err := t.resolveRoot(ctx, path)
+		if err != nil {
+			return ErrorResult(err.Error())
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return SilentResult(fmt.Sprintf("Directory does not exist: %s", path))
+		}
+		err = t.walkFiles(ctx, root, func(_, rel string) bool {
+			if g.match(rel) {
+				paths = append(paths, rel)
+			}
+			return true
+		})
+		if err != nil {
+			return ErrorResult(fmt.Sprintf("failed to search: %v", err))
+		}
+	}
+
+	if len(paths) == 0 {
+		return SilentResult("No files found.")
+	}
+	sort.Strings(paths)
+	total := len(paths)
+	if total > limit {
+		paths = paths[:limit]
+	}
+	out := strings.Join(paths, "\n")
+	if total > limit {
+		out += fmt.Sprintf("\n\n[Showing %d of %d files — narrow the pattern or path]", limit, total)
+	}
+	return SilentResult(out)
+}
+
+// globMatcher matches slash-separated relative paths against a glob pattern.
+type globMatcher struct {
+	re       *regexp.Regexp
+	baseOnly bool // pattern has no "/": match the file name at any depth
+}
+
+func (g *globMatcher) match(rel string) bool {
+	if g.baseOnly {
+		rel = rel[strings.LastIndex(rel, "/")+1:]
+	}
+	return g.re.MatchString(rel)
+}
+
+// compileGlob translates a glob into a regexp. Supported syntax: * and ?
+// (within one path segment), ** (any number of segments), [...] character
+// classes and {a,b} alternatives.
+func compileGlob(pattern string) (*globMatcher, error) {
+	pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+	var sb strings.Builder
+	sb.WriteString("^")
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid glob %q: unclosed [", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '{':
+			depth++
+			sb.WriteString("(?:")
+		case '}':
+			if depth == 0 {
+				return nil, fmt.Errorf("invalid glob %q: unmatched }", pattern)
+			}
+			depth--
+			sb.WriteString(")")
+		case ',':
+			if depth > 0 {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid glob %q: unclosed {", pattern)
+	}
+	sb.WriteString("$")
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %v", pattern, err)
+	}
+	return &globMatcher{re: re, baseOnly: !strings.Contains(pattern, "/")}, nil
+}
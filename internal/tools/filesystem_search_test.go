@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSearchTree(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.go":                 "package main\n\nfunc main() {\n\tserve()\n}\n",
+		"internal/server.go":      "package internal\n\n// serve starts the server.\nfunc serve() {}\n",
+		"web/app.ts":              "export function serve() {}\n",
+		"node_modules/x/index.js": "function serve() {}\n",
+		".goclaw/secret.go":       "func serve() {}\n",
+		"image.bin":               "serve\x00\x01\x02",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGrepTool_Matches(t *testing.T) {
+	dir := writeSearchTree(t)
+	tool := NewGrepTool(dir, true)
+	tool.DenyPaths(".goclaw")
+
+	res := tool.Execute(context.Background(), map[string]any{"pattern": `serve\(`})
+	if res.IsError {
+		t.Fatal(res.ForLLM)
+	}
+	for _, want := range []string{"main.go:4:\tserve()", "internal/server.go:4:func serve() {}", "web/app.ts:1:"} {
+		if !strings.Contains(res.ForLLM, want) {
+			t.Errorf("missing %q in:\n%s", want, res.ForLLM)
+		}
+	}
+	for _, skip := range []string{"node_modules", ".goclaw", "image.bin"} {
+		if strings.Contains(res.ForLLM, skip) {
+			t.Errorf("%s should be skipped:\n%s", skip, res.ForLLM)
+		}
+	}
+}
+
+func TestGrepTool_ContextGlobAndLimit(t *testing.T) {
+	dir := writeSearchTree(t)
+	tool := NewGrepTool(dir, true)
+
+	res := tool.Execute(context.Background(), map[string]any{
+		"pattern":     "SERVE",
+		"glob":        "*.go",
+		"path":        "internal",
+		"context":     float64(1),
+		"ignore_case": true,
+	})
+	want := "server.go-2-\nserver.go:3:// serve starts the server.\nserver.go:4:func serve() {}"
+	if !strings.HasPrefix(res.ForLLM, want) {
+		t.Errorf("output =\n%s\nwant prefix\n%s", res.ForLLM, want)
+	}
+
+	res = tool.Execute(context.Background(), map[string]any{"pattern": "serve", "max_matches": float64(1)})
+	if !strings.Contains(res.ForLLM, "Stopped at 1 matches") {
+		t.Errorf("expected truncation footer:\n%s", res.ForLLM)
+	}
+
+	res = tool.Execute(context.Background(), map[string]any{"pattern": "("})
+	if !res.IsError {
+		t.Error("expected error for invalid regex")
+	}
+	res = tool.Execute(context.Background(), map[string]any{"pattern": "(", "fixed_strings": true})
+	if res.IsError {
+		t.Errorf("fixed_strings should accept %q: %s", "(", res.ForLLM)
+	}
+}
+
+func TestGrepTool_RestrictedToWorkspace(t *testing.T) {
+	dir := writeSearchTree(t)
+	res := NewGrepTool(dir, true).Execute(context.Background(), map[string]any{"pattern": "x", "path": "/etc"})
+	if !res.IsError {
+		t.Errorf("expected error searching outside workspace, got %q", res.ForLLM)
+	}
+}
+
+func TestGlobTool(t *testing.T) {
+	dir := writeSearchTree(t)
+	tool := NewGlobTool(dir, true)
+	tool.DenyPaths(".goclaw")
+
+	cases := map[string]string{
+		"*.go":          "internal/server.go\nmain.go",
+		"internal/*.go": "internal/server.go",
+		"**/*.{go,ts}":  "internal/server.go\nmain.go\nweb/app.ts",
+		"web/**":        "web/app.ts",
+		"nothing.*":     "No files found.",
+	}
+	for pattern, want := range cases {
+		res := tool.Execute(context.Background(), map[string]any{"pattern": pattern})
+		if res.IsError || res.ForLLM != want {
+			t.Errorf("glob %q = %q, want %q", pattern, res.ForLLM, want)
+		}
+	}
+
+	res := tool.Execute(context.Background(), map[string]any{"pattern": "**", "max_results": float64(2)})
+	if !strings.Contains(res.ForLLM, "Showing 2 of 4 files") {
+		t.Errorf("expected truncation footer:\n%s", res.ForLLM)
+	}
+}
+
+func TestCompileGlob(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.go", "a/b/c.go", true},
+		{"a/*.go", "a/b/c.go", false},
+		{"a/**/*.go", "a/c.go", true},
+		{"a/**/*.go", "a/b/c/d.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"[!a]*.md", "b.md", true},
+		{"[!a]*.md", "a.md", false},
+	}
+	for _, c := range cases {
+		g, err := compileGlob(c.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := g.match(c.path); got != c.want {
+			t.Errorf("match(%q, %q) = %v, want %v", c.pattern, c.path, got, c.want)
+		}
+	}
+	if _, err := compileGlob("{a,b"); err == nil {
+		t.Error("expected error for unclosed brace")
+	}
+}
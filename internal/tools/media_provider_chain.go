@@ -421,6 +421,8 @@ func providerTypeFromName(name string) string {
 		return "anthropic"
 	case name == "byteplus" || strings.HasPrefix(name, "byteplus"):
 		return "byteplus"
+	case name == "stability" || strings.HasPrefix(name, "stability-"):
+		return "stability"
 	case name == "yescale":
 		return "openai"
 	default:
@@ -10,15 +10,21 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/nextlevelbuilder/goclaw/internal/config"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
+// RerankerResolver builds the reranker for an agent's rerank config.
+// Returns nil when the configured provider is unavailable.
+type RerankerResolver func(ctx context.Context, cfg *config.RerankConfig) store.Reranker
+
 // MemorySearchTool implements the memory_search tool for hybrid semantic + FTS search.
 type MemorySearchTool struct {
 	memStore      store.MemoryStore              // Postgres-backed
 	episodicStore store.EpisodicStore             // v3 episodic memory (nil = v2 fallback)
 	metricsStore  store.EvolutionMetricsStore     // evolution metrics (nil = disabled)
 	hasKG         bool                           // knowledge_graph_search tool is available
+	rerankers     RerankerResolver               // nil = rerank stage unavailable
 }
 
 func NewMemorySearchTool() *MemorySearchTool {
@@ -40,6 +46,11 @@ func (t *MemorySearchTool) SetEvolutionMetricsStore(ms store.EvolutionMetricsSto
 	t.metricsStore = ms
 }
 
+// SetRerankerResolver enables the optional rerank stage (MemoryConfig.Rerank).
+func (t *MemorySearchTool) SetRerankerResolver(fn RerankerResolver) {
+	t.rerankers = fn
+}
+
 // SetHasKG enables the KG hint in search results.
 func (t *MemorySearchTool) SetHasKG(has bool) {
 	t.hasKG = has
@@ -116,6 +127,12 @@ func (t *MemorySearchTool) Execute(ctx context.Context, args map[string]any) *Re
 		if mc.MinScore > 0 && searchOpts.MinScore <= 0 {
 			searchOpts.MinScore = mc.MinScore
 		}
+		if rc := mc.Rerank; rc != nil && rc.Enabled && t.rerankers != nil {
+			if rr := t.rerankers(ctx, rc); rr != nil {
+				searchOpts.Reranker = rr
+				searchOpts.RerankTopK = rc.TopK
+			}
+		}
 	}
 	agentStr := agentID.String()
 	results, err := t.memStore.Search(ctx, query, agentStr, userID, searchOpts)
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// maxIndexedMemoryFileBytes skips oversized files (logs, dumps) dropped into memory/.
+const maxIndexedMemoryFileBytes = 1 << 20
+
+// MemoryFileIndexer picks up memory files that land on the workspace disk
+// outside the memory interceptor — typically written by exec — and imports
+// them into the MemoryStore so memory_search sees them right away.
+//
+// Scans are debounced per (agent, user, workspace) and incremental: a file is
+// re-imported and re-indexed only when its content hash changed since the
+// last scan and differs from the stored document.
+type MemoryFileIndexer struct {
+	memStore store.MemoryStore
+	delay    time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer // scope key → debounce timer
+	hashes  map[string]string      // scope key + "\x00" + rel path → last synced sha256
+}
+
+// NewMemoryFileIndexer creates an indexer that waits delay after the last
+// trigger before scanning a workspace.
+func NewMemoryFileIndexer(ms store.MemoryStore, delay time.Duration) *MemoryFileIndexer {
+	return &MemoryFileIndexer{
+		memStore: ms,
+		delay:    delay,
+		pending:  make(map[string]*time.Timer),
+		hashes:   make(map[string]string),
+	}
+}
+
+// Schedule queues a scan of the memory files in the workspace from ctx (or
+// workspace when ctx has none). Repeated calls within the debounce window
+// collapse into one scan.
+func (x *MemoryFileIndexer) Schedule(ctx context.Context, workspace string) {
+	if ws := ToolWorkspaceFromCtx(ctx); ws != "" {
+		workspace = ws
+	}
+	agentID := store.AgentIDFromContext(ctx)
+	if agentID == uuid.Nil || workspace == "" {
+		return
+	}
+	userID := store.MemoryUserID(ctx)
+	scope := agentID.String() + "|" + userID + "|" + workspace
+	bg := context.WithoutCancel(ctx)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if t, ok := x.pending[scope]; ok {
+		t.Stop()
+	}
+	x.pending[scope] = time.AfterFunc(x.delay, func() {
+		x.mu.Lock()
+		delete(x.pending, scope)
+		x.mu.Unlock()
+		if n := x.sync(bg, scope, agentID.String(), userID, workspace); n > 0 {
+			slog.Info("memory indexer: imported workspace memory files", "agent", agentID, "files", n)
+		}
+	})
+}
+
+// sync imports changed memory files from workspace and returns how many were written.
+func (x *MemoryFileIndexer) sync(ctx context.Context, scope, agentID, userID, workspace string) int {
+	written := 0
+	for _, rel := range memoryFilesOnDisk(workspace) {
+		data, err := os.ReadFile(filepath.Join(workspace, rel))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+		key := scope + "\x00" + rel
+
+		x.mu.Lock()
+		unchanged := x.hashes[key] == hash
+		x.mu.Unlock()
+		if unchanged {
+			continue
+		}
+
+		content := string(data)
+		if existing, err := x.memStore.GetDocument(ctx, agentID, userID, rel); err != nil || existing != content {
+			if err := x.memStore.PutDocument(ctx, agentID, userID, rel, content); err != nil {
+				slog.Warn("memory indexer: put failed", "path", rel, "error", err)
+				continue
+			}
+			if err := x.memStore.IndexDocument(ctx, agentID, userID, rel); err != nil {
+				slog.Warn("memory indexer: index failed", "path", rel, "error", err)
+			}
+			written++
+		}
+
+		x.mu.Lock()
+		x.hashes[key] = hash
+		x.mu.Unlock()
+	}
+	return written
+}
+
+// memoryFilesOnDisk lists workspace-relative memory files: root MEMORY.md /
+// memory.md and Markdown files under memory/.
+func memoryFilesOnDisk(workspace string) []string {
+	var out []string
+	for _, name := range []string{bootstrap.MemoryFile, bootstrap.MemoryAltFile} {
+		if fi, err := os.Stat(filepath.Join(workspace, name)); err == nil && fi.Mode().IsRegular() && fi.Size() <= maxIndexedMemoryFileBytes {
+			out = append(out, name)
+		}
+	}
+	memDir := filepath.Join(workspace, "memory")
+	_ = filepath.WalkDir(memDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		if fi, err := d.Info(); err != nil || fi.Size() > maxIndexedMemoryFileBytes {
+			return nil
+		}
+		if rel, err := filepath.Rel(workspace, path); err == nil {
+			out = append(out, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryFileIndexerSyncIsIncremental(t *testing.T) {
+	ws := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(ws, "memory", "notes"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(rel, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(ws, rel), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("MEMORY.md", "# Facts")
+	write("memory/notes/2026-10-16.md", "shipped the indexer")
+	write("memory/raw.log", "not markdown")
+	write("README.md", "not memory")
+
+	ms := newMockMemoryStore()
+	x := NewMemoryFileIndexer(ms, time.Second)
+	ctx := context.Background()
+
+	if n := x.sync(ctx, "scope", "agent", "user", ws); n != 2 {
+		t.Fatalf("first sync wrote %d files, want 2", n)
+	}
+	if got := ms.docs[docKey("agent", "user", "memory/notes/2026-10-16.md")]; got != "shipped the indexer" {
+		t.Fatalf("imported content = %q", got)
+	}
+	if _, ok := ms.docs[docKey("agent", "user", "memory/raw.log")]; ok {
+		t.Fatal("non-markdown file should not be imported")
+	}
+
+	if n := x.sync(ctx, "scope", "agent", "user", ws); n != 0 {
+		t.Fatalf("unchanged sync wrote %d files, want 0", n)
+	}
+
+	write("MEMORY.md", "# Facts\n- new fact")
+	if n := x.sync(ctx, "scope", "agent", "user", ws); n != 1 {
+		t.Fatalf("sync after edit wrote %d files, want 1", n)
+	}
+}
+
+func TestMemoryFileIndexerSkipsDocsAlreadyInStore(t *testing.T) {
+	ws := t.TempDir()
+	if err := os.WriteFile(filepath.Join(ws, "MEMORY.md"), []byte("same"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ms := newMockMemoryStore()
+	ms.docs[docKey("agent", "", "MEMORY.md")] = "same"
+
+	x := NewMemoryFileIndexer(ms, time.Second)
+	if n := x.sync(context.Background(), "scope", "agent", "", ws); n != 0 {
+		t.Fatalf("sync wrote %d files, want 0 for content already stored", n)
+	}
+}
@@ -46,7 +46,7 @@ func isMemoryPath(path, workspace string) bool {
 
 	// Root-level MEMORY.md or memory.md
 	dir := filepath.Dir(clean)
-	if (dir == "." || dir == "/" || dir == "") && (base == bootstrap.MemoryFile || base == bootstrap.MemoryAltFile) {
+	if isRootLevelDir(dir) && (base == bootstrap.MemoryFile || base == bootstrap.MemoryAltFile) {
 		return true
 	}
 
@@ -58,11 +58,11 @@ func isMemoryPath(path, workspace string) bool {
 	// Absolute path at workspace root or under workspace/memory/
 	if workspace != "" && filepath.IsAbs(clean) {
 		cleanWS := filepath.Clean(workspace)
-		if filepath.Dir(clean) == cleanWS && (base == bootstrap.MemoryFile || base == bootstrap.MemoryAltFile) {
+		if foldPathCase(filepath.Dir(clean)) == foldPathCase(cleanWS) && (base == bootstrap.MemoryFile || base == bootstrap.MemoryAltFile) {
 			return true
 		}
 		memDir := filepath.Join(cleanWS, "memory")
-		if strings.HasPrefix(clean, memDir+string(filepath.Separator)) {
+		if clean != memDir && isPathInside(clean, memDir) {
 			return true
 		}
 	}
@@ -179,7 +179,11 @@ func (m *MemoryInterceptor) WriteFile(ctx context.Context, path, content string,
 	} else {
 		// Replace: capture previous content for overwrite warning.
 		oldContent, err := m.memStore.GetDocument(ctx, agentStr, userID, relPath)
-		if err == nil && oldContent != "" && oldContent != content {
+		if err == nil && oldContent == content {
+			// Unchanged: skip the write and re-index (chunks + embeddings are current).
+			return MemoryWriteResult{Handled: true}, nil
+		}
+		if err == nil && oldContent != "" {
 			previousContent = oldContent
 		}
 	}
@@ -58,6 +58,9 @@ func (m *mockMemoryStore) ListDocuments(_ context.Context, agentID, userID strin
 func (m *mockMemoryStore) ListAllDocumentsGlobal(_ context.Context) ([]store.DocumentInfo, error) {
 	return nil, nil
 }
+func (m *mockMemoryStore) Stats(_ context.Context, _ string) (*store.MemoryStats, error) {
+	return nil, nil
+}
 func (m *mockMemoryStore) ListAllDocuments(_ context.Context, _ string) ([]store.DocumentInfo, error) {
 	return nil, nil
 }
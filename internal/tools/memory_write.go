@@ -0,0 +1,308 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bootstrap"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// MemoryWriteTool implements the memory_write tool: structured writes into
+// MEMORY.md or memory/<topic>.md that merge under a heading instead of
+// overwriting the file. Writes go through the memory interceptor, so they
+// are stored, re-indexed and KG-extracted like write_file memory writes.
+type MemoryWriteTool struct {
+	intc *MemoryInterceptor
+}
+
+func NewMemoryWriteTool() *MemoryWriteTool {
+	return &MemoryWriteTool{}
+}
+
+// SetMemoryInterceptor wires the store-backed memory writer.
+func (t *MemoryWriteTool) SetMemoryInterceptor(intc *MemoryInterceptor) {
+	t.intc = intc
+}
+
+func (t *MemoryWriteTool) Name() string { return "memory_write" }
+
+func (t *MemoryWriteTool) Description() string {
+	return "Save durable facts to memory (MEMORY.md or memory/<topic>.md). Appends under a section heading (created if missing) and skips bullets that are already there, so it is safe to call repeatedly. Use mode=replace to rewrite one section. Prefer this over write_file for memory — it never clobbers the rest of the file."
+}
+
+func (t *MemoryWriteTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"content": map[string]any{
+				"type":        "string",
+				"description": "Markdown to save, typically one or more '- ' bullets",
+			},
+			"path": map[string]any{
+				"type":        "string",
+				"description": "MEMORY.md (default) or memory/<topic>.md",
+			},
+			"section": map[string]any{
+				"type":        "string",
+				"description": "Heading to write under, e.g. 'Preferences' or '### Deadlines'. Omit to write at the end of the file.",
+			},
+			"mode": map[string]any{
+				"type":        "string",
+				"description": "append (default): add new lines, skipping duplicate bullets. replace: replace the section body (or the whole file when no section is given).",
+				"enum":        []string{"append", "replace"},
+			},
+		},
+		"required": []string{"content"},
+	}
+}
+
+func (t *MemoryWriteTool) Execute(ctx context.Context, args map[string]any) *Result {
+	content, _ := args["content"].(string)
+	if strings.TrimSpace(content) == "" {
+		return ErrorResult("content parameter is required")
+	}
+	relPath, err := memoryWritePath(args["path"])
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	section, _ := args["section"].(string)
+	mode, _ := args["mode"].(string)
+	if mode == "" {
+		mode = "append"
+	}
+	if mode != "append" && mode != "replace" {
+		return ErrorResult("mode must be append or replace")
+	}
+
+	agentID := store.AgentIDFromContext(ctx)
+	if t.intc == nil || agentID == uuid.Nil {
+		return ErrorResult("memory system not available")
+	}
+
+	existing, err := t.intc.memStore.GetDocument(ctx, agentID.String(), store.MemoryUserID(ctx), relPath)
+	if err != nil {
+		existing = "" // new document
+	}
+	merged, added, skipped := mergeMemory(existing, section, content, mode == "replace")
+	if merged == existing {
+		return NewResult(fmt.Sprintf("Nothing to save: %s already contains this content.", relPath))
+	}
+
+	res, err := t.intc.WriteFile(ctx, relPath, merged, false)
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("failed to save %s: %v", relPath, err))
+	}
+	if !res.Handled {
+		return ErrorResult("memory system not available")
+	}
+
+	out := map[string]any{"path": relPath, "mode": mode, "linesAdded": added}
+	if section != "" {
+		out["section"] = strings.TrimSpace(strings.TrimLeft(section, "#"))
+	}
+	if skipped > 0 {
+		out["duplicatesSkipped"] = skipped
+	}
+	data, _ := json.MarshalIndent(out, "", "  ")
+	return NewResult(string(data))
+}
+
+// memoryWritePath validates the path argument: MEMORY.md or memory/<name>.md.
+func memoryWritePath(v any) (string, error) {
+	p, _ := v.(string)
+	p = strings.TrimPrefix(strings.TrimSpace(p), "./")
+	if p == "" {
+		return bootstrap.MemoryFile, nil
+	}
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if p == bootstrap.MemoryFile || p == bootstrap.MemoryAltFile {
+		return p, nil
+	}
+	if strings.HasPrefix(p, "memory/") && strings.HasSuffix(p, ".md") && !strings.Contains(p, "..") && len(p) > len("memory/.md") {
+		return p, nil
+	}
+	return "", fmt.Errorf("path must be %s or memory/<topic>.md, got %q", bootstrap.MemoryFile, p)
+}
+
+var mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+// parseHeading returns the level and normalized title of a markdown heading line.
+func parseHeading(line string) (int, string, bool) {
+	m := mdHeadingRe.FindStringSubmatch(line)
+	if m == nil {
+		return 0, "", false
+	}
+	return len(m[1]), normalizeMemoryText(m[2]), true
+}
+
+var bulletPrefixRe = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+
+// bulletKey returns the dedupe key of a list item, or "" for other lines.
+func bulletKey(line string) string {
+	loc := bulletPrefixRe.FindStringIndex(line)
+	if loc == nil {
+		return ""
+	}
+	return normalizeMemoryText(line[loc[1]:])
+}
+
+func normalizeMemoryText(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), ".")
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// mergeMemory merges content into doc. With a section, content goes under
+// that heading (created at the end when missing); otherwise at the end of
+// the document. Append mode drops bullets already present in the target
+// scope; replace mode swaps out the section body, or the whole document
+// when no section is given. Returns the new document and line counts.
+func mergeMemory(doc, section, content string, replace bool) (string, int, int) {
+	lines := splitMemoryLines(doc)
+	newLines := splitMemoryLines(content)
+
+	// Locate the target scope [start, end) within lines.
+	start, end, found := 0, len(lines), true
+	headingLevel := 2
+	title := strings.TrimSpace(section)
+	if title != "" {
+		if lvl := len(title) - len(strings.TrimLeft(title, "#")); lvl > 0 && lvl <= 6 {
+			headingLevel = lvl
+			title = strings.TrimSpace(title[lvl:])
+		}
+		start, end, found = findSection(lines, normalizeMemoryText(title))
+	}
+
+	// Filter content: drop duplicate bullets in append mode.
+	added, skipped := 0, 0
+	var keep []string
+	seen := map[string]bool{}
+	if !replace && found {
+		for _, l := range lines[start:end] {
+			if k := bulletKey(l); k != "" {
+				seen[k] = true
+			}
+		}
+	}
+	for _, l := range newLines {
+		if k := bulletKey(l); k != "" {
+			if seen[k] {
+				skipped++
+				continue
+			}
+			seen[k] = true
+		}
+		keep = append(keep, l)
+		if strings.TrimSpace(l) != "" {
+			added++
+		}
+	}
+	keep = trimBlankLines(keep)
+
+	var out []string
+	switch {
+	case !found:
+		if len(keep) == 0 {
+			return doc, 0, skipped
+		}
+		if prefix := trimBlankLines(lines); len(prefix) > 0 {
+			out = append(append(out, prefix...), "")
+		}
+		out = append(out, strings.Repeat("#", headingLevel)+" "+title, "")
+		out = append(out, keep...)
+	case replace:
+		out = append(out, lines[:start]...)
+		if title != "" {
+			out = append(out, "")
+		}
+		out = append(out, keep...)
+		out = append(out, sectionTail(lines[end:])...)
+	default:
+		if len(keep) == 0 {
+			return doc, 0, skipped
+		}
+		body := trimBlankLines(lines[start:end])
+		out = append(out, lines[:start]...)
+		if title != "" {
+			out = append(out, "")
+		}
+		out = append(out, body...)
+		// Keep a list contiguous; separate anything else with a blank line.
+		if len(body) > 0 && (bulletKey(body[len(body)-1]) == "" || bulletKey(keep[0]) == "") {
+			out = append(out, "")
+		}
+		out = append(out, keep...)
+		out = append(out, sectionTail(lines[end:])...)
+	}
+	merged := strings.Join(trimBlankLines(out), "\n") + "\n"
+	if strings.TrimSpace(merged) == strings.TrimSpace(doc) {
+		return doc, 0, skipped
+	}
+	return merged, added, skipped
+}
+
+// findSection returns the body range of the heading with the given title.
+// The body ends at the next heading of the same or a higher level. Without
+// a match it returns an empty range at the end of the document.
+func findSection(lines []string, title string) (int, int, bool) {
+	inFence := false
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), "```") {
+			inFence = !inFence
+			continue
+		}
+		lvl, t, ok := parseHeading(l)
+		if inFence || !ok || t != title {
+			continue
+		}
+		end := len(lines)
+		fence := false
+		for j := i + 1; j < len(lines); j++ {
+			if strings.HasPrefix(strings.TrimSpace(lines[j]), "```") {
+				fence = !fence
+				continue
+			}
+			if l2, _, ok := parseHeading(lines[j]); ok && !fence && l2 <= lvl {
+				end = j
+				break
+			}
+		}
+		return i + 1, end, true
+	}
+	return len(lines), len(lines), false
+}
+
+// sectionTail returns the lines after a section, preceded by a blank line
+// when there are any.
+func sectionTail(rest []string) []string {
+	rest = trimBlankLines(rest)
+	if len(rest) == 0 {
+		return nil
+	}
+	return append([]string{""}, rest...)
+}
+
+func splitMemoryLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// trimBlankLines drops leading and trailing blank lines.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMergeMemory_AppendUnderSection(t *testing.T) {
+	doc := "# Memory\n\n## Preferences\n\n- Likes tea\n\n## Projects\n\n- goclaw\n"
+	got, added, skipped := mergeMemory(doc, "Preferences", "- likes tea.\n- Prefers short answers", false)
+	want := "# Memory\n\n## Preferences\n\n- Likes tea\n- Prefers short answers\n\n## Projects\n\n- goclaw\n"
+	if got != want {
+		t.Errorf("merged:\n%s\nwant:\n%s", got, want)
+	}
+	if added != 1 || skipped != 1 {
+		t.Errorf("added=%d skipped=%d, want 1/1", added, skipped)
+	}
+}
+
+func TestMergeMemory_CreatesMissingSection(t *testing.T) {
+	got, _, _ := mergeMemory("# Memory\n\n- misc\n", "### Deadlines", "- Q3 report due 2026-09-30", false)
+	want := "# Memory\n\n- misc\n\n### Deadlines\n\n- Q3 report due 2026-09-30\n"
+	if got != want {
+		t.Errorf("merged:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, _, _ = mergeMemory("", "People", "- Alex: designer", false)
+	if got != "## People\n\n- Alex: designer\n" {
+		t.Errorf("empty doc: %q", got)
+	}
+}
+
+func TestMergeMemory_SubsectionsStayInSection(t *testing.T) {
+	doc := "## Work\n\n- a\n\n### Details\n\n- b\n\n## Home\n\n- c\n"
+	got, _, skipped := mergeMemory(doc, "Work", "- b\n- d", false)
+	if skipped != 1 {
+		t.Errorf("bullet in subsection should dedupe, skipped=%d", skipped)
+	}
+	want := "## Work\n\n- a\n\n### Details\n\n- b\n- d\n\n## Home\n\n- c\n"
+	if got != want {
+		t.Errorf("merged:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMergeMemory_AllDuplicatesIsNoop(t *testing.T) {
+	doc := "- one\n- two\n"
+	got, added, skipped := mergeMemory(doc, "", "- One\n- two", false)
+	if got != doc || added != 0 || skipped != 2 {
+		t.Errorf("got %q added=%d skipped=%d", got, added, skipped)
+	}
+}
+
+func TestMergeMemory_ReplaceSection(t *testing.T) {
+	doc := "## Status\n\n- old\n- older\n\n## Notes\n\n- keep\n"
+	got, _, _ := mergeMemory(doc, "status", "- new", true)
+	want := "## Status\n\n- new\n\n## Notes\n\n- keep\n"
+	if got != want {
+		t.Errorf("merged:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, _, _ = mergeMemory(doc, "", "fresh", true)
+	if got != "fresh\n" {
+		t.Errorf("whole-file replace: %q", got)
+	}
+}
+
+func TestMergeMemory_IgnoresHeadingsInCodeFences(t *testing.T) {
+	doc := "## Snippets\n\n```sh\n# Setup\necho hi\n```\n"
+	got, _, _ := mergeMemory(doc, "Setup", "- run make", false)
+	if !strings.HasSuffix(got, "```\n\n## Setup\n\n- run make\n") {
+		t.Errorf("fenced comment treated as heading:\n%s", got)
+	}
+}
+
+func TestMemoryWritePath(t *testing.T) {
+	for in, want := range map[string]string{
+		"":                  "MEMORY.md",
+		"./MEMORY.md":       "MEMORY.md",
+		"memory/people.md":  "memory/people.md",
+		"memory\\people.md": "memory/people.md",
+	} {
+		got, err := memoryWritePath(in)
+		if err != nil || got != want {
+			t.Errorf("memoryWritePath(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"notes.md", "memory/../SOUL.md", "memory/x.txt", "/etc/passwd", "memory/.md"} {
+		if _, err := memoryWritePath(in); err == nil {
+			t.Errorf("memoryWritePath(%q) should fail", in)
+		}
+	}
+}
+
+func TestMemoryWriteTool_Execute(t *testing.T) {
+	ms := newMockMemoryStore()
+	tool := NewMemoryWriteTool()
+	tool.SetMemoryInterceptor(NewMemoryInterceptor(ms, "/workspace"))
+	agentID := uuid.New()
+	ctx := memCtx(agentID, "user1", "")
+
+	args := map[string]any{"path": "memory/people.md", "section": "Team", "content": "- Alex leads design"}
+	if r := tool.Execute(ctx, args); r.IsError {
+		t.Fatalf("first write: %s", r.ForLLM)
+	}
+	if r := tool.Execute(ctx, args); r.IsError || !strings.Contains(r.ForLLM, "Nothing to save") {
+		t.Errorf("duplicate write: %+v", r)
+	}
+	got, _ := ms.GetDocument(ctx, agentID.String(), "user1", "memory/people.md")
+	if got != "## Team\n\n- Alex leads design\n" {
+		t.Errorf("stored %q", got)
+	}
+
+	// Team members can't write memory.
+	member := memCtx(uuid.New(), "user1", uuid.NewString())
+	if r := tool.Execute(member, args); !r.IsError {
+		t.Error("expected error for team member write")
+	}
+}
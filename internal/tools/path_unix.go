@@ -0,0 +1,22 @@
+//go:build !windows
+
+package tools
+
+import (
+	"os/exec"
+	"regexp"
+)
+
+// foldPathCase returns p unchanged: POSIX filesystems are case-sensitive.
+func foldPathCase(p string) string { return p }
+
+// pathDenyRegexp matches commands that reference p literally.
+func pathDenyRegexp(p string) *regexp.Regexp {
+	return regexp.MustCompile(regexp.QuoteMeta(p))
+}
+
+// setCmdExeLine appends the cmd.exe arguments; only meaningful on Windows,
+// where the raw command line is passed through unquoted.
+func setCmdExeLine(cmd *exec.Cmd, command string) {
+	cmd.Args = append(cmd.Args, "/d", "/s", "/c", command)
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package tools
+
+import (
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+)
+
+// foldPathCase lowercases p for comparisons: NTFS paths are case-insensitive.
+func foldPathCase(p string) string { return strings.ToLower(p) }
+
+// pathDenyRegexp matches commands that reference p in any letter case and with
+// either separator, since C:\data\.goclaw may be written as c:/data/.goclaw.
+func pathDenyRegexp(p string) *regexp.Regexp {
+	segs := strings.Split(filepath.ToSlash(p), "/")
+	for i, s := range segs {
+		segs[i] = regexp.QuoteMeta(s)
+	}
+	return regexp.MustCompile(`(?i)` + strings.Join(segs, `[\\/]`))
+}
+
+// setCmdExeLine passes command to cmd.exe verbatim. Go's default argument
+// quoting escapes embedded quotes in a way cmd.exe does not understand, so the
+// command line is built by hand; /s strips the outer quotes added here.
+func setCmdExeLine(cmd *exec.Cmd, command string) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine: `"` + cmd.Path + `" /d /s /c "` + command + `"`,
+	}
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package tools
+
+import "testing"
+
+func TestPathDenyRegexp_Windows(t *testing.T) {
+	re := pathDenyRegexp(`C:\goclaw\data`)
+	for _, cmd := range []string{`type C:\goclaw\data\config.json`, `cat c:/GoClaw/data/config.json`} {
+		if !re.MatchString(cmd) {
+			t.Errorf("%q should match deny path", cmd)
+		}
+	}
+	if re.MatchString(`type C:\goclaw\other\x`) {
+		t.Error("unrelated path matched")
+	}
+}
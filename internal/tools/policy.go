@@ -13,30 +13,31 @@ import (
 // builtinToolGroups is const-like seed data for per-Registry tool groups.
 // Do NOT modify at runtime — each Registry gets a deep copy in NewRegistry().
 var builtinToolGroups = map[string][]string{
-	"memory":     {"memory_search", "memory_get"},
+	"memory":     {"memory_search", "memory_get", "memory_write"},
 	"web":        {"web_search", "web_fetch"},
-	"fs":         {"read_file", "write_file", "list_files", "edit"},
-	"runtime":    {"exec"},
+	"fs":         {"read_file", "write_file", "list_files", "edit", "grep", "glob"},
+	"runtime":    {"exec", "exec_background", "process_list", "process_kill"},
 	"sessions":   {"sessions_list", "sessions_history", "sessions_send", "spawn", "session_status"},
-	"ui":         {"browser"},
-	"automation": {"cron"},
+	"ui":         {"browser", "browser_downloads"},
+	"automation": {"cron", "cron_add", "cron_list", "cron_remove"},
 	"messaging":  {"message", "create_forum_topic", "list_group_members"},
 	"team":       {"team_tasks"},
 	"vault":      {"vault_search", "vault_read"},
 	// Composite group: all goclaw native tools (excludes MCP/custom plugins).
 	"goclaw": {
-		"read_file", "write_file", "list_files", "edit", "exec",
-		"web_search", "web_fetch", "browser",
-		"memory_search", "memory_get", "memory_expand",
+		"read_file", "write_file", "list_files", "edit", "grep", "glob",
+		"exec", "exec_background", "process_list", "process_kill",
+		"web_search", "web_fetch", "browser", "browser_downloads",
+		"memory_search", "memory_get", "memory_write", "memory_expand",
 		"knowledge_graph_search", "vault_search", "vault_read",
 		"sessions_list", "sessions_history", "sessions_send", "spawn", "session_status",
 		"delegate",
-		"cron", "datetime", "heartbeat",
+		"cron", "cron_add", "cron_list", "cron_remove", "datetime", "heartbeat",
 		"message", "create_forum_topic", "list_group_members",
 		"read_image", "read_document", "read_audio", "read_video",
 		"create_image", "create_video", "create_audio",
 		"skill_search", "skill_manage", "publish_skill", "use_skill",
-		"mcp_tool_search", "tts",
+		"mcp_tool_search", "tts", "tts_speak",
 		"team_tasks",
 	},
 }
@@ -59,6 +60,7 @@ var legacyToolAliases = map[string]string{
 	"apply-patch":    "apply_patch",
 	"edit_file":      "edit",
 	"sessions_spawn": "spawn",
+	"generate_image": "create_image",
 }
 
 // LegacyToolAliases returns legacy aliases for registration into the Registry.
@@ -68,9 +70,9 @@ func LegacyToolAliases() map[string]string {
 
 // Subagent deny lists — tools subagents cannot use.
 var subagentDenyList = []string{
-	"exec", // subagents should not shell out — main agent can still exec
+	"exec", "exec_background", "process_list", "process_kill", // subagents should not shell out — main agent can still exec
 	"gateway", "agents_list", "whatsapp_login", "session_status",
-	"cron", "memory_search", "memory_get", "sessions_send",
+	"cron", "cron_add", "cron_remove", "memory_search", "memory_get", "memory_write", "sessions_send",
 }
 
 // Leaf subagent deny — additional restrictions at max spawn depth.
@@ -136,6 +138,10 @@ func (pe *PolicyEngine) FilterTools(
 	if isLeafAgent {
 		allowed = subtractSet(allowed, leafSubagentDenyList)
 	}
+	// exec_background runs the same commands as exec: never allow one without the other.
+	if !slices.Contains(allowed, "exec") {
+		allowed = subtractSet(allowed, []string{"exec_background"})
+	}
 
 	// Resolve aliases and build definitions
 	allowedSet := make(map[string]bool, len(allowed))
@@ -449,6 +455,9 @@ func (pe *PolicyEngine) IsDenied(name string, agentPolicy *config.ToolPolicySpec
 			return true
 		}
 	}
+	if name == "exec_background" {
+		return pe.IsDenied("exec", agentPolicy)
+	}
 	return false
 }
 
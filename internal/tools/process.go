@@ -0,0 +1,367 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
+)
+
+// Background process limits.
+const (
+	maxProcessesPerSession = 4                // running processes per session
+	processOutputBytes     = 64 << 10         // rolling output buffer per process
+	processRetention       = 30 * time.Minute // finished processes stay listed this long
+	processKillGrace       = 3 * time.Second  // SIGTERM → SIGKILL delay
+)
+
+// Process states.
+const (
+	ProcessRunning = "running"
+	ProcessExited  = "exited"
+	ProcessKilled  = "killed"
+)
+
+// sandboxPIDMarker prefixes the first stdout line of a sandboxed background
+// command, carrying the container-side PID used to signal it later.
+const sandboxPIDMarker = "__goclaw_bg_pid="
+
+// ProcessManager tracks long-running commands started by exec_background.
+// Processes are scoped to the session that started them: other sessions can
+// neither see nor kill them.
+type ProcessManager struct {
+	mu    sync.Mutex
+	procs map[string]*BackgroundProcess
+	seq   int
+}
+
+func NewProcessManager() *ProcessManager {
+	return &ProcessManager{procs: make(map[string]*BackgroundProcess)}
+}
+
+// BackgroundProcess is one detached command and its rolling output buffer.
+type BackgroundProcess struct {
+	ID         string
+	SessionKey string
+	Command    string
+	Cwd        string
+	Sandboxed  bool
+	StartedAt  time.Time
+
+	cmd    *exec.Cmd
+	output *outputRing
+	done   chan struct{}
+	signal func(force bool) error // delivers SIGTERM (or SIGKILL when force) to the command
+
+	mu       sync.Mutex
+	status   string
+	exitCode int
+	endedAt  time.Time
+}
+
+// ProcessSnapshot is a point-in-time view of a background process.
+type ProcessSnapshot struct {
+	ID        string
+	Command   string
+	Cwd       string
+	Sandboxed bool
+	Status    string
+	ExitCode  int
+	StartedAt time.Time
+	EndedAt   time.Time
+	Output    int64 // total bytes written so far
+}
+
+func (p *BackgroundProcess) Snapshot() ProcessSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return ProcessSnapshot{
+		ID: p.ID, Command: p.Command, Cwd: p.Cwd, Sandboxed: p.Sandboxed,
+		Status: p.status, ExitCode: p.exitCode, StartedAt: p.StartedAt, EndedAt: p.endedAt,
+		Output: p.output.Total(),
+	}
+}
+
+// Tail returns the last n lines of buffered output (all buffered output when n <= 0).
+func (p *BackgroundProcess) Tail(n int) string {
+	return p.output.Tail(n)
+}
+
+// Done is closed when the process exits.
+func (p *BackgroundProcess) Done() <-chan struct{} { return p.done }
+
+// StartHost runs command through the host shell in its own process group.
+func (m *ProcessManager) StartHost(sessionKey, command, cwd string, env []string) (*BackgroundProcess, error) {
+	cmd := hostShellCommand(command)
+	cmd.Dir = cwd
+	cmd.Env = env
+	setProcessGroup(cmd)
+
+	p := &BackgroundProcess{Command: command, Cwd: cwd, output: newOutputRing(processOutputBytes)}
+	cmd.Stdout = p.output
+	cmd.Stderr = p.output
+	p.signal = func(force bool) error {
+		if force {
+			return killProcessGroup(cmd, syscallSIGKILL)
+		}
+		return killProcessGroup(cmd, syscallSIGTERM)
+	}
+	return m.start(sessionKey, p, cmd)
+}
+
+// StartSandbox runs command inside the sandbox container. The container
+// engine's exec client stays attached on the host to stream output; the
+// command is signalled inside the container by its PID.
+func (m *ProcessManager) StartSandbox(sessionKey string, sb sandbox.Sandbox, command, cwd string) (*BackgroundProcess, error) {
+	binary := sandbox.Config{Runtime: sb.Runtime()}.Binary()
+	wrapper := `echo "` + sandboxPIDMarker + `$$"; exec sh -c "$0"`
+	cmd := exec.Command(binary, "exec", "-w", cwd, sb.ID(), "sh", "-c", wrapper, command)
+	setProcessGroup(cmd)
+
+	p := &BackgroundProcess{Command: command, Cwd: cwd, Sandboxed: true, output: newOutputRing(processOutputBytes)}
+	sniffer := &pidSniffer{out: p.output, pid: make(chan int, 1)}
+	cmd.Stdout = sniffer
+	cmd.Stderr = p.output
+	p.signal = func(force bool) error {
+		sig := "TERM"
+		if force {
+			sig = "KILL"
+		}
+		select {
+		case pid := <-sniffer.pid:
+			sniffer.pid <- pid // keep it for the next signal
+			kill := fmt.Sprintf("pkill -%s -P %d; kill -%s %d", sig, pid, sig, pid)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_, err := sb.Exec(ctx, []string{"sh", "-c", kill}, "")
+			if force {
+				_ = killProcessGroup(cmd, syscallSIGKILL)
+			}
+			return err
+		default:
+			// PID not reported yet: stopping the exec client is all we can do.
+			return killProcessGroup(cmd, syscallSIGKILL)
+		}
+	}
+	return m.start(sessionKey, p, cmd)
+}
+
+func (m *ProcessManager) start(sessionKey string, p *BackgroundProcess, cmd *exec.Cmd) (*BackgroundProcess, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneLocked()
+	running := 0
+	for _, other := range m.procs {
+		if other.SessionKey == sessionKey && other.Snapshot().Status == ProcessRunning {
+			running++
+		}
+	}
+	if running >= maxProcessesPerSession {
+		return nil, fmt.Errorf("%d background processes already running in this session (limit %d) — kill one with process_kill first", running, maxProcessesPerSession)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start command: %w", err)
+	}
+
+	m.seq++
+	p.ID = "proc-" + strconv.Itoa(m.seq)
+	p.SessionKey = sessionKey
+	p.StartedAt = time.Now()
+	p.cmd = cmd
+	p.status = ProcessRunning
+	p.done = make(chan struct{})
+	m.procs[p.ID] = p
+
+	go func() {
+		err := cmd.Wait()
+		p.mu.Lock()
+		p.endedAt = time.Now()
+		p.exitCode = 0
+		if cmd.ProcessState != nil {
+			p.exitCode = cmd.ProcessState.ExitCode()
+		} else if err != nil {
+			p.exitCode = -1
+		}
+		if p.status == ProcessRunning {
+			p.status = ProcessExited
+		}
+		p.mu.Unlock()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// Get returns the process with the given ID if it belongs to sessionKey.
+func (m *ProcessManager) Get(sessionKey, id string) (*BackgroundProcess, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.procs[id]
+	if !ok || p.SessionKey != sessionKey {
+		return nil, false
+	}
+	return p, true
+}
+
+// List returns the session's processes, oldest first.
+func (m *ProcessManager) List(sessionKey string) []*BackgroundProcess {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked()
+	var out []*BackgroundProcess
+	for _, p := range m.procs {
+		if p.SessionKey == sessionKey {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartedAt.Before(out[j].StartedAt) })
+	return out
+}
+
+// Kill terminates a process: SIGTERM to its process group, then SIGKILL if it
+// is still running after the grace period. Returns false when the process had
+// already exited.
+func (m *ProcessManager) Kill(p *BackgroundProcess) bool {
+	p.mu.Lock()
+	if p.status != ProcessRunning {
+		p.mu.Unlock()
+		return false
+	}
+	p.status = ProcessKilled
+	p.mu.Unlock()
+
+	_ = p.signal(false)
+	select {
+	case <-p.done:
+	case <-time.After(processKillGrace):
+		_ = p.signal(true)
+		<-p.done
+	}
+	return true
+}
+
+// KillAll terminates every running process. Called on gateway shutdown.
+func (m *ProcessManager) KillAll() {
+	m.mu.Lock()
+	procs := make([]*BackgroundProcess, 0, len(m.procs))
+	for _, p := range m.procs {
+		procs = append(procs, p)
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, p := range procs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Kill(p)
+		}()
+	}
+	wg.Wait()
+}
+
+// pruneLocked drops finished processes older than processRetention.
+func (m *ProcessManager) pruneLocked() {
+	cutoff := time.Now().Add(-processRetention)
+	for id, p := range m.procs {
+		s := p.Snapshot()
+		if s.Status != ProcessRunning && s.EndedAt.Before(cutoff) {
+			delete(m.procs, id)
+		}
+	}
+}
+
+// outputRing keeps the last max bytes written to it.
+type outputRing struct {
+	mu    sync.Mutex
+	buf   []byte
+	max   int
+	total int64
+}
+
+func newOutputRing(max int) *outputRing {
+	return &outputRing{max: max}
+}
+
+func (r *outputRing) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total += int64(len(p))
+	r.buf = append(r.buf, p...)
+	if over := len(r.buf) - r.max; over > 0 {
+		r.buf = append(r.buf[:0], r.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+func (r *outputRing) Total() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
+
+// Tail returns the last n lines (everything buffered when n <= 0). When older
+// output has been dropped, the first partial line is cut.
+func (r *outputRing) Tail(n int) string {
+	r.mu.Lock()
+	s := string(r.buf)
+	dropped := r.total > int64(len(r.buf))
+	r.mu.Unlock()
+
+	if dropped {
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	s = strings.TrimSuffix(s, "\n")
+	if n > 0 {
+		lines := strings.Split(s, "\n")
+		if len(lines) > n {
+			lines = lines[len(lines)-n:]
+		}
+		s = strings.Join(lines, "\n")
+	}
+	return s
+}
+
+// pidSniffer strips the PID marker line from a sandboxed command's stdout.
+type pidSniffer struct {
+	out     *outputRing
+	pid     chan int
+	pending []byte
+	done    bool
+}
+
+func (w *pidSniffer) Write(p []byte) (int, error) {
+	if w.done {
+		return w.out.Write(p)
+	}
+	w.pending = append(w.pending, p...)
+	i := bytes.IndexByte(w.pending, '\n')
+	if i < 0 && len(w.pending) < 64 {
+		return len(p), nil
+	}
+	w.done = true
+	rest := w.pending
+	if i >= 0 {
+		if line := string(w.pending[:i]); strings.HasPrefix(line, sandboxPIDMarker) {
+			if pid, err := strconv.Atoi(strings.TrimPrefix(line, sandboxPIDMarker)); err == nil {
+				w.pid <- pid
+			}
+			rest = w.pending[i+1:]
+		}
+	}
+	w.pending = nil
+	if len(rest) > 0 {
+		_, _ = w.out.Write(rest)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,140 @@
+//go:build !windows
+
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/config"
+)
+
+func TestBackgroundProcess_Lifecycle(t *testing.T) {
+	procs := NewProcessManager()
+	bg := NewExecBackgroundTool(NewExecTool(t.TempDir(), true), procs)
+	ctx := WithToolSessionKey(context.Background(), "session-a")
+
+	res := bg.Execute(ctx, map[string]any{
+		"command":      "echo ready; sleep 60",
+		"wait_seconds": float64(1),
+	})
+	if res.IsError {
+		t.Fatal(res.ForLLM)
+	}
+	if !strings.Contains(res.ForLLM, "proc-1: running") || !strings.Contains(res.ForLLM, "ready") {
+		t.Fatalf("start result = %q", res.ForLLM)
+	}
+
+	// Other sessions can neither see nor kill it.
+	other := WithToolSessionKey(context.Background(), "session-b")
+	if res := NewProcessListTool(procs).Execute(other, nil); !strings.Contains(res.ForLLM, "No background processes") {
+		t.Errorf("other session list = %q", res.ForLLM)
+	}
+	if res := NewProcessKillTool(procs).Execute(other, map[string]any{"id": "proc-1"}); !res.IsError {
+		t.Error("other session killed the process")
+	}
+
+	list := NewProcessListTool(procs).Execute(ctx, nil)
+	if !strings.Contains(list.ForLLM, "proc-1  running") {
+		t.Errorf("list = %q", list.ForLLM)
+	}
+
+	start := time.Now()
+	res = NewProcessKillTool(procs).Execute(ctx, map[string]any{"id": "proc-1"})
+	if res.IsError || !strings.Contains(res.ForLLM, "proc-1: killed") {
+		t.Fatalf("kill result = %q", res.ForLLM)
+	}
+	if time.Since(start) > processKillGrace {
+		t.Error("SIGTERM did not stop the process group")
+	}
+	if res := NewProcessKillTool(procs).Execute(ctx, map[string]any{"id": "proc-1"}); !strings.Contains(res.ForLLM, "already stopped") {
+		t.Errorf("second kill = %q", res.ForLLM)
+	}
+}
+
+func TestBackgroundProcess_ExitAndSafetyChecks(t *testing.T) {
+	procs := NewProcessManager()
+	bg := NewExecBackgroundTool(NewExecTool(t.TempDir(), true), procs)
+	ctx := WithToolSessionKey(context.Background(), "s")
+
+	res := bg.Execute(ctx, map[string]any{"command": "echo done; exit 3", "wait_seconds": float64(5)})
+	if !strings.Contains(res.ForLLM, "exited with code 3") || !strings.Contains(res.ForLLM, "done") {
+		t.Errorf("result = %q", res.ForLLM)
+	}
+
+	// Deny groups apply exactly as for exec.
+	if res := bg.Execute(ctx, map[string]any{"command": "rm -rf /"}); !res.IsError {
+		t.Errorf("dangerous command started: %q", res.ForLLM)
+	}
+	if res := bg.Execute(context.Background(), map[string]any{"command": "sleep 1"}); !res.IsError {
+		t.Error("started without a session")
+	}
+}
+
+func TestBackgroundProcess_SessionLimit(t *testing.T) {
+	procs := NewProcessManager()
+	defer procs.KillAll()
+	bg := NewExecBackgroundTool(NewExecTool(t.TempDir(), true), procs)
+	ctx := WithToolSessionKey(context.Background(), "s")
+
+	for i := 0; i < maxProcessesPerSession; i++ {
+		if res := bg.Execute(ctx, map[string]any{"command": "sleep 60", "wait_seconds": float64(0)}); res.IsError {
+			t.Fatal(res.ForLLM)
+		}
+	}
+	res := bg.Execute(ctx, map[string]any{"command": "sleep 60", "wait_seconds": float64(0)})
+	if !res.IsError || !strings.Contains(res.ForLLM, "limit") {
+		t.Errorf("result = %q, want session limit error", res.ForLLM)
+	}
+}
+
+func TestPolicy_ExecBackgroundFollowsExec(t *testing.T) {
+	reg := NewRegistry()
+	exec := NewExecTool(t.TempDir(), true)
+	procs := NewProcessManager()
+	reg.Register(exec)
+	reg.Register(NewExecBackgroundTool(exec, procs))
+	reg.Register(NewProcessListTool(procs))
+
+	pe := NewPolicyEngine(&config.ToolsConfig{})
+	pe.SetRegistry(reg)
+	policy := &config.ToolPolicySpec{Deny: []string{"exec"}}
+
+	for _, def := range pe.FilterTools(reg, "agent", "", policy, nil, false, false) {
+		if def.Function.Name == "exec_background" {
+			t.Error("exec_background offered although exec is denied")
+		}
+	}
+	if !pe.IsDenied("exec_background", policy) {
+		t.Error("IsDenied(exec_background) = false with exec denied")
+	}
+}
+
+func TestOutputRing_KeepsTail(t *testing.T) {
+	r := newOutputRing(16)
+	r.Write([]byte("line1\nline2\nline3\nline4\n"))
+	if got := r.Tail(0); got != "line3\nline4" {
+		t.Errorf("Tail(0) = %q", got)
+	}
+	if got := r.Tail(1); got != "line4" {
+		t.Errorf("Tail(1) = %q", got)
+	}
+	if r.Total() != 24 {
+		t.Errorf("Total = %d", r.Total())
+	}
+}
+
+func TestPIDSniffer(t *testing.T) {
+	out := newOutputRing(1024)
+	w := &pidSniffer{out: out, pid: make(chan int, 1)}
+	w.Write([]byte(sandboxPIDMarker + "42\nhel"))
+	w.Write([]byte("lo\n"))
+	if pid := <-w.pid; pid != 42 {
+		t.Errorf("pid = %d", pid)
+	}
+	if got := out.Tail(0); got != "hello" {
+		t.Errorf("output = %q", got)
+	}
+}
@@ -0,0 +1,242 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/sandbox"
+)
+
+const (
+	defaultProcessWait  = 2  // seconds exec_background waits for early output
+	maxProcessWait      = 30 // seconds
+	defaultProcessLines = 50
+	maxProcessLines     = 500
+)
+
+// ExecBackgroundTool starts a long-running command (dev server, watcher)
+// detached from the tool-call timeout. Commands go through the same safety
+// checks as exec.
+type ExecBackgroundTool struct {
+	exec  *ExecTool
+	procs *ProcessManager
+}
+
+func NewExecBackgroundTool(exec *ExecTool, procs *ProcessManager) *ExecBackgroundTool {
+	return &ExecBackgroundTool{exec: exec, procs: procs}
+}
+
+// Processes returns the shared process manager (for shutdown cleanup).
+func (t *ExecBackgroundTool) Processes() *ProcessManager { return t.procs }
+
+func (t *ExecBackgroundTool) Name() string { return "exec_background" }
+func (t *ExecBackgroundTool) Description() string {
+	desc := "Start a long-running shell command (dev server, file watcher, build --watch) in the background and return its process ID. " +
+		"The command keeps running after this call; read its output with process_list and stop it with process_kill. " +
+		"Use exec for commands that finish on their own."
+	if t.exec.sandboxMgr == nil {
+		desc += hostShellHint()
+	}
+	return desc
+}
+func (t *ExecBackgroundTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{
+				"type":        "string",
+				"description": "The shell command to run in the background",
+			},
+			"working_dir": map[string]any{
+				"type":        "string",
+				"description": "Working directory for the command (default: workspace root)",
+			},
+			"wait_seconds": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Seconds to wait for initial output before returning (default %d, max %d)", defaultProcessWait, maxProcessWait),
+			},
+		},
+		"required": []string{"command"},
+	}
+}
+
+func (t *ExecBackgroundTool) Execute(ctx context.Context, args map[string]any) *Result {
+	sessionKey := ToolSessionKeyFromCtx(ctx)
+	if sessionKey == "" {
+		return ErrorResult("exec_background is only available inside a chat session")
+	}
+
+	plan, res := t.exec.prepare(ctx, args)
+	if res != nil {
+		return res
+	}
+	if plan.cred != nil {
+		return ErrorResult(fmt.Sprintf("%s uses secure CLI credentials and cannot run in the background — use exec", plan.credBinary))
+	}
+
+	var p *BackgroundProcess
+	var err error
+	if sandboxKey := ToolSandboxKeyFromCtx(ctx); t.exec.sandboxMgr != nil && sandboxKey != "" {
+		p, err = t.startInSandbox(ctx, sessionKey, sandboxKey, plan)
+	} else {
+		p, err = t.procs.StartHost(sessionKey, plan.command, plan.cwd, t.exec.hostEnv(ctx))
+	}
+	if err != nil {
+		return ErrorResult(err.Error())
+	}
+	slog.Info("exec_background: started", "id", p.ID, "session", sessionKey, "command", truncateCmd(plan.command, 100))
+
+	wait := time.Duration(clampedIntArg(args, "wait_seconds", defaultProcessWait, 0, maxProcessWait)) * time.Second
+	select {
+	case <-p.Done():
+	case <-time.After(wait):
+	case <-ctx.Done():
+	}
+	return SilentResult(formatProcess(p, 20))
+}
+
+func (t *ExecBackgroundTool) startInSandbox(ctx context.Context, sessionKey, sandboxKey string, plan *execPlan) (*BackgroundProcess, error) {
+	sb, err := t.exec.sandboxMgr.Get(ctx, sandboxKey, t.exec.workspace, SandboxConfigFromCtx(ctx))
+	if err != nil {
+		if errors.Is(err, sandbox.ErrSandboxDisabled) {
+			return t.procs.StartHost(sessionKey, plan.command, plan.cwd, t.exec.hostEnv(ctx))
+		}
+		// Fail closed, same as exec: never fall back to the host.
+		return nil, fmt.Errorf("sandbox unavailable: %v (will not fall back to unsandboxed host execution)", err)
+	}
+	containerCwd, err := SandboxCwd(ctx, t.exec.workspace, sandbox.DefaultContainerWorkdir)
+	if err != nil {
+		return nil, fmt.Errorf("sandbox path mapping: %v", err)
+	}
+	return t.procs.StartSandbox(sessionKey, sb, plan.command, containerCwd)
+}
+
+// ProcessListTool lists the session's background processes, or shows the
+// recent output of one of them.
+type ProcessListTool struct {
+	procs *ProcessManager
+}
+
+func NewProcessListTool(procs *ProcessManager) *ProcessListTool {
+	return &ProcessListTool{procs: procs}
+}
+
+func (t *ProcessListTool) Name() string { return "process_list" }
+func (t *ProcessListTool) Description() string {
+	return "List background processes started with exec_background in this session, or pass id to see one process's status and recent output."
+}
+func (t *ProcessListTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{
+				"type":        "string",
+				"description": "Process ID (e.g. proc-1) to show output for; omit to list all",
+			},
+			"lines": map[string]any{
+				"type":        "integer",
+				"description": fmt.Sprintf("Output lines to show for id (default %d, max %d)", defaultProcessLines, maxProcessLines),
+			},
+		},
+	}
+}
+
+func (t *ProcessListTool) Execute(ctx context.Context, args map[string]any) *Result {
+	sessionKey := ToolSessionKeyFromCtx(ctx)
+
+	if id, _ := args["id"].(string); id != "" {
+		p, ok := t.procs.Get(sessionKey, id)
+		if !ok {
+			return ErrorResult(fmt.Sprintf("no background process %q in this session", id))
+		}
+		return SilentResult(formatProcess(p, clampedIntArg(args, "lines", defaultProcessLines, 1, maxProcessLines)))
+	}
+
+	procs := t.procs.List(sessionKey)
+	if len(procs) == 0 {
+		return SilentResult("No background processes in this session.")
+	}
+	var sb strings.Builder
+	for _, p := range procs {
+		s := p.Snapshot()
+		fmt.Fprintf(&sb, "%s  %s  %s  %s\n", s.ID, processState(s), processAge(s), truncateCmd(s.Command, 80))
+	}
+	return SilentResult(strings.TrimSuffix(sb.String(), "\n"))
+}
+
+// ProcessKillTool terminates a background process.
+type ProcessKillTool struct {
+	procs *ProcessManager
+}
+
+func NewProcessKillTool(procs *ProcessManager) *ProcessKillTool {
+	return &ProcessKillTool{procs: procs}
+}
+
+func (t *ProcessKillTool) Name() string { return "process_kill" }
+func (t *ProcessKillTool) Description() string {
+	return "Stop a background process started with exec_background (SIGTERM, then SIGKILL after 3s) and show its final output."
+}
+func (t *ProcessKillTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id": map[string]any{
+				"type":        "string",
+				"description": "Process ID to stop (e.g. proc-1)",
+			},
+		},
+		"required": []string{"id"},
+	}
+}
+
+func (t *ProcessKillTool) Execute(ctx context.Context, args map[string]any) *Result {
+	id, _ := args["id"].(string)
+	if id == "" {
+		return ErrorResult("id is required")
+	}
+	p, ok := t.procs.Get(ToolSessionKeyFromCtx(ctx), id)
+	if !ok {
+		return ErrorResult(fmt.Sprintf("no background process %q in this session", id))
+	}
+	if !t.procs.Kill(p) {
+		return SilentResult(fmt.Sprintf("%s had already stopped.\n%s", id, formatProcess(p, 20)))
+	}
+	slog.Info("process_kill: stopped", "id", id)
+	return SilentResult(formatProcess(p, 20))
+}
+
+// formatProcess renders a process header plus its last n output lines.
+func formatProcess(p *BackgroundProcess, n int) string {
+	s := p.Snapshot()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s: %s (%s)\n$ %s\n", s.ID, processState(s), processAge(s), s.Command)
+	out := p.Tail(n)
+	if out == "" {
+		sb.WriteString("(no output yet)")
+	} else {
+		fmt.Fprintf(&sb, "--- output (last %d lines, %d bytes total) ---\n%s", n, s.Output, out)
+	}
+	return sb.String()
+}
+
+func processState(s ProcessSnapshot) string {
+	switch s.Status {
+	case ProcessRunning:
+		return ProcessRunning
+	case ProcessKilled:
+		return ProcessKilled
+	}
+	return fmt.Sprintf("exited with code %d", s.ExitCode)
+}
+
+func processAge(s ProcessSnapshot) string {
+	if s.Status == ProcessRunning {
+		return "up " + time.Since(s.StartedAt).Round(time.Second).String()
+	}
+	return "ran " + s.EndedAt.Sub(s.StartedAt).Round(time.Second).String()
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// maxProgressChars caps one progress message (a long output line is truncated).
+const maxProgressChars = 500
+
+// ProgressReporter receives incremental progress from a running tool — output
+// lines of a long command, browser navigation milestones — so the user is not
+// left waiting on a silent tool. Implementations must be safe for concurrent
+// use and must not block; they may drop messages to rate-limit.
+type ProgressReporter func(message string)
+
+const ctxProgressReporter toolContextKey = "tool_progress_reporter"
+
+// WithProgressReporter attaches the reporter for the tool call running under ctx.
+func WithProgressReporter(ctx context.Context, r ProgressReporter) context.Context {
+	return context.WithValue(ctx, ctxProgressReporter, r)
+}
+
+// ProgressReporterFromCtx returns the tool call's reporter, or nil.
+func ProgressReporterFromCtx(ctx context.Context) ProgressReporter {
+	r, _ := ctx.Value(ctxProgressReporter).(ProgressReporter)
+	return r
+}
+
+// ReportProgress sends a progress message to the tool call's reporter, if any.
+// Messages leave the tool before result scrubbing, so they are scrubbed here.
+func ReportProgress(ctx context.Context, message string) {
+	r := ProgressReporterFromCtx(ctx)
+	if r == nil {
+		return
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return
+	}
+	if runes := []rune(message); len(runes) > maxProgressChars {
+		message = string(runes[:maxProgressChars]) + "..."
+	}
+	r(ScrubCredentials(message))
+}
+
+// progressLineWriter reports each complete line written to it. Use one per
+// stream: it is not safe for concurrent writes.
+type progressLineWriter struct {
+	ctx  context.Context
+	line bytes.Buffer
+}
+
+// newProgressLineWriter returns a writer reporting output lines as progress,
+// or nil when ctx carries no reporter.
+func newProgressLineWriter(ctx context.Context) io.Writer {
+	if ProgressReporterFromCtx(ctx) == nil {
+		return nil
+	}
+	return &progressLineWriter{ctx: ctx}
+}
+
+func (w *progressLineWriter) Write(p []byte) (int, error) {
+	rest := p
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			// Keep a bounded partial line; the overflow is dropped from progress only.
+			if room := maxProgressChars*4 - w.line.Len(); room > 0 {
+				w.line.Write(rest[:min(len(rest), room)])
+			}
+			return len(p), nil
+		}
+		w.line.Write(rest[:i])
+		// Carriage returns separate progress-bar redraws; report the latest one.
+		line := w.line.String()
+		if j := strings.LastIndexByte(strings.TrimRight(line, "\r"), '\r'); j >= 0 {
+			line = line[j+1:]
+		}
+		ReportProgress(w.ctx, line)
+		w.line.Reset()
+		rest = rest[i+1:]
+	}
+}
+
+// withProgressOutput tees w into a line reporter when ctx carries one.
+func withProgressOutput(ctx context.Context, w io.Writer) io.Writer {
+	if pw := newProgressLineWriter(ctx); pw != nil {
+		return io.MultiWriter(w, pw)
+	}
+	return w
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func collectProgress() (context.Context, *[]string) {
+	var got []string
+	ctx := WithProgressReporter(context.Background(), func(msg string) { got = append(got, msg) })
+	return ctx, &got
+}
+
+func TestProgressLineWriter_ReportsCompleteLines(t *testing.T) {
+	ctx, got := collectProgress()
+	w := newProgressLineWriter(ctx)
+
+	fmt.Fprint(w, "compiling a")
+	fmt.Fprint(w, "\ncompiling b\n\n  \npartial")
+	fmt.Fprint(w, "downloading 10%\rdownloading 50%\rdownloading 100%\r\n")
+
+	want := []string{"compiling a", "compiling b", "downloading 100%"}
+	if strings.Join(*got, "|") != strings.Join(want, "|") {
+		t.Errorf("reported %q, want %q", *got, want)
+	}
+}
+
+func TestReportProgress_ScrubsAndTruncates(t *testing.T) {
+	ctx, got := collectProgress()
+
+	ReportProgress(ctx, "export OPENAI_API_KEY=sk-proj-abcdefghijklmnopqrstuvwxyz0123456789")
+	ReportProgress(ctx, strings.Repeat("x", maxProgressChars+100))
+
+	if len(*got) != 2 {
+		t.Fatalf("reported %d messages, want 2", len(*got))
+	}
+	if strings.Contains((*got)[0], "abcdefghijklmnop") {
+		t.Errorf("credential not scrubbed: %q", (*got)[0])
+	}
+	if n := len([]rune((*got)[1])); n != maxProgressChars+3 {
+		t.Errorf("truncated length = %d, want %d", n, maxProgressChars+3)
+	}
+}
+
+func TestProgress_NoReporter(t *testing.T) {
+	ctx := context.Background()
+	ReportProgress(ctx, "ignored") // must not panic
+	if w := newProgressLineWriter(ctx); w != nil {
+		t.Error("expected nil writer without a reporter")
+	}
+	var sb strings.Builder
+	if withProgressOutput(ctx, &sb) != &sb {
+		t.Error("expected the original writer without a reporter")
+	}
+}
@@ -17,7 +17,7 @@ import (
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
-const maxSkillDirSize = 20 << 20 // 20 MB
+const maxSkillDirSize = skills.MaxSkillPackageSize // 20 MB
 
 // PublishSkillTool registers a skill directory in the database,
 // making it discoverable and grantable to agents.
@@ -159,7 +159,7 @@ func (t *PublishSkillTool) Execute(ctx context.Context, args map[string]any) *Re
 	// Auto-grant to calling agent (granted-by = owner, same as CreateSkillManaged)
 	agentID := store.AgentIDFromContext(ctx)
 	if agentID != uuid.Nil {
-		if err := t.skills.GrantToAgent(ctx, id, agentID, version, ownerID); err != nil {
+		if err := t.skills.GrantToAgent(ctx, id, agentID, 0, ownerID); err != nil {
 			slog.Warn("publish_skill: auto-grant failed", "error", err)
 		}
 	}
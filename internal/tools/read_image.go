@@ -27,6 +27,20 @@ func MediaImagesFromCtx(ctx context.Context) []providers.ImageContent {
 	return v
 }
 
+const ctxMainModelVision toolContextKey = "tool_main_model_vision"
+
+// WithMainModelVision marks whether the agent's own provider accepts image
+// input, enabling read_image attach mode.
+func WithMainModelVision(ctx context.Context, ok bool) context.Context {
+	return context.WithValue(ctx, ctxMainModelVision, ok)
+}
+
+// MainModelVisionFromCtx reports whether images can be attached to the main conversation.
+func MainModelVisionFromCtx(ctx context.Context) bool {
+	v, _ := ctx.Value(ctxMainModelVision).(bool)
+	return v
+}
+
 // --- ReadImageTool ---
 
 // visionProviderPriority is the order in which providers are tried for vision.
@@ -56,7 +70,7 @@ func NewReadImageTool(registry *providers.Registry) *ReadImageTool {
 func (t *ReadImageTool) Name() string { return "read_image" }
 
 func (t *ReadImageTool) Description() string {
-	return "Analyze images using vision AI. Works with: (1) images sent by the user (<media:image> tags), (2) workspace/generated image files (pass a file path)."
+	return "Analyze images using vision AI. Works with: (1) images sent by the user (<media:image> tags), (2) workspace/generated image files (pass a file path). Set attach=true to load the image into the conversation and look at it yourself."
 }
 
 func (t *ReadImageTool) Parameters() map[string]any {
@@ -71,6 +85,10 @@ func (t *ReadImageTool) Parameters() map[string]any {
 				"type":        "string",
 				"description": "Optional file path to an image in the workspace. Use this for generated images or attachments. If omitted, analyzes images from the conversation.",
 			},
+			"attach": map[string]any{
+				"type":        "boolean",
+				"description": "Load the image(s) into the conversation instead of getting a text description from a separate vision model. Only works when your own model supports images.",
+			},
 		},
 		"required": []string{"prompt"},
 	}
@@ -99,6 +117,14 @@ func (t *ReadImageTool) Execute(ctx context.Context, args map[string]any) *Resul
 		return ErrorResult("No images available. Either send an image in the chat or provide a file path with the 'path' parameter.")
 	}
 
+	attach, _ := args["attach"].(bool)
+	if attach {
+		if !MainModelVisionFromCtx(ctx) {
+			return ErrorResult("attach=true needs a model that accepts images; call read_image without attach to get a description instead.")
+		}
+		return attachImagesResult(images)
+	}
+
 	chain := ResolveMediaProviderChain(ctx, "read_image", "", "",
 		visionProviderPriority, visionModelDefaults, t.registry)
 
@@ -111,6 +137,10 @@ func (t *ReadImageTool) Execute(ctx context.Context, args map[string]any) *Resul
 		chain[i].Params["images"] = images
 	}
 
+	if len(chain) == 0 && MainModelVisionFromCtx(ctx) {
+		// No separate vision provider, but the agent's own model can look.
+		return attachImagesResult(images)
+	}
 	if len(chain) == 0 {
 		return ErrorResult("No vision provider configured. Ask the user to add a vision-capable provider (e.g. Gemini, Anthropic, OpenRouter) in the system settings.")
 	}
@@ -213,3 +243,12 @@ func (t *ReadImageTool) loadImageFromPath(ctx context.Context, path string) ([]p
 		Data:     base64.StdEncoding.EncodeToString(data),
 	}}, nil
 }
+
+// attachImagesResult hands the images to the agent loop, which adds them to
+// the conversation right after the tool results.
+func attachImagesResult(images []providers.ImageContent) *Result {
+	return &Result{
+		ForLLM: fmt.Sprintf("%d image(s) attached to the conversation; they follow this tool result. Answer the prompt by looking at them.", len(images)),
+		Images: images,
+	}
+}
@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/providers"
+)
+
+func TestReadImage_AttachMode(t *testing.T) {
+	ws := t.TempDir()
+	if err := os.WriteFile(filepath.Join(ws, "chart.png"), []byte("\x89PNG fake"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tool := NewReadImageTool(providers.NewRegistry(nil))
+	ctx := WithToolWorkspace(context.Background(), ws)
+	args := map[string]any{"prompt": "what does it show?", "path": "chart.png", "attach": true}
+
+	// The main model cannot see images: attach is refused.
+	if res := tool.Execute(ctx, args); !res.IsError || len(res.Images) != 0 {
+		t.Errorf("attach without vision: %+v", res)
+	}
+
+	ctx = WithMainModelVision(ctx, true)
+	res := tool.Execute(ctx, args)
+	if res.IsError || len(res.Images) != 1 || res.Images[0].MimeType != "image/png" {
+		t.Fatalf("attach: %+v", res)
+	}
+
+	// No vision provider registered: read_image falls back to attaching.
+	delete(args, "attach")
+	res = tool.Execute(ctx, args)
+	if res.IsError || len(res.Images) != 1 || !strings.Contains(res.ForLLM, "attached") {
+		t.Errorf("fallback: %+v", res)
+	}
+}
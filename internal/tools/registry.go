@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"maps"
@@ -10,8 +11,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
 	"github.com/nextlevelbuilder/goclaw/internal/providers"
 	"github.com/nextlevelbuilder/goclaw/internal/safego"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
 
 // Registry manages tool registration and execution.
@@ -21,8 +25,10 @@ type Registry struct {
 	aliases     map[string]string       // alias name → canonical tool name
 	disabled    map[string]bool         // tools disabled via admin UI (kept in registry, excluded from List)
 	mu          sync.RWMutex
-	rateLimiter *ToolRateLimiter // nil = no rate limiting
-	scrubbing   bool             // scrub credentials from output (default true)
+	rateLimiter *ToolRateLimiter     // nil = no rate limiting
+	scrubbing   bool                 // scrub credentials from output (default true)
+	approvals   *ExecApprovalManager // nil = no per-tool approval prompts
+	auditPub    bus.EventPublisher   // nil = tool executions are not audited
 
 	// Per-registry tool groups (eliminates global map race condition).
 	// MCP tools register their groups here so each Loop has isolated namespace.
@@ -70,11 +76,23 @@ func (r *Registry) TryActivateDeferred(name string) bool {
 	return fn(name)
 }
 
+// SetAuditPublisher makes every tool execution emit an audit.log event
+// (persisted to activity_logs by the gateway audit subscriber).
+func (r *Registry) SetAuditPublisher(pub bus.EventPublisher) {
+	r.auditPub = pub
+}
+
 // SetRateLimiter enables per-key tool rate limiting.
 func (r *Registry) SetRateLimiter(rl *ToolRateLimiter) {
 	r.rateLimiter = rl
 }
 
+// SetApprovalGate makes tools listed in the approval config wait for the
+// owner's confirmation before they run.
+func (r *Registry) SetApprovalGate(m *ExecApprovalManager) {
+	r.approvals = m
+}
+
 // SetScrubbing enables or disables credential scrubbing on tool output.
 func (r *Registry) SetScrubbing(enabled bool) {
 	r.scrubbing = enabled
@@ -207,6 +225,14 @@ func (r *Registry) ExecuteWithContext(ctx context.Context, name string, args map
 		}
 	}
 
+	// Human-in-the-loop approval for dangerous tools (exec has its own check)
+	if r.approvals != nil && r.approvals.NeedsApproval(tool.Name(), args) {
+		decision, err := r.approvals.RequestToolApproval(ctx, tool.Name(), summarizeToolArgs(args), store.AgentKeyFromContext(ctx), ToolApprovalTimeout)
+		if err != nil || decision == ApprovalDeny {
+			return toolApprovalDeniedResult(tool.Name(), err)
+		}
+	}
+
 	// Detect empty tool call arguments — typically caused by providers truncating
 	// or dropping arguments when output is too large (e.g. DashScope with long content).
 	// Give the model an actionable hint instead of a confusing "X is required" error.
@@ -242,10 +268,57 @@ func (r *Registry) ExecuteWithContext(ctx context.Context, name string, args map
 		"is_error", result.IsError,
 		"async", result.Async,
 	)
+	r.auditToolExecution(ctx, name, tool.Name(), result, duration)
 
 	return result
 }
 
+// auditToolExecution records one tool call with user attribution. Arguments
+// and output are deliberately left out — they may carry secrets or PII.
+func (r *Registry) auditToolExecution(ctx context.Context, calledAs, toolName string, result *Result, duration time.Duration) {
+	if r.auditPub == nil {
+		return
+	}
+	actorType, actorID := "user", store.UserIDFromContext(ctx)
+	if actorID == "" {
+		actorType, actorID = "agent", store.AgentKeyFromContext(ctx)
+	}
+	if actorID == "" {
+		actorType, actorID = "system", "system"
+	}
+	action := "tool.executed"
+	if result.IsError {
+		action = "tool.failed"
+	}
+
+	details := map[string]any{
+		"agent_key":   store.AgentKeyFromContext(ctx),
+		"session_key": ToolSessionKeyFromCtx(ctx),
+		"channel":     ToolChannelFromCtx(ctx),
+		"duration_ms": duration.Milliseconds(),
+	}
+	if calledAs != toolName {
+		details["called_as"] = calledAs
+	}
+	if result.Async {
+		details["async"] = true
+	}
+	raw, _ := json.Marshal(details)
+
+	r.auditPub.Broadcast(bus.Event{
+		Name: protocol.EventAuditLog,
+		Payload: bus.AuditEventPayload{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Action:     action,
+			EntityType: "tool",
+			EntityID:   toolName,
+			Details:    raw,
+			TenantID:   store.TenantIDFromContext(ctx),
+		},
+	})
+}
+
 // safeExecute runs tool.Execute with panic recovery. A panicking tool returns
 // an error result instead of crashing the process.
 func safeExecute(tool Tool, ctx context.Context, args map[string]any) (result *Result) {
@@ -359,6 +432,8 @@ func (r *Registry) Clone() *Registry {
 		toolGroups:  make(map[string][]string, len(r.toolGroups)),
 		rateLimiter: r.rateLimiter,
 		scrubbing:   r.scrubbing,
+		approvals:   r.approvals,
+		auditPub:    r.auditPub,
 	}
 	maps.Copy(clone.tools, r.tools)
 	maps.Copy(clone.metadata, r.metadata)
@@ -4,6 +4,9 @@ import (
 	"context"
 	"strings"
 	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 // mockTool is a minimal tool for testing the registry.
@@ -377,3 +380,33 @@ func TestRegistry_TryActivateDeferred_NilActivatorAfterSet(t *testing.T) {
 		t.Error("expected false after setting nil activator")
 	}
 }
+
+func TestRegistry_AuditsToolExecution(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(&mockTool{name: "ok_tool"})
+	reg.Register(&mockTool{name: "bad_tool", execFn: func(context.Context, map[string]any) *Result {
+		return ErrorResult("boom")
+	}})
+	pub := &recordingPublisher{}
+	reg.SetAuditPublisher(pub)
+
+	ctx := store.WithUserID(context.Background(), "user-1")
+	reg.Execute(ctx, "ok_tool", map[string]any{"secret": "s3cret"})
+	reg.Clone().Execute(context.Background(), "bad_tool", nil)
+
+	if len(pub.events) != 2 {
+		t.Fatalf("events = %v", pub.names())
+	}
+	first := pub.events[0].Payload.(bus.AuditEventPayload)
+	if first.Action != "tool.executed" || first.ActorType != "user" || first.ActorID != "user-1" ||
+		first.EntityType != "tool" || first.EntityID != "ok_tool" {
+		t.Errorf("first = %+v", first)
+	}
+	if strings.Contains(string(first.Details), "s3cret") {
+		t.Errorf("details leak tool args: %s", first.Details)
+	}
+	second := pub.events[1].Payload.(bus.AuditEventPayload)
+	if second.Action != "tool.failed" || second.ActorType != "system" {
+		t.Errorf("second = %+v", second)
+	}
+}
@@ -22,6 +22,11 @@ type Result struct {
 	// Nil means no prompt metadata available.
 	MediaPrompts map[int]string `json:"-"`
 
+	// Images are loaded into the conversation for the main model: the agent
+	// loop appends them as a user message after the tool results (read_image
+	// attach mode). Only set when the main provider accepts image input.
+	Images []providers.ImageContent `json:"-"`
+
 	// Deliverable holds the primary work output from this tool execution.
 	// Used to capture actual content (e.g. written file text, image prompt) for team
 	// task results instead of relying on the LLM's summary response.
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // publicIPServices returns the caller's public IP as plain text.
@@ -119,6 +121,7 @@ func detectPublicIP(ctx context.Context) string {
 	client := &http.Client{
 		Timeout: 5 * time.Second,
 		Transport: &http.Transport{
+			Proxy:               netproxy.For(netproxy.ScopeDefault),
 			MaxIdleConns:        2,
 			IdleConnTimeout:     10 * time.Second,
 			TLSHandshakeTimeout: 5 * time.Second,
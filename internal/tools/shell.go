@@ -7,9 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"regexp"
-	"runtime"
 	"strings"
 	"time"
 
@@ -47,6 +45,19 @@ type ExecTool struct {
 	// Per-agent overrides from context (store.WithShellDenyGroups) win per-key.
 	// Updated at startup and via TopicConfigChanged pub/sub for runtime reload.
 	globalDenyGroups map[string]bool
+	memIndexer       *MemoryFileIndexer // nil = memory files written by commands are not imported
+}
+
+// SetMemoryFileIndexer enables importing memory files that commands write to disk.
+func (t *ExecTool) SetMemoryFileIndexer(idx *MemoryFileIndexer) {
+	t.memIndexer = idx
+}
+
+// scheduleMemoryIndex queues a memory file scan when a command may have touched memory files.
+func (t *ExecTool) scheduleMemoryIndex(ctx context.Context, command string) {
+	if t.memIndexer != nil && strings.Contains(strings.ToLower(command), "memory") {
+		t.memIndexer.Schedule(ctx, t.workspace)
+	}
 }
 
 // SetGlobalShellDenyGroups replaces the global shell deny-group toggles. The
@@ -118,8 +129,7 @@ func (t *ExecTool) SetSandboxKey(key string) {}
 // These are NOT configurable via deny groups — they always apply regardless of group config.
 func (t *ExecTool) DenyPaths(paths ...string) {
 	for _, p := range paths {
-		escaped := regexp.QuoteMeta(p)
-		t.pathDenyPatterns = append(t.pathDenyPatterns, regexp.MustCompile(escaped))
+		t.pathDenyPatterns = append(t.pathDenyPatterns, pathDenyRegexp(p))
 		t.pathDenyRoots = append(t.pathDenyRoots, p)
 	}
 }
@@ -165,8 +175,14 @@ func (t *ExecTool) HasSecureCLIStore() bool {
 	return t.secureCLIStore != nil
 }
 
-func (t *ExecTool) Name() string        { return "exec" }
-func (t *ExecTool) Description() string { return "Execute a shell command and return its output" }
+func (t *ExecTool) Name() string { return "exec" }
+func (t *ExecTool) Description() string {
+	desc := "Execute a shell command and return its output"
+	if t.sandboxMgr == nil {
+		desc += hostShellHint()
+	}
+	return desc
+}
 func (t *ExecTool) Parameters() map[string]any {
 	return map[string]any{
 		"type": "object",
@@ -185,14 +201,52 @@ func (t *ExecTool) Parameters() map[string]any {
 }
 
 func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
+	plan, res := t.prepare(ctx, args)
+	if res != nil {
+		return res
+	}
+
+	// Credentialed exec bypasses approval (admin trust) and shell (security).
+	if plan.cred != nil {
+		return t.executeCredentialed(ctx, plan.cred, plan.credBinary, plan.credArgs, plan.cwd, ToolSandboxKeyFromCtx(ctx), plan.command)
+	}
+
+	// Sandbox routing (sandboxKey from ctx — thread-safe)
+	sandboxKey := ToolSandboxKeyFromCtx(ctx)
+	if t.sandboxMgr != nil && sandboxKey != "" {
+		defer t.scheduleMemoryIndex(ctx, plan.normalized)
+		return t.executeInSandbox(ctx, plan.command, plan.cwd, sandboxKey)
+	}
+
+	// Host execution
+	defer t.scheduleMemoryIndex(ctx, plan.normalized)
+	return t.executeOnHost(ctx, plan.command, plan.cwd)
+}
+
+// execPlan is a command that passed the safety checks, ready to run.
+type execPlan struct {
+	command    string
+	normalized string
+	cwd        string
+
+	// Set when the command targets a credentialed binary (Direct Exec Mode).
+	cred       *store.SecureCLIBinary
+	credBinary string
+	credArgs   []string
+}
+
+// prepare runs every check that gates a command — deny groups, secure CLI
+// gate, exec approval — and resolves the working directory. A non-nil Result
+// means the command must not run. Shared by exec and exec_background.
+func (t *ExecTool) prepare(ctx context.Context, args map[string]any) (*execPlan, *Result) {
 	command, _ := args["command"].(string)
 	if command == "" {
-		return ErrorResult("command is required")
+		return nil, ErrorResult("command is required")
 	}
 
 	// Reject NUL bytes — they cause silent shell truncation enabling injection.
 	if strings.ContainsRune(command, '\x00') {
-		return ErrorResult("command contains invalid NUL byte")
+		return nil, ErrorResult("command contains invalid NUL byte")
 	}
 
 	// Normalize command before all deny checks: NFKC + zero-width strip prevents
@@ -261,24 +315,24 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 			// This lets agents "request permission" from admin to install packages.
 			if t.approvalMgr != nil && matchesAny(normalizedCommand, pkgInstallPatterns) {
 				slog.Info("exec: package install requires approval", "command", truncateCmd(command, 100), "agent", t.agentID)
-				decision, err := t.approvalMgr.RequestApproval(command, t.agentID, 2*time.Minute)
+				decision, err := t.approvalMgr.RequestToolApproval(ctx, "exec", command, t.agentID, ToolApprovalTimeout)
 				if err != nil {
-					return ErrorResult(fmt.Sprintf("package install approval: %v", err))
+					return nil, ErrorResult(fmt.Sprintf("package install approval: %v", err))
 				}
 				if decision == ApprovalDeny {
-					return ErrorResult("package installation denied by admin")
+					return nil, ErrorResult("package installation denied by admin")
 				}
 				// Approved — skip deny, continue to execution.
 				continue
 			}
 
-			return ErrorResult(fmt.Sprintf("command denied by safety policy: matches pattern %s", pattern.String()))
+			return nil, ErrorResult(fmt.Sprintf("command denied by safety policy: matches pattern %s", pattern.String()))
 		}
 	}
 
 	// Memory path hint: shell commands can't access DB-backed memory files.
 	if hint := MaybeMemoryExecHint(normalizedCommand); hint != "" {
-		return SilentResult(hint)
+		return nil, SilentResult(hint)
 	}
 
 	// Credentialed exec: if command matches a configured binary, use Direct Exec Mode.
@@ -297,8 +351,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 				cwd = wd
 			}
 		}
-		sandboxKey := ToolSandboxKeyFromCtx(ctx)
-		return t.executeCredentialed(ctx, cred, binary, cmdArgs, cwd, sandboxKey, command)
+		return &execPlan{command: command, normalized: normalizedCommand, cwd: cwd, cred: cred, credBinary: binary, credArgs: cmdArgs}, nil
 	}
 
 	// Secure CLI gate: registered-but-not-granted binaries MUST NOT fall through
@@ -311,7 +364,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 			slog.Warn("security.credentialed_binary_wrapper_too_deep",
 				"command", truncateCmd(normalizedCommand, 80),
 				"agent_id", store.AgentIDFromContext(ctx))
-			return ErrorResult("Command nesting too deep (>3 shell wrappers). This looks adversarial; if legitimate, flatten the command.")
+			return nil, ErrorResult("Command nesting too deep (>3 shell wrappers). This looks adversarial; if legitimate, flatten the command.")
 		}
 		for _, c := range candidates {
 			if c.binary == "" {
@@ -324,7 +377,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 				slog.Warn("security.credentialed_binary_gate_error",
 					"binary", c.binary, "error", rerr,
 					"agent_id", store.AgentIDFromContext(ctx))
-				return ErrorResult("Secure CLI gate temporarily unavailable. Retry in a moment.")
+				return nil, ErrorResult("Secure CLI gate temporarily unavailable. Retry in a moment.")
 			}
 			if registered {
 				slog.Warn("security.credentialed_binary_denied",
@@ -333,7 +386,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 					"agent_id", store.AgentIDFromContext(ctx),
 					"tenant_id", store.TenantIDFromContext(ctx),
 					"command_prefix", truncateCmd(normalizedCommand, 80))
-				return ErrorResult(fmt.Sprintf(
+				return nil, ErrorResult(fmt.Sprintf(
 					"Binary %q requires a secure CLI grant. Ask admin to grant access to this agent.",
 					c.binary))
 			}
@@ -344,14 +397,14 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 	if t.approvalMgr != nil {
 		switch t.approvalMgr.CheckCommand(command) {
 		case "deny":
-			return ErrorResult("command denied by exec approval policy")
+			return nil, ErrorResult("command denied by exec approval policy")
 		case "ask":
-			decision, err := t.approvalMgr.RequestApproval(command, t.agentID, 2*time.Minute)
+			decision, err := t.approvalMgr.RequestToolApproval(ctx, "exec", command, t.agentID, ToolApprovalTimeout)
 			if err != nil {
-				return ErrorResult(fmt.Sprintf("exec approval: %v", err))
+				return nil, ErrorResult(fmt.Sprintf("exec approval: %v", err))
 			}
 			if decision == ApprovalDeny {
-				return ErrorResult("command denied by user")
+				return nil, ErrorResult("command denied by user")
 			}
 		}
 	}
@@ -379,7 +432,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 			allowed := allowedWriteWithTeamWorkspace(ctx, nil)
 			resolved, err := resolvePathWithAllowed(wd, wsBase, true, allowed)
 			if err != nil {
-				return ErrorResult(err.Error())
+				return nil, ErrorResult(err.Error())
 			}
 			cwd = resolved
 		} else {
@@ -387,14 +440,7 @@ func (t *ExecTool) Execute(ctx context.Context, args map[string]any) *Result {
 		}
 	}
 
-	// Sandbox routing (sandboxKey from ctx — thread-safe)
-	sandboxKey := ToolSandboxKeyFromCtx(ctx)
-	if t.sandboxMgr != nil && sandboxKey != "" {
-		return t.executeInSandbox(ctx, command, cwd, sandboxKey)
-	}
-
-	// Host execution
-	return t.executeOnHost(ctx, command, cwd)
+	return &execPlan{command: command, normalized: normalizedCommand, cwd: cwd}, nil
 }
 
 // matchesAny checks if a command matches any pattern in the list.
@@ -416,25 +462,11 @@ func (t *ExecTool) executeOnHost(ctx context.Context, command, cwd string) *Resu
 
 	// Use plain exec.Command (not CommandContext) so we control the kill sequence.
 	// CommandContext would SIGKILL only the direct child, leaving forked grandchildren alive.
-	// Route through the platform shell: cmd.exe on Windows, sh on POSIX.
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C", command)
-	} else {
-		cmd = exec.Command("sh", "-c", command)
-	}
+	// Route through the configured host shell (see SetHostShell).
+	cmd := hostShellCommand(command)
 	cmd.Dir = cwd
 
-	// Scrub credential env vars so fall-through exec cannot exfiltrate
-	// host secrets (Red Team F4). Uses static deny list + dynamic keys
-	// discovered from any registered secure-cli binary for this tenant.
-	var dynKeys []string
-	if t.secureCLIStore != nil {
-		dynKeys = t.credentialEnvKeys(ctx)
-	} else {
-		dynKeys = staticCredentialEnvKeys
-	}
-	cmd.Env = scrubCredentialEnv(os.Environ(), dynKeys)
+	cmd.Env = t.hostEnv(ctx)
 
 	// Place the child in its own process group so killProcessGroup(-pgid, sig)
 	// reaches the shell and all of its forked children.
@@ -443,8 +475,8 @@ func (t *ExecTool) executeOnHost(ctx context.Context, command, cwd string) *Resu
 	// Limit output to 1MB to prevent OOM from runaway commands.
 	stdout := &limitedBuffer{max: 1 << 20}
 	stderr := &limitedBuffer{max: 1 << 20}
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
+	cmd.Stdout = withProgressOutput(ctx, stdout)
+	cmd.Stderr = withProgressOutput(ctx, stderr)
 
 	if err := cmd.Start(); err != nil {
 		return ErrorResult(fmt.Sprintf("failed to start command: %v", err))
@@ -476,6 +508,18 @@ func (t *ExecTool) executeOnHost(ctx context.Context, command, cwd string) *Resu
 	}
 }
 
+// hostEnv returns the environment for host commands. Credential env vars are
+// scrubbed so fall-through exec cannot exfiltrate host secrets (Red Team F4):
+// static deny list + dynamic keys discovered from any registered secure-cli
+// binary for this tenant.
+func (t *ExecTool) hostEnv(ctx context.Context) []string {
+	dynKeys := staticCredentialEnvKeys
+	if t.secureCLIStore != nil {
+		dynKeys = t.credentialEnvKeys(ctx)
+	}
+	return scrubCredentialEnv(os.Environ(), dynKeys)
+}
+
 // buildHostResult formats the result of a completed host command execution.
 func buildHostResult(err error, stdout, stderr *limitedBuffer, ctx context.Context, timeout time.Duration) *Result {
 	var result string
@@ -527,7 +571,11 @@ func (t *ExecTool) executeInSandbox(ctx context.Context, command, cwd, sandboxKe
 		return ErrorResult(fmt.Sprintf("sandbox path mapping: %v", cwdErr))
 	}
 
-	result, err := sb.Exec(ctx, []string{"sh", "-c", command}, containerCwd) //nolint: no ExecOption for normal exec
+	var execOpts []sandbox.ExecOption
+	if ProgressReporterFromCtx(ctx) != nil {
+		execOpts = append(execOpts, sandbox.WithOutputTee(newProgressLineWriter(ctx), newProgressLineWriter(ctx)))
+	}
+	result, err := sb.Exec(ctx, []string{"sh", "-c", command}, containerCwd, execOpts...)
 	if err != nil {
 		return ErrorResult(fmt.Sprintf("sandbox exec: %v", err))
 	}
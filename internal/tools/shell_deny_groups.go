@@ -25,6 +25,9 @@ var DenyGroupRegistry = map[string]*DenyGroup{
 			regexp.MustCompile(`\brm\s+.*--force`),
 			regexp.MustCompile(`\bdel\s+/[fq]\b`),
 			regexp.MustCompile(`\brmdir\s+/s\b`),
+			regexp.MustCompile(`(?i)\brd\s+/s\b`),
+			regexp.MustCompile(`(?i)\bRemove-Item\b.*\s-(Recurse|Force)\b`),                         // PowerShell rm -rf
+			regexp.MustCompile(`(?i)\b(Format-Volume|Clear-Disk|Initialize-Disk|Stop-Computer|Restart-Computer)\b`), // PowerShell disk/power
 			regexp.MustCompile(`\b(mkfs|diskpart)\b|\bformat\s`),
 			regexp.MustCompile(`\bdd\s+if=`),
 			regexp.MustCompile(`>\s*/dev/sd[a-z]\b`),
@@ -98,6 +98,9 @@ func TestDestructiveOpsGaps(t *testing.T) {
 		"init 0", "init 6", "telinit 0", "telinit 6",
 		// new: systemctl suspend/hibernate
 		"systemctl suspend", "systemctl hibernate",
+		// Windows cmd / PowerShell
+		`rd /s /q build`, `Remove-Item -Recurse C:\data`, `remove-item .\cache -force`,
+		"Format-Volume -DriveLetter D", "Restart-Computer", "Stop-Computer -Force",
 	)
 
 	mustAllow(t, patterns,
@@ -108,6 +111,8 @@ func TestDestructiveOpsGaps(t *testing.T) {
 		"init 1",              // only 0 and 6 are blocked
 		"systemctl status",    // not suspend/hibernate
 		"systemctl start nginx",
+		"Remove-Item notes.txt", // single file, no -Recurse/-Force
+		"Get-ChildItem -Recurse -Filter *.md",
 	)
 }
 
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync/atomic"
+)
+
+// Host shells accepted by SetHostShell (config tools.exec_shell / GOCLAW_EXEC_SHELL).
+const (
+	ShellAuto       = "auto" // cmd on Windows, sh elsewhere
+	ShellSh         = "sh"
+	ShellBash       = "bash"
+	ShellCmd        = "cmd"
+	ShellPowerShell = "powershell" // Windows PowerShell 5.x
+	ShellPwsh       = "pwsh"       // PowerShell 7+
+)
+
+// hostShell is the configured interpreter for host (non-sandboxed) exec.
+// Process-wide: every ExecTool instance shares it.
+var hostShell atomic.Value // string
+
+// SetHostShell selects the interpreter used by host exec. "" means auto.
+func SetHostShell(name string) error {
+	switch name {
+	case "", ShellAuto, ShellSh, ShellBash, ShellCmd, ShellPowerShell, ShellPwsh:
+	default:
+		return fmt.Errorf("unknown exec shell %q (want auto, sh, bash, cmd, powershell or pwsh)", name)
+	}
+	hostShell.Store(name)
+	return nil
+}
+
+// HostShell returns the effective host exec interpreter after auto-detection.
+func HostShell() string {
+	name, _ := hostShell.Load().(string)
+	if name != "" && name != ShellAuto {
+		return name
+	}
+	if runtime.GOOS == "windows" {
+		return ShellCmd
+	}
+	return ShellSh
+}
+
+// hostShellCommand builds the command that runs command through the host shell.
+func hostShellCommand(command string) *exec.Cmd {
+	switch shell := HostShell(); shell {
+	case ShellCmd:
+		comspec := os.Getenv("ComSpec")
+		if comspec == "" {
+			comspec = "cmd.exe"
+		}
+		cmd := exec.Command(comspec)
+		setCmdExeLine(cmd, command)
+		return cmd
+	case ShellPowerShell, ShellPwsh:
+		return exec.Command(shell, "-NoProfile", "-NonInteractive", "-Command", command)
+	default:
+		return exec.Command(shell, "-c", command)
+	}
+}
+
+// hostShellHint tells the model which syntax host commands must use when it
+// is not a POSIX shell; empty for sh/bash.
+func hostShellHint() string {
+	switch HostShell() {
+	case ShellCmd:
+		return " Commands run in Windows cmd.exe: use cmd syntax (dir, type, set VAR=value), not POSIX shell."
+	case ShellPowerShell, ShellPwsh:
+		return " Commands run in PowerShell: use PowerShell syntax (Get-ChildItem, Get-Content, $env:VAR)."
+	}
+	return ""
+}
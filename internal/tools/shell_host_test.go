@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func setHostShellForTest(t *testing.T, name string) {
+	t.Helper()
+	if err := SetHostShell(name); err != nil {
+		t.Fatalf("SetHostShell(%q): %v", name, err)
+	}
+	t.Cleanup(func() { SetHostShell("") })
+}
+
+func TestSetHostShell(t *testing.T) {
+	if err := SetHostShell("fish"); err == nil {
+		t.Error("SetHostShell(fish) should fail")
+	}
+
+	setHostShellForTest(t, "")
+	want := ShellSh
+	if runtime.GOOS == "windows" {
+		want = ShellCmd
+	}
+	if got := HostShell(); got != want {
+		t.Errorf("auto HostShell() = %q, want %q", got, want)
+	}
+
+	setHostShellForTest(t, ShellPwsh)
+	if got := HostShell(); got != ShellPwsh {
+		t.Errorf("HostShell() = %q, want pwsh", got)
+	}
+}
+
+func TestHostShellCommand(t *testing.T) {
+	setHostShellForTest(t, ShellPowerShell)
+	cmd := hostShellCommand("Get-ChildItem")
+	if !slices.Equal(cmd.Args, []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", "Get-ChildItem"}) {
+		t.Errorf("powershell args = %q", cmd.Args)
+	}
+
+	setHostShellForTest(t, ShellBash)
+	cmd = hostShellCommand("ls -la")
+	if !slices.Equal(cmd.Args, []string{"bash", "-c", "ls -la"}) {
+		t.Errorf("bash args = %q", cmd.Args)
+	}
+}
+
+func TestExecDescription_HostShellHint(t *testing.T) {
+	setHostShellForTest(t, ShellPowerShell)
+	if d := NewExecTool(t.TempDir(), false).Description(); !strings.Contains(d, "PowerShell") {
+		t.Errorf("description %q should name PowerShell", d)
+	}
+
+	setHostShellForTest(t, ShellSh)
+	if d := NewExecTool(t.TempDir(), false).Description(); d != "Execute a shell command and return its output" {
+		t.Errorf("sh description = %q, want unchanged default", d)
+	}
+}
+
+func TestIsRootLevelDir(t *testing.T) {
+	for _, dir := range []string{".", "", "/"} {
+		if !isRootLevelDir(dir) {
+			t.Errorf("isRootLevelDir(%q) = false", dir)
+		}
+	}
+	if isRootLevelDir("memory") {
+		t.Error(`isRootLevelDir("memory") = true`)
+	}
+}
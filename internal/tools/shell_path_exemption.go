@@ -79,15 +79,15 @@ func (t *ExecTool) isNestedUnderDeniedRoot(path string) bool {
 		}
 		if !filepath.IsAbs(cleanRoot) {
 			marker := string(filepath.Separator) + cleanRoot + string(filepath.Separator)
-			if strings.Contains(path, marker) {
+			if strings.Contains(foldPathCase(path), foldPathCase(marker)) {
 				return true
 			}
 			continue
 		}
-		if path == cleanRoot {
+		if foldPathCase(path) == foldPathCase(cleanRoot) {
 			continue
 		}
-		if strings.HasPrefix(path, cleanRoot+string(filepath.Separator)) {
+		if isPathInside(path, cleanRoot) {
 			return true
 		}
 	}
@@ -97,7 +97,9 @@ func (t *ExecTool) isNestedUnderDeniedRoot(path string) bool {
 // matchesPathExemption checks if a resolved path falls under any exemption prefix.
 func matchesPathExemption(path string, exemptions []string) bool {
 	sep := string(filepath.Separator)
+	path = foldPathCase(path)
 	for _, ex := range exemptions {
+		ex = foldPathCase(ex)
 		if ex == "" {
 			continue
 		}
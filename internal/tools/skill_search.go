@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 
 	"github.com/google/uuid"
 
@@ -142,6 +143,7 @@ func (t *SkillSearchTool) Execute(ctx context.Context, args map[string]any) *Res
 }
 
 // filterByAccess filters search results to only include skills accessible to the calling agent.
+// Managed skills the agent's grant pins to a version point at that version's SKILL.md.
 // If no SkillAccessStore is set or no agent ID is in context, returns results unfiltered.
 func (t *SkillSearchTool) filterByAccess(ctx context.Context, results []skills.SkillSearchResult) []skills.SkillSearchResult {
 	if t.skillAccess == nil {
@@ -157,16 +159,19 @@ func (t *SkillSearchTool) filterByAccess(ctx context.Context, results []skills.S
 		slog.Warn("skill_search: failed to load accessible skills, returning unfiltered", "error", err)
 		return results
 	}
-	allowed := make(map[string]struct{}, len(accessible))
+	allowed := make(map[string]int, len(accessible)) // slug → pinned version
 	for _, s := range accessible {
-		allowed[s.Slug] = struct{}{}
+		allowed[s.Slug] = s.PinnedVersion
 	}
 	// Filesystem skills (source != "managed") are always allowed
 	filtered := make([]skills.SkillSearchResult, 0, len(results))
 	for _, r := range results {
 		if r.Source != "managed" {
 			filtered = append(filtered, r)
-		} else if _, ok := allowed[r.Slug]; ok {
+		} else if pin, ok := allowed[r.Slug]; ok {
+			if path, found := t.loader.ManagedSkillPath(r.Slug, pin); found {
+				r.Location, r.BaseDir = path, filepath.Dir(path)
+			}
 			filtered = append(filtered, r)
 		} else {
 			slog.Debug("skill_search: filtered out inaccessible managed skill", "slug", r.Slug, "name", r.Name)
@@ -83,6 +83,7 @@ type SubagentManager struct {
 	createTools   func() *Registry
 	announceQueue *AnnounceQueue          // optional: batches announces with debounce
 	taskStore     store.SubagentTaskStore // optional: persists tasks to DB (fire-and-forget)
+	laneRunner    LaneRunner              // optional: runs async subagents on the "subagent" lane
 }
 
 // NewSubagentManager creates a new subagent manager.
@@ -154,6 +155,7 @@ var SubagentDenyAlways = []string{
 	"cron",
 	"memory_search",
 	"memory_get",
+	"memory_write",
 	"sessions_send",
 	"team_tasks", // subagents must not use team orchestration
 }
@@ -149,6 +149,11 @@ func (sm *SubagentManager) executeTask(ctx context.Context, task *SubagentTask)
 			"trace_id", tracing.TraceIDFromContext(traceCtx),
 			"status", task.Status, "iterations", iteration)
 
+		sm.mu.RLock()
+		finished := finishedProgressMessage(task, iteration)
+		sm.mu.RUnlock()
+		sm.reportProgress(ctx, task, iteration, "", finished)
+
 		// Schedule auto-archive
 		if task.spawnConfig.ArchiveAfterMinutes > 0 {
 			go sm.scheduleArchive(task.ID, time.Duration(task.spawnConfig.ArchiveAfterMinutes)*time.Minute)
@@ -197,6 +202,7 @@ func (sm *SubagentManager) executeTask(ctx context.Context, task *SubagentTask)
 
 	// Emit running subagent root span (after model resolution so span has correct model).
 	sm.emitSubagentSpanStart(traceCtx, subRootSpanID, taskStart, task, model, activeProvider.Name())
+	sm.reportProgress(ctx, task, 0, "", fmt.Sprintf("started (depth %d, model %s)", task.Depth, model))
 
 	// Build subagent system prompt (matching TS buildSubagentSystemPrompt pattern).
 	workspace := ToolWorkspaceFromCtx(ctx)
@@ -299,6 +305,7 @@ func (sm *SubagentManager) executeTask(ctx context.Context, task *SubagentTask)
 		// Execute tools
 		for _, tc := range resp.ToolCalls {
 			slog.Debug("subagent tool call", "id", task.ID, "tool", tc.Name)
+			sm.reportProgress(ctx, task, iteration, tc.Name, "running "+tc.Name)
 
 			argsJSON, _ := json.Marshal(tc.Arguments)
 			toolStart := time.Now().UTC()
@@ -344,3 +351,15 @@ func (sm *SubagentManager) executeTask(ctx context.Context, task *SubagentTask)
 
 	return iteration
 }
+
+// finishedProgressMessage summarizes a finished task for its last progress event.
+func finishedProgressMessage(task *SubagentTask, iterations int) string {
+	switch task.Status {
+	case TaskStatusCompleted:
+		return fmt.Sprintf("completed in %d iterations", iterations)
+	case TaskStatusFailed:
+		return "failed: " + truncate(task.Result, 200)
+	default:
+		return task.Status + ": " + task.Result
+	}
+}
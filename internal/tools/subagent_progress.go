@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// SubagentProgress is the payload of subagent.progress events.
+type SubagentProgress struct {
+	ID        string `json:"id"`
+	ParentID  string `json:"parentId"`
+	Label     string `json:"label"`
+	Status    string `json:"status"`
+	Depth     int    `json:"depth"`
+	Iteration int    `json:"iteration,omitempty"`
+	Tool      string `json:"tool,omitempty"` // tool being called, for per-iteration events
+	Message   string `json:"message"`
+	UserID    string `json:"userId,omitempty"` // spawning user, for WS event filtering
+}
+
+// LaneRunner runs fn on a scheduler lane, blocking until a slot is free or
+// ctx is done. Matches scheduler.Lane.Submit (tools cannot import scheduler).
+type LaneRunner func(ctx context.Context, fn func()) error
+
+// SetLaneRunner makes async subagents run on a scheduler lane so they share
+// its concurrency limit and show up in lane stats. Without it each subagent
+// runs in its own goroutine.
+func (sm *SubagentManager) SetLaneRunner(run LaneRunner) {
+	sm.laneRunner = run
+}
+
+// reportProgress broadcasts a subagent.progress event and, for sync
+// subagents, forwards the message to the parent's spawn tool call so it
+// streams as tool.progress.
+func (sm *SubagentManager) reportProgress(ctx context.Context, task *SubagentTask, iteration int, tool, message string) {
+	sm.mu.RLock()
+	ev := SubagentProgress{
+		ID:        task.ID,
+		ParentID:  task.ParentID,
+		Label:     task.Label,
+		Status:    task.Status,
+		Depth:     task.Depth,
+		Iteration: iteration,
+		Tool:      tool,
+		Message:   message,
+		UserID:    task.OriginUserID,
+	}
+	sm.mu.RUnlock()
+
+	if sm.msgBus != nil {
+		bus.BroadcastForTenant(sm.msgBus, protocol.EventSubagentProgress, task.OriginTenantID, ev)
+	}
+	ReportProgress(ctx, fmt.Sprintf("[%s] %s", task.Label, message))
+}
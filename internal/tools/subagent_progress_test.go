@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScheduleTask_UsesLaneRunner(t *testing.T) {
+	sm := NewSubagentManager(nil, nil, "", nil, nil, SubagentConfig{})
+	var submitted int
+	sm.SetLaneRunner(func(_ context.Context, fn func()) error {
+		submitted++ // fn not run: the test only checks routing
+		return nil
+	})
+
+	task := &SubagentTask{ID: "sub-1", Label: "research", Status: TaskStatusRunning}
+	sm.scheduleTask(context.Background(), task, nil)
+
+	if submitted != 1 {
+		t.Errorf("lane runner called %d times, want 1", submitted)
+	}
+	if task.Status != TaskStatusRunning {
+		t.Errorf("status = %q, want unchanged %q", task.Status, TaskStatusRunning)
+	}
+}
+
+func TestScheduleTask_LaneRejectionCancelsTask(t *testing.T) {
+	sm := NewSubagentManager(nil, nil, "", nil, nil, SubagentConfig{})
+	sm.SetLaneRunner(func(context.Context, func()) error {
+		return errors.New("lane subagent is stopped")
+	})
+
+	ctx, got := collectProgress()
+	task := &SubagentTask{ID: "sub-1", Label: "research", Status: TaskStatusRunning}
+	sm.scheduleTask(ctx, task, nil)
+
+	if task.Status != TaskStatusCancelled || task.CompletedAt == 0 {
+		t.Errorf("task = %+v, want cancelled with completion time", task)
+	}
+	if len(*got) != 1 || !strings.Contains((*got)[0], "[research] not started: lane subagent is stopped") {
+		t.Errorf("progress = %q", *got)
+	}
+}
+
+func TestFinishedProgressMessage(t *testing.T) {
+	cases := []struct {
+		task *SubagentTask
+		want string
+	}{
+		{&SubagentTask{Status: TaskStatusCompleted}, "completed in 3 iterations"},
+		{&SubagentTask{Status: TaskStatusFailed, Result: "boom"}, "failed: boom"},
+		{&SubagentTask{Status: TaskStatusCancelled, Result: "cancelled by user"}, "cancelled: cancelled by user"},
+	}
+	for _, c := range cases {
+		if got := finishedProgressMessage(c.task, 3); got != c.want {
+			t.Errorf("finishedProgressMessage(%s) = %q, want %q", c.task.Status, got, c.want)
+		}
+	}
+}
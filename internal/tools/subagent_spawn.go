@@ -96,7 +96,9 @@ func (sm *SubagentManager) Spawn(
 	// Detach from parent's cancellation chain so subagent survives after parent run completes.
 	// WithoutCancel preserves all context values (agent ID, workspace, trace info, etc.)
 	// but parent Done() no longer propagates. Manual cancel via taskCancel() still works.
-	detached := context.WithoutCancel(ctx)
+	// The spawn tool call's progress reporter is dropped: that call returns immediately,
+	// so progress is only broadcast as subagent.progress events.
+	detached := WithProgressReporter(context.WithoutCancel(ctx), nil)
 	taskCtx, taskCancel := context.WithCancel(detached)
 	subTask.cancelFunc = taskCancel
 
@@ -115,13 +117,38 @@ func (sm *SubagentManager) Spawn(
 		go sm.persistCreate(taskCtx, subTask)
 	}
 
-	go sm.runTask(taskCtx, subTask, callback)
+	go sm.scheduleTask(taskCtx, subTask, callback)
 
 	return fmt.Sprintf("Spawned subagent '%s' (id=%s, depth=%d) for task: %s",
 		label, id, subTask.Depth, truncate(task, 100)), nil
 }
 
+// scheduleTask runs the task on the subagent lane when one is set, waiting
+// for a free slot. A task that never gets a slot (cancelled while queued,
+// gateway shutting down) is marked cancelled.
+func (sm *SubagentManager) scheduleTask(ctx context.Context, task *SubagentTask, callback AsyncCallback) {
+	if sm.laneRunner == nil {
+		sm.runTask(ctx, task, callback)
+		return
+	}
+	err := sm.laneRunner(ctx, func() { sm.runTask(ctx, task, callback) })
+	if err == nil {
+		return
+	}
+	sm.mu.Lock()
+	task.Status = TaskStatusCancelled
+	task.Result = fmt.Sprintf("not started: %v", err)
+	task.CompletedAt = time.Now().UnixMilli()
+	sm.mu.Unlock()
+	slog.Info("subagent not scheduled", "id", task.ID, "error", err)
+	sm.reportProgress(ctx, task, 0, "", task.Result)
+	go sm.persistStatus(ctx, task, 0)
+}
+
 // RunSync executes a subagent task synchronously, blocking until completion.
+// It runs inline rather than on the subagent lane: the parent already holds a
+// lane slot while it waits, and nested sync spawns would otherwise be able to
+// exhaust the lane and deadlock.
 func (sm *SubagentManager) RunSync(
 	ctx context.Context,
 	parentID string,
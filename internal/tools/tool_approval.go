@@ -0,0 +1,141 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+// ToolApprovalTimeout is how long a tool call waits for the owner's decision.
+const ToolApprovalTimeout = 2 * time.Minute
+
+// NeedsApproval reports whether a call to tool must be confirmed by the owner
+// before it runs. Rules in ExecApprovalConfig.Tools are tool names, or
+// "tool:action" to gate a single action of multi-action tools (browser).
+// exec is gated separately by CheckCommand.
+func (m *ExecApprovalManager) NeedsApproval(tool string, args map[string]any) bool {
+	if tool == "exec" || len(m.config.Tools) == 0 {
+		return false
+	}
+	action, _ := args["action"].(string)
+
+	m.mu.Lock()
+	always := m.alwaysAllow[toolAllowKey(tool)]
+	m.mu.Unlock()
+	if always {
+		return false
+	}
+
+	for _, rule := range m.config.Tools {
+		name, act, hasAction := strings.Cut(rule, ":")
+		if name != tool {
+			continue
+		}
+		if !hasAction || act == action {
+			return true
+		}
+	}
+	return false
+}
+
+// toolAllowKey is the always-allow key for non-exec tools. The prefix keeps
+// tool names apart from exec binaries of the same name.
+func toolAllowKey(tool string) string {
+	return "tool:" + tool
+}
+
+// summarizeToolArgs renders tool arguments as a short "key=value" line for
+// approval prompts. Long strings (file contents, scripts) are truncated.
+func summarizeToolArgs(args map[string]any) string {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		var v string
+		switch val := args[k].(type) {
+		case string:
+			v = truncateCmd(strings.ReplaceAll(val, "\n", " "), 80)
+		default:
+			b, _ := json.Marshal(val)
+			v = truncateCmd(string(b), 80)
+		}
+		parts = append(parts, k+"="+v)
+	}
+	return truncateCmd(strings.Join(parts, " "), 300)
+}
+
+// toolApprovalDeniedResult is returned to the LLM when the owner rejects a
+// tool call or does not answer in time.
+func toolApprovalDeniedResult(tool string, err error) *Result {
+	if err != nil {
+		return ErrorResult(fmt.Sprintf("tool approval for %s: %v", tool, err))
+	}
+	return ErrorResult(fmt.Sprintf("%s call denied by user", tool))
+}
+
+// publishRequested broadcasts exec.approval.requested so WS clients can show the prompt.
+func (m *ExecApprovalManager) publishRequested(pa *PendingApproval) {
+	if m.eventPub == nil {
+		return
+	}
+	bus.BroadcastForTenant(m.eventPub, protocol.EventExecApprovalReq, pa.TenantID, pa)
+}
+
+// recordDecision broadcasts exec.approval.resolved and writes an audit record.
+// Timeouts and cancellations are recorded as system denials.
+func (m *ExecApprovalManager) recordDecision(pa *PendingApproval, res approvalResult, waitErr error) {
+	if m.eventPub == nil {
+		return
+	}
+
+	actorType, actorID := "user", res.actor
+	action := "tool.approved"
+	if res.decision == ApprovalDeny {
+		action = "tool.denied"
+	}
+	reason := ""
+	if waitErr != nil {
+		actorType, actorID, action = "system", "", "tool.approval_expired"
+		reason = waitErr.Error()
+	}
+
+	bus.BroadcastForTenant(m.eventPub, protocol.EventExecApprovalRes, pa.TenantID, map[string]any{
+		"id":         pa.ID,
+		"tool":       pa.Tool,
+		"decision":   string(res.decision),
+		"resolvedBy": actorID,
+		"reason":     reason,
+		"userId":     pa.UserID,
+	})
+
+	details, _ := json.Marshal(map[string]any{
+		"tool":     pa.Tool,
+		"command":  truncateCmd(pa.Command, 500),
+		"agent_id": pa.AgentID,
+		"user_id":  pa.UserID,
+		"channel":  pa.Channel,
+		"decision": string(res.decision),
+		"reason":   reason,
+	})
+	m.eventPub.Broadcast(bus.Event{
+		Name: protocol.EventAuditLog,
+		Payload: bus.AuditEventPayload{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Action:     action,
+			EntityType: "tool_approval",
+			EntityID:   pa.ID,
+			Details:    details,
+			TenantID:   pa.TenantID,
+		},
+	})
+}
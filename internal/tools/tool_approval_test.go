@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []bus.Event
+}
+
+func (p *recordingPublisher) Subscribe(string, bus.EventHandler) {}
+func (p *recordingPublisher) Unsubscribe(string)                 {}
+func (p *recordingPublisher) Broadcast(e bus.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+}
+
+func (p *recordingPublisher) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var names []string
+	for _, e := range p.events {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
+type fakeWriteTool struct{ calls int }
+
+func (t *fakeWriteTool) Name() string               { return "write_file" }
+func (t *fakeWriteTool) Description() string        { return "" }
+func (t *fakeWriteTool) Parameters() map[string]any { return nil }
+func (t *fakeWriteTool) Execute(context.Context, map[string]any) *Result {
+	t.calls++
+	return NewResult("written")
+}
+
+func TestNeedsApproval_Rules(t *testing.T) {
+	m := NewExecApprovalManager(ExecApprovalConfig{Tools: []string{"write_file", "browser:act", "exec"}})
+	cases := []struct {
+		tool string
+		args map[string]any
+		want bool
+	}{
+		{"write_file", map[string]any{"path": "a.txt"}, true},
+		{"read_file", nil, false},
+		{"browser", map[string]any{"action": "act"}, true},
+		{"browser", map[string]any{"action": "snapshot"}, false},
+		{"exec", map[string]any{"command": "rm -rf /"}, false}, // gated by CheckCommand
+	}
+	for _, c := range cases {
+		if got := m.NeedsApproval(c.tool, c.args); got != c.want {
+			t.Errorf("NeedsApproval(%s, %v) = %v, want %v", c.tool, c.args, got, c.want)
+		}
+	}
+}
+
+func TestRegistryApprovalGate(t *testing.T) {
+	m := NewExecApprovalManager(ExecApprovalConfig{Tools: []string{"write_file"}})
+	pub := &recordingPublisher{}
+	m.SetEventPublisher(pub)
+
+	prompted := make(chan *PendingApproval, 1)
+	m.SetNotifier(func(_ context.Context, pa *PendingApproval) { prompted <- pa })
+
+	tool := &fakeWriteTool{}
+	reg := NewRegistry()
+	reg.Register(tool)
+	reg.SetApprovalGate(m)
+
+	ctx := store.WithUserID(context.Background(), "u1")
+	ctx = store.WithSenderID(ctx, "42")
+	args := map[string]any{"path": "notes.txt", "content": "hello"}
+
+	// Deny: the tool must not run.
+	done := make(chan *Result, 1)
+	go func() { done <- reg.ExecuteWithContext(ctx, "write_file", args, "telegram", "100", "direct", "", nil) }()
+	pa := <-prompted
+	if pa.Tool != "write_file" || pa.Channel != "telegram" || pa.ChatID != "100" || pa.SenderID != "42" {
+		t.Fatalf("pending approval = %+v", pa)
+	}
+	if !strings.Contains(pa.Command, "path=notes.txt") {
+		t.Errorf("summary = %q", pa.Command)
+	}
+	if err := m.ResolveBy(pa.ID, ApprovalDeny, "42"); err != nil {
+		t.Fatal(err)
+	}
+	if res := <-done; !res.IsError || tool.calls != 0 {
+		t.Fatalf("denied call: result %+v, calls %d", res, tool.calls)
+	}
+
+	// Allow-always: runs now and skips the prompt afterwards.
+	go func() { done <- reg.ExecuteWithContext(ctx, "write_file", args, "telegram", "100", "direct", "", nil) }()
+	pa = <-prompted
+	if err := m.ResolveBy(pa.ID, ApprovalAllowAlways, "42"); err != nil {
+		t.Fatal(err)
+	}
+	if res := <-done; res.IsError || tool.calls != 1 {
+		t.Fatalf("allowed call: result %+v, calls %d", res, tool.calls)
+	}
+	if res := reg.ExecuteWithContext(ctx, "write_file", args, "", "", "", "", nil); res.IsError || tool.calls != 2 {
+		t.Fatalf("always-allowed call: result %+v, calls %d", res, tool.calls)
+	}
+
+	want := []string{
+		protocol.EventExecApprovalReq, protocol.EventExecApprovalRes, protocol.EventAuditLog,
+		protocol.EventExecApprovalReq, protocol.EventExecApprovalRes, protocol.EventAuditLog,
+	}
+	if got := pub.names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
+
+func TestRequestToolApproval_TimeoutDenies(t *testing.T) {
+	m := NewExecApprovalManager(ExecApprovalConfig{Tools: []string{"edit"}})
+	pub := &recordingPublisher{}
+	m.SetEventPublisher(pub)
+
+	decision, err := m.RequestToolApproval(context.Background(), "edit", "path=a.go", "agent", 10*time.Millisecond)
+	if decision != ApprovalDeny || err == nil {
+		t.Fatalf("decision = %s, err = %v; want deny with timeout error", decision, err)
+	}
+	if len(m.ListPending()) != 0 {
+		t.Error("expired approval still pending")
+	}
+
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+	audit, ok := pub.events[len(pub.events)-1].Payload.(bus.AuditEventPayload)
+	if !ok || audit.Action != "tool.approval_expired" || audit.ActorType != "system" {
+		t.Errorf("audit record = %+v", pub.events[len(pub.events)-1].Payload)
+	}
+}
+
+func TestResolveBy_RejectsUnknownDecision(t *testing.T) {
+	m := NewExecApprovalManager(ExecApprovalConfig{})
+	if err := m.ResolveBy("exec-1", "maybe", ""); err == nil {
+		t.Error("expected error for invalid decision")
+	}
+	if err := m.ResolveBy("exec-1", ApprovalDeny, ""); err == nil {
+		t.Error("expected error for unknown approval")
+	}
+}
@@ -0,0 +1,275 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/tts"
+)
+
+// ttsSpeakChunkRunes caps the text synthesized per voice note. Long replies
+// are split at sentence boundaries so the first note reaches the user while
+// the rest is still being generated.
+const ttsSpeakChunkRunes = 600
+
+// TtsSpeakTool lets the agent explicitly reply with audio. Unlike tts (which
+// returns a file for the normal outbound path), tts_speak delivers each chunk
+// to the current chat as soon as it is synthesized — as a voice note on
+// Telegram — using streaming synthesis when the provider supports it.
+// Voice/model/provider resolution is shared with TtsTool (args > agent > tenant).
+type TtsSpeakTool struct {
+	tts    *TtsTool
+	msgBus *bus.MessageBus
+}
+
+// NewTtsSpeakTool creates a tts_speak tool sharing the given TtsTool's manager,
+// so config reloads via TtsTool.UpdateManager apply to both.
+func NewTtsSpeakTool(ttsTool *TtsTool) *TtsSpeakTool {
+	return &TtsSpeakTool{tts: ttsTool}
+}
+
+func (t *TtsSpeakTool) SetMessageBus(b *bus.MessageBus) { t.msgBus = b }
+
+func (t *TtsSpeakTool) Name() string { return "tts_speak" }
+
+func (t *TtsSpeakTool) Description() string {
+	return "Reply to the current chat with spoken audio (voice notes on Telegram). Long text is sent in several notes as they are generated. Use when the user asks for a voice reply; do not repeat the spoken text afterwards."
+}
+
+func (t *TtsSpeakTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"text": map[string]any{
+				"type":        "string",
+				"description": "The text to speak",
+			},
+			"voice": map[string]any{
+				"type":        "string",
+				"description": "Voice ID (provider-specific; ElevenLabs cloned voice IDs work too). Optional — uses the agent/tenant default if omitted.",
+			},
+			"model": map[string]any{
+				"type":        "string",
+				"description": "Model ID (provider-specific). Optional.",
+			},
+			"provider": map[string]any{
+				"type":        "string",
+				"description": "TTS provider: openai, elevenlabs, edge, minimax, gemini. Optional — uses primary if omitted.",
+			},
+		},
+		"required": []string{"text"},
+	}
+}
+
+func (t *TtsSpeakTool) Execute(ctx context.Context, args map[string]any) *Result {
+	text := strings.TrimSpace(argString(args, "text"))
+	if text == "" {
+		return ErrorResult("text is required")
+	}
+	channel := ToolChannelFromCtx(ctx)
+	chatID := ToolChatIDFromCtx(ctx)
+	if channel == "" || chatID == "" || t.msgBus == nil {
+		// No live chat to stream into (cron, API, tests): behave like tts.
+		return t.tts.Execute(ctx, args)
+	}
+
+	providerName := argString(args, "provider")
+	voice, model := t.tts.resolveVoiceAndModel(ctx, argString(args, "voice"), argString(args, "model"))
+	genericAgentParams := t.tts.resolveAgentGenericTTSParams(ctx)
+
+	t.tts.mu.RLock()
+	mgr := t.tts.manager
+	t.tts.mu.RUnlock()
+
+	if providerName == "" {
+		providerName = t.tts.resolvePrimary(ctx, mgr)
+	}
+	if _, ok := mgr.GetProvider(providerName); !ok {
+		return ErrorResult(fmt.Sprintf("tts provider not found: %s", providerName))
+	}
+
+	telegram := ToolChannelTypeFromCtx(ctx) == "telegram" || channel == "telegram"
+	opts := tts.Options{Voice: voice, Model: model}
+	if telegram {
+		opts.Format = "opus"
+	}
+	if adapted := audio.AdaptAgentParams(genericAgentParams, providerName); len(adapted) > 0 {
+		opts.Params = mergeParams(opts.Params, adapted)
+	}
+
+	ttsDir := os.TempDir()
+	if ws := ToolWorkspaceFromCtx(ctx); ws != "" {
+		ttsDir = filepath.Join(ws, "tts")
+	}
+	if err := os.MkdirAll(ttsDir, 0755); err != nil {
+		return ErrorResult(fmt.Sprintf("create tts directory: %s", err.Error()))
+	}
+
+	chunks := splitSpeechChunks(text, ttsSpeakChunkRunes)
+	stamp := time.Now().UnixNano()
+	sent := 0
+	for i, chunk := range chunks {
+		path, mimeType, err := t.synthesizeChunk(ctx, mgr, providerName, chunk, opts, genericAgentParams,
+			filepath.Join(ttsDir, fmt.Sprintf("speak-%d-%d", stamp, i+1)))
+		if err != nil {
+			if sent == 0 {
+				return ErrorResult(fmt.Sprintf("tts failed: %s", err.Error()))
+			}
+			return ErrorResult(fmt.Sprintf("tts failed after %d of %d voice notes were sent: %s", sent, len(chunks), err.Error()))
+		}
+
+		meta := map[string]string{}
+		if telegram && strings.HasPrefix(mimeType, "audio/ogg") {
+			meta["audio_as_voice"] = "true"
+		}
+		if isGroupContext(ctx) {
+			meta["group_id"] = chatID
+		}
+		t.msgBus.PublishOutbound(bus.OutboundMessage{
+			Channel:  channel,
+			ChatID:   chatID,
+			Media:    []bus.MediaAttachment{{URL: path, ContentType: mimeType}},
+			Metadata: meta,
+		})
+		if dm := DeliveredMediaFromCtx(ctx); dm != nil {
+			dm.Mark(path)
+		}
+		sent++
+	}
+
+	out, _ := json.Marshal(map[string]any{
+		"status":      "sent",
+		"voice_notes": sent,
+		"provider":    providerName,
+	})
+	return SilentResult(string(out) + "\nThe user has received this as audio. Do not repeat the spoken text; reply briefly or not at all.")
+}
+
+// synthesizeChunk renders one chunk to basePath+ext. Streams to disk when the
+// provider implements audio.StreamingTTSProvider; otherwise (or on stream
+// failure) uses buffered Synthesize, then the manager fallback chain.
+func (t *TtsSpeakTool) synthesizeChunk(ctx context.Context, mgr *tts.Manager, providerName, text string,
+	opts tts.Options, genericAgentParams map[string]any, basePath string) (path, mimeType string, err error) {
+	p, _ := mgr.GetProvider(providerName)
+
+	if sp, ok := p.(audio.StreamingTTSProvider); ok {
+		path, mimeType, err = writeTTSStream(ctx, sp, text, opts, basePath)
+		if err == nil {
+			return path, mimeType, nil
+		}
+		slog.Warn("tts_speak: streaming synthesis failed, retrying buffered", "provider", providerName, "error", err)
+	}
+
+	result, err := p.Synthesize(ctx, text, opts)
+	if err != nil {
+		slog.Warn("tts_speak: provider failed, trying fallback", "provider", providerName, "error", err)
+		fallbackOpts := opts
+		fallbackOpts.Params = nil
+		result, err = mgr.SynthesizeWithFallbackAdapted(ctx, text, fallbackOpts, genericAgentParams)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	path = basePath + "." + result.Extension
+	if err := os.WriteFile(path, result.Audio, 0644); err != nil {
+		return "", "", fmt.Errorf("write audio: %w", err)
+	}
+	mimeType = result.MimeType
+	if mimeType == "" {
+		mimeType = "audio/" + result.Extension
+	}
+	return path, mimeType, nil
+}
+
+// writeTTSStream copies a streaming synthesis response to basePath+ext.
+func writeTTSStream(ctx context.Context, sp audio.StreamingTTSProvider, text string, opts tts.Options, basePath string) (string, string, error) {
+	stream, err := sp.SynthesizeStream(ctx, text, opts)
+	if err != nil {
+		return "", "", err
+	}
+	defer stream.Audio.Close()
+
+	path := basePath + "." + stream.Extension
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", "", fmt.Errorf("create audio file: %w", err)
+	}
+	n, copyErr := io.Copy(f, stream.Audio)
+	closeErr := f.Close()
+	if err := errors.Join(copyErr, closeErr); err != nil || n == 0 {
+		os.Remove(path)
+		if err == nil {
+			err = errors.New("empty audio stream")
+		}
+		return "", "", err
+	}
+	return path, stream.MimeType, nil
+}
+
+// splitSpeechChunks splits text into chunks of at most maxRunes, preferring
+// paragraph, then sentence, then word boundaries.
+func splitSpeechChunks(text string, maxRunes int) []string {
+	var chunks []string
+	var cur strings.Builder
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			chunks = append(chunks, s)
+		}
+		cur.Reset()
+	}
+	add := func(piece string) {
+		if cur.Len() > 0 && utf8.RuneCountInString(cur.String())+utf8.RuneCountInString(piece) > maxRunes {
+			flush()
+		}
+		cur.WriteString(piece)
+	}
+
+	for _, sentence := range splitSentences(text) {
+		if utf8.RuneCountInString(sentence) <= maxRunes {
+			add(sentence)
+			continue
+		}
+		// A single overlong sentence: fall back to word boundaries.
+		for _, word := range strings.SplitAfter(sentence, " ") {
+			add(word)
+		}
+	}
+	flush()
+	return chunks
+}
+
+// splitSentences cuts text after sentence terminators and newlines, keeping
+// the delimiters so re-joined chunks read naturally.
+func splitSentences(text string) []string {
+	var out []string
+	start := 0
+	runes := []rune(text)
+	for i, r := range runes {
+		end := false
+		switch r {
+		case '\n', '。', '！', '？':
+			end = true
+		case '.', '!', '?':
+			end = i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n'
+		}
+		if end {
+			out = append(out, string(runes[start:i+1]))
+			start = i + 1
+		}
+	}
+	if start < len(runes) {
+		out = append(out, string(runes[start:]))
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/audio"
+	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/tts"
+)
+
+// streamingTTSProvider records the chunks it was asked to stream.
+type streamingTTSProvider struct {
+	fakeTTSProvider
+	texts []string
+}
+
+func (s *streamingTTSProvider) SynthesizeStream(_ context.Context, text string, opts tts.Options) (*audio.StreamResult, error) {
+	s.texts = append(s.texts, text)
+	return &audio.StreamResult{Audio: io.NopCloser(strings.NewReader("ogg:" + opts.Format)), Extension: "ogg", MimeType: "audio/ogg"}, nil
+}
+
+func TestSplitSpeechChunks(t *testing.T) {
+	text := "First sentence. Second one! Third? " + strings.Repeat("word ", 30)
+	chunks := splitSpeechChunks(text, 40)
+	if len(chunks) < 3 {
+		t.Fatalf("chunks = %q", chunks)
+	}
+	if !strings.HasPrefix(chunks[0], "First sentence. Second one! Third?") {
+		t.Errorf("first chunk = %q", chunks[0])
+	}
+	for _, c := range chunks {
+		if len([]rune(c)) > 40 {
+			t.Errorf("chunk over limit: %q", c)
+		}
+	}
+	if got := strings.Join(strings.Fields(strings.Join(chunks, " ")), " "); got != strings.Join(strings.Fields(text), " ") {
+		t.Errorf("chunks lost text: %q", got)
+	}
+}
+
+func TestTtsSpeak_StreamsVoiceNotesToChat(t *testing.T) {
+	p := &streamingTTSProvider{fakeTTSProvider: fakeTTSProvider{name: "elevenlabs"}}
+	mgr := tts.NewManager(tts.ManagerConfig{Primary: "elevenlabs"})
+	mgr.RegisterProvider(p)
+
+	mb := bus.New()
+	tool := NewTtsSpeakTool(NewTtsTool(mgr))
+	tool.SetMessageBus(mb)
+
+	ctx := WithToolChannel(context.Background(), "telegram")
+	ctx = WithToolChatID(ctx, "42")
+	ctx = WithToolWorkspace(ctx, t.TempDir())
+
+	text := strings.Repeat("This is a sentence for the voice note. ", 30)
+	res := tool.Execute(ctx, map[string]any{"text": text})
+	if res.IsError || !res.Silent {
+		t.Fatalf("result = %+v", res)
+	}
+	if len(p.texts) < 2 {
+		t.Fatalf("expected chunked synthesis, got %d chunk(s)", len(p.texts))
+	}
+
+	for i := range p.texts {
+		subCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		msg, ok := mb.SubscribeOutbound(subCtx)
+		cancel()
+		if !ok {
+			t.Fatalf("voice note %d not published", i+1)
+		}
+		if msg.Channel != "telegram" || msg.ChatID != "42" || msg.Metadata["audio_as_voice"] != "true" {
+			t.Errorf("outbound = %+v", msg)
+		}
+		data, err := os.ReadFile(msg.Media[0].URL)
+		if err != nil || string(data) != "ogg:opus" {
+			t.Errorf("audio file = %q, %v", data, err)
+		}
+	}
+}
+
+func TestTtsSpeak_NoChatFallsBackToFile(t *testing.T) {
+	tool := NewTtsSpeakTool(NewTtsTool(makeTTSManager("edge")))
+	ctx := WithToolWorkspace(context.Background(), t.TempDir())
+	res := tool.Execute(ctx, map[string]any{"text": "hello"})
+	if res.IsError || len(res.Media) != 1 {
+		t.Fatalf("result = %+v", res)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -15,8 +17,15 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 )
 
+// noContentExtractedNotice replaces HTML that yields no text at all.
+const noContentExtractedNotice = "[No content extracted. The page may require JavaScript to render, " +
+	"or returned a bot-protection challenge. Try using browser automation instead.]"
+
 // Matching TS src/agents/tools/web-fetch.ts constants.
 const (
 	defaultFetchMaxChars    = 60000
@@ -33,6 +42,8 @@ type WebFetchTool struct {
 	policy         string   // "allow_all" (default), "allowlist"
 	allowedDomains []string // domains when policy="allowlist" (supports "*.example.com")
 	blockedDomains []string // always checked regardless of policy (supports "*.example.com")
+	renderer       JSRenderer // headless browser for the JavaScript rendering fallback
+	renderEnabled  bool       // tools.web_fetch.render_js
 	mu             sync.RWMutex
 }
 
@@ -60,7 +71,7 @@ func NewWebFetchTool(cfg WebFetchConfig) *WebFetchTool {
 	}
 	return &WebFetchTool{
 		maxChars:       maxChars,
-		cache:          newWebCache(defaultCacheMaxEntries, ttl),
+		cache:          newWebCache(runtimeprofile.Current().WebCacheEntries, ttl),
 		policy:         policy,
 		allowedDomains: cfg.AllowedDomains,
 		blockedDomains: cfg.BlockedDomains,
@@ -146,7 +157,7 @@ func matchDomainList(hostname string, patterns []string) bool {
 func (t *WebFetchTool) Name() string { return "web_fetch" }
 
 func (t *WebFetchTool) Description() string {
-	return "Fetch a URL and extract its content. Supports HTML (converted to markdown/text), JSON, and plain text. If content exceeds the character limit, full content is saved to a temp file — use shell or read_file to access it. Includes SSRF protection."
+	return "Fetch a URL and extract its content. Supports HTML (converted to markdown/text), JSON, plain text, and PDF/DOCX/XLSX documents (text layer extracted to markdown, up to 20MB). If content exceeds the character limit, full content is saved to a temp file — use shell or read_file to access it. Includes SSRF protection."
 }
 
 func (t *WebFetchTool) Parameters() map[string]any {
@@ -167,6 +178,10 @@ func (t *WebFetchTool) Parameters() map[string]any {
 				"description": "Maximum characters to return (truncates when exceeded). Default: 60000. Omit to use the default.",
 				"minimum":     100.0,
 			},
+			"renderJs": map[string]any{
+				"type":        "boolean",
+				"description": "Render the page in a headless browser (for JavaScript-only pages). true forces rendering, false disables it; omit to render automatically when the static page has almost no text. Only works when the operator enabled rendering.",
+			},
 		},
 		"required": []string{"url"},
 	}
@@ -190,6 +205,11 @@ func (t *WebFetchTool) Execute(ctx context.Context, args map[string]any) *Result
 		return ErrorResult("missing hostname in URL")
 	}
 
+	// Offline mode: fail before any fetch or extractor work.
+	if err := netproxy.CheckOffline(parsed); err != nil {
+		return ErrorResult(err.Error())
+	}
+
 	// SSRF protection
 	if err := CheckSSRF(rawURL); err != nil {
 		return ErrorResult(fmt.Sprintf("SSRF protection: %v", err))
@@ -230,16 +250,21 @@ func (t *WebFetchTool) Execute(ctx context.Context, args map[string]any) *Result
 		}
 	}
 
+	renderMode := renderModeArg(args)
+	if renderMode == renderAlways && t.jsRenderer() == nil {
+		return ErrorResult("JavaScript rendering is not enabled on this server (tools.web_fetch.render_js with the browser tool); retry without renderJs")
+	}
+
 	// Check cache (scoped per channel to prevent cross-channel cache poisoning)
 	channel := ToolChannelFromCtx(ctx)
-	cacheKey := fmt.Sprintf("fetch:%s:%s:%s:%d", channel, rawURL, extractMode, maxChars)
+	cacheKey := fmt.Sprintf("fetch:%s:%s:%s:%d:%s", channel, rawURL, extractMode, maxChars, renderMode)
 	if cached, ok := t.cache.get(cacheKey); ok {
 		slog.Debug("web_fetch cache hit", "url", rawURL)
 		return NewResult(cached)
 	}
 
 	// Fetch
-	result, err := t.doFetch(ctx, rawURL, extractMode, maxChars, pol)
+	result, err := t.doFetch(ctx, rawURL, extractMode, maxChars, pol, renderMode)
 	if err != nil {
 		errMsg := truncateStr(err.Error(), defaultErrorMaxChars)
 		return ErrorResult(fmt.Sprintf("fetch failed: %s", errMsg))
@@ -250,19 +275,45 @@ func (t *WebFetchTool) Execute(ctx context.Context, args map[string]any) *Result
 	return NewResult(wrapped)
 }
 
-func (t *WebFetchTool) doFetch(ctx context.Context, rawURL, extractMode string, maxChars int, pol webFetchPolicy) (string, error) {
+func (t *WebFetchTool) doFetch(ctx context.Context, rawURL, extractMode string, maxChars int, pol webFetchPolicy, renderMode string) (string, error) {
+	renderer := t.jsRenderer()
+	if renderMode == renderNever {
+		renderer = nil
+	}
+	if renderer != nil && renderMode == renderAlways {
+		return t.doRenderedFetch(ctx, renderer, rawURL, extractMode, maxChars, pol)
+	}
+
+	result, thin, err := t.doStaticFetch(ctx, rawURL, extractMode, maxChars, pol)
+	if thin && renderer != nil {
+		// Near-empty static HTML usually means a client-rendered page.
+		rendered, rerr := t.doRenderedFetch(ctx, renderer, rawURL, extractMode, maxChars, pol)
+		if rerr == nil {
+			return rendered, nil
+		}
+		slog.Debug("web_fetch: render fallback failed", "url", rawURL, "error", rerr)
+	}
+	return result, err
+}
+
+// doStaticFetch fetches over plain HTTP. thin reports HTML whose extracted
+// text is below the quality threshold (candidate for the rendering fallback).
+func (t *WebFetchTool) doStaticFetch(ctx context.Context, rawURL, extractMode string, maxChars int, pol webFetchPolicy) (string, bool, error) {
 	// For markdown mode, use the extractor chain (Defuddle → InProcess waterfall)
 	// resolved from builtin_tools settings stored in context.
 	// InProcessExtractor delegates to fetchRawContent (same path as doDirectFetch),
 	// so no fallthrough is needed — it would just retry the same request.
-	if extractMode == "markdown" {
+	// Document URLs (.pdf/.docx/.xlsx) skip the chain: external extractors
+	// only handle HTML, and the direct path reports the document extractor.
+	if extractMode == "markdown" && documentKindFromURL(rawURL) == "" {
 		chain := ResolveExtractorChain(ctx, t)
 		if chain != nil {
 			result, err := chain.Extract(ctx, rawURL)
 			if err == nil {
-				return formatFetchResult(result.Content, result.Extractor, rawURL, maxChars, ctx), nil
+				thin := result.Content == noContentExtractedNotice
+				return formatFetchResult(result.Content, result.Extractor, rawURL, maxChars, ctx), thin, nil
 			}
-			return "", fmt.Errorf("all extractors failed: %w", err)
+			return "", errors.Is(err, errLowQualityContent), fmt.Errorf("all extractors failed: %w", err)
 		}
 	}
 
@@ -276,6 +327,7 @@ type fetchRawResult struct {
 	extractor  string
 	finalURL   string
 	statusCode int
+	thin       bool // HTML with almost no extractable text
 }
 
 // fetchRawContent performs HTTP GET with full security checks (SSRF, domain policy on
@@ -287,12 +339,14 @@ func (t *WebFetchTool) fetchRawContent(ctx context.Context, rawURL, extractMode
 		return fetchRawResult{}, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("User-Agent", fetchUserAgent)
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,application/pdf;q=0.8,*/*;q=0.8")
 
 	redirectCount := 0
 	client := &http.Client{
 		Timeout: time.Duration(fetchTimeoutSeconds) * time.Second,
 		Transport: &http.Transport{
+			Proxy:               netproxy.For(netproxy.Tool("web_fetch")),
+			DialContext:         ssrfSafeDialContext(netproxy.Tool("web_fetch"), &net.Dialer{Timeout: 30 * time.Second}),
 			ForceAttemptHTTP2:   true,
 			MaxIdleConns:        10,
 			IdleConnTimeout:     30 * time.Second,
@@ -303,17 +357,7 @@ func (t *WebFetchTool) fetchRawContent(ctx context.Context, rawURL, extractMode
 			if redirectCount > defaultFetchMaxRedirect {
 				return fmt.Errorf("stopped after %d redirects", defaultFetchMaxRedirect)
 			}
-			if err := CheckSSRF(req.URL.String()); err != nil {
-				return fmt.Errorf("redirect SSRF protection: %w", err)
-			}
-			redirectHost := req.URL.Hostname()
-			if matchDomainList(redirectHost, pol.blockedDomains) {
-				return fmt.Errorf("redirect to %q blocked: domain is in blocklist", redirectHost)
-			}
-			if pol.mode == "allowlist" && !matchDomainList(redirectHost, pol.allowedDomains) {
-				return fmt.Errorf("redirect to %q blocked: domain not in allowlist", redirectHost)
-			}
-			return nil
+			return checkRedirectTarget(req.URL, pol)
 		},
 	}
 
@@ -323,19 +367,40 @@ func (t *WebFetchTool) fetchRawContent(ctx context.Context, rawURL, extractMode
 	}
 	defer resp.Body.Close()
 
+	contentType := resp.Header.Get("Content-Type")
+	finalURL := resp.Request.URL.String()
+
+	// Documents are parsed whole, so they get a larger cap than text responses.
+	docKind := documentKind(contentType, finalURL)
 	readLimit := int64(max(maxChars*10, 512*1024))
+	if docKind != "" {
+		if resp.ContentLength > maxDocumentBytes {
+			return fetchRawResult{}, fmt.Errorf("%s document is %d MB, over the %d MB extraction limit", docKind, resp.ContentLength>>20, maxDocumentBytes>>20)
+		}
+		readLimit = maxDocumentBytes + 1
+	}
 	body, err := io.ReadAll(io.LimitReader(resp.Body, readLimit))
 	if err != nil {
 		return fetchRawResult{}, fmt.Errorf("read body: %w", err)
 	}
 
-	contentType := resp.Header.Get("Content-Type")
-	finalURL := resp.Request.URL.String()
-
 	var text string
 	var extractor string
+	var thin bool
 
 	switch {
+	case docKind != "":
+		if len(body) > maxDocumentBytes {
+			return fetchRawResult{}, fmt.Errorf("%s document exceeds the %d MB extraction limit", docKind, maxDocumentBytes>>20)
+		}
+		text, extractor, err = extractDocument(ctx, docKind, body)
+		if err != nil {
+			return fetchRawResult{}, err
+		}
+		if extractMode == "text" {
+			text = markdownToText(text)
+		}
+
 	case strings.Contains(contentType, "application/json"):
 		text, extractor = extractJSON(body)
 
@@ -355,9 +420,9 @@ func (t *WebFetchTool) fetchRawContent(ctx context.Context, rawURL, extractMode
 			text = htmlToText(string(body))
 			extractor = "html-to-text"
 		}
+		thin = !isQualityContent(text)
 		if text == "" && len(body) > 0 {
-			text = "[No content extracted. The page may require JavaScript to render, " +
-				"or returned a bot-protection challenge. Try using browser automation instead.]"
+			text = noContentExtractedNotice
 		}
 
 	default:
@@ -370,15 +435,33 @@ func (t *WebFetchTool) fetchRawContent(ctx context.Context, rawURL, extractMode
 		extractor:  extractor,
 		finalURL:   finalURL,
 		statusCode: resp.StatusCode,
+		thin:       thin,
 	}, nil
 }
 
+// checkRedirectTarget applies SSRF protection and the domain policy to a URL
+// the server sent the fetch to (HTTP redirect or browser navigation).
+func checkRedirectTarget(u *url.URL, pol webFetchPolicy) error {
+	if err := CheckSSRF(u.String()); err != nil {
+		return fmt.Errorf("redirect SSRF protection: %w", err)
+	}
+	redirectHost := u.Hostname()
+	if matchDomainList(redirectHost, pol.blockedDomains) {
+		return fmt.Errorf("redirect to %q blocked: domain is in blocklist", redirectHost)
+	}
+	if pol.mode == "allowlist" && !matchDomainList(redirectHost, pol.allowedDomains) {
+		return fmt.Errorf("redirect to %q blocked: domain not in allowlist", redirectHost)
+	}
+	return nil
+}
+
 // doDirectFetch wraps fetchRawContent with full HTTP metadata formatting.
-// Used for text mode extraction and as ultimate fallback.
-func (t *WebFetchTool) doDirectFetch(ctx context.Context, rawURL, extractMode string, maxChars int, pol webFetchPolicy) (string, error) {
+// Used for text mode extraction and as ultimate fallback. The bool reports
+// near-empty HTML.
+func (t *WebFetchTool) doDirectFetch(ctx context.Context, rawURL, extractMode string, maxChars int, pol webFetchPolicy) (string, bool, error) {
 	raw, err := t.fetchRawContent(ctx, rawURL, extractMode, maxChars, pol)
 	if err != nil {
-		return "", err
+		return "", false, err
 	}
 
 	var sb strings.Builder
@@ -390,7 +473,7 @@ func (t *WebFetchTool) doDirectFetch(ctx context.Context, rawURL, extractMode st
 	sb.WriteString(fmt.Sprintf("Extractor: %s\n", raw.extractor))
 	appendContent(&sb, raw.content, maxChars, raw.finalURL, ctx)
 
-	return sb.String(), nil
+	return sb.String(), raw.thin, nil
 }
 
 // formatFetchResult builds the metadata-prefixed response for chain-extracted content.
@@ -0,0 +1,97 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Document extraction limits. Documents are read whole (a truncated zip or
+// PDF cannot be parsed), so they get their own download cap.
+const (
+	maxDocumentBytes     = 20 << 20 // download cap for PDF/DOCX/XLSX
+	maxDocumentInflated  = 64 << 20 // total decompressed bytes per document
+	maxXLSXRowsPerSheet  = 1000
+	maxXLSXColumns       = 50
+	maxPDFPages          = 500
+	maxPDFTreeNodes      = 10 * maxPDFPages // page-tree nodes visited per document
+	documentNoTextNotice = "[No text extracted. The PDF may be scanned or image-only — try read_document for OCR-capable extraction.]"
+)
+
+// Document kinds handled by extractDocument.
+const (
+	docKindPDF  = "pdf"
+	docKindDOCX = "docx"
+	docKindXLSX = "xlsx"
+)
+
+// documentKind detects a PDF/DOCX/XLSX response from its Content-Type, falling
+// back to the URL extension when the server sends a generic type.
+func documentKind(contentType, rawURL string) string {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "application/pdf"):
+		return docKindPDF
+	case strings.Contains(ct, "wordprocessingml.document"):
+		return docKindDOCX
+	case strings.Contains(ct, "spreadsheetml.sheet"):
+		return docKindXLSX
+	case ct == "", strings.Contains(ct, "application/octet-stream"),
+		strings.Contains(ct, "binary/octet-stream"), strings.Contains(ct, "application/zip"),
+		strings.Contains(ct, "application/x-download"):
+		return documentKindFromURL(rawURL)
+	}
+	return ""
+}
+
+// documentKindFromURL returns the document kind implied by the URL path extension.
+func documentKindFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".pdf":
+		return docKindPDF
+	case ".docx":
+		return docKindDOCX
+	case ".xlsx":
+		return docKindXLSX
+	}
+	return ""
+}
+
+// extractDocument converts a downloaded document to markdown and returns the
+// text plus the extractor name reported in the web_fetch header.
+func extractDocument(ctx context.Context, kind string, body []byte) (string, string, error) {
+	switch kind {
+	case docKindPDF:
+		if !bytes.HasPrefix(bytes.TrimLeft(body, "\x00\t\r\n "), []byte("%PDF-")) {
+			return "", "", fmt.Errorf("response is not a PDF file")
+		}
+		text, err := extractPDFText(ctx, body)
+		if err != nil {
+			return "", "", fmt.Errorf("pdf: %w", err)
+		}
+		if strings.TrimSpace(text) == "" {
+			text = documentNoTextNotice
+		}
+		return text, "pdf-text", nil
+	case docKindDOCX:
+		text, err := extractDOCX(body)
+		if err != nil {
+			return "", "", fmt.Errorf("docx: %w", err)
+		}
+		return text, "docx-to-markdown", nil
+	case docKindXLSX:
+		text, err := extractXLSX(body)
+		if err != nil {
+			return "", "", fmt.Errorf("xlsx: %w", err)
+		}
+		return text, "xlsx-to-markdown", nil
+	}
+	return "", "", fmt.Errorf("unsupported document type %q", kind)
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// buildTestPDF assembles a two-page PDF: page 1 uses a simple font with an
+// uncompressed content stream, page 2 a Type0 font whose glyph codes are only
+// readable through its (Flate-compressed) ToUnicode CMap.
+func buildTestPDF(t *testing.T) []byte {
+	t.Helper()
+	deflate := func(s string) string {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write([]byte(s))
+		zw.Close()
+		return buf.String()
+	}
+	page1 := "BT /F1 12 Tf 72 720 Td (Quarterly report) Tj 0 -14 Td [(Revenue) -300 (grew)] TJ ET"
+	// Glyph IDs 0001 0002 0003 → "Hé!" via the CMap.
+	page2 := "BT /F2 12 Tf 1 0 0 1 72 700 Tm <000100020003> Tj ET"
+	cmap := "/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"1 beginbfchar <0003> <0021> endbfchar\n" +
+		"1 beginbfrange <0001> <0002> [<0048> <00E9>] endbfrange\n" +
+		"endcmap CMapName currentdict /CMap defineresource pop end end"
+
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents [8 0 R] >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /Foo /Encoding /Identity-H /ToUnicode 9 0 R >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(page1), page1),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(deflate(page2)), deflate(page2)),
+		fmt.Sprintf("<< /Length %d /Filter [/FlateDecode] >>\nstream\n%s\nendstream", len(deflate(cmap)), deflate(cmap)),
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for i, o := range objs {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func buildTestZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractPDFText(t *testing.T) {
+	text, extractor, err := extractDocument(context.Background(), docKindPDF, buildTestPDF(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extractor != "pdf-text" {
+		t.Errorf("extractor = %q", extractor)
+	}
+	for _, want := range []string{"## Page 1", "Quarterly report\nRevenue grew", "## Page 2", "Hé!"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestExtractPDFText_CyclicPageTree(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td (Only page) Tj ET"
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		// Kids lists the node itself twice: without cycle detection the
+		// walk doubles at every level down to the depth limit.
+		"<< /Type /Pages /Kids [2 0 R 2 0 R 3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for i, o := range objs {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	text, err := extractPDFText(context.Background(), buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "Only page" {
+		t.Errorf("text = %q", text)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := extractPDFText(ctx, buildTestPDF(t)); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
+func TestExtractPDFText_RejectsNonPDF(t *testing.T) {
+	if _, _, err := extractDocument(context.Background(), docKindPDF, []byte("<html>not a pdf</html>")); err == nil {
+		t.Error("expected error for non-PDF body")
+	}
+}
+
+func TestExtractDOCX(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Install guide</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Run the </w:t></w:r><w:r><w:t>installer.</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="0"/></w:numPr></w:pPr><w:r><w:t>First step</w:t></w:r></w:p>
+<w:tbl>
+<w:tr><w:tc><w:p><w:r><w:t>OS</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>Command</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>Linux</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>a|b</w:t></w:r></w:p></w:tc></w:tr>
+</w:tbl>
+</w:body></w:document>`
+	text, extractor, err := extractDocument(context.Background(), docKindDOCX, buildTestZip(t, map[string]string{"word/document.xml": doc}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extractor != "docx-to-markdown" {
+		t.Errorf("extractor = %q", extractor)
+	}
+	for _, want := range []string{"# Install guide", "Run the installer.", "- First step", "| OS | Command |", "| Linux | a\\|b |"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestExtractXLSX(t *testing.T) {
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Sales" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Region</t></si><si><t>Total</t></si><si><r><t>No</t></r><r><t>rth</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>
+<row r="2"><c r="A2" t="s"><v>2</v></c><c r="C2"><v>42.5</v></c></row>
+</sheetData></worksheet>`,
+	}
+	text, extractor, err := extractDocument(context.Background(), docKindXLSX, buildTestZip(t, files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extractor != "xlsx-to-markdown" {
+		t.Errorf("extractor = %q", extractor)
+	}
+	for _, want := range []string{"## Sales", "| Region | Total |  |", "| North |  | 42.5 |"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestDocumentKind(t *testing.T) {
+	cases := []struct {
+		ct, url, want string
+	}{
+		{"application/pdf", "https://x.test/file", docKindPDF},
+		{"application/octet-stream", "https://x.test/a/report.PDF?dl=1", docKindPDF},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "https://x.test/d", docKindXLSX},
+		{"", "https://x.test/doc.docx", docKindDOCX},
+		{"text/html", "https://x.test/page.pdf", ""},
+	}
+	for _, c := range cases {
+		if got := documentKind(c.ct, c.url); got != c.want {
+			t.Errorf("documentKind(%q, %q) = %q, want %q", c.ct, c.url, got, c.want)
+		}
+	}
+}
+
+func TestWebFetch_PDFResponse(t *testing.T) {
+	pdf := buildTestPDF(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write(pdf)
+	}))
+	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+
+	tool := NewWebFetchTool(WebFetchConfig{})
+	raw, err := tool.fetchRawContent(context.Background(), server.URL+"/download?id=1", "markdown", defaultFetchMaxChars, webFetchPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.extractor != "pdf-text" || !strings.Contains(raw.content, "Quarterly report") {
+		t.Errorf("extractor = %q, content = %q", raw.extractor, raw.content)
+	}
+
+	// The in-process chain extractor reports the document path too.
+	content, name, err := (&InProcessExtractor{tool: tool}).extractNamed(context.Background(), server.URL)
+	if err != nil || name != "pdf-text" || content == "" {
+		t.Errorf("extractNamed = %q, %q, %v", content, name, err)
+	}
+}
+
+func TestWebFetch_DocumentSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", fmt.Sprint(maxDocumentBytes+1))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+
+	tool := NewWebFetchTool(WebFetchConfig{})
+	_, err := tool.fetchRawContent(context.Background(), server.URL, "markdown", defaultFetchMaxChars, webFetchPolicy{})
+	if err == nil || !strings.Contains(err.Error(), "extraction limit") {
+		t.Errorf("err = %v, want size limit error", err)
+	}
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -15,6 +16,12 @@ type ContentExtractor interface {
 	Name() string
 }
 
+// namedExtractor is implemented by extractors whose reported name depends on
+// the fetched content (the in-process extractor also handles PDF/Office).
+type namedExtractor interface {
+	extractNamed(ctx context.Context, rawURL string) (content, name string, err error)
+}
+
 // ExtractResult holds the output from a successful extraction.
 type ExtractResult struct {
 	Content   string
@@ -52,7 +59,14 @@ func (c *ExtractorChain) Extract(ctx context.Context, rawURL string) (ExtractRes
 				callCtx, cancel = context.WithTimeout(ctx, timeout)
 			}
 
-			content, err := ext.Extract(callCtx, rawURL)
+			name := ext.Name()
+			var content string
+			var err error
+			if ne, ok := ext.(namedExtractor); ok {
+				content, name, err = ne.extractNamed(callCtx, rawURL)
+			} else {
+				content, err = ext.Extract(callCtx, rawURL)
+			}
 			if cancel != nil {
 				cancel()
 			}
@@ -73,10 +87,10 @@ func (c *ExtractorChain) Extract(ctx context.Context, rawURL string) (ExtractRes
 			}
 			if !isQualityContent(content) {
 				slog.Debug("extractor returned low quality content", "extractor", ext.Name(), "url", rawURL, "chars", len(content))
-				lastErr = fmt.Errorf("%s: content below quality threshold (%d chars)", ext.Name(), len(content))
+				lastErr = fmt.Errorf("%s: %w (%d chars)", ext.Name(), errLowQualityContent, len(content))
 				break // low quality is not transient — don't retry, cascade to next
 			}
-			return ExtractResult{Content: content, Extractor: ext.Name()}, nil
+			return ExtractResult{Content: content, Extractor: name}, nil
 		}
 
 		slog.Debug("extractor_chain: extractor exhausted, moving to next",
@@ -88,6 +102,9 @@ func (c *ExtractorChain) Extract(ctx context.Context, rawURL string) (ExtractRes
 	return ExtractResult{}, fmt.Errorf("no extractors configured")
 }
 
+// errLowQualityContent marks an extraction rejected by isQualityContent.
+var errLowQualityContent = errors.New("content below quality threshold")
+
 // isQualityContent checks if extracted content meets minimum quality thresholds.
 // Returns false for empty, very short (<100 chars), or low word count (<10 words) content.
 func isQualityContent(content string) bool {
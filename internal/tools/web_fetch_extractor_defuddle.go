@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 const (
@@ -46,6 +48,7 @@ func newDefuddleExtractor(baseURL string, timeout time.Duration) *DefuddleExtrac
 		client: &http.Client{
 			Timeout: timeout,
 			Transport: &http.Transport{
+				Proxy:               netproxy.For(netproxy.Tool("web_fetch")),
 				ForceAttemptHTTP2:   true,
 				MaxIdleConns:        5,
 				IdleConnTimeout:     30 * time.Second,
@@ -16,10 +16,16 @@ func (e *InProcessExtractor) Name() string { return "html-to-markdown" }
 // Extract fetches the URL via the tool's fetchRawContent (full security checks)
 // and returns the raw extracted markdown content.
 func (e *InProcessExtractor) Extract(ctx context.Context, rawURL string) (string, error) {
+	content, _, err := e.extractNamed(ctx, rawURL)
+	return content, err
+}
+
+// extractNamed also reports the content path used (html-to-markdown, pdf-text, ...).
+func (e *InProcessExtractor) extractNamed(ctx context.Context, rawURL string) (string, string, error) {
 	pol := e.tool.resolvePolicy(ctx)
 	raw, err := e.tool.fetchRawContent(ctx, rawURL, "markdown", defaultFetchMaxChars, pol)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return raw.content, nil
+	return raw.content, raw.extractor, nil
 }
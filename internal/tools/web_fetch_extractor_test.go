@@ -297,6 +297,7 @@ func TestInProcessExtractor_HTML(t *testing.T) {
 		w.Write([]byte(`<html><body><h1>Hello World</h1><p>This is a test paragraph with enough content to be meaningful and pass quality checks.</p></body></html>`))
 	}))
 	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil) // the dialer blocks loopback by default
 
 	tool := NewWebFetchTool(WebFetchConfig{})
 	ext := &InProcessExtractor{tool: tool}
@@ -318,6 +319,7 @@ func TestInProcessExtractor_JSON(t *testing.T) {
 		w.Write([]byte(`{"key":"value","nested":{"a":1}}`))
 	}))
 	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil) // the dialer blocks loopback by default
 
 	tool := NewWebFetchTool(WebFetchConfig{})
 	ext := &InProcessExtractor{tool: tool}
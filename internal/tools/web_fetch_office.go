@@ -0,0 +1,403 @@
+package tools
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ooxmlPackage reads parts from an Office Open XML zip with a shared
+// decompression budget (zip-bomb guard).
+type ooxmlPackage struct {
+	zr     *zip.Reader
+	budget int64
+}
+
+func openOOXML(body []byte) (*ooxmlPackage, error) {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid Office document: %w", err)
+	}
+	return &ooxmlPackage{zr: zr, budget: maxDocumentInflated}, nil
+}
+
+// read returns the named part, or nil when it does not exist.
+func (p *ooxmlPackage) read(name string) ([]byte, error) {
+	for _, f := range p.zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(io.LimitReader(rc, p.budget+1))
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > p.budget {
+			return nil, fmt.Errorf("document expands beyond %d MB", maxDocumentInflated>>20)
+		}
+		p.budget -= int64(len(data))
+		return data, nil
+	}
+	return nil, nil
+}
+
+var docxHeadingStyle = regexp.MustCompile(`(?i)^heading\s*([1-6])$`)
+
+// extractDOCX converts word/document.xml to markdown: paragraphs, headings
+// (Heading1-6 / Title styles), list items and tables.
+func extractDOCX(body []byte) (string, error) {
+	pkg, err := openOOXML(body)
+	if err != nil {
+		return "", err
+	}
+	doc, err := pkg.read("word/document.xml")
+	if err != nil {
+		return "", err
+	}
+	if doc == nil {
+		return "", fmt.Errorf("word/document.xml not found")
+	}
+
+	var (
+		out       strings.Builder
+		para      strings.Builder
+		prefix    string
+		tableRows [][]string
+		row       []string
+		cell      []string
+		depth     int // table nesting depth
+	)
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("parse document.xml: %w", err)
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			switch el.Name.Local {
+			case "p":
+				para.Reset()
+				prefix = ""
+			case "pStyle":
+				style := xmlAttr(el, "val")
+				if m := docxHeadingStyle.FindStringSubmatch(style); m != nil {
+					n, _ := strconv.Atoi(m[1])
+					prefix = strings.Repeat("#", n) + " "
+				} else if strings.EqualFold(style, "Title") {
+					prefix = "# "
+				}
+			case "numPr":
+				if prefix == "" {
+					prefix = "- "
+				}
+			case "t":
+				var s string
+				if err := dec.DecodeElement(&s, &el); err == nil {
+					para.WriteString(s)
+				}
+			case "tab":
+				para.WriteByte('\t')
+			case "br", "cr":
+				para.WriteByte('\n')
+			case "tbl":
+				depth++
+				if depth == 1 {
+					tableRows = nil
+				}
+			case "tr":
+				if depth == 1 {
+					row = nil
+				}
+			case "tc":
+				if depth == 1 {
+					cell = nil
+				}
+			}
+		case xml.EndElement:
+			switch el.Name.Local {
+			case "p":
+				text := strings.TrimSpace(para.String())
+				if depth > 0 {
+					if text != "" {
+						cell = append(cell, text)
+					}
+					continue
+				}
+				if text == "" {
+					continue
+				}
+				out.WriteString(prefix + text + "\n\n")
+			case "tc":
+				if depth == 1 {
+					row = append(row, strings.Join(cell, " "))
+				}
+			case "tr":
+				if depth == 1 {
+					tableRows = append(tableRows, row)
+				}
+			case "tbl":
+				depth--
+				if depth == 0 {
+					out.WriteString(markdownTable(tableRows))
+					out.WriteString("\n")
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// extractXLSX renders each worksheet as a markdown table under a "## <sheet>"
+// heading. Rows and columns beyond the caps are dropped with a note.
+func extractXLSX(body []byte) (string, error) {
+	pkg, err := openOOXML(body)
+	if err != nil {
+		return "", err
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := readOOXMLPart(pkg, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	var rels struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := readOOXMLPart(pkg, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+	targets := make(map[string]string, len(rels.Rels))
+	for _, r := range rels.Rels {
+		t := strings.TrimPrefix(r.Target, "/")
+		if !strings.HasPrefix(t, "xl/") {
+			t = path.Join("xl", t)
+		}
+		targets[r.ID] = t
+	}
+
+	var shared struct {
+		Items []struct {
+			Text string `xml:",innerxml"`
+		} `xml:"si"`
+	}
+	if err := readOOXMLPart(pkg, "xl/sharedStrings.xml", &shared); err != nil {
+		return "", err
+	}
+	strs := make([]string, len(shared.Items))
+	for i, si := range shared.Items {
+		strs[i] = ooxmlInnerText(si.Text)
+	}
+
+	var out strings.Builder
+	for _, sh := range workbook.Sheets {
+		target, ok := targets[sh.RID]
+		if !ok {
+			continue
+		}
+		rows, truncated, err := readXLSXSheet(pkg, target, strs)
+		if err != nil {
+			return "", fmt.Errorf("sheet %q: %w", sh.Name, err)
+		}
+		fmt.Fprintf(&out, "## %s\n\n", sh.Name)
+		if len(rows) == 0 {
+			out.WriteString("(empty sheet)\n\n")
+			continue
+		}
+		out.WriteString(markdownTable(rows))
+		if truncated {
+			fmt.Fprintf(&out, "\n[Sheet truncated to %d rows × %d columns]\n", maxXLSXRowsPerSheet, maxXLSXColumns)
+		}
+		out.WriteString("\n")
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				Text string `xml:",innerxml"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readXLSXSheet(pkg *ooxmlPackage, name string, shared []string) ([][]string, bool, error) {
+	var sheet xlsxSheet
+	if err := readOOXMLPart(pkg, name, &sheet); err != nil {
+		return nil, false, err
+	}
+	var rows [][]string
+	truncated := false
+	width := 0
+	for _, r := range sheet.Rows {
+		if len(rows) >= maxXLSXRowsPerSheet {
+			truncated = true
+			break
+		}
+		row := make([]string, 0, len(r.Cells))
+		for i, c := range r.Cells {
+			col := i
+			if c.Ref != "" {
+				col = xlsxColumnIndex(c.Ref)
+			}
+			if col >= maxXLSXColumns {
+				truncated = true
+				continue
+			}
+			for len(row) < col {
+				row = append(row, "")
+			}
+			var v string
+			switch c.Type {
+			case "s":
+				if idx, err := strconv.Atoi(c.Value); err == nil && idx >= 0 && idx < len(shared) {
+					v = shared[idx]
+				}
+			case "inlineStr":
+				v = ooxmlInnerText(c.Inline.Text)
+			case "b":
+				v = "FALSE"
+				if c.Value == "1" {
+					v = "TRUE"
+				}
+			default:
+				v = c.Value
+			}
+			if col < len(row) {
+				row[col] = v
+			} else {
+				row = append(row, v)
+			}
+		}
+		rows = append(rows, row)
+		width = max(width, len(row))
+	}
+	// Drop trailing empty rows.
+	for len(rows) > 0 && strings.Join(rows[len(rows)-1], "") == "" {
+		rows = rows[:len(rows)-1]
+	}
+	for i := range rows {
+		for len(rows[i]) < width {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return rows, truncated, nil
+}
+
+// xlsxColumnIndex converts a cell reference like "AB12" to a 0-based column.
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+	}
+	return col - 1
+}
+
+func readOOXMLPart(pkg *ooxmlPackage, name string, v any) error {
+	data, err := pkg.read(name)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil // optional part (e.g. no shared strings)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// ooxmlInnerText concatenates the <t> runs of a rich-text fragment.
+func ooxmlInnerText(fragment string) string {
+	dec := xml.NewDecoder(strings.NewReader("<x>" + fragment + "</x>"))
+	var sb strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch el.Name.Local {
+		case "rPh": // phonetic guide runs
+			_ = dec.Skip()
+		case "t":
+			var s string
+			if dec.DecodeElement(&s, &el) == nil {
+				sb.WriteString(s)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func xmlAttr(el xml.StartElement, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// markdownTable renders rows as a GFM table; the first row is the header.
+func markdownTable(rows [][]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	width := 0
+	for _, r := range rows {
+		width = max(width, len(r))
+	}
+	if width == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	writeRow := func(r []string) {
+		sb.WriteString("|")
+		for i := 0; i < width; i++ {
+			cell := ""
+			if i < len(r) {
+				cell = strings.NewReplacer("|", `\|`, "\n", " ").Replace(strings.TrimSpace(r[i]))
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sb.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+	for _, r := range rows[1:] {
+		writeRow(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,965 @@
+package tools
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/ascii85"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// Minimal PDF text-layer extractor: enough of the object model to walk the
+// page tree, inflate content streams and map glyph codes through ToUnicode
+// CMaps. Layout is approximated (line breaks on vertical moves); scanned
+// PDFs have no text layer and yield an empty result.
+
+// PDF object values: pdfDict, pdfArray, pdfName, pdfString, pdfRef,
+// float64, bool, nil, and pdfKeyword (content stream operators).
+type (
+	pdfDict    map[string]any
+	pdfArray   []any
+	pdfName    string
+	pdfString  []byte
+	pdfKeyword string
+	pdfRef     struct{ num, gen int }
+)
+
+type pdfObject struct {
+	value  any
+	stream []byte // raw (still encoded) stream data, nil for non-stream objects
+}
+
+type pdfDocument struct {
+	objects map[int]*pdfObject
+	budget  int64 // remaining decompression allowance
+	cmaps   map[int]*pdfCMap
+}
+
+var pdfObjHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// extractPDFText returns the text of each page, separated by page headings.
+// It stops with ctx's error when ctx is done.
+func extractPDFText(ctx context.Context, data []byte) (string, error) {
+	doc := &pdfDocument{objects: make(map[int]*pdfObject), budget: maxDocumentInflated, cmaps: make(map[int]*pdfCMap)}
+	doc.scanObjects(data)
+	if len(doc.objects) == 0 {
+		return "", fmt.Errorf("no PDF objects found")
+	}
+	for _, obj := range doc.objects {
+		if d, ok := obj.value.(pdfDict); ok && d["Encrypt"] != nil {
+			return "", fmt.Errorf("encrypted PDFs are not supported")
+		}
+	}
+	if pdfTrailerHasEncrypt(data) {
+		return "", fmt.Errorf("encrypted PDFs are not supported")
+	}
+	doc.expandObjectStreams()
+
+	pages := doc.pages(ctx)
+	var sb strings.Builder
+	for i, page := range pages {
+		if ctx.Err() != nil {
+			break
+		}
+		if i >= maxPDFPages {
+			fmt.Fprintf(&sb, "[Stopped after %d pages]\n", maxPDFPages)
+			break
+		}
+		text := strings.TrimSpace(doc.pageText(page))
+		if text == "" {
+			continue
+		}
+		if len(pages) > 1 {
+			fmt.Fprintf(&sb, "## Page %d\n\n", i+1)
+		}
+		sb.WriteString(text)
+		sb.WriteString("\n\n")
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+func pdfTrailerHasEncrypt(data []byte) bool {
+	i := bytes.LastIndex(data, []byte("trailer"))
+	return i >= 0 && bytes.Contains(data[i:], []byte("/Encrypt"))
+}
+
+// scanObjects locates "N G obj" definitions by scanning the file instead of
+// trusting the xref table, which is often broken. Later definitions win
+// (incremental updates).
+func (d *pdfDocument) scanObjects(data []byte) {
+	end := 0
+	for _, m := range pdfObjHeader.FindAllSubmatchIndex(data, -1) {
+		if m[0] < end {
+			continue // inside a previous object's stream
+		}
+		num, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		lx := &pdfLexer{data: data, pos: m[1]}
+		val := lx.parseObject()
+		obj := &pdfObject{value: val}
+		end = lx.pos
+
+		if dict, ok := val.(pdfDict); ok {
+			save := lx.pos
+			if kw, ok := lx.next().(pdfKeyword); ok && kw == "stream" {
+				start := lx.pos
+				if start < len(data) && data[start] == '\r' {
+					start++
+				}
+				if start < len(data) && data[start] == '\n' {
+					start++
+				}
+				obj.stream, end = pdfStreamData(data, start, dict)
+			} else {
+				lx.pos = save
+			}
+		}
+		d.objects[num] = obj
+	}
+}
+
+// pdfStreamData returns the bytes between "stream" and "endstream", using
+// /Length when it is direct and consistent.
+func pdfStreamData(data []byte, start int, dict pdfDict) ([]byte, int) {
+	if n, ok := dict["Length"].(float64); ok {
+		stop := start + int(n)
+		if n >= 0 && stop <= len(data) && bytes.HasPrefix(bytes.TrimLeft(data[stop:], "\r\n \t"), []byte("endstream")) {
+			return data[start:stop], stop
+		}
+	}
+	idx := bytes.Index(data[start:], []byte("endstream"))
+	if idx < 0 {
+		return data[start:], len(data)
+	}
+	stop := start + idx
+	return bytes.TrimRight(data[start:stop], "\r\n"), stop
+}
+
+// expandObjectStreams parses objects packed into /Type /ObjStm streams (PDF 1.5+).
+func (d *pdfDocument) expandObjectStreams() {
+	for _, obj := range d.objectsSnapshot() {
+		dict, ok := obj.value.(pdfDict)
+		if !ok || obj.stream == nil || dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := d.decodeStream(obj)
+		if err != nil {
+			continue
+		}
+		n, _ := dict["N"].(float64)
+		first, _ := dict["First"].(float64)
+		if int(first) > len(data) {
+			continue
+		}
+		header := &pdfLexer{data: data[:int(first)]}
+		for i := 0; i < int(n); i++ {
+			num, ok1 := header.next().(float64)
+			off, ok2 := header.next().(float64)
+			if !ok1 || !ok2 {
+				break
+			}
+			pos := int(first) + int(off)
+			if pos >= len(data) {
+				break
+			}
+			if _, exists := d.objects[int(num)]; exists {
+				continue // a direct definition (later update) takes precedence
+			}
+			lx := &pdfLexer{data: data, pos: pos}
+			d.objects[int(num)] = &pdfObject{value: lx.parseObject()}
+		}
+	}
+}
+
+func (d *pdfDocument) objectsSnapshot() []*pdfObject {
+	out := make([]*pdfObject, 0, len(d.objects))
+	for _, o := range d.objects {
+		out = append(out, o)
+	}
+	return out
+}
+
+// resolve follows indirect references.
+func (d *pdfDocument) resolve(v any) any {
+	for i := 0; i < 16; i++ {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		obj := d.objects[ref.num]
+		if obj == nil {
+			return nil
+		}
+		v = obj.value
+	}
+	return nil
+}
+
+func (d *pdfDocument) dict(v any) pdfDict {
+	dict, _ := d.resolve(v).(pdfDict)
+	return dict
+}
+
+// decodeStream applies the stream's filters. Only text-relevant filters are
+// supported (Flate, ASCIIHex, ASCII85); image filters return an error.
+func (d *pdfDocument) decodeStream(obj *pdfObject) ([]byte, error) {
+	dict, _ := obj.value.(pdfDict)
+	data := obj.stream
+	var filters []any
+	switch f := d.resolve(dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{f}
+	case pdfArray:
+		filters = f
+	}
+	for _, f := range filters {
+		name, _ := d.resolve(f).(pdfName)
+		var err error
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = d.inflate(data)
+		case "ASCIIHexDecode", "AHx":
+			data, err = pdfHexDecode(data)
+		case "ASCII85Decode", "A85":
+			data, err = pdfASCII85Decode(data)
+		default:
+			return nil, fmt.Errorf("unsupported filter %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func (d *pdfDocument) inflate(data []byte) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, d.budget+1))
+	if int64(len(out)) > d.budget {
+		return nil, fmt.Errorf("document expands beyond %d MB", maxDocumentInflated>>20)
+	}
+	d.budget -= int64(len(out))
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil // keep partial output from streams with bad checksums
+}
+
+func pdfHexDecode(data []byte) ([]byte, error) {
+	var clean []byte
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if isPDFHexDigit(c) {
+			clean = append(clean, c)
+		}
+	}
+	if len(clean)%2 == 1 {
+		clean = append(clean, '0')
+	}
+	return hex.DecodeString(string(clean))
+}
+
+func pdfASCII85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if i := bytes.Index(data, []byte("~>")); i >= 0 {
+		data = data[:i]
+	}
+	out := make([]byte, len(data)*4+4) // "z" expands one byte to four
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
+
+// pages walks the page tree from the catalog. When the catalog cannot be
+// found, every object typed /Page is used in object-number order. Each
+// object is visited once and the walk stops after maxPDFTreeNodes nodes, so
+// cyclic or fanned-out Kids arrays cannot blow it up.
+func (d *pdfDocument) pages(ctx context.Context) []pdfDict {
+	var out []pdfDict
+	visited := make(map[int]bool)
+	nodes := 0
+	var walk func(v, inherited any, depth int)
+	walk = func(v, inherited any, depth int) {
+		if depth > 32 || len(out) > maxPDFPages || nodes >= maxPDFTreeNodes || ctx.Err() != nil {
+			return
+		}
+		if ref, ok := v.(pdfRef); ok {
+			if visited[ref.num] {
+				return
+			}
+			visited[ref.num] = true
+		}
+		nodes++
+		node := d.dict(v)
+		if node == nil {
+			return
+		}
+		res := node["Resources"]
+		if res == nil {
+			res = inherited
+		}
+		if node["Type"] == pdfName("Page") || node["Kids"] == nil {
+			page := pdfDict{"Contents": node["Contents"], "Resources": res}
+			out = append(out, page)
+			return
+		}
+		kids, _ := d.resolve(node["Kids"]).(pdfArray)
+		for _, k := range kids {
+			walk(k, res, depth+1)
+		}
+	}
+	for _, obj := range d.objects {
+		if dict, ok := obj.value.(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+			walk(dict["Pages"], nil, 0)
+			if len(out) > 0 {
+				return out
+			}
+		}
+	}
+
+	nums := make([]int, 0, len(d.objects))
+	for n, obj := range d.objects {
+		if dict, ok := obj.value.(pdfDict); ok && dict["Type"] == pdfName("Page") {
+			nums = append(nums, n)
+		}
+	}
+	sort.Ints(nums)
+	for _, n := range nums {
+		walk(pdfRef{num: n}, nil, 0)
+	}
+	return out
+}
+
+// pageText concatenates the page's content streams and runs the text operators.
+func (d *pdfDocument) pageText(page pdfDict) string {
+	var content []byte
+	var refs []any
+	switch c := page["Contents"].(type) {
+	case pdfArray:
+		refs = c
+	case pdfRef:
+		if arr, ok := d.resolve(c).(pdfArray); ok {
+			refs = arr
+		} else {
+			refs = []any{c}
+		}
+	}
+	for _, r := range refs {
+		ref, ok := r.(pdfRef)
+		if !ok || d.objects[ref.num] == nil || d.objects[ref.num].stream == nil {
+			continue
+		}
+		data, err := d.decodeStream(d.objects[ref.num])
+		if err != nil {
+			continue
+		}
+		content = append(content, data...)
+		content = append(content, '\n')
+	}
+
+	fonts := map[string]*pdfFont{}
+	if fontDict := d.dict(d.dict(page["Resources"])["Font"]); fontDict != nil {
+		for name, ref := range fontDict {
+			fonts[name] = d.font(ref)
+		}
+	}
+	return runPDFContent(content, fonts)
+}
+
+// pdfFont is what the text extractor needs to know about a font.
+type pdfFont struct {
+	cmap      *pdfCMap
+	composite bool // Type0: multi-byte codes, unreadable without ToUnicode
+}
+
+func (d *pdfDocument) font(ref any) *pdfFont {
+	dict := d.dict(ref)
+	if dict == nil {
+		return nil
+	}
+	f := &pdfFont{composite: dict["Subtype"] == pdfName("Type0")}
+	if r, ok := dict["ToUnicode"].(pdfRef); ok {
+		if cached, ok := d.cmaps[r.num]; ok {
+			f.cmap = cached
+		} else if obj := d.objects[r.num]; obj != nil && obj.stream != nil {
+			if data, err := d.decodeStream(obj); err == nil {
+				f.cmap = parsePDFCMap(data)
+			}
+			d.cmaps[r.num] = f.cmap
+		}
+	}
+	return f
+}
+
+// runPDFContent interprets the text-showing operators of a content stream.
+func runPDFContent(content []byte, fonts map[string]*pdfFont) string {
+	var sb strings.Builder
+	var operands []any
+	var font *pdfFont
+	var lineY float64
+	haveY := false
+
+	newline := func() {
+		s := sb.String()
+		if len(s) > 0 && !strings.HasSuffix(s, "\n") {
+			sb.WriteByte('\n')
+		}
+	}
+	space := func() {
+		if s := sb.String(); len(s) > 0 && !strings.HasSuffix(s, "\n") && !strings.HasSuffix(s, " ") {
+			sb.WriteByte(' ')
+		}
+	}
+	moveTo := func(y float64) {
+		if haveY && abs64(y-lineY) > 0.5 {
+			newline()
+		} else {
+			space()
+		}
+		lineY, haveY = y, true
+	}
+	show := func(s pdfString) {
+		sb.WriteString(decodePDFString(s, font))
+	}
+
+	lx := &pdfLexer{data: content}
+	for {
+		tok := lx.next()
+		if tok == pdfEOF {
+			break
+		}
+		kw, ok := tok.(pdfKeyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(pdfName); ok {
+					font = fonts[string(name)]
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+					newline()
+					lineY += ty
+				} else {
+					space()
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				if y, ok := operands[len(operands)-1].(float64); ok {
+					moveTo(y)
+				}
+			}
+		case "T*":
+			newline()
+		case "Tj":
+			if len(operands) >= 1 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "'", "\"":
+			newline()
+			if len(operands) >= 1 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					show(s)
+				}
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				if arr, ok := operands[len(operands)-1].(pdfArray); ok {
+					for _, el := range arr {
+						switch v := el.(type) {
+						case pdfString:
+							show(v)
+						case float64:
+							if v < -200 { // large negative kerning is a word gap
+								sb.WriteByte(' ')
+							}
+						}
+					}
+				}
+			}
+		case "ET":
+			space()
+		case "ID":
+			lx.skipInlineImage()
+		}
+		operands = operands[:0]
+	}
+	return cleanPDFText(sb.String())
+}
+
+var pdfMultiBlank = regexp.MustCompile(`\n{3,}`)
+
+func cleanPDFText(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.Join(strings.Fields(l), " ")
+	}
+	return pdfMultiBlank.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+func abs64(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// decodePDFString maps shown bytes to text via the font's ToUnicode CMap,
+// falling back to WinAnsi for simple fonts. Composite fonts without a CMap
+// cannot be decoded and are dropped.
+func decodePDFString(s pdfString, font *pdfFont) string {
+	if font != nil && font.cmap != nil {
+		return font.cmap.decode(s)
+	}
+	if font != nil && font.composite {
+		return ""
+	}
+	var sb strings.Builder
+	for _, b := range s {
+		sb.WriteRune(winAnsiRune(b))
+	}
+	return sb.String()
+}
+
+// winAnsiHigh maps WinAnsiEncoding bytes 0x80-0x9F; other bytes match Latin-1.
+var winAnsiHigh = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+func winAnsiRune(b byte) rune {
+	if b >= 0x80 && b <= 0x9F {
+		if r := winAnsiHigh[b-0x80]; r != 0 {
+			return r
+		}
+		return ' '
+	}
+	if b < 0x20 && b != '\t' && b != '\n' {
+		return ' '
+	}
+	return rune(b)
+}
+
+// pdfCMap is a parsed ToUnicode CMap.
+type pdfCMap struct {
+	codeLens []int // byte lengths from codespacerange, longest first
+	chars    map[string]string
+	ranges   []pdfCMapRange
+}
+
+type pdfCMapRange struct {
+	lo, hi []byte
+	dst    []rune   // base destination (incremented across the range)
+	list   []string // explicit per-code destinations ([...] form)
+}
+
+func parsePDFCMap(data []byte) *pdfCMap {
+	cm := &pdfCMap{chars: make(map[string]string)}
+	lx := &pdfLexer{data: data}
+	var operands []any
+	mode := ""
+	for {
+		tok := lx.next()
+		if tok == pdfEOF {
+			break
+		}
+		kw, ok := tok.(pdfKeyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			mode = string(kw)
+			operands = operands[:0]
+			continue
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				if lo, ok := operands[i].(pdfString); ok {
+					cm.addCodeLen(len(lo))
+				}
+			}
+			mode = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfString)
+				dst, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 {
+					cm.chars[string(src)] = utf16BEString(dst)
+				}
+			}
+			mode = ""
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].(pdfString)
+				hi, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 || len(lo) != len(hi) {
+					continue
+				}
+				r := pdfCMapRange{lo: lo, hi: hi}
+				switch dst := operands[i+2].(type) {
+				case pdfString:
+					r.dst = []rune(utf16BEString(dst))
+				case pdfArray:
+					for _, el := range dst {
+						s, _ := el.(pdfString)
+						r.list = append(r.list, utf16BEString(s))
+					}
+				}
+				cm.ranges = append(cm.ranges, r)
+			}
+			mode = ""
+		}
+		if mode == "" {
+			operands = operands[:0]
+		}
+	}
+	if len(cm.codeLens) == 0 {
+		cm.addCodeLen(2)
+		cm.addCodeLen(1)
+	}
+	return cm
+}
+
+func (cm *pdfCMap) addCodeLen(n int) {
+	for _, l := range cm.codeLens {
+		if l == n {
+			return
+		}
+	}
+	cm.codeLens = append(cm.codeLens, n)
+	for i := len(cm.codeLens) - 1; i > 0 && cm.codeLens[i] > cm.codeLens[i-1]; i-- {
+		cm.codeLens[i], cm.codeLens[i-1] = cm.codeLens[i-1], cm.codeLens[i]
+	}
+}
+
+func (cm *pdfCMap) decode(s []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		matched := false
+		for _, n := range cm.codeLens {
+			if i+n > len(s) {
+				continue
+			}
+			if text, ok := cm.lookup(s[i : i+n]); ok {
+				sb.WriteString(text)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			i += cm.codeLens[len(cm.codeLens)-1] // unmapped code: skip it
+		}
+	}
+	return sb.String()
+}
+
+func (cm *pdfCMap) lookup(code []byte) (string, bool) {
+	if s, ok := cm.chars[string(code)]; ok {
+		return s, true
+	}
+	for _, r := range cm.ranges {
+		if len(r.lo) != len(code) || bytes.Compare(code, r.lo) < 0 || bytes.Compare(code, r.hi) > 0 {
+			continue
+		}
+		off := int(pdfCodeValue(code) - pdfCodeValue(r.lo))
+		if r.list != nil {
+			if off < len(r.list) {
+				return r.list[off], true
+			}
+			return "", false
+		}
+		if len(r.dst) == 0 {
+			return "", false
+		}
+		out := append([]rune(nil), r.dst...)
+		out[len(out)-1] += rune(off)
+		return string(out), true
+	}
+	return "", false
+}
+
+func pdfCodeValue(b []byte) uint32 {
+	var v uint32
+	for _, c := range b {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+func utf16BEString(b []byte) string {
+	if len(b)%2 == 1 {
+		b = append(b, 0)
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return string(utf16.Decode(u))
+}
+
+// pdfLexer tokenizes PDF object syntax and content streams.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+type pdfEOFType struct{}
+
+var pdfEOF = pdfEOFType{}
+
+// Sentinel keywords for container delimiters.
+const (
+	pdfDictOpen   pdfKeyword = "<<"
+	pdfDictClose  pdfKeyword = ">>"
+	pdfArrayClose pdfKeyword = "]"
+)
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isPDFHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// parseObject reads one complete object, assembling dicts, arrays and refs.
+func (lx *pdfLexer) parseObject() any {
+	return lx.parseFrom(lx.next(), 0)
+}
+
+func (lx *pdfLexer) parseFrom(tok any, depth int) any {
+	if depth > 64 {
+		return nil
+	}
+	if tok == pdfDictOpen {
+		dict := pdfDict{}
+		for {
+			k := lx.next()
+			if k == pdfDictClose || k == pdfEOF {
+				return dict
+			}
+			name, ok := k.(pdfName)
+			if !ok {
+				continue
+			}
+			dict[string(name)] = lx.parseFrom(lx.next(), depth+1)
+		}
+	}
+	// "num gen R" — look ahead for an indirect reference.
+	if n, ok := tok.(float64); ok {
+		save := lx.pos
+		if g, ok := lx.next().(float64); ok {
+			if kw, ok := lx.next().(pdfKeyword); ok && kw == "R" {
+				return pdfRef{num: int(n), gen: int(g)}
+			}
+		}
+		lx.pos = save
+	}
+	switch tok {
+	case pdfKeyword("true"):
+		return true
+	case pdfKeyword("false"):
+		return false
+	case pdfKeyword("null"):
+		return nil
+	}
+	return tok
+}
+
+// next returns the next token. Arrays are assembled here so content-stream
+// operators such as TJ receive their operand as a pdfArray.
+func (lx *pdfLexer) next() any {
+	d := lx.data
+	for lx.pos < len(d) {
+		c := d[lx.pos]
+		if isPDFWhitespace(c) {
+			lx.pos++
+			continue
+		}
+		if c == '%' {
+			for lx.pos < len(d) && d[lx.pos] != '\n' && d[lx.pos] != '\r' {
+				lx.pos++
+			}
+			continue
+		}
+		break
+	}
+	if lx.pos >= len(d) {
+		return pdfEOF
+	}
+	c := d[lx.pos]
+	switch {
+	case c == '/':
+		lx.pos++
+		start := lx.pos
+		for lx.pos < len(d) && !isPDFWhitespace(d[lx.pos]) && !isPDFDelimiter(d[lx.pos]) {
+			lx.pos++
+		}
+		return pdfName(pdfUnescapeName(d[start:lx.pos]))
+	case c == '(':
+		return lx.literalString()
+	case c == '<':
+		if lx.pos+1 < len(d) && d[lx.pos+1] == '<' {
+			lx.pos += 2
+			return pdfDictOpen
+		}
+		lx.pos++
+		start := lx.pos
+		for lx.pos < len(d) && d[lx.pos] != '>' {
+			lx.pos++
+		}
+		raw := d[start:lx.pos]
+		lx.pos++
+		s, _ := pdfHexDecode(raw)
+		return pdfString(s)
+	case c == '>':
+		if lx.pos+1 < len(d) && d[lx.pos+1] == '>' {
+			lx.pos += 2
+			return pdfDictClose
+		}
+		lx.pos++
+		return lx.next()
+	case c == '[':
+		lx.pos++
+		var arr pdfArray
+		for {
+			t := lx.next()
+			if t == pdfArrayClose || t == pdfEOF {
+				return arr
+			}
+			arr = append(arr, lx.parseFrom(t, 1))
+		}
+	case c == ']':
+		lx.pos++
+		return pdfArrayClose
+	case c == '{' || c == '}' || c == ')':
+		lx.pos++
+		return lx.next()
+	}
+
+	start := lx.pos
+	for lx.pos < len(d) && !isPDFWhitespace(d[lx.pos]) && !isPDFDelimiter(d[lx.pos]) {
+		lx.pos++
+	}
+	word := string(d[start:lx.pos])
+	if c := word[0]; (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' {
+		if f, err := strconv.ParseFloat(word, 64); err == nil {
+			return f
+		}
+	}
+	return pdfKeyword(word)
+}
+
+func (lx *pdfLexer) literalString() pdfString {
+	d := lx.data
+	lx.pos++ // (
+	var out []byte
+	depth := 1
+	for lx.pos < len(d) {
+		c := d[lx.pos]
+		lx.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return out
+			}
+		case '\\':
+			if lx.pos >= len(d) {
+				return out
+			}
+			e := d[lx.pos]
+			lx.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case '\r':
+				if lx.pos < len(d) && d[lx.pos] == '\n' {
+					lx.pos++
+				}
+			case '\n':
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for k := 0; k < 2 && lx.pos < len(d) && d[lx.pos] >= '0' && d[lx.pos] <= '7'; k++ {
+						v = v*8 + int(d[lx.pos]-'0')
+						lx.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// skipInlineImage skips binary inline image data after the ID operator.
+func (lx *pdfLexer) skipInlineImage() {
+	d := lx.data
+	for i := lx.pos; i+2 < len(d); i++ {
+		if d[i] == 'E' && d[i+1] == 'I' && isPDFWhitespace(d[i-1]) && (i+2 == len(d) || isPDFWhitespace(d[i+2])) {
+			lx.pos = i + 2
+			return
+		}
+	}
+	lx.pos = len(d)
+}
+
+func pdfUnescapeName(b []byte) string {
+	if bytes.IndexByte(b, '#') < 0 {
+		return string(b)
+	}
+	var out []byte
+	for i := 0; i < len(b); i++ {
+		if b[i] == '#' && i+2 < len(b) && isPDFHexDigit(b[i+1]) && isPDFHexDigit(b[i+2]) {
+			v, _ := strconv.ParseUint(string(b[i+1:i+3]), 16, 8)
+			out = append(out, byte(v))
+			i += 2
+			continue
+		}
+		out = append(out, b[i])
+	}
+	return string(out)
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// JSRenderer renders a page in a headless browser and returns the final DOM
+// and URL. Implemented by pkg/browser.Manager.
+type JSRenderer interface {
+	RenderHTML(ctx context.Context, url string) (html, finalURL string, err error)
+}
+
+// renderJs argument values.
+const (
+	renderAuto   = "auto"   // render only when static extraction comes back near-empty
+	renderAlways = "always" // skip the static fetch
+	renderNever  = "never"
+)
+
+// SetJSRenderer wires the browser used for the JavaScript rendering fallback.
+// Rendering stays off until SetJSRenderEnabled(true) (tools.web_fetch.render_js).
+func (t *WebFetchTool) SetJSRenderer(r JSRenderer) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.renderer = r
+}
+
+// SetJSRenderEnabled toggles the rendering fallback (called on config change).
+func (t *WebFetchTool) SetJSRenderEnabled(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.renderEnabled = enabled
+}
+
+// jsRenderer returns the renderer when rendering is enabled and available.
+func (t *WebFetchTool) jsRenderer() JSRenderer {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if !t.renderEnabled {
+		return nil
+	}
+	return t.renderer
+}
+
+// renderModeArg maps the renderJs argument: true forces rendering, false
+// disables it, omitted lets web_fetch fall back automatically.
+func renderModeArg(args map[string]any) string {
+	switch v := args["renderJs"].(type) {
+	case bool:
+		if v {
+			return renderAlways
+		}
+		return renderNever
+	case string: // some models send booleans as strings
+		switch strings.ToLower(v) {
+		case "true":
+			return renderAlways
+		case "false":
+			return renderNever
+		}
+	}
+	return renderAuto
+}
+
+// doRenderedFetch loads the page in the browser and converts the rendered DOM
+// like a static HTML response. Chrome follows redirects itself, so the page
+// it ended up on is checked against SSRF and domain policy before use.
+func (t *WebFetchTool) doRenderedFetch(ctx context.Context, r JSRenderer, rawURL, extractMode string, maxChars int, pol webFetchPolicy) (string, error) {
+	ReportProgress(ctx, "Rendering "+rawURL)
+	html, finalURL, err := r.RenderHTML(ctx, rawURL)
+	if err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+	if finalURL == "" {
+		finalURL = rawURL
+	}
+	if finalURL != rawURL {
+		u, err := url.Parse(finalURL)
+		if err != nil {
+			return "", fmt.Errorf("render: invalid final URL %q", finalURL)
+		}
+		if err := checkRedirectTarget(u, pol); err != nil {
+			return "", err
+		}
+	}
+
+	var text string
+	if extractMode == "markdown" {
+		text = htmlToMarkdown(html)
+	} else {
+		text = htmlToText(html)
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("render: page has no text content")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("URL: %s\n", finalURL))
+	if finalURL != rawURL {
+		sb.WriteString(fmt.Sprintf("Redirected from: %s\n", rawURL))
+	}
+	sb.WriteString("Extractor: browser-render\n")
+	appendContent(&sb, text, maxChars, finalURL, ctx)
+	return sb.String(), nil
+}
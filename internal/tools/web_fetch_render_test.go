@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeRenderer struct {
+	html     string
+	finalURL string
+	calls    int
+}
+
+func (f *fakeRenderer) RenderHTML(ctx context.Context, url string) (string, string, error) {
+	f.calls++
+	final := f.finalURL
+	if final == "" {
+		final = url
+	}
+	return f.html, final, nil
+}
+
+const renderedArticle = `<html><body><main><h1>Rendered title</h1><p>This paragraph only exists after the client-side bundle runs, and it has plenty of words to pass the quality check.</p></main></body></html>`
+
+func newRenderTestServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+	return server
+}
+
+func TestWebFetch_RenderFallbackOnThinPage(t *testing.T) {
+	server := newRenderTestServer(t, `<html><body><div id="root"></div><script src="/app.js"></script></body></html>`)
+	r := &fakeRenderer{html: renderedArticle}
+	tool := NewWebFetchTool(WebFetchConfig{})
+	tool.SetJSRenderer(r)
+	tool.SetJSRenderEnabled(true)
+
+	for _, mode := range []string{"markdown", "text"} {
+		res := tool.Execute(context.Background(), map[string]any{"url": server.URL + "/" + mode, "extractMode": mode})
+		if res.IsError {
+			t.Fatalf("%s: %s", mode, res.ForLLM)
+		}
+		if !strings.Contains(res.ForLLM, "Extractor: browser-render") || !strings.Contains(res.ForLLM, "Rendered title") {
+			t.Errorf("%s: result = %s", mode, res.ForLLM)
+		}
+	}
+
+	// renderJs=false keeps the static result.
+	calls := r.calls
+	res := tool.Execute(context.Background(), map[string]any{"url": server.URL + "/static", "extractMode": "text", "renderJs": false})
+	if r.calls != calls || strings.Contains(res.ForLLM, "browser-render") {
+		t.Errorf("rendered despite renderJs=false: %s", res.ForLLM)
+	}
+}
+
+func TestWebFetch_RenderSkippedForContentfulPage(t *testing.T) {
+	server := newRenderTestServer(t, renderedArticle)
+	r := &fakeRenderer{html: renderedArticle}
+	tool := NewWebFetchTool(WebFetchConfig{})
+	tool.SetJSRenderer(r)
+	tool.SetJSRenderEnabled(true)
+
+	res := tool.Execute(context.Background(), map[string]any{"url": server.URL})
+	if res.IsError || r.calls != 0 {
+		t.Errorf("calls = %d, result = %s", r.calls, res.ForLLM)
+	}
+
+	// renderJs=true forces rendering.
+	res = tool.Execute(context.Background(), map[string]any{"url": server.URL + "/forced", "renderJs": true})
+	if r.calls != 1 || !strings.Contains(res.ForLLM, "Extractor: browser-render") {
+		t.Errorf("calls = %d, result = %s", r.calls, res.ForLLM)
+	}
+}
+
+func TestWebFetch_RenderGateAndRedirectPolicy(t *testing.T) {
+	server := newRenderTestServer(t, `<html><body></body></html>`)
+	r := &fakeRenderer{html: renderedArticle}
+	tool := NewWebFetchTool(WebFetchConfig{BlockedDomains: []string{"93.184.216.34"}})
+	tool.SetJSRenderer(r)
+
+	// Gate off: renderJs=true is rejected and the automatic fallback never runs.
+	if res := tool.Execute(context.Background(), map[string]any{"url": server.URL, "renderJs": true}); !res.IsError {
+		t.Errorf("renderJs accepted while disabled: %s", res.ForLLM)
+	}
+	tool.Execute(context.Background(), map[string]any{"url": server.URL, "extractMode": "text"})
+	if r.calls != 0 {
+		t.Errorf("renderer called while disabled")
+	}
+
+	// The page the browser ends up on goes through the redirect policy.
+	tool.SetJSRenderEnabled(true)
+	r.finalURL = "https://93.184.216.34/landing"
+	res := tool.Execute(context.Background(), map[string]any{"url": server.URL + "/r", "renderJs": true})
+	if !res.IsError || !strings.Contains(res.ForLLM, "blocklist") {
+		t.Errorf("result = %s, want blocklist error", res.ForLLM)
+	}
+}
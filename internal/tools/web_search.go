@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nextlevelbuilder/goclaw/internal/bus"
+	"github.com/nextlevelbuilder/goclaw/internal/runtimeprofile"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/pkg/protocol"
 )
@@ -23,6 +24,7 @@ const (
 	braveSearchEndpoint  = "https://api.search.brave.com/res/v1/web/search"
 	exaSearchEndpoint    = "https://api.exa.ai/search"
 	tavilySearchEndpoint = "https://api.tavily.com/search"
+	googleSearchEndpoint = "https://www.googleapis.com/customsearch/v1"
 	webSearchUserAgent   = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_7_2) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
 )
 
@@ -30,6 +32,8 @@ const (
 	searchProviderExa        = "exa"
 	searchProviderTavily     = "tavily"
 	searchProviderBrave      = "brave"
+	searchProviderGoogle     = "google"
+	searchProviderSearXNG    = "searxng"
 	searchProviderDuckDuckGo = "duckduckgo"
 )
 
@@ -37,6 +41,8 @@ var defaultSearchProviderOrder = []string{
 	searchProviderExa,
 	searchProviderTavily,
 	searchProviderBrave,
+	searchProviderGoogle,
+	searchProviderSearXNG,
 	searchProviderDuckDuckGo,
 }
 
@@ -102,7 +108,7 @@ type WebSearchTool struct {
 func NewWebSearchTool(secrets store.ConfigSecretsStore, msgBus *bus.MessageBus) *WebSearchTool {
 	t := &WebSearchTool{
 		secrets:    secrets,
-		cache:      newWebCache(defaultCacheMaxEntries, defaultCacheTTL),
+		cache:      newWebCache(runtimeprofile.Current().WebCacheEntries, defaultCacheTTL),
 		chainCache: newTenantChainCache(),
 	}
 
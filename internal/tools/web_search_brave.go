@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // --- Brave Search Provider ---
@@ -22,7 +24,7 @@ func newBraveSearchProvider(apiKey string, maxResults int) *braveSearchProvider
 	return &braveSearchProvider{
 		apiKey:     apiKey,
 		maxResults: normalizeProviderMaxResults(maxResults),
-		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second},
+		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second, Transport: newSSRFSafeTransport(netproxy.Tool("web_search"))},
 	}
 }
 
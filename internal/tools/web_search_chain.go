@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,13 +24,20 @@ import (
 //  2. Use NormalizeWebSearchProviderOrder to determine iteration order.
 //  3. DDG is always appended last — force-enabled, no API key required.
 //  4. For other providers: skip if tenant explicitly disabled, or if no API
-//     key found in config_secrets for the current tenant.
+//     key found in config_secrets for the current tenant. Google also needs
+//     "engine_id" and SearXNG (keyless) needs "base_url" in its section.
+//  5. In "multi" mode, two or more configured providers are wrapped into one
+//     multiSearchProvider that queries them in parallel and merges results;
+//     DDG stays behind it as the fallback.
 //
 // Tenant settings schema (stored in builtin_tool_tenant_configs.settings):
 //
 //	{
 //	  "provider_order": ["brave", "exa"],     // optional reorder
+//	  "mode": "multi",                        // optional, default "fallback"
 //	  "brave":      { "enabled": false },     // optional per-provider disable
+//	  "google":     { "engine_id": "0123:abc" },
+//	  "searxng":    { "base_url": "https://searx.example.com" },
 //	  "duckduckgo": { "enabled": true }
 //	}
 
@@ -93,14 +101,23 @@ func (c *tenantChainCache) InvalidateAll() {
 // non-nil fields override the default. Unknown fields in the JSON blob are
 // ignored to stay forward-compatible with future tuning knobs.
 type WebSearchProviderOverride struct {
-	Enabled    *bool `json:"enabled,omitempty"`
-	MaxResults int   `json:"max_results,omitempty"`
+	Enabled    *bool  `json:"enabled,omitempty"`
+	MaxResults int    `json:"max_results,omitempty"`
+	BaseURL    string `json:"base_url,omitempty"`  // searxng: instance URL
+	EngineID   string `json:"engine_id,omitempty"` // google: Programmable Search Engine ID (cx)
 }
 
+// Web search chain modes.
+const (
+	webSearchModeFallback = "fallback" // first provider that succeeds wins (default)
+	webSearchModeMulti    = "multi"    // query all configured providers, merge + dedup
+)
+
 // WebSearchChainOverride is the full tenant settings shape for web_search.
 // All fields optional — an empty/nil override results in the default chain.
 type WebSearchChainOverride struct {
 	ProviderOrder []string                             `json:"provider_order,omitempty"`
+	Mode          string                               `json:"mode,omitempty"`
 	Providers     map[string]WebSearchProviderOverride `json:"-"`
 	// Per-provider sections are unmarshaled into Providers via custom logic
 	// below so admins can keep the natural JSON shape:
@@ -109,9 +126,9 @@ type WebSearchChainOverride struct {
 
 // UnmarshalJSON accepts the flat admin-facing shape:
 //
-//	{ "provider_order": [...], "brave": {...}, "duckduckgo": {...} }
+//	{ "provider_order": [...], "mode": "multi", "brave": {...}, "duckduckgo": {...} }
 //
-// Keeps ProviderOrder top-level and collects every other object field into
+// Keeps ProviderOrder and Mode top-level and collects every other object field into
 // the Providers map keyed by provider name.
 func (w *WebSearchChainOverride) UnmarshalJSON(data []byte) error {
 	var raw map[string]json.RawMessage
@@ -124,6 +141,12 @@ func (w *WebSearchChainOverride) UnmarshalJSON(data []byte) error {
 		}
 		delete(raw, "provider_order")
 	}
+	if modeRaw, ok := raw["mode"]; ok {
+		if err := json.Unmarshal(modeRaw, &w.Mode); err != nil {
+			return err
+		}
+		delete(raw, "mode")
+	}
 	if len(raw) > 0 {
 		w.Providers = make(map[string]WebSearchProviderOverride, len(raw))
 		for name, blob := range raw {
@@ -178,13 +201,11 @@ func BuildChainFromStorage(ctx context.Context, secrets store.ConfigSecretsStore
 
 	var chain []SearchProvider
 	for _, name := range order {
+		po := override.Providers[name]
+
 		if name == searchProviderDuckDuckGo {
 			// DDG is force-enabled — always last, no API key needed.
-			maxResults := defaultSearchCount
-			if po, ok := override.Providers[name]; ok && po.MaxResults > 0 {
-				maxResults = po.MaxResults
-			}
-			chain = append(chain, buildProviderByName(name, "", maxResults))
+			chain = append(chain, buildProviderByName(name, "", po))
 			continue
 		}
 
@@ -192,18 +213,20 @@ func BuildChainFromStorage(ctx context.Context, secrets store.ConfigSecretsStore
 			continue
 		}
 
-		key, err := secrets.Get(ctx, "tools.web."+name+".api_key")
-		if err != nil || key == "" {
-			// No key → provider not configured for this tenant; skip silently.
-			continue
+		var key string
+		if searchProviderNeedsKey(name) {
+			k, err := secrets.Get(ctx, "tools.web."+name+".api_key")
+			if err != nil || k == "" {
+				// No key → provider not configured for this tenant; skip silently.
+				continue
+			}
+			key = k
 		}
-
-		maxResults := defaultSearchCount
-		if po, ok := override.Providers[name]; ok && po.MaxResults > 0 {
-			maxResults = po.MaxResults
+		if (name == searchProviderGoogle && po.EngineID == "") || (name == searchProviderSearXNG && po.BaseURL == "") {
+			continue
 		}
 
-		p := buildProviderByName(name, key, maxResults)
+		p := buildProviderByName(name, key, po)
 		if p == nil {
 			slog.Warn("web_search: unknown provider name in chain", "name", name)
 			continue
@@ -211,5 +234,11 @@ func BuildChainFromStorage(ctx context.Context, secrets store.ConfigSecretsStore
 		chain = append(chain, p)
 	}
 
+	if strings.EqualFold(strings.TrimSpace(override.Mode), webSearchModeMulti) && len(chain) > 2 {
+		// chain always ends with DDG; merge everything in front of it.
+		last := len(chain) - 1
+		chain = []SearchProvider{newMultiSearchProvider(chain[:last]), chain[last]}
+	}
+
 	return chain
 }
@@ -6,9 +6,11 @@ import (
 )
 
 // buildProviderByName returns the SearchProvider for a known name.
-// Returns nil for unknown names. DDG ignores apiKey (not required).
-// maxResults <= 0 falls back to defaultSearchCount.
-func buildProviderByName(name, apiKey string, maxResults int) SearchProvider {
+// Returns nil for unknown names. DDG and SearXNG ignore apiKey (not required).
+// po.MaxResults <= 0 falls back to defaultSearchCount; Google reads its engine
+// ID and SearXNG its instance URL from po.
+func buildProviderByName(name, apiKey string, po WebSearchProviderOverride) SearchProvider {
+	maxResults := po.MaxResults
 	if maxResults <= 0 {
 		maxResults = defaultSearchCount
 	}
@@ -19,6 +21,10 @@ func buildProviderByName(name, apiKey string, maxResults int) SearchProvider {
 		return newTavilySearchProvider(apiKey, maxResults)
 	case searchProviderBrave:
 		return newBraveSearchProvider(apiKey, maxResults)
+	case searchProviderGoogle:
+		return newGoogleSearchProvider(apiKey, po.EngineID, maxResults)
+	case searchProviderSearXNG:
+		return newSearXNGSearchProvider(po.BaseURL, maxResults)
 	case searchProviderDuckDuckGo:
 		return newDuckDuckGoSearchProvider(maxResults)
 	default:
@@ -26,6 +32,12 @@ func buildProviderByName(name, apiKey string, maxResults int) SearchProvider {
 	}
 }
 
+// searchProviderNeedsKey reports whether a provider is skipped without an
+// API key in config_secrets.
+func searchProviderNeedsKey(name string) bool {
+	return name != searchProviderDuckDuckGo && name != searchProviderSearXNG
+}
+
 // NormalizeWebSearchProviderOrder normalizes user-specified provider order.
 // Explicit providers appear first in their specified order, remaining known
 // providers are appended (DuckDuckGo always last as free fallback).
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 // --- DuckDuckGo Search Provider ---
@@ -21,7 +23,7 @@ type duckDuckGoSearchProvider struct {
 func newDuckDuckGoSearchProvider(maxResults int) *duckDuckGoSearchProvider {
 	return &duckDuckGoSearchProvider{
 		maxResults: normalizeProviderMaxResults(maxResults),
-		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second},
+		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second, Transport: newSSRFSafeTransport(netproxy.Tool("web_search"))},
 	}
 }
 
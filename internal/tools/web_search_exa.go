@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 )
 
 type exaSearchProvider struct {
@@ -20,7 +22,7 @@ func newExaSearchProvider(apiKey string, maxResults int) *exaSearchProvider {
 	return &exaSearchProvider{
 		apiKey:     apiKey,
 		maxResults: normalizeProviderMaxResults(maxResults),
-		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second},
+		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second, Transport: newSSRFSafeTransport(netproxy.Tool("web_search"))},
 	}
 }
 
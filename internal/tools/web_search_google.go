@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// --- Google Programmable Search (Custom Search JSON API) ---
+
+type googleSearchProvider struct {
+	apiKey     string
+	engineID   string // "cx" — the Programmable Search Engine ID
+	endpoint   string
+	maxResults int
+	client     *http.Client
+}
+
+func newGoogleSearchProvider(apiKey, engineID string, maxResults int) *googleSearchProvider {
+	return &googleSearchProvider{
+		apiKey:     apiKey,
+		engineID:   engineID,
+		endpoint:   googleSearchEndpoint,
+		maxResults: normalizeProviderMaxResults(maxResults),
+		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second, Transport: newSSRFSafeTransport(netproxy.Tool("web_search"))},
+	}
+}
+
+func (p *googleSearchProvider) Name() string { return searchProviderGoogle }
+
+// googleDateRestrict maps freshness shortcuts to the CSE dateRestrict
+// parameter. Date ranges have no CSE equivalent and are dropped.
+var googleDateRestrict = map[string]string{"pd": "d1", "pw": "w1", "pm": "m1", "py": "y1"}
+
+func (p *googleSearchProvider) Search(ctx context.Context, params searchParams) ([]searchResult, error) {
+	q := url.Values{}
+	q.Set("key", p.apiKey)
+	q.Set("cx", p.engineID)
+	q.Set("q", params.Query)
+	q.Set("num", strconv.Itoa(clampProviderResultCount(params.Count, p.maxResults)))
+
+	if c := strings.ToLower(params.Country); c != "" && c != "all" {
+		q.Set("gl", c)
+	}
+	if params.SearchLang != "" {
+		q.Set("lr", "lang_"+strings.ToLower(params.SearchLang))
+	}
+	if params.UILang != "" {
+		q.Set("hl", params.UILang)
+	}
+	if r, ok := googleDateRestrict[normalizeFreshness(params.Freshness)]; ok {
+		q.Set("dateRestrict", r)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// *url.Error embeds the request URL, which carries the API key.
+		return nil, fmt.Errorf("request failed: %s", strings.ReplaceAll(err.Error(), p.apiKey, "***"))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("google API returned %d: %s", resp.StatusCode, truncateStr(string(body), 200))
+	}
+
+	var googleResp struct {
+		Items []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &googleResp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	results := make([]searchResult, 0, len(googleResp.Items))
+	for _, r := range googleResp.Items {
+		results = append(results, searchResult{
+			Title:       coalesceSearchText(r.Title, r.Link, "Untitled"),
+			URL:         r.Link,
+			Description: r.Snippet,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// multiSearchProvider queries several providers in parallel and merges their
+// results ("mode": "multi"). Results are interleaved round-robin in priority
+// order so every provider's top hits make the cut, and duplicate URLs are
+// dropped (the copy from the higher-priority provider wins).
+type multiSearchProvider struct {
+	providers []SearchProvider
+	name      string
+}
+
+func newMultiSearchProvider(providers []SearchProvider) *multiSearchProvider {
+	names := make([]string, len(providers))
+	for i, p := range providers {
+		names[i] = p.Name()
+	}
+	return &multiSearchProvider{providers: providers, name: strings.Join(names, "+")}
+}
+
+func (m *multiSearchProvider) Name() string { return m.name }
+
+func (m *multiSearchProvider) Search(ctx context.Context, params searchParams) ([]searchResult, error) {
+	lists := make([][]searchResult, len(m.providers))
+	errs := make([]error, len(m.providers))
+
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lists[i], errs[i] = p.Search(ctx, params)
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			slog.Warn("web_search provider failed", "provider", m.providers[i].Name(), "error", err)
+			errs[i] = fmt.Errorf("%s: %w", m.providers[i].Name(), err)
+			failed++
+		}
+	}
+	if failed == len(m.providers) {
+		return nil, errors.Join(errs...)
+	}
+
+	count := params.Count
+	if count <= 0 {
+		count = defaultSearchCount
+	}
+	return mergeSearchResults(lists, count), nil
+}
+
+// mergeSearchResults interleaves result lists round-robin, skipping URLs
+// already seen, until limit results are collected.
+func mergeSearchResults(lists [][]searchResult, limit int) []searchResult {
+	seen := make(map[string]bool)
+	var merged []searchResult
+	for rank := 0; len(merged) < limit; rank++ {
+		more := false
+		for _, list := range lists {
+			if rank >= len(list) {
+				continue
+			}
+			more = true
+			r := list[rank]
+			key := searchResultKey(r.URL)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, r)
+			if len(merged) == limit {
+				break
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return merged
+}
+
+// searchResultKey normalizes a result URL for dedup: scheme, "www." prefix,
+// fragment and trailing slash are ignored; host is case-insensitive.
+func searchResultKey(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSpace(raw)
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	key := host + strings.TrimSuffix(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
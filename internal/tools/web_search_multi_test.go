@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
+)
+
+// resultSearchProvider returns canned results (or an error).
+type resultSearchProvider struct {
+	name    string
+	results []searchResult
+	err     error
+}
+
+func (p *resultSearchProvider) Name() string { return p.name }
+func (p *resultSearchProvider) Search(_ context.Context, _ searchParams) ([]searchResult, error) {
+	return p.results, p.err
+}
+
+func urls(results []searchResult) []string {
+	out := make([]string, len(results))
+	for i, r := range results {
+		out[i] = r.URL
+	}
+	return out
+}
+
+func TestMultiSearchProvider_MergeAndDedup(t *testing.T) {
+	m := newMultiSearchProvider([]SearchProvider{
+		&resultSearchProvider{name: "exa", results: []searchResult{
+			{Title: "A", URL: "https://a.test/x"},
+			{Title: "B", URL: "https://b.test/"},
+		}},
+		&resultSearchProvider{name: "google", results: []searchResult{
+			{Title: "A dup", URL: "http://www.A.test/x/#top"},
+			{Title: "C", URL: "https://c.test/"},
+			{Title: "D", URL: "https://d.test/"},
+		}},
+		&resultSearchProvider{name: "brave", err: errors.New("quota exceeded")},
+	})
+	if m.Name() != "exa+google+brave" {
+		t.Errorf("Name() = %q", m.Name())
+	}
+
+	got, err := m.Search(context.Background(), searchParams{Query: "q", Count: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"https://a.test/x", "https://b.test/", "https://c.test/"}
+	if strings.Join(urls(got), " ") != strings.Join(want, " ") {
+		t.Errorf("merged = %v, want %v", urls(got), want)
+	}
+	if got[0].Title != "A" {
+		t.Errorf("duplicate should keep the higher-priority copy, got %q", got[0].Title)
+	}
+}
+
+func TestMultiSearchProvider_AllFail(t *testing.T) {
+	m := newMultiSearchProvider([]SearchProvider{
+		&resultSearchProvider{name: "exa", err: errors.New("boom")},
+		&resultSearchProvider{name: "google", err: errors.New("bad key")},
+	})
+	_, err := m.Search(context.Background(), searchParams{Query: "q"})
+	if err == nil || !strings.Contains(err.Error(), "exa: boom") || !strings.Contains(err.Error(), "google: bad key") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestBuildChainFromStorage_GoogleSearXNGAndMulti(t *testing.T) {
+	ctx := store.WithTenantID(context.Background(), uuid.New())
+	fake := newFakeSecretsStore()
+	fake.Set(ctx, "tools.web.google.api_key", "g-key")
+	fake.Set(ctx, "tools.web.brave.api_key", "b-key")
+
+	build := func(override string) []string {
+		c := WithTenantToolSettings(ctx, BuiltinToolSettings{"web_search": []byte(override)})
+		return chainNames(BuildChainFromStorage(c, fake))
+	}
+
+	// Google without engine_id and SearXNG without base_url are not configured.
+	if got := build(`{}`); strings.Join(got, ",") != "brave,duckduckgo" {
+		t.Errorf("chain = %v", got)
+	}
+
+	cfg := `"google":{"engine_id":"cx1"},"searxng":{"base_url":"https://searx.test"}`
+	if got := build(`{"provider_order":["searxng","google"],` + cfg + `}`); strings.Join(got, ",") != "searxng,google,brave,duckduckgo" {
+		t.Errorf("chain = %v", got)
+	}
+	if got := build(`{"provider_order":["searxng","google"],"mode":"multi",` + cfg + `}`); strings.Join(got, ",") != "searxng+google+brave,duckduckgo" {
+		t.Errorf("multi chain = %v", got)
+	}
+	// A single configured provider needs no merging.
+	if got := build(`{"mode":"multi","brave":{"enabled":false}}`); strings.Join(got, ",") != "duckduckgo" {
+		t.Errorf("chain = %v", got)
+	}
+}
+
+func TestGoogleSearchProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("key") != "g-key" || q.Get("cx") != "cx1" || q.Get("num") != "3" || q.Get("dateRestrict") != "w1" || q.Get("gl") != "de" {
+			http.Error(w, "bad query: "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"items":[{"title":"Go","link":"https://go.dev/","snippet":"The Go language"}]}`))
+	}))
+	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+
+	p := newGoogleSearchProvider("g-key", "cx1", 5)
+	p.endpoint = server.URL
+	got, err := p.Search(context.Background(), searchParams{Query: "golang", Count: 3, Country: "DE", Freshness: "pw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].URL != "https://go.dev/" || got[0].Description != "The Go language" {
+		t.Errorf("results = %+v", got)
+	}
+}
+
+func TestSearXNGSearchProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/searx/search" || r.URL.Query().Get("format") != "json" || r.URL.Query().Get("time_range") != "day" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"results":[
+			{"title":"One","url":"https://one.test/","content":"first"},
+			{"title":"","url":"https://two.test/","content":"second"},
+			{"title":"Three","url":"https://three.test/","content":"third"}]}`))
+	}))
+	defer server.Close()
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+
+	p := newSearXNGSearchProvider(server.URL+"/searx/", 5)
+	got, err := p.Search(context.Background(), searchParams{Query: "q", Count: 2, Freshness: "pd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Title != "https://two.test/" {
+		t.Errorf("results = %+v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// --- SearXNG (self-hosted metasearch) ---
+//
+// The instance must have the JSON output format enabled
+// (search.formats: [html, json] in settings.yml). Instances on private
+// addresses are only reachable once their range is listed in
+// tools.web.allow_cidrs — web_search dials through the SSRF-safe transport.
+
+type searxngSearchProvider struct {
+	baseURL    string
+	maxResults int
+	client     *http.Client
+}
+
+func newSearXNGSearchProvider(baseURL string, maxResults int) *searxngSearchProvider {
+	return &searxngSearchProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		maxResults: normalizeProviderMaxResults(maxResults),
+		client:     &http.Client{Timeout: time.Duration(searchTimeoutSeconds) * time.Second, Transport: newSSRFSafeTransport(netproxy.Tool("web_search"))},
+	}
+}
+
+func (p *searxngSearchProvider) Name() string { return searchProviderSearXNG }
+
+var searxngTimeRange = map[string]string{"pd": "day", "pw": "week", "pm": "month", "py": "year"}
+
+func (p *searxngSearchProvider) Search(ctx context.Context, params searchParams) ([]searchResult, error) {
+	q := url.Values{}
+	q.Set("q", params.Query)
+	q.Set("format", "json")
+	if params.SearchLang != "" {
+		q.Set("language", params.SearchLang)
+	}
+	if r, ok := searxngTimeRange[normalizeFreshness(params.Freshness)]; ok {
+		q.Set("time_range", r)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/search?"+q.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", webSearchUserAgent)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// 403 is what SearXNG returns when the json format is not enabled.
+		return nil, fmt.Errorf("searxng returned %d: %s", resp.StatusCode, truncateStr(string(body), 200))
+	}
+
+	var searxResp struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &searxResp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	// SearXNG has no count parameter; trim client-side.
+	count := clampProviderResultCount(params.Count, p.maxResults)
+	results := make([]searchResult, 0, min(count, len(searxResp.Results)))
+	for _, r := range searxResp.Results {
+		if len(results) >= count {
+			break
+		}
+		results = append(results, searchResult{
+			Title:       coalesceSearchText(r.Title, r.URL, "Untitled"),
+			URL:         r.URL,
+			Description: truncateStr(r.Content, 240),
+		})
+	}
+	return results, nil
+}
@@ -30,6 +30,8 @@ type webCache struct {
 	ttl     time.Duration
 }
 
+// newWebCache creates a result cache. maxSize <= 0 uses defaultCacheMaxEntries
+// (the runtime profile passes 0 unless it shrinks the cache).
 func newWebCache(maxSize int, ttl time.Duration) *webCache {
 	if maxSize <= 0 {
 		maxSize = defaultCacheMaxEntries
@@ -160,7 +162,9 @@ func isPrivateIP(ipStr string) bool {
 }
 
 // CheckSSRF validates a URL against SSRF attacks.
-// Returns an error if the URL targets a private/blocked host.
+// Returns an error if the URL targets a private/blocked host. This is a
+// pre-flight check; web tools also pin and re-validate IPs at dial time
+// (see ssrfSafeDialContext) since DNS can change between check and connect.
 func CheckSSRF(rawURL string) error {
 	parsed, err := url.Parse(rawURL)
 	if err != nil {
@@ -178,10 +182,7 @@ func CheckSSRF(rawURL string) error {
 
 	// Check if hostname is already an IP
 	if ip := net.ParseIP(hostname); ip != nil {
-		if isPrivateIP(hostname) {
-			return fmt.Errorf("private IP address not allowed: %s", hostname)
-		}
-		return nil
+		return checkSSRFIP(ip)
 	}
 
 	// DNS resolution check (pinning)
@@ -191,8 +192,10 @@ func CheckSSRF(rawURL string) error {
 	}
 
 	for _, addr := range addrs {
-		if isPrivateIP(addr) {
-			return fmt.Errorf("hostname %s resolves to private IP %s", hostname, addr)
+		if ip := net.ParseIP(addr); ip != nil {
+			if err := checkSSRFIP(ip); err != nil {
+				return fmt.Errorf("hostname %s resolves to %w", hostname, err)
+			}
 		}
 	}
 
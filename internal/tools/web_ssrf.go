@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
+)
+
+// webNetworkPolicy holds operator CIDR overrides for web_fetch and web_search
+// (config tools.web). Deny ranges are always blocked; allow ranges exempt a
+// private range from the built-in block (e.g. an intranet docs server).
+type webNetworkPolicy struct {
+	allow []*net.IPNet
+	deny  []*net.IPNet
+}
+
+var webNetPolicy atomic.Pointer[webNetworkPolicy]
+
+// SetWebNetworkPolicy replaces the allow/deny CIDR lists used by the SSRF
+// checks. Entries may be CIDRs or single IPs. On a parse error the previous
+// policy is kept.
+func SetWebNetworkPolicy(allow, deny []string) error {
+	p := &webNetworkPolicy{}
+	var err error
+	if p.allow, err = parseCIDRList(allow); err != nil {
+		return fmt.Errorf("tools.web.allow_cidrs: %w", err)
+	}
+	if p.deny, err = parseCIDRList(deny); err != nil {
+		return fmt.Errorf("tools.web.deny_cidrs: %w", err)
+	}
+	webNetPolicy.Store(p)
+	return nil
+}
+
+func parseCIDRList(entries []string) ([]*net.IPNet, error) {
+	var out []*net.IPNet
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if !strings.Contains(e, "/") {
+			ip := net.ParseIP(e)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP or CIDR %q", e)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP or CIDR %q", e)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func cidrsContain(list []*net.IPNet, ip net.IP) bool {
+	for _, n := range list {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSSRFIP rejects IPs web tools must not reach: operator deny ranges,
+// then private/reserved ranges unless an operator allow range covers them.
+func checkSSRFIP(ip net.IP) error {
+	p := webNetPolicy.Load()
+	if p != nil && cidrsContain(p.deny, ip) {
+		return fmt.Errorf("IP address %s is in a denied range", ip)
+	}
+	if p != nil && cidrsContain(p.allow, ip) {
+		return nil
+	}
+	if isPrivateIP(ip.String()) || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("private IP address not allowed: %s", ip)
+	}
+	return nil
+}
+
+// ssrfLookupIP resolves hostnames for the pinned dialer (swapped in tests).
+var ssrfLookupIP = net.DefaultResolver.LookupIPAddr
+
+// ssrfSafeDialContext returns a DialContext that resolves the target host
+// itself, validates every resolved IP and dials a validated IP directly. DNS
+// is resolved at connect time for every connection — including each redirect
+// hop — so a rebinding answer between CheckSSRF and the dial cannot reach an
+// internal address. The dial to the operator-configured proxy for scope is
+// not rewritten; the proxy resolves the destination in that case.
+func ssrfSafeDialContext(scope string, dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if netproxy.IsProxyAddr(scope, addr) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("ssrf: split host/port from %q: %w", addr, err)
+		}
+		if isBlockedHostname(host) {
+			return nil, fmt.Errorf("ssrf: blocked hostname: %s", host)
+		}
+
+		var ips []net.IP
+		if ip := net.ParseIP(host); ip != nil {
+			ips = []net.IP{ip}
+		} else {
+			addrs, err := ssrfLookupIP(ctx, host)
+			if err != nil {
+				return nil, fmt.Errorf("ssrf: resolve %s: %w", host, err)
+			}
+			for _, a := range addrs {
+				ips = append(ips, a.IP)
+			}
+		}
+		if len(ips) == 0 {
+			return nil, fmt.Errorf("ssrf: %s resolved to no addresses", host)
+		}
+		for _, ip := range ips {
+			if err := checkSSRFIP(ip); err != nil {
+				slog.Warn("security.web.ssrf_block", "host", host, "ip", ip.String())
+				return nil, fmt.Errorf("ssrf: %s: %w", host, err)
+			}
+		}
+
+		var dialErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			dialErr = errors.Join(dialErr, err)
+		}
+		return nil, dialErr
+	}
+}
+
+// newSSRFSafeTransport returns a proxy-scoped transport whose dials are
+// pinned to validated IPs. Used by web_fetch and the web_search providers.
+func newSSRFSafeTransport(scope string) *http.Transport {
+	t := netproxy.Transport(scope)
+	t.DialContext = ssrfSafeDialContext(scope, &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	return t
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// withWebNetworkPolicy sets the CIDR policy for one test.
+func withWebNetworkPolicy(t *testing.T, allow, deny []string) {
+	t.Helper()
+	if err := SetWebNetworkPolicy(allow, deny); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetWebNetworkPolicy(nil, nil) })
+}
+
+func TestCheckSSRFIP_Policy(t *testing.T) {
+	withWebNetworkPolicy(t, []string{"10.20.0.0/16", "192.168.1.5"}, []string{"10.20.9.0/24", "8.8.8.8"})
+
+	cases := map[string]bool{ // ip → allowed
+		"93.184.216.34": true,
+		"10.1.1.1":      false, // private, not in allow list
+		"10.20.1.1":     true,  // allow list
+		"10.20.9.1":     false, // deny wins over allow
+		"192.168.1.5":   true,  // single IP entry
+		"192.168.1.6":   false,
+		"8.8.8.8":       false, // public but denied
+		"127.0.0.1":     false,
+		"224.0.0.1":     false, // multicast
+	}
+	for ip, want := range cases {
+		if got := checkSSRFIP(net.ParseIP(ip)) == nil; got != want {
+			t.Errorf("checkSSRFIP(%s) allowed = %v, want %v", ip, got, want)
+		}
+	}
+}
+
+func TestSetWebNetworkPolicy_InvalidKeepsPrevious(t *testing.T) {
+	withWebNetworkPolicy(t, nil, []string{"8.8.8.8"})
+	if err := SetWebNetworkPolicy(nil, []string{"not-a-cidr"}); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if checkSSRFIP(net.ParseIP("8.8.8.8")) == nil {
+		t.Error("previous deny list was dropped")
+	}
+}
+
+func TestSSRFSafeTransport_BlocksRebinding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "internal")
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	// The hostname passes the pre-flight check but resolves to loopback at dial time.
+	orig := ssrfLookupIP
+	ssrfLookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("127.0.0.1")}}, nil
+	}
+	defer func() { ssrfLookupIP = orig }()
+
+	client := &http.Client{Transport: newSSRFSafeTransport("tool:test")}
+	_, err := client.Get("http://rebind.example.com:" + port + "/")
+	if err == nil || !strings.Contains(err.Error(), "private IP address not allowed") {
+		t.Fatalf("err = %v, want SSRF block", err)
+	}
+
+	// An operator allow range opens it up, and the dial goes to the pinned IP.
+	withWebNetworkPolicy(t, []string{"127.0.0.0/8"}, nil)
+	resp, err := client.Get("http://rebind.example.com:" + port + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "internal" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestSSRFSafeTransport_RechecksRedirectHops(t *testing.T) {
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://metadata.example.com/latest", http.StatusFound)
+	}))
+	defer public.Close()
+
+	// The first hop is allowed; the redirect target resolves to an internal IP.
+	withWebNetworkPolicy(t, []string{"127.0.0.1"}, nil)
+	orig := ssrfLookupIP
+	ssrfLookupIP = func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		return []net.IPAddr{{IP: net.ParseIP("169.254.169.254")}}, nil
+	}
+	defer func() { ssrfLookupIP = orig }()
+
+	client := &http.Client{Transport: newSSRFSafeTransport("tool:test")}
+	_, err := client.Get(public.URL)
+	if err == nil || !strings.Contains(err.Error(), "169.254.169.254") {
+		t.Fatalf("err = %v, want SSRF block on redirect hop", err)
+	}
+}
@@ -87,7 +87,7 @@ func FormatError(s *SchemaStatus) string {
 		"Database schema is outdated: current v%d, required v%d.\n\n"+
 			"  Run:  ./goclaw upgrade\n"+
 			"  Or:   ./goclaw migrate up   (SQL-only, no data hooks)\n\n"+
-			"  Docker/CI: set GOCLAW_AUTO_UPGRADE=true to upgrade automatically on startup.\n",
+			"  Docker/CI: set GOCLAW_AUTO_UPGRADE=true (or database.auto_migrate) to upgrade automatically on startup.\n",
 		s.CurrentVersion, s.RequiredVersion,
 	)
 }
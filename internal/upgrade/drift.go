@@ -0,0 +1,269 @@
+package upgrade
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ExpectedSchema maps table → set of column names.
+type ExpectedSchema map[string]map[string]bool
+
+// DriftReport lists schema objects the migrations create but the database lacks.
+// Extra tables/columns (manual additions, extensions) are not drift.
+type DriftReport struct {
+	MissingTables  []string
+	MissingColumns []string // "table.column"
+}
+
+// HasDrift reports whether any expected object is missing.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.MissingTables) > 0 || len(r.MissingColumns) > 0
+}
+
+var (
+	migrationFileRe = regexp.MustCompile(`^(\d+)_.*\.(up|down)\.sql$`)
+	dollarQuoteRe   = regexp.MustCompile(`(?s)\$([A-Za-z_]*)\$.*?\$([A-Za-z_]*)\$`)
+	lineCommentRe   = regexp.MustCompile(`--[^\n]*`)
+	leadingIdentRe  = regexp.MustCompile(`^\s*("[^"]+"|\w+)`)
+	createTableRe   = regexp.MustCompile(`(?is)^CREATE\s+(?:UNLOGGED\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w."]+)\s*\((.*)\)`)
+	dropTableRe     = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(.+?)(?:\s+CASCADE|\s+RESTRICT)?$`)
+	alterTableRe    = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?([\w."]+)\s+(.*)$`)
+	addColumnRe     = regexp.MustCompile(`(?is)^ADD\s+COLUMN\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w"]+)`)
+	dropColumnRe    = regexp.MustCompile(`(?is)^DROP\s+COLUMN\s+(?:IF\s+EXISTS\s+)?([\w"]+)`)
+	renameColumnRe  = regexp.MustCompile(`(?is)^RENAME\s+(?:COLUMN\s+)?([\w"]+)\s+TO\s+([\w"]+)$`)
+	renameTableRe   = regexp.MustCompile(`(?is)^RENAME\s+TO\s+([\w."]+)$`)
+)
+
+// tableConstraintKeywords start CREATE TABLE elements that are not columns.
+var tableConstraintKeywords = []string{"CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "EXCLUDE", "LIKE"}
+
+// LoadExpectedSchema replays the CREATE/ALTER/DROP TABLE statements of the
+// *.up.sql files in dir up to and including version. Statements inside
+// dollar-quoted bodies (functions, DO blocks) are ignored: their DDL is
+// conditional and cannot be replayed statically.
+func LoadExpectedSchema(dir string, version uint) (ExpectedSchema, error) {
+	files, err := ListMigrations(dir, "up")
+	if err != nil {
+		return nil, err
+	}
+	schema := ExpectedSchema{}
+	for _, f := range files {
+		if f.Version > version {
+			break
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		schema.apply(string(data))
+	}
+	return schema, nil
+}
+
+// MigrationFile is one numbered migration file.
+type MigrationFile struct {
+	Version uint
+	Name    string
+}
+
+// ListMigrations returns the "up" or "down" migration files in dir, sorted by
+// version.
+func ListMigrations(dir, direction string) ([]MigrationFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+	var files []MigrationFile
+	for _, e := range entries {
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if m == nil || m[2] != direction {
+			continue
+		}
+		v, _ := strconv.ParseUint(m[1], 10, 64)
+		files = append(files, MigrationFile{Version: uint(v), Name: e.Name()})
+	}
+	slices.SortFunc(files, func(a, b MigrationFile) int { return cmp.Compare(a.Version, b.Version) })
+	return files, nil
+}
+
+// statements splits a migration file into top-level statements, blanking
+// dollar-quoted bodies and comments first.
+func statements(sqlText string) []string {
+	sqlText = dollarQuoteRe.ReplaceAllString(sqlText, "''")
+	sqlText = lineCommentRe.ReplaceAllString(sqlText, "")
+	var out []string
+	for _, stmt := range strings.Split(sqlText, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt != "" {
+			out = append(out, stmt)
+		}
+	}
+	return out
+}
+
+// apply replays the table DDL of one migration file.
+func (s ExpectedSchema) apply(sqlText string) {
+	for _, stmt := range statements(sqlText) {
+		switch {
+		case createTableRe.MatchString(stmt):
+			m := createTableRe.FindStringSubmatch(stmt)
+			cols := map[string]bool{}
+			for _, elem := range splitTopLevel(m[2]) {
+				name := leadingIdentRe.FindString(elem)
+				if name == "" || slices.Contains(tableConstraintKeywords, strings.ToUpper(strings.TrimSpace(name))) {
+					continue
+				}
+				cols[ident(name)] = true
+			}
+			s[ident(m[1])] = cols
+		case dropTableRe.MatchString(stmt):
+			for _, t := range strings.Split(dropTableRe.FindStringSubmatch(stmt)[1], ",") {
+				delete(s, ident(t))
+			}
+		case alterTableRe.MatchString(stmt):
+			m := alterTableRe.FindStringSubmatch(stmt)
+			s.alter(ident(m[1]), m[2])
+		}
+	}
+}
+
+func (s ExpectedSchema) alter(table, actions string) {
+	for _, action := range splitTopLevel(actions) {
+		action = strings.TrimSpace(action)
+		if m := renameTableRe.FindStringSubmatch(action); m != nil {
+			if cols, ok := s[table]; ok {
+				delete(s, table)
+				s[ident(m[1])] = cols
+			}
+			return
+		}
+		cols, ok := s[table]
+		if !ok {
+			continue // table created conditionally (DO block) — not tracked
+		}
+		switch {
+		case addColumnRe.MatchString(action):
+			cols[ident(addColumnRe.FindStringSubmatch(action)[1])] = true
+		case dropColumnRe.MatchString(action):
+			delete(cols, ident(dropColumnRe.FindStringSubmatch(action)[1]))
+		case renameColumnRe.MatchString(action):
+			m := renameColumnRe.FindStringSubmatch(action)
+			delete(cols, ident(m[1]))
+			cols[ident(m[2])] = true
+		}
+	}
+}
+
+// splitTopLevel splits s on commas outside parentheses and quotes.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	inQuote := false
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			inQuote = !inQuote
+		case inQuote:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// ident normalizes an identifier: unquoted, lowercase, without the public schema.
+func ident(s string) string {
+	s = strings.ToLower(strings.Trim(strings.TrimSpace(s), `"`))
+	return strings.TrimPrefix(s, "public.")
+}
+
+// DetectDrift compares expected against the tables and columns visible in the
+// current schema.
+func DetectDrift(ctx context.Context, db *sql.DB, expected ExpectedSchema) (*DriftReport, error) {
+	actual, err := loadActualSchema(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return diffSchema(expected, actual), nil
+}
+
+// loadActualSchema reads the tables and columns of the current schema.
+func loadActualSchema(ctx context.Context, db *sql.DB) (map[string]map[string]bool, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT table_name, column_name FROM information_schema.columns WHERE table_schema = current_schema()`)
+	if err != nil {
+		return nil, fmt.Errorf("query information_schema: %w", err)
+	}
+	defer rows.Close()
+
+	actual := map[string]map[string]bool{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		if actual[table] == nil {
+			actual[table] = map[string]bool{}
+		}
+		actual[table][column] = true
+	}
+	return actual, rows.Err()
+}
+
+func diffSchema(expected, actual map[string]map[string]bool) *DriftReport {
+	r := &DriftReport{}
+	for table, cols := range expected {
+		have, ok := actual[table]
+		if !ok {
+			r.MissingTables = append(r.MissingTables, table)
+			continue
+		}
+		for col := range cols {
+			if !have[col] {
+				r.MissingColumns = append(r.MissingColumns, table+"."+col)
+			}
+		}
+	}
+	slices.Sort(r.MissingTables)
+	slices.Sort(r.MissingColumns)
+	return r
+}
+
+// FormatDrift returns a user-friendly error message for a drift report.
+func FormatDrift(r *DriftReport, version uint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Database schema drift detected: schema_migrations says v%d but objects created by the migrations are missing.\n", version)
+	const maxListed = 10
+	list := func(label string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		shown := items[:min(len(items), maxListed)]
+		fmt.Fprintf(&b, "  Missing %s: %s", label, strings.Join(shown, ", "))
+		if len(items) > maxListed {
+			fmt.Fprintf(&b, " (+%d more)", len(items)-maxListed)
+		}
+		b.WriteString("\n")
+	}
+	list("tables", r.MissingTables)
+	list("columns", r.MissingColumns)
+	b.WriteString("\nThis usually means the database was restored from an older dump or altered by hand\n" +
+		"after migrations ran.\n\n" +
+		"  Inspect: ./goclaw doctor\n" +
+		"  Fix:     restore the missing objects (re-run the migration that creates them with\n" +
+		"           ./goclaw migrate force <version-before-it> && ./goclaw upgrade)\n" +
+		"  Bypass:  set database.schema_drift_check to \"warn\" (or GOCLAW_SCHEMA_DRIFT_CHECK=warn)\n")
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package upgrade
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpectedSchemaApply(t *testing.T) {
+	s := ExpectedSchema{}
+	s.apply(`
+CREATE TABLE IF NOT EXISTS widgets (
+    id UUID PRIMARY KEY,
+    "Name" TEXT NOT NULL DEFAULT 'a,b', -- trailing comment
+    size NUMERIC(10, 2),
+    UNIQUE(id, size),
+    CONSTRAINT widgets_size_check CHECK (size > 0)
+);
+CREATE TABLE scratch (id INT);
+ALTER TABLE widgets ADD COLUMN IF NOT EXISTS color TEXT, DROP COLUMN size;
+ALTER TABLE widgets RENAME COLUMN color TO colour;
+DO $$ BEGIN ALTER TABLE widgets ADD COLUMN ghost TEXT; END $$;
+DROP TABLE IF EXISTS scratch;
+ALTER TABLE widgets RENAME TO gadgets;
+`)
+	if _, ok := s["widgets"]; ok {
+		t.Error("widgets should have been renamed")
+	}
+	if _, ok := s["scratch"]; ok {
+		t.Error("scratch should have been dropped")
+	}
+	var cols []string
+	for c := range s["gadgets"] {
+		cols = append(cols, c)
+	}
+	slices.Sort(cols)
+	if want := []string{"colour", "id", "name"}; !slices.Equal(cols, want) {
+		t.Errorf("gadgets columns = %v, want %v", cols, want)
+	}
+}
+
+// TestLoadExpectedSchemaRepoMigrations guards the parser against the real
+// migrations: a false positive here would stop every gateway from starting.
+func TestLoadExpectedSchemaRepoMigrations(t *testing.T) {
+	s, err := LoadExpectedSchema("../../migrations", RequiredSchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for table, col := range map[string]string{
+		"agents":          "budget_monthly_cents",
+		"traces":          "parent_trace_id",
+		"vault_documents": "chat_id",
+		"hooks":           "name",
+	} {
+		if !s[table][col] {
+			t.Errorf("expected %s.%s in replayed schema", table, col)
+		}
+	}
+	for table, cols := range s {
+		for col := range cols {
+			if col == "unique" || col == "constraint" || col == "primary" {
+				t.Errorf("table constraint parsed as column: %s.%s", table, col)
+			}
+		}
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	expected := map[string]map[string]bool{
+		"agents": {"id": true, "tsv": true},
+		"hooks":  {"id": true},
+	}
+	actual := map[string]map[string]bool{
+		"agents": {"id": true, "extra": true},
+		"other":  {"id": true},
+	}
+	r := diffSchema(expected, actual)
+	if !r.HasDrift() {
+		t.Fatal("expected drift")
+	}
+	if !slices.Equal(r.MissingTables, []string{"hooks"}) || !slices.Equal(r.MissingColumns, []string{"agents.tsv"}) {
+		t.Errorf("report = %+v", r)
+	}
+	if diffSchema(expected, expected).HasDrift() {
+		t.Error("identical schemas should not drift")
+	}
+}
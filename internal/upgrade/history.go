@@ -0,0 +1,114 @@
+package upgrade
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// schema_migrations (golang-migrate) only stores the current version, so
+// goclaw keeps its own log of when each version was applied or rolled back.
+
+// MigrationStatus is one migration file and whether the database has it.
+// AppliedAt is zero for pending versions and for versions applied before the
+// history table existed (or by an external tool).
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+func ensureHistoryTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migration_history (
+			id         BIGSERIAL PRIMARY KEY,
+			version    BIGINT NOT NULL,
+			direction  VARCHAR(4) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	return err
+}
+
+// RecordMigrations logs the versions in dir crossed by moving the schema
+// from version `from` to version `to` (up when to > from, down otherwise).
+func RecordMigrations(ctx context.Context, db *sql.DB, dir string, from, to uint) error {
+	if from == to {
+		return nil
+	}
+	files, err := ListMigrations(dir, "up")
+	if err != nil {
+		return err
+	}
+	if err := ensureHistoryTable(ctx, db); err != nil {
+		return fmt.Errorf("ensure schema_migration_history table: %w", err)
+	}
+	direction, lo, hi := "up", from, to
+	if to < from {
+		direction, lo, hi = "down", to, from
+	}
+	for _, f := range files {
+		if f.Version <= lo || f.Version > hi {
+			continue
+		}
+		if _, err := db.ExecContext(ctx,
+			"INSERT INTO schema_migration_history (version, direction) VALUES ($1, $2)",
+			f.Version, direction,
+		); err != nil {
+			return fmt.Errorf("record migration %d: %w", f.Version, err)
+		}
+	}
+	return nil
+}
+
+// ListMigrationStatus lists the migration files in dir, marking those at or
+// below the current schema version as applied, with the time each was last
+// applied when known.
+func ListMigrationStatus(ctx context.Context, db *sql.DB, dir string, current uint) ([]MigrationStatus, error) {
+	files, err := ListMigrations(dir, "up")
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureHistoryTable(ctx, db); err != nil {
+		return nil, fmt.Errorf("ensure schema_migration_history table: %w", err)
+	}
+
+	// Latest event per version; a trailing "down" means the recorded apply time
+	// no longer holds (the version was re-applied by something else).
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT ON (version) version, direction, applied_at
+		FROM schema_migration_history
+		ORDER BY version, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migration_history: %w", err)
+	}
+	defer rows.Close()
+
+	appliedAt := map[uint]time.Time{}
+	for rows.Next() {
+		var version int64
+		var direction string
+		var at time.Time
+		if err := rows.Scan(&version, &direction, &at); err != nil {
+			return nil, err
+		}
+		if direction == "up" {
+			appliedAt[uint(version)] = at
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]MigrationStatus, 0, len(files))
+	for _, f := range files {
+		st := MigrationStatus{Version: f.Version, Name: f.Name, Applied: f.Version <= current}
+		if st.Applied {
+			st.AppliedAt = appliedAt[f.Version]
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
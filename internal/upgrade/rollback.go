@@ -0,0 +1,105 @@
+package upgrade
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DataAtRisk is a table or column that a rollback would drop while it still
+// holds data.
+type DataAtRisk struct {
+	Table  string
+	Column string // empty for a whole table
+}
+
+func (d DataAtRisk) String() string {
+	if d.Column == "" {
+		return "table " + d.Table
+	}
+	return "column " + d.Table + "." + d.Column
+}
+
+// rollbackDrops collects the tables and columns dropped by the *.down.sql
+// files that rolling back from version `from` to version `to` would run.
+func rollbackDrops(dir string, from, to uint) ([]DataAtRisk, error) {
+	files, err := ListMigrations(dir, "down")
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(files)
+
+	var drops []DataAtRisk
+	for _, f := range files {
+		if f.Version > from || f.Version <= to {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		for _, stmt := range statements(string(data)) {
+			if m := dropTableRe.FindStringSubmatch(stmt); m != nil {
+				for _, t := range strings.Split(m[1], ",") {
+					drops = append(drops, DataAtRisk{Table: ident(t)})
+				}
+				continue
+			}
+			m := alterTableRe.FindStringSubmatch(stmt)
+			if m == nil {
+				continue
+			}
+			for _, action := range splitTopLevel(m[2]) {
+				if c := dropColumnRe.FindStringSubmatch(strings.TrimSpace(action)); c != nil {
+					drops = append(drops, DataAtRisk{Table: ident(m[1]), Column: ident(c[1])})
+				}
+			}
+		}
+	}
+	return drops, nil
+}
+
+// CheckRollback returns the tables (non-empty) and columns (with non-NULL
+// values) that rolling back from version `from` to `to` would destroy.
+// Objects that do not exist in the database are skipped.
+func CheckRollback(ctx context.Context, db *sql.DB, dir string, from, to uint) ([]DataAtRisk, error) {
+	drops, err := rollbackDrops(dir, from, to)
+	if err != nil || len(drops) == 0 {
+		return nil, err
+	}
+	actual, err := loadActualSchema(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var atRisk []DataAtRisk
+	seen := map[DataAtRisk]bool{}
+	for _, d := range drops {
+		cols, ok := actual[d.Table]
+		if seen[d] || !ok || (d.Column != "" && !cols[d.Column]) {
+			continue
+		}
+		seen[d] = true
+		// Identifiers come from information_schema, so quoting is sufficient.
+		query := "SELECT EXISTS (SELECT 1 FROM " + quoteIdent(d.Table) + ")"
+		if d.Column != "" {
+			query = "SELECT EXISTS (SELECT 1 FROM " + quoteIdent(d.Table) + " WHERE " + quoteIdent(d.Column) + " IS NOT NULL)"
+		}
+		var hasData bool
+		if err := db.QueryRowContext(ctx, query).Scan(&hasData); err != nil {
+			return nil, fmt.Errorf("check %s: %w", d, err)
+		}
+		if hasData {
+			atRisk = append(atRisk, d)
+		}
+	}
+	return atRisk, nil
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
@@ -0,0 +1,49 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRollbackDrops(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"000001_init.down.sql":   "DROP TABLE IF EXISTS agents CASCADE;",
+		"000002_notes.down.sql":  "DROP TABLE notes;\nDROP INDEX IF EXISTS idx_notes;",
+		"000003_column.down.sql": "ALTER TABLE agents DROP COLUMN IF EXISTS emoji, DROP COLUMN \"Mood\";",
+		"000003_column.up.sql":   "ALTER TABLE agents ADD COLUMN emoji TEXT;",
+	}
+	for name, body := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	drops, err := rollbackDrops(dir, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range drops {
+		got = append(got, d.String())
+	}
+	// Newest first, in the order the down migrations run; v1 is the target and stays.
+	want := []string{"column agents.emoji", "column agents.mood", "table notes"}
+	if !slices.Equal(got, want) {
+		t.Errorf("rollbackDrops(3 -> 1) = %v, want %v", got, want)
+	}
+}
+
+// TestRepoDownMigrationsParse makes sure every down migration in the repo is
+// readable by the rollback pre-check.
+func TestRepoDownMigrationsParse(t *testing.T) {
+	drops, err := rollbackDrops("../../migrations", RequiredSchemaVersion, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(drops, DataAtRisk{Table: "vault_documents", Column: "chat_id"}) {
+		t.Errorf("expected vault_documents.chat_id among %d drops", len(drops))
+	}
+}
@@ -0,0 +1,13 @@
+-- Cross-replica session ownership for multi-instance gateways. A row means the
+-- gateway instance in `holder` is running turns on the session; other replicas
+-- wait until it is released or `expires_at` passes (crashed holder).
+CREATE TABLE IF NOT EXISTS session_leases (
+    tenant_id   UUID        NOT NULL,
+    session_key TEXT        NOT NULL,
+    holder      TEXT        NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (tenant_id, session_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_leases_holder ON session_leases(holder);
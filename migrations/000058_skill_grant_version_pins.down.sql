@@ -0,0 +1,3 @@
+ALTER TABLE skill_agent_grants ALTER COLUMN pinned_version DROP DEFAULT;
+UPDATE skill_agent_grants g SET pinned_version = s.version
+FROM skills s WHERE s.id = g.skill_id AND g.pinned_version = 0;
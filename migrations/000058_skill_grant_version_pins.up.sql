@@ -0,0 +1,6 @@
+-- skill_agent_grants.pinned_version becomes an explicit pin: 0 means the agent
+-- follows the skill's active version, N > 0 loads version N. Existing rows only
+-- recorded the version current at grant time (never enforced), so they go
+-- back to following the active version.
+UPDATE skill_agent_grants SET pinned_version = 0;
+ALTER TABLE skill_agent_grants ALTER COLUMN pinned_version SET DEFAULT 0;
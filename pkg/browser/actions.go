@@ -66,6 +66,9 @@ func (m *Manager) Press(ctx context.Context, targetID, key string) error {
 	tenantID := tenantIDFromCtx(ctx)
 	m.mu.Lock()
 	page, err := m.getPageForTenant(targetID, tenantID)
+	if err == nil {
+		m.bindDownloadsLocked(ctx, page)
+	}
 	m.mu.Unlock()
 	if err != nil {
 		return err
@@ -153,6 +156,9 @@ func (m *Manager) Evaluate(ctx context.Context, targetID, js string) (string, er
 	tenantID := tenantIDFromCtx(ctx)
 	m.mu.Lock()
 	page, err := m.getPageForTenant(targetID, tenantID)
+	if err == nil {
+		m.bindDownloadsLocked(ctx, page)
+	}
 	m.mu.Unlock()
 	if err != nil {
 		return "", err
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,11 +27,30 @@ type Manager struct {
 	pageLastUsed map[string]time.Time       // targetID → last access time
 	headless      bool
 	remoteURL     string        // CDP endpoint for remote Chrome (sidecar); skips local launcher
+	cdpHeader     http.Header   // headers for the remote CDP endpoint (e.g. Authorization)
 	actionTimeout time.Duration // per-action context timeout (default 30s)
 	idleTimeout   time.Duration // auto-close pages idle longer than this (default 10m, 0=disabled)
 	maxPages      int           // max open pages per tenant (default 5)
+	proxyServer   string        // --proxy-server for local Chrome (empty = direct)
+	proxyBypass   []string      // --proxy-bypass-list entries
+	profilesDir   string        // root for named profiles (empty = profiles disabled)
+	profile       string        // profile name when this Manager serves one
+	userDataDir   string        // persistent --user-data-dir (profiles only; empty = temporary)
+	opts          []Option      // options New was called with, reused for profiles
 	stopReaper    chan struct{} // signal to stop the reaper goroutine
 	logger        *slog.Logger
+
+	profiles map[string]*Manager // "<tenant>/<name>" → profile Manager
+
+	stagingDir      string                    // Chrome's download dir; files move to their target when done
+	downloadTargets map[string]DownloadTarget // targetID → where its downloads go
+	downloads       []*Download               // oldest first, at most maxDownloads
+	downloadHook    func(Download)            // called when a download finishes
+	stopDownloads   context.CancelFunc        // stops the download event watcher
+
+	block        BlockRules             // requests to fail via Fetch interception
+	stopBlocking context.CancelFunc     // stops request interception
+	netLogs      map[string]*networkLog // targetID → network capture
 }
 
 // Option configures a Manager.
@@ -65,6 +87,16 @@ func WithMaxPages(n int) Option {
 	return func(m *Manager) { m.maxPages = n }
 }
 
+// WithProxy routes local Chrome traffic through proxyServer
+// (e.g. "http://proxy:3128" or "socks5://proxy:1080"). Hosts in bypass
+// connect directly. Ignored for remote Chrome.
+func WithProxy(proxyServer string, bypass []string) Option {
+	return func(m *Manager) {
+		m.proxyServer = proxyServer
+		m.proxyBypass = bypass
+	}
+}
+
 // New creates a Manager with options.
 func New(opts ...Option) *Manager {
 	m := &Manager{
@@ -77,6 +109,8 @@ func New(opts ...Option) *Manager {
 		actionTimeout: 30 * time.Second,
 		idleTimeout:   10 * time.Minute,
 		maxPages:      5,
+		profiles:      make(map[string]*Manager),
+		opts:          opts,
 		logger:        slog.Default(),
 	}
 	for _, o := range opts {
@@ -115,12 +149,12 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	if m.remoteURL != "" {
 		// Remote Chrome sidecar — query /json/version and fix host for Docker networking
-		u, err := resolveRemoteCDP(m.remoteURL)
+		u, err := resolveRemoteCDP(m.remoteURL, m.cdpHeader)
 		if err != nil {
-			return fmt.Errorf("resolve remote Chrome at %s: %w", m.remoteURL, err)
+			return fmt.Errorf("resolve remote Chrome at %s: %w", RedactURL(m.remoteURL), err)
 		}
 		controlURL = u
-		m.logger.Info("connecting to remote Chrome", "cdp", controlURL, "remote", m.remoteURL)
+		m.logger.Info("connecting to remote Chrome", "cdp", RedactURL(controlURL), "remote", RedactURL(m.remoteURL))
 	} else {
 		// Local Chrome — launch via rod launcher with stability flags
 		launchCtx, launchCancel := context.WithTimeout(ctx, 30*time.Second)
@@ -140,6 +174,18 @@ func (m *Manager) Start(ctx context.Context) error {
 			Set("disable-renderer-backgrounding").
 			Set("disable-background-timer-throttling").
 			Set("disable-backgrounding-occluded-windows")
+		if m.userDataDir != "" {
+			if err := os.MkdirAll(m.userDataDir, 0700); err != nil {
+				return fmt.Errorf("create profile dir: %w", err)
+			}
+			l = l.UserDataDir(m.userDataDir)
+		}
+		if m.proxyServer != "" {
+			l = l.Set("proxy-server", m.proxyServer)
+			if len(m.proxyBypass) > 0 {
+				l = l.Set("proxy-bypass-list", strings.Join(m.proxyBypass, ";"))
+			}
+		}
 
 		u, err := l.Launch()
 		if err != nil {
@@ -147,24 +193,30 @@ func (m *Manager) Start(ctx context.Context) error {
 		}
 		controlURL = u
 		m.launcher = l
-		m.logger.Info("Chrome launched", "cdp", controlURL, "headless", m.headless, "pid", l.PID())
+		m.logger.Info("Chrome launched", "cdp", controlURL, "headless", m.headless, "pid", l.PID(), "profile", m.profile)
 	}
 
 	connectCtx, connectCancel := context.WithTimeout(ctx, 15*time.Second)
 	defer connectCancel()
 
-	b := rod.New().Context(connectCtx).ControlURL(controlURL)
-	if err := b.Connect(); err != nil {
+	var b *rod.Browser
+	var err error
+	if m.remoteURL != "" {
+		b, err = m.dialRemoteCDP(connectCtx, controlURL)
+	} else {
+		b = rod.New().Context(connectCtx).ControlURL(controlURL)
+		err = b.Connect()
+	}
+	if err != nil {
 		// If local launch succeeded but connect failed, kill the orphan process
-		if m.launcher != nil {
-			m.launcher.Kill()
-			m.launcher.Cleanup()
-			m.launcher = nil
-		}
+		m.killLauncherLocked()
 		return fmt.Errorf("connect to Chrome: %w", err)
 	}
 
 	m.browser = b
+	m.enableDownloadsLocked(b)
+	m.watchDownloadsLocked()
+	m.startBlockingLocked()
 
 	// Start idle-page reaper if configured
 	if m.idleTimeout > 0 && m.stopReaper == nil {
@@ -195,17 +247,16 @@ func (m *Manager) Stop(ctx context.Context) error {
 	}
 
 	m.closeTenantContextsLocked()
+	m.stopDownloadsLocked()
+	m.stopBlockingLocked()
+	m.resetNetworkLocked()
 
 	var err error
 	if m.remoteURL == "" {
 		// Local Chrome — close the browser process
 		err = m.browser.Close()
 		// Force-kill via launcher if retained
-		if m.launcher != nil {
-			m.launcher.Kill()
-			m.launcher.Cleanup()
-			m.launcher = nil
-		}
+		m.killLauncherLocked()
 	}
 	// Remote Chrome — just drop the connection; sidecar stays alive
 
@@ -214,6 +265,7 @@ func (m *Manager) Stop(ctx context.Context) error {
 	m.console = make(map[string][]ConsoleMessage)
 	m.pageTenants = make(map[string]string)
 	m.pageLastUsed = make(map[string]time.Time)
+	m.downloadTargets = make(map[string]DownloadTarget)
 	return err
 }
 
@@ -231,16 +283,16 @@ func (m *Manager) closeTenantContextsLocked() {
 // Must be called with mu held.
 func (m *Manager) cleanupDeadBrowserLocked() {
 	m.closeTenantContextsLocked()
-	if m.launcher != nil {
-		m.launcher.Kill()
-		m.launcher.Cleanup()
-		m.launcher = nil
-	}
+	m.stopDownloadsLocked()
+	m.stopBlockingLocked()
+	m.resetNetworkLocked()
+	m.killLauncherLocked()
 	m.browser = nil
 	m.pages = make(map[string]*rod.Page)
 	m.console = make(map[string][]ConsoleMessage)
 	m.pageTenants = make(map[string]string)
 	m.pageLastUsed = make(map[string]time.Time)
+	m.downloadTargets = make(map[string]DownloadTarget)
 	m.refs = NewRefStore()
 }
 
@@ -255,8 +307,8 @@ func (m *Manager) tenantBrowserLocked(tenantID string) (*rod.Browser, error) {
 	if m.browser == nil {
 		return nil, fmt.Errorf("browser not running")
 	}
-	// Master tenant or no tenant: use main browser
-	if tenantID == "" || tenantID == MasterTenantID {
+	// Master tenant, no tenant or a profile (already tenant-scoped by its dir): use main browser
+	if tenantID == "" || tenantID == MasterTenantID || m.userDataDir != "" {
 		return m.browser, nil
 	}
 	// Return existing incognito context
@@ -269,23 +321,32 @@ func (m *Manager) tenantBrowserLocked(tenantID string) (*rod.Browser, error) {
 		return nil, fmt.Errorf("create incognito context for tenant %s: %w", tenantID, err)
 	}
 	m.tenantCtxs[tenantID] = incognito
+	m.enableDownloadsLocked(incognito)
 	m.logger.Info("created incognito browser context", "tenant", tenantID)
 	return incognito, nil
 }
 
 // Status returns current browser status.
+// connected reports whether a browser connection exists (without probing it).
+func (m *Manager) connected() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.browser != nil
+}
+
 func (m *Manager) Status() *StatusInfo {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if m.browser == nil {
-		return &StatusInfo{Running: false}
+		return &StatusInfo{Running: false, Profile: m.profile}
 	}
 
 	pages, _ := m.browser.Pages()
 	info := &StatusInfo{
 		Running: true,
 		Tabs:    len(pages),
+		Profile: m.profile,
 	}
 	if len(pages) > 0 {
 		if pageInfo, err := pages[0].Info(); err == nil {
@@ -0,0 +1,114 @@
+package browser
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// blockPresets are well-known ad and analytics domains (subdomains included).
+var blockPresets = map[string][]string{
+	"ads": {
+		"doubleclick.net", "googlesyndication.com", "googleadservices.com", "adservice.google.com",
+		"adnxs.com", "amazon-adsystem.com", "criteo.com", "criteo.net", "taboola.com", "outbrain.com",
+		"pubmatic.com", "rubiconproject.com", "openx.net", "adsrvr.org", "moatads.com", "casalemedia.com",
+	},
+	"analytics": {
+		"google-analytics.com", "googletagmanager.com", "analytics.google.com", "segment.io", "segment.com",
+		"mixpanel.com", "hotjar.com", "fullstory.com", "amplitude.com", "heap.io", "heapanalytics.com",
+		"clarity.ms", "scorecardresearch.com", "quantserve.com", "newrelic.com", "nr-data.net",
+		"connect.facebook.net", "plausible.io",
+	},
+}
+
+// BlockPreset returns the domains of a named preset ("ads", "analytics").
+func BlockPreset(name string) ([]string, bool) {
+	d, ok := blockPresets[name]
+	return d, ok
+}
+
+// BlockRules decides which requests the browser refuses to send. Domains
+// match themselves and their subdomains. Non-network URLs (data:, blob:,
+// about:, chrome:) are never blocked.
+type BlockRules struct {
+	Domains      []string // blocked domains
+	AllowDomains []string // when set, every other domain is blocked
+}
+
+// WithBlockRules blocks matching requests in every page via CDP Fetch
+// interception. Blocked requests fail with ERR_BLOCKED_BY_CLIENT.
+func WithBlockRules(r BlockRules) Option {
+	return func(m *Manager) { m.block = r }
+}
+
+func (r BlockRules) empty() bool {
+	return len(r.Domains) == 0 && len(r.AllowDomains) == 0
+}
+
+// Blocks reports whether a request to rawURL is blocked.
+func (r BlockRules) Blocks(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if matchDomain(host, r.Domains) {
+		return true
+	}
+	return len(r.AllowDomains) > 0 && !matchDomain(host, r.AllowDomains)
+}
+
+func matchDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(d), ".")
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// startBlockingLocked pauses every request of the connected browser and
+// fails those the rules block. Must be called with mu held.
+func (m *Manager) startBlockingLocked() {
+	if m.block.empty() || m.browser == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := m.browser.Context(ctx)
+	enable := proto.FetchEnable{Patterns: []*proto.FetchRequestPattern{{URLPattern: "*"}}}
+	if err := enable.Call(b); err != nil {
+		cancel()
+		m.logger.Warn("request blocking disabled: enable fetch interception", "error", err)
+		return
+	}
+	m.stopBlocking = cancel
+	rules := m.block
+	go b.EachEvent(func(e *proto.FetchRequestPaused) {
+		go resolvePaused(b, rules, e)
+	})()
+}
+
+func resolvePaused(b *rod.Browser, rules BlockRules, e *proto.FetchRequestPaused) {
+	if e.Request != nil && rules.Blocks(e.Request.URL) {
+		_ = proto.FetchFailRequest{RequestID: e.RequestID, ErrorReason: proto.NetworkErrorReasonBlockedByClient}.Call(b)
+		return
+	}
+	_ = proto.FetchContinueRequest{RequestID: e.RequestID}.Call(b)
+}
+
+// stopBlockingLocked stops request interception. Must be called with mu held.
+func (m *Manager) stopBlockingLocked() {
+	if m.stopBlocking != nil {
+		m.stopBlocking()
+		m.stopBlocking = nil
+	}
+}
@@ -0,0 +1,300 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Download states.
+const (
+	DownloadInProgress = "in_progress"
+	DownloadCompleted  = "completed"
+	DownloadCanceled   = "canceled"
+	DownloadFailed     = "failed"
+)
+
+// maxDownloads bounds the download history a Manager keeps; the oldest
+// finished download is dropped first.
+const maxDownloads = 200
+
+// DownloadTarget says where downloads started by a caller's pages are saved
+// and whom they are reported to.
+type DownloadTarget struct {
+	Dir        string // destination directory, created on first download
+	SessionKey string
+	UserID     string
+	AgentKey   string
+}
+
+// Download is one file downloaded by a browser page.
+type Download struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	Filename   string    `json:"filename"`
+	Path       string    `json:"path,omitempty"` // saved file (completed only)
+	Size       int64     `json:"size"`
+	State      string    `json:"state"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitzero"`
+
+	TargetID   string `json:"-"` // tab that started the download
+	TenantID   string `json:"-"`
+	SessionKey string `json:"-"`
+	UserID     string `json:"-"`
+	AgentKey   string `json:"-"`
+	dir        string // target directory, fixed when the download starts
+}
+
+// browserDownloadKey is a context key for passing the download target.
+type browserDownloadKey struct{}
+
+// WithDownloadTarget returns a context whose page actions send downloads to t.
+func WithDownloadTarget(ctx context.Context, t DownloadTarget) context.Context {
+	return context.WithValue(ctx, browserDownloadKey{}, t)
+}
+
+func downloadTargetFromCtx(ctx context.Context) (DownloadTarget, bool) {
+	t, ok := ctx.Value(browserDownloadKey{}).(DownloadTarget)
+	return t, ok && t.Dir != ""
+}
+
+// SetDownloadHook registers fn to be called when a download finishes
+// (completed, canceled or failed), including downloads of profile browsers.
+func (m *Manager) SetDownloadHook(fn func(Download)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.downloadHook = fn
+	for _, p := range m.profiles {
+		p.mu.Lock()
+		p.downloadHook = fn
+		p.mu.Unlock()
+	}
+}
+
+// Downloads returns the downloads of a session, newest first, including
+// those of profile browsers.
+func (m *Manager) Downloads(sessionKey string) []Download {
+	m.mu.Lock()
+	var out []Download
+	for _, d := range m.downloads {
+		if d.SessionKey == sessionKey {
+			out = append(out, *d)
+		}
+	}
+	profiles := make([]*Manager, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		profiles = append(profiles, p)
+	}
+	m.mu.Unlock()
+
+	for _, p := range profiles {
+		out = append(out, p.Downloads(sessionKey)...)
+	}
+	slices.SortFunc(out, func(a, b Download) int { return b.StartedAt.Compare(a.StartedAt) })
+	return out
+}
+
+// bindDownloadsLocked sends downloads started by page to the caller's
+// download target. Must be called with mu held.
+func (m *Manager) bindDownloadsLocked(ctx context.Context, page *rod.Page) {
+	if t, ok := downloadTargetFromCtx(ctx); ok {
+		if m.downloadTargets == nil {
+			m.downloadTargets = make(map[string]DownloadTarget)
+		}
+		m.downloadTargets[string(page.TargetID)] = t
+	}
+}
+
+// enableDownloadsLocked lets b's browser context download into the staging
+// dir. Files are named by download ID until they are moved to their target.
+// Local Chrome only: a remote Chrome saves to its own filesystem.
+// Must be called with mu held.
+func (m *Manager) enableDownloadsLocked(b *rod.Browser) {
+	if m.remoteURL != "" {
+		return
+	}
+	if m.stagingDir == "" {
+		dir, err := os.MkdirTemp("", "goclaw-downloads-")
+		if err != nil {
+			m.logger.Warn("browser downloads disabled: create staging dir", "error", err)
+			return
+		}
+		m.stagingDir = dir
+	}
+	err := proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     m.stagingDir,
+		EventsEnabled:    true,
+	}.Call(b)
+	if err != nil {
+		m.logger.Warn("failed to enable browser downloads", "error", err)
+	}
+}
+
+// watchDownloadsLocked follows download events of the connected browser
+// until stopDownloadsLocked. Must be called with mu held.
+func (m *Manager) watchDownloadsLocked() {
+	if m.remoteURL != "" || m.browser == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stopDownloads = cancel
+	b := m.browser.Context(ctx)
+	go b.EachEvent(
+		func(e *proto.BrowserDownloadWillBegin) { m.downloadBegan(b, e) },
+		func(e *proto.BrowserDownloadProgress) { m.downloadProgressed(e) },
+	)()
+}
+
+// stopDownloadsLocked stops the download watcher. Must be called with mu held.
+func (m *Manager) stopDownloadsLocked() {
+	if m.stopDownloads != nil {
+		m.stopDownloads()
+		m.stopDownloads = nil
+	}
+}
+
+func (m *Manager) downloadBegan(b *rod.Browser, e *proto.BrowserDownloadWillBegin) {
+	// The frame is the tab's main frame. A download opened in a new tab
+	// (target=_blank) belongs to the tab that opened it.
+	targetID := string(e.FrameID)
+	m.mu.Lock()
+	_, known := m.downloadTargets[targetID]
+	m.mu.Unlock()
+	if !known {
+		if info, err := (proto.TargetGetTargetInfo{TargetID: proto.TargetTargetID(targetID)}).Call(b); err == nil && info.TargetInfo.OpenerID != "" {
+			targetID = string(info.TargetInfo.OpenerID)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d := &Download{
+		ID:        e.GUID,
+		URL:       e.URL,
+		Filename:  e.SuggestedFilename,
+		State:     DownloadInProgress,
+		StartedAt: time.Now().UTC(),
+		TargetID:  targetID,
+		TenantID:  m.pageTenants[targetID],
+	}
+	if t, ok := m.downloadTargets[targetID]; ok {
+		d.SessionKey, d.UserID, d.AgentKey, d.dir = t.SessionKey, t.UserID, t.AgentKey, t.Dir
+	}
+	m.downloads = append(m.downloads, d)
+	if len(m.downloads) > maxDownloads {
+		if i := slices.IndexFunc(m.downloads, func(d *Download) bool { return d.State != DownloadInProgress }); i >= 0 {
+			m.downloads = slices.Delete(m.downloads, i, i+1)
+		}
+	}
+	m.logger.Info("browser download started", "id", d.ID, "url", d.URL, "targetId", targetID)
+}
+
+func (m *Manager) downloadProgressed(e *proto.BrowserDownloadProgress) {
+	m.mu.Lock()
+	i := slices.IndexFunc(m.downloads, func(d *Download) bool { return d.ID == e.GUID })
+	if i < 0 || m.downloads[i].State != DownloadInProgress {
+		m.mu.Unlock()
+		return
+	}
+	d := m.downloads[i]
+	d.Size = int64(e.ReceivedBytes)
+	if e.State == proto.BrowserDownloadProgressStateInProgress {
+		m.mu.Unlock()
+		return
+	}
+	dir := d.dir
+	staged := filepath.Join(m.stagingDir, d.ID)
+	filename := d.Filename
+	m.mu.Unlock()
+
+	// Move the file out of the staging dir before reporting it.
+	var path string
+	state := DownloadCompleted
+	var errMsg string
+	switch {
+	case e.State == proto.BrowserDownloadProgressStateCanceled:
+		state = DownloadCanceled
+		_ = os.Remove(staged)
+	case dir == "":
+		state, errMsg = DownloadFailed, "tab has no download directory"
+		_ = os.Remove(staged)
+	default:
+		var err error
+		if path, err = saveDownload(staged, dir, filename); err != nil {
+			state, errMsg = DownloadFailed, err.Error()
+			_ = os.Remove(staged)
+		}
+	}
+
+	m.mu.Lock()
+	d.State, d.Path, d.Error = state, path, errMsg
+	d.FinishedAt = time.Now().UTC()
+	done := *d
+	hook := m.downloadHook
+	m.mu.Unlock()
+
+	m.logger.Info("browser download finished", "id", done.ID, "state", done.State, "path", done.Path, "size", done.Size, "error", done.Error)
+	if hook != nil {
+		hook(done)
+	}
+}
+
+// saveDownload moves a staged download into dir under its suggested name,
+// adding " (n)" when the name is taken. Returns the saved path.
+func saveDownload(staged, dir, filename string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create download dir: %w", err)
+	}
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		name = "download"
+	}
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	path := filepath.Join(dir, name)
+	for n := 1; ; n++ {
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			break
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", stem, n, ext))
+	}
+	if err := os.Rename(staged, path); err == nil {
+		return path, nil
+	}
+	// Staging and target may be on different filesystems.
+	if err := copyFile(staged, path); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("save download: %w", err)
+	}
+	_ = os.Remove(staged)
+	return path, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
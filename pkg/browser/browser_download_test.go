@@ -0,0 +1,94 @@
+package browser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+func TestDownloadFlow(t *testing.T) {
+	ws := t.TempDir()
+	m := New()
+	m.stagingDir = t.TempDir()
+	target := DownloadTarget{Dir: DownloadDir(ws, "agent:a:ws:direct:1"), SessionKey: "agent:a:ws:direct:1", UserID: "u1"}
+	m.downloadTargets = map[string]DownloadTarget{"T1": target}
+	m.pageTenants["T1"] = "tenant-a"
+
+	var finished []Download
+	m.SetDownloadHook(func(d Download) { finished = append(finished, d) })
+
+	if err := os.WriteFile(filepath.Join(m.stagingDir, "g1"), []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.downloadBegan(nil, &proto.BrowserDownloadWillBegin{FrameID: "T1", GUID: "g1", URL: "https://example.com/r.csv", SuggestedFilename: "report.csv"})
+	m.downloadProgressed(&proto.BrowserDownloadProgress{GUID: "g1", ReceivedBytes: 2, State: proto.BrowserDownloadProgressStateInProgress})
+	if got := m.Downloads(target.SessionKey); len(got) != 1 || got[0].State != DownloadInProgress || got[0].Size != 2 {
+		t.Fatalf("in-progress downloads = %+v", got)
+	}
+	m.downloadProgressed(&proto.BrowserDownloadProgress{GUID: "g1", ReceivedBytes: 4, State: proto.BrowserDownloadProgressStateCompleted})
+
+	want := filepath.Join(target.Dir, "report.csv")
+	if data, err := os.ReadFile(want); err != nil || string(data) != "a,b\n" {
+		t.Fatalf("saved file: %q, %v", data, err)
+	}
+	if len(finished) != 1 || finished[0].Path != want || finished[0].State != DownloadCompleted ||
+		finished[0].UserID != "u1" || finished[0].TenantID != "tenant-a" {
+		t.Errorf("hook got %+v", finished)
+	}
+	if got := m.Downloads("other-session"); len(got) != 0 {
+		t.Errorf("other session sees %+v", got)
+	}
+
+	// The tool lists workspace-relative paths for the session.
+	ctx := tools.WithToolWorkspace(tools.WithToolSessionKey(context.Background(), target.SessionKey), ws)
+	res := NewDownloadsTool(m).Execute(ctx, map[string]any{})
+	if !strings.Contains(res.ForLLM, filepath.Join("downloads", "agent_a_ws_direct_1", "report.csv")) || strings.Contains(res.ForLLM, ws) {
+		t.Errorf("tool result = %s", res.ForLLM)
+	}
+}
+
+func TestDownloadWithoutTargetFails(t *testing.T) {
+	m := New()
+	m.stagingDir = t.TempDir()
+	m.downloadTargets = map[string]DownloadTarget{"T1": {}}
+	staged := filepath.Join(m.stagingDir, "g1")
+	if err := os.WriteFile(staged, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m.downloadBegan(nil, &proto.BrowserDownloadWillBegin{FrameID: "T1", GUID: "g1", SuggestedFilename: "x.bin"})
+	m.downloadProgressed(&proto.BrowserDownloadProgress{GUID: "g1", State: proto.BrowserDownloadProgressStateCompleted})
+
+	if m.downloads[0].State != DownloadFailed {
+		t.Errorf("state = %s", m.downloads[0].State)
+	}
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Error("staged file should be removed")
+	}
+}
+
+func TestSaveDownload_UniqueSafeNames(t *testing.T) {
+	staging, dir := t.TempDir(), t.TempDir()
+	for i, name := range []string{"a.txt", "a.txt", "../../etc/passwd", ""} {
+		staged := filepath.Join(staging, "s")
+		if err := os.WriteFile(staged, []byte{byte(i)}, 0644); err != nil {
+			t.Fatal(err)
+		}
+		path, err := saveDownload(staged, dir, name)
+		if err != nil {
+			t.Fatalf("saveDownload(%q): %v", name, err)
+		}
+		if filepath.Dir(path) != dir {
+			t.Errorf("saveDownload(%q) escaped dir: %s", name, path)
+		}
+		want := []string{"a.txt", "a (1).txt", "passwd", "download"}[i]
+		if filepath.Base(path) != want {
+			t.Errorf("saveDownload(%q) = %s, want %s", name, filepath.Base(path), want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package browser
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HAR 1.2 (http://www.softwareishard.com/blog/har-12-spec/). Only the fields
+// browsers and HAR viewers need are filled; bodies are not captured.
+
+type harLog struct {
+	Log harContent `json:"log"`
+}
+
+type harContent struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Pages   []harPage  `json:"pages"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     map[string]int `json:"pageTimings"`
+}
+
+type harEntry struct {
+	PageRef         string      `json:"pageref"`
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string  `json:"method"`
+	URL         string  `json:"url"`
+	HTTPVersion string  `json:"httpVersion"`
+	Cookies     []harNV `json:"cookies"`
+	Headers     []harNV `json:"headers"`
+	QueryString []harNV `json:"queryString"`
+	HeadersSize int     `json:"headersSize"`
+	BodySize    int     `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNV        `json:"cookies"`
+	Headers     []harNV        `json:"headers"`
+	Content     harContentBody `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harContentBody struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+type harNV struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// harTimings are in milliseconds; -1 means not applicable.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// redactedHeaders hold credentials; their values are not exported.
+var redactedHeaders = []string{"authorization", "proxy-authorization", "cookie", "set-cookie"}
+
+func buildHAR(entries []NetworkEntry, pageURL, title string, startedAt time.Time) ([]byte, error) {
+	if title == "" {
+		title = pageURL
+	}
+	doc := harLog{Log: harContent{
+		Version: "1.2",
+		Creator: harCreator{Name: "goclaw", Version: "1.0"},
+		Pages: []harPage{{
+			StartedDateTime: startedAt.Format(time.RFC3339Nano),
+			ID:              "page_1",
+			Title:           title,
+			PageTimings:     map[string]int{"onContentLoad": -1, "onLoad": -1},
+		}},
+		Entries: make([]harEntry, 0, len(entries)),
+	}}
+	for _, e := range entries {
+		doc.Log.Entries = append(doc.Log.Entries, harEntryFor(e))
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func harEntryFor(e NetworkEntry) harEntry {
+	httpVersion := harHTTPVersion(e.Protocol)
+	entry := harEntry{
+		PageRef:         "page_1",
+		StartedDateTime: e.StartedAt.Format(time.RFC3339Nano),
+		Time:            e.DurationMs,
+		Request: harRequest{
+			Method:      e.Method,
+			URL:         e.URL,
+			HTTPVersion: httpVersion,
+			Cookies:     []harNV{},
+			Headers:     harHeaders(e.requestHeaders),
+			QueryString: harQuery(e.URL),
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Response: harResponse{
+			Status:      e.Status,
+			StatusText:  e.StatusText,
+			HTTPVersion: httpVersion,
+			Cookies:     []harNV{},
+			Headers:     harHeaders(e.responseHeaders),
+			Content:     harContentBody{Size: e.Size, MimeType: e.MimeType},
+			RedirectURL: e.redirectURL,
+			HeadersSize: -1,
+			BodySize:    e.Size,
+		},
+		Timings: harTimingsFor(e),
+		Comment: e.Error,
+	}
+	if e.Status == 0 { // failed or never answered
+		entry.Response.BodySize = -1
+	}
+	return entry
+}
+
+func harTimingsFor(e NetworkEntry) harTimings {
+	t := harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 0, Wait: e.DurationMs, Receive: 0}
+	rt := e.timing
+	if rt == nil {
+		return t
+	}
+	span := func(start, end float64) float64 {
+		if start < 0 || end < start {
+			return -1
+		}
+		return end - start
+	}
+	t.DNS = span(rt.DNSStart, rt.DNSEnd)
+	t.Connect = span(rt.ConnectStart, rt.ConnectEnd)
+	t.SSL = span(rt.SslStart, rt.SslEnd)
+	t.Send = max(span(rt.SendStart, rt.SendEnd), 0)
+	t.Wait = max(span(rt.SendEnd, rt.ReceiveHeadersEnd), 0)
+	t.Receive = max(e.DurationMs-rt.ReceiveHeadersEnd, 0)
+	return t
+}
+
+func harHTTPVersion(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "h2":
+		return "HTTP/2.0"
+	case "h3", "h3-29":
+		return "HTTP/3.0"
+	case "":
+		return "HTTP/1.1"
+	}
+	return strings.ToUpper(protocol)
+}
+
+func harHeaders(h map[string]string) []harNV {
+	out := make([]harNV, 0, len(h))
+	for k, v := range h {
+		if slices.Contains(redactedHeaders, strings.ToLower(k)) {
+			v = "[redacted]"
+		}
+		out = append(out, harNV{Name: k, Value: v})
+	}
+	slices.SortFunc(out, func(a, b harNV) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+func harQuery(rawURL string) []harNV {
+	out := []harNV{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return out
+	}
+	for k, vs := range u.Query() {
+		for _, v := range vs {
+			out = append(out, harNV{Name: k, Value: v})
+		}
+	}
+	slices.SortFunc(out, func(a, b harNV) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
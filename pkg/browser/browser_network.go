@@ -0,0 +1,253 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// maxNetworkEntries bounds the requests recorded per tab; the oldest are
+// dropped first.
+const maxNetworkEntries = 1000
+
+// NetworkEntry is one request recorded on a tab.
+type NetworkEntry struct {
+	URL        string    `json:"url"`
+	Method     string    `json:"method"`
+	Type       string    `json:"type,omitempty"` // Document, Script, XHR, ...
+	Status     int       `json:"status,omitempty"`
+	StatusText string    `json:"statusText,omitempty"`
+	MimeType   string    `json:"mimeType,omitempty"`
+	Protocol   string    `json:"protocol,omitempty"`
+	Size       int64     `json:"size,omitempty"` // bytes received over the wire
+	Error      string    `json:"error,omitempty"`
+	Blocked    bool      `json:"blocked,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs float64   `json:"durationMs,omitempty"` // 0 while pending
+
+	redirectURL     string
+	requestHeaders  map[string]string
+	responseHeaders map[string]string
+	timing          *proto.NetworkResourceTiming
+	start           proto.MonotonicTime
+}
+
+// networkLog records one tab's requests. Fields are guarded by Manager.mu.
+type networkLog struct {
+	entries   []*NetworkEntry
+	pending   map[proto.NetworkRequestID]*NetworkEntry
+	startedAt time.Time
+	stop      context.CancelFunc
+}
+
+func newNetworkLog() *networkLog {
+	return &networkLog{pending: make(map[proto.NetworkRequestID]*NetworkEntry), startedAt: time.Now().UTC()}
+}
+
+func (l *networkLog) requestWillBeSent(e *proto.NetworkRequestWillBeSent) {
+	if e.Request == nil {
+		return
+	}
+	// A redirect reuses the request ID: close the previous hop first.
+	if prev := l.pending[e.RequestID]; prev != nil && e.RedirectResponse != nil {
+		prev.response(e.RedirectResponse)
+		prev.redirectURL = e.Request.URL
+		prev.finish(e.Timestamp)
+	}
+	entry := &NetworkEntry{
+		URL:            e.Request.URL,
+		Method:         e.Request.Method,
+		Type:           string(e.Type),
+		StartedAt:      e.WallTime.Time().UTC(),
+		requestHeaders: headerMap(e.Request.Headers),
+		start:          e.Timestamp,
+	}
+	l.pending[e.RequestID] = entry
+	l.entries = append(l.entries, entry)
+	if len(l.entries) > maxNetworkEntries {
+		dropped := l.entries[0]
+		l.entries = l.entries[1:]
+		for id, p := range l.pending {
+			if p == dropped {
+				delete(l.pending, id)
+			}
+		}
+	}
+}
+
+func (l *networkLog) responseReceived(e *proto.NetworkResponseReceived) {
+	if entry := l.pending[e.RequestID]; entry != nil && e.Response != nil {
+		entry.response(e.Response)
+		if entry.Type == "" {
+			entry.Type = string(e.Type)
+		}
+	}
+}
+
+func (l *networkLog) loadingFinished(e *proto.NetworkLoadingFinished) {
+	if entry := l.pending[e.RequestID]; entry != nil {
+		entry.Size = int64(e.EncodedDataLength)
+		entry.finish(e.Timestamp)
+		delete(l.pending, e.RequestID)
+	}
+}
+
+func (l *networkLog) loadingFailed(e *proto.NetworkLoadingFailed) {
+	if entry := l.pending[e.RequestID]; entry != nil {
+		entry.Error = e.ErrorText
+		entry.Blocked = e.BlockedReason != "" || strings.Contains(e.ErrorText, "ERR_BLOCKED_BY_CLIENT")
+		entry.finish(e.Timestamp)
+		delete(l.pending, e.RequestID)
+	}
+}
+
+func (e *NetworkEntry) response(r *proto.NetworkResponse) {
+	e.Status = r.Status
+	e.StatusText = r.StatusText
+	e.MimeType = r.MIMEType
+	e.Protocol = r.Protocol
+	e.timing = r.Timing
+	e.responseHeaders = headerMap(r.Headers)
+	if len(r.RequestHeaders) > 0 { // what was actually sent, incl. cookies
+		e.requestHeaders = headerMap(r.RequestHeaders)
+	}
+}
+
+func (e *NetworkEntry) finish(ts proto.MonotonicTime) {
+	if ts > e.start {
+		e.DurationMs = float64(ts-e.start) * 1000
+	}
+}
+
+func headerMap(h proto.NetworkHeaders) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = v.Str()
+	}
+	return out
+}
+
+// StartNetworkCapture starts recording the tab's requests and returns its
+// target ID. Requests made before the call are not captured.
+func (m *Manager) StartNetworkCapture(ctx context.Context, targetID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	page, err := m.getPageForTenant(targetID, tenantIDFromCtx(ctx))
+	if err != nil {
+		return "", err
+	}
+	tid := string(page.TargetID)
+	if _, ok := m.netLogs[tid]; ok {
+		return tid, nil
+	}
+	if err := (proto.NetworkEnable{}).Call(page); err != nil {
+		return "", fmt.Errorf("enable network events: %w", err)
+	}
+	log := newNetworkLog()
+	watchCtx, cancel := context.WithCancel(context.Background())
+	log.stop = cancel
+	if m.netLogs == nil {
+		m.netLogs = make(map[string]*networkLog)
+	}
+	m.netLogs[tid] = log
+	m.watchNetwork(page.Context(watchCtx), log)
+	return tid, nil
+}
+
+func (m *Manager) watchNetwork(page *rod.Page, log *networkLog) {
+	locked := func(fn func()) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		fn()
+	}
+	go page.EachEvent(
+		func(e *proto.NetworkRequestWillBeSent) { locked(func() { log.requestWillBeSent(e) }) },
+		func(e *proto.NetworkResponseReceived) { locked(func() { log.responseReceived(e) }) },
+		func(e *proto.NetworkLoadingFinished) { locked(func() { log.loadingFinished(e) }) },
+		func(e *proto.NetworkLoadingFailed) { locked(func() { log.loadingFailed(e) }) },
+	)()
+}
+
+// StopNetworkCapture stops recording the tab and discards what it recorded.
+func (m *Manager) StopNetworkCapture(ctx context.Context, targetID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	page, err := m.getPageForTenant(targetID, tenantIDFromCtx(ctx))
+	if err != nil {
+		return err
+	}
+	m.stopNetworkLocked(string(page.TargetID))
+	_ = proto.NetworkDisable{}.Call(page)
+	return nil
+}
+
+// NetworkEntries returns the tab's recorded requests, oldest first, and
+// whether the tab is being recorded.
+func (m *Manager) NetworkEntries(ctx context.Context, targetID string) ([]NetworkEntry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	page, err := m.getPageForTenant(targetID, tenantIDFromCtx(ctx))
+	if err != nil {
+		return nil, false, err
+	}
+	log, ok := m.netLogs[string(page.TargetID)]
+	if !ok {
+		return nil, false, nil
+	}
+	out := make([]NetworkEntry, len(log.entries))
+	for i, e := range log.entries {
+		out[i] = *e
+	}
+	return out, true, nil
+}
+
+// HAR exports the tab's recorded requests as a HAR 1.2 document.
+// Authorization and cookie header values are redacted.
+func (m *Manager) HAR(ctx context.Context, targetID string) ([]byte, int, error) {
+	m.mu.Lock()
+	page, err := m.getPageForTenant(targetID, tenantIDFromCtx(ctx))
+	if err != nil {
+		m.mu.Unlock()
+		return nil, 0, err
+	}
+	log, ok := m.netLogs[string(page.TargetID)]
+	if !ok {
+		m.mu.Unlock()
+		return nil, 0, fmt.Errorf("network capture is not running on this tab: start it first")
+	}
+	entries := make([]NetworkEntry, len(log.entries))
+	for i, e := range log.entries {
+		entries[i] = *e
+	}
+	startedAt := log.startedAt
+	m.mu.Unlock()
+
+	title, pageURL := "", ""
+	if info, err := page.Info(); err == nil && info != nil {
+		title, pageURL = info.Title, info.URL
+	}
+	data, err := buildHAR(entries, pageURL, title, startedAt)
+	return data, len(entries), err
+}
+
+// stopNetworkLocked stops recording a tab. Must be called with mu held.
+func (m *Manager) stopNetworkLocked(targetID string) {
+	if log, ok := m.netLogs[targetID]; ok {
+		log.stop()
+		delete(m.netLogs, targetID)
+	}
+}
+
+// resetNetworkLocked stops recording all tabs. Must be called with mu held.
+func (m *Manager) resetNetworkLocked() {
+	for tid := range m.netLogs {
+		m.stopNetworkLocked(tid)
+	}
+}
@@ -0,0 +1,157 @@
+package browser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestNetworkLog(t *testing.T) {
+	l := newNetworkLog()
+	wall := proto.TimeSinceEpoch(1700000000)
+
+	l.requestWillBeSent(&proto.NetworkRequestWillBeSent{
+		RequestID: "1", Timestamp: 10, WallTime: wall, Type: proto.NetworkResourceTypeDocument,
+		Request: &proto.NetworkRequest{URL: "http://example.com/", Method: "GET"},
+	})
+	// Redirect to https reuses the request ID.
+	l.requestWillBeSent(&proto.NetworkRequestWillBeSent{
+		RequestID: "1", Timestamp: 10.05, WallTime: wall, Type: proto.NetworkResourceTypeDocument,
+		Request:          &proto.NetworkRequest{URL: "https://example.com/", Method: "GET"},
+		RedirectResponse: &proto.NetworkResponse{Status: 301, StatusText: "Moved Permanently"},
+	})
+	l.responseReceived(&proto.NetworkResponseReceived{
+		RequestID: "1", Type: proto.NetworkResourceTypeDocument,
+		Response: &proto.NetworkResponse{Status: 200, StatusText: "OK", MIMEType: "text/html", Protocol: "h2"},
+	})
+	l.loadingFinished(&proto.NetworkLoadingFinished{RequestID: "1", Timestamp: 10.25, EncodedDataLength: 5120})
+
+	l.requestWillBeSent(&proto.NetworkRequestWillBeSent{
+		RequestID: "2", Timestamp: 10.3, WallTime: wall, Type: proto.NetworkResourceTypeScript,
+		Request: &proto.NetworkRequest{URL: "https://www.googletagmanager.com/gtm.js", Method: "GET"},
+	})
+	l.loadingFailed(&proto.NetworkLoadingFailed{RequestID: "2", Timestamp: 10.31, ErrorText: "net::ERR_BLOCKED_BY_CLIENT"})
+
+	if len(l.entries) != 3 || len(l.pending) != 0 {
+		t.Fatalf("entries = %d, pending = %d", len(l.entries), len(l.pending))
+	}
+	hop, doc, gtm := l.entries[0], l.entries[1], l.entries[2]
+	if hop.Status != 301 || hop.redirectURL != "https://example.com/" || int(hop.DurationMs) != 50 {
+		t.Errorf("redirect hop = %+v", hop)
+	}
+	if doc.Status != 200 || doc.Size != 5120 || doc.Protocol != "h2" || int(doc.DurationMs+0.5) != 200 {
+		t.Errorf("document = %+v", doc)
+	}
+	if !gtm.Blocked || gtm.Error == "" {
+		t.Errorf("blocked script = %+v", gtm)
+	}
+}
+
+func TestNetworkLogBounded(t *testing.T) {
+	l := newNetworkLog()
+	for i := 0; i < maxNetworkEntries+5; i++ {
+		l.requestWillBeSent(&proto.NetworkRequestWillBeSent{
+			RequestID: proto.NetworkRequestID(strings.Repeat("x", i+1)),
+			Request:   &proto.NetworkRequest{URL: "https://example.com/", Method: "GET"},
+		})
+	}
+	if len(l.entries) != maxNetworkEntries || len(l.pending) != maxNetworkEntries {
+		t.Fatalf("entries = %d, pending = %d", len(l.entries), len(l.pending))
+	}
+}
+
+func TestBuildHAR(t *testing.T) {
+	entries := []NetworkEntry{{
+		URL: "https://example.com/api?q=go&page=2", Method: "GET", Status: 200, StatusText: "OK",
+		MimeType: "application/json", Protocol: "h2", Size: 42, DurationMs: 120,
+		StartedAt:       time.Unix(1700000000, 0).UTC(),
+		requestHeaders:  map[string]string{"Authorization": "Bearer secret", "Accept": "*/*"},
+		responseHeaders: map[string]string{"Set-Cookie": "sid=secret", "Content-Type": "application/json"},
+		timing:          &proto.NetworkResourceTiming{DNSStart: -1, DNSEnd: -1, ConnectStart: -1, ConnectEnd: -1, SslStart: -1, SslEnd: -1, SendStart: 1, SendEnd: 2, ReceiveHeadersEnd: 100},
+	}, {
+		URL: "https://ads.doubleclick.net/x.js", Method: "GET", Error: "net::ERR_BLOCKED_BY_CLIENT", Blocked: true,
+		StartedAt: time.Unix(1700000001, 0).UTC(),
+	}}
+	data, err := buildHAR(entries, "https://example.com/", "Example", time.Unix(1700000000, 0).UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("HAR leaks credentials:\n%s", data)
+	}
+
+	var doc harLog
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Log.Version != "1.2" || len(doc.Log.Pages) != 1 || len(doc.Log.Entries) != 2 {
+		t.Fatalf("log = %+v", doc.Log)
+	}
+	e := doc.Log.Entries[0]
+	if e.Request.HTTPVersion != "HTTP/2.0" || len(e.Request.QueryString) != 2 || e.Response.Content.Size != 42 {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Timings.DNS != -1 || e.Timings.Send != 1 || e.Timings.Wait != 98 || e.Timings.Receive != 20 {
+		t.Errorf("timings = %+v", e.Timings)
+	}
+	if blocked := doc.Log.Entries[1]; blocked.Response.Status != 0 || blocked.Response.BodySize != -1 || blocked.Comment == "" {
+		t.Errorf("blocked entry = %+v", blocked)
+	}
+}
+
+func TestBlockRules(t *testing.T) {
+	ads, ok := BlockPreset("ads")
+	if !ok {
+		t.Fatal("ads preset missing")
+	}
+	if _, ok := BlockPreset("nope"); ok {
+		t.Fatal("unknown preset found")
+	}
+
+	deny := BlockRules{Domains: append([]string{"Tracker.example"}, ads...)}
+	allow := BlockRules{AllowDomains: []string{"example.com"}}
+	tests := []struct {
+		rules BlockRules
+		url   string
+		want  bool
+	}{
+		{deny, "https://securepubads.g.doubleclick.net/tag.js", true},
+		{deny, "https://doubleclick.net/", true},
+		{deny, "https://notdoubleclick.net/", false},
+		{deny, "wss://tracker.example./socket", true},
+		{deny, "https://example.com/", false},
+		{deny, "data:text/html,doubleclick.net", false},
+		{allow, "https://example.com/", false},
+		{allow, "https://cdn.example.com/app.js", false},
+		{allow, "https://other.org/", true},
+		{allow, "blob:https://other.org/1", false},
+		{allow, "about:blank", false},
+		{BlockRules{}, "https://doubleclick.net/", false},
+	}
+	for _, tt := range tests {
+		if got := tt.rules.Blocks(tt.url); got != tt.want {
+			t.Errorf("Blocks(%q) with %+v = %v, want %v", tt.url, tt.rules, got, tt.want)
+		}
+	}
+}
+
+func TestFormatNetworkEntries(t *testing.T) {
+	entries := []NetworkEntry{
+		{URL: "https://example.com/", Method: "GET", Type: "Document", Status: 200, DurationMs: 153, Size: 1200},
+		{URL: "https://example.com/api", Method: "POST", Type: "XHR"},
+		{URL: "https://doubleclick.net/x.js", Method: "GET", Error: "net::ERR_BLOCKED_BY_CLIENT", Blocked: true},
+	}
+	out := formatNetworkEntries(entries, "example.com", 1)
+	if !strings.Contains(out, "3 request(s) recorded, 2 matching, showing 1") ||
+		!strings.Contains(out, "pending POST https://example.com/api [XHR]") || strings.Contains(out, "Document") {
+		t.Errorf("filtered output:\n%s", out)
+	}
+	out = formatNetworkEntries(entries, "", 10)
+	if !strings.Contains(out, "200 GET https://example.com/ [Document] 153ms 1200B") ||
+		!strings.Contains(out, "BLOCKED GET https://doubleclick.net/x.js\n") {
+		t.Errorf("full output:\n%s", out)
+	}
+}
@@ -3,11 +3,15 @@ package browser
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/proto"
+	"github.com/google/uuid"
+
+	"github.com/nextlevelbuilder/goclaw/internal/store"
 )
 
 // watchPageClose spawns a goroutine that closes page when ctx is cancelled.
@@ -42,12 +46,12 @@ func (m *Manager) Snapshot(ctx context.Context, targetID string, opts SnapshotOp
 		return nil, err
 	}
 
-	result, err := proto.AccessibilityGetFullAXTree{}.Call(page)
+	frames, err := snapshotFrames(page)
 	if err != nil {
 		return nil, fmt.Errorf("get AX tree: %w", err)
 	}
 
-	snap := FormatSnapshot(result.Nodes, opts)
+	snap := FormatFrameSnapshot(frames, opts)
 	info, _ := page.Info()
 	snap.TargetID = targetID
 	if info != nil {
@@ -86,6 +90,9 @@ func (m *Manager) Navigate(ctx context.Context, targetID, url string) error {
 	tenantID := tenantIDFromCtx(ctx)
 	m.mu.Lock()
 	page, err := m.getPageForTenant(targetID, tenantID)
+	if err == nil {
+		m.bindDownloadsLocked(ctx, page)
+	}
 	m.mu.Unlock()
 
 	if err != nil {
@@ -111,9 +118,46 @@ func (m *Manager) Navigate(ctx context.Context, targetID, url string) error {
 	return nil
 }
 
-// Close shuts down the browser if running.
+// RenderHTML loads url in a throwaway tab, waits for it to settle and returns
+// the rendered DOM and the URL the page ended up on. The tab is closed before
+// returning. Used by web_fetch's JavaScript rendering fallback.
+func (m *Manager) RenderHTML(ctx context.Context, url string) (html, finalURL string, err error) {
+	if tid := store.TenantIDFromContext(ctx); tid != uuid.Nil {
+		ctx = WithTenantID(ctx, tid.String())
+	}
+	if err := m.Start(ctx); err != nil {
+		return "", "", fmt.Errorf("start browser: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.ActionTimeout())
+	defer cancel()
+
+	tab, err := m.OpenTab(ctx, url)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() { _ = m.CloseTab(context.WithoutCancel(ctx), tab.TargetID) }()
+
+	html, err = m.Evaluate(ctx, tab.TargetID, `() => document.documentElement.outerHTML`)
+	if err != nil {
+		return "", "", err
+	}
+	return html, tab.URL, nil
+}
+
+// Close shuts down the browser and all profile browsers, and removes the
+// download staging dir.
 func (m *Manager) Close() error {
-	return m.Stop(context.Background())
+	m.stopProfiles()
+	err := m.Stop(context.Background())
+
+	m.mu.Lock()
+	dir := m.stagingDir
+	m.stagingDir = ""
+	m.mu.Unlock()
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+	return err
 }
 
 // Refs returns the RefStore for external use (e.g. actions).
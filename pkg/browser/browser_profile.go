@@ -0,0 +1,103 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// profileNameRe restricts profile names to safe directory names.
+var profileNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// ValidProfileName reports whether name can be used as a browser profile.
+func ValidProfileName(name string) bool {
+	return profileNameRe.MatchString(name)
+}
+
+// WithProfilesDir enables named profiles. Each profile runs its own local
+// Chrome with a persistent user-data dir under dir/<tenant>/<name>, so
+// cookies and localStorage survive restarts. Ignored for remote Chrome.
+func WithProfilesDir(dir string) Option {
+	return func(m *Manager) { m.profilesDir = dir }
+}
+
+// Profile returns the Manager for a named profile, creating it on first use.
+// An empty name returns m itself (ephemeral browser). Profiles are scoped to
+// the caller's tenant; agents of the same tenant share a profile by name.
+func (m *Manager) Profile(ctx context.Context, name string) (*Manager, error) {
+	if name == "" {
+		return m, nil
+	}
+	if !ValidProfileName(name) {
+		return nil, fmt.Errorf("invalid browser profile %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	if m.remoteURL != "" {
+		return nil, fmt.Errorf("browser profiles need a local Chrome (not supported with remote_url)")
+	}
+	if m.profilesDir == "" {
+		return nil, fmt.Errorf("browser profiles are not enabled")
+	}
+
+	scope := tenantIDFromCtx(ctx)
+	if scope == "" || scope == MasterTenantID {
+		scope = "master"
+	}
+	key := scope + "/" + name
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.profiles[key]; ok {
+		return p, nil
+	}
+	p := New(m.opts...)
+	p.profilesDir = ""
+	p.profile = name
+	p.userDataDir = filepath.Join(m.profilesDir, scope, name)
+	p.logger = m.logger.With("profile", key)
+	p.downloadHook = m.downloadHook
+	m.profiles[key] = p
+	return p, nil
+}
+
+// Profiles lists the profile keys ("<tenant>/<name>") used since startup.
+func (m *Manager) Profiles() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.profiles))
+	for k := range m.profiles {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// stopProfiles closes every profile browser. Their user-data dirs are kept.
+func (m *Manager) stopProfiles() {
+	m.mu.Lock()
+	profiles := make([]*Manager, 0, len(m.profiles))
+	for _, p := range m.profiles {
+		profiles = append(profiles, p)
+	}
+	m.mu.Unlock()
+
+	for _, p := range profiles {
+		if err := p.Close(); err != nil {
+			p.logger.Warn("failed to stop profile browser", "error", err)
+		}
+	}
+}
+
+// killLauncherLocked force-kills a locally launched Chrome. A temporary
+// user-data dir is removed; a profile's is kept. Must be called with mu held.
+func (m *Manager) killLauncherLocked() {
+	if m.launcher == nil {
+		return
+	}
+	m.launcher.Kill()
+	if m.userDataDir == "" {
+		m.launcher.Cleanup()
+	}
+	m.launcher = nil
+}
@@ -47,6 +47,8 @@ func (m *Manager) reapIdlePages() {
 		delete(m.pages, targetID)
 		delete(m.console, targetID)
 		delete(m.pageTenants, targetID)
+		delete(m.downloadTargets, targetID)
+		m.stopNetworkLocked(targetID)
 		delete(m.pageLastUsed, targetID)
 		m.refs.Remove(targetID)
 		m.logger.Info("reaper: closed idle page", "targetId", targetID, "idle", now.Sub(lastUsed).Round(time.Second))
@@ -11,30 +11,56 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/proto"
 )
 
+// WithCDPHeader sets HTTP headers sent to the remote CDP endpoint, on both the
+// /json/version lookup and the WebSocket handshake (e.g. Authorization for a
+// hosted browser service). Ignored for local Chrome.
+func WithCDPHeader(h http.Header) Option {
+	return func(m *Manager) { m.cdpHeader = h }
+}
+
+// dialRemoteCDP connects to a remote Chrome's WebSocket URL with the
+// configured headers.
+func (m *Manager) dialRemoteCDP(ctx context.Context, controlURL string) (*rod.Browser, error) {
+	client, err := cdp.StartWithURL(ctx, controlURL, m.cdpHeader)
+	if err != nil {
+		return nil, err
+	}
+	b := rod.New().Context(ctx).Client(client)
+	if err := b.Connect(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 // reconnectLocked re-establishes the CDP connection to a remote Chrome.
 // Must be called with m.mu held. Only works when remoteURL is set.
 func (m *Manager) reconnectLocked() error {
 	m.closeTenantContextsLocked()
+	m.stopBlockingLocked()
+	m.resetNetworkLocked()
 	m.browser = nil
 	m.pages = make(map[string]*rod.Page)
 	m.console = make(map[string][]ConsoleMessage)
 	m.pageTenants = make(map[string]string)
 	m.pageLastUsed = make(map[string]time.Time)
+	m.downloadTargets = make(map[string]DownloadTarget)
 	m.refs = NewRefStore()
 
-	controlURL, err := resolveRemoteCDP(m.remoteURL)
+	controlURL, err := resolveRemoteCDP(m.remoteURL, m.cdpHeader)
 	if err != nil {
 		return err
 	}
 
-	b := rod.New().ControlURL(controlURL)
-	if err := b.Connect(); err != nil {
+	b, err := m.dialRemoteCDP(context.Background(), controlURL)
+	if err != nil {
 		return err
 	}
 	m.browser = b
+	m.startBlockingLocked()
 	return nil
 }
 
@@ -165,13 +191,23 @@ func (m *Manager) resolveElement(page *rod.Page, targetID, ref string) (*rod.Ele
 		return nil, fmt.Errorf("no backendNodeID for ref %q", ref)
 	}
 
+	// Backend node IDs are per process: an element in an out-of-process
+	// iframe must be resolved through that frame's target.
+	owner, err := framePage(page, roleRef)
+	if err != nil {
+		return nil, fmt.Errorf("attach to frame of %q: %w", ref, err)
+	}
+	if owner != page {
+		_ = proto.DOMEnable{}.Call(owner)
+	}
+
 	backendID := proto.DOMBackendNodeID(roleRef.BackendNodeID)
-	resolved, err := proto.DOMResolveNode{BackendNodeID: backendID}.Call(page)
+	resolved, err := proto.DOMResolveNode{BackendNodeID: backendID}.Call(owner)
 	if err != nil {
 		return nil, fmt.Errorf("resolve DOM node for %q (backendNodeID=%d): %w", ref, roleRef.BackendNodeID, err)
 	}
 
-	el, err := page.ElementFromObject(resolved.Object)
+	el, err := owner.ElementFromObject(resolved.Object)
 	if err != nil {
 		return nil, fmt.Errorf("get element from object for %q: %w", ref, err)
 	}
@@ -184,6 +220,9 @@ func (m *Manager) getPageAndResolve(ctx context.Context, targetID, ref string) (
 	tenantID := tenantIDFromCtx(ctx)
 	m.mu.Lock()
 	page, err := m.getPageForTenant(targetID, tenantID)
+	if err == nil {
+		m.bindDownloadsLocked(ctx, page)
+	}
 	m.mu.Unlock()
 	if err != nil {
 		return nil, nil, err
@@ -205,8 +244,15 @@ func waitStable(page *rod.Page) {
 	_ = page.WaitStable(300 * time.Millisecond)
 }
 
-// resolveRemoteCDP queries a Chrome endpoint's /json/version to get the CDP
-// WebSocket URL, resolving the hostname to an IP address.
+// resolveRemoteCDP turns a remote Chrome endpoint into the CDP WebSocket URL
+// to dial:
+//
+//   - ws://host:port (a sidecar): /json/version is queried on the host's IP
+//     and the returned URL is rewritten to that IP.
+//   - ws:// with a path or query, or any wss:// URL: used as given
+//     (e.g. a browserless URL carrying its token).
+//   - https://host: /json/version is queried on the host and the returned
+//     URL is rewritten to wss://host.
 //
 // Chrome (M113+) rejects HTTP/WebSocket requests where the Host header is a
 // hostname (not an IP or "localhost") to prevent DNS rebinding attacks.
@@ -216,11 +262,17 @@ func waitStable(page *rod.Page) {
 // cdpHTTPClient is used for /json/version queries with a reasonable timeout.
 var cdpHTTPClient = &http.Client{Timeout: 10 * time.Second}
 
-func resolveRemoteCDP(remoteURL string) (string, error) {
+func resolveRemoteCDP(remoteURL string, header http.Header) (string, error) {
 	parsed, err := url.Parse(remoteURL)
 	if err != nil {
 		return "", fmt.Errorf("parse remote URL: %w", err)
 	}
+	switch {
+	case parsed.Scheme == "wss", parsed.Scheme == "ws" && (strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != ""):
+		return remoteURL, nil
+	case parsed.Scheme == "https":
+		return resolveHostedCDP(parsed, header)
+	}
 
 	host := parsed.Hostname()
 	port := parsed.Port()
@@ -237,34 +289,85 @@ func resolveRemoteCDP(remoteURL string) (string, error) {
 
 	// Query /json/version using the IP (so Host header is an IP).
 	versionURL := fmt.Sprintf("http://%s:%s/json/version", ip, port)
-	resp, err := cdpHTTPClient.Get(versionURL) //nolint:gosec // resolved from user-configured URL
+	wsURL, err := queryCDPVersion(versionURL, header)
+	if err != nil {
+		return "", err
+	}
+
+	// Replace host in returned URL with the resolved IP.
+	// Chrome returns ws://127.0.0.1/... but we need ws://<container-IP>:<port>/...
+	wsURL.Host = net.JoinHostPort(ip, port)
+	return wsURL.String(), nil
+}
+
+// resolveHostedCDP looks up the WebSocket URL of a Chrome served over HTTPS
+// (behind a TLS proxy or a hosted service). The URL's query, which such
+// services use for tokens, is kept on the WebSocket URL.
+func resolveHostedCDP(endpoint *url.URL, header http.Header) (string, error) {
+	versionURL := *endpoint
+	versionURL.Path = strings.TrimSuffix(endpoint.Path, "/") + "/json/version"
+	wsURL, err := queryCDPVersion(versionURL.String(), header)
+	if err != nil {
+		return "", err
+	}
+	wsURL.Scheme = "wss"
+	wsURL.Host = endpoint.Host
+	if wsURL.RawQuery == "" {
+		wsURL.RawQuery = endpoint.RawQuery
+	}
+	return wsURL.String(), nil
+}
+
+// queryCDPVersion fetches /json/version and returns its webSocketDebuggerUrl.
+func queryCDPVersion(versionURL string, header http.Header) (*url.URL, error) {
+	req, err := http.NewRequest(http.MethodGet, versionURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	resp, err := cdpHTTPClient.Do(req) //nolint:gosec // resolved from user-configured URL
 	if err != nil {
-		return "", fmt.Errorf("query /json/version at %s: %w", versionURL, err)
+		return nil, fmt.Errorf("query /json/version at %s: %w", RedactURL(versionURL), err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("/json/version returned HTTP %d", resp.StatusCode)
+		return nil, fmt.Errorf("/json/version returned HTTP %d", resp.StatusCode)
 	}
 
 	var ver struct {
 		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&ver); err != nil {
-		return "", fmt.Errorf("parse /json/version: %w", err)
+		return nil, fmt.Errorf("parse /json/version: %w", err)
 	}
 	if ver.WebSocketDebuggerURL == "" {
-		return "", fmt.Errorf("empty webSocketDebuggerUrl in /json/version response")
+		return nil, fmt.Errorf("empty webSocketDebuggerUrl in /json/version response")
 	}
-
-	// Replace host in returned URL with the resolved IP.
-	// Chrome returns ws://127.0.0.1/... but we need ws://<container-IP>:<port>/...
 	wsURL, err := url.Parse(ver.WebSocketDebuggerURL)
 	if err != nil {
-		return "", fmt.Errorf("parse webSocketDebuggerUrl: %w", err)
+		return nil, fmt.Errorf("parse webSocketDebuggerUrl: %w", err)
 	}
-	wsURL.Host = net.JoinHostPort(ip, port)
-	return wsURL.String(), nil
+	return wsURL, nil
+}
+
+// RedactURL hides a URL's query and userinfo, where CDP services put tokens.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	if u.User != nil {
+		u.User = url.User("redacted")
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = "redacted"
+	}
+	return u.String()
 }
 
 // resolveToIPv4 resolves a hostname to an IPv4 address.
@@ -104,6 +104,7 @@ func (m *Manager) OpenTab(ctx context.Context, url string) (*TabInfo, error) {
 	tid := string(page.TargetID)
 	m.pages[tid] = page
 	m.touchPageLocked(tid)
+	m.bindDownloadsLocked(ctx, page)
 	if tenantID != "" {
 		m.pageTenants[tid] = tenantID
 	}
@@ -168,6 +169,8 @@ func (m *Manager) evictOldestIfOverLimitLocked(tenantID string) {
 	delete(m.pages, oldestID)
 	delete(m.console, oldestID)
 	delete(m.pageTenants, oldestID)
+	delete(m.downloadTargets, oldestID)
+	m.stopNetworkLocked(oldestID)
 	delete(m.pageLastUsed, oldestID)
 	m.refs.Remove(oldestID)
 	m.logger.Info("evicted oldest page (max pages reached)", "targetId", oldestID, "tenant", tenantID)
@@ -202,6 +205,8 @@ func (m *Manager) CloseTab(ctx context.Context, targetID string) error {
 	delete(m.pages, targetID)
 	delete(m.console, targetID)
 	delete(m.pageTenants, targetID)
+	delete(m.downloadTargets, targetID)
+	m.stopNetworkLocked(targetID)
 	delete(m.pageLastUsed, targetID)
 	m.refs.Remove(targetID)
 	return page.Close()
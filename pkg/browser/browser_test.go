@@ -1,12 +1,16 @@
 package browser
 
 import (
+	"context"
 	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
 )
 
 // --- resolveToIPv4 ---
@@ -72,7 +76,7 @@ func TestResolveRemoteCDP_Success(t *testing.T) {
 	// Extract host:port from test server URL.
 	wsURL := "ws://" + srv.Listener.Addr().String()
 
-	got, err := resolveRemoteCDP(wsURL)
+	got, err := resolveRemoteCDP(wsURL, nil)
 	if err != nil {
 		t.Fatalf("resolveRemoteCDP(%q) error: %v", wsURL, err)
 	}
@@ -95,7 +99,7 @@ func TestResolveRemoteCDP_NonOKStatus(t *testing.T) {
 	defer srv.Close()
 
 	wsURL := "ws://" + srv.Listener.Addr().String()
-	_, err := resolveRemoteCDP(wsURL)
+	_, err := resolveRemoteCDP(wsURL, nil)
 	if err == nil {
 		t.Fatal("expected error for 500 status, got nil")
 	}
@@ -114,7 +118,7 @@ func TestResolveRemoteCDP_EmptyWebSocketURL(t *testing.T) {
 	defer srv.Close()
 
 	wsURL := "ws://" + srv.Listener.Addr().String()
-	_, err := resolveRemoteCDP(wsURL)
+	_, err := resolveRemoteCDP(wsURL, nil)
 	if err == nil {
 		t.Fatal("expected error for empty webSocketDebuggerUrl, got nil")
 	}
@@ -130,7 +134,7 @@ func TestResolveRemoteCDP_InvalidJSON(t *testing.T) {
 	defer srv.Close()
 
 	wsURL := "ws://" + srv.Listener.Addr().String()
-	_, err := resolveRemoteCDP(wsURL)
+	_, err := resolveRemoteCDP(wsURL, nil)
 	if err == nil {
 		t.Fatal("expected error for invalid JSON, got nil")
 	}
@@ -138,14 +142,14 @@ func TestResolveRemoteCDP_InvalidJSON(t *testing.T) {
 
 func TestResolveRemoteCDP_ConnectionRefused(t *testing.T) {
 	// Use a port that's definitely not listening.
-	_, err := resolveRemoteCDP("ws://127.0.0.1:1")
+	_, err := resolveRemoteCDP("ws://127.0.0.1:1", nil)
 	if err == nil {
 		t.Fatal("expected error for connection refused, got nil")
 	}
 }
 
 func TestResolveRemoteCDP_InvalidURL(t *testing.T) {
-	_, err := resolveRemoteCDP("://invalid")
+	_, err := resolveRemoteCDP("://invalid", nil)
 	if err == nil {
 		t.Fatal("expected error for invalid URL, got nil")
 	}
@@ -154,7 +158,7 @@ func TestResolveRemoteCDP_InvalidURL(t *testing.T) {
 func TestResolveRemoteCDP_DefaultPort(t *testing.T) {
 	// Verify that when port is omitted, 9222 is used.
 	// This will fail to connect but the error should reference port 9222.
-	_, err := resolveRemoteCDP("ws://127.0.0.1")
+	_, err := resolveRemoteCDP("ws://127.0.0.1", nil)
 	if err == nil {
 		t.Fatal("expected error (nothing on 9222), got nil")
 	}
@@ -178,7 +182,7 @@ func TestResolveRemoteCDP_HostReplacement(t *testing.T) {
 	defer srv.Close()
 
 	wsURL := "ws://" + srv.Listener.Addr().String()
-	got, err := resolveRemoteCDP(wsURL)
+	got, err := resolveRemoteCDP(wsURL, nil)
 	if err != nil {
 		t.Fatalf("resolveRemoteCDP(%q) error: %v", wsURL, err)
 	}
@@ -192,6 +196,79 @@ func TestResolveRemoteCDP_HostReplacement(t *testing.T) {
 	}
 }
 
+func TestResolveRemoteCDP_SendsHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"webSocketDebuggerUrl": "ws://127.0.0.1:9222/devtools/browser/abc",
+		})
+	}))
+	defer srv.Close()
+
+	wsURL := "ws://" + srv.Listener.Addr().String()
+	if _, err := resolveRemoteCDP(wsURL, nil); err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Fatalf("without header: err = %v, want HTTP 401", err)
+	}
+	header := http.Header{"Authorization": {"Bearer s3cret"}}
+	if _, err := resolveRemoteCDP(wsURL, header); err != nil {
+		t.Fatalf("with header: %v", err)
+	}
+}
+
+func TestResolveRemoteCDP_DirectURL(t *testing.T) {
+	// Full WebSocket endpoints are dialed as given, without /json/version.
+	for _, u := range []string{
+		"wss://chrome.example.com?token=abc",
+		"ws://chrome:3000/?token=abc",
+		"ws://chrome:9222/devtools/browser/abc-123",
+	} {
+		got, err := resolveRemoteCDP(u, nil)
+		if err != nil || got != u {
+			t.Errorf("resolveRemoteCDP(%q) = %q, %v; want it unchanged", u, got, err)
+		}
+	}
+}
+
+func TestResolveRemoteCDP_HTTPS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chrome/json/version" || r.Header.Get("X-API-Key") != "k" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"webSocketDebuggerUrl": "ws://127.0.0.1:9222/devtools/browser/xyz",
+		})
+	}))
+	defer srv.Close()
+	orig := cdpHTTPClient
+	cdpHTTPClient = srv.Client()
+	defer func() { cdpHTTPClient = orig }()
+
+	host := srv.Listener.Addr().String()
+	got, err := resolveRemoteCDP("https://"+host+"/chrome?token=abc", http.Header{"X-API-Key": {"k"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "wss://" + host + "/devtools/browser/xyz?token=abc"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := map[string]string{
+		"wss://user:pw@chrome.example.com/x?token=abc": "wss://redacted@chrome.example.com/x?redacted",
+		"ws://chrome:9222": "ws://chrome:9222",
+	}
+	for in, want := range tests {
+		if got := RedactURL(in); got != want {
+			t.Errorf("RedactURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
 // --- Manager options ---
 
 func TestManagerOptions(t *testing.T) {
@@ -222,3 +299,77 @@ func TestManagerStatusWhenStopped(t *testing.T) {
 		t.Error("Status.Running should be false when browser is nil")
 	}
 }
+
+// --- Profiles ---
+
+func TestManagerProfile(t *testing.T) {
+	dir := t.TempDir()
+	m := New(WithHeadless(true), WithProfilesDir(dir))
+
+	if p, err := m.Profile(context.Background(), ""); err != nil || p != m {
+		t.Fatalf("empty profile should return the manager itself, got %p, %v", p, err)
+	}
+
+	p, err := m.Profile(context.Background(), "work")
+	if err != nil {
+		t.Fatalf("Profile(work) error: %v", err)
+	}
+	if want := filepath.Join(dir, "master", "work"); p.userDataDir != want {
+		t.Errorf("userDataDir = %q, want %q", p.userDataDir, want)
+	}
+	if !p.headless || p.profilesDir != "" {
+		t.Errorf("profile should inherit options but not nest profiles: headless=%v profilesDir=%q", p.headless, p.profilesDir)
+	}
+	if again, _ := m.Profile(context.Background(), "work"); again != p {
+		t.Error("Profile(work) should return the same manager")
+	}
+	if st := p.Status(); st.Running || st.Profile != "work" {
+		t.Errorf("status = %+v", st)
+	}
+
+	tenantCtx := WithTenantID(context.Background(), "0193a5b0-7000-7000-8000-0000000000aa")
+	tp, err := m.Profile(tenantCtx, "work")
+	if err != nil {
+		t.Fatalf("tenant Profile(work) error: %v", err)
+	}
+	if tp == p || tp.userDataDir != filepath.Join(dir, "0193a5b0-7000-7000-8000-0000000000aa", "work") {
+		t.Errorf("tenant profile should be isolated, got dir %q", tp.userDataDir)
+	}
+	if got := m.Profiles(); len(got) != 2 {
+		t.Errorf("Profiles() = %v", got)
+	}
+}
+
+func TestManagerProfileErrors(t *testing.T) {
+	for _, name := range []string{"Work", "../etc", "a/b", strings.Repeat("x", 65)} {
+		if _, err := New(WithProfilesDir(t.TempDir())).Profile(context.Background(), name); err == nil {
+			t.Errorf("Profile(%q) should fail", name)
+		}
+	}
+	if _, err := New().Profile(context.Background(), "work"); err == nil {
+		t.Error("Profile without a profiles dir should fail")
+	}
+	if _, err := New(WithRemoteURL("ws://chrome:9222"), WithProfilesDir(t.TempDir())).Profile(context.Background(), "work"); err == nil {
+		t.Error("Profile with remote Chrome should fail")
+	}
+}
+
+func TestBrowserToolAgentDefaultProfile(t *testing.T) {
+	m := New(WithProfilesDir(t.TempDir()))
+	tool := NewBrowserTool(m)
+	tool.SetAgentProfiles(map[string]string{"shopper": "shop"})
+
+	ctx := tools.WithToolAgentKey(context.Background(), "shopper")
+	res := tool.Execute(ctx, map[string]any{"action": "status"})
+	if !strings.Contains(res.ForLLM, `"profile": "shop"`) {
+		t.Errorf("agent default profile not used: %s", res.ForLLM)
+	}
+	res = tool.Execute(ctx, map[string]any{"action": "status", "browser_profile": "bank"})
+	if !strings.Contains(res.ForLLM, `"profile": "bank"`) {
+		t.Errorf("browser_profile argument not used: %s", res.ForLLM)
+	}
+	res = tool.Execute(context.Background(), map[string]any{"action": "status", "browser_profile": "Bad Name"})
+	if !res.IsError {
+		t.Errorf("invalid profile should error: %s", res.ForLLM)
+	}
+}
@@ -0,0 +1,74 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nextlevelbuilder/goclaw/internal/tools"
+)
+
+// DownloadDir returns the directory a session's browser downloads are saved
+// to: downloads/<session> in the workspace.
+func DownloadDir(workspace, sessionKey string) string {
+	dir := filepath.Join(workspace, "downloads")
+	if sessionKey != "" {
+		dir = filepath.Join(dir, tools.SanitizePathSegment(sessionKey))
+	}
+	return dir
+}
+
+// DownloadsTool lists the files the browser downloaded in the current session.
+type DownloadsTool struct {
+	manager *Manager
+}
+
+// NewDownloadsTool creates the browser_downloads tool.
+func NewDownloadsTool(manager *Manager) *DownloadsTool {
+	return &DownloadsTool{manager: manager}
+}
+
+func (t *DownloadsTool) Name() string { return "browser_downloads" }
+
+func (t *DownloadsTool) Description() string {
+	return "List files the browser downloaded in this conversation, newest first, with their workspace paths. Read completed files with read_file or read_document. In-progress downloads are listed too; check again later for them."
+}
+
+func (t *DownloadsTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"limit": map[string]any{
+				"type":        "number",
+				"description": "Max downloads to list (default 20)",
+			},
+		},
+	}
+}
+
+func (t *DownloadsTool) Execute(ctx context.Context, args map[string]any) *tools.Result {
+	limit := 20
+	if n, ok := args["limit"].(float64); ok && n > 0 {
+		limit = int(n)
+	}
+	downloads := t.manager.Downloads(tools.ToolSessionKeyFromCtx(ctx))
+	if len(downloads) == 0 {
+		return tools.NewResult("No browser downloads in this conversation.")
+	}
+	if len(downloads) > limit {
+		downloads = downloads[:limit]
+	}
+
+	// Show paths relative to the workspace so file tools accept them.
+	if ws := tools.ToolWorkspaceFromCtx(ctx); ws != "" {
+		for i, d := range downloads {
+			if rel, err := filepath.Rel(ws, d.Path); d.Path != "" && err == nil && !strings.HasPrefix(rel, "..") {
+				downloads[i].Path = rel
+			}
+		}
+	}
+	res := jsonResult(downloads)
+	res.ForLLM = fmt.Sprintf("%d download(s):\n%s", len(downloads), res.ForLLM)
+	return res
+}
@@ -30,6 +30,7 @@ func axValue(v *proto.AccessibilityAXValue) string {
 type axNodeTree struct {
 	node     *proto.AccessibilityAXNode
 	depth    int
+	frame    *FrameTree // frame the node belongs to (set by flattenFrames)
 }
 
 // buildAXTree converts flat CDP AX nodes into a tree structure.
@@ -161,6 +162,12 @@ func removeNthFromNonDuplicates(refs map[string]RoleRef, tracker *roleNameTracke
 // - TS cdp.ts:192-249 (formatAriaSnapshot) — tree building
 // - TS pw-role-snapshot.ts:207-267 (processLine) — role filtering + ref assignment
 func FormatSnapshot(nodes []*proto.AccessibilityAXNode, opts SnapshotOptions) *SnapshotResult {
+	return FormatFrameSnapshot(&FrameTree{Nodes: nodes}, opts)
+}
+
+// FormatFrameSnapshot is FormatSnapshot over a page's frames: each iframe's
+// content is nested below its iframe node, and refs inside it carry the frame.
+func FormatFrameSnapshot(root *FrameTree, opts SnapshotOptions) *SnapshotResult {
 	if opts.MaxChars == 0 {
 		opts.MaxChars = 8000
 	}
@@ -168,7 +175,7 @@ func FormatSnapshot(nodes []*proto.AccessibilityAXNode, opts SnapshotOptions) *S
 		opts.Limit = 500
 	}
 
-	treeNodes := buildAXTree(nodes, opts.Limit)
+	treeNodes := flattenFrames(root, opts.Limit)
 	if len(treeNodes) == 0 {
 		return &SnapshotResult{
 			Snapshot: "(empty page)",
@@ -248,6 +255,8 @@ func FormatSnapshot(nodes []*proto.AccessibilityAXNode, opts SnapshotOptions) *S
 				Name:          name,
 				Nth:           nth,
 				BackendNodeID: backendNodeID,
+				FrameID:       tn.frame.FrameID,
+				FrameTargetID: tn.frame.FrameTargetID,
 			}
 
 			line += fmt.Sprintf(" [ref=%s]", ref)
@@ -300,6 +309,7 @@ func FormatSnapshot(nodes []*proto.AccessibilityAXNode, opts SnapshotOptions) *S
 			Chars:       len(snapshot),
 			Refs:        len(refs),
 			Interactive: interactiveCount,
+			Frames:      root.frameCount(),
 		},
 	}
 }
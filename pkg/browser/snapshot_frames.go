@@ -0,0 +1,147 @@
+package browser
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Bounds on the frames walked per snapshot; each frame costs CDP round-trips.
+const (
+	maxSnapshotFrames     = 20
+	maxSnapshotFrameDepth = 4
+)
+
+// FrameTree is the accessibility tree of one frame and its child frames.
+// Shadow DOM content is already part of a frame's AX tree, which follows the
+// composed (flat) tree, so open and closed shadow roots need no extra walk.
+type FrameTree struct {
+	FrameID            string // "" for the main frame
+	FrameTargetID      string // target of an out-of-process frame ("" when it shares the page's session)
+	OwnerBackendNodeID int    // <iframe> element in the parent frame
+	Nodes              []*proto.AccessibilityAXNode
+	Children           []*FrameTree
+}
+
+// flattenFrames walks the main frame's AX tree and splices each child
+// frame's tree in below its <iframe> node. Frames whose owner is not in the
+// parent's tree are appended at the end of the parent.
+func flattenFrames(root *FrameTree, limit int) []*axNodeTree {
+	var out []*axNodeTree
+	var walk func(f *FrameTree, base int)
+	walk = func(f *FrameTree, base int) {
+		owned := make(map[int]*FrameTree, len(f.Children))
+		for _, c := range f.Children {
+			owned[c.OwnerBackendNodeID] = c
+		}
+		for _, tn := range buildAXTree(f.Nodes, len(f.Nodes)) {
+			if len(out) >= limit {
+				return
+			}
+			tn.depth += base
+			tn.frame = f
+			out = append(out, tn)
+			if c, ok := owned[int(tn.node.BackendDOMNodeID)]; ok && c.OwnerBackendNodeID != 0 {
+				delete(owned, c.OwnerBackendNodeID)
+				walk(c, tn.depth+1)
+			}
+		}
+		for _, c := range f.Children {
+			if _, ok := owned[c.OwnerBackendNodeID]; ok {
+				delete(owned, c.OwnerBackendNodeID)
+				walk(c, base+1)
+			}
+		}
+	}
+	walk(root, 0)
+	return out
+}
+
+// frameCount returns the number of child frames under f.
+func (f *FrameTree) frameCount() int {
+	n := 0
+	for _, c := range f.Children {
+		n += 1 + c.frameCount()
+	}
+	return n
+}
+
+// snapshotFrames collects the AX trees of page's main frame and its iframes.
+// Same-process frames are read through the page's session; out-of-process
+// frames (OOPIFs) through their own target. Frames that cannot be read are
+// skipped.
+func snapshotFrames(page *rod.Page) (*FrameTree, error) {
+	res, err := proto.AccessibilityGetFullAXTree{}.Call(page)
+	if err != nil {
+		return nil, err
+	}
+	root := &FrameTree{Nodes: res.Nodes}
+
+	tree, err := proto.PageGetFrameTree{}.Call(page)
+	if err != nil || tree.FrameTree == nil || len(tree.FrameTree.ChildFrames) == 0 {
+		return root, nil
+	}
+	oopifs := make(map[proto.PageFrameID]bool)
+	if targets, err := (proto.TargetGetTargets{}).Call(page.Browser()); err == nil {
+		for _, t := range targets.TargetInfos {
+			if t.Type == "iframe" {
+				oopifs[proto.PageFrameID(t.TargetID)] = true
+			}
+		}
+	}
+	budget := maxSnapshotFrames
+	addSnapshotFrames(page, root, tree.FrameTree.ChildFrames, "", oopifs, 1, &budget)
+	return root, nil
+}
+
+func addSnapshotFrames(sess *rod.Page, parent *FrameTree, frames []*proto.PageFrameTree, targetID string,
+	oopifs map[proto.PageFrameID]bool, depth int, budget *int) {
+	if depth > maxSnapshotFrameDepth {
+		return
+	}
+	for _, ft := range frames {
+		if *budget <= 0 {
+			return
+		}
+		if ft.Frame == nil {
+			continue
+		}
+		fid := ft.Frame.ID
+		owner, err := proto.DOMGetFrameOwner{FrameID: fid}.Call(sess)
+		if err != nil {
+			continue
+		}
+		child := &FrameTree{FrameID: string(fid), FrameTargetID: targetID, OwnerBackendNodeID: int(owner.BackendNodeID)}
+		frameSess, children := sess, ft.ChildFrames
+		if oopifs[fid] {
+			if frameSess, err = sess.Browser().PageFromTarget(proto.TargetTargetID(fid)); err != nil {
+				continue
+			}
+			child.FrameTargetID = string(fid)
+			children = nil
+			if sub, err := (proto.PageGetFrameTree{}).Call(frameSess); err == nil && sub.FrameTree != nil {
+				children = sub.FrameTree.ChildFrames
+			}
+		}
+		req := proto.AccessibilityGetFullAXTree{}
+		if !oopifs[fid] {
+			req.FrameID = fid
+		}
+		res, err := req.Call(frameSess)
+		if err != nil {
+			continue
+		}
+		child.Nodes = res.Nodes
+		*budget--
+		parent.Children = append(parent.Children, child)
+		addSnapshotFrames(frameSess, child, children, child.FrameTargetID, oopifs, depth+1, budget)
+	}
+}
+
+// framePage returns the session that owns ref's DOM node: page itself, or
+// the out-of-process frame's target.
+func framePage(page *rod.Page, ref *RoleRef) (*rod.Page, error) {
+	if ref.FrameTargetID == "" {
+		return page, nil
+	}
+	return page.Browser().PageFromTarget(proto.TargetTargetID(ref.FrameTargetID))
+}
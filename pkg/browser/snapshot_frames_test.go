@@ -0,0 +1,112 @@
+package browser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// axNode builds a CDP AX node the way Chrome reports it.
+func axNode(id, role, name string, backendID int, children ...string) *proto.AccessibilityAXNode {
+	raw := map[string]any{
+		"nodeId":           id,
+		"ignored":          false,
+		"role":             map[string]any{"type": "role", "value": role},
+		"backendDOMNodeId": backendID,
+		"childIds":         children,
+	}
+	if name != "" {
+		raw["name"] = map[string]any{"type": "computedString", "value": name}
+	}
+	data, _ := json.Marshal(raw)
+	var n proto.AccessibilityAXNode
+	if err := json.Unmarshal(data, &n); err != nil {
+		panic(err)
+	}
+	return &n
+}
+
+func TestFormatFrameSnapshot(t *testing.T) {
+	root := &FrameTree{
+		Nodes: []*proto.AccessibilityAXNode{
+			axNode("1", "RootWebArea", "Shop", 1, "2", "3", "4"),
+			axNode("2", "button", "Menu", 2),
+			axNode("3", "Iframe", "Checkout", 3),
+			axNode("4", "link", "Help", 4),
+		},
+		Children: []*FrameTree{{
+			FrameID:            "F1",
+			FrameTargetID:      "F1",
+			OwnerBackendNodeID: 3,
+			Nodes: []*proto.AccessibilityAXNode{
+				axNode("1", "RootWebArea", "Pay", 1, "2"),
+				axNode("2", "button", "Pay now", 2),
+			},
+			Children: []*FrameTree{{
+				FrameID:            "F2",
+				FrameTargetID:      "F1",
+				OwnerBackendNodeID: 99, // owner not in the AX tree
+				Nodes: []*proto.AccessibilityAXNode{
+					axNode("1", "RootWebArea", "", 1, "2"),
+					axNode("2", "textbox", "Card", 2),
+				},
+			}},
+		}},
+	}
+
+	snap := FormatFrameSnapshot(root, DefaultSnapshotOptions())
+	want := strings.Join([]string{
+		`- rootwebarea "Shop"`,
+		`  - button "Menu" [ref=e1]`,
+		`  - iframe "Checkout"`,
+		`    - rootwebarea "Pay"`,
+		`      - button "Pay now" [ref=e2]`,
+		`      - rootwebarea`,
+		`        - textbox "Card" [ref=e3]`,
+		`  - link "Help" [ref=e4]`,
+	}, "\n")
+	if snap.Snapshot != want {
+		t.Fatalf("snapshot:\n%s\nwant:\n%s", snap.Snapshot, want)
+	}
+	if snap.Stats.Frames != 2 {
+		t.Errorf("frames = %d, want 2", snap.Stats.Frames)
+	}
+
+	wantRefs := map[string]RoleRef{
+		"e1": {Role: "button", Name: "Menu", BackendNodeID: 2},
+		"e2": {Role: "button", Name: "Pay now", BackendNodeID: 2, FrameID: "F1", FrameTargetID: "F1"},
+		"e3": {Role: "textbox", Name: "Card", BackendNodeID: 2, FrameID: "F2", FrameTargetID: "F1"},
+		"e4": {Role: "link", Name: "Help", BackendNodeID: 4},
+	}
+	for ref, want := range wantRefs {
+		if got := snap.Refs[ref]; got != want {
+			t.Errorf("refs[%s] = %+v, want %+v", ref, got, want)
+		}
+	}
+}
+
+func TestFormatFrameSnapshotLimit(t *testing.T) {
+	root := &FrameTree{
+		Nodes: []*proto.AccessibilityAXNode{
+			axNode("1", "RootWebArea", "Shop", 1, "2", "3"),
+			axNode("2", "Iframe", "Ad", 2),
+			axNode("3", "button", "Buy", 3),
+		},
+		Children: []*FrameTree{{
+			FrameID:            "F1",
+			OwnerBackendNodeID: 2,
+			Nodes: []*proto.AccessibilityAXNode{
+				axNode("1", "RootWebArea", "Ad", 1, "2"),
+				axNode("2", "link", "Click me", 2),
+			},
+		}},
+	}
+	opts := DefaultSnapshotOptions()
+	opts.Limit = 3
+	snap := FormatFrameSnapshot(root, opts)
+	if strings.Contains(snap.Snapshot, "Click me") || strings.Contains(snap.Snapshot, "Buy") {
+		t.Errorf("limit not applied across frames:\n%s", snap.Snapshot)
+	}
+}
@@ -6,15 +6,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/nextlevelbuilder/goclaw/internal/netproxy"
 	"github.com/nextlevelbuilder/goclaw/internal/store"
 	"github.com/nextlevelbuilder/goclaw/internal/tools"
 )
 
 // BrowserTool implements tools.Tool for browser automation.
 type BrowserTool struct {
-	manager *Manager
+	manager       *Manager
+	agentProfiles map[string]string // agent key → default profile
 }
 
 // NewBrowserTool creates a BrowserTool wrapping a Manager.
@@ -22,6 +26,12 @@ func NewBrowserTool(manager *Manager) *BrowserTool {
 	return &BrowserTool{manager: manager}
 }
 
+// SetAgentProfiles sets each agent's default profile, used when a call has
+// no browser_profile argument.
+func (t *BrowserTool) SetAgentProfiles(profiles map[string]string) {
+	t.agentProfiles = profiles
+}
+
 func (t *BrowserTool) Name() string { return "browser" }
 
 func (t *BrowserTool) Description() string {
@@ -39,6 +49,8 @@ Actions:
 - navigate: Navigate tab to URL (requires targetId, targetUrl)
 - console: Get browser console messages (requires targetId)
 - act: Interact with elements (requires request object with kind, ref, etc.)
+- network: Record and list a tab's requests (mode: start, list, stop; use targetId, filter, limit). Only requests made after start are recorded
+- har: Save the tab's recorded requests as a HAR file in the workspace (start network recording first)
 
 Act kinds: click, type, press, hover, wait, evaluate
 - click: Click element (request: {kind:"click", ref:"e1"})
@@ -48,7 +60,11 @@ Act kinds: click, type, press, hover, wait, evaluate
 - wait: Wait for condition (request: {kind:"wait", timeMs:1000} or {kind:"wait", text:"loaded"})
 - evaluate: Run JavaScript (request: {kind:"evaluate", fn:"document.title"})
 
-Workflow: start → open URL → snapshot (get refs) → act (use refs) → snapshot again`
+Workflow: start → open URL → snapshot (get refs) → act (use refs) → snapshot again
+
+Downloads: files the page downloads are saved to downloads/ in the workspace. Use browser_downloads to list them.
+
+Profiles: pass browser_profile (e.g. "work") to use a named browser whose cookies and logins persist across runs. Omit it for the agent's default browser.`
 }
 
 func (t *BrowserTool) Parameters() map[string]any {
@@ -57,7 +73,7 @@ func (t *BrowserTool) Parameters() map[string]any {
 		"properties": map[string]any{
 			"action": map[string]any{
 				"type":        "string",
-				"enum":        []string{"status", "start", "stop", "tabs", "open", "close", "snapshot", "screenshot", "navigate", "console", "act"},
+				"enum":        []string{"status", "start", "stop", "tabs", "open", "close", "snapshot", "screenshot", "navigate", "console", "act", "network", "har"},
 				"description": "The browser action to perform",
 			},
 			"targetUrl": map[string]any{
@@ -68,6 +84,10 @@ func (t *BrowserTool) Parameters() map[string]any {
 				"type":        "string",
 				"description": "Tab target ID (omit for current tab)",
 			},
+			"browser_profile": map[string]any{
+				"type":        "string",
+				"description": "Named browser profile with persistent cookies and logins (lowercase letters, digits, '-', '_')",
+			},
 			"maxChars": map[string]any{
 				"type":        "number",
 				"description": "Max characters for snapshot (default 8000)",
@@ -88,6 +108,19 @@ func (t *BrowserTool) Parameters() map[string]any {
 				"type":        "boolean",
 				"description": "Capture full page screenshot",
 			},
+			"mode": map[string]any{
+				"type":        "string",
+				"enum":        []string{"start", "list", "stop"},
+				"description": "network action: start recording, list recorded requests (default), or stop and discard",
+			},
+			"filter": map[string]any{
+				"type":        "string",
+				"description": "network list: only requests whose URL contains this text",
+			},
+			"limit": map[string]any{
+				"type":        "number",
+				"description": "network list: max requests to show, most recent (default 50)",
+			},
 			"timeoutMs": map[string]any{
 				"type":        "number",
 				"description": "Timeout in milliseconds for actions",
@@ -143,9 +176,34 @@ func (t *BrowserTool) Execute(ctx context.Context, args map[string]any) *tools.R
 		ctx = WithTenantID(ctx, tid.String())
 	}
 
+	// Downloads go to downloads/<session>/ in the workspace.
+	if ws := tools.ToolWorkspaceFromCtx(ctx); ws != "" {
+		ctx = WithDownloadTarget(ctx, DownloadTarget{
+			Dir:        DownloadDir(ws, tools.ToolSessionKeyFromCtx(ctx)),
+			SessionKey: tools.ToolSessionKeyFromCtx(ctx),
+			UserID:     store.UserIDFromContext(ctx),
+			AgentKey:   tools.ToolAgentKeyFromCtx(ctx),
+		})
+	}
+
+	profile, _ := args["browser_profile"].(string)
+	if profile == "" {
+		profile = t.agentProfiles[tools.ToolAgentKeyFromCtx(ctx)]
+	}
+	mgr, err := t.manager.Profile(ctx, profile)
+	if err != nil {
+		return tools.ErrorResult(err.Error())
+	}
+	if mgr != t.manager {
+		t = &BrowserTool{manager: mgr}
+	}
+
 	// Auto-start browser for actions that need it
 	switch action {
-	case "open", "snapshot", "screenshot", "navigate", "act", "tabs":
+	case "open", "snapshot", "screenshot", "navigate", "act", "tabs", "network", "har":
+		if !t.manager.connected() {
+			tools.ReportProgress(ctx, "Starting browser")
+		}
 		if err := t.manager.Start(ctx); err != nil {
 			return tools.ErrorResult(fmt.Sprintf("failed to start browser: %v", err))
 		}
@@ -186,6 +244,10 @@ func (t *BrowserTool) Execute(ctx context.Context, args map[string]any) *tools.R
 		return t.handleConsole(ctx, args)
 	case "act":
 		return t.handleAct(ctx, args)
+	case "network":
+		return t.handleNetwork(ctx, args)
+	case "har":
+		return t.handleHAR(ctx, args)
 	default:
 		return tools.ErrorResult(fmt.Sprintf("unknown action: %s", action))
 	}
@@ -223,10 +285,15 @@ func (t *BrowserTool) handleOpen(ctx context.Context, args map[string]any) *tool
 	if url == "" {
 		return tools.ErrorResult("targetUrl is required for open action")
 	}
+	if err := netproxy.CheckOfflineURL(url); err != nil {
+		return tools.ErrorResult(err.Error())
+	}
+	tools.ReportProgress(ctx, "Loading "+url)
 	tab, err := t.manager.OpenTab(ctx, url)
 	if err != nil {
 		return tools.ErrorResult(err.Error())
 	}
+	tools.ReportProgress(ctx, "Loaded "+url)
 	return jsonResult(tab)
 }
 
@@ -261,8 +328,12 @@ func (t *BrowserTool) handleSnapshot(ctx context.Context, args map[string]any) *
 	}
 
 	// Return snapshot text directly (optimized for LLM consumption)
-	header := fmt.Sprintf("Page: %s\nURL: %s\nTargetID: %s\nStats: %d refs, %d interactive\n\n",
+	header := fmt.Sprintf("Page: %s\nURL: %s\nTargetID: %s\nStats: %d refs, %d interactive",
 		snap.Title, snap.URL, snap.TargetID, snap.Stats.Refs, snap.Stats.Interactive)
+	if snap.Stats.Frames > 0 {
+		header += fmt.Sprintf(", %d iframes", snap.Stats.Frames)
+	}
+	header += "\n\n"
 	return tools.NewResult(header + snap.Snapshot)
 }
 
@@ -298,10 +369,15 @@ func (t *BrowserTool) handleNavigate(ctx context.Context, args map[string]any) *
 	if url == "" {
 		return tools.ErrorResult("targetUrl is required for navigate action")
 	}
+	if err := netproxy.CheckOfflineURL(url); err != nil {
+		return tools.ErrorResult(err.Error())
+	}
 
+	tools.ReportProgress(ctx, "Loading "+url)
 	if err := t.manager.Navigate(ctx, targetID, url); err != nil {
 		return tools.ErrorResult(err.Error())
 	}
+	tools.ReportProgress(ctx, "Loaded "+url)
 	return tools.NewResult(fmt.Sprintf("Navigated to %s", url))
 }
 
@@ -422,3 +498,98 @@ func jsonResult(v any) *tools.Result {
 	data, _ := json.MarshalIndent(v, "", "  ")
 	return tools.NewResult(string(data))
 }
+
+func (t *BrowserTool) handleNetwork(ctx context.Context, args map[string]any) *tools.Result {
+	targetID, _ := args["targetId"].(string)
+	mode, _ := args["mode"].(string)
+	switch mode {
+	case "start":
+		tid, err := t.manager.StartNetworkCapture(ctx, targetID)
+		if err != nil {
+			return tools.ErrorResult(err.Error())
+		}
+		return tools.NewResult(fmt.Sprintf("Recording network requests on tab %s. Navigate or act, then list them.", tid))
+	case "stop":
+		if err := t.manager.StopNetworkCapture(ctx, targetID); err != nil {
+			return tools.ErrorResult(err.Error())
+		}
+		return tools.NewResult("Network recording stopped.")
+	case "", "list":
+	default:
+		return tools.ErrorResult(fmt.Sprintf("unknown network mode: %s", mode))
+	}
+
+	entries, recording, err := t.manager.NetworkEntries(ctx, targetID)
+	if err != nil {
+		return tools.ErrorResult(err.Error())
+	}
+	if !recording {
+		return tools.NewResult("Network recording is off for this tab. Use mode \"start\" first.")
+	}
+	filter, _ := args["filter"].(string)
+	limit := 50
+	if n, ok := args["limit"].(float64); ok && n > 0 {
+		limit = int(n)
+	}
+	return tools.NewResult(formatNetworkEntries(entries, filter, limit))
+}
+
+// formatNetworkEntries renders one line per request, most recent last.
+func formatNetworkEntries(entries []NetworkEntry, filter string, limit int) string {
+	var matched []NetworkEntry
+	for _, e := range entries {
+		if filter == "" || strings.Contains(e.URL, filter) {
+			matched = append(matched, e)
+		}
+	}
+	shown := matched[max(len(matched)-limit, 0):]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d request(s) recorded, %d matching, showing %d:\n", len(entries), len(matched), len(shown))
+	for _, e := range shown {
+		status := "pending"
+		switch {
+		case e.Blocked:
+			status = "BLOCKED"
+		case e.Error != "":
+			status = "FAILED"
+		case e.Status > 0:
+			status = strconv.Itoa(e.Status)
+		}
+		fmt.Fprintf(&b, "%s %s %s", status, e.Method, e.URL)
+		if e.Type != "" {
+			fmt.Fprintf(&b, " [%s]", e.Type)
+		}
+		if e.DurationMs > 0 {
+			fmt.Fprintf(&b, " %.0fms", e.DurationMs)
+		}
+		if e.Size > 0 {
+			fmt.Fprintf(&b, " %dB", e.Size)
+		}
+		if e.Error != "" && !e.Blocked {
+			fmt.Fprintf(&b, " (%s)", e.Error)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (t *BrowserTool) handleHAR(ctx context.Context, args map[string]any) *tools.Result {
+	targetID, _ := args["targetId"].(string)
+	data, n, err := t.manager.HAR(ctx, targetID)
+	if err != nil {
+		return tools.ErrorResult(fmt.Sprintf("har export failed: %v", err))
+	}
+
+	harDir := filepath.Join(os.TempDir(), "goclaw_har")
+	if ws := tools.ToolWorkspaceFromCtx(ctx); ws != "" {
+		harDir = filepath.Join(ws, "har")
+	}
+	if err := os.MkdirAll(harDir, 0755); err != nil {
+		return tools.ErrorResult(fmt.Sprintf("failed to create har directory: %v", err))
+	}
+	harPath := filepath.Join(harDir, fmt.Sprintf("network_%d.har", time.Now().UnixNano()))
+	if err := os.WriteFile(harPath, data, 0644); err != nil {
+		return tools.ErrorResult(fmt.Sprintf("failed to save har: %v", err))
+	}
+	return tools.NewResult(fmt.Sprintf("Saved HAR with %d request(s) to %s", n, harPath))
+}
@@ -13,6 +13,8 @@ type RoleRef struct {
 	Name          string `json:"name,omitempty"`
 	Nth           int    `json:"nth,omitempty"`
 	BackendNodeID int    `json:"backendNodeId,omitempty"`
+	FrameID       string `json:"frameId,omitempty"`       // iframe the element is in ("" = main frame)
+	FrameTargetID string `json:"frameTargetId,omitempty"` // target of an out-of-process iframe
 }
 
 // SnapshotResult is the output of a page snapshot.
@@ -32,6 +34,7 @@ type SnapshotStats struct {
 	Chars       int `json:"chars"`
 	Refs        int `json:"refs"`
 	Interactive int `json:"interactive"`
+	Frames      int `json:"frames,omitempty"` // iframes included
 }
 
 // SnapshotOptions controls snapshot generation.
@@ -96,4 +99,5 @@ type StatusInfo struct {
 	Running bool   `json:"running"`
 	Tabs    int    `json:"tabs"`
 	URL     string `json:"url,omitempty"` // current tab URL
+	Profile string `json:"profile,omitempty"`
 }
@@ -0,0 +1,80 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Binary frames carry attachment bytes (screenshots, audio, files) without
+// base64 overhead. Each WebSocket binary message is one chunk of a transfer:
+//
+//	[4-byte big-endian header length][JSON BinaryHeader][chunk bytes]
+//
+// Chunks of a transfer share an ID, are numbered from 0 and sent in order;
+// the last one has Final set. Filename, MimeType and Size are only required
+// on chunk 0.
+const (
+	FrameTypeChunk = "chunk"
+
+	// MaxBinaryChunkSize is the largest chunk payload a peer may send.
+	MaxBinaryChunkSize = 256 * 1024
+
+	// maxBinaryHeaderSize bounds the JSON header of a binary frame.
+	maxBinaryHeaderSize = 4 * 1024
+)
+
+// BinaryHeader describes one chunk of an attachment transfer.
+type BinaryHeader struct {
+	Type     string `json:"type"`               // always "chunk"
+	ID       string `json:"id"`                 // transfer ID (sender-generated)
+	Seq      int    `json:"seq"`                // chunk index, starting at 0
+	Final    bool   `json:"final,omitempty"`    // last chunk of the transfer
+	Filename string `json:"filename,omitempty"` // original file name (chunk 0)
+	MimeType string `json:"mimeType,omitempty"` // content type (chunk 0)
+	Size     int64  `json:"size,omitempty"`     // total size in bytes (chunk 0, 0 = unknown)
+}
+
+// EncodeBinaryFrame builds a binary frame from a header and chunk payload.
+func EncodeBinaryFrame(h BinaryHeader, chunk []byte) ([]byte, error) {
+	if len(chunk) > MaxBinaryChunkSize {
+		return nil, fmt.Errorf("chunk too large: %d > %d bytes", len(chunk), MaxBinaryChunkSize)
+	}
+	h.Type = FrameTypeChunk
+	hdr, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 4, 4+len(hdr)+len(chunk))
+	binary.BigEndian.PutUint32(out, uint32(len(hdr)))
+	out = append(out, hdr...)
+	return append(out, chunk...), nil
+}
+
+// DecodeBinaryFrame splits a binary frame into its header and chunk payload.
+// The payload aliases data.
+func DecodeBinaryFrame(data []byte) (BinaryHeader, []byte, error) {
+	var h BinaryHeader
+	if len(data) < 4 {
+		return h, nil, errors.New("binary frame too short")
+	}
+	n := binary.BigEndian.Uint32(data)
+	if n == 0 || n > maxBinaryHeaderSize || int(n) > len(data)-4 {
+		return h, nil, fmt.Errorf("invalid binary header length %d", n)
+	}
+	if err := json.Unmarshal(data[4:4+n], &h); err != nil {
+		return h, nil, fmt.Errorf("malformed binary header: %w", err)
+	}
+	if h.Type != FrameTypeChunk {
+		return h, nil, fmt.Errorf("unexpected binary frame type %q", h.Type)
+	}
+	if h.ID == "" || h.Seq < 0 {
+		return h, nil, errors.New("binary header requires id and seq >= 0")
+	}
+	chunk := data[4+n:]
+	if len(chunk) > MaxBinaryChunkSize {
+		return h, nil, fmt.Errorf("chunk too large: %d > %d bytes", len(chunk), MaxBinaryChunkSize)
+	}
+	return h, chunk, nil
+}
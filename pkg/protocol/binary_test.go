@@ -0,0 +1,45 @@
+package protocol
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBinaryFrameRoundTrip(t *testing.T) {
+	chunk := []byte("\x89PNG\r\n\x1a\nrest-of-image")
+	frame, err := EncodeBinaryFrame(BinaryHeader{ID: "t1", Seq: 0, Final: true, Filename: "shot.png", MimeType: "image/png", Size: int64(len(chunk))}, chunk)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	h, got, err := DecodeBinaryFrame(frame)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if h.Type != FrameTypeChunk || h.ID != "t1" || !h.Final || h.Filename != "shot.png" || h.Size != int64(len(chunk)) {
+		t.Errorf("unexpected header %+v", h)
+	}
+	if !bytes.Equal(got, chunk) {
+		t.Errorf("payload mismatch: %q", got)
+	}
+}
+
+func TestDecodeBinaryFrameRejectsMalformed(t *testing.T) {
+	valid, _ := EncodeBinaryFrame(BinaryHeader{ID: "t1"}, nil)
+	cases := map[string][]byte{
+		"short":          {0, 0},
+		"zero header":    {0, 0, 0, 0},
+		"header overrun": {0, 0, 0, 9, '{', '}'},
+		"bad json":       append([]byte{0, 0, 0, 2}, "{x"...),
+		"wrong type":     append([]byte{0, 0, 0, 14}, `{"type":"req"}`...),
+		"missing id":     append([]byte{0, 0, 0, 16}, `{"type":"chunk"}`...),
+		"oversized":      append(valid, make([]byte, MaxBinaryChunkSize+1)...),
+	}
+	for name, data := range cases {
+		if _, _, err := DecodeBinaryFrame(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := EncodeBinaryFrame(BinaryHeader{ID: "t1"}, make([]byte, MaxBinaryChunkSize+1)); err == nil {
+		t.Error("encode: expected error for oversized chunk")
+	}
+}
@@ -37,6 +37,9 @@ const (
 	EventDelegationAccumulated = "delegation.accumulated"
 	EventDelegationAnnounce    = "delegation.announce"
 
+	// Subagent lifecycle: started, each tool call, finished. Payload: SubagentProgress.
+	EventSubagentProgress = "subagent.progress"
+
 	// Team task lifecycle events.
 	EventTeamTaskClaimed   = "team.task.claimed"
 	EventTeamTaskCancelled = "team.task.cancelled"
@@ -76,6 +79,13 @@ const (
 	// Immediate status change event (not flush-buffered; fired on every status write).
 	EventTraceStatusChanged = "trace.status"
 
+	// Skills on disk changed (SKILL.md added/edited/removed); payload: {version}.
+	EventSkillsUpdated = "skills.updated"
+
+	// Config reloaded (SIGHUP, config file edit, config.apply/patch); payload:
+	// {trigger, changed, applied, restart_required, error?}. Owner/admin only.
+	EventConfigReloaded = "config.reloaded"
+
 	// Skill dependency check events (realtime progress during startup/rescan).
 	EventSkillDepsChecked  = "skill.deps.checked"
 	EventSkillDepsComplete = "skill.deps.complete"
@@ -113,19 +123,36 @@ const (
 
 	// Background worker alerts (non-retryable LLM errors).
 	EventBackgroundError = "background.error"
+
+	// Owner alert: a user or agent is approaching a quota or budget limit.
+	EventQuotaWarning = "quota.warning"
+
+	// A run was rejected because a user or agent reached a token/cost cap.
+	EventBudgetExceeded = "budget.exceeded"
+
+	// Client → server binary attachment upload finished or was rejected.
+	EventAttachmentUploaded = "attachment.uploaded" // payload: {id, path, filename, mimeType, size}
+	EventAttachmentFailed   = "attachment.failed"   // payload: {id, error}
+
+	// A browser download finished (completed, canceled or failed).
+	// payload: {id, url, filename, path, size, state, error, userId, agentKey, sessionKey}
+	EventBrowserDownloadFinished = "browser.download.finished"
 )
 
 // Agent event subtypes (in payload.type)
 const (
-	AgentEventRunStarted   = "run.started"
-	AgentEventRunCompleted = "run.completed"
-	AgentEventRunFailed    = "run.failed"
-	AgentEventRunCancelled = "run.cancelled"
-	AgentEventRunRetrying  = "run.retrying"
-	AgentEventToolCall     = "tool.call"
-	AgentEventToolResult   = "tool.result"
-	AgentEventBlockReply   = "block.reply"
-	AgentEventActivity     = "activity" // agent phase transitions: thinking, tool_exec, compacting
+	AgentEventRunStarted      = "run.started"
+	AgentEventRunCompleted    = "run.completed"
+	AgentEventRunFailed       = "run.failed"
+	AgentEventRunCancelled    = "run.cancelled"
+	AgentEventRunRetrying     = "run.retrying"
+	AgentEventToolCall        = "tool.call"
+	AgentEventToolResult      = "tool.result"
+	AgentEventToolProgress    = "tool.progress" // incremental tool output: {name, id, message, skipped}
+	AgentEventBlockReply      = "block.reply"
+	AgentEventActivity        = "activity"         // agent phase transitions: thinking, tool_exec, compacting
+	AgentEventCompaction      = "compaction"       // context compaction report: {strategy, trigger, summarized, tokensBefore, tokensAfter, tokensRecovered, ...}
+	AgentEventMessageInjected = "message.injected" // a mid-run user message entered the loop before the next LLM call: {id, content}
 )
 
 // Chat event subtypes (in payload.type)
@@ -44,6 +44,7 @@ type ErrorShape struct {
 	Details      any    `json:"details,omitempty"`
 	Retryable    bool   `json:"retryable,omitempty"`
 	RetryAfterMs int    `json:"retryAfterMs,omitempty"`
+	RequestID    string `json:"requestId,omitempty"` // server correlation ID, for bug reports
 }
 
 // EventFrame is pushed from server to client without a preceding request.
@@ -34,12 +34,17 @@ const (
 	MethodConfigDefaults = "config.defaults"
 
 	// Sessions
-	MethodSessionsList    = "sessions.list"
-	MethodSessionsPreview = "sessions.preview"
-	MethodSessionsPatch   = "sessions.patch"
-	MethodSessionsDelete  = "sessions.delete"
-	MethodSessionsReset   = "sessions.reset"
-	MethodSessionsCompact = "sessions.compact"
+	MethodSessionsList        = "sessions.list"
+	MethodSessionsPreview     = "sessions.preview"
+	MethodSessionsPatch       = "sessions.patch"
+	MethodSessionsDelete      = "sessions.delete"
+	MethodSessionsReset       = "sessions.reset"
+	MethodSessionsCompact     = "sessions.compact"
+	MethodSessionsArchive     = "sessions.archive"
+	MethodSessionsExport      = "sessions.export"
+	MethodSessionsImport      = "sessions.import"
+	MethodSessionsCheckpoints = "sessions.checkpoints"
+	MethodSessionsRewind      = "sessions.rewind"
 
 	// System
 	MethodConnect = "connect"
@@ -96,6 +101,7 @@ const (
 	MethodHeartbeatChecklistGet = "heartbeat.checklist.get"
 	MethodHeartbeatChecklistSet = "heartbeat.checklist.set"
 	MethodHeartbeatTargets      = "heartbeat.targets"
+	MethodHeartbeatSnooze       = "heartbeat.snooze"
 )
 
 // Config permissions
@@ -165,6 +171,7 @@ const (
 	MethodAPIKeysList   = "api_keys.list"
 	MethodAPIKeysCreate = "api_keys.create"
 	MethodAPIKeysRevoke = "api_keys.revoke"
+	MethodAPIKeysRotate = "api_keys.rotate"
 )
 
 // Voices (ElevenLabs voice picker)
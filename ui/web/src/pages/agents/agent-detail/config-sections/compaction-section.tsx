@@ -1,8 +1,15 @@
 import { useTranslation } from "react-i18next";
 import { Input } from "@/components/ui/input";
 import { Switch } from "@/components/ui/switch";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import type { CompactionConfig } from "@/types/agent";
-import { InfoLabel, numOrUndef } from "./config-section";
+import { InfoLabel, arrayToTags, numOrUndef, tagsToArray } from "./config-section";
 
 interface CompactionSectionProps {
   value: CompactionConfig;
@@ -40,6 +47,38 @@ export function CompactionSection({ value, onChange }: CompactionSectionProps) {
           />
         </div>
       </div>
+      <div className="grid grid-cols-1 gap-4 sm:grid-cols-2">
+        <div className="space-y-2">
+          <InfoLabel tip={t(`${s}.strategyTip`)}>{t(`${s}.strategy`)}</InfoLabel>
+          <Select
+            value={value.strategy ?? ""}
+            onValueChange={(v) => onChange({ ...value, strategy: v as CompactionConfig["strategy"] })}
+          >
+            <SelectTrigger><SelectValue placeholder="summarize-oldest" /></SelectTrigger>
+            <SelectContent>
+              <SelectItem value="summarize-oldest">summarize-oldest</SelectItem>
+              <SelectItem value="drop-tool-results-first">drop-tool-results-first</SelectItem>
+              <SelectItem value="semantic-dedupe">semantic-dedupe</SelectItem>
+            </SelectContent>
+          </Select>
+        </div>
+        <div className="space-y-2">
+          <InfoLabel tip={t(`${s}.pinPatternsTip`)}>{t(`${s}.pinPatterns`)}</InfoLabel>
+          <Input
+            type="text"
+            placeholder="#pin"
+            defaultValue={arrayToTags(value.pinPatterns)}
+            onBlur={(e) => onChange({ ...value, pinPatterns: tagsToArray(e.target.value) })}
+          />
+        </div>
+      </div>
+      <div className="flex items-center gap-2">
+        <Switch
+          checked={value.pinFirstUserMessage ?? false}
+          onCheckedChange={(v) => onChange({ ...value, pinFirstUserMessage: v || undefined })}
+        />
+        <InfoLabel tip={t(`${s}.pinFirstUserMessageTip`)}>{t(`${s}.pinFirstUserMessage`)}</InfoLabel>
+      </div>
       <div className="flex items-center gap-2">
         <Switch
           checked={value.memoryFlush?.enabled ?? true}
@@ -33,6 +33,8 @@ const ALL_SCOPES = [
   "operator.approvals",
   "operator.pairing",
   "operator.provision",
+  "operator.chat",
+  "operator.observe",
 ] as const;
 
 const EXPIRY_OPTIONS = [
@@ -25,13 +25,17 @@ import { Label } from "@/components/ui/label";
 import { Switch } from "@/components/ui/switch";
 import { DialogHeader, DialogTitle, DialogDescription, DialogFooter } from "@/components/ui/dialog";
 
-type ProviderKey = "exa" | "tavily" | "brave" | "duckduckgo";
+type ProviderKey = "exa" | "tavily" | "brave" | "google" | "searxng" | "duckduckgo";
 
 interface ProviderEntry {
   id: string;
   name: ProviderKey;
   enabled: boolean;
   max_results?: number;
+  /** SearXNG instance URL */
+  base_url?: string;
+  /** Google Programmable Search Engine ID (cx) */
+  engine_id?: string;
   /** Staged API key value — extracted and saved to config_secrets on PUT, never stored in settings */
   apiKey?: string;
 }
@@ -44,23 +48,29 @@ interface Props {
   onCancel: () => void;
 }
 
-const SORTABLE_PROVIDERS: ProviderKey[] = ["exa", "tavily", "brave"];
+const SORTABLE_PROVIDERS: ProviderKey[] = ["exa", "tavily", "brave", "google", "searxng"];
 const LOCKED_PROVIDER: ProviderKey = "duckduckgo";
-const DEFAULT_ORDER: ProviderKey[] = ["exa", "tavily", "brave"];
+const DEFAULT_ORDER: ProviderKey[] = ["exa", "tavily", "brave", "google", "searxng"];
+/** Self-hosted SearXNG needs no API key. */
+const KEYLESS_PROVIDERS: ProviderKey[] = ["searxng"];
 
 const RAIL_COLOR: Record<ProviderKey, string> = {
   exa: "bg-blue-600",
   tavily: "bg-cyan-500",
   brave: "bg-orange-500",
+  google: "bg-green-600",
+  searxng: "bg-violet-500",
   duckduckgo: "bg-slate-500",
 };
 
 function parseInitialEntries(settings: Record<string, unknown>): ProviderEntry[] {
-  const rawOrder = Array.isArray(settings.provider_order)
+  const listed = Array.isArray(settings.provider_order)
     ? (settings.provider_order as string[]).filter((p): p is ProviderKey =>
         SORTABLE_PROVIDERS.includes(p as ProviderKey),
       )
     : DEFAULT_ORDER;
+  // Providers missing from a saved order (e.g. added in a later release) go last.
+  const rawOrder = [...listed, ...DEFAULT_ORDER.filter((p) => !listed.includes(p))];
 
   return rawOrder.map((name) => {
     const cfg = (settings[name] ?? {}) as Record<string, unknown>;
@@ -69,6 +79,8 @@ function parseInitialEntries(settings: Record<string, unknown>): ProviderEntry[]
       name,
       enabled: Boolean(cfg.enabled ?? true),
       max_results: cfg.max_results != null ? Number(cfg.max_results) : undefined,
+      base_url: typeof cfg.base_url === "string" ? cfg.base_url : undefined,
+      engine_id: typeof cfg.engine_id === "string" ? cfg.engine_id : undefined,
     };
   });
 }
@@ -140,46 +152,81 @@ function SortableProviderCard({ entry, index, secretsSet, onUpdate }: SortableCa
           />
         </div>
 
-        {/* API key row */}
-        <div className="flex items-center gap-1.5 mt-2 pl-10">
-          <Label className="text-xs text-muted-foreground whitespace-nowrap">
-            {t("builtin.searchChain.apiKey")}
-          </Label>
-          {keyIsSet && !showInput ? (
-            <div className="flex items-center gap-2">
-              <span className="text-xs text-green-600 dark:text-green-400 font-medium">
-                {t("builtin.searchChain.apiKeySet")}
-              </span>
-              <Button
-                variant="ghost"
-                size="sm"
-                className="h-6 px-2 text-xs"
-                onClick={() => setShowKeyInput(true)}
-              >
-                {t("builtin.searchChain.apiKeyChange")}
-              </Button>
-            </div>
-          ) : (
+        {entry.name === "searxng" && (
+          <div className="flex items-center gap-1.5 mt-2 pl-10">
+            <Label className="text-xs text-muted-foreground whitespace-nowrap">
+              {t("builtin.searchChain.baseUrl")}
+            </Label>
             <Input
-              type="password"
-              autoComplete="off"
-              placeholder={
-                keyIsSet
-                  ? t("builtin.searchChain.apiKeyReplacePlaceholder")
-                  : t("builtin.searchChain.apiKeyPlaceholder")
-              }
-              value={entry.apiKey ?? ""}
-              onChange={(e) => onUpdate(entry.id, { apiKey: e.target.value })}
+              placeholder="https://searx.example.com"
+              value={entry.base_url ?? ""}
+              onChange={(e) => onUpdate(entry.id, { base_url: e.target.value })}
               className="h-7 flex-1 text-base md:text-sm font-mono"
             />
-          )}
-        </div>
+          </div>
+        )}
+
+        {entry.name === "google" && (
+          <div className="flex items-center gap-1.5 mt-2 pl-10">
+            <Label className="text-xs text-muted-foreground whitespace-nowrap">
+              {t("builtin.searchChain.engineId")}
+            </Label>
+            <Input
+              value={entry.engine_id ?? ""}
+              onChange={(e) => onUpdate(entry.id, { engine_id: e.target.value })}
+              className="h-7 flex-1 text-base md:text-sm font-mono"
+            />
+          </div>
+        )}
+
+        {/* API key row */}
+        {!KEYLESS_PROVIDERS.includes(entry.name) && (
+          <div className="flex items-center gap-1.5 mt-2 pl-10">
+            <Label className="text-xs text-muted-foreground whitespace-nowrap">
+              {t("builtin.searchChain.apiKey")}
+            </Label>
+            {keyIsSet && !showInput ? (
+              <div className="flex items-center gap-2">
+                <span className="text-xs text-green-600 dark:text-green-400 font-medium">
+                  {t("builtin.searchChain.apiKeySet")}
+                </span>
+                <Button
+                  variant="ghost"
+                  size="sm"
+                  className="h-6 px-2 text-xs"
+                  onClick={() => setShowKeyInput(true)}
+                >
+                  {t("builtin.searchChain.apiKeyChange")}
+                </Button>
+              </div>
+            ) : (
+              <Input
+                type="password"
+                autoComplete="off"
+                placeholder={
+                  keyIsSet
+                    ? t("builtin.searchChain.apiKeyReplacePlaceholder")
+                    : t("builtin.searchChain.apiKeyPlaceholder")
+                }
+                value={entry.apiKey ?? ""}
+                onChange={(e) => onUpdate(entry.id, { apiKey: e.target.value })}
+                className="h-7 flex-1 text-base md:text-sm font-mono"
+              />
+            )}
+          </div>
+        )}
       </div>
     </div>
   );
 }
 
-function LockedDuckDuckGoCard({ settings }: { settings: Record<string, unknown> }) {
+function LockedDuckDuckGoCard({
+  settings,
+  position,
+}: {
+  settings: Record<string, unknown>;
+  position: number;
+}) {
   const { t } = useTranslation("tools");
   const cfg = (settings[LOCKED_PROVIDER] ?? {}) as Record<string, unknown>;
   const enabled = Boolean(cfg.enabled ?? true);
@@ -190,7 +237,7 @@ function LockedDuckDuckGoCard({ settings }: { settings: Record<string, unknown>
       <div className="flex-1 px-3 py-3">
         <div className="flex items-center gap-2">
           <Lock className="size-4 text-muted-foreground shrink-0" />
-          <span className="text-xs text-muted-foreground font-mono shrink-0">#4</span>
+          <span className="text-xs text-muted-foreground font-mono shrink-0">#{position}</span>
           <Switch size="sm" checked disabled />
           <span className="text-sm font-medium flex-1">
             {t("builtin.searchChain.providers.duckduckgo")}
@@ -207,6 +254,7 @@ export function WebSearchChainForm({ initialSettings, secretsSet, onSave, onCanc
   const [entries, setEntries] = useState<ProviderEntry[]>(() =>
     parseInitialEntries(initialSettings),
   );
+  const [multi, setMulti] = useState(initialSettings.mode === "multi");
   const [saving, setSaving] = useState(false);
 
   const sensors = useSensors(
@@ -233,10 +281,15 @@ export function WebSearchChainForm({ initialSettings, secretsSet, onSave, onCanc
     setSaving(true);
     try {
       const providerOrder = entries.map((e) => e.name);
-      const settings: Record<string, unknown> = { provider_order: providerOrder };
+      const settings: Record<string, unknown> = {
+        provider_order: providerOrder,
+        mode: multi ? "multi" : "fallback",
+      };
       for (const entry of entries) {
         const cfg: Record<string, unknown> = { enabled: entry.enabled };
         if (entry.max_results != null) cfg.max_results = entry.max_results;
+        if (entry.base_url?.trim()) cfg.base_url = entry.base_url.trim();
+        if (entry.engine_id?.trim()) cfg.engine_id = entry.engine_id.trim();
         // Include api_key only when user typed a new value — backend extracts and strips it
         if (entry.apiKey && entry.apiKey.trim() !== "") {
           cfg.api_key = entry.apiKey.trim();
@@ -259,6 +312,14 @@ export function WebSearchChainForm({ initialSettings, secretsSet, onSave, onCanc
         <DialogDescription>{t("builtin.searchChain.description")}</DialogDescription>
       </DialogHeader>
 
+      <div className="flex items-start gap-2 mt-4">
+        <Switch size="sm" checked={multi} onCheckedChange={setMulti} />
+        <div>
+          <Label className="text-sm">{t("builtin.searchChain.multiMode")}</Label>
+          <p className="text-xs text-muted-foreground">{t("builtin.searchChain.multiModeHint")}</p>
+        </div>
+      </div>
+
       <div className="space-y-2 max-h-[60vh] overflow-y-auto pr-1 my-4">
         <DndContext sensors={sensors} collisionDetection={closestCenter} onDragEnd={handleDragEnd}>
           <SortableContext items={entries.map((e) => e.id)} strategy={verticalListSortingStrategy}>
@@ -273,7 +334,7 @@ export function WebSearchChainForm({ initialSettings, secretsSet, onSave, onCanc
             ))}
           </SortableContext>
         </DndContext>
-        <LockedDuckDuckGoCard settings={initialSettings} />
+        <LockedDuckDuckGoCard settings={initialSettings} position={entries.length + 1} />
       </div>
 
       <DialogFooter>
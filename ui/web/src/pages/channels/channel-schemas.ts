@@ -120,6 +120,8 @@ export const configSchema: Record<string, FieldDef[]> = {
     { key: "group_stream", label: "Group Streaming", type: "boolean", defaultValue: false, help: "Stream response progressively in groups" },
     { key: "draft_transport", label: "Draft Preview", type: "boolean", defaultValue: true, help: "Use stealth draft preview for answer stream in DMs — no notification per edit (requires DM Streaming)" },
     { key: "reasoning_stream", label: "Show Reasoning", type: "boolean", defaultValue: true, help: "Display AI thinking as a separate message before the answer (requires streaming)" },
+    { key: "stream_throttle_ms", label: "Stream Edit Interval (ms)", type: "number", defaultValue: 1000, help: "Minimum delay between streaming edits (requires streaming)" },
+    { key: "stream_min_chars", label: "Stream Min Chars", type: "number", defaultValue: 0, help: "Hold back an edit until this many new characters arrived (0 = any change)" },
     { key: "reaction_level", label: "Reaction Level", type: "select", options: [{ value: "off", label: "Off" }, { value: "minimal", label: "Minimal" }, { value: "full", label: "Full" }], defaultValue: "full" },
     { key: "media_max_mb", label: "Max Media Size (MB)", type: "number", defaultValue: 20, help: "Default: 20 MB (cloud API). Increase when using local Bot API server." },
     { key: "link_preview", label: "Link Preview", type: "boolean", defaultValue: true },
@@ -159,6 +161,9 @@ export const configSchema: Record<string, FieldDef[]> = {
     { key: "topic_session_mode", label: "Topic Session Mode", type: "select", options: [{ value: "disabled", label: "Disabled" }, { value: "enabled", label: "Enabled" }], defaultValue: "disabled", help: "Use thread root_id for session isolation" },
     { key: "history_limit", label: "Group History Limit", type: "number", help: "Max pending group messages for context (0 = disabled)" },
     { key: "render_mode", label: "Render Mode", type: "select", options: [{ value: "auto", label: "Auto" }, { value: "raw", label: "Raw" }, { value: "card", label: "Card" }], defaultValue: "auto" },
+    { key: "streaming", label: "Streaming", type: "boolean", defaultValue: true, help: "Stream replies progressively into a card" },
+    { key: "stream_throttle_ms", label: "Stream Edit Interval (ms)", type: "number", defaultValue: 100, help: "Minimum delay between card updates (requires streaming)" },
+    { key: "stream_min_chars", label: "Stream Min Chars", type: "number", defaultValue: 0, help: "Hold back an update until this many new characters arrived (0 = any change)" },
     { key: "text_chunk_limit", label: "Text Chunk Limit", type: "number", defaultValue: 4000, help: "Max characters per message" },
     { key: "media_max_mb", label: "Max Media Size (MB)", type: "number", defaultValue: 30, help: "Max inbound media download size" },
     { key: "reaction_level", label: "Reaction Level", type: "select", options: [{ value: "off", label: "Off" }, { value: "minimal", label: "Minimal" }, { value: "full", label: "Full" }], defaultValue: "off", help: "Typing emoji reaction on user messages while bot is processing" },
@@ -5,7 +5,7 @@ export interface VerifyResult {
   valid: boolean;
   error?: string;
   dimensions?: number;
-  dimension_mismatch?: boolean; // true when output dims != 1536
+  dimension_mismatch?: boolean; // true when output dims exceed the vector column size
 }
 
 export function useProviderVerify() {
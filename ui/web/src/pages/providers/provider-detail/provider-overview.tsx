@@ -196,7 +196,7 @@ export function ProviderOverview({ provider, onUpdate }: ProviderOverviewProps)
       if (submittedAPIKey) data.api_key = submittedAPIKey;
       let nextSettings = { ...((provider.settings || {}) as Record<string, unknown>) };
       if (showEmbedding) {
-        nextSettings = { ...nextSettings, embedding: embEnabled ? { enabled: true, model: embModel.trim() || undefined, api_base: embApiBase.trim() || undefined } : { enabled: false } };
+        nextSettings = { ...nextSettings, embedding: embEnabled ? { ...initEmb, enabled: true, model: embModel.trim() || undefined, api_base: embApiBase.trim() || undefined } : { enabled: false } };
       }
       if (isOAuth) nextSettings = buildProviderSettingsWithChatGPTOAuthRouting(nextSettings, poolRouting);
       nextSettings = buildProviderSettingsWithReasoningDefaults(nextSettings, showReasoningDefaults ? { effort: reasoningExpert ? reasoningEffort : reasoningThinkingLevel, fallback: reasoningExpert ? reasoningFallback : "downgrade" } : null);
@@ -28,6 +28,9 @@ export interface CompactionConfig {
     enabled?: boolean;
     softThresholdTokens?: number;
   };
+  strategy?: "summarize-oldest" | "drop-tool-results-first" | "semantic-dedupe";
+  pinFirstUserMessage?: boolean;
+  pinPatterns?: string[];
 }
 
 export interface ContextPruningConfig {
@@ -70,6 +73,8 @@ export interface MemoryConfig {
   text_weight?: number;
   min_score?: number;
   dreaming?: DreamingConfig | null;
+  transcripts?: TranscriptMemoryConfig | null;
+  rerank?: RerankConfig | null;
 }
 
 /**
@@ -85,6 +90,27 @@ export interface DreamingConfig {
   verbose_log?: boolean;
 }
 
+/**
+ * TranscriptMemoryConfig mirrors Go internal/config.TranscriptMemoryConfig —
+ * opt-in ingestion of completed session summaries into memory documents.
+ */
+export interface TranscriptMemoryConfig {
+  enabled?: boolean;
+  retention_days?: number;
+  scrub_pii?: boolean;
+  scrub_patterns?: string[];
+}
+
+/** RerankConfig mirrors Go internal/config.RerankConfig — memory_search rerank stage. */
+export interface RerankConfig {
+  enabled?: boolean;
+  mode?: "llm" | "cross_encoder";
+  provider?: string;
+  model?: string;
+  api_base?: string;
+  top_k?: number;
+}
+
 export interface WorkspaceSharingConfig {
   shared_dm?: boolean;
   shared_group?: boolean;
@@ -51,7 +51,9 @@ export interface EmbeddingSettings {
   enabled: boolean;
   model?: string;
   api_base?: string;
-  dimensions?: number; // truncate output to N dims (e.g. 1536); 0/undefined = model default
+  dimensions?: number; // requested output dims (≤ 1536, smaller is zero-padded); 0/undefined = model default
+  format?: "openai" | "voyage" | "cohere" | "ollama"; // undefined = detect from API base
+  batch_size?: number; // max texts per request; 0/undefined = format default
 }
 
 export interface NormalizedChatGPTOAuthProviderRouting {